@@ -33,7 +33,10 @@ func main() {
 	defer dbManager.Close()
 
 	// Create server
-	srv := server.NewServer(config, dbManager)
+	srv, err := server.NewServer(config, dbManager)
+	if err != nil {
+		log.Fatalf("Failed to create server: %v", err)
+	}
 
 	// Start server
 	addr := fmt.Sprintf("%s:%s", config.Host, config.Port)
@@ -0,0 +1,154 @@
+package entities
+
+import (
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// BoxOfficeShiftStatus represents the state of a cash drawer session
+type BoxOfficeShiftStatus string
+
+const (
+	BoxOfficeShiftOpen   BoxOfficeShiftStatus = "open"
+	BoxOfficeShiftClosed BoxOfficeShiftStatus = "closed"
+)
+
+// BoxOfficeShift represents one operator's cash drawer session at an event
+type BoxOfficeShift struct {
+	ID           uuid.UUID            `json:"id" db:"id"`
+	EventID      uuid.UUID            `json:"event_id" db:"event_id"`
+	OperatorID   uuid.UUID            `json:"operator_id" db:"operator_id"`
+	Currency     string               `json:"currency" db:"currency"`
+	Status       BoxOfficeShiftStatus `json:"status" db:"status"`
+	OpeningFloat float64              `json:"opening_float" db:"opening_float"`
+	CountedCash  *float64             `json:"counted_cash,omitempty" db:"counted_cash"`
+	ExpectedCash *float64             `json:"expected_cash,omitempty" db:"expected_cash"`
+	CashVariance *float64             `json:"cash_variance,omitempty" db:"cash_variance"`
+	Notes        *string              `json:"notes,omitempty" db:"notes"`
+	OpenedAt     time.Time            `json:"opened_at" db:"opened_at"`
+	ClosedAt     *time.Time           `json:"closed_at,omitempty" db:"closed_at"`
+	ClosedBy     *uuid.UUID           `json:"closed_by,omitempty" db:"closed_by"`
+	CreatedAt    time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time            `json:"updated_at" db:"updated_at"`
+}
+
+// NewBoxOfficeShift opens a new cash drawer session
+func NewBoxOfficeShift(eventID, operatorID uuid.UUID, currency string, openingFloat float64) *BoxOfficeShift {
+	now := time.Now()
+	return &BoxOfficeShift{
+		ID:           uuid.New(),
+		EventID:      eventID,
+		OperatorID:   operatorID,
+		Currency:     currency,
+		Status:       BoxOfficeShiftOpen,
+		OpeningFloat: openingFloat,
+		OpenedAt:     now,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// Validate performs business rule validation for the shift
+func (s *BoxOfficeShift) Validate() error {
+	if s.EventID == uuid.Nil {
+		return NewValidationError("event_id", "event is required")
+	}
+	if s.OperatorID == uuid.Nil {
+		return NewValidationError("operator_id", "operator is required")
+	}
+	if s.OpeningFloat < 0 {
+		return NewValidationError("opening_float", "opening float cannot be negative")
+	}
+	return nil
+}
+
+// IsOpen checks if the shift can still take sales
+func (s *BoxOfficeShift) IsOpen() bool {
+	return s.Status == BoxOfficeShiftOpen
+}
+
+// Close closes the drawer, recording the counted cash against what the system expects
+func (s *BoxOfficeShift) Close(cashSales, countedCash float64, closedBy uuid.UUID, notes *string) error {
+	if !s.IsOpen() {
+		return NewBusinessRuleError("shift_closed", "shift is already closed", nil)
+	}
+	if countedCash < 0 {
+		return NewValidationError("counted_cash", "counted cash cannot be negative")
+	}
+
+	expected := roundMoney(s.OpeningFloat + cashSales)
+	variance := roundMoney(countedCash - expected)
+	now := time.Now()
+
+	s.Status = BoxOfficeShiftClosed
+	s.CountedCash = &countedCash
+	s.ExpectedCash = &expected
+	s.CashVariance = &variance
+	s.ClosedAt = &now
+	s.ClosedBy = &closedBy
+	if notes != nil {
+		s.Notes = notes
+	}
+	s.UpdatedAt = now
+	return nil
+}
+
+// BoxOfficeSale links a walk-in order to the shift that took payment for it
+type BoxOfficeSale struct {
+	ID                uuid.UUID     `json:"id" db:"id"`
+	ShiftID           uuid.UUID     `json:"shift_id" db:"shift_id"`
+	OrderID           uuid.UUID     `json:"order_id" db:"order_id"`
+	OperatorID        uuid.UUID     `json:"operator_id" db:"operator_id"`
+	PaymentMethod     PaymentMethod `json:"payment_method" db:"payment_method"`
+	Amount            float64       `json:"amount" db:"amount"`
+	AmountTendered    *float64      `json:"amount_tendered,omitempty" db:"amount_tendered"`
+	ChangeGiven       *float64      `json:"change_given,omitempty" db:"change_given"`
+	TerminalReference *string       `json:"terminal_reference,omitempty" db:"terminal_reference"`
+	TicketCount       int           `json:"ticket_count" db:"ticket_count"`
+	CreatedAt         time.Time     `json:"created_at" db:"created_at"`
+}
+
+// NewBoxOfficeSale records a box office sale against a shift
+func NewBoxOfficeSale(shift *BoxOfficeShift, orderID uuid.UUID, method PaymentMethod, amount float64) *BoxOfficeSale {
+	return &BoxOfficeSale{
+		ID:            uuid.New(),
+		ShiftID:       shift.ID,
+		OrderID:       orderID,
+		OperatorID:    shift.OperatorID,
+		PaymentMethod: method,
+		Amount:        amount,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// SetCashTendered records the cash handed over and the change due
+func (s *BoxOfficeSale) SetCashTendered(tendered float64) error {
+	if tendered < s.Amount {
+		return NewValidationError("amount_tendered", "amount tendered is less than the order total")
+	}
+	change := roundMoney(tendered - s.Amount)
+	s.AmountTendered = &tendered
+	s.ChangeGiven = &change
+	return nil
+}
+
+// Validate performs business rule validation for the sale
+func (s *BoxOfficeSale) Validate() error {
+	if !s.PaymentMethod.IsOffline() {
+		return NewValidationError("payment_method", "box office sales must be paid by cash or POS terminal")
+	}
+	if s.Amount < 0 {
+		return NewValidationError("amount", "amount cannot be negative")
+	}
+	if s.PaymentMethod == PaymentMethodPOS && (s.TerminalReference == nil || *s.TerminalReference == "") {
+		return NewValidationError("terminal_reference", "terminal reference is required for POS terminal payments")
+	}
+	return nil
+}
+
+// roundMoney rounds an amount to two decimal places
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
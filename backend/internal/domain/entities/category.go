@@ -0,0 +1,220 @@
+package entities
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// MaxCategoryDepth is how deep categories may nest: a category, its
+// subcategories and theirs
+const MaxCategoryDepth = 3
+
+var (
+	categorySlugPattern  = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+	categoryColorPattern = regexp.MustCompile(`^#([0-9a-fA-F]{3}|[0-9a-fA-F]{6})$`)
+	nonSlugCharacters    = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// Category groups events for browsing. Categories nest through ParentID and
+// are ordered among their siblings by DisplayOrder.
+type Category struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty" db:"parent_id"`
+	Name         string     `json:"name" db:"name"`
+	Slug         string     `json:"slug" db:"slug"`
+	Description  *string    `json:"description,omitempty" db:"description"`
+	Icon         *string    `json:"icon,omitempty" db:"icon"`
+	Color        *string    `json:"color,omitempty" db:"color"`
+	DisplayOrder int        `json:"display_order" db:"display_order"`
+	IsActive     bool       `json:"is_active" db:"is_active"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+
+	// EventCount is the number of listed events in the category itself; it
+	// is only loaded by category listings
+	EventCount int `json:"event_count" db:"event_count"`
+
+	// Tree, filled by BuildCategoryTree
+	TotalEventCount int         `json:"total_event_count" db:"-"`
+	Subcategories   []*Category `json:"subcategories,omitempty" db:"-"`
+}
+
+// NewCategory creates a new active category, deriving the slug from the name when none is given
+func NewCategory(name, slug string) *Category {
+	name = strings.TrimSpace(name)
+	slug = strings.TrimSpace(slug)
+	if slug == "" {
+		slug = CategorySlug(name)
+	}
+
+	now := time.Now()
+	return &Category{
+		ID:        uuid.New(),
+		Name:      name,
+		Slug:      slug,
+		IsActive:  true,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// CategorySlug turns a category name into a URL slug, e.g. "Arts & Theater" into "arts-theater"
+func CategorySlug(name string) string {
+	return strings.Trim(nonSlugCharacters.ReplaceAllString(strings.ToLower(name), "-"), "-")
+}
+
+// Validate performs business rule validation for the category
+func (c *Category) Validate() error {
+	if c.Name == "" {
+		return NewValidationError("name", "category name is required")
+	}
+	if len(c.Name) > 100 {
+		return NewValidationError("name", "category name must be 100 characters or less")
+	}
+	if c.Slug == "" {
+		return NewValidationError("slug", "category slug is required")
+	}
+	if len(c.Slug) > 100 {
+		return NewValidationError("slug", "category slug must be 100 characters or less")
+	}
+	if !categorySlugPattern.MatchString(c.Slug) {
+		return NewValidationError("slug", "slug may only contain lowercase letters, digits and single hyphens")
+	}
+	if c.Icon != nil && utf8.RuneCountInString(*c.Icon) > 50 {
+		return NewValidationError("icon", "icon must be 50 characters or less")
+	}
+	if c.Color != nil && !categoryColorPattern.MatchString(*c.Color) {
+		return NewValidationError("color", "color must be a hex color such as #FF6B6B")
+	}
+	if c.DisplayOrder < 0 {
+		return NewValidationError("display_order", "display order must be non-negative")
+	}
+	if c.ParentID != nil && *c.ParentID == c.ID {
+		return NewValidationError("parent_id", "a category cannot be its own parent")
+	}
+	return nil
+}
+
+// SetParent moves the category under parentID, or to the top level when nil
+func (c *Category) SetParent(parentID *uuid.UUID) {
+	c.ParentID = parentID
+	c.UpdatedAt = time.Now()
+}
+
+// ValidateCategoryParent checks that moving the category id under parentID
+// keeps the categories a tree: the parent must exist, must not be the category
+// or one of its subcategories, and the move must stay within MaxCategoryDepth
+func ValidateCategoryParent(categories []*Category, id uuid.UUID, parentID *uuid.UUID) error {
+	if parentID == nil {
+		return nil
+	}
+
+	byID := make(map[uuid.UUID]*Category, len(categories))
+	children := make(map[uuid.UUID][]uuid.UUID)
+	for _, category := range categories {
+		byID[category.ID] = category
+		if category.ParentID != nil {
+			children[*category.ParentID] = append(children[*category.ParentID], category.ID)
+		}
+	}
+
+	if _, ok := byID[*parentID]; !ok {
+		return NewValidationError("parent_id", "parent category does not exist")
+	}
+
+	// Depth of the new parent, counting the top level as 1
+	parentDepth := 0
+	for current := parentID; current != nil; {
+		if *current == id {
+			return NewValidationError("parent_id", "a category cannot be moved under its own subcategory")
+		}
+		parentDepth++
+		if parentDepth > len(categories) {
+			break
+		}
+		parent, ok := byID[*current]
+		if !ok {
+			break
+		}
+		current = parent.ParentID
+	}
+
+	// Levels the moved category brings with it, itself included
+	var height func(uuid.UUID, int) int
+	height = func(categoryID uuid.UUID, guard int) int {
+		if guard > len(categories) {
+			return guard
+		}
+		deepest := 0
+		for _, child := range children[categoryID] {
+			if h := height(child, guard+1); h > deepest {
+				deepest = h
+			}
+		}
+		return deepest + 1
+	}
+
+	if parentDepth+height(id, 0) > MaxCategoryDepth {
+		return NewValidationError("parent_id", fmt.Sprintf("categories can nest at most %d levels deep", MaxCategoryDepth))
+	}
+	return nil
+}
+
+// BuildCategoryTree nests categories under their parents and returns the top
+// level, each level ordered by display order then name. A category whose
+// parent is not in the list is left out with its subcategories, so a hidden
+// parent hides its branch. TotalEventCount sums each branch's event counts.
+func BuildCategoryTree(categories []*Category) []*Category {
+	byID := make(map[uuid.UUID]*Category, len(categories))
+	for _, category := range categories {
+		category.Subcategories = nil
+		byID[category.ID] = category
+	}
+
+	var roots []*Category
+	for _, category := range categories {
+		if category.ParentID == nil {
+			roots = append(roots, category)
+			continue
+		}
+		if parent, ok := byID[*category.ParentID]; ok {
+			parent.Subcategories = append(parent.Subcategories, category)
+		}
+	}
+
+	var finish func([]*Category, int)
+	finish = func(level []*Category, depth int) {
+		sortCategories(level)
+		for _, category := range level {
+			category.TotalEventCount = category.EventCount
+			if depth >= len(categories) {
+				continue
+			}
+			finish(category.Subcategories, depth+1)
+			for _, sub := range category.Subcategories {
+				category.TotalEventCount += sub.TotalEventCount
+			}
+		}
+	}
+	finish(roots, 0)
+
+	if roots == nil {
+		roots = []*Category{}
+	}
+	return roots
+}
+
+func sortCategories(categories []*Category) {
+	sort.SliceStable(categories, func(i, j int) bool {
+		if categories[i].DisplayOrder != categories[j].DisplayOrder {
+			return categories[i].DisplayOrder < categories[j].DisplayOrder
+		}
+		return strings.ToLower(categories[i].Name) < strings.ToLower(categories[j].Name)
+	})
+}
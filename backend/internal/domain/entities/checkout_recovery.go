@@ -0,0 +1,202 @@
+package entities
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CheckoutRecoveryReason is why a checkout was abandoned
+type CheckoutRecoveryReason string
+
+const (
+	// CheckoutOrderExpired orders ran out of time before being paid
+	CheckoutOrderExpired CheckoutRecoveryReason = "order_expired"
+	// CheckoutPaymentFailed orders could not start a payment with the provider
+	CheckoutPaymentFailed CheckoutRecoveryReason = "payment_failed"
+)
+
+// CheckoutRecoveryStatus represents where a recovery message is in its delivery
+type CheckoutRecoveryStatus string
+
+const (
+	CheckoutRecoveryPending CheckoutRecoveryStatus = "pending"
+	CheckoutRecoverySent    CheckoutRecoveryStatus = "sent"
+	CheckoutRecoverySkipped CheckoutRecoveryStatus = "skipped"
+	CheckoutRecoveryFailed  CheckoutRecoveryStatus = "failed"
+)
+
+// Reasons a recovery message is not sent
+const (
+	CheckoutSkipPurchased   = "purchased"
+	CheckoutSkipUnavailable = "unavailable"
+	CheckoutSkipNoContact   = "no_contact"
+)
+
+// CheckoutRecovery is the message sent to a customer who left an order
+// unpaid, with a link that places the same order again. It records whether
+// the link was used and whether the new order was paid, so recovered
+// revenue can be measured.
+type CheckoutRecovery struct {
+	ID               uuid.UUID              `json:"id" db:"id"`
+	OrderID          uuid.UUID              `json:"order_id" db:"order_id"`
+	EventID          uuid.UUID              `json:"event_id" db:"event_id"`
+	UserID           *uuid.UUID             `json:"user_id,omitempty" db:"user_id"`
+	ContactKey       string                 `json:"-" db:"contact_key"`
+	Name             *string                `json:"name,omitempty" db:"name"`
+	Email            *string                `json:"email,omitempty" db:"email"`
+	Phone            *string                `json:"phone,omitempty" db:"phone"`
+	Reason           CheckoutRecoveryReason `json:"reason" db:"reason"`
+	Amount           float64                `json:"amount" db:"amount"`
+	Currency         string                 `json:"currency" db:"currency"`
+	Status           CheckoutRecoveryStatus `json:"status" db:"status"`
+	SkipReason       *string                `json:"skip_reason,omitempty" db:"skip_reason"`
+	Channel          *NotificationChannel   `json:"channel,omitempty" db:"channel"`
+	Token            string                 `json:"-" db:"token"`
+	SendAt           time.Time              `json:"send_at" db:"send_at"`
+	Attempts         int                    `json:"attempts" db:"attempts"`
+	LastError        *string                `json:"last_error,omitempty" db:"last_error"`
+	SentAt           *time.Time             `json:"sent_at,omitempty" db:"sent_at"`
+	ClickedAt        *time.Time             `json:"clicked_at,omitempty" db:"clicked_at"`
+	RecoveredOrderID *uuid.UUID             `json:"recovered_order_id,omitempty" db:"recovered_order_id"`
+	ConvertedAt      *time.Time             `json:"converted_at,omitempty" db:"converted_at"`
+	RecoveredAmount  float64                `json:"recovered_amount" db:"recovered_amount"`
+	CreatedAt        time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at" db:"updated_at"`
+}
+
+// NewCheckoutRecovery creates a recovery for an abandoned order, to be sent after delay
+func NewCheckoutRecovery(order *Order, reason CheckoutRecoveryReason, delay time.Duration) *CheckoutRecovery {
+	now := time.Now()
+	recovery := &CheckoutRecovery{
+		ID:        uuid.New(),
+		OrderID:   order.ID,
+		EventID:   orderEventID(order),
+		UserID:    order.UserID,
+		Reason:    reason,
+		Amount:    order.TotalAmount,
+		Currency:  NormalizeCurrency(order.Currency),
+		Status:    CheckoutRecoveryPending,
+		Token:     generateRecoveryToken(),
+		SendAt:    now.Add(delay),
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+
+	if recovery.Currency == "" {
+		recovery.Currency = DefaultCurrency
+	}
+	if name := strings.TrimSpace(order.CustomerFirstName + " " + order.CustomerLastName); name != "" {
+		recovery.Name = &name
+	}
+	recovery.Email = firstNonEmpty(order.CustomerEmail, order.Email)
+	if order.Phone != nil {
+		recovery.Phone = firstNonEmpty(order.CustomerPhone, *order.Phone)
+	} else {
+		recovery.Phone = firstNonEmpty(order.CustomerPhone)
+	}
+
+	switch {
+	case order.UserID != nil:
+		recovery.ContactKey = UserContactKey(*order.UserID)
+	case recovery.Email != nil:
+		recovery.ContactKey = "email:" + strings.ToLower(*recovery.Email)
+	case recovery.Phone != nil:
+		recovery.ContactKey = "phone:" + *recovery.Phone
+	}
+	return recovery
+}
+
+// FirstName returns the first word of the customer's name, for greetings
+func (r *CheckoutRecovery) FirstName() string {
+	if r.Name == nil {
+		return ""
+	}
+	parts := strings.Fields(*r.Name)
+	if len(parts) == 0 {
+		return ""
+	}
+	return parts[0]
+}
+
+// MarkSent records that the message was handed to a channel
+func (r *CheckoutRecovery) MarkSent(channel NotificationChannel) {
+	now := time.Now()
+	r.Channel = &channel
+	r.Status = CheckoutRecoverySent
+	r.LastError = nil
+	r.SentAt = &now
+	r.UpdatedAt = now
+}
+
+// Skip closes the recovery without sending anything
+func (r *CheckoutRecovery) Skip(reason string) {
+	r.Status = CheckoutRecoverySkipped
+	r.SkipReason = &reason
+	r.UpdatedAt = time.Now()
+}
+
+// Fail closes the recovery after an error that retrying cannot fix
+func (r *CheckoutRecovery) Fail(channel NotificationChannel, message string) {
+	r.Attempts++
+	r.Channel = &channel
+	r.Status = CheckoutRecoveryFailed
+	r.LastError = &message
+	r.UpdatedAt = time.Now()
+}
+
+// RecordAttemptError records a failed send and when to try again; once the
+// attempts are used up the recovery is marked failed
+func (r *CheckoutRecovery) RecordAttemptError(channel NotificationChannel, message string, retryAt time.Time) {
+	r.Attempts++
+	r.Channel = &channel
+	r.LastError = &message
+	r.SendAt = retryAt
+	r.UpdatedAt = time.Now()
+	if r.Attempts >= MaxRecipientAttempts {
+		r.Status = CheckoutRecoveryFailed
+	}
+}
+
+// MarkClicked records that the link placed a new order. The latest order
+// placed from the link is the one a conversion is credited to.
+func (r *CheckoutRecovery) MarkClicked(orderID uuid.UUID) {
+	now := time.Now()
+	if r.ClickedAt == nil {
+		r.ClickedAt = &now
+	}
+	r.RecoveredOrderID = &orderID
+	r.UpdatedAt = now
+}
+
+// MarkConverted records that the order placed from the link was paid
+func (r *CheckoutRecovery) MarkConverted(amount float64) {
+	now := time.Now()
+	r.ConvertedAt = &now
+	r.RecoveredAmount = amount
+	r.UpdatedAt = now
+}
+
+// IsConverted reports whether the recovery led to a paid order
+func (r *CheckoutRecovery) IsConverted() bool {
+	return r.ConvertedAt != nil
+}
+
+func generateRecoveryToken() string {
+	bytes := make([]byte, 24)
+	rand.Read(bytes)
+	return hex.EncodeToString(bytes)
+}
+
+// firstNonEmpty returns the first value that is not blank
+func firstNonEmpty(values ...string) *string {
+	for _, value := range values {
+		if trimmed := strings.TrimSpace(value); trimmed != "" {
+			return &trimmed
+		}
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CompAllocation is the number of complimentary tickets that may be issued from a ticket tier
+type CompAllocation struct {
+	ID           uuid.UUID  `json:"id" db:"id"`
+	EventID      uuid.UUID  `json:"event_id" db:"event_id"`
+	TicketTierID uuid.UUID  `json:"ticket_tier_id" db:"ticket_tier_id"`
+	Quota        int        `json:"quota" db:"quota"`
+	Issued       int        `json:"issued" db:"issued"`
+	CreatedBy    *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// NewCompAllocation creates a comp quota for a ticket tier
+func NewCompAllocation(eventID, ticketTierID uuid.UUID, quota int) *CompAllocation {
+	now := time.Now()
+	return &CompAllocation{
+		ID:           uuid.New(),
+		EventID:      eventID,
+		TicketTierID: ticketTierID,
+		Quota:        quota,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// Validate performs business rule validation for the allocation
+func (a *CompAllocation) Validate() error {
+	if a.EventID == uuid.Nil {
+		return NewValidationError("event_id", "event is required")
+	}
+	if a.TicketTierID == uuid.Nil {
+		return NewValidationError("ticket_tier_id", "ticket tier is required")
+	}
+	if a.Quota < 0 {
+		return NewValidationError("quota", "quota cannot be negative")
+	}
+	if a.Quota < a.Issued {
+		return NewValidationError("quota", "quota cannot be lower than the number of comps already issued")
+	}
+	return nil
+}
+
+// Remaining returns how many comps can still be issued
+func (a *CompAllocation) Remaining() int {
+	if a.Issued >= a.Quota {
+		return 0
+	}
+	return a.Quota - a.Issued
+}
+
+// GuestListCategory classifies who a comp was issued to
+type GuestListCategory string
+
+const (
+	GuestListCategoryArtist  GuestListCategory = "artist"
+	GuestListCategorySponsor GuestListCategory = "sponsor"
+	GuestListCategoryPress   GuestListCategory = "press"
+	GuestListCategoryStaff   GuestListCategory = "staff"
+	GuestListCategoryGuest   GuestListCategory = "guest"
+)
+
+// IsValid checks if the category is one of the known categories
+func (c GuestListCategory) IsValid() bool {
+	switch c {
+	case GuestListCategoryArtist, GuestListCategorySponsor, GuestListCategoryPress,
+		GuestListCategoryStaff, GuestListCategoryGuest:
+		return true
+	}
+	return false
+}
+
+// GuestListStatus represents the state of a guest list entry
+type GuestListStatus string
+
+const (
+	GuestListStatusIssued    GuestListStatus = "issued"
+	GuestListStatusCheckedIn GuestListStatus = "checked_in"
+	GuestListStatusCancelled GuestListStatus = "cancelled"
+)
+
+// GuestListEntry is a named comp. Its tickets can be scanned as normal, or the
+// guest can be found by name and admitted at the door without a QR code.
+type GuestListEntry struct {
+	ID           uuid.UUID         `json:"id" db:"id"`
+	EventID      uuid.UUID         `json:"event_id" db:"event_id"`
+	TicketTierID uuid.UUID         `json:"ticket_tier_id" db:"ticket_tier_id"`
+	AllocationID uuid.UUID         `json:"allocation_id" db:"allocation_id"`
+	OrderID      *uuid.UUID        `json:"order_id,omitempty" db:"order_id"`
+	FirstName    string            `json:"first_name" db:"first_name"`
+	LastName     string            `json:"last_name" db:"last_name"`
+	Email        *string           `json:"email,omitempty" db:"email"`
+	Phone        *string           `json:"phone,omitempty" db:"phone"`
+	Category     GuestListCategory `json:"category" db:"category"`
+	PlusOnes     int               `json:"plus_ones" db:"plus_ones"`
+	Notes        *string           `json:"notes,omitempty" db:"notes"`
+	Status       GuestListStatus   `json:"status" db:"status"`
+	IssuedBy     *uuid.UUID        `json:"issued_by,omitempty" db:"issued_by"`
+	CheckedInAt  *time.Time        `json:"checked_in_at,omitempty" db:"checked_in_at"`
+	CheckedInBy  *string           `json:"checked_in_by,omitempty" db:"checked_in_by"`
+	CreatedAt    time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// NewGuestListEntry creates a named comp against an allocation
+func NewGuestListEntry(allocation *CompAllocation, firstName, lastName string) *GuestListEntry {
+	now := time.Now()
+	return &GuestListEntry{
+		ID:           uuid.New(),
+		EventID:      allocation.EventID,
+		TicketTierID: allocation.TicketTierID,
+		AllocationID: allocation.ID,
+		FirstName:    strings.TrimSpace(firstName),
+		LastName:     strings.TrimSpace(lastName),
+		Category:     GuestListCategoryGuest,
+		Status:       GuestListStatusIssued,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// Validate performs business rule validation for the entry
+func (e *GuestListEntry) Validate() error {
+	if e.FirstName == "" {
+		return NewValidationError("first_name", "first name is required")
+	}
+	if e.LastName == "" {
+		return NewValidationError("last_name", "last name is required")
+	}
+	if !e.Category.IsValid() {
+		return NewValidationError("category", "invalid guest list category")
+	}
+	if e.PlusOnes < 0 {
+		return NewValidationError("plus_ones", "plus ones cannot be negative")
+	}
+	return nil
+}
+
+// Admissions returns the number of people admitted on this entry
+func (e *GuestListEntry) Admissions() int {
+	return 1 + e.PlusOnes
+}
+
+// FullName returns the guest's display name
+func (e *GuestListEntry) FullName() string {
+	return e.FirstName + " " + e.LastName
+}
+
+// CheckIn admits the guest at the door
+func (e *GuestListEntry) CheckIn(checkedInBy string) error {
+	switch e.Status {
+	case GuestListStatusCheckedIn:
+		return NewBusinessRuleError("already_checked_in", "guest has already been checked in", map[string]interface{}{
+			"checked_in_at": e.CheckedInAt,
+		})
+	case GuestListStatusCancelled:
+		return NewBusinessRuleError("comp_cancelled", "guest list entry has been cancelled", nil)
+	}
+
+	now := time.Now()
+	e.Status = GuestListStatusCheckedIn
+	e.CheckedInAt = &now
+	e.CheckedInBy = &checkedInBy
+	e.UpdatedAt = now
+	return nil
+}
+
+// Cancel withdraws the comp
+func (e *GuestListEntry) Cancel() error {
+	switch e.Status {
+	case GuestListStatusCheckedIn:
+		return NewBusinessRuleError("already_checked_in", "a checked-in guest cannot be cancelled", nil)
+	case GuestListStatusCancelled:
+		return NewBusinessRuleError("comp_cancelled", "guest list entry is already cancelled", nil)
+	}
+
+	e.Status = GuestListStatusCancelled
+	e.UpdatedAt = time.Now()
+	return nil
+}
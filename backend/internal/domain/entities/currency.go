@@ -0,0 +1,111 @@
+package entities
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// DefaultCurrency is the currency used when an event does not specify one
+const DefaultCurrency = "NGN"
+
+// Supported settlement currencies
+const (
+	CurrencyNGN = "NGN"
+	CurrencyGHS = "GHS"
+	CurrencyKES = "KES"
+	CurrencyUSD = "USD"
+)
+
+// currencyMinorUnits maps each supported currency to its number of minor units
+// (kobo, pesewas, cents) per major unit
+var currencyMinorUnits = map[string]int64{
+	CurrencyNGN: 100,
+	CurrencyGHS: 100,
+	CurrencyKES: 100,
+	CurrencyUSD: 100,
+}
+
+// SupportedCurrencies returns the currencies events can be priced in
+func SupportedCurrencies() []string {
+	return []string{CurrencyNGN, CurrencyGHS, CurrencyKES, CurrencyUSD}
+}
+
+// NormalizeCurrency upper-cases and trims a currency code
+func NormalizeCurrency(currency string) string {
+	return strings.ToUpper(strings.TrimSpace(currency))
+}
+
+// IsSupportedCurrency checks if the currency can be used for pricing
+func IsSupportedCurrency(currency string) bool {
+	_, ok := currencyMinorUnits[NormalizeCurrency(currency)]
+	return ok
+}
+
+// ToMinorUnits converts a major-unit amount to the currency's smallest unit
+func ToMinorUnits(amount float64, currency string) int64 {
+	factor, ok := currencyMinorUnits[NormalizeCurrency(currency)]
+	if !ok {
+		factor = 100
+	}
+	return int64(math.Round(amount * float64(factor)))
+}
+
+// FromMinorUnits converts an amount in the currency's smallest unit to major units
+func FromMinorUnits(amount int64, currency string) float64 {
+	factor, ok := currencyMinorUnits[NormalizeCurrency(currency)]
+	if !ok {
+		factor = 100
+	}
+	return float64(amount) / float64(factor)
+}
+
+// FXRate represents an admin-maintained exchange rate used for reporting conversion.
+// One unit of BaseCurrency equals Rate units of QuoteCurrency.
+type FXRate struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	BaseCurrency  string     `json:"base_currency" db:"base_currency"`
+	QuoteCurrency string     `json:"quote_currency" db:"quote_currency"`
+	Rate          float64    `json:"rate" db:"rate"`
+	EffectiveAt   time.Time  `json:"effective_at" db:"effective_at"`
+	Source        *string    `json:"source,omitempty" db:"source"`
+	CreatedBy     *uuid.UUID `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NewFXRate creates a new exchange rate effective immediately
+func NewFXRate(baseCurrency, quoteCurrency string, rate float64) *FXRate {
+	now := time.Now()
+	return &FXRate{
+		ID:            uuid.New(),
+		BaseCurrency:  NormalizeCurrency(baseCurrency),
+		QuoteCurrency: NormalizeCurrency(quoteCurrency),
+		Rate:          rate,
+		EffectiveAt:   now,
+		CreatedAt:     now,
+	}
+}
+
+// Validate performs business rule validation for the exchange rate
+func (r *FXRate) Validate() error {
+	if !IsSupportedCurrency(r.BaseCurrency) {
+		return NewValidationError("base_currency", "unsupported base currency")
+	}
+	if !IsSupportedCurrency(r.QuoteCurrency) {
+		return NewValidationError("quote_currency", "unsupported quote currency")
+	}
+	if r.BaseCurrency == r.QuoteCurrency {
+		return NewValidationError("quote_currency", "quote currency must differ from base currency")
+	}
+	if r.Rate <= 0 {
+		return NewValidationError("rate", "rate must be positive")
+	}
+	return nil
+}
+
+// Convert converts an amount in the base currency to the quote currency
+func (r *FXRate) Convert(amount float64) float64 {
+	return math.Round(amount*r.Rate*100) / 100
+}
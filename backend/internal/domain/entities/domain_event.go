@@ -0,0 +1,287 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Domain event names
+const (
+	DomainEventOrderCreated   = "order.created"
+	DomainEventOrderPaid      = "order.paid"
+	DomainEventOrderExpired   = "order.expired"
+	DomainEventPaymentFailed  = "payment.failed"
+	DomainEventTicketIssued   = "ticket.issued"
+	DomainEventTicketRedeemed = "ticket.redeemed"
+	DomainEventTicketVoided   = "ticket.voided"
+	DomainEventEventPublished = "event.published"
+)
+
+// DomainEvent is something that happened in the domain which other parts of
+// the system may react to. Events are published as pointers to the structs
+// below and are serialized as JSON when queued for asynchronous subscribers.
+type DomainEvent interface {
+	// EventName identifies the event type, e.g. "order.paid"
+	EventName() string
+
+	// AggregateType and AggregateID identify the entity the event is about
+	AggregateType() string
+	AggregateID() uuid.UUID
+}
+
+// NewDomainEvent returns an empty event of the named type to decode into
+func NewDomainEvent(name string) (DomainEvent, bool) {
+	switch name {
+	case DomainEventOrderCreated:
+		return &OrderCreated{}, true
+	case DomainEventOrderPaid:
+		return &OrderPaid{}, true
+	case DomainEventOrderExpired:
+		return &OrderExpired{}, true
+	case DomainEventPaymentFailed:
+		return &PaymentFailed{}, true
+	case DomainEventTicketIssued:
+		return &TicketIssued{}, true
+	case DomainEventTicketRedeemed:
+		return &TicketRedeemed{}, true
+	case DomainEventTicketVoided:
+		return &TicketVoided{}, true
+	case DomainEventEventPublished:
+		return &EventPublished{}, true
+	default:
+		return nil, false
+	}
+}
+
+// OrderCreated is published when a customer places an order and its inventory is held
+type OrderCreated struct {
+	OrderID     uuid.UUID  `json:"order_id"`
+	OrderCode   string     `json:"order_code"`
+	EventID     uuid.UUID  `json:"event_id"`
+	UserID      *uuid.UUID `json:"user_id,omitempty"`
+	TotalAmount float64    `json:"total_amount"`
+	Currency    string     `json:"currency"`
+	ExpiresAt   time.Time  `json:"expires_at"`
+	OccurredAt  time.Time  `json:"occurred_at"`
+}
+
+// NewOrderCreated creates an OrderCreated event for an order
+func NewOrderCreated(order *Order) *OrderCreated {
+	return &OrderCreated{
+		OrderID:     order.ID,
+		OrderCode:   order.Code,
+		EventID:     orderEventID(order),
+		UserID:      order.UserID,
+		TotalAmount: order.TotalAmount,
+		Currency:    order.Currency,
+		ExpiresAt:   order.ExpiresAt,
+		OccurredAt:  time.Now(),
+	}
+}
+
+func (e *OrderCreated) EventName() string      { return DomainEventOrderCreated }
+func (e *OrderCreated) AggregateType() string  { return "order" }
+func (e *OrderCreated) AggregateID() uuid.UUID { return e.OrderID }
+
+// OrderPaid is published when an order is paid, whether online, at the box
+// office, as a comp or through an import. DeliverTickets is false when the
+// customer must not be sent their tickets, e.g. for a silent import.
+type OrderPaid struct {
+	OrderID        uuid.UUID      `json:"order_id"`
+	OrderCode      string         `json:"order_code"`
+	EventID        uuid.UUID      `json:"event_id"`
+	UserID         *uuid.UUID     `json:"user_id,omitempty"`
+	TotalAmount    float64        `json:"total_amount"`
+	Currency       string         `json:"currency"`
+	PaymentMethod  *PaymentMethod `json:"payment_method,omitempty"`
+	DeliverTickets bool           `json:"deliver_tickets"`
+	OccurredAt     time.Time      `json:"occurred_at"`
+}
+
+// NewOrderPaid creates an OrderPaid event for an order
+func NewOrderPaid(order *Order, deliverTickets bool) *OrderPaid {
+	return &OrderPaid{
+		OrderID:        order.ID,
+		OrderCode:      order.Code,
+		EventID:        orderEventID(order),
+		UserID:         order.UserID,
+		TotalAmount:    order.TotalAmount,
+		Currency:       order.Currency,
+		PaymentMethod:  order.PaymentMethod,
+		DeliverTickets: deliverTickets,
+		OccurredAt:     time.Now(),
+	}
+}
+
+func (e *OrderPaid) EventName() string      { return DomainEventOrderPaid }
+func (e *OrderPaid) AggregateType() string  { return "order" }
+func (e *OrderPaid) AggregateID() uuid.UUID { return e.OrderID }
+
+// OrderExpired is published when an unpaid order's hold runs out
+type OrderExpired struct {
+	OrderID    uuid.UUID  `json:"order_id"`
+	OrderCode  string     `json:"order_code"`
+	EventID    uuid.UUID  `json:"event_id"`
+	UserID     *uuid.UUID `json:"user_id,omitempty"`
+	OccurredAt time.Time  `json:"occurred_at"`
+}
+
+// NewOrderExpired creates an OrderExpired event for an order
+func NewOrderExpired(order *Order) *OrderExpired {
+	return &OrderExpired{
+		OrderID:    order.ID,
+		OrderCode:  order.Code,
+		EventID:    orderEventID(order),
+		UserID:     order.UserID,
+		OccurredAt: time.Now(),
+	}
+}
+
+func (e *OrderExpired) EventName() string      { return DomainEventOrderExpired }
+func (e *OrderExpired) AggregateType() string  { return "order" }
+func (e *OrderExpired) AggregateID() uuid.UUID { return e.OrderID }
+
+// PaymentFailed is published when a payment for an order could not be started
+// with the provider; the order stays pending until it is paid or expires
+type PaymentFailed struct {
+	PaymentID     uuid.UUID     `json:"payment_id"`
+	OrderID       uuid.UUID     `json:"order_id"`
+	OrderCode     string        `json:"order_code"`
+	EventID       uuid.UUID     `json:"event_id"`
+	UserID        *uuid.UUID    `json:"user_id,omitempty"`
+	PaymentMethod PaymentMethod `json:"payment_method"`
+	Error         string        `json:"error"`
+	OccurredAt    time.Time     `json:"occurred_at"`
+}
+
+// NewPaymentFailed creates a PaymentFailed event for a payment of an order
+func NewPaymentFailed(payment *Payment, order *Order, cause error) *PaymentFailed {
+	return &PaymentFailed{
+		PaymentID:     payment.ID,
+		OrderID:       order.ID,
+		OrderCode:     order.Code,
+		EventID:       orderEventID(order),
+		UserID:        order.UserID,
+		PaymentMethod: payment.Provider,
+		Error:         cause.Error(),
+		OccurredAt:    time.Now(),
+	}
+}
+
+func (e *PaymentFailed) EventName() string      { return DomainEventPaymentFailed }
+func (e *PaymentFailed) AggregateType() string  { return "payment" }
+func (e *PaymentFailed) AggregateID() uuid.UUID { return e.PaymentID }
+
+// TicketIssued is published for every ticket created for a paid order
+type TicketIssued struct {
+	TicketID     uuid.UUID `json:"ticket_id"`
+	SerialNumber string    `json:"serial_number"`
+	OrderID      uuid.UUID `json:"order_id"`
+	OrderLineID  uuid.UUID `json:"order_line_id"`
+	TicketTierID uuid.UUID `json:"ticket_tier_id"`
+	EventID      uuid.UUID `json:"event_id"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+// NewTicketIssued creates a TicketIssued event for a ticket of an order line
+func NewTicketIssued(ticket *Ticket, orderID, ticketTierID, eventID uuid.UUID) *TicketIssued {
+	return &TicketIssued{
+		TicketID:     ticket.ID,
+		SerialNumber: ticket.SerialNumber,
+		OrderID:      orderID,
+		OrderLineID:  ticket.OrderLineID,
+		TicketTierID: ticketTierID,
+		EventID:      eventID,
+		OccurredAt:   time.Now(),
+	}
+}
+
+func (e *TicketIssued) EventName() string      { return DomainEventTicketIssued }
+func (e *TicketIssued) AggregateType() string  { return "ticket" }
+func (e *TicketIssued) AggregateID() uuid.UUID { return e.TicketID }
+
+// TicketRedeemed is published when a ticket is used for admission, whether
+// scanned or checked in by name from the guest list
+type TicketRedeemed struct {
+	TicketID     uuid.UUID  `json:"ticket_id"`
+	SerialNumber string     `json:"serial_number"`
+	EventID      uuid.UUID  `json:"event_id"`
+	RedeemedBy   string     `json:"redeemed_by"`
+	SessionID    *uuid.UUID `json:"session_id,omitempty"` // scanner session, when scanned
+	Notes        *string    `json:"notes,omitempty"`      // scanner's notes, when scanned
+	OccurredAt   time.Time  `json:"occurred_at"`
+}
+
+// NewTicketRedeemed creates a TicketRedeemed event for a ticket
+func NewTicketRedeemed(ticket *Ticket, eventID uuid.UUID, redeemedBy string, sessionID *uuid.UUID) *TicketRedeemed {
+	return &TicketRedeemed{
+		TicketID:     ticket.ID,
+		SerialNumber: ticket.SerialNumber,
+		EventID:      eventID,
+		RedeemedBy:   redeemedBy,
+		SessionID:    sessionID,
+		OccurredAt:   time.Now(),
+	}
+}
+
+func (e *TicketRedeemed) EventName() string      { return DomainEventTicketRedeemed }
+func (e *TicketRedeemed) AggregateType() string  { return "ticket" }
+func (e *TicketRedeemed) AggregateID() uuid.UUID { return e.TicketID }
+
+// TicketVoided is published when a ticket stops being valid, e.g. after a
+// refund, a revoked comp or a cancelled event
+type TicketVoided struct {
+	TicketID     uuid.UUID `json:"ticket_id"`
+	SerialNumber string    `json:"serial_number"`
+	EventID      uuid.UUID `json:"event_id"`
+	Reason       string    `json:"reason"`
+	OccurredAt   time.Time `json:"occurred_at"`
+}
+
+// NewTicketVoided creates a TicketVoided event for a ticket
+func NewTicketVoided(ticket *Ticket, eventID uuid.UUID, reason string) *TicketVoided {
+	return &TicketVoided{
+		TicketID:     ticket.ID,
+		SerialNumber: ticket.SerialNumber,
+		EventID:      eventID,
+		Reason:       reason,
+		OccurredAt:   time.Now(),
+	}
+}
+
+func (e *TicketVoided) EventName() string      { return DomainEventTicketVoided }
+func (e *TicketVoided) AggregateType() string  { return "ticket" }
+func (e *TicketVoided) AggregateID() uuid.UUID { return e.TicketID }
+
+// EventPublished is published when an event goes on sale to the public
+type EventPublished struct {
+	EventID     uuid.UUID  `json:"event_id"`
+	OrganizerID *uuid.UUID `json:"organizer_id,omitempty"`
+	Name        string     `json:"name"`
+	Slug        string     `json:"slug"`
+	EventDate   time.Time  `json:"event_date"`
+	OccurredAt  time.Time  `json:"occurred_at"`
+}
+
+// NewEventPublished creates an EventPublished event for an event
+func NewEventPublished(event *Event) *EventPublished {
+	return &EventPublished{
+		EventID:     event.ID,
+		OrganizerID: event.OrganizerID,
+		Name:        event.Name,
+		Slug:        event.Slug,
+		EventDate:   event.EventDate,
+		OccurredAt:  time.Now(),
+	}
+}
+
+func (e *EventPublished) EventName() string      { return DomainEventEventPublished }
+func (e *EventPublished) AggregateType() string  { return "event" }
+func (e *EventPublished) AggregateID() uuid.UUID { return e.EventID }
+
+// orderEventID parses the order's event ID, which orders store as a string
+func orderEventID(order *Order) uuid.UUID {
+	eventID, _ := uuid.Parse(order.EventID)
+	return eventID
+}
@@ -75,6 +75,10 @@ var (
 	ErrEventSoldOut         = errors.New("event is sold out")
 	ErrEventCancelled       = errors.New("event is cancelled")
 	ErrEventExpired         = errors.New("event has expired")
+	ErrEventSeriesNotFound  = errors.New("event series not found")
+	ErrEventTemplateNotFound = errors.New("event template not found")
+	ErrEventChangeNotFound  = errors.New("event change not found")
+	ErrEventChangeRecipientNotFound = errors.New("event change recipient not found")
 
 	// Ticket errors
 	ErrTicketNotFound       = errors.New("ticket not found")
@@ -111,11 +115,13 @@ var (
 	ErrTourNotFound         = errors.New("tour not found")
 	ErrTourAlreadyExists    = errors.New("tour already exists")
 	ErrTourNotActive        = errors.New("tour is not active")
+	ErrTourPassNotFound     = errors.New("tour pass not found")
 
 	// Ticket Tier errors
 	ErrTicketTierNotFound   = errors.New("ticket tier not found")
 	ErrTicketTierSoldOut    = errors.New("ticket tier is sold out")
 	ErrTicketTierNotActive  = errors.New("ticket tier is not active")
+	ErrTierPriceChangeNotFound = errors.New("ticket tier price change not found")
 
 	// OTP errors
 	ErrOTPNotFound          = errors.New("OTP not found")
@@ -134,6 +140,56 @@ var (
 	ErrInventoryHoldNotFound    = errors.New("inventory hold not found")
 	ErrInventoryHoldExpired     = errors.New("inventory hold expired")
 
+	// Box office errors
+	ErrBoxOfficeShiftNotFound   = errors.New("box office shift not found")
+	ErrBoxOfficeShiftOpen       = errors.New("operator already has an open box office shift")
+
+	// Comp and guest list errors
+	ErrCompAllocationNotFound   = errors.New("comp allocation not found")
+	ErrCompQuotaExceeded        = errors.New("comp quota exceeded")
+	ErrGuestListEntryNotFound   = errors.New("guest list entry not found")
+
+	// Ticket import errors
+	ErrTicketImportNotFound         = errors.New("ticket import not found")
+	ErrOrderExternalReferenceExists = errors.New("an order with this external reference already exists")
+
+	// Venue errors
+	ErrVenueNotFound            = errors.New("venue not found")
+
+	// Category errors
+	ErrCategoryNotFound         = errors.New("category not found")
+
+	// Outbox errors
+	ErrOutboxMessageNotFound    = errors.New("outbox message not found")
+
+	// Webhook errors
+	ErrWebhookSubscriptionNotFound = errors.New("webhook subscription not found")
+	ErrWebhookDeliveryNotFound     = errors.New("webhook delivery not found")
+
+	// Notification errors
+	ErrNotificationNotFound  = errors.New("notification message not found")
+	ErrWhatsAppOptInNotFound = errors.New("whatsapp opt-in not found")
+
+	// Message template errors
+	ErrMessageTemplateNotFound   = errors.New("message template not found")
+	ErrOrganizerBrandingNotFound = errors.New("organizer branding not found")
+
+	// Event campaign errors
+	ErrEventCampaignNotFound    = errors.New("event campaign not found")
+	ErrCampaignDeliveryNotFound = errors.New("campaign delivery not found")
+
+	// Checkout recovery errors
+	ErrCheckoutRecoveryNotFound = errors.New("checkout recovery not found")
+
+	// Marketing errors
+	ErrMarketingAudienceNotFound = errors.New("marketing audience not found")
+	ErrMarketingCampaignNotFound = errors.New("marketing campaign not found")
+	ErrMarketingDeliveryNotFound = errors.New("marketing delivery not found")
+
+	// Currency errors
+	ErrFXRateNotFound           = errors.New("exchange rate not found")
+	ErrUnsupportedCurrency      = errors.New("unsupported currency")
+
 	// General validation errors
 	ErrValidationError       = errors.New("validation error")
 	ErrInvalidInput          = errors.New("invalid input")
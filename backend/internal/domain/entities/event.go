@@ -23,6 +23,9 @@ type Event struct {
 	ID              uuid.UUID              `json:"id" db:"id"`
 	OrganizerID     *uuid.UUID             `json:"organizer_id,omitempty" db:"organizer_id"`
 	CategoryID      *uuid.UUID             `json:"category_id,omitempty" db:"category_id"`
+	TourID          *uuid.UUID             `json:"tour_id,omitempty" db:"tour_id"`
+	SeriesID        *uuid.UUID             `json:"series_id,omitempty" db:"series_id"`
+	VenueID         *uuid.UUID             `json:"venue_id,omitempty" db:"venue_id"`
 	Name            string                 `json:"name" db:"name"`
 	Slug            string                 `json:"slug" db:"slug"`
 	Description     *string                `json:"description,omitempty" db:"description"`
@@ -34,6 +37,8 @@ type Event struct {
 	VenueState      *string                `json:"venue_state,omitempty" db:"venue_state"`
 	VenueCountry    *string                `json:"venue_country,omitempty" db:"venue_country"`
 	VenueCapacity   *int                   `json:"venue_capacity,omitempty" db:"venue_capacity"`
+	VenueLatitude   *float64               `json:"venue_latitude,omitempty" db:"venue_latitude"`
+	VenueLongitude  *float64               `json:"venue_longitude,omitempty" db:"venue_longitude"`
 	EventImageURL   *string                `json:"event_image_url,omitempty" db:"event_image_url"`
 	ThumbnailURL    *string                `json:"thumbnail_url,omitempty" db:"thumbnail_url"`
 	PromoVideoURL   *string                `json:"promo_video_url,omitempty" db:"promo_video_url"`
@@ -42,6 +47,7 @@ type Event struct {
 	SaleStart       *time.Time             `json:"sale_start,omitempty" db:"sale_start"`
 	SaleEnd         *time.Time             `json:"sale_end,omitempty" db:"sale_end"`
 	Settings        JSONB                  `json:"settings" db:"settings"`
+	Currency        string                 `json:"currency" db:"currency"`
 	EnableMomo      bool                   `json:"enable_momo" db:"enable_momo"`
 	EnablePaystack  bool                   `json:"enable_paystack" db:"enable_paystack"`
 	CreatedAt       time.Time              `json:"created_at" db:"created_at"`
@@ -72,6 +78,7 @@ func NewEvent(organizerID uuid.UUID, name, slug string, eventDate time.Time, ven
 			VenueCountry:   &vcountry,
 			Status:         EventStatusDraft,
 			Settings:       make(map[string]interface{}),
+			Currency:       DefaultCurrency,
 			EnableMomo:     true,  // Enable MoMo by default
 			EnablePaystack: true,  // Enable Paystack by default
 			CreatedAt:      time.Now(),
@@ -180,6 +187,16 @@ func (e *Event) Validate() error {
 		return NewValidationError("venue_country", "venue country is required")
 	}
 	
+	if (e.VenueLatitude == nil) != (e.VenueLongitude == nil) {
+		return NewValidationError("venue_latitude", "venue latitude and longitude must be set together")
+	}
+	
+	if e.VenueLatitude != nil {
+		if err := ValidateCoordinates(*e.VenueLatitude, *e.VenueLongitude); err != nil {
+			return err
+		}
+	}
+	
 	if e.EventDate.IsZero() {
 		return NewValidationError("event_date", "event date is required")
 	}
@@ -188,6 +205,10 @@ func (e *Event) Validate() error {
 		return NewValidationError("event_date", "event date cannot be in the past")
 	}
 	
+	if !IsSupportedCurrency(e.Currency) {
+		return NewValidationError("currency", "unsupported currency")
+	}
+	
 	return nil
 }
 
@@ -232,18 +253,99 @@ func (e *Event) GetRevenue() float64 {
 	return total
 }
 
-// SetTour sets the tour ID for the event (deprecated - tour_id not in schema)
-// func (e *Event) SetTour(tourID uuid.UUID) {
-// 	e.TourID = &tourID
-// 	e.UpdatedAt = time.Now()
-// }
-
-// SetVenueLocation sets the venue coordinates (deprecated - coordinates not in schema)
-// func (e *Event) SetVenueLocation(latitude, longitude float64) {
-// 	e.VenueLatitude = &latitude
-// 	e.VenueLongitude = &longitude
-// 	e.UpdatedAt = time.Now()
-// }
+// SetTour sets the tour ID for the event
+func (e *Event) SetTour(tourID uuid.UUID) {
+	e.TourID = &tourID
+	e.UpdatedAt = time.Now()
+}
+
+// SetCategory files the event under a category, or removes it from any when nil
+func (e *Event) SetCategory(categoryID *uuid.UUID) {
+	e.CategoryID = categoryID
+	e.UpdatedAt = time.Now()
+}
+
+// Clone copies the event as a new draft starting at start. Doors and sale
+// times keep their offset from the event start; tour and series links are
+// not copied.
+func (e *Event) Clone(slug string, start time.Time) *Event {
+	shift := start.Sub(e.EventDate)
+	now := time.Now()
+	
+	clone := *e
+	clone.ID = uuid.New()
+	clone.TourID = nil
+	clone.SeriesID = nil
+	clone.Slug = slug
+	clone.EventDate = start
+	clone.DoorsOpen = shiftTime(e.DoorsOpen, shift)
+	clone.SaleStart = shiftTime(e.SaleStart, shift)
+	clone.SaleEnd = shiftTime(e.SaleEnd, shift)
+	clone.GalleryImages = append(JSONBArray{}, e.GalleryImages...)
+	clone.Settings = make(JSONB, len(e.Settings))
+	for key, value := range e.Settings {
+		clone.Settings[key] = value
+	}
+	clone.Status = EventStatusDraft
+	clone.IsActive = true
+	clone.CreatedAt = now
+	clone.UpdatedAt = now
+	clone.Organizer = nil
+	clone.Tour = nil
+	clone.TicketTiers = nil
+	clone.Orders = nil
+	clone.Tickets = nil
+	
+	return &clone
+}
+
+// CopyAsOccurrence copies the event as an occurrence of a series starting at
+// start. The copy stays on the event's tour and is a draft if the event is,
+// and published otherwise.
+func (e *Event) CopyAsOccurrence(seriesID uuid.UUID, start time.Time, slug string) *Event {
+	occurrence := e.Clone(slug, start)
+	occurrence.TourID = e.TourID
+	occurrence.SeriesID = &seriesID
+	if e.Status != EventStatusDraft {
+		occurrence.Status = EventStatusPublished
+	}
+	return occurrence
+}
+
+// shiftTime returns t moved by d, or nil when t is nil
+func shiftTime(t *time.Time, d time.Duration) *time.Time {
+	if t == nil {
+		return nil
+	}
+	shifted := t.Add(d)
+	return &shifted
+}
+
+// SetVenueLocation sets the venue coordinates
+func (e *Event) SetVenueLocation(latitude, longitude float64) error {
+	if err := ValidateCoordinates(latitude, longitude); err != nil {
+		return err
+	}
+	e.VenueLatitude = &latitude
+	e.VenueLongitude = &longitude
+	e.UpdatedAt = time.Now()
+	return nil
+}
+
+// AssignVenue links the event to a venue and copies the venue details onto the event
+func (e *Event) AssignVenue(venue *Venue) {
+	e.VenueID = &venue.ID
+	e.VenueName = venue.Name
+	e.VenueAddress = venue.Address
+	e.VenueCity = venue.City
+	e.VenueState = venue.State
+	country := venue.Country
+	e.VenueCountry = &country
+	e.VenueCapacity = venue.Capacity
+	e.VenueLatitude = venue.Latitude
+	e.VenueLongitude = venue.Longitude
+	e.UpdatedAt = time.Now()
+}
 
 // SetImage sets the event hero image URL
 func (e *Event) SetImage(imageURL string) {
@@ -0,0 +1,291 @@
+package entities
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// CampaignType represents what a scheduled event campaign tells ticket holders
+type CampaignType string
+
+const (
+	// CampaignReminder reaches holders of unredeemed tickets before the event
+	CampaignReminder CampaignType = "reminder"
+	// CampaignFollowUp thanks holders who attended, after the event
+	CampaignFollowUp CampaignType = "follow_up"
+	// CampaignTypeAll is only used for unsubscribes that cover every campaign type
+	CampaignTypeAll CampaignType = "all"
+)
+
+// CampaignStatus represents the progress of an event campaign
+type CampaignStatus string
+
+const (
+	CampaignStatusScheduled           CampaignStatus = "scheduled"
+	CampaignStatusProcessing          CampaignStatus = "processing"
+	CampaignStatusCompleted           CampaignStatus = "completed"
+	CampaignStatusCompletedWithErrors CampaignStatus = "completed_with_errors"
+	CampaignStatusFailed              CampaignStatus = "failed"
+	CampaignStatusCancelled           CampaignStatus = "cancelled"
+	// CampaignStatusExpired reminders came due only after the event had started
+	CampaignStatusExpired CampaignStatus = "expired"
+)
+
+// MaxCampaignOffsetMinutes caps how far from the event a campaign can be sent: 30 days
+const MaxCampaignOffsetMinutes = 30 * 24 * 60
+
+// EventCampaign is a message sent to an event's ticket holders at an offset
+// from the event's start: reminders before it, follow-ups after it. The send
+// time follows the event, so a postponed event moves its campaigns along.
+type EventCampaign struct {
+	ID                 uuid.UUID      `json:"id" db:"id"`
+	EventID            uuid.UUID      `json:"event_id" db:"event_id"`
+	Type               CampaignType   `json:"type" db:"type"`
+	OffsetMinutes      int            `json:"offset_minutes" db:"offset_minutes"`
+	Message            *string        `json:"message,omitempty" db:"message"`
+	SurveyURL          *string        `json:"survey_url,omitempty" db:"survey_url"`
+	Status             CampaignStatus `json:"status" db:"status"`
+	SendAt             time.Time      `json:"send_at" db:"send_at"`
+	TotalRecipients    int            `json:"total_recipients" db:"total_recipients"`
+	SentCount          int            `json:"sent_count" db:"sent_count"`
+	SkippedCount       int            `json:"skipped_count" db:"skipped_count"`
+	FailedCount        int            `json:"failed_count" db:"failed_count"`
+	LastError          *string        `json:"last_error,omitempty" db:"last_error"`
+	CreatedBy          *uuid.UUID     `json:"created_by,omitempty" db:"created_by"`
+	RecipientsStagedAt *time.Time     `json:"recipients_staged_at,omitempty" db:"recipients_staged_at"`
+	StartedAt          *time.Time     `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt        *time.Time     `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt          time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// NewEventCampaign creates a scheduled campaign for an event
+func NewEventCampaign(event *Event, campaignType CampaignType, offsetMinutes int) *EventCampaign {
+	now := time.Now()
+	campaign := &EventCampaign{
+		ID:            uuid.New(),
+		EventID:       event.ID,
+		Type:          campaignType,
+		OffsetMinutes: offsetMinutes,
+		Status:        CampaignStatusScheduled,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+	campaign.Schedule(event)
+	return campaign
+}
+
+// Validate performs business rule validation for the campaign
+func (c *EventCampaign) Validate() error {
+	if c.Type != CampaignReminder && c.Type != CampaignFollowUp {
+		return NewValidationError("type", "type must be reminder or follow_up")
+	}
+	if c.OffsetMinutes < 0 || c.OffsetMinutes > MaxCampaignOffsetMinutes {
+		return NewValidationError("offset_minutes", "offset must be between 0 and 43200 minutes (30 days)")
+	}
+	if c.Message != nil && len(*c.Message) > 1000 {
+		return NewValidationError("message", "message must be 1000 characters or less")
+	}
+	if c.SurveyURL != nil {
+		if c.Type != CampaignFollowUp {
+			return NewValidationError("survey_url", "a survey link only applies to follow-ups")
+		}
+		parsed, err := url.Parse(*c.SurveyURL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return NewValidationError("survey_url", "survey link must be an http or https URL")
+		}
+	}
+	return nil
+}
+
+// Schedule computes the send time from the event's current start
+func (c *EventCampaign) Schedule(event *Event) {
+	offset := time.Duration(c.OffsetMinutes) * time.Minute
+	if c.Type == CampaignReminder {
+		c.SendAt = event.EventDate.Add(-offset)
+		return
+	}
+	c.SendAt = event.EventDate.Add(offset)
+}
+
+// IsEditable checks if the campaign has not started sending yet
+func (c *EventCampaign) IsEditable() bool {
+	return c.Status == CampaignStatusScheduled
+}
+
+// IsFinished checks if the campaign will not send anything more
+func (c *EventCampaign) IsFinished() bool {
+	switch c.Status {
+	case CampaignStatusCompleted, CampaignStatusCompletedWithErrors, CampaignStatusCancelled, CampaignStatusExpired:
+		return true
+	}
+	return false
+}
+
+// MarkProcessing records that the scheduler has picked the campaign up
+func (c *EventCampaign) MarkProcessing() {
+	now := time.Now()
+	c.Status = CampaignStatusProcessing
+	if c.StartedAt == nil {
+		c.StartedAt = &now
+	}
+	c.LastError = nil
+	c.UpdatedAt = now
+}
+
+// MarkFinished closes the campaign once no pending recipients remain
+func (c *EventCampaign) MarkFinished() {
+	now := time.Now()
+	c.Status = CampaignStatusCompleted
+	if c.FailedCount > 0 {
+		c.Status = CampaignStatusCompletedWithErrors
+	}
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+}
+
+// MarkFailed records a processing error; the scheduler resumes the campaign later
+func (c *EventCampaign) MarkFailed(message string) {
+	c.Status = CampaignStatusFailed
+	c.LastError = &message
+	c.UpdatedAt = time.Now()
+}
+
+// Close stops a campaign for good, e.g. cancelled by an admin or expired
+func (c *EventCampaign) Close(status CampaignStatus, reason string) {
+	now := time.Now()
+	c.Status = status
+	if reason != "" {
+		c.LastError = &reason
+	}
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+}
+
+// CampaignDeliveryStatus represents the outcome of one campaign message
+type CampaignDeliveryStatus string
+
+const (
+	CampaignDeliveryPending CampaignDeliveryStatus = "pending"
+	CampaignDeliverySent    CampaignDeliveryStatus = "sent"
+	CampaignDeliverySkipped CampaignDeliveryStatus = "skipped"
+	CampaignDeliveryFailed  CampaignDeliveryStatus = "failed"
+)
+
+// Reasons a campaign delivery was skipped
+const (
+	CampaignSkipUnsubscribed = "unsubscribed"
+	CampaignSkipNoContact    = "no_contact"
+	CampaignSkipCancelled    = "campaign_cancelled"
+	CampaignSkipExpired      = "expired"
+)
+
+// CampaignDelivery is the campaign message for one ticket holder. Holders are
+// identified by a contact key (their account, or else their email or phone),
+// so someone with several orders for the event gets one message.
+type CampaignDelivery struct {
+	ID               uuid.UUID              `json:"id" db:"id"`
+	CampaignID       uuid.UUID              `json:"campaign_id" db:"campaign_id"`
+	OrderID          uuid.UUID              `json:"order_id" db:"order_id"`
+	UserID           *uuid.UUID             `json:"user_id,omitempty" db:"user_id"`
+	ContactKey       string                 `json:"-" db:"contact_key"`
+	Name             *string                `json:"name,omitempty" db:"name"`
+	Email            *string                `json:"email,omitempty" db:"email"`
+	Phone            *string                `json:"phone,omitempty" db:"phone"`
+	Channel          *NotificationChannel   `json:"channel,omitempty" db:"channel"`
+	Status           CampaignDeliveryStatus `json:"status" db:"status"`
+	SkipReason       *string                `json:"skip_reason,omitempty" db:"skip_reason"`
+	UnsubscribeToken string                 `json:"-" db:"unsubscribe_token"`
+	Attempts         int                    `json:"attempts" db:"attempts"`
+	LastError        *string                `json:"last_error,omitempty" db:"last_error"`
+	SentAt           *time.Time             `json:"sent_at,omitempty" db:"sent_at"`
+	CreatedAt        time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at" db:"updated_at"`
+}
+
+// FirstName returns the first word of the holder's name, for greetings
+func (d *CampaignDelivery) FirstName() string {
+	if d.Name == nil {
+		return ""
+	}
+	parts := strings.Fields(*d.Name)
+	if len(parts) == 0 {
+		return ""
+	}
+	return parts[0]
+}
+
+// MarkSent records that the message was handed to a channel
+func (d *CampaignDelivery) MarkSent(channel NotificationChannel) {
+	now := time.Now()
+	d.Channel = &channel
+	d.Status = CampaignDeliverySent
+	d.LastError = nil
+	d.SentAt = &now
+	d.UpdatedAt = now
+}
+
+// Skip closes the delivery without sending anything
+func (d *CampaignDelivery) Skip(reason string) {
+	d.Status = CampaignDeliverySkipped
+	d.SkipReason = &reason
+	d.UpdatedAt = time.Now()
+}
+
+// Fail closes the delivery after an error that retrying cannot fix
+func (d *CampaignDelivery) Fail(channel NotificationChannel, message string) {
+	d.Attempts++
+	d.Channel = &channel
+	d.Status = CampaignDeliveryFailed
+	d.LastError = &message
+	d.UpdatedAt = time.Now()
+}
+
+// RecordAttemptError records a failed send; once the attempts are used up
+// the delivery is marked failed
+func (d *CampaignDelivery) RecordAttemptError(channel NotificationChannel, message string) {
+	d.Attempts++
+	d.Channel = &channel
+	d.LastError = &message
+	d.UpdatedAt = time.Now()
+	if d.Attempts >= MaxRecipientAttempts {
+		d.Status = CampaignDeliveryFailed
+	}
+}
+
+// CampaignUnsubscribe stops campaign messages of one type, or of every type,
+// from reaching a contact
+type CampaignUnsubscribe struct {
+	ID         uuid.UUID    `json:"id" db:"id"`
+	ContactKey string       `json:"-" db:"contact_key"`
+	UserID     *uuid.UUID   `json:"user_id,omitempty" db:"user_id"`
+	Type       CampaignType `json:"type" db:"campaign_type"`
+	Source     string       `json:"source" db:"source"`
+	CreatedAt  time.Time    `json:"created_at" db:"created_at"`
+}
+
+// Unsubscribe sources
+const (
+	CampaignUnsubscribeLink    = "link"
+	CampaignUnsubscribeAccount = "account"
+)
+
+// NewCampaignUnsubscribe creates an unsubscribe for a contact
+func NewCampaignUnsubscribe(contactKey string, userID *uuid.UUID, campaignType CampaignType, source string) *CampaignUnsubscribe {
+	return &CampaignUnsubscribe{
+		ID:         uuid.New(),
+		ContactKey: contactKey,
+		UserID:     userID,
+		Type:       campaignType,
+		Source:     source,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// UserContactKey is the contact key of a registered customer
+func UserContactKey(userID uuid.UUID) string {
+	return "user:" + userID.String()
+}
@@ -0,0 +1,308 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventChangeType represents what happened to an event
+type EventChangeType string
+
+const (
+	EventChangeCancellation EventChangeType = "cancellation"
+	EventChangePostponement EventChangeType = "postponement"
+)
+
+// RefundPolicy determines how ticket holders are refunded after an event change
+type RefundPolicy string
+
+const (
+	// RefundPolicyNone leaves orders as they are
+	RefundPolicyNone RefundPolicy = "none"
+	// RefundPolicyAutomatic refunds every paid order
+	RefundPolicyAutomatic RefundPolicy = "automatic"
+	// RefundPolicyChoice lets each holder keep their tickets or ask for a refund before a deadline
+	RefundPolicyChoice RefundPolicy = "choice"
+)
+
+// EventChangeStatus represents the progress of notifying holders about an event change
+type EventChangeStatus string
+
+const (
+	EventChangeStatusPending             EventChangeStatus = "pending"
+	EventChangeStatusProcessing          EventChangeStatus = "processing"
+	EventChangeStatusCompleted           EventChangeStatus = "completed"
+	EventChangeStatusCompletedWithErrors EventChangeStatus = "completed_with_errors"
+	EventChangeStatusFailed              EventChangeStatus = "failed"
+)
+
+// EventChange is a cancellation or postponement of an event and the work of
+// voiding, refunding and notifying its ticket holders
+type EventChange struct {
+	ID                 uuid.UUID         `json:"id" db:"id"`
+	EventID            uuid.UUID         `json:"event_id" db:"event_id"`
+	Type               EventChangeType   `json:"type" db:"type"`
+	Reason             *string           `json:"reason,omitempty" db:"reason"`
+	Message            *string           `json:"message,omitempty" db:"message"`
+	PreviousDate       time.Time         `json:"previous_date" db:"previous_date"`
+	NewDate            *time.Time        `json:"new_date,omitempty" db:"new_date"`
+	VoidTickets        bool              `json:"void_tickets" db:"void_tickets"`
+	RefundPolicy       RefundPolicy      `json:"refund_policy" db:"refund_policy"`
+	RefundDeadline     *time.Time        `json:"refund_deadline,omitempty" db:"refund_deadline"`
+	NotifyEmail        bool              `json:"notify_email" db:"notify_email"`
+	NotifySMS          bool              `json:"notify_sms" db:"notify_sms"`
+	Status             EventChangeStatus `json:"status" db:"status"`
+	TotalRecipients    int               `json:"total_recipients" db:"total_recipients"`
+	NotifiedRecipients int               `json:"notified_recipients" db:"notified_recipients"`
+	FailedRecipients   int               `json:"failed_recipients" db:"failed_recipients"`
+	RefundsRequested   int               `json:"refunds_requested" db:"refunds_requested"`
+	RefundsCompleted   int               `json:"refunds_completed" db:"refunds_completed"`
+	LastError          *string           `json:"last_error,omitempty" db:"last_error"`
+	CreatedBy          *uuid.UUID        `json:"created_by,omitempty" db:"created_by"`
+	RecipientsStagedAt *time.Time        `json:"recipients_staged_at,omitempty" db:"recipients_staged_at"`
+	StartedAt          *time.Time        `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt        *time.Time        `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt          time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time         `json:"updated_at" db:"updated_at"`
+}
+
+// NewEventChange creates a pending change for an event, recording its current date
+func NewEventChange(event *Event, changeType EventChangeType) *EventChange {
+	now := time.Now()
+	return &EventChange{
+		ID:           uuid.New(),
+		EventID:      event.ID,
+		Type:         changeType,
+		PreviousDate: event.EventDate,
+		RefundPolicy: RefundPolicyNone,
+		NotifyEmail:  true,
+		Status:       EventChangeStatusPending,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// Validate performs business rule validation for the change
+func (c *EventChange) Validate() error {
+	switch c.Type {
+	case EventChangeCancellation:
+		if c.NewDate != nil {
+			return NewValidationError("new_date", "a cancelled event has no new date")
+		}
+		if c.RefundPolicy == RefundPolicyChoice {
+			return NewValidationError("refund_policy", "holders of a cancelled event cannot keep their tickets")
+		}
+	case EventChangePostponement:
+		if c.NewDate == nil {
+			return NewValidationError("new_date", "new date is required")
+		}
+		if !c.NewDate.After(time.Now()) {
+			return NewValidationError("new_date", "new date must be in the future")
+		}
+		if c.VoidTickets {
+			return NewValidationError("void_tickets", "tickets stay valid for a postponed event")
+		}
+	default:
+		return NewValidationError("type", "type must be cancellation or postponement")
+	}
+
+	switch c.RefundPolicy {
+	case RefundPolicyNone, RefundPolicyAutomatic:
+		if c.RefundDeadline != nil {
+			return NewValidationError("refund_deadline", "a refund deadline only applies when holders choose")
+		}
+	case RefundPolicyChoice:
+		if c.RefundDeadline == nil {
+			return NewValidationError("refund_deadline", "refund deadline is required")
+		}
+		if !c.RefundDeadline.After(time.Now()) {
+			return NewValidationError("refund_deadline", "refund deadline must be in the future")
+		}
+		if c.RefundDeadline.After(*c.NewDate) {
+			return NewValidationError("refund_deadline", "refund deadline must be before the new date")
+		}
+	default:
+		return NewValidationError("refund_policy", "refund policy must be none, automatic or choice")
+	}
+
+	if c.Message != nil && len(*c.Message) > 2000 {
+		return NewValidationError("message", "message must be 2000 characters or less")
+	}
+	return nil
+}
+
+// AcceptsChoices checks if holders can still choose between keeping and refunding
+func (c *EventChange) AcceptsChoices() bool {
+	return c.RefundPolicy == RefundPolicyChoice && c.RefundDeadline != nil && time.Now().Before(*c.RefundDeadline)
+}
+
+// IsFinished checks if every recipient has been processed
+func (c *EventChange) IsFinished() bool {
+	return c.Status == EventChangeStatusCompleted || c.Status == EventChangeStatusCompletedWithErrors
+}
+
+// MarkProcessing records that a worker has picked the change up
+func (c *EventChange) MarkProcessing() {
+	now := time.Now()
+	c.Status = EventChangeStatusProcessing
+	if c.StartedAt == nil {
+		c.StartedAt = &now
+	}
+	c.LastError = nil
+	c.UpdatedAt = now
+}
+
+// MarkFinished closes the change once no pending recipients remain
+func (c *EventChange) MarkFinished() {
+	now := time.Now()
+	c.Status = EventChangeStatusCompleted
+	if c.FailedRecipients > 0 {
+		c.Status = EventChangeStatusCompletedWithErrors
+	}
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+}
+
+// MarkFailed records a processing error; pending recipients can be resumed later
+func (c *EventChange) MarkFailed(message string) {
+	c.Status = EventChangeStatusFailed
+	c.LastError = &message
+	c.UpdatedAt = time.Now()
+}
+
+// EventChangeRecipientStatus represents the progress of one ticket holder
+type EventChangeRecipientStatus string
+
+const (
+	EventChangeRecipientPending EventChangeRecipientStatus = "pending"
+	EventChangeRecipientDone    EventChangeRecipientStatus = "done"
+	EventChangeRecipientFailed  EventChangeRecipientStatus = "failed"
+)
+
+// DeliveryStatus represents the outcome of one notification channel
+type DeliveryStatus string
+
+const (
+	DeliveryPending DeliveryStatus = "pending"
+	DeliverySent    DeliveryStatus = "sent"
+	DeliveryFailed  DeliveryStatus = "failed"
+	DeliverySkipped DeliveryStatus = "skipped"
+)
+
+// RefundChoice is a holder's answer to a keep-or-refund offer
+type RefundChoice string
+
+const (
+	RefundChoiceKeep   RefundChoice = "keep"
+	RefundChoiceRefund RefundChoice = "refund"
+)
+
+// RecipientRefundStatus represents the refund of one holder's order
+type RecipientRefundStatus string
+
+const (
+	RecipientRefundNone     RecipientRefundStatus = "none"
+	RecipientRefundPending  RecipientRefundStatus = "pending"
+	RecipientRefundRefunded RecipientRefundStatus = "refunded"
+	RecipientRefundFailed   RecipientRefundStatus = "failed"
+)
+
+// MaxRecipientAttempts is how often a holder is retried before being marked failed
+const MaxRecipientAttempts = 3
+
+// EventChangeRecipient is one order whose holder is voided, refunded and notified.
+// Each step records its own outcome so a resumed run only repeats what is unfinished.
+type EventChangeRecipient struct {
+	ID            uuid.UUID                  `json:"id" db:"id"`
+	ChangeID      uuid.UUID                  `json:"change_id" db:"change_id"`
+	OrderID       uuid.UUID                  `json:"order_id" db:"order_id"`
+	UserID        *uuid.UUID                 `json:"user_id,omitempty" db:"user_id"`
+	Name          *string                    `json:"name,omitempty" db:"name"`
+	Email         *string                    `json:"email,omitempty" db:"email"`
+	Phone         *string                    `json:"phone,omitempty" db:"phone"`
+	Status        EventChangeRecipientStatus `json:"status" db:"status"`
+	TicketsVoided bool                       `json:"tickets_voided" db:"tickets_voided"`
+	EmailStatus   DeliveryStatus             `json:"email_status" db:"email_status"`
+	SMSStatus     DeliveryStatus             `json:"sms_status" db:"sms_status"`
+	RefundStatus  RecipientRefundStatus      `json:"refund_status" db:"refund_status"`
+	RefundChoice  *RefundChoice              `json:"refund_choice,omitempty" db:"refund_choice"`
+	ChoiceToken   string                     `json:"-" db:"choice_token"`
+	ChosenAt      *time.Time                 `json:"chosen_at,omitempty" db:"chosen_at"`
+	Attempts      int                        `json:"attempts" db:"attempts"`
+	LastError     *string                    `json:"last_error,omitempty" db:"last_error"`
+	NotifiedAt    *time.Time                 `json:"notified_at,omitempty" db:"notified_at"`
+	CreatedAt     time.Time                  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time                  `json:"updated_at" db:"updated_at"`
+}
+
+// FirstName returns the first word of the recipient's name, for greetings
+func (r *EventChangeRecipient) FirstName() string {
+	if r.Name == nil {
+		return ""
+	}
+	parts := strings.Fields(*r.Name)
+	if len(parts) == 0 {
+		return ""
+	}
+	return parts[0]
+}
+
+// RecordAttemptError records a failed step. Once the attempts are used up, the
+// steps still pending are marked failed and the recipient is closed.
+func (r *EventChangeRecipient) RecordAttemptError(message string) {
+	r.Attempts++
+	r.LastError = &message
+	r.UpdatedAt = time.Now()
+	if r.Attempts < MaxRecipientAttempts {
+		return
+	}
+	if r.EmailStatus == DeliveryPending {
+		r.EmailStatus = DeliveryFailed
+	}
+	if r.SMSStatus == DeliveryPending {
+		r.SMSStatus = DeliveryFailed
+	}
+	if r.RefundStatus == RecipientRefundPending {
+		r.RefundStatus = RecipientRefundFailed
+	}
+	r.Status = EventChangeRecipientFailed
+}
+
+// Finish closes the recipient once no step is left pending
+func (r *EventChangeRecipient) Finish() {
+	now := time.Now()
+	r.Status = EventChangeRecipientDone
+	if r.EmailStatus == DeliveryFailed || r.SMSStatus == DeliveryFailed || r.RefundStatus == RecipientRefundFailed {
+		r.Status = EventChangeRecipientFailed
+	}
+	if r.NotifiedAt == nil && (r.EmailStatus == DeliverySent || r.SMSStatus == DeliverySent) {
+		r.NotifiedAt = &now
+	}
+	r.UpdatedAt = now
+}
+
+// Choose records the holder's keep-or-refund answer. Asking for a refund puts
+// the recipient back in the queue so the worker processes the refund.
+func (r *EventChangeRecipient) Choose(choice RefundChoice) error {
+	if choice != RefundChoiceKeep && choice != RefundChoiceRefund {
+		return NewValidationError("choice", "choice must be keep or refund")
+	}
+	if r.RefundChoice != nil {
+		return NewBusinessRuleError("choice_recorded", "a choice has already been recorded for this order", map[string]interface{}{
+			"choice": *r.RefundChoice,
+		})
+	}
+
+	now := time.Now()
+	r.RefundChoice = &choice
+	r.ChosenAt = &now
+	r.UpdatedAt = now
+	if choice == RefundChoiceRefund {
+		r.RefundStatus = RecipientRefundPending
+		r.Status = EventChangeRecipientPending
+		r.Attempts = 0
+	}
+	return nil
+}
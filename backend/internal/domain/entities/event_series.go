@@ -0,0 +1,218 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultSeriesHorizonDays is how far ahead occurrences are generated by default
+	DefaultSeriesHorizonDays = 90
+
+	// MaxSeriesHorizonDays caps how far ahead occurrences may be generated
+	MaxSeriesHorizonDays = 365
+
+	// MaxSeriesOccurrences caps the occurrences created in one generation run
+	MaxSeriesOccurrences = 100
+
+	// seriesDateLayout is the layout of series exception dates
+	seriesDateLayout = "2006-01-02"
+)
+
+// occurrenceSlugSuffix matches the date suffix of a generated occurrence slug
+var occurrenceSlugSuffix = regexp.MustCompile(`-\d{8}$`)
+
+// EventSeries is a recurring event. Its occurrences are real events copied,
+// with their ticket tiers, from the series template event.
+type EventSeries struct {
+	ID              uuid.UUID  `json:"id" db:"id"`
+	OrganizerID     uuid.UUID  `json:"organizer_id" db:"organizer_id"`
+	TemplateEventID uuid.UUID  `json:"template_event_id" db:"template_event_id"`
+	Name            string     `json:"name" db:"name"`
+	RRule           string     `json:"rrule" db:"rrule"`
+	Timezone        string     `json:"timezone" db:"timezone"`
+	StartsAt        time.Time  `json:"starts_at" db:"starts_at"`
+	ExceptionDates  DateList   `json:"exception_dates" db:"exception_dates"`
+	HorizonDays     int        `json:"horizon_days" db:"horizon_days"`
+	GeneratedUntil  *time.Time `json:"generated_until,omitempty" db:"generated_until"`
+	IsActive        bool       `json:"is_active" db:"is_active"`
+	CreatedAt       time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// NewEventSeries creates a series whose first occurrence is the template event
+func NewEventSeries(template *Event, name, rrule, timezone string) *EventSeries {
+	now := time.Now()
+	series := &EventSeries{
+		ID:              uuid.New(),
+		TemplateEventID: template.ID,
+		Name:            strings.TrimSpace(name),
+		RRule:           strings.TrimPrefix(strings.TrimSpace(rrule), "RRULE:"),
+		Timezone:        strings.TrimSpace(timezone),
+		StartsAt:        template.EventDate,
+		ExceptionDates:  DateList{},
+		HorizonDays:     DefaultSeriesHorizonDays,
+		IsActive:        true,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+	if template.OrganizerID != nil {
+		series.OrganizerID = *template.OrganizerID
+	}
+	if series.Name == "" {
+		series.Name = template.Name
+	}
+	if series.Timezone == "" {
+		series.Timezone = DefaultVenueTimezone
+	}
+	return series
+}
+
+// Validate performs business rule validation for the series
+func (s *EventSeries) Validate() error {
+	if s.Name == "" {
+		return NewValidationError("name", "name is required")
+	}
+	if len(s.Name) > 255 {
+		return NewValidationError("name", "name must be 255 characters or less")
+	}
+	if s.OrganizerID == uuid.Nil {
+		return NewValidationError("organizer_id", "the template event must belong to an organizer")
+	}
+	if _, err := time.LoadLocation(s.Timezone); err != nil {
+		return NewValidationError("timezone", "unknown timezone")
+	}
+	if s.HorizonDays < 1 || s.HorizonDays > MaxSeriesHorizonDays {
+		return NewValidationError("horizon_days", fmt.Sprintf("horizon must be between 1 and %d days", MaxSeriesHorizonDays))
+	}
+
+	rule, err := s.Rule()
+	if err != nil {
+		return err
+	}
+
+	// The template event is the first occurrence, so it must match the rule
+	start := s.LocalStart()
+	first := rule.Occurrences(start, start, 1)
+	if len(first) == 0 || !first[0].Equal(start) {
+		return NewValidationError("rrule", "the template event date must be an occurrence of the rule")
+	}
+
+	return nil
+}
+
+// Rule parses the series recurrence rule
+func (s *EventSeries) Rule() (*RecurrenceRule, error) {
+	return ParseRecurrenceRule(s.RRule)
+}
+
+// Location returns the series' time.Location, falling back to the default timezone
+func (s *EventSeries) Location() *time.Location {
+	if loc, err := time.LoadLocation(s.Timezone); err == nil {
+		return loc
+	}
+	loc, _ := time.LoadLocation(DefaultVenueTimezone)
+	return loc
+}
+
+// LocalStart returns the first occurrence in the series timezone; the rule is
+// expanded in local time so occurrences keep their wall clock time
+func (s *EventSeries) LocalStart() time.Time {
+	return s.StartsAt.In(s.Location())
+}
+
+// IsException checks if no occurrence should be held on the local date of t
+func (s *EventSeries) IsException(t time.Time) bool {
+	return s.ExceptionDates.Contains(s.LocalDate(t))
+}
+
+// SetExceptionDates replaces the dates on which no occurrence is held
+func (s *EventSeries) SetExceptionDates(dates []string) error {
+	list := make(DateList, 0, len(dates))
+	for _, date := range dates {
+		date = strings.TrimSpace(date)
+		if _, err := time.Parse(seriesDateLayout, date); err != nil {
+			return NewValidationError("exception_dates", fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", date))
+		}
+		if !list.Contains(date) {
+			list = append(list, date)
+		}
+	}
+	sort.Strings(list)
+	s.ExceptionDates = list
+	s.UpdatedAt = time.Now()
+	return nil
+}
+
+// OccurrenceSlug derives the slug of an occurrence from the template slug and
+// its local date, replacing the date suffix of a generated template slug
+func (s *EventSeries) OccurrenceSlug(templateSlug string, occurrence time.Time) string {
+	base := occurrenceSlugSuffix.ReplaceAllString(templateSlug, "")
+	return fmt.Sprintf("%s-%s", base, occurrence.In(s.Location()).Format("20060102"))
+}
+
+// LocalDate returns the calendar date of t in the series timezone
+func (s *EventSeries) LocalDate(t time.Time) string {
+	return t.In(s.Location()).Format(seriesDateLayout)
+}
+
+// OccurrenceStart combines the local date of day with the wall clock time of
+// clock, both taken in the series timezone
+func (s *EventSeries) OccurrenceStart(day, clock time.Time) time.Time {
+	loc := s.Location()
+	day = day.In(loc)
+	hour, minute, second := clock.In(loc).Clock()
+	return time.Date(day.Year(), day.Month(), day.Day(), hour, minute, second, 0, loc)
+}
+
+// DateList is a list of calendar dates (YYYY-MM-DD) stored as a JSONB array
+type DateList []string
+
+// Contains checks if the list holds the date
+func (d DateList) Contains(date string) bool {
+	for _, existing := range d {
+		if existing == date {
+			return true
+		}
+	}
+	return false
+}
+
+// Value implements the driver.Valuer interface for database writes
+func (d DateList) Value() (driver.Value, error) {
+	if d == nil {
+		return "[]", nil
+	}
+	b, err := json.Marshal([]string(d))
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface for database reads
+func (d *DateList) Scan(value interface{}) error {
+	if value == nil {
+		*d = DateList{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into DateList", value)
+	}
+
+	return json.Unmarshal(bytes, (*[]string)(d))
+}
@@ -0,0 +1,320 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// EventTemplate is a reusable event setup an organizer can create new events from
+type EventTemplate struct {
+	ID          uuid.UUID            `json:"id" db:"id"`
+	OrganizerID uuid.UUID            `json:"organizer_id" db:"organizer_id"`
+	Name        string               `json:"name" db:"name"`
+	Description *string              `json:"description,omitempty" db:"description"`
+	Content     EventTemplateContent `json:"content" db:"content"`
+	CreatedBy   *uuid.UUID           `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time            `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time            `json:"updated_at" db:"updated_at"`
+	IsActive    bool                 `json:"is_active" db:"is_active"`
+}
+
+// EventTemplateContent holds everything an event is set up with except its
+// date and slug. Times are stored as minutes relative to the event start, so
+// a sale opening two weeks before the show is -20160.
+type EventTemplateContent struct {
+	EventName       string                 `json:"event_name"`
+	Description     *string                `json:"description,omitempty"`
+	CategoryID      *uuid.UUID             `json:"category_id,omitempty"`
+	VenueID         *uuid.UUID             `json:"venue_id,omitempty"`
+	VenueName       string                 `json:"venue_name"`
+	VenueAddress    string                 `json:"venue_address"`
+	VenueCity       string                 `json:"venue_city"`
+	VenueState      *string                `json:"venue_state,omitempty"`
+	VenueCountry    *string                `json:"venue_country,omitempty"`
+	VenueCapacity   *int                   `json:"venue_capacity,omitempty"`
+	VenueLatitude   *float64               `json:"venue_latitude,omitempty"`
+	VenueLongitude  *float64               `json:"venue_longitude,omitempty"`
+	EventImageURL   *string                `json:"event_image_url,omitempty"`
+	ThumbnailURL    *string                `json:"thumbnail_url,omitempty"`
+	PromoVideoURL   *string                `json:"promo_video_url,omitempty"`
+	GalleryImages   []string               `json:"gallery_images,omitempty"`
+	Settings        map[string]interface{} `json:"settings,omitempty"`
+	Currency        string                 `json:"currency"`
+	EnableMomo      bool                   `json:"enable_momo"`
+	EnablePaystack  bool                   `json:"enable_paystack"`
+	DoorsOpenOffset *int                   `json:"doors_open_offset_minutes,omitempty"`
+	SaleStartOffset *int                   `json:"sale_start_offset_minutes,omitempty"`
+	SaleEndOffset   *int                   `json:"sale_end_offset_minutes,omitempty"`
+	TicketTiers     []TemplateTicketTier   `json:"ticket_tiers"`
+}
+
+// TemplateTicketTier is a ticket tier definition within an event template
+type TemplateTicketTier struct {
+	Name            string  `json:"name"`
+	Description     *string `json:"description,omitempty"`
+	Price           float64 `json:"price"`
+	Quota           int     `json:"quota"`
+	MinPurchase     int     `json:"min_per_order"`
+	MaxPurchase     int     `json:"max_per_order"`
+	ImageURL        *string `json:"image_url,omitempty"`
+	SaleStartOffset *int    `json:"sale_start_offset_minutes,omitempty"`
+	SaleEndOffset   *int    `json:"sale_end_offset_minutes,omitempty"`
+	OpensAfter      string  `json:"opens_after,omitempty"`
+	// PricingStrategy carries the tier's dynamic pricing over to new events
+	PricingStrategy *PricingStrategy `json:"pricing_strategy,omitempty"`
+}
+
+// NewEventTemplate creates a template from an event and its ticket tiers
+func NewEventTemplate(event *Event, tiers []*TicketTier, name string) *EventTemplate {
+	now := time.Now()
+	template := &EventTemplate{
+		ID:        uuid.New(),
+		Name:      strings.TrimSpace(name),
+		Content:   NewEventTemplateContent(event, tiers),
+		CreatedAt: now,
+		UpdatedAt: now,
+		IsActive:  true,
+	}
+	if event.OrganizerID != nil {
+		template.OrganizerID = *event.OrganizerID
+	}
+	if template.Name == "" {
+		template.Name = event.Name
+	}
+	return template
+}
+
+// NewEventTemplateContent captures an event's setup relative to its start
+func NewEventTemplateContent(event *Event, tiers []*TicketTier) EventTemplateContent {
+	content := EventTemplateContent{
+		EventName:       event.Name,
+		Description:     event.Description,
+		CategoryID:      event.CategoryID,
+		VenueID:         event.VenueID,
+		VenueName:       event.VenueName,
+		VenueAddress:    event.VenueAddress,
+		VenueCity:       event.VenueCity,
+		VenueState:      event.VenueState,
+		VenueCountry:    event.VenueCountry,
+		VenueCapacity:   event.VenueCapacity,
+		VenueLatitude:   event.VenueLatitude,
+		VenueLongitude:  event.VenueLongitude,
+		EventImageURL:   event.EventImageURL,
+		ThumbnailURL:    event.ThumbnailURL,
+		PromoVideoURL:   event.PromoVideoURL,
+		Settings:        map[string]interface{}(event.Settings),
+		Currency:        event.Currency,
+		EnableMomo:      event.EnableMomo,
+		EnablePaystack:  event.EnablePaystack,
+		DoorsOpenOffset: offsetMinutes(event.DoorsOpen, event.EventDate),
+		SaleStartOffset: offsetMinutes(event.SaleStart, event.EventDate),
+		SaleEndOffset:   offsetMinutes(event.SaleEnd, event.EventDate),
+		TicketTiers:     make([]TemplateTicketTier, 0, len(tiers)),
+	}
+	for _, image := range event.GalleryImages {
+		if url, ok := image.(string); ok {
+			content.GalleryImages = append(content.GalleryImages, url)
+		}
+	}
+	tierNames := make(map[uuid.UUID]string, len(tiers))
+	for _, tier := range tiers {
+		if tier.IsActive {
+			tierNames[tier.ID] = tier.Name
+		}
+	}
+	for _, tier := range tiers {
+		if !tier.IsActive {
+			continue
+		}
+		opensAfter := ""
+		if tier.OpensAfterTierID != nil {
+			opensAfter = tierNames[*tier.OpensAfterTierID]
+		}
+		content.TicketTiers = append(content.TicketTiers, TemplateTicketTier{
+			Name:            tier.Name,
+			Description:     tier.Description,
+			Price:           tier.Price,
+			Quota:           tier.Quota,
+			MinPurchase:     tier.MinPurchase,
+			MaxPurchase:     tier.MaxPurchase,
+			ImageURL:        tier.ImageURL,
+			SaleStartOffset: offsetMinutes(tier.SaleStart, event.EventDate),
+			SaleEndOffset:   offsetMinutes(tier.SaleEnd, event.EventDate),
+			OpensAfter:      opensAfter,
+			PricingStrategy: tier.PricingStrategy,
+		})
+	}
+	return content
+}
+
+// Validate performs business rule validation for the template
+func (t *EventTemplate) Validate() error {
+	if t.Name == "" {
+		return NewValidationError("name", "name is required")
+	}
+	if len(t.Name) > 255 {
+		return NewValidationError("name", "name must be 255 characters or less")
+	}
+	if t.OrganizerID == uuid.Nil {
+		return NewValidationError("organizer_id", "organizer is required")
+	}
+	if t.Content.EventName == "" {
+		return NewValidationError("content.event_name", "event name is required")
+	}
+	if t.Content.VenueID == nil && (t.Content.VenueName == "" || t.Content.VenueAddress == "" || t.Content.VenueCity == "") {
+		return NewValidationError("content.venue_id", "a venue or venue name, address and city are required")
+	}
+	if !IsSupportedCurrency(t.Content.Currency) {
+		return NewValidationError("content.currency", "unsupported currency")
+	}
+	for i, tier := range t.Content.TicketTiers {
+		if tier.Name == "" {
+			return NewValidationError(fmt.Sprintf("content.ticket_tiers[%d].name", i), "name is required")
+		}
+		if tier.Price < 0 {
+			return NewValidationError(fmt.Sprintf("content.ticket_tiers[%d].price", i), "price must be non-negative")
+		}
+		if tier.Quota <= 0 {
+			return NewValidationError(fmt.Sprintf("content.ticket_tiers[%d].quota", i), "quota must be positive")
+		}
+		if tier.PricingStrategy != nil {
+			if err := tier.PricingStrategy.Validate(); err != nil {
+				return err
+			}
+		}
+		if tier.OpensAfter != "" && t.Content.tierIndex(tier.OpensAfter) < 0 {
+			return NewValidationError(fmt.Sprintf("content.ticket_tiers[%d].opens_after", i), "must name another ticket tier of the template")
+		}
+	}
+	return nil
+}
+
+// Instantiate builds a draft event starting at start, with its ticket tiers.
+// The venue details are the ones saved in the template; callers refresh them
+// from the venue when the template references one.
+func (c EventTemplateContent) Instantiate(organizerID uuid.UUID, name, slug string, start time.Time) (*Event, []*TicketTier) {
+	if name == "" {
+		name = c.EventName
+	}
+	country := ""
+	if c.VenueCountry != nil {
+		country = *c.VenueCountry
+	}
+
+	event := NewEvent(organizerID, name, slug, start, c.VenueName, c.VenueAddress, c.VenueCity, country)
+	event.CategoryID = c.CategoryID
+	event.VenueID = c.VenueID
+	event.VenueState = c.VenueState
+	event.VenueCapacity = c.VenueCapacity
+	event.VenueLatitude = c.VenueLatitude
+	event.VenueLongitude = c.VenueLongitude
+	event.Description = c.Description
+	event.EventImageURL = c.EventImageURL
+	event.ThumbnailURL = c.ThumbnailURL
+	event.PromoVideoURL = c.PromoVideoURL
+	if len(c.GalleryImages) > 0 {
+		event.SetGallery(c.GalleryImages)
+	}
+	for key, value := range c.Settings {
+		event.Settings[key] = value
+	}
+	event.Currency = NormalizeCurrency(c.Currency)
+	event.EnableMomo = c.EnableMomo
+	event.EnablePaystack = c.EnablePaystack
+	event.DoorsOpen = atOffset(start, c.DoorsOpenOffset)
+	event.SaleStart = atOffset(start, c.SaleStartOffset)
+	event.SaleEnd = atOffset(start, c.SaleEndOffset)
+
+	tiers := make([]*TicketTier, 0, len(c.TicketTiers))
+	for i, definition := range c.TicketTiers {
+		tier := NewTicketTier(event.ID, definition.Name, definition.Price)
+		tier.Description = definition.Description
+		tier.Currency = event.Currency
+		tier.Quota = definition.Quota
+		if definition.MinPurchase > 0 {
+			tier.MinPurchase = definition.MinPurchase
+		}
+		if definition.MaxPurchase > 0 {
+			tier.MaxPurchase = definition.MaxPurchase
+		}
+		tier.ImageURL = definition.ImageURL
+		tier.SaleStart = atOffset(start, definition.SaleStartOffset)
+		tier.SaleEnd = atOffset(start, definition.SaleEndOffset)
+		tier.PricingStrategy = definition.PricingStrategy
+		tier.Position = i
+		tiers = append(tiers, tier)
+	}
+	for i, definition := range c.TicketTiers {
+		if previous := c.tierIndex(definition.OpensAfter); previous >= 0 && previous != i {
+			tiers[i].OpensAfterTierID = &tiers[previous].ID
+		}
+	}
+
+	return event, tiers
+}
+
+// tierIndex returns the position of the tier definition named name, or -1
+func (c EventTemplateContent) tierIndex(name string) int {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return -1
+	}
+	for i, tier := range c.TicketTiers {
+		if strings.EqualFold(strings.TrimSpace(tier.Name), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// Value implements the driver.Valuer interface for database writes
+func (c EventTemplateContent) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface for database reads
+func (c *EventTemplateContent) Scan(value interface{}) error {
+	if value == nil {
+		*c = EventTemplateContent{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into EventTemplateContent", value)
+	}
+
+	return json.Unmarshal(bytes, c)
+}
+
+// offsetMinutes returns t as minutes relative to start, or nil when t is nil
+func offsetMinutes(t *time.Time, start time.Time) *int {
+	if t == nil {
+		return nil
+	}
+	minutes := int(t.Sub(start).Round(time.Minute) / time.Minute)
+	return &minutes
+}
+
+// atOffset returns the time offset minutes from start, or nil when offset is nil
+func atOffset(start time.Time, offset *int) *time.Time {
+	if offset == nil {
+		return nil
+	}
+	t := start.Add(time.Duration(*offset) * time.Minute)
+	return &t
+}
@@ -0,0 +1,12 @@
+package entities
+
+// ValidateCoordinates checks that a latitude and longitude are in range
+func ValidateCoordinates(latitude, longitude float64) error {
+	if latitude < -90 || latitude > 90 {
+		return NewValidationError("latitude", "latitude must be between -90 and 90")
+	}
+	if longitude < -180 || longitude > 180 {
+		return NewValidationError("longitude", "longitude must be between -180 and 180")
+	}
+	return nil
+}
@@ -0,0 +1,390 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Where a marketing consent choice was made
+const (
+	ConsentSourceCheckout    = "checkout"
+	ConsentSourceSettings    = "settings"
+	ConsentSourceUnsubscribe = "unsubscribe_link"
+)
+
+// MarketingConsent is whether a contact agrees to receive marketing from one
+// organizer, or from the platform itself when OrganizerID is nil. Only the
+// latest choice is kept here; every choice is kept in the consent records.
+type MarketingConsent struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	ContactKey    string     `json:"-" db:"contact_key"`
+	UserID        *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	Email         *string    `json:"email,omitempty" db:"email"`
+	OrganizerID   *uuid.UUID `json:"organizer_id,omitempty" db:"organizer_id"`
+	Granted       bool       `json:"granted" db:"granted"`
+	Source        string     `json:"source" db:"source"`
+	PolicyVersion string     `json:"policy_version" db:"policy_version"`
+	GrantedAt     *time.Time `json:"granted_at,omitempty" db:"granted_at"`
+	WithdrawnAt   *time.Time `json:"withdrawn_at,omitempty" db:"withdrawn_at"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// MarketingConsentRecord is one consent choice as it was made, kept as the
+// audit trail: who chose, for which organizer, what, when, where and under
+// which version of the consent wording. Records are never changed or removed.
+type MarketingConsentRecord struct {
+	ID            uuid.UUID  `json:"id" db:"id"`
+	ContactKey    string     `json:"-" db:"contact_key"`
+	UserID        *uuid.UUID `json:"user_id,omitempty" db:"user_id"`
+	Email         *string    `json:"email,omitempty" db:"email"`
+	OrganizerID   *uuid.UUID `json:"organizer_id,omitempty" db:"organizer_id"`
+	Granted       bool       `json:"granted" db:"granted"`
+	Source        string     `json:"source" db:"source"`
+	PolicyVersion string     `json:"policy_version" db:"policy_version"`
+	IPAddress     *string    `json:"ip_address,omitempty" db:"ip_address"`
+	UserAgent     *string    `json:"user_agent,omitempty" db:"user_agent"`
+	OrderID       *uuid.UUID `json:"order_id,omitempty" db:"order_id"`
+	CreatedAt     time.Time  `json:"created_at" db:"created_at"`
+}
+
+// NewMarketingConsentRecord creates a consent choice for a contact
+func NewMarketingConsentRecord(contactKey string, userID *uuid.UUID, email *string, organizerID *uuid.UUID, granted bool, source, policyVersion string) *MarketingConsentRecord {
+	if email != nil {
+		normalized := strings.ToLower(strings.TrimSpace(*email))
+		email = &normalized
+	}
+	return &MarketingConsentRecord{
+		ID:            uuid.New(),
+		ContactKey:    contactKey,
+		UserID:        userID,
+		Email:         email,
+		OrganizerID:   organizerID,
+		Granted:       granted,
+		Source:        source,
+		PolicyVersion: policyVersion,
+		CreatedAt:     time.Now(),
+	}
+}
+
+// AudienceCriteria selects past buyers by what they bought. Every criterion
+// that is set must match: a buyer is in the audience when one of their paid
+// orders is for one of the events, includes one of the tiers, is for an
+// event in one of the cities and was placed within the dates, and their
+// total spend in Currency across all their paid orders is within the range.
+type AudienceCriteria struct {
+	EventIDs        []uuid.UUID `json:"event_ids,omitempty"`
+	TicketTierIDs   []uuid.UUID `json:"ticket_tier_ids,omitempty"`
+	Cities          []string    `json:"cities,omitempty"`
+	MinSpend        *float64    `json:"min_spend,omitempty"`
+	MaxSpend        *float64    `json:"max_spend,omitempty"`
+	Currency        string      `json:"currency,omitempty"`
+	PurchasedAfter  *time.Time  `json:"purchased_after,omitempty"`
+	PurchasedBefore *time.Time  `json:"purchased_before,omitempty"`
+}
+
+// Value implements the driver.Valuer interface for database writes
+func (c AudienceCriteria) Value() (driver.Value, error) {
+	b, err := json.Marshal(c)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface for database reads
+func (c *AudienceCriteria) Scan(value interface{}) error {
+	if value == nil {
+		*c = AudienceCriteria{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into AudienceCriteria", value)
+	}
+	return json.Unmarshal(bytes, c)
+}
+
+// MarketingAudience is a saved segment of an organizer's past buyers, or of
+// every buyer on the platform when OrganizerID is nil. Members are worked out
+// when a campaign is sent, and only those who consented are messaged.
+type MarketingAudience struct {
+	ID          uuid.UUID        `json:"id" db:"id"`
+	OrganizerID *uuid.UUID       `json:"organizer_id,omitempty" db:"organizer_id"`
+	Name        string           `json:"name" db:"name"`
+	Description *string          `json:"description,omitempty" db:"description"`
+	Criteria    AudienceCriteria `json:"criteria" db:"criteria"`
+	CreatedBy   *uuid.UUID       `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// NewMarketingAudience creates an audience for an organizer, or for the platform when organizerID is nil
+func NewMarketingAudience(organizerID *uuid.UUID, name string, criteria AudienceCriteria) *MarketingAudience {
+	now := time.Now()
+	audience := &MarketingAudience{
+		ID:          uuid.New(),
+		OrganizerID: organizerID,
+		Name:        strings.TrimSpace(name),
+		Criteria:    criteria,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+	audience.Normalize()
+	return audience
+}
+
+// Normalize tidies the criteria: cities are matched case-insensitively and
+// spend defaults to the platform currency
+func (a *MarketingAudience) Normalize() {
+	cities := make([]string, 0, len(a.Criteria.Cities))
+	for _, city := range a.Criteria.Cities {
+		if city = strings.ToLower(strings.TrimSpace(city)); city != "" {
+			cities = append(cities, city)
+		}
+	}
+	a.Criteria.Cities = cities
+	a.Criteria.Currency = NormalizeCurrency(a.Criteria.Currency)
+	if a.Criteria.Currency == "" {
+		a.Criteria.Currency = DefaultCurrency
+	}
+}
+
+// Validate performs business rule validation for the audience
+func (a *MarketingAudience) Validate() error {
+	if a.Name == "" || len(a.Name) > 100 {
+		return NewValidationError("name", "name is required and must be 100 characters or less")
+	}
+	if a.Description != nil && len(*a.Description) > 500 {
+		return NewValidationError("description", "description must be 500 characters or less")
+	}
+
+	criteria := a.Criteria
+	if criteria.MinSpend != nil && *criteria.MinSpend < 0 {
+		return NewValidationError("criteria.min_spend", "minimum spend cannot be negative")
+	}
+	if criteria.MinSpend != nil && criteria.MaxSpend != nil && *criteria.MaxSpend < *criteria.MinSpend {
+		return NewValidationError("criteria.max_spend", "maximum spend cannot be less than minimum spend")
+	}
+	if !IsSupportedCurrency(criteria.Currency) {
+		return NewValidationError("criteria.currency", "currency is not supported")
+	}
+	if criteria.PurchasedAfter != nil && criteria.PurchasedBefore != nil && !criteria.PurchasedAfter.Before(*criteria.PurchasedBefore) {
+		return NewValidationError("criteria.purchased_before", "purchased_before must be after purchased_after")
+	}
+	return nil
+}
+
+// MaxMarketingMessageLength caps the body of a marketing email
+const MaxMarketingMessageLength = 5000
+
+// MarketingCampaign is a marketing email sent to an audience's consenting
+// members at a scheduled time. Its progress follows the same statuses as
+// event campaigns, and each member's message is a MarketingDelivery.
+type MarketingCampaign struct {
+	ID                 uuid.UUID      `json:"id" db:"id"`
+	OrganizerID        *uuid.UUID     `json:"organizer_id,omitempty" db:"organizer_id"`
+	AudienceID         uuid.UUID      `json:"audience_id" db:"audience_id"`
+	Name               string         `json:"name" db:"name"`
+	Subject            string         `json:"subject" db:"subject"`
+	Message            string         `json:"message" db:"message"`
+	LinkURL            *string        `json:"link_url,omitempty" db:"link_url"`
+	LinkLabel          *string        `json:"link_label,omitempty" db:"link_label"`
+	Status             CampaignStatus `json:"status" db:"status"`
+	SendAt             time.Time      `json:"send_at" db:"send_at"`
+	TotalRecipients    int            `json:"total_recipients" db:"total_recipients"`
+	SentCount          int            `json:"sent_count" db:"sent_count"`
+	SkippedCount       int            `json:"skipped_count" db:"skipped_count"`
+	FailedCount        int            `json:"failed_count" db:"failed_count"`
+	LastError          *string        `json:"last_error,omitempty" db:"last_error"`
+	CreatedBy          *uuid.UUID     `json:"created_by,omitempty" db:"created_by"`
+	RecipientsStagedAt *time.Time     `json:"recipients_staged_at,omitempty" db:"recipients_staged_at"`
+	StartedAt          *time.Time     `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt        *time.Time     `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt          time.Time      `json:"created_at" db:"created_at"`
+	UpdatedAt          time.Time      `json:"updated_at" db:"updated_at"`
+}
+
+// NewMarketingCampaign creates a scheduled campaign for an audience, sent in the audience's scope
+func NewMarketingCampaign(audience *MarketingAudience, name, subject, message string, sendAt time.Time) *MarketingCampaign {
+	now := time.Now()
+	return &MarketingCampaign{
+		ID:          uuid.New(),
+		OrganizerID: audience.OrganizerID,
+		AudienceID:  audience.ID,
+		Name:        strings.TrimSpace(name),
+		Subject:     strings.TrimSpace(subject),
+		Message:     strings.TrimSpace(message),
+		Status:      CampaignStatusScheduled,
+		SendAt:      sendAt,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate performs business rule validation for the campaign
+func (c *MarketingCampaign) Validate() error {
+	if c.Name == "" || len(c.Name) > 100 {
+		return NewValidationError("name", "name is required and must be 100 characters or less")
+	}
+	if c.Subject == "" || len(c.Subject) > 150 {
+		return NewValidationError("subject", "subject is required and must be 150 characters or less")
+	}
+	if c.Message == "" || len(c.Message) > MaxMarketingMessageLength {
+		return NewValidationError("message", "message is required and must be 5000 characters or less")
+	}
+	if c.LinkURL != nil {
+		parsed, err := url.Parse(*c.LinkURL)
+		if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+			return NewValidationError("link_url", "link must be an http or https URL")
+		}
+	}
+	if c.LinkLabel != nil {
+		if c.LinkURL == nil {
+			return NewValidationError("link_label", "a link label needs a link")
+		}
+		if len(*c.LinkLabel) > 50 {
+			return NewValidationError("link_label", "link label must be 50 characters or less")
+		}
+	}
+	return nil
+}
+
+// IsEditable checks if the campaign has not started sending yet
+func (c *MarketingCampaign) IsEditable() bool {
+	return c.Status == CampaignStatusScheduled
+}
+
+// IsFinished checks if the campaign will not send anything more
+func (c *MarketingCampaign) IsFinished() bool {
+	switch c.Status {
+	case CampaignStatusCompleted, CampaignStatusCompletedWithErrors, CampaignStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// MarkProcessing records that the scheduler has picked the campaign up
+func (c *MarketingCampaign) MarkProcessing() {
+	now := time.Now()
+	c.Status = CampaignStatusProcessing
+	if c.StartedAt == nil {
+		c.StartedAt = &now
+	}
+	c.LastError = nil
+	c.UpdatedAt = now
+}
+
+// MarkFinished closes the campaign once no pending recipients remain
+func (c *MarketingCampaign) MarkFinished() {
+	now := time.Now()
+	c.Status = CampaignStatusCompleted
+	if c.FailedCount > 0 {
+		c.Status = CampaignStatusCompletedWithErrors
+	}
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+}
+
+// MarkFailed records a processing error; the scheduler resumes the campaign later
+func (c *MarketingCampaign) MarkFailed(message string) {
+	c.Status = CampaignStatusFailed
+	c.LastError = &message
+	c.UpdatedAt = time.Now()
+}
+
+// Close stops a campaign for good, e.g. cancelled by an admin
+func (c *MarketingCampaign) Close(status CampaignStatus, reason string) {
+	now := time.Now()
+	c.Status = status
+	if reason != "" {
+		c.LastError = &reason
+	}
+	c.CompletedAt = &now
+	c.UpdatedAt = now
+}
+
+// CampaignSkipNoConsent deliveries lost their member's consent after the audience was staged
+const CampaignSkipNoConsent = "no_consent"
+
+// MarketingDelivery is a marketing campaign's email to one audience member
+type MarketingDelivery struct {
+	ID               uuid.UUID              `json:"id" db:"id"`
+	CampaignID       uuid.UUID              `json:"campaign_id" db:"campaign_id"`
+	UserID           *uuid.UUID             `json:"user_id,omitempty" db:"user_id"`
+	ContactKey       string                 `json:"-" db:"contact_key"`
+	Name             *string                `json:"name,omitempty" db:"name"`
+	Email            string                 `json:"email" db:"email"`
+	Status           CampaignDeliveryStatus `json:"status" db:"status"`
+	SkipReason       *string                `json:"skip_reason,omitempty" db:"skip_reason"`
+	UnsubscribeToken string                 `json:"-" db:"unsubscribe_token"`
+	Attempts         int                    `json:"attempts" db:"attempts"`
+	LastError        *string                `json:"last_error,omitempty" db:"last_error"`
+	SentAt           *time.Time             `json:"sent_at,omitempty" db:"sent_at"`
+	CreatedAt        time.Time              `json:"created_at" db:"created_at"`
+	UpdatedAt        time.Time              `json:"updated_at" db:"updated_at"`
+}
+
+// FirstName returns the first word of the member's name, for greetings
+func (d *MarketingDelivery) FirstName() string {
+	if d.Name == nil {
+		return ""
+	}
+	parts := strings.Fields(*d.Name)
+	if len(parts) == 0 {
+		return ""
+	}
+	return parts[0]
+}
+
+// MarkSent records that the email was queued
+func (d *MarketingDelivery) MarkSent() {
+	now := time.Now()
+	d.Status = CampaignDeliverySent
+	d.LastError = nil
+	d.SentAt = &now
+	d.UpdatedAt = now
+}
+
+// Skip closes the delivery without sending anything
+func (d *MarketingDelivery) Skip(reason string) {
+	d.Status = CampaignDeliverySkipped
+	d.SkipReason = &reason
+	d.UpdatedAt = time.Now()
+}
+
+// Fail closes the delivery after an error that retrying cannot fix
+func (d *MarketingDelivery) Fail(message string) {
+	d.Attempts++
+	d.Status = CampaignDeliveryFailed
+	d.LastError = &message
+	d.UpdatedAt = time.Now()
+}
+
+// RecordAttemptError records a failed send; once the attempts are used up
+// the delivery is marked failed
+func (d *MarketingDelivery) RecordAttemptError(message string) {
+	d.Attempts++
+	d.LastError = &message
+	d.UpdatedAt = time.Now()
+	if d.Attempts >= MaxRecipientAttempts {
+		d.Status = CampaignDeliveryFailed
+	}
+}
+
+// EmailContactKey is the contact key of a customer known only by email
+func EmailContactKey(email string) string {
+	return "email:" + strings.ToLower(strings.TrimSpace(email))
+}
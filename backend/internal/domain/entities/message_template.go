@@ -0,0 +1,209 @@
+package entities
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// Message locales. Messages are written in the recipient's locale and fall
+// back to English where a template has no translation.
+const (
+	LocaleEnglish = "en"
+	LocaleYoruba  = "yo"
+	LocaleHausa   = "ha"
+	LocaleIgbo    = "ig"
+	LocaleFrench  = "fr"
+
+	DefaultLocale = LocaleEnglish
+)
+
+// SupportedLocales lists the locales messages can be written in
+var SupportedLocales = []string{LocaleEnglish, LocaleYoruba, LocaleHausa, LocaleIgbo, LocaleFrench}
+
+// UserSettingLocale is the key of the preferred message locale in a user's settings
+const UserSettingLocale = "locale"
+
+// DefaultBrandColor is the header colour of messages without organizer branding
+const DefaultBrandColor = "#667eea"
+
+const (
+	messageTemplateMaxKeyLength     = 100
+	messageTemplateMaxSubjectLength = 255
+	messageTemplateMaxBodyLength    = 100000
+	brandingMaxNameLength           = 100
+	brandingMaxFooterLength         = 500
+)
+
+var (
+	messageTemplateKeyPattern = regexp.MustCompile(`^[a-z][a-z0-9_]*$`)
+	brandColorPattern         = regexp.MustCompile(`^#[0-9a-fA-F]{6}$`)
+)
+
+// IsSupportedLocale reports whether messages can be written in a locale
+func IsSupportedLocale(locale string) bool {
+	for _, supported := range SupportedLocales {
+		if locale == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeLocale reduces a locale such as "fr-FR" or "YO" to a supported
+// language code, and returns DefaultLocale for anything unsupported
+func NormalizeLocale(locale string) string {
+	locale = strings.ToLower(strings.TrimSpace(locale))
+	if i := strings.IndexAny(locale, "-_"); i >= 0 {
+		locale = locale[:i]
+	}
+	if IsSupportedLocale(locale) {
+		return locale
+	}
+	return DefaultLocale
+}
+
+// MessageTemplate is one version of an override of a built-in message
+// template, for every organizer or for one. Saving a template adds a new
+// version; only the active version of a key, channel, locale and organizer is used.
+type MessageTemplate struct {
+	ID          uuid.UUID           `json:"id" db:"id"`
+	Key         string              `json:"key" db:"key"`
+	Channel     NotificationChannel `json:"channel" db:"channel"`
+	Locale      string              `json:"locale" db:"locale"`
+	OrganizerID *uuid.UUID          `json:"organizer_id,omitempty" db:"organizer_id"`
+	Version     int                 `json:"version" db:"version"`
+	Subject     string              `json:"subject" db:"subject"`
+	HTMLBody    string              `json:"html_body" db:"html_body"`
+	TextBody    string              `json:"text_body" db:"text_body"`
+	IsActive    bool                `json:"is_active" db:"is_active"`
+	CreatedBy   *uuid.UUID          `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// NewMessageTemplate creates an active template version; the repository assigns the version number
+func NewMessageTemplate(key string, channel NotificationChannel, locale string, organizerID *uuid.UUID) *MessageTemplate {
+	now := time.Now()
+	return &MessageTemplate{
+		ID:          uuid.New(),
+		Key:         key,
+		Channel:     channel,
+		Locale:      locale,
+		OrganizerID: organizerID,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate validates the message template. Emails need a subject and a
+// body; text channels have a text body only.
+func (t *MessageTemplate) Validate() error {
+	if !messageTemplateKeyPattern.MatchString(t.Key) || len(t.Key) > messageTemplateMaxKeyLength {
+		return NewValidationError("key", "key must be lowercase letters, digits and underscores")
+	}
+	if !IsSupportedLocale(t.Locale) {
+		return NewValidationError("locale", "locale must be one of en, yo, ha, ig, fr")
+	}
+	if utf8.RuneCountInString(t.Subject) > messageTemplateMaxSubjectLength {
+		return NewValidationError("subject", "subject must be at most 255 characters")
+	}
+	if len(t.HTMLBody) > messageTemplateMaxBodyLength || len(t.TextBody) > messageTemplateMaxBodyLength {
+		return NewValidationError("body", "template body is too long")
+	}
+
+	switch t.Channel {
+	case NotificationChannelEmail:
+		if strings.TrimSpace(t.Subject) == "" {
+			return NewValidationError("subject", "subject is required for email templates")
+		}
+		if strings.TrimSpace(t.HTMLBody) == "" && strings.TrimSpace(t.TextBody) == "" {
+			return NewValidationError("html_body", "an HTML or text body is required")
+		}
+	case NotificationChannelSMS, NotificationChannelWhatsApp:
+		if t.Subject != "" || t.HTMLBody != "" {
+			return NewValidationError("html_body", "text message templates have a text body only")
+		}
+		if strings.TrimSpace(t.TextBody) == "" {
+			return NewValidationError("text_body", "text body is required")
+		}
+	default:
+		return NewValidationError("channel", "channel must be email, sms or whatsapp")
+	}
+	return nil
+}
+
+// IsGlobal reports whether the template applies to every organizer
+func (t *MessageTemplate) IsGlobal() bool {
+	return t.OrganizerID == nil
+}
+
+// Deactivate stops the version from being used
+func (t *MessageTemplate) Deactivate(now time.Time) {
+	t.IsActive = false
+	t.UpdatedAt = now
+}
+
+// Activate makes the version the one in use
+func (t *MessageTemplate) Activate(now time.Time) {
+	t.IsActive = true
+	t.UpdatedAt = now
+}
+
+// OrganizerBranding is how an organizer's messages look: the name and logo
+// in the header, the header colour and the footer
+type OrganizerBranding struct {
+	OrganizerID  uuid.UUID `json:"organizer_id" db:"organizer_id"`
+	DisplayName  string    `json:"display_name" db:"display_name"`
+	LogoURL      *string   `json:"logo_url,omitempty" db:"logo_url"`
+	PrimaryColor string    `json:"primary_color" db:"primary_color"`
+	FooterText   string    `json:"footer_text" db:"footer_text"`
+	SupportEmail *string   `json:"support_email,omitempty" db:"support_email"`
+	CreatedAt    time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NewOrganizerBranding creates the branding an organizer starts with: its
+// name and logo with the default colour
+func NewOrganizerBranding(organizer *Organizer) *OrganizerBranding {
+	now := time.Now()
+	return &OrganizerBranding{
+		OrganizerID:  organizer.ID,
+		DisplayName:  organizer.Name,
+		LogoURL:      organizer.LogoURL,
+		PrimaryColor: DefaultBrandColor,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// Validate validates the organizer branding
+func (b *OrganizerBranding) Validate() error {
+	if strings.TrimSpace(b.DisplayName) == "" {
+		return NewValidationError("display_name", "display name is required")
+	}
+	if utf8.RuneCountInString(b.DisplayName) > brandingMaxNameLength {
+		return NewValidationError("display_name", "display name must be at most 100 characters")
+	}
+	if !brandColorPattern.MatchString(b.PrimaryColor) {
+		return NewValidationError("primary_color", "primary color must be a hex colour such as #667eea")
+	}
+	if utf8.RuneCountInString(b.FooterText) > brandingMaxFooterLength {
+		return NewValidationError("footer_text", "footer text must be at most 500 characters")
+	}
+	if b.LogoURL != nil && *b.LogoURL != "" {
+		parsed, err := url.Parse(*b.LogoURL)
+		if err != nil || parsed.Host == "" || (parsed.Scheme != "https" && parsed.Scheme != "http") {
+			return NewValidationError("logo_url", "logo URL must be an http or https URL")
+		}
+	}
+	if b.SupportEmail != nil && *b.SupportEmail != "" && !strings.Contains(*b.SupportEmail, "@") {
+		return NewValidationError("support_email", "support email must be a valid email address")
+	}
+	return nil
+}
@@ -0,0 +1,224 @@
+package entities
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+// NotificationChannel identifies how a notification reaches its recipient
+type NotificationChannel string
+
+const (
+	NotificationChannelEmail    NotificationChannel = "email"
+	NotificationChannelSMS      NotificationChannel = "sms"
+	NotificationChannelWhatsApp NotificationChannel = "whatsapp"
+)
+
+// NotificationStatus represents the delivery state of a notification message
+type NotificationStatus string
+
+const (
+	// NotificationQueued messages wait to be handed to the provider
+	NotificationQueued NotificationStatus = "queued"
+	// NotificationSent messages were accepted by the provider
+	NotificationSent NotificationStatus = "sent"
+	// NotificationDelivered messages were confirmed by a delivery receipt
+	NotificationDelivered NotificationStatus = "delivered"
+	// NotificationFailed messages were rejected, undeliverable or ran out of attempts
+	NotificationFailed NotificationStatus = "failed"
+)
+
+const (
+	// DefaultPhoneCountryCode is assumed for local numbers such as 0803 123 4567
+	DefaultPhoneCountryCode = "234"
+
+	// MaxSMSLength caps a message at six concatenated segments
+	MaxSMSLength = 918
+
+	// notificationMaxErrorLength keeps stored errors readable
+	notificationMaxErrorLength = 1000
+)
+
+// NotificationMessage is one message sent to one recipient over a channel,
+// kept as the delivery log and updated from the provider's delivery receipts
+type NotificationMessage struct {
+	ID                uuid.UUID           `json:"id" db:"id"`
+	Channel           NotificationChannel `json:"channel" db:"channel"`
+	Recipient         string              `json:"recipient" db:"recipient"`
+	SenderID          *string             `json:"sender_id,omitempty" db:"sender_id"`
+	Template          *string             `json:"template,omitempty" db:"template"`
+	Body              string              `json:"body" db:"body"`
+	Status            NotificationStatus  `json:"status" db:"status"`
+	Provider          *string             `json:"provider,omitempty" db:"provider"`
+	ProviderMessageID *string             `json:"provider_message_id,omitempty" db:"provider_message_id"`
+	LastError         *string             `json:"last_error,omitempty" db:"last_error"`
+	UserID            *uuid.UUID          `json:"user_id,omitempty" db:"user_id"`
+	OrderID           *uuid.UUID          `json:"order_id,omitempty" db:"order_id"`
+	SentAt            *time.Time          `json:"sent_at,omitempty" db:"sent_at"`
+	DeliveredAt       *time.Time          `json:"delivered_at,omitempty" db:"delivered_at"`
+	FailedAt          *time.Time          `json:"failed_at,omitempty" db:"failed_at"`
+	CreatedAt         time.Time           `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time           `json:"updated_at" db:"updated_at"`
+}
+
+// NewNotificationMessage creates a queued message to a recipient
+func NewNotificationMessage(channel NotificationChannel, recipient, body string) *NotificationMessage {
+	now := time.Now()
+	return &NotificationMessage{
+		ID:        uuid.New(),
+		Channel:   channel,
+		Recipient: recipient,
+		Body:      body,
+		Status:    NotificationQueued,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate validates the notification message
+func (m *NotificationMessage) Validate() error {
+	if m.Channel == "" {
+		return NewValidationError("channel", "channel is required")
+	}
+	if m.Recipient == "" {
+		return NewValidationError("recipient", "recipient is required")
+	}
+	if strings.TrimSpace(m.Body) == "" {
+		return NewValidationError("body", "message body is required")
+	}
+	if m.Channel == NotificationChannelSMS && utf8.RuneCountInString(m.Body) > MaxSMSLength {
+		return NewValidationError("body", "message is too long for an SMS")
+	}
+	return nil
+}
+
+// MarkSent records that the provider accepted the message
+func (m *NotificationMessage) MarkSent(provider, providerMessageID string, now time.Time) {
+	m.Status = NotificationSent
+	m.Provider = &provider
+	if providerMessageID != "" {
+		m.ProviderMessageID = &providerMessageID
+	}
+	m.LastError = nil
+	m.SentAt = &now
+	m.UpdatedAt = now
+}
+
+// MarkFailed gives up on the message
+func (m *NotificationMessage) MarkFailed(message string, now time.Time) {
+	if len(message) > notificationMaxErrorLength {
+		message = message[:notificationMaxErrorLength]
+	}
+	m.Status = NotificationFailed
+	m.LastError = &message
+	m.FailedAt = &now
+	m.UpdatedAt = now
+}
+
+// RecordAttemptError notes why an attempt failed while the message stays queued for a retry
+func (m *NotificationMessage) RecordAttemptError(message string, now time.Time) {
+	if len(message) > notificationMaxErrorLength {
+		message = message[:notificationMaxErrorLength]
+	}
+	m.LastError = &message
+	m.UpdatedAt = now
+}
+
+// ApplyReceipt updates the message from a provider delivery receipt. Receipts
+// may arrive out of order, so a delivered message stays delivered.
+func (m *NotificationMessage) ApplyReceipt(status NotificationStatus, message string, now time.Time) {
+	if m.Status == NotificationDelivered {
+		return
+	}
+
+	switch status {
+	case NotificationDelivered:
+		m.Status = NotificationDelivered
+		m.DeliveredAt = &now
+		m.LastError = nil
+		m.UpdatedAt = now
+	case NotificationFailed:
+		if message == "" {
+			message = "provider reported the message as undelivered"
+		}
+		m.MarkFailed(message, now)
+	}
+}
+
+// WhatsAppOptIn records whether a user agreed to receive messages on WhatsApp,
+// which the WhatsApp Business policy requires before a business messages them
+type WhatsAppOptIn struct {
+	UserID     uuid.UUID  `json:"user_id" db:"user_id"`
+	Phone      string     `json:"phone" db:"phone"`
+	OptedIn    bool       `json:"opted_in" db:"opted_in"`
+	Source     string     `json:"source" db:"source"`
+	OptedInAt  *time.Time `json:"opted_in_at,omitempty" db:"opted_in_at"`
+	OptedOutAt *time.Time `json:"opted_out_at,omitempty" db:"opted_out_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt  time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// NewWhatsAppOptIn creates a user's WhatsApp opt-in record, opted out until OptIn is called
+func NewWhatsAppOptIn(userID uuid.UUID) *WhatsAppOptIn {
+	now := time.Now()
+	return &WhatsAppOptIn{
+		UserID:    userID,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// OptIn records consent to WhatsApp messages on phone. source says where the
+// consent was given, e.g. "checkout" or "account".
+func (o *WhatsAppOptIn) OptIn(phone, source string, now time.Time) {
+	o.Phone = phone
+	o.OptedIn = true
+	o.Source = source
+	o.OptedInAt = &now
+	o.OptedOutAt = nil
+	o.UpdatedAt = now
+}
+
+// OptOut withdraws consent; the phone number is kept for the record
+func (o *WhatsAppOptIn) OptOut(now time.Time) {
+	o.OptedIn = false
+	o.OptedOutAt = &now
+	o.UpdatedAt = now
+}
+
+// NormalizePhoneNumber converts a phone number to E.164, e.g. "0803 123 4567"
+// becomes "+2348031234567". Local numbers are assumed to be Nigerian.
+func NormalizePhoneNumber(phone string) (string, error) {
+	var digits strings.Builder
+	trimmed := strings.TrimSpace(phone)
+	for i, r := range trimmed {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ' || r == '-' || r == '(' || r == ')' || r == '.':
+		default:
+			return "", NewValidationError("phone", "phone number contains invalid characters")
+		}
+	}
+
+	number := digits.String()
+	switch {
+	case strings.HasPrefix(trimmed, "+"):
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	case strings.HasPrefix(number, "0"):
+		number = DefaultPhoneCountryCode + number[1:]
+	case strings.HasPrefix(number, DefaultPhoneCountryCode):
+	default:
+		return "", NewValidationError("phone", "phone number must include a country code")
+	}
+
+	if len(number) < 8 || len(number) > 15 || number[0] == '0' {
+		return "", NewValidationError("phone", "phone number is not valid")
+	}
+	return "+" + number, nil
+}
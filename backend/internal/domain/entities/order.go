@@ -27,8 +27,15 @@ const (
 	PaymentMethodMoMo     PaymentMethod = "momo"
 	PaymentMethodPaystack PaymentMethod = "paystack"
 	PaymentMethodCard     PaymentMethod = "card"
+	PaymentMethodCash     PaymentMethod = "cash"
+	PaymentMethodPOS      PaymentMethod = "pos_terminal"
 )
 
+// IsOffline returns true for payment methods taken in person at the box office
+func (pm PaymentMethod) IsOffline() bool {
+	return pm == PaymentMethodCash || pm == PaymentMethodPOS
+}
+
 // Order represents shopping cart and purchase transactions
 type Order struct {
 	ID                 uuid.UUID              `json:"id" db:"id"`
@@ -62,6 +69,9 @@ type Order struct {
 	Comment            *string                `json:"comment,omitempty" db:"comment"`
 	MetaInfo           map[string]interface{} `json:"meta_info" db:"meta_info"`
 	IsActive           bool                   `json:"is_active" db:"is_active"`
+	IsComp             bool                   `json:"is_comp" db:"is_comp"`
+	IsImported         bool                   `json:"is_imported" db:"is_imported"`
+	ExternalReference  *string                `json:"external_reference,omitempty" db:"external_reference"`
 	CreatedAt          time.Time              `json:"created_at" db:"created_at"`
 	UpdatedAt          time.Time              `json:"updated_at" db:"updated_at"`
 
@@ -80,7 +90,7 @@ func NewOrder(eventID string, email string) *Order {
 		EventID:   eventID,
 		Email:     email,
 		Status:    OrderStatusPending,
-		Currency:  "NGN",
+		Currency:  DefaultCurrency,
 		ExpiresAt: time.Now().Add(15 * time.Minute).UTC(), // Add 15 minutes THEN convert to UTC
 		Secret:    generateSecret(),
 		Locale:    "en",
@@ -140,7 +150,7 @@ func (o *Order) Cancel() error {
 
 // Refund refunds the order
 func (o *Order) Refund() error {
-	if o.Status != OrderStatusPaid {
+	if o.Status != OrderStatusPaid && o.Status != OrderStatusConfirmed {
 		return NewBusinessRuleError("refund", "only paid orders can be refunded", nil)
 	}
 	o.Status = OrderStatusRefunded
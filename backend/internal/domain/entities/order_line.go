@@ -21,6 +21,12 @@ type OrderLine struct {
 	CreatedAt      time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
 
+	// Price quote for tiers with dynamic pricing: the tier's base price and the
+	// price quoted at hold time, which stays locked until the hold expires
+	BasePrice      *float64   `json:"base_price,omitempty" db:"base_price"`
+	QuotedPrice    *float64   `json:"quoted_price,omitempty" db:"quoted_price"`
+	QuoteExpiresAt *time.Time `json:"quote_expires_at,omitempty" db:"quote_expires_at"`
+
 	// Denormalised fields (populated by JOIN queries in GetByOrder/GetByID)
 	TicketTierName        string  `json:"ticket_tier_name,omitempty" db:"ticket_tier_name"`
 	TicketTierDescription string  `json:"ticket_tier_description,omitempty" db:"ticket_tier_description"`
@@ -54,6 +60,16 @@ func NewOrderLine(orderID, ticketTierID uuid.UUID, quantity int, unitPrice float
 	}
 }
 
+// LockQuote records that the line's unit price was quoted from basePrice and
+// holds until expiresAt
+func (ol *OrderLine) LockQuote(basePrice float64, expiresAt time.Time) {
+	quotedPrice := ol.UnitPrice
+	ol.BasePrice = &basePrice
+	ol.QuotedPrice = &quotedPrice
+	ol.QuoteExpiresAt = &expiresAt
+	ol.UpdatedAt = time.Now()
+}
+
 // GetTotal returns the total price for this line item including fees and taxes minus discounts
 func (ol *OrderLine) GetTotal() float64 {
 	return ol.Subtotal + ol.Fees + ol.Taxes - ol.DiscountAmount
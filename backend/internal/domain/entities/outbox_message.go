@@ -0,0 +1,154 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// OutboxStatus represents the delivery state of an outbox message
+type OutboxStatus string
+
+const (
+	// OutboxStatusPending messages wait for their next delivery attempt
+	OutboxStatusPending OutboxStatus = "pending"
+	// OutboxStatusDelivered messages were handed off successfully
+	OutboxStatusDelivered OutboxStatus = "delivered"
+	// OutboxStatusDead messages ran out of attempts and wait for an admin to retry them
+	OutboxStatusDead OutboxStatus = "dead"
+)
+
+const (
+	// DefaultOutboxMaxAttempts is how often a message is tried before it is dead-lettered
+	DefaultOutboxMaxAttempts = 10
+
+	// outboxBaseBackoff is the wait after the first failed attempt; it doubles per attempt
+	outboxBaseBackoff = 30 * time.Second
+
+	// outboxMaxBackoff caps the wait between two attempts
+	outboxMaxBackoff = time.Hour
+
+	// outboxMaxErrorLength keeps stored errors readable
+	outboxMaxErrorLength = 1000
+)
+
+// OutboxMessage is a side effect, such as an email, recorded in the same
+// transaction as the change that causes it and delivered afterwards by the
+// dispatcher. Delivery is at least once: a message may be delivered again if
+// the dispatcher stops between delivering it and recording the result.
+type OutboxMessage struct {
+	ID            uuid.UUID    `json:"id" db:"id"`
+	Topic         string       `json:"topic" db:"topic"`
+	AggregateType *string      `json:"aggregate_type,omitempty" db:"aggregate_type"`
+	AggregateID   *uuid.UUID   `json:"aggregate_id,omitempty" db:"aggregate_id"`
+	Payload       JSONB        `json:"payload" db:"payload"`
+	Status        OutboxStatus `json:"status" db:"status"`
+	Attempts      int          `json:"attempts" db:"attempts"`
+	MaxAttempts   int          `json:"max_attempts" db:"max_attempts"`
+	NextAttemptAt time.Time    `json:"next_attempt_at" db:"next_attempt_at"`
+	LockedUntil   *time.Time   `json:"locked_until,omitempty" db:"locked_until"`
+	LastError     *string      `json:"last_error,omitempty" db:"last_error"`
+	DeliveredAt   *time.Time   `json:"delivered_at,omitempty" db:"delivered_at"`
+	DeadAt        *time.Time   `json:"dead_at,omitempty" db:"dead_at"`
+	CreatedAt     time.Time    `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time    `json:"updated_at" db:"updated_at"`
+}
+
+// NewOutboxMessage creates a pending message that is due immediately
+func NewOutboxMessage(topic string, payload JSONB) *OutboxMessage {
+	now := time.Now()
+	if payload == nil {
+		payload = JSONB{}
+	}
+	return &OutboxMessage{
+		ID:            uuid.New(),
+		Topic:         topic,
+		Payload:       payload,
+		Status:        OutboxStatusPending,
+		MaxAttempts:   DefaultOutboxMaxAttempts,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// SetAggregate records the entity the message is about, e.g. the order whose tickets it emails
+func (m *OutboxMessage) SetAggregate(aggregateType string, aggregateID uuid.UUID) {
+	m.AggregateType = &aggregateType
+	m.AggregateID = &aggregateID
+}
+
+// Validate performs business rule validation for the outbox message
+func (m *OutboxMessage) Validate() error {
+	if strings.TrimSpace(m.Topic) == "" {
+		return NewValidationError("topic", "outbox topic is required")
+	}
+	if m.MaxAttempts <= 0 {
+		return NewValidationError("max_attempts", "max attempts must be greater than 0")
+	}
+	return nil
+}
+
+// MarkDelivered records a successful delivery
+func (m *OutboxMessage) MarkDelivered(now time.Time) {
+	m.Status = OutboxStatusDelivered
+	m.Attempts++
+	m.DeliveredAt = &now
+	m.LockedUntil = nil
+	m.LastError = nil
+	m.UpdatedAt = now
+}
+
+// MarkFailed records a failed attempt and schedules the next one with
+// exponential backoff, or dead-letters the message once it is out of attempts
+func (m *OutboxMessage) MarkFailed(message string, now time.Time) {
+	if len(message) > outboxMaxErrorLength {
+		message = message[:outboxMaxErrorLength]
+	}
+
+	m.Attempts++
+	m.LastError = &message
+	m.LockedUntil = nil
+	m.UpdatedAt = now
+
+	if m.Attempts >= m.MaxAttempts {
+		m.Status = OutboxStatusDead
+		m.DeadAt = &now
+		return
+	}
+	m.NextAttemptAt = now.Add(OutboxBackoff(m.Attempts))
+}
+
+// Retry puts a dead or waiting message back in the queue for an immediate
+// attempt with a fresh set of attempts
+func (m *OutboxMessage) Retry() error {
+	if m.Status == OutboxStatusDelivered {
+		return NewBusinessRuleError("outbox_message_delivered", "the message was already delivered", nil)
+	}
+
+	now := time.Now()
+	m.Status = OutboxStatusPending
+	m.Attempts = 0
+	m.NextAttemptAt = now
+	m.LockedUntil = nil
+	m.DeadAt = nil
+	m.UpdatedAt = now
+	return nil
+}
+
+// OutboxBackoff returns the wait after the given number of failed attempts:
+// 30s, 1m, 2m, 4m and so on, capped at an hour
+func OutboxBackoff(attempts int) time.Duration {
+	if attempts < 1 {
+		return 0
+	}
+	backoff := outboxBaseBackoff
+	for i := 1; i < attempts; i++ {
+		backoff *= 2
+		if backoff >= outboxMaxBackoff {
+			return outboxMaxBackoff
+		}
+	}
+	return backoff
+}
@@ -0,0 +1,290 @@
+package entities
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RecurrenceFrequency is the FREQ of a recurrence rule
+type RecurrenceFrequency string
+
+const (
+	RecurrenceWeekly  RecurrenceFrequency = "WEEKLY"
+	RecurrenceMonthly RecurrenceFrequency = "MONTHLY"
+)
+
+// maxRecurrencePeriods bounds how many weeks or months a rule is expanded over
+const maxRecurrencePeriods = 1000
+
+var recurrenceWeekdays = map[string]time.Weekday{
+	"MO": time.Monday,
+	"TU": time.Tuesday,
+	"WE": time.Wednesday,
+	"TH": time.Thursday,
+	"FR": time.Friday,
+	"SA": time.Saturday,
+	"SU": time.Sunday,
+}
+
+// RecurrenceDay is a BYDAY entry; Ordinal is the nth weekday of the month
+// (negative counts from the end) and is only used by monthly rules
+type RecurrenceDay struct {
+	Weekday time.Weekday
+	Ordinal int
+}
+
+// RecurrenceRule is the supported subset of an RFC 5545 RRULE: weekly or
+// monthly frequency with INTERVAL, BYDAY, BYMONTHDAY, COUNT and UNTIL
+type RecurrenceRule struct {
+	Frequency  RecurrenceFrequency
+	Interval   int
+	ByDay      []RecurrenceDay
+	ByMonthDay []int
+	Count      int
+	Until      *time.Time
+}
+
+// ParseRecurrenceRule parses an RRULE such as "FREQ=WEEKLY;BYDAY=FR;COUNT=10".
+// A leading "RRULE:" is accepted.
+func ParseRecurrenceRule(value string) (*RecurrenceRule, error) {
+	value = strings.TrimPrefix(strings.TrimSpace(value), "RRULE:")
+	if value == "" {
+		return nil, NewValidationError("rrule", "recurrence rule is required")
+	}
+
+	rule := &RecurrenceRule{Interval: 1}
+	for _, part := range strings.Split(value, ";") {
+		key, val, ok := strings.Cut(part, "=")
+		if !ok || val == "" {
+			return nil, NewValidationError("rrule", fmt.Sprintf("invalid rule part %q", part))
+		}
+
+		switch strings.ToUpper(key) {
+		case "FREQ":
+			rule.Frequency = RecurrenceFrequency(strings.ToUpper(val))
+		case "INTERVAL":
+			interval, err := strconv.Atoi(val)
+			if err != nil || interval < 1 {
+				return nil, NewValidationError("rrule", "INTERVAL must be a positive number")
+			}
+			rule.Interval = interval
+		case "COUNT":
+			count, err := strconv.Atoi(val)
+			if err != nil || count < 1 {
+				return nil, NewValidationError("rrule", "COUNT must be a positive number")
+			}
+			rule.Count = count
+		case "UNTIL":
+			until, err := parseRecurrenceTime(val)
+			if err != nil {
+				return nil, NewValidationError("rrule", "UNTIL must be a date (20060102) or UTC date-time (20060102T150405Z)")
+			}
+			rule.Until = &until
+		case "BYDAY":
+			for _, day := range strings.Split(val, ",") {
+				parsed, err := parseRecurrenceDay(strings.ToUpper(day))
+				if err != nil {
+					return nil, err
+				}
+				rule.ByDay = append(rule.ByDay, parsed)
+			}
+		case "BYMONTHDAY":
+			for _, day := range strings.Split(val, ",") {
+				monthDay, err := strconv.Atoi(day)
+				if err != nil || monthDay == 0 || monthDay < -31 || monthDay > 31 {
+					return nil, NewValidationError("rrule", fmt.Sprintf("invalid BYMONTHDAY %q", day))
+				}
+				rule.ByMonthDay = append(rule.ByMonthDay, monthDay)
+			}
+		case "WKST":
+			if strings.ToUpper(val) != "MO" {
+				return nil, NewValidationError("rrule", "only WKST=MO is supported")
+			}
+		default:
+			return nil, NewValidationError("rrule", fmt.Sprintf("unsupported rule part %s", key))
+		}
+	}
+
+	if err := rule.Validate(); err != nil {
+		return nil, err
+	}
+	return rule, nil
+}
+
+// Validate checks that the rule is within the supported subset
+func (r *RecurrenceRule) Validate() error {
+	switch r.Frequency {
+	case RecurrenceWeekly:
+		if len(r.ByMonthDay) > 0 {
+			return NewValidationError("rrule", "BYMONTHDAY is only supported with FREQ=MONTHLY")
+		}
+		for _, day := range r.ByDay {
+			if day.Ordinal != 0 {
+				return NewValidationError("rrule", "ordinal BYDAY is only supported with FREQ=MONTHLY")
+			}
+		}
+	case RecurrenceMonthly:
+		if len(r.ByDay) > 0 && len(r.ByMonthDay) > 0 {
+			return NewValidationError("rrule", "BYDAY and BYMONTHDAY cannot be combined")
+		}
+	case "":
+		return NewValidationError("rrule", "FREQ is required")
+	default:
+		return NewValidationError("rrule", "only FREQ=WEEKLY and FREQ=MONTHLY are supported")
+	}
+	if r.Count > 0 && r.Until != nil {
+		return NewValidationError("rrule", "COUNT and UNTIL cannot be combined")
+	}
+	return nil
+}
+
+// Occurrences expands the rule from dtstart and returns the occurrences from
+// dtstart up to and including end, at most limit of them when limit is
+// positive. COUNT is counted from dtstart. Every occurrence keeps the wall
+// clock time and location of dtstart.
+func (r *RecurrenceRule) Occurrences(dtstart, end time.Time, limit int) []time.Time {
+	var occurrences []time.Time
+	emitted := 0
+
+	for period := 0; period < maxRecurrencePeriods; period++ {
+		candidates := r.periodDates(dtstart, period*r.Interval)
+		if len(candidates) == 0 {
+			continue
+		}
+		for _, candidate := range candidates {
+			if candidate.Before(dtstart) {
+				continue
+			}
+			if r.Until != nil && candidate.After(*r.Until) {
+				return occurrences
+			}
+			if r.Count > 0 && emitted >= r.Count {
+				return occurrences
+			}
+			if candidate.After(end) || (limit > 0 && len(occurrences) >= limit) {
+				return occurrences
+			}
+			occurrences = append(occurrences, candidate)
+			emitted++
+		}
+	}
+
+	return occurrences
+}
+
+// periodDates returns the candidate occurrences, in order, of the week or
+// month that lies offset periods after the one containing dtstart
+func (r *RecurrenceRule) periodDates(dtstart time.Time, offset int) []time.Time {
+	hour, minute, second := dtstart.Clock()
+	loc := dtstart.Location()
+	var dates []time.Time
+
+	if r.Frequency == RecurrenceWeekly {
+		// Weeks start on Monday
+		daysSinceMonday := (int(dtstart.Weekday()) + 6) % 7
+		monday := time.Date(dtstart.Year(), dtstart.Month(), dtstart.Day()-daysSinceMonday+7*offset, hour, minute, second, 0, loc)
+
+		days := r.ByDay
+		if len(days) == 0 {
+			days = []RecurrenceDay{{Weekday: dtstart.Weekday()}}
+		}
+		for _, day := range days {
+			dates = append(dates, monday.AddDate(0, 0, (int(day.Weekday)+6)%7))
+		}
+	} else {
+		first := time.Date(dtstart.Year(), dtstart.Month()+time.Month(offset), 1, hour, minute, second, 0, loc)
+		daysInMonth := first.AddDate(0, 1, -1).Day()
+
+		switch {
+		case len(r.ByDay) > 0:
+			for _, day := range r.ByDay {
+				dates = append(dates, monthWeekdays(first, daysInMonth, day)...)
+			}
+		case len(r.ByMonthDay) > 0:
+			for _, monthDay := range r.ByMonthDay {
+				if monthDay < 0 {
+					monthDay = daysInMonth + monthDay + 1
+				}
+				// Days that do not exist in the month are skipped, as in RFC 5545
+				if monthDay >= 1 && monthDay <= daysInMonth {
+					dates = append(dates, first.AddDate(0, 0, monthDay-1))
+				}
+			}
+		default:
+			if dtstart.Day() <= daysInMonth {
+				dates = append(dates, first.AddDate(0, 0, dtstart.Day()-1))
+			}
+		}
+	}
+
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	return dedupeTimes(dates)
+}
+
+// monthWeekdays returns the days of the month matching a BYDAY entry
+func monthWeekdays(first time.Time, daysInMonth int, day RecurrenceDay) []time.Time {
+	var matches []time.Time
+	offset := (int(day.Weekday) - int(first.Weekday()) + 7) % 7
+	for d := 1 + offset; d <= daysInMonth; d += 7 {
+		matches = append(matches, first.AddDate(0, 0, d-1))
+	}
+
+	switch {
+	case day.Ordinal > 0 && day.Ordinal <= len(matches):
+		return matches[day.Ordinal-1 : day.Ordinal]
+	case day.Ordinal < 0 && -day.Ordinal <= len(matches):
+		i := len(matches) + day.Ordinal
+		return matches[i : i+1]
+	case day.Ordinal == 0:
+		return matches
+	default:
+		return nil
+	}
+}
+
+func dedupeTimes(times []time.Time) []time.Time {
+	result := times[:0]
+	for i, t := range times {
+		if i == 0 || !t.Equal(times[i-1]) {
+			result = append(result, t)
+		}
+	}
+	return result
+}
+
+func parseRecurrenceDay(value string) (RecurrenceDay, error) {
+	if len(value) < 2 {
+		return RecurrenceDay{}, NewValidationError("rrule", fmt.Sprintf("invalid BYDAY %q", value))
+	}
+
+	code := value[len(value)-2:]
+	weekday, ok := recurrenceWeekdays[code]
+	if !ok {
+		return RecurrenceDay{}, NewValidationError("rrule", fmt.Sprintf("invalid BYDAY %q", value))
+	}
+
+	day := RecurrenceDay{Weekday: weekday}
+	if prefix := value[:len(value)-2]; prefix != "" {
+		ordinal, err := strconv.Atoi(prefix)
+		if err != nil || ordinal == 0 || ordinal < -5 || ordinal > 5 {
+			return RecurrenceDay{}, NewValidationError("rrule", fmt.Sprintf("invalid BYDAY %q", value))
+		}
+		day.Ordinal = ordinal
+	}
+	return day, nil
+}
+
+func parseRecurrenceTime(value string) (time.Time, error) {
+	if t, err := time.Parse("20060102T150405Z", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("20060102", value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	// A date-only UNTIL includes the whole day
+	return t.Add(24*time.Hour - time.Second), nil
+}
@@ -195,7 +195,7 @@ func (p *Payment) Validate() error {
 	if p.Currency == "" {
 		return NewValidationError("currency", "currency is required")
 	}
-	if p.Provider != PaymentMethodMoMo && p.Provider != PaymentMethodPaystack {
+	if p.Provider != PaymentMethodMoMo && p.Provider != PaymentMethodPaystack && !p.Provider.IsOffline() {
 		return NewValidationError("provider", "invalid payment provider")
 	}
 	return nil
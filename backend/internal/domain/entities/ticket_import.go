@@ -0,0 +1,175 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TicketImportStatus represents the progress of a bulk ticket import
+type TicketImportStatus string
+
+const (
+	TicketImportStatusPending             TicketImportStatus = "pending"
+	TicketImportStatusProcessing          TicketImportStatus = "processing"
+	TicketImportStatusCompleted           TicketImportStatus = "completed"
+	TicketImportStatusCompletedWithErrors TicketImportStatus = "completed_with_errors"
+	TicketImportStatusFailed              TicketImportStatus = "failed"
+)
+
+// TicketImport is one uploaded CSV of attendees or pre-sold tickets for an event
+type TicketImport struct {
+	ID            uuid.UUID          `json:"id" db:"id"`
+	EventID       uuid.UUID          `json:"event_id" db:"event_id"`
+	Source        *string            `json:"source,omitempty" db:"source"`
+	Filename      *string            `json:"filename,omitempty" db:"filename"`
+	Status        TicketImportStatus `json:"status" db:"status"`
+	SendEmails    bool               `json:"send_emails" db:"send_emails"`
+	TotalRows     int                `json:"total_rows" db:"total_rows"`
+	ImportedRows  int                `json:"imported_rows" db:"imported_rows"`
+	SkippedRows   int                `json:"skipped_rows" db:"skipped_rows"`
+	FailedRows    int                `json:"failed_rows" db:"failed_rows"`
+	TicketsIssued int                `json:"tickets_issued" db:"tickets_issued"`
+	LastError     *string            `json:"last_error,omitempty" db:"last_error"`
+	CreatedBy     *uuid.UUID         `json:"created_by,omitempty" db:"created_by"`
+	StartedAt     *time.Time         `json:"started_at,omitempty" db:"started_at"`
+	CompletedAt   *time.Time         `json:"completed_at,omitempty" db:"completed_at"`
+	CreatedAt     time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" db:"updated_at"`
+}
+
+// NewTicketImport creates a pending import for an event
+func NewTicketImport(eventID uuid.UUID) *TicketImport {
+	now := time.Now()
+	return &TicketImport{
+		ID:        uuid.New(),
+		EventID:   eventID,
+		Status:    TicketImportStatusPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// PendingRows returns the number of rows not yet processed
+func (i *TicketImport) PendingRows() int {
+	pending := i.TotalRows - i.ImportedRows - i.SkippedRows - i.FailedRows
+	if pending < 0 {
+		return 0
+	}
+	return pending
+}
+
+// IsFinished checks if every row has been processed
+func (i *TicketImport) IsFinished() bool {
+	return i.Status == TicketImportStatusCompleted || i.Status == TicketImportStatusCompletedWithErrors
+}
+
+// MarkProcessing records that a worker has picked the import up
+func (i *TicketImport) MarkProcessing() {
+	now := time.Now()
+	i.Status = TicketImportStatusProcessing
+	if i.StartedAt == nil {
+		i.StartedAt = &now
+	}
+	i.LastError = nil
+	i.UpdatedAt = now
+}
+
+// MarkFinished closes the import once no pending rows remain
+func (i *TicketImport) MarkFinished() {
+	now := time.Now()
+	i.Status = TicketImportStatusCompleted
+	if i.FailedRows > 0 {
+		i.Status = TicketImportStatusCompletedWithErrors
+	}
+	i.CompletedAt = &now
+	i.UpdatedAt = now
+}
+
+// MarkFailed records a processing error; pending rows can be resumed later
+func (i *TicketImport) MarkFailed(message string) {
+	i.Status = TicketImportStatusFailed
+	i.LastError = &message
+	i.UpdatedAt = time.Now()
+}
+
+// TicketImportRowStatus represents the outcome of one imported row
+type TicketImportRowStatus string
+
+const (
+	TicketImportRowPending  TicketImportRowStatus = "pending"
+	TicketImportRowImported TicketImportRowStatus = "imported"
+	TicketImportRowSkipped  TicketImportRowStatus = "skipped"
+	TicketImportRowFailed   TicketImportRowStatus = "failed"
+)
+
+// TicketImportRow is one staged CSV row and its result
+type TicketImportRow struct {
+	ID                uuid.UUID             `json:"id" db:"id"`
+	ImportID          uuid.UUID             `json:"import_id" db:"import_id"`
+	RowNumber         int                   `json:"row_number" db:"row_number"`
+	ExternalReference *string               `json:"external_reference,omitempty" db:"external_reference"`
+	FirstName         *string               `json:"first_name,omitempty" db:"first_name"`
+	LastName          *string               `json:"last_name,omitempty" db:"last_name"`
+	Email             *string               `json:"email,omitempty" db:"email"`
+	Phone             *string               `json:"phone,omitempty" db:"phone"`
+	Tier              *string               `json:"tier,omitempty" db:"tier"`
+	Quantity          int                   `json:"quantity" db:"quantity"`
+	Status            TicketImportRowStatus `json:"status" db:"status"`
+	Error             *string               `json:"error,omitempty" db:"error"`
+	OrderID           *uuid.UUID            `json:"order_id,omitempty" db:"order_id"`
+	CreatedAt         time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// NewTicketImportRow stages a CSV row for processing
+func NewTicketImportRow(importID uuid.UUID, rowNumber int) *TicketImportRow {
+	now := time.Now()
+	return &TicketImportRow{
+		ID:        uuid.New(),
+		ImportID:  importID,
+		RowNumber: rowNumber,
+		Quantity:  1,
+		Status:    TicketImportRowPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// SetName splits a full name into first and last name
+func (r *TicketImportRow) SetName(fullName string) {
+	parts := strings.Fields(fullName)
+	if len(parts) == 0 {
+		return
+	}
+	first := parts[0]
+	r.FirstName = &first
+	if len(parts) > 1 {
+		last := strings.Join(parts[1:], " ")
+		r.LastName = &last
+	}
+}
+
+// MarkImported records the order created for the row
+func (r *TicketImportRow) MarkImported(orderID uuid.UUID) {
+	r.Status = TicketImportRowImported
+	r.OrderID = &orderID
+	r.Error = nil
+	r.UpdatedAt = time.Now()
+}
+
+// MarkSkipped records that the row's external reference was already imported
+func (r *TicketImportRow) MarkSkipped(existingOrderID *uuid.UUID, reason string) {
+	r.Status = TicketImportRowSkipped
+	r.OrderID = existingOrderID
+	r.Error = &reason
+	r.UpdatedAt = time.Now()
+}
+
+// MarkFailed records why the row could not be imported
+func (r *TicketImportRow) MarkFailed(reason string) {
+	r.Status = TicketImportRowFailed
+	r.Error = &reason
+	r.UpdatedAt = time.Now()
+}
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"fmt"
+	"math"
 	"time"
 
 	"github.com/google/uuid"
@@ -25,6 +27,12 @@ type TicketTier struct {
 	IsActive     bool       `json:"is_active" db:"is_active"`
 	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time  `json:"updated_at" db:"updated_at"`
+
+	// OpensAfterTierID sequences the tier: it stays upcoming until that tier
+	// sells out or its sale ends, e.g. regular tickets opening after early bird
+	OpensAfterTierID *uuid.UUID `json:"opens_after_tier_id,omitempty" db:"opens_after_tier_id"`
+	// PricingStrategy makes the price follow demand; Price is then the base price
+	PricingStrategy *PricingStrategy `json:"pricing_strategy,omitempty" db:"pricing_strategy"`
 }
 
 // NewTicketTier creates a new ticket tier with default values
@@ -35,6 +43,7 @@ func NewTicketTier(eventID uuid.UUID, name string, price float64) *TicketTier {
 		EventID:     eventID,
 		Name:        name,
 		Price:       price,
+		Currency:    DefaultCurrency,
 		Quota:       100,
 		Sold:        0,
 		MaxPurchase: 10,
@@ -45,6 +54,43 @@ func NewTicketTier(eventID uuid.UUID, name string, price float64) *TicketTier {
 	}
 }
 
+// CopyForEvent copies the tier to another event, moving its sale window by shift.
+// The copy is not sequenced; CopyTiersForEvent keeps a set of tiers sequenced.
+func (tt *TicketTier) CopyForEvent(eventID uuid.UUID, shift time.Duration) *TicketTier {
+	now := time.Now()
+	tier := *tt
+	tier.ID = uuid.New()
+	tier.EventID = eventID
+	tier.Sold = 0
+	tier.SaleStart = shiftTime(tt.SaleStart, shift)
+	tier.SaleEnd = shiftTime(tt.SaleEnd, shift)
+	tier.OpensAfterTierID = nil
+	tier.CreatedAt = now
+	tier.UpdatedAt = now
+	return &tier
+}
+
+// CopyTiersForEvent copies an event's tiers to another event, keeping their
+// sequencing pointed at the copies rather than the source tiers
+func CopyTiersForEvent(tiers []*TicketTier, eventID uuid.UUID, shift time.Duration) []*TicketTier {
+	copies := make([]*TicketTier, 0, len(tiers))
+	copiedIDs := make(map[uuid.UUID]uuid.UUID, len(tiers))
+	for _, tier := range tiers {
+		tierCopy := tier.CopyForEvent(eventID, shift)
+		copiedIDs[tier.ID] = tierCopy.ID
+		copies = append(copies, tierCopy)
+	}
+	for i, tier := range tiers {
+		if tier.OpensAfterTierID == nil {
+			continue
+		}
+		if copiedID, ok := copiedIDs[*tier.OpensAfterTierID]; ok {
+			copies[i].OpensAfterTierID = &copiedID
+		}
+	}
+	return copies
+}
+
 // Validate performs business rule validation for the ticket tier
 func (tt *TicketTier) Validate() error {
 	if tt.Name == "" {
@@ -65,11 +111,22 @@ func (tt *TicketTier) Validate() error {
 	if tt.Quota <= 0 {
 		return NewValidationError("quota", "quota must be positive")
 	}
+	if !IsSupportedCurrency(tt.Currency) {
+		return NewValidationError("currency", "unsupported currency")
+	}
 	
 	// Validate sale dates if provided
 	if tt.SaleStart != nil && tt.SaleEnd != nil && tt.SaleStart.After(*tt.SaleEnd) {
 		return NewValidationError("sale_dates", "sale start must be before sale end")
 	}
+	if tt.OpensAfterTierID != nil && *tt.OpensAfterTierID == tt.ID {
+		return NewValidationError("opens_after_tier_id", "a ticket tier cannot open after itself")
+	}
+	if tt.PricingStrategy != nil {
+		if err := tt.PricingStrategy.Validate(); err != nil {
+			return err
+		}
+	}
 	
 	return nil
 }
@@ -122,7 +179,26 @@ func (tt *TicketTier) UpdatePrice(price float64) error {
 	return nil
 }
 
-// IsOnSale checks if the ticket tier is currently on sale
+// OpensAfter sequences the tier after another tier of the same event
+func (tt *TicketTier) OpensAfter(previous *TicketTier) error {
+	if previous == nil {
+		tt.OpensAfterTierID = nil
+		tt.UpdatedAt = time.Now()
+		return nil
+	}
+	if previous.ID == tt.ID {
+		return NewValidationError("opens_after_tier_id", "a ticket tier cannot open after itself")
+	}
+	if previous.EventID != tt.EventID {
+		return NewValidationError("opens_after_tier_id", "ticket tiers can only be sequenced within the same event")
+	}
+	tt.OpensAfterTierID = &previous.ID
+	tt.UpdatedAt = time.Now()
+	return nil
+}
+
+// IsOnSale checks if the ticket tier's own sale window is open. Use
+// ResolveTierSaleStates to also account for stock and sequencing.
 func (tt *TicketTier) IsOnSale() bool {
 	if !tt.IsActive {
 		return false
@@ -143,6 +219,37 @@ func (tt *TicketTier) IsOnSale() bool {
 	return true
 }
 
+// SetPricingStrategy sets or, with nil, removes the tier's dynamic pricing
+func (tt *TicketTier) SetPricingStrategy(strategy *PricingStrategy) error {
+	if strategy != nil {
+		if err := strategy.Validate(); err != nil {
+			return err
+		}
+	}
+	tt.PricingStrategy = strategy
+	tt.UpdatedAt = time.Now()
+	return nil
+}
+
+// QuotePrice returns the price of one ticket at now, given how many of the
+// quota are sold or held. Without a pricing strategy it is the tier price.
+func (tt *TicketTier) QuotePrice(soldOrHeld int, eventDate, now time.Time) float64 {
+	return QuoteTierPrice(tt.Price, tt.PricingStrategy, tt.Quota, soldOrHeld, eventDate, now)
+}
+
+// QuoteTierPrice quotes basePrice under strategy for a quota of which
+// soldOrHeld tickets are gone. A nil strategy quotes basePrice.
+func QuoteTierPrice(basePrice float64, strategy *PricingStrategy, quota, soldOrHeld int, eventDate, now time.Time) float64 {
+	if strategy == nil {
+		return basePrice
+	}
+	soldRatio := 1.0
+	if quota > 0 {
+		soldRatio = math.Min(float64(soldOrHeld)/float64(quota), 1)
+	}
+	return strategy.Quote(basePrice, soldRatio, eventDate.Sub(now))
+}
+
 // IsValidQuantity checks if the requested quantity is valid for this tier
 func (tt *TicketTier) IsValidQuantity(quantity int) bool {
 	return quantity >= tt.MinPurchase && quantity <= tt.MaxPurchase
@@ -174,3 +281,104 @@ func (tt *TicketTier) Deactivate() {
 func (tt *TicketTier) IsFree() bool {
 	return tt.Price == 0
 }
+
+// TierSaleState is where a ticket tier is in its sale
+type TierSaleState string
+
+const (
+	TierSaleStateUpcoming TierSaleState = "upcoming"
+	TierSaleStateOnSale   TierSaleState = "on_sale"
+	TierSaleStateSoldOut  TierSaleState = "sold_out"
+	TierSaleStateEnded    TierSaleState = "ended"
+)
+
+// TierSchedule is what decides a tier's sale state at a point in time
+type TierSchedule struct {
+	TierID           uuid.UUID
+	SaleStart        *time.Time
+	SaleEnd          *time.Time
+	OpensAfterTierID *uuid.UUID
+	Available        int
+}
+
+// Schedule returns the tier's schedule given the tickets still available
+func (tt *TicketTier) Schedule(available int) TierSchedule {
+	return TierSchedule{
+		TierID:           tt.ID,
+		SaleStart:        tt.SaleStart,
+		SaleEnd:          tt.SaleEnd,
+		OpensAfterTierID: tt.OpensAfterTierID,
+		Available:        available,
+	}
+}
+
+// ResolveTierSaleStates resolves the sale state of an event's tiers. A tier
+// whose window has passed is ended and one with nothing left is sold out. A
+// sequenced tier stays upcoming until the tier it opens after is sold out or
+// ended; a predecessor missing from schedules, e.g. a deleted tier, no longer
+// holds it back.
+func ResolveTierSaleStates(schedules []TierSchedule, now time.Time) map[uuid.UUID]TierSaleState {
+	byID := make(map[uuid.UUID]TierSchedule, len(schedules))
+	for _, schedule := range schedules {
+		byID[schedule.TierID] = schedule
+	}
+
+	states := make(map[uuid.UUID]TierSaleState, len(schedules))
+	var resolve func(schedule TierSchedule, depth int) TierSaleState
+	resolve = func(schedule TierSchedule, depth int) TierSaleState {
+		if state, ok := states[schedule.TierID]; ok {
+			return state
+		}
+
+		state := TierSaleStateOnSale
+		switch {
+		case schedule.SaleEnd != nil && now.After(*schedule.SaleEnd):
+			state = TierSaleStateEnded
+		case schedule.Available <= 0:
+			state = TierSaleStateSoldOut
+		case schedule.SaleStart != nil && now.Before(*schedule.SaleStart):
+			state = TierSaleStateUpcoming
+		case schedule.OpensAfterTierID != nil:
+			// depth stops a sequence cycle from recursing forever
+			if previous, ok := byID[*schedule.OpensAfterTierID]; ok && depth < len(schedules) {
+				switch resolve(previous, depth+1) {
+				case TierSaleStateUpcoming, TierSaleStateOnSale:
+					state = TierSaleStateUpcoming
+				}
+			}
+		}
+
+		states[schedule.TierID] = state
+		return state
+	}
+
+	for _, schedule := range schedules {
+		resolve(schedule, 0)
+	}
+	return states
+}
+
+// ValidateTierSequence checks that every sequenced tier opens after another
+// tier in tiers and that no sequence loops back on itself
+func ValidateTierSequence(tiers []*TicketTier) error {
+	byID := make(map[uuid.UUID]*TicketTier, len(tiers))
+	for _, tier := range tiers {
+		byID[tier.ID] = tier
+	}
+
+	for _, tier := range tiers {
+		seen := map[uuid.UUID]bool{tier.ID: true}
+		for current := tier; current.OpensAfterTierID != nil; {
+			previous, ok := byID[*current.OpensAfterTierID]
+			if !ok {
+				return NewValidationError("opens_after_tier_id", fmt.Sprintf("ticket tier '%s' opens after a tier that is not part of this event", current.Name))
+			}
+			if seen[previous.ID] {
+				return NewValidationError("opens_after_tier_id", fmt.Sprintf("ticket tier '%s' is part of a sequence that loops back on itself", tier.Name))
+			}
+			seen[previous.ID] = true
+			current = previous
+		}
+	}
+	return nil
+}
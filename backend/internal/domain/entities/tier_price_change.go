@@ -0,0 +1,79 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// TierPriceChangeStatus represents the lifecycle of a scheduled price change
+type TierPriceChangeStatus string
+
+const (
+	TierPriceChangeScheduled TierPriceChangeStatus = "scheduled"
+	TierPriceChangeApplied   TierPriceChangeStatus = "applied"
+	TierPriceChangeCancelled TierPriceChangeStatus = "cancelled"
+)
+
+// TierPriceChange is a price a ticket tier switches to at a set time. Applied
+// changes are kept as the tier's price history, so the price an order line was
+// charged can be traced to the change in effect when it was bought.
+type TierPriceChange struct {
+	ID            uuid.UUID             `json:"id" db:"id"`
+	TicketTierID  uuid.UUID             `json:"ticket_tier_id" db:"ticket_tier_id"`
+	Price         float64               `json:"price" db:"price"`
+	PreviousPrice *float64              `json:"previous_price,omitempty" db:"previous_price"`
+	EffectiveAt   time.Time             `json:"effective_at" db:"effective_at"`
+	Status        TierPriceChangeStatus `json:"status" db:"status"`
+	Reason        *string               `json:"reason,omitempty" db:"reason"`
+	CreatedBy     *uuid.UUID            `json:"created_by,omitempty" db:"created_by"`
+	AppliedAt     *time.Time            `json:"applied_at,omitempty" db:"applied_at"`
+	CancelledAt   *time.Time            `json:"cancelled_at,omitempty" db:"cancelled_at"`
+	CreatedAt     time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// NewTierPriceChange schedules a tier to switch to price at effectiveAt
+func NewTierPriceChange(tierID uuid.UUID, price float64, effectiveAt time.Time) *TierPriceChange {
+	now := time.Now()
+	return &TierPriceChange{
+		ID:           uuid.New(),
+		TicketTierID: tierID,
+		Price:        price,
+		EffectiveAt:  effectiveAt,
+		Status:       TierPriceChangeScheduled,
+		CreatedAt:    now,
+		UpdatedAt:    now,
+	}
+}
+
+// Validate performs business rule validation for the price change
+func (c *TierPriceChange) Validate() error {
+	if c.TicketTierID == uuid.Nil {
+		return NewValidationError("ticket_tier_id", "ticket tier is required")
+	}
+	if c.Price < 0 {
+		return NewValidationError("price", "price must be non-negative")
+	}
+	if c.EffectiveAt.IsZero() {
+		return NewValidationError("effective_at", "effective time is required")
+	}
+	if c.Status == TierPriceChangeScheduled && !c.EffectiveAt.After(c.CreatedAt) {
+		return NewValidationError("effective_at", "effective time must be in the future")
+	}
+	return nil
+}
+
+// Cancel withdraws a change that has not taken effect yet
+func (c *TierPriceChange) Cancel() error {
+	if c.Status != TierPriceChangeScheduled {
+		return NewBusinessRuleError("price_change_not_scheduled", "only scheduled price changes can be cancelled", map[string]interface{}{
+			"status": c.Status,
+		})
+	}
+	now := time.Now()
+	c.Status = TierPriceChangeCancelled
+	c.CancelledAt = &now
+	c.UpdatedAt = now
+	return nil
+}
@@ -0,0 +1,187 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// PricingStrategyType determines what drives a tier's dynamic price
+type PricingStrategyType string
+
+const (
+	// PricingStrategyInventory steps the price up as the quota sells
+	PricingStrategyInventory PricingStrategyType = "inventory"
+	// PricingStrategyTime moves the price along a curve as the event gets closer
+	PricingStrategyTime PricingStrategyType = "time"
+)
+
+// PricingStep applies Multiplier to the tier price once SoldPercent of the quota is sold or held
+type PricingStep struct {
+	SoldPercent float64 `json:"sold_percent"`
+	Multiplier  float64 `json:"multiplier"`
+}
+
+// PricingCurvePoint sets the multiplier HoursBefore hours before the event starts
+type PricingCurvePoint struct {
+	HoursBefore float64 `json:"hours_before"`
+	Multiplier  float64 `json:"multiplier"`
+}
+
+// PricingStrategy makes a tier's price follow demand. The tier price is the
+// base: an inventory strategy multiplies it by the step reached so far, a time
+// strategy by the curve interpolated between its points. The result is kept
+// between Floor and Ceiling when they are set.
+type PricingStrategy struct {
+	Type    PricingStrategyType `json:"type"`
+	Steps   []PricingStep       `json:"steps,omitempty"`
+	Curve   []PricingCurvePoint `json:"curve,omitempty"`
+	Floor   *float64            `json:"floor,omitempty"`
+	Ceiling *float64            `json:"ceiling,omitempty"`
+}
+
+// Validate performs business rule validation for the pricing strategy
+func (p *PricingStrategy) Validate() error {
+	switch p.Type {
+	case PricingStrategyInventory:
+		if len(p.Steps) == 0 {
+			return NewValidationError("pricing_strategy.steps", "an inventory strategy needs at least one step")
+		}
+		if len(p.Curve) > 0 {
+			return NewValidationError("pricing_strategy.curve", "an inventory strategy uses steps, not a curve")
+		}
+		seen := make(map[float64]bool, len(p.Steps))
+		for i, step := range p.Steps {
+			if step.SoldPercent < 0 || step.SoldPercent >= 100 {
+				return NewValidationError(fmt.Sprintf("pricing_strategy.steps[%d].sold_percent", i), "sold percent must be from 0 up to 100")
+			}
+			if seen[step.SoldPercent] {
+				return NewValidationError(fmt.Sprintf("pricing_strategy.steps[%d].sold_percent", i), "each step needs a different sold percent")
+			}
+			seen[step.SoldPercent] = true
+			if step.Multiplier <= 0 {
+				return NewValidationError(fmt.Sprintf("pricing_strategy.steps[%d].multiplier", i), "multiplier must be positive")
+			}
+		}
+	case PricingStrategyTime:
+		if len(p.Curve) == 0 {
+			return NewValidationError("pricing_strategy.curve", "a time strategy needs at least one curve point")
+		}
+		if len(p.Steps) > 0 {
+			return NewValidationError("pricing_strategy.steps", "a time strategy uses a curve, not steps")
+		}
+		seen := make(map[float64]bool, len(p.Curve))
+		for i, point := range p.Curve {
+			if point.HoursBefore < 0 {
+				return NewValidationError(fmt.Sprintf("pricing_strategy.curve[%d].hours_before", i), "hours before must be non-negative")
+			}
+			if seen[point.HoursBefore] {
+				return NewValidationError(fmt.Sprintf("pricing_strategy.curve[%d].hours_before", i), "each curve point needs different hours before")
+			}
+			seen[point.HoursBefore] = true
+			if point.Multiplier <= 0 {
+				return NewValidationError(fmt.Sprintf("pricing_strategy.curve[%d].multiplier", i), "multiplier must be positive")
+			}
+		}
+	default:
+		return NewValidationError("pricing_strategy.type", "type must be inventory or time")
+	}
+
+	if p.Floor != nil && *p.Floor < 0 {
+		return NewValidationError("pricing_strategy.floor", "floor must be non-negative")
+	}
+	if p.Ceiling != nil && *p.Ceiling < 0 {
+		return NewValidationError("pricing_strategy.ceiling", "ceiling must be non-negative")
+	}
+	if p.Floor != nil && p.Ceiling != nil && *p.Floor > *p.Ceiling {
+		return NewValidationError("pricing_strategy.ceiling", "ceiling cannot be below floor")
+	}
+	return nil
+}
+
+// Quote returns the price for basePrice given the share of the quota sold or
+// held (0 to 1) and the time left before the event
+func (p *PricingStrategy) Quote(basePrice, soldRatio float64, untilEvent time.Duration) float64 {
+	price := basePrice
+	switch p.Type {
+	case PricingStrategyInventory:
+		price = basePrice * p.stepMultiplier(soldRatio*100)
+	case PricingStrategyTime:
+		price = basePrice * p.curveMultiplier(untilEvent.Hours())
+	}
+
+	if p.Floor != nil && price < *p.Floor {
+		price = *p.Floor
+	}
+	if p.Ceiling != nil && price > *p.Ceiling {
+		price = *p.Ceiling
+	}
+	return math.Round(price*100) / 100
+}
+
+// stepMultiplier returns the multiplier of the highest step reached, or 1 before the first
+func (p *PricingStrategy) stepMultiplier(soldPercent float64) float64 {
+	multiplier := 1.0
+	reached := -1.0
+	for _, step := range p.Steps {
+		if soldPercent >= step.SoldPercent && step.SoldPercent > reached {
+			multiplier = step.Multiplier
+			reached = step.SoldPercent
+		}
+	}
+	return multiplier
+}
+
+// curveMultiplier interpolates the curve at hoursBefore, holding the end
+// points flat before the curve starts and after it ends
+func (p *PricingStrategy) curveMultiplier(hoursBefore float64) float64 {
+	points := make([]PricingCurvePoint, len(p.Curve))
+	copy(points, p.Curve)
+	sort.Slice(points, func(i, j int) bool {
+		return points[i].HoursBefore > points[j].HoursBefore
+	})
+
+	if hoursBefore >= points[0].HoursBefore {
+		return points[0].Multiplier
+	}
+	for i := 1; i < len(points); i++ {
+		if hoursBefore >= points[i].HoursBefore {
+			far, near := points[i-1], points[i]
+			progress := (far.HoursBefore - hoursBefore) / (far.HoursBefore - near.HoursBefore)
+			return far.Multiplier + progress*(near.Multiplier-far.Multiplier)
+		}
+	}
+	return points[len(points)-1].Multiplier
+}
+
+// Value implements the driver.Valuer interface for database writes
+func (p PricingStrategy) Value() (driver.Value, error) {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface for database reads
+func (p *PricingStrategy) Scan(value interface{}) error {
+	if value == nil {
+		*p = PricingStrategy{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into PricingStrategy", value)
+	}
+
+	return json.Unmarshal(bytes, p)
+}
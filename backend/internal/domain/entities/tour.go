@@ -17,10 +17,13 @@ type Tour struct {
 	TourImageURL *string                `json:"tour_image_url,omitempty" db:"tour_image_url"`
 	StartDate    *time.Time             `json:"start_date,omitempty" db:"start_date"`
 	EndDate      *time.Time             `json:"end_date,omitempty" db:"end_date"`
-	Settings     map[string]interface{} `json:"settings" db:"settings"`
+	Settings     JSONB                  `json:"settings" db:"settings"`
 	CreatedAt    time.Time              `json:"created_at" db:"created_at"`
 	UpdatedAt    time.Time              `json:"updated_at" db:"updated_at"`
 	IsActive     bool                   `json:"is_active" db:"is_active"`
+
+	// Aggregates (populated by list queries)
+	EventCount int `json:"event_count" db:"event_count"`
 }
 
 // NewTour creates a new tour with default values
@@ -32,7 +35,7 @@ func NewTour(organizerID uuid.UUID, name, slug, artistName string) *Tour {
 		Name:        name,
 		Slug:        slug,
 		ArtistName:  artistName,
-		Settings:    make(map[string]interface{}),
+		Settings:    make(JSONB),
 		CreatedAt:   now,
 		UpdatedAt:   now,
 		IsActive:    true,
@@ -88,7 +91,7 @@ func (t *Tour) SetImage(imageURL string) {
 // UpdateSettings updates the tour settings
 func (t *Tour) UpdateSettings(settings map[string]interface{}) {
 	if t.Settings == nil {
-		t.Settings = make(map[string]interface{})
+		t.Settings = make(JSONB)
 	}
 	for key, value := range settings {
 		t.Settings[key] = value
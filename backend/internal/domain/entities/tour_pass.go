@@ -0,0 +1,149 @@
+package entities
+
+import (
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// MinTourPassEvents is the fewest events a tour pass may cover
+const MinTourPassEvents = 2
+
+// TourPass is a single purchase that admits the holder to several events of a tour
+type TourPass struct {
+	ID          uuid.UUID  `json:"id" db:"id"`
+	TourID      uuid.UUID  `json:"tour_id" db:"tour_id"`
+	Name        string     `json:"name" db:"name"`
+	Description *string    `json:"description,omitempty" db:"description"`
+	Price       float64    `json:"price" db:"price"`
+	Currency    string     `json:"currency" db:"currency"`
+	Quota       *int       `json:"quota,omitempty" db:"quota"`
+	MaxPerOrder int        `json:"max_per_order" db:"max_per_order"`
+	SaleStart   *time.Time `json:"sale_start,omitempty" db:"sale_start"`
+	SaleEnd     *time.Time `json:"sale_end,omitempty" db:"sale_end"`
+	IsActive    bool       `json:"is_active" db:"is_active"`
+	CreatedAt   time.Time  `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at" db:"updated_at"`
+
+	// Relations
+	Events []*TourPassEvent `json:"events,omitempty" db:"-"`
+}
+
+// TourPassEvent is an event covered by a tour pass and the tier its holders are admitted under
+type TourPassEvent struct {
+	TourPassID   uuid.UUID `json:"tour_pass_id" db:"tour_pass_id"`
+	EventID      uuid.UUID `json:"event_id" db:"event_id"`
+	TicketTierID uuid.UUID `json:"ticket_tier_id" db:"ticket_tier_id"`
+
+	// Denormalised fields (populated by JOIN queries)
+	EventName      string    `json:"event_name,omitempty" db:"event_name"`
+	EventDate      time.Time `json:"event_date" db:"event_date"`
+	VenueName      string    `json:"venue_name,omitempty" db:"venue_name"`
+	VenueCity      string    `json:"venue_city,omitempty" db:"venue_city"`
+	TicketTierName string    `json:"ticket_tier_name,omitempty" db:"ticket_tier_name"`
+}
+
+// TourPassOrder links an order to the tour passes it bought
+type TourPassOrder struct {
+	OrderID    uuid.UUID `json:"order_id" db:"order_id"`
+	TourPassID uuid.UUID `json:"tour_pass_id" db:"tour_pass_id"`
+	Quantity   int       `json:"quantity" db:"quantity"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// NewTourPass creates a new tour pass with default values
+func NewTourPass(tourID uuid.UUID, name string, price float64, currency string) *TourPass {
+	now := time.Now()
+	return &TourPass{
+		ID:          uuid.New(),
+		TourID:      tourID,
+		Name:        strings.TrimSpace(name),
+		Price:       price,
+		Currency:    NormalizeCurrency(currency),
+		MaxPerOrder: 10,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+}
+
+// Validate performs business rule validation for the tour pass
+func (p *TourPass) Validate() error {
+	if p.Name == "" {
+		return NewValidationError("name", "name is required")
+	}
+	if len(p.Name) > 255 {
+		return NewValidationError("name", "name must be 255 characters or less")
+	}
+	if p.Price < 0 {
+		return NewValidationError("price", "price cannot be negative")
+	}
+	if !IsSupportedCurrency(p.Currency) {
+		return NewValidationError("currency", "unsupported currency")
+	}
+	if p.Quota != nil && *p.Quota <= 0 {
+		return NewValidationError("quota", "quota must be greater than 0")
+	}
+	if p.MaxPerOrder <= 0 {
+		return NewValidationError("max_per_order", "max per order must be greater than 0")
+	}
+	if p.SaleStart != nil && p.SaleEnd != nil && !p.SaleStart.Before(*p.SaleEnd) {
+		return NewValidationError("sale_period", "sale end time must be after sale start time")
+	}
+	if len(p.Events) < MinTourPassEvents {
+		return NewValidationError("events", "a tour pass must cover at least two events")
+	}
+
+	seen := make(map[uuid.UUID]bool, len(p.Events))
+	for _, event := range p.Events {
+		if seen[event.EventID] {
+			return NewValidationError("events", "an event may only be listed once")
+		}
+		seen[event.EventID] = true
+	}
+
+	return nil
+}
+
+// SetEvents replaces the events covered by the pass
+func (p *TourPass) SetEvents(events []*TourPassEvent) {
+	for _, event := range events {
+		event.TourPassID = p.ID
+	}
+	p.Events = events
+	p.UpdatedAt = time.Now()
+}
+
+// IsOnSale checks if the pass can be bought at the given time
+func (p *TourPass) IsOnSale(now time.Time) bool {
+	if !p.IsActive {
+		return false
+	}
+	if p.SaleStart != nil && now.Before(*p.SaleStart) {
+		return false
+	}
+	if p.SaleEnd != nil && now.After(*p.SaleEnd) {
+		return false
+	}
+	return true
+}
+
+// EventPrices splits the pass price across its events in the pass currency's
+// smallest unit. The first event takes any remainder, so the shares always add
+// up to exactly the pass price.
+func (p *TourPass) EventPrices() []float64 {
+	prices := make([]float64, len(p.Events))
+	if len(p.Events) == 0 {
+		return prices
+	}
+
+	total := ToMinorUnits(p.Price, p.Currency)
+	share := total / int64(len(p.Events))
+	for i := range prices {
+		prices[i] = FromMinorUnits(share, p.Currency)
+	}
+	prices[0] = FromMinorUnits(total-share*int64(len(p.Events)-1), p.Currency)
+
+	return prices
+}
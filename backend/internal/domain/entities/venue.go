@@ -0,0 +1,239 @@
+package entities
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+	// Venue timezones are validated against the embedded zone database so
+	// validation does not depend on the host having tzdata installed
+	_ "time/tzdata"
+
+	"github.com/google/uuid"
+)
+
+// DefaultVenueTimezone is the timezone of venues created without one
+const DefaultVenueTimezone = "Africa/Lagos"
+
+// Venue is a place events are held, reused across events
+type Venue struct {
+	ID            uuid.UUID          `json:"id" db:"id"`
+	Name          string             `json:"name" db:"name"`
+	Address       string             `json:"address" db:"address"`
+	City          string             `json:"city" db:"city"`
+	State         *string            `json:"state,omitempty" db:"state"`
+	Country       string             `json:"country" db:"country"`
+	Latitude      *float64           `json:"latitude,omitempty" db:"latitude"`
+	Longitude     *float64           `json:"longitude,omitempty" db:"longitude"`
+	Capacity      *int               `json:"capacity,omitempty" db:"capacity"`
+	Timezone      string             `json:"timezone" db:"timezone"`
+	Description   *string            `json:"description,omitempty" db:"description"`
+	Accessibility VenueAccessibility `json:"accessibility" db:"accessibility"`
+	ImageURL      *string            `json:"image_url,omitempty" db:"image_url"`
+	GalleryImages JSONBArray         `json:"gallery_images" db:"gallery_images"`
+	IsActive      bool               `json:"is_active" db:"is_active"`
+	CreatedAt     time.Time          `json:"created_at" db:"created_at"`
+	UpdatedAt     time.Time          `json:"updated_at" db:"updated_at"`
+
+	// Relations
+	Gates []*VenueGate `json:"gates,omitempty" db:"-"`
+}
+
+// NewVenue creates a new venue with default values
+func NewVenue(name, address, city, country string) *Venue {
+	now := time.Now()
+	return &Venue{
+		ID:            uuid.New(),
+		Name:          strings.TrimSpace(name),
+		Address:       strings.TrimSpace(address),
+		City:          strings.TrimSpace(city),
+		Country:       strings.TrimSpace(country),
+		Timezone:      DefaultVenueTimezone,
+		GalleryImages: JSONBArray{},
+		IsActive:      true,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Validate performs business rule validation for the venue
+func (v *Venue) Validate() error {
+	if v.Name == "" {
+		return NewValidationError("name", "venue name is required")
+	}
+	if v.City == "" {
+		return NewValidationError("city", "venue city is required")
+	}
+	if v.Country == "" {
+		return NewValidationError("country", "venue country is required")
+	}
+	if v.Capacity != nil && *v.Capacity <= 0 {
+		return NewValidationError("capacity", "capacity must be greater than 0")
+	}
+	if (v.Latitude == nil) != (v.Longitude == nil) {
+		return NewValidationError("latitude", "latitude and longitude must be set together")
+	}
+	if v.Latitude != nil {
+		if err := ValidateCoordinates(*v.Latitude, *v.Longitude); err != nil {
+			return err
+		}
+	}
+	if _, err := time.LoadLocation(v.Timezone); err != nil || v.Timezone == "" {
+		return NewValidationError("timezone", "timezone must be an IANA timezone such as Africa/Lagos")
+	}
+
+	seen := make(map[string]bool, len(v.Gates))
+	for _, gate := range v.Gates {
+		if err := gate.Validate(); err != nil {
+			return err
+		}
+		key := strings.ToLower(gate.Name)
+		if seen[key] {
+			return NewValidationError("gates", fmt.Sprintf("gate %q is listed more than once", gate.Name))
+		}
+		seen[key] = true
+	}
+
+	return nil
+}
+
+// SetLocation sets the venue coordinates
+func (v *Venue) SetLocation(latitude, longitude float64) error {
+	if err := ValidateCoordinates(latitude, longitude); err != nil {
+		return err
+	}
+	v.Latitude = &latitude
+	v.Longitude = &longitude
+	v.UpdatedAt = time.Now()
+	return nil
+}
+
+// Location returns the venue's time.Location, falling back to the default timezone
+func (v *Venue) Location() *time.Location {
+	if loc, err := time.LoadLocation(v.Timezone); err == nil {
+		return loc
+	}
+	loc, _ := time.LoadLocation(DefaultVenueTimezone)
+	return loc
+}
+
+// SetGates replaces the venue's gates and entrances, keeping the given order
+func (v *Venue) SetGates(gates []*VenueGate) {
+	for i, gate := range gates {
+		gate.VenueID = v.ID
+		gate.Position = i
+	}
+	v.Gates = gates
+	v.UpdatedAt = time.Now()
+}
+
+// SetGallery sets the venue gallery image URLs
+func (v *Venue) SetGallery(images []string) {
+	gallery := make(JSONBArray, len(images))
+	for i, img := range images {
+		gallery[i] = img
+	}
+	v.GalleryImages = gallery
+	v.UpdatedAt = time.Now()
+}
+
+// Deactivate hides the venue from new events; existing events keep their link
+func (v *Venue) Deactivate() {
+	v.IsActive = false
+	v.UpdatedAt = time.Now()
+}
+
+// VenueAccessibility describes the accessibility facilities of a venue
+type VenueAccessibility struct {
+	WheelchairAccessible bool   `json:"wheelchair_accessible"`
+	StepFreeAccess       bool   `json:"step_free_access"`
+	AccessibleToilets    bool   `json:"accessible_toilets"`
+	HearingLoop          bool   `json:"hearing_loop"`
+	AccessibleParking    bool   `json:"accessible_parking"`
+	Notes                string `json:"notes,omitempty"`
+}
+
+// Value implements the driver.Valuer interface for database writes
+func (a VenueAccessibility) Value() (driver.Value, error) {
+	b, err := json.Marshal(a)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// Scan implements the sql.Scanner interface for database reads
+func (a *VenueAccessibility) Scan(value interface{}) error {
+	if value == nil {
+		*a = VenueAccessibility{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into VenueAccessibility", value)
+	}
+
+	return json.Unmarshal(bytes, a)
+}
+
+// VenueGateKind distinguishes ticket-checking gates from plain entrances
+type VenueGateKind string
+
+const (
+	VenueGateKindGate     VenueGateKind = "gate"
+	VenueGateKindEntrance VenueGateKind = "entrance"
+)
+
+// IsValid checks if the kind is one of the known kinds
+func (k VenueGateKind) IsValid() bool {
+	return k == VenueGateKindGate || k == VenueGateKindEntrance
+}
+
+// VenueGate is a named gate or entrance of a venue
+type VenueGate struct {
+	ID           uuid.UUID     `json:"id" db:"id"`
+	VenueID      uuid.UUID     `json:"venue_id" db:"venue_id"`
+	Name         string        `json:"name" db:"name"`
+	Kind         VenueGateKind `json:"kind" db:"kind"`
+	Description  *string       `json:"description,omitempty" db:"description"`
+	IsAccessible bool          `json:"is_accessible" db:"is_accessible"`
+	Position     int           `json:"position" db:"position"`
+	CreatedAt    time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at" db:"updated_at"`
+}
+
+// NewVenueGate creates a new gate or entrance
+func NewVenueGate(name string, kind VenueGateKind) *VenueGate {
+	now := time.Now()
+	if kind == "" {
+		kind = VenueGateKindGate
+	}
+	return &VenueGate{
+		ID:        uuid.New(),
+		Name:      strings.TrimSpace(name),
+		Kind:      kind,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+}
+
+// Validate performs business rule validation for the gate
+func (g *VenueGate) Validate() error {
+	if g.Name == "" {
+		return NewValidationError("gates", "gate name is required")
+	}
+	if len(g.Name) > 100 {
+		return NewValidationError("gates", "gate name must be at most 100 characters")
+	}
+	if !g.Kind.IsValid() {
+		return NewValidationError("gates", "gate kind must be gate or entrance")
+	}
+	return nil
+}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WalletPassRegistration is an Apple Wallet device that installed a ticket pass
+// and wants a push notification whenever the pass changes
+type WalletPassRegistration struct {
+	ID              uuid.UUID `json:"id" db:"id"`
+	DeviceLibraryID string    `json:"device_library_id" db:"device_library_id"`
+	PushToken       string    `json:"push_token" db:"push_token"`
+	PassTypeID      string    `json:"pass_type_id" db:"pass_type_id"`
+	SerialNumber    string    `json:"serial_number" db:"serial_number"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// NewWalletPassRegistration creates a device registration for a pass
+func NewWalletPassRegistration(deviceLibraryID, pushToken, passTypeID, serialNumber string) *WalletPassRegistration {
+	now := time.Now()
+	return &WalletPassRegistration{
+		ID:              uuid.New(),
+		DeviceLibraryID: deviceLibraryID,
+		PushToken:       pushToken,
+		PassTypeID:      passTypeID,
+		SerialNumber:    serialNumber,
+		CreatedAt:       now,
+		UpdatedAt:       now,
+	}
+}
+
+// Validate performs business rule validation for the registration
+func (r *WalletPassRegistration) Validate() error {
+	if r.DeviceLibraryID == "" {
+		return NewValidationError("device_library_id", "device library identifier is required")
+	}
+	if r.PushToken == "" {
+		return NewValidationError("push_token", "push token is required")
+	}
+	if r.PassTypeID == "" {
+		return NewValidationError("pass_type_id", "pass type identifier is required")
+	}
+	if r.SerialNumber == "" {
+		return NewValidationError("serial_number", "serial number is required")
+	}
+	return nil
+}
@@ -0,0 +1,284 @@
+package entities
+
+import (
+	"crypto/rand"
+	"database/sql/driver"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// WebhookDeliveryStatus represents the state of one webhook delivery
+type WebhookDeliveryStatus string
+
+const (
+	// WebhookDeliveryPending deliveries are waiting for their first or next attempt
+	WebhookDeliveryPending WebhookDeliveryStatus = "pending"
+	// WebhookDeliverySucceeded deliveries were answered with a 2xx status
+	WebhookDeliverySucceeded WebhookDeliveryStatus = "succeeded"
+	// WebhookDeliveryFailed deliveries ran out of attempts or their subscription was disabled
+	WebhookDeliveryFailed WebhookDeliveryStatus = "failed"
+)
+
+const (
+	// WebhookMaxConsecutiveFailures is how many failed attempts in a row disable a subscription
+	WebhookMaxConsecutiveFailures = 25
+
+	// webhookSecretPrefix makes signing secrets recognizable, e.g. in leaked logs
+	webhookSecretPrefix = "whsec_"
+
+	// webhookMaxResponseLength keeps stored receiver responses readable
+	webhookMaxResponseLength = 2000
+)
+
+// WebhookEventTypes are the domain events organizers can subscribe to
+var WebhookEventTypes = []string{
+	DomainEventOrderCreated,
+	DomainEventOrderPaid,
+	DomainEventOrderExpired,
+	DomainEventTicketIssued,
+	DomainEventTicketRedeemed,
+	DomainEventTicketVoided,
+	DomainEventEventPublished,
+}
+
+// IsWebhookEventType reports whether organizers can subscribe to the event type
+func IsWebhookEventType(eventType string) bool {
+	for _, t := range WebhookEventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// WebhookEventList is a list of event types stored as a JSONB array
+type WebhookEventList []string
+
+// Value implements the driver.Valuer interface for database writes
+func (l WebhookEventList) Value() (driver.Value, error) {
+	if l == nil {
+		return json.Marshal([]string{})
+	}
+	return json.Marshal([]string(l))
+}
+
+// Scan implements the sql.Scanner interface for database reads
+func (l *WebhookEventList) Scan(value interface{}) error {
+	if value == nil {
+		*l = WebhookEventList{}
+		return nil
+	}
+
+	var bytes []byte
+	switch v := value.(type) {
+	case []byte:
+		bytes = v
+	case string:
+		bytes = []byte(v)
+	default:
+		return fmt.Errorf("cannot scan %T into WebhookEventList", value)
+	}
+
+	var types []string
+	if err := json.Unmarshal(bytes, &types); err != nil {
+		return err
+	}
+	*l = types
+	return nil
+}
+
+// WebhookSubscription sends an organizer's domain events to a URL of theirs.
+// Payloads are signed with Secret so the receiver can verify them.
+type WebhookSubscription struct {
+	ID                  uuid.UUID        `json:"id" db:"id"`
+	OrganizerID         uuid.UUID        `json:"organizer_id" db:"organizer_id"`
+	URL                 string           `json:"url" db:"url"`
+	Description         *string          `json:"description,omitempty" db:"description"`
+	Secret              string           `json:"-" db:"secret"`
+	EventTypes          WebhookEventList `json:"event_types" db:"event_types"`
+	IsActive            bool             `json:"is_active" db:"is_active"`
+	ConsecutiveFailures int              `json:"consecutive_failures" db:"consecutive_failures"`
+	DisabledAt          *time.Time       `json:"disabled_at,omitempty" db:"disabled_at"`
+	DisabledReason      *string          `json:"disabled_reason,omitempty" db:"disabled_reason"`
+	LastSuccessAt       *time.Time       `json:"last_success_at,omitempty" db:"last_success_at"`
+	LastFailureAt       *time.Time       `json:"last_failure_at,omitempty" db:"last_failure_at"`
+	CreatedAt           time.Time        `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time        `json:"updated_at" db:"updated_at"`
+}
+
+// NewWebhookSubscription creates an active subscription with a fresh signing secret
+func NewWebhookSubscription(organizerID uuid.UUID, endpoint string, eventTypes []string) (*WebhookSubscription, error) {
+	secret, err := GenerateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &WebhookSubscription{
+		ID:          uuid.New(),
+		OrganizerID: organizerID,
+		URL:         endpoint,
+		Secret:      secret,
+		EventTypes:  eventTypes,
+		IsActive:    true,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+// GenerateWebhookSecret creates a random signing secret
+func GenerateWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return webhookSecretPrefix + hex.EncodeToString(buf), nil
+}
+
+// Validate validates the subscription
+func (s *WebhookSubscription) Validate() error {
+	if s.OrganizerID == uuid.Nil {
+		return NewValidationError("organizer_id", "organizer is required")
+	}
+
+	endpoint, err := url.Parse(s.URL)
+	if err != nil || endpoint.Host == "" || (endpoint.Scheme != "https" && endpoint.Scheme != "http") {
+		return NewValidationError("url", "url must be an absolute http or https URL")
+	}
+	if endpoint.User != nil {
+		return NewValidationError("url", "url must not contain credentials")
+	}
+
+	if len(s.EventTypes) == 0 {
+		return NewValidationError("event_types", "at least one event type is required")
+	}
+	seen := make(map[string]bool, len(s.EventTypes))
+	for _, eventType := range s.EventTypes {
+		if !IsWebhookEventType(eventType) {
+			return NewValidationError("event_types", fmt.Sprintf("unknown event type '%s'", eventType))
+		}
+		if seen[eventType] {
+			return NewValidationError("event_types", fmt.Sprintf("event type '%s' is listed twice", eventType))
+		}
+		seen[eventType] = true
+	}
+
+	return nil
+}
+
+// Subscribes reports whether the subscription wants events of the given type
+func (s *WebhookSubscription) Subscribes(eventType string) bool {
+	for _, t := range s.EventTypes {
+		if t == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+// Enable reactivates the subscription and forgets its failure streak
+func (s *WebhookSubscription) Enable() {
+	s.IsActive = true
+	s.ConsecutiveFailures = 0
+	s.DisabledAt = nil
+	s.DisabledReason = nil
+	s.UpdatedAt = time.Now()
+}
+
+// Disable stops deliveries to the subscription
+func (s *WebhookSubscription) Disable(reason string) {
+	now := time.Now()
+	s.IsActive = false
+	s.DisabledAt = &now
+	s.DisabledReason = &reason
+	s.UpdatedAt = now
+}
+
+// WebhookDelivery is one event sent to one subscription. Its ID is sent as the
+// idempotency ID and stays the same across retries and manual redeliveries.
+type WebhookDelivery struct {
+	ID             uuid.UUID             `json:"id" db:"id"`
+	SubscriptionID uuid.UUID             `json:"subscription_id" db:"subscription_id"`
+	EventType      string                `json:"event_type" db:"event_type"`
+	AggregateID    uuid.UUID             `json:"aggregate_id" db:"aggregate_id"`
+	Payload        JSONB                 `json:"payload" db:"payload"`
+	Status         WebhookDeliveryStatus `json:"status" db:"status"`
+	Attempts       int                   `json:"attempts" db:"attempts"`
+	LastStatusCode *int                  `json:"last_status_code,omitempty" db:"last_status_code"`
+	LastError      *string               `json:"last_error,omitempty" db:"last_error"`
+	LastResponse   *string               `json:"last_response,omitempty" db:"last_response"`
+	LastAttemptAt  *time.Time            `json:"last_attempt_at,omitempty" db:"last_attempt_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at,omitempty" db:"delivered_at"`
+	CreatedAt      time.Time             `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time             `json:"updated_at" db:"updated_at"`
+}
+
+// NewWebhookDelivery creates a pending delivery of an event to a subscription
+func NewWebhookDelivery(subscriptionID uuid.UUID, eventType string, aggregateID uuid.UUID, payload JSONB) *WebhookDelivery {
+	now := time.Now()
+	return &WebhookDelivery{
+		ID:             uuid.New(),
+		SubscriptionID: subscriptionID,
+		EventType:      eventType,
+		AggregateID:    aggregateID,
+		Payload:        payload,
+		Status:         WebhookDeliveryPending,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+// RecordSuccess records an attempt the receiver accepted
+func (d *WebhookDelivery) RecordSuccess(statusCode int, response string, now time.Time) {
+	d.recordAttempt(&statusCode, nil, response, now)
+	d.Status = WebhookDeliverySucceeded
+	d.DeliveredAt = &now
+}
+
+// RecordFailure records a failed attempt. statusCode is nil when the receiver
+// could not be reached. A final failure gives up on the delivery.
+func (d *WebhookDelivery) RecordFailure(statusCode *int, message, response string, now time.Time, final bool) {
+	d.recordAttempt(statusCode, &message, response, now)
+	if final {
+		d.Status = WebhookDeliveryFailed
+	}
+}
+
+// Abandon gives up on the delivery without another attempt
+func (d *WebhookDelivery) Abandon(reason string) {
+	d.Status = WebhookDeliveryFailed
+	d.LastError = &reason
+	d.UpdatedAt = time.Now()
+}
+
+// Redeliver queues a finished delivery to be sent again with the same ID
+func (d *WebhookDelivery) Redeliver() error {
+	if d.Status == WebhookDeliveryPending {
+		return NewBusinessRuleError("webhook_delivery_pending", "delivery is still pending", map[string]interface{}{
+			"attempts": d.Attempts,
+		})
+	}
+	d.Status = WebhookDeliveryPending
+	d.UpdatedAt = time.Now()
+	return nil
+}
+
+func (d *WebhookDelivery) recordAttempt(statusCode *int, message *string, response string, now time.Time) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = message
+	d.LastResponse = nil
+	if response != "" {
+		if len(response) > webhookMaxResponseLength {
+			response = response[:webhookMaxResponseLength]
+		}
+		d.LastResponse = &response
+	}
+	d.LastAttemptAt = &now
+	d.UpdatedAt = now
+}
@@ -0,0 +1,128 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// AnalyticsRepository defines the interface for sales and buyer analytics.
+// Sales figures come from daily rollups that are refreshed periodically;
+// buyer and event counts are live.
+type AnalyticsRepository interface {
+	// GetSalesTotals sums sales over the filter's period per currency
+	GetSalesTotals(ctx context.Context, filter AnalyticsFilter) ([]*SalesFigures, error)
+	
+	// GetSalesSeries sums sales per bucket and currency; buckets without sales are left out
+	GetSalesSeries(ctx context.Context, filter AnalyticsFilter, bucket AnalyticsBucket) ([]*SalesPoint, error)
+	
+	// GetSalesBreakdown sums paid sales per value of a dimension, keeping the
+	// limit best-selling values by revenue in each currency
+	GetSalesBreakdown(ctx context.Context, filter AnalyticsFilter, dimension AnalyticsDimension, limit int) ([]*SalesBreakdown, error)
+	
+	// GetBuyerTotals counts the buyers in the filter's period and how many bought for the first time
+	GetBuyerTotals(ctx context.Context, filter AnalyticsFilter) (*BuyerTotals, error)
+	
+	// GetBuyerSeries counts buyers per bucket; buckets without buyers are left out
+	GetBuyerSeries(ctx context.Context, filter AnalyticsFilter, bucket AnalyticsBucket) ([]*BuyerPoint, error)
+	
+	// GetEventCounts counts events, optionally for one organizer
+	GetEventCounts(ctx context.Context, organizerID *uuid.UUID) (*EventCounts, error)
+	
+	// Refresh rebuilds the sales rollups from orders and returns when the refresh started
+	Refresh(ctx context.Context) (time.Time, error)
+	
+	// GetRefreshedAt returns when the sales rollups were last refreshed, or nil if never
+	GetRefreshedAt(ctx context.Context) (*time.Time, error)
+}
+
+// AnalyticsBucket is the period sales and buyers are grouped by over time
+type AnalyticsBucket string
+
+const (
+	AnalyticsBucketDay   AnalyticsBucket = "day"
+	AnalyticsBucketWeek  AnalyticsBucket = "week"
+	AnalyticsBucketMonth AnalyticsBucket = "month"
+)
+
+// IsValid checks if the bucket is valid
+func (b AnalyticsBucket) IsValid() bool {
+	switch b {
+	case AnalyticsBucketDay, AnalyticsBucketWeek, AnalyticsBucketMonth:
+		return true
+	default:
+		return false
+	}
+}
+
+// AnalyticsDimension is what sales are broken down by
+type AnalyticsDimension string
+
+const (
+	AnalyticsDimensionEvent         AnalyticsDimension = "event"
+	AnalyticsDimensionTier          AnalyticsDimension = "tier"
+	AnalyticsDimensionPaymentMethod AnalyticsDimension = "payment_method"
+	AnalyticsDimensionCity          AnalyticsDimension = "city"
+)
+
+// AnalyticsFilter limits analytics to a period of whole UTC days, [From, To),
+// and optionally to an organizer, an event and a currency
+type AnalyticsFilter struct {
+	From        time.Time
+	To          time.Time
+	OrganizerID *uuid.UUID
+	EventID     *uuid.UUID
+	Currency    string
+}
+
+// SalesFigures summarizes sales in one currency. Orders are counted when they
+// are placed and, if paid, again when they are paid.
+type SalesFigures struct {
+	Currency          string  `json:"currency" db:"currency"`
+	OrdersCreated     int     `json:"orders_created" db:"orders_created"`
+	PaidOrders        int     `json:"paid_orders" db:"paid_orders"`
+	TicketsSold       int     `json:"tickets_sold" db:"tickets_sold"`
+	Revenue           float64 `json:"revenue" db:"revenue"`
+	AverageOrderValue float64 `json:"average_order_value" db:"-"`
+	ConversionRate    float64 `json:"conversion_rate" db:"-"`
+}
+
+// SalesPoint summarizes the sales in one currency during one bucket
+type SalesPoint struct {
+	PeriodStart time.Time `json:"period_start" db:"period_start"`
+	SalesFigures
+}
+
+// SalesBreakdown summarizes paid sales in one currency for one value of a
+// dimension, e.g. an event or a payment method
+type SalesBreakdown struct {
+	Key         string  `json:"key" db:"key"`
+	Label       string  `json:"label" db:"label"`
+	Currency    string  `json:"currency" db:"currency"`
+	PaidOrders  int     `json:"paid_orders" db:"paid_orders"`
+	TicketsSold int     `json:"tickets_sold" db:"tickets_sold"`
+	Revenue     float64 `json:"revenue" db:"revenue"`
+}
+
+// BuyerTotals counts distinct buyers. New buyers made their first purchase in
+// the period; returning buyers had bought before it. With an organizer or
+// event in the filter, only purchases from it count.
+type BuyerTotals struct {
+	Buyers          int `json:"buyers" db:"buyers"`
+	NewBuyers       int `json:"new_buyers" db:"new_buyers"`
+	ReturningBuyers int `json:"returning_buyers" db:"-"`
+}
+
+// BuyerPoint counts the buyers during one bucket
+type BuyerPoint struct {
+	PeriodStart time.Time `json:"period_start" db:"period_start"`
+	BuyerTotals
+}
+
+// EventCounts counts events by whether they are on sale and still to come
+type EventCounts struct {
+	TotalEvents     int `json:"total_events" db:"total_events"`
+	PublishedEvents int `json:"published_events" db:"published_events"`
+	UpcomingEvents  int `json:"upcoming_events" db:"upcoming_events"`
+}
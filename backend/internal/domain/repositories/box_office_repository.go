@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// BoxOfficeRepository defines the interface for box office shift and sale persistence
+type BoxOfficeRepository interface {
+	// CreateShift opens a new cash drawer session
+	CreateShift(ctx context.Context, shift *entities.BoxOfficeShift) error
+	
+	// GetShiftByID retrieves a shift by ID
+	GetShiftByID(ctx context.Context, id uuid.UUID) (*entities.BoxOfficeShift, error)
+	
+	// GetOpenShiftByOperator retrieves the operator's currently open shift
+	GetOpenShiftByOperator(ctx context.Context, operatorID uuid.UUID) (*entities.BoxOfficeShift, error)
+	
+	// UpdateShift updates an existing shift
+	UpdateShift(ctx context.Context, shift *entities.BoxOfficeShift) error
+	
+	// ListShifts retrieves shifts with pagination and filtering
+	ListShifts(ctx context.Context, filter BoxOfficeShiftFilter) ([]*entities.BoxOfficeShift, *PaginationResult, error)
+	
+	// CreateSale records a box office sale against a shift
+	CreateSale(ctx context.Context, sale *entities.BoxOfficeSale) error
+	
+	// GetSaleByOrder retrieves the box office sale for an order
+	GetSaleByOrder(ctx context.Context, orderID uuid.UUID) (*entities.BoxOfficeSale, error)
+	
+	// GetSalesByShift retrieves all sales taken during a shift
+	GetSalesByShift(ctx context.Context, shiftID uuid.UUID) ([]*entities.BoxOfficeSale, error)
+	
+	// GetShiftTotals retrieves sale totals for a shift grouped by payment method
+	GetShiftTotals(ctx context.Context, shiftID uuid.UUID) ([]*BoxOfficePaymentTotal, error)
+}
+
+// BoxOfficeShiftFilter defines filtering options for shift queries
+type BoxOfficeShiftFilter struct {
+	BaseFilter
+	
+	// Filtering
+	EventID    *uuid.UUID
+	OperatorID *uuid.UUID
+	Status     *entities.BoxOfficeShiftStatus
+	
+	// Date filtering
+	OpenedFrom *time.Time
+	OpenedTo   *time.Time
+}
+
+// BoxOfficePaymentTotal represents the takings of a shift for one payment method
+type BoxOfficePaymentTotal struct {
+	PaymentMethod entities.PaymentMethod `json:"payment_method" db:"payment_method"`
+	Sales         int                    `json:"sales" db:"sales"`
+	Tickets       int                    `json:"tickets" db:"tickets"`
+	Amount        float64                `json:"amount" db:"amount"`
+	ChangeGiven   float64                `json:"change_given" db:"change_given"`
+}
@@ -0,0 +1,41 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// CategoryRepository defines the interface for event category persistence operations
+type CategoryRepository interface {
+	// Create creates a new category
+	Create(ctx context.Context, category *entities.Category) error
+	
+	// GetByID retrieves a category by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error)
+	
+	// GetBySlug retrieves a category by its slug
+	GetBySlug(ctx context.Context, slug string) (*entities.Category, error)
+	
+	// Update updates an existing category
+	Update(ctx context.Context, category *entities.Category) error
+	
+	// Delete deletes a category
+	Delete(ctx context.Context, id uuid.UUID) error
+	
+	// List retrieves all categories matching the filter with their listed event counts.
+	// Categories are few, so the list is not paginated.
+	List(ctx context.Context, filter CategoryFilter) ([]*entities.Category, error)
+	
+	// Reorder sets the display order of categories to their position in ids
+	Reorder(ctx context.Context, ids []uuid.UUID) error
+	
+	// CountEvents counts all events in a category, whatever their status
+	CountEvents(ctx context.Context, categoryID uuid.UUID) (int, error)
+}
+
+// CategoryFilter defines filtering options for category queries
+type CategoryFilter struct {
+	IsActive *bool
+}
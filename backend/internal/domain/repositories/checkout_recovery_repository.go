@@ -0,0 +1,74 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// CheckoutRecoveryRepository defines the interface for abandoned checkout recovery persistence
+type CheckoutRecoveryRepository interface {
+	// Create records a recovery unless the order already has one or the same
+	// customer had one for the event within the throttle window; false means
+	// nothing was recorded
+	Create(ctx context.Context, recovery *entities.CheckoutRecovery, throttle time.Duration) (bool, error)
+	
+	// GetByID retrieves a recovery by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.CheckoutRecovery, error)
+	
+	// GetByToken retrieves a recovery by the token in its link
+	GetByToken(ctx context.Context, token string) (*entities.CheckoutRecovery, error)
+	
+	// GetByRecoveredOrder retrieves the recovery whose link placed an order
+	GetByRecoveredOrder(ctx context.Context, orderID uuid.UUID) (*entities.CheckoutRecovery, error)
+	
+	// ClaimDue retrieves pending recoveries whose send time has passed and
+	// pushes their send time back by lease, so no other worker sends them
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.CheckoutRecovery, error)
+	
+	// Update records the delivery and conversion of a recovery
+	Update(ctx context.Context, recovery *entities.CheckoutRecovery) error
+	
+	// HasPurchased reports whether the customer paid for the event since abandoning the order, the abandoned order included
+	HasPurchased(ctx context.Context, recovery *entities.CheckoutRecovery) (bool, error)
+	
+	// List retrieves recoveries with pagination and filtering
+	List(ctx context.Context, filter CheckoutRecoveryFilter) ([]*entities.CheckoutRecovery, *PaginationResult, error)
+	
+	// GetStats summarizes recoveries per currency
+	GetStats(ctx context.Context, filter CheckoutRecoveryStatsFilter) ([]*CheckoutRecoveryStats, error)
+}
+
+// CheckoutRecoveryFilter defines filtering options for checkout recovery queries
+type CheckoutRecoveryFilter struct {
+	BaseFilter
+	
+	EventID   *uuid.UUID
+	Status    *entities.CheckoutRecoveryStatus
+	Reason    *entities.CheckoutRecoveryReason
+	Converted *bool
+}
+
+// CheckoutRecoveryStatsFilter limits recovery stats to an event and to recoveries created in a period
+type CheckoutRecoveryStatsFilter struct {
+	EventID *uuid.UUID
+	From    *time.Time
+	To      *time.Time
+}
+
+// CheckoutRecoveryStats summarizes the recoveries in one currency
+type CheckoutRecoveryStats struct {
+	Currency        string  `json:"currency" db:"currency"`
+	Abandoned       int     `json:"abandoned" db:"abandoned"`
+	Pending         int     `json:"pending" db:"pending"`
+	Sent            int     `json:"sent" db:"sent"`
+	Skipped         int     `json:"skipped" db:"skipped"`
+	Failed          int     `json:"failed" db:"failed"`
+	Clicked         int     `json:"clicked" db:"clicked"`
+	Converted       int     `json:"converted" db:"converted"`
+	AbandonedAmount float64 `json:"abandoned_amount" db:"abandoned_amount"`
+	RecoveredAmount float64 `json:"recovered_amount" db:"recovered_amount"`
+	ConversionRate  float64 `json:"conversion_rate" db:"-"`
+}
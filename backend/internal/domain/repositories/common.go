@@ -81,6 +81,48 @@ type Transaction interface {
 	
 	// OTPTokens returns the OTP token repository within this transaction
 	OTPTokens() OTPTokenRepository
+	
+	// BoxOffice returns the box office repository within this transaction
+	BoxOffice() BoxOfficeRepository
+	
+	// Comps returns the comp and guest list repository within this transaction
+	Comps() CompRepository
+	
+	// TicketImports returns the ticket import repository within this transaction
+	TicketImports() TicketImportRepository
+	
+	// Venues returns the venue repository within this transaction
+	Venues() VenueRepository
+	
+	// TourPasses returns the tour pass repository within this transaction
+	TourPasses() TourPassRepository
+	
+	// EventSeries returns the event series repository within this transaction
+	EventSeries() EventSeriesRepository
+	
+	// ScannerUsers returns the scanner user repository within this transaction
+	ScannerUsers() ScannerUserRepository
+	
+	// EventChanges returns the event change repository within this transaction
+	EventChanges() EventChangeRepository
+	
+	// Outbox returns the outbox repository within this transaction
+	Outbox() OutboxRepository
+	
+	// Webhooks returns the webhook repository within this transaction
+	Webhooks() WebhookRepository
+	
+	// Notifications returns the notification message repository within this transaction
+	Notifications() NotificationRepository
+	
+	// MessageTemplates returns the message template repository within this transaction
+	MessageTemplates() MessageTemplateRepository
+	
+	// EventCampaigns returns the event campaign repository within this transaction
+	EventCampaigns() EventCampaignRepository
+	
+	// MarketingCampaigns returns the marketing campaign repository within this transaction
+	MarketingCampaigns() MarketingCampaignRepository
 }
 
 // RepositoryManager defines the interface for accessing all repositories
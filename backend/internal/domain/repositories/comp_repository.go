@@ -0,0 +1,58 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// CompRepository defines the interface for comp allocation and guest list persistence
+type CompRepository interface {
+	// CreateAllocation creates a comp quota for a ticket tier
+	CreateAllocation(ctx context.Context, allocation *entities.CompAllocation) error
+	
+	// GetAllocationByID retrieves an allocation by ID
+	GetAllocationByID(ctx context.Context, id uuid.UUID) (*entities.CompAllocation, error)
+	
+	// GetAllocationByTier retrieves the allocation for a ticket tier
+	GetAllocationByTier(ctx context.Context, ticketTierID uuid.UUID) (*entities.CompAllocation, error)
+	
+	// UpdateAllocation updates an allocation's quota
+	UpdateAllocation(ctx context.Context, allocation *entities.CompAllocation) error
+	
+	// ListAllocations retrieves the allocations for an event
+	ListAllocations(ctx context.Context, eventID uuid.UUID) ([]*entities.CompAllocation, error)
+	
+	// IncrementIssued atomically consumes quota, failing with ErrCompQuotaExceeded when not enough remains
+	IncrementIssued(ctx context.Context, allocationID uuid.UUID, quantity int) error
+	
+	// DecrementIssued atomically returns quota to an allocation
+	DecrementIssued(ctx context.Context, allocationID uuid.UUID, quantity int) error
+	
+	// CreateGuestListEntry creates a guest list entry
+	CreateGuestListEntry(ctx context.Context, entry *entities.GuestListEntry) error
+	
+	// GetGuestListEntryByID retrieves a guest list entry by ID
+	GetGuestListEntryByID(ctx context.Context, id uuid.UUID) (*entities.GuestListEntry, error)
+	
+	// UpdateGuestListEntry updates a guest list entry
+	UpdateGuestListEntry(ctx context.Context, entry *entities.GuestListEntry) error
+	
+	// ListGuestList retrieves guest list entries with pagination and filtering
+	ListGuestList(ctx context.Context, filter GuestListFilter) ([]*entities.GuestListEntry, *PaginationResult, error)
+}
+
+// GuestListFilter defines filtering options for guest list queries
+type GuestListFilter struct {
+	BaseFilter
+	
+	// Filtering
+	EventID      *uuid.UUID
+	TicketTierID *uuid.UUID
+	Status       *entities.GuestListStatus
+	Category     *entities.GuestListCategory
+	
+	// Search matches the start of the first name, last name or full name
+	Search string
+}
@@ -0,0 +1,86 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// EventCampaignRepository defines the interface for event reminder and follow-up campaign persistence
+type EventCampaignRepository interface {
+	// Create creates a new campaign
+	Create(ctx context.Context, campaign *entities.EventCampaign) error
+	
+	// GetByID retrieves a campaign by ID, with its send time computed from the event's current date
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.EventCampaign, error)
+	
+	// Update updates a campaign's settings, status and progress counters
+	Update(ctx context.Context, campaign *entities.EventCampaign) error
+	
+	// ListByEvent retrieves an event's campaigns in send order
+	ListByEvent(ctx context.Context, eventID uuid.UUID) ([]*entities.EventCampaign, error)
+	
+	// CreateDefaults adds a campaign of the type to every upcoming published event
+	// without one (a cancelled campaign counts) and returns how many were added
+	CreateDefaults(ctx context.Context, campaignType entities.CampaignType, offsetMinutes int) (int, error)
+	
+	// GetDue retrieves scheduled campaigns whose send time has passed, and
+	// processing or failed ones not touched since staleBefore
+	GetDue(ctx context.Context, staleBefore time.Time, limit int) ([]*entities.EventCampaign, error)
+	
+	// Claim marks a due campaign as processing; false means another worker has it
+	Claim(ctx context.Context, id uuid.UUID, staleBefore time.Time) (bool, error)
+	
+	// StageDeliveries adds one delivery per ticket holder still to be reached and returns how many were added
+	StageDeliveries(ctx context.Context, campaign *entities.EventCampaign) (int, error)
+	
+	// GetPendingDeliveries retrieves the next deliveries still to be sent
+	GetPendingDeliveries(ctx context.Context, campaignID uuid.UUID, limit int) ([]*entities.CampaignDelivery, error)
+	
+	// GetDelivery retrieves a delivery by ID
+	GetDelivery(ctx context.Context, id uuid.UUID) (*entities.CampaignDelivery, error)
+	
+	// GetDeliveryByToken retrieves a delivery by the token in its unsubscribe link
+	GetDeliveryByToken(ctx context.Context, token string) (*entities.CampaignDelivery, error)
+	
+	// UpdateDelivery records the outcome of a delivery
+	UpdateDelivery(ctx context.Context, delivery *entities.CampaignDelivery) error
+	
+	// SkipPendingDeliveries closes the deliveries still pending and returns how many there were
+	SkipPendingDeliveries(ctx context.Context, campaignID uuid.UUID, reason string) (int, error)
+	
+	// ListDeliveries retrieves deliveries with pagination and filtering
+	ListDeliveries(ctx context.Context, filter CampaignDeliveryFilter) ([]*entities.CampaignDelivery, *PaginationResult, error)
+	
+	// CountDeliveries recounts a campaign's deliveries by outcome
+	CountDeliveries(ctx context.Context, campaignID uuid.UUID) (*CampaignDeliveryCounts, error)
+	
+	// Unsubscribe records an unsubscribe; repeating one is not an error
+	Unsubscribe(ctx context.Context, unsubscribe *entities.CampaignUnsubscribe) error
+	
+	// ListUnsubscribes retrieves a contact's unsubscribes
+	ListUnsubscribes(ctx context.Context, contactKey string) ([]*entities.CampaignUnsubscribe, error)
+	
+	// DeleteUnsubscribes removes all of a contact's unsubscribes
+	DeleteUnsubscribes(ctx context.Context, contactKey string) error
+}
+
+// CampaignDeliveryFilter defines filtering options for campaign delivery queries
+type CampaignDeliveryFilter struct {
+	BaseFilter
+	
+	CampaignID uuid.UUID
+	Status     *entities.CampaignDeliveryStatus
+	Channel    *entities.NotificationChannel
+}
+
+// CampaignDeliveryCounts represents a campaign's deliveries grouped by outcome
+type CampaignDeliveryCounts struct {
+	Total   int `db:"total"`
+	Pending int `db:"pending"`
+	Sent    int `db:"sent"`
+	Skipped int `db:"skipped"`
+	Failed  int `db:"failed"`
+}
@@ -0,0 +1,79 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// EventChangeRepository defines the interface for event cancellation and postponement persistence
+type EventChangeRepository interface {
+	// Create creates a new event change
+	Create(ctx context.Context, change *entities.EventChange) error
+	
+	// GetByID retrieves an event change by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.EventChange, error)
+	
+	// Update updates an event change's status and progress counters
+	Update(ctx context.Context, change *entities.EventChange) error
+	
+	// List retrieves event changes with pagination and filtering
+	List(ctx context.Context, filter EventChangeFilter) ([]*entities.EventChange, *PaginationResult, error)
+	
+	// GetUnfinished retrieves changes still pending or left processing, e.g. by a crashed worker
+	GetUnfinished(ctx context.Context) ([]*entities.EventChange, error)
+	
+	// GetUnfinishedByEvent retrieves the event's change that is still being worked on, or nil
+	GetUnfinishedByEvent(ctx context.Context, eventID uuid.UUID) (*entities.EventChange, error)
+	
+	// StageRecipients adds one recipient per paid order of the event and returns how many were added
+	StageRecipients(ctx context.Context, change *entities.EventChange) (int, error)
+	
+	// GetPendingRecipients retrieves the next recipients still to be processed
+	GetPendingRecipients(ctx context.Context, changeID uuid.UUID, limit int) ([]*entities.EventChangeRecipient, error)
+	
+	// GetRecipient retrieves a recipient by ID
+	GetRecipient(ctx context.Context, id uuid.UUID) (*entities.EventChangeRecipient, error)
+	
+	// GetRecipientByToken retrieves a recipient by the token sent in their keep-or-refund link
+	GetRecipientByToken(ctx context.Context, token string) (*entities.EventChangeRecipient, error)
+	
+	// UpdateRecipient records the outcome of a recipient's steps
+	UpdateRecipient(ctx context.Context, recipient *entities.EventChangeRecipient) error
+	
+	// ListRecipients retrieves recipients with pagination and filtering
+	ListRecipients(ctx context.Context, filter EventChangeRecipientFilter) ([]*entities.EventChangeRecipient, *PaginationResult, error)
+	
+	// CountRecipients recounts a change's recipients by outcome
+	CountRecipients(ctx context.Context, changeID uuid.UUID) (*EventChangeRecipientCounts, error)
+}
+
+// EventChangeFilter defines filtering options for event change queries
+type EventChangeFilter struct {
+	BaseFilter
+	
+	// Filtering
+	EventID *uuid.UUID
+	Type    *entities.EventChangeType
+	Status  *entities.EventChangeStatus
+}
+
+// EventChangeRecipientFilter defines filtering options for event change recipient queries
+type EventChangeRecipientFilter struct {
+	BaseFilter
+	
+	ChangeID     uuid.UUID
+	Status       *entities.EventChangeRecipientStatus
+	RefundStatus *entities.RecipientRefundStatus
+}
+
+// EventChangeRecipientCounts represents a change's recipients grouped by outcome
+type EventChangeRecipientCounts struct {
+	Total            int `db:"total"`
+	Pending          int `db:"pending"`
+	Notified         int `db:"notified"`
+	Failed           int `db:"failed"`
+	RefundsRequested int `db:"refunds_requested"`
+	RefundsCompleted int `db:"refunds_completed"`
+}
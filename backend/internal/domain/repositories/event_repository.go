@@ -31,12 +31,21 @@ type EventRepository interface {
 	// ListPublic retrieves public events (published/on_sale) with pagination and filtering
 	ListPublic(ctx context.Context, filter PublicEventFilter) ([]*entities.Event, *PaginationResult, error)
 	
+	// ListPublicNearby retrieves public events within filter.RadiusKm of filter.Near, nearest first
+	ListPublicNearby(ctx context.Context, filter PublicEventFilter) ([]*NearbyEvent, *PaginationResult, error)
+	
 	// GetByOrganizer retrieves events for a specific organizer
 	GetByOrganizer(ctx context.Context, organizerID uuid.UUID, filter EventFilter) ([]*entities.Event, *PaginationResult, error)
 	
 	// GetByTour retrieves events for a specific tour
 	GetByTour(ctx context.Context, tourID uuid.UUID, filter EventFilter) ([]*entities.Event, *PaginationResult, error)
 	
+	// GetBySeries retrieves the occurrences of an event series in date order
+	GetBySeries(ctx context.Context, seriesID uuid.UUID) ([]*entities.Event, error)
+	
+	// GetPublicBySeries retrieves the public occurrences of the given series from a point in time, in date order
+	GetPublicBySeries(ctx context.Context, seriesIDs []uuid.UUID, from time.Time) ([]*entities.Event, error)
+	
 	// GetUpcoming retrieves upcoming events
 	GetUpcoming(ctx context.Context, filter EventFilter) ([]*entities.Event, *PaginationResult, error)
 	
@@ -96,16 +105,38 @@ type PublicEventFilter struct {
 	TourID      *uuid.UUID
 	Search      string
 	
+	// CategoryID matches events in the category or any of its subcategories
+	CategoryID *uuid.UUID
+	
 	// Date filtering
 	EventDateFrom *time.Time
 	EventDateTo   *time.Time
 	
+	// Geo filtering, used by ListPublicNearby
+	Near     *GeoPoint
+	RadiusKm float64
+	
+	// GroupSeries lists one event per series, the next upcoming occurrence
+	GroupSeries bool
+	
 	// Include related data
 	IncludeTour        bool
 	IncludeTicketTiers bool
 	IncludeMinMaxPrice bool
 }
 
+// GeoPoint is a latitude/longitude pair in decimal degrees
+type GeoPoint struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// NearbyEvent is an event with its distance from the searched point
+type NearbyEvent struct {
+	Event      *entities.Event
+	DistanceKm float64
+}
+
 // EventStats represents event statistics
 type EventStats struct {
 	EventID           uuid.UUID `json:"event_id"`
@@ -196,18 +227,18 @@ type TourFilter struct {
 
 // TourStats represents tour statistics
 type TourStats struct {
-	TourID           uuid.UUID  `json:"tour_id"`
-	TotalEvents      int        `json:"total_events"`
-	PublishedEvents  int        `json:"published_events"`
-	OnSaleEvents     int        `json:"on_sale_events"`
-	SoldOutEvents    int        `json:"sold_out_events"`
-	CompletedEvents  int        `json:"completed_events"`
-	CancelledEvents  int        `json:"cancelled_events"`
-	TotalTickets     int        `json:"total_tickets"`
-	SoldTickets      int        `json:"sold_tickets"`
-	TotalRevenue     float64    `json:"total_revenue"`
-	ConfirmedRevenue float64    `json:"confirmed_revenue"`
-	FirstEventDate   *time.Time `json:"first_event_date"`
-	LastEventDate    *time.Time `json:"last_event_date"`
+	TourID           uuid.UUID  `json:"tour_id" db:"tour_id"`
+	TotalEvents      int        `json:"total_events" db:"total_events"`
+	PublishedEvents  int        `json:"published_events" db:"published_events"`
+	OnSaleEvents     int        `json:"on_sale_events" db:"on_sale_events"`
+	SoldOutEvents    int        `json:"sold_out_events" db:"sold_out_events"`
+	CompletedEvents  int        `json:"completed_events" db:"completed_events"`
+	CancelledEvents  int        `json:"cancelled_events" db:"cancelled_events"`
+	TotalTickets     int        `json:"total_tickets" db:"total_tickets"`
+	SoldTickets      int        `json:"sold_tickets" db:"sold_tickets"`
+	TotalRevenue     float64    `json:"total_revenue" db:"total_revenue"`
+	ConfirmedRevenue float64    `json:"confirmed_revenue" db:"confirmed_revenue"`
+	FirstEventDate   *time.Time `json:"first_event_date" db:"first_event_date"`
+	LastEventDate    *time.Time `json:"last_event_date" db:"last_event_date"`
 }
 
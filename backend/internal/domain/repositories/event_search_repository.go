@@ -0,0 +1,96 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// EventSearchRepository defines the interface for public event search
+type EventSearchRepository interface {
+	// Search runs a ranked full-text search over public events and returns facet counts
+	// for the matched set. When Fuzzy is set, trigram similarity is used instead.
+	Search(ctx context.Context, filter EventSearchFilter) (*EventSearchResult, error)
+	
+	// Suggest returns autocomplete suggestions for a partial query
+	Suggest(ctx context.Context, prefix string, limit int) ([]*SearchSuggestion, error)
+}
+
+// EventSearchFilter defines the query, filters and facet definitions for an event search
+type EventSearchFilter struct {
+	BaseFilter
+	
+	// Query is free text; an empty query matches every public event
+	Query string
+	Fuzzy bool
+	
+	// Filtering
+	CategoryID *uuid.UUID // includes its subcategories
+	City       string
+	DateFrom   *time.Time
+	DateTo     *time.Time
+	PriceMin   *float64
+	PriceMax   *float64
+	
+	// Facet definitions, counted over the matched set before the price and date filters
+	// narrow it, so each option still shows how many events selecting it would return
+	DateBuckets []DateBucket
+	PriceBands  []PriceBand
+}
+
+// DateBucket is a named event date range [From, To)
+type DateBucket struct {
+	Key  string
+	From time.Time
+	To   time.Time
+}
+
+// PriceBand is a named ticket price range [Min, Max). A nil bound is open.
+type PriceBand struct {
+	Key string
+	Min *float64
+	Max *float64
+}
+
+// EventSearchHit is a matched event with its relevance and ticket price range
+type EventSearchHit struct {
+	Event         *entities.Event `json:"event"`
+	Rank          float64         `json:"rank"`
+	ArtistName    *string         `json:"artist_name,omitempty"`
+	OrganizerName *string         `json:"organizer_name,omitempty"`
+	CategoryName  *string         `json:"category_name,omitempty"`
+	MinPrice      *float64        `json:"min_price,omitempty"`
+	MaxPrice      *float64        `json:"max_price,omitempty"`
+}
+
+// FacetCount is the number of matched events for one facet value
+type FacetCount struct {
+	Key   string `json:"key" db:"key"`
+	Label string `json:"label,omitempty" db:"label"`
+	Count int    `json:"count" db:"count"`
+}
+
+// SearchFacets holds facet counts for a search
+type SearchFacets struct {
+	Categories  []FacetCount `json:"categories"`
+	Cities      []FacetCount `json:"cities"`
+	DateBuckets []FacetCount `json:"date_buckets"`
+	PriceBands  []FacetCount `json:"price_bands"`
+}
+
+// EventSearchResult is a page of search hits with facets
+type EventSearchResult struct {
+	Hits       []*EventSearchHit `json:"hits"`
+	Facets     *SearchFacets     `json:"facets"`
+	Pagination *PaginationResult `json:"pagination"`
+}
+
+// SearchSuggestion is an autocomplete entry
+type SearchSuggestion struct {
+	Text    string     `json:"text" db:"text"`
+	Type    string     `json:"type" db:"type"` // event, artist, venue, city
+	EventID *uuid.UUID `json:"event_id,omitempty" db:"event_id"`
+	Score   float64    `json:"score" db:"score"`
+}
@@ -0,0 +1,36 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// EventSeriesRepository defines the interface for event series persistence operations
+type EventSeriesRepository interface {
+	// Create creates a new event series
+	Create(ctx context.Context, series *entities.EventSeries) error
+	
+	// GetByID retrieves an event series by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.EventSeries, error)
+	
+	// GetByIDForUpdate retrieves an event series and locks it until the transaction ends,
+	// so occurrences are never generated twice
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.EventSeries, error)
+	
+	// Update updates an existing event series
+	Update(ctx context.Context, series *entities.EventSeries) error
+	
+	// List retrieves event series with pagination and filtering
+	List(ctx context.Context, filter EventSeriesFilter) ([]*entities.EventSeries, *PaginationResult, error)
+}
+
+// EventSeriesFilter defines filtering options for event series queries
+type EventSeriesFilter struct {
+	BaseFilter
+	
+	OrganizerID *uuid.UUID
+	IsActive    *bool
+	Search      string // Search in name
+}
@@ -0,0 +1,34 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// EventTemplateRepository defines the interface for event template persistence operations
+type EventTemplateRepository interface {
+	// Create creates a new event template
+	Create(ctx context.Context, template *entities.EventTemplate) error
+	
+	// GetByID retrieves an active event template by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.EventTemplate, error)
+	
+	// Update updates an existing event template
+	Update(ctx context.Context, template *entities.EventTemplate) error
+	
+	// Delete deactivates an event template
+	Delete(ctx context.Context, id uuid.UUID) error
+	
+	// List retrieves active event templates with pagination and filtering
+	List(ctx context.Context, filter EventTemplateFilter) ([]*entities.EventTemplate, *PaginationResult, error)
+}
+
+// EventTemplateFilter defines filtering options for event template queries
+type EventTemplateFilter struct {
+	BaseFilter
+	
+	OrganizerID *uuid.UUID
+	Search      string // Search in name and description
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// FXRateRepository defines the interface for exchange rate persistence operations
+type FXRateRepository interface {
+	// Create records a new exchange rate
+	Create(ctx context.Context, rate *entities.FXRate) error
+	
+	// GetByID retrieves an exchange rate by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.FXRate, error)
+	
+	// GetEffective retrieves the rate in force for a currency pair at the given instant
+	GetEffective(ctx context.Context, baseCurrency, quoteCurrency string, at time.Time) (*entities.FXRate, error)
+	
+	// List retrieves exchange rates with pagination and filtering
+	List(ctx context.Context, filter FXRateFilter) ([]*entities.FXRate, *PaginationResult, error)
+	
+	// Delete deletes an exchange rate
+	Delete(ctx context.Context, id uuid.UUID) error
+}
+
+// FXRateFilter defines filtering options for exchange rate queries
+type FXRateFilter struct {
+	BaseFilter
+	
+	// Filtering
+	BaseCurrency  string
+	QuoteCurrency string
+	
+	// Date filtering
+	EffectiveFrom *time.Time
+	EffectiveTo   *time.Time
+}
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// MarketingConsentRepository defines the interface for marketing consent persistence
+type MarketingConsentRepository interface {
+	// Record stores a consent choice in the audit trail and makes it the
+	// contact's current choice for the organizer, in one statement
+	Record(ctx context.Context, record *entities.MarketingConsentRecord) (*entities.MarketingConsent, error)
+	
+	// ListConsents retrieves a contact's current choices, platform-wide first
+	ListConsents(ctx context.Context, contactKey string) ([]*entities.MarketingConsent, error)
+	
+	// HasConsent reports whether the latest choice for the organizer (nil for
+	// the platform) made under the contact key or the email address is a grant
+	HasConsent(ctx context.Context, contactKey string, email *string, organizerID *uuid.UUID) (bool, error)
+	
+	// ListRecords retrieves the consent audit trail, newest first
+	ListRecords(ctx context.Context, filter MarketingConsentRecordFilter) ([]*entities.MarketingConsentRecord, *PaginationResult, error)
+	
+	// ListUserOrganizers retrieves the organizers a user has bought tickets from
+	ListUserOrganizers(ctx context.Context, userID uuid.UUID) ([]*ConsentOrganizer, error)
+}
+
+// MarketingCampaignRepository defines the interface for marketing audience and campaign persistence
+type MarketingCampaignRepository interface {
+	// CreateAudience creates a new audience
+	CreateAudience(ctx context.Context, audience *entities.MarketingAudience) error
+	
+	// GetAudience retrieves an audience by ID
+	GetAudience(ctx context.Context, id uuid.UUID) (*entities.MarketingAudience, error)
+	
+	// UpdateAudience updates an audience's name and criteria
+	UpdateAudience(ctx context.Context, audience *entities.MarketingAudience) error
+	
+	// DeleteAudience deletes an audience no campaign was sent to
+	DeleteAudience(ctx context.Context, id uuid.UUID) error
+	
+	// ListAudiences retrieves audiences with pagination and filtering
+	ListAudiences(ctx context.Context, filter MarketingAudienceFilter) ([]*entities.MarketingAudience, *PaginationResult, error)
+	
+	// CountAudience counts an audience's members and how many of them consented
+	CountAudience(ctx context.Context, audience *entities.MarketingAudience) (*AudienceSize, error)
+	
+	// Create creates a new campaign
+	Create(ctx context.Context, campaign *entities.MarketingCampaign) error
+	
+	// GetByID retrieves a campaign by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.MarketingCampaign, error)
+	
+	// Update updates a campaign's content, schedule, status and progress counters
+	Update(ctx context.Context, campaign *entities.MarketingCampaign) error
+	
+	// List retrieves campaigns with pagination and filtering
+	List(ctx context.Context, filter MarketingCampaignFilter) ([]*entities.MarketingCampaign, *PaginationResult, error)
+	
+	// GetDue retrieves scheduled campaigns whose send time has passed, and
+	// processing or failed ones not touched since staleBefore
+	GetDue(ctx context.Context, staleBefore time.Time, limit int) ([]*entities.MarketingCampaign, error)
+	
+	// Claim marks a due campaign as processing; false means another worker has it
+	Claim(ctx context.Context, id uuid.UUID, staleBefore time.Time) (bool, error)
+	
+	// StageDeliveries adds one delivery per consenting audience member and returns how many were added
+	StageDeliveries(ctx context.Context, campaign *entities.MarketingCampaign, audience *entities.MarketingAudience) (int, error)
+	
+	// GetPendingDeliveries retrieves the next deliveries still to be sent
+	GetPendingDeliveries(ctx context.Context, campaignID uuid.UUID, limit int) ([]*entities.MarketingDelivery, error)
+	
+	// GetDelivery retrieves a delivery by ID
+	GetDelivery(ctx context.Context, id uuid.UUID) (*entities.MarketingDelivery, error)
+	
+	// GetDeliveryByToken retrieves a delivery by the token in its unsubscribe link
+	GetDeliveryByToken(ctx context.Context, token string) (*entities.MarketingDelivery, error)
+	
+	// UpdateDelivery records the outcome of a delivery
+	UpdateDelivery(ctx context.Context, delivery *entities.MarketingDelivery) error
+	
+	// SkipPendingDeliveries closes the deliveries still pending and returns how many there were
+	SkipPendingDeliveries(ctx context.Context, campaignID uuid.UUID, reason string) (int, error)
+	
+	// ListDeliveries retrieves deliveries with pagination and filtering
+	ListDeliveries(ctx context.Context, filter MarketingDeliveryFilter) ([]*entities.MarketingDelivery, *PaginationResult, error)
+	
+	// CountDeliveries recounts a campaign's deliveries by outcome
+	CountDeliveries(ctx context.Context, campaignID uuid.UUID) (*CampaignDeliveryCounts, error)
+}
+
+// MarketingConsentRecordFilter defines filtering options for the consent audit trail
+type MarketingConsentRecordFilter struct {
+	BaseFilter
+	
+	ContactKey  *string
+	UserID      *uuid.UUID
+	Email       *string
+	OrganizerID *uuid.UUID
+	// PlatformOnly limits the records to platform-wide choices
+	PlatformOnly bool
+}
+
+// MarketingAudienceFilter defines filtering options for audience queries
+type MarketingAudienceFilter struct {
+	BaseFilter
+	
+	OrganizerID  *uuid.UUID
+	PlatformOnly bool
+}
+
+// MarketingCampaignFilter defines filtering options for marketing campaign queries
+type MarketingCampaignFilter struct {
+	BaseFilter
+	
+	OrganizerID  *uuid.UUID
+	PlatformOnly bool
+	AudienceID   *uuid.UUID
+	Status       *entities.CampaignStatus
+}
+
+// MarketingDeliveryFilter defines filtering options for marketing delivery queries
+type MarketingDeliveryFilter struct {
+	BaseFilter
+	
+	CampaignID uuid.UUID
+	Status     *entities.CampaignDeliveryStatus
+}
+
+// ConsentOrganizer is an organizer a user can give marketing consent to
+type ConsentOrganizer struct {
+	ID   uuid.UUID `json:"id" db:"id"`
+	Name string    `json:"name" db:"name"`
+}
+
+// AudienceSize is how many buyers match an audience and how many of them can be emailed
+type AudienceSize struct {
+	Members   int `json:"members" db:"members"`
+	Reachable int `json:"reachable" db:"reachable"`
+}
@@ -0,0 +1,49 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// MessageTemplateRepository defines the interface for message template and organizer branding persistence operations
+type MessageTemplateRepository interface {
+	// Create stores a new template version, assigning the next version number of its scope
+	Create(ctx context.Context, template *entities.MessageTemplate) error
+	
+	// GetByID retrieves a template version by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.MessageTemplate, error)
+	
+	// GetActive retrieves the active version for a key, channel and locale; a
+	// nil organizer ID looks up the template shared by every organizer
+	GetActive(ctx context.Context, key string, channel entities.NotificationChannel, locale string, organizerID *uuid.UUID) (*entities.MessageTemplate, error)
+	
+	// Update records whether a template version is active
+	Update(ctx context.Context, template *entities.MessageTemplate) error
+	
+	// DeactivateOthers deactivates the active versions in the template's scope other than the template itself
+	DeactivateOthers(ctx context.Context, template *entities.MessageTemplate) error
+	
+	// List retrieves template versions, newest first
+	List(ctx context.Context, filter MessageTemplateFilter) ([]*entities.MessageTemplate, *PaginationResult, error)
+	
+	// GetBranding retrieves an organizer's message branding
+	GetBranding(ctx context.Context, organizerID uuid.UUID) (*entities.OrganizerBranding, error)
+	
+	// SaveBranding creates or replaces an organizer's message branding
+	SaveBranding(ctx context.Context, branding *entities.OrganizerBranding) error
+}
+
+// MessageTemplateFilter defines filtering options for message template queries
+type MessageTemplateFilter struct {
+	BaseFilter
+	
+	// Filtering
+	Key         string
+	Channel     *entities.NotificationChannel
+	Locale      string
+	OrganizerID *uuid.UUID
+	GlobalOnly  bool
+	ActiveOnly  bool
+}
@@ -0,0 +1,48 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// NotificationRepository defines the interface for notification message persistence operations
+type NotificationRepository interface {
+	// Create records a new notification message
+	Create(ctx context.Context, message *entities.NotificationMessage) error
+	
+	// GetByID retrieves a notification message by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.NotificationMessage, error)
+	
+	// GetByProviderMessageID retrieves the message a provider knows by its own ID
+	GetByProviderMessageID(ctx context.Context, provider, providerMessageID string) (*entities.NotificationMessage, error)
+	
+	// Update records a message's delivery state
+	Update(ctx context.Context, message *entities.NotificationMessage) error
+	
+	// CountSince counts the messages created for a recipient on a channel since a time
+	CountSince(ctx context.Context, channel entities.NotificationChannel, recipient string, since time.Time) (int, error)
+	
+	// List retrieves notification messages, newest first
+	List(ctx context.Context, filter NotificationFilter) ([]*entities.NotificationMessage, *PaginationResult, error)
+	
+	// GetWhatsAppOptIn retrieves a user's WhatsApp opt-in record
+	GetWhatsAppOptIn(ctx context.Context, userID uuid.UUID) (*entities.WhatsAppOptIn, error)
+	
+	// SaveWhatsAppOptIn creates or replaces a user's WhatsApp opt-in record
+	SaveWhatsAppOptIn(ctx context.Context, optIn *entities.WhatsAppOptIn) error
+}
+
+// NotificationFilter defines filtering options for notification message queries
+type NotificationFilter struct {
+	BaseFilter
+	
+	// Filtering
+	Channel   *entities.NotificationChannel
+	Status    *entities.NotificationStatus
+	Recipient string
+	OrderID   *uuid.UUID
+	Template  string
+}
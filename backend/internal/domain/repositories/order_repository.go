@@ -61,6 +61,9 @@ type OrderRepository interface {
 	// GetOrderStats retrieves statistics for an order
 	GetOrderStats(ctx context.Context, orderID uuid.UUID) (*OrderStats, error)
 	
+	// GetRevenueByCurrency retrieves paid revenue grouped by settlement currency
+	GetRevenueByCurrency(ctx context.Context, filter RevenueFilter) ([]*CurrencyRevenue, error)
+	
 	// Exists checks if an order exists by ID
 	Exists(ctx context.Context, id uuid.UUID) (bool, error)
 	
@@ -111,3 +114,21 @@ type OrderStats struct {
 	ConversionRate    float64 `json:"conversion_rate" db:"conversion_rate"`
 }
 
+
+// RevenueFilter defines filtering options for revenue aggregation
+type RevenueFilter struct {
+	EventID     *uuid.UUID
+	OrganizerID *uuid.UUID
+	Currency    string
+	DateFrom    *time.Time
+	DateTo      *time.Time
+}
+
+// CurrencyRevenue represents paid revenue settled in a single currency
+type CurrencyRevenue struct {
+	Currency          string  `json:"currency" db:"currency"`
+	PaidOrders        int     `json:"paid_orders" db:"paid_orders"`
+	TicketsSold       int     `json:"tickets_sold" db:"tickets_sold"`
+	GrossRevenue      float64 `json:"gross_revenue" db:"gross_revenue"`
+	AverageOrderValue float64 `json:"average_order_value" db:"average_order_value"`
+}
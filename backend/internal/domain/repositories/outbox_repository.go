@@ -0,0 +1,44 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// OutboxRepository defines the interface for outbox message persistence operations
+type OutboxRepository interface {
+	// Create records a new message; use the transaction's repository to record
+	// it atomically with the change that causes it
+	Create(ctx context.Context, message *entities.OutboxMessage) error
+	
+	// GetByID retrieves an outbox message by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.OutboxMessage, error)
+	
+	// Update records the outcome of a delivery attempt or a retry, along with
+	// the payload, which loses its secrets once delivered
+	Update(ctx context.Context, message *entities.OutboxMessage) error
+	
+	// ClaimDue locks up to limit pending messages that are due for lease, so
+	// concurrent dispatchers never deliver the same message at the same time.
+	// A message whose lease runs out without a result is claimed again.
+	ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxMessage, error)
+	
+	// List retrieves outbox messages with pagination and filtering, newest first
+	List(ctx context.Context, filter OutboxFilter) ([]*entities.OutboxMessage, *PaginationResult, error)
+	
+	// CountByStatus counts messages per status
+	CountByStatus(ctx context.Context) (map[entities.OutboxStatus]int, error)
+}
+
+// OutboxFilter defines filtering options for outbox message queries
+type OutboxFilter struct {
+	BaseFilter
+	
+	// Filtering
+	Status      *entities.OutboxStatus
+	Topic       string
+	AggregateID *uuid.UUID
+}
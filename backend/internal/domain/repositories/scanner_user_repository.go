@@ -44,6 +44,8 @@ type ScannerUserRepository interface {
 
 	// Ticket validation
 	ValidateTicket(ctx context.Context, validation *entities.TicketValidation) error
+	// RecordRedemption records a valid scan, reporting false when the ticket's valid scan in the session is already recorded
+	RecordRedemption(ctx context.Context, validation *entities.TicketValidation) (bool, error)
 	GetValidationHistory(ctx context.Context, scannerID uuid.UUID, filter *TicketValidationFilter) ([]*entities.TicketValidation, *PaginationResult, error)
 
 	// Statistics
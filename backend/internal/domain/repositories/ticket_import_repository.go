@@ -0,0 +1,68 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// TicketImportRepository defines the interface for bulk ticket import persistence
+type TicketImportRepository interface {
+	// Create creates a new import
+	Create(ctx context.Context, ticketImport *entities.TicketImport) error
+	
+	// GetByID retrieves an import by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketImport, error)
+	
+	// Update updates an import's status and progress counters
+	Update(ctx context.Context, ticketImport *entities.TicketImport) error
+	
+	// List retrieves imports with pagination and filtering
+	List(ctx context.Context, filter TicketImportFilter) ([]*entities.TicketImport, *PaginationResult, error)
+	
+	// CreateRows stages a batch of CSV rows
+	CreateRows(ctx context.Context, rows []*entities.TicketImportRow) error
+	
+	// GetPendingRows retrieves the next unprocessed rows in file order
+	GetPendingRows(ctx context.Context, importID uuid.UUID, limit int) ([]*entities.TicketImportRow, error)
+	
+	// UpdateRow records the outcome of a row
+	UpdateRow(ctx context.Context, row *entities.TicketImportRow) error
+	
+	// ListRows retrieves rows with pagination and filtering
+	ListRows(ctx context.Context, filter TicketImportRowFilter) ([]*entities.TicketImportRow, *PaginationResult, error)
+	
+	// CountRows recounts an import's rows by status
+	CountRows(ctx context.Context, importID uuid.UUID) (*TicketImportRowCounts, error)
+	
+	// FindOrderByExternalReference returns the ID of the event's order carrying the external reference, or nil
+	FindOrderByExternalReference(ctx context.Context, eventID uuid.UUID, externalReference string) (*uuid.UUID, error)
+}
+
+// TicketImportFilter defines filtering options for import queries
+type TicketImportFilter struct {
+	BaseFilter
+	
+	// Filtering
+	EventID *uuid.UUID
+	Status  *entities.TicketImportStatus
+}
+
+// TicketImportRowFilter defines filtering options for import row queries
+type TicketImportRowFilter struct {
+	BaseFilter
+	
+	ImportID uuid.UUID
+	Status   *entities.TicketImportRowStatus
+}
+
+// TicketImportRowCounts represents an import's rows grouped by status
+type TicketImportRowCounts struct {
+	Total         int `db:"total"`
+	Pending       int `db:"pending"`
+	Imported      int `db:"imported"`
+	Skipped       int `db:"skipped"`
+	Failed        int `db:"failed"`
+	TicketsIssued int `db:"tickets_issued"`
+}
@@ -106,11 +106,23 @@ type TicketTierRepository interface {
 	// GetTierStats retrieves statistics for a ticket tier
 	GetTierStats(ctx context.Context, tierID uuid.UUID) (*TicketTierStats, error)
 	
-	// GetAvailableQuantity retrieves the available quantity for a ticket tier
+	// GetAvailableQuantity retrieves the available quantity for a ticket tier, leaving out
+	// the unissued part of its comp allocation
 	GetAvailableQuantity(ctx context.Context, ticketTierID uuid.UUID) (int, error)
 
+	// GetByIDForUpdate retrieves a ticket tier and locks it until the transaction ends,
+	// so sales checking its availability one after another cannot oversell it
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error)
+
+	// LockForUpdate locks ticket tiers until the transaction ends and returns them by ID.
+	// Tiers are always locked in ID order, so sales sharing tiers cannot deadlock each other.
+	LockForUpdate(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.TicketTier, error)
+
 	// IncrementSold atomically increments the sold count for a ticket tier by the given quantity
 	IncrementSold(ctx context.Context, tierID uuid.UUID, quantity int) error
+
+	// DecrementSold atomically releases sold capacity on a ticket tier, e.g. when a comp is cancelled
+	DecrementSold(ctx context.Context, tierID uuid.UUID, quantity int) error
 }
 
 // PaymentRepository defines the interface for payment persistence operations
@@ -274,17 +286,29 @@ type PaymentFilter struct {
 }
 
 // Statistics and availability types
+// TicketTierAvailability is a tier's stock and sale state. BasePrice is the
+// tier price in effect now, including a scheduled change not yet applied to
+// the tier; Price is what one ticket is quoted at under dynamic pricing.
 type TicketTierAvailability struct {
-	TicketTierID uuid.UUID `json:"ticket_tier_id"`
-	Name         string    `json:"name"`
-	Price        float64   `json:"price"`
-	Currency     string    `json:"currency"`
-	Quota        *int      `json:"quota"`
-	Sold         int       `json:"sold"`
-	Reserved     int       `json:"reserved"`
-	Available    int       `json:"available"`
-	IsOnSale     bool      `json:"is_on_sale"`
-	SaleStatus   string    `json:"sale_status"`
+	TicketTierID     uuid.UUID                 `json:"ticket_tier_id" db:"ticket_tier_id"`
+	Name             string                    `json:"name" db:"name"`
+	Description      *string                   `json:"description,omitempty" db:"description"`
+	Price            float64                   `json:"price" db:"-"`
+	BasePrice        float64                   `json:"base_price" db:"base_price"`
+	Currency         string                    `json:"currency" db:"currency"`
+	Quota            *int                      `json:"quota" db:"quota"`
+	Sold             int                       `json:"sold" db:"sold"`
+	Reserved         int                       `json:"reserved" db:"reserved"`
+	Available        int                       `json:"available" db:"available"`
+	MinPurchase      int                       `json:"min_per_order" db:"min_per_order"`
+	MaxPurchase      int                       `json:"max_per_order" db:"max_per_order"`
+	SaleStart        *time.Time                `json:"sale_start,omitempty" db:"sale_start"`
+	SaleEnd          *time.Time                `json:"sale_end,omitempty" db:"sale_end"`
+	OpensAfterTierID *uuid.UUID                `json:"opens_after_tier_id,omitempty" db:"opens_after_tier_id"`
+	PricingStrategy  *entities.PricingStrategy `json:"pricing_strategy,omitempty" db:"pricing_strategy"`
+	EventDate        time.Time                 `json:"-" db:"event_date"`
+	IsOnSale         bool                      `json:"is_on_sale" db:"-"`
+	SaleStatus       entities.TierSaleState    `json:"sale_status" db:"-"`
 }
 
 type PaymentStats struct {
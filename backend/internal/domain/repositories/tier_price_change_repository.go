@@ -0,0 +1,35 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// TierPriceChangeRepository defines the interface for scheduled ticket tier price persistence
+type TierPriceChangeRepository interface {
+	// Create creates a new price change
+	Create(ctx context.Context, change *entities.TierPriceChange) error
+	
+	// GetByID retrieves a price change by ID
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.TierPriceChange, error)
+	
+	// Update updates a price change's status
+	Update(ctx context.Context, change *entities.TierPriceChange) error
+	
+	// List retrieves price changes with pagination and filtering, latest effective first
+	List(ctx context.Context, filter TierPriceChangeFilter) ([]*entities.TierPriceChange, *PaginationResult, error)
+	
+	// ApplyDue sets the price of every tier with a scheduled change now due, optionally
+	// only for one event's tiers, and returns how many tiers were repriced
+	ApplyDue(ctx context.Context, eventID *uuid.UUID) (int, error)
+}
+
+// TierPriceChangeFilter defines filtering options for price change queries
+type TierPriceChangeFilter struct {
+	BaseFilter
+	
+	TicketTierID uuid.UUID
+	Status       *entities.TierPriceChangeStatus
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// TourPassRepository defines the interface for tour pass persistence operations
+type TourPassRepository interface {
+	// Create creates a new tour pass
+	Create(ctx context.Context, pass *entities.TourPass) error
+	
+	// GetByID retrieves a tour pass by ID, with its events in date order
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.TourPass, error)
+	
+	// GetByIDForUpdate retrieves a tour pass and locks it until the transaction ends,
+	// serialising purchases that count against its quota
+	GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.TourPass, error)
+	
+	// Update updates an existing tour pass
+	Update(ctx context.Context, pass *entities.TourPass) error
+	
+	// ReplaceEvents replaces the events covered by a tour pass
+	ReplaceEvents(ctx context.Context, passID uuid.UUID, events []*entities.TourPassEvent) error
+	
+	// ListByTour retrieves the passes of a tour, with their events
+	ListByTour(ctx context.Context, tourID uuid.UUID, activeOnly bool) ([]*entities.TourPass, error)
+	
+	// CountReserved counts passes in paid or pending orders
+	CountReserved(ctx context.Context, passID uuid.UUID) (int, error)
+	
+	// CreateOrder links an order to the passes it bought
+	CreateOrder(ctx context.Context, passOrder *entities.TourPassOrder) error
+	
+	// GetOrder retrieves the tour pass purchase of an order
+	GetOrder(ctx context.Context, orderID uuid.UUID) (*entities.TourPassOrder, error)
+}
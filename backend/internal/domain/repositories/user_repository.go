@@ -58,6 +58,18 @@ type UserRepository interface {
 	// UpdatePassword updates the user's password hash
 	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
 	
+	// GetLocale retrieves the user's preferred message locale, or "" when none is set
+	GetLocale(ctx context.Context, userID uuid.UUID) (string, error)
+	
+	// UpdateLocale sets the user's preferred message locale
+	UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) error
+	
+	// GetNotificationChannel retrieves the channel the user prefers to be reached on, or "" when none is set
+	GetNotificationChannel(ctx context.Context, userID uuid.UUID) (entities.NotificationChannel, error)
+	
+	// UpdateNotificationChannel sets the channel the user prefers to be reached on
+	UpdateNotificationChannel(ctx context.Context, userID uuid.UUID, channel entities.NotificationChannel) error
+	
 	// IncrementFailedAttempts increments the failed login attempts counter
 	IncrementFailedAttempts(ctx context.Context, userID uuid.UUID) error
 	
@@ -0,0 +1,42 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// VenueRepository defines the interface for venue persistence operations
+type VenueRepository interface {
+	// Create creates a new venue
+	Create(ctx context.Context, venue *entities.Venue) error
+	
+	// GetByID retrieves a venue by ID, with its gates
+	GetByID(ctx context.Context, id uuid.UUID) (*entities.Venue, error)
+	
+	// GetByNameAndCity retrieves a venue by its case-insensitive name and city
+	GetByNameAndCity(ctx context.Context, name, city string) (*entities.Venue, error)
+	
+	// Update updates an existing venue
+	Update(ctx context.Context, venue *entities.Venue) error
+	
+	// ReplaceGates replaces the gates and entrances of a venue
+	ReplaceGates(ctx context.Context, venueID uuid.UUID, gates []*entities.VenueGate) error
+	
+	// List retrieves venues with pagination and filtering
+	List(ctx context.Context, filter VenueFilter) ([]*entities.Venue, *PaginationResult, error)
+	
+	// CountEvents counts the active events linked to a venue
+	CountEvents(ctx context.Context, venueID uuid.UUID) (int, error)
+}
+
+// VenueFilter defines filtering options for venue queries
+type VenueFilter struct {
+	BaseFilter
+	
+	// Filtering
+	City     string
+	Search   string // Search in name and address
+	IsActive *bool
+}
@@ -0,0 +1,32 @@
+package repositories
+
+import (
+	"context"
+	"time"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// WalletPassRepository defines the interface for Apple Wallet device registrations
+type WalletPassRepository interface {
+	// Register stores a device registration; it returns false if the device was already registered for the pass
+	Register(ctx context.Context, registration *entities.WalletPassRegistration) (bool, error)
+	
+	// Unregister removes a device registration
+	Unregister(ctx context.Context, deviceLibraryID, passTypeID, serialNumber string) error
+	
+	// GetBySerialNumber retrieves the devices registered for a pass
+	GetBySerialNumber(ctx context.Context, passTypeID, serialNumber string) ([]*entities.WalletPassRegistration, error)
+	
+	// GetUpdatedSerialNumbers retrieves the passes on a device whose tickets changed after since (all passes when since is nil)
+	GetUpdatedSerialNumbers(ctx context.Context, deviceLibraryID, passTypeID string, since *time.Time) (*WalletPassUpdates, error)
+	
+	// DeleteByPushToken removes every registration using a push token that is no longer valid
+	DeleteByPushToken(ctx context.Context, pushToken string) error
+}
+
+// WalletPassUpdates lists changed passes for a device
+type WalletPassUpdates struct {
+	SerialNumbers []string
+	LastUpdated   time.Time
+}
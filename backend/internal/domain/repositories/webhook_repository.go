@@ -0,0 +1,59 @@
+package repositories
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// WebhookRepository defines the interface for webhook subscription and delivery persistence operations
+type WebhookRepository interface {
+	// CreateSubscription creates a new webhook subscription
+	CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error
+	
+	// GetSubscription retrieves a webhook subscription by ID
+	GetSubscription(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error)
+	
+	// UpdateSubscription updates a webhook subscription's settings, secret and state
+	UpdateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error
+	
+	// DeleteSubscription deletes a webhook subscription and its delivery log
+	DeleteSubscription(ctx context.Context, id uuid.UUID) error
+	
+	// ListSubscriptions retrieves an organizer's webhook subscriptions, oldest first
+	ListSubscriptions(ctx context.Context, organizerID uuid.UUID) ([]*entities.WebhookSubscription, error)
+	
+	// ListActiveSubscriptions retrieves an organizer's active subscriptions to an event type
+	ListActiveSubscriptions(ctx context.Context, organizerID uuid.UUID, eventType string) ([]*entities.WebhookSubscription, error)
+	
+	// RecordSuccess resets a subscription's failure streak after a successful delivery
+	RecordSuccess(ctx context.Context, id uuid.UUID) error
+	
+	// RecordFailure extends a subscription's failure streak and disables it once
+	// the streak reaches maxFailures. It reports whether the subscription is now disabled.
+	RecordFailure(ctx context.Context, id uuid.UUID, maxFailures int) (bool, error)
+	
+	// CreateDelivery records a delivery unless the subscription already has one for
+	// the same event type and aggregate, and reports whether it was created
+	CreateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) (bool, error)
+	
+	// GetDelivery retrieves a webhook delivery by ID
+	GetDelivery(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error)
+	
+	// UpdateDelivery records the outcome of a delivery attempt or a redelivery
+	UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error
+	
+	// ListDeliveries retrieves a subscription's delivery log, newest first
+	ListDeliveries(ctx context.Context, filter WebhookDeliveryFilter) ([]*entities.WebhookDelivery, *PaginationResult, error)
+}
+
+// WebhookDeliveryFilter defines filtering options for webhook delivery queries
+type WebhookDeliveryFilter struct {
+	BaseFilter
+	
+	// Filtering
+	SubscriptionID uuid.UUID
+	Status         *entities.WebhookDeliveryStatus
+	EventType      string
+}
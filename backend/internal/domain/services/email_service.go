@@ -10,8 +10,8 @@ type EmailService interface {
 	// SendTicketEmail sends ticket information to the customer
 	SendTicketEmail(ctx context.Context, order *entities.Order, tickets []*entities.Ticket) error
 	
-	// SendTicketPDFEmail sends ticket PDFs to the customer
-	SendTicketPDFEmail(ctx context.Context, order *entities.Order, tickets []*entities.Ticket, orderLines []*entities.OrderLine, event *entities.Event) error
+	// SendTicketPDFEmail sends ticket PDFs to the customer, with "add to wallet" links when available
+	SendTicketPDFEmail(ctx context.Context, order *entities.Order, tickets []*entities.Ticket, orderLines []*entities.OrderLine, event *entities.Event, walletLinks []WalletLinks) error
 	
 	// SendOrderConfirmation sends order confirmation email
 	SendOrderConfirmation(ctx context.Context, order *entities.Order) error
@@ -21,4 +21,16 @@ type EmailService interface {
 	
 	// SendPasswordResetEmail sends password reset link
 	SendPasswordResetEmail(ctx context.Context, email, resetToken string) error
+	
+	// SendEventChangeEmail tells a ticket holder that their event was cancelled or postponed
+	SendEventChangeEmail(ctx context.Context, change *entities.EventChange, event *entities.Event, recipient *entities.EventChangeRecipient) error
+	
+	// SendEventCampaignEmail sends a ticket holder an event reminder or follow-up
+	SendEventCampaignEmail(ctx context.Context, campaign *entities.EventCampaign, event *entities.Event, delivery *entities.CampaignDelivery) error
+	
+	// SendCheckoutRecoveryEmail invites a customer back to an order they left unpaid
+	SendCheckoutRecoveryEmail(ctx context.Context, recovery *entities.CheckoutRecovery, event *entities.Event, orderLines []*entities.OrderLine) error
+	
+	// SendMarketingEmail sends a consenting audience member a marketing campaign
+	SendMarketingEmail(ctx context.Context, campaign *entities.MarketingCampaign, delivery *entities.MarketingDelivery) error
 }
@@ -0,0 +1,76 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// Message template keys. Each key has a built-in template per channel it is
+// sent on, which admins and organizers can override.
+const (
+	MessageTicket            = "ticket"
+	MessageTicketPDF         = "ticket_pdf"
+	MessageOrderConfirmation = "order_confirmation"
+	MessageWelcome           = "welcome"
+	MessagePasswordReset     = "password_reset"
+	MessageEventChange       = "event_change"
+	MessageOTP               = "otp"
+	MessageTicketLink        = "ticket_link"
+	MessageTicketDelivery    = "ticket_delivery"
+	MessageEventReminder     = "event_reminder"
+	MessageEventFollowUp     = "event_follow_up"
+	MessageCheckoutRecovery  = "checkout_recovery"
+	MessageMarketing         = "marketing_campaign"
+)
+
+// MessageRenderer renders transactional messages from templates, in the
+// recipient's locale and with the organizer's overrides and branding
+type MessageRenderer interface {
+	// Render renders a message. An empty Locale selects the locale of UserID,
+	// then the one carried by the context, then English.
+	Render(ctx context.Context, req RenderRequest) (*RenderedMessage, error)
+}
+
+// RenderRequest names the template to render and the data to fill it with
+type RenderRequest struct {
+	Channel     entities.NotificationChannel
+	Key         string
+	Locale      string
+	UserID      *uuid.UUID
+	OrganizerID *uuid.UUID
+	Data        interface{}
+}
+
+// RenderedMessage is a rendered message. Emails have a subject, an HTML body
+// and its plain-text alternative; SMS and WhatsApp messages have Text only.
+type RenderedMessage struct {
+	Subject string `json:"subject,omitempty"`
+	HTML    string `json:"html,omitempty"`
+	Text    string `json:"text"`
+	Locale  string `json:"locale"`
+	// Version is the override version used, or 0 for the built-in template
+	Version int `json:"version"`
+}
+
+// MessageContext carries who a message is for down to the services that
+// render it, where their signatures have no room for it (e.g. EmailService)
+type MessageContext struct {
+	Locale      string
+	UserID      *uuid.UUID
+	OrganizerID *uuid.UUID
+}
+
+type messageContextKey struct{}
+
+// WithMessageContext returns a context carrying the message context
+func WithMessageContext(ctx context.Context, messageContext MessageContext) context.Context {
+	return context.WithValue(ctx, messageContextKey{}, messageContext)
+}
+
+// MessageContextFrom returns the message context carried by ctx, if any
+func MessageContextFrom(ctx context.Context) MessageContext {
+	messageContext, _ := ctx.Value(messageContextKey{}).(MessageContext)
+	return messageContext
+}
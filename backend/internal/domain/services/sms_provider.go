@@ -0,0 +1,40 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// ErrSMSRejected wraps provider errors that retrying cannot fix, such as an
+// invalid number or a blocked sender ID
+var ErrSMSRejected = errors.New("sms rejected by provider")
+
+// SMSSendResult is the provider's answer to one message
+type SMSSendResult struct {
+	// ProviderMessageID identifies the message in the provider's delivery receipts
+	ProviderMessageID string
+}
+
+// SMSDeliveryReceipt is a provider's report on a message it accepted earlier
+type SMSDeliveryReceipt struct {
+	ProviderMessageID string
+	Status            entities.NotificationStatus
+	Error             string
+}
+
+// SMSProvider defines the interface for SMS gateways such as Termii, Africa's
+// Talking and Twilio
+type SMSProvider interface {
+	// Name identifies the provider in the message log and the receipt webhook URL
+	Name() string
+	
+	// Send hands one message to the provider. to is in E.164 format; senderID
+	// is the alphanumeric sender or number the message appears to come from.
+	Send(ctx context.Context, to, senderID, body string) (*SMSSendResult, error)
+	
+	// ParseDeliveryReceipts reads the receipts in a delivery report callback.
+	// Receipts that only report progress carry the sent status.
+	ParseDeliveryReceipts(contentType string, body []byte) ([]SMSDeliveryReceipt, error)
+}
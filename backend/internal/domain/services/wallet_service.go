@@ -0,0 +1,26 @@
+package services
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// WalletLinks are the "add to wallet" links for one ticket. A link is empty when
+// the provider is not configured.
+type WalletLinks struct {
+	TicketID  uuid.UUID
+	AppleURL  string
+	GoogleURL string
+}
+
+// WalletPassService defines the interface for Apple Wallet and Google Wallet ticket passes
+type WalletPassService interface {
+	// GetWalletLinks returns signed download links for the tickets, suitable for emails
+	GetWalletLinks(ctx context.Context, tickets []*entities.Ticket) []WalletLinks
+	
+	// NotifyTicketsChanged refreshes the wallet passes of tickets that were voided,
+	// transferred or otherwise changed. It returns immediately; delivery is best effort.
+	NotifyTicketsChanged(ctx context.Context, ticketIDs []uuid.UUID)
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"context"
+	"errors"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// ErrWhatsAppRejected wraps provider errors that retrying cannot fix, such as
+// a number without WhatsApp or an unapproved template
+var ErrWhatsAppRejected = errors.New("whatsapp message rejected by provider")
+
+// WhatsAppDocument is a document attached to a template message's header
+type WhatsAppDocument struct {
+	Filename string
+	MimeType string
+	Data     []byte
+}
+
+// WhatsAppTemplateMessage is a message built from a template approved in the
+// WhatsApp Business account. Business-initiated messages must use templates.
+type WhatsAppTemplateMessage struct {
+	Template string
+	Language string
+	// Document, when set, fills the template's document header
+	Document *WhatsAppDocument
+	// BodyParameters fill the template body's {{1}}, {{2}}, ... in order
+	BodyParameters []string
+}
+
+// WhatsAppSendResult is the provider's answer to one message
+type WhatsAppSendResult struct {
+	// ProviderMessageID identifies the message in the provider's status webhooks
+	ProviderMessageID string
+}
+
+// WhatsAppStatusUpdate is a provider's report on a message it accepted earlier
+type WhatsAppStatusUpdate struct {
+	ProviderMessageID string
+	Status            entities.NotificationStatus
+	Error             string
+}
+
+// WhatsAppProvider defines the interface for WhatsApp Business messaging
+type WhatsAppProvider interface {
+	// Name identifies the provider in the message log
+	Name() string
+	
+	// SendTemplate uploads the message's document, if any, and sends the
+	// template message. to is in E.164 format.
+	SendTemplate(ctx context.Context, to string, message WhatsAppTemplateMessage) (*WhatsAppSendResult, error)
+	
+	// VerifyWebhook answers the provider's webhook verification handshake,
+	// returning the challenge to echo when the verify token matches
+	VerifyWebhook(mode, token, challenge string) (string, bool)
+	
+	// VerifySignature checks a status webhook's signature header
+	VerifySignature(body []byte, signature string) bool
+	
+	// ParseStatusUpdates reads the message status updates in a status webhook.
+	// Updates that only report progress carry the sent status.
+	ParseStatusUpdates(body []byte) ([]WhatsAppStatusUpdate, error)
+}
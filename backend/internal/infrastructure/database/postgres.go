@@ -29,6 +29,28 @@ type DatabaseManager struct {
 	inventoryHoldRepo  repositories.InventoryHoldRepository
 	otpTokenRepo       repositories.OTPTokenRepository
 	scannerUserRepo    repositories.ScannerUserRepository
+	fxRateRepo         repositories.FXRateRepository
+	boxOfficeRepo      repositories.BoxOfficeRepository
+	compRepo           repositories.CompRepository
+	ticketImportRepo   repositories.TicketImportRepository
+	walletPassRepo     repositories.WalletPassRepository
+	eventSearchRepo    repositories.EventSearchRepository
+	venueRepo          repositories.VenueRepository
+	tourPassRepo       repositories.TourPassRepository
+	eventSeriesRepo    repositories.EventSeriesRepository
+	eventTemplateRepo  repositories.EventTemplateRepository
+	eventChangeRepo    repositories.EventChangeRepository
+	tierPriceRepo      repositories.TierPriceChangeRepository
+	categoryRepo       repositories.CategoryRepository
+	outboxRepo         repositories.OutboxRepository
+	webhookRepo        repositories.WebhookRepository
+	notificationRepo   repositories.NotificationRepository
+	messageTemplateRepo repositories.MessageTemplateRepository
+	eventCampaignRepo  repositories.EventCampaignRepository
+	checkoutRecoveryRepo repositories.CheckoutRecoveryRepository
+	marketingConsentRepo repositories.MarketingConsentRepository
+	marketingCampaignRepo repositories.MarketingCampaignRepository
+	analyticsRepo      repositories.AnalyticsRepository
 }
 
 func NewDatabaseManager(databaseURL string) (*DatabaseManager, error) {
@@ -62,6 +84,28 @@ func NewDatabaseManager(databaseURL string) (*DatabaseManager, error) {
 		inventoryHoldRepo: postgres.NewInventoryHoldRepository(db),
 		otpTokenRepo:      postgres.NewOTPTokenRepository(db),
 		scannerUserRepo:   postgres.NewScannerUserRepository(db),
+		fxRateRepo:        postgres.NewFXRateRepository(db),
+		boxOfficeRepo:     postgres.NewBoxOfficeRepository(db),
+		compRepo:          postgres.NewCompRepository(db),
+		ticketImportRepo:  postgres.NewTicketImportRepository(db),
+		walletPassRepo:    postgres.NewWalletPassRepository(db),
+		eventSearchRepo:   postgres.NewEventSearchRepository(db),
+		venueRepo:         postgres.NewVenueRepository(db),
+		tourPassRepo:      postgres.NewTourPassRepository(db),
+		eventSeriesRepo:   postgres.NewEventSeriesRepository(db),
+		eventTemplateRepo: postgres.NewEventTemplateRepository(db),
+		eventChangeRepo:   postgres.NewEventChangeRepository(db),
+		tierPriceRepo:     postgres.NewTierPriceChangeRepository(db),
+		categoryRepo:      postgres.NewCategoryRepository(db),
+		outboxRepo:        postgres.NewOutboxRepository(db),
+		webhookRepo:       postgres.NewWebhookRepository(db),
+		notificationRepo:  postgres.NewNotificationRepository(db),
+		messageTemplateRepo: postgres.NewMessageTemplateRepository(db),
+		eventCampaignRepo: postgres.NewEventCampaignRepository(db),
+		checkoutRecoveryRepo: postgres.NewCheckoutRecoveryRepository(db),
+		marketingConsentRepo: postgres.NewMarketingConsentRepository(db),
+		marketingCampaignRepo: postgres.NewMarketingCampaignRepository(db),
+		analyticsRepo:     postgres.NewAnalyticsRepository(db),
 	}, nil
 }
 
@@ -122,6 +166,94 @@ func (dm *DatabaseManager) OTPTokens() repositories.OTPTokenRepository {
 	return dm.otpTokenRepo
 }
 
+func (dm *DatabaseManager) FXRates() repositories.FXRateRepository {
+	return dm.fxRateRepo
+}
+
+func (dm *DatabaseManager) BoxOffice() repositories.BoxOfficeRepository {
+	return dm.boxOfficeRepo
+}
+
+func (dm *DatabaseManager) Comps() repositories.CompRepository {
+	return dm.compRepo
+}
+
+func (dm *DatabaseManager) TicketImports() repositories.TicketImportRepository {
+	return dm.ticketImportRepo
+}
+
+func (dm *DatabaseManager) WalletPasses() repositories.WalletPassRepository {
+	return dm.walletPassRepo
+}
+
+func (dm *DatabaseManager) EventSearch() repositories.EventSearchRepository {
+	return dm.eventSearchRepo
+}
+
+func (dm *DatabaseManager) Venues() repositories.VenueRepository {
+	return dm.venueRepo
+}
+
+func (dm *DatabaseManager) TourPasses() repositories.TourPassRepository {
+	return dm.tourPassRepo
+}
+
+func (dm *DatabaseManager) EventSeries() repositories.EventSeriesRepository {
+	return dm.eventSeriesRepo
+}
+
+func (dm *DatabaseManager) EventTemplates() repositories.EventTemplateRepository {
+	return dm.eventTemplateRepo
+}
+
+func (dm *DatabaseManager) EventChanges() repositories.EventChangeRepository {
+	return dm.eventChangeRepo
+}
+
+func (dm *DatabaseManager) TierPriceChanges() repositories.TierPriceChangeRepository {
+	return dm.tierPriceRepo
+}
+
+func (dm *DatabaseManager) Categories() repositories.CategoryRepository {
+	return dm.categoryRepo
+}
+
+func (dm *DatabaseManager) Outbox() repositories.OutboxRepository {
+	return dm.outboxRepo
+}
+
+func (dm *DatabaseManager) Webhooks() repositories.WebhookRepository {
+	return dm.webhookRepo
+}
+
+func (dm *DatabaseManager) Notifications() repositories.NotificationRepository {
+	return dm.notificationRepo
+}
+
+func (dm *DatabaseManager) MessageTemplates() repositories.MessageTemplateRepository {
+	return dm.messageTemplateRepo
+}
+
+func (dm *DatabaseManager) EventCampaigns() repositories.EventCampaignRepository {
+	return dm.eventCampaignRepo
+}
+
+func (dm *DatabaseManager) CheckoutRecoveries() repositories.CheckoutRecoveryRepository {
+	return dm.checkoutRecoveryRepo
+}
+
+func (dm *DatabaseManager) MarketingConsents() repositories.MarketingConsentRepository {
+	return dm.marketingConsentRepo
+}
+
+func (dm *DatabaseManager) MarketingCampaigns() repositories.MarketingCampaignRepository {
+	return dm.marketingCampaignRepo
+}
+
+func (dm *DatabaseManager) Analytics() repositories.AnalyticsRepository {
+	return dm.analyticsRepo
+}
+
 // Transaction support
 func (dm *DatabaseManager) BeginTx(ctx context.Context) (*sqlx.Tx, error) {
 	return dm.db.BeginTxx(ctx, nil)
@@ -0,0 +1,303 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// analyticsViews are the sales rollups from migration 045, refreshed together
+var analyticsViews = []string{"analytics_daily_sales", "analytics_daily_tier_sales"}
+
+// analyticsBuyerKey identifies a buyer the way marketing audiences do: by
+// account, else by the order's email address
+const analyticsBuyerKey = `COALESCE('user:' || o.user_id::text,
+	'email:' || lower(COALESCE(NULLIF(o.customer_email, ''), NULLIF(o.email, ''))))`
+
+type analyticsRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	}
+}
+
+func NewAnalyticsRepository(db *sqlx.DB) repositories.AnalyticsRepository {
+	return &analyticsRepository{db: db}
+}
+
+// analyticsScope returns the conditions limiting rows of alias, which carry
+// event_id and currency and are joined to their event as e, to the filter's
+// organizer, event and currency
+func analyticsScope(filter repositories.AnalyticsFilter, alias string, args []interface{}, argIndex int) ([]string, []interface{}, int) {
+	conditions := []string{"1 = 1"}
+	
+	if filter.OrganizerID != nil {
+		conditions = append(conditions, fmt.Sprintf("e.organizer_id = $%d", argIndex))
+		args = append(args, *filter.OrganizerID)
+		argIndex++
+	}
+	
+	if filter.EventID != nil {
+		conditions = append(conditions, fmt.Sprintf("%s.event_id = $%d", alias, argIndex))
+		args = append(args, *filter.EventID)
+		argIndex++
+	}
+	
+	if filter.Currency != "" {
+		conditions = append(conditions, fmt.Sprintf("%s.currency = $%d", alias, argIndex))
+		args = append(args, filter.Currency)
+		argIndex++
+	}
+	
+	return conditions, args, argIndex
+}
+
+// rollupScope adds the filter's days to its scope, for rows of the daily rollups aliased s
+func rollupScope(filter repositories.AnalyticsFilter, args []interface{}, argIndex int) ([]string, []interface{}, int) {
+	conditions, args, argIndex := analyticsScope(filter, "s", args, argIndex)
+	conditions = append(conditions, fmt.Sprintf("s.day >= $%d::date AND s.day < $%d::date", argIndex, argIndex+1))
+	args = append(args, filter.From.UTC().Format("2006-01-02"), filter.To.UTC().Format("2006-01-02"))
+	argIndex += 2
+	return conditions, args, argIndex
+}
+
+func (r *analyticsRepository) GetSalesTotals(ctx context.Context, filter repositories.AnalyticsFilter) ([]*repositories.SalesFigures, error) {
+	conditions, args, _ := rollupScope(filter, []interface{}{}, 1)
+	
+	query := fmt.Sprintf(`
+		SELECT s.currency,
+			   COALESCE(SUM(s.orders_created), 0) AS orders_created,
+			   COALESCE(SUM(s.paid_orders), 0) AS paid_orders,
+			   COALESCE(SUM(s.tickets_sold), 0) AS tickets_sold,
+			   COALESCE(SUM(s.revenue), 0) AS revenue
+		FROM analytics_daily_sales s
+		JOIN events e ON e.id = s.event_id
+		WHERE %s
+		GROUP BY s.currency
+		ORDER BY s.currency ASC`, strings.Join(conditions, " AND "))
+	
+	totals := []*repositories.SalesFigures{}
+	if err := r.db.SelectContext(ctx, &totals, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get sales totals: %w", err)
+	}
+	
+	return totals, nil
+}
+
+func (r *analyticsRepository) GetSalesSeries(ctx context.Context, filter repositories.AnalyticsFilter, bucket repositories.AnalyticsBucket) ([]*repositories.SalesPoint, error) {
+	conditions, args, _ := rollupScope(filter, []interface{}{string(bucket)}, 2)
+	
+	query := fmt.Sprintf(`
+		SELECT date_trunc($1, s.day::timestamp) AS period_start,
+			   s.currency,
+			   COALESCE(SUM(s.orders_created), 0) AS orders_created,
+			   COALESCE(SUM(s.paid_orders), 0) AS paid_orders,
+			   COALESCE(SUM(s.tickets_sold), 0) AS tickets_sold,
+			   COALESCE(SUM(s.revenue), 0) AS revenue
+		FROM analytics_daily_sales s
+		JOIN events e ON e.id = s.event_id
+		WHERE %s
+		GROUP BY 1, s.currency
+		ORDER BY 1 ASC, s.currency ASC`, strings.Join(conditions, " AND "))
+	
+	points := []*repositories.SalesPoint{}
+	if err := r.db.SelectContext(ctx, &points, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get sales series: %w", err)
+	}
+	
+	return points, nil
+}
+
+func (r *analyticsRepository) GetSalesBreakdown(ctx context.Context, filter repositories.AnalyticsFilter, dimension repositories.AnalyticsDimension, limit int) ([]*repositories.SalesBreakdown, error) {
+	source, join := "analytics_daily_sales", ""
+	var key, label string
+	switch dimension {
+	case repositories.AnalyticsDimensionEvent:
+		key, label = "s.event_id::text", "e.name"
+	case repositories.AnalyticsDimensionPaymentMethod:
+		key, label = "s.payment_method", "s.payment_method"
+	case repositories.AnalyticsDimensionCity:
+		key, label = "e.venue_city", "e.venue_city"
+	case repositories.AnalyticsDimensionTier:
+		source, join = "analytics_daily_tier_sales", "JOIN ticket_tiers tt ON tt.id = s.ticket_tier_id"
+		key, label = "s.ticket_tier_id::text", "e.name || ' - ' || tt.name"
+	default:
+		return nil, fmt.Errorf("unsupported analytics dimension: %s", dimension)
+	}
+	
+	conditions, args, argIndex := rollupScope(filter, []interface{}{}, 1)
+	args = append(args, limit)
+	
+	// Revenue is only comparable within a currency, so values are ranked per currency
+	query := fmt.Sprintf(`
+		SELECT key, label, currency, paid_orders, tickets_sold, revenue
+		FROM (
+			SELECT %s AS key,
+				   %s AS label,
+				   s.currency,
+				   SUM(s.paid_orders) AS paid_orders,
+				   SUM(s.tickets_sold) AS tickets_sold,
+				   SUM(s.revenue) AS revenue,
+				   ROW_NUMBER() OVER (
+					   PARTITION BY s.currency ORDER BY SUM(s.revenue) DESC, SUM(s.tickets_sold) DESC, %s
+				   ) AS rank
+			FROM %s s
+			JOIN events e ON e.id = s.event_id
+			%s
+			WHERE %s
+			GROUP BY 1, 2, s.currency
+			HAVING SUM(s.paid_orders) > 0
+		) ranked
+		WHERE rank <= $%d
+		ORDER BY currency ASC, rank ASC`, key, label, key, source, join, strings.Join(conditions, " AND "), argIndex)
+	
+	breakdown := []*repositories.SalesBreakdown{}
+	if err := r.db.SelectContext(ctx, &breakdown, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get sales breakdown by %s: %w", dimension, err)
+	}
+	
+	return breakdown, nil
+}
+
+// buyerPurchases returns a CTE of the paid orders in the filter's scope up to
+// the end of its period, with who bought and when; first purchases are found
+// from every earlier order, not just those in the period. Comps and imported
+// orders were not bought here, so their holders are not counted as buyers.
+func buyerPurchases(filter repositories.AnalyticsFilter, args []interface{}, argIndex int) (string, []interface{}, int) {
+	conditions, args, argIndex := analyticsScope(filter, "o", args, argIndex)
+	conditions = append(conditions, fmt.Sprintf("COALESCE(o.paid_at, o.created_at) < $%d", argIndex))
+	args = append(args, filter.To)
+	argIndex++
+	
+	cte := fmt.Sprintf(`
+		WITH purchases AS (
+			SELECT %s AS buyer, COALESCE(o.paid_at, o.created_at) AS purchased_at
+			FROM orders o
+			JOIN events e ON e.id = o.event_id
+			WHERE o.is_active = true
+			  AND o.is_comp = false
+			  AND o.is_imported = false
+			  AND o.status IN ('paid', 'confirmed')
+			  AND %s
+		),
+		first_purchases AS (
+			SELECT buyer, MIN(purchased_at) AS first_purchase_at
+			FROM purchases
+			WHERE buyer IS NOT NULL
+			GROUP BY buyer
+		)`, analyticsBuyerKey, strings.Join(conditions, " AND "))
+	
+	return cte, args, argIndex
+}
+
+func (r *analyticsRepository) GetBuyerTotals(ctx context.Context, filter repositories.AnalyticsFilter) (*repositories.BuyerTotals, error) {
+	cte, args, argIndex := buyerPurchases(filter, []interface{}{}, 1)
+	args = append(args, filter.From)
+	
+	query := cte + fmt.Sprintf(`
+		SELECT COUNT(DISTINCT p.buyer) AS buyers,
+			   COUNT(DISTINCT p.buyer) FILTER (WHERE f.first_purchase_at >= $%d) AS new_buyers
+		FROM purchases p
+		JOIN first_purchases f ON f.buyer = p.buyer
+		WHERE p.purchased_at >= $%d`, argIndex, argIndex)
+	
+	var totals repositories.BuyerTotals
+	if err := r.db.GetContext(ctx, &totals, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get buyer totals: %w", err)
+	}
+	
+	return &totals, nil
+}
+
+func (r *analyticsRepository) GetBuyerSeries(ctx context.Context, filter repositories.AnalyticsFilter, bucket repositories.AnalyticsBucket) ([]*repositories.BuyerPoint, error) {
+	cte, args, argIndex := buyerPurchases(filter, []interface{}{string(bucket)}, 2)
+	args = append(args, filter.From)
+	
+	// A buyer is new in the bucket their first purchase falls in
+	query := cte + fmt.Sprintf(`,
+		bucketed AS (
+			SELECT p.buyer, date_trunc($1, p.purchased_at AT TIME ZONE 'UTC') AS period_start
+			FROM purchases p
+			WHERE p.purchased_at >= $%d
+		)
+		SELECT b.period_start,
+			   COUNT(DISTINCT b.buyer) AS buyers,
+			   COUNT(DISTINCT b.buyer) FILTER (
+				   WHERE f.first_purchase_at AT TIME ZONE 'UTC' >= b.period_start
+			   ) AS new_buyers
+		FROM bucketed b
+		JOIN first_purchases f ON f.buyer = b.buyer
+		GROUP BY b.period_start
+		ORDER BY b.period_start ASC`, argIndex)
+	
+	points := []*repositories.BuyerPoint{}
+	if err := r.db.SelectContext(ctx, &points, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get buyer series: %w", err)
+	}
+	
+	return points, nil
+}
+
+func (r *analyticsRepository) GetEventCounts(ctx context.Context, organizerID *uuid.UUID) (*repositories.EventCounts, error) {
+	query := `
+		SELECT COUNT(*) AS total_events,
+			   COUNT(*) FILTER (WHERE status::text IN ('published', 'on_sale', 'sold_out')) AS published_events,
+			   COUNT(*) FILTER (
+				   WHERE status::text IN ('published', 'on_sale', 'sold_out') AND event_date > NOW()
+			   ) AS upcoming_events
+		FROM events
+		WHERE is_active = true
+		  AND ($1::uuid IS NULL OR organizer_id = $1::uuid)`
+	
+	var counts repositories.EventCounts
+	if err := r.db.GetContext(ctx, &counts, query, organizerID); err != nil {
+		return nil, fmt.Errorf("failed to get event counts: %w", err)
+	}
+	
+	return &counts, nil
+}
+
+func (r *analyticsRepository) Refresh(ctx context.Context) (time.Time, error) {
+	// Taken before refreshing, so the views hold at least every order committed by then
+	var startedAt time.Time
+	if err := r.db.GetContext(ctx, &startedAt, `SELECT NOW()`); err != nil {
+		return time.Time{}, fmt.Errorf("failed to start analytics refresh: %w", err)
+	}
+	
+	// CONCURRENTLY keeps the views readable while they are rebuilt; a second
+	// refresh started meanwhile waits for the first rather than failing
+	for _, view := range analyticsViews {
+		if _, err := r.db.ExecContext(ctx, "REFRESH MATERIALIZED VIEW CONCURRENTLY "+view); err != nil {
+			return time.Time{}, fmt.Errorf("failed to refresh %s: %w", view, err)
+		}
+	}
+	
+	query := `
+		INSERT INTO analytics_refreshes (name, refreshed_at) VALUES ('sales', $1)
+		ON CONFLICT (name) DO UPDATE SET refreshed_at = GREATEST(analytics_refreshes.refreshed_at, EXCLUDED.refreshed_at)`
+	if _, err := r.db.ExecContext(ctx, query, startedAt); err != nil {
+		return time.Time{}, fmt.Errorf("failed to record analytics refresh: %w", err)
+	}
+	
+	return startedAt, nil
+}
+
+func (r *analyticsRepository) GetRefreshedAt(ctx context.Context) (*time.Time, error) {
+	var refreshedAt time.Time
+	err := r.db.GetContext(ctx, &refreshedAt, `SELECT refreshed_at FROM analytics_refreshes WHERE name = 'sales'`)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get analytics refresh time: %w", err)
+	}
+	
+	return &refreshedAt, nil
+}
@@ -0,0 +1,508 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	_ "github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// analyticsTestSchema holds just the columns the sales rollups and buyer
+// queries read; migrations 045 and 046 are then run over it unchanged
+const analyticsTestSchema = `
+	CREATE TABLE events (
+		id UUID PRIMARY KEY,
+		organizer_id UUID NOT NULL,
+		name TEXT NOT NULL,
+		venue_city TEXT NOT NULL,
+		status TEXT NOT NULL DEFAULT 'published',
+		event_date TIMESTAMP WITH TIME ZONE NOT NULL,
+		is_active BOOLEAN NOT NULL DEFAULT true
+	);
+	CREATE TABLE ticket_tiers (
+		id UUID PRIMARY KEY,
+		event_id UUID NOT NULL REFERENCES events(id),
+		name TEXT NOT NULL
+	);
+	CREATE TABLE orders (
+		id UUID PRIMARY KEY,
+		event_id UUID NOT NULL REFERENCES events(id),
+		user_id UUID,
+		email TEXT,
+		customer_email TEXT,
+		status TEXT NOT NULL,
+		payment_method TEXT,
+		currency TEXT NOT NULL,
+		total_amount DECIMAL(12, 2) NOT NULL,
+		is_active BOOLEAN NOT NULL DEFAULT true,
+		is_comp BOOLEAN NOT NULL DEFAULT false,
+		created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+		paid_at TIMESTAMP WITH TIME ZONE
+	);
+	CREATE TABLE order_lines (
+		id UUID PRIMARY KEY,
+		order_id UUID NOT NULL REFERENCES orders(id),
+		ticket_tier_id UUID NOT NULL REFERENCES ticket_tiers(id),
+		quantity INTEGER NOT NULL,
+		subtotal DECIMAL(12, 2) NOT NULL,
+		fees DECIMAL(12, 2) NOT NULL DEFAULT 0,
+		taxes DECIMAL(12, 2) NOT NULL DEFAULT 0,
+		discount_amount DECIMAL(12, 2) NOT NULL DEFAULT 0
+	);
+	CREATE TABLE ticket_import_rows (
+		order_id UUID
+	);`
+
+// openAnalyticsTestDB connects to TEST_DATABASE_URL and builds the analytics
+// schema in a throwaway schema dropped when the test ends. The pool is one
+// connection so the search path holds for every query.
+func openAnalyticsTestDB(t *testing.T) *sqlx.DB {
+	t.Helper()
+
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		t.Skip("TEST_DATABASE_URL not set")
+	}
+
+	db, err := sqlx.Connect("postgres", databaseURL)
+	if err != nil {
+		t.Fatalf("failed to connect to test database: %v", err)
+	}
+	db.SetMaxOpenConns(1)
+
+	schema := "analytics_test_" + uuid.New().String()[:8]
+	t.Cleanup(func() {
+		db.Exec("SET search_path TO public")
+		db.Exec("DROP SCHEMA IF EXISTS " + schema + " CASCADE")
+		db.Close()
+	})
+
+	statements := []string{"CREATE SCHEMA " + schema, "SET search_path TO " + schema, analyticsTestSchema}
+	for _, migration := range []string{"045_sales_analytics.sql", "046_imported_orders.sql"} {
+		sql, err := os.ReadFile(filepath.Join("..", "..", "..", "..", "migrations", migration))
+		if err != nil {
+			t.Fatalf("failed to read migration %s: %v", migration, err)
+		}
+		statements = append(statements, string(sql))
+	}
+	for _, statement := range statements {
+		if _, err := db.Exec(statement); err != nil {
+			t.Fatalf("failed to set up analytics schema: %v", err)
+		}
+	}
+
+	return db
+}
+
+type analyticsSeed struct {
+	db *sqlx.DB
+
+	organizerA, organizerB uuid.UUID
+	lagos, abuja           uuid.UUID
+	regular, vip, general  uuid.UUID
+	ada, chi, dan          uuid.UUID
+}
+
+type seedLine struct {
+	tier     uuid.UUID
+	quantity int
+	subtotal float64
+}
+
+type seedOrder struct {
+	event         uuid.UUID
+	userID        *uuid.UUID
+	email         string
+	customerEmail string
+	status        string
+	paymentMethod string
+	currency      string
+	createdAt     time.Time
+	paidAt        *time.Time
+	comp          bool
+	imported      bool
+	lines         []seedLine
+}
+
+func day(month time.Month, d, hour, minute int) time.Time {
+	return time.Date(2026, month, d, hour, minute, 0, 0, time.UTC)
+}
+
+func at(t time.Time) *time.Time { return &t }
+
+// newAnalyticsSeed creates two events from different organizers and these
+// orders, all placed in March 2026 unless noted:
+//
+//   - Ada: Regular on 20 Feb, before the period, then 2 VIP on 2 Mar (paystack)
+//   - Bola, by email only: Regular and VIP placed 3 Mar 23:50 and paid after
+//     midnight (momo), then 2 General in Abuja on 10 Mar under a lowercase
+//     address (paystack)
+//   - Chi: a pending Regular on 4 Mar and a comp of 4 VIP on 5 Mar
+//   - Ada again: 10 imported VIP on 5 Mar
+//   - Dan: 1 General paid in USD on 11 Mar (card)
+//
+// None of it is in the rollups until they are refreshed.
+func newAnalyticsSeed(t *testing.T) *analyticsSeed {
+	t.Helper()
+
+	s := &analyticsSeed{
+		db:         openAnalyticsTestDB(t),
+		organizerA: uuid.New(), organizerB: uuid.New(),
+		lagos: uuid.New(), abuja: uuid.New(),
+		regular: uuid.New(), vip: uuid.New(), general: uuid.New(),
+		ada: uuid.New(), chi: uuid.New(), dan: uuid.New(),
+	}
+
+	s.exec(t, `INSERT INTO events (id, organizer_id, name, venue_city, event_date) VALUES ($1, $2, 'Lagos Live', 'Lagos', $3), ($4, $5, 'Abuja Nights', 'Abuja', $3)`,
+		s.lagos, s.organizerA, day(time.June, 1, 20, 0), s.abuja, s.organizerB)
+	s.exec(t, `INSERT INTO ticket_tiers (id, event_id, name) VALUES ($1, $2, 'Regular'), ($3, $2, 'VIP'), ($4, $5, 'General')`,
+		s.regular, s.lagos, s.vip, s.general, s.abuja)
+
+	orders := []seedOrder{
+		{event: s.lagos, userID: &s.ada, status: "paid", paymentMethod: "card", currency: "NGN",
+			createdAt: day(time.February, 20, 12, 0), paidAt: at(day(time.February, 20, 12, 0)),
+			lines: []seedLine{{s.regular, 1, 10000}}},
+		{event: s.lagos, userID: &s.ada, status: "paid", paymentMethod: "paystack", currency: "NGN",
+			createdAt: day(time.March, 2, 10, 0), paidAt: at(day(time.March, 2, 10, 5)),
+			lines: []seedLine{{s.vip, 2, 100000}}},
+		{event: s.lagos, customerEmail: "Bola@Example.com", status: "confirmed", paymentMethod: "momo", currency: "NGN",
+			createdAt: day(time.March, 3, 23, 50), paidAt: at(day(time.March, 4, 0, 10)),
+			lines: []seedLine{{s.regular, 1, 10000}, {s.vip, 1, 50000}}},
+		{event: s.lagos, userID: &s.chi, status: "pending", currency: "NGN",
+			createdAt: day(time.March, 4, 9, 0),
+			lines:     []seedLine{{s.regular, 1, 10000}}},
+		{event: s.lagos, userID: &s.chi, status: "paid", currency: "NGN", comp: true,
+			createdAt: day(time.March, 5, 9, 0), paidAt: at(day(time.March, 5, 9, 0)),
+			lines: []seedLine{{s.vip, 4, 0}}},
+		{event: s.lagos, userID: &s.ada, status: "paid", currency: "NGN", imported: true,
+			createdAt: day(time.March, 5, 11, 0), paidAt: at(day(time.March, 5, 11, 0)),
+			lines: []seedLine{{s.vip, 10, 500000}}},
+		{event: s.abuja, email: "bola@example.com", status: "paid", paymentMethod: "paystack", currency: "NGN",
+			createdAt: day(time.March, 10, 15, 0), paidAt: at(day(time.March, 10, 15, 2)),
+			lines: []seedLine{{s.general, 2, 40000}}},
+		{event: s.abuja, userID: &s.dan, status: "paid", paymentMethod: "card", currency: "USD",
+			createdAt: day(time.March, 11, 8, 0), paidAt: at(day(time.March, 11, 8, 1)),
+			lines: []seedLine{{s.general, 1, 50}}},
+	}
+	for _, order := range orders {
+		s.insert(t, order)
+	}
+
+	return s
+}
+
+func (s *analyticsSeed) exec(t *testing.T, query string, args ...interface{}) {
+	t.Helper()
+	if _, err := s.db.Exec(query, args...); err != nil {
+		t.Fatalf("failed to seed analytics data: %v", err)
+	}
+}
+
+func (s *analyticsSeed) insert(t *testing.T, order seedOrder) uuid.UUID {
+	t.Helper()
+
+	id := uuid.New()
+	total := 0.0
+	for _, line := range order.lines {
+		total += line.subtotal
+	}
+	s.exec(t, `
+		INSERT INTO orders (id, event_id, user_id, email, customer_email, status, payment_method, currency,
+			total_amount, is_comp, is_imported, created_at, paid_at)
+		VALUES ($1, $2, $3, NULLIF($4, ''), NULLIF($5, ''), $6, NULLIF($7, ''), $8, $9, $10, $11, $12, $13)`,
+		id, order.event, order.userID, order.email, order.customerEmail, order.status, order.paymentMethod,
+		order.currency, total, order.comp, order.imported, order.createdAt, order.paidAt)
+	for _, line := range order.lines {
+		s.exec(t, `INSERT INTO order_lines (id, order_id, ticket_tier_id, quantity, subtotal) VALUES ($1, $2, $3, $4, $5)`,
+			uuid.New(), id, line.tier, line.quantity, line.subtotal)
+	}
+	return id
+}
+
+func (s *analyticsSeed) refresh(t *testing.T, repo repositories.AnalyticsRepository) {
+	t.Helper()
+	if _, err := repo.Refresh(context.Background()); err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+}
+
+func march() repositories.AnalyticsFilter {
+	return repositories.AnalyticsFilter{From: day(time.March, 1, 0, 0), To: day(time.April, 1, 0, 0)}
+}
+
+func formatFigures(rows []*repositories.SalesFigures) string {
+	parts := make([]string, len(rows))
+	for i, row := range rows {
+		parts[i] = fmt.Sprintf("%s created=%d paid=%d tickets=%d revenue=%.2f",
+			row.Currency, row.OrdersCreated, row.PaidOrders, row.TicketsSold, row.Revenue)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatPoints(rows []*repositories.SalesPoint) string {
+	parts := make([]string, len(rows))
+	for i, row := range rows {
+		parts[i] = row.PeriodStart.UTC().Format("2006-01-02") + " " + formatFigures([]*repositories.SalesFigures{&row.SalesFigures})
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatBreakdown(rows []*repositories.SalesBreakdown) string {
+	parts := make([]string, len(rows))
+	for i, row := range rows {
+		parts[i] = fmt.Sprintf("%s %s paid=%d tickets=%d revenue=%.2f",
+			row.Currency, row.Label, row.PaidOrders, row.TicketsSold, row.Revenue)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func formatBuyers(rows []*repositories.BuyerPoint) string {
+	parts := make([]string, len(rows))
+	for i, row := range rows {
+		parts[i] = fmt.Sprintf("%s buyers=%d new=%d", row.PeriodStart.UTC().Format("2006-01-02"), row.Buyers, row.NewBuyers)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func TestAnalyticsRefreshRollsUpOrders(t *testing.T) {
+	s := newAnalyticsSeed(t)
+	repo := NewAnalyticsRepository(s.db)
+	ctx := context.Background()
+
+	totals, err := repo.GetSalesTotals(ctx, march())
+	if err != nil {
+		t.Fatalf("GetSalesTotals() error = %v", err)
+	}
+	if len(totals) != 0 {
+		t.Fatalf("sales before a refresh = %s, want none", formatFigures(totals))
+	}
+
+	startedAt, err := repo.Refresh(ctx)
+	if err != nil {
+		t.Fatalf("Refresh() error = %v", err)
+	}
+	refreshedAt, err := repo.GetRefreshedAt(ctx)
+	if err != nil {
+		t.Fatalf("GetRefreshedAt() error = %v", err)
+	}
+	if refreshedAt == nil || !refreshedAt.Equal(startedAt) {
+		t.Errorf("GetRefreshedAt() = %v, want %v", refreshedAt, startedAt)
+	}
+
+	// Comps and imported orders are neither checkouts nor revenue
+	totals, err = repo.GetSalesTotals(ctx, march())
+	if err != nil {
+		t.Fatalf("GetSalesTotals() error = %v", err)
+	}
+	want := "NGN created=4 paid=3 tickets=6 revenue=200000.00; USD created=1 paid=1 tickets=1 revenue=50.00"
+	if got := formatFigures(totals); got != want {
+		t.Errorf("GetSalesTotals() = %s, want %s", got, want)
+	}
+
+	// Orders placed since the last refresh only count after the next one
+	s.insert(t, seedOrder{event: s.lagos, userID: &s.dan, status: "paid", paymentMethod: "card", currency: "NGN",
+		createdAt: day(time.March, 20, 12, 0), paidAt: at(day(time.March, 20, 12, 0)),
+		lines: []seedLine{{s.regular, 1, 10000}}})
+	totals, _ = repo.GetSalesTotals(ctx, march())
+	if got := formatFigures(totals); got != want {
+		t.Errorf("GetSalesTotals() before the next refresh = %s, want %s", got, want)
+	}
+
+	s.refresh(t, repo)
+	totals, _ = repo.GetSalesTotals(ctx, march())
+	want = "NGN created=5 paid=4 tickets=7 revenue=210000.00; USD created=1 paid=1 tickets=1 revenue=50.00"
+	if got := formatFigures(totals); got != want {
+		t.Errorf("GetSalesTotals() after the next refresh = %s, want %s", got, want)
+	}
+}
+
+func TestAnalyticsSalesScopedByOrganizerAndCurrency(t *testing.T) {
+	s := newAnalyticsSeed(t)
+	repo := NewAnalyticsRepository(s.db)
+	s.refresh(t, repo)
+
+	filter := march()
+	filter.OrganizerID = &s.organizerA
+	totals, err := repo.GetSalesTotals(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("GetSalesTotals() error = %v", err)
+	}
+	if got, want := formatFigures(totals), "NGN created=3 paid=2 tickets=4 revenue=160000.00"; got != want {
+		t.Errorf("GetSalesTotals() for organizer A = %s, want %s", got, want)
+	}
+
+	filter = march()
+	filter.Currency = "USD"
+	totals, err = repo.GetSalesTotals(context.Background(), filter)
+	if err != nil {
+		t.Fatalf("GetSalesTotals() error = %v", err)
+	}
+	if got, want := formatFigures(totals), "USD created=1 paid=1 tickets=1 revenue=50.00"; got != want {
+		t.Errorf("GetSalesTotals() in USD = %s, want %s", got, want)
+	}
+}
+
+func TestAnalyticsSalesSeriesBuckets(t *testing.T) {
+	s := newAnalyticsSeed(t)
+	repo := NewAnalyticsRepository(s.db)
+	s.refresh(t, repo)
+
+	tests := []struct {
+		bucket repositories.AnalyticsBucket
+		want   string
+	}{
+		{
+			// Bola's order counts as placed on the 3rd and paid on the 4th
+			bucket: repositories.AnalyticsBucketDay,
+			want: "2026-03-02 NGN created=1 paid=1 tickets=2 revenue=100000.00; " +
+				"2026-03-03 NGN created=1 paid=0 tickets=0 revenue=0.00; " +
+				"2026-03-04 NGN created=1 paid=1 tickets=2 revenue=60000.00; " +
+				"2026-03-10 NGN created=1 paid=1 tickets=2 revenue=40000.00; " +
+				"2026-03-11 USD created=1 paid=1 tickets=1 revenue=50.00",
+		},
+		{
+			// Weeks start on Monday
+			bucket: repositories.AnalyticsBucketWeek,
+			want: "2026-03-02 NGN created=3 paid=2 tickets=4 revenue=160000.00; " +
+				"2026-03-09 NGN created=1 paid=1 tickets=2 revenue=40000.00; " +
+				"2026-03-09 USD created=1 paid=1 tickets=1 revenue=50.00",
+		},
+		{
+			bucket: repositories.AnalyticsBucketMonth,
+			want: "2026-03-01 NGN created=4 paid=3 tickets=6 revenue=200000.00; " +
+				"2026-03-01 USD created=1 paid=1 tickets=1 revenue=50.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.bucket), func(t *testing.T) {
+			points, err := repo.GetSalesSeries(context.Background(), march(), tt.bucket)
+			if err != nil {
+				t.Fatalf("GetSalesSeries() error = %v", err)
+			}
+			if got := formatPoints(points); got != tt.want {
+				t.Errorf("GetSalesSeries() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyticsSalesBreakdowns(t *testing.T) {
+	s := newAnalyticsSeed(t)
+	repo := NewAnalyticsRepository(s.db)
+	s.refresh(t, repo)
+
+	tests := []struct {
+		dimension repositories.AnalyticsDimension
+		limit     int
+		want      string
+	}{
+		{
+			// Tier revenue comes from the order lines; values are ranked per currency
+			dimension: repositories.AnalyticsDimensionTier,
+			limit:     10,
+			want: "NGN Lagos Live - VIP paid=2 tickets=3 revenue=150000.00; " +
+				"NGN Abuja Nights - General paid=1 tickets=2 revenue=40000.00; " +
+				"NGN Lagos Live - Regular paid=1 tickets=1 revenue=10000.00; " +
+				"USD Abuja Nights - General paid=1 tickets=1 revenue=50.00",
+		},
+		{
+			dimension: repositories.AnalyticsDimensionTier,
+			limit:     1,
+			want: "NGN Lagos Live - VIP paid=2 tickets=3 revenue=150000.00; " +
+				"USD Abuja Nights - General paid=1 tickets=1 revenue=50.00",
+		},
+		{
+			// Chi's unpaid order has no payment method and no sales, so it is left out
+			dimension: repositories.AnalyticsDimensionPaymentMethod,
+			limit:     10,
+			want: "NGN paystack paid=2 tickets=4 revenue=140000.00; " +
+				"NGN momo paid=1 tickets=2 revenue=60000.00; " +
+				"USD card paid=1 tickets=1 revenue=50.00",
+		},
+		{
+			dimension: repositories.AnalyticsDimensionCity,
+			limit:     10,
+			want: "NGN Lagos paid=2 tickets=4 revenue=160000.00; " +
+				"NGN Abuja paid=1 tickets=2 revenue=40000.00; " +
+				"USD Abuja paid=1 tickets=1 revenue=50.00",
+		},
+		{
+			dimension: repositories.AnalyticsDimensionEvent,
+			limit:     10,
+			want: "NGN Lagos Live paid=2 tickets=4 revenue=160000.00; " +
+				"NGN Abuja Nights paid=1 tickets=2 revenue=40000.00; " +
+				"USD Abuja Nights paid=1 tickets=1 revenue=50.00",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%s/%d", tt.dimension, tt.limit), func(t *testing.T) {
+			rows, err := repo.GetSalesBreakdown(context.Background(), march(), tt.dimension, tt.limit)
+			if err != nil {
+				t.Fatalf("GetSalesBreakdown() error = %v", err)
+			}
+			if got := formatBreakdown(rows); got != tt.want {
+				t.Errorf("GetSalesBreakdown() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAnalyticsNewAndReturningBuyers(t *testing.T) {
+	s := newAnalyticsSeed(t)
+	repo := NewAnalyticsRepository(s.db)
+	ctx := context.Background()
+
+	// Efe's only tickets were imported from another platform
+	s.insert(t, seedOrder{event: s.lagos, email: "efe@example.com", status: "paid", currency: "NGN", imported: true,
+		createdAt: day(time.March, 6, 12, 0), paidAt: at(day(time.March, 6, 12, 0)),
+		lines: []seedLine{{s.regular, 2, 20000}}})
+
+	tests := []struct {
+		name        string
+		organizerID *uuid.UUID
+		buyers      int
+		newBuyers   int
+	}{
+		// Ada bought in February; Bola is one buyer under both addresses; Chi
+		// only has a pending order and a comp; Efe only has an imported order
+		{name: "all organizers", buyers: 3, newBuyers: 2},
+		{name: "organizer A", organizerID: &s.organizerA, buyers: 2, newBuyers: 1},
+		// Bola's first purchase from organizer B is in the period
+		{name: "organizer B", organizerID: &s.organizerB, buyers: 2, newBuyers: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := march()
+			filter.OrganizerID = tt.organizerID
+			totals, err := repo.GetBuyerTotals(ctx, filter)
+			if err != nil {
+				t.Fatalf("GetBuyerTotals() error = %v", err)
+			}
+			if totals.Buyers != tt.buyers || totals.NewBuyers != tt.newBuyers {
+				t.Errorf("GetBuyerTotals() = %d buyers, %d new, want %d buyers, %d new",
+					totals.Buyers, totals.NewBuyers, tt.buyers, tt.newBuyers)
+			}
+		})
+	}
+
+	// Buyers are live, so no refresh is needed; a buyer is new only in the
+	// week of their first purchase
+	points, err := repo.GetBuyerSeries(ctx, march(), repositories.AnalyticsBucketWeek)
+	if err != nil {
+		t.Fatalf("GetBuyerSeries() error = %v", err)
+	}
+	if got, want := formatBuyers(points), "2026-03-02 buyers=2 new=1; 2026-03-09 buyers=2 new=1"; got != want {
+		t.Errorf("GetBuyerSeries() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,253 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const boxOfficeShiftSelectColumns = `id, event_id, operator_id, currency, status, opening_float,
+	counted_cash, expected_cash, cash_variance, notes, opened_at, closed_at, closed_by,
+	created_at, updated_at`
+
+const boxOfficeSaleSelectColumns = `id, shift_id, order_id, operator_id, payment_method, amount,
+	amount_tendered, change_given, terminal_reference, ticket_count, created_at`
+
+type boxOfficeRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewBoxOfficeRepository(db *sqlx.DB) repositories.BoxOfficeRepository {
+	return &boxOfficeRepository{db: db}
+}
+
+func NewBoxOfficeRepositoryWithTx(tx *sqlx.Tx) repositories.BoxOfficeRepository {
+	return &boxOfficeRepository{db: tx}
+}
+
+func (r *boxOfficeRepository) CreateShift(ctx context.Context, shift *entities.BoxOfficeShift) error {
+	query := `
+		INSERT INTO box_office_shifts (
+			id, event_id, operator_id, currency, status, opening_float,
+			notes, opened_at, created_at, updated_at
+		) VALUES (
+			:id, :event_id, :operator_id, :currency, :status, :opening_float,
+			:notes, :opened_at, :created_at, :updated_at
+		)`
+	
+	_, err := r.db.NamedExecContext(ctx, query, shift)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok {
+			switch pqErr.Code {
+			case "23505": // unique_violation on the open-shift-per-operator index
+				return entities.ErrBoxOfficeShiftOpen
+			case "23503": // foreign_key_violation
+				return entities.ErrEventNotFound
+			}
+		}
+		return fmt.Errorf("failed to create box office shift: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *boxOfficeRepository) GetShiftByID(ctx context.Context, id uuid.UUID) (*entities.BoxOfficeShift, error) {
+	var shift entities.BoxOfficeShift
+	query := fmt.Sprintf(`SELECT %s FROM box_office_shifts WHERE id = $1`, boxOfficeShiftSelectColumns)
+	
+	err := r.db.GetContext(ctx, &shift, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrBoxOfficeShiftNotFound
+		}
+		return nil, fmt.Errorf("failed to get box office shift: %w", err)
+	}
+	
+	return &shift, nil
+}
+
+func (r *boxOfficeRepository) GetOpenShiftByOperator(ctx context.Context, operatorID uuid.UUID) (*entities.BoxOfficeShift, error) {
+	var shift entities.BoxOfficeShift
+	query := fmt.Sprintf(`SELECT %s FROM box_office_shifts WHERE operator_id = $1 AND status = 'open'`, boxOfficeShiftSelectColumns)
+	
+	err := r.db.GetContext(ctx, &shift, query, operatorID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrBoxOfficeShiftNotFound
+		}
+		return nil, fmt.Errorf("failed to get open box office shift: %w", err)
+	}
+	
+	return &shift, nil
+}
+
+func (r *boxOfficeRepository) UpdateShift(ctx context.Context, shift *entities.BoxOfficeShift) error {
+	query := `
+		UPDATE box_office_shifts SET
+			status = :status,
+			counted_cash = :counted_cash,
+			expected_cash = :expected_cash,
+			cash_variance = :cash_variance,
+			notes = :notes,
+			closed_at = :closed_at,
+			closed_by = :closed_by,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, shift)
+	if err != nil {
+		return fmt.Errorf("failed to update box office shift: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrBoxOfficeShiftNotFound
+	}
+	
+	return nil
+}
+
+func (r *boxOfficeRepository) ListShifts(ctx context.Context, filter repositories.BoxOfficeShiftFilter) ([]*entities.BoxOfficeShift, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.EventID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("event_id = $%d", argIndex))
+		args = append(args, *filter.EventID)
+		argIndex++
+	}
+	
+	if filter.OperatorID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("operator_id = $%d", argIndex))
+		args = append(args, *filter.OperatorID)
+		argIndex++
+	}
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	if filter.OpenedFrom != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("opened_at >= $%d", argIndex))
+		args = append(args, *filter.OpenedFrom)
+		argIndex++
+	}
+	
+	if filter.OpenedTo != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("opened_at <= $%d", argIndex))
+		args = append(args, *filter.OpenedTo)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM box_office_shifts WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count box office shifts: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM box_office_shifts
+		WHERE %s
+		ORDER BY opened_at DESC
+		LIMIT $%d OFFSET $%d`, boxOfficeShiftSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var shifts []*entities.BoxOfficeShift
+	if err := r.db.SelectContext(ctx, &shifts, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list box office shifts: %w", err)
+	}
+	
+	return shifts, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *boxOfficeRepository) CreateSale(ctx context.Context, sale *entities.BoxOfficeSale) error {
+	query := `
+		INSERT INTO box_office_sales (
+			id, shift_id, order_id, operator_id, payment_method, amount,
+			amount_tendered, change_given, terminal_reference, ticket_count, created_at
+		) VALUES (
+			:id, :shift_id, :order_id, :operator_id, :payment_method, :amount,
+			:amount_tendered, :change_given, :terminal_reference, :ticket_count, :created_at
+		)`
+	
+	_, err := r.db.NamedExecContext(ctx, query, sale)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return entities.NewConflictError("box_office_sale", "order is already recorded as a box office sale", nil)
+		}
+		return fmt.Errorf("failed to create box office sale: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *boxOfficeRepository) GetSaleByOrder(ctx context.Context, orderID uuid.UUID) (*entities.BoxOfficeSale, error) {
+	var sale entities.BoxOfficeSale
+	query := fmt.Sprintf(`SELECT %s FROM box_office_sales WHERE order_id = $1`, boxOfficeSaleSelectColumns)
+	
+	err := r.db.GetContext(ctx, &sale, query, orderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.NewNotFoundError("box_office_sale", "box office sale not found")
+		}
+		return nil, fmt.Errorf("failed to get box office sale: %w", err)
+	}
+	
+	return &sale, nil
+}
+
+func (r *boxOfficeRepository) GetSalesByShift(ctx context.Context, shiftID uuid.UUID) ([]*entities.BoxOfficeSale, error) {
+	var sales []*entities.BoxOfficeSale
+	query := fmt.Sprintf(`SELECT %s FROM box_office_sales WHERE shift_id = $1 ORDER BY created_at ASC`, boxOfficeSaleSelectColumns)
+	
+	if err := r.db.SelectContext(ctx, &sales, query, shiftID); err != nil {
+		return nil, fmt.Errorf("failed to get box office sales: %w", err)
+	}
+	
+	return sales, nil
+}
+
+func (r *boxOfficeRepository) GetShiftTotals(ctx context.Context, shiftID uuid.UUID) ([]*repositories.BoxOfficePaymentTotal, error) {
+	var totals []*repositories.BoxOfficePaymentTotal
+	query := `
+		SELECT payment_method,
+			   COUNT(*) as sales,
+			   COALESCE(SUM(ticket_count), 0) as tickets,
+			   COALESCE(SUM(amount), 0) as amount,
+			   COALESCE(SUM(change_given), 0) as change_given
+		FROM box_office_sales
+		WHERE shift_id = $1
+		GROUP BY payment_method
+		ORDER BY payment_method ASC`
+	
+	if err := r.db.SelectContext(ctx, &totals, query, shiftID); err != nil {
+		return nil, fmt.Errorf("failed to get box office shift totals: %w", err)
+	}
+	
+	return totals, nil
+}
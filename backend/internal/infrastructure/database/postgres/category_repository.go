@@ -0,0 +1,222 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const categorySelectColumns = `c.id, c.parent_id, c.name, c.slug, c.description, c.icon, c.color,
+	c.display_order, c.is_active, c.created_at, c.updated_at`
+
+type categoryRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewCategoryRepository(db *sqlx.DB) repositories.CategoryRepository {
+	return &categoryRepository{db: db}
+}
+
+func NewCategoryRepositoryWithTx(tx *sqlx.Tx) repositories.CategoryRepository {
+	return &categoryRepository{db: tx}
+}
+
+func (r *categoryRepository) Create(ctx context.Context, category *entities.Category) error {
+	query := `
+		INSERT INTO categories (
+			id, parent_id, name, slug, description, icon, color,
+			display_order, is_active, created_at, updated_at
+		) VALUES (
+			:id, :parent_id, :name, :slug, :description, :icon, :color,
+			:display_order, :is_active, :created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, category); err != nil {
+		if mapped := categoryWriteError(err); mapped != nil {
+			return mapped
+		}
+		return fmt.Errorf("failed to create category: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *categoryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	var category entities.Category
+	query := fmt.Sprintf(`SELECT %s FROM categories c WHERE c.id = $1`, categorySelectColumns)
+	
+	err := r.db.GetContext(ctx, &category, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	
+	return &category, nil
+}
+
+func (r *categoryRepository) GetBySlug(ctx context.Context, slug string) (*entities.Category, error) {
+	var category entities.Category
+	query := fmt.Sprintf(`SELECT %s FROM categories c WHERE c.slug = lower($1)`, categorySelectColumns)
+	
+	err := r.db.GetContext(ctx, &category, query, strings.TrimSpace(slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrCategoryNotFound
+		}
+		return nil, fmt.Errorf("failed to get category by slug: %w", err)
+	}
+	
+	return &category, nil
+}
+
+func (r *categoryRepository) Update(ctx context.Context, category *entities.Category) error {
+	query := `
+		UPDATE categories SET
+			parent_id = :parent_id,
+			name = :name,
+			slug = :slug,
+			description = :description,
+			icon = :icon,
+			color = :color,
+			display_order = :display_order,
+			is_active = :is_active,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, category)
+	if err != nil {
+		if mapped := categoryWriteError(err); mapped != nil {
+			return mapped
+		}
+		return fmt.Errorf("failed to update category: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrCategoryNotFound
+	}
+	
+	return nil
+}
+
+// categoryWriteError maps constraint violations on category writes to domain errors
+func categoryWriteError(err error) error {
+	pqErr, ok := err.(*pq.Error)
+	if !ok {
+		return nil
+	}
+	
+	switch pqErr.Code {
+	case "23505": // unique_violation
+		if strings.Contains(pqErr.Constraint, "slug") {
+			return entities.NewConflictError("category", "a category with this slug already exists", nil)
+		}
+		return entities.NewConflictError("category", "a category with this name already exists at this level", nil)
+	case "23503": // foreign_key_violation
+		return entities.NewValidationError("parent_id", "parent category does not exist")
+	}
+	return nil
+}
+
+func (r *categoryRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM categories WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete category: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrCategoryNotFound
+	}
+	
+	return nil
+}
+
+func (r *categoryRepository) List(ctx context.Context, filter repositories.CategoryFilter) ([]*entities.Category, error) {
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.IsActive != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("c.is_active = $%d", argIndex))
+		args = append(args, *filter.IsActive)
+		argIndex++
+	}
+	
+	// Event counts use the same rule as the public event listing
+	query := fmt.Sprintf(`
+		SELECT %s, COALESCE(counts.event_count, 0) AS event_count
+		FROM categories c
+		LEFT JOIN (
+			SELECT category_id, COUNT(*) AS event_count
+			FROM events
+			WHERE is_active = true AND status IN ('published', 'on_sale') AND category_id IS NOT NULL
+			GROUP BY category_id
+		) counts ON counts.category_id = c.id
+		WHERE %s
+		ORDER BY c.display_order ASC, lower(c.name) ASC`, categorySelectColumns, strings.Join(whereConditions, " AND "))
+	
+	categories := []*entities.Category{}
+	if err := r.db.SelectContext(ctx, &categories, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to list categories: %w", err)
+	}
+	
+	return categories, nil
+}
+
+func (r *categoryRepository) Reorder(ctx context.Context, ids []uuid.UUID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+	
+	idStrings := make([]string, len(ids))
+	for i, id := range ids {
+		idStrings[i] = id.String()
+	}
+	
+	query := `
+		UPDATE categories c SET
+			display_order = o.position,
+			updated_at = NOW()
+		FROM unnest($1::uuid[]) WITH ORDINALITY AS o(id, position)
+		WHERE c.id = o.id`
+	
+	if _, err := r.db.ExecContext(ctx, query, pq.Array(idStrings)); err != nil {
+		return fmt.Errorf("failed to reorder categories: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *categoryRepository) CountEvents(ctx context.Context, categoryID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM events WHERE category_id = $1`
+	
+	if err := r.db.GetContext(ctx, &count, query, categoryID); err != nil {
+		return 0, fmt.Errorf("failed to count category events: %w", err)
+	}
+	
+	return count, nil
+}
@@ -0,0 +1,279 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const checkoutRecoverySelectColumns = `id, order_id, event_id, user_id, contact_key, name, email, phone,
+	reason, amount, currency, status, skip_reason, channel, token, send_at, attempts, last_error,
+	sent_at, clicked_at, recovered_order_id, converted_at, recovered_amount, created_at, updated_at`
+
+type checkoutRecoveryRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewCheckoutRecoveryRepository(db *sqlx.DB) repositories.CheckoutRecoveryRepository {
+	return &checkoutRecoveryRepository{db: db}
+}
+
+func NewCheckoutRecoveryRepositoryWithTx(tx *sqlx.Tx) repositories.CheckoutRecoveryRepository {
+	return &checkoutRecoveryRepository{db: tx}
+}
+
+func (r *checkoutRecoveryRepository) Create(ctx context.Context, recovery *entities.CheckoutRecovery, throttle time.Duration) (bool, error) {
+	// Skipped recoveries were never sent, so they do not hold back the next one
+	query := `
+		INSERT INTO checkout_recoveries (
+			id, order_id, event_id, user_id, contact_key, name, email, phone, reason, amount,
+			currency, status, token, send_at, attempts, recovered_amount, created_at, updated_at
+		)
+		SELECT $1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, 0, 0, $15, $15
+		WHERE NOT EXISTS (
+			SELECT 1 FROM checkout_recoveries cr
+			WHERE cr.event_id = $3
+			  AND cr.contact_key = $5
+			  AND cr.status <> 'skipped'
+			  AND cr.created_at > $15 - ($16 * INTERVAL '1 second')
+		)
+		ON CONFLICT (order_id) DO NOTHING`
+	
+	result, err := r.db.ExecContext(ctx, query,
+		recovery.ID, recovery.OrderID, recovery.EventID, recovery.UserID, recovery.ContactKey,
+		recovery.Name, recovery.Email, recovery.Phone, recovery.Reason, recovery.Amount,
+		recovery.Currency, recovery.Status, recovery.Token, recovery.SendAt, recovery.CreatedAt,
+		throttle.Seconds(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("failed to create checkout recovery: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+func (r *checkoutRecoveryRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.CheckoutRecovery, error) {
+	return r.getOne(ctx, "id = $1", id)
+}
+
+func (r *checkoutRecoveryRepository) GetByToken(ctx context.Context, token string) (*entities.CheckoutRecovery, error) {
+	return r.getOne(ctx, "token = $1", token)
+}
+
+func (r *checkoutRecoveryRepository) GetByRecoveredOrder(ctx context.Context, orderID uuid.UUID) (*entities.CheckoutRecovery, error) {
+	return r.getOne(ctx, "recovered_order_id = $1", orderID)
+}
+
+func (r *checkoutRecoveryRepository) getOne(ctx context.Context, condition string, arg interface{}) (*entities.CheckoutRecovery, error) {
+	var recovery entities.CheckoutRecovery
+	query := fmt.Sprintf(`SELECT %s FROM checkout_recoveries WHERE %s`, checkoutRecoverySelectColumns, condition)
+	
+	err := r.db.GetContext(ctx, &recovery, query, arg)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrCheckoutRecoveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get checkout recovery: %w", err)
+	}
+	
+	return &recovery, nil
+}
+
+func (r *checkoutRecoveryRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.CheckoutRecovery, error) {
+	query := fmt.Sprintf(`
+		UPDATE checkout_recoveries SET
+			send_at = NOW() + ($2 * INTERVAL '1 second'),
+			updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM checkout_recoveries
+			WHERE status = 'pending' AND send_at <= NOW()
+			ORDER BY send_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s`, checkoutRecoverySelectColumns)
+	
+	recoveries := []*entities.CheckoutRecovery{}
+	if err := r.db.SelectContext(ctx, &recoveries, query, limit, lease.Seconds()); err != nil {
+		return nil, fmt.Errorf("failed to claim checkout recoveries: %w", err)
+	}
+	
+	return recoveries, nil
+}
+
+func (r *checkoutRecoveryRepository) Update(ctx context.Context, recovery *entities.CheckoutRecovery) error {
+	query := `
+		UPDATE checkout_recoveries SET
+			status = :status,
+			skip_reason = :skip_reason,
+			channel = :channel,
+			send_at = :send_at,
+			attempts = :attempts,
+			last_error = :last_error,
+			sent_at = :sent_at,
+			clicked_at = :clicked_at,
+			recovered_order_id = :recovered_order_id,
+			converted_at = :converted_at,
+			recovered_amount = :recovered_amount,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, recovery)
+	if err != nil {
+		return fmt.Errorf("failed to update checkout recovery: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrCheckoutRecoveryNotFound
+	}
+	
+	return nil
+}
+
+func (r *checkoutRecoveryRepository) HasPurchased(ctx context.Context, recovery *entities.CheckoutRecovery) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1 FROM orders o
+			JOIN orders abandoned ON abandoned.id = $1
+			WHERE o.event_id = abandoned.event_id
+			  AND o.status IN ('paid', 'confirmed')
+			  AND o.created_at >= abandoned.created_at
+			  AND (o.id = abandoned.id
+			       OR o.user_id = $2
+			       OR (CAST($3 AS TEXT) IS NOT NULL AND lower(COALESCE(NULLIF(o.customer_email, ''), o.email)) = lower($3)))
+		)`
+	
+	var purchased bool
+	if err := r.db.GetContext(ctx, &purchased, query, recovery.OrderID, recovery.UserID, recovery.Email); err != nil {
+		return false, fmt.Errorf("failed to check for a purchase after checkout recovery: %w", err)
+	}
+	
+	return purchased, nil
+}
+
+func (r *checkoutRecoveryRepository) List(ctx context.Context, filter repositories.CheckoutRecoveryFilter) ([]*entities.CheckoutRecovery, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.EventID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("event_id = $%d", argIndex))
+		args = append(args, *filter.EventID)
+		argIndex++
+	}
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	if filter.Reason != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("reason = $%d", argIndex))
+		args = append(args, *filter.Reason)
+		argIndex++
+	}
+	
+	if filter.Converted != nil {
+		if *filter.Converted {
+			whereConditions = append(whereConditions, "converted_at IS NOT NULL")
+		} else {
+			whereConditions = append(whereConditions, "converted_at IS NULL")
+		}
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM checkout_recoveries WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count checkout recoveries: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM checkout_recoveries
+		WHERE %s
+		ORDER BY created_at DESC, id ASC
+		LIMIT $%d OFFSET $%d`, checkoutRecoverySelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var recoveries []*entities.CheckoutRecovery
+	if err := r.db.SelectContext(ctx, &recoveries, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list checkout recoveries: %w", err)
+	}
+	
+	return recoveries, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *checkoutRecoveryRepository) GetStats(ctx context.Context, filter repositories.CheckoutRecoveryStatsFilter) ([]*repositories.CheckoutRecoveryStats, error) {
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.EventID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("event_id = $%d", argIndex))
+		args = append(args, *filter.EventID)
+		argIndex++
+	}
+	
+	if filter.From != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("created_at >= $%d", argIndex))
+		args = append(args, *filter.From)
+		argIndex++
+	}
+	
+	if filter.To != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("created_at < $%d", argIndex))
+		args = append(args, *filter.To)
+		argIndex++
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT currency,
+			   COUNT(*) as abandoned,
+			   COUNT(*) FILTER (WHERE status = 'pending') as pending,
+			   COUNT(*) FILTER (WHERE status = 'sent') as sent,
+			   COUNT(*) FILTER (WHERE status = 'skipped') as skipped,
+			   COUNT(*) FILTER (WHERE status = 'failed') as failed,
+			   COUNT(*) FILTER (WHERE clicked_at IS NOT NULL) as clicked,
+			   COUNT(*) FILTER (WHERE converted_at IS NOT NULL) as converted,
+			   COALESCE(SUM(amount), 0) as abandoned_amount,
+			   COALESCE(SUM(recovered_amount), 0) as recovered_amount
+		FROM checkout_recoveries
+		WHERE %s
+		GROUP BY currency
+		ORDER BY currency ASC`, strings.Join(whereConditions, " AND "))
+	
+	stats := []*repositories.CheckoutRecoveryStats{}
+	if err := r.db.SelectContext(ctx, &stats, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get checkout recovery stats: %w", err)
+	}
+	
+	return stats, nil
+}
@@ -0,0 +1,298 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const compAllocationSelectColumns = `id, event_id, ticket_tier_id, quota, issued, created_by, created_at, updated_at`
+
+const guestListEntrySelectColumns = `id, event_id, ticket_tier_id, allocation_id, order_id, first_name, last_name,
+	email, phone, category, plus_ones, notes, status, issued_by, checked_in_at, checked_in_by,
+	created_at, updated_at`
+
+type compRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewCompRepository(db *sqlx.DB) repositories.CompRepository {
+	return &compRepository{db: db}
+}
+
+func NewCompRepositoryWithTx(tx *sqlx.Tx) repositories.CompRepository {
+	return &compRepository{db: tx}
+}
+
+func (r *compRepository) CreateAllocation(ctx context.Context, allocation *entities.CompAllocation) error {
+	query := `
+		INSERT INTO comp_allocations (
+			id, event_id, ticket_tier_id, quota, issued, created_by, created_at, updated_at
+		) VALUES (
+			:id, :event_id, :ticket_tier_id, :quota, :issued, :created_by, :created_at, :updated_at
+		)`
+	
+	_, err := r.db.NamedExecContext(ctx, query, allocation)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return entities.NewConflictError("comp_allocation", "ticket tier already has a comp allocation", nil)
+		}
+		return fmt.Errorf("failed to create comp allocation: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *compRepository) GetAllocationByID(ctx context.Context, id uuid.UUID) (*entities.CompAllocation, error) {
+	var allocation entities.CompAllocation
+	query := fmt.Sprintf(`SELECT %s FROM comp_allocations WHERE id = $1`, compAllocationSelectColumns)
+	
+	err := r.db.GetContext(ctx, &allocation, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrCompAllocationNotFound
+		}
+		return nil, fmt.Errorf("failed to get comp allocation: %w", err)
+	}
+	
+	return &allocation, nil
+}
+
+func (r *compRepository) GetAllocationByTier(ctx context.Context, ticketTierID uuid.UUID) (*entities.CompAllocation, error) {
+	var allocation entities.CompAllocation
+	query := fmt.Sprintf(`SELECT %s FROM comp_allocations WHERE ticket_tier_id = $1`, compAllocationSelectColumns)
+	
+	err := r.db.GetContext(ctx, &allocation, query, ticketTierID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrCompAllocationNotFound
+		}
+		return nil, fmt.Errorf("failed to get comp allocation: %w", err)
+	}
+	
+	return &allocation, nil
+}
+
+func (r *compRepository) UpdateAllocation(ctx context.Context, allocation *entities.CompAllocation) error {
+	query := `
+		UPDATE comp_allocations SET
+			quota = :quota,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, allocation)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23514" { // check_violation
+			return entities.NewValidationError("quota", "quota cannot be lower than the number of comps already issued")
+		}
+		return fmt.Errorf("failed to update comp allocation: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrCompAllocationNotFound
+	}
+	
+	return nil
+}
+
+func (r *compRepository) ListAllocations(ctx context.Context, eventID uuid.UUID) ([]*entities.CompAllocation, error) {
+	var allocations []*entities.CompAllocation
+	query := fmt.Sprintf(`SELECT %s FROM comp_allocations WHERE event_id = $1 ORDER BY created_at ASC`, compAllocationSelectColumns)
+	
+	if err := r.db.SelectContext(ctx, &allocations, query, eventID); err != nil {
+		return nil, fmt.Errorf("failed to list comp allocations: %w", err)
+	}
+	
+	return allocations, nil
+}
+
+// IncrementIssued consumes quota with a single guarded UPDATE so concurrent issuers cannot overshoot it.
+func (r *compRepository) IncrementIssued(ctx context.Context, allocationID uuid.UUID, quantity int) error {
+	query := `
+		UPDATE comp_allocations
+		SET issued = issued + $1, updated_at = NOW()
+		WHERE id = $2 AND issued + $1 <= quota`
+	
+	result, err := r.db.ExecContext(ctx, query, quantity, allocationID)
+	if err != nil {
+		return fmt.Errorf("failed to increment comp allocation: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrCompQuotaExceeded
+	}
+	
+	return nil
+}
+
+func (r *compRepository) DecrementIssued(ctx context.Context, allocationID uuid.UUID, quantity int) error {
+	query := `
+		UPDATE comp_allocations
+		SET issued = GREATEST(issued - $1, 0), updated_at = NOW()
+		WHERE id = $2`
+	
+	result, err := r.db.ExecContext(ctx, query, quantity, allocationID)
+	if err != nil {
+		return fmt.Errorf("failed to decrement comp allocation: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrCompAllocationNotFound
+	}
+	
+	return nil
+}
+
+func (r *compRepository) CreateGuestListEntry(ctx context.Context, entry *entities.GuestListEntry) error {
+	query := `
+		INSERT INTO guest_list_entries (
+			id, event_id, ticket_tier_id, allocation_id, order_id, first_name, last_name,
+			email, phone, category, plus_ones, notes, status, issued_by, created_at, updated_at
+		) VALUES (
+			:id, :event_id, :ticket_tier_id, :allocation_id, :order_id, :first_name, :last_name,
+			:email, :phone, :category, :plus_ones, :notes, :status, :issued_by, :created_at, :updated_at
+		)`
+	
+	_, err := r.db.NamedExecContext(ctx, query, entry)
+	if err != nil {
+		return fmt.Errorf("failed to create guest list entry: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *compRepository) GetGuestListEntryByID(ctx context.Context, id uuid.UUID) (*entities.GuestListEntry, error) {
+	var entry entities.GuestListEntry
+	query := fmt.Sprintf(`SELECT %s FROM guest_list_entries WHERE id = $1`, guestListEntrySelectColumns)
+	
+	err := r.db.GetContext(ctx, &entry, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrGuestListEntryNotFound
+		}
+		return nil, fmt.Errorf("failed to get guest list entry: %w", err)
+	}
+	
+	return &entry, nil
+}
+
+func (r *compRepository) UpdateGuestListEntry(ctx context.Context, entry *entities.GuestListEntry) error {
+	query := `
+		UPDATE guest_list_entries SET
+			order_id = :order_id,
+			email = :email,
+			phone = :phone,
+			notes = :notes,
+			status = :status,
+			checked_in_at = :checked_in_at,
+			checked_in_by = :checked_in_by,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, entry)
+	if err != nil {
+		return fmt.Errorf("failed to update guest list entry: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrGuestListEntryNotFound
+	}
+	
+	return nil
+}
+
+func (r *compRepository) ListGuestList(ctx context.Context, filter repositories.GuestListFilter) ([]*entities.GuestListEntry, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.EventID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("event_id = $%d", argIndex))
+		args = append(args, *filter.EventID)
+		argIndex++
+	}
+	
+	if filter.TicketTierID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("ticket_tier_id = $%d", argIndex))
+		args = append(args, *filter.TicketTierID)
+		argIndex++
+	}
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	if filter.Category != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("category = $%d", argIndex))
+		args = append(args, *filter.Category)
+		argIndex++
+	}
+	
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf(
+			"(LOWER(first_name) LIKE $%d OR LOWER(last_name) LIKE $%d OR LOWER(first_name || ' ' || last_name) LIKE $%d)",
+			argIndex, argIndex, argIndex))
+		args = append(args, strings.ToLower(search)+"%")
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM guest_list_entries WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count guest list entries: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM guest_list_entries
+		WHERE %s
+		ORDER BY LOWER(last_name) ASC, LOWER(first_name) ASC
+		LIMIT $%d OFFSET $%d`, guestListEntrySelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var entries []*entities.GuestListEntry
+	if err := r.db.SelectContext(ctx, &entries, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list guest list entries: %w", err)
+	}
+	
+	return entries, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
@@ -0,0 +1,452 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// eventCampaignSendAt computes a campaign's send time from its event's current date,
+// so campaigns follow a postponed event without being rescheduled
+const eventCampaignSendAt = `CASE WHEN c.type = 'reminder'
+	THEN e.event_date - make_interval(mins => c.offset_minutes)
+	ELSE e.event_date + make_interval(mins => c.offset_minutes) END`
+
+const eventCampaignSelectColumns = `c.id, c.event_id, c.type, c.offset_minutes, c.message, c.survey_url,
+	c.status, ` + eventCampaignSendAt + ` AS send_at, c.total_recipients, c.sent_count,
+	c.skipped_count, c.failed_count, c.last_error, c.created_by, c.recipients_staged_at,
+	c.started_at, c.completed_at, c.created_at, c.updated_at`
+
+const campaignDeliverySelectColumns = `id, campaign_id, order_id, user_id, contact_key, name, email, phone,
+	channel, status, skip_reason, unsubscribe_token, attempts, last_error, sent_at, created_at, updated_at`
+
+type eventCampaignRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewEventCampaignRepository(db *sqlx.DB) repositories.EventCampaignRepository {
+	return &eventCampaignRepository{db: db}
+}
+
+func NewEventCampaignRepositoryWithTx(tx *sqlx.Tx) repositories.EventCampaignRepository {
+	return &eventCampaignRepository{db: tx}
+}
+
+func (r *eventCampaignRepository) Create(ctx context.Context, campaign *entities.EventCampaign) error {
+	query := `
+		INSERT INTO event_campaigns (
+			id, event_id, type, offset_minutes, message, survey_url, status, created_by,
+			created_at, updated_at
+		) VALUES (
+			:id, :event_id, :type, :offset_minutes, :message, :survey_url, :status, :created_by,
+			:created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, campaign); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return entities.NewConflictError("event_campaign", "the event already has a campaign of this type at this offset", nil)
+		}
+		return fmt.Errorf("failed to create event campaign: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *eventCampaignRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.EventCampaign, error) {
+	var campaign entities.EventCampaign
+	query := fmt.Sprintf(`
+		SELECT %s FROM event_campaigns c
+		JOIN events e ON e.id = c.event_id
+		WHERE c.id = $1`, eventCampaignSelectColumns)
+	
+	err := r.db.GetContext(ctx, &campaign, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrEventCampaignNotFound
+		}
+		return nil, fmt.Errorf("failed to get event campaign: %w", err)
+	}
+	
+	return &campaign, nil
+}
+
+func (r *eventCampaignRepository) Update(ctx context.Context, campaign *entities.EventCampaign) error {
+	query := `
+		UPDATE event_campaigns SET
+			offset_minutes = :offset_minutes,
+			message = :message,
+			survey_url = :survey_url,
+			status = :status,
+			total_recipients = :total_recipients,
+			sent_count = :sent_count,
+			skipped_count = :skipped_count,
+			failed_count = :failed_count,
+			last_error = :last_error,
+			recipients_staged_at = :recipients_staged_at,
+			started_at = :started_at,
+			completed_at = :completed_at,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, campaign)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return entities.NewConflictError("event_campaign", "the event already has a campaign of this type at this offset", nil)
+		}
+		return fmt.Errorf("failed to update event campaign: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrEventCampaignNotFound
+	}
+	
+	return nil
+}
+
+func (r *eventCampaignRepository) ListByEvent(ctx context.Context, eventID uuid.UUID) ([]*entities.EventCampaign, error) {
+	var campaigns []*entities.EventCampaign
+	query := fmt.Sprintf(`
+		SELECT %s FROM event_campaigns c
+		JOIN events e ON e.id = c.event_id
+		WHERE c.event_id = $1
+		ORDER BY send_at ASC, c.created_at ASC`, eventCampaignSelectColumns)
+	
+	if err := r.db.SelectContext(ctx, &campaigns, query, eventID); err != nil {
+		return nil, fmt.Errorf("failed to list event campaigns: %w", err)
+	}
+	
+	return campaigns, nil
+}
+
+func (r *eventCampaignRepository) CreateDefaults(ctx context.Context, campaignType entities.CampaignType, offsetMinutes int) (int, error) {
+	// Any campaign of the type counts, cancelled ones included, so an admin
+	// who cancels a default campaign does not see it come back
+	query := `
+		INSERT INTO event_campaigns (id, event_id, type, offset_minutes, status, created_at, updated_at)
+		SELECT gen_random_uuid(), e.id, $1, $2, 'scheduled', NOW(), NOW()
+		FROM events e
+		WHERE e.status IN ('published', 'on_sale', 'sold_out')
+		  AND e.event_date > NOW()
+		  AND NOT EXISTS (
+			SELECT 1 FROM event_campaigns c WHERE c.event_id = e.id AND c.type = $1
+		  )
+		ON CONFLICT DO NOTHING`
+	
+	result, err := r.db.ExecContext(ctx, query, campaignType, offsetMinutes)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create default event campaigns: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return int(rowsAffected), nil
+}
+
+func (r *eventCampaignRepository) GetDue(ctx context.Context, staleBefore time.Time, limit int) ([]*entities.EventCampaign, error) {
+	var campaigns []*entities.EventCampaign
+	query := fmt.Sprintf(`
+		SELECT %s FROM event_campaigns c
+		JOIN events e ON e.id = c.event_id
+		WHERE (c.status = 'scheduled' AND %s <= NOW())
+		   OR (c.status IN ('processing', 'failed') AND c.updated_at < $1)
+		ORDER BY send_at ASC
+		LIMIT $2`, eventCampaignSelectColumns, eventCampaignSendAt)
+	
+	if err := r.db.SelectContext(ctx, &campaigns, query, staleBefore, limit); err != nil {
+		return nil, fmt.Errorf("failed to get due event campaigns: %w", err)
+	}
+	
+	return campaigns, nil
+}
+
+func (r *eventCampaignRepository) Claim(ctx context.Context, id uuid.UUID, staleBefore time.Time) (bool, error) {
+	query := `
+		UPDATE event_campaigns SET
+			status = 'processing',
+			started_at = COALESCE(started_at, NOW()),
+			last_error = NULL,
+			updated_at = NOW()
+		WHERE id = $1
+		  AND (status = 'scheduled' OR (status IN ('processing', 'failed') AND updated_at < $2))`
+	
+	result, err := r.db.ExecContext(ctx, query, id, staleBefore)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim event campaign: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+func (r *eventCampaignRepository) StageDeliveries(ctx context.Context, campaign *entities.EventCampaign) (int, error) {
+	// One delivery per holder: orders are grouped by the customer's account, or
+	// else their email or phone, keeping the latest order's contact details.
+	// Reminders go to holders with a ticket still to be scanned, follow-ups to
+	// holders with a scanned one. Unsubscribed holders are recorded as skipped
+	// so the report shows them.
+	query := `
+		INSERT INTO campaign_deliveries (
+			id, campaign_id, order_id, user_id, contact_key, name, email, phone, status,
+			skip_reason, unsubscribe_token, attempts, created_at, updated_at
+		)
+		SELECT DISTINCT ON (k.contact_key)
+			gen_random_uuid(), $1, o.id, o.user_id, k.contact_key, h.name, h.email, h.phone,
+			CASE WHEN u.unsubscribed THEN 'skipped' ELSE 'pending' END,
+			CASE WHEN u.unsubscribed THEN 'unsubscribed' END,
+			replace(gen_random_uuid()::text || gen_random_uuid()::text, '-', ''),
+			0, NOW(), NOW()
+		FROM orders o
+		CROSS JOIN LATERAL (
+			SELECT NULLIF(TRIM(CONCAT_WS(' ', o.customer_first_name, o.customer_last_name)), '') AS name,
+				   COALESCE(NULLIF(o.customer_email, ''), NULLIF(o.email, '')) AS email,
+				   COALESCE(NULLIF(o.customer_phone, ''), NULLIF(o.phone, '')) AS phone
+		) h
+		CROSS JOIN LATERAL (
+			SELECT COALESCE('user:' || o.user_id::text, 'email:' || lower(h.email), 'phone:' || h.phone) AS contact_key
+		) k
+		CROSS JOIN LATERAL (
+			SELECT EXISTS (
+				SELECT 1 FROM campaign_unsubscribes cu
+				WHERE cu.contact_key IN ('user:' || o.user_id::text, 'email:' || lower(h.email), 'phone:' || h.phone)
+				  AND cu.campaign_type IN ('all', $3)
+			) AS unsubscribed
+		) u
+		WHERE o.event_id = $2
+		  AND o.status IN ('paid', 'confirmed')
+		  AND k.contact_key IS NOT NULL
+		  AND EXISTS (SELECT 1 FROM tickets t WHERE t.order_id = o.id AND t.status = $4)
+		ORDER BY k.contact_key, o.created_at DESC
+		ON CONFLICT (campaign_id, contact_key) DO NOTHING`
+	
+	ticketStatus := entities.TicketStatusActive
+	if campaign.Type == entities.CampaignFollowUp {
+		ticketStatus = entities.TicketStatusRedeemed
+	}
+	
+	result, err := r.db.ExecContext(ctx, query, campaign.ID, campaign.EventID, campaign.Type, ticketStatus)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stage campaign deliveries: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return int(rowsAffected), nil
+}
+
+func (r *eventCampaignRepository) GetPendingDeliveries(ctx context.Context, campaignID uuid.UUID, limit int) ([]*entities.CampaignDelivery, error) {
+	var deliveries []*entities.CampaignDelivery
+	query := fmt.Sprintf(`
+		SELECT %s FROM campaign_deliveries
+		WHERE campaign_id = $1 AND status = 'pending'
+		ORDER BY created_at ASC, id ASC
+		LIMIT $2`, campaignDeliverySelectColumns)
+	
+	if err := r.db.SelectContext(ctx, &deliveries, query, campaignID, limit); err != nil {
+		return nil, fmt.Errorf("failed to get pending campaign deliveries: %w", err)
+	}
+	
+	return deliveries, nil
+}
+
+func (r *eventCampaignRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*entities.CampaignDelivery, error) {
+	var delivery entities.CampaignDelivery
+	query := fmt.Sprintf(`SELECT %s FROM campaign_deliveries WHERE id = $1`, campaignDeliverySelectColumns)
+	
+	err := r.db.GetContext(ctx, &delivery, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrCampaignDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get campaign delivery: %w", err)
+	}
+	
+	return &delivery, nil
+}
+
+func (r *eventCampaignRepository) GetDeliveryByToken(ctx context.Context, token string) (*entities.CampaignDelivery, error) {
+	var delivery entities.CampaignDelivery
+	query := fmt.Sprintf(`SELECT %s FROM campaign_deliveries WHERE unsubscribe_token = $1`, campaignDeliverySelectColumns)
+	
+	err := r.db.GetContext(ctx, &delivery, query, token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrCampaignDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get campaign delivery: %w", err)
+	}
+	
+	return &delivery, nil
+}
+
+func (r *eventCampaignRepository) UpdateDelivery(ctx context.Context, delivery *entities.CampaignDelivery) error {
+	query := `
+		UPDATE campaign_deliveries SET
+			channel = :channel,
+			status = :status,
+			skip_reason = :skip_reason,
+			attempts = :attempts,
+			last_error = :last_error,
+			sent_at = :sent_at,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, delivery)
+	if err != nil {
+		return fmt.Errorf("failed to update campaign delivery: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrCampaignDeliveryNotFound
+	}
+	
+	return nil
+}
+
+func (r *eventCampaignRepository) SkipPendingDeliveries(ctx context.Context, campaignID uuid.UUID, reason string) (int, error) {
+	query := `
+		UPDATE campaign_deliveries SET status = 'skipped', skip_reason = $2, updated_at = NOW()
+		WHERE campaign_id = $1 AND status = 'pending'`
+	
+	result, err := r.db.ExecContext(ctx, query, campaignID, reason)
+	if err != nil {
+		return 0, fmt.Errorf("failed to skip pending campaign deliveries: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return int(rowsAffected), nil
+}
+
+func (r *eventCampaignRepository) ListDeliveries(ctx context.Context, filter repositories.CampaignDeliveryFilter) ([]*entities.CampaignDelivery, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"campaign_id = $1"}
+	args := []interface{}{filter.CampaignID}
+	argIndex := 2
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	if filter.Channel != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("channel = $%d", argIndex))
+		args = append(args, *filter.Channel)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM campaign_deliveries WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count campaign deliveries: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM campaign_deliveries
+		WHERE %s
+		ORDER BY created_at ASC, id ASC
+		LIMIT $%d OFFSET $%d`, campaignDeliverySelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var deliveries []*entities.CampaignDelivery
+	if err := r.db.SelectContext(ctx, &deliveries, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list campaign deliveries: %w", err)
+	}
+	
+	return deliveries, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *eventCampaignRepository) CountDeliveries(ctx context.Context, campaignID uuid.UUID) (*repositories.CampaignDeliveryCounts, error) {
+	var counts repositories.CampaignDeliveryCounts
+	query := `
+		SELECT COUNT(*) as total,
+			   COUNT(*) FILTER (WHERE status = 'pending') as pending,
+			   COUNT(*) FILTER (WHERE status = 'sent') as sent,
+			   COUNT(*) FILTER (WHERE status = 'skipped') as skipped,
+			   COUNT(*) FILTER (WHERE status = 'failed') as failed
+		FROM campaign_deliveries
+		WHERE campaign_id = $1`
+	
+	if err := r.db.GetContext(ctx, &counts, query, campaignID); err != nil {
+		return nil, fmt.Errorf("failed to count campaign deliveries: %w", err)
+	}
+	
+	return &counts, nil
+}
+
+func (r *eventCampaignRepository) Unsubscribe(ctx context.Context, unsubscribe *entities.CampaignUnsubscribe) error {
+	query := `
+		INSERT INTO campaign_unsubscribes (id, contact_key, user_id, campaign_type, source, created_at)
+		VALUES (:id, :contact_key, :user_id, :campaign_type, :source, :created_at)
+		ON CONFLICT (contact_key, campaign_type) DO NOTHING`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, unsubscribe); err != nil {
+		return fmt.Errorf("failed to record campaign unsubscribe: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *eventCampaignRepository) ListUnsubscribes(ctx context.Context, contactKey string) ([]*entities.CampaignUnsubscribe, error) {
+	var unsubscribes []*entities.CampaignUnsubscribe
+	query := `
+		SELECT id, contact_key, user_id, campaign_type, source, created_at
+		FROM campaign_unsubscribes
+		WHERE contact_key = $1
+		ORDER BY created_at ASC`
+	
+	if err := r.db.SelectContext(ctx, &unsubscribes, query, contactKey); err != nil {
+		return nil, fmt.Errorf("failed to list campaign unsubscribes: %w", err)
+	}
+	
+	return unsubscribes, nil
+}
+
+func (r *eventCampaignRepository) DeleteUnsubscribes(ctx context.Context, contactKey string) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM campaign_unsubscribes WHERE contact_key = $1`, contactKey); err != nil {
+		return fmt.Errorf("failed to delete campaign unsubscribes: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,373 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const eventChangeSelectColumns = `id, event_id, type, reason, message, previous_date, new_date,
+	void_tickets, refund_policy, refund_deadline, notify_email, notify_sms, status,
+	total_recipients, notified_recipients, failed_recipients, refunds_requested,
+	refunds_completed, last_error, created_by, recipients_staged_at, started_at,
+	completed_at, created_at, updated_at`
+
+const eventChangeRecipientSelectColumns = `id, change_id, order_id, user_id, name, email, phone,
+	status, tickets_voided, email_status, sms_status, refund_status, refund_choice,
+	choice_token, chosen_at, attempts, last_error, notified_at, created_at, updated_at`
+
+type eventChangeRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewEventChangeRepository(db *sqlx.DB) repositories.EventChangeRepository {
+	return &eventChangeRepository{db: db}
+}
+
+func NewEventChangeRepositoryWithTx(tx *sqlx.Tx) repositories.EventChangeRepository {
+	return &eventChangeRepository{db: tx}
+}
+
+func (r *eventChangeRepository) Create(ctx context.Context, change *entities.EventChange) error {
+	query := `
+		INSERT INTO event_changes (
+			id, event_id, type, reason, message, previous_date, new_date, void_tickets,
+			refund_policy, refund_deadline, notify_email, notify_sms, status, created_by,
+			created_at, updated_at
+		) VALUES (
+			:id, :event_id, :type, :reason, :message, :previous_date, :new_date, :void_tickets,
+			:refund_policy, :refund_deadline, :notify_email, :notify_sms, :status, :created_by,
+			:created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, change); err != nil {
+		return fmt.Errorf("failed to create event change: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *eventChangeRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.EventChange, error) {
+	var change entities.EventChange
+	query := fmt.Sprintf(`SELECT %s FROM event_changes WHERE id = $1`, eventChangeSelectColumns)
+	
+	err := r.db.GetContext(ctx, &change, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrEventChangeNotFound
+		}
+		return nil, fmt.Errorf("failed to get event change: %w", err)
+	}
+	
+	return &change, nil
+}
+
+func (r *eventChangeRepository) Update(ctx context.Context, change *entities.EventChange) error {
+	query := `
+		UPDATE event_changes SET
+			status = :status,
+			total_recipients = :total_recipients,
+			notified_recipients = :notified_recipients,
+			failed_recipients = :failed_recipients,
+			refunds_requested = :refunds_requested,
+			refunds_completed = :refunds_completed,
+			last_error = :last_error,
+			recipients_staged_at = :recipients_staged_at,
+			started_at = :started_at,
+			completed_at = :completed_at,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, change)
+	if err != nil {
+		return fmt.Errorf("failed to update event change: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrEventChangeNotFound
+	}
+	
+	return nil
+}
+
+func (r *eventChangeRepository) List(ctx context.Context, filter repositories.EventChangeFilter) ([]*entities.EventChange, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.EventID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("event_id = $%d", argIndex))
+		args = append(args, *filter.EventID)
+		argIndex++
+	}
+	
+	if filter.Type != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("type = $%d", argIndex))
+		args = append(args, *filter.Type)
+		argIndex++
+	}
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM event_changes WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count event changes: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM event_changes
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, eventChangeSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var changes []*entities.EventChange
+	if err := r.db.SelectContext(ctx, &changes, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list event changes: %w", err)
+	}
+	
+	return changes, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *eventChangeRepository) GetUnfinished(ctx context.Context) ([]*entities.EventChange, error) {
+	var changes []*entities.EventChange
+	query := fmt.Sprintf(`
+		SELECT %s FROM event_changes
+		WHERE status IN ('pending', 'processing')
+		ORDER BY created_at ASC`, eventChangeSelectColumns)
+	
+	if err := r.db.SelectContext(ctx, &changes, query); err != nil {
+		return nil, fmt.Errorf("failed to get unfinished event changes: %w", err)
+	}
+	
+	return changes, nil
+}
+
+func (r *eventChangeRepository) GetUnfinishedByEvent(ctx context.Context, eventID uuid.UUID) (*entities.EventChange, error) {
+	var change entities.EventChange
+	query := fmt.Sprintf(`
+		SELECT %s FROM event_changes
+		WHERE event_id = $1 AND status IN ('pending', 'processing', 'failed')
+		ORDER BY created_at DESC
+		LIMIT 1`, eventChangeSelectColumns)
+	
+	err := r.db.GetContext(ctx, &change, query, eventID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get unfinished event change: %w", err)
+	}
+	
+	return &change, nil
+}
+
+func (r *eventChangeRepository) StageRecipients(ctx context.Context, change *entities.EventChange) (int, error) {
+	// One recipient per paid order. Channels without contact details are skipped
+	// up front, and comp orders are never refunded since they carry no money.
+	query := `
+		INSERT INTO event_change_recipients (
+			id, change_id, order_id, user_id, name, email, phone, status, tickets_voided,
+			email_status, sms_status, refund_status, choice_token, attempts, created_at, updated_at
+		)
+		SELECT gen_random_uuid(), $1, o.id, o.user_id, h.name, h.email, h.phone, 'pending', false,
+			CASE WHEN $3 AND h.email IS NOT NULL THEN 'pending' ELSE 'skipped' END,
+			CASE WHEN $4 AND h.phone IS NOT NULL THEN 'pending' ELSE 'skipped' END,
+			CASE WHEN $5 AND o.total_amount > 0 AND NOT COALESCE(o.is_comp, false) AND NOT COALESCE(o.is_imported, false) THEN 'pending' ELSE 'none' END,
+			replace(gen_random_uuid()::text || gen_random_uuid()::text, '-', ''),
+			0, NOW(), NOW()
+		FROM orders o
+		CROSS JOIN LATERAL (
+			SELECT NULLIF(TRIM(CONCAT_WS(' ', o.customer_first_name, o.customer_last_name)), '') AS name,
+				   COALESCE(NULLIF(o.customer_email, ''), NULLIF(o.email, '')) AS email,
+				   COALESCE(NULLIF(o.customer_phone, ''), NULLIF(o.phone, '')) AS phone
+		) h
+		WHERE o.event_id = $2 AND o.status IN ('paid', 'confirmed')
+		ON CONFLICT (change_id, order_id) DO NOTHING`
+	
+	result, err := r.db.ExecContext(ctx, query,
+		change.ID,
+		change.EventID,
+		change.NotifyEmail,
+		change.NotifySMS,
+		change.RefundPolicy == entities.RefundPolicyAutomatic,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stage event change recipients: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return int(rowsAffected), nil
+}
+
+func (r *eventChangeRepository) GetPendingRecipients(ctx context.Context, changeID uuid.UUID, limit int) ([]*entities.EventChangeRecipient, error) {
+	var recipients []*entities.EventChangeRecipient
+	query := fmt.Sprintf(`
+		SELECT %s FROM event_change_recipients
+		WHERE change_id = $1 AND status = 'pending'
+		ORDER BY created_at ASC, id ASC
+		LIMIT $2`, eventChangeRecipientSelectColumns)
+	
+	if err := r.db.SelectContext(ctx, &recipients, query, changeID, limit); err != nil {
+		return nil, fmt.Errorf("failed to get pending event change recipients: %w", err)
+	}
+	
+	return recipients, nil
+}
+
+func (r *eventChangeRepository) GetRecipient(ctx context.Context, id uuid.UUID) (*entities.EventChangeRecipient, error) {
+	var recipient entities.EventChangeRecipient
+	query := fmt.Sprintf(`SELECT %s FROM event_change_recipients WHERE id = $1`, eventChangeRecipientSelectColumns)
+	
+	err := r.db.GetContext(ctx, &recipient, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrEventChangeRecipientNotFound
+		}
+		return nil, fmt.Errorf("failed to get event change recipient: %w", err)
+	}
+	
+	return &recipient, nil
+}
+
+func (r *eventChangeRepository) GetRecipientByToken(ctx context.Context, token string) (*entities.EventChangeRecipient, error) {
+	var recipient entities.EventChangeRecipient
+	query := fmt.Sprintf(`SELECT %s FROM event_change_recipients WHERE choice_token = $1`, eventChangeRecipientSelectColumns)
+	
+	err := r.db.GetContext(ctx, &recipient, query, token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrEventChangeRecipientNotFound
+		}
+		return nil, fmt.Errorf("failed to get event change recipient: %w", err)
+	}
+	
+	return &recipient, nil
+}
+
+func (r *eventChangeRepository) UpdateRecipient(ctx context.Context, recipient *entities.EventChangeRecipient) error {
+	query := `
+		UPDATE event_change_recipients SET
+			status = :status,
+			tickets_voided = :tickets_voided,
+			email_status = :email_status,
+			sms_status = :sms_status,
+			refund_status = :refund_status,
+			refund_choice = :refund_choice,
+			chosen_at = :chosen_at,
+			attempts = :attempts,
+			last_error = :last_error,
+			notified_at = :notified_at,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, recipient)
+	if err != nil {
+		return fmt.Errorf("failed to update event change recipient: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrEventChangeRecipientNotFound
+	}
+	
+	return nil
+}
+
+func (r *eventChangeRepository) ListRecipients(ctx context.Context, filter repositories.EventChangeRecipientFilter) ([]*entities.EventChangeRecipient, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"change_id = $1"}
+	args := []interface{}{filter.ChangeID}
+	argIndex := 2
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	if filter.RefundStatus != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("refund_status = $%d", argIndex))
+		args = append(args, *filter.RefundStatus)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM event_change_recipients WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count event change recipients: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM event_change_recipients
+		WHERE %s
+		ORDER BY created_at ASC, id ASC
+		LIMIT $%d OFFSET $%d`, eventChangeRecipientSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var recipients []*entities.EventChangeRecipient
+	if err := r.db.SelectContext(ctx, &recipients, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list event change recipients: %w", err)
+	}
+	
+	return recipients, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *eventChangeRepository) CountRecipients(ctx context.Context, changeID uuid.UUID) (*repositories.EventChangeRecipientCounts, error) {
+	var counts repositories.EventChangeRecipientCounts
+	query := `
+		SELECT COUNT(*) as total,
+			   COUNT(*) FILTER (WHERE status = 'pending') as pending,
+			   COUNT(*) FILTER (WHERE notified_at IS NOT NULL) as notified,
+			   COUNT(*) FILTER (WHERE status = 'failed') as failed,
+			   COUNT(*) FILTER (WHERE refund_status <> 'none') as refunds_requested,
+			   COUNT(*) FILTER (WHERE refund_status = 'refunded') as refunds_completed
+		FROM event_change_recipients
+		WHERE change_id = $1`
+	
+	if err := r.db.GetContext(ctx, &counts, query, changeID); err != nil {
+		return nil, fmt.Errorf("failed to count event change recipients: %w", err)
+	}
+	
+	return &counts, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"math"
 	"strings"
 	"time"
 
@@ -34,17 +35,17 @@ func NewEventRepositoryWithTx(tx *sqlx.Tx) repositories.EventRepository {
 func (r *eventRepository) Create(ctx context.Context, event *entities.Event) error {
 		query := `
 			INSERT INTO events (
-				id, organizer_id, category_id, name, slug, description, 
+				id, organizer_id, category_id, tour_id, series_id, venue_id, name, slug, description, 
 				event_date, doors_open, venue_name, venue_address, 
-				venue_city, venue_state, venue_country, venue_capacity, 
+				venue_city, venue_state, venue_country, venue_capacity, venue_latitude, venue_longitude, 
 				event_image_url, thumbnail_url, promo_video_url, gallery_images, status, sale_start, sale_end, 
-				settings, is_active, created_at, updated_at
+				settings, currency, is_active, created_at, updated_at
 			) VALUES (
-				:id, :organizer_id, :category_id, :name, :slug, :description,
+				:id, :organizer_id, :category_id, :tour_id, :series_id, :venue_id, :name, :slug, :description,
 				:event_date, :doors_open, :venue_name, :venue_address,
-				:venue_city, :venue_state, :venue_country, :venue_capacity,
+				:venue_city, :venue_state, :venue_country, :venue_capacity, :venue_latitude, :venue_longitude,
 				:event_image_url, :thumbnail_url, :promo_video_url, :gallery_images, :status, :sale_start, :sale_end,
-				:settings, :is_active, :created_at, :updated_at
+				:settings, :currency, :is_active, :created_at, :updated_at
 			)`
 	
 	_, err := r.db.NamedExecContext(ctx, query, event)
@@ -55,6 +56,10 @@ func (r *eventRepository) Create(ctx context.Context, event *entities.Event) err
 				if strings.Contains(pqErr.Detail, "slug") {
 					return entities.ErrConflictError
 				}
+			case "23503": // foreign_key_violation
+				if strings.Contains(pqErr.Constraint, "category") {
+					return entities.NewValidationError("category_id", "category does not exist")
+				}
 			}
 		}
 		return fmt.Errorf("failed to create event: %w", err)
@@ -66,11 +71,11 @@ func (r *eventRepository) Create(ctx context.Context, event *entities.Event) err
 func (r *eventRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
 	var event entities.Event
 	query := `
-		SELECT e.id, e.organizer_id, e.category_id, e.name, e.slug, e.description,
+		SELECT e.id, e.organizer_id, e.category_id, e.tour_id, e.series_id, e.venue_id, e.name, e.slug, e.description,
 			   e.event_date, e.doors_open, e.venue_name, e.venue_address, 
-			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity,
+			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity, e.venue_latitude, e.venue_longitude,
 			   e.event_image_url, e.thumbnail_url, e.promo_video_url, e.gallery_images, e.status, e.sale_start, e.sale_end, 
-			   e.settings, e.created_at, e.updated_at, e.is_active
+			   e.settings, e.currency, e.created_at, e.updated_at, e.is_active
 		FROM events e
 		WHERE e.id = $1 AND e.is_active = true`
 	
@@ -88,11 +93,11 @@ func (r *eventRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.
 func (r *eventRepository) GetBySlug(ctx context.Context, organizerID uuid.UUID, slug string) (*entities.Event, error) {
 	var event entities.Event
 	query := `
-		SELECT e.id, e.organizer_id, e.category_id, e.name, e.slug, e.description,
+		SELECT e.id, e.organizer_id, e.category_id, e.tour_id, e.series_id, e.venue_id, e.name, e.slug, e.description,
 			   e.event_date, e.doors_open, e.venue_name, e.venue_address, 
-			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity,
+			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity, e.venue_latitude, e.venue_longitude,
 			   e.event_image_url, e.thumbnail_url, e.promo_video_url, e.gallery_images, e.status, e.sale_start, e.sale_end, 
-			   e.settings, e.created_at, e.updated_at, e.is_active
+			   e.settings, e.currency, e.created_at, e.updated_at, e.is_active
 		FROM events e
 		WHERE e.organizer_id = $1 AND e.slug = $2 AND e.is_active = true`
 	
@@ -168,11 +173,11 @@ func (r *eventRepository) List(ctx context.Context, filter repositories.EventFil
 	var events []*entities.Event
 	
 	baseQuery := `
-		SELECT e.id, e.organizer_id, e.category_id, e.name, e.slug, e.description,
+		SELECT e.id, e.organizer_id, e.category_id, e.tour_id, e.series_id, e.venue_id, e.name, e.slug, e.description,
 			   e.event_date, e.doors_open, e.venue_name, e.venue_address, 
-			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity,
+			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity, e.venue_latitude, e.venue_longitude,
 			   e.event_image_url, e.thumbnail_url, e.promo_video_url, e.gallery_images, e.status, e.sale_start, e.sale_end, 
-			   e.settings, e.created_at, e.updated_at, e.is_active
+			   e.settings, e.currency, e.created_at, e.updated_at, e.is_active
 		FROM events e`
 	
 	query, args := r.buildEventQuery(baseQuery, filter)
@@ -215,15 +220,37 @@ func (r *eventRepository) List(ctx context.Context, filter repositories.EventFil
 	return events, pagination, nil
 }
 
+// seriesRepresentativeCondition keeps one public event per series: the next
+// upcoming occurrence, or the latest one once the series has ended
+const seriesRepresentativeCondition = `
+		AND (e.series_id IS NULL OR e.id = (
+			SELECT o.id FROM events o
+			WHERE o.series_id = e.series_id AND o.is_active = true AND o.status IN ('published', 'on_sale')
+			ORDER BY (o.event_date < NOW()), CASE WHEN o.event_date >= NOW() THEN o.event_date END ASC, o.event_date DESC
+			LIMIT 1
+		))`
+
+// categoryTreeCondition matches events in a category or any category nested
+// under it. UNION rather than UNION ALL stops the walk on a parent cycle.
+func categoryTreeCondition(placeholder string) string {
+	return fmt.Sprintf(`e.category_id IN (
+		WITH RECURSIVE category_tree AS (
+			SELECT id FROM categories WHERE id = %s
+			UNION
+			SELECT c.id FROM categories c JOIN category_tree t ON c.parent_id = t.id
+		)
+		SELECT id FROM category_tree)`, placeholder)
+}
+
 func (r *eventRepository) ListPublic(ctx context.Context, filter repositories.PublicEventFilter) ([]*entities.Event, *repositories.PaginationResult, error) {
 	var events []*entities.Event
 	
 	query := `
-		SELECT e.id, e.organizer_id, e.category_id, e.name, e.slug, e.description,
+		SELECT e.id, e.organizer_id, e.category_id, e.tour_id, e.series_id, e.venue_id, e.name, e.slug, e.description,
 			   e.event_date, e.doors_open, e.venue_name, e.venue_address, 
-			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity,
+			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity, e.venue_latitude, e.venue_longitude,
 			   e.event_image_url, e.thumbnail_url, e.promo_video_url, e.gallery_images, e.status, e.sale_start, e.sale_end, 
-			   e.settings, e.created_at, e.updated_at, e.is_active
+			   e.settings, e.currency, e.created_at, e.updated_at, e.is_active
 		FROM events e
 		WHERE e.is_active = true AND e.status IN ('published', 'on_sale')`
 	
@@ -242,6 +269,12 @@ func (r *eventRepository) ListPublic(ctx context.Context, filter repositories.Pu
 		argIndex++
 	}
 	
+	if filter.TourID != nil {
+		query += fmt.Sprintf(" AND e.tour_id = $%d", argIndex)
+		args = append(args, *filter.TourID)
+		argIndex++
+	}
+	
 	if filter.Search != "" {
 		query += fmt.Sprintf(" AND (e.name ILIKE $%d OR e.description ILIKE $%d)", argIndex, argIndex)
 		searchTerm := "%" + filter.Search + "%"
@@ -249,6 +282,16 @@ func (r *eventRepository) ListPublic(ctx context.Context, filter repositories.Pu
 		argIndex++
 	}
 	
+	if filter.CategoryID != nil {
+		query += " AND " + categoryTreeCondition(fmt.Sprintf("$%d", argIndex))
+		args = append(args, *filter.CategoryID)
+		argIndex++
+	}
+	
+	if filter.GroupSeries {
+		query += seriesRepresentativeCondition
+	}
+	
 	// Count total records with same filters
 	countQuery := "SELECT COUNT(*) FROM events e WHERE e.is_active = true AND e.status IN ('published', 'on_sale')"
 	countArgs := []interface{}{}
@@ -266,6 +309,12 @@ func (r *eventRepository) ListPublic(ctx context.Context, filter repositories.Pu
 		countArgIndex++
 	}
 	
+	if filter.TourID != nil {
+		countQuery += fmt.Sprintf(" AND e.tour_id = $%d", countArgIndex)
+		countArgs = append(countArgs, *filter.TourID)
+		countArgIndex++
+	}
+	
 	if filter.Search != "" {
 		countQuery += fmt.Sprintf(" AND (e.name ILIKE $%d OR e.description ILIKE $%d)", countArgIndex, countArgIndex)
 		searchTerm := "%" + filter.Search + "%"
@@ -273,6 +322,16 @@ func (r *eventRepository) ListPublic(ctx context.Context, filter repositories.Pu
 		countArgIndex++
 	}
 	
+	if filter.CategoryID != nil {
+		countQuery += " AND " + categoryTreeCondition(fmt.Sprintf("$%d", countArgIndex))
+		countArgs = append(countArgs, *filter.CategoryID)
+		countArgIndex++
+	}
+	
+	if filter.GroupSeries {
+		countQuery += seriesRepresentativeCondition
+	}
+	
 	var total int
 	err := r.db.GetContext(ctx, &total, countQuery, countArgs...)
 	if err != nil {
@@ -302,6 +361,122 @@ func (r *eventRepository) ListPublic(ctx context.Context, filter repositories.Pu
 	return events, pagination, nil
 }
 
+// earthRadiusKm is the mean Earth radius used for haversine distances
+const earthRadiusKm = 6371.0
+
+// kmPerDegreeLatitude is the length of one degree of latitude
+const kmPerDegreeLatitude = 111.045
+
+func (r *eventRepository) ListPublicNearby(ctx context.Context, filter repositories.PublicEventFilter) ([]*repositories.NearbyEvent, *repositories.PaginationResult, error) {
+	if filter.Near == nil || filter.RadiusKm <= 0 {
+		return nil, nil, entities.NewValidationError("near", "a location and radius are required")
+	}
+	
+	lat, lng := filter.Near.Latitude, filter.Near.Longitude
+	
+	// The bounding box is a cheap indexed prefilter; the haversine distance then
+	// trims its corners and gives the exact ordering
+	conditions := []string{
+		"e.is_active = true",
+		"e.status IN ('published', 'on_sale')",
+		"e.venue_latitude IS NOT NULL",
+	}
+	args := []interface{}{lat, lng, filter.RadiusKm}
+	addArg := func(value interface{}) string {
+		args = append(args, value)
+		return fmt.Sprintf("$%d", len(args))
+	}
+	
+	deltaLat := filter.RadiusKm / kmPerDegreeLatitude
+	conditions = append(conditions, fmt.Sprintf("e.venue_latitude BETWEEN %s AND %s",
+		addArg(math.Max(lat-deltaLat, -90)), addArg(math.Min(lat+deltaLat, 90))))
+	
+	// Longitude degrees shrink towards the poles. Near a pole, or when the box
+	// would cross the antimeridian, the latitude band alone is the prefilter.
+	if cosLat := math.Cos(lat * math.Pi / 180); cosLat > 0.01 {
+		deltaLng := filter.RadiusKm / (kmPerDegreeLatitude * cosLat)
+		if lng-deltaLng >= -180 && lng+deltaLng <= 180 {
+			conditions = append(conditions, fmt.Sprintf("e.venue_longitude BETWEEN %s AND %s",
+				addArg(lng-deltaLng), addArg(lng+deltaLng)))
+		}
+	}
+	
+	if filter.City != "" {
+		conditions = append(conditions, fmt.Sprintf("e.venue_city ILIKE %s", addArg("%"+filter.City+"%")))
+	}
+	
+	if filter.Country != "" {
+		conditions = append(conditions, fmt.Sprintf("e.venue_country ILIKE %s", addArg("%"+filter.Country+"%")))
+	}
+	
+	if filter.TourID != nil {
+		conditions = append(conditions, fmt.Sprintf("e.tour_id = %s", addArg(*filter.TourID)))
+	}
+	
+	if filter.Search != "" {
+		searchArg := addArg("%" + filter.Search + "%")
+		conditions = append(conditions, fmt.Sprintf("(e.name ILIKE %s OR e.description ILIKE %s)", searchArg, searchArg))
+	}
+	
+	if filter.CategoryID != nil {
+		conditions = append(conditions, categoryTreeCondition(addArg(*filter.CategoryID)))
+	}
+	
+	if filter.EventDateFrom != nil {
+		conditions = append(conditions, fmt.Sprintf("e.event_date >= %s", addArg(*filter.EventDateFrom)))
+	}
+	
+	if filter.EventDateTo != nil {
+		conditions = append(conditions, fmt.Sprintf("e.event_date <= %s", addArg(*filter.EventDateTo)))
+	}
+	
+	inner := fmt.Sprintf(`
+		SELECT e.id, e.organizer_id, e.category_id, e.tour_id, e.series_id, e.venue_id, e.name, e.slug, e.description,
+			   e.event_date, e.doors_open, e.venue_name, e.venue_address,
+			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity, e.venue_latitude, e.venue_longitude,
+			   e.event_image_url, e.thumbnail_url, e.promo_video_url, e.gallery_images, e.status, e.sale_start, e.sale_end,
+			   e.settings, e.currency, e.created_at, e.updated_at, e.is_active,
+			   %f * 2 * asin(sqrt(
+				   power(sin(radians(e.venue_latitude - $1) / 2), 2) +
+				   cos(radians($1)) * cos(radians(e.venue_latitude)) *
+				   power(sin(radians(e.venue_longitude - $2) / 2), 2)
+			   )) AS distance_km
+		FROM events e
+		WHERE %s`, earthRadiusKm, strings.Join(conditions, " AND "))
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM (%s) nearby WHERE nearby.distance_km <= $3", inner)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count nearby events: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT * FROM (%s) nearby
+		WHERE nearby.distance_km <= $3
+		ORDER BY nearby.distance_km ASC, nearby.event_date ASC
+		LIMIT %s OFFSET %s`, inner, addArg(filter.Limit), addArg(filter.GetOffset()))
+	
+	var rows []*struct {
+		entities.Event
+		DistanceKm float64 `db:"distance_km"`
+	}
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list nearby events: %w", err)
+	}
+	
+	events := make([]*repositories.NearbyEvent, 0, len(rows))
+	for _, row := range rows {
+		event := row.Event
+		events = append(events, &repositories.NearbyEvent{
+			Event:      &event,
+			DistanceKm: row.DistanceKm,
+		})
+	}
+	
+	pagination := repositories.NewPaginationResult(filter.Page, filter.Limit, total)
+	return events, pagination, nil
+}
+
 func (r *eventRepository) GetByOrganizer(ctx context.Context, organizerID uuid.UUID, filter repositories.EventFilter) ([]*entities.Event, *repositories.PaginationResult, error) {
 	filter.OrganizerID = &organizerID
 	return r.List(ctx, filter)
@@ -312,6 +487,54 @@ func (r *eventRepository) GetByTour(ctx context.Context, tourID uuid.UUID, filte
 	return r.List(ctx, filter)
 }
 
+func (r *eventRepository) GetBySeries(ctx context.Context, seriesID uuid.UUID) ([]*entities.Event, error) {
+	var events []*entities.Event
+	query := `
+		SELECT e.id, e.organizer_id, e.category_id, e.tour_id, e.series_id, e.venue_id, e.name, e.slug, e.description,
+			   e.event_date, e.doors_open, e.venue_name, e.venue_address, 
+			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity, e.venue_latitude, e.venue_longitude,
+			   e.event_image_url, e.thumbnail_url, e.promo_video_url, e.gallery_images, e.status, e.sale_start, e.sale_end, 
+			   e.settings, e.currency, e.created_at, e.updated_at, e.is_active
+		FROM events e
+		WHERE e.series_id = $1 AND e.is_active = true
+		ORDER BY e.event_date ASC`
+	
+	if err := r.db.SelectContext(ctx, &events, query, seriesID); err != nil {
+		return nil, fmt.Errorf("failed to get events by series: %w", err)
+	}
+	
+	return events, nil
+}
+
+func (r *eventRepository) GetPublicBySeries(ctx context.Context, seriesIDs []uuid.UUID, from time.Time) ([]*entities.Event, error) {
+	var events []*entities.Event
+	if len(seriesIDs) == 0 {
+		return events, nil
+	}
+	
+	query := `
+		SELECT e.id, e.organizer_id, e.category_id, e.tour_id, e.series_id, e.venue_id, e.name, e.slug, e.description,
+			   e.event_date, e.doors_open, e.venue_name, e.venue_address, 
+			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity, e.venue_latitude, e.venue_longitude,
+			   e.event_image_url, e.thumbnail_url, e.promo_video_url, e.gallery_images, e.status, e.sale_start, e.sale_end, 
+			   e.settings, e.currency, e.created_at, e.updated_at, e.is_active
+		FROM events e
+		WHERE e.series_id = ANY($1::uuid[]) AND e.event_date >= $2
+		  AND e.is_active = true AND e.status IN ('published', 'on_sale')
+		ORDER BY e.event_date ASC`
+	
+	ids := make([]string, len(seriesIDs))
+	for i, id := range seriesIDs {
+		ids[i] = id.String()
+	}
+	
+	if err := r.db.SelectContext(ctx, &events, query, pq.Array(ids), from); err != nil {
+		return nil, fmt.Errorf("failed to get public events by series: %w", err)
+	}
+	
+	return events, nil
+}
+
 func (r *eventRepository) GetUpcoming(ctx context.Context, filter repositories.EventFilter) ([]*entities.Event, *repositories.PaginationResult, error) {
 	now := time.Now()
 	filter.EventDateFrom = &now
@@ -337,12 +560,18 @@ func (r *eventRepository) Update(ctx context.Context, event *entities.Event) err
 			description = :description,
 			event_date = :event_date,
 			doors_open = :doors_open,
+			category_id = :category_id,
+			tour_id = :tour_id,
+			series_id = :series_id,
+			venue_id = :venue_id,
 			venue_name = :venue_name,
 			venue_address = :venue_address,
 			venue_city = :venue_city,
 			venue_state = :venue_state,
 			venue_country = :venue_country,
 			venue_capacity = :venue_capacity,
+			venue_latitude = :venue_latitude,
+			venue_longitude = :venue_longitude,
 			event_image_url = :event_image_url,
 			thumbnail_url = :thumbnail_url,
 			promo_video_url = :promo_video_url,
@@ -351,6 +580,7 @@ func (r *eventRepository) Update(ctx context.Context, event *entities.Event) err
 			sale_start = :sale_start,
 			sale_end = :sale_end,
 			settings = :settings,
+			currency = :currency,
 
 			updated_at = :updated_at
 		WHERE id = :id AND is_active = true`
@@ -363,6 +593,10 @@ func (r *eventRepository) Update(ctx context.Context, event *entities.Event) err
 				if strings.Contains(pqErr.Detail, "slug") {
 					return entities.ErrConflictError
 				}
+			case "23503": // foreign_key_violation
+				if strings.Contains(pqErr.Constraint, "category") {
+					return entities.NewValidationError("category_id", "category does not exist")
+				}
 			}
 		}
 		return fmt.Errorf("failed to update event: %w", err)
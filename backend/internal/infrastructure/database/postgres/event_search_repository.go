@@ -0,0 +1,353 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// Facet dimensions; each facet is counted without its own filter applied
+const (
+	searchDimCategory = "category"
+	searchDimCity     = "city"
+	searchDimDate     = "date"
+	searchDimPrice    = "price"
+)
+
+const eventSearchFrom = `
+		FROM events e
+		LEFT JOIN tours t ON t.id = e.tour_id
+		LEFT JOIN organizers o ON o.id = e.organizer_id
+		LEFT JOIN categories c ON c.id = e.category_id
+		LEFT JOIN LATERAL (
+			SELECT MIN(tt.price) AS min_price, MAX(tt.price) AS max_price
+			FROM ticket_tiers tt
+			WHERE tt.event_id = e.id AND tt.is_active = true
+		) p ON true`
+
+type eventSearchRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	}
+}
+
+func NewEventSearchRepository(db *sqlx.DB) repositories.EventSearchRepository {
+	return &eventSearchRepository{db: db}
+}
+
+// eventSearchRow is a search hit as scanned from the database
+type eventSearchRow struct {
+	entities.Event
+	Rank          float64  `db:"rank"`
+	ArtistName    *string  `db:"artist_name"`
+	OrganizerName *string  `db:"organizer_name"`
+	CategoryName  *string  `db:"category_name"`
+	MinPrice      *float64 `db:"min_price"`
+	MaxPrice      *float64 `db:"max_price"`
+}
+
+// searchArgs collects positional query arguments
+type searchArgs []interface{}
+
+func (a *searchArgs) add(value interface{}) string {
+	*a = append(*a, value)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+func (r *eventSearchRepository) Search(ctx context.Context, filter repositories.EventSearchFilter) (*repositories.EventSearchResult, error) {
+	filter.BaseFilter.Validate()
+	tsQuery := buildPrefixTSQuery(filter.Query)
+	
+	// Count
+	var countArgs searchArgs
+	countQuery := "SELECT COUNT(*)" + eventSearchFrom + " WHERE " + searchWhere(filter, tsQuery, "", &countArgs)
+	
+	var total int
+	if err := r.db.GetContext(ctx, &total, countQuery, countArgs...); err != nil {
+		return nil, fmt.Errorf("failed to count search results: %w", err)
+	}
+	
+	// Hits
+	var args searchArgs
+	where := searchWhere(filter, tsQuery, "", &args)
+	rank := searchRank(filter, tsQuery, &args)
+	
+	query := fmt.Sprintf(`
+		SELECT e.id, e.organizer_id, e.category_id, e.tour_id, e.series_id, e.venue_id, e.name, e.slug, e.description,
+			   e.event_date, e.doors_open, e.venue_name, e.venue_address,
+			   e.venue_city, e.venue_state, e.venue_country, e.venue_capacity, e.venue_latitude, e.venue_longitude,
+			   e.event_image_url, e.thumbnail_url, e.promo_video_url, e.gallery_images, e.status, e.sale_start, e.sale_end,
+			   e.settings, e.currency, e.created_at, e.updated_at, e.is_active,
+			   %s AS rank, t.artist_name, o.name AS organizer_name, c.name AS category_name,
+			   p.min_price, p.max_price
+		%s
+		WHERE %s
+		ORDER BY %s
+		LIMIT %s OFFSET %s`,
+		rank, eventSearchFrom, where, searchOrderBy(filter),
+		args.add(filter.Limit), args.add(filter.GetOffset()))
+	
+	var rows []*eventSearchRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to search events: %w", err)
+	}
+	
+	hits := make([]*repositories.EventSearchHit, 0, len(rows))
+	for _, row := range rows {
+		event := row.Event
+		hits = append(hits, &repositories.EventSearchHit{
+			Event:         &event,
+			Rank:          row.Rank,
+			ArtistName:    row.ArtistName,
+			OrganizerName: row.OrganizerName,
+			CategoryName:  row.CategoryName,
+			MinPrice:      row.MinPrice,
+			MaxPrice:      row.MaxPrice,
+		})
+	}
+	
+	facets, err := r.searchFacets(ctx, filter, tsQuery)
+	if err != nil {
+		return nil, err
+	}
+	
+	return &repositories.EventSearchResult{
+		Hits:       hits,
+		Facets:     facets,
+		Pagination: repositories.NewPaginationResult(filter.Page, filter.Limit, total),
+	}, nil
+}
+
+func (r *eventSearchRepository) searchFacets(ctx context.Context, filter repositories.EventSearchFilter, tsQuery string) (*repositories.SearchFacets, error) {
+	facets := &repositories.SearchFacets{
+		Categories:  []repositories.FacetCount{},
+		Cities:      []repositories.FacetCount{},
+		DateBuckets: []repositories.FacetCount{},
+		PriceBands:  []repositories.FacetCount{},
+	}
+	
+	var categoryArgs searchArgs
+	categoryQuery := `
+		SELECT c.id::text AS key, c.name AS label, COUNT(*) AS count` + eventSearchFrom + `
+		WHERE c.id IS NOT NULL AND ` + searchWhere(filter, tsQuery, searchDimCategory, &categoryArgs) + `
+		GROUP BY c.id, c.name
+		ORDER BY count DESC, c.name
+		LIMIT 20`
+	if err := r.db.SelectContext(ctx, &facets.Categories, categoryQuery, categoryArgs...); err != nil {
+		return nil, fmt.Errorf("failed to count category facets: %w", err)
+	}
+	
+	var cityArgs searchArgs
+	cityQuery := `
+		SELECT lower(trim(e.venue_city)) AS key, MIN(e.venue_city) AS label, COUNT(*) AS count` + eventSearchFrom + `
+		WHERE ` + searchWhere(filter, tsQuery, searchDimCity, &cityArgs) + `
+		GROUP BY lower(trim(e.venue_city))
+		ORDER BY count DESC, key
+		LIMIT 20`
+	if err := r.db.SelectContext(ctx, &facets.Cities, cityQuery, cityArgs...); err != nil {
+		return nil, fmt.Errorf("failed to count city facets: %w", err)
+	}
+	
+	if len(filter.DateBuckets) > 0 {
+		var dateArgs searchArgs
+		values := make([]string, 0, len(filter.DateBuckets))
+		for i, bucket := range filter.DateBuckets {
+			values = append(values, fmt.Sprintf("(%d, %s::text, %s::timestamptz, %s::timestamptz)",
+				i, dateArgs.add(bucket.Key), dateArgs.add(bucket.From), dateArgs.add(bucket.To)))
+		}
+		dateQuery := fmt.Sprintf(`
+			SELECT b.key, COUNT(m.event_date) AS count
+			FROM (VALUES %s) AS b(ord, key, from_at, to_at)
+			LEFT JOIN (
+				SELECT e.event_date %s WHERE %s
+			) m ON m.event_date >= b.from_at AND m.event_date < b.to_at
+			GROUP BY b.ord, b.key
+			ORDER BY b.ord`,
+			strings.Join(values, ", "), eventSearchFrom, searchWhere(filter, tsQuery, searchDimDate, &dateArgs))
+		if err := r.db.SelectContext(ctx, &facets.DateBuckets, dateQuery, dateArgs...); err != nil {
+			return nil, fmt.Errorf("failed to count date facets: %w", err)
+		}
+	}
+	
+	if len(filter.PriceBands) > 0 {
+		var priceArgs searchArgs
+		values := make([]string, 0, len(filter.PriceBands))
+		for i, band := range filter.PriceBands {
+			values = append(values, fmt.Sprintf("(%d, %s::text, %s::numeric, %s::numeric)",
+				i, priceArgs.add(band.Key), priceArgs.add(band.Min), priceArgs.add(band.Max)))
+		}
+		// An event is counted in every band its ticket price range overlaps
+		priceQuery := fmt.Sprintf(`
+			SELECT b.key, COUNT(m.min_price) AS count
+			FROM (VALUES %s) AS b(ord, key, band_min, band_max)
+			LEFT JOIN (
+				SELECT p.min_price, p.max_price %s WHERE p.min_price IS NOT NULL AND %s
+			) m ON (b.band_min IS NULL OR m.max_price >= b.band_min)
+				AND (b.band_max IS NULL OR m.min_price < b.band_max)
+			GROUP BY b.ord, b.key
+			ORDER BY b.ord`,
+			strings.Join(values, ", "), eventSearchFrom, searchWhere(filter, tsQuery, searchDimPrice, &priceArgs))
+		if err := r.db.SelectContext(ctx, &facets.PriceBands, priceQuery, priceArgs...); err != nil {
+			return nil, fmt.Errorf("failed to count price facets: %w", err)
+		}
+	}
+	
+	return facets, nil
+}
+
+// searchWhere builds the WHERE clause for a search, leaving out the filter for
+// the facet dimension being counted
+func searchWhere(filter repositories.EventSearchFilter, tsQuery, excludeDim string, args *searchArgs) string {
+	conditions := []string{
+		"e.is_active = true",
+		"e.status IN ('published', 'on_sale')",
+		"e.event_date >= NOW()",
+	}
+	
+	if filter.Query != "" {
+		if filter.Fuzzy {
+			conditions = append(conditions, fmt.Sprintf("%s <%% e.search_text", args.add(strings.ToLower(filter.Query))))
+		} else if tsQuery != "" {
+			conditions = append(conditions, fmt.Sprintf("e.search_vector @@ to_tsquery('simple', %s)", args.add(tsQuery)))
+		}
+	}
+	
+	if filter.CategoryID != nil && excludeDim != searchDimCategory {
+		conditions = append(conditions, categoryTreeCondition(args.add(*filter.CategoryID)))
+	}
+	
+	if filter.City != "" && excludeDim != searchDimCity {
+		conditions = append(conditions, fmt.Sprintf("lower(trim(e.venue_city)) = lower(trim(%s))", args.add(filter.City)))
+	}
+	
+	if excludeDim != searchDimDate {
+		if filter.DateFrom != nil {
+			conditions = append(conditions, fmt.Sprintf("e.event_date >= %s", args.add(*filter.DateFrom)))
+		}
+		if filter.DateTo != nil {
+			conditions = append(conditions, fmt.Sprintf("e.event_date < %s", args.add(*filter.DateTo)))
+		}
+	}
+	
+	// Price filters match events whose ticket price range overlaps the requested range
+	if excludeDim != searchDimPrice {
+		if filter.PriceMin != nil {
+			conditions = append(conditions, fmt.Sprintf("p.max_price >= %s", args.add(*filter.PriceMin)))
+		}
+		if filter.PriceMax != nil {
+			conditions = append(conditions, fmt.Sprintf("p.min_price <= %s", args.add(*filter.PriceMax)))
+		}
+	}
+	
+	return strings.Join(conditions, " AND ")
+}
+
+// searchRank returns the relevance expression for a search
+func searchRank(filter repositories.EventSearchFilter, tsQuery string, args *searchArgs) string {
+	switch {
+	case filter.Query == "":
+		return "0::float8"
+	case filter.Fuzzy:
+		return fmt.Sprintf("word_similarity(%s, e.search_text)::float8", args.add(strings.ToLower(filter.Query)))
+	case tsQuery != "":
+		return fmt.Sprintf("ts_rank_cd(e.search_vector, to_tsquery('simple', %s))::float8", args.add(tsQuery))
+	}
+	return "0::float8"
+}
+
+func searchOrderBy(filter repositories.EventSearchFilter) string {
+	switch filter.SortBy {
+	case "date":
+		return "e.event_date ASC, e.id"
+	case "price_asc":
+		return "p.min_price ASC NULLS LAST, e.event_date ASC, e.id"
+	case "price_desc":
+		return "p.max_price DESC NULLS LAST, e.event_date ASC, e.id"
+	}
+	return "rank DESC, e.event_date ASC, e.id"
+}
+
+// buildPrefixTSQuery turns free text into a to_tsquery expression that matches
+// every word, treating the last word as a prefix so results follow typing.
+// Only letters and digits survive, so the result is always a valid tsquery.
+func buildPrefixTSQuery(text string) string {
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	if len(words) == 0 {
+		return ""
+	}
+	
+	words[len(words)-1] += ":*"
+	return strings.Join(words, " & ")
+}
+
+func (r *eventSearchRepository) Suggest(ctx context.Context, prefix string, limit int) ([]*repositories.SearchSuggestion, error) {
+	prefix = strings.ToLower(strings.TrimSpace(prefix))
+	if prefix == "" {
+		return []*repositories.SearchSuggestion{}, nil
+	}
+	if limit < 1 || limit > 20 {
+		limit = 10
+	}
+	
+	escaped := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`).Replace(prefix)
+	
+	// Suggestions match at the start of any word or by trigram similarity, and
+	// prefix matches always outrank fuzzy ones
+	query := `
+		WITH upcoming AS (
+			SELECT e.id, e.name, e.venue_name, e.venue_city, e.tour_id
+			FROM events e
+			WHERE e.is_active = true AND e.status IN ('published', 'on_sale') AND e.event_date >= NOW()
+		)
+		SELECT text, type, event_id, score FROM (
+			SELECT u.name AS text, 'event' AS type, u.id AS event_id,
+				   similarity(lower(u.name), $1) + CASE WHEN lower(u.name) LIKE $2 OR lower(u.name) LIKE $3 THEN 1 ELSE 0 END AS score
+			FROM upcoming u
+			WHERE lower(u.name) LIKE $2 OR lower(u.name) LIKE $3 OR lower(u.name) % $1
+	
+			UNION ALL
+	
+			SELECT MIN(t.artist_name), 'artist', NULL::uuid,
+				   MAX(similarity(lower(t.artist_name), $1) + CASE WHEN lower(t.artist_name) LIKE $2 OR lower(t.artist_name) LIKE $3 THEN 1 ELSE 0 END)
+			FROM upcoming u
+			JOIN tours t ON t.id = u.tour_id
+			WHERE lower(t.artist_name) LIKE $2 OR lower(t.artist_name) LIKE $3 OR lower(t.artist_name) % $1
+			GROUP BY lower(t.artist_name)
+	
+			UNION ALL
+	
+			SELECT MIN(u.venue_name), 'venue', NULL::uuid,
+				   MAX(similarity(lower(u.venue_name), $1) + CASE WHEN lower(u.venue_name) LIKE $2 OR lower(u.venue_name) LIKE $3 THEN 1 ELSE 0 END)
+			FROM upcoming u
+			WHERE lower(u.venue_name) LIKE $2 OR lower(u.venue_name) LIKE $3 OR lower(u.venue_name) % $1
+			GROUP BY lower(u.venue_name)
+	
+			UNION ALL
+	
+			SELECT MIN(u.venue_city), 'city', NULL::uuid,
+				   MAX(similarity(lower(u.venue_city), $1) + CASE WHEN lower(u.venue_city) LIKE $2 THEN 1 ELSE 0 END)
+			FROM upcoming u
+			WHERE lower(u.venue_city) LIKE $2 OR lower(u.venue_city) % $1
+			GROUP BY lower(u.venue_city)
+		) s
+		ORDER BY score DESC, text
+		LIMIT $4`
+	
+	suggestions := []*repositories.SearchSuggestion{}
+	err := r.db.SelectContext(ctx, &suggestions, query, prefix, escaped+"%", "% "+escaped+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search suggestions: %w", err)
+	}
+	
+	return suggestions, nil
+}
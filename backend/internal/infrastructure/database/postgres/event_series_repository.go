@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const eventSeriesSelectColumns = `id, organizer_id, template_event_id, name, rrule, timezone, starts_at,
+	exception_dates, horizon_days, generated_until, is_active, created_at, updated_at`
+
+type eventSeriesRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewEventSeriesRepository(db *sqlx.DB) repositories.EventSeriesRepository {
+	return &eventSeriesRepository{db: db}
+}
+
+func NewEventSeriesRepositoryWithTx(tx *sqlx.Tx) repositories.EventSeriesRepository {
+	return &eventSeriesRepository{db: tx}
+}
+
+func (r *eventSeriesRepository) Create(ctx context.Context, series *entities.EventSeries) error {
+	query := `
+		INSERT INTO event_series (
+			id, organizer_id, template_event_id, name, rrule, timezone, starts_at,
+			exception_dates, horizon_days, generated_until, is_active, created_at, updated_at
+		) VALUES (
+			:id, :organizer_id, :template_event_id, :name, :rrule, :timezone, :starts_at,
+			:exception_dates, :horizon_days, :generated_until, :is_active, :created_at, :updated_at
+		)`
+	
+	_, err := r.db.NamedExecContext(ctx, query, series)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			if strings.Contains(pqErr.Constraint, "organizer") {
+				return entities.ErrOrganizerNotFound
+			}
+			return entities.ErrEventNotFound
+		}
+		return fmt.Errorf("failed to create event series: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *eventSeriesRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.EventSeries, error) {
+	return r.get(ctx, fmt.Sprintf(`SELECT %s FROM event_series WHERE id = $1`, eventSeriesSelectColumns), id)
+}
+
+func (r *eventSeriesRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.EventSeries, error) {
+	return r.get(ctx, fmt.Sprintf(`SELECT %s FROM event_series WHERE id = $1 FOR UPDATE`, eventSeriesSelectColumns), id)
+}
+
+func (r *eventSeriesRepository) get(ctx context.Context, query string, id uuid.UUID) (*entities.EventSeries, error) {
+	var series entities.EventSeries
+	
+	err := r.db.GetContext(ctx, &series, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrEventSeriesNotFound
+		}
+		return nil, fmt.Errorf("failed to get event series: %w", err)
+	}
+	
+	return &series, nil
+}
+
+func (r *eventSeriesRepository) Update(ctx context.Context, series *entities.EventSeries) error {
+	query := `
+		UPDATE event_series SET
+			template_event_id = :template_event_id,
+			name = :name,
+			rrule = :rrule,
+			timezone = :timezone,
+			exception_dates = :exception_dates,
+			horizon_days = :horizon_days,
+			generated_until = :generated_until,
+			is_active = :is_active,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, series)
+	if err != nil {
+		return fmt.Errorf("failed to update event series: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrEventSeriesNotFound
+	}
+	
+	return nil
+}
+
+func (r *eventSeriesRepository) List(ctx context.Context, filter repositories.EventSeriesFilter) ([]*entities.EventSeries, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.OrganizerID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("organizer_id = $%d", argIndex))
+		args = append(args, *filter.OrganizerID)
+		argIndex++
+	}
+	
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("name ILIKE $%d", argIndex))
+		args = append(args, "%"+search+"%")
+		argIndex++
+	}
+	
+	if filter.IsActive != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("is_active = $%d", argIndex))
+		args = append(args, *filter.IsActive)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM event_series WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count event series: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM event_series
+		WHERE %s
+		ORDER BY starts_at DESC
+		LIMIT $%d OFFSET $%d`, eventSeriesSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var series []*entities.EventSeries
+	if err := r.db.SelectContext(ctx, &series, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list event series: %w", err)
+	}
+	
+	return series, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const eventTemplateSelectColumns = `id, organizer_id, name, description, content, created_by, is_active, created_at, updated_at`
+
+type eventTemplateRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewEventTemplateRepository(db *sqlx.DB) repositories.EventTemplateRepository {
+	return &eventTemplateRepository{db: db}
+}
+
+func NewEventTemplateRepositoryWithTx(tx *sqlx.Tx) repositories.EventTemplateRepository {
+	return &eventTemplateRepository{db: tx}
+}
+
+func (r *eventTemplateRepository) Create(ctx context.Context, template *entities.EventTemplate) error {
+	query := `
+		INSERT INTO event_templates (
+			id, organizer_id, name, description, content, created_by, is_active, created_at, updated_at
+		) VALUES (
+			:id, :organizer_id, :name, :description, :content, :created_by, :is_active, :created_at, :updated_at
+		)`
+	
+	_, err := r.db.NamedExecContext(ctx, query, template)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return entities.ErrOrganizerNotFound
+		}
+		return fmt.Errorf("failed to create event template: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *eventTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.EventTemplate, error) {
+	var template entities.EventTemplate
+	query := fmt.Sprintf(`SELECT %s FROM event_templates WHERE id = $1 AND is_active = true`, eventTemplateSelectColumns)
+	
+	err := r.db.GetContext(ctx, &template, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrEventTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get event template: %w", err)
+	}
+	
+	return &template, nil
+}
+
+func (r *eventTemplateRepository) Update(ctx context.Context, template *entities.EventTemplate) error {
+	query := `
+		UPDATE event_templates SET
+			name = :name,
+			description = :description,
+			content = :content,
+			updated_at = :updated_at
+		WHERE id = :id AND is_active = true`
+	
+	result, err := r.db.NamedExecContext(ctx, query, template)
+	if err != nil {
+		return fmt.Errorf("failed to update event template: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrEventTemplateNotFound
+	}
+	
+	return nil
+}
+
+func (r *eventTemplateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	query := `UPDATE event_templates SET is_active = false, updated_at = NOW() WHERE id = $1 AND is_active = true`
+	
+	result, err := r.db.ExecContext(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete event template: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrEventTemplateNotFound
+	}
+	
+	return nil
+}
+
+func (r *eventTemplateRepository) List(ctx context.Context, filter repositories.EventTemplateFilter) ([]*entities.EventTemplate, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"is_active = true"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.OrganizerID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("organizer_id = $%d", argIndex))
+		args = append(args, *filter.OrganizerID)
+		argIndex++
+	}
+	
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("(name ILIKE $%d OR description ILIKE $%d)", argIndex, argIndex))
+		args = append(args, "%"+search+"%")
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM event_templates WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count event templates: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM event_templates
+		WHERE %s
+		ORDER BY name ASC
+		LIMIT $%d OFFSET $%d`, eventTemplateSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var templates []*entities.EventTemplate
+	if err := r.db.SelectContext(ctx, &templates, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list event templates: %w", err)
+	}
+	
+	return templates, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
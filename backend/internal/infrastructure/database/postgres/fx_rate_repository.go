@@ -0,0 +1,161 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const fxRateSelectColumns = `id, base_currency, quote_currency, rate, effective_at, source, created_by, created_at`
+
+type fxRateRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewFXRateRepository(db *sqlx.DB) repositories.FXRateRepository {
+	return &fxRateRepository{db: db}
+}
+
+func NewFXRateRepositoryWithTx(tx *sqlx.Tx) repositories.FXRateRepository {
+	return &fxRateRepository{db: tx}
+}
+
+func (r *fxRateRepository) Create(ctx context.Context, rate *entities.FXRate) error {
+	query := `
+		INSERT INTO fx_rates (
+			id, base_currency, quote_currency, rate, effective_at, source, created_by, created_at
+		) VALUES (
+			:id, :base_currency, :quote_currency, :rate, :effective_at, :source, :created_by, :created_at
+		)`
+	
+	_, err := r.db.NamedExecContext(ctx, query, rate)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return entities.NewConflictError("fx_rate", "a rate for this currency pair already exists at this effective time", nil)
+		}
+		return fmt.Errorf("failed to create fx rate: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *fxRateRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.FXRate, error) {
+	var rate entities.FXRate
+	query := fmt.Sprintf(`SELECT %s FROM fx_rates WHERE id = $1`, fxRateSelectColumns)
+	
+	err := r.db.GetContext(ctx, &rate, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrFXRateNotFound
+		}
+		return nil, fmt.Errorf("failed to get fx rate: %w", err)
+	}
+	
+	return &rate, nil
+}
+
+func (r *fxRateRepository) GetEffective(ctx context.Context, baseCurrency, quoteCurrency string, at time.Time) (*entities.FXRate, error) {
+	var rate entities.FXRate
+	query := fmt.Sprintf(`
+		SELECT %s FROM fx_rates
+		WHERE base_currency = $1 AND quote_currency = $2 AND effective_at <= $3
+		ORDER BY effective_at DESC
+		LIMIT 1`, fxRateSelectColumns)
+	
+	err := r.db.GetContext(ctx, &rate, query, baseCurrency, quoteCurrency, at)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrFXRateNotFound
+		}
+		return nil, fmt.Errorf("failed to get effective fx rate: %w", err)
+	}
+	
+	return &rate, nil
+}
+
+func (r *fxRateRepository) List(ctx context.Context, filter repositories.FXRateFilter) ([]*entities.FXRate, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.BaseCurrency != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("base_currency = $%d", argIndex))
+		args = append(args, filter.BaseCurrency)
+		argIndex++
+	}
+	
+	if filter.QuoteCurrency != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("quote_currency = $%d", argIndex))
+		args = append(args, filter.QuoteCurrency)
+		argIndex++
+	}
+	
+	if filter.EffectiveFrom != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("effective_at >= $%d", argIndex))
+		args = append(args, *filter.EffectiveFrom)
+		argIndex++
+	}
+	
+	if filter.EffectiveTo != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("effective_at <= $%d", argIndex))
+		args = append(args, *filter.EffectiveTo)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM fx_rates WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count fx rates: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM fx_rates
+		WHERE %s
+		ORDER BY effective_at DESC, base_currency ASC, quote_currency ASC
+		LIMIT $%d OFFSET $%d`, fxRateSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var rates []*entities.FXRate
+	if err := r.db.SelectContext(ctx, &rates, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list fx rates: %w", err)
+	}
+	
+	return rates, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *fxRateRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM fx_rates WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete fx rate: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrFXRateNotFound
+	}
+	
+	return nil
+}
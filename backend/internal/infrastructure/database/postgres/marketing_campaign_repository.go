@@ -0,0 +1,622 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const marketingAudienceSelectColumns = `id, organizer_id, name, description, criteria, created_by, created_at, updated_at`
+
+const marketingCampaignSelectColumns = `id, organizer_id, audience_id, name, subject, message, link_url, link_label,
+	status, send_at, total_recipients, sent_count, skipped_count, failed_count, last_error, created_by,
+	recipients_staged_at, started_at, completed_at, created_at, updated_at`
+
+const marketingDeliverySelectColumns = `id, campaign_id, user_id, contact_key, name, email, status, skip_reason,
+	unsubscribe_token, attempts, last_error, sent_at, created_at, updated_at`
+
+type marketingCampaignRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewMarketingCampaignRepository(db *sqlx.DB) repositories.MarketingCampaignRepository {
+	return &marketingCampaignRepository{db: db}
+}
+
+func NewMarketingCampaignRepositoryWithTx(tx *sqlx.Tx) repositories.MarketingCampaignRepository {
+	return &marketingCampaignRepository{db: tx}
+}
+
+func (r *marketingCampaignRepository) CreateAudience(ctx context.Context, audience *entities.MarketingAudience) error {
+	query := `
+		INSERT INTO marketing_audiences (
+			id, organizer_id, name, description, criteria, created_by, created_at, updated_at
+		) VALUES (
+			:id, :organizer_id, :name, :description, :criteria, :created_by, :created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, audience); err != nil {
+		return fmt.Errorf("failed to create marketing audience: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *marketingCampaignRepository) GetAudience(ctx context.Context, id uuid.UUID) (*entities.MarketingAudience, error) {
+	var audience entities.MarketingAudience
+	query := fmt.Sprintf(`SELECT %s FROM marketing_audiences WHERE id = $1`, marketingAudienceSelectColumns)
+	
+	err := r.db.GetContext(ctx, &audience, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrMarketingAudienceNotFound
+		}
+		return nil, fmt.Errorf("failed to get marketing audience: %w", err)
+	}
+	
+	return &audience, nil
+}
+
+func (r *marketingCampaignRepository) UpdateAudience(ctx context.Context, audience *entities.MarketingAudience) error {
+	query := `
+		UPDATE marketing_audiences SET
+			name = :name,
+			description = :description,
+			criteria = :criteria,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, audience)
+	if err != nil {
+		return fmt.Errorf("failed to update marketing audience: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrMarketingAudienceNotFound
+	}
+	
+	return nil
+}
+
+func (r *marketingCampaignRepository) DeleteAudience(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM marketing_audiences WHERE id = $1`, id)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return entities.NewConflictError("marketing_audience", "the audience has campaigns and cannot be deleted", nil)
+		}
+		return fmt.Errorf("failed to delete marketing audience: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrMarketingAudienceNotFound
+	}
+	
+	return nil
+}
+
+func (r *marketingCampaignRepository) ListAudiences(ctx context.Context, filter repositories.MarketingAudienceFilter) ([]*entities.MarketingAudience, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.OrganizerID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("organizer_id = $%d", argIndex))
+		args = append(args, *filter.OrganizerID)
+		argIndex++
+	} else if filter.PlatformOnly {
+		whereConditions = append(whereConditions, "organizer_id IS NULL")
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM marketing_audiences WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count marketing audiences: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM marketing_audiences
+		WHERE %s
+		ORDER BY created_at DESC, id ASC
+		LIMIT $%d OFFSET $%d`, marketingAudienceSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var audiences []*entities.MarketingAudience
+	if err := r.db.SelectContext(ctx, &audiences, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list marketing audiences: %w", err)
+	}
+	
+	return audiences, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+// marketingAudienceMembers builds the CTEs resolving an audience to its
+// members: one row per buyer, with the contact details of their latest
+// matching order and whether their latest choice in the audience's scope is
+// a grant. $1 is always the audience's organizer; callers number their own
+// parameters after the returned ones.
+func marketingAudienceMembers(audience *entities.MarketingAudience) (string, []interface{}) {
+	criteria := audience.Criteria
+	args := []interface{}{audience.OrganizerID}
+	argIndex := 2
+	
+	matchConditions := []string{"1 = 1"}
+	
+	if len(criteria.EventIDs) > 0 {
+		matchConditions = append(matchConditions, fmt.Sprintf("p.event_id = ANY($%d::uuid[])", argIndex))
+		args = append(args, uuidArray(criteria.EventIDs))
+		argIndex++
+	}
+	
+	if len(criteria.TicketTierIDs) > 0 {
+		matchConditions = append(matchConditions, fmt.Sprintf(
+			"EXISTS (SELECT 1 FROM order_lines ol WHERE ol.order_id = p.id AND ol.ticket_tier_id = ANY($%d::uuid[]))", argIndex))
+		args = append(args, uuidArray(criteria.TicketTierIDs))
+		argIndex++
+	}
+	
+	if len(criteria.Cities) > 0 {
+		matchConditions = append(matchConditions, fmt.Sprintf("lower(p.venue_city) = ANY($%d::text[])", argIndex))
+		args = append(args, pq.StringArray(criteria.Cities))
+		argIndex++
+	}
+	
+	if criteria.PurchasedAfter != nil {
+		matchConditions = append(matchConditions, fmt.Sprintf("p.purchased_at >= $%d", argIndex))
+		args = append(args, *criteria.PurchasedAfter)
+		argIndex++
+	}
+	
+	if criteria.PurchasedBefore != nil {
+		matchConditions = append(matchConditions, fmt.Sprintf("p.purchased_at < $%d", argIndex))
+		args = append(args, *criteria.PurchasedBefore)
+		argIndex++
+	}
+	
+	// Spend counts every paid order in the scope, not just the matching ones
+	spendJoin := ""
+	if criteria.MinSpend != nil || criteria.MaxSpend != nil {
+		spendConditions := []string{"s.contact_key = l.contact_key"}
+		if criteria.MinSpend != nil {
+			spendConditions = append(spendConditions, fmt.Sprintf("s.total >= $%d", argIndex))
+			args = append(args, *criteria.MinSpend)
+			argIndex++
+		}
+		if criteria.MaxSpend != nil {
+			spendConditions = append(spendConditions, fmt.Sprintf("s.total <= $%d", argIndex))
+			args = append(args, *criteria.MaxSpend)
+			argIndex++
+		}
+		spendJoin = fmt.Sprintf(`
+			JOIN (
+				SELECT contact_key, COALESCE(SUM(total_amount) FILTER (WHERE currency = $%d), 0) AS total
+				FROM purchases
+				GROUP BY contact_key
+			) s ON %s`, argIndex, strings.Join(spendConditions, " AND "))
+		args = append(args, criteria.Currency)
+		argIndex++
+	}
+	
+	cte := fmt.Sprintf(`
+		WITH purchases AS (
+			SELECT o.id, o.user_id, o.total_amount, o.currency, e.id AS event_id, e.venue_city,
+				   COALESCE(o.paid_at, o.created_at) AS purchased_at, h.name, h.email,
+				   COALESCE('user:' || o.user_id::text, 'email:' || h.email) AS contact_key
+			FROM orders o
+			JOIN events e ON e.id = o.event_id
+			CROSS JOIN LATERAL (
+				SELECT NULLIF(TRIM(CONCAT_WS(' ', o.customer_first_name, o.customer_last_name)), '') AS name,
+					   lower(COALESCE(NULLIF(o.customer_email, ''), NULLIF(o.email, ''))) AS email
+			) h
+			WHERE o.status IN ('paid', 'confirmed')
+			  AND h.email IS NOT NULL
+			  AND ($1::uuid IS NULL OR e.organizer_id = $1::uuid)
+		),
+		latest AS (
+			SELECT DISTINCT ON (p.contact_key) p.contact_key, p.user_id, p.name, p.email
+			FROM purchases p
+			WHERE %s
+			ORDER BY p.contact_key, p.purchased_at DESC
+		),
+		members AS (
+			SELECT l.contact_key, l.user_id, l.name, l.email,
+				   COALESCE((
+					   SELECT mc.granted FROM marketing_consents mc
+					   WHERE (mc.contact_key = l.contact_key OR mc.email = l.email)
+						 AND mc.organizer_id IS NOT DISTINCT FROM $1::uuid
+					   ORDER BY mc.updated_at DESC
+					   LIMIT 1
+				   ), false) AS consented
+			FROM latest l%s
+		)`, strings.Join(matchConditions, " AND "), spendJoin)
+	
+	return cte, args
+}
+
+func uuidArray(ids []uuid.UUID) pq.StringArray {
+	values := make(pq.StringArray, len(ids))
+	for i, id := range ids {
+		values[i] = id.String()
+	}
+	return values
+}
+
+func (r *marketingCampaignRepository) CountAudience(ctx context.Context, audience *entities.MarketingAudience) (*repositories.AudienceSize, error) {
+	cte, args := marketingAudienceMembers(audience)
+	query := cte + `
+		SELECT COUNT(*) AS members, COUNT(*) FILTER (WHERE consented) AS reachable
+		FROM members`
+	
+	var size repositories.AudienceSize
+	if err := r.db.GetContext(ctx, &size, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to count marketing audience: %w", err)
+	}
+	
+	return &size, nil
+}
+
+func (r *marketingCampaignRepository) Create(ctx context.Context, campaign *entities.MarketingCampaign) error {
+	query := `
+		INSERT INTO marketing_campaigns (
+			id, organizer_id, audience_id, name, subject, message, link_url, link_label, status,
+			send_at, created_by, created_at, updated_at
+		) VALUES (
+			:id, :organizer_id, :audience_id, :name, :subject, :message, :link_url, :link_label, :status,
+			:send_at, :created_by, :created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, campaign); err != nil {
+		return fmt.Errorf("failed to create marketing campaign: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *marketingCampaignRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.MarketingCampaign, error) {
+	var campaign entities.MarketingCampaign
+	query := fmt.Sprintf(`SELECT %s FROM marketing_campaigns WHERE id = $1`, marketingCampaignSelectColumns)
+	
+	err := r.db.GetContext(ctx, &campaign, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrMarketingCampaignNotFound
+		}
+		return nil, fmt.Errorf("failed to get marketing campaign: %w", err)
+	}
+	
+	return &campaign, nil
+}
+
+func (r *marketingCampaignRepository) Update(ctx context.Context, campaign *entities.MarketingCampaign) error {
+	query := `
+		UPDATE marketing_campaigns SET
+			name = :name,
+			subject = :subject,
+			message = :message,
+			link_url = :link_url,
+			link_label = :link_label,
+			status = :status,
+			send_at = :send_at,
+			total_recipients = :total_recipients,
+			sent_count = :sent_count,
+			skipped_count = :skipped_count,
+			failed_count = :failed_count,
+			last_error = :last_error,
+			recipients_staged_at = :recipients_staged_at,
+			started_at = :started_at,
+			completed_at = :completed_at,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, campaign)
+	if err != nil {
+		return fmt.Errorf("failed to update marketing campaign: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrMarketingCampaignNotFound
+	}
+	
+	return nil
+}
+
+func (r *marketingCampaignRepository) List(ctx context.Context, filter repositories.MarketingCampaignFilter) ([]*entities.MarketingCampaign, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.OrganizerID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("organizer_id = $%d", argIndex))
+		args = append(args, *filter.OrganizerID)
+		argIndex++
+	} else if filter.PlatformOnly {
+		whereConditions = append(whereConditions, "organizer_id IS NULL")
+	}
+	
+	if filter.AudienceID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("audience_id = $%d", argIndex))
+		args = append(args, *filter.AudienceID)
+		argIndex++
+	}
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM marketing_campaigns WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count marketing campaigns: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM marketing_campaigns
+		WHERE %s
+		ORDER BY send_at DESC, id ASC
+		LIMIT $%d OFFSET $%d`, marketingCampaignSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var campaigns []*entities.MarketingCampaign
+	if err := r.db.SelectContext(ctx, &campaigns, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list marketing campaigns: %w", err)
+	}
+	
+	return campaigns, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *marketingCampaignRepository) GetDue(ctx context.Context, staleBefore time.Time, limit int) ([]*entities.MarketingCampaign, error) {
+	var campaigns []*entities.MarketingCampaign
+	query := fmt.Sprintf(`
+		SELECT %s FROM marketing_campaigns
+		WHERE (status = 'scheduled' AND send_at <= NOW())
+		   OR (status IN ('processing', 'failed') AND updated_at < $1)
+		ORDER BY send_at ASC
+		LIMIT $2`, marketingCampaignSelectColumns)
+	
+	if err := r.db.SelectContext(ctx, &campaigns, query, staleBefore, limit); err != nil {
+		return nil, fmt.Errorf("failed to get due marketing campaigns: %w", err)
+	}
+	
+	return campaigns, nil
+}
+
+func (r *marketingCampaignRepository) Claim(ctx context.Context, id uuid.UUID, staleBefore time.Time) (bool, error) {
+	query := `
+		UPDATE marketing_campaigns SET
+			status = 'processing',
+			started_at = COALESCE(started_at, NOW()),
+			last_error = NULL,
+			updated_at = NOW()
+		WHERE id = $1
+		  AND ((status = 'scheduled' AND send_at <= NOW())
+		       OR (status IN ('processing', 'failed') AND updated_at < $2))`
+	
+	result, err := r.db.ExecContext(ctx, query, id, staleBefore)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim marketing campaign: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+func (r *marketingCampaignRepository) StageDeliveries(ctx context.Context, campaign *entities.MarketingCampaign, audience *entities.MarketingAudience) (int, error) {
+	// Members who never consented, or withdrew, are left out entirely rather
+	// than recorded as skipped: the campaign has no business with them
+	cte, args := marketingAudienceMembers(audience)
+	query := fmt.Sprintf(`%s
+		INSERT INTO marketing_deliveries (
+			id, campaign_id, user_id, contact_key, name, email, status, unsubscribe_token,
+			attempts, created_at, updated_at
+		)
+		SELECT gen_random_uuid(), $%d, m.user_id, m.contact_key, m.name, m.email, 'pending',
+			   replace(gen_random_uuid()::text || gen_random_uuid()::text, '-', ''),
+			   0, NOW(), NOW()
+		FROM members m
+		WHERE m.consented
+		ON CONFLICT (campaign_id, contact_key) DO NOTHING`, cte, len(args)+1)
+	args = append(args, campaign.ID)
+	
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to stage marketing deliveries: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return int(rowsAffected), nil
+}
+
+func (r *marketingCampaignRepository) GetPendingDeliveries(ctx context.Context, campaignID uuid.UUID, limit int) ([]*entities.MarketingDelivery, error) {
+	var deliveries []*entities.MarketingDelivery
+	query := fmt.Sprintf(`
+		SELECT %s FROM marketing_deliveries
+		WHERE campaign_id = $1 AND status = 'pending'
+		ORDER BY created_at ASC, id ASC
+		LIMIT $2`, marketingDeliverySelectColumns)
+	
+	if err := r.db.SelectContext(ctx, &deliveries, query, campaignID, limit); err != nil {
+		return nil, fmt.Errorf("failed to get pending marketing deliveries: %w", err)
+	}
+	
+	return deliveries, nil
+}
+
+func (r *marketingCampaignRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*entities.MarketingDelivery, error) {
+	var delivery entities.MarketingDelivery
+	query := fmt.Sprintf(`SELECT %s FROM marketing_deliveries WHERE id = $1`, marketingDeliverySelectColumns)
+	
+	err := r.db.GetContext(ctx, &delivery, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrMarketingDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get marketing delivery: %w", err)
+	}
+	
+	return &delivery, nil
+}
+
+func (r *marketingCampaignRepository) GetDeliveryByToken(ctx context.Context, token string) (*entities.MarketingDelivery, error) {
+	var delivery entities.MarketingDelivery
+	query := fmt.Sprintf(`SELECT %s FROM marketing_deliveries WHERE unsubscribe_token = $1`, marketingDeliverySelectColumns)
+	
+	err := r.db.GetContext(ctx, &delivery, query, token)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrMarketingDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get marketing delivery: %w", err)
+	}
+	
+	return &delivery, nil
+}
+
+func (r *marketingCampaignRepository) UpdateDelivery(ctx context.Context, delivery *entities.MarketingDelivery) error {
+	query := `
+		UPDATE marketing_deliveries SET
+			status = :status,
+			skip_reason = :skip_reason,
+			attempts = :attempts,
+			last_error = :last_error,
+			sent_at = :sent_at,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, delivery)
+	if err != nil {
+		return fmt.Errorf("failed to update marketing delivery: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrMarketingDeliveryNotFound
+	}
+	
+	return nil
+}
+
+func (r *marketingCampaignRepository) SkipPendingDeliveries(ctx context.Context, campaignID uuid.UUID, reason string) (int, error) {
+	query := `
+		UPDATE marketing_deliveries SET status = 'skipped', skip_reason = $2, updated_at = NOW()
+		WHERE campaign_id = $1 AND status = 'pending'`
+	
+	result, err := r.db.ExecContext(ctx, query, campaignID, reason)
+	if err != nil {
+		return 0, fmt.Errorf("failed to skip pending marketing deliveries: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return int(rowsAffected), nil
+}
+
+func (r *marketingCampaignRepository) ListDeliveries(ctx context.Context, filter repositories.MarketingDeliveryFilter) ([]*entities.MarketingDelivery, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"campaign_id = $1"}
+	args := []interface{}{filter.CampaignID}
+	argIndex := 2
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM marketing_deliveries WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count marketing deliveries: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM marketing_deliveries
+		WHERE %s
+		ORDER BY created_at ASC, id ASC
+		LIMIT $%d OFFSET $%d`, marketingDeliverySelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var deliveries []*entities.MarketingDelivery
+	if err := r.db.SelectContext(ctx, &deliveries, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list marketing deliveries: %w", err)
+	}
+	
+	return deliveries, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *marketingCampaignRepository) CountDeliveries(ctx context.Context, campaignID uuid.UUID) (*repositories.CampaignDeliveryCounts, error) {
+	var counts repositories.CampaignDeliveryCounts
+	query := `
+		SELECT COUNT(*) as total,
+			   COUNT(*) FILTER (WHERE status = 'pending') as pending,
+			   COUNT(*) FILTER (WHERE status = 'sent') as sent,
+			   COUNT(*) FILTER (WHERE status = 'skipped') as skipped,
+			   COUNT(*) FILTER (WHERE status = 'failed') as failed
+		FROM marketing_deliveries
+		WHERE campaign_id = $1`
+	
+	if err := r.db.GetContext(ctx, &counts, query, campaignID); err != nil {
+		return nil, fmt.Errorf("failed to count marketing deliveries: %w", err)
+	}
+	
+	return &counts, nil
+}
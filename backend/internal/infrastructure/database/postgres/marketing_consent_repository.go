@@ -0,0 +1,184 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const marketingConsentSelectColumns = `id, contact_key, user_id, email, organizer_id, granted, source,
+	policy_version, granted_at, withdrawn_at, created_at, updated_at`
+
+const marketingConsentRecordSelectColumns = `id, contact_key, user_id, email, organizer_id, granted, source,
+	policy_version, ip_address, user_agent, order_id, created_at`
+
+type marketingConsentRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	}
+}
+
+func NewMarketingConsentRepository(db *sqlx.DB) repositories.MarketingConsentRepository {
+	return &marketingConsentRepository{db: db}
+}
+
+func NewMarketingConsentRepositoryWithTx(tx *sqlx.Tx) repositories.MarketingConsentRepository {
+	return &marketingConsentRepository{db: tx}
+}
+
+func (r *marketingConsentRepository) Record(ctx context.Context, record *entities.MarketingConsentRecord) (*entities.MarketingConsent, error) {
+	// The audit row and the current choice are written by one statement, so
+	// neither can exist without the other
+	query := fmt.Sprintf(`
+		WITH record AS (
+			INSERT INTO marketing_consent_records (
+				id, contact_key, user_id, email, organizer_id, granted, source, policy_version,
+				ip_address, user_agent, order_id, created_at
+			) VALUES ($1, $2, $3, $4, $5, $6::boolean, $7, $8, $9, $10, $11, $12)
+		)
+		INSERT INTO marketing_consents (
+			id, contact_key, user_id, email, organizer_id, granted, source, policy_version,
+			granted_at, withdrawn_at, created_at, updated_at
+		) VALUES (
+			uuid_generate_v4(), $2, $3, $4, $5, $6::boolean, $7, $8,
+			CASE WHEN $6::boolean THEN $12::timestamptz END,
+			CASE WHEN NOT $6::boolean THEN $12::timestamptz END,
+			$12, $12
+		)
+		ON CONFLICT (contact_key, (COALESCE(organizer_id, '00000000-0000-0000-0000-000000000000'::uuid))) DO UPDATE SET
+			user_id = COALESCE(EXCLUDED.user_id, marketing_consents.user_id),
+			email = COALESCE(EXCLUDED.email, marketing_consents.email),
+			granted = EXCLUDED.granted,
+			source = EXCLUDED.source,
+			policy_version = EXCLUDED.policy_version,
+			granted_at = COALESCE(EXCLUDED.granted_at, marketing_consents.granted_at),
+			withdrawn_at = CASE WHEN EXCLUDED.granted THEN NULL ELSE EXCLUDED.withdrawn_at END,
+			updated_at = EXCLUDED.updated_at
+		RETURNING %s`, marketingConsentSelectColumns)
+	
+	var consent entities.MarketingConsent
+	err := r.db.GetContext(ctx, &consent, query,
+		record.ID, record.ContactKey, record.UserID, record.Email, record.OrganizerID, record.Granted,
+		record.Source, record.PolicyVersion, record.IPAddress, record.UserAgent, record.OrderID, record.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to record marketing consent: %w", err)
+	}
+	
+	return &consent, nil
+}
+
+func (r *marketingConsentRepository) ListConsents(ctx context.Context, contactKey string) ([]*entities.MarketingConsent, error) {
+	consents := []*entities.MarketingConsent{}
+	query := fmt.Sprintf(`
+		SELECT %s FROM marketing_consents
+		WHERE contact_key = $1
+		ORDER BY organizer_id NULLS FIRST, created_at ASC`, marketingConsentSelectColumns)
+	
+	if err := r.db.SelectContext(ctx, &consents, query, contactKey); err != nil {
+		return nil, fmt.Errorf("failed to list marketing consents: %w", err)
+	}
+	
+	return consents, nil
+}
+
+func (r *marketingConsentRepository) HasConsent(ctx context.Context, contactKey string, email *string, organizerID *uuid.UUID) (bool, error) {
+	query := `
+		SELECT COALESCE((
+			SELECT granted FROM marketing_consents
+			WHERE (contact_key = $1 OR (CAST($2 AS TEXT) IS NOT NULL AND email = lower($2)))
+			  AND organizer_id IS NOT DISTINCT FROM $3
+			ORDER BY updated_at DESC
+			LIMIT 1
+		), false)`
+	
+	var granted bool
+	if err := r.db.GetContext(ctx, &granted, query, contactKey, email, organizerID); err != nil {
+		return false, fmt.Errorf("failed to check marketing consent: %w", err)
+	}
+	
+	return granted, nil
+}
+
+func (r *marketingConsentRepository) ListRecords(ctx context.Context, filter repositories.MarketingConsentRecordFilter) ([]*entities.MarketingConsentRecord, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.ContactKey != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("contact_key = $%d", argIndex))
+		args = append(args, *filter.ContactKey)
+		argIndex++
+	}
+	
+	if filter.UserID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("user_id = $%d", argIndex))
+		args = append(args, *filter.UserID)
+		argIndex++
+	}
+	
+	if filter.Email != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("email = lower($%d)", argIndex))
+		args = append(args, strings.TrimSpace(*filter.Email))
+		argIndex++
+	}
+	
+	if filter.OrganizerID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("organizer_id = $%d", argIndex))
+		args = append(args, *filter.OrganizerID)
+		argIndex++
+	} else if filter.PlatformOnly {
+		whereConditions = append(whereConditions, "organizer_id IS NULL")
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM marketing_consent_records WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count marketing consent records: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM marketing_consent_records
+		WHERE %s
+		ORDER BY created_at DESC, id ASC
+		LIMIT $%d OFFSET $%d`, marketingConsentRecordSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	records := []*entities.MarketingConsentRecord{}
+	if err := r.db.SelectContext(ctx, &records, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list marketing consent records: %w", err)
+	}
+	
+	return records, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *marketingConsentRepository) ListUserOrganizers(ctx context.Context, userID uuid.UUID) ([]*repositories.ConsentOrganizer, error) {
+	query := `
+		SELECT DISTINCT org.id, org.name
+		FROM orders o
+		JOIN events e ON e.id = o.event_id
+		JOIN organizers org ON org.id = e.organizer_id
+		WHERE o.user_id = $1 AND o.status IN ('paid', 'confirmed')
+		ORDER BY org.name ASC`
+	
+	organizers := []*repositories.ConsentOrganizer{}
+	if err := r.db.SelectContext(ctx, &organizers, query, userID); err != nil {
+		return nil, fmt.Errorf("failed to list organizers for user: %w", err)
+	}
+	
+	return organizers, nil
+}
@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const messageTemplateSelectColumns = `id, key, channel, locale, organizer_id, version, subject, html_body, text_body,
+	is_active, created_by, created_at, updated_at`
+
+type messageTemplateRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewMessageTemplateRepository(db *sqlx.DB) repositories.MessageTemplateRepository {
+	return &messageTemplateRepository{db: db}
+}
+
+func NewMessageTemplateRepositoryWithTx(tx *sqlx.Tx) repositories.MessageTemplateRepository {
+	return &messageTemplateRepository{db: tx}
+}
+
+func (r *messageTemplateRepository) Create(ctx context.Context, template *entities.MessageTemplate) error {
+	versionQuery := `
+		SELECT COALESCE(MAX(version), 0) + 1 FROM message_templates
+		WHERE key = $1 AND channel = $2 AND locale = $3 AND organizer_id IS NOT DISTINCT FROM $4`
+	
+	var version int
+	if err := r.db.GetContext(ctx, &version, versionQuery, template.Key, template.Channel, template.Locale, template.OrganizerID); err != nil {
+		return fmt.Errorf("failed to get next message template version: %w", err)
+	}
+	template.Version = version
+	
+	query := `
+		INSERT INTO message_templates (
+			id, key, channel, locale, organizer_id, version, subject, html_body, text_body,
+			is_active, created_by, created_at, updated_at
+		) VALUES (
+			:id, :key, :channel, :locale, :organizer_id, :version, :subject, :html_body, :text_body,
+			:is_active, :created_by, :created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, template); err != nil {
+		return fmt.Errorf("failed to create message template: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *messageTemplateRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.MessageTemplate, error) {
+	var template entities.MessageTemplate
+	query := fmt.Sprintf(`SELECT %s FROM message_templates WHERE id = $1`, messageTemplateSelectColumns)
+	
+	err := r.db.GetContext(ctx, &template, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrMessageTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get message template: %w", err)
+	}
+	
+	return &template, nil
+}
+
+func (r *messageTemplateRepository) GetActive(ctx context.Context, key string, channel entities.NotificationChannel, locale string, organizerID *uuid.UUID) (*entities.MessageTemplate, error) {
+	var template entities.MessageTemplate
+	query := fmt.Sprintf(`
+		SELECT %s FROM message_templates
+		WHERE key = $1 AND channel = $2 AND locale = $3 AND organizer_id IS NOT DISTINCT FROM $4 AND is_active = true`,
+		messageTemplateSelectColumns)
+	
+	err := r.db.GetContext(ctx, &template, query, key, channel, locale, organizerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrMessageTemplateNotFound
+		}
+		return nil, fmt.Errorf("failed to get active message template: %w", err)
+	}
+	
+	return &template, nil
+}
+
+func (r *messageTemplateRepository) Update(ctx context.Context, template *entities.MessageTemplate) error {
+	query := `
+		UPDATE message_templates SET
+			is_active = :is_active,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, template)
+	if err != nil {
+		return fmt.Errorf("failed to update message template: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrMessageTemplateNotFound
+	}
+	
+	return nil
+}
+
+func (r *messageTemplateRepository) DeactivateOthers(ctx context.Context, template *entities.MessageTemplate) error {
+	query := `
+		UPDATE message_templates SET is_active = false, updated_at = NOW()
+		WHERE key = $1 AND channel = $2 AND locale = $3 AND organizer_id IS NOT DISTINCT FROM $4
+			AND is_active = true AND id <> $5`
+	
+	if _, err := r.db.ExecContext(ctx, query, template.Key, template.Channel, template.Locale, template.OrganizerID, template.ID); err != nil {
+		return fmt.Errorf("failed to deactivate message templates: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *messageTemplateRepository) List(ctx context.Context, filter repositories.MessageTemplateFilter) ([]*entities.MessageTemplate, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.Key != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("key = $%d", argIndex))
+		args = append(args, filter.Key)
+		argIndex++
+	}
+	
+	if filter.Channel != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("channel = $%d", argIndex))
+		args = append(args, *filter.Channel)
+		argIndex++
+	}
+	
+	if filter.Locale != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("locale = $%d", argIndex))
+		args = append(args, filter.Locale)
+		argIndex++
+	}
+	
+	if filter.OrganizerID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("organizer_id = $%d", argIndex))
+		args = append(args, *filter.OrganizerID)
+		argIndex++
+	} else if filter.GlobalOnly {
+		whereConditions = append(whereConditions, "organizer_id IS NULL")
+	}
+	
+	if filter.ActiveOnly {
+		whereConditions = append(whereConditions, "is_active = true")
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM message_templates WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count message templates: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM message_templates
+		WHERE %s
+		ORDER BY key, channel, locale, created_at DESC
+		LIMIT $%d OFFSET $%d`, messageTemplateSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	templates := []*entities.MessageTemplate{}
+	if err := r.db.SelectContext(ctx, &templates, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list message templates: %w", err)
+	}
+	
+	return templates, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *messageTemplateRepository) GetBranding(ctx context.Context, organizerID uuid.UUID) (*entities.OrganizerBranding, error) {
+	var branding entities.OrganizerBranding
+	query := `
+		SELECT organizer_id, display_name, logo_url, primary_color, footer_text, support_email, created_at, updated_at
+		FROM organizer_brandings
+		WHERE organizer_id = $1`
+	
+	err := r.db.GetContext(ctx, &branding, query, organizerID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrOrganizerBrandingNotFound
+		}
+		return nil, fmt.Errorf("failed to get organizer branding: %w", err)
+	}
+	
+	return &branding, nil
+}
+
+func (r *messageTemplateRepository) SaveBranding(ctx context.Context, branding *entities.OrganizerBranding) error {
+	query := `
+		INSERT INTO organizer_brandings (
+			organizer_id, display_name, logo_url, primary_color, footer_text, support_email, created_at, updated_at
+		) VALUES (
+			:organizer_id, :display_name, :logo_url, :primary_color, :footer_text, :support_email, :created_at, :updated_at
+		)
+		ON CONFLICT (organizer_id) DO UPDATE SET
+			display_name = EXCLUDED.display_name,
+			logo_url = EXCLUDED.logo_url,
+			primary_color = EXCLUDED.primary_color,
+			footer_text = EXCLUDED.footer_text,
+			support_email = EXCLUDED.support_email,
+			updated_at = EXCLUDED.updated_at`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, branding); err != nil {
+		return fmt.Errorf("failed to save organizer branding: %w", err)
+	}
+	
+	return nil
+}
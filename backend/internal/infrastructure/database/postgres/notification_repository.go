@@ -0,0 +1,228 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const notificationSelectColumns = `id, channel, recipient, sender_id, template, body, status, provider,
+	provider_message_id, last_error, user_id, order_id, sent_at, delivered_at, failed_at, created_at, updated_at`
+
+type notificationRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewNotificationRepository(db *sqlx.DB) repositories.NotificationRepository {
+	return &notificationRepository{db: db}
+}
+
+func NewNotificationRepositoryWithTx(tx *sqlx.Tx) repositories.NotificationRepository {
+	return &notificationRepository{db: tx}
+}
+
+func (r *notificationRepository) Create(ctx context.Context, message *entities.NotificationMessage) error {
+	query := `
+		INSERT INTO notification_messages (
+			id, channel, recipient, sender_id, template, body, status, provider,
+			provider_message_id, last_error, user_id, order_id, created_at, updated_at
+		) VALUES (
+			:id, :channel, :recipient, :sender_id, :template, :body, :status, :provider,
+			:provider_message_id, :last_error, :user_id, :order_id, :created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, message); err != nil {
+		return fmt.Errorf("failed to create notification message: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *notificationRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.NotificationMessage, error) {
+	var message entities.NotificationMessage
+	query := fmt.Sprintf(`SELECT %s FROM notification_messages WHERE id = $1`, notificationSelectColumns)
+	
+	err := r.db.GetContext(ctx, &message, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification message: %w", err)
+	}
+	
+	return &message, nil
+}
+
+func (r *notificationRepository) GetByProviderMessageID(ctx context.Context, provider, providerMessageID string) (*entities.NotificationMessage, error) {
+	var message entities.NotificationMessage
+	query := fmt.Sprintf(`
+		SELECT %s FROM notification_messages
+		WHERE provider = $1 AND provider_message_id = $2`, notificationSelectColumns)
+	
+	err := r.db.GetContext(ctx, &message, query, provider, providerMessageID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrNotificationNotFound
+		}
+		return nil, fmt.Errorf("failed to get notification message by provider ID: %w", err)
+	}
+	
+	return &message, nil
+}
+
+func (r *notificationRepository) Update(ctx context.Context, message *entities.NotificationMessage) error {
+	query := `
+		UPDATE notification_messages SET
+			status = :status,
+			provider = :provider,
+			provider_message_id = :provider_message_id,
+			last_error = :last_error,
+			sent_at = :sent_at,
+			delivered_at = :delivered_at,
+			failed_at = :failed_at,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, message)
+	if err != nil {
+		return fmt.Errorf("failed to update notification message: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrNotificationNotFound
+	}
+	
+	return nil
+}
+
+func (r *notificationRepository) CountSince(ctx context.Context, channel entities.NotificationChannel, recipient string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*) FROM notification_messages
+		WHERE channel = $1 AND recipient = $2 AND created_at >= $3`
+	
+	var count int
+	if err := r.db.GetContext(ctx, &count, query, channel, recipient, since); err != nil {
+		return 0, fmt.Errorf("failed to count notification messages: %w", err)
+	}
+	
+	return count, nil
+}
+
+func (r *notificationRepository) List(ctx context.Context, filter repositories.NotificationFilter) ([]*entities.NotificationMessage, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.Channel != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("channel = $%d", argIndex))
+		args = append(args, *filter.Channel)
+		argIndex++
+	}
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	if filter.Recipient != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("recipient = $%d", argIndex))
+		args = append(args, filter.Recipient)
+		argIndex++
+	}
+	
+	if filter.OrderID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("order_id = $%d", argIndex))
+		args = append(args, *filter.OrderID)
+		argIndex++
+	}
+	
+	if filter.Template != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("template = $%d", argIndex))
+		args = append(args, filter.Template)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM notification_messages WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count notification messages: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM notification_messages
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, notificationSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	messages := []*entities.NotificationMessage{}
+	if err := r.db.SelectContext(ctx, &messages, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list notification messages: %w", err)
+	}
+	
+	return messages, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *notificationRepository) GetWhatsAppOptIn(ctx context.Context, userID uuid.UUID) (*entities.WhatsAppOptIn, error) {
+	var optIn entities.WhatsAppOptIn
+	query := `
+		SELECT user_id, phone, opted_in, source, opted_in_at, opted_out_at, created_at, updated_at
+		FROM whatsapp_opt_ins
+		WHERE user_id = $1`
+	
+	err := r.db.GetContext(ctx, &optIn, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrWhatsAppOptInNotFound
+		}
+		return nil, fmt.Errorf("failed to get whatsapp opt-in: %w", err)
+	}
+	
+	return &optIn, nil
+}
+
+func (r *notificationRepository) SaveWhatsAppOptIn(ctx context.Context, optIn *entities.WhatsAppOptIn) error {
+	query := `
+		INSERT INTO whatsapp_opt_ins (
+			user_id, phone, opted_in, source, opted_in_at, opted_out_at, created_at, updated_at
+		) VALUES (
+			:user_id, :phone, :opted_in, :source, :opted_in_at, :opted_out_at, :created_at, :updated_at
+		)
+		ON CONFLICT (user_id) DO UPDATE SET
+			phone = EXCLUDED.phone,
+			opted_in = EXCLUDED.opted_in,
+			source = EXCLUDED.source,
+			opted_in_at = EXCLUDED.opted_in_at,
+			opted_out_at = EXCLUDED.opted_out_at,
+			updated_at = EXCLUDED.updated_at`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, optIn); err != nil {
+		return fmt.Errorf("failed to save whatsapp opt-in: %w", err)
+	}
+	
+	return nil
+}
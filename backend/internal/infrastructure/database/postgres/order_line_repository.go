@@ -34,10 +34,12 @@ func (r *orderLineRepository) Create(ctx context.Context, orderLine *entities.Or
 	query := `
 		INSERT INTO order_lines (
 			id, order_id, ticket_tier_id, quantity, unit_price, 
-			subtotal, total_price, fees, taxes, discount_amount, created_at, updated_at
+			subtotal, total_price, fees, taxes, discount_amount,
+			base_price, quoted_price, quote_expires_at, created_at, updated_at
 		) VALUES (
 			:id, :order_id, :ticket_tier_id, :quantity, :unit_price,
-			:subtotal, :total_price, :fees, :taxes, :discount_amount, :created_at, :updated_at
+			:subtotal, :total_price, :fees, :taxes, :discount_amount,
+			:base_price, :quoted_price, :quote_expires_at, :created_at, :updated_at
 		)`
 	
 	_, err := r.db.NamedExecContext(ctx, query, orderLine)
@@ -65,7 +67,8 @@ func (r *orderLineRepository) GetByID(ctx context.Context, id uuid.UUID) (*entit
 	var orderLine entities.OrderLine
 	query := `
 		SELECT ol.id, ol.order_id, ol.ticket_tier_id, ol.quantity, 
-			   ol.unit_price, ol.subtotal, ol.created_at,
+			   ol.unit_price, ol.subtotal, ol.base_price, ol.quoted_price,
+			   ol.quote_expires_at, ol.created_at,
 			   tt.name as ticket_tier_name, tt.description as ticket_tier_description,
 			   e.name as event_title, e.slug as event_slug
 		FROM order_lines ol
@@ -88,7 +91,8 @@ func (r *orderLineRepository) GetByOrder(ctx context.Context, orderID uuid.UUID)
 	var orderLines []*entities.OrderLine
 	query := `
 		SELECT ol.id, ol.order_id, ol.ticket_tier_id, ol.quantity, 
-			   ol.unit_price, ol.subtotal, ol.created_at,
+			   ol.unit_price, ol.subtotal, ol.base_price, ol.quoted_price,
+			   ol.quote_expires_at, ol.created_at,
 			   tt.name as ticket_tier_name, tt.description as ticket_tier_description,
 			   e.name as event_title, e.slug as event_slug
 		FROM order_lines ol
@@ -332,7 +336,8 @@ func (r *orderLineRepository) List(ctx context.Context, filter repositories.Orde
 	offset := (filter.Page - 1) * filter.Limit
 	query := fmt.Sprintf(`
 		SELECT ol.id, ol.order_id, ol.ticket_tier_id, ol.quantity, 
-			   ol.unit_price, ol.subtotal, ol.created_at,
+			   ol.unit_price, ol.subtotal, ol.base_price, ol.quoted_price,
+			   ol.quote_expires_at, ol.created_at,
 			   tt.name as ticket_tier_name, tt.description as ticket_tier_description,
 			   e.name as event_title, e.slug as event_slug
 		FROM order_lines ol
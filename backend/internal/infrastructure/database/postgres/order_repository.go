@@ -61,12 +61,12 @@ func NewOrderRepositoryWithTx(tx *sqlx.Tx) repositories.OrderRepository {
 			id, user_id, event_id, code, secret, status, total_amount, 
 			currency, email, phone, first_name, last_name,
 			customer_email, customer_phone, customer_first_name, 
-			customer_last_name, expires_at, created_at, updated_at
+			customer_last_name, expires_at, is_comp, is_imported, external_reference, created_at, updated_at
 		) VALUES (
 			:id, :user_id, :event_id, :code, :secret, :status, :total_amount,
 			:currency, :email, :phone, :first_name, :last_name,
 			:customer_email, :customer_phone, :customer_first_name,
-			:customer_last_name, :expires_at, :created_at, :updated_at
+			:customer_last_name, :expires_at, :is_comp, :is_imported, :external_reference, :created_at, :updated_at
 		)`
 	
 		_, err := tx.NamedExecContext(ctx, orderQuery, order)
@@ -77,6 +77,9 @@ func NewOrderRepositoryWithTx(tx *sqlx.Tx) repositories.OrderRepository {
 				if strings.Contains(pqErr.Detail, "code") {
 					return entities.ErrOrderCodeExists
 				}
+				if strings.Contains(pqErr.Detail, "external_reference") {
+					return entities.ErrOrderExternalReferenceExists
+				}
 			}
 		}
 		return fmt.Errorf("failed to create order: %w", err)
@@ -120,7 +123,7 @@ func (r *orderRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.
 			   o.total_amount, o.currency, o.customer_email, o.customer_phone,
 			   o.customer_first_name, o.customer_last_name, o.payment_method,
 			   o.payment_reference, o.paid_at, o.expires_at, o.created_at, o.updated_at,
-			   o.is_active
+			   o.is_active, o.is_comp, o.is_imported, o.external_reference
 		FROM orders o
 		WHERE o.id = $1 AND o.is_active = true`
 	
@@ -163,7 +166,7 @@ func (r *orderRepository) GetByCode(ctx context.Context, code string) (*entities
 			   o.total_amount, o.currency, o.customer_email, o.customer_phone,
 			   o.customer_first_name, o.customer_last_name, o.payment_method,
 			   o.payment_reference, o.paid_at, o.expires_at, o.created_at, o.updated_at,
-			   o.is_active
+			   o.is_active, o.is_comp, o.is_imported, o.external_reference
 		FROM orders o
 		WHERE o.code = $1 AND o.is_active = true`
 	
@@ -541,13 +544,13 @@ func (r *orderRepository) GetOrderStats(ctx context.Context, orderID uuid.UUID)
 		SELECT 
 			$1 as event_id,
 			COALESCE(COUNT(o.id), 0) as total_orders,
-			COALESCE(COUNT(CASE WHEN o.status = 'paid' THEN 1 END), 0) as paid_orders,
+			COALESCE(COUNT(CASE WHEN o.status = 'paid' AND NOT o.is_comp AND NOT o.is_imported THEN 1 END), 0) as paid_orders,
 			COALESCE(COUNT(CASE WHEN o.status = 'pending' THEN 1 END), 0) as pending_orders,
 			COALESCE(COUNT(CASE WHEN o.status = 'expired' THEN 1 END), 0) as expired_orders,
 			COALESCE(COUNT(CASE WHEN o.status = 'cancelled' THEN 1 END), 0) as cancelled_orders,
 			COALESCE(COUNT(CASE WHEN o.status = 'refunded' THEN 1 END), 0) as refunded_orders,
-			COALESCE(SUM(CASE WHEN o.status = 'paid' THEN o.total_amount ELSE 0 END), 0) as total_revenue,
-			COALESCE(AVG(CASE WHEN o.status = 'paid' THEN o.total_amount END), 0) as average_order_value,
+			COALESCE(SUM(CASE WHEN o.status = 'paid' AND NOT o.is_imported THEN o.total_amount ELSE 0 END), 0) as total_revenue,
+			COALESCE(AVG(CASE WHEN o.status = 'paid' AND NOT o.is_comp AND NOT o.is_imported THEN o.total_amount END), 0) as average_order_value,
 			COALESCE(SUM(ol.quantity), 0) as total_tickets_sold,
 			MIN(CASE WHEN o.status = 'paid' THEN o.created_at END) as first_sale_at,
 			MAX(CASE WHEN o.status = 'paid' THEN o.created_at END) as last_sale_at
@@ -565,6 +568,65 @@ func (r *orderRepository) GetOrderStats(ctx context.Context, orderID uuid.UUID)
 	return &stats, nil
 }
 
+func (r *orderRepository) GetRevenueByCurrency(ctx context.Context, filter repositories.RevenueFilter) ([]*repositories.CurrencyRevenue, error) {
+	// Comps are paid orders with no revenue and imported orders were paid on
+	// another platform; leave both out of settlement figures
+	whereConditions := []string{"o.is_active = true", "o.status IN ('paid', 'confirmed')", "o.is_comp = false", "o.is_imported = false"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.EventID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("o.event_id = $%d", argIndex))
+		args = append(args, *filter.EventID)
+		argIndex++
+	}
+	
+	if filter.OrganizerID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("o.event_id IN (SELECT id FROM events WHERE organizer_id = $%d)", argIndex))
+		args = append(args, *filter.OrganizerID)
+		argIndex++
+	}
+	
+	if filter.Currency != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("o.currency = $%d", argIndex))
+		args = append(args, filter.Currency)
+		argIndex++
+	}
+	
+	if filter.DateFrom != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("o.created_at >= $%d", argIndex))
+		args = append(args, *filter.DateFrom)
+		argIndex++
+	}
+	
+	if filter.DateTo != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("o.created_at <= $%d", argIndex))
+		args = append(args, *filter.DateTo)
+		argIndex++
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT o.currency,
+			   COUNT(o.id) as paid_orders,
+			   COALESCE(SUM(lines.quantity), 0) as tickets_sold,
+			   COALESCE(SUM(o.total_amount), 0) as gross_revenue,
+			   COALESCE(AVG(o.total_amount), 0) as average_order_value
+		FROM orders o
+		LEFT JOIN (
+			SELECT order_id, SUM(quantity) as quantity FROM order_lines GROUP BY order_id
+		) lines ON lines.order_id = o.id
+		WHERE %s
+		GROUP BY o.currency
+		ORDER BY o.currency ASC`, strings.Join(whereConditions, " AND "))
+	
+	var revenue []*repositories.CurrencyRevenue
+	if err := r.db.SelectContext(ctx, &revenue, query, args...); err != nil {
+		return nil, fmt.Errorf("failed to get revenue by currency: %w", err)
+	}
+	
+	return revenue, nil
+}
+
 func (r *orderRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
 	var exists bool
 	query := `SELECT EXISTS(SELECT 1 FROM orders WHERE id = $1 AND is_active = true)`
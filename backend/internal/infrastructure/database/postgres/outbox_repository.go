@@ -0,0 +1,194 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const outboxSelectColumns = `id, topic, aggregate_type, aggregate_id, payload, status, attempts, max_attempts,
+	next_attempt_at, locked_until, last_error, delivered_at, dead_at, created_at, updated_at`
+
+type outboxRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewOutboxRepository(db *sqlx.DB) repositories.OutboxRepository {
+	return &outboxRepository{db: db}
+}
+
+func NewOutboxRepositoryWithTx(tx *sqlx.Tx) repositories.OutboxRepository {
+	return &outboxRepository{db: tx}
+}
+
+func (r *outboxRepository) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	query := `
+		INSERT INTO outbox_messages (
+			id, topic, aggregate_type, aggregate_id, payload, status, attempts, max_attempts,
+			next_attempt_at, created_at, updated_at
+		) VALUES (
+			:id, :topic, :aggregate_type, :aggregate_id, :payload, :status, :attempts, :max_attempts,
+			:next_attempt_at, :created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, message); err != nil {
+		return fmt.Errorf("failed to create outbox message: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *outboxRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.OutboxMessage, error) {
+	var message entities.OutboxMessage
+	query := fmt.Sprintf(`SELECT %s FROM outbox_messages WHERE id = $1`, outboxSelectColumns)
+	
+	err := r.db.GetContext(ctx, &message, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrOutboxMessageNotFound
+		}
+		return nil, fmt.Errorf("failed to get outbox message: %w", err)
+	}
+	
+	return &message, nil
+}
+
+func (r *outboxRepository) Update(ctx context.Context, message *entities.OutboxMessage) error {
+	query := `
+		UPDATE outbox_messages SET
+			payload = :payload,
+			status = :status,
+			attempts = :attempts,
+			next_attempt_at = :next_attempt_at,
+			locked_until = :locked_until,
+			last_error = :last_error,
+			delivered_at = :delivered_at,
+			dead_at = :dead_at,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, message)
+	if err != nil {
+		return fmt.Errorf("failed to update outbox message: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrOutboxMessageNotFound
+	}
+	
+	return nil
+}
+
+func (r *outboxRepository) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxMessage, error) {
+	query := fmt.Sprintf(`
+		UPDATE outbox_messages SET
+			locked_until = NOW() + ($2 * INTERVAL '1 second'),
+			updated_at = NOW()
+		WHERE id IN (
+			SELECT id FROM outbox_messages
+			WHERE status = 'pending'
+			  AND next_attempt_at <= NOW()
+			  AND (locked_until IS NULL OR locked_until < NOW())
+			ORDER BY next_attempt_at ASC
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING %s`, outboxSelectColumns)
+	
+	messages := []*entities.OutboxMessage{}
+	if err := r.db.SelectContext(ctx, &messages, query, limit, lease.Seconds()); err != nil {
+		return nil, fmt.Errorf("failed to claim outbox messages: %w", err)
+	}
+	
+	return messages, nil
+}
+
+func (r *outboxRepository) List(ctx context.Context, filter repositories.OutboxFilter) ([]*entities.OutboxMessage, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	if filter.Topic != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("topic = $%d", argIndex))
+		args = append(args, filter.Topic)
+		argIndex++
+	}
+	
+	if filter.AggregateID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("aggregate_id = $%d", argIndex))
+		args = append(args, *filter.AggregateID)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM outbox_messages WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count outbox messages: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM outbox_messages
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, outboxSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	messages := []*entities.OutboxMessage{}
+	if err := r.db.SelectContext(ctx, &messages, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list outbox messages: %w", err)
+	}
+	
+	return messages, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *outboxRepository) CountByStatus(ctx context.Context) (map[entities.OutboxStatus]int, error) {
+	var rows []struct {
+		Status entities.OutboxStatus `db:"status"`
+		Count  int                   `db:"count"`
+	}
+	query := `SELECT status, COUNT(*) AS count FROM outbox_messages GROUP BY status`
+	
+	if err := r.db.SelectContext(ctx, &rows, query); err != nil {
+		return nil, fmt.Errorf("failed to count outbox messages: %w", err)
+	}
+	
+	counts := map[entities.OutboxStatus]int{
+		entities.OutboxStatusPending:   0,
+		entities.OutboxStatusDelivered: 0,
+		entities.OutboxStatusDead:      0,
+	}
+	for _, row := range rows {
+		counts[row.Status] = row.Count
+	}
+	
+	return counts, nil
+}
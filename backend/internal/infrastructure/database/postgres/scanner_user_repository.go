@@ -590,6 +590,31 @@ func (r *scannerUserRepository) ValidateTicket(ctx context.Context, validation *
 	return nil
 }
 
+func (r *scannerUserRepository) RecordRedemption(ctx context.Context, validation *entities.TicketValidation) (bool, error) {
+	if validation.ID == uuid.Nil {
+		validation.ID = uuid.New()
+	}
+	
+	query := `
+		INSERT INTO ticket_validations (id, ticket_id, scanner_id, session_id, validation_result, 
+										validation_timestamp, notes)
+		VALUES (:id, :ticket_id, :scanner_id, :session_id, :validation_result, 
+				:validation_timestamp, :notes)
+		ON CONFLICT (ticket_id, session_id) WHERE validation_result = 'valid' DO NOTHING`
+	
+	result, err := r.db.NamedExecContext(ctx, query, validation)
+	if err != nil {
+		return false, fmt.Errorf("failed to record ticket redemption: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
 func (r *scannerUserRepository) GetValidationHistory(ctx context.Context, scannerID uuid.UUID, filter *repositories.TicketValidationFilter) ([]*entities.TicketValidation, *repositories.PaginationResult, error) {
 	var conditions []string
 	var args []interface{}
@@ -0,0 +1,272 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const ticketImportSelectColumns = `id, event_id, source, filename, status, send_emails, total_rows,
+	imported_rows, skipped_rows, failed_rows, tickets_issued, last_error, created_by,
+	started_at, completed_at, created_at, updated_at`
+
+const ticketImportRowSelectColumns = `id, import_id, row_number, external_reference, first_name, last_name,
+	email, phone, tier, quantity, status, error, order_id, created_at, updated_at`
+
+type ticketImportRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewTicketImportRepository(db *sqlx.DB) repositories.TicketImportRepository {
+	return &ticketImportRepository{db: db}
+}
+
+func NewTicketImportRepositoryWithTx(tx *sqlx.Tx) repositories.TicketImportRepository {
+	return &ticketImportRepository{db: tx}
+}
+
+func (r *ticketImportRepository) Create(ctx context.Context, ticketImport *entities.TicketImport) error {
+	query := `
+		INSERT INTO ticket_imports (
+			id, event_id, source, filename, status, send_emails, total_rows,
+			imported_rows, skipped_rows, failed_rows, tickets_issued, created_by,
+			created_at, updated_at
+		) VALUES (
+			:id, :event_id, :source, :filename, :status, :send_emails, :total_rows,
+			:imported_rows, :skipped_rows, :failed_rows, :tickets_issued, :created_by,
+			:created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, ticketImport); err != nil {
+		return fmt.Errorf("failed to create ticket import: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *ticketImportRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketImport, error) {
+	var ticketImport entities.TicketImport
+	query := fmt.Sprintf(`SELECT %s FROM ticket_imports WHERE id = $1`, ticketImportSelectColumns)
+	
+	err := r.db.GetContext(ctx, &ticketImport, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrTicketImportNotFound
+		}
+		return nil, fmt.Errorf("failed to get ticket import: %w", err)
+	}
+	
+	return &ticketImport, nil
+}
+
+func (r *ticketImportRepository) Update(ctx context.Context, ticketImport *entities.TicketImport) error {
+	query := `
+		UPDATE ticket_imports SET
+			status = :status,
+			total_rows = :total_rows,
+			imported_rows = :imported_rows,
+			skipped_rows = :skipped_rows,
+			failed_rows = :failed_rows,
+			tickets_issued = :tickets_issued,
+			last_error = :last_error,
+			started_at = :started_at,
+			completed_at = :completed_at,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, ticketImport)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket import: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrTicketImportNotFound
+	}
+	
+	return nil
+}
+
+func (r *ticketImportRepository) List(ctx context.Context, filter repositories.TicketImportFilter) ([]*entities.TicketImport, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.EventID != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("event_id = $%d", argIndex))
+		args = append(args, *filter.EventID)
+		argIndex++
+	}
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM ticket_imports WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count ticket imports: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM ticket_imports
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, ticketImportSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var imports []*entities.TicketImport
+	if err := r.db.SelectContext(ctx, &imports, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list ticket imports: %w", err)
+	}
+	
+	return imports, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *ticketImportRepository) CreateRows(ctx context.Context, rows []*entities.TicketImportRow) error {
+	if len(rows) == 0 {
+		return nil
+	}
+	
+	query := `
+		INSERT INTO ticket_import_rows (
+			id, import_id, row_number, external_reference, first_name, last_name,
+			email, phone, tier, quantity, status, error, order_id, created_at, updated_at
+		) VALUES (
+			:id, :import_id, :row_number, :external_reference, :first_name, :last_name,
+			:email, :phone, :tier, :quantity, :status, :error, :order_id, :created_at, :updated_at
+		)`
+	
+	// sqlx expands a slice into a single multi-row INSERT
+	if _, err := r.db.NamedExecContext(ctx, query, rows); err != nil {
+		return fmt.Errorf("failed to create ticket import rows: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *ticketImportRepository) GetPendingRows(ctx context.Context, importID uuid.UUID, limit int) ([]*entities.TicketImportRow, error) {
+	var rows []*entities.TicketImportRow
+	query := fmt.Sprintf(`
+		SELECT %s FROM ticket_import_rows
+		WHERE import_id = $1 AND status = 'pending'
+		ORDER BY row_number ASC
+		LIMIT $2`, ticketImportRowSelectColumns)
+	
+	if err := r.db.SelectContext(ctx, &rows, query, importID, limit); err != nil {
+		return nil, fmt.Errorf("failed to get pending ticket import rows: %w", err)
+	}
+	
+	return rows, nil
+}
+
+func (r *ticketImportRepository) UpdateRow(ctx context.Context, row *entities.TicketImportRow) error {
+	query := `
+		UPDATE ticket_import_rows SET
+			status = :status,
+			error = :error,
+			order_id = :order_id,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, row); err != nil {
+		return fmt.Errorf("failed to update ticket import row: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *ticketImportRepository) ListRows(ctx context.Context, filter repositories.TicketImportRowFilter) ([]*entities.TicketImportRow, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"import_id = $1"}
+	args := []interface{}{filter.ImportID}
+	argIndex := 2
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM ticket_import_rows WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count ticket import rows: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM ticket_import_rows
+		WHERE %s
+		ORDER BY row_number ASC
+		LIMIT $%d OFFSET $%d`, ticketImportRowSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var rows []*entities.TicketImportRow
+	if err := r.db.SelectContext(ctx, &rows, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list ticket import rows: %w", err)
+	}
+	
+	return rows, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *ticketImportRepository) CountRows(ctx context.Context, importID uuid.UUID) (*repositories.TicketImportRowCounts, error) {
+	var counts repositories.TicketImportRowCounts
+	query := `
+		SELECT COUNT(*) as total,
+			   COUNT(*) FILTER (WHERE status = 'pending') as pending,
+			   COUNT(*) FILTER (WHERE status = 'imported') as imported,
+			   COUNT(*) FILTER (WHERE status = 'skipped') as skipped,
+			   COUNT(*) FILTER (WHERE status = 'failed') as failed,
+			   COALESCE(SUM(quantity) FILTER (WHERE status = 'imported'), 0) as tickets_issued
+		FROM ticket_import_rows
+		WHERE import_id = $1`
+	
+	if err := r.db.GetContext(ctx, &counts, query, importID); err != nil {
+		return nil, fmt.Errorf("failed to count ticket import rows: %w", err)
+	}
+	
+	return &counts, nil
+}
+
+func (r *ticketImportRepository) FindOrderByExternalReference(ctx context.Context, eventID uuid.UUID, externalReference string) (*uuid.UUID, error) {
+	var orderID uuid.UUID
+	query := `SELECT id FROM orders WHERE event_id = $1 AND external_reference = $2 LIMIT 1`
+	
+	err := r.db.GetContext(ctx, &orderID, query, eventID, externalReference)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find order by external reference: %w", err)
+	}
+	
+	return &orderID, nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sort"
 	"strings"
 	"time"
 
@@ -36,11 +37,11 @@ func (r *ticketTierRepository) Create(ctx context.Context, tier *entities.Ticket
 		INSERT INTO ticket_tiers (
 			id, event_id, name, description, price, currency, 
 			quota, max_per_order, sale_start, sale_end, 
-			is_active, position, created_at, updated_at
+			is_active, position, opens_after_tier_id, pricing_strategy, created_at, updated_at
 		) VALUES (
 			:id, :event_id, :name, :description, :price, :currency,
 			:quota, :max_per_order, :sale_start, :sale_end,
-			:is_active, :position, :created_at, :updated_at
+			:is_active, :position, :opens_after_tier_id, :pricing_strategy, :created_at, :updated_at
 		)`
 	
 	_, err := r.db.NamedExecContext(ctx, query, tier)
@@ -60,14 +61,43 @@ func (r *ticketTierRepository) Create(ctx context.Context, tier *entities.Ticket
 }
 
 func (r *ticketTierRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
-	var tier entities.TicketTier
-	query := `
+	return r.get(ctx, ticketTierByIDQuery, id)
+}
+
+func (r *ticketTierRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
+	return r.get(ctx, ticketTierByIDQuery+" FOR UPDATE", id)
+}
+
+func (r *ticketTierRepository) LockForUpdate(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.TicketTier, error) {
+	sorted := append([]uuid.UUID(nil), ids...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].String() < sorted[j].String() })
+	
+	tiers := make(map[uuid.UUID]*entities.TicketTier, len(sorted))
+	for _, id := range sorted {
+		if _, ok := tiers[id]; ok {
+			continue
+		}
+		tier, err := r.GetByIDForUpdate(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		tiers[id] = tier
+	}
+	
+	return tiers, nil
+}
+
+const ticketTierByIDQuery = `
 			SELECT tt.id, tt.event_id, tt.name, tt.description, tt.price, tt.currency,
 				   tt.quota, tt.sold,
 				   tt.min_per_order, tt.max_per_order, tt.sale_start, tt.sale_end,
-			   tt.is_active, tt.created_at, tt.updated_at
+			   tt.is_active, tt.position, tt.opens_after_tier_id, tt.pricing_strategy,
+			   tt.created_at, tt.updated_at
 		FROM ticket_tiers tt
 		WHERE tt.id = $1 AND tt.is_active = true`
+
+func (r *ticketTierRepository) get(ctx context.Context, query string, id uuid.UUID) (*entities.TicketTier, error) {
+	var tier entities.TicketTier
 	
 	err := r.db.GetContext(ctx, &tier, query, id)
 	if err != nil {
@@ -80,12 +110,26 @@ func (r *ticketTierRepository) GetByID(ctx context.Context, id uuid.UUID) (*enti
 	return &tier, nil
 }
 
+// GetByEvent retrieves all active ticket tiers of an event, whatever their sale window
 func (r *ticketTierRepository) GetByEvent(ctx context.Context, eventID uuid.UUID) ([]*entities.TicketTier, error) {
-	filter := repositories.TicketTierFilter{
-		EventID: &eventID,
+	var tiers []*entities.TicketTier
+	
+	query := `
+		SELECT tt.id, tt.event_id, tt.name, tt.description, tt.price, tt.currency,
+			   tt.quota, tt.sold,
+			   tt.min_per_order, tt.max_per_order,
+			   tt.sale_start, tt.sale_end, tt.is_active, tt.position,
+			   tt.opens_after_tier_id, tt.pricing_strategy, tt.created_at, tt.updated_at
+		FROM ticket_tiers tt
+		WHERE tt.event_id = $1 AND tt.is_active = true
+		ORDER BY tt.position ASC, tt.created_at ASC`
+	
+	err := r.db.SelectContext(ctx, &tiers, query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket tiers by event: %w", err)
 	}
-	tiers, _, err := r.List(ctx, filter)
-	return tiers, err
+	
+	return tiers, nil
 }
 
 // GetActiveByEvent retrieves active ticket tiers for a specific event
@@ -97,7 +141,7 @@ func (r *ticketTierRepository) GetActiveByEvent(ctx context.Context, eventID uui
 			   tt.quota, tt.sold,
 			   tt.min_per_order, tt.max_per_order,
 			   tt.sale_start, tt.sale_end, tt.is_active, tt.position,
-			   tt.created_at, tt.updated_at
+			   tt.opens_after_tier_id, tt.pricing_strategy, tt.created_at, tt.updated_at
 		FROM ticket_tiers tt
 		WHERE tt.event_id = $1 
 		AND tt.is_active = true
@@ -129,7 +173,8 @@ func (r *ticketTierRepository) Update(ctx context.Context, tier *entities.Ticket
 			sale_end = :sale_end,
 			is_active = :is_active,
 			position = :position,
-			meta_info = :meta_info,
+			opens_after_tier_id = :opens_after_tier_id,
+			pricing_strategy = :pricing_strategy,
 			updated_at = :updated_at
 		WHERE id = :id AND is_active = true`
 	
@@ -343,6 +388,10 @@ func (r *ticketTierRepository) GetAvailable(ctx context.Context, eventID uuid.UU
 	return tiers, err
 }
 
+// GetAvailability retrieves the stock, quoted price and sale state of an
+// event's tiers. The state depends on the other tiers of the event through
+// sequencing, and a dynamic price on stock and time, so both are resolved once
+// all rows are loaded.
 func (r *ticketTierRepository) GetAvailability(ctx context.Context, eventID uuid.UUID) ([]*repositories.TicketTierAvailability, error) {
 	var availability []*repositories.TicketTierAvailability
 	
@@ -350,30 +399,38 @@ func (r *ticketTierRepository) GetAvailability(ctx context.Context, eventID uuid
 		SELECT 
 			tt.id as ticket_tier_id,
 			tt.name,
-			tt.price,
+			tt.description,
+			COALESCE(due.price, tt.price) as base_price,
 			tt.currency,
 			tt.quota as quota,
 			tt.sold as sold,
 			COALESCE(reserved.count, 0) as reserved,
-			CASE 
-				WHEN tt.quota IS NULL THEN 999999
-				ELSE tt.quota - tt.sold - COALESCE(reserved.count, 0)
-			END as available,
-			tt.is_active as is_on_sale,
-			CASE 
-				WHEN NOT tt.is_active THEN 'inactive'
-				WHEN tt.sale_start IS NOT NULL AND tt.sale_start > NOW() THEN 'not_started'
-				WHEN tt.sale_end IS NOT NULL AND tt.sale_end < NOW() THEN 'ended'
-				WHEN tt.quota IS NOT NULL AND tt.sold >= tt.quota THEN 'sold_out'
-				ELSE 'available'
-			END as sale_status
+			GREATEST(tt.quota - tt.sold - COALESCE(reserved.count, 0) - COALESCE(ca.quota - ca.issued, 0), 0) as available,
+			tt.min_per_order,
+			tt.max_per_order,
+			tt.sale_start,
+			tt.sale_end,
+			tt.opens_after_tier_id,
+			tt.pricing_strategy,
+			e.event_date
 		FROM ticket_tiers tt
+		JOIN events e ON tt.event_id = e.id
 		LEFT JOIN (
 			SELECT ih.ticket_tier_id, SUM(ih.quantity) as count
 			FROM inventory_holds ih
 			WHERE ih.expires_at > NOW()
 			GROUP BY ih.ticket_tier_id
 		) reserved ON tt.id = reserved.ticket_tier_id
+		LEFT JOIN comp_allocations ca ON ca.ticket_tier_id = tt.id
+		LEFT JOIN LATERAL (
+			SELECT pc.price
+			FROM ticket_tier_price_changes pc
+			WHERE pc.ticket_tier_id = tt.id
+			AND pc.status = 'scheduled'
+			AND pc.effective_at <= NOW()
+			ORDER BY pc.effective_at DESC, pc.created_at DESC
+			LIMIT 1
+		) due ON true
 		WHERE tt.event_id = $1 AND tt.is_active = true
 		ORDER BY tt.position ASC, tt.created_at ASC`
 	
@@ -382,6 +439,29 @@ func (r *ticketTierRepository) GetAvailability(ctx context.Context, eventID uuid
 		return nil, fmt.Errorf("failed to get ticket tier availability: %w", err)
 	}
 	
+	schedules := make([]entities.TierSchedule, 0, len(availability))
+	for _, tier := range availability {
+		schedules = append(schedules, entities.TierSchedule{
+			TierID:           tier.TicketTierID,
+			SaleStart:        tier.SaleStart,
+			SaleEnd:          tier.SaleEnd,
+			OpensAfterTierID: tier.OpensAfterTierID,
+			Available:        tier.Available,
+		})
+	}
+	now := time.Now()
+	states := entities.ResolveTierSaleStates(schedules, now)
+	for _, tier := range availability {
+		tier.SaleStatus = states[tier.TicketTierID]
+		tier.IsOnSale = tier.SaleStatus == entities.TierSaleStateOnSale
+		
+		quota := 0
+		if tier.Quota != nil {
+			quota = *tier.Quota
+		}
+		tier.Price = entities.QuoteTierPrice(tier.BasePrice, tier.PricingStrategy, quota, tier.Sold+tier.Reserved, tier.EventDate, now)
+	}
+	
 	return availability, nil
 }
 
@@ -498,7 +578,8 @@ func (r *ticketTierRepository) GetStats(ctx context.Context, tierID uuid.UUID) (
 }
 
 
-// GetAvailableQuantity retrieves the available quantity for a ticket tier
+// GetAvailableQuantity retrieves the available quantity for a ticket tier. The
+// unissued part of the tier's comp allocation is set aside for the guest list.
 func (r *ticketTierRepository) GetAvailableQuantity(ctx context.Context, ticketTierID uuid.UUID) (int, error) {
 	query := `
 		SELECT 
@@ -507,6 +588,8 @@ func (r *ticketTierRepository) GetAvailableQuantity(ctx context.Context, ticketT
 					WHEN o.status IN ('paid', 'pending') THEN ol.quantity 
 					ELSE 0 
 				END
+			), 0) - COALESCE((
+				SELECT ca.quota - ca.issued FROM comp_allocations ca WHERE ca.ticket_tier_id = tt.id
 			), 0) as available_quantity
 		FROM ticket_tiers tt
 		LEFT JOIN order_lines ol ON tt.id = ol.ticket_tier_id
@@ -556,3 +639,27 @@ func (r *ticketTierRepository) IncrementSold(ctx context.Context, tierID uuid.UU
 
 	return nil
 }
+
+// DecrementSold atomically releases sold capacity on a ticket tier, never dropping below zero.
+func (r *ticketTierRepository) DecrementSold(ctx context.Context, tierID uuid.UUID, quantity int) error {
+	query := `
+		UPDATE ticket_tiers
+		SET sold = GREATEST(sold - $1, 0), updated_at = NOW()
+		WHERE id = $2 AND is_active = true`
+
+	result, err := r.db.ExecContext(ctx, query, quantity, tierID)
+	if err != nil {
+		return fmt.Errorf("failed to decrement sold count for tier %s: %w", tierID, err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return entities.ErrTicketTierNotFound
+	}
+
+	return nil
+}
@@ -0,0 +1,61 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// lockRecorder stands in for the connection and records the tiers locked
+type lockRecorder struct {
+	locked []uuid.UUID
+}
+
+func (r *lockRecorder) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	panic("unexpected ExecContext")
+}
+
+func (r *lockRecorder) GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	if !strings.HasSuffix(strings.TrimSpace(query), "FOR UPDATE") {
+		panic("unexpected query: " + query)
+	}
+	id := args[0].(uuid.UUID)
+	r.locked = append(r.locked, id)
+	*dest.(*entities.TicketTier) = entities.TicketTier{ID: id}
+	return nil
+}
+
+func (r *lockRecorder) SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error {
+	panic("unexpected SelectContext")
+}
+
+func (r *lockRecorder) NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error) {
+	panic("unexpected NamedExecContext")
+}
+
+func TestLockForUpdateLocksEachTierOnceInIDOrder(t *testing.T) {
+	a := uuid.MustParse("0f0e7b9a-0000-4000-8000-000000000001")
+	b := uuid.MustParse("7c1d2e3f-0000-4000-8000-000000000002")
+	c := uuid.MustParse("e2a4c6d8-0000-4000-8000-000000000003")
+
+	// Two sales asking for the same tiers in different orders lock them the same way
+	for _, ids := range [][]uuid.UUID{{c, a, b, a}, {b, c, a}} {
+		db := &lockRecorder{}
+		repo := &ticketTierRepository{db: db}
+
+		tiers, err := repo.LockForUpdate(context.Background(), ids)
+		if err != nil {
+			t.Fatalf("LockForUpdate() error = %v", err)
+		}
+		if len(db.locked) != 3 || db.locked[0] != a || db.locked[1] != b || db.locked[2] != c {
+			t.Errorf("LockForUpdate(%v) locked %v, want %v", ids, db.locked, []uuid.UUID{a, b, c})
+		}
+		if len(tiers) != 3 || tiers[a] == nil || tiers[b] == nil || tiers[c] == nil {
+			t.Errorf("LockForUpdate() returned %d tiers, want each tier by ID", len(tiers))
+		}
+	}
+}
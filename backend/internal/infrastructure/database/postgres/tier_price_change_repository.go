@@ -0,0 +1,174 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const tierPriceChangeSelectColumns = `id, ticket_tier_id, price, previous_price, effective_at, status,
+	reason, created_by, applied_at, cancelled_at, created_at, updated_at`
+
+type tierPriceChangeRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewTierPriceChangeRepository(db *sqlx.DB) repositories.TierPriceChangeRepository {
+	return &tierPriceChangeRepository{db: db}
+}
+
+func NewTierPriceChangeRepositoryWithTx(tx *sqlx.Tx) repositories.TierPriceChangeRepository {
+	return &tierPriceChangeRepository{db: tx}
+}
+
+func (r *tierPriceChangeRepository) Create(ctx context.Context, change *entities.TierPriceChange) error {
+	query := `
+		INSERT INTO ticket_tier_price_changes (
+			id, ticket_tier_id, price, previous_price, effective_at, status,
+			reason, created_by, applied_at, cancelled_at, created_at, updated_at
+		) VALUES (
+			:id, :ticket_tier_id, :price, :previous_price, :effective_at, :status,
+			:reason, :created_by, :applied_at, :cancelled_at, :created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, change); err != nil {
+		return fmt.Errorf("failed to create ticket tier price change: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *tierPriceChangeRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.TierPriceChange, error) {
+	var change entities.TierPriceChange
+	query := fmt.Sprintf(`SELECT %s FROM ticket_tier_price_changes WHERE id = $1`, tierPriceChangeSelectColumns)
+	
+	err := r.db.GetContext(ctx, &change, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrTierPriceChangeNotFound
+		}
+		return nil, fmt.Errorf("failed to get ticket tier price change: %w", err)
+	}
+	
+	return &change, nil
+}
+
+func (r *tierPriceChangeRepository) Update(ctx context.Context, change *entities.TierPriceChange) error {
+	query := `
+		UPDATE ticket_tier_price_changes SET
+			status = :status,
+			previous_price = :previous_price,
+			applied_at = :applied_at,
+			cancelled_at = :cancelled_at,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, change)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket tier price change: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrTierPriceChangeNotFound
+	}
+	
+	return nil
+}
+
+func (r *tierPriceChangeRepository) List(ctx context.Context, filter repositories.TierPriceChangeFilter) ([]*entities.TierPriceChange, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"ticket_tier_id = $1"}
+	args := []interface{}{filter.TicketTierID}
+	argIndex := 2
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM ticket_tier_price_changes WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count ticket tier price changes: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM ticket_tier_price_changes
+		WHERE %s
+		ORDER BY effective_at DESC, created_at DESC
+		LIMIT $%d OFFSET $%d`, tierPriceChangeSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var changes []*entities.TierPriceChange
+	if err := r.db.SelectContext(ctx, &changes, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list ticket tier price changes: %w", err)
+	}
+	
+	return changes, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+// ApplyDue marks every due change applied and moves each tier to its latest due
+// price in one statement. A concurrent run blocks on the locked rows and then
+// skips them, since they are no longer scheduled.
+func (r *tierPriceChangeRepository) ApplyDue(ctx context.Context, eventID *uuid.UUID) (int, error) {
+	eventCondition := ""
+	args := []interface{}{}
+	if eventID != nil {
+		eventCondition = "AND tt.event_id = $1"
+		args = append(args, *eventID)
+	}
+	
+	query := fmt.Sprintf(`
+		WITH applied AS (
+			UPDATE ticket_tier_price_changes c
+			SET status = 'applied', previous_price = tt.price, applied_at = NOW(), updated_at = NOW()
+			FROM ticket_tiers tt
+			WHERE c.ticket_tier_id = tt.id
+			AND c.status = 'scheduled'
+			AND c.effective_at <= NOW()
+			%s
+			RETURNING c.ticket_tier_id, c.price, c.effective_at, c.created_at
+		), latest AS (
+			SELECT DISTINCT ON (ticket_tier_id) ticket_tier_id, price
+			FROM applied
+			ORDER BY ticket_tier_id, effective_at DESC, created_at DESC
+		)
+		UPDATE ticket_tiers tt
+		SET price = latest.price, updated_at = NOW()
+		FROM latest
+		WHERE tt.id = latest.ticket_tier_id`, eventCondition)
+	
+	result, err := r.db.ExecContext(ctx, query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to apply due ticket tier price changes: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return int(rowsAffected), nil
+}
@@ -0,0 +1,239 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const tourPassSelectColumns = `id, tour_id, name, description, price, currency, quota, max_per_order,
+	sale_start, sale_end, is_active, created_at, updated_at`
+
+type tourPassRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewTourPassRepository(db *sqlx.DB) repositories.TourPassRepository {
+	return &tourPassRepository{db: db}
+}
+
+func NewTourPassRepositoryWithTx(tx *sqlx.Tx) repositories.TourPassRepository {
+	return &tourPassRepository{db: tx}
+}
+
+func (r *tourPassRepository) Create(ctx context.Context, pass *entities.TourPass) error {
+	query := `
+		INSERT INTO tour_passes (
+			id, tour_id, name, description, price, currency, quota, max_per_order,
+			sale_start, sale_end, is_active, created_at, updated_at
+		) VALUES (
+			:id, :tour_id, :name, :description, :price, :currency, :quota, :max_per_order,
+			:sale_start, :sale_end, :is_active, :created_at, :updated_at
+		)`
+	
+	_, err := r.db.NamedExecContext(ctx, query, pass)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return entities.ErrTourNotFound
+		}
+		return fmt.Errorf("failed to create tour pass: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *tourPassRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.TourPass, error) {
+	return r.get(ctx, fmt.Sprintf(`SELECT %s FROM tour_passes WHERE id = $1`, tourPassSelectColumns), id)
+}
+
+func (r *tourPassRepository) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.TourPass, error) {
+	return r.get(ctx, fmt.Sprintf(`SELECT %s FROM tour_passes WHERE id = $1 FOR UPDATE`, tourPassSelectColumns), id)
+}
+
+func (r *tourPassRepository) get(ctx context.Context, query string, id uuid.UUID) (*entities.TourPass, error) {
+	var pass entities.TourPass
+	
+	err := r.db.GetContext(ctx, &pass, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrTourPassNotFound
+		}
+		return nil, fmt.Errorf("failed to get tour pass: %w", err)
+	}
+	
+	events, err := r.getEvents(ctx, []uuid.UUID{pass.ID})
+	if err != nil {
+		return nil, err
+	}
+	pass.Events = events[pass.ID]
+	
+	return &pass, nil
+}
+
+// getEvents loads the covered events of the given passes, grouped by pass
+func (r *tourPassRepository) getEvents(ctx context.Context, passIDs []uuid.UUID) (map[uuid.UUID][]*entities.TourPassEvent, error) {
+	grouped := make(map[uuid.UUID][]*entities.TourPassEvent, len(passIDs))
+	if len(passIDs) == 0 {
+		return grouped, nil
+	}
+	
+	ids := make([]string, len(passIDs))
+	for i, id := range passIDs {
+		ids[i] = id.String()
+	}
+	
+	query := `
+		SELECT tpe.tour_pass_id, tpe.event_id, tpe.ticket_tier_id,
+			   e.name as event_name, e.event_date, e.venue_name, e.venue_city,
+			   tt.name as ticket_tier_name
+		FROM tour_pass_events tpe
+		JOIN events e ON e.id = tpe.event_id
+		JOIN ticket_tiers tt ON tt.id = tpe.ticket_tier_id
+		WHERE tpe.tour_pass_id = ANY($1::uuid[])
+		ORDER BY e.event_date ASC, e.name ASC`
+	
+	var events []*entities.TourPassEvent
+	if err := r.db.SelectContext(ctx, &events, query, pq.Array(ids)); err != nil {
+		return nil, fmt.Errorf("failed to get tour pass events: %w", err)
+	}
+	
+	for _, event := range events {
+		grouped[event.TourPassID] = append(grouped[event.TourPassID], event)
+	}
+	
+	return grouped, nil
+}
+
+func (r *tourPassRepository) Update(ctx context.Context, pass *entities.TourPass) error {
+	query := `
+		UPDATE tour_passes SET
+			name = :name,
+			description = :description,
+			price = :price,
+			currency = :currency,
+			quota = :quota,
+			max_per_order = :max_per_order,
+			sale_start = :sale_start,
+			sale_end = :sale_end,
+			is_active = :is_active,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, pass)
+	if err != nil {
+		return fmt.Errorf("failed to update tour pass: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrTourPassNotFound
+	}
+	
+	return nil
+}
+
+func (r *tourPassRepository) ReplaceEvents(ctx context.Context, passID uuid.UUID, events []*entities.TourPassEvent) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM tour_pass_events WHERE tour_pass_id = $1`, passID); err != nil {
+		return fmt.Errorf("failed to clear tour pass events: %w", err)
+	}
+	
+	if len(events) == 0 {
+		return nil
+	}
+	
+	query := `
+		INSERT INTO tour_pass_events (tour_pass_id, event_id, ticket_tier_id)
+		VALUES (:tour_pass_id, :event_id, :ticket_tier_id)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, events); err != nil {
+		return fmt.Errorf("failed to create tour pass events: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *tourPassRepository) ListByTour(ctx context.Context, tourID uuid.UUID, activeOnly bool) ([]*entities.TourPass, error) {
+	query := fmt.Sprintf(`SELECT %s FROM tour_passes WHERE tour_id = $1`, tourPassSelectColumns)
+	if activeOnly {
+		query += " AND is_active = true"
+	}
+	query += " ORDER BY price ASC, name ASC"
+	
+	passes := []*entities.TourPass{}
+	if err := r.db.SelectContext(ctx, &passes, query, tourID); err != nil {
+		return nil, fmt.Errorf("failed to list tour passes: %w", err)
+	}
+	
+	passIDs := make([]uuid.UUID, len(passes))
+	for i, pass := range passes {
+		passIDs[i] = pass.ID
+	}
+	
+	events, err := r.getEvents(ctx, passIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, pass := range passes {
+		pass.Events = events[pass.ID]
+	}
+	
+	return passes, nil
+}
+
+func (r *tourPassRepository) CountReserved(ctx context.Context, passID uuid.UUID) (int, error) {
+	// Same rule as ticket tier availability: paid and pending orders hold capacity
+	query := `
+		SELECT COALESCE(SUM(tpo.quantity), 0)
+		FROM tour_pass_orders tpo
+		JOIN orders o ON o.id = tpo.order_id AND o.is_active = true
+		WHERE tpo.tour_pass_id = $1 AND o.status IN ('paid', 'confirmed', 'pending')`
+	
+	var reserved int
+	if err := r.db.GetContext(ctx, &reserved, query, passID); err != nil {
+		return 0, fmt.Errorf("failed to count reserved tour passes: %w", err)
+	}
+	
+	return reserved, nil
+}
+
+func (r *tourPassRepository) CreateOrder(ctx context.Context, passOrder *entities.TourPassOrder) error {
+	query := `
+		INSERT INTO tour_pass_orders (order_id, tour_pass_id, quantity, created_at)
+		VALUES (:order_id, :tour_pass_id, :quantity, :created_at)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, passOrder); err != nil {
+		return fmt.Errorf("failed to create tour pass order: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *tourPassRepository) GetOrder(ctx context.Context, orderID uuid.UUID) (*entities.TourPassOrder, error) {
+	var passOrder entities.TourPassOrder
+	query := `SELECT order_id, tour_pass_id, quantity, created_at FROM tour_pass_orders WHERE order_id = $1`
+	
+	err := r.db.GetContext(ctx, &passOrder, query, orderID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrTourPassNotFound
+		}
+		return nil, fmt.Errorf("failed to get tour pass order: %w", err)
+	}
+	
+	return &passOrder, nil
+}
@@ -31,16 +31,28 @@ func NewTourRepositoryWithTx(tx *sqlx.Tx) repositories.TourRepository {
 	return &tourRepository{db: tx}
 }
 
+const tourSelectColumns = `t.id, t.organizer_id, t.name, t.slug, t.artist_name, t.description,
+	t.tour_image_url, t.start_date, t.end_date, t.settings, t.is_active, t.created_at, t.updated_at`
+
+// tourSortColumns whitelists the columns tours can be sorted by
+var tourSortColumns = map[string]string{
+	"name":        "t.name",
+	"artist_name": "t.artist_name",
+	"start_date":  "t.start_date",
+	"end_date":    "t.end_date",
+	"created_at":  "t.created_at",
+}
+
 func (r *tourRepository) Create(ctx context.Context, tour *entities.Tour) error {
 	query := `
 		INSERT INTO tours (
-			id, organizer_id, name, slug, description, event_date, 
-			end_date, is_active, image_url, meta_info, 
-			created_at, updated_at
+			id, organizer_id, name, slug, artist_name, description,
+			tour_image_url, start_date, end_date, settings,
+			is_active, created_at, updated_at
 		) VALUES (
-			:id, :organizer_id, :name, :slug, :description, :event_date,
-			:end_date, :is_active, :image_url, :meta_info,
-			:created_at, :updated_at
+			:id, :organizer_id, :name, :slug, :artist_name, :description,
+			:tour_image_url, :start_date, :end_date, :settings,
+			:is_active, :created_at, :updated_at
 		)`
 	
 	_, err := r.db.NamedExecContext(ctx, query, tour)
@@ -48,11 +60,9 @@ func (r *tourRepository) Create(ctx context.Context, tour *entities.Tour) error
 		if pqErr, ok := err.(*pq.Error); ok {
 			switch pqErr.Code {
 			case "23505": // unique_violation
-				if strings.Contains(pqErr.Detail, "slug") {
-					return entities.ErrConflictError
-				}
+				return entities.NewConflictError("tour", "a tour with this slug already exists for this organizer", nil)
 			case "23503": // foreign_key_violation
-				return entities.ErrNotFoundError
+				return entities.ErrOrganizerNotFound
 			}
 		}
 		return fmt.Errorf("failed to create tour: %w", err)
@@ -63,19 +73,16 @@ func (r *tourRepository) Create(ctx context.Context, tour *entities.Tour) error
 
 func (r *tourRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Tour, error) {
 	var tour entities.Tour
-	query := `
-		SELECT t.id, t.organizer_id, t.name, t.slug, t.description, 
-			   t.event_date, t.end_date, t.is_active, t.image_url, 
-			   t.meta_info, t.created_at, t.updated_at,
-			   o.name as organizer_name, o.slug as organizer_slug
+	query := fmt.Sprintf(`
+		SELECT %s
 		FROM tours t
 		JOIN organizers o ON t.organizer_id = o.id
-		WHERE t.id = $1 AND t.is_active = true AND o.is_active = true`
+		WHERE t.id = $1 AND t.is_active = true AND o.is_active = true`, tourSelectColumns)
 	
 	err := r.db.GetContext(ctx, &tour, query, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, entities.ErrNotFoundError
+			return nil, entities.ErrTourNotFound
 		}
 		return nil, fmt.Errorf("failed to get tour by ID: %w", err)
 	}
@@ -85,19 +92,16 @@ func (r *tourRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.T
 
 func (r *tourRepository) GetBySlug(ctx context.Context, organizerID uuid.UUID, slug string) (*entities.Tour, error) {
 	var tour entities.Tour
-	query := `
-		SELECT t.id, t.organizer_id, t.name, t.slug, t.description, 
-			   t.event_date, t.end_date, t.is_active, t.image_url, 
-			   t.meta_info, t.created_at, t.updated_at,
-			   o.name as organizer_name, o.slug as organizer_slug
+	query := fmt.Sprintf(`
+		SELECT %s
 		FROM tours t
 		JOIN organizers o ON t.organizer_id = o.id
-		WHERE t.organizer_id = $1 AND t.slug = $2 AND t.is_active = true AND o.is_active = true`
+		WHERE t.organizer_id = $1 AND t.slug = $2 AND t.is_active = true AND o.is_active = true`, tourSelectColumns)
 	
 	err := r.db.GetContext(ctx, &tour, query, organizerID, slug)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, entities.ErrNotFoundError
+			return nil, entities.ErrTourNotFound
 		}
 		return nil, fmt.Errorf("failed to get tour by slug: %w", err)
 	}
@@ -115,29 +119,22 @@ func (r *tourRepository) Update(ctx context.Context, tour *entities.Tour) error
 	
 	query := `
 		UPDATE tours SET
-			organizer_id = :organizer_id,
 			name = :name,
 			slug = :slug,
+			artist_name = :artist_name,
 			description = :description,
-			event_date = :event_date,
+			tour_image_url = :tour_image_url,
+			start_date = :start_date,
 			end_date = :end_date,
+			settings = :settings,
 			is_active = :is_active,
-			image_url = :image_url,
-			meta_info = :meta_info,
 			updated_at = :updated_at
-		WHERE id = :id AND is_active = true`
+		WHERE id = :id`
 	
 	result, err := r.db.NamedExecContext(ctx, query, tour)
 	if err != nil {
-		if pqErr, ok := err.(*pq.Error); ok {
-			switch pqErr.Code {
-			case "23505": // unique_violation
-				if strings.Contains(pqErr.Detail, "slug") {
-					return entities.ErrConflictError
-				}
-			case "23503": // foreign_key_violation
-				return entities.ErrNotFoundError
-			}
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return entities.NewConflictError("tour", "a tour with this slug already exists for this organizer", nil)
 		}
 		return fmt.Errorf("failed to update tour: %w", err)
 	}
@@ -148,14 +145,14 @@ func (r *tourRepository) Update(ctx context.Context, tour *entities.Tour) error
 	}
 	
 	if rowsAffected == 0 {
-		return entities.ErrNotFoundError
+		return entities.ErrTourNotFound
 	}
 	
 	return nil
 }
 
 func (r *tourRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	query := `UPDATE tours SET is_active = false WHERE id = $1 AND is_active = true`
+	query := `UPDATE tours SET is_active = false, updated_at = NOW() WHERE id = $1 AND is_active = true`
 	
 	result, err := r.db.ExecContext(ctx, query, id)
 	if err != nil {
@@ -168,17 +165,21 @@ func (r *tourRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	}
 	
 	if rowsAffected == 0 {
-		return entities.ErrNotFoundError
+		return entities.ErrTourNotFound
 	}
 	
 	return nil
 }
 
 func (r *tourRepository) List(ctx context.Context, filter repositories.TourFilter) ([]*entities.Tour, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
 	var tours []*entities.Tour
 	var totalCount int
 	
-	// Build WHERE clause
+	// Deleted tours are soft-deleted, so inactive tours are never listed
 	whereConditions := []string{"t.is_active = true", "o.is_active = true"}
 	args := []interface{}{}
 	argIndex := 1
@@ -189,33 +190,33 @@ func (r *tourRepository) List(ctx context.Context, filter repositories.TourFilte
 		argIndex++
 	}
 	
-	if filter.IsActive != nil {
-		whereConditions = append(whereConditions, fmt.Sprintf("t.is_active = $%d", argIndex))
-		args = append(args, *filter.IsActive)
+	if filter.ArtistName != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("t.artist_name ILIKE $%d", argIndex))
+		args = append(args, "%"+filter.ArtistName+"%")
 		argIndex++
 	}
 	
 	if filter.Search != "" {
 		searchPattern := "%" + filter.Search + "%"
-		whereConditions = append(whereConditions, fmt.Sprintf("(t.name ILIKE $%d OR t.description ILIKE $%d)", argIndex, argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf("(t.name ILIKE $%d OR t.artist_name ILIKE $%d)", argIndex, argIndex))
 		args = append(args, searchPattern)
 		argIndex++
 	}
 	
 	if filter.StartDateFrom != nil {
-		whereConditions = append(whereConditions, fmt.Sprintf("t.event_date >= $%d", argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf("t.start_date >= $%d", argIndex))
 		args = append(args, *filter.StartDateFrom)
 		argIndex++
 	}
 	
 	if filter.StartDateTo != nil {
-		whereConditions = append(whereConditions, fmt.Sprintf("t.event_date <= $%d", argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf("t.start_date <= $%d", argIndex))
 		args = append(args, *filter.StartDateTo)
 		argIndex++
 	}
 	
 	if filter.EndDateFrom != nil {
-		whereConditions = append(whereConditions, fmt.Sprintf("t.end_date >= $%d", argIndex))
+		whereConditions = append(whereConditions, fmt.Sprintf("(t.end_date IS NULL OR t.end_date >= $%d)", argIndex))
 		args = append(args, *filter.EndDateFrom)
 		argIndex++
 	}
@@ -253,51 +254,32 @@ func (r *tourRepository) List(ctx context.Context, filter repositories.TourFilte
 	}
 	
 	// Build ORDER BY clause
-	orderBy := "t.event_date ASC"
-	if filter.SortBy != "" {
+	orderBy := "t.start_date ASC NULLS LAST, t.name ASC"
+	if column, ok := tourSortColumns[filter.SortBy]; ok {
 		direction := "ASC"
-		if filter.SortOrder == "desc" {
+		if filter.SortOrder == repositories.SortOrderDesc {
 			direction = "DESC"
 		}
-		orderBy = fmt.Sprintf("t.%s %s", filter.SortBy, direction)
+		orderBy = fmt.Sprintf("%s %s NULLS LAST", column, direction)
 	}
 	
-	// Build main query with pagination
-	offset := (filter.Page - 1) * filter.Limit
 	query := fmt.Sprintf(`
-		SELECT t.id, t.organizer_id, t.name, t.slug, t.description, 
-			   t.event_date, t.end_date, t.is_active, t.image_url, 
-			   t.meta_info, t.created_at, t.updated_at,
-			   o.name as organizer_name, o.slug as organizer_slug,
-			   COUNT(e.id) as event_count
+		SELECT %s,
+			   (SELECT COUNT(*) FROM events e WHERE e.tour_id = t.id AND e.is_active = true) as event_count
 		FROM tours t
 		JOIN organizers o ON t.organizer_id = o.id
-		LEFT JOIN events e ON t.id = e.tour_id AND e.is_active = true
 		WHERE %s 
-		GROUP BY t.id, t.organizer_id, t.name, t.slug, t.description, 
-				 t.event_date, t.end_date, t.is_active, t.image_url, 
-				 t.meta_info, t.created_at, t.updated_at,
-				 o.name, o.slug
 		ORDER BY %s 
-		LIMIT $%d OFFSET $%d`, whereClause, orderBy, argIndex, argIndex+1)
+		LIMIT $%d OFFSET $%d`, tourSelectColumns, whereClause, orderBy, argIndex, argIndex+1)
 	
-	args = append(args, filter.Limit, offset)
+	args = append(args, filter.Limit, filter.GetOffset())
 	
 	err = r.db.SelectContext(ctx, &tours, query, args...)
 	if err != nil {
 		return nil, nil, fmt.Errorf("failed to list tours: %w", err)
 	}
 	
-	// Calculate pagination
-	totalPages := (totalCount + filter.Limit - 1) / filter.Limit
-	pagination := &repositories.PaginationResult{
-		Page:       filter.Page,
-		Limit:      filter.Limit,
-		Total:      totalCount,
-		TotalPages: totalPages,
-	}
-	
-	return tours, pagination, nil
+	return tours, repositories.NewPaginationResult(filter.Page, filter.Limit, totalCount), nil
 }
 
 func (r *tourRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error) {
@@ -314,7 +296,7 @@ func (r *tourRepository) Exists(ctx context.Context, id uuid.UUID) (bool, error)
 
 func (r *tourRepository) ExistsBySlug(ctx context.Context, organizerID uuid.UUID, slug string) (bool, error) {
 	var exists bool
-	query := `SELECT EXISTS(SELECT 1 FROM tours WHERE organizer_id = $1 AND slug = $2 AND is_active = true)`
+	query := `SELECT EXISTS(SELECT 1 FROM tours WHERE organizer_id = $1 AND slug = $2)`
 	
 	err := r.db.GetContext(ctx, &exists, query, organizerID, slug)
 	if err != nil {
@@ -345,28 +327,34 @@ func (r *tourRepository) GetTourStats(ctx context.Context, tourID uuid.UUID) (*r
 	var stats repositories.TourStats
 	
 	query := `
+		WITH tour_events AS (
+			SELECT e.id, e.status, e.event_date
+			FROM events e
+			WHERE e.tour_id = $1 AND e.is_active = true
+		)
 		SELECT 
 			t.id as tour_id,
-			t.name as tour_name,
-			COUNT(e.id) as total_events,
-			COUNT(CASE WHEN e.status = 'published' THEN 1 END) as published_events,
-			COUNT(CASE WHEN e.status = 'published' AND e.event_date > NOW() THEN 1 END) as on_sale_events,
-			COALESCE(SUM(CASE WHEN o.status IN ('confirmed', 'paid') THEN ol.total_amount ELSE 0 END), 0) as total_revenue,
-			COUNT(DISTINCT CASE WHEN o.status IN ('confirmed', 'paid') THEN o.id END) as total_orders,
-			COUNT(DISTINCT CASE WHEN tk.status = 'redeemed' THEN tk.id END) as redeemed_tickets
+			(SELECT COUNT(*) FROM tour_events) as total_events,
+			(SELECT COUNT(*) FROM tour_events WHERE status IN ('published', 'on_sale', 'sold_out')) as published_events,
+			(SELECT COUNT(*) FROM tour_events WHERE status = 'on_sale' OR (status = 'published' AND event_date > NOW())) as on_sale_events,
+			(SELECT COUNT(*) FROM tour_events WHERE status = 'sold_out') as sold_out_events,
+			(SELECT COUNT(*) FROM tour_events WHERE status = 'completed') as completed_events,
+			(SELECT COUNT(*) FROM tour_events WHERE status = 'cancelled') as cancelled_events,
+			(SELECT COALESCE(SUM(tt.quota), 0) FROM ticket_tiers tt JOIN tour_events te ON tt.event_id = te.id WHERE tt.is_active = true) as total_tickets,
+			(SELECT COALESCE(SUM(tt.sold), 0) FROM ticket_tiers tt JOIN tour_events te ON tt.event_id = te.id WHERE tt.is_active = true) as sold_tickets,
+			(SELECT COALESCE(SUM(o.total_amount), 0) FROM orders o JOIN tour_events te ON o.event_id = te.id
+				WHERE o.status IN ('paid', 'confirmed') AND o.is_comp = false AND o.is_imported = false) as total_revenue,
+			(SELECT COALESCE(SUM(o.total_amount), 0) FROM orders o JOIN tour_events te ON o.event_id = te.id
+				WHERE o.status = 'confirmed' AND o.is_comp = false AND o.is_imported = false) as confirmed_revenue,
+			(SELECT MIN(event_date) FROM tour_events) as first_event_date,
+			(SELECT MAX(event_date) FROM tour_events) as last_event_date
 		FROM tours t
-		LEFT JOIN events e ON t.id = e.tour_id AND e.is_active = true
-		LEFT JOIN ticket_tiers tt ON e.id = tt.event_id AND tt.is_active = true
-		LEFT JOIN order_lines ol ON tt.id = ol.ticket_tier_id
-		LEFT JOIN orders o ON ol.order_id = o.id AND o.is_active = true
-		LEFT JOIN tickets tk ON ol.id = tk.order_line_id
-		WHERE t.id = $1 AND t.is_active = true
-		GROUP BY t.id, t.name`
+		WHERE t.id = $1 AND t.is_active = true`
 	
 	err := r.db.GetContext(ctx, &stats, query, tourID)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return nil, entities.ErrNotFoundError
+			return nil, entities.ErrTourNotFound
 		}
 		return nil, fmt.Errorf("failed to get tour stats: %w", err)
 	}
@@ -385,39 +373,3 @@ func (r *tourRepository) GetByDateRange(ctx context.Context, startDate, endDate
 	filter.EndDateTo = &endDate
 	return r.List(ctx, filter)
 }
-
-func (r *tourRepository) GetStats(ctx context.Context, tourID uuid.UUID) (*repositories.TourStats, error) {
-	var stats repositories.TourStats
-	
-	query := `
-		SELECT 
-			t.id as tour_id,
-			t.name as tour_name,
-			COALESCE(COUNT(DISTINCT e.id), 0) as total_events,
-			COALESCE(COUNT(DISTINCT CASE WHEN e.status = 'published' THEN e.id END), 0) as published_events,
-			COALESCE(COUNT(DISTINCT CASE WHEN e.status = 'draft' THEN e.id END), 0) as draft_events,
-			COALESCE(COUNT(DISTINCT CASE WHEN e.status = 'cancelled' THEN e.id END), 0) as cancelled_events,
-			COALESCE(COUNT(DISTINCT o.id), 0) as total_orders,
-			COALESCE(COUNT(DISTINCT CASE WHEN o.status = 'paid' THEN o.id END), 0) as paid_orders,
-			COALESCE(SUM(CASE WHEN o.status = 'paid' THEN o.total_amount ELSE 0 END), 0) as total_revenue,
-			COALESCE(COUNT(DISTINCT tk.id), 0) as total_tickets_sold,
-			COALESCE(COUNT(DISTINCT CASE WHEN tk.status = 'redeemed' THEN tk.id END), 0) as tickets_redeemed,
-			MIN(CASE WHEN e.status = 'published' THEN e.event_date END) as first_event_date,
-			MAX(CASE WHEN e.status = 'published' THEN e.event_date END) as last_event_date,
-			MIN(CASE WHEN o.status = 'paid' THEN o.created_at END) as first_sale_at,
-			MAX(CASE WHEN o.status = 'paid' THEN o.created_at END) as last_sale_at
-		FROM tours t
-		LEFT JOIN events e ON t.id = e.tour_id AND e.is_active = true
-		LEFT JOIN orders o ON e.id = o.event_id AND o.is_active = true
-		LEFT JOIN tickets tk ON o.id = tk.order_id
-		WHERE t.id = $1 AND t.is_active = true
-		GROUP BY t.id, t.name`
-	
-	err := r.db.GetContext(ctx, &stats, query, tourID)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get tour stats: %w", err)
-	}
-	
-	return &stats, nil
-}
-
@@ -28,6 +28,19 @@ type postgresTransaction struct {
 	adminUsers      repositories.AdminUserRepository
 	scannerUsers    repositories.ScannerUserRepository
 	otpTokens       repositories.OTPTokenRepository
+	boxOffice       repositories.BoxOfficeRepository
+	comps           repositories.CompRepository
+	ticketImports   repositories.TicketImportRepository
+	venues          repositories.VenueRepository
+	tourPasses      repositories.TourPassRepository
+	eventSeries     repositories.EventSeriesRepository
+	eventChanges    repositories.EventChangeRepository
+	outbox          repositories.OutboxRepository
+	webhooks        repositories.WebhookRepository
+	notifications   repositories.NotificationRepository
+	messageTemplates repositories.MessageTemplateRepository
+	eventCampaigns  repositories.EventCampaignRepository
+	marketingCampaigns repositories.MarketingCampaignRepository
 }
 
 // Commit commits the transaction
@@ -155,6 +168,110 @@ func (t *postgresTransaction) OTPTokens() repositories.OTPTokenRepository {
 	return t.otpTokens
 }
 
+// BoxOffice returns the box office repository within this transaction
+func (t *postgresTransaction) BoxOffice() repositories.BoxOfficeRepository {
+	if t.boxOffice == nil {
+		t.boxOffice = NewBoxOfficeRepositoryWithTx(t.tx)
+	}
+	return t.boxOffice
+}
+
+// Comps returns the comp and guest list repository within this transaction
+func (t *postgresTransaction) Comps() repositories.CompRepository {
+	if t.comps == nil {
+		t.comps = NewCompRepositoryWithTx(t.tx)
+	}
+	return t.comps
+}
+
+// TicketImports returns the ticket import repository within this transaction
+func (t *postgresTransaction) TicketImports() repositories.TicketImportRepository {
+	if t.ticketImports == nil {
+		t.ticketImports = NewTicketImportRepositoryWithTx(t.tx)
+	}
+	return t.ticketImports
+}
+
+// Venues returns the venue repository within this transaction
+func (t *postgresTransaction) Venues() repositories.VenueRepository {
+	if t.venues == nil {
+		t.venues = NewVenueRepositoryWithTx(t.tx)
+	}
+	return t.venues
+}
+
+// TourPasses returns the tour pass repository within this transaction
+func (t *postgresTransaction) TourPasses() repositories.TourPassRepository {
+	if t.tourPasses == nil {
+		t.tourPasses = NewTourPassRepositoryWithTx(t.tx)
+	}
+	return t.tourPasses
+}
+
+// EventSeries returns the event series repository within this transaction
+func (t *postgresTransaction) EventSeries() repositories.EventSeriesRepository {
+	if t.eventSeries == nil {
+		t.eventSeries = NewEventSeriesRepositoryWithTx(t.tx)
+	}
+	return t.eventSeries
+}
+
+// EventChanges returns the event change repository within this transaction
+func (t *postgresTransaction) EventChanges() repositories.EventChangeRepository {
+	if t.eventChanges == nil {
+		t.eventChanges = NewEventChangeRepositoryWithTx(t.tx)
+	}
+	return t.eventChanges
+}
+
+// Outbox returns the outbox repository within this transaction
+func (t *postgresTransaction) Outbox() repositories.OutboxRepository {
+	if t.outbox == nil {
+		t.outbox = NewOutboxRepositoryWithTx(t.tx)
+	}
+	return t.outbox
+}
+
+// Webhooks returns the webhook repository within this transaction
+func (t *postgresTransaction) Webhooks() repositories.WebhookRepository {
+	if t.webhooks == nil {
+		t.webhooks = NewWebhookRepositoryWithTx(t.tx)
+	}
+	return t.webhooks
+}
+
+// Notifications returns the notification message repository within this transaction
+func (t *postgresTransaction) Notifications() repositories.NotificationRepository {
+	if t.notifications == nil {
+		t.notifications = NewNotificationRepositoryWithTx(t.tx)
+	}
+	return t.notifications
+}
+
+// MessageTemplates returns the message template repository within this transaction
+func (t *postgresTransaction) MessageTemplates() repositories.MessageTemplateRepository {
+	if t.messageTemplates == nil {
+		t.messageTemplates = NewMessageTemplateRepositoryWithTx(t.tx)
+	}
+	return t.messageTemplates
+}
+
+// EventCampaigns returns the event campaign repository within this transaction
+func (t *postgresTransaction) EventCampaigns() repositories.EventCampaignRepository {
+	if t.eventCampaigns == nil {
+		t.eventCampaigns = NewEventCampaignRepositoryWithTx(t.tx)
+	}
+	return t.eventCampaigns
+}
+
+// MarketingCampaigns returns the marketing campaign repository within this transaction
+func (t *postgresTransaction) MarketingCampaigns() repositories.MarketingCampaignRepository {
+	if t.marketingCampaigns == nil {
+		t.marketingCampaigns = NewMarketingCampaignRepositoryWithTx(t.tx)
+	}
+	return t.marketingCampaigns
+}
+
 // postgresUnitOfWork implements the UnitOfWork interface
 type postgresUnitOfWork struct {
 	db *sqlx.DB
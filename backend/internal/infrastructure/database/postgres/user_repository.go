@@ -458,6 +458,82 @@ func (r *userRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, p
 	return nil
 }
 
+func (r *userRepository) GetLocale(ctx context.Context, userID uuid.UUID) (string, error) {
+	query := `SELECT COALESCE(settings->>'locale', '') FROM users WHERE id = $1`
+	
+	var locale string
+	err := r.db.GetContext(ctx, &locale, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", entities.ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to get user locale: %w", err)
+	}
+	
+	return locale, nil
+}
+
+func (r *userRepository) UpdateLocale(ctx context.Context, userID uuid.UUID, locale string) error {
+	query := `
+		UPDATE users
+		SET settings = jsonb_set(COALESCE(settings, '{}'::jsonb), '{locale}', to_jsonb($1::text)), updated_at = NOW()
+		WHERE id = $2 AND is_active = true`
+	
+	result, err := r.db.ExecContext(ctx, query, locale, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user locale: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrUserNotFound
+	}
+	
+	return nil
+}
+
+func (r *userRepository) GetNotificationChannel(ctx context.Context, userID uuid.UUID) (entities.NotificationChannel, error) {
+	query := `SELECT COALESCE(settings->>'notification_channel', '') FROM users WHERE id = $1`
+	
+	var channel entities.NotificationChannel
+	err := r.db.GetContext(ctx, &channel, query, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", entities.ErrUserNotFound
+		}
+		return "", fmt.Errorf("failed to get user notification channel: %w", err)
+	}
+	
+	return channel, nil
+}
+
+func (r *userRepository) UpdateNotificationChannel(ctx context.Context, userID uuid.UUID, channel entities.NotificationChannel) error {
+	query := `
+		UPDATE users
+		SET settings = jsonb_set(COALESCE(settings, '{}'::jsonb), '{notification_channel}', to_jsonb($1::text)), updated_at = NOW()
+		WHERE id = $2 AND is_active = true`
+	
+	result, err := r.db.ExecContext(ctx, query, channel, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user notification channel: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrUserNotFound
+	}
+	
+	return nil
+}
+
 func (r *userRepository) GetUserStats(ctx context.Context, userID uuid.UUID) (*repositories.UserStats, error) {
 	var stats repositories.UserStats
 	
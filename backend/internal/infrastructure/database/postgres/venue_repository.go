@@ -0,0 +1,232 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const venueSelectColumns = `id, name, address, city, state, country, latitude, longitude, capacity, timezone,
+	description, accessibility, image_url, gallery_images, is_active, created_at, updated_at`
+
+const venueGateSelectColumns = `id, venue_id, name, kind, description, is_accessible, position, created_at, updated_at`
+
+type venueRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewVenueRepository(db *sqlx.DB) repositories.VenueRepository {
+	return &venueRepository{db: db}
+}
+
+func NewVenueRepositoryWithTx(tx *sqlx.Tx) repositories.VenueRepository {
+	return &venueRepository{db: tx}
+}
+
+func (r *venueRepository) Create(ctx context.Context, venue *entities.Venue) error {
+	query := `
+		INSERT INTO venues (
+			id, name, address, city, state, country, latitude, longitude, capacity, timezone,
+			description, accessibility, image_url, gallery_images, is_active, created_at, updated_at
+		) VALUES (
+			:id, :name, :address, :city, :state, :country, :latitude, :longitude, :capacity, :timezone,
+			:description, :accessibility, :image_url, :gallery_images, :is_active, :created_at, :updated_at
+		)`
+	
+	_, err := r.db.NamedExecContext(ctx, query, venue)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return entities.NewConflictError("venue", "a venue with this name already exists in this city", nil)
+		}
+		return fmt.Errorf("failed to create venue: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *venueRepository) GetByID(ctx context.Context, id uuid.UUID) (*entities.Venue, error) {
+	var venue entities.Venue
+	query := fmt.Sprintf(`SELECT %s FROM venues WHERE id = $1`, venueSelectColumns)
+	
+	err := r.db.GetContext(ctx, &venue, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrVenueNotFound
+		}
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+	
+	if venue.Gates, err = r.getGates(ctx, venue.ID); err != nil {
+		return nil, err
+	}
+	
+	return &venue, nil
+}
+
+func (r *venueRepository) GetByNameAndCity(ctx context.Context, name, city string) (*entities.Venue, error) {
+	var venue entities.Venue
+	query := fmt.Sprintf(`
+		SELECT %s FROM venues
+		WHERE lower(name) = lower($1) AND lower(city) = lower($2)`, venueSelectColumns)
+	
+	err := r.db.GetContext(ctx, &venue, query, strings.TrimSpace(name), strings.TrimSpace(city))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrVenueNotFound
+		}
+		return nil, fmt.Errorf("failed to get venue by name: %w", err)
+	}
+	
+	if venue.Gates, err = r.getGates(ctx, venue.ID); err != nil {
+		return nil, err
+	}
+	
+	return &venue, nil
+}
+
+func (r *venueRepository) getGates(ctx context.Context, venueID uuid.UUID) ([]*entities.VenueGate, error) {
+	gates := []*entities.VenueGate{}
+	query := fmt.Sprintf(`SELECT %s FROM venue_gates WHERE venue_id = $1 ORDER BY position, name`, venueGateSelectColumns)
+	
+	if err := r.db.SelectContext(ctx, &gates, query, venueID); err != nil {
+		return nil, fmt.Errorf("failed to get venue gates: %w", err)
+	}
+	
+	return gates, nil
+}
+
+func (r *venueRepository) Update(ctx context.Context, venue *entities.Venue) error {
+	query := `
+		UPDATE venues SET
+			name = :name,
+			address = :address,
+			city = :city,
+			state = :state,
+			country = :country,
+			latitude = :latitude,
+			longitude = :longitude,
+			capacity = :capacity,
+			timezone = :timezone,
+			description = :description,
+			accessibility = :accessibility,
+			image_url = :image_url,
+			gallery_images = :gallery_images,
+			is_active = :is_active,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, venue)
+	if err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23505" {
+			return entities.NewConflictError("venue", "a venue with this name already exists in this city", nil)
+		}
+		return fmt.Errorf("failed to update venue: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrVenueNotFound
+	}
+	
+	return nil
+}
+
+func (r *venueRepository) ReplaceGates(ctx context.Context, venueID uuid.UUID, gates []*entities.VenueGate) error {
+	if _, err := r.db.ExecContext(ctx, `DELETE FROM venue_gates WHERE venue_id = $1`, venueID); err != nil {
+		return fmt.Errorf("failed to clear venue gates: %w", err)
+	}
+	
+	if len(gates) == 0 {
+		return nil
+	}
+	
+	query := `
+		INSERT INTO venue_gates (
+			id, venue_id, name, kind, description, is_accessible, position, created_at, updated_at
+		) VALUES (
+			:id, :venue_id, :name, :kind, :description, :is_accessible, :position, :created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, gates); err != nil {
+		return fmt.Errorf("failed to create venue gates: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *venueRepository) List(ctx context.Context, filter repositories.VenueFilter) ([]*entities.Venue, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"1 = 1"}
+	args := []interface{}{}
+	argIndex := 1
+	
+	if filter.City != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("lower(city) = lower($%d)", argIndex))
+		args = append(args, strings.TrimSpace(filter.City))
+		argIndex++
+	}
+	
+	if search := strings.TrimSpace(filter.Search); search != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("(name ILIKE $%d OR address ILIKE $%d)", argIndex, argIndex))
+		args = append(args, "%"+search+"%")
+		argIndex++
+	}
+	
+	if filter.IsActive != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("is_active = $%d", argIndex))
+		args = append(args, *filter.IsActive)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM venues WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count venues: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM venues
+		WHERE %s
+		ORDER BY lower(city) ASC, lower(name) ASC
+		LIMIT $%d OFFSET $%d`, venueSelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	var venues []*entities.Venue
+	if err := r.db.SelectContext(ctx, &venues, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list venues: %w", err)
+	}
+	
+	return venues, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
+
+func (r *venueRepository) CountEvents(ctx context.Context, venueID uuid.UUID) (int, error) {
+	var count int
+	query := `SELECT COUNT(*) FROM events WHERE venue_id = $1 AND is_active = true`
+	
+	if err := r.db.GetContext(ctx, &count, query, venueID); err != nil {
+		return 0, fmt.Errorf("failed to count venue events: %w", err)
+	}
+	
+	return count, nil
+}
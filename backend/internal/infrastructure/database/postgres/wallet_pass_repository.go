@@ -0,0 +1,119 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+type walletPassRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+	}
+}
+
+func NewWalletPassRepository(db *sqlx.DB) repositories.WalletPassRepository {
+	return &walletPassRepository{db: db}
+}
+
+func (r *walletPassRepository) Register(ctx context.Context, registration *entities.WalletPassRegistration) (bool, error) {
+	if err := registration.Validate(); err != nil {
+		return false, err
+	}
+	
+	// xmax is 0 only for a freshly inserted row, which tells a new registration
+	// apart from a device refreshing its push token
+	query := `
+		INSERT INTO wallet_pass_registrations (
+			id, device_library_id, push_token, pass_type_id, serial_number, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (device_library_id, pass_type_id, serial_number)
+		DO UPDATE SET push_token = EXCLUDED.push_token, updated_at = EXCLUDED.updated_at
+		RETURNING (xmax = 0) AS inserted`
+	
+	var inserted bool
+	err := r.db.GetContext(ctx, &inserted, query,
+		registration.ID, registration.DeviceLibraryID, registration.PushToken,
+		registration.PassTypeID, registration.SerialNumber,
+		registration.CreatedAt, registration.UpdatedAt)
+	if err != nil {
+		return false, fmt.Errorf("failed to register wallet pass device: %w", err)
+	}
+	
+	return inserted, nil
+}
+
+func (r *walletPassRepository) Unregister(ctx context.Context, deviceLibraryID, passTypeID, serialNumber string) error {
+	query := `
+		DELETE FROM wallet_pass_registrations
+		WHERE device_library_id = $1 AND pass_type_id = $2 AND serial_number = $3`
+	
+	if _, err := r.db.ExecContext(ctx, query, deviceLibraryID, passTypeID, serialNumber); err != nil {
+		return fmt.Errorf("failed to unregister wallet pass device: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *walletPassRepository) GetBySerialNumber(ctx context.Context, passTypeID, serialNumber string) ([]*entities.WalletPassRegistration, error) {
+	var registrations []*entities.WalletPassRegistration
+	query := `
+		SELECT id, device_library_id, push_token, pass_type_id, serial_number, created_at, updated_at
+		FROM wallet_pass_registrations
+		WHERE pass_type_id = $1 AND serial_number = $2`
+	
+	if err := r.db.SelectContext(ctx, &registrations, query, passTypeID, serialNumber); err != nil {
+		return nil, fmt.Errorf("failed to get wallet pass registrations: %w", err)
+	}
+	
+	return registrations, nil
+}
+
+func (r *walletPassRepository) GetUpdatedSerialNumbers(ctx context.Context, deviceLibraryID, passTypeID string, since *time.Time) (*repositories.WalletPassUpdates, error) {
+	var rows []struct {
+		SerialNumber string    `db:"serial_number"`
+		UpdatedAt    time.Time `db:"updated_at"`
+	}
+	
+	query := `
+		SELECT t.serial_number, t.updated_at
+		FROM wallet_pass_registrations r
+		JOIN tickets t ON t.serial_number = r.serial_number
+		WHERE r.device_library_id = $1
+		  AND r.pass_type_id = $2
+		  AND ($3::timestamptz IS NULL OR t.updated_at > $3::timestamptz)
+		ORDER BY t.updated_at ASC`
+	
+	if err := r.db.SelectContext(ctx, &rows, query, deviceLibraryID, passTypeID, since); err != nil {
+		return nil, fmt.Errorf("failed to get updated wallet passes: %w", err)
+	}
+	
+	updates := &repositories.WalletPassUpdates{
+		SerialNumbers: make([]string, 0, len(rows)),
+	}
+	for _, row := range rows {
+		updates.SerialNumbers = append(updates.SerialNumbers, row.SerialNumber)
+		if row.UpdatedAt.After(updates.LastUpdated) {
+			updates.LastUpdated = row.UpdatedAt
+		}
+	}
+	
+	return updates, nil
+}
+
+func (r *walletPassRepository) DeleteByPushToken(ctx context.Context, pushToken string) error {
+	query := `DELETE FROM wallet_pass_registrations WHERE push_token = $1`
+	
+	if _, err := r.db.ExecContext(ctx, query, pushToken); err != nil {
+		return fmt.Errorf("failed to delete wallet pass registrations: %w", err)
+	}
+	
+	return nil
+}
@@ -0,0 +1,310 @@
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+	"github.com/lib/pq"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const webhookSubscriptionSelectColumns = `id, organizer_id, url, description, secret, event_types, is_active,
+	consecutive_failures, disabled_at, disabled_reason, last_success_at, last_failure_at, created_at, updated_at`
+
+const webhookDeliverySelectColumns = `id, subscription_id, event_type, aggregate_id, payload, status, attempts,
+	last_status_code, last_error, last_response, last_attempt_at, delivered_at, created_at, updated_at`
+
+type webhookRepository struct {
+	db interface {
+		ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+		GetContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		SelectContext(ctx context.Context, dest interface{}, query string, args ...interface{}) error
+		NamedExecContext(ctx context.Context, query string, arg interface{}) (sql.Result, error)
+	}
+}
+
+func NewWebhookRepository(db *sqlx.DB) repositories.WebhookRepository {
+	return &webhookRepository{db: db}
+}
+
+func NewWebhookRepositoryWithTx(tx *sqlx.Tx) repositories.WebhookRepository {
+	return &webhookRepository{db: tx}
+}
+
+func (r *webhookRepository) CreateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	query := `
+		INSERT INTO webhook_subscriptions (
+			id, organizer_id, url, description, secret, event_types, is_active,
+			consecutive_failures, created_at, updated_at
+		) VALUES (
+			:id, :organizer_id, :url, :description, :secret, :event_types, :is_active,
+			:consecutive_failures, :created_at, :updated_at
+		)`
+	
+	if _, err := r.db.NamedExecContext(ctx, query, subscription); err != nil {
+		if pqErr, ok := err.(*pq.Error); ok && pqErr.Code == "23503" {
+			return entities.NewValidationError("organizer_id", "organizer does not exist")
+		}
+		return fmt.Errorf("failed to create webhook subscription: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *webhookRepository) GetSubscription(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	var subscription entities.WebhookSubscription
+	query := fmt.Sprintf(`SELECT %s FROM webhook_subscriptions WHERE id = $1`, webhookSubscriptionSelectColumns)
+	
+	err := r.db.GetContext(ctx, &subscription, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrWebhookSubscriptionNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook subscription: %w", err)
+	}
+	
+	return &subscription, nil
+}
+
+func (r *webhookRepository) UpdateSubscription(ctx context.Context, subscription *entities.WebhookSubscription) error {
+	query := `
+		UPDATE webhook_subscriptions SET
+			url = :url,
+			description = :description,
+			secret = :secret,
+			event_types = :event_types,
+			is_active = :is_active,
+			consecutive_failures = :consecutive_failures,
+			disabled_at = :disabled_at,
+			disabled_reason = :disabled_reason,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, subscription)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook subscription: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrWebhookSubscriptionNotFound
+	}
+	
+	return nil
+}
+
+func (r *webhookRepository) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	result, err := r.db.ExecContext(ctx, `DELETE FROM webhook_subscriptions WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook subscription: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrWebhookSubscriptionNotFound
+	}
+	
+	return nil
+}
+
+func (r *webhookRepository) ListSubscriptions(ctx context.Context, organizerID uuid.UUID) ([]*entities.WebhookSubscription, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM webhook_subscriptions
+		WHERE organizer_id = $1
+		ORDER BY created_at ASC`, webhookSubscriptionSelectColumns)
+	
+	subscriptions := []*entities.WebhookSubscription{}
+	if err := r.db.SelectContext(ctx, &subscriptions, query, organizerID); err != nil {
+		return nil, fmt.Errorf("failed to list webhook subscriptions: %w", err)
+	}
+	
+	return subscriptions, nil
+}
+
+func (r *webhookRepository) ListActiveSubscriptions(ctx context.Context, organizerID uuid.UUID, eventType string) ([]*entities.WebhookSubscription, error) {
+	query := fmt.Sprintf(`
+		SELECT %s FROM webhook_subscriptions
+		WHERE organizer_id = $1
+		  AND is_active
+		  AND event_types @> jsonb_build_array($2::text)
+		ORDER BY created_at ASC`, webhookSubscriptionSelectColumns)
+	
+	subscriptions := []*entities.WebhookSubscription{}
+	if err := r.db.SelectContext(ctx, &subscriptions, query, organizerID, eventType); err != nil {
+		return nil, fmt.Errorf("failed to list active webhook subscriptions: %w", err)
+	}
+	
+	return subscriptions, nil
+}
+
+func (r *webhookRepository) RecordSuccess(ctx context.Context, id uuid.UUID) error {
+	query := `
+		UPDATE webhook_subscriptions SET
+			consecutive_failures = 0,
+			last_success_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1`
+	
+	if _, err := r.db.ExecContext(ctx, query, id); err != nil {
+		return fmt.Errorf("failed to record webhook success: %w", err)
+	}
+	
+	return nil
+}
+
+func (r *webhookRepository) RecordFailure(ctx context.Context, id uuid.UUID, maxFailures int) (bool, error) {
+	// Counting in SQL keeps concurrent deliveries to one subscription from losing failures
+	query := `
+		UPDATE webhook_subscriptions SET
+			consecutive_failures = consecutive_failures + 1,
+			is_active = is_active AND consecutive_failures + 1 < $2,
+			disabled_at = CASE
+				WHEN is_active AND consecutive_failures + 1 >= $2 THEN NOW()
+				ELSE disabled_at
+			END,
+			disabled_reason = CASE
+				WHEN is_active AND consecutive_failures + 1 >= $2 THEN $3
+				ELSE disabled_reason
+			END,
+			last_failure_at = NOW(),
+			updated_at = NOW()
+		WHERE id = $1
+		RETURNING is_active`
+	
+	reason := fmt.Sprintf("disabled after %d failed deliveries in a row", maxFailures)
+	var active bool
+	if err := r.db.GetContext(ctx, &active, query, id, maxFailures, reason); err != nil {
+		if err == sql.ErrNoRows {
+			return false, entities.ErrWebhookSubscriptionNotFound
+		}
+		return false, fmt.Errorf("failed to record webhook failure: %w", err)
+	}
+	
+	return !active, nil
+}
+
+func (r *webhookRepository) CreateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) (bool, error) {
+	query := `
+		INSERT INTO webhook_deliveries (
+			id, subscription_id, event_type, aggregate_id, payload, status, attempts,
+			created_at, updated_at
+		) VALUES (
+			:id, :subscription_id, :event_type, :aggregate_id, :payload, :status, :attempts,
+			:created_at, :updated_at
+		)
+		ON CONFLICT (subscription_id, event_type, aggregate_id) DO NOTHING`
+	
+	result, err := r.db.NamedExecContext(ctx, query, delivery)
+	if err != nil {
+		return false, fmt.Errorf("failed to create webhook delivery: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	return rowsAffected > 0, nil
+}
+
+func (r *webhookRepository) GetDelivery(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	var delivery entities.WebhookDelivery
+	query := fmt.Sprintf(`SELECT %s FROM webhook_deliveries WHERE id = $1`, webhookDeliverySelectColumns)
+	
+	err := r.db.GetContext(ctx, &delivery, query, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entities.ErrWebhookDeliveryNotFound
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+	
+	return &delivery, nil
+}
+
+func (r *webhookRepository) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	query := `
+		UPDATE webhook_deliveries SET
+			status = :status,
+			attempts = :attempts,
+			last_status_code = :last_status_code,
+			last_error = :last_error,
+			last_response = :last_response,
+			last_attempt_at = :last_attempt_at,
+			delivered_at = :delivered_at,
+			updated_at = :updated_at
+		WHERE id = :id`
+	
+	result, err := r.db.NamedExecContext(ctx, query, delivery)
+	if err != nil {
+		return fmt.Errorf("failed to update webhook delivery: %w", err)
+	}
+	
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	
+	if rowsAffected == 0 {
+		return entities.ErrWebhookDeliveryNotFound
+	}
+	
+	return nil
+}
+
+func (r *webhookRepository) ListDeliveries(ctx context.Context, filter repositories.WebhookDeliveryFilter) ([]*entities.WebhookDelivery, *repositories.PaginationResult, error) {
+	if err := filter.BaseFilter.Validate(); err != nil {
+		return nil, nil, err
+	}
+	
+	whereConditions := []string{"subscription_id = $1"}
+	args := []interface{}{filter.SubscriptionID}
+	argIndex := 2
+	
+	if filter.Status != nil {
+		whereConditions = append(whereConditions, fmt.Sprintf("status = $%d", argIndex))
+		args = append(args, *filter.Status)
+		argIndex++
+	}
+	
+	if filter.EventType != "" {
+		whereConditions = append(whereConditions, fmt.Sprintf("event_type = $%d", argIndex))
+		args = append(args, filter.EventType)
+		argIndex++
+	}
+	
+	whereClause := strings.Join(whereConditions, " AND ")
+	
+	var total int
+	countQuery := fmt.Sprintf("SELECT COUNT(*) FROM webhook_deliveries WHERE %s", whereClause)
+	if err := r.db.GetContext(ctx, &total, countQuery, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to count webhook deliveries: %w", err)
+	}
+	
+	query := fmt.Sprintf(`
+		SELECT %s FROM webhook_deliveries
+		WHERE %s
+		ORDER BY created_at DESC
+		LIMIT $%d OFFSET $%d`, webhookDeliverySelectColumns, whereClause, argIndex, argIndex+1)
+	args = append(args, filter.Limit, filter.GetOffset())
+	
+	deliveries := []*entities.WebhookDelivery{}
+	if err := r.db.SelectContext(ctx, &deliveries, query, args...); err != nil {
+		return nil, nil, fmt.Errorf("failed to list webhook deliveries: %w", err)
+	}
+	
+	return deliveries, repositories.NewPaginationResult(filter.Page, filter.Limit, total), nil
+}
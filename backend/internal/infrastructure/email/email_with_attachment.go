@@ -8,6 +8,8 @@ import (
 	"net/smtp"
 	"net/textproto"
 	"strings"
+
+	"github.com/uduxpass/backend/internal/domain/services"
 )
 
 // EmailAttachment represents a file attachment
@@ -17,12 +19,13 @@ type EmailAttachment struct {
 	Data        []byte
 }
 
-// sendEmailWithAttachments sends an email with PDF attachments
-func (s *SMTPEmailService) sendEmailWithAttachments(to, subject, htmlBody string, attachments []EmailAttachment) error {
+// sendEmailWithAttachments sends an email with PDF attachments. The message
+// body is a multipart/alternative part carrying its plain text and HTML.
+func (s *SMTPEmailService) sendEmailWithAttachments(to string, message *services.RenderedMessage, attachments []EmailAttachment) error {
 	// If SMTP is not configured, log and return (dev mode)
 	if s.host == "" || s.port == "" {
-		fmt.Printf("[Email Service] Would send email with %d attachments to %s: %s\n", len(attachments), to, subject)
-		fmt.Printf("[Email Service] Body preview: %s\n", htmlBody[:min(len(htmlBody), 200)])
+		fmt.Printf("[Email Service] Would send email with %d attachments to %s: %s\n", len(attachments), to, message.Subject)
+		fmt.Printf("[Email Service] Body preview: %s\n", message.Text[:min(len(message.Text), 200)])
 		return nil
 	}
 
@@ -37,7 +40,7 @@ func (s *SMTPEmailService) sendEmailWithAttachments(to, subject, htmlBody string
 	headers := make(map[string]string)
 	headers["From"] = s.from
 	headers["To"] = to
-	headers["Subject"] = subject
+	headers["Subject"] = encodeSubject(message.Subject)
 	headers["MIME-Version"] = "1.0"
 	headers["Content-Type"] = fmt.Sprintf("multipart/mixed; boundary=%s", boundary)
 
@@ -48,17 +51,21 @@ func (s *SMTPEmailService) sendEmailWithAttachments(to, subject, htmlBody string
 	}
 	headerStr.WriteString("\r\n")
 
-	// Write HTML body part
-	htmlPart, err := writer.CreatePart(textproto.MIMEHeader{
-		"Content-Type":              []string{"text/html; charset=UTF-8"},
-		"Content-Transfer-Encoding": []string{"quoted-printable"},
+	// Write the text and HTML bodies as one alternative part
+	var body bytes.Buffer
+	bodyWriter := multipart.NewWriter(&body)
+	if err := writeAlternatives(bodyWriter, message); err != nil {
+		return err
+	}
+	bodyPart, err := writer.CreatePart(textproto.MIMEHeader{
+		"Content-Type": []string{fmt.Sprintf("multipart/alternative; boundary=%s", bodyWriter.Boundary())},
 	})
 	if err != nil {
-		return fmt.Errorf("failed to create HTML part: %w", err)
+		return fmt.Errorf("failed to create body part: %w", err)
 	}
-	_, err = htmlPart.Write([]byte(htmlBody))
+	_, err = bodyPart.Write(body.Bytes())
 	if err != nil {
-		return fmt.Errorf("failed to write HTML body: %w", err)
+		return fmt.Errorf("failed to write body: %w", err)
 	}
 
 	// Add attachments
@@ -95,17 +102,17 @@ func (s *SMTPEmailService) sendEmailWithAttachments(to, subject, htmlBody string
 	}
 
 	// Combine headers and body
-	message := []byte(headerStr.String() + buf.String())
+	msg := []byte(headerStr.String() + buf.String())
 
 	// Send email via SMTP
 	auth := smtp.PlainAuth("", s.username, s.password, s.host)
 	addr := fmt.Sprintf("%s:%s", s.host, s.port)
 
-	err = smtp.SendMail(addr, auth, s.from, []string{to}, message)
+	err = smtp.SendMail(addr, auth, s.from, []string{to}, msg)
 	if err != nil {
 		return fmt.Errorf("failed to send email: %w", err)
 	}
 
-	fmt.Printf("[Email Service] Successfully sent email with %d attachments to %s: %s\n", len(attachments), to, subject)
+	fmt.Printf("[Email Service] Successfully sent email with %d attachments to %s: %s\n", len(attachments), to, message.Subject)
 	return nil
 }
@@ -0,0 +1,48 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// SendCheckoutRecoveryEmail invites a customer back to an order they left unpaid
+func (s *SMTPEmailService) SendCheckoutRecoveryEmail(ctx context.Context, recovery *entities.CheckoutRecovery, event *entities.Event, orderLines []*entities.OrderLine) error {
+	if recovery.Email == nil || *recovery.Email == "" {
+		return fmt.Errorf("recipient has no email address")
+	}
+
+	items := make([]map[string]interface{}, 0, len(orderLines))
+	for _, line := range orderLines {
+		items = append(items, map[string]interface{}{
+			"Name":     line.TicketTierName,
+			"Quantity": line.Quantity,
+		})
+	}
+	data := map[string]interface{}{
+		"FirstName":   recovery.FirstName(),
+		"EventName":   event.Name,
+		"EventDate":   event.EventDate,
+		"VenueName":   event.VenueName,
+		"Items":       items,
+		"Total":       recovery.Amount,
+		"Currency":    recovery.Currency,
+		"Expired":     recovery.Reason == entities.CheckoutOrderExpired,
+		"RecoveryURL": fmt.Sprintf("%s/checkout/recover?token=%s", os.Getenv("FRONTEND_URL"), recovery.Token),
+	}
+
+	if messageContext := services.MessageContextFrom(ctx); messageContext.UserID == nil && recovery.UserID != nil {
+		messageContext.UserID = recovery.UserID
+		ctx = services.WithMessageContext(ctx, messageContext)
+	}
+
+	message, err := s.render(ctx, services.MessageCheckoutRecovery, event.OrganizerID, data)
+	if err != nil {
+		return err
+	}
+
+	return s.sendEmail(*recovery.Email, message)
+}
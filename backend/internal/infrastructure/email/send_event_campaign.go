@@ -0,0 +1,46 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// SendEventCampaignEmail sends a ticket holder an event reminder or follow-up
+func (s *SMTPEmailService) SendEventCampaignEmail(ctx context.Context, campaign *entities.EventCampaign, event *entities.Event, delivery *entities.CampaignDelivery) error {
+	if delivery.Email == nil || *delivery.Email == "" {
+		return fmt.Errorf("recipient has no email address")
+	}
+
+	key := services.MessageEventReminder
+	data := map[string]interface{}{
+		"FirstName":      delivery.FirstName(),
+		"EventName":      event.Name,
+		"VenueName":      event.VenueName,
+		"Message":        stringValue(campaign.Message),
+		"UnsubscribeURL": fmt.Sprintf("%s/unsubscribe?token=%s", os.Getenv("FRONTEND_URL"), delivery.UnsubscribeToken),
+	}
+	if campaign.Type == entities.CampaignFollowUp {
+		key = services.MessageEventFollowUp
+		data["SurveyURL"] = stringValue(campaign.SurveyURL)
+	} else {
+		data["EventDate"] = event.EventDate
+		data["DoorsOpen"] = event.DoorsOpen
+		data["VenueAddress"] = event.VenueAddress
+	}
+
+	if messageContext := services.MessageContextFrom(ctx); messageContext.UserID == nil && delivery.UserID != nil {
+		messageContext.UserID = delivery.UserID
+		ctx = services.WithMessageContext(ctx, messageContext)
+	}
+
+	message, err := s.render(ctx, key, event.OrganizerID, data)
+	if err != nil {
+		return err
+	}
+
+	return s.sendEmail(*delivery.Email, message)
+}
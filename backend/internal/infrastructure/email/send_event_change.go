@@ -0,0 +1,54 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"os"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// SendEventChangeEmail tells a ticket holder that their event was cancelled or postponed
+func (s *SMTPEmailService) SendEventChangeEmail(ctx context.Context, change *entities.EventChange, event *entities.Event, recipient *entities.EventChangeRecipient) error {
+	if recipient.Email == nil || *recipient.Email == "" {
+		return fmt.Errorf("recipient has no email address")
+	}
+
+	data := map[string]interface{}{
+		"FirstName":    recipient.FirstName(),
+		"EventName":    event.Name,
+		"VenueName":    event.VenueName,
+		"Cancelled":    change.Type == entities.EventChangeCancellation,
+		"PreviousDate": change.PreviousDate,
+		"NewDate":      change.NewDate,
+		"Reason":       stringValue(change.Reason),
+		"Message":      stringValue(change.Message),
+		"TicketsVoid":  change.VoidTickets,
+		"Refunded":     recipient.RefundStatus != entities.RecipientRefundNone && change.RefundPolicy == entities.RefundPolicyAutomatic,
+		"CanChoose":    change.RefundPolicy == entities.RefundPolicyChoice && recipient.RefundChoice == nil,
+	}
+	if change.RefundDeadline != nil {
+		data["RefundDeadline"] = change.RefundDeadline
+		data["ChoiceURL"] = fmt.Sprintf("%s/event-changes/choice?token=%s", os.Getenv("FRONTEND_URL"), recipient.ChoiceToken)
+	}
+
+	if messageContext := services.MessageContextFrom(ctx); messageContext.UserID == nil && recipient.UserID != nil {
+		messageContext.UserID = recipient.UserID
+		ctx = services.WithMessageContext(ctx, messageContext)
+	}
+
+	message, err := s.render(ctx, services.MessageEventChange, event.OrganizerID, data)
+	if err != nil {
+		return err
+	}
+
+	return s.sendEmail(*recipient.Email, message)
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
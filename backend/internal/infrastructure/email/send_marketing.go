@@ -0,0 +1,51 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// SendMarketingEmail sends a consenting audience member a marketing campaign,
+// branded for the campaign's organizer
+func (s *SMTPEmailService) SendMarketingEmail(ctx context.Context, campaign *entities.MarketingCampaign, delivery *entities.MarketingDelivery) error {
+	if delivery.Email == "" {
+		return fmt.Errorf("recipient has no email address")
+	}
+
+	data := map[string]interface{}{
+		"FirstName":      delivery.FirstName(),
+		"Subject":        campaign.Subject,
+		"Paragraphs":     paragraphs(campaign.Message),
+		"LinkURL":        stringValue(campaign.LinkURL),
+		"LinkLabel":      stringValue(campaign.LinkLabel),
+		"UnsubscribeURL": fmt.Sprintf("%s/marketing/unsubscribe?token=%s", os.Getenv("FRONTEND_URL"), delivery.UnsubscribeToken),
+	}
+
+	if messageContext := services.MessageContextFrom(ctx); messageContext.UserID == nil && delivery.UserID != nil {
+		messageContext.UserID = delivery.UserID
+		ctx = services.WithMessageContext(ctx, messageContext)
+	}
+
+	message, err := s.render(ctx, services.MessageMarketing, campaign.OrganizerID, data)
+	if err != nil {
+		return err
+	}
+
+	return s.sendEmail(delivery.Email, message)
+}
+
+// paragraphs splits a plain-text message on blank lines
+func paragraphs(message string) []string {
+	var result []string
+	for _, block := range strings.Split(strings.ReplaceAll(message, "\r\n", "\n"), "\n\n") {
+		if block = strings.TrimSpace(block); block != "" {
+			result = append(result, block)
+		}
+	}
+	return result
+}
@@ -30,6 +30,16 @@ func NewMoMoProvider(apiKey, apiSecret string ) *MoMoProvider {
 	}
 }
 
+// GetSupportedCurrencies returns the currencies MoMo PSB can settle
+func (m *MoMoProvider) GetSupportedCurrencies() []string {
+	return []string{entities.CurrencyNGN}
+}
+
+// SupportsCurrency checks if MoMo PSB can charge in the given currency
+func (m *MoMoProvider) SupportsCurrency(currency string) bool {
+	return supportsCurrency(m.GetSupportedCurrencies(), currency)
+}
+
 // InitializePayment initializes a payment with Mobile Money
 func (m *MoMoProvider ) InitializePayment(order *entities.Order) (*PaymentResponse, error) {
 	url := fmt.Sprintf("%s/payments/initialize", m.baseURL)
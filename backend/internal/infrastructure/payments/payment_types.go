@@ -2,6 +2,7 @@ package payments
 
 import (
 	"context"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
@@ -212,3 +213,14 @@ const (
 	ErrCodeRefundNotSupported   = "REFUND_NOT_SUPPORTED"
 )
 
+
+// supportsCurrency checks if a currency appears in a provider's supported list
+func supportsCurrency(supported []string, currency string) bool {
+	currency = strings.ToUpper(strings.TrimSpace(currency))
+	for _, c := range supported {
+		if c == currency {
+			return true
+		}
+	}
+	return false
+}
@@ -29,18 +29,33 @@ func NewPaystackProvider(secretKey string ) *PaystackProvider {
 	}
 }
 
+// GetSupportedCurrencies returns the currencies Paystack can settle
+func (p *PaystackProvider) GetSupportedCurrencies() []string {
+	return []string{entities.CurrencyNGN, entities.CurrencyGHS, entities.CurrencyKES, entities.CurrencyUSD}
+}
+
+// SupportsCurrency checks if Paystack can charge in the given currency
+func (p *PaystackProvider) SupportsCurrency(currency string) bool {
+	return supportsCurrency(p.GetSupportedCurrencies(), currency)
+}
+
 // InitializePayment initializes a payment with Paystack
 func (p *PaystackProvider ) InitializePayment(order *entities.Order) (*PaymentResponse, error) {
 	url := fmt.Sprintf("%s/transaction/initialize", p.baseURL)
 	
-	// Convert amount to kobo (Paystack uses kobo for NGN)
-	amountInKobo := int64(order.TotalAmount * 100)
+	currency := order.Currency
+	if currency == "" {
+		currency = entities.DefaultCurrency
+	}
+	
+	// Paystack expects the amount in the currency's subunit (kobo, pesewas, cents)
+	amountInSubunit := entities.ToMinorUnits(order.TotalAmount, currency)
 	
 	payload := map[string]interface{}{
 		"email":     order.CustomerEmail,
-		"amount":    amountInKobo,
+		"amount":    amountInSubunit,
 		"reference": order.ID.String(),
-		"currency":  "NGN",
+		"currency":  currency,
 		"metadata": map[string]interface{}{
 			"order_id": order.ID.String(),
 		},
@@ -95,7 +110,7 @@ func (p *PaystackProvider ) InitializePayment(order *entities.Order) (*PaymentRe
 		Reference: order.ID.String(),
 		Status:    PaymentStatusPending,
 		Amount:    order.TotalAmount,
-		Currency:  "NGN",
+		Currency:  currency,
 		Method:    entities.PaymentMethodCard,
 		Message:   authURL, // Store authorization URL in message field
 		CreatedAt: time.Now(),
@@ -159,8 +174,8 @@ func (p *PaystackProvider) VerifyPayment(reference string) (*PaymentResponse, er
 		}
 	}
 	
-	// Convert amount from kobo to naira
-	amount := float64(verifyResp.Data.Amount) / 100
+	// Convert amount from the currency subunit to major units
+	amount := entities.FromMinorUnits(verifyResp.Data.Amount, verifyResp.Data.Currency)
 	
 	return &PaymentResponse{
 		ID:        strconv.FormatInt(verifyResp.Data.ID, 10),
@@ -214,12 +229,16 @@ func (p *PaystackProvider) ProcessWebhook(payload []byte) (*PaymentResponse, err
 func (p *PaystackProvider) InitializeTransaction(ctx context.Context, request PaystackPaymentRequest) (*PaymentResponse, error) {
 	url := fmt.Sprintf("%s/transaction/initialize", p.baseURL)
 	
-	// Convert amount to kobo (Paystack uses kobo for NGN)
-	amountInKobo := int64(request.Amount * 100)
+	if !p.SupportsCurrency(request.Currency) {
+		return nil, fmt.Errorf("paystack does not support currency %s", request.Currency)
+	}
+	
+	// Paystack expects the amount in the currency's subunit (kobo, pesewas, cents)
+	amountInSubunit := entities.ToMinorUnits(request.Amount, request.Currency)
 	
 	payload := map[string]interface{}{
 		"email":        request.Email,
-		"amount":       amountInKobo,
+		"amount":       amountInSubunit,
 		"reference":    request.Reference,
 		"currency":     request.Currency,
 		"callback_url": request.CallbackURL,
@@ -278,6 +297,88 @@ func (p *PaystackProvider) InitializeTransaction(ctx context.Context, request Pa
 	}, nil
 }
 
+// RefundTransaction refunds a Paystack transaction. A zero amount refunds it in full.
+// Paystack rejects refunds beyond what is left on a transaction, so a repeated
+// request for an already refunded transaction fails instead of paying out twice.
+func (p *PaystackProvider) RefundTransaction(ctx context.Context, reference string, amount float64, currency string) (*RefundPaymentResponse, error) {
+	url := fmt.Sprintf("%s/refund", p.baseURL)
+	
+	payload := map[string]interface{}{
+		"transaction": reference,
+	}
+	if amount > 0 {
+		payload["amount"] = entities.ToMinorUnits(amount, currency)
+	}
+	
+	jsonPayload, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewBuffer(jsonPayload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	
+	req.Header.Set("Authorization", "Bearer "+p.secretKey)
+	req.Header.Set("Content-Type", "application/json")
+	
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+	
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	
+	var paystackResp PaystackResponse
+	if err := json.Unmarshal(body, &paystackResp); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal response: %w", err)
+	}
+	
+	if !paystackResp.Status {
+		return nil, fmt.Errorf("paystack error: %s", paystackResp.Message)
+	}
+	
+	// Refunds are settled asynchronously; "pending" means Paystack accepted the request
+	var status RefundStatus
+	switch paystackResp.Data["status"] {
+	case "processed":
+		status = RefundStatusSuccess
+	case "failed":
+		status = RefundStatusFailed
+	default:
+		status = RefundStatusPending
+	}
+	
+	refundID := ""
+	if id, ok := paystackResp.Data["id"].(float64); ok {
+		refundID = strconv.FormatInt(int64(id), 10)
+	}
+	refundCurrency, _ := paystackResp.Data["currency"].(string)
+	if refundCurrency == "" {
+		refundCurrency = currency
+	}
+	refundAmount := amount
+	if minor, ok := paystackResp.Data["amount"].(float64); ok {
+		refundAmount = entities.FromMinorUnits(int64(minor), refundCurrency)
+	}
+	now := time.Now()
+	
+	return &RefundPaymentResponse{
+		RefundID:         refundID,
+		PaymentReference: reference,
+		Status:           status,
+		Amount:           refundAmount,
+		Currency:         refundCurrency,
+		ProcessedAt:      &now,
+		Metadata:         paystackResp.Data,
+	}, nil
+}
+
 
 // PaystackPaymentRequest represents a Paystack payment request (local definition)
 type PaystackPaymentRequest struct {
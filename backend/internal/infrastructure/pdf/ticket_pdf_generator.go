@@ -36,8 +36,35 @@ type TicketData struct {
 
 // GenerateTicketPDF generates a professional PDF ticket
 func (g *TicketPDFGenerator) GenerateTicketPDF(ticket TicketData) ([]byte, error) {
+	return g.GenerateOrderPDF([]TicketData{ticket})
+}
+
+// GenerateOrderPDF generates one PDF with a page per ticket, for channels
+// that deliver a single document per order
+func (g *TicketPDFGenerator) GenerateOrderPDF(tickets []TicketData) ([]byte, error) {
+	if len(tickets) == 0 {
+		return nil, fmt.Errorf("no tickets to generate a PDF for")
+	}
+
 	// Create new PDF
 	pdf := gofpdf.New("P", "mm", "A4", "")
+	for i, ticket := range tickets {
+		if err := g.addTicketPage(pdf, ticket, fmt.Sprintf("qrcode-%d", i)); err != nil {
+			return nil, err
+		}
+	}
+
+	// Output PDF to buffer
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, fmt.Errorf("failed to generate PDF: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// addTicketPage adds one ticket's page; qrImageName must be unique within the document
+func (g *TicketPDFGenerator) addTicketPage(pdf *gofpdf.Fpdf, ticket TicketData, qrImageName string) error {
 	pdf.AddPage()
 
 	// Set margins
@@ -59,7 +86,7 @@ func (g *TicketPDFGenerator) GenerateTicketPDF(ticket TicketData) ([]byte, error
 	// QR Code Section
 	qrImage, err := g.generateQRCodeImage(ticket.QRCode)
 	if err != nil {
-		return nil, fmt.Errorf("failed to generate QR code: %w", err)
+		return fmt.Errorf("failed to generate QR code: %w", err)
 	}
 
 	// Center QR code
@@ -71,8 +98,8 @@ func (g *TicketPDFGenerator) GenerateTicketPDF(ticket TicketData) ([]byte, error
 	imageOpts := gofpdf.ImageOptions{
 		ImageType: "PNG",
 	}
-	pdf.RegisterImageOptionsReader("qrcode", imageOpts, bytes.NewReader(qrImage))
-	pdf.ImageOptions("qrcode", qrX, pdf.GetY(), qrSize, qrSize, false, imageOpts, 0, "")
+	pdf.RegisterImageOptionsReader(qrImageName, imageOpts, bytes.NewReader(qrImage))
+	pdf.ImageOptions(qrImageName, qrX, pdf.GetY(), qrSize, qrSize, false, imageOpts, 0, "")
 	pdf.Ln(qrSize + 10)
 
 	// Ticket Code
@@ -147,14 +174,7 @@ func (g *TicketPDFGenerator) GenerateTicketPDF(ticket TicketData) ([]byte, error
 	pdf.CellFormat(0, 5, "Powered by uduXPass - Enterprise Ticketing Platform", "", 1, "C", false, 0, "")
 	pdf.CellFormat(0, 5, fmt.Sprintf("Generated on %s", time.Now().Format("January 2, 2006 at 3:04 PM")), "", 1, "C", false, 0, "")
 
-	// Output PDF to buffer
-	var buf bytes.Buffer
-	err = pdf.Output(&buf)
-	if err != nil {
-		return nil, fmt.Errorf("failed to generate PDF: %w", err)
-	}
-
-	return buf.Bytes(), nil
+	return nil
 }
 
 // addInfoRow adds a labeled information row
@@ -0,0 +1,129 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// AfricasTalkingProvider sends SMS through Africa's Talking
+type AfricasTalkingProvider struct {
+	username string
+	apiKey   string
+	baseURL  string
+	client   *http.Client
+}
+
+// NewAfricasTalkingProvider creates an Africa's Talking provider. The
+// "sandbox" username sends through the sandbox API.
+func NewAfricasTalkingProvider(username, apiKey string) *AfricasTalkingProvider {
+	baseURL := "https://api.africastalking.com"
+	if username == "sandbox" {
+		baseURL = "https://api.sandbox.africastalking.com"
+	}
+	return &AfricasTalkingProvider{
+		username: username,
+		apiKey:   apiKey,
+		baseURL:  baseURL,
+		client:   &http.Client{Timeout: requestTimeout},
+	}
+}
+
+var _ services.SMSProvider = (*AfricasTalkingProvider)(nil)
+
+// Name returns "africastalking"
+func (p *AfricasTalkingProvider) Name() string {
+	return ProviderAfricasTalking
+}
+
+type africasTalkingSendResponse struct {
+	SMSMessageData struct {
+		Message    string `json:"Message"`
+		Recipients []struct {
+			StatusCode int    `json:"statusCode"`
+			Number     string `json:"number"`
+			Status     string `json:"status"`
+			MessageID  string `json:"messageId"`
+		} `json:"Recipients"`
+	} `json:"SMSMessageData"`
+}
+
+// Send sends one message through the Africa's Talking messaging API
+func (p *AfricasTalkingProvider) Send(ctx context.Context, to, senderID, body string) (*services.SMSSendResult, error) {
+	form := url.Values{}
+	form.Set("username", p.username)
+	form.Set("to", to)
+	form.Set("message", body)
+	if senderID != "" {
+		form.Set("from", senderID)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/version1/messaging", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("apiKey", p.apiKey)
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result africasTalkingSendResponse
+	raw, err := readResponse(resp, &result)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, statusError(ProviderAfricasTalking, resp.StatusCode, raw)
+	}
+	if len(result.SMSMessageData.Recipients) == 0 {
+		return nil, fmt.Errorf("africastalking error: %s", result.SMSMessageData.Message)
+	}
+
+	recipient := result.SMSMessageData.Recipients[0]
+	switch recipient.StatusCode {
+	case 100, 101, 102:
+		return &services.SMSSendResult{ProviderMessageID: recipient.MessageID}, nil
+	case 402, 403, 404, 406, 407:
+		// Invalid sender ID, invalid or unsupported number, blacklisted or unroutable
+		return nil, fmt.Errorf("%w: africastalking: %s", services.ErrSMSRejected, recipient.Status)
+	default:
+		return nil, fmt.Errorf("africastalking error: %s", recipient.Status)
+	}
+}
+
+// ParseDeliveryReceipts reads an Africa's Talking delivery report, a form post per message
+func (p *AfricasTalkingProvider) ParseDeliveryReceipts(contentType string, body []byte) ([]services.SMSDeliveryReceipt, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid africastalking delivery report: %w", err)
+	}
+	if form.Get("id") == "" {
+		return nil, fmt.Errorf("africastalking delivery report has no id")
+	}
+
+	result := services.SMSDeliveryReceipt{ProviderMessageID: form.Get("id")}
+	switch form.Get("status") {
+	case "Success":
+		result.Status = entities.NotificationDelivered
+	case "Failed", "Rejected", "AbsentSubscriber", "Expired":
+		result.Status = entities.NotificationFailed
+		result.Error = form.Get("status")
+		if reason := form.Get("failureReason"); reason != "" {
+			result.Error += ": " + reason
+		}
+	default:
+		// Sent, Submitted and Buffered report progress
+		result.Status = entities.NotificationSent
+	}
+	return []services.SMSDeliveryReceipt{result}, nil
+}
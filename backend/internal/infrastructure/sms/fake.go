@@ -0,0 +1,123 @@
+package sms
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// FakeMessage is a message the fake provider was asked to send
+type FakeMessage struct {
+	ID       string
+	To       string
+	SenderID string
+	Body     string
+	SentAt   time.Time
+}
+
+// FakeProvider records messages instead of sending them and prints them to
+// the log. It is the default provider in development and stands in for a
+// real gateway in tests, where FailWith simulates provider errors.
+type FakeProvider struct {
+	mu       sync.Mutex
+	messages []FakeMessage
+	failWith error
+}
+
+// NewFakeProvider creates a fake provider with no recorded messages
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{}
+}
+
+var _ services.SMSProvider = (*FakeProvider)(nil)
+
+// Name returns "fake"
+func (p *FakeProvider) Name() string {
+	return ProviderFake
+}
+
+// Send records the message, or returns the error set with FailWith
+func (p *FakeProvider) Send(ctx context.Context, to, senderID, body string) (*services.SMSSendResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failWith != nil {
+		return nil, p.failWith
+	}
+
+	message := FakeMessage{
+		ID:       "fake-" + uuid.NewString(),
+		To:       to,
+		SenderID: senderID,
+		Body:     body,
+		SentAt:   time.Now(),
+	}
+	p.messages = append(p.messages, message)
+	fmt.Printf("[SMS Service] Would send SMS from %s to %s: %s\n", senderID, to, body)
+
+	return &services.SMSSendResult{ProviderMessageID: message.ID}, nil
+}
+
+type fakeReceipt struct {
+	MessageID string                      `json:"message_id"`
+	Status    entities.NotificationStatus `json:"status"`
+	Error     string                      `json:"error"`
+}
+
+// ParseDeliveryReceipts reads {"message_id": "...", "status": "delivered"|"failed", "error": "..."}
+func (p *FakeProvider) ParseDeliveryReceipts(contentType string, body []byte) ([]services.SMSDeliveryReceipt, error) {
+	var receipt fakeReceipt
+	if err := json.Unmarshal(body, &receipt); err != nil {
+		return nil, fmt.Errorf("invalid fake delivery report: %w", err)
+	}
+	if receipt.MessageID == "" {
+		return nil, fmt.Errorf("fake delivery report has no message_id")
+	}
+	return []services.SMSDeliveryReceipt{{
+		ProviderMessageID: receipt.MessageID,
+		Status:            receipt.Status,
+		Error:             receipt.Error,
+	}}, nil
+}
+
+// Messages returns the recorded messages, oldest first
+func (p *FakeProvider) Messages() []FakeMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]FakeMessage(nil), p.messages...)
+}
+
+// MessagesTo returns the recorded messages to one number
+func (p *FakeProvider) MessagesTo(to string) []FakeMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var messages []FakeMessage
+	for _, message := range p.messages {
+		if message.To == to {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// FailWith makes every following Send return err; nil restores sending
+func (p *FakeProvider) FailWith(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failWith = err
+}
+
+// Reset forgets the recorded messages and any failure
+func (p *FakeProvider) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = nil
+	p.failWith = nil
+}
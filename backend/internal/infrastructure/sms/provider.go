@@ -0,0 +1,103 @@
+// Package sms implements services.SMSProvider for the SMS gateways uduXPass
+// can send through, plus a fake provider that records messages instead of
+// sending them. The provider is picked with SMS_PROVIDER.
+package sms
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// Provider names, as used in SMS_PROVIDER and the delivery receipt webhook URL
+const (
+	ProviderTermii         = "termii"
+	ProviderAfricasTalking = "africastalking"
+	ProviderTwilio         = "twilio"
+	ProviderFake           = "fake"
+)
+
+// requestTimeout bounds one call to a provider's API
+const requestTimeout = 15 * time.Second
+
+// Config selects and configures the SMS provider
+type Config struct {
+	Provider string
+
+	TermiiAPIKey  string
+	TermiiBaseURL string
+	// TermiiChannel is "generic" or "dnd"; DND routes reach numbers on the do-not-disturb list
+	TermiiChannel string
+
+	AfricasTalkingUsername string
+	AfricasTalkingAPIKey   string
+
+	TwilioAccountSID string
+	TwilioAuthToken  string
+	// TwilioStatusCallbackURL receives Twilio's delivery receipts; Termii and
+	// Africa's Talking are given their callback URL in their dashboards
+	TwilioStatusCallbackURL string
+}
+
+// NewProvider creates the configured provider. An empty provider name selects
+// the fake provider, so development setups work without an SMS account.
+func NewProvider(config Config) (services.SMSProvider, error) {
+	switch strings.ToLower(config.Provider) {
+	case ProviderTermii:
+		if config.TermiiAPIKey == "" {
+			return nil, fmt.Errorf("TERMII_API_KEY is required for the termii SMS provider")
+		}
+		return NewTermiiProvider(config.TermiiAPIKey, config.TermiiBaseURL, config.TermiiChannel), nil
+	case ProviderAfricasTalking:
+		if config.AfricasTalkingUsername == "" || config.AfricasTalkingAPIKey == "" {
+			return nil, fmt.Errorf("AFRICASTALKING_USERNAME and AFRICASTALKING_API_KEY are required for the africastalking SMS provider")
+		}
+		return NewAfricasTalkingProvider(config.AfricasTalkingUsername, config.AfricasTalkingAPIKey), nil
+	case ProviderTwilio:
+		if config.TwilioAccountSID == "" || config.TwilioAuthToken == "" {
+			return nil, fmt.Errorf("TWILIO_ACCOUNT_SID and TWILIO_AUTH_TOKEN are required for the twilio SMS provider")
+		}
+		return NewTwilioProvider(config.TwilioAccountSID, config.TwilioAuthToken, config.TwilioStatusCallbackURL), nil
+	case ProviderFake, "":
+		return NewFakeProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown SMS provider %q", config.Provider)
+	}
+}
+
+// readResponse reads a provider response, decoding it into out when the call succeeded
+func readResponse(resp *http.Response, out interface{}) ([]byte, error) {
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 && out != nil {
+		if err := json.Unmarshal(body, out); err != nil {
+			return body, fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+	}
+	return body, nil
+}
+
+// statusError turns an unsuccessful response into an error. Client errors
+// other than rate limiting are permanent, so they wrap services.ErrSMSRejected.
+func statusError(provider string, statusCode int, body []byte) error {
+	message := strings.TrimSpace(string(body))
+	if len(message) > 300 {
+		message = message[:300]
+	}
+	if statusCode >= 400 && statusCode < 500 && statusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %s responded with status %d: %s", services.ErrSMSRejected, provider, statusCode, message)
+	}
+	return fmt.Errorf("%s responded with status %d: %s", provider, statusCode, message)
+}
+
+// withoutPlus returns an E.164 number without its leading plus, as some providers expect
+func withoutPlus(phone string) string {
+	return strings.TrimPrefix(phone, "+")
+}
@@ -0,0 +1,75 @@
+package sms
+
+import (
+	"context"
+	"testing"
+)
+
+func TestNewProviderSelectsConfiguredGateway(t *testing.T) {
+	tests := []struct {
+		name     string
+		config   Config
+		wantName string
+		wantErr  bool
+	}{
+		{name: "unset", config: Config{}, wantName: ProviderFake},
+		{name: "fake", config: Config{Provider: "fake"}, wantName: ProviderFake},
+		{name: "termii", config: Config{Provider: "termii", TermiiAPIKey: "key"}, wantName: ProviderTermii},
+		{name: "mixed case", config: Config{Provider: "Termii", TermiiAPIKey: "key"}, wantName: ProviderTermii},
+		{name: "termii without key", config: Config{Provider: "termii"}, wantErr: true},
+		{
+			name:     "africastalking",
+			config:   Config{Provider: "africastalking", AfricasTalkingUsername: "uduxpass", AfricasTalkingAPIKey: "key"},
+			wantName: ProviderAfricasTalking,
+		},
+		{name: "africastalking without key", config: Config{Provider: "africastalking", AfricasTalkingUsername: "uduxpass"}, wantErr: true},
+		{
+			name:     "twilio",
+			config:   Config{Provider: "twilio", TwilioAccountSID: "AC123", TwilioAuthToken: "token"},
+			wantName: ProviderTwilio,
+		},
+		{name: "twilio without token", config: Config{Provider: "twilio", TwilioAccountSID: "AC123"}, wantErr: true},
+		{name: "unknown", config: Config{Provider: "carrier-pigeon"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			provider, err := NewProvider(tt.config)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("NewProvider() = %s, want an error", provider.Name())
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("NewProvider() error = %v", err)
+			}
+			if provider.Name() != tt.wantName {
+				t.Errorf("NewProvider() selected %s, want %s", provider.Name(), tt.wantName)
+			}
+		})
+	}
+}
+
+func TestFakeProviderRecordsMessagesAndReceipts(t *testing.T) {
+	provider := NewFakeProvider()
+
+	result, err := provider.Send(context.Background(), "+2348031234567", "uduXPass", "Your code is 123456")
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if got := provider.MessagesTo("+2348031234567"); len(got) != 1 || got[0].ID != result.ProviderMessageID {
+		t.Fatalf("MessagesTo() = %+v, want the sent message", got)
+	}
+
+	receipts, err := provider.ParseDeliveryReceipts("application/json", []byte(`{"message_id":"`+result.ProviderMessageID+`","status":"delivered"}`))
+	if err != nil {
+		t.Fatalf("ParseDeliveryReceipts() error = %v", err)
+	}
+	if len(receipts) != 1 || receipts[0].ProviderMessageID != result.ProviderMessageID || receipts[0].Status != "delivered" {
+		t.Errorf("ParseDeliveryReceipts() = %+v, want a delivered receipt for %s", receipts, result.ProviderMessageID)
+	}
+	if _, err := provider.ParseDeliveryReceipts("application/json", []byte(`{"status":"delivered"}`)); err == nil {
+		t.Errorf("ParseDeliveryReceipts() accepted a receipt without a message ID")
+	}
+}
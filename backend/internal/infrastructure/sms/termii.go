@@ -0,0 +1,120 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// TermiiProvider sends SMS through Termii
+type TermiiProvider struct {
+	apiKey  string
+	baseURL string
+	channel string
+	client  *http.Client
+}
+
+// NewTermiiProvider creates a Termii provider. baseURL defaults to Termii's
+// Nigerian API and channel to the generic route.
+func NewTermiiProvider(apiKey, baseURL, channel string) *TermiiProvider {
+	if baseURL == "" {
+		baseURL = "https://api.ng.termii.com"
+	}
+	if channel == "" {
+		channel = "generic"
+	}
+	return &TermiiProvider{
+		apiKey:  apiKey,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		channel: channel,
+		client:  &http.Client{Timeout: requestTimeout},
+	}
+}
+
+var _ services.SMSProvider = (*TermiiProvider)(nil)
+
+// Name returns "termii"
+func (p *TermiiProvider) Name() string {
+	return ProviderTermii
+}
+
+type termiiSendResponse struct {
+	MessageID string `json:"message_id"`
+	Message   string `json:"message"`
+}
+
+// Send sends one message through Termii's SMS API
+func (p *TermiiProvider) Send(ctx context.Context, to, senderID, body string) (*services.SMSSendResult, error) {
+	payload, err := json.Marshal(map[string]string{
+		"api_key": p.apiKey,
+		"to":      withoutPlus(to),
+		"from":    senderID,
+		"sms":     body,
+		"type":    "plain",
+		"channel": p.channel,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+"/api/sms/send", bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result termiiSendResponse
+	raw, err := readResponse(resp, &result)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, statusError(ProviderTermii, resp.StatusCode, raw)
+	}
+	if result.MessageID == "" {
+		return nil, fmt.Errorf("termii error: %s", result.Message)
+	}
+
+	return &services.SMSSendResult{ProviderMessageID: result.MessageID}, nil
+}
+
+type termiiReceipt struct {
+	MessageID string `json:"message_id"`
+	Status    string `json:"status"`
+}
+
+// ParseDeliveryReceipts reads a Termii delivery report, a JSON object per message
+func (p *TermiiProvider) ParseDeliveryReceipts(contentType string, body []byte) ([]services.SMSDeliveryReceipt, error) {
+	var receipt termiiReceipt
+	if err := json.Unmarshal(body, &receipt); err != nil {
+		return nil, fmt.Errorf("invalid termii delivery report: %w", err)
+	}
+	if receipt.MessageID == "" {
+		return nil, fmt.Errorf("termii delivery report has no message_id")
+	}
+
+	result := services.SMSDeliveryReceipt{ProviderMessageID: receipt.MessageID}
+	switch strings.ToLower(receipt.Status) {
+	case "delivered":
+		result.Status = entities.NotificationDelivered
+	case "message failed", "rejected", "expired", "dnd active on phone number":
+		result.Status = entities.NotificationFailed
+		result.Error = receipt.Status
+	default:
+		// "Message Sent", "Received" and other progress reports
+		result.Status = entities.NotificationSent
+	}
+	return []services.SMSDeliveryReceipt{result}, nil
+}
@@ -0,0 +1,112 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// TwilioProvider sends SMS through Twilio's Programmable Messaging
+type TwilioProvider struct {
+	accountSID        string
+	authToken         string
+	statusCallbackURL string
+	baseURL           string
+	client            *http.Client
+}
+
+// NewTwilioProvider creates a Twilio provider. statusCallbackURL, when set,
+// is passed with every message so Twilio reports its delivery.
+func NewTwilioProvider(accountSID, authToken, statusCallbackURL string) *TwilioProvider {
+	return &TwilioProvider{
+		accountSID:        accountSID,
+		authToken:         authToken,
+		statusCallbackURL: statusCallbackURL,
+		baseURL:           "https://api.twilio.com",
+		client:            &http.Client{Timeout: requestTimeout},
+	}
+}
+
+var _ services.SMSProvider = (*TwilioProvider)(nil)
+
+// Name returns "twilio"
+func (p *TwilioProvider) Name() string {
+	return ProviderTwilio
+}
+
+type twilioSendResponse struct {
+	SID string `json:"sid"`
+}
+
+// Send sends one message through Twilio. senderID is a Twilio number, an
+// alphanumeric sender ID or a messaging service SID ("MG...").
+func (p *TwilioProvider) Send(ctx context.Context, to, senderID, body string) (*services.SMSSendResult, error) {
+	form := url.Values{}
+	form.Set("To", to)
+	form.Set("Body", body)
+	if strings.HasPrefix(senderID, "MG") {
+		form.Set("MessagingServiceSid", senderID)
+	} else {
+		form.Set("From", senderID)
+	}
+	if p.statusCallbackURL != "" {
+		form.Set("StatusCallback", p.statusCallbackURL)
+	}
+
+	endpoint := fmt.Sprintf("%s/2010-04-01/Accounts/%s/Messages.json", p.baseURL, p.accountSID)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.SetBasicAuth(p.accountSID, p.authToken)
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result twilioSendResponse
+	raw, err := readResponse(resp, &result)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, statusError(ProviderTwilio, resp.StatusCode, raw)
+	}
+
+	return &services.SMSSendResult{ProviderMessageID: result.SID}, nil
+}
+
+// ParseDeliveryReceipts reads a Twilio status callback, a form post per status change
+func (p *TwilioProvider) ParseDeliveryReceipts(contentType string, body []byte) ([]services.SMSDeliveryReceipt, error) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		return nil, fmt.Errorf("invalid twilio status callback: %w", err)
+	}
+	if form.Get("MessageSid") == "" {
+		return nil, fmt.Errorf("twilio status callback has no MessageSid")
+	}
+
+	result := services.SMSDeliveryReceipt{ProviderMessageID: form.Get("MessageSid")}
+	switch form.Get("MessageStatus") {
+	case "delivered":
+		result.Status = entities.NotificationDelivered
+	case "undelivered", "failed":
+		result.Status = entities.NotificationFailed
+		result.Error = form.Get("MessageStatus")
+		if code := form.Get("ErrorCode"); code != "" {
+			result.Error += " (error " + code + ")"
+		}
+	default:
+		// queued, sending and sent report progress
+		result.Status = entities.NotificationSent
+	}
+	return []services.SMSDeliveryReceipt{result}, nil
+}
@@ -0,0 +1,170 @@
+package templates
+
+import (
+	"bufio"
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// Built-in templates live in defaults/<locale>/<channel>.tmpl. Each file holds
+// sections introduced by a "--- <key>.<part>" line, where part is subject,
+// html or text; lines before the first section are comments. Email files also
+// hold the "layout.footer" and "layout.powered_by" lines of the shared layout.
+//
+//go:embed defaults
+var defaultFiles embed.FS
+
+// Template parts
+const (
+	partSubject = "subject"
+	partHTML    = "html"
+	partText    = "text"
+)
+
+// layoutKey names the sections of the shared email layout
+const layoutKey = "layout"
+
+const sectionPrefix = "--- "
+
+// defaultSet holds the built-in template sections, by locale, channel and "<key>.<part>"
+type defaultSet struct {
+	sections map[string]string
+	layout   string
+}
+
+func sectionID(locale string, channel entities.NotificationChannel, key, part string) string {
+	return fmt.Sprintf("%s/%s/%s.%s", locale, channel, key, part)
+}
+
+// loadDefaults reads the embedded built-in templates
+func loadDefaults() (*defaultSet, error) {
+	set := &defaultSet{sections: make(map[string]string)}
+
+	layout, err := defaultFiles.ReadFile("defaults/layout.html")
+	if err != nil {
+		return nil, fmt.Errorf("failed to read email layout: %w", err)
+	}
+	set.layout = string(layout)
+
+	files, err := fs.Glob(defaultFiles, "defaults/*/*.tmpl")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list default templates: %w", err)
+	}
+	for _, file := range files {
+		locale := path.Base(path.Dir(file))
+		if !entities.IsSupportedLocale(locale) {
+			return nil, fmt.Errorf("default templates for unsupported locale %q", locale)
+		}
+		channel := entities.NotificationChannel(strings.TrimSuffix(path.Base(file), ".tmpl"))
+
+		content, err := defaultFiles.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", file, err)
+		}
+		sections, err := parseSections(string(content))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", file, err)
+		}
+		for name, body := range sections {
+			set.sections[fmt.Sprintf("%s/%s/%s", locale, channel, name)] = body
+		}
+	}
+	return set, nil
+}
+
+// parseSections splits a defaults file into its "<key>.<part>" sections
+func parseSections(content string) (map[string]string, error) {
+	sections := make(map[string]string)
+	var name string
+	var body strings.Builder
+
+	flush := func() {
+		if name != "" {
+			sections[name] = strings.TrimSpace(body.String())
+		}
+		body.Reset()
+	}
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.HasPrefix(line, sectionPrefix) {
+			flush()
+			name = strings.TrimSpace(strings.TrimPrefix(line, sectionPrefix))
+			if strings.Count(name, ".") != 1 {
+				return nil, fmt.Errorf("section %q must be named <key>.<part>", name)
+			}
+			if _, exists := sections[name]; exists {
+				return nil, fmt.Errorf("duplicate section %q", name)
+			}
+			continue
+		}
+		if name != "" {
+			body.WriteString(line)
+			body.WriteString("\n")
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	flush()
+	return sections, nil
+}
+
+// get returns a built-in section, if there is one
+func (d *defaultSet) get(locale string, channel entities.NotificationChannel, key, part string) (string, bool) {
+	body, ok := d.sections[sectionID(locale, channel, key, part)]
+	return body, ok
+}
+
+// has reports whether a key has a built-in template on a channel in a locale
+func (d *defaultSet) has(locale string, channel entities.NotificationChannel, key string) bool {
+	if _, ok := d.get(locale, channel, key, partText); ok {
+		return true
+	}
+	_, ok := d.get(locale, channel, key, partHTML)
+	return ok
+}
+
+// catalog lists the built-in templates with the locales each is written in
+func (d *defaultSet) catalog() []CatalogEntry {
+	locales := make(map[string]map[string]bool)
+	for id := range d.sections {
+		parts := strings.SplitN(id, "/", 3)
+		key := strings.SplitN(parts[2], ".", 2)[0]
+		if key == layoutKey {
+			continue
+		}
+		entry := parts[1] + "/" + key
+		if locales[entry] == nil {
+			locales[entry] = make(map[string]bool)
+		}
+		locales[entry][parts[0]] = true
+	}
+
+	entries := make([]CatalogEntry, 0, len(locales))
+	for entry, available := range locales {
+		channel, key, _ := strings.Cut(entry, "/")
+		catalogEntry := CatalogEntry{Channel: entities.NotificationChannel(channel), Key: key}
+		for _, locale := range entities.SupportedLocales {
+			if available[locale] {
+				catalogEntry.Locales = append(catalogEntry.Locales, locale)
+			}
+		}
+		entries = append(entries, catalogEntry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Channel != entries[j].Channel {
+			return entries[i].Channel < entries[j].Channel
+		}
+		return entries[i].Key < entries[j].Key
+	})
+	return entries
+}
@@ -0,0 +1,441 @@
+package templates
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	htmltemplate "html/template"
+	"strings"
+	texttemplate "text/template"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// PlatformName signs the messages that no organizer's branding applies to
+const PlatformName = "uduXPass"
+
+// Brand is the branding a message is rendered with, available to templates as {{brand}}
+type Brand struct {
+	Name         string `json:"name"`
+	LogoURL      string `json:"logo_url,omitempty"`
+	PrimaryColor string `json:"primary_color"`
+	FooterText   string `json:"footer_text,omitempty"`
+	SupportEmail string `json:"support_email,omitempty"`
+	// Organizer is set when an organizer's branding applies rather than the platform's
+	Organizer bool `json:"organizer"`
+}
+
+// CatalogEntry is a built-in template and the locales it is written in
+type CatalogEntry struct {
+	Channel entities.NotificationChannel `json:"channel"`
+	Key     string                       `json:"key"`
+	Locales []string                     `json:"locales"`
+}
+
+// Source is the template content a message is rendered from: a stored
+// override (Version > 0) or a built-in template
+type Source struct {
+	Subject     string     `json:"subject,omitempty"`
+	HTML        string     `json:"html_body,omitempty"`
+	Text        string     `json:"text_body,omitempty"`
+	Locale      string     `json:"locale"`
+	Version     int        `json:"version"`
+	OrganizerID *uuid.UUID `json:"organizer_id,omitempty"`
+}
+
+// PreviewRequest describes a preview. Draft, when set, is rendered instead
+// of the stored templates, and Data defaults to the key's sample data.
+type PreviewRequest struct {
+	Channel     entities.NotificationChannel
+	Key         string
+	Locale      string
+	OrganizerID *uuid.UUID
+	Draft       *entities.MessageTemplate
+	Data        interface{}
+}
+
+// Engine renders transactional messages for every channel. A message uses
+// the first of these that exists, in the recipient's locale and then in
+// English: the organizer's active override, the active override shared by
+// every organizer, the built-in template. Emails are wrapped in the branded
+// layout and get a plain-text alternative.
+type Engine struct {
+	templateRepo  repositories.MessageTemplateRepository
+	userRepo      repositories.UserRepository
+	organizerRepo repositories.OrganizerRepository
+	defaults      *defaultSet
+	layout        *htmltemplate.Template
+}
+
+// NewEngine creates the template engine, checking that every built-in template compiles
+func NewEngine(
+	templateRepo repositories.MessageTemplateRepository,
+	userRepo repositories.UserRepository,
+	organizerRepo repositories.OrganizerRepository,
+) (*Engine, error) {
+	defaults, err := loadDefaults()
+	if err != nil {
+		return nil, err
+	}
+	layout, err := htmltemplate.New("layout").Parse(defaults.layout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse email layout: %w", err)
+	}
+
+	funcs := templateFuncs(entities.DefaultLocale, platformBrand())
+	for id, body := range defaults.sections {
+		if err := compile(id[strings.LastIndex(id, ".")+1:], body, funcs); err != nil {
+			return nil, fmt.Errorf("default template %s: %w", id, err)
+		}
+	}
+	for _, entry := range defaults.catalog() {
+		if !defaults.has(entities.DefaultLocale, entry.Channel, entry.Key) {
+			return nil, fmt.Errorf("default %s template %q has no English version", entry.Channel, entry.Key)
+		}
+	}
+
+	return &Engine{
+		templateRepo:  templateRepo,
+		userRepo:      userRepo,
+		organizerRepo: organizerRepo,
+		defaults:      defaults,
+		layout:        layout,
+	}, nil
+}
+
+var _ services.MessageRenderer = (*Engine)(nil)
+
+// Render renders a message for its recipient
+func (e *Engine) Render(ctx context.Context, req services.RenderRequest) (*services.RenderedMessage, error) {
+	messageContext := services.MessageContextFrom(ctx)
+	if req.UserID == nil {
+		req.UserID = messageContext.UserID
+	}
+	if req.OrganizerID == nil {
+		req.OrganizerID = messageContext.OrganizerID
+	}
+
+	locale := req.Locale
+	if locale == "" {
+		locale = e.userLocale(ctx, req.UserID)
+	}
+	if locale == "" {
+		locale = messageContext.Locale
+	}
+
+	source, err := e.Resolve(ctx, req.Channel, req.Key, entities.NormalizeLocale(locale), req.OrganizerID)
+	if err != nil {
+		return nil, err
+	}
+	brand, err := e.Brand(ctx, req.OrganizerID)
+	if err != nil {
+		return nil, err
+	}
+	return e.Execute(req.Channel, source, brand, req.Data)
+}
+
+// Preview renders a stored or draft template with sample data
+func (e *Engine) Preview(ctx context.Context, req PreviewRequest) (*services.RenderedMessage, error) {
+	data := req.Data
+	if data == nil {
+		data = SampleData(req.Channel, req.Key)
+	}
+	brand, err := e.Brand(ctx, req.OrganizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Draft != nil {
+		if err := e.Check(req.Draft); err != nil {
+			return nil, err
+		}
+		return e.Execute(req.Draft.Channel, sourceFromTemplate(req.Draft), brand, data)
+	}
+
+	if !e.defaults.has(entities.DefaultLocale, req.Channel, req.Key) {
+		return nil, entities.NewNotFoundError("message_template", fmt.Sprintf("there is no %s template %q", req.Channel, req.Key))
+	}
+	source, err := e.Resolve(ctx, req.Channel, req.Key, entities.NormalizeLocale(req.Locale), req.OrganizerID)
+	if err != nil {
+		return nil, err
+	}
+	return e.Execute(req.Channel, source, brand, data)
+}
+
+// Resolve finds the template a message is rendered from
+func (e *Engine) Resolve(ctx context.Context, channel entities.NotificationChannel, key, locale string, organizerID *uuid.UUID) (*Source, error) {
+	if !e.defaults.has(entities.DefaultLocale, channel, key) {
+		return nil, fmt.Errorf("unknown %s template %q", channel, key)
+	}
+
+	locales := []string{locale}
+	if locale != entities.DefaultLocale {
+		locales = append(locales, entities.DefaultLocale)
+	}
+	scopes := []*uuid.UUID{nil}
+	if organizerID != nil {
+		scopes = []*uuid.UUID{organizerID, nil}
+	}
+
+	for _, candidate := range locales {
+		for _, scope := range scopes {
+			override, err := e.templateRepo.GetActive(ctx, key, channel, candidate, scope)
+			if err == nil {
+				return sourceFromTemplate(override), nil
+			}
+			if err != entities.ErrMessageTemplateNotFound {
+				return nil, err
+			}
+		}
+		if source, ok := e.Default(channel, key, candidate); ok {
+			return source, nil
+		}
+	}
+	return nil, fmt.Errorf("no %s template %q for locale %s", channel, key, locale)
+}
+
+// Default returns a built-in template, e.g. as the starting point of an override
+func (e *Engine) Default(channel entities.NotificationChannel, key, locale string) (*Source, bool) {
+	if !e.defaults.has(locale, channel, key) {
+		return nil, false
+	}
+	source := &Source{Locale: locale}
+	source.Subject, _ = e.defaults.get(locale, channel, key, partSubject)
+	source.HTML, _ = e.defaults.get(locale, channel, key, partHTML)
+	source.Text, _ = e.defaults.get(locale, channel, key, partText)
+	return source, true
+}
+
+// Catalog lists the built-in templates that can be overridden
+func (e *Engine) Catalog() []CatalogEntry {
+	return e.defaults.catalog()
+}
+
+// Check validates a template and renders it with its key's sample data, so
+// that templates which cannot render are refused before they are saved
+func (e *Engine) Check(template *entities.MessageTemplate) error {
+	if err := template.Validate(); err != nil {
+		return err
+	}
+	if !e.defaults.has(entities.DefaultLocale, template.Channel, template.Key) {
+		return entities.NewValidationError("key", fmt.Sprintf("there is no %s template %q to override", template.Channel, template.Key))
+	}
+
+	funcs := templateFuncs(template.Locale, platformBrand())
+	for part, body := range map[string]string{partSubject: template.Subject, partHTML: template.HTMLBody, partText: template.TextBody} {
+		if err := compile(part, body, funcs); err != nil {
+			return entities.NewValidationError(part, err.Error())
+		}
+	}
+	if _, err := e.Execute(template.Channel, sourceFromTemplate(template), platformBrand(), SampleData(template.Channel, template.Key)); err != nil {
+		return entities.NewValidationError("body", err.Error())
+	}
+	return nil
+}
+
+// Brand returns the branding of an organizer's messages: its saved
+// branding, else its name and logo. Without an organizer the platform's applies.
+func (e *Engine) Brand(ctx context.Context, organizerID *uuid.UUID) (Brand, error) {
+	if organizerID == nil {
+		return platformBrand(), nil
+	}
+
+	branding, err := e.templateRepo.GetBranding(ctx, *organizerID)
+	if err == entities.ErrOrganizerBrandingNotFound {
+		organizer, orgErr := e.organizerRepo.GetByID(ctx, *organizerID)
+		if orgErr == entities.ErrNotFoundError {
+			return platformBrand(), nil
+		}
+		if orgErr != nil {
+			return Brand{}, orgErr
+		}
+		branding, err = entities.NewOrganizerBranding(organizer), nil
+	}
+	if err != nil {
+		return Brand{}, err
+	}
+
+	brand := Brand{
+		Name:         branding.DisplayName,
+		PrimaryColor: branding.PrimaryColor,
+		FooterText:   branding.FooterText,
+		Organizer:    true,
+	}
+	if branding.LogoURL != nil {
+		brand.LogoURL = *branding.LogoURL
+	}
+	if branding.SupportEmail != nil {
+		brand.SupportEmail = *branding.SupportEmail
+	}
+	return brand, nil
+}
+
+type layoutData struct {
+	Locale    string
+	Subject   string
+	Brand     Brand
+	Content   htmltemplate.HTML
+	Footer    string
+	PoweredBy string
+}
+
+// Execute renders a template with data
+func (e *Engine) Execute(channel entities.NotificationChannel, source *Source, brand Brand, data interface{}) (*services.RenderedMessage, error) {
+	funcs := templateFuncs(source.Locale, brand)
+	rendered := &services.RenderedMessage{Locale: source.Locale, Version: source.Version}
+
+	if channel != entities.NotificationChannelEmail {
+		text, err := executeText(partText, source.Text, funcs, data)
+		if err != nil {
+			return nil, err
+		}
+		rendered.Text = strings.TrimSpace(text)
+		return rendered, nil
+	}
+
+	subject, err := executeText(partSubject, source.Subject, funcs, data)
+	if err != nil {
+		return nil, err
+	}
+	rendered.Subject = strings.Join(strings.Fields(subject), " ")
+
+	var content string
+	if source.HTML != "" {
+		if content, err = executeHTML(partHTML, source.HTML, funcs, data); err != nil {
+			return nil, err
+		}
+	}
+	text := htmlToText(content)
+	if source.Text != "" {
+		if text, err = executeText(partText, source.Text, funcs, data); err != nil {
+			return nil, err
+		}
+		text = strings.TrimSpace(text)
+	}
+	if content == "" {
+		content = textToHTML(text)
+	}
+
+	footer, poweredBy, err := e.footer(source.Locale, brand, funcs)
+	if err != nil {
+		return nil, err
+	}
+
+	var body bytes.Buffer
+	err = e.layout.Execute(&body, layoutData{
+		Locale:    source.Locale,
+		Subject:   rendered.Subject,
+		Brand:     brand,
+		Content:   htmltemplate.HTML(content),
+		Footer:    footer,
+		PoweredBy: poweredBy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render email layout: %w", err)
+	}
+	rendered.HTML = body.String()
+
+	rendered.Text = text + "\n\n--\n" + footer
+	if poweredBy != "" {
+		rendered.Text += "\n" + poweredBy
+	}
+	return rendered, nil
+}
+
+// footer renders the layout's footer lines: the brand's own footer text, or
+// the default copyright line, and "powered by" under organizer branding
+func (e *Engine) footer(locale string, brand Brand, funcs map[string]interface{}) (string, string, error) {
+	footer := brand.FooterText
+	if footer == "" {
+		var err error
+		if footer, err = executeText("footer", e.layoutSection(locale, "footer"), funcs, nil); err != nil {
+			return "", "", err
+		}
+	}
+
+	var poweredBy string
+	if brand.Organizer {
+		var err error
+		if poweredBy, err = executeText("powered_by", e.layoutSection(locale, "powered_by"), funcs, nil); err != nil {
+			return "", "", err
+		}
+	}
+	return strings.TrimSpace(footer), strings.TrimSpace(poweredBy), nil
+}
+
+func (e *Engine) layoutSection(locale, part string) string {
+	if body, ok := e.defaults.get(locale, entities.NotificationChannelEmail, layoutKey, part); ok {
+		return body
+	}
+	body, _ := e.defaults.get(entities.DefaultLocale, entities.NotificationChannelEmail, layoutKey, part)
+	return body
+}
+
+// userLocale returns the user's preferred locale, or "" when unknown
+func (e *Engine) userLocale(ctx context.Context, userID *uuid.UUID) string {
+	if userID == nil {
+		return ""
+	}
+	locale, err := e.userRepo.GetLocale(ctx, *userID)
+	if err != nil {
+		if err != entities.ErrUserNotFound {
+			fmt.Printf("Warning: failed to get locale of user %s: %v\n", userID, err)
+		}
+		return ""
+	}
+	return locale
+}
+
+func platformBrand() Brand {
+	return Brand{Name: PlatformName, PrimaryColor: entities.DefaultBrandColor}
+}
+
+func sourceFromTemplate(template *entities.MessageTemplate) *Source {
+	return &Source{
+		Subject:     template.Subject,
+		HTML:        template.HTMLBody,
+		Text:        template.TextBody,
+		Locale:      template.Locale,
+		Version:     template.Version,
+		OrganizerID: template.OrganizerID,
+	}
+}
+
+// compile parses one template part; HTML parts are parsed as html/template so they are escaped
+func compile(part, body string, funcs map[string]interface{}) error {
+	if part == partHTML {
+		_, err := htmltemplate.New(part).Funcs(funcs).Parse(body)
+		return err
+	}
+	_, err := texttemplate.New(part).Funcs(funcs).Parse(body)
+	return err
+}
+
+func executeText(name, body string, funcs map[string]interface{}, data interface{}) (string, error) {
+	tmpl, err := texttemplate.New(name).Funcs(funcs).Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return out.String(), nil
+}
+
+func executeHTML(name, body string, funcs map[string]interface{}, data interface{}) (string, error) {
+	tmpl, err := htmltemplate.New(name).Funcs(funcs).Parse(body)
+	if err != nil {
+		return "", fmt.Errorf("failed to parse %s: %w", name, err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, data); err != nil {
+		return "", fmt.Errorf("failed to render %s: %w", name, err)
+	}
+	return out.String(), nil
+}
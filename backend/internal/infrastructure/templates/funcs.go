@@ -0,0 +1,149 @@
+package templates
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// localeFormat holds the names and layouts dates are written with in a locale
+type localeFormat struct {
+	// weekdays start on Sunday
+	weekdays    [7]string
+	months      [12]string
+	shortMonths [12]string
+	// dateLayout places {weekday}, {day}, {month} and {year}
+	dateLayout string
+}
+
+var localeFormats = map[string]localeFormat{
+	entities.LocaleEnglish: {
+		weekdays:    [7]string{"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+		months:      [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"},
+		shortMonths: [12]string{"Jan", "Feb", "Mar", "Apr", "May", "Jun", "Jul", "Aug", "Sep", "Oct", "Nov", "Dec"},
+		dateLayout:  "{weekday}, {day} {month} {year}",
+	},
+	entities.LocaleYoruba: {
+		weekdays:    [7]string{"Ọjọ́ Àìkú", "Ọjọ́ Ajé", "Ọjọ́ Ìṣẹ́gun", "Ọjọ́rú", "Ọjọ́bọ̀", "Ọjọ́ Ẹtì", "Ọjọ́ Àbámẹ́ta"},
+		months:      [12]string{"Ṣẹ́rẹ́", "Èrèlè", "Ẹrẹ̀nà", "Ìgbé", "Ẹ̀bibi", "Òkúdu", "Agẹmọ", "Ògún", "Owewe", "Ọ̀wàrà", "Bélú", "Ọ̀pẹ̀"},
+		shortMonths: [12]string{"Ṣẹ́rẹ́", "Èrèlè", "Ẹrẹ̀nà", "Ìgbé", "Ẹ̀bibi", "Òkúdu", "Agẹmọ", "Ògún", "Owewe", "Ọ̀wàrà", "Bélú", "Ọ̀pẹ̀"},
+		dateLayout:  "{weekday}, {day} Oṣù {month} {year}",
+	},
+	entities.LocaleHausa: {
+		weekdays:    [7]string{"Lahadi", "Litinin", "Talata", "Laraba", "Alhamis", "Jummaʼa", "Asabar"},
+		months:      [12]string{"Janairu", "Faburairu", "Maris", "Afirilu", "Mayu", "Yuni", "Yuli", "Agusta", "Satumba", "Oktoba", "Nuwamba", "Disamba"},
+		shortMonths: [12]string{"Jan", "Fab", "Mar", "Afi", "May", "Yun", "Yul", "Agu", "Sat", "Okt", "Nuw", "Dis"},
+		dateLayout:  "{weekday}, {day} {month}, {year}",
+	},
+	entities.LocaleIgbo: {
+		weekdays:    [7]string{"Sọndee", "Mọnde", "Tiuzdee", "Wenezdee", "Tọọzdee", "Fraịdee", "Satọdee"},
+		months:      [12]string{"Jenụwarị", "Febrụwarị", "Maachị", "Epreel", "Mee", "Jun", "Julaị", "Ọgọọst", "Septemba", "Ọktoba", "Novemba", "Disemba"},
+		shortMonths: [12]string{"Jen", "Feb", "Maa", "Epr", "Mee", "Jun", "Jul", "Ọgọ", "Sep", "Ọkt", "Nov", "Dis"},
+		dateLayout:  "{weekday}, {day} {month} {year}",
+	},
+	entities.LocaleFrench: {
+		weekdays:    [7]string{"dimanche", "lundi", "mardi", "mercredi", "jeudi", "vendredi", "samedi"},
+		months:      [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"},
+		shortMonths: [12]string{"janv.", "févr.", "mars", "avr.", "mai", "juin", "juil.", "août", "sept.", "oct.", "nov.", "déc."},
+		dateLayout:  "{weekday} {day} {month} {year}",
+	},
+}
+
+// templateFuncs returns the functions templates are executed with:
+//
+//	brand            the sender's branding: .Name, .LogoURL, .PrimaryColor, .SupportEmail
+//	date  t          "Monday, 2 January 2006" in the locale
+//	shortdate t      "2 Jan 2006 15:04" in the locale, for text messages
+//	clock t          "15:04 WAT"
+//	money amount     "₦12,500.00"
+//	year             the current year
+func templateFuncs(locale string, brand Brand) map[string]interface{} {
+	format, ok := localeFormats[locale]
+	if !ok {
+		format = localeFormats[entities.DefaultLocale]
+	}
+
+	return map[string]interface{}{
+		"brand": func() Brand { return brand },
+		"date": func(value interface{}) string {
+			t, ok := timeValue(value)
+			if !ok {
+				return ""
+			}
+			return strings.NewReplacer(
+				"{weekday}", format.weekdays[t.Weekday()],
+				"{day}", fmt.Sprint(t.Day()),
+				"{month}", format.months[t.Month()-1],
+				"{year}", fmt.Sprint(t.Year()),
+			).Replace(format.dateLayout)
+		},
+		"shortdate": func(value interface{}) string {
+			t, ok := timeValue(value)
+			if !ok {
+				return ""
+			}
+			return fmt.Sprintf("%d %s %d %s", t.Day(), format.shortMonths[t.Month()-1], t.Year(), t.Format("15:04"))
+		},
+		"clock": func(value interface{}) string {
+			t, ok := timeValue(value)
+			if !ok {
+				return ""
+			}
+			return t.Format("15:04 MST")
+		},
+		"money": formatMoney,
+		"year":  func() int { return time.Now().Year() },
+	}
+}
+
+// timeValue accepts a time.Time or a non-nil *time.Time
+func timeValue(value interface{}) (time.Time, bool) {
+	switch t := value.(type) {
+	case time.Time:
+		return t, !t.IsZero()
+	case *time.Time:
+		if t == nil {
+			return time.Time{}, false
+		}
+		return *t, !t.IsZero()
+	default:
+		return time.Time{}, false
+	}
+}
+
+// formatMoney writes a naira amount with thousands separators, e.g. ₦12,500.00
+func formatMoney(value interface{}) string {
+	var amount float64
+	switch v := value.(type) {
+	case float64:
+		amount = v
+	case float32:
+		amount = float64(v)
+	case int:
+		amount = float64(v)
+	case int64:
+		amount = float64(v)
+	default:
+		return fmt.Sprint(value)
+	}
+
+	sign := ""
+	if amount < 0 {
+		sign = "-"
+		amount = -amount
+	}
+	cents := int64(math.Round(amount * 100))
+	whole := fmt.Sprint(cents / 100)
+
+	var grouped strings.Builder
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteByte(',')
+		}
+		grouped.WriteRune(digit)
+	}
+	return fmt.Sprintf("%s₦%s.%02d", sign, grouped.String(), cents%100)
+}
@@ -0,0 +1,149 @@
+package templates
+
+import (
+	"time"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// SampleData returns realistic data to preview and check a template with;
+// it has every field the services render the key with
+func SampleData(channel entities.NotificationChannel, key string) interface{} {
+	eventDate := time.Date(time.Now().Year()+1, time.March, 14, 19, 0, 0, 0, time.FixedZone("WAT", 3600))
+	newDate := eventDate.AddDate(0, 1, 0)
+	deadline := eventDate.AddDate(0, 0, -7)
+
+	switch key {
+	case services.MessageTicket:
+		return map[string]interface{}{
+			"OrderCode":    "UDX-7K2M9Q",
+			"CustomerName": "Adaeze Okafor",
+			"Tickets": []map[string]interface{}{
+				{"Code": "TKT-4F9A2C1B", "TierName": "VIP", "QRCodeURL": "https://example.com/qr/TKT-4F9A2C1B.png"},
+				{"Code": "TKT-8D3E7F20", "TierName": "VIP", "QRCodeURL": "https://example.com/qr/TKT-8D3E7F20.png"},
+			},
+			"Total": 50000.0,
+		}
+	case services.MessageTicketPDF:
+		return map[string]interface{}{
+			"OrderCode":    "UDX-7K2M9Q",
+			"CustomerName": "Adaeze Okafor",
+			"EventName":    "Lagos Jazz Night",
+			"EventDate":    eventDate,
+			"VenueName":    "Eko Convention Centre",
+			"VenueAddress": "Plot 1415 Adetokunbo Ademola Street, Victoria Island, Lagos",
+			"TicketCount":  2,
+			"Total":        50000.0,
+			"Wallet": []map[string]interface{}{
+				{"Number": 1, "Serial": "TKT-4F9A2C1B", "AppleURL": "https://example.com/wallet/apple/1", "GoogleURL": "https://example.com/wallet/google/1"},
+			},
+		}
+	case services.MessageOrderConfirmation:
+		return map[string]interface{}{
+			"OrderCode":    "UDX-7K2M9Q",
+			"CustomerName": "Adaeze Okafor",
+			"Total":        50000.0,
+			"Status":       "paid",
+			"CreatedAt":    time.Now(),
+		}
+	case services.MessageWelcome:
+		return map[string]interface{}{
+			"FirstName": "Adaeze",
+			"Email":     "adaeze@example.com",
+		}
+	case services.MessagePasswordReset:
+		return map[string]interface{}{
+			"ResetURL": "https://example.com/reset-password?token=sample",
+		}
+	case services.MessageEventChange:
+		data := map[string]interface{}{
+			"FirstName":      "Adaeze",
+			"EventName":      "Lagos Jazz Night",
+			"VenueName":      "Eko Convention Centre",
+			"Cancelled":      false,
+			"PreviousDate":   eventDate,
+			"NewDate":        newDate,
+			"Reason":         "Artist travel delays",
+			"Message":        "We're sorry for the change and look forward to seeing you.",
+			"TicketsVoid":    false,
+			"Refunded":       false,
+			"CanChoose":      true,
+			"RefundDeadline": deadline,
+			"ChoiceURL":      "https://example.com/event-changes/choice?token=sample",
+		}
+		if channel == entities.NotificationChannelSMS {
+			data["Refunding"] = false
+		}
+		return data
+	case services.MessageOTP:
+		return map[string]interface{}{
+			"Code":    "482913",
+			"Minutes": 15,
+		}
+	case services.MessageTicketLink:
+		return map[string]interface{}{
+			"EventName":   "Lagos Jazz Night",
+			"OrderCode":   "UDX-7K2M9Q",
+			"TicketCount": 2,
+			"Link":        "https://example.com/t/UDX-7K2M9Q.3f9a2c1b8d3e7f20",
+		}
+	case services.MessageTicketDelivery:
+		return map[string]interface{}{
+			"FirstName":   "Adaeze",
+			"EventName":   "Lagos Jazz Night",
+			"OrderCode":   "UDX-7K2M9Q",
+			"TicketCount": 2,
+			"Link":        "https://example.com/t/UDX-7K2M9Q.3f9a2c1b8d3e7f20",
+		}
+	case services.MessageEventReminder:
+		doorsOpen := eventDate.Add(-time.Hour)
+		return map[string]interface{}{
+			"FirstName":      "Adaeze",
+			"EventName":      "Lagos Jazz Night",
+			"EventDate":      eventDate,
+			"DoorsOpen":      &doorsOpen,
+			"VenueName":      "Eko Convention Centre",
+			"VenueAddress":   "Plot 1415 Adetokunbo Ademola Street, Victoria Island, Lagos",
+			"Message":        "Parking opens at 5pm; the nearest entrance is Gate B.",
+			"UnsubscribeURL": "https://example.com/unsubscribe?token=sample",
+		}
+	case services.MessageEventFollowUp:
+		return map[string]interface{}{
+			"FirstName":      "Adaeze",
+			"EventName":      "Lagos Jazz Night",
+			"VenueName":      "Eko Convention Centre",
+			"Message":        "Photos from the night are up on our page.",
+			"SurveyURL":      "https://example.com/survey/lagos-jazz-night",
+			"UnsubscribeURL": "https://example.com/unsubscribe?token=sample",
+		}
+	case services.MessageCheckoutRecovery:
+		return map[string]interface{}{
+			"FirstName": "Adaeze",
+			"EventName": "Lagos Jazz Night",
+			"EventDate": eventDate,
+			"VenueName": "Eko Convention Centre",
+			"Items": []map[string]interface{}{
+				{"Name": "VIP", "Quantity": 2},
+			},
+			"Total":       50000.0,
+			"Currency":    "NGN",
+			"Expired":     true,
+			"RecoveryURL": "https://example.com/checkout/recover?token=sample",
+		}
+	case services.MessageMarketing:
+		return map[string]interface{}{
+			"FirstName": "Adaeze",
+			"Subject":   "Lagos Jazz Night is back",
+			"Paragraphs": []string{
+				"Thanks for coming out last year. We're back at Eko Convention Centre this December with a bigger lineup.",
+				"Early bird tickets are on sale until Friday.",
+			},
+			"LinkURL":        "https://example.com/events/lagos-jazz-night",
+			"LinkLabel":      "Get tickets",
+			"UnsubscribeURL": "https://example.com/marketing/unsubscribe?token=sample",
+		}
+	default:
+		return map[string]interface{}{}
+	}
+}
@@ -0,0 +1,59 @@
+package templates
+
+import (
+	"html"
+	"regexp"
+	"strings"
+)
+
+var (
+	invisibleBlocks = regexp.MustCompile(`(?is)<(head|style|script)[^>]*>.*?</(head|style|script)>`)
+	htmlLinks       = regexp.MustCompile(`(?is)<a\s[^>]*href\s*=\s*"([^"]*)"[^>]*>(.*?)</a>`)
+	lineBreaks      = regexp.MustCompile(`(?i)<br\s*/?>`)
+	blockEnds       = regexp.MustCompile(`(?i)</(p|div|h[1-6]|tr|table|ul|ol)>`)
+	listItems       = regexp.MustCompile(`(?i)<li[^>]*>`)
+	htmlTags        = regexp.MustCompile(`(?s)<[^>]*>`)
+	spaceRuns       = regexp.MustCompile(`[ \t]+`)
+	blankLines      = regexp.MustCompile(`\n{3,}`)
+)
+
+// htmlToText derives the plain-text alternative of an HTML body for
+// templates that have no text part: links keep their URL and blocks keep
+// their line breaks
+func htmlToText(body string) string {
+	text := invisibleBlocks.ReplaceAllString(body, "")
+	text = htmlLinks.ReplaceAllStringFunc(text, func(link string) string {
+		match := htmlLinks.FindStringSubmatch(link)
+		label := strings.TrimSpace(htmlTags.ReplaceAllString(match[2], ""))
+		if label == "" || label == match[1] {
+			return match[1]
+		}
+		return label + " (" + match[1] + ")"
+	})
+	text = lineBreaks.ReplaceAllString(text, "\n")
+	text = blockEnds.ReplaceAllString(text, "\n\n")
+	text = listItems.ReplaceAllString(text, "\n- ")
+	text = htmlTags.ReplaceAllString(text, "")
+	text = html.UnescapeString(text)
+
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaceRuns.ReplaceAllString(line, " "))
+	}
+	text = strings.Join(lines, "\n")
+	return strings.TrimSpace(blankLines.ReplaceAllString(text, "\n\n"))
+}
+
+// textToHTML lays out a text-only email body as escaped paragraphs
+func textToHTML(text string) string {
+	var b strings.Builder
+	for _, paragraph := range strings.Split(strings.TrimSpace(text), "\n\n") {
+		if strings.TrimSpace(paragraph) == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(strings.TrimSpace(paragraph)), "\n", "<br>"))
+		b.WriteString("</p>\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,357 @@
+package wallet
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ApplePassContentType is the MIME type of a .pkpass bundle
+const ApplePassContentType = "application/vnd.apple.pkpass"
+
+// ErrWalletNotConfigured is returned when a wallet provider has no credentials configured
+var ErrWalletNotConfigured = errors.New("wallet provider is not configured")
+
+// passImages are the optional artwork files picked up from the images directory
+var passImages = []string{
+	"icon.png", "icon@2x.png", "icon@3x.png",
+	"logo.png", "logo@2x.png", "logo@3x.png",
+	"strip.png", "strip@2x.png", "strip@3x.png",
+	"background.png", "background@2x.png",
+}
+
+// AppleConfig holds the Pass Type ID certificate and pass branding
+type AppleConfig struct {
+	PassTypeID          string
+	TeamID              string
+	OrganizationName    string
+	WebServiceURL       string
+	CertificatePath     string
+	KeyPath             string
+	WWDRCertificatePath string
+	ImagesDir           string
+	BackgroundColor     string
+	ForegroundColor     string
+	LabelColor          string
+}
+
+// AppleConfigFromEnv reads the Apple Wallet configuration from the environment
+func AppleConfigFromEnv() AppleConfig {
+	return AppleConfig{
+		PassTypeID:          os.Getenv("APPLE_WALLET_PASS_TYPE_ID"),
+		TeamID:              os.Getenv("APPLE_WALLET_TEAM_ID"),
+		OrganizationName:    getEnv("APPLE_WALLET_ORGANIZATION", "uduXPass"),
+		WebServiceURL:       os.Getenv("APPLE_WALLET_WEB_SERVICE_URL"),
+		CertificatePath:     os.Getenv("APPLE_WALLET_CERT_PATH"),
+		KeyPath:             os.Getenv("APPLE_WALLET_KEY_PATH"),
+		WWDRCertificatePath: os.Getenv("APPLE_WALLET_WWDR_CERT_PATH"),
+		ImagesDir:           os.Getenv("APPLE_WALLET_IMAGES_DIR"),
+		BackgroundColor:     getEnv("APPLE_WALLET_BACKGROUND_COLOR", "rgb(0, 102, 204)"),
+		ForegroundColor:     getEnv("APPLE_WALLET_FOREGROUND_COLOR", "rgb(255, 255, 255)"),
+		LabelColor:          getEnv("APPLE_WALLET_LABEL_COLOR", "rgb(204, 224, 255)"),
+	}
+}
+
+// Enabled reports whether enough is configured to sign passes
+func (c AppleConfig) Enabled() bool {
+	return c.PassTypeID != "" && c.TeamID != "" && c.CertificatePath != "" && c.KeyPath != ""
+}
+
+// ApplePassGenerator builds signed .pkpass bundles for tickets
+type ApplePassGenerator struct {
+	config        AppleConfig
+	certificate   *x509.Certificate
+	key           crypto.Signer
+	intermediates []*x509.Certificate
+	images        map[string][]byte
+}
+
+// NewApplePassGenerator loads the signing certificate, key and pass artwork
+func NewApplePassGenerator(config AppleConfig) (*ApplePassGenerator, error) {
+	if !config.Enabled() {
+		return nil, ErrWalletNotConfigured
+	}
+
+	certificate, err := loadCertificate(config.CertificatePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pass certificate: %w", err)
+	}
+
+	key, err := loadPrivateKey(config.KeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load pass key: %w", err)
+	}
+
+	var intermediates []*x509.Certificate
+	if config.WWDRCertificatePath != "" {
+		wwdr, err := loadCertificate(config.WWDRCertificatePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load WWDR certificate: %w", err)
+		}
+		intermediates = append(intermediates, wwdr)
+	}
+
+	images := make(map[string][]byte)
+	if config.ImagesDir != "" {
+		for _, name := range passImages {
+			data, err := os.ReadFile(filepath.Join(config.ImagesDir, name))
+			if err == nil {
+				images[name] = data
+			}
+		}
+	}
+	// Wallet rejects a pass without an icon
+	if _, ok := images["icon.png"]; !ok {
+		images["icon.png"] = solidPNG(29, color.RGBA{R: 0, G: 102, B: 204, A: 255})
+		images["icon@2x.png"] = solidPNG(58, color.RGBA{R: 0, G: 102, B: 204, A: 255})
+	}
+
+	return &ApplePassGenerator{
+		config:        config,
+		certificate:   certificate,
+		key:           key,
+		intermediates: intermediates,
+		images:        images,
+	}, nil
+}
+
+// PassTypeID returns the pass type identifier passes are issued under
+func (g *ApplePassGenerator) PassTypeID() string {
+	return g.config.PassTypeID
+}
+
+// Certificate returns the Pass Type ID certificate, which also authenticates update pushes
+func (g *ApplePassGenerator) Certificate() *x509.Certificate {
+	return g.certificate
+}
+
+// Key returns the Pass Type ID private key
+func (g *ApplePassGenerator) Key() crypto.Signer {
+	return g.key
+}
+
+type passField struct {
+	Key           string `json:"key"`
+	Label         string `json:"label,omitempty"`
+	Value         string `json:"value"`
+	ChangeMessage string `json:"changeMessage,omitempty"`
+	DateStyle     string `json:"dateStyle,omitempty"`
+	TimeStyle     string `json:"timeStyle,omitempty"`
+}
+
+type passBarcode struct {
+	Format          string `json:"format"`
+	Message         string `json:"message"`
+	MessageEncoding string `json:"messageEncoding"`
+	AltText         string `json:"altText,omitempty"`
+}
+
+type passStructure struct {
+	HeaderFields    []passField `json:"headerFields,omitempty"`
+	PrimaryFields   []passField `json:"primaryFields,omitempty"`
+	SecondaryFields []passField `json:"secondaryFields,omitempty"`
+	AuxiliaryFields []passField `json:"auxiliaryFields,omitempty"`
+	BackFields      []passField `json:"backFields,omitempty"`
+}
+
+type passJSON struct {
+	FormatVersion       int           `json:"formatVersion"`
+	PassTypeIdentifier  string        `json:"passTypeIdentifier"`
+	SerialNumber        string        `json:"serialNumber"`
+	TeamIdentifier      string        `json:"teamIdentifier"`
+	OrganizationName    string        `json:"organizationName"`
+	Description         string        `json:"description"`
+	LogoText            string        `json:"logoText,omitempty"`
+	WebServiceURL       string        `json:"webServiceURL,omitempty"`
+	AuthenticationToken string        `json:"authenticationToken,omitempty"`
+	RelevantDate        string        `json:"relevantDate,omitempty"`
+	Voided              bool          `json:"voided,omitempty"`
+	BackgroundColor     string        `json:"backgroundColor,omitempty"`
+	ForegroundColor     string        `json:"foregroundColor,omitempty"`
+	LabelColor          string        `json:"labelColor,omitempty"`
+	Barcodes            []passBarcode `json:"barcodes"`
+	Barcode             *passBarcode  `json:"barcode,omitempty"`
+	EventTicket         passStructure `json:"eventTicket"`
+}
+
+// Generate builds a signed .pkpass bundle for a ticket. Voided and redeemed
+// tickets are marked voided so Wallet greys them out and stops suggesting them.
+func (g *ApplePassGenerator) Generate(pass TicketPass) ([]byte, error) {
+	barcode := passBarcode{
+		Format:          "PKBarcodeFormatQR",
+		Message:         pass.Barcode,
+		MessageEncoding: "iso-8859-1",
+		AltText:         pass.SerialNumber,
+	}
+
+	status := "Valid"
+	switch {
+	case pass.Voided:
+		status = "Voided"
+	case pass.Redeemed:
+		status = "Used"
+	}
+
+	eventDate := pass.EventDate.Format(time.RFC3339)
+	definition := passJSON{
+		FormatVersion:       1,
+		PassTypeIdentifier:  g.config.PassTypeID,
+		SerialNumber:        pass.SerialNumber,
+		TeamIdentifier:      g.config.TeamID,
+		OrganizationName:    g.config.OrganizationName,
+		Description:         fmt.Sprintf("Ticket for %s", pass.EventName),
+		LogoText:            g.config.OrganizationName,
+		WebServiceURL:       g.config.WebServiceURL,
+		AuthenticationToken: pass.AuthenticationToken,
+		RelevantDate:        eventDate,
+		Voided:              pass.Voided || pass.Redeemed,
+		BackgroundColor:     g.config.BackgroundColor,
+		ForegroundColor:     g.config.ForegroundColor,
+		LabelColor:          g.config.LabelColor,
+		Barcodes:            []passBarcode{barcode},
+		Barcode:             &barcode,
+		EventTicket: passStructure{
+			HeaderFields: []passField{
+				{Key: "status", Label: "STATUS", Value: status, ChangeMessage: "Your ticket is now %@"},
+			},
+			PrimaryFields: []passField{
+				{Key: "event", Label: "EVENT", Value: pass.EventName},
+			},
+			SecondaryFields: []passField{
+				{Key: "date", Label: "DATE", Value: eventDate, DateStyle: "PKDateStyleMedium", TimeStyle: "PKDateStyleShort", ChangeMessage: "Event moved to %@"},
+				{Key: "venue", Label: "VENUE", Value: pass.VenueName, ChangeMessage: "Venue changed to %@"},
+			},
+			AuxiliaryFields: []passField{
+				{Key: "tier", Label: "TICKET", Value: pass.TierName},
+				{Key: "holder", Label: "HOLDER", Value: pass.HolderName, ChangeMessage: "Ticket holder is now %@"},
+			},
+			BackFields: []passField{
+				{Key: "order", Label: "Order", Value: pass.OrderCode},
+				{Key: "serial", Label: "Ticket number", Value: pass.SerialNumber},
+				{Key: "address", Label: "Venue address", Value: pass.VenueAddress},
+				{Key: "terms", Label: "Terms", Value: "Present this pass at the venue entrance. Each ticket is valid for one entry only."},
+			},
+		},
+	}
+
+	passData, err := json.Marshal(definition)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pass.json: %w", err)
+	}
+
+	files := map[string][]byte{"pass.json": passData}
+	for name, data := range g.images {
+		files[name] = data
+	}
+
+	manifest := make(map[string]string, len(files))
+	for name, data := range files {
+		sum := sha1.Sum(data)
+		manifest[name] = hex.EncodeToString(sum[:])
+	}
+	manifestData, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	signature, err := signDetached(manifestData, g.certificate, g.key, g.intermediates)
+	if err != nil {
+		return nil, err
+	}
+
+	files["manifest.json"] = manifestData
+	files["signature"] = signature
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for name, data := range files {
+		w, err := archive.Create(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s to pass: %w", name, err)
+		}
+		if _, err := w.Write(data); err != nil {
+			return nil, fmt.Errorf("failed to add %s to pass: %w", name, err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write pass bundle: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// loadCertificate reads a PEM or DER encoded certificate
+func loadCertificate(path string) (*x509.Certificate, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	if block, _ := pem.Decode(data); block != nil {
+		data = block.Bytes
+	}
+	return x509.ParseCertificate(data)
+}
+
+// loadPrivateKey reads a PEM encoded PKCS #8, PKCS #1 or SEC 1 private key
+func loadPrivateKey(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no PEM block found")
+	}
+
+	if key, err := x509.ParsePKCS8PrivateKey(block.Bytes); err == nil {
+		switch k := key.(type) {
+		case *rsa.PrivateKey:
+			return k, nil
+		case *ecdsa.PrivateKey:
+			return k, nil
+		}
+		return nil, fmt.Errorf("unsupported private key type %T", key)
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	if key, err := x509.ParseECPrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	return nil, errors.New("unrecognised private key format")
+}
+
+// solidPNG renders a square single-colour PNG used when no pass artwork is configured
+func solidPNG(size int, fill color.RGBA) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+	var buf bytes.Buffer
+	_ = png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -0,0 +1,135 @@
+package wallet
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/smallstep/pkcs7"
+)
+
+func TestApplePassManifestCoversEveryFile(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	chain := newTestChain(t, key)
+
+	dir := t.TempDir()
+	keyDER, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatalf("failed to encode key: %v", err)
+	}
+	writePEM(t, filepath.Join(dir, "pass.key"), "PRIVATE KEY", keyDER)
+	writePEM(t, filepath.Join(dir, "pass.pem"), "CERTIFICATE", chain.cert.Raw)
+	writePEM(t, filepath.Join(dir, "wwdr.pem"), "CERTIFICATE", chain.ca.Raw)
+
+	generator, err := NewApplePassGenerator(AppleConfig{
+		PassTypeID:          "pass.com.uduxpass.test",
+		TeamID:              "TEAM123456",
+		OrganizationName:    "uduXPass",
+		CertificatePath:     filepath.Join(dir, "pass.pem"),
+		KeyPath:             filepath.Join(dir, "pass.key"),
+		WWDRCertificatePath: filepath.Join(dir, "wwdr.pem"),
+	})
+	if err != nil {
+		t.Fatalf("NewApplePassGenerator() error = %v", err)
+	}
+
+	bundle, err := generator.Generate(TicketPass{
+		TicketID:     "ticket-1",
+		SerialNumber: "UDX-0001",
+		Barcode:      "UDX-0001-QR",
+		EventName:    "Afrobeats Live",
+		EventDate:    time.Date(2026, 12, 24, 19, 0, 0, 0, time.UTC),
+		VenueName:    "Eko Hotel",
+		TierName:     "VIP",
+		HolderName:   "Ada Obi",
+		OrderCode:    "ORD-1",
+	})
+	if err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	files := readZip(t, bundle)
+	for _, name := range []string{"pass.json", "manifest.json", "signature", "icon.png"} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("bundle is missing %s", name)
+		}
+	}
+
+	var manifest map[string]string
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+		t.Fatalf("manifest.json is not valid JSON: %v", err)
+	}
+
+	// Wallet rejects a pass whose manifest misses a file or lists a wrong hash
+	for name, data := range files {
+		if name == "manifest.json" || name == "signature" {
+			if _, listed := manifest[name]; listed {
+				t.Errorf("manifest must not list %s", name)
+			}
+			continue
+		}
+		sum := sha1.Sum(data)
+		if got, want := manifest[name], hex.EncodeToString(sum[:]); got != want {
+			t.Errorf("manifest hash for %s = %q, want %q", name, got, want)
+		}
+	}
+	if len(manifest) != len(files)-2 {
+		t.Errorf("manifest lists %d files, bundle has %d besides manifest and signature", len(manifest), len(files)-2)
+	}
+
+	p7, err := pkcs7.Parse(files["signature"])
+	if err != nil {
+		t.Fatalf("failed to parse signature: %v", err)
+	}
+	p7.Content = files["manifest.json"]
+	truststore := x509.NewCertPool()
+	truststore.AddCert(chain.ca)
+	if err := p7.VerifyWithChain(truststore); err != nil {
+		t.Fatalf("signature does not verify over manifest.json: %v", err)
+	}
+}
+
+func writePEM(t *testing.T, path, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", path, err)
+	}
+}
+
+func readZip(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("bundle is not a zip archive: %v", err)
+	}
+	files := make(map[string][]byte, len(archive.File))
+	for _, f := range archive.File {
+		r, err := f.Open()
+		if err != nil {
+			t.Fatalf("failed to open %s: %v", f.Name, err)
+		}
+		content, err := io.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("failed to read %s: %v", f.Name, err)
+		}
+		files[f.Name] = content
+	}
+	return files
+}
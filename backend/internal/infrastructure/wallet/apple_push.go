@@ -0,0 +1,79 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+)
+
+const (
+	apnsProductionURL = "https://api.push.apple.com/3/device/"
+	apnsSandboxURL    = "https://api.sandbox.push.apple.com/3/device/"
+)
+
+// ErrPushTokenInvalid is returned when APNs reports that a device token is no longer registered
+var ErrPushTokenInvalid = errors.New("push token is no longer valid")
+
+// ApplePassPusher tells Wallet on registered devices that a pass has changed.
+// The push carries no payload; the device then calls the pass web service to
+// fetch the updated pass.
+type ApplePassPusher struct {
+	passTypeID string
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewApplePassPusher creates a pusher authenticated with the Pass Type ID certificate
+func NewApplePassPusher(generator *ApplePassGenerator) *ApplePassPusher {
+	baseURL := apnsProductionURL
+	if os.Getenv("APPLE_WALLET_APNS_SANDBOX") == "true" {
+		baseURL = apnsSandboxURL
+	}
+
+	transport := &http.Transport{
+		TLSClientConfig: &tls.Config{
+			Certificates: []tls.Certificate{{
+				Certificate: [][]byte{generator.Certificate().Raw},
+				PrivateKey:  generator.Key(),
+				Leaf:        generator.Certificate(),
+			}},
+		},
+		// APNs only speaks HTTP/2
+		ForceAttemptHTTP2: true,
+	}
+
+	return &ApplePassPusher{
+		passTypeID: generator.PassTypeID(),
+		baseURL:    baseURL,
+		httpClient: &http.Client{Transport: transport, Timeout: 10 * time.Second},
+	}
+}
+
+// Push notifies one device that its passes of this type have changed
+func (p *ApplePassPusher) Push(ctx context.Context, pushToken string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+pushToken, bytes.NewReader([]byte("{}")))
+	if err != nil {
+		return fmt.Errorf("failed to create push request: %w", err)
+	}
+	req.Header.Set("apns-topic", p.passTypeID)
+	req.Header.Set("apns-push-type", "background")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send pass update push: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusGone, http.StatusBadRequest:
+		return ErrPushTokenInvalid
+	}
+	return fmt.Errorf("APNs returned status %d", resp.StatusCode)
+}
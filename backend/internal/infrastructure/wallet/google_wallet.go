@@ -0,0 +1,308 @@
+package wallet
+
+import (
+	"bytes"
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	googleSaveURL     = "https://pay.google.com/gp/v/save/"
+	googleTokenURL    = "https://oauth2.googleapis.com/token"
+	googleObjectsURL  = "https://walletobjects.googleapis.com/walletobjects/v1/eventTicketObject/"
+	googleWalletScope = "https://www.googleapis.com/auth/wallet_object.issuer"
+)
+
+// GoogleConfig holds the Google Wallet issuer and service account credentials
+type GoogleConfig struct {
+	IssuerID            string
+	IssuerName          string
+	ServiceAccountEmail string
+	PrivateKeyPath      string
+	Origins             []string
+}
+
+// GoogleConfigFromEnv reads the Google Wallet configuration from the environment
+func GoogleConfigFromEnv() GoogleConfig {
+	var origins []string
+	for _, origin := range strings.Split(os.Getenv("GOOGLE_WALLET_ORIGINS"), ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			origins = append(origins, origin)
+		}
+	}
+
+	return GoogleConfig{
+		IssuerID:            os.Getenv("GOOGLE_WALLET_ISSUER_ID"),
+		IssuerName:          getEnv("GOOGLE_WALLET_ISSUER_NAME", "uduXPass"),
+		ServiceAccountEmail: os.Getenv("GOOGLE_WALLET_SERVICE_ACCOUNT_EMAIL"),
+		PrivateKeyPath:      os.Getenv("GOOGLE_WALLET_PRIVATE_KEY_PATH"),
+		Origins:             origins,
+	}
+}
+
+// Enabled reports whether enough is configured to sign save links
+func (c GoogleConfig) Enabled() bool {
+	return c.IssuerID != "" && c.ServiceAccountEmail != "" && c.PrivateKeyPath != ""
+}
+
+// GoogleWalletIssuer creates "Add to Google Wallet" links for tickets and keeps
+// saved ticket objects in sync through the Wallet REST API
+type GoogleWalletIssuer struct {
+	config     GoogleConfig
+	key        *rsa.PrivateKey
+	httpClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	tokenExpiry time.Time
+}
+
+// NewGoogleWalletIssuer loads the service account key
+func NewGoogleWalletIssuer(config GoogleConfig) (*GoogleWalletIssuer, error) {
+	if !config.Enabled() {
+		return nil, ErrWalletNotConfigured
+	}
+
+	signer, err := loadPrivateKey(config.PrivateKeyPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load service account key: %w", err)
+	}
+	key, ok := signer.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("service account key must be an RSA key")
+	}
+
+	return &GoogleWalletIssuer{
+		config:     config,
+		key:        key,
+		httpClient: &http.Client{Timeout: 15 * time.Second},
+	}, nil
+}
+
+// ClassID returns the event ticket class ID for an event
+func (g *GoogleWalletIssuer) ClassID(eventID string) string {
+	return fmt.Sprintf("%s.event_%s", g.config.IssuerID, eventID)
+}
+
+// ObjectID returns the event ticket object ID for a ticket
+func (g *GoogleWalletIssuer) ObjectID(ticketID string) string {
+	return fmt.Sprintf("%s.ticket_%s", g.config.IssuerID, ticketID)
+}
+
+type localizedString struct {
+	DefaultValue translatedString `json:"defaultValue"`
+}
+
+type translatedString struct {
+	Language string `json:"language"`
+	Value    string `json:"value"`
+}
+
+func localized(value string) *localizedString {
+	return &localizedString{DefaultValue: translatedString{Language: "en-US", Value: value}}
+}
+
+type eventTicketClass struct {
+	ID           string           `json:"id"`
+	IssuerName   string           `json:"issuerName"`
+	ReviewStatus string           `json:"reviewStatus"`
+	EventName    *localizedString `json:"eventName"`
+	Venue        *eventVenue      `json:"venue,omitempty"`
+	DateTime     *eventDateTime   `json:"dateTime,omitempty"`
+}
+
+type eventVenue struct {
+	Name    *localizedString `json:"name"`
+	Address *localizedString `json:"address"`
+}
+
+type eventDateTime struct {
+	Start string `json:"start"`
+}
+
+type eventTicketObject struct {
+	ID               string           `json:"id"`
+	ClassID          string           `json:"classId"`
+	State            string           `json:"state"`
+	TicketHolderName string           `json:"ticketHolderName,omitempty"`
+	TicketNumber     string           `json:"ticketNumber,omitempty"`
+	TicketType       *localizedString `json:"ticketType,omitempty"`
+	Barcode          *objectBarcode   `json:"barcode,omitempty"`
+}
+
+type objectBarcode struct {
+	Type          string `json:"type"`
+	Value         string `json:"value"`
+	AlternateText string `json:"alternateText,omitempty"`
+}
+
+func (g *GoogleWalletIssuer) buildClass(pass TicketPass) eventTicketClass {
+	return eventTicketClass{
+		ID:           g.ClassID(pass.EventID),
+		IssuerName:   g.config.IssuerName,
+		ReviewStatus: "UNDER_REVIEW",
+		EventName:    localized(pass.EventName),
+		Venue: &eventVenue{
+			Name:    localized(pass.VenueName),
+			Address: localized(pass.VenueAddress),
+		},
+		DateTime: &eventDateTime{Start: pass.EventDate.Format(time.RFC3339)},
+	}
+}
+
+func (g *GoogleWalletIssuer) buildObject(pass TicketPass) eventTicketObject {
+	return eventTicketObject{
+		ID:               g.ObjectID(pass.TicketID),
+		ClassID:          g.ClassID(pass.EventID),
+		State:            objectState(pass),
+		TicketHolderName: pass.HolderName,
+		TicketNumber:     pass.SerialNumber,
+		TicketType:       localized(pass.TierName),
+		Barcode: &objectBarcode{
+			Type:          "QR_CODE",
+			Value:         pass.Barcode,
+			AlternateText: pass.SerialNumber,
+		},
+	}
+}
+
+// objectState maps a ticket onto a Google Wallet object state
+func objectState(pass TicketPass) string {
+	switch {
+	case pass.Voided:
+		return "INACTIVE"
+	case pass.Redeemed:
+		return "COMPLETED"
+	}
+	return "ACTIVE"
+}
+
+// SaveURL returns an "Add to Google Wallet" link. The class and object travel
+// inside the signed JWT, so Google creates them on first save.
+func (g *GoogleWalletIssuer) SaveURL(pass TicketPass) (string, error) {
+	origins := g.config.Origins
+	if origins == nil {
+		origins = []string{}
+	}
+
+	claims := jwt.MapClaims{
+		"iss":     g.config.ServiceAccountEmail,
+		"aud":     "google",
+		"typ":     "savetowallet",
+		"iat":     time.Now().Unix(),
+		"origins": origins,
+		"payload": map[string]interface{}{
+			"eventTicketClasses": []eventTicketClass{g.buildClass(pass)},
+			"eventTicketObjects": []eventTicketObject{g.buildObject(pass)},
+		},
+	}
+	token, err := jwt.NewWithClaims(jwt.SigningMethodRS256, claims).SignedString(g.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign save link: %w", err)
+	}
+
+	return googleSaveURL + token, nil
+}
+
+// UpdateObject pushes the current state of a ticket to an already saved object.
+// Objects that were never saved return no error, since there is nothing to update.
+func (g *GoogleWalletIssuer) UpdateObject(ctx context.Context, pass TicketPass) error {
+	object := g.buildObject(pass)
+	body, err := json.Marshal(object)
+	if err != nil {
+		return fmt.Errorf("failed to encode wallet object: %w", err)
+	}
+
+	token, err := g.getAccessToken(ctx)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPatch, googleObjectsURL+url.PathEscape(object.ID), bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to update wallet object: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotFound {
+		return nil
+	}
+	if resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("google wallet returned %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+// getAccessToken exchanges a service account assertion for an OAuth access token, caching it until shortly before expiry
+func (g *GoogleWalletIssuer) getAccessToken(ctx context.Context) (string, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if g.accessToken != "" && time.Now().Before(g.tokenExpiry) {
+		return g.accessToken, nil
+	}
+
+	now := time.Now()
+	assertion, err := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss":   g.config.ServiceAccountEmail,
+		"scope": googleWalletScope,
+		"aud":   googleTokenURL,
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+	}).SignedString(g.key)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign token assertion: %w", err)
+	}
+
+	form := url.Values{
+		"grant_type": {"urn:ietf:params:oauth:grant-type:jwt-bearer"},
+		"assertion":  {assertion},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, googleTokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("failed to create token request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		Error       string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("failed to decode token response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || result.AccessToken == "" {
+		return "", fmt.Errorf("failed to get access token: %s", result.Error)
+	}
+
+	g.accessToken = result.AccessToken
+	g.tokenExpiry = now.Add(time.Duration(result.ExpiresIn)*time.Second - time.Minute)
+	return g.accessToken, nil
+}
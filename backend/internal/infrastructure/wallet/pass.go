@@ -0,0 +1,23 @@
+package wallet
+
+import "time"
+
+// TicketPass is the provider-neutral content of a wallet pass for one ticket
+type TicketPass struct {
+	TicketID     string
+	SerialNumber string
+	Barcode      string
+	EventID      string
+	EventName    string
+	EventDate    time.Time
+	VenueName    string
+	VenueAddress string
+	TierName     string
+	HolderName   string
+	OrderCode    string
+	Voided       bool
+	Redeemed     bool
+
+	// AuthenticationToken is echoed back by Apple Wallet when it asks for updates
+	AuthenticationToken string
+}
@@ -0,0 +1,175 @@
+package wallet
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// Object identifiers used in a CMS (PKCS #7) SignedData structure
+var (
+	oidData            = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData      = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidContentType     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidMessageDigest   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidSigningTime     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidSHA256          = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidRSAEncryption   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+	oidECDSAWithSHA256 = asn1.ObjectIdentifier{1, 2, 840, 10045, 4, 3, 2}
+)
+
+// contentInfo carries its content pre-wrapped in the explicit [0] tag, since
+// encoding/asn1 writes a RawValue's FullBytes verbatim and ignores field tags
+type contentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"optional"`
+}
+
+type signedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      contentInfo
+	Certificates     asn1.RawValue `asn1:"optional,tag:0"`
+	SignerInfos      []signerInfo  `asn1:"set"`
+}
+
+type issuerAndSerial struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type signerInfo struct {
+	Version                   int
+	IssuerAndSerialNumber     issuerAndSerial
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	AuthenticatedAttributes   asn1.RawValue `asn1:"optional,tag:0"`
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+}
+
+type attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+// signDetached produces a DER-encoded, detached CMS SignedData signature over
+// content. The signer certificate and any intermediates are embedded so the
+// verifier can build the chain. This is the signature format Apple requires for
+// the manifest of a .pkpass bundle.
+func signDetached(content []byte, cert *x509.Certificate, key crypto.Signer, intermediates []*x509.Certificate) ([]byte, error) {
+	digest := sha256.Sum256(content)
+
+	attributes, err := marshalAttributes(
+		attributeOf(oidContentType, oidData),
+		attributeOf(oidSigningTime, time.Now().UTC()),
+		attributeOf(oidMessageDigest, digest[:]),
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	// The signature covers the attributes encoded as a universal SET, even though
+	// they are stored with an implicit [0] tag
+	signedAttributes, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: attributes})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signed attributes: %w", err)
+	}
+	attributesDigest := sha256.Sum256(signedAttributes)
+
+	var signatureAlgorithm pkix.AlgorithmIdentifier
+	switch key.Public().(type) {
+	case *rsa.PublicKey:
+		signatureAlgorithm = pkix.AlgorithmIdentifier{Algorithm: oidRSAEncryption, Parameters: asn1.NullRawValue}
+	case *ecdsa.PublicKey:
+		signatureAlgorithm = pkix.AlgorithmIdentifier{Algorithm: oidECDSAWithSHA256}
+	default:
+		return nil, fmt.Errorf("unsupported signing key type %T", key.Public())
+	}
+
+	signature, err := key.Sign(rand.Reader, attributesDigest[:], crypto.SHA256)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign manifest: %w", err)
+	}
+
+	var certificates []byte
+	certificates = append(certificates, cert.Raw...)
+	for _, intermediate := range intermediates {
+		certificates = append(certificates, intermediate.Raw...)
+	}
+
+	sha256Algorithm := pkix.AlgorithmIdentifier{Algorithm: oidSHA256, Parameters: asn1.NullRawValue}
+	sd := signedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{sha256Algorithm},
+		ContentInfo:      contentInfo{ContentType: oidData},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certificates},
+		SignerInfos: []signerInfo{{
+			Version: 1,
+			IssuerAndSerialNumber: issuerAndSerial{
+				Issuer:       asn1.RawValue{FullBytes: cert.RawIssuer},
+				SerialNumber: cert.SerialNumber,
+			},
+			DigestAlgorithm:           sha256Algorithm,
+			AuthenticatedAttributes:   asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: attributes},
+			DigestEncryptionAlgorithm: signatureAlgorithm,
+			EncryptedDigest:           signature,
+		}},
+	}
+
+	inner, err := asn1.Marshal(sd)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signed data: %w", err)
+	}
+
+	wrapped, err := asn1.Marshal(asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: inner})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signed data: %w", err)
+	}
+
+	return asn1.Marshal(contentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{FullBytes: wrapped},
+	})
+}
+
+type pendingAttribute struct {
+	oid   asn1.ObjectIdentifier
+	value interface{}
+}
+
+func attributeOf(oid asn1.ObjectIdentifier, value interface{}) pendingAttribute {
+	return pendingAttribute{oid: oid, value: value}
+}
+
+// marshalAttributes encodes attributes as the contents of a DER SET OF, which
+// must be sorted by their encodings
+func marshalAttributes(pending ...pendingAttribute) ([]byte, error) {
+	encoded := make([][]byte, 0, len(pending))
+	for _, p := range pending {
+		value, err := asn1.Marshal(p.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode attribute %v: %w", p.oid, err)
+		}
+		attr, err := asn1.Marshal(attribute{Type: p.oid, Values: []asn1.RawValue{{FullBytes: value}}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode attribute %v: %w", p.oid, err)
+		}
+		encoded = append(encoded, attr)
+	}
+
+	sort.Slice(encoded, func(i, j int) bool {
+		return bytes.Compare(encoded[i], encoded[j]) < 0
+	})
+
+	return bytes.Join(encoded, nil), nil
+}
@@ -0,0 +1,128 @@
+package wallet
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/smallstep/pkcs7"
+)
+
+// testChain is a signing certificate issued by a throwaway CA, standing in for
+// a Pass Type ID certificate and Apple's WWDR intermediate
+type testChain struct {
+	ca   *x509.Certificate
+	cert *x509.Certificate
+	key  crypto.Signer
+}
+
+func newTestChain(t *testing.T, key crypto.Signer) testChain {
+	t.Helper()
+
+	caKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate CA key: %v", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test WWDR"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, caKey.Public(), caKey)
+	if err != nil {
+		t.Fatalf("failed to create CA certificate: %v", err)
+	}
+	ca, err := x509.ParseCertificate(caDER)
+	if err != nil {
+		t.Fatalf("failed to parse CA certificate: %v", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(4242),
+		Subject:      pkix.Name{CommonName: "Pass Type ID: pass.com.uduxpass.test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageAny},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, ca, key.Public(), caKey)
+	if err != nil {
+		t.Fatalf("failed to create signing certificate: %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("failed to parse signing certificate: %v", err)
+	}
+
+	return testChain{ca: ca, cert: cert, key: key}
+}
+
+func TestSignDetachedVerifiesWithIndependentParser(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate ECDSA key: %v", err)
+	}
+
+	tests := []struct {
+		name string
+		key  crypto.Signer
+	}{
+		{name: "rsa", key: rsaKey},
+		{name: "ecdsa", key: ecKey},
+	}
+
+	content := []byte(`{"pass.json":"0123456789abcdef0123456789abcdef01234567"}`)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chain := newTestChain(t, tt.key)
+
+			signature, err := signDetached(content, chain.cert, chain.key, []*x509.Certificate{chain.ca})
+			if err != nil {
+				t.Fatalf("signDetached() error = %v", err)
+			}
+
+			p7, err := pkcs7.Parse(signature)
+			if err != nil {
+				t.Fatalf("failed to parse signature: %v", err)
+			}
+			if len(p7.Content) != 0 {
+				t.Fatalf("signature should be detached, got %d bytes of content", len(p7.Content))
+			}
+			if len(p7.Certificates) != 2 {
+				t.Fatalf("got %d embedded certificates, want signer and intermediate", len(p7.Certificates))
+			}
+			if signer := p7.GetOnlySigner(); signer == nil || !signer.Equal(chain.cert) {
+				t.Fatalf("signer certificate does not match the signing certificate")
+			}
+
+			p7.Content = content
+			truststore := x509.NewCertPool()
+			truststore.AddCert(chain.ca)
+			if err := p7.VerifyWithChain(truststore); err != nil {
+				t.Fatalf("signature does not verify: %v", err)
+			}
+
+			p7.Content = append([]byte(nil), content...)
+			p7.Content[0] = '['
+			if err := p7.Verify(); err == nil {
+				t.Fatalf("signature verified over tampered content")
+			}
+		})
+	}
+}
@@ -0,0 +1,299 @@
+package whatsapp
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"strings"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// cloudRetryableErrors are Cloud API error codes that come with a 4xx status
+// but clear up on their own: rate limits and temporary service errors
+var cloudRetryableErrors = map[int]bool{
+	4:      true, // application request limit
+	80007:  true, // WhatsApp Business account rate limit
+	130429: true, // throughput rate limit
+	131000: true, // something went wrong
+	131016: true, // service unavailable
+	131056: true, // pair rate limit
+}
+
+// CloudProvider sends messages through the WhatsApp Business Cloud API
+type CloudProvider struct {
+	phoneNumberID string
+	accessToken   string
+	appSecret     string
+	verifyToken   string
+	baseURL       string
+	client        *http.Client
+}
+
+// NewCloudProvider creates a Cloud API provider. apiVersion defaults to v19.0.
+func NewCloudProvider(phoneNumberID, accessToken, appSecret, verifyToken, apiVersion string) *CloudProvider {
+	if apiVersion == "" {
+		apiVersion = "v19.0"
+	}
+	return &CloudProvider{
+		phoneNumberID: phoneNumberID,
+		accessToken:   accessToken,
+		appSecret:     appSecret,
+		verifyToken:   verifyToken,
+		baseURL:       "https://graph.facebook.com/" + apiVersion,
+		client:        &http.Client{Timeout: requestTimeout},
+	}
+}
+
+var _ services.WhatsAppProvider = (*CloudProvider)(nil)
+
+// Name returns "whatsapp_cloud"
+func (p *CloudProvider) Name() string {
+	return "whatsapp_" + ProviderCloud
+}
+
+type cloudErrorResponse struct {
+	Error struct {
+		Message string `json:"message"`
+		Code    int    `json:"code"`
+	} `json:"error"`
+}
+
+type cloudMediaResponse struct {
+	ID string `json:"id"`
+}
+
+type cloudMessageResponse struct {
+	Messages []struct {
+		ID string `json:"id"`
+	} `json:"messages"`
+}
+
+// SendTemplate uploads the document, if any, and sends the template message
+func (p *CloudProvider) SendTemplate(ctx context.Context, to string, message services.WhatsAppTemplateMessage) (*services.WhatsAppSendResult, error) {
+	components := []map[string]interface{}{}
+
+	if message.Document != nil {
+		mediaID, err := p.uploadMedia(ctx, message.Document)
+		if err != nil {
+			return nil, err
+		}
+		components = append(components, map[string]interface{}{
+			"type": "header",
+			"parameters": []map[string]interface{}{{
+				"type":     "document",
+				"document": map[string]string{"id": mediaID, "filename": message.Document.Filename},
+			}},
+		})
+	}
+
+	if len(message.BodyParameters) > 0 {
+		parameters := make([]map[string]string, 0, len(message.BodyParameters))
+		for _, value := range message.BodyParameters {
+			parameters = append(parameters, map[string]string{"type": "text", "text": value})
+		}
+		components = append(components, map[string]interface{}{
+			"type":       "body",
+			"parameters": parameters,
+		})
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{
+		"messaging_product": "whatsapp",
+		"to":                strings.TrimPrefix(to, "+"),
+		"type":              "template",
+		"template": map[string]interface{}{
+			"name":       message.Template,
+			"language":   map[string]string{"code": message.Language},
+			"components": components,
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s/messages", p.baseURL, p.phoneNumberID), bytes.NewReader(payload))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	var result cloudMessageResponse
+	if err := p.do(req, &result); err != nil {
+		return nil, err
+	}
+	if len(result.Messages) == 0 || result.Messages[0].ID == "" {
+		return nil, fmt.Errorf("whatsapp cloud API returned no message ID")
+	}
+
+	return &services.WhatsAppSendResult{ProviderMessageID: result.Messages[0].ID}, nil
+}
+
+// uploadMedia uploads a document and returns its media ID
+func (p *CloudProvider) uploadMedia(ctx context.Context, document *services.WhatsAppDocument) (string, error) {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	if err := writer.WriteField("messaging_product", "whatsapp"); err != nil {
+		return "", fmt.Errorf("failed to write media form: %w", err)
+	}
+	if err := writer.WriteField("type", document.MimeType); err != nil {
+		return "", fmt.Errorf("failed to write media form: %w", err)
+	}
+
+	header := make(textproto.MIMEHeader)
+	header.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename=%q`, document.Filename))
+	header.Set("Content-Type", document.MimeType)
+	part, err := writer.CreatePart(header)
+	if err != nil {
+		return "", fmt.Errorf("failed to write media form: %w", err)
+	}
+	if _, err := part.Write(document.Data); err != nil {
+		return "", fmt.Errorf("failed to write media form: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return "", fmt.Errorf("failed to write media form: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/%s/media", p.baseURL, p.phoneNumberID), &body)
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+
+	var result cloudMediaResponse
+	if err := p.do(req, &result); err != nil {
+		return "", fmt.Errorf("failed to upload %s: %w", document.Filename, err)
+	}
+	if result.ID == "" {
+		return "", fmt.Errorf("whatsapp cloud API returned no media ID")
+	}
+	return result.ID, nil
+}
+
+// do sends an authenticated request and decodes a successful response into out.
+// Client errors other than rate limits and temporary failures wrap
+// services.ErrWhatsAppRejected.
+func (p *CloudProvider) do(req *http.Request, out interface{}) error {
+	req.Header.Set("Authorization", "Bearer "+p.accessToken)
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to make request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		if err := json.Unmarshal(body, out); err != nil {
+			return fmt.Errorf("failed to unmarshal response: %w", err)
+		}
+		return nil
+	}
+
+	var apiErr cloudErrorResponse
+	_ = json.Unmarshal(body, &apiErr)
+	message := apiErr.Error.Message
+	if message == "" {
+		message = strings.TrimSpace(string(body))
+		if len(message) > 300 {
+			message = message[:300]
+		}
+	}
+
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests && !cloudRetryableErrors[apiErr.Error.Code] {
+		return fmt.Errorf("%w: cloud API responded with status %d (error %d): %s", services.ErrWhatsAppRejected, resp.StatusCode, apiErr.Error.Code, message)
+	}
+	return fmt.Errorf("whatsapp cloud API responded with status %d (error %d): %s", resp.StatusCode, apiErr.Error.Code, message)
+}
+
+// VerifyWebhook answers Meta's hub.challenge handshake
+func (p *CloudProvider) VerifyWebhook(mode, token, challenge string) (string, bool) {
+	if mode != "subscribe" || p.verifyToken == "" {
+		return "", false
+	}
+	if subtle.ConstantTimeCompare([]byte(token), []byte(p.verifyToken)) != 1 {
+		return "", false
+	}
+	return challenge, true
+}
+
+// VerifySignature checks X-Hub-Signature-256: "sha256=" and the hex HMAC-SHA256 of the body under the app secret
+func (p *CloudProvider) VerifySignature(body []byte, signature string) bool {
+	expected, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok || p.appSecret == "" {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(p.appSecret))
+	mac.Write(body)
+	return hmac.Equal([]byte(expected), []byte(hex.EncodeToString(mac.Sum(nil))))
+}
+
+type cloudWebhook struct {
+	Entry []struct {
+		Changes []struct {
+			Value struct {
+				Statuses []struct {
+					ID     string `json:"id"`
+					Status string `json:"status"`
+					Errors []struct {
+						Code    int    `json:"code"`
+						Title   string `json:"title"`
+						Message string `json:"message"`
+					} `json:"errors"`
+				} `json:"statuses"`
+			} `json:"value"`
+		} `json:"changes"`
+	} `json:"entry"`
+}
+
+// ParseStatusUpdates reads the statuses in a Cloud API webhook. Webhooks for
+// incoming messages carry no statuses and yield no updates.
+func (p *CloudProvider) ParseStatusUpdates(body []byte) ([]services.WhatsAppStatusUpdate, error) {
+	var webhook cloudWebhook
+	if err := json.Unmarshal(body, &webhook); err != nil {
+		return nil, fmt.Errorf("invalid whatsapp webhook: %w", err)
+	}
+
+	var updates []services.WhatsAppStatusUpdate
+	for _, entry := range webhook.Entry {
+		for _, change := range entry.Changes {
+			for _, status := range change.Value.Statuses {
+				if status.ID == "" {
+					continue
+				}
+
+				update := services.WhatsAppStatusUpdate{ProviderMessageID: status.ID}
+				switch status.Status {
+				case "delivered", "read":
+					update.Status = entities.NotificationDelivered
+				case "failed":
+					update.Status = entities.NotificationFailed
+					update.Error = "failed"
+					if len(status.Errors) > 0 {
+						update.Error = fmt.Sprintf("%s (error %d)", status.Errors[0].Title, status.Errors[0].Code)
+					}
+				default:
+					// "sent" and other progress reports
+					update.Status = entities.NotificationSent
+				}
+				updates = append(updates, update)
+			}
+		}
+	}
+	return updates, nil
+}
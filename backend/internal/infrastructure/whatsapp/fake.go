@@ -0,0 +1,143 @@
+package whatsapp
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// FakeMessage is a template message the fake provider was asked to send
+type FakeMessage struct {
+	ID             string
+	To             string
+	Template       string
+	Language       string
+	BodyParameters []string
+	DocumentName   string
+	DocumentSize   int
+	SentAt         time.Time
+}
+
+// FakeProvider records messages instead of sending them and prints them to
+// the log. It is the default provider in development and stands in for the
+// Cloud API in tests, where FailWith simulates provider errors.
+type FakeProvider struct {
+	mu       sync.Mutex
+	messages []FakeMessage
+	failWith error
+}
+
+// NewFakeProvider creates a fake provider with no recorded messages
+func NewFakeProvider() *FakeProvider {
+	return &FakeProvider{}
+}
+
+var _ services.WhatsAppProvider = (*FakeProvider)(nil)
+
+// Name returns "whatsapp_fake"
+func (p *FakeProvider) Name() string {
+	return "whatsapp_" + ProviderFake
+}
+
+// SendTemplate records the message, or returns the error set with FailWith
+func (p *FakeProvider) SendTemplate(ctx context.Context, to string, message services.WhatsAppTemplateMessage) (*services.WhatsAppSendResult, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if p.failWith != nil {
+		return nil, p.failWith
+	}
+
+	recorded := FakeMessage{
+		ID:             "wamid.fake-" + uuid.NewString(),
+		To:             to,
+		Template:       message.Template,
+		Language:       message.Language,
+		BodyParameters: append([]string(nil), message.BodyParameters...),
+		SentAt:         time.Now(),
+	}
+	if message.Document != nil {
+		recorded.DocumentName = message.Document.Filename
+		recorded.DocumentSize = len(message.Document.Data)
+	}
+	p.messages = append(p.messages, recorded)
+	fmt.Printf("[WhatsApp Service] Would send template %s to %s (%s) with document %q\n",
+		message.Template, to, strings.Join(message.BodyParameters, " | "), recorded.DocumentName)
+
+	return &services.WhatsAppSendResult{ProviderMessageID: recorded.ID}, nil
+}
+
+// VerifyWebhook accepts any subscribe handshake
+func (p *FakeProvider) VerifyWebhook(mode, token, challenge string) (string, bool) {
+	return challenge, mode == "subscribe"
+}
+
+// VerifySignature accepts any signature; fake status updates only name fake messages
+func (p *FakeProvider) VerifySignature(body []byte, signature string) bool {
+	return true
+}
+
+type fakeStatus struct {
+	MessageID string                      `json:"message_id"`
+	Status    entities.NotificationStatus `json:"status"`
+	Error     string                      `json:"error"`
+}
+
+// ParseStatusUpdates reads {"message_id": "...", "status": "delivered"|"failed", "error": "..."}
+func (p *FakeProvider) ParseStatusUpdates(body []byte) ([]services.WhatsAppStatusUpdate, error) {
+	var status fakeStatus
+	if err := json.Unmarshal(body, &status); err != nil {
+		return nil, fmt.Errorf("invalid fake status update: %w", err)
+	}
+	if status.MessageID == "" {
+		return nil, fmt.Errorf("fake status update has no message_id")
+	}
+	return []services.WhatsAppStatusUpdate{{
+		ProviderMessageID: status.MessageID,
+		Status:            status.Status,
+		Error:             status.Error,
+	}}, nil
+}
+
+// Messages returns the recorded messages, oldest first
+func (p *FakeProvider) Messages() []FakeMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]FakeMessage(nil), p.messages...)
+}
+
+// MessagesTo returns the recorded messages to one number
+func (p *FakeProvider) MessagesTo(to string) []FakeMessage {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	var messages []FakeMessage
+	for _, message := range p.messages {
+		if message.To == to {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// FailWith makes every following SendTemplate return err; nil restores sending
+func (p *FakeProvider) FailWith(err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.failWith = err
+}
+
+// Reset forgets the recorded messages and any failure
+func (p *FakeProvider) Reset() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.messages = nil
+	p.failWith = nil
+}
@@ -0,0 +1,55 @@
+// Package whatsapp implements services.WhatsAppProvider for the WhatsApp
+// Business Cloud API, plus a fake provider that records messages instead of
+// sending them. The provider is picked with WHATSAPP_PROVIDER.
+package whatsapp
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// Provider names, as used in WHATSAPP_PROVIDER; the message log records them
+// prefixed with "whatsapp_" to keep them apart from SMS providers
+const (
+	ProviderCloud = "cloud"
+	ProviderFake  = "fake"
+)
+
+// requestTimeout bounds one call to the provider's API; media uploads carry a PDF
+const requestTimeout = 30 * time.Second
+
+// Config selects and configures the WhatsApp provider
+type Config struct {
+	Provider string
+
+	// PhoneNumberID is the Cloud API ID of the business phone number messages are sent from
+	PhoneNumberID string
+	AccessToken   string
+	// AppSecret signs status webhooks (X-Hub-Signature-256)
+	AppSecret string
+	// VerifyToken is echoed back by Meta when the webhook URL is registered
+	VerifyToken string
+	APIVersion  string
+}
+
+// NewProvider creates the configured provider. An empty provider name selects
+// the fake provider, so development setups work without a WhatsApp Business account.
+func NewProvider(config Config) (services.WhatsAppProvider, error) {
+	switch strings.ToLower(config.Provider) {
+	case ProviderCloud:
+		if config.PhoneNumberID == "" || config.AccessToken == "" {
+			return nil, fmt.Errorf("WHATSAPP_PHONE_NUMBER_ID and WHATSAPP_ACCESS_TOKEN are required for the cloud WhatsApp provider")
+		}
+		if config.AppSecret == "" {
+			return nil, fmt.Errorf("WHATSAPP_APP_SECRET is required to verify status webhooks")
+		}
+		return NewCloudProvider(config.PhoneNumberID, config.AccessToken, config.AppSecret, config.VerifyToken, config.APIVersion), nil
+	case ProviderFake, "":
+		return NewFakeProvider(), nil
+	default:
+		return nil, fmt.Errorf("unknown WhatsApp provider %q", config.Provider)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/uduxpass/backend/internal/domain/repositories"
 	"github.com/uduxpass/backend/internal/usecases/admin"
+	"github.com/uduxpass/backend/internal/usecases/analytics"
 	"github.com/uduxpass/backend/internal/usecases/events"
 )
 
@@ -20,6 +21,7 @@ type AdminHandlerExtended struct {
 	ticketRepo       repositories.TicketRepository
 	scannerUserRepo  repositories.ScannerUserRepository
 	organizerRepo    repositories.OrganizerRepository
+	analyticsService *analytics.AnalyticsService
 }
 
 // NewAdminHandlerExtended creates a new extended admin handler
@@ -31,6 +33,7 @@ func NewAdminHandlerExtended(
 	ticketRepo repositories.TicketRepository,
 	scannerUserRepo repositories.ScannerUserRepository,
 	organizerRepo repositories.OrganizerRepository,
+	analyticsService *analytics.AnalyticsService,
 ) *AdminHandlerExtended {
 	return &AdminHandlerExtended{
 		adminAuthService: adminAuthService,
@@ -40,6 +43,7 @@ func NewAdminHandlerExtended(
 		ticketRepo:       ticketRepo,
 		scannerUserRepo:  scannerUserRepo,
 		organizerRepo:    organizerRepo,
+		analyticsService: analyticsService,
 	}
 }
 
@@ -418,27 +422,104 @@ func (h *AdminHandlerExtended) ValidateTicket(c *gin.Context) {
 
 // Analytics Methods
 
+// GetDashboard summarizes sales, buyers and events over a period
 func (h *AdminHandlerExtended) GetDashboard(c *gin.Context) {
-	// Get dashboard statistics
-	// TODO: Implement proper dashboard aggregation
-	
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data": gin.H{
-			"totalEvents":  0,
-			"totalOrders":  0,
-			"totalTickets": 0,
-			"totalRevenue": 0,
-		},
-	})
+	req, ok := analyticsRequest(c)
+	if !ok {
+		return
+	}
+
+	dashboard, err := h.analyticsService.GetDashboard(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, dashboard)
 }
 
+// GetSalesAnalytics reports revenue, orders, tickets and conversion over time
+// with breakdowns by event, tier, payment method and city
 func (h *AdminHandlerExtended) GetSalesAnalytics(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "Not implemented yet"})
+	req, ok := analyticsRequest(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.analyticsService.GetSalesAnalytics(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, report)
 }
 
+// GetUserAnalytics reports new and returning buyers over time
 func (h *AdminHandlerExtended) GetUserAnalytics(c *gin.Context) {
-	c.JSON(http.StatusNotImplemented, gin.H{"error": "Not implemented yet"})
+	req, ok := analyticsRequest(c)
+	if !ok {
+		return
+	}
+
+	report, err := h.analyticsService.GetBuyerAnalytics(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, report)
+}
+
+// RefreshAnalytics rebuilds the sales figures now rather than at the next scheduled refresh
+func (h *AdminHandlerExtended) RefreshAnalytics(c *gin.Context) {
+	refreshedAt, err := h.analyticsService.Refresh(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, gin.H{"refreshed_at": refreshedAt})
+}
+
+// analyticsRequest reads the period, scope and grouping shared by the analytics endpoints
+func analyticsRequest(c *gin.Context) (*analytics.AnalyticsRequest, bool) {
+	from, ok := parseQueryDate(c, "from", false)
+	if !ok {
+		return nil, false
+	}
+	to, ok := parseQueryDate(c, "to", true)
+	if !ok {
+		return nil, false
+	}
+	organizerID, err := parseQueryUUID(c, "organizer_id")
+	if err != nil {
+		validationErrorResponse(c, "organizer_id", "organizer_id must be a valid UUID")
+		return nil, false
+	}
+	eventID, err := parseQueryUUID(c, "event_id")
+	if err != nil {
+		validationErrorResponse(c, "event_id", "event_id must be a valid UUID")
+		return nil, false
+	}
+
+	limit := 0
+	if raw := c.Query("limit"); raw != "" {
+		if limit, err = strconv.Atoi(raw); err != nil || limit < 1 {
+			validationErrorResponse(c, "limit", "limit must be a positive number")
+			return nil, false
+		}
+	}
+
+	return &analytics.AnalyticsRequest{
+		From:        from,
+		To:          to,
+		OrganizerID: organizerID,
+		EventID:     eventID,
+		Currency:    c.Query("currency"),
+		Bucket:      repositories.AnalyticsBucket(c.Query("bucket")),
+		Limit:       limit,
+	}, true
 }
 
 // Scanner User Management Methods
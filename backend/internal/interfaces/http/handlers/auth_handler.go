@@ -101,9 +101,7 @@ func (h *AuthHandler) LoginEmailUser(c *gin.Context) {
 		return
 	}
 	
-	// For now, use email-based OTP as a stub
-	// In production, this would integrate with MoMo API
-	err := h.authService.SendOTP(c.Request.Context(), req.Phone+"@momo.temp")
+	err := h.authService.SendPhoneOTP(c.Request.Context(), req.Phone)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -140,18 +138,13 @@ func (h *AuthHandler) VerifyMoMoOTP(c *gin.Context) {
 		return
 	}
 	
-	// For now, use email-based OTP verification as a stub
-	// In production, this would integrate with MoMo API
-	err := h.authService.VerifyOTP(c.Request.Context(), req.Phone+"@momo.temp", req.OTP)
+	response, err := h.authService.VerifyPhoneOTP(c.Request.Context(), req.Phone, req.OTP)
 	if err != nil {
 		handleError(c, err)
 		return
 	}
 	
-	c.JSON(http.StatusOK, gin.H{
-		"message": "OTP verified successfully",
-		"phone":   req.Phone,
-	})
+	c.JSON(http.StatusOK, response)
 }
 
 // RefreshToken handles token refresh
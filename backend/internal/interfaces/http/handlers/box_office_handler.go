@@ -0,0 +1,216 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/boxoffice"
+)
+
+// BoxOfficeHandler handles in-person sales, ticket printing and cash drawer reconciliation
+type BoxOfficeHandler struct {
+	boxOfficeService *boxoffice.BoxOfficeService
+}
+
+// NewBoxOfficeHandler creates a new box office handler
+func NewBoxOfficeHandler(boxOfficeService *boxoffice.BoxOfficeService) *BoxOfficeHandler {
+	return &BoxOfficeHandler{
+		boxOfficeService: boxOfficeService,
+	}
+}
+
+// OpenShift opens a cash drawer for the signed-in operator
+func (h *BoxOfficeHandler) OpenShift(c *gin.Context) {
+	operatorID := getAdminID(c)
+	if operatorID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
+		return
+	}
+
+	var req boxoffice.OpenShiftRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.OperatorID = *operatorID
+
+	shift, err := h.boxOfficeService.OpenShift(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, shift)
+}
+
+// GetCurrentShift returns the signed-in operator's open shift
+func (h *BoxOfficeHandler) GetCurrentShift(c *gin.Context) {
+	operatorID := getAdminID(c)
+	if operatorID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
+		return
+	}
+
+	shift, err := h.boxOfficeService.GetCurrentShift(c.Request.Context(), *operatorID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, shift)
+}
+
+// ListShifts lists cash drawer sessions, newest first
+func (h *BoxOfficeHandler) ListShifts(c *gin.Context) {
+	page, limit, _, _ := getPaginationParams(c)
+
+	eventID, err := parseQueryUUID(c, "event_id")
+	if err != nil {
+		validationErrorResponse(c, "event_id", "invalid event ID")
+		return
+	}
+	operatorID, err := parseQueryUUID(c, "operator_id")
+	if err != nil {
+		validationErrorResponse(c, "operator_id", "invalid operator ID")
+		return
+	}
+
+	filter := repositories.BoxOfficeShiftFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		EventID:    eventID,
+		OperatorID: operatorID,
+	}
+	if status := c.Query("status"); status != "" {
+		shiftStatus := entities.BoxOfficeShiftStatus(status)
+		filter.Status = &shiftStatus
+	}
+
+	shifts, pagination, err := h.boxOfficeService.ListShifts(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       shifts,
+		"pagination": pagination,
+	})
+}
+
+// GetShiftReport returns the reconciliation report for a shift
+func (h *BoxOfficeHandler) GetShiftReport(c *gin.Context) {
+	shiftID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+	callerID := getAdminID(c)
+	if callerID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
+		return
+	}
+
+	report, err := h.boxOfficeService.GetShiftReport(c.Request.Context(), shiftID, *callerID, entities.AdminRole(c.GetString("adminRole")), c.Query("include_sales") == "true")
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, report)
+}
+
+// CloseShift closes a cash drawer with the counted cash and returns the reconciliation
+func (h *BoxOfficeHandler) CloseShift(c *gin.Context) {
+	shiftID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+	closedBy := getAdminID(c)
+	if closedBy == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
+		return
+	}
+
+	var req boxoffice.CloseShiftRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.ShiftID = shiftID
+	req.ClosedBy = *closedBy
+	req.CallerRole = entities.AdminRole(c.GetString("adminRole"))
+
+	report, err := h.boxOfficeService.CloseShift(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, report)
+}
+
+// CreateSale sells tickets to a walk-in customer on the operator's open shift
+func (h *BoxOfficeHandler) CreateSale(c *gin.Context) {
+	operatorID := getAdminID(c)
+	if operatorID == nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Admin authentication required"})
+		return
+	}
+
+	var req boxoffice.CreateSaleRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.OperatorID = *operatorID
+
+	resp, err := h.boxOfficeService.CreateSale(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, resp)
+}
+
+// EmailTickets emails the tickets of a box office order to the customer
+func (h *BoxOfficeHandler) EmailTickets(c *gin.Context) {
+	orderID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req boxoffice.EmailTicketsRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.OrderID = orderID
+
+	if err := h.boxOfficeService.EmailTickets(c.Request.Context(), &req); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, gin.H{"message": "Tickets are being emailed"})
+}
+
+// PrintTicket returns a single ticket of a box office order as a PDF
+func (h *BoxOfficeHandler) PrintTicket(c *gin.Context) {
+	orderID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+	ticketID, ok := parseUUID(c, "ticketId")
+	if !ok {
+		return
+	}
+
+	ticket, err := h.boxOfficeService.GetTicketPDF(c.Request.Context(), orderID, ticketID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("inline; filename=%q", ticket.Filename))
+	c.Data(http.StatusOK, "application/pdf", ticket.PDF)
+}
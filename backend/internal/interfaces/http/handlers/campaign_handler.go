@@ -0,0 +1,223 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/campaigns"
+)
+
+// CampaignHandler handles event reminder and follow-up campaign requests
+type CampaignHandler struct {
+	campaignService *campaigns.CampaignService
+}
+
+// NewCampaignHandler creates a new campaign handler
+func NewCampaignHandler(campaignService *campaigns.CampaignService) *CampaignHandler {
+	return &CampaignHandler{
+		campaignService: campaignService,
+	}
+}
+
+// ListCampaigns lists an event's reminders and follow-ups in send order
+// GET /v1/admin/events/:id/campaigns
+func (h *CampaignHandler) ListCampaigns(c *gin.Context) {
+	eventID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	eventCampaigns, err := h.campaignService.ListCampaigns(c.Request.Context(), eventID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, eventCampaigns)
+}
+
+// CreateCampaign schedules a reminder or follow-up for an event
+// POST /v1/admin/events/:id/campaigns
+func (h *CampaignHandler) CreateCampaign(c *gin.Context) {
+	eventID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req campaigns.CreateCampaignRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.CreatedBy = getAdminID(c)
+
+	campaign, err := h.campaignService.CreateCampaign(c.Request.Context(), eventID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, campaign)
+}
+
+// GetCampaign returns a campaign and its progress
+// GET /v1/admin/campaigns/:id
+func (h *CampaignHandler) GetCampaign(c *gin.Context) {
+	campaignID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	campaign, err := h.campaignService.GetCampaign(c.Request.Context(), campaignID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, campaign)
+}
+
+// UpdateCampaign changes a campaign that has not been sent
+// PUT /v1/admin/campaigns/:id
+func (h *CampaignHandler) UpdateCampaign(c *gin.Context) {
+	campaignID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req campaigns.UpdateCampaignRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	campaign, err := h.campaignService.UpdateCampaign(c.Request.Context(), campaignID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, campaign)
+}
+
+// CancelCampaign stops a campaign that is not being sent right now
+// POST /v1/admin/campaigns/:id/cancel
+func (h *CampaignHandler) CancelCampaign(c *gin.Context) {
+	campaignID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	campaign, err := h.campaignService.CancelCampaign(c.Request.Context(), campaignID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, campaign)
+}
+
+// ListDeliveries lists who a campaign reached, how, and who it skipped
+// GET /v1/admin/campaigns/:id/deliveries?status=&channel=&page=&limit=
+func (h *CampaignHandler) ListDeliveries(c *gin.Context) {
+	campaignID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.CampaignDeliveryFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		CampaignID: campaignID,
+	}
+	if status := c.Query("status"); status != "" {
+		deliveryStatus := entities.CampaignDeliveryStatus(status)
+		filter.Status = &deliveryStatus
+	}
+	if channel := c.Query("channel"); channel != "" {
+		deliveryChannel := entities.NotificationChannel(channel)
+		filter.Channel = &deliveryChannel
+	}
+
+	deliveries, pagination, err := h.campaignService.ListDeliveries(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       deliveries,
+		"pagination": pagination,
+	})
+}
+
+// GetUnsubscribe describes the campaign behind an unsubscribe link
+// GET /v1/campaigns/unsubscribe/:token
+func (h *CampaignHandler) GetUnsubscribe(c *gin.Context) {
+	info, err := h.campaignService.GetUnsubscribe(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, info)
+}
+
+// Unsubscribe stops further campaigns of the link's type, or of every type with all=true
+// POST /v1/campaigns/unsubscribe/:token?all=
+func (h *CampaignHandler) Unsubscribe(c *gin.Context) {
+	all, err := parseQueryBool(c, "all")
+	if err != nil {
+		validationErrorResponse(c, "all", "all must be true or false")
+		return
+	}
+
+	req := campaigns.UnsubscribeRequest{All: all != nil && *all}
+	info, err := h.campaignService.Unsubscribe(c.Request.Context(), c.Param("token"), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, info)
+}
+
+// GetNotificationPreferences returns the current user's campaign channel and subscriptions
+// GET /v1/user/notification-preferences
+func (h *CampaignHandler) GetNotificationPreferences(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	preferences, err := h.campaignService.GetPreferences(c.Request.Context(), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, preferences)
+}
+
+// UpdateNotificationPreferences sets the current user's campaign channel and subscriptions
+// PUT /v1/user/notification-preferences
+func (h *CampaignHandler) UpdateNotificationPreferences(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req campaigns.UpdatePreferencesRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	preferences, err := h.campaignService.UpdatePreferences(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, preferences)
+}
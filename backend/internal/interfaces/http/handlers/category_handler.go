@@ -2,80 +2,168 @@ package handlers
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/gin-gonic/gin"
-	"github.com/jmoiron/sqlx"
+	"github.com/uduxpass/backend/internal/usecases/categories"
 )
 
+// CategoryHandler handles event categories
 type CategoryHandler struct {
-	db *sqlx.DB
+	categoryService *categories.CategoryService
 }
 
-type Category struct {
-	ID           string    `db:"id" json:"id"`
-	Name         string    `db:"name" json:"name"`
-	Slug         string    `db:"slug" json:"slug"`
-	Description  *string   `db:"description" json:"description"`
-	Icon         *string   `db:"icon" json:"icon"`
-	Color        *string   `db:"color" json:"color"`
-	DisplayOrder int       `db:"display_order" json:"display_order"`
-	IsActive     bool      `db:"is_active" json:"is_active"`
-	CreatedAt    time.Time `db:"created_at" json:"created_at"`
-	UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
+// NewCategoryHandler creates a new category handler
+func NewCategoryHandler(categoryService *categories.CategoryService) *CategoryHandler {
+	return &CategoryHandler{
+		categoryService: categoryService,
+	}
 }
 
-func NewCategoryHandler(db *sqlx.DB) *CategoryHandler {
-	return &CategoryHandler{db: db}
+// GetCategories lists active categories as a tree with listed event counts,
+// or flat with ?flat=true
+// GET /v1/categories?flat=
+func (h *CategoryHandler) GetCategories(c *gin.Context) {
+	h.listCategories(c, false)
 }
 
-func (h *CategoryHandler) GetCategories(c *gin.Context) {
-	query := `
-		SELECT id, name, slug, description, icon, color, display_order, is_active, created_at, updated_at
-		FROM categories
-		WHERE is_active = true
-		ORDER BY display_order ASC, name ASC
-	`
-
-	rows, err := h.db.Query(query)
+// ListCategories lists all categories, inactive ones included, for administration
+// GET /v1/admin/categories?flat=
+func (h *CategoryHandler) ListCategories(c *gin.Context) {
+	h.listCategories(c, true)
+}
+
+func (h *CategoryHandler) listCategories(c *gin.Context, includeInactive bool) {
+	flat, err := parseQueryBool(c, "flat")
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to fetch categories",
-			"message": err.Error(),
-		})
-		return
-	}
-	defer rows.Close()
-
-	categories := []Category{}
-	for rows.Next() {
-		var cat Category
-		err := rows.Scan(
-			&cat.ID,
-			&cat.Name,
-			&cat.Slug,
-			&cat.Description,
-			&cat.Icon,
-			&cat.Color,
-			&cat.DisplayOrder,
-			&cat.IsActive,
-			&cat.CreatedAt,
-			&cat.UpdatedAt,
-		)
+		validationErrorResponse(c, "flat", "must be true or false")
+		return
+	}
+
+	if flat != nil && *flat {
+		categoryList, err := h.categoryService.ListCategories(c.Request.Context(), includeInactive)
 		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"error":   "Failed to scan category",
-				"message": err.Error(),
-			})
+			handleError(c, err)
 			return
 		}
-		categories = append(categories, cat)
+		successResponse(c, categoryList)
+		return
+	}
+
+	tree, err := h.categoryService.GetCategoryTree(c.Request.Context(), includeInactive)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, tree)
+}
+
+// GetCategory retrieves a category with its subcategories
+func (h *CategoryHandler) GetCategory(c *gin.Context) {
+	categoryID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	category, err := h.categoryService.GetCategory(c.Request.Context(), categoryID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, category)
+}
+
+// CreateCategory creates a category or subcategory
+func (h *CategoryHandler) CreateCategory(c *gin.Context) {
+	var req categories.CreateCategoryRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	category, err := h.categoryService.CreateCategory(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, category)
+}
+
+// UpdateCategory updates a category, including moving it under another parent
+func (h *CategoryHandler) UpdateCategory(c *gin.Context) {
+	categoryID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req categories.UpdateCategoryRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	category, err := h.categoryService.UpdateCategory(c.Request.Context(), categoryID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, category)
+}
+
+// DeleteCategory deletes a category with no subcategories and no events
+func (h *CategoryHandler) DeleteCategory(c *gin.Context) {
+	categoryID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.categoryService.DeleteCategory(c.Request.Context(), categoryID); err != nil {
+		handleError(c, err)
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
 		"success": true,
-		"data":    categories,
+		"message": "Category deleted",
 	})
 }
+
+// ReorderCategories sets the display order of one level of categories
+// PUT /v1/admin/categories/order
+func (h *CategoryHandler) ReorderCategories(c *gin.Context) {
+	var req categories.ReorderCategoriesRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	tree, err := h.categoryService.ReorderCategories(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, tree)
+}
+
+// SetEventCategory files an event under a category, or uncategorizes it
+// PUT /v1/admin/events/:id/category
+func (h *CategoryHandler) SetEventCategory(c *gin.Context) {
+	eventID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req categories.SetEventCategoryRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	event, err := h.categoryService.SetEventCategory(c.Request.Context(), eventID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, event)
+}
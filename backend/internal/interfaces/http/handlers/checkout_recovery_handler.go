@@ -0,0 +1,121 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/checkoutrecovery"
+)
+
+// CheckoutRecoveryHandler handles abandoned checkout recovery requests
+type CheckoutRecoveryHandler struct {
+	recoveryService *checkoutrecovery.RecoveryService
+}
+
+// NewCheckoutRecoveryHandler creates a new checkout recovery handler
+func NewCheckoutRecoveryHandler(recoveryService *checkoutrecovery.RecoveryService) *CheckoutRecoveryHandler {
+	return &CheckoutRecoveryHandler{
+		recoveryService: recoveryService,
+	}
+}
+
+// GetCheckout returns the tickets a recovery link offers at today's prices
+// GET /v1/checkout-recovery/:token
+func (h *CheckoutRecoveryHandler) GetCheckout(c *gin.Context) {
+	checkout, err := h.recoveryService.GetCheckout(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, checkout)
+}
+
+// RecreateOrder places the abandoned order again for the current user
+// POST /v1/checkout-recovery/:token/order
+func (h *CheckoutRecoveryHandler) RecreateOrder(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	created, err := h.recoveryService.RecreateOrder(c.Request.Context(), c.Param("token"), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, created)
+}
+
+// ListRecoveries lists abandoned checkouts and what became of their recovery messages
+// GET /v1/admin/checkout-recoveries?event_id=&status=&reason=&converted=&page=&limit=
+func (h *CheckoutRecoveryHandler) ListRecoveries(c *gin.Context) {
+	eventID, err := parseQueryUUID(c, "event_id")
+	if err != nil {
+		validationErrorResponse(c, "event_id", "event_id must be a valid UUID")
+		return
+	}
+	converted, err := parseQueryBool(c, "converted")
+	if err != nil {
+		validationErrorResponse(c, "converted", "converted must be true or false")
+		return
+	}
+
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.CheckoutRecoveryFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		EventID:    eventID,
+		Converted:  converted,
+	}
+	if status := c.Query("status"); status != "" {
+		recoveryStatus := entities.CheckoutRecoveryStatus(status)
+		filter.Status = &recoveryStatus
+	}
+	if reason := c.Query("reason"); reason != "" {
+		recoveryReason := entities.CheckoutRecoveryReason(reason)
+		filter.Reason = &recoveryReason
+	}
+
+	recoveries, pagination, err := h.recoveryService.ListRecoveries(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       recoveries,
+		"pagination": pagination,
+	})
+}
+
+// GetStats reports abandoned and recovered revenue per currency
+// GET /v1/admin/checkout-recoveries/stats?event_id=&from=&to=
+func (h *CheckoutRecoveryHandler) GetStats(c *gin.Context) {
+	eventID, err := parseQueryUUID(c, "event_id")
+	if err != nil {
+		validationErrorResponse(c, "event_id", "event_id must be a valid UUID")
+		return
+	}
+
+	from, ok := parseQueryDate(c, "from", false)
+	if !ok {
+		return
+	}
+	to, ok := parseQueryDate(c, "to", true)
+	if !ok {
+		return
+	}
+
+	filter := repositories.CheckoutRecoveryStatsFilter{EventID: eventID, From: from, To: to}
+	stats, err := h.recoveryService.GetStats(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, stats)
+}
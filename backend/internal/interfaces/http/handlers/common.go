@@ -279,3 +279,20 @@ type SuccessResponse struct {
 	Data    interface{} `json:"data,omitempty"`
 }
 
+
+// getAdminID returns the authenticated admin's ID set by the admin auth middleware
+func getAdminID(c *gin.Context) *uuid.UUID {
+	value, exists := c.Get("adminID")
+	if !exists {
+		return nil
+	}
+	switch v := value.(type) {
+	case uuid.UUID:
+		return &v
+	case string:
+		if id, err := uuid.Parse(v); err == nil {
+			return &id
+		}
+	}
+	return nil
+}
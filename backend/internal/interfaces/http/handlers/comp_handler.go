@@ -0,0 +1,258 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/comps"
+)
+
+// CompHandler handles comp allocations, comp issuing and guest list check-in
+type CompHandler struct {
+	compService *comps.CompService
+}
+
+// NewCompHandler creates a new comp handler
+func NewCompHandler(compService *comps.CompService) *CompHandler {
+	return &CompHandler{
+		compService: compService,
+	}
+}
+
+// ListAllocations lists the comp quotas of an event
+func (h *CompHandler) ListAllocations(c *gin.Context) {
+	eventID, err := parseQueryUUID(c, "event_id")
+	if err != nil || eventID == nil {
+		validationErrorResponse(c, "event_id", "a valid event ID is required")
+		return
+	}
+
+	allocations, err := h.compService.ListAllocations(c.Request.Context(), *eventID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, allocations)
+}
+
+// SetAllocation creates or resizes the comp quota of a ticket tier
+func (h *CompHandler) SetAllocation(c *gin.Context) {
+	var req comps.SetAllocationRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.CreatedBy = getAdminID(c)
+
+	allocation, err := h.compService.SetAllocation(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, allocation)
+}
+
+// IssueComp issues a single named comp
+func (h *CompHandler) IssueComp(c *gin.Context) {
+	var req comps.IssueCompRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.IssuedBy = getAdminID(c)
+
+	resp, err := h.compService.IssueComp(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, resp)
+}
+
+// ImportComps issues comps from an uploaded CSV file
+func (h *CompHandler) ImportComps(c *gin.Context) {
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		validationErrorResponse(c, "file", "a CSV file is required")
+		return
+	}
+
+	var tierID *uuid.UUID
+	if value := c.PostForm("ticket_tier_id"); value != "" {
+		id, err := uuid.Parse(value)
+		if err != nil {
+			validationErrorResponse(c, "ticket_tier_id", "invalid ticket tier ID")
+			return
+		}
+		tierID = &id
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		validationErrorResponse(c, "file", "could not read the uploaded file")
+		return
+	}
+	defer file.Close()
+
+	resp, err := h.compService.ImportComps(c.Request.Context(), &comps.ImportCompsRequest{
+		TicketTierID: tierID,
+		File:         file,
+		IssuedBy:     getAdminID(c),
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, resp)
+}
+
+// ListGuestList lists guest list entries, searchable by name
+func (h *CompHandler) ListGuestList(c *gin.Context) {
+	filter, ok := guestListFilterFromQuery(c)
+	if !ok {
+		return
+	}
+
+	h.listGuestList(c, filter)
+}
+
+// CheckInGuest admits a guest by name from the admin dashboard
+func (h *CompHandler) CheckInGuest(c *gin.Context) {
+	entryID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	checkedInBy := "admin"
+	if adminID := getAdminID(c); adminID != nil {
+		checkedInBy = fmt.Sprintf("admin:%s", adminID)
+	}
+
+	entry, err := h.compService.CheckIn(c.Request.Context(), &comps.CheckInRequest{
+		EntryID:     entryID,
+		CheckedInBy: checkedInBy,
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, entry)
+}
+
+// CancelComp withdraws an unused comp
+func (h *CompHandler) CancelComp(c *gin.Context) {
+	entryID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	entry, err := h.compService.CancelComp(c.Request.Context(), entryID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, entry)
+}
+
+// ScannerSearchGuestList lets door staff look a guest up by name for one event
+func (h *CompHandler) ScannerSearchGuestList(c *gin.Context) {
+	filter, ok := guestListFilterFromQuery(c)
+	if !ok {
+		return
+	}
+	if filter.EventID == nil {
+		validationErrorResponse(c, "event_id", "event ID is required")
+		return
+	}
+
+	h.listGuestList(c, filter)
+}
+
+// ScannerCheckInGuest lets door staff admit a guest by name without a QR code
+func (h *CompHandler) ScannerCheckInGuest(c *gin.Context) {
+	scannerID, exists := c.Get("scanner_id")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Scanner authentication required"})
+		return
+	}
+
+	entryID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req struct {
+		EventID uuid.UUID `json:"event_id" validate:"required"`
+	}
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	entry, err := h.compService.CheckIn(c.Request.Context(), &comps.CheckInRequest{
+		EntryID:     entryID,
+		EventID:     &req.EventID,
+		CheckedInBy: fmt.Sprintf("scanner:%v", scannerID),
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, entry)
+}
+
+func (h *CompHandler) listGuestList(c *gin.Context, filter repositories.GuestListFilter) {
+	entries, pagination, err := h.compService.ListGuestList(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       entries,
+		"pagination": pagination,
+	})
+}
+
+// guestListFilterFromQuery builds a guest list filter from query parameters
+func guestListFilterFromQuery(c *gin.Context) (repositories.GuestListFilter, bool) {
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.GuestListFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		Search:     c.Query("search"),
+	}
+
+	eventID, err := parseQueryUUID(c, "event_id")
+	if err != nil {
+		validationErrorResponse(c, "event_id", "invalid event ID")
+		return filter, false
+	}
+	filter.EventID = eventID
+
+	tierID, err := parseQueryUUID(c, "ticket_tier_id")
+	if err != nil {
+		validationErrorResponse(c, "ticket_tier_id", "invalid ticket tier ID")
+		return filter, false
+	}
+	filter.TicketTierID = tierID
+
+	if status := c.Query("status"); status != "" {
+		guestStatus := entities.GuestListStatus(status)
+		filter.Status = &guestStatus
+	}
+	if category := c.Query("category"); category != "" {
+		guestCategory := entities.GuestListCategory(category)
+		filter.Category = &guestCategory
+	}
+
+	return filter, true
+}
@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/currency"
+)
+
+// CurrencyHandler handles FX rate maintenance and per-currency settlement reporting
+type CurrencyHandler struct {
+	fxService *currency.FXService
+}
+
+// NewCurrencyHandler creates a new currency handler
+func NewCurrencyHandler(fxService *currency.FXService) *CurrencyHandler {
+	return &CurrencyHandler{
+		fxService: fxService,
+	}
+}
+
+// GetSupportedCurrencies lists the currencies events can be priced in
+func (h *CurrencyHandler) GetSupportedCurrencies(c *gin.Context) {
+	successResponse(c, gin.H{
+		"currencies": entities.SupportedCurrencies(),
+		"default":    entities.DefaultCurrency,
+	})
+}
+
+// ListFXRates lists exchange rates, newest first
+func (h *CurrencyHandler) ListFXRates(c *gin.Context) {
+	page, limit, _, _ := getPaginationParams(c)
+
+	filter := repositories.FXRateFilter{
+		BaseFilter:    repositories.BaseFilter{Page: page, Limit: limit},
+		BaseCurrency:  c.Query("base_currency"),
+		QuoteCurrency: c.Query("quote_currency"),
+	}
+
+	rates, pagination, err := h.fxService.ListRates(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       rates,
+		"pagination": pagination,
+	})
+}
+
+// CreateFXRate publishes a new exchange rate
+func (h *CurrencyHandler) CreateFXRate(c *gin.Context) {
+	var req currency.CreateRateRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.CreatedBy = getAdminID(c)
+
+	rate, err := h.fxService.CreateRate(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, rate)
+}
+
+// DeleteFXRate removes an exchange rate entered in error
+func (h *CurrencyHandler) DeleteFXRate(c *gin.Context) {
+	id, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.fxService.DeleteRate(c.Request.Context(), id); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, gin.H{"message": "Exchange rate deleted successfully"})
+}
+
+// GetSettlementReport returns paid revenue per settlement currency with optional conversion
+func (h *CurrencyHandler) GetSettlementReport(c *gin.Context) {
+	eventID, err := parseQueryUUID(c, "event_id")
+	if err != nil {
+		validationErrorResponse(c, "event_id", "invalid event ID")
+		return
+	}
+	organizerID, err := parseQueryUUID(c, "organizer_id")
+	if err != nil {
+		validationErrorResponse(c, "organizer_id", "invalid organizer ID")
+		return
+	}
+	dateFrom, err := parseQueryTime(c, "date_from")
+	if err != nil {
+		validationErrorResponse(c, "date_from", "invalid date")
+		return
+	}
+	dateTo, err := parseQueryTime(c, "date_to")
+	if err != nil {
+		validationErrorResponse(c, "date_to", "invalid date")
+		return
+	}
+
+	report, err := h.fxService.GetSettlementReport(c.Request.Context(), &currency.SettlementReportRequest{
+		EventID:           eventID,
+		OrganizerID:       organizerID,
+		DateFrom:          dateFrom,
+		DateTo:            dateTo,
+		ReportingCurrency: c.Query("reporting_currency"),
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, report)
+}
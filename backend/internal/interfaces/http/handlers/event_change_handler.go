@@ -0,0 +1,210 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/eventchanges"
+)
+
+// EventChangeHandler handles event cancellation and postponement requests
+type EventChangeHandler struct {
+	changeService *eventchanges.EventChangeService
+}
+
+// NewEventChangeHandler creates a new event change handler
+func NewEventChangeHandler(changeService *eventchanges.EventChangeService) *EventChangeHandler {
+	return &EventChangeHandler{
+		changeService: changeService,
+	}
+}
+
+// CancelEvent cancels an event; holders are voided, refunded and notified in the background
+// POST /v1/admin/events/:id/cancel
+func (h *EventChangeHandler) CancelEvent(c *gin.Context) {
+	eventID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req eventchanges.CancelEventRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.CreatedBy = getAdminID(c)
+
+	change, err := h.changeService.CancelEvent(c.Request.Context(), eventID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    change,
+	})
+}
+
+// PostponeEvent moves an event to a new date; holders are notified in the background
+// POST /v1/admin/events/:id/postpone
+func (h *EventChangeHandler) PostponeEvent(c *gin.Context) {
+	eventID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req eventchanges.PostponeEventRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.CreatedBy = getAdminID(c)
+
+	change, err := h.changeService.PostponeEvent(c.Request.Context(), eventID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    change,
+	})
+}
+
+// ListChanges lists cancellations and postponements, optionally for one event
+// GET /v1/admin/event-changes?event_id=&type=&status=&page=&limit=
+func (h *EventChangeHandler) ListChanges(c *gin.Context) {
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.EventChangeFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+	}
+
+	eventID, err := parseQueryUUID(c, "event_id")
+	if err != nil {
+		validationErrorResponse(c, "event_id", "invalid event ID")
+		return
+	}
+	filter.EventID = eventID
+
+	if changeType := c.Query("type"); changeType != "" {
+		eventChangeType := entities.EventChangeType(changeType)
+		filter.Type = &eventChangeType
+	}
+	if status := c.Query("status"); status != "" {
+		changeStatus := entities.EventChangeStatus(status)
+		filter.Status = &changeStatus
+	}
+
+	changes, pagination, err := h.changeService.ListChanges(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       changes,
+		"pagination": pagination,
+	})
+}
+
+// GetChange returns a change and its delivery and refund progress
+func (h *EventChangeHandler) GetChange(c *gin.Context) {
+	changeID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	change, err := h.changeService.GetChange(c.Request.Context(), changeID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, change)
+}
+
+// ResumeChange restarts processing of the holders still pending
+func (h *EventChangeHandler) ResumeChange(c *gin.Context) {
+	changeID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	change, err := h.changeService.ResumeChange(c.Request.Context(), changeID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    change,
+	})
+}
+
+// ListRecipients returns the per-holder delivery and refund report of a change
+// GET /v1/admin/event-changes/:id/recipients?status=&refund_status=&page=&limit=
+func (h *EventChangeHandler) ListRecipients(c *gin.Context) {
+	changeID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.EventChangeRecipientFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		ChangeID:   changeID,
+	}
+	if status := c.Query("status"); status != "" {
+		recipientStatus := entities.EventChangeRecipientStatus(status)
+		filter.Status = &recipientStatus
+	}
+	if refundStatus := c.Query("refund_status"); refundStatus != "" {
+		status := entities.RecipientRefundStatus(refundStatus)
+		filter.RefundStatus = &status
+	}
+
+	recipients, pagination, err := h.changeService.ListRecipients(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       recipients,
+		"pagination": pagination,
+	})
+}
+
+// GetChoice shows a ticket holder their keep-or-refund offer
+// GET /v1/event-changes/choices/:token
+func (h *EventChangeHandler) GetChoice(c *gin.Context) {
+	info, err := h.changeService.GetChoice(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, info)
+}
+
+// RecordChoice records a ticket holder's keep-or-refund answer
+// POST /v1/event-changes/choices/:token {"choice": "keep"|"refund"}
+func (h *EventChangeHandler) RecordChoice(c *gin.Context) {
+	var req eventchanges.RecordChoiceRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	info, err := h.changeService.RecordChoice(c.Request.Context(), c.Param("token"), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, info)
+}
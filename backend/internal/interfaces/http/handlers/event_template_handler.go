@@ -0,0 +1,169 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/events"
+)
+
+// EventTemplateHandler handles event cloning and event template requests
+type EventTemplateHandler struct {
+	eventService *events.EventService
+}
+
+// NewEventTemplateHandler creates a new event template handler
+func NewEventTemplateHandler(eventService *events.EventService) *EventTemplateHandler {
+	return &EventTemplateHandler{
+		eventService: eventService,
+	}
+}
+
+// CloneEvent copies an event to a new date as a draft
+// POST /v1/admin/events/:id/clone
+func (h *EventTemplateHandler) CloneEvent(c *gin.Context) {
+	eventID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req events.CloneEventRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.AssignedBy = getAdminID(c)
+
+	resp, err := h.eventService.CloneEvent(c.Request.Context(), eventID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, resp)
+}
+
+// ListTemplates lists event templates, filterable by organizer
+// GET /v1/admin/event-templates?organizer_id=&search=&page=&limit=
+func (h *EventTemplateHandler) ListTemplates(c *gin.Context) {
+	page, limit, sortBy, sortOrder := getPaginationParams(c)
+	filter := repositories.EventTemplateFilter{
+		BaseFilter: repositories.BaseFilter{
+			Page:      page,
+			Limit:     limit,
+			SortBy:    sortBy,
+			SortOrder: repositories.SortOrder(sortOrder),
+		},
+		Search: c.Query("search"),
+	}
+
+	organizerID, err := parseQueryUUID(c, "organizer_id")
+	if err != nil {
+		validationErrorResponse(c, "organizer_id", "invalid organizer ID")
+		return
+	}
+	filter.OrganizerID = organizerID
+
+	templates, pagination, err := h.eventService.ListEventTemplates(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       templates,
+		"pagination": pagination,
+	})
+}
+
+// GetTemplate retrieves an event template
+func (h *EventTemplateHandler) GetTemplate(c *gin.Context) {
+	templateID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	template, err := h.eventService.GetEventTemplate(c.Request.Context(), templateID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, template)
+}
+
+// CreateTemplate saves an event template from an event or from given content
+func (h *EventTemplateHandler) CreateTemplate(c *gin.Context) {
+	var req events.CreateEventTemplateRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.CreatedBy = getAdminID(c)
+
+	template, err := h.eventService.CreateEventTemplate(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, template)
+}
+
+// UpdateTemplate updates an event template
+func (h *EventTemplateHandler) UpdateTemplate(c *gin.Context) {
+	templateID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req events.UpdateEventTemplateRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	template, err := h.eventService.UpdateEventTemplate(c.Request.Context(), templateID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, template)
+}
+
+// DeleteTemplate deactivates an event template
+func (h *EventTemplateHandler) DeleteTemplate(c *gin.Context) {
+	templateID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.eventService.DeleteEventTemplate(c.Request.Context(), templateID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	noContentResponse(c)
+}
+
+// CreateEventFromTemplate creates a draft event from a template
+// POST /v1/admin/event-templates/:id/events
+func (h *EventTemplateHandler) CreateEventFromTemplate(c *gin.Context) {
+	templateID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req events.CreateEventFromTemplateRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.eventService.CreateEventFromTemplate(c.Request.Context(), templateID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, resp)
+}
@@ -0,0 +1,431 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/marketing"
+)
+
+// MarketingHandler handles marketing consent, audience and campaign requests
+type MarketingHandler struct {
+	marketingService *marketing.MarketingService
+}
+
+// NewMarketingHandler creates a new marketing handler
+func NewMarketingHandler(marketingService *marketing.MarketingService) *MarketingHandler {
+	return &MarketingHandler{
+		marketingService: marketingService,
+	}
+}
+
+// GetConsents returns the current user's marketing choices, platform-wide and per organizer
+// GET /v1/user/marketing-consents
+func (h *MarketingHandler) GetConsents(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	choices, err := h.marketingService.GetConsents(c.Request.Context(), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, choices)
+}
+
+// UpdateConsent grants or withdraws the current user's consent for the platform or an organizer
+// PUT /v1/user/marketing-consents
+func (h *MarketingHandler) UpdateConsent(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req marketing.UpdateConsentRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	choices, err := h.marketingService.UpdateConsent(c.Request.Context(), userID, &req, consentMetadata(c))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, choices)
+}
+
+// GetConsentHistory lists every marketing choice the current user has made
+// GET /v1/user/marketing-consents/history?page=&limit=
+func (h *MarketingHandler) GetConsentHistory(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	page, limit, _, _ := getPaginationParams(c)
+	records, pagination, err := h.marketingService.ListConsentHistory(c.Request.Context(), userID, page, limit)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       records,
+		"pagination": pagination,
+	})
+}
+
+// GetUnsubscribe describes who a marketing unsubscribe link stops emails from
+// GET /v1/marketing/unsubscribe/:token
+func (h *MarketingHandler) GetUnsubscribe(c *gin.Context) {
+	info, err := h.marketingService.GetUnsubscribe(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, info)
+}
+
+// Unsubscribe withdraws marketing consent for the organizer behind the link
+// POST /v1/marketing/unsubscribe/:token
+func (h *MarketingHandler) Unsubscribe(c *gin.Context) {
+	info, err := h.marketingService.Unsubscribe(c.Request.Context(), c.Param("token"), consentMetadata(c))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, info)
+}
+
+// ListConsentRecords lists the consent audit trail
+// GET /v1/admin/marketing/consents/audit?user_id=&email=&organizer_id=&platform=&page=&limit=
+func (h *MarketingHandler) ListConsentRecords(c *gin.Context) {
+	userID, err := parseQueryUUID(c, "user_id")
+	if err != nil {
+		validationErrorResponse(c, "user_id", "user_id must be a valid UUID")
+		return
+	}
+	organizerID, err := parseQueryUUID(c, "organizer_id")
+	if err != nil {
+		validationErrorResponse(c, "organizer_id", "organizer_id must be a valid UUID")
+		return
+	}
+	platform, err := parseQueryBool(c, "platform")
+	if err != nil {
+		validationErrorResponse(c, "platform", "platform must be true or false")
+		return
+	}
+
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.MarketingConsentRecordFilter{
+		BaseFilter:   repositories.BaseFilter{Page: page, Limit: limit},
+		UserID:       userID,
+		OrganizerID:  organizerID,
+		PlatformOnly: platform != nil && *platform,
+	}
+	if email := c.Query("email"); email != "" {
+		filter.Email = &email
+	}
+
+	records, pagination, err := h.marketingService.ListConsentRecords(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       records,
+		"pagination": pagination,
+	})
+}
+
+// ListAudiences lists saved audiences
+// GET /v1/admin/marketing/audiences?organizer_id=&platform=&page=&limit=
+func (h *MarketingHandler) ListAudiences(c *gin.Context) {
+	organizerID, err := parseQueryUUID(c, "organizer_id")
+	if err != nil {
+		validationErrorResponse(c, "organizer_id", "organizer_id must be a valid UUID")
+		return
+	}
+	platform, err := parseQueryBool(c, "platform")
+	if err != nil {
+		validationErrorResponse(c, "platform", "platform must be true or false")
+		return
+	}
+
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.MarketingAudienceFilter{
+		BaseFilter:   repositories.BaseFilter{Page: page, Limit: limit},
+		OrganizerID:  organizerID,
+		PlatformOnly: platform != nil && *platform,
+	}
+
+	audiences, pagination, err := h.marketingService.ListAudiences(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       audiences,
+		"pagination": pagination,
+	})
+}
+
+// CreateAudience saves a segment of past buyers
+// POST /v1/admin/marketing/audiences
+func (h *MarketingHandler) CreateAudience(c *gin.Context) {
+	var req marketing.CreateAudienceRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.CreatedBy = getAdminID(c)
+
+	audience, err := h.marketingService.CreateAudience(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, audience)
+}
+
+// GetAudience returns an audience
+// GET /v1/admin/marketing/audiences/:id
+func (h *MarketingHandler) GetAudience(c *gin.Context) {
+	audienceID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	audience, err := h.marketingService.GetAudience(c.Request.Context(), audienceID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, audience)
+}
+
+// UpdateAudience changes an audience's name or criteria
+// PUT /v1/admin/marketing/audiences/:id
+func (h *MarketingHandler) UpdateAudience(c *gin.Context) {
+	audienceID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req marketing.UpdateAudienceRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	audience, err := h.marketingService.UpdateAudience(c.Request.Context(), audienceID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, audience)
+}
+
+// DeleteAudience deletes an audience no campaign uses
+// DELETE /v1/admin/marketing/audiences/:id
+func (h *MarketingHandler) DeleteAudience(c *gin.Context) {
+	audienceID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.marketingService.DeleteAudience(c.Request.Context(), audienceID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, gin.H{"deleted": true})
+}
+
+// PreviewAudience counts the buyers an audience matches and how many of them can be emailed
+// GET /v1/admin/marketing/audiences/:id/preview
+func (h *MarketingHandler) PreviewAudience(c *gin.Context) {
+	audienceID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	preview, err := h.marketingService.PreviewAudience(c.Request.Context(), audienceID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, preview)
+}
+
+// ListCampaigns lists marketing campaigns
+// GET /v1/admin/marketing/campaigns?organizer_id=&platform=&audience_id=&status=&page=&limit=
+func (h *MarketingHandler) ListCampaigns(c *gin.Context) {
+	organizerID, err := parseQueryUUID(c, "organizer_id")
+	if err != nil {
+		validationErrorResponse(c, "organizer_id", "organizer_id must be a valid UUID")
+		return
+	}
+	audienceID, err := parseQueryUUID(c, "audience_id")
+	if err != nil {
+		validationErrorResponse(c, "audience_id", "audience_id must be a valid UUID")
+		return
+	}
+	platform, err := parseQueryBool(c, "platform")
+	if err != nil {
+		validationErrorResponse(c, "platform", "platform must be true or false")
+		return
+	}
+
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.MarketingCampaignFilter{
+		BaseFilter:   repositories.BaseFilter{Page: page, Limit: limit},
+		OrganizerID:  organizerID,
+		PlatformOnly: platform != nil && *platform,
+		AudienceID:   audienceID,
+	}
+	if status := c.Query("status"); status != "" {
+		campaignStatus := entities.CampaignStatus(status)
+		filter.Status = &campaignStatus
+	}
+
+	campaigns, pagination, err := h.marketingService.ListCampaigns(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       campaigns,
+		"pagination": pagination,
+	})
+}
+
+// CreateCampaign schedules a marketing email to an audience
+// POST /v1/admin/marketing/campaigns
+func (h *MarketingHandler) CreateCampaign(c *gin.Context) {
+	var req marketing.CreateCampaignRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.CreatedBy = getAdminID(c)
+
+	campaign, err := h.marketingService.CreateCampaign(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, campaign)
+}
+
+// GetCampaign returns a marketing campaign and its progress
+// GET /v1/admin/marketing/campaigns/:id
+func (h *MarketingHandler) GetCampaign(c *gin.Context) {
+	campaignID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	campaign, err := h.marketingService.GetCampaign(c.Request.Context(), campaignID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, campaign)
+}
+
+// UpdateCampaign changes a marketing campaign that has not been sent
+// PUT /v1/admin/marketing/campaigns/:id
+func (h *MarketingHandler) UpdateCampaign(c *gin.Context) {
+	campaignID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req marketing.UpdateCampaignRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	campaign, err := h.marketingService.UpdateCampaign(c.Request.Context(), campaignID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, campaign)
+}
+
+// CancelCampaign stops a marketing campaign that is not being sent right now
+// POST /v1/admin/marketing/campaigns/:id/cancel
+func (h *MarketingHandler) CancelCampaign(c *gin.Context) {
+	campaignID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	campaign, err := h.marketingService.CancelCampaign(c.Request.Context(), campaignID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, campaign)
+}
+
+// ListDeliveries lists who a marketing campaign reached and who it skipped
+// GET /v1/admin/marketing/campaigns/:id/deliveries?status=&page=&limit=
+func (h *MarketingHandler) ListDeliveries(c *gin.Context) {
+	campaignID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.MarketingDeliveryFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		CampaignID: campaignID,
+	}
+	if status := c.Query("status"); status != "" {
+		deliveryStatus := entities.CampaignDeliveryStatus(status)
+		filter.Status = &deliveryStatus
+	}
+
+	deliveries, pagination, err := h.marketingService.ListDeliveries(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       deliveries,
+		"pagination": pagination,
+	})
+}
+
+// consentMetadata records where a consent choice came from for the audit trail
+func consentMetadata(c *gin.Context) marketing.ConsentMetadata {
+	return marketing.ConsentMetadata{
+		IPAddress: c.ClientIP(),
+		UserAgent: c.Request.UserAgent(),
+	}
+}
@@ -0,0 +1,263 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/messagetemplates"
+)
+
+// MessageTemplateHandler handles message template overrides, previews,
+// organizer branding and users' message locale
+type MessageTemplateHandler struct {
+	templateService *messagetemplates.TemplateService
+}
+
+// NewMessageTemplateHandler creates a new message template handler
+func NewMessageTemplateHandler(templateService *messagetemplates.TemplateService) *MessageTemplateHandler {
+	return &MessageTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// GetCatalog lists the templates that can be overridden and their built-in locales
+// GET /v1/admin/message-templates/catalog
+func (h *MessageTemplateHandler) GetCatalog(c *gin.Context) {
+	successResponse(c, gin.H{
+		"templates": h.templateService.Catalog(),
+		"locales":   entities.SupportedLocales,
+	})
+}
+
+// GetDefault returns the built-in version of a template, the starting point of an override
+// GET /v1/admin/message-templates/defaults?channel=&key=&locale=
+func (h *MessageTemplateHandler) GetDefault(c *gin.Context) {
+	channel := entities.NotificationChannel(c.Query("channel"))
+	key := c.Query("key")
+	if channel == "" || key == "" {
+		validationErrorResponse(c, "key", "channel and key are required")
+		return
+	}
+
+	source, err := h.templateService.GetDefault(channel, key, c.Query("locale"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, source)
+}
+
+// ListVersions lists stored template versions, newest first
+// GET /v1/admin/message-templates?key=&channel=&locale=&organizer_id=&global=&active=&page=&limit=
+func (h *MessageTemplateHandler) ListVersions(c *gin.Context) {
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.MessageTemplateFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		Key:        c.Query("key"),
+		Locale:     c.Query("locale"),
+	}
+
+	if channel := c.Query("channel"); channel != "" {
+		messageChannel := entities.NotificationChannel(channel)
+		filter.Channel = &messageChannel
+	}
+	if value := c.Query("organizer_id"); value != "" {
+		organizerID, err := uuid.Parse(value)
+		if err != nil {
+			validationErrorResponse(c, "organizer_id", "organizer_id must be a valid UUID")
+			return
+		}
+		filter.OrganizerID = &organizerID
+	}
+	global, err := parseQueryBool(c, "global")
+	if err != nil {
+		validationErrorResponse(c, "global", "global must be true or false")
+		return
+	}
+	filter.GlobalOnly = global != nil && *global
+	active, err := parseQueryBool(c, "active")
+	if err != nil {
+		validationErrorResponse(c, "active", "active must be true or false")
+		return
+	}
+	filter.ActiveOnly = active != nil && *active
+
+	versions, pagination, err := h.templateService.ListVersions(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       versions,
+		"pagination": pagination,
+	})
+}
+
+// GetVersion retrieves a stored template version
+// GET /v1/admin/message-templates/:id
+func (h *MessageTemplateHandler) GetVersion(c *gin.Context) {
+	templateID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	template, err := h.templateService.GetVersion(c.Request.Context(), templateID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, template)
+}
+
+// PublishVersion stores a new version of a template and makes it active
+// POST /v1/admin/message-templates
+func (h *MessageTemplateHandler) PublishVersion(c *gin.Context) {
+	var req messagetemplates.PublishTemplateRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	template, err := h.templateService.Publish(c.Request.Context(), &req, getAdminID(c))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, template)
+}
+
+// ActivateVersion makes a stored version the active one
+// POST /v1/admin/message-templates/:id/activate
+func (h *MessageTemplateHandler) ActivateVersion(c *gin.Context) {
+	templateID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	template, err := h.templateService.Activate(c.Request.Context(), templateID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, template)
+}
+
+// DeactivateVersion deactivates a stored version, falling back to the next template in line
+// POST /v1/admin/message-templates/:id/deactivate
+func (h *MessageTemplateHandler) DeactivateVersion(c *gin.Context) {
+	templateID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	template, err := h.templateService.Deactivate(c.Request.Context(), templateID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, template)
+}
+
+// Preview renders a template, or a draft of one, with sample data
+// POST /v1/admin/message-templates/preview
+func (h *MessageTemplateHandler) Preview(c *gin.Context) {
+	var req messagetemplates.PreviewTemplateRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	message, err := h.templateService.Preview(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, message)
+}
+
+// GetBranding returns an organizer's message branding
+// GET /v1/admin/organizers/:id/branding
+func (h *MessageTemplateHandler) GetBranding(c *gin.Context) {
+	organizerID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	branding, err := h.templateService.GetBranding(c.Request.Context(), organizerID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, branding)
+}
+
+// UpdateBranding updates an organizer's message branding
+// PUT /v1/admin/organizers/:id/branding
+func (h *MessageTemplateHandler) UpdateBranding(c *gin.Context) {
+	organizerID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req messagetemplates.UpdateBrandingRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	branding, err := h.templateService.UpdateBranding(c.Request.Context(), organizerID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, branding)
+}
+
+// GetLocale returns the language the current user receives messages in
+// GET /v1/user/locale
+func (h *MessageTemplateHandler) GetLocale(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	settings, err := h.templateService.GetLocale(c.Request.Context(), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, settings)
+}
+
+// UpdateLocale sets the language the current user receives messages in
+// PUT /v1/user/locale
+func (h *MessageTemplateHandler) UpdateLocale(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req messagetemplates.UpdateLocaleRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	settings, err := h.templateService.UpdateLocale(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, settings)
+}
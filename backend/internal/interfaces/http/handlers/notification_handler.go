@@ -0,0 +1,280 @@
+package handlers
+
+import (
+	"crypto/subtle"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/notifications"
+)
+
+// maxReceiptBodySize bounds delivery report bodies read from SMS providers
+const maxReceiptBodySize = 1 << 20
+
+// NotificationHandler handles SMS delivery receipts, WhatsApp status
+// webhooks and opt-ins, ticket retrieval links and the admin message log
+type NotificationHandler struct {
+	smsService      *notifications.SMSService
+	whatsAppService *notifications.WhatsAppService
+	ticketLinks     *notifications.TicketLinkService
+	receiptToken    string
+}
+
+// NewNotificationHandler creates a new notification handler. receiptToken
+// must be passed as ?token= on SMS delivery receipt callbacks; when it is
+// empty, receipts are refused.
+func NewNotificationHandler(
+	smsService *notifications.SMSService,
+	whatsAppService *notifications.WhatsAppService,
+	ticketLinks *notifications.TicketLinkService,
+	receiptToken string,
+) *NotificationHandler {
+	return &NotificationHandler{
+		smsService:      smsService,
+		whatsAppService: whatsAppService,
+		ticketLinks:     ticketLinks,
+		receiptToken:    receiptToken,
+	}
+}
+
+// SMSDeliveryReceipt applies a provider's delivery report
+// POST /v1/webhooks/sms/:provider?token=
+func (h *NotificationHandler) SMSDeliveryReceipt(c *gin.Context) {
+	token := c.Query("token")
+	if h.receiptToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(h.receiptToken)) != 1 {
+		errorResponse(c, http.StatusUnauthorized, "Invalid receipt token")
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxReceiptBodySize))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	updated, err := h.smsService.HandleDeliveryReceipts(c.Request.Context(), c.Param("provider"), c.ContentType(), body)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, gin.H{"updated": updated})
+}
+
+// VerifyWhatsAppWebhook answers the webhook verification handshake sent when
+// the callback URL is registered with Meta
+// GET /v1/webhooks/whatsapp?hub.mode=&hub.verify_token=&hub.challenge=
+func (h *NotificationHandler) VerifyWhatsAppWebhook(c *gin.Context) {
+	challenge, ok := h.whatsAppService.VerifyWebhook(c.Query("hub.mode"), c.Query("hub.verify_token"), c.Query("hub.challenge"))
+	if !ok {
+		errorResponse(c, http.StatusForbidden, "Invalid verify token")
+		return
+	}
+
+	c.String(http.StatusOK, challenge)
+}
+
+// WhatsAppStatusWebhook applies message status updates
+// POST /v1/webhooks/whatsapp
+func (h *NotificationHandler) WhatsAppStatusWebhook(c *gin.Context) {
+	body, err := io.ReadAll(io.LimitReader(c.Request.Body, maxReceiptBodySize))
+	if err != nil {
+		errorResponse(c, http.StatusBadRequest, "Failed to read request body")
+		return
+	}
+
+	updated, err := h.whatsAppService.HandleStatusWebhook(c.Request.Context(), body, c.GetHeader("X-Hub-Signature-256"))
+	if err != nil {
+		if errors.Is(err, notifications.ErrInvalidWhatsAppSignature) {
+			errorResponse(c, http.StatusUnauthorized, "Invalid signature")
+			return
+		}
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, gin.H{"updated": updated})
+}
+
+// GetWhatsAppOptIn returns whether the current user receives messages on WhatsApp
+// GET /v1/user/whatsapp
+func (h *NotificationHandler) GetWhatsAppOptIn(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	optIn, err := h.whatsAppService.GetOptIn(c.Request.Context(), userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, optIn)
+}
+
+// UpdateWhatsAppOptIn opts the current user in to or out of WhatsApp messages
+// PUT /v1/user/whatsapp
+func (h *NotificationHandler) UpdateWhatsAppOptIn(c *gin.Context) {
+	userID, ok := currentUserID(c)
+	if !ok {
+		return
+	}
+
+	var req notifications.UpdateWhatsAppOptInRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	optIn, err := h.whatsAppService.UpdateOptIn(c.Request.Context(), userID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, optIn)
+}
+
+// GetTicketLink opens a ticket retrieval link sent by SMS
+// GET /v1/ticket-links/:token
+func (h *NotificationHandler) GetTicketLink(c *gin.Context) {
+	retrieval, err := h.ticketLinks.Retrieve(c.Request.Context(), c.Param("token"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, retrieval)
+}
+
+// ListSMSMessages lists sent and queued text messages, newest first
+// GET /v1/admin/notifications/sms?status=&recipient=&order_id=&page=&limit=
+func (h *NotificationHandler) ListSMSMessages(c *gin.Context) {
+	filter, ok := notificationFilter(c)
+	if !ok {
+		return
+	}
+
+	messages, pagination, err := h.smsService.ListMessages(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       messages,
+		"pagination": pagination,
+	})
+}
+
+// ListWhatsAppMessages lists WhatsApp messages, newest first
+// GET /v1/admin/notifications/whatsapp?status=&recipient=&order_id=&page=&limit=
+func (h *NotificationHandler) ListWhatsAppMessages(c *gin.Context) {
+	filter, ok := notificationFilter(c)
+	if !ok {
+		return
+	}
+
+	messages, pagination, err := h.whatsAppService.ListMessages(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       messages,
+		"pagination": pagination,
+	})
+}
+
+// notificationFilter reads the message log filters shared by the channels
+func notificationFilter(c *gin.Context) (repositories.NotificationFilter, bool) {
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.NotificationFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+	}
+
+	if recipient := c.Query("recipient"); recipient != "" {
+		normalized, err := entities.NormalizePhoneNumber(recipient)
+		if err != nil {
+			handleError(c, err)
+			return filter, false
+		}
+		filter.Recipient = normalized
+	}
+
+	if status := c.Query("status"); status != "" {
+		messageStatus := entities.NotificationStatus(status)
+		switch messageStatus {
+		case entities.NotificationQueued, entities.NotificationSent, entities.NotificationDelivered, entities.NotificationFailed:
+			filter.Status = &messageStatus
+		default:
+			validationErrorResponse(c, "status", "status must be queued, sent, delivered or failed")
+			return filter, false
+		}
+	}
+
+	if value := c.Query("order_id"); value != "" {
+		orderID, err := uuid.Parse(value)
+		if err != nil {
+			validationErrorResponse(c, "order_id", "order_id must be a valid UUID")
+			return filter, false
+		}
+		filter.OrderID = &orderID
+	}
+
+	return filter, true
+}
+
+// GetSMSMessage returns one text message with its delivery state
+// GET /v1/admin/notifications/sms/:id
+func (h *NotificationHandler) GetSMSMessage(c *gin.Context) {
+	messageID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	message, err := h.smsService.GetMessage(c.Request.Context(), messageID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, message)
+}
+
+// GetWhatsAppMessage returns one WhatsApp message with its delivery state
+// GET /v1/admin/notifications/whatsapp/:id
+func (h *NotificationHandler) GetWhatsAppMessage(c *gin.Context) {
+	messageID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	message, err := h.whatsAppService.GetMessage(c.Request.Context(), messageID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, message)
+}
+
+// currentUserID reads the authenticated user's ID set by the auth middleware
+func currentUserID(c *gin.Context) (uuid.UUID, bool) {
+	userIDValue, exists := c.Get("userID")
+	userIDStr, _ := userIDValue.(string)
+	userID, err := uuid.Parse(userIDStr)
+	if !exists || err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return uuid.Nil, false
+	}
+	return userID, true
+}
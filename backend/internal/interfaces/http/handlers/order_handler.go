@@ -6,27 +6,46 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/usecases/marketing"
 	"github.com/uduxpass/backend/internal/usecases/orders"
 	"github.com/uduxpass/backend/internal/usecases/payments"
 )
 
 // OrderHandler handles order-related HTTP requests
 type OrderHandler struct {
-	orderService   *orders.OrderService
-	paymentService *payments.PaymentService
+	orderService     *orders.OrderService
+	paymentService   *payments.PaymentService
+	marketingService *marketing.MarketingService
 }
 
 // NewOrderHandler creates a new order handler
 func NewOrderHandler(
 	orderService *orders.OrderService,
 	paymentService *payments.PaymentService,
+	marketingService *marketing.MarketingService,
 ) *OrderHandler {
 	return &OrderHandler{
-		orderService:   orderService,
-		paymentService: paymentService,
+		orderService:     orderService,
+		paymentService:   paymentService,
+		marketingService: marketingService,
 	}
 }
 
+// createOrderBody is an order request together with the marketing boxes
+// ticked at checkout
+type createOrderBody struct {
+	orders.CreateOrderRequest
+	MarketingConsent *marketing.CheckoutConsent `json:"marketing_consent,omitempty"`
+}
+
+// createTourPassOrderBody is a tour pass order request together with the
+// marketing boxes ticked at checkout
+type createTourPassOrderBody struct {
+	orders.CreateTourPassOrderRequest
+	MarketingConsent *marketing.CheckoutConsent `json:"marketing_consent,omitempty"`
+}
+
 // CreateOrder handles order creation with payment initialization
 // POST /v1/orders
 func (h *OrderHandler) CreateOrder(c *gin.Context) {
@@ -40,8 +59,8 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
-	var req orders.CreateOrderRequest
-	if err := c.ShouldBindJSON(&req); err != nil {
+	var body createOrderBody
+	if err := c.ShouldBindJSON(&body); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{
 			"success": false,
 			"message": "Invalid request",
@@ -69,6 +88,7 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 	
+	req := body.CreateOrderRequest
 	req.UserID = userUUID
 
 	// Create order with inventory holds
@@ -78,6 +98,59 @@ func (h *OrderHandler) CreateOrder(c *gin.Context) {
 		return
 	}
 
+	h.recordMarketingConsent(c, orderResp.Order, body.MarketingConsent)
+	h.respondWithPayment(c, orderResp)
+}
+
+// CreateTourPassOrder handles buying tour passes with payment initialization
+// POST /v1/tour-passes/:id/orders
+func (h *OrderHandler) CreateTourPassOrder(c *gin.Context) {
+	passID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	userUUID, err := uuid.Parse(c.GetString("userID"))
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{
+			"success": false,
+			"message": "User not authenticated",
+		})
+		return
+	}
+
+	var body createTourPassOrderBody
+	if !bindAndValidate(c, &body) {
+		return
+	}
+	req := body.CreateTourPassOrderRequest
+	req.UserID = userUUID
+	req.TourPassID = passID
+
+	orderResp, err := h.orderService.CreateTourPassOrder(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	h.recordMarketingConsent(c, orderResp.Order, body.MarketingConsent)
+	h.respondWithPayment(c, orderResp)
+}
+
+// recordMarketingConsent records the marketing boxes ticked at checkout. The
+// order stands either way, so a failure is only logged.
+func (h *OrderHandler) recordMarketingConsent(c *gin.Context, order *entities.Order, choice *marketing.CheckoutConsent) {
+	if h.marketingService == nil || choice == nil {
+		return
+	}
+	if err := h.marketingService.RecordCheckoutConsent(c.Request.Context(), order, choice, consentMetadata(c)); err != nil {
+		fmt.Printf("Warning: failed to record marketing consent for order %s: %v\n", order.Code, err)
+	}
+}
+
+// respondWithPayment initializes payment for a newly created order and writes
+// the order together with the payment details
+func (h *OrderHandler) respondWithPayment(c *gin.Context, orderResp *orders.CreateOrderResponse) {
 	// Initialize payment with Paystack
 	paymentReq := &payments.InitiatePaymentRequest{
 		OrderID:       orderResp.Order.ID,
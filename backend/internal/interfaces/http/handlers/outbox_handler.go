@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
+)
+
+// OutboxHandler handles admin inspection and retry of queued deliveries
+type OutboxHandler struct {
+	outboxService *outbox.OutboxService
+}
+
+// NewOutboxHandler creates a new outbox handler
+func NewOutboxHandler(outboxService *outbox.OutboxService) *OutboxHandler {
+	return &OutboxHandler{
+		outboxService: outboxService,
+	}
+}
+
+// ListMessages lists outbox messages, newest first
+// GET /v1/admin/outbox?status=&topic=&aggregate_id=&page=&limit=
+func (h *OutboxHandler) ListMessages(c *gin.Context) {
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.OutboxFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		Topic:      c.Query("topic"),
+	}
+
+	if status := c.Query("status"); status != "" {
+		outboxStatus := entities.OutboxStatus(status)
+		switch outboxStatus {
+		case entities.OutboxStatusPending, entities.OutboxStatusDelivered, entities.OutboxStatusDead:
+			filter.Status = &outboxStatus
+		default:
+			validationErrorResponse(c, "status", "status must be pending, delivered or dead")
+			return
+		}
+	}
+
+	aggregateID, err := parseQueryUUID(c, "aggregate_id")
+	if err != nil {
+		validationErrorResponse(c, "aggregate_id", "invalid aggregate ID")
+		return
+	}
+	filter.AggregateID = aggregateID
+
+	messages, pagination, err := h.outboxService.ListMessages(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       messages,
+		"pagination": pagination,
+	})
+}
+
+// GetStats counts outbox messages per status
+// GET /v1/admin/outbox/stats
+func (h *OutboxHandler) GetStats(c *gin.Context) {
+	stats, err := h.outboxService.GetStats(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, stats)
+}
+
+// GetMessage returns one outbox message with its last error
+// GET /v1/admin/outbox/:id
+func (h *OutboxHandler) GetMessage(c *gin.Context) {
+	messageID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	message, err := h.outboxService.GetMessage(c.Request.Context(), messageID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, message)
+}
+
+// RetryMessage queues a dead or waiting message for an immediate attempt
+// POST /v1/admin/outbox/:id/retry
+func (h *OutboxHandler) RetryMessage(c *gin.Context) {
+	messageID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	message, err := h.outboxService.RetryMessage(c.Request.Context(), messageID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, message)
+}
+
+// RetryDead queues every dead message, optionally only those on one topic
+// POST /v1/admin/outbox/retry-dead?topic=
+func (h *OutboxHandler) RetryDead(c *gin.Context) {
+	retried, err := h.outboxService.RetryDead(c.Request.Context(), c.Query("topic"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, gin.H{"retried": retried})
+}
@@ -0,0 +1,112 @@
+package handlers
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/usecases/search"
+)
+
+// SearchHandler handles public event search and autocomplete
+type SearchHandler struct {
+	searchService *search.SearchService
+}
+
+// NewSearchHandler creates a new search handler
+func NewSearchHandler(searchService *search.SearchService) *SearchHandler {
+	return &SearchHandler{
+		searchService: searchService,
+	}
+}
+
+// SearchEvents searches public events with facets
+// GET /v1/search/events?q=&category_id=&city=&date=&date_from=&date_to=&price=&price_min=&price_max=&sort=&page=&limit=
+func (h *SearchHandler) SearchEvents(c *gin.Context) {
+	page, limit, _, _ := getPaginationParams(c)
+	req := &search.SearchEventsRequest{
+		Query:     c.Query("q"),
+		City:      c.Query("city"),
+		Date:      c.Query("date"),
+		PriceBand: c.Query("price"),
+		Sort:      c.Query("sort"),
+		Page:      page,
+		Limit:     limit,
+	}
+
+	categoryID, err := parseQueryUUID(c, "category_id")
+	if err != nil {
+		validationErrorResponse(c, "category_id", "Invalid category ID")
+		return
+	}
+	req.CategoryID = categoryID
+
+	var ok bool
+	if req.DateFrom, ok = parseQueryDate(c, "date_from", false); !ok {
+		return
+	}
+	if req.DateTo, ok = parseQueryDate(c, "date_to", true); !ok {
+		return
+	}
+
+	for _, param := range []struct {
+		name string
+		dest **float64
+	}{
+		{"price_min", &req.PriceMin},
+		{"price_max", &req.PriceMax},
+	} {
+		raw := c.Query(param.name)
+		if raw == "" {
+			continue
+		}
+		value, err := strconv.ParseFloat(raw, 64)
+		if err != nil || value < 0 {
+			validationErrorResponse(c, param.name, "Must be a non-negative number")
+			return
+		}
+		*param.dest = &value
+	}
+
+	result, err := h.searchService.SearchEvents(c.Request.Context(), req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, result)
+}
+
+// Suggest returns autocomplete suggestions for a partial query
+// GET /v1/search/suggestions?q=&limit=
+func (h *SearchHandler) Suggest(c *gin.Context) {
+	suggestions, err := h.searchService.Suggest(c.Request.Context(), c.Query("q"), parseQueryInt(c, "limit", 10))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, gin.H{"suggestions": suggestions})
+}
+
+// parseQueryDate parses an RFC 3339 timestamp or YYYY-MM-DD date from a query
+// parameter. A bare date used as an exclusive end bound covers the whole day.
+func parseQueryDate(c *gin.Context, param string, endOfDay bool) (*time.Time, bool) {
+	raw := c.Query(param)
+	if raw == "" {
+		return nil, true
+	}
+
+	if value, err := time.Parse(time.RFC3339, raw); err == nil {
+		return &value, true
+	}
+	if value, err := time.Parse("2006-01-02", raw); err == nil {
+		if endOfDay {
+			value = value.AddDate(0, 0, 1)
+		}
+		return &value, true
+	}
+
+	validationErrorResponse(c, param, "Must be a date (YYYY-MM-DD) or RFC 3339 timestamp")
+	return nil, false
+}
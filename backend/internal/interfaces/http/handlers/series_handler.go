@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/series"
+)
+
+// SeriesHandler handles recurring event series requests
+type SeriesHandler struct {
+	seriesService *series.SeriesService
+}
+
+// NewSeriesHandler creates a new series handler
+func NewSeriesHandler(seriesService *series.SeriesService) *SeriesHandler {
+	return &SeriesHandler{
+		seriesService: seriesService,
+	}
+}
+
+// ListSeries lists event series, filterable by organizer and active state
+// GET /v1/admin/series?organizer_id=&search=&is_active=&page=&limit=
+func (h *SeriesHandler) ListSeries(c *gin.Context) {
+	page, limit, sortBy, sortOrder := getPaginationParams(c)
+	filter := repositories.EventSeriesFilter{
+		BaseFilter: repositories.BaseFilter{
+			Page:      page,
+			Limit:     limit,
+			SortBy:    sortBy,
+			SortOrder: repositories.SortOrder(sortOrder),
+		},
+		Search: c.Query("search"),
+	}
+
+	organizerID, err := parseQueryUUID(c, "organizer_id")
+	if err != nil {
+		validationErrorResponse(c, "organizer_id", "invalid organizer ID")
+		return
+	}
+	filter.OrganizerID = organizerID
+
+	isActive, err := parseQueryBool(c, "is_active")
+	if err != nil {
+		validationErrorResponse(c, "is_active", "must be true or false")
+		return
+	}
+	filter.IsActive = isActive
+
+	seriesList, pagination, err := h.seriesService.ListSeries(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       seriesList,
+		"pagination": pagination,
+	})
+}
+
+// GetSeries retrieves a series with its occurrences
+func (h *SeriesHandler) GetSeries(c *gin.Context) {
+	seriesID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.seriesService.GetSeries(c.Request.Context(), seriesID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, resp)
+}
+
+// CreateSeries turns an event into a recurring series and generates its occurrences
+func (h *SeriesHandler) CreateSeries(c *gin.Context) {
+	var req series.CreateSeriesRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.seriesService.CreateSeries(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, resp)
+}
+
+// UpdateSeries updates a series' rule, exception dates or horizon
+func (h *SeriesHandler) UpdateSeries(c *gin.Context) {
+	seriesID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req series.UpdateSeriesRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	resp, err := h.seriesService.UpdateSeries(c.Request.Context(), seriesID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, resp)
+}
+
+// GenerateOccurrences creates the occurrences that have come within the series horizon
+func (h *SeriesHandler) GenerateOccurrences(c *gin.Context) {
+	seriesID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	created, err := h.seriesService.GenerateOccurrences(c.Request.Context(), seriesID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, created)
+}
+
+// UpdateOccurrence edits one occurrence of a series or it and all following ones
+// PUT /v1/admin/series/occurrences/:id {"scope": "this"|"following", ...}
+func (h *SeriesHandler) UpdateOccurrence(c *gin.Context) {
+	eventID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req series.UpdateOccurrenceRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	updated, err := h.seriesService.UpdateOccurrence(c.Request.Context(), eventID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, updated)
+}
@@ -0,0 +1,237 @@
+package handlers
+
+import (
+	"encoding/csv"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/imports"
+)
+
+// TicketImportHandler handles bulk CSV imports of attendees and pre-sold tickets
+type TicketImportHandler struct {
+	importService *imports.TicketImportService
+}
+
+// NewTicketImportHandler creates a new ticket import handler
+func NewTicketImportHandler(importService *imports.TicketImportService) *TicketImportHandler {
+	return &TicketImportHandler{
+		importService: importService,
+	}
+}
+
+// CreateImport uploads a CSV file and starts the import in the background
+func (h *TicketImportHandler) CreateImport(c *gin.Context) {
+	eventID, err := uuid.Parse(c.PostForm("event_id"))
+	if err != nil {
+		validationErrorResponse(c, "event_id", "a valid event ID is required")
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		validationErrorResponse(c, "file", "a CSV file is required")
+		return
+	}
+
+	sendEmails := false
+	if value := c.PostForm("send_emails"); value != "" {
+		sendEmails, err = strconv.ParseBool(value)
+		if err != nil {
+			validationErrorResponse(c, "send_emails", "send_emails must be true or false")
+			return
+		}
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		validationErrorResponse(c, "file", "could not read the uploaded file")
+		return
+	}
+	defer file.Close()
+
+	ticketImport, err := h.importService.CreateImport(c.Request.Context(), &imports.CreateImportRequest{
+		EventID:    eventID,
+		File:       file,
+		Filename:   fileHeader.Filename,
+		Source:     c.PostForm("source"),
+		SendEmails: sendEmails,
+		CreatedBy:  getAdminID(c),
+	})
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    ticketImport,
+	})
+}
+
+// ListImports lists imports, optionally for one event
+func (h *TicketImportHandler) ListImports(c *gin.Context) {
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.TicketImportFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+	}
+
+	eventID, err := parseQueryUUID(c, "event_id")
+	if err != nil {
+		validationErrorResponse(c, "event_id", "invalid event ID")
+		return
+	}
+	filter.EventID = eventID
+
+	if status := c.Query("status"); status != "" {
+		importStatus := entities.TicketImportStatus(status)
+		filter.Status = &importStatus
+	}
+
+	ticketImports, pagination, err := h.importService.ListImports(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       ticketImports,
+		"pagination": pagination,
+	})
+}
+
+// GetImport returns an import and its progress counters
+func (h *TicketImportHandler) GetImport(c *gin.Context) {
+	importID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	ticketImport, err := h.importService.GetImport(c.Request.Context(), importID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, ticketImport)
+}
+
+// ResumeImport restarts processing of the rows still pending
+func (h *TicketImportHandler) ResumeImport(c *gin.Context) {
+	importID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	ticketImport, err := h.importService.ResumeImport(c.Request.Context(), importID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"data":    ticketImport,
+	})
+}
+
+// ListRows returns the per-row result report of an import
+func (h *TicketImportHandler) ListRows(c *gin.Context) {
+	importID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.TicketImportRowFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		ImportID:   importID,
+	}
+	if status := c.Query("status"); status != "" {
+		rowStatus := entities.TicketImportRowStatus(status)
+		filter.Status = &rowStatus
+	}
+
+	rows, pagination, err := h.importService.ListRows(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       rows,
+		"pagination": pagination,
+	})
+}
+
+// DownloadReport streams the per-row result report as CSV
+func (h *TicketImportHandler) DownloadReport(c *gin.Context) {
+	importID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	filter := repositories.TicketImportRowFilter{
+		BaseFilter: repositories.BaseFilter{Page: 1, Limit: 100},
+		ImportID:   importID,
+	}
+	rows, pagination, err := h.importService.ListRows(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Header("Content-Type", "text/csv")
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("import-%s-report.csv", importID)))
+	c.Status(http.StatusOK)
+
+	writer := csv.NewWriter(c.Writer)
+	writer.Write([]string{"row_number", "external_reference", "first_name", "last_name", "email", "phone", "tier", "quantity", "status", "order_id", "error"})
+
+	for {
+		for _, row := range rows {
+			orderID := ""
+			if row.OrderID != nil {
+				orderID = row.OrderID.String()
+			}
+			writer.Write([]string{
+				strconv.Itoa(row.RowNumber),
+				stringOrEmpty(row.ExternalReference),
+				stringOrEmpty(row.FirstName),
+				stringOrEmpty(row.LastName),
+				stringOrEmpty(row.Email),
+				stringOrEmpty(row.Phone),
+				stringOrEmpty(row.Tier),
+				strconv.Itoa(row.Quantity),
+				string(row.Status),
+				orderID,
+				stringOrEmpty(row.Error),
+			})
+		}
+
+		if !pagination.HasNext {
+			break
+		}
+		filter.Page++
+		rows, pagination, err = h.importService.ListRows(c.Request.Context(), filter)
+		if err != nil {
+			break
+		}
+	}
+
+	writer.Flush()
+}
+
+func stringOrEmpty(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
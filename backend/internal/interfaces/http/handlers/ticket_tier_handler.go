@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/events"
+	"github.com/uduxpass/backend/internal/usecases/tiers"
+)
+
+// TicketTierHandler handles ticket tier sale sequencing, scheduled price and dynamic pricing requests
+type TicketTierHandler struct {
+	tierService  *tiers.TierService
+	eventService *events.EventService
+}
+
+// NewTicketTierHandler creates a new ticket tier handler
+func NewTicketTierHandler(tierService *tiers.TierService, eventService *events.EventService) *TicketTierHandler {
+	return &TicketTierHandler{
+		tierService:  tierService,
+		eventService: eventService,
+	}
+}
+
+// GetEventTiers returns an event's tiers with their current price, stock and sale state
+// GET /v1/admin/events/:id/ticket-tiers
+func (h *TicketTierHandler) GetEventTiers(c *gin.Context) {
+	eventID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	ticketTiers, err := h.eventService.GetTicketTierAvailability(c.Request.Context(), eventID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, ticketTiers)
+}
+
+// SetSequence makes a tier open once another tier sells out or ends
+// PUT /v1/admin/ticket-tiers/:id/sequence {"opens_after_tier_id": "..."|null}
+func (h *TicketTierHandler) SetSequence(c *gin.Context) {
+	tierID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req tiers.SetSequenceRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	tier, err := h.tierService.SetSequence(c.Request.Context(), tierID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, tier)
+}
+
+// SetPricingStrategy sets or removes a tier's dynamic pricing
+// PUT /v1/admin/ticket-tiers/:id/pricing {"strategy": {...}|null}
+func (h *TicketTierHandler) SetPricingStrategy(c *gin.Context) {
+	tierID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req tiers.SetPricingStrategyRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	tier, err := h.tierService.SetPricingStrategy(c.Request.Context(), tierID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, tier)
+}
+
+// SimulatePricing previews the revenue curve of a tier's pricing strategy
+// POST /v1/admin/ticket-tiers/:id/pricing/simulate {} or {"strategy": {...}, "points": 20, "sell_through_percent": 80}
+func (h *TicketTierHandler) SimulatePricing(c *gin.Context) {
+	tierID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req tiers.SimulatePricingRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	simulation, err := h.tierService.SimulatePricing(c.Request.Context(), tierID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, simulation)
+}
+
+// SchedulePriceChange schedules a tier to switch price at a set time
+// POST /v1/admin/ticket-tiers/:id/price-changes
+func (h *TicketTierHandler) SchedulePriceChange(c *gin.Context) {
+	tierID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req tiers.SchedulePriceChangeRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+	req.CreatedBy = getAdminID(c)
+
+	change, err := h.tierService.SchedulePriceChange(c.Request.Context(), tierID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, change)
+}
+
+// ListPriceChanges returns a tier's price history and upcoming price changes
+// GET /v1/admin/ticket-tiers/:id/price-changes?status=&page=&limit=
+func (h *TicketTierHandler) ListPriceChanges(c *gin.Context) {
+	tierID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.TierPriceChangeFilter{
+		BaseFilter:   repositories.BaseFilter{Page: page, Limit: limit},
+		TicketTierID: tierID,
+	}
+	if status := c.Query("status"); status != "" {
+		changeStatus := entities.TierPriceChangeStatus(status)
+		filter.Status = &changeStatus
+	}
+
+	changes, pagination, err := h.tierService.ListPriceChanges(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       changes,
+		"pagination": pagination,
+	})
+}
+
+// CancelPriceChange withdraws a price change that has not taken effect yet
+// DELETE /v1/admin/ticket-tiers/:id/price-changes/:change_id
+func (h *TicketTierHandler) CancelPriceChange(c *gin.Context) {
+	tierID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+	changeID, ok := parseUUID(c, "change_id")
+	if !ok {
+		return
+	}
+
+	change, err := h.tierService.CancelPriceChange(c.Request.Context(), tierID, changeID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, change)
+}
@@ -0,0 +1,228 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/tours"
+)
+
+// TourHandler handles tour and tour pass requests
+type TourHandler struct {
+	tourService *tours.TourService
+}
+
+// NewTourHandler creates a new tour handler
+func NewTourHandler(tourService *tours.TourService) *TourHandler {
+	return &TourHandler{
+		tourService: tourService,
+	}
+}
+
+// ListTours lists tours, filterable by organizer, artist and active state
+// GET /v1/admin/tours?organizer_id=&artist=&search=&is_active=&page=&limit=
+func (h *TourHandler) ListTours(c *gin.Context) {
+	page, limit, sortBy, sortOrder := getPaginationParams(c)
+	filter := repositories.TourFilter{
+		BaseFilter: repositories.BaseFilter{
+			Page:      page,
+			Limit:     limit,
+			SortBy:    sortBy,
+			SortOrder: repositories.SortOrder(sortOrder),
+		},
+		ArtistName: c.Query("artist"),
+		Search:     c.Query("search"),
+	}
+
+	organizerID, err := parseQueryUUID(c, "organizer_id")
+	if err != nil {
+		validationErrorResponse(c, "organizer_id", "invalid organizer ID")
+		return
+	}
+	filter.OrganizerID = organizerID
+
+	isActive, err := parseQueryBool(c, "is_active")
+	if err != nil {
+		validationErrorResponse(c, "is_active", "must be true or false")
+		return
+	}
+	filter.IsActive = isActive
+
+	tourList, pagination, err := h.tourService.ListTours(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       tourList,
+		"pagination": pagination,
+	})
+}
+
+// GetTour retrieves a tour with its events, passes and sales statistics
+func (h *TourHandler) GetTour(c *gin.Context) {
+	tourID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.tourService.GetTour(c.Request.Context(), tourID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, resp)
+}
+
+// CreateTour creates a tour
+func (h *TourHandler) CreateTour(c *gin.Context) {
+	var req tours.CreateTourRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	tour, err := h.tourService.CreateTour(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, tour)
+}
+
+// UpdateTour updates a tour
+func (h *TourHandler) UpdateTour(c *gin.Context) {
+	tourID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req tours.UpdateTourRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	tour, err := h.tourService.UpdateTour(c.Request.Context(), tourID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, tour)
+}
+
+// DeleteTour deactivates a tour
+func (h *TourHandler) DeleteTour(c *gin.Context) {
+	tourID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.tourService.DeleteTour(c.Request.Context(), tourID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	noContentResponse(c)
+}
+
+// ListTourPasses lists all passes of a tour
+func (h *TourHandler) ListTourPasses(c *gin.Context) {
+	tourID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	passes, err := h.tourService.ListTourPasses(c.Request.Context(), tourID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, passes)
+}
+
+// CreateTourPass creates a pass covering several events of a tour
+func (h *TourHandler) CreateTourPass(c *gin.Context) {
+	tourID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req tours.CreateTourPassRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	pass, err := h.tourService.CreateTourPass(c.Request.Context(), tourID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, pass)
+}
+
+// UpdateTourPass updates a tour pass; an events list replaces the covered events
+func (h *TourHandler) UpdateTourPass(c *gin.Context) {
+	passID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req tours.UpdateTourPassRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	pass, err := h.tourService.UpdateTourPass(c.Request.Context(), passID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, pass)
+}
+
+// ListPublicTours lists active tours that have not yet ended
+// GET /v1/tours?artist=&search=&page=&limit=
+func (h *TourHandler) ListPublicTours(c *gin.Context) {
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.TourFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		ArtistName: c.Query("artist"),
+		Search:     c.Query("search"),
+	}
+
+	tourList, pagination, err := h.tourService.ListPublicTours(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       tourList,
+		"pagination": pagination,
+	})
+}
+
+// GetPublicTour retrieves a tour page with its dates and the passes on sale
+func (h *TourHandler) GetPublicTour(c *gin.Context) {
+	tourID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.tourService.GetPublicTour(c.Request.Context(), tourID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, resp)
+}
@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/venues"
+)
+
+// VenueHandler handles venue management
+type VenueHandler struct {
+	venueService *venues.VenueService
+}
+
+// NewVenueHandler creates a new venue handler
+func NewVenueHandler(venueService *venues.VenueService) *VenueHandler {
+	return &VenueHandler{
+		venueService: venueService,
+	}
+}
+
+// ListVenues lists venues, filterable by city, name and active state
+// GET /v1/admin/venues?city=&search=&is_active=&page=&limit=
+func (h *VenueHandler) ListVenues(c *gin.Context) {
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.VenueFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		City:       c.Query("city"),
+		Search:     c.Query("search"),
+	}
+
+	isActive, err := parseQueryBool(c, "is_active")
+	if err != nil {
+		validationErrorResponse(c, "is_active", "must be true or false")
+		return
+	}
+	filter.IsActive = isActive
+
+	venueList, pagination, err := h.venueService.ListVenues(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       venueList,
+		"pagination": pagination,
+	})
+}
+
+// GetVenue retrieves a venue with its gates
+func (h *VenueHandler) GetVenue(c *gin.Context) {
+	venueID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	resp, err := h.venueService.GetVenue(c.Request.Context(), venueID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, resp)
+}
+
+// CreateVenue creates a venue
+func (h *VenueHandler) CreateVenue(c *gin.Context) {
+	var req venues.CreateVenueRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	venue, err := h.venueService.CreateVenue(c.Request.Context(), &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, venue)
+}
+
+// UpdateVenue updates a venue; a gates list replaces the venue's gates
+func (h *VenueHandler) UpdateVenue(c *gin.Context) {
+	venueID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req venues.UpdateVenueRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	venue, err := h.venueService.UpdateVenue(c.Request.Context(), venueID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, venue)
+}
+
+// DeactivateVenue hides a venue from new events
+func (h *VenueHandler) DeactivateVenue(c *gin.Context) {
+	venueID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	venue, err := h.venueService.DeactivateVenue(c.Request.Context(), venueID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, venue)
+}
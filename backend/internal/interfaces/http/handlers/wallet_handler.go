@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	walletpass "github.com/uduxpass/backend/internal/infrastructure/wallet"
+	"github.com/uduxpass/backend/internal/usecases/wallet"
+)
+
+// WalletHandler serves Apple Wallet and Google Wallet passes for tickets
+type WalletHandler struct {
+	walletService *wallet.WalletService
+}
+
+// NewWalletHandler creates a new wallet handler
+func NewWalletHandler(walletService *wallet.WalletService) *WalletHandler {
+	return &WalletHandler{
+		walletService: walletService,
+	}
+}
+
+// GetApplePass downloads the .pkpass for a ticket on the user's order
+// GET /v1/orders/:id/tickets/:ticketId/wallet/apple
+func (h *WalletHandler) GetApplePass(c *gin.Context) {
+	orderID, ticketID, userID, ok := h.orderTicketParams(c)
+	if !ok {
+		return
+	}
+
+	pass, err := h.walletService.GetApplePass(c.Request.Context(), orderID, ticketID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	writeApplePass(c, pass)
+}
+
+// GetGoogleSaveURL returns the "Add to Google Wallet" link for a ticket on the user's order
+// GET /v1/orders/:id/tickets/:ticketId/wallet/google
+func (h *WalletHandler) GetGoogleSaveURL(c *gin.Context) {
+	orderID, ticketID, userID, ok := h.orderTicketParams(c)
+	if !ok {
+		return
+	}
+
+	saveURL, err := h.walletService.GetGoogleSaveURL(c.Request.Context(), orderID, ticketID, userID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, gin.H{"save_url": saveURL})
+}
+
+// GetApplePassByLink downloads a .pkpass from a signed email link
+// GET /v1/wallet/tickets/:ticketId/apple?token=
+func (h *WalletHandler) GetApplePassByLink(c *gin.Context) {
+	ticketID, ok := parseUUID(c, "ticketId")
+	if !ok {
+		return
+	}
+
+	pass, err := h.walletService.GetApplePassByLink(c.Request.Context(), ticketID, c.Query("token"))
+	if err != nil {
+		h.handleWalletError(c, err)
+		return
+	}
+
+	writeApplePass(c, pass)
+}
+
+// RedirectToGoogleWallet sends the browser from a signed email link to Google Wallet
+// GET /v1/wallet/tickets/:ticketId/google?token=
+func (h *WalletHandler) RedirectToGoogleWallet(c *gin.Context) {
+	ticketID, ok := parseUUID(c, "ticketId")
+	if !ok {
+		return
+	}
+
+	saveURL, err := h.walletService.GetGoogleSaveURLByLink(c.Request.Context(), ticketID, c.Query("token"))
+	if err != nil {
+		h.handleWalletError(c, err)
+		return
+	}
+
+	c.Redirect(http.StatusFound, saveURL)
+}
+
+// RefreshTicketPasses pushes the current state of a ticket to every wallet holding it
+// POST /v1/admin/tickets/:id/wallet/refresh
+func (h *WalletHandler) RefreshTicketPasses(c *gin.Context) {
+	ticketID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	h.walletService.NotifyTicketsChanged(c.Request.Context(), []uuid.UUID{ticketID})
+	c.JSON(http.StatusAccepted, gin.H{
+		"success": true,
+		"message": "Wallet pass update queued",
+	})
+}
+
+// Apple Wallet web service endpoints. Wallet calls these itself, authenticating
+// with the per-pass token from pass.json in an "ApplePass <token>" header.
+
+// RegisterDevice registers a device to receive pass update pushes
+// POST .../v1/devices/:deviceId/registrations/:passTypeId/:serialNumber
+func (h *WalletHandler) RegisterDevice(c *gin.Context) {
+	var body struct {
+		PushToken string `json:"pushToken"`
+	}
+	if err := c.ShouldBindJSON(&body); err != nil || body.PushToken == "" {
+		c.Status(http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.walletService.RegisterDevice(c.Request.Context(),
+		c.Param("deviceId"), c.Param("passTypeId"), c.Param("serialNumber"),
+		applePassToken(c), body.PushToken)
+	if err != nil {
+		h.handleWalletError(c, err)
+		return
+	}
+
+	if created {
+		c.Status(http.StatusCreated)
+		return
+	}
+	c.Status(http.StatusOK)
+}
+
+// UnregisterDevice stops update pushes for a pass removed from a device
+// DELETE .../v1/devices/:deviceId/registrations/:passTypeId/:serialNumber
+func (h *WalletHandler) UnregisterDevice(c *gin.Context) {
+	err := h.walletService.UnregisterDevice(c.Request.Context(),
+		c.Param("deviceId"), c.Param("passTypeId"), c.Param("serialNumber"),
+		applePassToken(c))
+	if err != nil {
+		h.handleWalletError(c, err)
+		return
+	}
+
+	c.Status(http.StatusOK)
+}
+
+// ListUpdatedPasses lists the serial numbers of a device's passes changed since the last update tag
+// GET .../v1/devices/:deviceId/registrations/:passTypeId?passesUpdatedSince=
+func (h *WalletHandler) ListUpdatedPasses(c *gin.Context) {
+	updates, err := h.walletService.GetUpdatedSerialNumbers(c.Request.Context(),
+		c.Param("deviceId"), c.Param("passTypeId"), c.Query("passesUpdatedSince"))
+	if err != nil {
+		h.handleWalletError(c, err)
+		return
+	}
+
+	if len(updates.SerialNumbers) == 0 {
+		c.Status(http.StatusNoContent)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"serialNumbers": updates.SerialNumbers,
+		"lastUpdated":   fmt.Sprintf("%d", updates.LastUpdated.Unix()),
+	})
+}
+
+// GetLatestPass returns the current version of a pass
+// GET .../v1/passes/:passTypeId/:serialNumber
+func (h *WalletHandler) GetLatestPass(c *gin.Context) {
+	pass, err := h.walletService.GetLatestPass(c.Request.Context(),
+		c.Param("passTypeId"), c.Param("serialNumber"), applePassToken(c))
+	if err != nil {
+		h.handleWalletError(c, err)
+		return
+	}
+
+	if since, err := http.ParseTime(c.GetHeader("If-Modified-Since")); err == nil {
+		if !pass.LastModified.Truncate(time.Second).After(since) {
+			c.Status(http.StatusNotModified)
+			return
+		}
+	}
+
+	writeApplePass(c, pass)
+}
+
+// LogErrors receives diagnostic messages from Wallet about failed pass updates
+// POST .../v1/log
+func (h *WalletHandler) LogErrors(c *gin.Context) {
+	var body struct {
+		Logs []string `json:"logs"`
+	}
+	if err := c.ShouldBindJSON(&body); err == nil {
+		for _, entry := range body.Logs {
+			fmt.Printf("Apple Wallet: %s\n", entry)
+		}
+	} else {
+		io.Copy(io.Discard, c.Request.Body)
+	}
+
+	c.Status(http.StatusOK)
+}
+
+func (h *WalletHandler) handleWalletError(c *gin.Context, err error) {
+	if errors.Is(err, wallet.ErrPassUnauthorized) {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return
+	}
+	handleError(c, err)
+}
+
+// orderTicketParams reads the order and ticket IDs from the path and the authenticated user
+func (h *WalletHandler) orderTicketParams(c *gin.Context) (uuid.UUID, uuid.UUID, uuid.UUID, bool) {
+	userIDValue, exists := c.Get("userID")
+	userIDStr, _ := userIDValue.(string)
+	userID, err := uuid.Parse(userIDStr)
+	if !exists || err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "Unauthorized"})
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	orderID, ok := parseUUID(c, "id")
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+	ticketID, ok := parseUUID(c, "ticketId")
+	if !ok {
+		return uuid.Nil, uuid.Nil, uuid.Nil, false
+	}
+
+	return orderID, ticketID, userID, true
+}
+
+// applePassToken extracts the token from an "Authorization: ApplePass <token>" header
+func applePassToken(c *gin.Context) string {
+	return strings.TrimSpace(strings.TrimPrefix(c.GetHeader("Authorization"), "ApplePass "))
+}
+
+func writeApplePass(c *gin.Context, pass *wallet.ApplePass) {
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", pass.Filename))
+	c.Header("Last-Modified", pass.LastModified.UTC().Format(http.TimeFormat))
+	c.Data(http.StatusOK, walletpass.ApplePassContentType, pass.Data)
+}
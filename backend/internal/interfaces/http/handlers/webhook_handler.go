@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/webhooks"
+)
+
+// WebhookHandler handles organizer webhook subscriptions and their delivery log
+type WebhookHandler struct {
+	webhookService *webhooks.WebhookService
+}
+
+// NewWebhookHandler creates a new webhook handler
+func NewWebhookHandler(webhookService *webhooks.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		webhookService: webhookService,
+	}
+}
+
+// ListWebhooks lists an organizer's webhook subscriptions
+// GET /v1/admin/organizers/:id/webhooks
+func (h *WebhookHandler) ListWebhooks(c *gin.Context) {
+	organizerID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	subscriptions, err := h.webhookService.ListSubscriptions(c.Request.Context(), organizerID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, subscriptions)
+}
+
+// CreateWebhook subscribes a URL to an organizer's events. The response
+// carries the signing secret, which is not shown again.
+// POST /v1/admin/organizers/:id/webhooks
+func (h *WebhookHandler) CreateWebhook(c *gin.Context) {
+	organizerID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req webhooks.CreateWebhookRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	subscription, err := h.webhookService.CreateSubscription(c.Request.Context(), organizerID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	createdResponse(c, subscription)
+}
+
+// GetWebhook retrieves a webhook subscription
+// GET /v1/admin/webhooks/:id
+func (h *WebhookHandler) GetWebhook(c *gin.Context) {
+	subscriptionID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.webhookService.GetSubscription(c.Request.Context(), subscriptionID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, subscription)
+}
+
+// UpdateWebhook updates a webhook subscription, including re-enabling a disabled one
+// PUT /v1/admin/webhooks/:id
+func (h *WebhookHandler) UpdateWebhook(c *gin.Context) {
+	subscriptionID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	var req webhooks.UpdateWebhookRequest
+	if !bindAndValidate(c, &req) {
+		return
+	}
+
+	subscription, err := h.webhookService.UpdateSubscription(c.Request.Context(), subscriptionID, &req)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, subscription)
+}
+
+// DeleteWebhook deletes a webhook subscription and its delivery log
+// DELETE /v1/admin/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c *gin.Context) {
+	subscriptionID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	if err := h.webhookService.DeleteSubscription(c.Request.Context(), subscriptionID); err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, gin.H{"message": "Webhook deleted successfully"})
+}
+
+// RotateSecret replaces a webhook's signing secret and returns the new one
+// POST /v1/admin/webhooks/:id/rotate-secret
+func (h *WebhookHandler) RotateSecret(c *gin.Context) {
+	subscriptionID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	subscription, err := h.webhookService.RotateSecret(c.Request.Context(), subscriptionID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, subscription)
+}
+
+// ListDeliveries lists a webhook's delivery log, newest first
+// GET /v1/admin/webhooks/:id/deliveries?status=&event_type=&page=&limit=
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	subscriptionID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	page, limit, _, _ := getPaginationParams(c)
+	filter := repositories.WebhookDeliveryFilter{
+		BaseFilter:     repositories.BaseFilter{Page: page, Limit: limit},
+		SubscriptionID: subscriptionID,
+		EventType:      c.Query("event_type"),
+	}
+
+	if status := c.Query("status"); status != "" {
+		deliveryStatus := entities.WebhookDeliveryStatus(status)
+		switch deliveryStatus {
+		case entities.WebhookDeliveryPending, entities.WebhookDeliverySucceeded, entities.WebhookDeliveryFailed:
+			filter.Status = &deliveryStatus
+		default:
+			validationErrorResponse(c, "status", "status must be pending, succeeded or failed")
+			return
+		}
+	}
+
+	deliveries, pagination, err := h.webhookService.ListDeliveries(c.Request.Context(), filter)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"success":    true,
+		"data":       deliveries,
+		"pagination": pagination,
+	})
+}
+
+// GetDelivery returns one webhook delivery with its last attempt
+// GET /v1/admin/webhook-deliveries/:id
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	deliveryID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookService.GetDelivery(c.Request.Context(), deliveryID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, delivery)
+}
+
+// Redeliver sends a succeeded or failed delivery again with the same delivery ID
+// POST /v1/admin/webhook-deliveries/:id/redeliver
+func (h *WebhookHandler) Redeliver(c *gin.Context) {
+	deliveryID, ok := parseUUID(c, "id")
+	if !ok {
+		return
+	}
+
+	delivery, err := h.webhookService.Redeliver(c.Request.Context(), deliveryID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	successResponse(c, delivery)
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
@@ -14,17 +15,43 @@ import (
 
 	"github.com/uduxpass/backend/internal/domain/entities"
 	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
 	"github.com/uduxpass/backend/internal/infrastructure/database"
 	"github.com/uduxpass/backend/internal/infrastructure/email"
 	"github.com/uduxpass/backend/internal/infrastructure/payments"
+	"github.com/uduxpass/backend/internal/infrastructure/sms"
 	"github.com/uduxpass/backend/internal/infrastructure/storage"
+	"github.com/uduxpass/backend/internal/infrastructure/templates"
+	"github.com/uduxpass/backend/internal/infrastructure/whatsapp"
+	walletpass "github.com/uduxpass/backend/internal/infrastructure/wallet"
 	"github.com/uduxpass/backend/internal/interfaces/http/handlers"
 	"github.com/uduxpass/backend/internal/usecases/admin"
+	"github.com/uduxpass/backend/internal/usecases/analytics"
 	"github.com/uduxpass/backend/internal/usecases/auth"
+	"github.com/uduxpass/backend/internal/usecases/boxoffice"
+	"github.com/uduxpass/backend/internal/usecases/campaigns"
+	"github.com/uduxpass/backend/internal/usecases/checkoutrecovery"
+	"github.com/uduxpass/backend/internal/usecases/categories"
+	"github.com/uduxpass/backend/internal/usecases/comps"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/imports"
+	"github.com/uduxpass/backend/internal/usecases/marketing"
+	"github.com/uduxpass/backend/internal/usecases/messagetemplates"
+	"github.com/uduxpass/backend/internal/usecases/wallet"
+	"github.com/uduxpass/backend/internal/usecases/currency"
+	"github.com/uduxpass/backend/internal/usecases/eventchanges"
 	"github.com/uduxpass/backend/internal/usecases/events"
+	"github.com/uduxpass/backend/internal/usecases/notifications"
 	"github.com/uduxpass/backend/internal/usecases/orders"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
 	paymentservice "github.com/uduxpass/backend/internal/usecases/payments"
 	"github.com/uduxpass/backend/internal/usecases/scanner"
+	"github.com/uduxpass/backend/internal/usecases/search"
+	"github.com/uduxpass/backend/internal/usecases/series"
+	"github.com/uduxpass/backend/internal/usecases/tiers"
+	"github.com/uduxpass/backend/internal/usecases/tours"
+	"github.com/uduxpass/backend/internal/usecases/venues"
+	"github.com/uduxpass/backend/internal/usecases/webhooks"
 	"github.com/uduxpass/backend/pkg/jwt"
 	"github.com/uduxpass/backend/pkg/security"
 )
@@ -54,6 +81,14 @@ type Server struct {
 	orderService    *orders.OrderService
 	paymentService  *paymentservice.PaymentService
 	scannerAuthService *scanner.ScannerAuthService
+	eventChangeService *eventchanges.EventChangeService
+	campaignService    *campaigns.CampaignService
+	recoveryService    *checkoutrecovery.RecoveryService
+	marketingService   *marketing.MarketingService
+	analyticsService   *analytics.AnalyticsService
+	tierService        *tiers.TierService
+	categoryService    *categories.CategoryService
+	outboxDispatcher   *outbox.Dispatcher
 	
 	// Handlers
 	authHandler    *handlers.AuthHandler
@@ -61,10 +96,35 @@ type Server struct {
 	scannerHandler *handlers.ScannerHandler
 	orderHandler   *handlers.OrderHandler
 	uploadHandler  *handlers.UploadHandler
+	currencyHandler *handlers.CurrencyHandler
+	boxOfficeHandler *handlers.BoxOfficeHandler
+	compHandler     *handlers.CompHandler
+	ticketImportHandler *handlers.TicketImportHandler
+	walletHandler       *handlers.WalletHandler
+	searchHandler       *handlers.SearchHandler
+	venueHandler        *handlers.VenueHandler
+	tourHandler         *handlers.TourHandler
+	seriesHandler       *handlers.SeriesHandler
+	eventTemplateHandler *handlers.EventTemplateHandler
+	eventChangeHandler   *handlers.EventChangeHandler
+	ticketTierHandler    *handlers.TicketTierHandler
+	categoryHandler      *handlers.CategoryHandler
+	outboxHandler        *handlers.OutboxHandler
+	webhookHandler       *handlers.WebhookHandler
+	notificationHandler  *handlers.NotificationHandler
+	messageTemplateHandler *handlers.MessageTemplateHandler
+	campaignHandler        *handlers.CampaignHandler
+	checkoutRecoveryHandler *handlers.CheckoutRecoveryHandler
+	marketingHandler       *handlers.MarketingHandler
+	
+	// Delivery webhooks are only served for real providers; the fakes accept
+	// any signature, so their status updates could be forged
+	smsReceipts      bool
+	whatsAppWebhooks bool
 }
 
 // NewServer creates a new HTTP server with proper dependency injection
-func NewServer(config *Config, dbManager *database.DatabaseManager) *Server {
+func NewServer(config *Config, dbManager *database.DatabaseManager) (*Server, error) {
 	// Initialize services
 	jwtService := jwt.NewJWTService(
 		config.JWTSecret,
@@ -77,12 +137,75 @@ func NewServer(config *Config, dbManager *database.DatabaseManager) *Server {
 		Cost: 10, // Default bcrypt cost
 	})
 	
+	// Domain events are delivered to asynchronous subscribers by the outbox dispatcher
+	outboxDispatcher := outbox.NewDispatcher(dbManager.Outbox())
+	eventBus := eventbus.NewBus(dbManager.Outbox(), outboxDispatcher)
+	
+	// Initialize the message template engine shared by email, SMS and WhatsApp:
+	// built-in templates in every supported locale, with admin and organizer
+	// overrides stored in the database
+	messageTemplates, err := templates.NewEngine(dbManager.MessageTemplates(), dbManager.Users(), dbManager.Organizers())
+	if err != nil {
+		return nil, fmt.Errorf("failed to load message templates: %w", err)
+	}
+	
+	// Initialize SMS. Messages are recorded and queued in the outbox; without
+	// SMS_PROVIDER the fake provider only logs them.
+	smsProvider, err := newSMSProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure SMS provider: %w", err)
+	}
+	ticketLinkService := notifications.NewTicketLinkService(
+		dbManager.Orders(),
+		dbManager.Tickets(),
+		dbManager.Events(),
+		os.Getenv("FRONTEND_URL"),
+	)
+	smsService := notifications.NewSMSService(
+		dbManager.UnitOfWork(),
+		dbManager.Notifications(),
+		dbManager.Orders(),
+		dbManager.Tickets(),
+		dbManager.Events(),
+		ticketLinkService,
+		messageTemplates,
+		smsProvider,
+		getEnv("SMS_SENDER_ID", notifications.DefaultSMSSenderID),
+	)
+	smsService.Register(outboxDispatcher)
+	
+	// Initialize WhatsApp ticket delivery for customers who opted in; without
+	// WHATSAPP_PROVIDER the fake provider only logs messages
+	whatsAppProvider, err := newWhatsAppProvider()
+	if err != nil {
+		return nil, fmt.Errorf("failed to configure WhatsApp provider: %w", err)
+	}
+	whatsAppService := notifications.NewWhatsAppService(
+		dbManager.UnitOfWork(),
+		dbManager.Notifications(),
+		dbManager.Orders(),
+		dbManager.OrderLines(),
+		dbManager.Tickets(),
+		dbManager.Events(),
+		dbManager.Outbox(),
+		ticketLinkService,
+		messageTemplates,
+		whatsAppProvider,
+		notifications.WhatsAppConfig{
+			TicketTemplate: getEnv("WHATSAPP_TICKET_TEMPLATE", notifications.DefaultWhatsAppTicketTemplate),
+			Language:       getEnv("WHATSAPP_TEMPLATE_LANGUAGE", notifications.DefaultWhatsAppLanguage),
+			Languages:      getEnvList("WHATSAPP_TEMPLATE_LANGUAGES"),
+		},
+	)
+	whatsAppService.Register(outboxDispatcher)
+	
 	// Initialize use case services
 	authService := auth.NewAuthService(
 		dbManager.Users(),
 		dbManager.OTPTokens(),
 		jwtService,
 		passwordService,
+		smsService,
 	)
 	
 	adminAuthService := admin.NewAdminAuthService(
@@ -96,7 +219,10 @@ func NewServer(config *Config, dbManager *database.DatabaseManager) *Server {
 		dbManager.Tours(),
 		dbManager.Organizers(),
 		dbManager.TicketTiers(),
+		dbManager.Venues(),
+		dbManager.EventTemplates(),
 		dbManager.UnitOfWork(),
+		eventBus,
 	)
 	
 	orderService := orders.NewOrderService(
@@ -105,11 +231,44 @@ func NewServer(config *Config, dbManager *database.DatabaseManager) *Server {
 		dbManager.InventoryHolds(),
 		dbManager.Events(),
 		dbManager.TicketTiers(),
+		dbManager.TierPriceChanges(),
 		dbManager.Users(),
+		dbManager.UnitOfWork(),
+		eventBus,
 	)
 	
-	// Initialize email service
-	emailService := email.NewSMTPEmailService()
+	// Initialize email service. Services queue emails in the outbox; only the
+	// dispatcher talks to SMTP, retrying with backoff until delivery succeeds.
+	emailService := email.NewSMTPEmailService(messageTemplates)
+	queuedEmailService := outbox.NewQueuedEmailService(dbManager.Outbox())
+	
+	baseURL := getEnv("BASE_URL", fmt.Sprintf("http://localhost:%s", config.Port))
+	
+	// Initialize wallet passes; each provider stays disabled until its credentials are configured
+	appleConfig := walletpass.AppleConfigFromEnv()
+	if appleConfig.WebServiceURL == "" {
+		appleConfig.WebServiceURL = baseURL + "/v1/wallet/apple"
+	}
+	applePasses, err := walletpass.NewApplePassGenerator(appleConfig)
+	if err != nil && !errors.Is(err, walletpass.ErrWalletNotConfigured) {
+		fmt.Printf("Warning: Apple Wallet disabled: %v\n", err)
+	}
+	googlePasses, err := walletpass.NewGoogleWalletIssuer(walletpass.GoogleConfigFromEnv())
+	if err != nil && !errors.Is(err, walletpass.ErrWalletNotConfigured) {
+		fmt.Printf("Warning: Google Wallet disabled: %v\n", err)
+	}
+	walletService := wallet.NewWalletService(
+		dbManager.Tickets(),
+		dbManager.Orders(),
+		dbManager.OrderLines(),
+		dbManager.Events(),
+		dbManager.WalletPasses(),
+		applePasses,
+		googlePasses,
+		getEnv("WALLET_LINK_SECRET", config.JWTSecret),
+		baseURL,
+	)
+	walletService.Subscribe(eventBus)
 	
 	// Initialize payment providers
 	// Get Paystack secret key from environment
@@ -129,15 +288,148 @@ func NewServer(config *Config, dbManager *database.DatabaseManager) *Server {
 		momoProvider,
 		*paystackProvider,
 		dbManager.UnitOfWork(),
-		emailService,
+		dbManager.Outbox(),
+		eventBus,
 		config.JWTSecret,
 	)
+	paymentService.Subscribe(eventBus)
 	
 	scannerAuthService := scanner.NewScannerAuthService(
 		dbManager,
 		config.JWTSecret,
+		eventBus,
+	)
+	scannerAuthService.Subscribe(eventBus)
+	
+	fxService := currency.NewFXService(
+		dbManager.FXRates(),
+		dbManager.Orders(),
+	)
+	
+	boxOfficeService := boxoffice.NewBoxOfficeService(
+		dbManager.BoxOffice(),
+		dbManager.Events(),
+		dbManager.Orders(),
+		dbManager.OrderLines(),
+		dbManager.Tickets(),
+		dbManager.UnitOfWork(),
+		paymentService,
+	)
+	
+	compService := comps.NewCompService(
+		dbManager.Comps(),
+		dbManager.TicketTiers(),
+		dbManager.UnitOfWork(),
+		paymentService,
+		eventBus,
+	)
+	
+	ticketImportService := imports.NewTicketImportService(
+		dbManager.TicketImports(),
+		dbManager.Events(),
+		dbManager.TicketTiers(),
+		dbManager.UnitOfWork(),
+		paymentService,
+	)
+	
+	eventChangeService := eventchanges.NewEventChangeService(
+		dbManager.EventChanges(),
+		dbManager.Events(),
+		dbManager.Tickets(),
+		dbManager.UnitOfWork(),
+		paymentService,
+		queuedEmailService,
+		smsService,
+		messageTemplates,
+		eventBus,
+	)
+	
+	// Reminders before and follow-ups after events; each default is added to
+	// every upcoming event and can be turned off by setting it to 0
+	campaignService := campaigns.NewCampaignService(
+		dbManager.EventCampaigns(),
+		dbManager.Events(),
+		dbManager.Users(),
+		dbManager.UnitOfWork(),
+		queuedEmailService,
+		smsService,
+		whatsAppService,
+		campaigns.Config{
+			DefaultReminderMinutes:   getEnvInt("CAMPAIGN_DEFAULT_REMINDER_MINUTES", campaigns.DefaultReminderMinutes),
+			DefaultFollowUpMinutes:   getEnvInt("CAMPAIGN_DEFAULT_FOLLOW_UP_MINUTES", campaigns.DefaultFollowUpMinutes),
+			WhatsAppReminderTemplate: getEnv("WHATSAPP_REMINDER_TEMPLATE", campaigns.DefaultWhatsAppReminderTemplate),
+			WhatsAppFollowUpTemplate: getEnv("WHATSAPP_FOLLOW_UP_TEMPLATE", campaigns.DefaultWhatsAppFollowUpTemplate),
+		},
+	)
+	
+	// Abandoned checkout recovery: expired orders and failed payment starts
+	// are followed up with a link that places the same order again
+	recoveryService := checkoutrecovery.NewRecoveryService(
+		dbManager.CheckoutRecoveries(),
+		dbManager.Orders(),
+		dbManager.OrderLines(),
+		dbManager.Events(),
+		dbManager.TicketTiers(),
+		dbManager.Users(),
+		orderService,
+		queuedEmailService,
+		smsService,
+		whatsAppService,
+		checkoutrecovery.Config{
+			Delay:            time.Duration(getEnvInt("CHECKOUT_RECOVERY_DELAY_MINUTES", checkoutrecovery.DefaultDelayMinutes)) * time.Minute,
+			Throttle:         time.Duration(getEnvInt("CHECKOUT_RECOVERY_THROTTLE_HOURS", checkoutrecovery.DefaultThrottleHours)) * time.Hour,
+			WhatsAppTemplate: getEnv("WHATSAPP_CHECKOUT_RECOVERY_TEMPLATE", checkoutrecovery.DefaultWhatsAppTemplate),
+		},
+	)
+	recoveryService.Subscribe(eventBus)
+	
+	// Marketing consent and campaigns to past buyers who opted in
+	marketingService := marketing.NewMarketingService(
+		dbManager.MarketingConsents(),
+		dbManager.MarketingCampaigns(),
+		dbManager.Organizers(),
+		dbManager.Events(),
+		dbManager.Users(),
+		dbManager.UnitOfWork(),
+		queuedEmailService,
+		marketing.Config{
+			PolicyVersion: getEnv("MARKETING_CONSENT_POLICY_VERSION", marketing.DefaultPolicyVersion),
+		},
+	)
+	
+	tierService := tiers.NewTierService(
+		dbManager.TicketTiers(),
+		dbManager.TierPriceChanges(),
+		dbManager.Events(),
+	)
+	
+	categoryService := categories.NewCategoryService(
+		dbManager.Categories(),
+		dbManager.Events(),
 	)
 	
+	outbox.NewEmailDelivery(
+		emailService,
+		dbManager.Orders(),
+		dbManager.OrderLines(),
+		dbManager.Tickets(),
+		dbManager.Events(),
+		dbManager.Users(),
+		dbManager.EventChanges(),
+		dbManager.EventCampaigns(),
+		dbManager.CheckoutRecoveries(),
+		dbManager.MarketingCampaigns(),
+		walletService,
+	).Register(outboxDispatcher)
+	
+	// Organizer webhooks: fan domain events out to subscriptions and send them
+	webhookDeliverer := webhooks.NewDeliverer(dbManager.UnitOfWork(), dbManager.Webhooks(), dbManager.Events())
+	webhookDeliverer.Subscribe(eventBus)
+	webhookDeliverer.Register(outboxDispatcher)
+	
+	// Sales analytics over daily rollups the scheduler keeps fresh
+	analyticsService := analytics.NewAnalyticsService(dbManager.Analytics(), dbManager.Organizers())
+	
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(authService)
 	adminHandler := handlers.NewAdminHandlerExtended(
@@ -148,6 +440,7 @@ func NewServer(config *Config, dbManager *database.DatabaseManager) *Server {
 		dbManager.Tickets(),
 		dbManager.ScannerUsers(),
 		dbManager.Organizers(),
+		analyticsService,
 	)
 	scannerHandler := handlers.NewScannerHandler(
 		scannerAuthService,
@@ -164,10 +457,9 @@ func NewServer(config *Config, dbManager *database.DatabaseManager) *Server {
 	// Initialize storage provider
 	// Set STORAGE_PROVIDER=gcs and configure GCS env vars to switch to GCP Cloud Storage in production.
 	uploadDir := getEnv("UPLOAD_DIR", "./uploads")
-	baseURL := getEnv("BASE_URL", fmt.Sprintf("http://localhost:%s", config.Port))
 	localStore, storeErr := storage.NewLocalStorage(uploadDir, baseURL+"/uploads")
 	if storeErr != nil {
-		panic(fmt.Sprintf("failed to initialize storage: %v", storeErr))
+		return nil, fmt.Errorf("failed to initialize storage: %w", storeErr)
 	}
 
 	server := &Server{
@@ -182,17 +474,70 @@ func NewServer(config *Config, dbManager *database.DatabaseManager) *Server {
 		orderService:       orderService,
 		paymentService:     paymentService,
 		scannerAuthService: scannerAuthService,
+		eventChangeService: eventChangeService,
+		campaignService:    campaignService,
+		recoveryService:    recoveryService,
+		marketingService:   marketingService,
+		analyticsService:   analyticsService,
+		tierService:        tierService,
+		categoryService:    categoryService,
+		outboxDispatcher:   outboxDispatcher,
 		authHandler:        authHandler,
 		adminHandler:       adminHandler,
 		scannerHandler:     scannerHandler,
-		orderHandler:       handlers.NewOrderHandler(orderService, paymentService),
+		orderHandler:       handlers.NewOrderHandler(orderService, paymentService, marketingService),
 		uploadHandler:      handlers.NewUploadHandler(localStore),
+		currencyHandler:    handlers.NewCurrencyHandler(fxService),
+		boxOfficeHandler:   handlers.NewBoxOfficeHandler(boxOfficeService),
+		compHandler:        handlers.NewCompHandler(compService),
+		ticketImportHandler: handlers.NewTicketImportHandler(ticketImportService),
+		walletHandler:       handlers.NewWalletHandler(walletService),
+		searchHandler:       handlers.NewSearchHandler(search.NewSearchService(dbManager.EventSearch())),
+		venueHandler:        handlers.NewVenueHandler(venues.NewVenueService(dbManager.Venues(), dbManager.UnitOfWork())),
+		tourHandler:         handlers.NewTourHandler(tours.NewTourService(
+			dbManager.Tours(),
+			dbManager.TourPasses(),
+			dbManager.Events(),
+			dbManager.TicketTiers(),
+			dbManager.Organizers(),
+			dbManager.UnitOfWork(),
+		)),
+		seriesHandler:       handlers.NewSeriesHandler(series.NewSeriesService(
+			dbManager.EventSeries(),
+			dbManager.Events(),
+			dbManager.TicketTiers(),
+			dbManager.Venues(),
+			dbManager.UnitOfWork(),
+		)),
+		eventTemplateHandler: handlers.NewEventTemplateHandler(eventService),
+		eventChangeHandler:   handlers.NewEventChangeHandler(eventChangeService),
+		ticketTierHandler:    handlers.NewTicketTierHandler(tierService, eventService),
+		categoryHandler:      handlers.NewCategoryHandler(categoryService),
+		outboxHandler:        handlers.NewOutboxHandler(outbox.NewOutboxService(dbManager.Outbox())),
+		webhookHandler:       handlers.NewWebhookHandler(webhooks.NewWebhookService(
+			dbManager.UnitOfWork(),
+			dbManager.Webhooks(),
+			dbManager.Organizers(),
+		)),
+		notificationHandler:  handlers.NewNotificationHandler(smsService, whatsAppService, ticketLinkService, os.Getenv("SMS_RECEIPT_TOKEN")),
+		messageTemplateHandler: handlers.NewMessageTemplateHandler(messagetemplates.NewTemplateService(
+			messageTemplates,
+			dbManager.UnitOfWork(),
+			dbManager.MessageTemplates(),
+			dbManager.Users(),
+			dbManager.Organizers(),
+		)),
+		campaignHandler:        handlers.NewCampaignHandler(campaignService),
+		checkoutRecoveryHandler: handlers.NewCheckoutRecoveryHandler(recoveryService),
+		marketingHandler:       handlers.NewMarketingHandler(marketingService),
+		smsReceipts:            !isFakeSMSProvider(smsProvider),
+		whatsAppWebhooks:       !isFakeWhatsAppProvider(whatsAppProvider),
 	}
 	
 	server.setupMiddleware()
 	server.setupRoutes()
 	
-	return server
+	return server, nil
 }
 
 // setupMiddleware configures middleware
@@ -306,8 +651,52 @@ func (s *Server) setupRoutes() {
 			events.GET("/:id", s.handleGetEvent)
 		}
 		
+		// Public search routes
+		searchRoutes := v1.Group("/search")
+		{
+			searchRoutes.GET("/events", s.searchHandler.SearchEvents)
+			searchRoutes.GET("/suggestions", s.searchHandler.Suggest)
+		}
+		
+		// Public tour pages
+		toursRoutes := v1.Group("/tours")
+		{
+			toursRoutes.GET("", s.tourHandler.ListPublicTours)
+			toursRoutes.GET("/:id", s.tourHandler.GetPublicTour)
+		}
+		
+		// Keep-or-refund links sent to holders of a postponed event
+		eventChangeRoutes := v1.Group("/event-changes")
+		{
+			eventChangeRoutes.GET("/choices/:token", s.eventChangeHandler.GetChoice)
+			eventChangeRoutes.POST("/choices/:token", s.eventChangeHandler.RecordChoice)
+		}
+		
+		// Unsubscribe links sent with event reminders and follow-ups
+		campaignRoutes := v1.Group("/campaigns")
+		{
+			campaignRoutes.GET("/unsubscribe/:token", s.campaignHandler.GetUnsubscribe)
+			campaignRoutes.POST("/unsubscribe/:token", s.campaignHandler.Unsubscribe)
+		}
+		
+		// Links sent to customers who left an order unpaid
+		recoveryRoutes := v1.Group("/checkout-recovery")
+		{
+			recoveryRoutes.GET("/:token", s.checkoutRecoveryHandler.GetCheckout)
+		}
+		
+		// Unsubscribe links sent with marketing campaigns
+		marketingRoutes := v1.Group("/marketing")
+		{
+			marketingRoutes.GET("/unsubscribe/:token", s.marketingHandler.GetUnsubscribe)
+			marketingRoutes.POST("/unsubscribe/:token", s.marketingHandler.Unsubscribe)
+		}
+		
 		// Public categories route
-		v1.GET("/categories", s.handleGetCategories)
+		v1.GET("/categories", s.categoryHandler.GetCategories)
+		
+		// Public currencies route
+		v1.GET("/currencies", s.currencyHandler.GetSupportedCurrencies)
 		
 		// Protected user routes
 		user := v1.Group("/user")
@@ -317,6 +706,15 @@ func (s *Server) setupRoutes() {
 			user.PUT("/profile", s.handleUpdateProfile)
 			user.GET("/orders", s.handleGetUserOrders)
 			user.GET("/tickets", s.handleGetUserTickets)
+			user.GET("/whatsapp", s.notificationHandler.GetWhatsAppOptIn)
+			user.PUT("/whatsapp", s.notificationHandler.UpdateWhatsAppOptIn)
+			user.GET("/locale", s.messageTemplateHandler.GetLocale)
+			user.PUT("/locale", s.messageTemplateHandler.UpdateLocale)
+			user.GET("/notification-preferences", s.campaignHandler.GetNotificationPreferences)
+			user.PUT("/notification-preferences", s.campaignHandler.UpdateNotificationPreferences)
+			user.GET("/marketing-consents", s.marketingHandler.GetConsents)
+			user.PUT("/marketing-consents", s.marketingHandler.UpdateConsent)
+			user.GET("/marketing-consents/history", s.marketingHandler.GetConsentHistory)
 		}
 		
 		// Order routes
@@ -327,6 +725,39 @@ func (s *Server) setupRoutes() {
 			orders.GET("/:id", s.handleGetOrder)
 			orders.POST("/:id/cancel", s.handleCancelOrder)
 			orders.GET("/:id/tickets", s.handleGetOrderTickets)
+			orders.GET("/:id/tickets/:ticketId/wallet/apple", s.walletHandler.GetApplePass)
+			orders.GET("/:id/tickets/:ticketId/wallet/google", s.walletHandler.GetGoogleSaveURL)
+		}
+		
+		// Tour pass purchases; the order holds one line per covered event
+		tourPasses := v1.Group("/tour-passes")
+		tourPasses.Use(s.authMiddleware())
+		{
+			tourPasses.POST("/:id/orders", s.orderHandler.CreateTourPassOrder)
+		}
+		
+		// Place an abandoned order again from its recovery link
+		recoveryOrders := v1.Group("/checkout-recovery")
+		recoveryOrders.Use(s.authMiddleware())
+		{
+			recoveryOrders.POST("/:token/order", s.checkoutRecoveryHandler.RecreateOrder)
+		}
+		
+		// Wallet passes: signed links from ticket emails, and the Apple Wallet
+		// web service that installed passes call back for updates
+		walletRoutes := v1.Group("/wallet")
+		{
+			walletRoutes.GET("/tickets/:ticketId/apple", s.walletHandler.GetApplePassByLink)
+			walletRoutes.GET("/tickets/:ticketId/google", s.walletHandler.RedirectToGoogleWallet)
+			
+			appleWallet := walletRoutes.Group("/apple/v1")
+			{
+				appleWallet.POST("/devices/:deviceId/registrations/:passTypeId/:serialNumber", s.walletHandler.RegisterDevice)
+				appleWallet.DELETE("/devices/:deviceId/registrations/:passTypeId/:serialNumber", s.walletHandler.UnregisterDevice)
+				appleWallet.GET("/devices/:deviceId/registrations/:passTypeId", s.walletHandler.ListUpdatedPasses)
+				appleWallet.GET("/passes/:passTypeId/:serialNumber", s.walletHandler.GetLatestPass)
+				appleWallet.POST("/log", s.walletHandler.LogErrors)
+			}
 		}
 		
 		// Payment routes
@@ -342,8 +773,18 @@ func (s *Server) setupRoutes() {
 		{
 			webhooks.POST("/momo", s.handleMomoWebhook)
 			webhooks.POST("/paystack", s.handlePaystackWebhook)
+			if s.smsReceipts {
+				webhooks.POST("/sms/:provider", s.notificationHandler.SMSDeliveryReceipt)
+			}
+			if s.whatsAppWebhooks {
+				webhooks.GET("/whatsapp", s.notificationHandler.VerifyWhatsAppWebhook)
+				webhooks.POST("/whatsapp", s.notificationHandler.WhatsAppStatusWebhook)
+			}
 		}
 		
+		// Ticket retrieval links texted to customers without an email address
+		v1.GET("/ticket-links/:token", s.notificationHandler.GetTicketLink)
+		
 		// Scanner routes
 		scanner := v1.Group("/scanner")
 		{
@@ -367,6 +808,10 @@ func (s *Server) setupRoutes() {
 				scannerProtected.POST("/validate", s.scannerHandler.ValidateTicket)
 				scannerProtected.GET("/stats", s.scannerHandler.GetStats)
 				scannerProtected.GET("/validation-history", s.scannerHandler.GetValidationHistory)
+				
+				// Guest list check-in by name (no QR)
+				scannerProtected.GET("/guest-list", s.compHandler.ScannerSearchGuestList)
+				scannerProtected.POST("/guest-list/:id/check-in", s.compHandler.ScannerCheckInGuest)
 			}
 		}
 		
@@ -411,12 +856,252 @@ func (s *Server) setupRoutes() {
 				adminProtected.GET("/tickets/:id", s.adminHandler.GetTicket)
 				adminProtected.PUT("/tickets/:id", s.adminHandler.UpdateTicket)
 				adminProtected.POST("/tickets/:id/validate", s.adminHandler.ValidateTicket)
+				walletAdmin := adminProtected.Group("")
+				walletAdmin.Use(s.requireAdminRole("super_admin", "admin"))
+				{
+					walletAdmin.POST("/tickets/:id/wallet/refresh", s.walletHandler.RefreshTicketPasses)
+				}
 				
 				// Analytics and reports
 				adminProtected.GET("/analytics/dashboard", s.adminHandler.GetDashboard)
 				adminProtected.GET("/analytics/events", s.adminHandler.GetEventAnalytics)
 				adminProtected.GET("/analytics/sales", s.adminHandler.GetSalesAnalytics)
 				adminProtected.GET("/analytics/users", s.adminHandler.GetUserAnalytics)
+				adminProtected.GET("/analytics/settlement", s.currencyHandler.GetSettlementReport)
+				
+				// Rebuild the sales figures without waiting for the scheduled refresh
+				analyticsAdmin := adminProtected.Group("")
+				analyticsAdmin.Use(s.requireAdminRole("super_admin", "admin"))
+				{
+					analyticsAdmin.POST("/analytics/refresh", s.adminHandler.RefreshAnalytics)
+				}
+				
+				// FX rates (reporting conversion only; settlement stays in event currency)
+				adminProtected.GET("/fx-rates", s.currencyHandler.ListFXRates)
+				fxRatesAdmin := adminProtected.Group("")
+				fxRatesAdmin.Use(s.requireAdminRole("super_admin", "admin"))
+				{
+					fxRatesAdmin.POST("/fx-rates", s.currencyHandler.CreateFXRate)
+					fxRatesAdmin.DELETE("/fx-rates/:id", s.currencyHandler.DeleteFXRate)
+				}
+				
+				// Venues (shared by events; deactivated rather than deleted)
+				venuesAdmin := adminProtected.Group("/venues")
+				venuesAdmin.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					venuesAdmin.GET("", s.venueHandler.ListVenues)
+					venuesAdmin.POST("", s.venueHandler.CreateVenue)
+					venuesAdmin.GET("/:id", s.venueHandler.GetVenue)
+					venuesAdmin.PUT("/:id", s.venueHandler.UpdateVenue)
+					venuesAdmin.POST("/:id/deactivate", s.venueHandler.DeactivateVenue)
+				}
+				
+				// Tours and tour passes
+				toursAdmin := adminProtected.Group("")
+				toursAdmin.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					toursAdmin.GET("/tours", s.tourHandler.ListTours)
+					toursAdmin.POST("/tours", s.tourHandler.CreateTour)
+					toursAdmin.GET("/tours/:id", s.tourHandler.GetTour)
+					toursAdmin.PUT("/tours/:id", s.tourHandler.UpdateTour)
+					toursAdmin.DELETE("/tours/:id", s.tourHandler.DeleteTour)
+					toursAdmin.GET("/tours/:id/passes", s.tourHandler.ListTourPasses)
+					toursAdmin.POST("/tours/:id/passes", s.tourHandler.CreateTourPass)
+					toursAdmin.PUT("/tour-passes/:id", s.tourHandler.UpdateTourPass)
+				}
+				
+				// Recurring event series and their occurrences
+				seriesAdmin := adminProtected.Group("")
+				seriesAdmin.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					seriesAdmin.GET("/series", s.seriesHandler.ListSeries)
+					seriesAdmin.POST("/series", s.seriesHandler.CreateSeries)
+					seriesAdmin.GET("/series/:id", s.seriesHandler.GetSeries)
+					seriesAdmin.PUT("/series/:id", s.seriesHandler.UpdateSeries)
+					seriesAdmin.POST("/series/:id/generate", s.seriesHandler.GenerateOccurrences)
+					seriesAdmin.PUT("/series/occurrences/:id", s.seriesHandler.UpdateOccurrence)
+				}
+				
+				// Event cloning and reusable event templates
+				templatesAdmin := adminProtected.Group("")
+				templatesAdmin.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					templatesAdmin.POST("/events/:id/clone", s.eventTemplateHandler.CloneEvent)
+					templatesAdmin.GET("/event-templates", s.eventTemplateHandler.ListTemplates)
+					templatesAdmin.POST("/event-templates", s.eventTemplateHandler.CreateTemplate)
+					templatesAdmin.GET("/event-templates/:id", s.eventTemplateHandler.GetTemplate)
+					templatesAdmin.PUT("/event-templates/:id", s.eventTemplateHandler.UpdateTemplate)
+					templatesAdmin.DELETE("/event-templates/:id", s.eventTemplateHandler.DeleteTemplate)
+					templatesAdmin.POST("/event-templates/:id/events", s.eventTemplateHandler.CreateEventFromTemplate)
+				}
+				
+				// Event cancellation and postponement, with holder notification progress
+				changesAdmin := adminProtected.Group("")
+				changesAdmin.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					changesAdmin.POST("/events/:id/cancel", s.eventChangeHandler.CancelEvent)
+					changesAdmin.POST("/events/:id/postpone", s.eventChangeHandler.PostponeEvent)
+					changesAdmin.GET("/event-changes", s.eventChangeHandler.ListChanges)
+					changesAdmin.GET("/event-changes/:id", s.eventChangeHandler.GetChange)
+					changesAdmin.POST("/event-changes/:id/resume", s.eventChangeHandler.ResumeChange)
+					changesAdmin.GET("/event-changes/:id/recipients", s.eventChangeHandler.ListRecipients)
+				}
+				
+				// Event reminder and follow-up campaigns, with per-holder delivery reports
+				campaignsAdmin := adminProtected.Group("")
+				campaignsAdmin.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					campaignsAdmin.GET("/events/:id/campaigns", s.campaignHandler.ListCampaigns)
+					campaignsAdmin.POST("/events/:id/campaigns", s.campaignHandler.CreateCampaign)
+					campaignsAdmin.GET("/campaigns/:id", s.campaignHandler.GetCampaign)
+					campaignsAdmin.PUT("/campaigns/:id", s.campaignHandler.UpdateCampaign)
+					campaignsAdmin.POST("/campaigns/:id/cancel", s.campaignHandler.CancelCampaign)
+					campaignsAdmin.GET("/campaigns/:id/deliveries", s.campaignHandler.ListDeliveries)
+				}
+				
+				// Abandoned checkouts, their recovery messages and recovered revenue
+				recoveryAdmin := adminProtected.Group("")
+				recoveryAdmin.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					recoveryAdmin.GET("/checkout-recoveries", s.checkoutRecoveryHandler.ListRecoveries)
+					recoveryAdmin.GET("/checkout-recoveries/stats", s.checkoutRecoveryHandler.GetStats)
+				}
+				
+				// Marketing consent audit trail, audiences and campaigns
+				marketingAdmin := adminProtected.Group("/marketing")
+				marketingAdmin.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					marketingAdmin.GET("/consents/audit", s.marketingHandler.ListConsentRecords)
+					marketingAdmin.GET("/audiences", s.marketingHandler.ListAudiences)
+					marketingAdmin.POST("/audiences", s.marketingHandler.CreateAudience)
+					marketingAdmin.GET("/audiences/:id", s.marketingHandler.GetAudience)
+					marketingAdmin.PUT("/audiences/:id", s.marketingHandler.UpdateAudience)
+					marketingAdmin.DELETE("/audiences/:id", s.marketingHandler.DeleteAudience)
+					marketingAdmin.GET("/audiences/:id/preview", s.marketingHandler.PreviewAudience)
+					marketingAdmin.GET("/campaigns", s.marketingHandler.ListCampaigns)
+					marketingAdmin.POST("/campaigns", s.marketingHandler.CreateCampaign)
+					marketingAdmin.GET("/campaigns/:id", s.marketingHandler.GetCampaign)
+					marketingAdmin.PUT("/campaigns/:id", s.marketingHandler.UpdateCampaign)
+					marketingAdmin.POST("/campaigns/:id/cancel", s.marketingHandler.CancelCampaign)
+					marketingAdmin.GET("/campaigns/:id/deliveries", s.marketingHandler.ListDeliveries)
+				}
+				
+				// Ticket tier sequencing, scheduled price changes and dynamic pricing
+				tiersAdmin := adminProtected.Group("")
+				tiersAdmin.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					tiersAdmin.GET("/events/:id/ticket-tiers", s.ticketTierHandler.GetEventTiers)
+					tiersAdmin.PUT("/ticket-tiers/:id/sequence", s.ticketTierHandler.SetSequence)
+					tiersAdmin.PUT("/ticket-tiers/:id/pricing", s.ticketTierHandler.SetPricingStrategy)
+					tiersAdmin.POST("/ticket-tiers/:id/pricing/simulate", s.ticketTierHandler.SimulatePricing)
+					tiersAdmin.GET("/ticket-tiers/:id/price-changes", s.ticketTierHandler.ListPriceChanges)
+					tiersAdmin.POST("/ticket-tiers/:id/price-changes", s.ticketTierHandler.SchedulePriceChange)
+					tiersAdmin.DELETE("/ticket-tiers/:id/price-changes/:change_id", s.ticketTierHandler.CancelPriceChange)
+				}
+				
+				// Event categories (nested, ordered among siblings)
+				categoriesAdmin := adminProtected.Group("")
+				categoriesAdmin.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					categoriesAdmin.GET("/categories", s.categoryHandler.ListCategories)
+					categoriesAdmin.POST("/categories", s.categoryHandler.CreateCategory)
+					categoriesAdmin.PUT("/categories/order", s.categoryHandler.ReorderCategories)
+					categoriesAdmin.GET("/categories/:id", s.categoryHandler.GetCategory)
+					categoriesAdmin.PUT("/categories/:id", s.categoryHandler.UpdateCategory)
+					categoriesAdmin.DELETE("/categories/:id", s.categoryHandler.DeleteCategory)
+					categoriesAdmin.PUT("/events/:id/category", s.categoryHandler.SetEventCategory)
+				}
+				
+				// Outbox: queued emails, their delivery attempts and dead letters
+				outboxAdmin := adminProtected.Group("")
+				outboxAdmin.Use(s.requireAdminRole("super_admin", "admin"))
+				{
+					outboxAdmin.GET("/outbox", s.outboxHandler.ListMessages)
+					outboxAdmin.GET("/outbox/stats", s.outboxHandler.GetStats)
+					outboxAdmin.POST("/outbox/retry-dead", s.outboxHandler.RetryDead)
+					outboxAdmin.GET("/outbox/:id", s.outboxHandler.GetMessage)
+					outboxAdmin.POST("/outbox/:id/retry", s.outboxHandler.RetryMessage)
+				}
+				
+				// Organizer webhooks and their delivery log
+				webhooksAdmin := adminProtected.Group("")
+				webhooksAdmin.Use(s.requireAdminRole("super_admin", "admin"))
+				{
+					webhooksAdmin.GET("/organizers/:id/webhooks", s.webhookHandler.ListWebhooks)
+					webhooksAdmin.POST("/organizers/:id/webhooks", s.webhookHandler.CreateWebhook)
+					webhooksAdmin.GET("/webhooks/:id", s.webhookHandler.GetWebhook)
+					webhooksAdmin.PUT("/webhooks/:id", s.webhookHandler.UpdateWebhook)
+					webhooksAdmin.DELETE("/webhooks/:id", s.webhookHandler.DeleteWebhook)
+					webhooksAdmin.POST("/webhooks/:id/rotate-secret", s.webhookHandler.RotateSecret)
+					webhooksAdmin.GET("/webhooks/:id/deliveries", s.webhookHandler.ListDeliveries)
+					webhooksAdmin.GET("/webhook-deliveries/:id", s.webhookHandler.GetDelivery)
+					webhooksAdmin.POST("/webhook-deliveries/:id/redeliver", s.webhookHandler.Redeliver)
+				}
+				
+				// SMS and WhatsApp message logs
+				notificationsAdmin := adminProtected.Group("")
+				notificationsAdmin.Use(s.requireAdminRole("super_admin", "admin"))
+				{
+					notificationsAdmin.GET("/notifications/sms", s.notificationHandler.ListSMSMessages)
+					notificationsAdmin.GET("/notifications/sms/:id", s.notificationHandler.GetSMSMessage)
+					notificationsAdmin.GET("/notifications/whatsapp", s.notificationHandler.ListWhatsAppMessages)
+					notificationsAdmin.GET("/notifications/whatsapp/:id", s.notificationHandler.GetWhatsAppMessage)
+				}
+				
+				// Message templates, previews and organizer branding
+				messageTemplatesAdmin := adminProtected.Group("")
+				messageTemplatesAdmin.Use(s.requireAdminRole("super_admin", "admin"))
+				{
+					messageTemplatesAdmin.GET("/message-templates/catalog", s.messageTemplateHandler.GetCatalog)
+					messageTemplatesAdmin.GET("/message-templates/defaults", s.messageTemplateHandler.GetDefault)
+					messageTemplatesAdmin.POST("/message-templates/preview", s.messageTemplateHandler.Preview)
+					messageTemplatesAdmin.GET("/message-templates", s.messageTemplateHandler.ListVersions)
+					messageTemplatesAdmin.POST("/message-templates", s.messageTemplateHandler.PublishVersion)
+					messageTemplatesAdmin.GET("/message-templates/:id", s.messageTemplateHandler.GetVersion)
+					messageTemplatesAdmin.POST("/message-templates/:id/activate", s.messageTemplateHandler.ActivateVersion)
+					messageTemplatesAdmin.POST("/message-templates/:id/deactivate", s.messageTemplateHandler.DeactivateVersion)
+					messageTemplatesAdmin.GET("/organizers/:id/branding", s.messageTemplateHandler.GetBranding)
+					messageTemplatesAdmin.PUT("/organizers/:id/branding", s.messageTemplateHandler.UpdateBranding)
+				}
+				
+				// Comps and guest list
+				compsAdmin := adminProtected.Group("")
+				compsAdmin.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					compsAdmin.GET("/comps/allocations", s.compHandler.ListAllocations)
+					compsAdmin.PUT("/comps/allocations", s.compHandler.SetAllocation)
+					compsAdmin.POST("/comps", s.compHandler.IssueComp)
+					compsAdmin.POST("/comps/import", s.compHandler.ImportComps)
+					compsAdmin.POST("/guest-list/:id/cancel", s.compHandler.CancelComp)
+				}
+				adminProtected.GET("/guest-list", s.compHandler.ListGuestList)
+				adminProtected.POST("/guest-list/:id/check-in", s.requireAdminRole("super_admin", "admin", "event_manager", "support"), s.compHandler.CheckInGuest)
+				
+				// Box office (walk-in sales at the venue; analysts are read-only and excluded)
+				boxOffice := adminProtected.Group("/box-office")
+				boxOffice.Use(s.requireAdminRole("super_admin", "admin", "event_manager", "support"))
+				{
+					boxOffice.POST("/shifts", s.boxOfficeHandler.OpenShift)
+					boxOffice.GET("/shifts", s.boxOfficeHandler.ListShifts)
+					boxOffice.GET("/shifts/current", s.boxOfficeHandler.GetCurrentShift)
+					boxOffice.GET("/shifts/:id/report", s.boxOfficeHandler.GetShiftReport)
+					boxOffice.POST("/shifts/:id/close", s.boxOfficeHandler.CloseShift)
+					boxOffice.POST("/sales", s.boxOfficeHandler.CreateSale)
+					boxOffice.POST("/orders/:id/email", s.boxOfficeHandler.EmailTickets)
+					boxOffice.GET("/orders/:id/tickets/:ticketId/pdf", s.boxOfficeHandler.PrintTicket)
+				}
+				
+				// Bulk ticket imports (attendee lists and tickets sold elsewhere)
+				ticketImports := adminProtected.Group("/imports")
+				ticketImports.Use(s.requireAdminRole("super_admin", "admin", "event_manager"))
+				{
+					ticketImports.POST("", s.ticketImportHandler.CreateImport)
+					ticketImports.GET("", s.ticketImportHandler.ListImports)
+					ticketImports.GET("/:id", s.ticketImportHandler.GetImport)
+					ticketImports.POST("/:id/resume", s.ticketImportHandler.ResumeImport)
+					ticketImports.GET("/:id/rows", s.ticketImportHandler.ListRows)
+					ticketImports.GET("/:id/report.csv", s.ticketImportHandler.DownloadReport)
+				}
 				
 				// Scanner user management
 				adminProtected.GET("/scanner-users", s.adminHandler.GetScannerUsers)
@@ -538,6 +1223,22 @@ func (s *Server) adminAuthMiddleware() gin.HandlerFunc {
 	}
 }
 
+// requireAdminRole restricts a route group to the given admin roles.
+// Must run after adminAuthMiddleware, which sets adminRole.
+func (s *Server) requireAdminRole(roles ...string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		role := c.GetString("adminRole")
+		for _, allowed := range roles {
+			if role == allowed {
+				c.Next()
+				return
+			}
+		}
+		c.JSON(http.StatusForbidden, gin.H{"error": "Insufficient permissions"})
+		c.Abort()
+	}
+}
+
 // scannerAuthMiddleware validates JWT tokens for scanner users
 func (s *Server) scannerAuthMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
@@ -643,10 +1344,49 @@ func (s *Server) handleGetEvents(c *gin.Context) {
 		Limit:  limit,
 		Search: search,
 		City:   city,
+		// Recurring events are listed once unless ?group_series=false
+		GroupSeries: c.Query("group_series") != "false",
+	}
+	
+	// ?category= takes a category ID or slug and includes its subcategories
+	if categoryParam := c.Query("category"); categoryParam != "" {
+		category, err := s.categoryService.ResolveCategory(ctx, categoryParam)
+		if err != nil {
+			if entities.IsNotFoundError(err) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch events"})
+			return
+		}
+		req.CategoryID = &category.ID
+	}
+	
+	// Nearby events: ?near=lat,lng&radius_km=
+	if near := c.Query("near"); near != "" {
+		point, err := parseGeoPoint(near)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "near must be latitude,longitude"})
+			return
+		}
+		req.Near = point
+		
+		if radiusStr := c.Query("radius_km"); radiusStr != "" {
+			radius, err := strconv.ParseFloat(radiusStr, 64)
+			if err != nil || radius <= 0 {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "radius_km must be a positive number"})
+				return
+			}
+			req.RadiusKm = radius
+		}
 	}
 	
 	response, err := s.eventService.GetPublicEvents(ctx, req)
 	if err != nil {
+		if entities.IsValidationError(err) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch events"})
 		return
 	}
@@ -675,12 +1415,12 @@ func (s *Server) handleGetEvent(c *gin.Context) {
 		return
 	}
 	
-	// Fetch active ticket tiers for this event
-	tiers, err := s.dbManager.TicketTiers().GetActiveByEvent(ctx, eventID)
+	// Fetch ticket tiers with their price, stock and sale state (upcoming, on sale, sold out, ended)
+	ticketTiers, err := s.eventService.GetTicketTierAvailability(ctx, eventID)
 	if err != nil {
 		// Log error but don't fail the request if tiers can't be fetched
 		fmt.Printf("Warning: Failed to fetch ticket tiers for event %s: %v\n", eventID, err)
-		tiers = []*entities.TicketTier{}
+		ticketTiers = []*events.TicketTierAvailabilityInfo{}
 	}
 	
 	// Create response with event and ticket tiers
@@ -697,17 +1437,19 @@ func (s *Server) handleGetEvent(c *gin.Context) {
 		"venue_state":      event.VenueState,
 		"venue_country":    event.VenueCountry,
 		"venue_capacity":   event.VenueCapacity,
+		"venue_latitude":   event.VenueLatitude,
+		"venue_longitude":  event.VenueLongitude,
 		"event_image_url":  event.EventImageURL,
 		"status":           event.Status,
 		"sale_start":       event.SaleStart,
 		"sale_end":         event.SaleEnd,
-			"currency":         "NGN", // Hardcoded to NGN
+		"currency":         event.Currency,
 		"category_id":      event.CategoryID,
 		"settings":         event.Settings,
 		"is_active":        event.IsActive,
 		"created_at":       event.CreatedAt,
 		"updated_at":       event.UpdatedAt,
-		"ticket_tiers":     tiers,
+		"ticket_tiers":     ticketTiers,
 	}
 	
 	c.JSON(http.StatusOK, gin.H{
@@ -716,70 +1458,6 @@ func (s *Server) handleGetEvent(c *gin.Context) {
 	})
 }
 
-func (s *Server) handleGetCategories(c *gin.Context) {
-	ctx := c.Request.Context()
-	
-	query := `
-		SELECT id, name, slug, description, icon, color, display_order, is_active, created_at, updated_at
-		FROM categories
-		WHERE is_active = true
-		ORDER BY display_order ASC, name ASC
-	`
-	
-	type Category struct {
-		ID           string    `db:"id" json:"id"`
-		Name         string    `db:"name" json:"name"`
-		Slug         string    `db:"slug" json:"slug"`
-		Description  *string   `db:"description" json:"description"`
-		Icon         *string   `db:"icon" json:"icon"`
-		Color        *string   `db:"color" json:"color"`
-		DisplayOrder int       `db:"display_order" json:"display_order"`
-		IsActive     bool      `db:"is_active" json:"is_active"`
-		CreatedAt    time.Time `db:"created_at" json:"created_at"`
-		UpdatedAt    time.Time `db:"updated_at" json:"updated_at"`
-	}
-	
-	rows, err := s.dbManager.GetDB().QueryContext(ctx, query)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{
-			"success": false,
-			"error":   "Failed to fetch categories",
-		})
-		return
-	}
-	defer rows.Close()
-	
-	categories := []Category{}
-	for rows.Next() {
-		var cat Category
-		err := rows.Scan(
-			&cat.ID,
-			&cat.Name,
-			&cat.Slug,
-			&cat.Description,
-			&cat.Icon,
-			&cat.Color,
-			&cat.DisplayOrder,
-			&cat.IsActive,
-			&cat.CreatedAt,
-			&cat.UpdatedAt,
-		)
-		if err != nil {
-			c.JSON(http.StatusInternalServerError, gin.H{
-				"success": false,
-				"error":   "Failed to scan category",
-			})
-			return
-		}
-		categories = append(categories, cat)
-	}
-	
-	c.JSON(http.StatusOK, gin.H{
-		"success": true,
-		"data":    categories,
-	})
-}
-
 func (s *Server) handleGetProfile(c *gin.Context) {
 	userIDStr, _ := c.Get("userID")
 	userID, err := uuid.Parse(userIDStr.(string))
@@ -986,6 +1664,34 @@ func (s *Server) handleGetOrderTickets(c *gin.Context) {
 func (s *Server) Start() error {
 	addr := fmt.Sprintf("%s:%s", s.config.Host, s.config.Port)
 	
+	// Pick up cancellations and postponements a previous process did not finish
+	if err := s.eventChangeService.ResumeUnfinished(context.Background()); err != nil {
+		fmt.Printf("Warning: failed to resume event changes: %v\n", err)
+	}
+	
+	// Switch ticket tiers to their scheduled prices as they come due
+	go s.tierService.RunPriceScheduler(context.Background(), tiers.DefaultPriceSchedulerInterval)
+	
+	// Send event reminders and follow-ups as they come due
+	go s.campaignService.RunScheduler(context.Background(), campaigns.DefaultSchedulerInterval)
+	
+	// Expire unpaid orders once their hold runs out, releasing their tickets
+	go s.orderService.RunExpiry(context.Background(), orders.DefaultExpiryInterval)
+	
+	// Send abandoned checkout recoveries once their delay has passed
+	go s.recoveryService.RunScheduler(context.Background(), checkoutrecovery.DefaultSchedulerInterval)
+	
+	// Send marketing campaigns as they come due
+	go s.marketingService.RunScheduler(context.Background(), marketing.DefaultSchedulerInterval)
+	
+	// Refresh the sales analytics rollups; 0 leaves refreshing to an external job
+	if refreshMinutes := getEnvInt("ANALYTICS_REFRESH_MINUTES", analytics.DefaultRefreshMinutes); refreshMinutes > 0 {
+		go s.analyticsService.RunScheduler(context.Background(), time.Duration(refreshMinutes)*time.Minute)
+	}
+	
+	// Deliver queued emails, retrying failures with backoff
+	go s.outboxDispatcher.Run(context.Background(), outbox.DefaultDispatchInterval)
+	
 	s.httpServer = &http.Server{
 		Addr:         addr,
 		Handler:      s.router,
@@ -1004,6 +1710,46 @@ func (s *Server) Shutdown(ctx context.Context) error {
 	return nil
 }
 
+// newSMSProvider builds the SMS provider named by SMS_PROVIDER, or the fake
+// provider when it is unset. A provider that is named but misconfigured is an
+// error rather than a silent fallback to the fake.
+func newSMSProvider() (services.SMSProvider, error) {
+	return sms.NewProvider(sms.Config{
+		Provider:                getEnv("SMS_PROVIDER", ""),
+		TermiiAPIKey:            getEnv("TERMII_API_KEY", ""),
+		TermiiBaseURL:           getEnv("TERMII_BASE_URL", ""),
+		TermiiChannel:           getEnv("TERMII_CHANNEL", ""),
+		AfricasTalkingUsername:  getEnv("AFRICASTALKING_USERNAME", ""),
+		AfricasTalkingAPIKey:    getEnv("AFRICASTALKING_API_KEY", ""),
+		TwilioAccountSID:        getEnv("TWILIO_ACCOUNT_SID", ""),
+		TwilioAuthToken:         getEnv("TWILIO_AUTH_TOKEN", ""),
+		TwilioStatusCallbackURL: getEnv("TWILIO_STATUS_CALLBACK_URL", ""),
+	})
+}
+
+// newWhatsAppProvider builds the WhatsApp provider named by WHATSAPP_PROVIDER,
+// or the fake provider when it is unset, like newSMSProvider
+func newWhatsAppProvider() (services.WhatsAppProvider, error) {
+	return whatsapp.NewProvider(whatsapp.Config{
+		Provider:      getEnv("WHATSAPP_PROVIDER", ""),
+		PhoneNumberID: getEnv("WHATSAPP_PHONE_NUMBER_ID", ""),
+		AccessToken:   getEnv("WHATSAPP_ACCESS_TOKEN", ""),
+		AppSecret:     getEnv("WHATSAPP_APP_SECRET", ""),
+		VerifyToken:   getEnv("WHATSAPP_VERIFY_TOKEN", ""),
+		APIVersion:    getEnv("WHATSAPP_API_VERSION", ""),
+	})
+}
+
+func isFakeSMSProvider(provider services.SMSProvider) bool {
+	_, fake := provider.(*sms.FakeProvider)
+	return fake
+}
+
+func isFakeWhatsAppProvider(provider services.WhatsAppProvider) bool {
+	_, fake := provider.(*whatsapp.FakeProvider)
+	return fake
+}
+
 // getEnv retrieves environment variable or returns default value
 func getEnv(key, defaultValue string) string {
 	if value := os.Getenv(key); value != "" {
@@ -1012,6 +1758,26 @@ func getEnv(key, defaultValue string) string {
 	return defaultValue
 }
 
+// getEnvInt retrieves an integer environment variable or returns default value
+func getEnvInt(key string, defaultValue int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return defaultValue
+	}
+	return value
+}
+
+// getEnvList returns the comma-separated values of an environment variable
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
 // isNotFoundError checks if an error is a not-found error
 func isNotFoundError(err error) bool {
 	if err == nil {
@@ -1026,6 +1792,25 @@ func isNotFoundError(err error) bool {
 	return false
 }
 
+// parseGeoPoint parses a "latitude,longitude" pair
+func parseGeoPoint(value string) (*repositories.GeoPoint, error) {
+	parts := strings.Split(value, ",")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("expected latitude,longitude")
+	}
+	
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(parts[0]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latitude: %w", err)
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(parts[1]), 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid longitude: %w", err)
+	}
+	
+	return &repositories.GeoPoint{Latitude: latitude, Longitude: longitude}, nil
+}
+
 // isValidationError checks if an error is a validation error
 func isValidationError(err error) bool {
 	if err == nil {
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+func TestProvidersFallBackToFakeOnlyWhenUnset(t *testing.T) {
+	t.Setenv("SMS_PROVIDER", "")
+	t.Setenv("WHATSAPP_PROVIDER", "")
+
+	smsProvider, err := newSMSProvider()
+	if err != nil || !isFakeSMSProvider(smsProvider) {
+		t.Errorf("newSMSProvider() = %T, %v, want the fake provider", smsProvider, err)
+	}
+	whatsAppProvider, err := newWhatsAppProvider()
+	if err != nil || !isFakeWhatsAppProvider(whatsAppProvider) {
+		t.Errorf("newWhatsAppProvider() = %T, %v, want the fake provider", whatsAppProvider, err)
+	}
+
+	// A named provider that cannot be built must stop the server from starting
+	for _, provider := range []string{"termii", "carrier-pigeon"} {
+		t.Setenv("SMS_PROVIDER", provider)
+		t.Setenv("TERMII_API_KEY", "")
+		if _, err := newSMSProvider(); err == nil {
+			t.Errorf("newSMSProvider() with SMS_PROVIDER=%s succeeded, want an error", provider)
+		}
+	}
+	for _, provider := range []string{"cloud", "carrier-pigeon"} {
+		t.Setenv("WHATSAPP_PROVIDER", provider)
+		t.Setenv("WHATSAPP_ACCESS_TOKEN", "")
+		if _, err := newWhatsAppProvider(); err == nil {
+			t.Errorf("newWhatsAppProvider() with WHATSAPP_PROVIDER=%s succeeded, want an error", provider)
+		}
+	}
+}
+
+func TestDeliveryWebhooksOnlyServedForRealProviders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	webhooks := []string{"POST /v1/webhooks/sms/:provider", "GET /v1/webhooks/whatsapp", "POST /v1/webhooks/whatsapp"}
+
+	for _, real := range []bool{false, true} {
+		s := &Server{config: &Config{}, router: gin.New(), smsReceipts: real, whatsAppWebhooks: real}
+		s.setupRoutes()
+
+		routes := make(map[string]bool)
+		for _, route := range s.router.Routes() {
+			routes[route.Method+" "+route.Path] = true
+		}
+		for _, webhook := range webhooks {
+			if routes[webhook] != real {
+				t.Errorf("real providers = %v: route %s registered = %v", real, webhook, routes[webhook])
+			}
+		}
+	}
+}
@@ -0,0 +1,433 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const (
+	// DefaultRefreshMinutes is how often the sales rollups are refreshed,
+	// overridden with ANALYTICS_REFRESH_MINUTES
+	DefaultRefreshMinutes = 15
+
+	// DefaultPeriodDays is how many days analytics cover when no start is given
+	DefaultPeriodDays = 30
+
+	// DefaultBreakdownLimit is how many values a breakdown keeps per currency
+	DefaultBreakdownLimit = 10
+
+	// MaxBreakdownLimit caps the values a breakdown keeps per currency
+	MaxBreakdownLimit = 100
+
+	// dashboardTopEvents is how many best-selling events the dashboard lists per currency
+	dashboardTopEvents = 5
+
+	// maxBuckets caps the points in a series, a little over a year of days
+	maxBuckets = 400
+)
+
+// AnalyticsService reports sales and buyers for the admin dashboard.
+//
+// Sales come from daily rollups (the materialized views of migration 045),
+// so a report costs the same however many orders there are. RunScheduler
+// refreshes them every ANALYTICS_REFRESH_MINUTES without blocking reads and
+// Refresh does so on demand; every sales report says when they were last
+// refreshed, as its figures leave out orders placed or paid since. Buyer
+// counts need distinct buyers across days, which daily rows cannot give, so
+// they are queried live from orders, as are event counts.
+//
+// Periods are whole UTC days. Revenue is never summed across currencies.
+type AnalyticsService struct {
+	analyticsRepo repositories.AnalyticsRepository
+	organizerRepo repositories.OrganizerRepository
+}
+
+// NewAnalyticsService creates a new analytics service
+func NewAnalyticsService(
+	analyticsRepo repositories.AnalyticsRepository,
+	organizerRepo repositories.OrganizerRepository,
+) *AnalyticsService {
+	return &AnalyticsService{
+		analyticsRepo: analyticsRepo,
+		organizerRepo: organizerRepo,
+	}
+}
+
+// AnalyticsRequest selects the period, scope and grouping of analytics. From
+// is rounded down and To up to a UTC midnight; without them the period is
+// the last DefaultPeriodDays days, today included.
+type AnalyticsRequest struct {
+	From        *time.Time
+	To          *time.Time
+	OrganizerID *uuid.UUID
+	EventID     *uuid.UUID
+	Currency    string
+	Bucket      repositories.AnalyticsBucket
+	Limit       int
+}
+
+// Period is the span analytics cover, from the start of From to the start of To
+type Period struct {
+	From time.Time `json:"from"`
+	To   time.Time `json:"to"`
+}
+
+// Dashboard summarizes a period. Event counts are as of now, whatever the period.
+type Dashboard struct {
+	Period      Period                         `json:"period"`
+	Events      *repositories.EventCounts      `json:"events"`
+	Sales       []*repositories.SalesFigures   `json:"sales"`
+	Buyers      *repositories.BuyerTotals      `json:"buyers"`
+	TopEvents   []*repositories.SalesBreakdown `json:"top_events"`
+	RefreshedAt *time.Time                     `json:"refreshed_at"`
+}
+
+// SalesAnalytics reports sales over a period, per bucket and broken down by
+// event, ticket tier, payment method and city. Conversion is paid orders per
+// order placed in the same period, as a percentage.
+type SalesAnalytics struct {
+	Period          Period                         `json:"period"`
+	Bucket          repositories.AnalyticsBucket   `json:"bucket"`
+	Totals          []*repositories.SalesFigures   `json:"totals"`
+	Series          []*repositories.SalesPoint     `json:"series"`
+	ByEvent         []*repositories.SalesBreakdown `json:"by_event"`
+	ByTier          []*repositories.SalesBreakdown `json:"by_tier"`
+	ByPaymentMethod []*repositories.SalesBreakdown `json:"by_payment_method"`
+	ByCity          []*repositories.SalesBreakdown `json:"by_city"`
+	RefreshedAt     *time.Time                     `json:"refreshed_at"`
+}
+
+// BuyerAnalytics reports new and returning buyers over a period, per bucket
+type BuyerAnalytics struct {
+	Period Period                       `json:"period"`
+	Bucket repositories.AnalyticsBucket `json:"bucket"`
+	Totals *repositories.BuyerTotals    `json:"totals"`
+	Series []*repositories.BuyerPoint   `json:"series"`
+}
+
+// GetDashboard summarizes sales, buyers and events over a period
+func (s *AnalyticsService) GetDashboard(ctx context.Context, req *AnalyticsRequest) (*Dashboard, error) {
+	filter, _, err := s.resolve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.analyticsRepo.GetEventCounts(ctx, filter.OrganizerID)
+	if err != nil {
+		return nil, err
+	}
+	sales, err := s.getTotals(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	buyers, err := s.getBuyerTotals(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	topEvents, err := s.analyticsRepo.GetSalesBreakdown(ctx, filter, repositories.AnalyticsDimensionEvent, dashboardTopEvents)
+	if err != nil {
+		return nil, err
+	}
+	refreshedAt, err := s.analyticsRepo.GetRefreshedAt(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Dashboard{
+		Period:      Period{From: filter.From, To: filter.To},
+		Events:      events,
+		Sales:       sales,
+		Buyers:      buyers,
+		TopEvents:   topEvents,
+		RefreshedAt: refreshedAt,
+	}, nil
+}
+
+// GetSalesAnalytics reports sales over a period. Breakdowns keep the
+// request's limit of best-selling values per currency; by event, they are the
+// top events.
+func (s *AnalyticsService) GetSalesAnalytics(ctx context.Context, req *AnalyticsRequest) (*SalesAnalytics, error) {
+	filter, bucket, err := s.resolve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	limit := req.Limit
+	if limit <= 0 {
+		limit = DefaultBreakdownLimit
+	}
+	if limit > MaxBreakdownLimit {
+		limit = MaxBreakdownLimit
+	}
+
+	totals, err := s.getTotals(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	points, err := s.analyticsRepo.GetSalesSeries(ctx, filter, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &SalesAnalytics{
+		Period: Period{From: filter.From, To: filter.To},
+		Bucket: bucket,
+		Totals: totals,
+		Series: fillSalesSeries(points, bucketStarts(filter.From, filter.To, bucket)),
+	}
+
+	breakdowns := []struct {
+		dimension repositories.AnalyticsDimension
+		rows      *[]*repositories.SalesBreakdown
+	}{
+		{repositories.AnalyticsDimensionEvent, &report.ByEvent},
+		{repositories.AnalyticsDimensionTier, &report.ByTier},
+		{repositories.AnalyticsDimensionPaymentMethod, &report.ByPaymentMethod},
+		{repositories.AnalyticsDimensionCity, &report.ByCity},
+	}
+	for _, breakdown := range breakdowns {
+		rows, err := s.analyticsRepo.GetSalesBreakdown(ctx, filter, breakdown.dimension, limit)
+		if err != nil {
+			return nil, err
+		}
+		*breakdown.rows = rows
+	}
+
+	if report.RefreshedAt, err = s.analyticsRepo.GetRefreshedAt(ctx); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// GetBuyerAnalytics reports new and returning buyers over a period
+func (s *AnalyticsService) GetBuyerAnalytics(ctx context.Context, req *AnalyticsRequest) (*BuyerAnalytics, error) {
+	filter, bucket, err := s.resolve(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	totals, err := s.getBuyerTotals(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	points, err := s.analyticsRepo.GetBuyerSeries(ctx, filter, bucket)
+	if err != nil {
+		return nil, err
+	}
+
+	return &BuyerAnalytics{
+		Period: Period{From: filter.From, To: filter.To},
+		Bucket: bucket,
+		Totals: totals,
+		Series: fillBuyerSeries(points, bucketStarts(filter.From, filter.To, bucket)),
+	}, nil
+}
+
+// Refresh rebuilds the sales rollups now and returns when the refresh started
+func (s *AnalyticsService) Refresh(ctx context.Context) (time.Time, error) {
+	return s.analyticsRepo.Refresh(ctx)
+}
+
+// RunScheduler refreshes the sales rollups every interval until ctx is done
+func (s *AnalyticsService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.Refresh(ctx); err != nil {
+			fmt.Printf("Warning: failed to refresh sales analytics: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// resolve validates a request and turns it into a filter over whole days
+func (s *AnalyticsService) resolve(ctx context.Context, req *AnalyticsRequest) (repositories.AnalyticsFilter, repositories.AnalyticsBucket, error) {
+	filter := repositories.AnalyticsFilter{OrganizerID: req.OrganizerID, EventID: req.EventID}
+
+	bucket := req.Bucket
+	if bucket == "" {
+		bucket = repositories.AnalyticsBucketDay
+	}
+	if !bucket.IsValid() {
+		return filter, "", entities.NewValidationError("bucket", "bucket must be day, week or month")
+	}
+
+	if req.Currency != "" {
+		filter.Currency = entities.NormalizeCurrency(req.Currency)
+		if !entities.IsSupportedCurrency(filter.Currency) {
+			return filter, "", entities.NewValidationError("currency", "unsupported currency")
+		}
+	}
+
+	filter.To = startOfDay(time.Now()).AddDate(0, 0, 1)
+	if req.To != nil {
+		filter.To = startOfDay(*req.To)
+		if !filter.To.Equal(req.To.UTC()) {
+			filter.To = filter.To.AddDate(0, 0, 1)
+		}
+	}
+	filter.From = filter.To.AddDate(0, 0, -DefaultPeriodDays)
+	if req.From != nil {
+		filter.From = startOfDay(*req.From)
+	}
+	if !filter.From.Before(filter.To) {
+		return filter, "", entities.NewValidationError("from", "from must be before to")
+	}
+	if len(bucketStarts(filter.From, filter.To, bucket)) > maxBuckets {
+		return filter, "", entities.NewValidationError("bucket", fmt.Sprintf("the period has more than %d %ss; use a larger bucket or a shorter period", maxBuckets, bucket))
+	}
+
+	if filter.OrganizerID != nil {
+		if _, err := s.organizerRepo.GetByID(ctx, *filter.OrganizerID); err != nil {
+			return filter, "", entities.NewNotFoundError("organizer", "organizer not found")
+		}
+	}
+
+	return filter, bucket, nil
+}
+
+func (s *AnalyticsService) getTotals(ctx context.Context, filter repositories.AnalyticsFilter) ([]*repositories.SalesFigures, error) {
+	totals, err := s.analyticsRepo.GetSalesTotals(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, figures := range totals {
+		fillRates(figures)
+	}
+	return totals, nil
+}
+
+func (s *AnalyticsService) getBuyerTotals(ctx context.Context, filter repositories.AnalyticsFilter) (*repositories.BuyerTotals, error) {
+	totals, err := s.analyticsRepo.GetBuyerTotals(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	totals.ReturningBuyers = totals.Buyers - totals.NewBuyers
+	return totals, nil
+}
+
+// fillRates works out the average order value and conversion rate of sales figures
+func fillRates(figures *repositories.SalesFigures) {
+	if figures.PaidOrders > 0 {
+		figures.AverageOrderValue = math.Round(figures.Revenue/float64(figures.PaidOrders)*100) / 100
+	}
+	if figures.OrdersCreated > 0 {
+		figures.ConversionRate = float64(figures.PaidOrders) / float64(figures.OrdersCreated) * 100
+	}
+}
+
+// fillSalesSeries returns one point per bucket in every currency that had
+// sales, adding empty points for buckets without any, so charts need no gaps filled
+func fillSalesSeries(points []*repositories.SalesPoint, starts []time.Time) []*repositories.SalesPoint {
+	byBucket := make(map[string]*repositories.SalesPoint, len(points))
+	currencies := []string{}
+	for _, point := range points {
+		key := seriesKey(point.PeriodStart, point.Currency)
+		if _, ok := byBucket[key]; !ok {
+			byBucket[key] = point
+		}
+		if !containsString(currencies, point.Currency) {
+			currencies = append(currencies, point.Currency)
+		}
+	}
+	sort.Strings(currencies)
+
+	series := make([]*repositories.SalesPoint, 0, len(starts)*len(currencies))
+	for _, start := range starts {
+		for _, currency := range currencies {
+			point, ok := byBucket[seriesKey(start, currency)]
+			if !ok {
+				point = &repositories.SalesPoint{SalesFigures: repositories.SalesFigures{Currency: currency}}
+			}
+			point.PeriodStart = start
+			fillRates(&point.SalesFigures)
+			series = append(series, point)
+		}
+	}
+	return series
+}
+
+// fillBuyerSeries returns one point per bucket, adding empty points for buckets without buyers
+func fillBuyerSeries(points []*repositories.BuyerPoint, starts []time.Time) []*repositories.BuyerPoint {
+	byBucket := make(map[int64]*repositories.BuyerPoint, len(points))
+	for _, point := range points {
+		byBucket[point.PeriodStart.Unix()] = point
+	}
+
+	series := make([]*repositories.BuyerPoint, 0, len(starts))
+	for _, start := range starts {
+		point, ok := byBucket[start.Unix()]
+		if !ok {
+			point = &repositories.BuyerPoint{}
+		}
+		point.PeriodStart = start
+		point.ReturningBuyers = point.Buyers - point.NewBuyers
+		series = append(series, point)
+	}
+	return series
+}
+
+// bucketStarts lists the start of every bucket overlapping [from, to)
+func bucketStarts(from, to time.Time, bucket repositories.AnalyticsBucket) []time.Time {
+	starts := []time.Time{}
+	for start := truncate(from, bucket); start.Before(to); start = next(start, bucket) {
+		starts = append(starts, start)
+	}
+	return starts
+}
+
+// truncate returns the start of the bucket t falls in, the way Postgres'
+// date_trunc does: weeks start on Monday
+func truncate(t time.Time, bucket repositories.AnalyticsBucket) time.Time {
+	day := startOfDay(t)
+	switch bucket {
+	case repositories.AnalyticsBucketWeek:
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case repositories.AnalyticsBucketMonth:
+		return time.Date(day.Year(), day.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return day
+	}
+}
+
+func next(start time.Time, bucket repositories.AnalyticsBucket) time.Time {
+	switch bucket {
+	case repositories.AnalyticsBucketWeek:
+		return start.AddDate(0, 0, 7)
+	case repositories.AnalyticsBucketMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
+
+func startOfDay(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+func seriesKey(start time.Time, currency string) string {
+	return fmt.Sprintf("%d:%s", start.Unix(), currency)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
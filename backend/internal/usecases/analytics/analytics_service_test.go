@@ -0,0 +1,350 @@
+package analytics
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// The fakes embed the repository interfaces, so a call the service is not
+// expected to make panics instead of passing silently.
+
+// fakeAnalytics returns rows as the postgres repository would for the seeded
+// sales, sparse and per currency, and records what it was asked for
+type fakeAnalytics struct {
+	repositories.AnalyticsRepository
+	totals      []*repositories.SalesFigures
+	points      []*repositories.SalesPoint
+	buyers      repositories.BuyerTotals
+	buyerPoints []*repositories.BuyerPoint
+	filter      repositories.AnalyticsFilter
+	bucket      repositories.AnalyticsBucket
+	breakdowns  []repositories.AnalyticsDimension
+	limits      []int
+	refreshedAt *time.Time
+}
+
+func (f *fakeAnalytics) GetSalesTotals(ctx context.Context, filter repositories.AnalyticsFilter) ([]*repositories.SalesFigures, error) {
+	f.filter = filter
+	return f.totals, nil
+}
+
+func (f *fakeAnalytics) GetSalesSeries(ctx context.Context, filter repositories.AnalyticsFilter, bucket repositories.AnalyticsBucket) ([]*repositories.SalesPoint, error) {
+	f.bucket = bucket
+	return f.points, nil
+}
+
+func (f *fakeAnalytics) GetSalesBreakdown(ctx context.Context, filter repositories.AnalyticsFilter, dimension repositories.AnalyticsDimension, limit int) ([]*repositories.SalesBreakdown, error) {
+	f.breakdowns = append(f.breakdowns, dimension)
+	f.limits = append(f.limits, limit)
+	return []*repositories.SalesBreakdown{}, nil
+}
+
+func (f *fakeAnalytics) GetBuyerTotals(ctx context.Context, filter repositories.AnalyticsFilter) (*repositories.BuyerTotals, error) {
+	f.filter = filter
+	totals := f.buyers
+	return &totals, nil
+}
+
+func (f *fakeAnalytics) GetBuyerSeries(ctx context.Context, filter repositories.AnalyticsFilter, bucket repositories.AnalyticsBucket) ([]*repositories.BuyerPoint, error) {
+	f.bucket = bucket
+	return f.buyerPoints, nil
+}
+
+func (f *fakeAnalytics) GetRefreshedAt(ctx context.Context) (*time.Time, error) {
+	return f.refreshedAt, nil
+}
+
+type fakeOrganizers struct {
+	repositories.OrganizerRepository
+	organizers map[uuid.UUID]*entities.Organizer
+}
+
+func (f *fakeOrganizers) GetByID(ctx context.Context, id uuid.UUID) (*entities.Organizer, error) {
+	organizer, ok := f.organizers[id]
+	if !ok {
+		return nil, entities.ErrNotFoundError
+	}
+	return organizer, nil
+}
+
+func newAnalyticsFixture(t *testing.T) (*AnalyticsService, *fakeAnalytics, uuid.UUID) {
+	t.Helper()
+
+	organizerID := uuid.New()
+	repo := &fakeAnalytics{}
+	service := NewAnalyticsService(repo, &fakeOrganizers{
+		organizers: map[uuid.UUID]*entities.Organizer{organizerID: {ID: organizerID}},
+	})
+	return service, repo, organizerID
+}
+
+func date(month time.Month, day int) time.Time {
+	return time.Date(2026, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func ptr(t time.Time) *time.Time { return &t }
+
+func salesPoint(start time.Time, currency string, created, paid, tickets int, revenue float64) *repositories.SalesPoint {
+	return &repositories.SalesPoint{
+		PeriodStart: start,
+		SalesFigures: repositories.SalesFigures{
+			Currency:      currency,
+			OrdersCreated: created,
+			PaidOrders:    paid,
+			TicketsSold:   tickets,
+			Revenue:       revenue,
+		},
+	}
+}
+
+func formatSeries(series []*repositories.SalesPoint) string {
+	parts := make([]string, len(series))
+	for i, point := range series {
+		parts[i] = fmt.Sprintf("%s %s created=%d paid=%d revenue=%.2f aov=%.2f conversion=%.1f",
+			point.PeriodStart.Format("01-02"), point.Currency, point.OrdersCreated, point.PaidOrders,
+			point.Revenue, point.AverageOrderValue, point.ConversionRate)
+	}
+	return strings.Join(parts, "\n")
+}
+
+func TestResolveDefaultsToLastThirtyDaysByDay(t *testing.T) {
+	service, repo, _ := newAnalyticsFixture(t)
+
+	report, err := service.GetBuyerAnalytics(context.Background(), &AnalyticsRequest{})
+	if err != nil {
+		t.Fatalf("GetBuyerAnalytics() error = %v", err)
+	}
+
+	tomorrow := startOfDay(time.Now()).AddDate(0, 0, 1)
+	if !repo.filter.To.Equal(tomorrow) || !repo.filter.From.Equal(tomorrow.AddDate(0, 0, -DefaultPeriodDays)) {
+		t.Errorf("period = %s to %s, want the %d days up to %s", repo.filter.From, repo.filter.To, DefaultPeriodDays, tomorrow)
+	}
+	if report.Bucket != repositories.AnalyticsBucketDay || repo.bucket != repositories.AnalyticsBucketDay {
+		t.Errorf("bucket = %s, want day", report.Bucket)
+	}
+	if len(report.Series) != DefaultPeriodDays {
+		t.Errorf("series has %d points, want one per day", len(report.Series))
+	}
+}
+
+func TestResolveRoundsPeriodToWholeUTCDays(t *testing.T) {
+	lagos := time.FixedZone("WAT", 3600)
+
+	tests := []struct {
+		name     string
+		from, to time.Time
+		wantFrom time.Time
+		wantTo   time.Time
+	}{
+		{
+			name:     "midnight to is exclusive",
+			from:     date(time.March, 2),
+			to:       date(time.March, 9),
+			wantFrom: date(time.March, 2),
+			wantTo:   date(time.March, 9),
+		},
+		{
+			name:     "to rounds up",
+			from:     date(time.March, 2).Add(15 * time.Hour),
+			to:       date(time.March, 8).Add(10 * time.Hour),
+			wantFrom: date(time.March, 2),
+			wantTo:   date(time.March, 9),
+		},
+		{
+			// 00:30 in Lagos is still the previous day in UTC
+			name:     "other time zones",
+			from:     time.Date(2026, time.March, 2, 0, 30, 0, 0, lagos),
+			to:       time.Date(2026, time.March, 9, 0, 30, 0, 0, lagos),
+			wantFrom: date(time.March, 1),
+			wantTo:   date(time.March, 9),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo, _ := newAnalyticsFixture(t)
+			if _, err := service.GetBuyerAnalytics(context.Background(), &AnalyticsRequest{From: ptr(tt.from), To: ptr(tt.to)}); err != nil {
+				t.Fatalf("GetBuyerAnalytics() error = %v", err)
+			}
+			if !repo.filter.From.Equal(tt.wantFrom) || !repo.filter.To.Equal(tt.wantTo) {
+				t.Errorf("period = %s to %s, want %s to %s", repo.filter.From, repo.filter.To, tt.wantFrom, tt.wantTo)
+			}
+		})
+	}
+}
+
+func TestResolveRejectsInvalidRequests(t *testing.T) {
+	service, _, organizerID := newAnalyticsFixture(t)
+	unknown := uuid.New()
+
+	tests := []struct {
+		name  string
+		req   AnalyticsRequest
+		field string
+	}{
+		{name: "bucket", req: AnalyticsRequest{Bucket: "hour"}, field: "bucket"},
+		{name: "currency", req: AnalyticsRequest{Currency: "XYZ"}, field: "currency"},
+		{name: "empty period", req: AnalyticsRequest{From: ptr(date(time.March, 9)), To: ptr(date(time.March, 9))}, field: "from"},
+		{name: "too many buckets", req: AnalyticsRequest{From: ptr(date(time.January, 1)), To: ptr(date(time.January, 1).AddDate(2, 0, 0))}, field: "bucket"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.GetSalesAnalytics(context.Background(), &tt.req)
+			validationErr, ok := err.(*entities.ValidationError)
+			if !ok || validationErr.Field != tt.field {
+				t.Errorf("GetSalesAnalytics() error = %v, want a validation error on %s", err, tt.field)
+			}
+		})
+	}
+
+	// Two years by month is fine
+	months := AnalyticsRequest{From: ptr(date(time.January, 1)), To: ptr(date(time.January, 1).AddDate(2, 0, 0)), Bucket: repositories.AnalyticsBucketMonth}
+	if _, err := service.GetSalesAnalytics(context.Background(), &months); err != nil {
+		t.Errorf("GetSalesAnalytics() by month error = %v", err)
+	}
+
+	if _, err := service.GetDashboard(context.Background(), &AnalyticsRequest{OrganizerID: &unknown}); err == nil {
+		t.Errorf("GetDashboard() accepted an unknown organizer")
+	} else if _, ok := err.(*entities.NotFoundError); !ok {
+		t.Errorf("GetDashboard() error = %v, want organizer not found", err)
+	}
+	if _, err := service.GetBuyerAnalytics(context.Background(), &AnalyticsRequest{OrganizerID: &organizerID, Currency: " ngn "}); err != nil {
+		t.Errorf("GetBuyerAnalytics() for a known organizer error = %v", err)
+	}
+}
+
+func TestGetSalesAnalyticsFillsWeeklySeriesAndRates(t *testing.T) {
+	service, repo, _ := newAnalyticsFixture(t)
+	refreshedAt := date(time.March, 25).Add(9 * time.Hour)
+	repo.refreshedAt = &refreshedAt
+	repo.totals = []*repositories.SalesFigures{
+		{Currency: "NGN", OrdersCreated: 8, PaidOrders: 6, TicketsSold: 9, Revenue: 250000},
+		{Currency: "USD", OrdersCreated: 1, PaidOrders: 1, TicketsSold: 1, Revenue: 50},
+	}
+	// Weeks without sales in a currency are missing, as from the repository
+	repo.points = []*repositories.SalesPoint{
+		salesPoint(date(time.March, 2), "NGN", 3, 2, 4, 160000),
+		salesPoint(date(time.March, 16), "NGN", 5, 4, 5, 90000),
+		salesPoint(date(time.March, 16), "USD", 1, 1, 1, 50),
+	}
+
+	// 4 March is a Wednesday, so the first bucket is the week of Monday 2 March
+	report, err := service.GetSalesAnalytics(context.Background(), &AnalyticsRequest{
+		From:   ptr(date(time.March, 4)),
+		To:     ptr(date(time.March, 25)),
+		Bucket: repositories.AnalyticsBucketWeek,
+	})
+	if err != nil {
+		t.Fatalf("GetSalesAnalytics() error = %v", err)
+	}
+
+	want := strings.Join([]string{
+		"03-02 NGN created=3 paid=2 revenue=160000.00 aov=80000.00 conversion=66.7",
+		"03-02 USD created=0 paid=0 revenue=0.00 aov=0.00 conversion=0.0",
+		"03-09 NGN created=0 paid=0 revenue=0.00 aov=0.00 conversion=0.0",
+		"03-09 USD created=0 paid=0 revenue=0.00 aov=0.00 conversion=0.0",
+		"03-16 NGN created=5 paid=4 revenue=90000.00 aov=22500.00 conversion=80.0",
+		"03-16 USD created=1 paid=1 revenue=50.00 aov=50.00 conversion=100.0",
+		"03-23 NGN created=0 paid=0 revenue=0.00 aov=0.00 conversion=0.0",
+		"03-23 USD created=0 paid=0 revenue=0.00 aov=0.00 conversion=0.0",
+	}, "\n")
+	if got := formatSeries(report.Series); got != want {
+		t.Errorf("series =\n%s\nwant\n%s", got, want)
+	}
+
+	ngn := report.Totals[0]
+	if ngn.ConversionRate != 75 || ngn.AverageOrderValue != 41666.67 {
+		t.Errorf("NGN totals conversion = %v, average = %v, want 75 and 41666.67", ngn.ConversionRate, ngn.AverageOrderValue)
+	}
+	if report.RefreshedAt == nil || !report.RefreshedAt.Equal(refreshedAt) {
+		t.Errorf("RefreshedAt = %v, want %s", report.RefreshedAt, refreshedAt)
+	}
+
+	wantDimensions := []repositories.AnalyticsDimension{
+		repositories.AnalyticsDimensionEvent,
+		repositories.AnalyticsDimensionTier,
+		repositories.AnalyticsDimensionPaymentMethod,
+		repositories.AnalyticsDimensionCity,
+	}
+	if fmt.Sprint(repo.breakdowns) != fmt.Sprint(wantDimensions) {
+		t.Errorf("breakdowns = %v, want %v", repo.breakdowns, wantDimensions)
+	}
+}
+
+func TestGetSalesAnalyticsClampsBreakdownLimit(t *testing.T) {
+	tests := []struct {
+		limit int
+		want  int
+	}{
+		{limit: 0, want: DefaultBreakdownLimit},
+		{limit: 3, want: 3},
+		{limit: 1000, want: MaxBreakdownLimit},
+	}
+	for _, tt := range tests {
+		service, repo, _ := newAnalyticsFixture(t)
+		if _, err := service.GetSalesAnalytics(context.Background(), &AnalyticsRequest{Limit: tt.limit}); err != nil {
+			t.Fatalf("GetSalesAnalytics() error = %v", err)
+		}
+		for _, limit := range repo.limits {
+			if limit != tt.want {
+				t.Errorf("limit %d became %d, want %d", tt.limit, limit, tt.want)
+			}
+		}
+	}
+}
+
+func TestGetBuyerAnalyticsSplitsNewAndReturning(t *testing.T) {
+	service, repo, _ := newAnalyticsFixture(t)
+	repo.buyers = repositories.BuyerTotals{Buyers: 5, NewBuyers: 2}
+	repo.buyerPoints = []*repositories.BuyerPoint{
+		{PeriodStart: date(time.February, 1), BuyerTotals: repositories.BuyerTotals{Buyers: 3, NewBuyers: 1}},
+		{PeriodStart: date(time.April, 1), BuyerTotals: repositories.BuyerTotals{Buyers: 2, NewBuyers: 2}},
+	}
+
+	report, err := service.GetBuyerAnalytics(context.Background(), &AnalyticsRequest{
+		From:   ptr(date(time.February, 10)),
+		To:     ptr(date(time.April, 20)),
+		Bucket: repositories.AnalyticsBucketMonth,
+	})
+	if err != nil {
+		t.Fatalf("GetBuyerAnalytics() error = %v", err)
+	}
+
+	if report.Totals.ReturningBuyers != 3 {
+		t.Errorf("returning buyers = %d, want 3", report.Totals.ReturningBuyers)
+	}
+
+	parts := make([]string, len(report.Series))
+	for i, point := range report.Series {
+		parts[i] = fmt.Sprintf("%s %d/%d/%d", point.PeriodStart.Format("01-02"), point.Buyers, point.NewBuyers, point.ReturningBuyers)
+	}
+	if got, want := strings.Join(parts, ", "), "02-01 3/1/2, 03-01 0/0/0, 04-01 2/2/0"; got != want {
+		t.Errorf("series = %s, want %s", got, want)
+	}
+}
+
+func TestTruncateMatchesDateTrunc(t *testing.T) {
+	tests := []struct {
+		at     time.Time
+		bucket repositories.AnalyticsBucket
+		want   time.Time
+	}{
+		{date(time.March, 4).Add(23 * time.Hour), repositories.AnalyticsBucketDay, date(time.March, 4)},
+		{date(time.March, 2), repositories.AnalyticsBucketWeek, date(time.March, 2)},
+		{date(time.March, 8).Add(12 * time.Hour), repositories.AnalyticsBucketWeek, date(time.March, 2)},
+		{date(time.January, 1), repositories.AnalyticsBucketWeek, time.Date(2025, time.December, 29, 0, 0, 0, 0, time.UTC)},
+		{date(time.March, 31), repositories.AnalyticsBucketMonth, date(time.March, 1)},
+	}
+	for _, tt := range tests {
+		if got := truncate(tt.at, tt.bucket); !got.Equal(tt.want) {
+			t.Errorf("truncate(%s, %s) = %s, want %s", tt.at, tt.bucket, got, tt.want)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
 	"time"
 
@@ -19,6 +20,7 @@ type AuthService struct {
 	otpRepo     repositories.OTPTokenRepository
 	jwtService  jwt.Service
 	passwordSvc security.PasswordService
+	smsService  security.SMSService
 }
 
 // NewAuthService creates a new auth service
@@ -27,12 +29,14 @@ func NewAuthService(
 	otpRepo repositories.OTPTokenRepository,
 	jwtService jwt.Service,
 	passwordSvc security.PasswordService,
+	smsService security.SMSService,
 ) *AuthService {
 	return &AuthService{
 		userRepo:    userRepo,
 		otpRepo:     otpRepo,
 		jwtService:  jwtService,
 		passwordSvc: passwordSvc,
+		smsService:  smsService,
 	}
 }
 
@@ -353,6 +357,127 @@ func (s *AuthService) ResetPassword(ctx context.Context, email, otp, newPassword
 	return nil
 }
 
+// phoneOTPExpiry is how long a phone login code is valid
+const phoneOTPExpiry = 15 * time.Minute
+
+// SendPhoneOTP texts a login code to a phone number. Any earlier pending
+// login code for the number is cancelled.
+func (s *AuthService) SendPhoneOTP(ctx context.Context, phone string) error {
+	phone, err := entities.NormalizePhoneNumber(phone)
+	if err != nil {
+		return err
+	}
+
+	// Cancel a previous pending code so only the latest one works
+	previous, err := s.otpRepo.GetActiveByPhoneAndPurpose(ctx, phone, entities.OTPPurposeLogin)
+	if err != nil && err != entities.ErrOTPTokenNotFound {
+		return fmt.Errorf("failed to get OTP token: %w", err)
+	}
+	if previous != nil {
+		previous.MarkAsCancelled()
+		if err := s.otpRepo.Update(ctx, previous); err != nil {
+			return fmt.Errorf("failed to cancel OTP token: %w", err)
+		}
+	}
+
+	code, err := security.NewOTPService().GenerateNumericOTP(6)
+	if err != nil {
+		return fmt.Errorf("failed to generate OTP: %w", err)
+	}
+
+	otpToken := entities.NewOTPToken(phone, code, entities.OTPPurposeLogin, phoneOTPExpiry)
+	if err := s.otpRepo.Create(ctx, otpToken); err != nil {
+		return fmt.Errorf("failed to save OTP token: %w", err)
+	}
+
+	if err := s.smsService.SendOTP(phone, code, security.OTPPurposeLogin); err != nil {
+		otpToken.MarkAsCancelled()
+		if updateErr := s.otpRepo.Update(ctx, otpToken); updateErr != nil {
+			fmt.Printf("Failed to cancel unsent OTP for %s: %v\n", phone, updateErr)
+		}
+		return err
+	}
+
+	return nil
+}
+
+// VerifyPhoneOTP checks a phone login code and signs the user in, creating a
+// phone-only account the first time a number logs in
+func (s *AuthService) VerifyPhoneOTP(ctx context.Context, phone, code string) (*AuthResponse, error) {
+	phone, err := entities.NormalizePhoneNumber(phone)
+	if err != nil {
+		return nil, err
+	}
+
+	otpToken, err := s.otpRepo.GetActiveByPhoneAndPurpose(ctx, phone, entities.OTPPurposeLogin)
+	if err != nil {
+		if err == entities.ErrOTPTokenNotFound {
+			return nil, entities.NewValidationError("otp", "Invalid or expired OTP")
+		}
+		return nil, fmt.Errorf("failed to get OTP token: %w", err)
+	}
+	if !otpToken.IsValid() || !otpToken.CanAttempt() {
+		return nil, entities.NewValidationError("otp", "Invalid or expired OTP")
+	}
+
+	if subtle.ConstantTimeCompare([]byte(otpToken.Code), []byte(code)) != 1 {
+		otpToken.IncrementAttempt()
+		if !otpToken.CanAttempt() {
+			otpToken.MarkAsCancelled()
+		}
+		if err := s.otpRepo.Update(ctx, otpToken); err != nil {
+			return nil, fmt.Errorf("failed to update OTP token: %w", err)
+		}
+		return nil, entities.NewValidationError("otp", "Invalid or expired OTP")
+	}
+
+	otpToken.MarkAsUsed()
+	if err := s.otpRepo.Update(ctx, otpToken); err != nil {
+		return nil, fmt.Errorf("failed to mark OTP as used: %w", err)
+	}
+
+	user, err := s.userRepo.GetByPhone(ctx, phone)
+	if err != nil {
+		if err != entities.ErrUserNotFound {
+			return nil, fmt.Errorf("failed to get user: %w", err)
+		}
+		user = entities.NewMoMoUser(phone, phone)
+		if err := s.userRepo.Create(ctx, user); err != nil {
+			if err == entities.ErrUserPhoneExists {
+				// GetByPhone skips deactivated accounts
+				return nil, entities.NewValidationError("account", "Account is deactivated")
+			}
+			return nil, fmt.Errorf("failed to create user: %w", err)
+		}
+	}
+
+	if !user.IsActive {
+		return nil, entities.NewValidationError("account", "Account is deactivated")
+	}
+
+	if err := s.userRepo.UpdateLastLogin(ctx, user.ID); err != nil {
+		// Log error but don't fail the login
+		fmt.Printf("Failed to update last login for user %s: %v\n", user.ID, err)
+	}
+
+	accessToken, err := s.jwtService.GenerateAccessToken(user.ID, "user")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+
+	refreshToken, err := s.jwtService.GenerateRefreshToken(user.ID, "user")
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return &AuthResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresIn:    3600, // 1 hour
+		User:         user,
+	}, nil
+}
+
 // generateOTP generates a 6-digit OTP
 func (s *AuthService) generateOTP() string {
 	// Simple OTP generation - in production, use crypto/rand
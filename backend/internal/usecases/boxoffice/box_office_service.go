@@ -0,0 +1,534 @@
+package boxoffice
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/infrastructure/pdf"
+	"github.com/uduxpass/backend/internal/usecases/payments"
+)
+
+// BoxOfficeService handles in-person sales to walk-in customers at the venue
+type BoxOfficeService struct {
+	boxOfficeRepo  repositories.BoxOfficeRepository
+	eventRepo      repositories.EventRepository
+	orderRepo      repositories.OrderRepository
+	orderLineRepo  repositories.OrderLineRepository
+	ticketRepo     repositories.TicketRepository
+	unitOfWork     repositories.UnitOfWork
+	paymentService *payments.PaymentService
+	pdfGenerator   *pdf.TicketPDFGenerator
+}
+
+// NewBoxOfficeService creates a new box office service
+func NewBoxOfficeService(
+	boxOfficeRepo repositories.BoxOfficeRepository,
+	eventRepo repositories.EventRepository,
+	orderRepo repositories.OrderRepository,
+	orderLineRepo repositories.OrderLineRepository,
+	ticketRepo repositories.TicketRepository,
+	unitOfWork repositories.UnitOfWork,
+	paymentService *payments.PaymentService,
+) *BoxOfficeService {
+	return &BoxOfficeService{
+		boxOfficeRepo:  boxOfficeRepo,
+		eventRepo:      eventRepo,
+		orderRepo:      orderRepo,
+		orderLineRepo:  orderLineRepo,
+		ticketRepo:     ticketRepo,
+		unitOfWork:     unitOfWork,
+		paymentService: paymentService,
+		pdfGenerator:   pdf.NewTicketPDFGenerator(),
+	}
+}
+
+// OpenShiftRequest represents the request to open a cash drawer
+type OpenShiftRequest struct {
+	EventID      uuid.UUID `json:"event_id" validate:"required"`
+	OpeningFloat float64   `json:"opening_float" validate:"gte=0"`
+	OperatorID   uuid.UUID `json:"-"`
+}
+
+// OpenShift opens a cash drawer for the operator. An operator can only hold one open shift.
+func (s *BoxOfficeService) OpenShift(ctx context.Context, req *OpenShiftRequest) (*entities.BoxOfficeShift, error) {
+	event, err := s.eventRepo.GetByID(ctx, req.EventID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("event", "event not found")
+	}
+
+	currency := event.Currency
+	if currency == "" {
+		currency = entities.DefaultCurrency
+	}
+
+	shift := entities.NewBoxOfficeShift(event.ID, req.OperatorID, currency, req.OpeningFloat)
+	if err := shift.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.boxOfficeRepo.CreateShift(ctx, shift); err != nil {
+		if err == entities.ErrBoxOfficeShiftOpen {
+			return nil, entities.NewConflictError("box_office_shift", "operator already has an open shift", nil)
+		}
+		return nil, err
+	}
+
+	return shift, nil
+}
+
+// GetCurrentShift retrieves the operator's open shift
+func (s *BoxOfficeService) GetCurrentShift(ctx context.Context, operatorID uuid.UUID) (*entities.BoxOfficeShift, error) {
+	shift, err := s.boxOfficeRepo.GetOpenShiftByOperator(ctx, operatorID)
+	if err != nil {
+		if err == entities.ErrBoxOfficeShiftNotFound {
+			return nil, entities.NewNotFoundError("box_office_shift", "no open shift for this operator")
+		}
+		return nil, err
+	}
+	return shift, nil
+}
+
+// SaleLineRequest represents one ticket tier in a box office sale
+type SaleLineRequest struct {
+	TicketTierID uuid.UUID `json:"ticket_tier_id" validate:"required"`
+	Quantity     int       `json:"quantity" validate:"required,min=1,max=50"`
+}
+
+// SaleCustomer represents the optional details of a walk-in customer
+type SaleCustomer struct {
+	FirstName string `json:"first_name"`
+	LastName  string `json:"last_name"`
+	Email     string `json:"email" validate:"omitempty,email"`
+	Phone     string `json:"phone"`
+}
+
+// CreateSaleRequest represents a walk-in sale taken at the box office
+type CreateSaleRequest struct {
+	Lines             []SaleLineRequest      `json:"lines" validate:"required,min=1,dive"`
+	PaymentMethod     entities.PaymentMethod `json:"payment_method" validate:"required"`
+	AmountTendered    *float64               `json:"amount_tendered,omitempty"`
+	TerminalReference *string                `json:"terminal_reference,omitempty"`
+	Customer          SaleCustomer           `json:"customer"`
+	OperatorID        uuid.UUID              `json:"-"`
+}
+
+// CreateSaleResponse represents a completed box office sale
+type CreateSaleResponse struct {
+	Order      *entities.Order         `json:"order"`
+	OrderLines []*entities.OrderLine   `json:"order_lines"`
+	Sale       *entities.BoxOfficeSale `json:"sale"`
+	Tickets    []*entities.Ticket      `json:"tickets"`
+	Emailed    bool                    `json:"emailed"`
+}
+
+// CreateSale sells tickets to a walk-in customer against the operator's open shift.
+// The order is created already paid and tickets are issued in the same transaction.
+func (s *BoxOfficeService) CreateSale(ctx context.Context, req *CreateSaleRequest) (*CreateSaleResponse, error) {
+	if !req.PaymentMethod.IsOffline() {
+		return nil, entities.NewValidationError("payment_method", "box office sales must be paid by cash or POS terminal")
+	}
+
+	shift, err := s.GetCurrentShift(ctx, req.OperatorID)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, shift.EventID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("event", "event not found")
+	}
+	if event.Status != entities.EventStatusPublished && event.Status != entities.EventStatusOnSale {
+		return nil, entities.NewBusinessRuleError("event_not_active", "event is not on sale", nil)
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	order := entities.NewOrder(event.ID.String(), req.Customer.Email)
+	order.Currency = shift.Currency
+	order.CustomerFirstName = req.Customer.FirstName
+	order.CustomerLastName = req.Customer.LastName
+	order.CustomerEmail = req.Customer.Email
+	order.CustomerPhone = req.Customer.Phone
+
+	if err := tx.Orders().Create(tx.Context(), order); err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	var orderLines []*entities.OrderLine
+	var totalAmount float64
+	var ticketCount int
+
+	// Lock every tier before checking availability, so a concurrent box office
+	// or online sale of the same tier waits for this one to commit
+	tierIDs := make([]uuid.UUID, 0, len(req.Lines))
+	for _, line := range req.Lines {
+		tierIDs = append(tierIDs, line.TicketTierID)
+	}
+	tiers, err := tx.TicketTiers().LockForUpdate(tx.Context(), tierIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket tier: %w", err)
+	}
+
+	for _, line := range req.Lines {
+		tier := tiers[line.TicketTierID]
+		if tier.EventID != event.ID {
+			return nil, entities.NewValidationError("ticket_tier_id", "ticket tier does not belong to this event")
+		}
+		if entities.NormalizeCurrency(tier.Currency) != shift.Currency {
+			return nil, entities.NewBusinessRuleError("mixed_currency", "ticket tier is not priced in the shift currency", map[string]interface{}{
+				"shift_currency": shift.Currency,
+				"tier_currency":  tier.Currency,
+				"ticket_tier_id": tier.ID,
+			})
+		}
+
+		available, err := tx.TicketTiers().GetAvailableQuantity(tx.Context(), tier.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check availability: %w", err)
+		}
+		if available < line.Quantity {
+			return nil, entities.NewBusinessRuleError("insufficient_tickets", "not enough tickets available", map[string]interface{}{
+				"ticket_tier_id": tier.ID,
+				"available":      available,
+			})
+		}
+
+		orderLine := entities.NewOrderLine(order.ID, tier.ID, line.Quantity, tier.Price)
+		if err := tx.OrderLines().Create(tx.Context(), orderLine); err != nil {
+			return nil, fmt.Errorf("failed to create order line: %w", err)
+		}
+
+		orderLines = append(orderLines, orderLine)
+		totalAmount += float64(line.Quantity) * tier.Price
+		ticketCount += line.Quantity
+	}
+
+	// Record the in-person payment as already settled
+	sale := entities.NewBoxOfficeSale(shift, order.ID, req.PaymentMethod, totalAmount)
+	sale.TicketCount = ticketCount
+	sale.TerminalReference = req.TerminalReference
+	if req.PaymentMethod == entities.PaymentMethodCash {
+		tendered := totalAmount
+		if req.AmountTendered != nil {
+			tendered = *req.AmountTendered
+		}
+		if err := sale.SetCashTendered(tendered); err != nil {
+			return nil, err
+		}
+	}
+	if err := sale.Validate(); err != nil {
+		return nil, err
+	}
+
+	payment := entities.NewPayment(order.ID, req.PaymentMethod, totalAmount, order.Currency)
+	if req.TerminalReference != nil {
+		payment.SetProviderTransactionID(*req.TerminalReference)
+	}
+	payment.UpdateProviderResponse(map[string]interface{}{
+		"channel":     "box_office",
+		"shift_id":    shift.ID.String(),
+		"operator_id": shift.OperatorID.String(),
+	})
+	if err := payment.MarkCompleted(); err != nil {
+		return nil, err
+	}
+	if err := tx.Payments().Create(tx.Context(), payment); err != nil {
+		return nil, fmt.Errorf("failed to create payment: %w", err)
+	}
+
+	now := time.Now()
+	method := req.PaymentMethod
+	order.TotalAmount = totalAmount
+	order.PaymentMethod = &method
+	order.PaymentReference = req.TerminalReference
+	order.PaidAt = &now
+	order.MarkPaid()
+	if err := tx.Orders().Update(tx.Context(), order); err != nil {
+		return nil, fmt.Errorf("failed to update order: %w", err)
+	}
+
+	// Walk-in customers who leave an email address also get their tickets by email
+	tickets, err := s.paymentService.IssueTickets(tx.Context(), tx, order, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue tickets: %w", err)
+	}
+
+	if err := tx.BoxOffice().CreateSale(tx.Context(), sale); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	emailed := order.CustomerEmail != ""
+
+	return &CreateSaleResponse{
+		Order:      order,
+		OrderLines: orderLines,
+		Sale:       sale,
+		Tickets:    tickets,
+		Emailed:    emailed,
+	}, nil
+}
+
+// EmailTicketsRequest represents the request to email box office tickets after the sale
+type EmailTicketsRequest struct {
+	OrderID uuid.UUID `json:"-"`
+	Email   string    `json:"email" validate:"required,email"`
+}
+
+// EmailTickets emails the tickets of a box office order to the given address
+func (s *BoxOfficeService) EmailTickets(ctx context.Context, req *EmailTicketsRequest) error {
+	order, tickets, err := s.getBoxOfficeOrder(ctx, req.OrderID)
+	if err != nil {
+		return err
+	}
+
+	if order.CustomerEmail != req.Email {
+		order.CustomerEmail = req.Email
+		if err := s.orderRepo.Update(ctx, order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+	}
+
+	return s.paymentService.SendTicketEmail(ctx, order, tickets)
+}
+
+// PrintableTicket represents a rendered ticket ready to print
+type PrintableTicket struct {
+	TicketID     uuid.UUID
+	SerialNumber string
+	Filename     string
+	PDF          []byte
+}
+
+// GetTicketPDF renders one ticket of a box office order for printing
+func (s *BoxOfficeService) GetTicketPDF(ctx context.Context, orderID, ticketID uuid.UUID) (*PrintableTicket, error) {
+	order, tickets, err := s.getBoxOfficeOrder(ctx, orderID)
+	if err != nil {
+		return nil, err
+	}
+
+	ticketNumber := 0
+	var ticket *entities.Ticket
+	for i, t := range tickets {
+		if t.ID == ticketID {
+			ticket = t
+			ticketNumber = i + 1
+			break
+		}
+	}
+	if ticket == nil {
+		return nil, entities.NewNotFoundError("ticket", "ticket not found on this order")
+	}
+
+	eventID, err := uuid.Parse(order.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID on order %s: %w", order.Code, err)
+	}
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("event", "event not found")
+	}
+
+	orderLines, err := s.orderLineRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order lines: %w", err)
+	}
+	var tierName string
+	var price float64
+	for _, line := range orderLines {
+		if line.ID == ticket.OrderLineID {
+			price = line.UnitPrice
+			if line.TicketTier != nil {
+				tierName = line.TicketTier.Name
+			}
+			break
+		}
+	}
+
+	pdfBytes, err := s.pdfGenerator.GenerateTicketPDF(pdf.TicketData{
+		TicketID:      ticket.ID.String(),
+		QRCode:        ticket.SerialNumber,
+		EventName:     event.Name,
+		EventDate:     event.EventDate,
+		VenueName:     event.VenueName,
+		VenueAddress:  event.VenueAddress,
+		TierName:      tierName,
+		Price:         price,
+		CustomerName:  order.CustomerFirstName + " " + order.CustomerLastName,
+		CustomerEmail: order.CustomerEmail,
+		OrderID:       order.Code,
+		TicketNumber:  ticketNumber,
+		TotalTickets:  len(tickets),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ticket PDF: %w", err)
+	}
+
+	return &PrintableTicket{
+		TicketID:     ticket.ID,
+		SerialNumber: ticket.SerialNumber,
+		Filename:     fmt.Sprintf("ticket_%s.pdf", ticket.SerialNumber),
+		PDF:          pdfBytes,
+	}, nil
+}
+
+// getBoxOfficeOrder loads an order sold at the box office along with its tickets
+func (s *BoxOfficeService) getBoxOfficeOrder(ctx context.Context, orderID uuid.UUID) (*entities.Order, []*entities.Ticket, error) {
+	if _, err := s.boxOfficeRepo.GetSaleByOrder(ctx, orderID); err != nil {
+		return nil, nil, err
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, nil, entities.NewNotFoundError("order", "order not found")
+	}
+
+	tickets, err := s.ticketRepo.GetByOrder(ctx, orderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get tickets: %w", err)
+	}
+
+	return order, tickets, nil
+}
+
+// CloseShiftRequest represents the request to close out a cash drawer
+type CloseShiftRequest struct {
+	ShiftID     uuid.UUID `json:"-"`
+	CountedCash float64   `json:"counted_cash" validate:"gte=0"`
+	Notes       *string   `json:"notes,omitempty"`
+	ClosedBy    uuid.UUID          `json:"-"`
+	CallerRole  entities.AdminRole `json:"-"`
+}
+
+// ShiftReport represents the reconciliation of a cash drawer session
+type ShiftReport struct {
+	Shift        *entities.BoxOfficeShift              `json:"shift"`
+	Totals       []*repositories.BoxOfficePaymentTotal `json:"totals"`
+	SalesCount   int                                   `json:"sales_count"`
+	TicketsSold  int                                   `json:"tickets_sold"`
+	CashSales    float64                               `json:"cash_sales"`
+	POSSales     float64                               `json:"pos_sales"`
+	TotalSales   float64                               `json:"total_sales"`
+	ExpectedCash float64                               `json:"expected_cash"`
+	Sales        []*entities.BoxOfficeSale             `json:"sales,omitempty"`
+}
+
+// CloseShift closes a cash drawer and reconciles the counted cash against recorded sales
+func (s *BoxOfficeService) CloseShift(ctx context.Context, req *CloseShiftRequest) (*ShiftReport, error) {
+	shift, err := s.getShift(ctx, req.ShiftID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkShiftAccess(shift, req.ClosedBy, req.CallerRole); err != nil {
+		return nil, err
+	}
+
+	report, err := s.buildShiftReport(ctx, shift, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := shift.Close(report.CashSales, req.CountedCash, req.ClosedBy, req.Notes); err != nil {
+		return nil, err
+	}
+
+	if err := s.boxOfficeRepo.UpdateShift(ctx, shift); err != nil {
+		return nil, err
+	}
+
+	return report, nil
+}
+
+// GetShiftReport builds the reconciliation report for a shift, open or closed.
+// Only the shift's operator or a manager can see it.
+func (s *BoxOfficeService) GetShiftReport(ctx context.Context, shiftID, callerID uuid.UUID, callerRole entities.AdminRole, includeSales bool) (*ShiftReport, error) {
+	shift, err := s.getShift(ctx, shiftID)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkShiftAccess(shift, callerID, callerRole); err != nil {
+		return nil, err
+	}
+	return s.buildShiftReport(ctx, shift, includeSales)
+}
+
+// ListShifts retrieves shifts with filtering and pagination
+func (s *BoxOfficeService) ListShifts(ctx context.Context, filter repositories.BoxOfficeShiftFilter) ([]*entities.BoxOfficeShift, *repositories.PaginationResult, error) {
+	return s.boxOfficeRepo.ListShifts(ctx, filter)
+}
+
+func (s *BoxOfficeService) getShift(ctx context.Context, shiftID uuid.UUID) (*entities.BoxOfficeShift, error) {
+	shift, err := s.boxOfficeRepo.GetShiftByID(ctx, shiftID)
+	if err != nil {
+		if err == entities.ErrBoxOfficeShiftNotFound {
+			return nil, entities.NewNotFoundError("box_office_shift", "shift not found")
+		}
+		return nil, err
+	}
+	return shift, nil
+}
+
+// checkShiftAccess allows the shift's own operator and managers, who oversee
+// every drawer, to report on or close out a shift
+func checkShiftAccess(shift *entities.BoxOfficeShift, callerID uuid.UUID, callerRole entities.AdminRole) error {
+	if shift.OperatorID == callerID {
+		return nil
+	}
+	switch callerRole {
+	case entities.AdminRoleSuperAdmin, entities.AdminRoleAdmin, entities.AdminRoleEventManager:
+		return nil
+	}
+	return entities.NewBusinessRuleError("shift_not_owned", "only the shift's operator or a manager can access this shift", map[string]interface{}{
+		"shift_id": shift.ID,
+	})
+}
+
+// buildShiftReport totals a shift's sales per payment method. The report shares
+// the shift pointer, so a caller closing the shift sees the final variance.
+func (s *BoxOfficeService) buildShiftReport(ctx context.Context, shift *entities.BoxOfficeShift, includeSales bool) (*ShiftReport, error) {
+	totals, err := s.boxOfficeRepo.GetShiftTotals(ctx, shift.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &ShiftReport{
+		Shift:  shift,
+		Totals: totals,
+	}
+	for _, total := range totals {
+		report.SalesCount += total.Sales
+		report.TicketsSold += total.Tickets
+		report.TotalSales += total.Amount
+		switch total.PaymentMethod {
+		case entities.PaymentMethodCash:
+			report.CashSales += total.Amount
+		case entities.PaymentMethodPOS:
+			report.POSSales += total.Amount
+		}
+	}
+	report.ExpectedCash = shift.OpeningFloat + report.CashSales
+	if shift.ExpectedCash != nil {
+		report.ExpectedCash = *shift.ExpectedCash
+	}
+
+	if includeSales {
+		sales, err := s.boxOfficeRepo.GetSalesByShift(ctx, shift.ID)
+		if err != nil {
+			return nil, err
+		}
+		report.Sales = sales
+	}
+
+	return report, nil
+}
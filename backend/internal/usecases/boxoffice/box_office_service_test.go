@@ -0,0 +1,340 @@
+package boxoffice
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	infrapayments "github.com/uduxpass/backend/internal/infrastructure/payments"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/payments"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+type fakeBoxOffice struct {
+	repositories.BoxOfficeRepository
+	shift *entities.BoxOfficeShift
+	sales []*entities.BoxOfficeSale
+}
+
+func (f *fakeBoxOffice) GetOpenShiftByOperator(ctx context.Context, operatorID uuid.UUID) (*entities.BoxOfficeShift, error) {
+	if f.shift == nil || f.shift.OperatorID != operatorID {
+		return nil, entities.ErrBoxOfficeShiftNotFound
+	}
+	return f.shift, nil
+}
+
+func (f *fakeBoxOffice) CreateSale(ctx context.Context, sale *entities.BoxOfficeSale) error {
+	f.sales = append(f.sales, sale)
+	return nil
+}
+
+type fakeEvents struct {
+	repositories.EventRepository
+	event *entities.Event
+}
+
+func (f *fakeEvents) GetByID(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	if id != f.event.ID {
+		return nil, entities.ErrEventNotFound
+	}
+	return f.event, nil
+}
+
+type fakeOrders struct {
+	repositories.OrderRepository
+	orders map[uuid.UUID]*entities.Order
+}
+
+func (f *fakeOrders) Create(ctx context.Context, order *entities.Order) error {
+	f.orders[order.ID] = order
+	return nil
+}
+
+func (f *fakeOrders) Update(ctx context.Context, order *entities.Order) error {
+	f.orders[order.ID] = order
+	return nil
+}
+
+type fakeOrderLines struct {
+	repositories.OrderLineRepository
+	lines []*entities.OrderLine
+}
+
+func (f *fakeOrderLines) Create(ctx context.Context, line *entities.OrderLine) error {
+	f.lines = append(f.lines, line)
+	return nil
+}
+
+func (f *fakeOrderLines) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderLine, error) {
+	var lines []*entities.OrderLine
+	for _, line := range f.lines {
+		if line.OrderID == orderID {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// fakeTiers notes availability checked on a tier that is not locked, which a
+// concurrent sale could change before the box office sale commits
+type fakeTiers struct {
+	repositories.TicketTierRepository
+	tiers     map[uuid.UUID]*entities.TicketTier
+	available map[uuid.UUID]int
+	sold      map[uuid.UUID]int
+	locked    map[uuid.UUID]bool
+	unlocked  bool
+}
+
+func (f *fakeTiers) GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
+	tier, ok := f.tiers[id]
+	if !ok {
+		return nil, entities.ErrNotFoundError
+	}
+	return tier, nil
+}
+
+func (f *fakeTiers) LockForUpdate(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.TicketTier, error) {
+	tiers := make(map[uuid.UUID]*entities.TicketTier, len(ids))
+	for _, id := range ids {
+		tier, err := f.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		f.locked[id] = true
+		tiers[id] = tier
+	}
+	return tiers, nil
+}
+
+func (f *fakeTiers) GetAvailableQuantity(ctx context.Context, id uuid.UUID) (int, error) {
+	if !f.locked[id] {
+		f.unlocked = true
+	}
+	return f.available[id], nil
+}
+
+func (f *fakeTiers) IncrementSold(ctx context.Context, id uuid.UUID, quantity int) error {
+	f.sold[id] += quantity
+	return nil
+}
+
+type fakePayments struct {
+	repositories.PaymentRepository
+	payments []*entities.Payment
+}
+
+func (f *fakePayments) Create(ctx context.Context, payment *entities.Payment) error {
+	f.payments = append(f.payments, payment)
+	return nil
+}
+
+type fakeTickets struct {
+	repositories.TicketRepository
+	tickets []*entities.Ticket
+}
+
+func (f *fakeTickets) CreateBatch(ctx context.Context, tickets []*entities.Ticket) error {
+	f.tickets = append(f.tickets, tickets...)
+	return nil
+}
+
+type fakeOutbox struct {
+	repositories.OutboxRepository
+	messages []*entities.OutboxMessage
+}
+
+func (f *fakeOutbox) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	ctx        context.Context
+	boxOffice  *fakeBoxOffice
+	orders     *fakeOrders
+	orderLines *fakeOrderLines
+	tiers      *fakeTiers
+	payments   *fakePayments
+	tickets    *fakeTickets
+	outbox     *fakeOutbox
+	committed  bool
+}
+
+func (tx *fakeTx) Commit() error                                  { tx.committed = true; return nil }
+func (tx *fakeTx) Rollback() error                                { return nil }
+func (tx *fakeTx) Context() context.Context                       { return tx.ctx }
+func (tx *fakeTx) BoxOffice() repositories.BoxOfficeRepository    { return tx.boxOffice }
+func (tx *fakeTx) Orders() repositories.OrderRepository           { return tx.orders }
+func (tx *fakeTx) OrderLines() repositories.OrderLineRepository   { return tx.orderLines }
+func (tx *fakeTx) TicketTiers() repositories.TicketTierRepository { return tx.tiers }
+func (tx *fakeTx) Payments() repositories.PaymentRepository       { return tx.payments }
+func (tx *fakeTx) Tickets() repositories.TicketRepository         { return tx.tickets }
+func (tx *fakeTx) Outbox() repositories.OutboxRepository          { return tx.outbox }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	u.tx.ctx = ctx
+	return u.tx, nil
+}
+
+type boxOfficeFixture struct {
+	service  *BoxOfficeService
+	tx       *fakeTx
+	event    *entities.Event
+	shift    *entities.BoxOfficeShift
+	regular  *entities.TicketTier
+	vip      *entities.TicketTier
+	operator uuid.UUID
+}
+
+// newBoxOfficeFixture builds a box office service over fakes, with an
+// operator's open shift at an event on sale and two of its tiers
+func newBoxOfficeFixture(t *testing.T) *boxOfficeFixture {
+	t.Helper()
+
+	event := entities.NewEvent(uuid.New(), "Afrobeats Live", "afrobeats-live", time.Now().Add(30*24*time.Hour), "Eko Hotel", "Victoria Island", "Lagos", "NG")
+	event.Status = entities.EventStatusOnSale
+	event.Currency = entities.DefaultCurrency
+
+	regular := entities.NewTicketTier(event.ID, "Regular", 10000)
+	vip := entities.NewTicketTier(event.ID, "VIP", 50000)
+	operator := uuid.New()
+	shift := entities.NewBoxOfficeShift(event.ID, operator, entities.DefaultCurrency, 20000)
+
+	boxOffice := &fakeBoxOffice{shift: shift}
+	orderLines := &fakeOrderLines{}
+	tickets := &fakeTickets{}
+	tx := &fakeTx{
+		boxOffice:  boxOffice,
+		orders:     &fakeOrders{orders: make(map[uuid.UUID]*entities.Order)},
+		orderLines: orderLines,
+		tiers: &fakeTiers{
+			tiers:     map[uuid.UUID]*entities.TicketTier{regular.ID: regular, vip.ID: vip},
+			available: map[uuid.UUID]int{regular.ID: 10, vip.ID: 2},
+			sold:      make(map[uuid.UUID]int),
+			locked:    make(map[uuid.UUID]bool),
+		},
+		payments: &fakePayments{},
+		tickets:  tickets,
+		outbox:   &fakeOutbox{},
+	}
+
+	bus := eventbus.NewBus(nil, nil)
+	paymentService := payments.NewPaymentService(
+		nil, nil, orderLines, tickets, nil, nil,
+		infrapayments.MoMoProvider{}, infrapayments.PaystackProvider{},
+		nil, nil, bus, "test-secret",
+	)
+
+	service := NewBoxOfficeService(boxOffice, &fakeEvents{event: event}, nil, orderLines, tickets, &fakeUnitOfWork{tx: tx}, paymentService)
+
+	return &boxOfficeFixture{
+		service:  service,
+		tx:       tx,
+		event:    event,
+		shift:    shift,
+		regular:  regular,
+		vip:      vip,
+		operator: operator,
+	}
+}
+
+func TestCreateSaleLocksTiersAndIssuesPaidTickets(t *testing.T) {
+	f := newBoxOfficeFixture(t)
+	tendered := 80000.0
+
+	resp, err := f.service.CreateSale(context.Background(), &CreateSaleRequest{
+		Lines: []SaleLineRequest{
+			{TicketTierID: f.vip.ID, Quantity: 1},
+			{TicketTierID: f.regular.ID, Quantity: 2},
+		},
+		PaymentMethod:  entities.PaymentMethodCash,
+		AmountTendered: &tendered,
+		OperatorID:     f.operator,
+	})
+	if err != nil {
+		t.Fatalf("CreateSale() error = %v", err)
+	}
+
+	if !f.tx.tiers.locked[f.regular.ID] || !f.tx.tiers.locked[f.vip.ID] {
+		t.Errorf("locked tiers %v, want both of the sale's tiers", f.tx.tiers.locked)
+	}
+	if f.tx.tiers.unlocked {
+		t.Errorf("availability was checked before the tier was locked")
+	}
+	if !f.tx.committed {
+		t.Fatalf("sale was not committed")
+	}
+
+	if resp.Order.Status != entities.OrderStatusPaid || resp.Order.TotalAmount != 70000 {
+		t.Errorf("order = %s for %v, want paid for 70000", resp.Order.Status, resp.Order.TotalAmount)
+	}
+	if len(resp.Tickets) != 3 || f.tx.tiers.sold[f.regular.ID] != 2 || f.tx.tiers.sold[f.vip.ID] != 1 {
+		t.Errorf("issued %d tickets, sold %v, want 2 Regular and 1 VIP", len(resp.Tickets), f.tx.tiers.sold)
+	}
+	if resp.Sale.ChangeGiven == nil || *resp.Sale.ChangeGiven != 10000 {
+		t.Errorf("change given = %v, want 10000", resp.Sale.ChangeGiven)
+	}
+	if len(f.tx.boxOffice.sales) != 1 || len(f.tx.payments.payments) != 1 {
+		t.Errorf("recorded %d sales and %d payments, want 1 of each", len(f.tx.boxOffice.sales), len(f.tx.payments.payments))
+	}
+}
+
+func TestCreateSaleRejectsMoreThanAvailable(t *testing.T) {
+	f := newBoxOfficeFixture(t)
+
+	_, err := f.service.CreateSale(context.Background(), &CreateSaleRequest{
+		Lines:         []SaleLineRequest{{TicketTierID: f.vip.ID, Quantity: 3}},
+		PaymentMethod: entities.PaymentMethodPOS,
+		OperatorID:    f.operator,
+	})
+	ruleErr, ok := err.(*entities.BusinessRuleError)
+	if !ok || ruleErr.Rule != "insufficient_tickets" {
+		t.Fatalf("CreateSale() error = %v, want insufficient_tickets", err)
+	}
+	if f.tx.committed || len(f.tx.tickets.tickets) != 0 {
+		t.Errorf("a rejected sale must not be committed or issue tickets")
+	}
+}
+
+func TestCreateSaleRequiresEventOnSale(t *testing.T) {
+	tests := []struct {
+		status entities.EventStatus
+		ok     bool
+	}{
+		{status: entities.EventStatusPublished, ok: true},
+		{status: entities.EventStatusOnSale, ok: true},
+		{status: entities.EventStatusDraft},
+		{status: entities.EventStatusCancelled},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			f := newBoxOfficeFixture(t)
+			f.event.Status = tt.status
+
+			_, err := f.service.CreateSale(context.Background(), &CreateSaleRequest{
+				Lines:         []SaleLineRequest{{TicketTierID: f.regular.ID, Quantity: 1}},
+				PaymentMethod: entities.PaymentMethodCash,
+				OperatorID:    f.operator,
+			})
+			if tt.ok && err != nil {
+				t.Fatalf("CreateSale() error = %v", err)
+			}
+			if !tt.ok && err == nil {
+				t.Fatalf("CreateSale() sold tickets for a %s event", tt.status)
+			}
+		})
+	}
+}
@@ -0,0 +1,602 @@
+package campaigns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+	"github.com/uduxpass/backend/internal/usecases/notifications"
+)
+
+const (
+	// DefaultBatchSize is the number of ticket holders messaged per batch
+	DefaultBatchSize = 100
+
+	// DefaultSchedulerInterval is how often the scheduler looks for due campaigns
+	DefaultSchedulerInterval = time.Minute
+
+	// defaultBatchPause spaces batches out to stay within provider rate limits
+	defaultBatchPause = time.Second
+
+	// staleAfter is how long a processing campaign may go without progress
+	// before the scheduler takes it over, e.g. after a crash
+	staleAfter = 10 * time.Minute
+
+	// dueLimit caps the campaigns picked up per scheduler run
+	dueLimit = 20
+)
+
+// Default campaign offsets, overridden with CAMPAIGN_DEFAULT_REMINDER_MINUTES and CAMPAIGN_DEFAULT_FOLLOW_UP_MINUTES
+const (
+	DefaultReminderMinutes = 24 * 60
+	DefaultFollowUpMinutes = 24 * 60
+)
+
+// WhatsApp template defaults, overridden with WHATSAPP_REMINDER_TEMPLATE and WHATSAPP_FOLLOW_UP_TEMPLATE
+const (
+	DefaultWhatsAppReminderTemplate = "event_reminder"
+	DefaultWhatsAppFollowUpTemplate = "event_follow_up"
+)
+
+// Config sets up default campaigns and the WhatsApp templates campaigns are
+// sent with. A default offset of 0 disables that default campaign. The
+// reminder template has five body parameters (first name, event name, date,
+// venue, doors-open time); the follow-up template has three (first name,
+// event name, a survey or event link).
+type Config struct {
+	DefaultReminderMinutes   int
+	DefaultFollowUpMinutes   int
+	WhatsAppReminderTemplate string
+	WhatsAppFollowUpTemplate string
+}
+
+// CampaignService schedules reminders before and follow-ups after events. A
+// scheduler picks campaigns up as they come due, stages one delivery per
+// ticket holder and sends them in batches, each through the channel the
+// holder prefers. Every delivery records its outcome, so a scheduler that
+// stops partway resumes without messaging anyone twice.
+type CampaignService struct {
+	campaignRepo    repositories.EventCampaignRepository
+	eventRepo       repositories.EventRepository
+	userRepo        repositories.UserRepository
+	unitOfWork      repositories.UnitOfWork
+	emailService    services.EmailService
+	smsService      *notifications.SMSService
+	whatsAppService *notifications.WhatsAppService
+	config          Config
+	batchSize       int
+	batchPause      time.Duration
+}
+
+// NewCampaignService creates a new campaign service. Empty WhatsApp template names take the defaults.
+func NewCampaignService(
+	campaignRepo repositories.EventCampaignRepository,
+	eventRepo repositories.EventRepository,
+	userRepo repositories.UserRepository,
+	unitOfWork repositories.UnitOfWork,
+	emailService services.EmailService,
+	smsService *notifications.SMSService,
+	whatsAppService *notifications.WhatsAppService,
+	config Config,
+) *CampaignService {
+	if config.WhatsAppReminderTemplate == "" {
+		config.WhatsAppReminderTemplate = DefaultWhatsAppReminderTemplate
+	}
+	if config.WhatsAppFollowUpTemplate == "" {
+		config.WhatsAppFollowUpTemplate = DefaultWhatsAppFollowUpTemplate
+	}
+	return &CampaignService{
+		campaignRepo:    campaignRepo,
+		eventRepo:       eventRepo,
+		userRepo:        userRepo,
+		unitOfWork:      unitOfWork,
+		emailService:    emailService,
+		smsService:      smsService,
+		whatsAppService: whatsAppService,
+		config:          config,
+		batchSize:       DefaultBatchSize,
+		batchPause:      defaultBatchPause,
+	}
+}
+
+// CreateCampaignRequest represents the request to schedule a campaign for an event
+type CreateCampaignRequest struct {
+	Type          entities.CampaignType `json:"type" validate:"required,oneof=reminder follow_up"`
+	OffsetMinutes int                   `json:"offset_minutes" validate:"min=0"`
+	Message       *string               `json:"message,omitempty"`
+	SurveyURL     *string               `json:"survey_url,omitempty"`
+	CreatedBy     *uuid.UUID            `json:"-"`
+}
+
+// UpdateCampaignRequest represents changes to a campaign that has not been sent. Omitted fields are left unchanged.
+type UpdateCampaignRequest struct {
+	OffsetMinutes *int    `json:"offset_minutes,omitempty" validate:"omitempty,min=0"`
+	Message       *string `json:"message,omitempty"`
+	SurveyURL     *string `json:"survey_url,omitempty"`
+}
+
+// CreateCampaign schedules a reminder or follow-up for an event. A campaign
+// whose send time has already passed goes out on the scheduler's next run.
+func (s *CampaignService) CreateCampaign(ctx context.Context, eventID uuid.UUID, req *CreateCampaignRequest) (*entities.EventCampaign, error) {
+	event, err := s.getEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.Status == entities.EventStatusCancelled {
+		return nil, entities.NewBusinessRuleError("event_cancelled", "event has been cancelled", nil)
+	}
+	if req.Type == entities.CampaignReminder && !time.Now().Before(event.EventDate) {
+		return nil, entities.NewBusinessRuleError("event_started", "reminders can only be scheduled before the event starts", nil)
+	}
+
+	campaign := entities.NewEventCampaign(event, req.Type, req.OffsetMinutes)
+	campaign.Message = optionalText(req.Message)
+	campaign.SurveyURL = optionalText(req.SurveyURL)
+	campaign.CreatedBy = req.CreatedBy
+	if err := campaign.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.campaignRepo.Create(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// ListCampaigns retrieves an event's campaigns in send order
+func (s *CampaignService) ListCampaigns(ctx context.Context, eventID uuid.UUID) ([]*entities.EventCampaign, error) {
+	if _, err := s.getEvent(ctx, eventID); err != nil {
+		return nil, err
+	}
+	return s.campaignRepo.ListByEvent(ctx, eventID)
+}
+
+// GetCampaign retrieves a campaign and its progress counters
+func (s *CampaignService) GetCampaign(ctx context.Context, campaignID uuid.UUID) (*entities.EventCampaign, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, campaignID)
+	if err != nil {
+		if errors.Is(err, entities.ErrEventCampaignNotFound) {
+			return nil, entities.NewNotFoundError("event_campaign", "event campaign not found")
+		}
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// UpdateCampaign changes the offset or content of a campaign that has not been sent
+func (s *CampaignService) UpdateCampaign(ctx context.Context, campaignID uuid.UUID, req *UpdateCampaignRequest) (*entities.EventCampaign, error) {
+	campaign, err := s.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if !campaign.IsEditable() {
+		return nil, entities.NewBusinessRuleError("campaign_not_editable", "only scheduled campaigns can be changed", map[string]interface{}{
+			"status": campaign.Status,
+		})
+	}
+
+	event, err := s.getEvent(ctx, campaign.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.OffsetMinutes != nil {
+		campaign.OffsetMinutes = *req.OffsetMinutes
+	}
+	if req.Message != nil {
+		campaign.Message = optionalText(req.Message)
+	}
+	if req.SurveyURL != nil {
+		campaign.SurveyURL = optionalText(req.SurveyURL)
+	}
+	campaign.UpdatedAt = time.Now()
+	if err := campaign.Validate(); err != nil {
+		return nil, err
+	}
+	campaign.Schedule(event)
+
+	if err := s.campaignRepo.Update(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// CancelCampaign stops a campaign that is not being sent right now. Holders
+// a failed campaign has not reached yet are skipped.
+func (s *CampaignService) CancelCampaign(ctx context.Context, campaignID uuid.UUID) (*entities.EventCampaign, error) {
+	campaign, err := s.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	switch campaign.Status {
+	case entities.CampaignStatusScheduled, entities.CampaignStatusFailed:
+	case entities.CampaignStatusProcessing:
+		return nil, entities.NewConflictError("event_campaign", "campaign is being sent", nil)
+	default:
+		return nil, entities.NewBusinessRuleError("campaign_finished", "campaign has already finished", map[string]interface{}{
+			"status": campaign.Status,
+		})
+	}
+
+	if err := s.close(ctx, campaign, entities.CampaignStatusCancelled, entities.CampaignSkipCancelled, ""); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// ListDeliveries retrieves the per-holder delivery report of a campaign
+func (s *CampaignService) ListDeliveries(ctx context.Context, filter repositories.CampaignDeliveryFilter) ([]*entities.CampaignDelivery, *repositories.PaginationResult, error) {
+	if _, err := s.GetCampaign(ctx, filter.CampaignID); err != nil {
+		return nil, nil, err
+	}
+	return s.campaignRepo.ListDeliveries(ctx, filter)
+}
+
+// RunScheduler sends due campaigns every interval until ctx is done
+func (s *CampaignService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SendDue(ctx); err != nil {
+			fmt.Printf("Warning: failed to send due event campaigns: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue schedules the default campaigns of new events, then sends every
+// due campaign, picking up ones a previous run left unfinished
+func (s *CampaignService) SendDue(ctx context.Context) error {
+	if err := s.createDefaults(ctx); err != nil {
+		return err
+	}
+
+	staleBefore := time.Now().Add(-staleAfter)
+	campaigns, err := s.campaignRepo.GetDue(ctx, staleBefore, dueLimit)
+	if err != nil {
+		return err
+	}
+
+	for _, campaign := range campaigns {
+		claimed, err := s.campaignRepo.Claim(ctx, campaign.ID, staleBefore)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+		if err := s.process(ctx, campaign); err != nil {
+			fmt.Printf("Warning: event campaign %s stopped: %v\n", campaign.ID, err)
+		}
+	}
+	return nil
+}
+
+func (s *CampaignService) createDefaults(ctx context.Context) error {
+	defaults := map[entities.CampaignType]int{
+		entities.CampaignReminder: s.config.DefaultReminderMinutes,
+		entities.CampaignFollowUp: s.config.DefaultFollowUpMinutes,
+	}
+	for campaignType, offset := range defaults {
+		if offset <= 0 {
+			continue
+		}
+		if _, err := s.campaignRepo.CreateDefaults(ctx, campaignType, offset); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// process stages the deliveries once, then sends the pending ones batch by batch
+func (s *CampaignService) process(ctx context.Context, campaign *entities.EventCampaign) error {
+	campaign.MarkProcessing()
+
+	fail := func(err error) error {
+		campaign.MarkFailed(err.Error())
+		if updateErr := s.campaignRepo.Update(ctx, campaign); updateErr != nil {
+			fmt.Printf("Warning: failed to record event campaign %s failure: %v\n", campaign.ID, updateErr)
+		}
+		return err
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, campaign.EventID)
+	if err != nil {
+		return fail(fmt.Errorf("failed to get event: %w", err))
+	}
+	if event.Status == entities.EventStatusCancelled {
+		return s.close(ctx, campaign, entities.CampaignStatusCancelled, entities.CampaignSkipCancelled, "event cancelled")
+	}
+
+	// Holders are captured once, when the campaign comes due
+	if campaign.RecipientsStagedAt == nil {
+		if err := s.stageDeliveries(ctx, campaign); err != nil {
+			return fail(err)
+		}
+	}
+
+	for {
+		if campaign.Type == entities.CampaignReminder && !time.Now().Before(event.EventDate) {
+			return s.close(ctx, campaign, entities.CampaignStatusExpired, entities.CampaignSkipExpired, "event started before the reminder was sent")
+		}
+
+		deliveries, err := s.campaignRepo.GetPendingDeliveries(ctx, campaign.ID, s.batchSize)
+		if err != nil {
+			return fail(err)
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+
+		for _, delivery := range deliveries {
+			if err := s.processDelivery(ctx, campaign, event, delivery); err != nil {
+				return fail(err)
+			}
+		}
+
+		if err := s.refreshProgress(ctx, campaign); err != nil {
+			return fail(err)
+		}
+		time.Sleep(s.batchPause)
+	}
+
+	if err := s.refreshProgress(ctx, campaign); err != nil {
+		return fail(err)
+	}
+	campaign.MarkFinished()
+	return s.campaignRepo.Update(ctx, campaign)
+}
+
+// stageDeliveries records one delivery per holder in the same transaction
+// that marks the campaign as staged, so a restart never stages twice
+func (s *CampaignService) stageDeliveries(ctx context.Context, campaign *entities.EventCampaign) error {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	staged, err := tx.EventCampaigns().StageDeliveries(tx.Context(), campaign)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	campaign.RecipientsStagedAt = &now
+	campaign.TotalRecipients = staged
+	campaign.UpdatedAt = now
+	if err := tx.EventCampaigns().Update(tx.Context(), campaign); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// processDelivery sends one holder their message. Send failures are recorded
+// on the delivery and retried on a later batch; only a failure to load or
+// save the delivery's state stops the campaign.
+func (s *CampaignService) processDelivery(ctx context.Context, campaign *entities.EventCampaign, event *entities.Event, delivery *entities.CampaignDelivery) error {
+	channel, address, err := s.pickChannel(ctx, delivery)
+	if err != nil {
+		return err
+	}
+	if channel == "" {
+		delivery.Skip(entities.CampaignSkipNoContact)
+		return s.campaignRepo.UpdateDelivery(ctx, delivery)
+	}
+
+	sendErr := s.send(ctx, channel, address, campaign, event, delivery)
+	var businessErr *entities.BusinessRuleError
+	var validationErr *entities.ValidationError
+	switch {
+	case sendErr == nil:
+		delivery.MarkSent(channel)
+	case errors.As(sendErr, &businessErr), errors.As(sendErr, &validationErr):
+		// Retrying cannot help, e.g. a rate-limited or invalid phone number
+		delivery.Fail(channel, sendErr.Error())
+	default:
+		delivery.RecordAttemptError(channel, sendErr.Error())
+	}
+
+	return s.campaignRepo.UpdateDelivery(ctx, delivery)
+}
+
+// pickChannel picks how to reach a holder and the address to use: the
+// channel their account prefers when it can reach them, else email, else
+// SMS. WhatsApp is only used when preferred and the holder has opted in.
+func (s *CampaignService) pickChannel(ctx context.Context, delivery *entities.CampaignDelivery) (entities.NotificationChannel, string, error) {
+	preferred := entities.NotificationChannelEmail
+	if delivery.UserID != nil {
+		channel, err := s.userRepo.GetNotificationChannel(ctx, *delivery.UserID)
+		if err != nil && !errors.Is(err, entities.ErrUserNotFound) {
+			return "", "", err
+		}
+		if channel != "" {
+			preferred = channel
+		}
+	}
+
+	for _, channel := range []entities.NotificationChannel{preferred, entities.NotificationChannelEmail, entities.NotificationChannelSMS} {
+		switch channel {
+		case entities.NotificationChannelEmail:
+			if delivery.Email != nil {
+				return channel, *delivery.Email, nil
+			}
+		case entities.NotificationChannelSMS:
+			if delivery.Phone != nil {
+				return channel, *delivery.Phone, nil
+			}
+		case entities.NotificationChannelWhatsApp:
+			if delivery.UserID == nil {
+				continue
+			}
+			optIn, err := s.whatsAppService.ActiveOptIn(ctx, *delivery.UserID)
+			if err != nil {
+				return "", "", err
+			}
+			if optIn != nil {
+				return channel, optIn.Phone, nil
+			}
+		}
+	}
+	return "", "", nil
+}
+
+// send hands the message to the channel's service, which queues it for delivery
+func (s *CampaignService) send(ctx context.Context, channel entities.NotificationChannel, address string, campaign *entities.EventCampaign, event *entities.Event, delivery *entities.CampaignDelivery) error {
+	switch channel {
+	case entities.NotificationChannelEmail:
+		return s.emailService.SendEventCampaignEmail(ctx, campaign, event, delivery)
+	case entities.NotificationChannelSMS:
+		_, err := s.smsService.Send(ctx, notifications.SMSRequest{
+			To:          address,
+			Template:    messageKey(campaign),
+			Data:        messageData(campaign, event, delivery),
+			UserID:      delivery.UserID,
+			OrderID:     &delivery.OrderID,
+			OrganizerID: event.OrganizerID,
+		})
+		return err
+	case entities.NotificationChannelWhatsApp:
+		_, err := s.whatsAppService.SendTemplate(ctx, notifications.WhatsAppTemplateRequest{
+			To:          address,
+			Template:    s.whatsAppTemplate(campaign),
+			Parameters:  whatsAppParameters(campaign, event, delivery),
+			Key:         messageKey(campaign),
+			Data:        messageData(campaign, event, delivery),
+			UserID:      delivery.UserID,
+			OrganizerID: event.OrganizerID,
+		})
+		return err
+	}
+	return fmt.Errorf("unsupported channel %q", channel)
+}
+
+// close ends a campaign for good, skipping the holders it has not reached
+func (s *CampaignService) close(ctx context.Context, campaign *entities.EventCampaign, status entities.CampaignStatus, skipReason, reason string) error {
+	if _, err := s.campaignRepo.SkipPendingDeliveries(ctx, campaign.ID, skipReason); err != nil {
+		return err
+	}
+	if err := s.refreshProgress(ctx, campaign); err != nil {
+		return err
+	}
+	campaign.Close(status, reason)
+	return s.campaignRepo.Update(ctx, campaign)
+}
+
+// refreshProgress recounts the deliveries so progress stays correct across resumes
+func (s *CampaignService) refreshProgress(ctx context.Context, campaign *entities.EventCampaign) error {
+	counts, err := s.campaignRepo.CountDeliveries(ctx, campaign.ID)
+	if err != nil {
+		return err
+	}
+
+	campaign.TotalRecipients = counts.Total
+	campaign.SentCount = counts.Sent
+	campaign.SkippedCount = counts.Skipped
+	campaign.FailedCount = counts.Failed
+	campaign.UpdatedAt = time.Now()
+	return s.campaignRepo.Update(ctx, campaign)
+}
+
+func (s *CampaignService) getEvent(ctx context.Context, eventID uuid.UUID) (*entities.Event, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("event", "event not found")
+	}
+	return event, nil
+}
+
+func (s *CampaignService) whatsAppTemplate(campaign *entities.EventCampaign) string {
+	if campaign.Type == entities.CampaignFollowUp {
+		return s.config.WhatsAppFollowUpTemplate
+	}
+	return s.config.WhatsAppReminderTemplate
+}
+
+func messageKey(campaign *entities.EventCampaign) string {
+	if campaign.Type == entities.CampaignFollowUp {
+		return services.MessageEventFollowUp
+	}
+	return services.MessageEventReminder
+}
+
+// messageData fills the event_reminder and event_follow_up SMS and WhatsApp templates
+func messageData(campaign *entities.EventCampaign, event *entities.Event, delivery *entities.CampaignDelivery) map[string]interface{} {
+	data := map[string]interface{}{
+		"FirstName":      delivery.FirstName(),
+		"EventName":      event.Name,
+		"VenueName":      event.VenueName,
+		"Message":        stringValue(campaign.Message),
+		"UnsubscribeURL": unsubscribeURL(delivery),
+	}
+	if campaign.Type == entities.CampaignFollowUp {
+		data["SurveyURL"] = stringValue(campaign.SurveyURL)
+	} else {
+		data["EventDate"] = event.EventDate
+		data["DoorsOpen"] = event.DoorsOpen
+		data["VenueAddress"] = event.VenueAddress
+	}
+	return data
+}
+
+// whatsAppParameters fills the approved template's body parameters, which cannot be empty
+func whatsAppParameters(campaign *entities.EventCampaign, event *entities.Event, delivery *entities.CampaignDelivery) []string {
+	name := delivery.FirstName()
+	if name == "" {
+		name = "there"
+	}
+
+	if campaign.Type == entities.CampaignFollowUp {
+		link := stringValue(campaign.SurveyURL)
+		if link == "" {
+			link = fmt.Sprintf("%s/events/%s", os.Getenv("FRONTEND_URL"), event.Slug)
+		}
+		return []string{name, event.Name, link}
+	}
+
+	doorsOpen := event.EventDate
+	if event.DoorsOpen != nil {
+		doorsOpen = *event.DoorsOpen
+	}
+	return []string{name, event.Name, event.EventDate.Format("Mon 2 Jan 2006, 15:04"), event.VenueName, doorsOpen.Format("15:04")}
+}
+
+func unsubscribeURL(delivery *entities.CampaignDelivery) string {
+	return fmt.Sprintf("%s/unsubscribe?token=%s", os.Getenv("FRONTEND_URL"), delivery.UnsubscribeToken)
+}
+
+// optionalText trims a text field, treating an empty value as unset
+func optionalText(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
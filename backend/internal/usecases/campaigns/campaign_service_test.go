@@ -0,0 +1,625 @@
+package campaigns
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+	"github.com/uduxpass/backend/internal/usecases/notifications"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+// fakeCampaigns stages its holders as the deliveries of whichever campaign
+// asks, standing in for the query that targets an event's ticket holders
+type fakeCampaigns struct {
+	repositories.EventCampaignRepository
+	campaigns    map[uuid.UUID]*entities.EventCampaign
+	holders      []*entities.CampaignDelivery
+	deliveries   []*entities.CampaignDelivery
+	unsubscribes []*entities.CampaignUnsubscribe
+	staged       int
+	batches      []int
+}
+
+func (f *fakeCampaigns) Create(ctx context.Context, campaign *entities.EventCampaign) error {
+	f.campaigns[campaign.ID] = campaign
+	return nil
+}
+
+func (f *fakeCampaigns) GetByID(ctx context.Context, id uuid.UUID) (*entities.EventCampaign, error) {
+	campaign, ok := f.campaigns[id]
+	if !ok {
+		return nil, entities.ErrEventCampaignNotFound
+	}
+	copied := *campaign
+	return &copied, nil
+}
+
+func (f *fakeCampaigns) Update(ctx context.Context, campaign *entities.EventCampaign) error {
+	f.campaigns[campaign.ID] = campaign
+	return nil
+}
+
+func (f *fakeCampaigns) GetDue(ctx context.Context, staleBefore time.Time, limit int) ([]*entities.EventCampaign, error) {
+	var due []*entities.EventCampaign
+	for _, campaign := range f.campaigns {
+		if !campaign.IsFinished() && !campaign.SendAt.After(time.Now()) {
+			due = append(due, campaign)
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeCampaigns) Claim(ctx context.Context, id uuid.UUID, staleBefore time.Time) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeCampaigns) StageDeliveries(ctx context.Context, campaign *entities.EventCampaign) (int, error) {
+	f.staged++
+	for _, holder := range f.holders {
+		holder.CampaignID = campaign.ID
+		f.deliveries = append(f.deliveries, holder)
+	}
+	return len(f.holders), nil
+}
+
+func (f *fakeCampaigns) GetPendingDeliveries(ctx context.Context, campaignID uuid.UUID, limit int) ([]*entities.CampaignDelivery, error) {
+	var pending []*entities.CampaignDelivery
+	for _, delivery := range f.deliveries {
+		if delivery.CampaignID == campaignID && delivery.Status == entities.CampaignDeliveryPending && len(pending) < limit {
+			pending = append(pending, delivery)
+		}
+	}
+	if len(pending) > 0 {
+		f.batches = append(f.batches, len(pending))
+	}
+	return pending, nil
+}
+
+func (f *fakeCampaigns) GetDeliveryByToken(ctx context.Context, token string) (*entities.CampaignDelivery, error) {
+	for _, delivery := range f.deliveries {
+		if delivery.UnsubscribeToken == token {
+			return delivery, nil
+		}
+	}
+	return nil, entities.ErrCampaignDeliveryNotFound
+}
+
+func (f *fakeCampaigns) UpdateDelivery(ctx context.Context, delivery *entities.CampaignDelivery) error {
+	return nil
+}
+
+func (f *fakeCampaigns) SkipPendingDeliveries(ctx context.Context, campaignID uuid.UUID, reason string) (int, error) {
+	skipped := 0
+	for _, delivery := range f.deliveries {
+		if delivery.CampaignID == campaignID && delivery.Status == entities.CampaignDeliveryPending {
+			delivery.Skip(reason)
+			skipped++
+		}
+	}
+	return skipped, nil
+}
+
+func (f *fakeCampaigns) CountDeliveries(ctx context.Context, campaignID uuid.UUID) (*repositories.CampaignDeliveryCounts, error) {
+	counts := &repositories.CampaignDeliveryCounts{}
+	for _, delivery := range f.deliveries {
+		if delivery.CampaignID != campaignID {
+			continue
+		}
+		counts.Total++
+		switch delivery.Status {
+		case entities.CampaignDeliveryPending:
+			counts.Pending++
+		case entities.CampaignDeliverySent:
+			counts.Sent++
+		case entities.CampaignDeliverySkipped:
+			counts.Skipped++
+		case entities.CampaignDeliveryFailed:
+			counts.Failed++
+		}
+	}
+	return counts, nil
+}
+
+func (f *fakeCampaigns) Unsubscribe(ctx context.Context, unsubscribe *entities.CampaignUnsubscribe) error {
+	f.unsubscribes = append(f.unsubscribes, unsubscribe)
+	return nil
+}
+
+func (f *fakeCampaigns) ListUnsubscribes(ctx context.Context, contactKey string) ([]*entities.CampaignUnsubscribe, error) {
+	var unsubscribes []*entities.CampaignUnsubscribe
+	for _, unsubscribe := range f.unsubscribes {
+		if unsubscribe.ContactKey == contactKey {
+			unsubscribes = append(unsubscribes, unsubscribe)
+		}
+	}
+	return unsubscribes, nil
+}
+
+func (f *fakeCampaigns) DeleteUnsubscribes(ctx context.Context, contactKey string) error {
+	var kept []*entities.CampaignUnsubscribe
+	for _, unsubscribe := range f.unsubscribes {
+		if unsubscribe.ContactKey != contactKey {
+			kept = append(kept, unsubscribe)
+		}
+	}
+	f.unsubscribes = kept
+	return nil
+}
+
+type fakeEvents struct {
+	repositories.EventRepository
+	event *entities.Event
+}
+
+func (f *fakeEvents) GetByID(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	if id != f.event.ID {
+		return nil, entities.ErrEventNotFound
+	}
+	return f.event, nil
+}
+
+type fakeUsers struct {
+	repositories.UserRepository
+	channels map[uuid.UUID]entities.NotificationChannel
+}
+
+func (f *fakeUsers) GetNotificationChannel(ctx context.Context, userID uuid.UUID) (entities.NotificationChannel, error) {
+	return f.channels[userID], nil
+}
+
+func (f *fakeUsers) UpdateNotificationChannel(ctx context.Context, userID uuid.UUID, channel entities.NotificationChannel) error {
+	f.channels[userID] = channel
+	return nil
+}
+
+// fakeMessages records the SMS and WhatsApp messages queued and holds the
+// users' WhatsApp opt-ins
+type fakeMessages struct {
+	repositories.NotificationRepository
+	queued []*entities.NotificationMessage
+	optIns map[uuid.UUID]*entities.WhatsAppOptIn
+}
+
+func (f *fakeMessages) Create(ctx context.Context, message *entities.NotificationMessage) error {
+	f.queued = append(f.queued, message)
+	return nil
+}
+
+func (f *fakeMessages) CountSince(ctx context.Context, channel entities.NotificationChannel, recipient string, since time.Time) (int, error) {
+	return 0, nil
+}
+
+func (f *fakeMessages) GetWhatsAppOptIn(ctx context.Context, userID uuid.UUID) (*entities.WhatsAppOptIn, error) {
+	optIn, ok := f.optIns[userID]
+	if !ok {
+		return nil, entities.ErrWhatsAppOptInNotFound
+	}
+	return optIn, nil
+}
+
+type fakeOutbox struct {
+	repositories.OutboxRepository
+}
+
+func (f *fakeOutbox) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	return nil
+}
+
+type fakeRenderer struct{}
+
+func (fakeRenderer) Render(ctx context.Context, req services.RenderRequest) (*services.RenderedMessage, error) {
+	return &services.RenderedMessage{Text: req.Key, Locale: "en"}, nil
+}
+
+// fakeEmails records who was emailed, failing for the addresses in failing
+type fakeEmails struct {
+	services.EmailService
+	sent    []string
+	failing map[string]bool
+}
+
+func (f *fakeEmails) SendEventCampaignEmail(ctx context.Context, campaign *entities.EventCampaign, event *entities.Event, delivery *entities.CampaignDelivery) error {
+	if f.failing[*delivery.Email] {
+		return errors.New("mail server unavailable")
+	}
+	f.sent = append(f.sent, *delivery.Email)
+	return nil
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	ctx       context.Context
+	campaigns *fakeCampaigns
+	messages  *fakeMessages
+}
+
+func (tx *fakeTx) Commit() error                                        { return nil }
+func (tx *fakeTx) Rollback() error                                      { return nil }
+func (tx *fakeTx) Context() context.Context                             { return tx.ctx }
+func (tx *fakeTx) EventCampaigns() repositories.EventCampaignRepository { return tx.campaigns }
+func (tx *fakeTx) Notifications() repositories.NotificationRepository   { return tx.messages }
+func (tx *fakeTx) Outbox() repositories.OutboxRepository                { return &fakeOutbox{} }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	u.tx.ctx = ctx
+	return u.tx, nil
+}
+
+type campaignFixture struct {
+	service   *CampaignService
+	campaigns *fakeCampaigns
+	users     *fakeUsers
+	messages  *fakeMessages
+	emails    *fakeEmails
+	event     *entities.Event
+}
+
+// newCampaignFixture builds a campaign service over fakes, sending SMS and
+// WhatsApp messages through the real services, for a published event in two
+// days. No default campaigns are configured and batches are not spaced out.
+func newCampaignFixture(t *testing.T) *campaignFixture {
+	t.Helper()
+
+	event := entities.NewEvent(uuid.New(), "Afrobeats Live", "afrobeats-live", time.Now().Add(48*time.Hour), "Eko Hotel", "Victoria Island", "Lagos", "NG")
+	event.Status = entities.EventStatusPublished
+
+	f := &campaignFixture{
+		campaigns: &fakeCampaigns{campaigns: make(map[uuid.UUID]*entities.EventCampaign)},
+		users:     &fakeUsers{channels: make(map[uuid.UUID]entities.NotificationChannel)},
+		messages:  &fakeMessages{optIns: make(map[uuid.UUID]*entities.WhatsAppOptIn)},
+		emails:    &fakeEmails{failing: make(map[string]bool)},
+		event:     event,
+	}
+	unitOfWork := &fakeUnitOfWork{tx: &fakeTx{campaigns: f.campaigns, messages: f.messages}}
+	sms := notifications.NewSMSService(unitOfWork, f.messages, nil, nil, nil, nil, fakeRenderer{}, nil, "")
+	whatsApp := notifications.NewWhatsAppService(unitOfWork, f.messages, nil, nil, nil, nil, nil, nil, fakeRenderer{}, nil, notifications.WhatsAppConfig{})
+
+	f.service = NewCampaignService(f.campaigns, &fakeEvents{event: event}, f.users, unitOfWork, f.emails, sms, whatsApp, Config{})
+	f.service.batchPause = 0
+	return f
+}
+
+// dueCampaign saves a campaign of the event whose send time has passed
+func (f *campaignFixture) dueCampaign(campaignType entities.CampaignType) *entities.EventCampaign {
+	campaign := entities.NewEventCampaign(f.event, campaignType, 60)
+	campaign.SendAt = time.Now().Add(-time.Minute)
+	f.campaigns.campaigns[campaign.ID] = campaign
+	return campaign
+}
+
+// holder adds a ticket holder the campaigns target; a holder with an account
+// is keyed by it, a guest by their email or phone
+func (f *campaignFixture) holder(name string, userID *uuid.UUID, email, phone string) *entities.CampaignDelivery {
+	delivery := &entities.CampaignDelivery{
+		ID:               uuid.New(),
+		OrderID:          uuid.New(),
+		UserID:           userID,
+		Name:             &name,
+		Status:           entities.CampaignDeliveryPending,
+		UnsubscribeToken: uuid.New().String(),
+	}
+	if email != "" {
+		delivery.Email = &email
+	}
+	if phone != "" {
+		delivery.Phone = &phone
+	}
+	switch {
+	case userID != nil:
+		delivery.ContactKey = entities.UserContactKey(*userID)
+	case email != "":
+		delivery.ContactKey = "email:" + email
+	default:
+		delivery.ContactKey = "phone:" + phone
+	}
+	f.campaigns.holders = append(f.campaigns.holders, delivery)
+	return delivery
+}
+
+func (f *campaignFixture) sendDue(t *testing.T) {
+	t.Helper()
+	if err := f.service.SendDue(context.Background()); err != nil {
+		t.Fatalf("SendDue() error = %v", err)
+	}
+}
+
+func TestCreateCampaignSchedulesAroundTheEvent(t *testing.T) {
+	f := newCampaignFixture(t)
+	ctx := context.Background()
+	survey := "https://example.com/survey"
+
+	reminder, err := f.service.CreateCampaign(ctx, f.event.ID, &CreateCampaignRequest{Type: entities.CampaignReminder, OffsetMinutes: 120})
+	if err != nil {
+		t.Fatalf("CreateCampaign() error = %v", err)
+	}
+	if !reminder.SendAt.Equal(f.event.EventDate.Add(-2 * time.Hour)) {
+		t.Errorf("reminder sends at %v, want two hours before the event", reminder.SendAt)
+	}
+
+	followUp, err := f.service.CreateCampaign(ctx, f.event.ID, &CreateCampaignRequest{Type: entities.CampaignFollowUp, OffsetMinutes: 24 * 60, SurveyURL: &survey})
+	if err != nil {
+		t.Fatalf("CreateCampaign() error = %v", err)
+	}
+	if !followUp.SendAt.Equal(f.event.EventDate.Add(24 * time.Hour)) {
+		t.Errorf("follow-up sends at %v, want a day after the event", followUp.SendAt)
+	}
+
+	offset := 30
+	reminder, err = f.service.UpdateCampaign(ctx, reminder.ID, &UpdateCampaignRequest{OffsetMinutes: &offset})
+	if err != nil {
+		t.Fatalf("UpdateCampaign() error = %v", err)
+	}
+	if !reminder.SendAt.Equal(f.event.EventDate.Add(-30 * time.Minute)) {
+		t.Errorf("rescheduled reminder sends at %v, want half an hour before the event", reminder.SendAt)
+	}
+
+	tests := []struct {
+		name  string
+		req   CreateCampaignRequest
+		field string
+	}{
+		{name: "survey on a reminder", req: CreateCampaignRequest{Type: entities.CampaignReminder, OffsetMinutes: 60, SurveyURL: &survey}, field: "survey_url"},
+		{name: "offset beyond 30 days", req: CreateCampaignRequest{Type: entities.CampaignFollowUp, OffsetMinutes: entities.MaxCampaignOffsetMinutes + 1}, field: "offset_minutes"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.CreateCampaign(ctx, f.event.ID, &tt.req)
+			var validationErr *entities.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("CreateCampaign() error = %v, want a validation error on %s", err, tt.field)
+			}
+		})
+	}
+
+	f.event.EventDate = time.Now().Add(-time.Hour)
+	_, err = f.service.CreateCampaign(ctx, f.event.ID, &CreateCampaignRequest{Type: entities.CampaignReminder, OffsetMinutes: 60})
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "event_started" {
+		t.Errorf("CreateCampaign() after the event started error = %v, want event_started", err)
+	}
+}
+
+func TestSendDueReachesHoldersOnTheirPreferredChannel(t *testing.T) {
+	f := newCampaignFixture(t)
+	f.service.batchSize = 2
+	ada, bola, chi := uuid.New(), uuid.New(), uuid.New()
+
+	// Ada prefers SMS; Bola prefers WhatsApp and opted in on another number;
+	// Chi prefers WhatsApp without having opted in, so falls back to email
+	f.users.channels[ada] = entities.NotificationChannelSMS
+	f.users.channels[bola] = entities.NotificationChannelWhatsApp
+	f.users.channels[chi] = entities.NotificationChannelWhatsApp
+	f.messages.optIns[bola] = &entities.WhatsAppOptIn{UserID: bola, Phone: "+2348090000002", OptedIn: true}
+
+	f.holder("Ada Obi", &ada, "ada@example.com", "+2348030000001")
+	f.holder("Bola Ade", &bola, "bola@example.com", "+2348030000002")
+	f.holder("Chi Eze", &chi, "chi@example.com", "+2348030000003")
+	f.holder("Dayo", nil, "", "+2348030000004")
+	f.holder("Emeka", nil, "", "")
+	campaign := f.dueCampaign(entities.CampaignReminder)
+
+	f.sendDue(t)
+
+	got := make(map[string]entities.NotificationChannel)
+	for _, message := range f.messages.queued {
+		got[message.Recipient] = message.Channel
+	}
+	want := map[string]entities.NotificationChannel{
+		"+2348030000001": entities.NotificationChannelSMS,
+		"+2348090000002": entities.NotificationChannelWhatsApp,
+		"+2348030000004": entities.NotificationChannelSMS,
+	}
+	if len(got) != len(want) {
+		t.Errorf("queued %v, want %v", got, want)
+	}
+	for recipient, channel := range want {
+		if got[recipient] != channel {
+			t.Errorf("%s reached by %q, want %q", recipient, got[recipient], channel)
+		}
+	}
+	if len(f.emails.sent) != 1 || f.emails.sent[0] != "chi@example.com" {
+		t.Errorf("emailed %v, want only Chi", f.emails.sent)
+	}
+
+	emeka := f.campaigns.holders[4]
+	if emeka.Status != entities.CampaignDeliverySkipped || *emeka.SkipReason != entities.CampaignSkipNoContact {
+		t.Errorf("holder without contact details = %s, want skipped for no contact", emeka.Status)
+	}
+
+	saved := f.campaigns.campaigns[campaign.ID]
+	if saved.Status != entities.CampaignStatusCompleted || saved.TotalRecipients != 5 || saved.SentCount != 4 || saved.SkippedCount != 1 {
+		t.Errorf("campaign = %s with %d/%d sent and %d skipped, want completed with 4/5 sent and 1 skipped",
+			saved.Status, saved.SentCount, saved.TotalRecipients, saved.SkippedCount)
+	}
+	if len(f.campaigns.batches) != 3 || f.campaigns.batches[0] != 2 || f.campaigns.batches[2] != 1 {
+		t.Errorf("sent in batches of %v, want 2, 2 and 1", f.campaigns.batches)
+	}
+}
+
+func TestSendDueResumesWithoutMessagingAnyoneTwice(t *testing.T) {
+	f := newCampaignFixture(t)
+	first := f.holder("Ada Obi", nil, "ada@example.com", "")
+	f.holder("Bola Ade", nil, "bola@example.com", "")
+	campaign := f.dueCampaign(entities.CampaignReminder)
+
+	// A previous run staged both holders and reached Ada before stopping
+	if _, err := f.campaigns.StageDeliveries(context.Background(), campaign); err != nil {
+		t.Fatalf("StageDeliveries() error = %v", err)
+	}
+	stagedAt := time.Now().Add(-time.Hour)
+	campaign.RecipientsStagedAt = &stagedAt
+	campaign.MarkFailed("database went away")
+	first.MarkSent(entities.NotificationChannelEmail)
+
+	f.sendDue(t)
+
+	if f.campaigns.staged != 1 {
+		t.Errorf("holders staged %d times, want only by the first run", f.campaigns.staged)
+	}
+	if len(f.emails.sent) != 1 || f.emails.sent[0] != "bola@example.com" {
+		t.Errorf("emailed %v, want only Bola", f.emails.sent)
+	}
+	saved := f.campaigns.campaigns[campaign.ID]
+	if saved.Status != entities.CampaignStatusCompleted || saved.SentCount != 2 || saved.LastError != nil {
+		t.Errorf("campaign = %s with %d sent, want completed with both sent", saved.Status, saved.SentCount)
+	}
+
+	// A later run has nothing left to do
+	f.sendDue(t)
+	if len(f.emails.sent) != 1 {
+		t.Errorf("emailed %v after the campaign finished", f.emails.sent)
+	}
+}
+
+func TestSendDueRetriesFailedSendsThenGivesUp(t *testing.T) {
+	f := newCampaignFixture(t)
+	f.emails.failing["bola@example.com"] = true
+	f.holder("Ada Obi", nil, "ada@example.com", "")
+	bola := f.holder("Bola Ade", nil, "bola@example.com", "")
+	campaign := f.dueCampaign(entities.CampaignFollowUp)
+
+	f.sendDue(t)
+
+	if bola.Status != entities.CampaignDeliveryFailed || bola.Attempts != entities.MaxRecipientAttempts {
+		t.Errorf("failing delivery = %s after %d attempts, want failed after %d", bola.Status, bola.Attempts, entities.MaxRecipientAttempts)
+	}
+	saved := f.campaigns.campaigns[campaign.ID]
+	if saved.Status != entities.CampaignStatusCompletedWithErrors || saved.SentCount != 1 || saved.FailedCount != 1 {
+		t.Errorf("campaign = %s with %d sent and %d failed, want completed with errors, 1 each", saved.Status, saved.SentCount, saved.FailedCount)
+	}
+}
+
+func TestSendDueClosesCampaignsThatCanNoLongerGoOut(t *testing.T) {
+	tests := []struct {
+		name       string
+		change     func(event *entities.Event)
+		status     entities.CampaignStatus
+		skipReason string
+	}{
+		{
+			name:       "reminder after the event started",
+			change:     func(event *entities.Event) { event.EventDate = time.Now().Add(-10 * time.Minute) },
+			status:     entities.CampaignStatusExpired,
+			skipReason: entities.CampaignSkipExpired,
+		},
+		{
+			name:       "event cancelled",
+			change:     func(event *entities.Event) { event.Status = entities.EventStatusCancelled },
+			status:     entities.CampaignStatusCancelled,
+			skipReason: entities.CampaignSkipCancelled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newCampaignFixture(t)
+			holder := f.holder("Ada Obi", nil, "ada@example.com", "")
+			campaign := f.dueCampaign(entities.CampaignReminder)
+			if _, err := f.campaigns.StageDeliveries(context.Background(), campaign); err != nil {
+				t.Fatalf("StageDeliveries() error = %v", err)
+			}
+			stagedAt := time.Now().Add(-time.Hour)
+			campaign.RecipientsStagedAt = &stagedAt
+			tt.change(f.event)
+
+			f.sendDue(t)
+
+			if saved := f.campaigns.campaigns[campaign.ID]; saved.Status != tt.status || saved.SkippedCount != 1 {
+				t.Errorf("campaign = %s with %d skipped, want %s with the holder skipped", saved.Status, saved.SkippedCount, tt.status)
+			}
+			if holder.SkipReason == nil || *holder.SkipReason != tt.skipReason || len(f.emails.sent) != 0 {
+				t.Errorf("holder = %s (%v), want skipped as %s without an email", holder.Status, holder.SkipReason, tt.skipReason)
+			}
+		})
+	}
+}
+
+func TestUnsubscribeFromLink(t *testing.T) {
+	f := newCampaignFixture(t)
+	ctx := context.Background()
+	holder := f.holder("Ada Obi", nil, "ada@example.com", "")
+	campaign := f.dueCampaign(entities.CampaignReminder)
+	if _, err := f.campaigns.StageDeliveries(ctx, campaign); err != nil {
+		t.Fatalf("StageDeliveries() error = %v", err)
+	}
+
+	info, err := f.service.Unsubscribe(ctx, holder.UnsubscribeToken, &UnsubscribeRequest{})
+	if err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if info.EventName != f.event.Name || len(info.Unsubscribed) != 1 || info.Unsubscribed[0] != entities.CampaignReminder {
+		t.Errorf("Unsubscribe() = %+v, want reminders stopped", info)
+	}
+	if unsubscribe := f.campaigns.unsubscribes[0]; unsubscribe.ContactKey != holder.ContactKey || unsubscribe.Source != entities.CampaignUnsubscribeLink {
+		t.Errorf("unsubscribe = %+v, want the holder's contact from a link", unsubscribe)
+	}
+
+	info, err = f.service.Unsubscribe(ctx, holder.UnsubscribeToken, &UnsubscribeRequest{All: true})
+	if err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if len(info.Unsubscribed) != 2 {
+		t.Errorf("Unsubscribe(all) stopped %v, want reminders and follow-ups", info.Unsubscribed)
+	}
+
+	var notFound *entities.NotFoundError
+	if _, err := f.service.GetUnsubscribe(ctx, "unknown"); !errors.As(err, &notFound) {
+		t.Errorf("GetUnsubscribe() with an unknown token error = %v, want not found", err)
+	}
+}
+
+func TestUpdatePreferencesReplacesLinkUnsubscribes(t *testing.T) {
+	f := newCampaignFixture(t)
+	ctx := context.Background()
+	userID := uuid.New()
+	contactKey := entities.UserContactKey(userID)
+	f.campaigns.unsubscribes = append(f.campaigns.unsubscribes,
+		entities.NewCampaignUnsubscribe(contactKey, &userID, entities.CampaignTypeAll, entities.CampaignUnsubscribeLink))
+
+	preferences, err := f.service.GetPreferences(ctx, userID)
+	if err != nil {
+		t.Fatalf("GetPreferences() error = %v", err)
+	}
+	if preferences.Channel != entities.NotificationChannelEmail || preferences.Reminders || preferences.FollowUps {
+		t.Errorf("GetPreferences() = %+v, want email with everything stopped", preferences)
+	}
+
+	reminders := true
+	preferences, err = f.service.UpdatePreferences(ctx, userID, &UpdatePreferencesRequest{Reminders: &reminders})
+	if err != nil {
+		t.Fatalf("UpdatePreferences() error = %v", err)
+	}
+	if !preferences.Reminders || preferences.FollowUps {
+		t.Errorf("UpdatePreferences() = %+v, want reminders back on", preferences)
+	}
+	if len(f.campaigns.unsubscribes) != 1 || f.campaigns.unsubscribes[0].Type != entities.CampaignFollowUp || f.campaigns.unsubscribes[0].Source != entities.CampaignUnsubscribeAccount {
+		t.Errorf("unsubscribes = %+v, want only follow-ups, from the account", f.campaigns.unsubscribes)
+	}
+
+	whatsApp := entities.NotificationChannelWhatsApp
+	_, err = f.service.UpdatePreferences(ctx, userID, &UpdatePreferencesRequest{Channel: &whatsApp})
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "whatsapp_not_opted_in" {
+		t.Errorf("UpdatePreferences() to WhatsApp error = %v, want whatsapp_not_opted_in", err)
+	}
+
+	f.messages.optIns[userID] = &entities.WhatsAppOptIn{UserID: userID, Phone: "+2348030000001", OptedIn: true}
+	if _, err := f.service.UpdatePreferences(ctx, userID, &UpdatePreferencesRequest{Channel: &whatsApp}); err != nil {
+		t.Fatalf("UpdatePreferences() error = %v", err)
+	}
+	if f.users.channels[userID] != entities.NotificationChannelWhatsApp {
+		t.Errorf("channel = %q, want whatsapp once opted in", f.users.channels[userID])
+	}
+}
@@ -0,0 +1,211 @@
+package campaigns
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// UnsubscribeInfo describes the campaign behind an unsubscribe link
+type UnsubscribeInfo struct {
+	EventName    string                  `json:"event_name"`
+	CampaignType entities.CampaignType   `json:"campaign_type"`
+	Unsubscribed []entities.CampaignType `json:"unsubscribed"`
+}
+
+// UnsubscribeRequest represents an unsubscribe from a link. All stops every
+// campaign type rather than just the one the link came with.
+type UnsubscribeRequest struct {
+	All bool `json:"all"`
+}
+
+// NotificationPreferences is how a user hears about the events they hold tickets for
+type NotificationPreferences struct {
+	Channel   entities.NotificationChannel `json:"channel"`
+	Reminders bool                         `json:"reminders"`
+	FollowUps bool                         `json:"follow_ups"`
+}
+
+// UpdatePreferencesRequest represents a user's notification preferences. Omitted fields are left unchanged.
+type UpdatePreferencesRequest struct {
+	Channel   *entities.NotificationChannel `json:"channel,omitempty" validate:"omitempty,oneof=email sms whatsapp"`
+	Reminders *bool                         `json:"reminders,omitempty"`
+	FollowUps *bool                         `json:"follow_ups,omitempty"`
+}
+
+// GetUnsubscribe describes the campaign behind an unsubscribe link and what the holder has already stopped
+func (s *CampaignService) GetUnsubscribe(ctx context.Context, token string) (*UnsubscribeInfo, error) {
+	delivery, campaign, err := s.getByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.unsubscribeInfo(ctx, delivery, campaign)
+}
+
+// Unsubscribe stops further campaigns of the link's type, or of every type,
+// from reaching the holder. Links keep working, so repeating one is harmless.
+func (s *CampaignService) Unsubscribe(ctx context.Context, token string, req *UnsubscribeRequest) (*UnsubscribeInfo, error) {
+	delivery, campaign, err := s.getByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	campaignType := campaign.Type
+	if req.All {
+		campaignType = entities.CampaignTypeAll
+	}
+	unsubscribe := entities.NewCampaignUnsubscribe(delivery.ContactKey, delivery.UserID, campaignType, entities.CampaignUnsubscribeLink)
+	if err := s.campaignRepo.Unsubscribe(ctx, unsubscribe); err != nil {
+		return nil, err
+	}
+	return s.unsubscribeInfo(ctx, delivery, campaign)
+}
+
+// GetPreferences retrieves a user's campaign channel and which campaigns they receive
+func (s *CampaignService) GetPreferences(ctx context.Context, userID uuid.UUID) (*NotificationPreferences, error) {
+	channel, err := s.userRepo.GetNotificationChannel(ctx, userID)
+	if err != nil {
+		if errors.Is(err, entities.ErrUserNotFound) {
+			return nil, entities.NewNotFoundError("user", "user not found")
+		}
+		return nil, err
+	}
+	if channel == "" {
+		channel = entities.NotificationChannelEmail
+	}
+
+	unsubscribed, err := s.unsubscribedTypes(ctx, entities.UserContactKey(userID))
+	if err != nil {
+		return nil, err
+	}
+	return &NotificationPreferences{
+		Channel:   channel,
+		Reminders: !unsubscribed[entities.CampaignReminder],
+		FollowUps: !unsubscribed[entities.CampaignFollowUp],
+	}, nil
+}
+
+// UpdatePreferences sets a user's campaign channel and which campaigns they
+// receive. WhatsApp can only be chosen once the user has opted in to it.
+func (s *CampaignService) UpdatePreferences(ctx context.Context, userID uuid.UUID, req *UpdatePreferencesRequest) (*NotificationPreferences, error) {
+	preferences, err := s.GetPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Channel != nil && *req.Channel != preferences.Channel {
+		if *req.Channel == entities.NotificationChannelWhatsApp {
+			optIn, err := s.whatsAppService.ActiveOptIn(ctx, userID)
+			if err != nil {
+				return nil, err
+			}
+			if optIn == nil {
+				return nil, entities.NewBusinessRuleError("whatsapp_not_opted_in", "opt in to WhatsApp messages before choosing WhatsApp", nil)
+			}
+		}
+		if err := s.userRepo.UpdateNotificationChannel(ctx, userID, *req.Channel); err != nil {
+			return nil, err
+		}
+		preferences.Channel = *req.Channel
+	}
+
+	if req.Reminders == nil && req.FollowUps == nil {
+		return preferences, nil
+	}
+	if req.Reminders != nil {
+		preferences.Reminders = *req.Reminders
+	}
+	if req.FollowUps != nil {
+		preferences.FollowUps = *req.FollowUps
+	}
+
+	// The account's choices replace any unsubscribes made from links
+	contactKey := entities.UserContactKey(userID)
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.EventCampaigns().DeleteUnsubscribes(tx.Context(), contactKey); err != nil {
+		return nil, err
+	}
+	disabled := map[entities.CampaignType]bool{
+		entities.CampaignReminder: !preferences.Reminders,
+		entities.CampaignFollowUp: !preferences.FollowUps,
+	}
+	for campaignType, off := range disabled {
+		if !off {
+			continue
+		}
+		unsubscribe := entities.NewCampaignUnsubscribe(contactKey, &userID, campaignType, entities.CampaignUnsubscribeAccount)
+		if err := tx.EventCampaigns().Unsubscribe(tx.Context(), unsubscribe); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return preferences, nil
+}
+
+func (s *CampaignService) getByToken(ctx context.Context, token string) (*entities.CampaignDelivery, *entities.EventCampaign, error) {
+	delivery, err := s.campaignRepo.GetDeliveryByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, entities.ErrCampaignDeliveryNotFound) {
+			return nil, nil, entities.NewNotFoundError("unsubscribe_link", "unsubscribe link not found")
+		}
+		return nil, nil, err
+	}
+	campaign, err := s.GetCampaign(ctx, delivery.CampaignID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return delivery, campaign, nil
+}
+
+func (s *CampaignService) unsubscribeInfo(ctx context.Context, delivery *entities.CampaignDelivery, campaign *entities.EventCampaign) (*UnsubscribeInfo, error) {
+	event, err := s.getEvent(ctx, campaign.EventID)
+	if err != nil {
+		return nil, err
+	}
+
+	unsubscribed, err := s.unsubscribedTypes(ctx, delivery.ContactKey)
+	if err != nil {
+		return nil, err
+	}
+	info := &UnsubscribeInfo{
+		EventName:    event.Name,
+		CampaignType: campaign.Type,
+		Unsubscribed: []entities.CampaignType{},
+	}
+	for _, campaignType := range []entities.CampaignType{entities.CampaignReminder, entities.CampaignFollowUp} {
+		if unsubscribed[campaignType] {
+			info.Unsubscribed = append(info.Unsubscribed, campaignType)
+		}
+	}
+	return info, nil
+}
+
+// unsubscribedTypes reports which campaign types a contact has stopped, expanding an unsubscribe from all
+func (s *CampaignService) unsubscribedTypes(ctx context.Context, contactKey string) (map[entities.CampaignType]bool, error) {
+	unsubscribes, err := s.campaignRepo.ListUnsubscribes(ctx, contactKey)
+	if err != nil {
+		return nil, err
+	}
+
+	unsubscribed := make(map[entities.CampaignType]bool)
+	for _, unsubscribe := range unsubscribes {
+		if unsubscribe.Type == entities.CampaignTypeAll {
+			unsubscribed[entities.CampaignReminder] = true
+			unsubscribed[entities.CampaignFollowUp] = true
+			continue
+		}
+		unsubscribed[unsubscribe.Type] = true
+	}
+	return unsubscribed, nil
+}
@@ -0,0 +1,367 @@
+package categories
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// CategoryService handles event category use cases
+type CategoryService struct {
+	categoryRepo repositories.CategoryRepository
+	eventRepo    repositories.EventRepository
+}
+
+// NewCategoryService creates a new category service
+func NewCategoryService(
+	categoryRepo repositories.CategoryRepository,
+	eventRepo repositories.EventRepository,
+) *CategoryService {
+	return &CategoryService{
+		categoryRepo: categoryRepo,
+		eventRepo:    eventRepo,
+	}
+}
+
+// CreateCategoryRequest represents the request to create a category
+type CreateCategoryRequest struct {
+	Name         string     `json:"name" validate:"required,max=100"`
+	Slug         string     `json:"slug,omitempty" validate:"omitempty,max=100"`
+	Description  *string    `json:"description,omitempty"`
+	Icon         *string    `json:"icon,omitempty"`
+	Color        *string    `json:"color,omitempty"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	DisplayOrder *int       `json:"display_order,omitempty" validate:"omitempty,min=0"`
+}
+
+// UpdateCategoryRequest represents the request to update a category. Omitted
+// fields are left unchanged; top_level moves a subcategory to the top level.
+type UpdateCategoryRequest struct {
+	Name         *string    `json:"name,omitempty" validate:"omitempty,max=100"`
+	Slug         *string    `json:"slug,omitempty" validate:"omitempty,max=100"`
+	Description  *string    `json:"description,omitempty"`
+	Icon         *string    `json:"icon,omitempty"`
+	Color        *string    `json:"color,omitempty"`
+	ParentID     *uuid.UUID `json:"parent_id,omitempty"`
+	TopLevel     bool       `json:"top_level,omitempty"`
+	DisplayOrder *int       `json:"display_order,omitempty" validate:"omitempty,min=0"`
+	IsActive     *bool      `json:"is_active,omitempty"`
+}
+
+// ReorderCategoriesRequest lists every category under one parent, or at the
+// top level when parent_id is omitted, in their new display order
+type ReorderCategoriesRequest struct {
+	ParentID    *uuid.UUID  `json:"parent_id,omitempty"`
+	CategoryIDs []uuid.UUID `json:"category_ids" validate:"required,min=1"`
+}
+
+// SetEventCategoryRequest files an event under a category; an omitted category_id uncategorizes it
+type SetEventCategoryRequest struct {
+	CategoryID *uuid.UUID `json:"category_id"`
+}
+
+// ListCategories lists categories flat, ordered by display order then name
+func (s *CategoryService) ListCategories(ctx context.Context, includeInactive bool) ([]*entities.Category, error) {
+	return s.categoryRepo.List(ctx, categoryFilter(includeInactive))
+}
+
+// GetCategoryTree lists categories nested under their parents with event
+// counts per category and per branch. Without inactive categories, an
+// inactive parent hides its whole branch.
+func (s *CategoryService) GetCategoryTree(ctx context.Context, includeInactive bool) ([]*entities.Category, error) {
+	categoryList, err := s.categoryRepo.List(ctx, categoryFilter(includeInactive))
+	if err != nil {
+		return nil, err
+	}
+	return entities.BuildCategoryTree(categoryList), nil
+}
+
+// GetCategory retrieves a category with its subcategories and event counts
+func (s *CategoryService) GetCategory(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	categoryList, err := s.categoryRepo.List(ctx, repositories.CategoryFilter{})
+	if err != nil {
+		return nil, err
+	}
+	entities.BuildCategoryTree(categoryList)
+
+	for _, category := range categoryList {
+		if category.ID == id {
+			return category, nil
+		}
+	}
+	return nil, entities.NewNotFoundError("category", "category not found")
+}
+
+// ResolveCategory finds an active category by ID or slug, for filtering public listings
+func (s *CategoryService) ResolveCategory(ctx context.Context, idOrSlug string) (*entities.Category, error) {
+	var category *entities.Category
+	var err error
+	if id, parseErr := uuid.Parse(idOrSlug); parseErr == nil {
+		category, err = s.categoryRepo.GetByID(ctx, id)
+	} else {
+		category, err = s.categoryRepo.GetBySlug(ctx, idOrSlug)
+	}
+	if err != nil {
+		if err == entities.ErrCategoryNotFound {
+			return nil, entities.NewNotFoundError("category", "category not found")
+		}
+		return nil, err
+	}
+	if !category.IsActive {
+		return nil, entities.NewNotFoundError("category", "category not found")
+	}
+	return category, nil
+}
+
+// CreateCategory creates a category, placed after its siblings unless a display order is given
+func (s *CategoryService) CreateCategory(ctx context.Context, req *CreateCategoryRequest) (*entities.Category, error) {
+	category := entities.NewCategory(req.Name, req.Slug)
+	category.Description = trimOptional(req.Description)
+	category.Icon = trimOptional(req.Icon)
+	category.Color = trimOptional(req.Color)
+	category.ParentID = req.ParentID
+
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	all, err := s.categoryRepo.List(ctx, repositories.CategoryFilter{})
+	if err != nil {
+		return nil, err
+	}
+	if err := entities.ValidateCategoryParent(all, category.ID, category.ParentID); err != nil {
+		return nil, err
+	}
+
+	if req.DisplayOrder != nil {
+		category.DisplayOrder = *req.DisplayOrder
+	} else {
+		category.DisplayOrder = nextDisplayOrder(all, category.ParentID)
+	}
+
+	if err := s.categoryRepo.Create(ctx, category); err != nil {
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// UpdateCategory updates a category. Moving it under another parent places it
+// after its new siblings unless a display order is given.
+func (s *CategoryService) UpdateCategory(ctx context.Context, id uuid.UUID, req *UpdateCategoryRequest) (*entities.Category, error) {
+	if req.TopLevel && req.ParentID != nil {
+		return nil, entities.NewValidationError("parent_id", "parent_id and top_level cannot be combined")
+	}
+
+	category, err := s.getCategory(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		category.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Slug != nil {
+		category.Slug = strings.TrimSpace(*req.Slug)
+	}
+	if req.Description != nil {
+		category.Description = trimOptional(req.Description)
+	}
+	if req.Icon != nil {
+		category.Icon = trimOptional(req.Icon)
+	}
+	if req.Color != nil {
+		category.Color = trimOptional(req.Color)
+	}
+	if req.IsActive != nil {
+		category.IsActive = *req.IsActive
+	}
+
+	moved := false
+	if req.TopLevel && category.ParentID != nil {
+		category.SetParent(nil)
+		moved = true
+	}
+	if req.ParentID != nil && (category.ParentID == nil || *category.ParentID != *req.ParentID) {
+		category.SetParent(req.ParentID)
+		moved = true
+	}
+
+	if err := category.Validate(); err != nil {
+		return nil, err
+	}
+
+	if moved {
+		all, err := s.categoryRepo.List(ctx, repositories.CategoryFilter{})
+		if err != nil {
+			return nil, err
+		}
+		if err := entities.ValidateCategoryParent(all, category.ID, category.ParentID); err != nil {
+			return nil, err
+		}
+		category.DisplayOrder = nextDisplayOrder(all, category.ParentID)
+	}
+	if req.DisplayOrder != nil {
+		category.DisplayOrder = *req.DisplayOrder
+	}
+
+	category.UpdatedAt = time.Now()
+	if err := s.categoryRepo.Update(ctx, category); err != nil {
+		if err == entities.ErrCategoryNotFound {
+			return nil, entities.NewNotFoundError("category", "category not found")
+		}
+		return nil, err
+	}
+
+	return category, nil
+}
+
+// DeleteCategory deletes a category that has no subcategories and no events.
+// Categories still in use should be deactivated instead.
+func (s *CategoryService) DeleteCategory(ctx context.Context, id uuid.UUID) error {
+	category, err := s.GetCategory(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	if len(category.Subcategories) > 0 {
+		return entities.NewBusinessRuleError("category_has_subcategories",
+			"move or delete the subcategories before deleting this category",
+			map[string]interface{}{"subcategories": len(category.Subcategories)})
+	}
+
+	count, err := s.categoryRepo.CountEvents(ctx, id)
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return entities.NewBusinessRuleError("category_in_use",
+			"the category has events; deactivate it instead",
+			map[string]interface{}{"event_count": count})
+	}
+
+	if err := s.categoryRepo.Delete(ctx, id); err != nil {
+		if err == entities.ErrCategoryNotFound {
+			return entities.NewNotFoundError("category", "category not found")
+		}
+		return err
+	}
+
+	return nil
+}
+
+// ReorderCategories sets the display order of one level of categories. The
+// request must list every category at that level exactly once.
+func (s *CategoryService) ReorderCategories(ctx context.Context, req *ReorderCategoriesRequest) ([]*entities.Category, error) {
+	all, err := s.categoryRepo.List(ctx, repositories.CategoryFilter{})
+	if err != nil {
+		return nil, err
+	}
+
+	siblings := make(map[uuid.UUID]bool)
+	for _, category := range all {
+		if sameParent(category.ParentID, req.ParentID) {
+			siblings[category.ID] = true
+		}
+	}
+
+	seen := make(map[uuid.UUID]bool, len(req.CategoryIDs))
+	for _, id := range req.CategoryIDs {
+		if !siblings[id] {
+			return nil, entities.NewValidationError("category_ids", "category "+id.String()+" is not at this level")
+		}
+		if seen[id] {
+			return nil, entities.NewValidationError("category_ids", "category "+id.String()+" is listed more than once")
+		}
+		seen[id] = true
+	}
+	if len(seen) != len(siblings) {
+		return nil, entities.NewValidationError("category_ids", "every category at this level must be listed")
+	}
+
+	if err := s.categoryRepo.Reorder(ctx, req.CategoryIDs); err != nil {
+		return nil, err
+	}
+
+	return s.GetCategoryTree(ctx, true)
+}
+
+// SetEventCategory files an event under an active category, or uncategorizes it
+func (s *CategoryService) SetEventCategory(ctx context.Context, eventID uuid.UUID, req *SetEventCategoryRequest) (*entities.Event, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		if err == entities.ErrEventNotFound {
+			return nil, entities.NewNotFoundError("event", "event not found")
+		}
+		return nil, err
+	}
+
+	if req.CategoryID != nil {
+		category, err := s.getCategory(ctx, *req.CategoryID)
+		if err != nil {
+			return nil, err
+		}
+		if !category.IsActive {
+			return nil, entities.NewValidationError("category_id", "category is not active")
+		}
+	}
+
+	event.SetCategory(req.CategoryID)
+	if err := s.eventRepo.Update(ctx, event); err != nil {
+		return nil, err
+	}
+
+	return event, nil
+}
+
+func (s *CategoryService) getCategory(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	category, err := s.categoryRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == entities.ErrCategoryNotFound {
+			return nil, entities.NewNotFoundError("category", "category not found")
+		}
+		return nil, err
+	}
+	return category, nil
+}
+
+func categoryFilter(includeInactive bool) repositories.CategoryFilter {
+	if includeInactive {
+		return repositories.CategoryFilter{}
+	}
+	active := true
+	return repositories.CategoryFilter{IsActive: &active}
+}
+
+// nextDisplayOrder returns the display order that places a category after its siblings
+func nextDisplayOrder(categoryList []*entities.Category, parentID *uuid.UUID) int {
+	next := 1
+	for _, category := range categoryList {
+		if sameParent(category.ParentID, parentID) && category.DisplayOrder >= next {
+			next = category.DisplayOrder + 1
+		}
+	}
+	return next
+}
+
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
+
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
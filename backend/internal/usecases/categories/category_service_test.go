@@ -0,0 +1,285 @@
+package categories
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+// fakeCategories hands out copies of its categories, so changes only stick
+// once the use case saves them
+type fakeCategories struct {
+	repositories.CategoryRepository
+	categories map[uuid.UUID]*entities.Category
+	events     map[uuid.UUID]int
+	updated    []*entities.Category
+	deleted    []uuid.UUID
+	reordered  []uuid.UUID
+}
+
+func (f *fakeCategories) Create(ctx context.Context, category *entities.Category) error {
+	f.categories[category.ID] = category
+	return nil
+}
+
+func (f *fakeCategories) GetByID(ctx context.Context, id uuid.UUID) (*entities.Category, error) {
+	category, ok := f.categories[id]
+	if !ok {
+		return nil, entities.ErrCategoryNotFound
+	}
+	copied := *category
+	return &copied, nil
+}
+
+func (f *fakeCategories) Update(ctx context.Context, category *entities.Category) error {
+	f.categories[category.ID] = category
+	f.updated = append(f.updated, category)
+	return nil
+}
+
+func (f *fakeCategories) Delete(ctx context.Context, id uuid.UUID) error {
+	delete(f.categories, id)
+	f.deleted = append(f.deleted, id)
+	return nil
+}
+
+func (f *fakeCategories) List(ctx context.Context, filter repositories.CategoryFilter) ([]*entities.Category, error) {
+	var categories []*entities.Category
+	for _, category := range f.categories {
+		if filter.IsActive != nil && category.IsActive != *filter.IsActive {
+			continue
+		}
+		copied := *category
+		copied.EventCount = f.events[category.ID]
+		categories = append(categories, &copied)
+	}
+	return categories, nil
+}
+
+func (f *fakeCategories) Reorder(ctx context.Context, ids []uuid.UUID) error {
+	for i, id := range ids {
+		f.categories[id].DisplayOrder = i + 1
+	}
+	f.reordered = ids
+	return nil
+}
+
+func (f *fakeCategories) CountEvents(ctx context.Context, categoryID uuid.UUID) (int, error) {
+	return f.events[categoryID], nil
+}
+
+type categoryFixture struct {
+	service    *CategoryService
+	categories *fakeCategories
+	music      *entities.Category
+	afrobeats  *entities.Category
+	amapiano   *entities.Category
+	comedy     *entities.Category
+	sports     *entities.Category
+}
+
+// newCategoryFixture builds a category service over fakes, with Music >
+// Afrobeats > Amapiano nested as deep as categories go, and Comedy and Sports
+// at the top level beside Music
+func newCategoryFixture(t *testing.T) *categoryFixture {
+	t.Helper()
+
+	f := &categoryFixture{
+		music:     entities.NewCategory("Music", ""),
+		afrobeats: entities.NewCategory("Afrobeats", ""),
+		amapiano:  entities.NewCategory("Amapiano", ""),
+		comedy:    entities.NewCategory("Comedy", ""),
+		sports:    entities.NewCategory("Sports", ""),
+	}
+	f.afrobeats.ParentID = &f.music.ID
+	f.amapiano.ParentID = &f.afrobeats.ID
+
+	f.categories = &fakeCategories{categories: make(map[uuid.UUID]*entities.Category), events: make(map[uuid.UUID]int)}
+	for i, category := range []*entities.Category{f.music, f.comedy, f.sports} {
+		category.DisplayOrder = i + 1
+	}
+	f.afrobeats.DisplayOrder = 1
+	f.amapiano.DisplayOrder = 1
+	for _, category := range []*entities.Category{f.music, f.afrobeats, f.amapiano, f.comedy, f.sports} {
+		f.categories.categories[category.ID] = category
+	}
+
+	f.service = NewCategoryService(f.categories, nil)
+	return f
+}
+
+func TestCreateCategoryPlacesItAfterItsSiblings(t *testing.T) {
+	f := newCategoryFixture(t)
+	ctx := context.Background()
+
+	category, err := f.service.CreateCategory(ctx, &CreateCategoryRequest{Name: " Arts & Theater "})
+	if err != nil {
+		t.Fatalf("CreateCategory() error = %v", err)
+	}
+	if category.Slug != "arts-theater" || category.DisplayOrder != 4 {
+		t.Errorf("CreateCategory() = %q at %d, want arts-theater after the three top level categories", category.Slug, category.DisplayOrder)
+	}
+
+	category, err = f.service.CreateCategory(ctx, &CreateCategoryRequest{Name: "Highlife", ParentID: &f.music.ID})
+	if err != nil {
+		t.Fatalf("CreateCategory() error = %v", err)
+	}
+	if category.DisplayOrder != 2 {
+		t.Errorf("subcategory display order = %d, want 2 after Afrobeats", category.DisplayOrder)
+	}
+}
+
+func TestCategoriesCannotFormCycles(t *testing.T) {
+	f := newCategoryFixture(t)
+	ctx := context.Background()
+	unknown := uuid.New()
+
+	tests := []struct {
+		name     string
+		category uuid.UUID
+		parent   uuid.UUID
+	}{
+		{name: "own parent", category: f.music.ID, parent: f.music.ID},
+		{name: "under its subcategory", category: f.music.ID, parent: f.afrobeats.ID},
+		{name: "under a deeper subcategory", category: f.music.ID, parent: f.amapiano.ID},
+		{name: "unknown parent", category: f.comedy.ID, parent: unknown},
+		{name: "below the deepest level", category: f.comedy.ID, parent: f.amapiano.ID},
+		{name: "branch too deep", category: f.music.ID, parent: f.comedy.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parent := tt.parent
+			_, err := f.service.UpdateCategory(ctx, tt.category, &UpdateCategoryRequest{ParentID: &parent})
+			var validationErr *entities.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != "parent_id" {
+				t.Errorf("UpdateCategory() error = %v, want a validation error on parent_id", err)
+			}
+		})
+	}
+	if len(f.categories.updated) != 0 {
+		t.Errorf("UpdateCategory() saved %d invalid moves", len(f.categories.updated))
+	}
+
+	// A category cannot be created under a missing parent either
+	_, err := f.service.CreateCategory(ctx, &CreateCategoryRequest{Name: "Jazz", ParentID: &unknown})
+	var validationErr *entities.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "parent_id" {
+		t.Errorf("CreateCategory() error = %v, want a validation error on parent_id", err)
+	}
+}
+
+func TestUpdateCategoryMovesBranchToTopLevel(t *testing.T) {
+	f := newCategoryFixture(t)
+
+	category, err := f.service.UpdateCategory(context.Background(), f.afrobeats.ID, &UpdateCategoryRequest{TopLevel: true})
+	if err != nil {
+		t.Fatalf("UpdateCategory() error = %v", err)
+	}
+	if category.ParentID != nil || category.DisplayOrder != 4 {
+		t.Errorf("UpdateCategory() = parent %v at %d, want the top level after Sports", category.ParentID, category.DisplayOrder)
+	}
+
+	tree, err := f.service.GetCategoryTree(context.Background(), true)
+	if err != nil {
+		t.Fatalf("GetCategoryTree() error = %v", err)
+	}
+	if len(tree) != 4 || tree[3].ID != f.afrobeats.ID || len(tree[3].Subcategories) != 1 || tree[3].Subcategories[0].ID != f.amapiano.ID {
+		t.Errorf("GetCategoryTree() = %d top level categories, want Afrobeats last with Amapiano under it", len(tree))
+	}
+
+	parent := f.music.ID
+	if _, err := f.service.UpdateCategory(context.Background(), f.afrobeats.ID, &UpdateCategoryRequest{TopLevel: true, ParentID: &parent}); err == nil {
+		t.Errorf("UpdateCategory() accepted both parent_id and top_level")
+	}
+}
+
+func TestCategoryTreeCountsBranchEvents(t *testing.T) {
+	f := newCategoryFixture(t)
+	f.categories.events[f.music.ID] = 1
+	f.categories.events[f.afrobeats.ID] = 4
+	f.categories.events[f.amapiano.ID] = 2
+	f.categories.events[f.comedy.ID] = 3
+
+	tree, err := f.service.GetCategoryTree(context.Background(), false)
+	if err != nil {
+		t.Fatalf("GetCategoryTree() error = %v", err)
+	}
+	if len(tree) != 3 || tree[0].ID != f.music.ID || tree[0].TotalEventCount != 7 || tree[0].Subcategories[0].TotalEventCount != 6 {
+		t.Errorf("Music branch = %d events, want 7 with 6 under Afrobeats", tree[0].TotalEventCount)
+	}
+
+	// An inactive category hides its branch from the public tree
+	f.afrobeats.IsActive = false
+	tree, err = f.service.GetCategoryTree(context.Background(), false)
+	if err != nil {
+		t.Fatalf("GetCategoryTree() error = %v", err)
+	}
+	if len(tree[0].Subcategories) != 0 || tree[0].TotalEventCount != 1 {
+		t.Errorf("Music = %d subcategories with %d events, want Afrobeats and Amapiano hidden", len(tree[0].Subcategories), tree[0].TotalEventCount)
+	}
+}
+
+func TestDeleteCategoryOnlyWhenUnused(t *testing.T) {
+	f := newCategoryFixture(t)
+	ctx := context.Background()
+	f.categories.events[f.comedy.ID] = 2
+
+	tests := []struct {
+		category uuid.UUID
+		rule     string
+	}{
+		{category: f.music.ID, rule: "category_has_subcategories"},
+		{category: f.comedy.ID, rule: "category_in_use"},
+	}
+	for _, tt := range tests {
+		var ruleErr *entities.BusinessRuleError
+		if err := f.service.DeleteCategory(ctx, tt.category); !errors.As(err, &ruleErr) || ruleErr.Rule != tt.rule {
+			t.Errorf("DeleteCategory() error = %v, want business rule %s", err, tt.rule)
+		}
+	}
+
+	if err := f.service.DeleteCategory(ctx, f.sports.ID); err != nil {
+		t.Fatalf("DeleteCategory() error = %v", err)
+	}
+	if len(f.categories.deleted) != 1 || f.categories.deleted[0] != f.sports.ID {
+		t.Errorf("deleted %v, want only Sports", f.categories.deleted)
+	}
+}
+
+func TestReorderCategoriesListsEveryCategoryOnce(t *testing.T) {
+	f := newCategoryFixture(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		ids  []uuid.UUID
+	}{
+		{name: "missing one", ids: []uuid.UUID{f.sports.ID, f.music.ID}},
+		{name: "repeated", ids: []uuid.UUID{f.sports.ID, f.music.ID, f.music.ID}},
+		{name: "other level", ids: []uuid.UUID{f.sports.ID, f.music.ID, f.comedy.ID, f.afrobeats.ID}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.ReorderCategories(ctx, &ReorderCategoriesRequest{CategoryIDs: tt.ids})
+			var validationErr *entities.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != "category_ids" {
+				t.Errorf("ReorderCategories() error = %v, want a validation error on category_ids", err)
+			}
+		})
+	}
+
+	tree, err := f.service.ReorderCategories(ctx, &ReorderCategoriesRequest{CategoryIDs: []uuid.UUID{f.sports.ID, f.music.ID, f.comedy.ID}})
+	if err != nil {
+		t.Fatalf("ReorderCategories() error = %v", err)
+	}
+	if tree[0].ID != f.sports.ID || tree[1].ID != f.music.ID || tree[2].ID != f.comedy.ID {
+		t.Errorf("ReorderCategories() = %s, %s, %s, want Sports, Music, Comedy", tree[0].Name, tree[1].Name, tree[2].Name)
+	}
+}
@@ -0,0 +1,229 @@
+package checkoutrecovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/orders"
+)
+
+// Checkout is what a recovery link offers: the abandoned order's tickets at
+// today's prices, limited to what can still be bought
+type Checkout struct {
+	EventID   uuid.UUID                       `json:"event_id"`
+	EventName string                          `json:"event_name"`
+	EventSlug string                          `json:"event_slug"`
+	EventDate time.Time                       `json:"event_date"`
+	VenueName string                          `json:"venue_name"`
+	Reason    entities.CheckoutRecoveryReason `json:"reason"`
+	Items     []*CheckoutItem                 `json:"items"`
+	Total     float64                         `json:"total"`
+	Currency  string                          `json:"currency"`
+	Available bool                            `json:"available"`
+	Recovered bool                            `json:"recovered"`
+
+	event *entities.Event
+}
+
+// CheckoutItem is one tier of the abandoned order. Quantity is what a new
+// order would hold, which is less than OriginalQuantity when fewer tickets
+// are left; unavailable tiers are left out of the new order.
+type CheckoutItem struct {
+	TicketTierID     uuid.UUID              `json:"ticket_tier_id"`
+	Name             string                 `json:"name"`
+	OriginalQuantity int                    `json:"original_quantity"`
+	Quantity         int                    `json:"quantity"`
+	UnitPrice        float64                `json:"unit_price"`
+	Subtotal         float64                `json:"subtotal"`
+	Available        bool                   `json:"available"`
+	SaleStatus       entities.TierSaleState `json:"sale_status,omitempty"`
+}
+
+// GetCheckout returns what a recovery link offers
+func (s *RecoveryService) GetCheckout(ctx context.Context, token string) (*Checkout, error) {
+	recovery, err := s.getByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.checkout(ctx, recovery)
+}
+
+// RecreateOrder places the abandoned order again for userID with the tickets
+// that are still available. A recovery made from a signed-in order can only
+// be used by that account. Following the link again while the order it
+// placed is still held returns that order rather than placing another.
+func (s *RecoveryService) RecreateOrder(ctx context.Context, token string, userID uuid.UUID) (*orders.CreateOrderResponse, error) {
+	recovery, err := s.getByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if recovery.UserID != nil && *recovery.UserID != userID {
+		return nil, entities.NewNotFoundError("checkout_recovery", "checkout recovery not found")
+	}
+	if recovery.IsConverted() {
+		return nil, entities.NewBusinessRuleError("checkout_already_recovered", "this order has already been completed", nil)
+	}
+
+	if recovery.RecoveredOrderID != nil {
+		order, orderLines, err := s.orderService.GetOrderWithLines(ctx, *recovery.RecoveredOrderID)
+		if err == nil && order.Status == entities.OrderStatusPending && time.Now().Before(order.ExpiresAt) {
+			return &orders.CreateOrderResponse{
+				Order:       order,
+				OrderLines:  orderLines,
+				TotalAmount: order.TotalAmount,
+				ExpiresAt:   order.ExpiresAt,
+			}, nil
+		}
+	}
+
+	checkout, err := s.checkout(ctx, recovery)
+	if err != nil {
+		return nil, err
+	}
+	if !checkout.Available {
+		return nil, errCheckoutUnavailable
+	}
+
+	req := &orders.CreateOrderRequest{
+		UserID:  userID,
+		EventID: recovery.EventID,
+	}
+	for _, item := range checkout.Items {
+		if !item.Available {
+			continue
+		}
+		req.OrderLines = append(req.OrderLines, orders.CreateOrderLineItem{
+			TicketTierID: item.TicketTierID,
+			Quantity:     item.Quantity,
+		})
+	}
+
+	created, err := s.orderService.CreateOrder(ctx, req)
+	if err != nil {
+		if errors.Is(err, entities.ErrInsufficientTickets) || errors.Is(err, entities.ErrEventNotActive) || errors.Is(err, entities.ErrEventExpired) {
+			return nil, errCheckoutUnavailable
+		}
+		return nil, err
+	}
+
+	recovery.MarkClicked(created.Order.ID)
+	if err := s.recoveryRepo.Update(ctx, recovery); err != nil {
+		return nil, err
+	}
+	return created, nil
+}
+
+// ListRecoveries lists recoveries, newest first
+func (s *RecoveryService) ListRecoveries(ctx context.Context, filter repositories.CheckoutRecoveryFilter) ([]*entities.CheckoutRecovery, *repositories.PaginationResult, error) {
+	return s.recoveryRepo.List(ctx, filter)
+}
+
+// GetStats reports abandoned and recovered revenue per currency. The
+// conversion rate is the percentage of sent recoveries that led to a paid order.
+func (s *RecoveryService) GetStats(ctx context.Context, filter repositories.CheckoutRecoveryStatsFilter) ([]*repositories.CheckoutRecoveryStats, error) {
+	if filter.From != nil && filter.To != nil && !filter.From.Before(*filter.To) {
+		return nil, entities.NewValidationError("from", "from must be before to")
+	}
+
+	stats, err := s.recoveryRepo.GetStats(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	for _, currencyStats := range stats {
+		if currencyStats.Sent > 0 {
+			currencyStats.ConversionRate = float64(currencyStats.Converted) / float64(currencyStats.Sent) * 100
+		}
+	}
+	return stats, nil
+}
+
+var errCheckoutUnavailable = entities.NewBusinessRuleError("checkout_unavailable", "the tickets in this order are no longer available", nil)
+
+// checkout prices the abandoned order's tiers as they are sold now. Each
+// tier keeps as many tickets as are left, within its per-order limits.
+func (s *RecoveryService) checkout(ctx context.Context, recovery *entities.CheckoutRecovery) (*Checkout, error) {
+	event, err := s.eventRepo.GetByID(ctx, recovery.EventID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("event", "event not found")
+	}
+	orderLines, err := s.orderLineRepo.GetByOrderID(ctx, recovery.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order lines: %w", err)
+	}
+	availability, err := s.ticketTierRepo.GetAvailability(ctx, event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket tier availability: %w", err)
+	}
+	quotes := make(map[uuid.UUID]*repositories.TicketTierAvailability, len(availability))
+	for _, tier := range availability {
+		quotes[tier.TicketTierID] = tier
+	}
+
+	now := time.Now()
+	open := event.Status == entities.EventStatusPublished && now.Before(event.EventDate) &&
+		(event.SaleEnd == nil || now.Before(*event.SaleEnd))
+
+	checkout := &Checkout{
+		EventID:   event.ID,
+		EventName: event.Name,
+		EventSlug: event.Slug,
+		EventDate: event.EventDate,
+		VenueName: event.VenueName,
+		Reason:    recovery.Reason,
+		Items:     make([]*CheckoutItem, 0, len(orderLines)),
+		Currency:  recovery.Currency,
+		Recovered: recovery.IsConverted(),
+		event:     event,
+	}
+	for _, line := range orderLines {
+		item := &CheckoutItem{
+			TicketTierID:     line.TicketTierID,
+			Name:             line.TicketTierName,
+			OriginalQuantity: line.Quantity,
+			UnitPrice:        line.UnitPrice,
+		}
+		checkout.Items = append(checkout.Items, item)
+
+		quote, ok := quotes[line.TicketTierID]
+		if !ok {
+			continue
+		}
+		item.Name = quote.Name
+		item.UnitPrice = quote.Price
+		item.SaleStatus = quote.SaleStatus
+
+		quantity := line.Quantity
+		if quantity > quote.Available {
+			quantity = quote.Available
+		}
+		if quote.MaxPurchase > 0 && quantity > quote.MaxPurchase {
+			quantity = quote.MaxPurchase
+		}
+		if !open || !quote.IsOnSale || quantity <= 0 || quantity < quote.MinPurchase {
+			continue
+		}
+
+		item.Quantity = quantity
+		item.Subtotal = float64(quantity) * quote.Price
+		item.Available = true
+		checkout.Total += item.Subtotal
+		checkout.Available = true
+	}
+	return checkout, nil
+}
+
+func (s *RecoveryService) getByToken(ctx context.Context, token string) (*entities.CheckoutRecovery, error) {
+	recovery, err := s.recoveryRepo.GetByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, entities.ErrCheckoutRecoveryNotFound) {
+			return nil, entities.NewNotFoundError("checkout_recovery", "checkout recovery not found")
+		}
+		return nil, err
+	}
+	return recovery, nil
+}
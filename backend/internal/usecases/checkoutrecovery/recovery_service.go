@@ -0,0 +1,356 @@
+package checkoutrecovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/notifications"
+	"github.com/uduxpass/backend/internal/usecases/orders"
+)
+
+const (
+	// DefaultSchedulerInterval is how often the scheduler looks for due recoveries
+	DefaultSchedulerInterval = time.Minute
+
+	// DefaultDelayMinutes is how long after an order is abandoned the message
+	// is sent, overridden with CHECKOUT_RECOVERY_DELAY_MINUTES
+	DefaultDelayMinutes = 60
+
+	// DefaultThrottleHours is how long a customer goes without another
+	// recovery for the same event, overridden with CHECKOUT_RECOVERY_THROTTLE_HOURS
+	DefaultThrottleHours = 7 * 24
+
+	// DefaultWhatsAppTemplate is the approved WhatsApp template recoveries are
+	// sent with, overridden with WHATSAPP_CHECKOUT_RECOVERY_TEMPLATE
+	DefaultWhatsAppTemplate = "checkout_recovery"
+
+	// claimLease is how long a claimed recovery is hidden from other
+	// scheduler runs; one still pending after that is picked up again
+	claimLease = 10 * time.Minute
+
+	// retryAfter is how long to wait before retrying a failed send
+	retryAfter = 15 * time.Minute
+
+	// dueLimit caps the recoveries sent per scheduler run
+	dueLimit = 100
+)
+
+// Config sets when recovery messages are sent and the WhatsApp template they
+// are sent with. The template has three body parameters (first name, event
+// name, recovery link).
+type Config struct {
+	Delay            time.Duration
+	Throttle         time.Duration
+	WhatsAppTemplate string
+}
+
+// RecoveryService invites customers back to orders they left unpaid. When an
+// order expires or its payment cannot be started, a recovery is recorded and
+// sent after a delay through the channel the customer prefers, unless they
+// bought tickets for the event in the meantime or were sent one for the same
+// event recently. The message links to a page that places the same order
+// again, and a paid order placed from the link counts as recovered revenue.
+type RecoveryService struct {
+	recoveryRepo    repositories.CheckoutRecoveryRepository
+	orderRepo       repositories.OrderRepository
+	orderLineRepo   repositories.OrderLineRepository
+	eventRepo       repositories.EventRepository
+	ticketTierRepo  repositories.TicketTierRepository
+	userRepo        repositories.UserRepository
+	orderService    *orders.OrderService
+	emailService    services.EmailService
+	smsService      *notifications.SMSService
+	whatsAppService *notifications.WhatsAppService
+	config          Config
+}
+
+// NewRecoveryService creates a new checkout recovery service. An empty WhatsApp template name takes the default.
+func NewRecoveryService(
+	recoveryRepo repositories.CheckoutRecoveryRepository,
+	orderRepo repositories.OrderRepository,
+	orderLineRepo repositories.OrderLineRepository,
+	eventRepo repositories.EventRepository,
+	ticketTierRepo repositories.TicketTierRepository,
+	userRepo repositories.UserRepository,
+	orderService *orders.OrderService,
+	emailService services.EmailService,
+	smsService *notifications.SMSService,
+	whatsAppService *notifications.WhatsAppService,
+	config Config,
+) *RecoveryService {
+	if config.WhatsAppTemplate == "" {
+		config.WhatsAppTemplate = DefaultWhatsAppTemplate
+	}
+	return &RecoveryService{
+		recoveryRepo:    recoveryRepo,
+		orderRepo:       orderRepo,
+		orderLineRepo:   orderLineRepo,
+		eventRepo:       eventRepo,
+		ticketTierRepo:  ticketTierRepo,
+		userRepo:        userRepo,
+		orderService:    orderService,
+		emailService:    emailService,
+		smsService:      smsService,
+		whatsAppService: whatsAppService,
+		config:          config,
+	}
+}
+
+// Subscribe records a recovery for every abandoned order and credits paid
+// orders placed from a recovery link
+func (s *RecoveryService) Subscribe(bus *eventbus.Bus) {
+	bus.SubscribeAsync("checkout_recovery", entities.DomainEventOrderExpired, s.handleOrderExpired)
+	bus.SubscribeAsync("checkout_recovery", entities.DomainEventPaymentFailed, s.handlePaymentFailed)
+	bus.SubscribeAsync("checkout_recovery", entities.DomainEventOrderPaid, s.handleOrderPaid)
+}
+
+func (s *RecoveryService) handleOrderExpired(ctx context.Context, event entities.DomainEvent) error {
+	expired, ok := event.(*entities.OrderExpired)
+	if !ok {
+		return fmt.Errorf("unexpected %T for %s", event, entities.DomainEventOrderExpired)
+	}
+	return s.record(ctx, expired.OrderID, entities.CheckoutOrderExpired)
+}
+
+func (s *RecoveryService) handlePaymentFailed(ctx context.Context, event entities.DomainEvent) error {
+	failed, ok := event.(*entities.PaymentFailed)
+	if !ok {
+		return fmt.Errorf("unexpected %T for %s", event, entities.DomainEventPaymentFailed)
+	}
+	return s.record(ctx, failed.OrderID, entities.CheckoutPaymentFailed)
+}
+
+func (s *RecoveryService) handleOrderPaid(ctx context.Context, event entities.DomainEvent) error {
+	paid, ok := event.(*entities.OrderPaid)
+	if !ok {
+		return fmt.Errorf("unexpected %T for %s", event, entities.DomainEventOrderPaid)
+	}
+
+	recovery, err := s.recoveryRepo.GetByRecoveredOrder(ctx, paid.OrderID)
+	if err != nil {
+		if errors.Is(err, entities.ErrCheckoutRecoveryNotFound) {
+			return nil
+		}
+		return err
+	}
+	if recovery.IsConverted() {
+		return nil
+	}
+	recovery.MarkConverted(paid.TotalAmount)
+	return s.recoveryRepo.Update(ctx, recovery)
+}
+
+// record schedules a recovery for an abandoned order. An order is recovered
+// at most once, and a customer sent a recovery for the event within the
+// throttle window is not sent another.
+func (s *RecoveryService) record(ctx context.Context, orderID uuid.UUID, reason entities.CheckoutRecoveryReason) error {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order %s: %w", orderID, err)
+	}
+
+	recovery := entities.NewCheckoutRecovery(order, reason, s.config.Delay)
+	if recovery.ContactKey == "" || recovery.EventID == uuid.Nil {
+		return nil
+	}
+	_, err = s.recoveryRepo.Create(ctx, recovery, s.config.Throttle)
+	return err
+}
+
+// RunScheduler sends due recoveries every interval until ctx is done
+func (s *RecoveryService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SendDue(ctx); err != nil {
+			fmt.Printf("Warning: failed to send due checkout recoveries: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue sends every recovery whose delay has passed
+func (s *RecoveryService) SendDue(ctx context.Context) error {
+	recoveries, err := s.recoveryRepo.ClaimDue(ctx, dueLimit, claimLease)
+	if err != nil {
+		return err
+	}
+
+	for _, recovery := range recoveries {
+		if err := s.process(ctx, recovery); err != nil {
+			fmt.Printf("Warning: checkout recovery %s not processed: %v\n", recovery.ID, err)
+		}
+	}
+	return nil
+}
+
+// process sends one recovery, or skips it when the customer has since bought
+// tickets or the tickets they wanted can no longer be bought. Send failures
+// are recorded on the recovery and retried later.
+func (s *RecoveryService) process(ctx context.Context, recovery *entities.CheckoutRecovery) error {
+	purchased, err := s.recoveryRepo.HasPurchased(ctx, recovery)
+	if err != nil {
+		return err
+	}
+	if purchased {
+		recovery.Skip(entities.CheckoutSkipPurchased)
+		return s.recoveryRepo.Update(ctx, recovery)
+	}
+
+	checkout, err := s.checkout(ctx, recovery)
+	if err != nil {
+		return err
+	}
+	if !checkout.Available {
+		recovery.Skip(entities.CheckoutSkipUnavailable)
+		return s.recoveryRepo.Update(ctx, recovery)
+	}
+
+	channel, address, err := s.pickChannel(ctx, recovery)
+	if err != nil {
+		return err
+	}
+	if channel == "" {
+		recovery.Skip(entities.CheckoutSkipNoContact)
+		return s.recoveryRepo.Update(ctx, recovery)
+	}
+
+	sendErr := s.send(ctx, channel, address, recovery, checkout)
+	var businessErr *entities.BusinessRuleError
+	var validationErr *entities.ValidationError
+	switch {
+	case sendErr == nil:
+		recovery.MarkSent(channel)
+	case errors.As(sendErr, &businessErr), errors.As(sendErr, &validationErr):
+		// Retrying cannot help, e.g. a rate-limited or invalid phone number
+		recovery.Fail(channel, sendErr.Error())
+	default:
+		recovery.RecordAttemptError(channel, sendErr.Error(), time.Now().Add(retryAfter))
+	}
+
+	return s.recoveryRepo.Update(ctx, recovery)
+}
+
+// pickChannel picks how to reach the customer and the address to use: the
+// channel their account prefers when it can reach them, else email, else
+// SMS. WhatsApp is only used when preferred and the customer has opted in.
+func (s *RecoveryService) pickChannel(ctx context.Context, recovery *entities.CheckoutRecovery) (entities.NotificationChannel, string, error) {
+	preferred := entities.NotificationChannelEmail
+	if recovery.UserID != nil {
+		channel, err := s.userRepo.GetNotificationChannel(ctx, *recovery.UserID)
+		if err != nil && !errors.Is(err, entities.ErrUserNotFound) {
+			return "", "", err
+		}
+		if channel != "" {
+			preferred = channel
+		}
+	}
+
+	for _, channel := range []entities.NotificationChannel{preferred, entities.NotificationChannelEmail, entities.NotificationChannelSMS} {
+		switch channel {
+		case entities.NotificationChannelEmail:
+			if recovery.Email != nil {
+				return channel, *recovery.Email, nil
+			}
+		case entities.NotificationChannelSMS:
+			if recovery.Phone != nil {
+				return channel, *recovery.Phone, nil
+			}
+		case entities.NotificationChannelWhatsApp:
+			if recovery.UserID == nil {
+				continue
+			}
+			optIn, err := s.whatsAppService.ActiveOptIn(ctx, *recovery.UserID)
+			if err != nil {
+				return "", "", err
+			}
+			if optIn != nil {
+				return channel, optIn.Phone, nil
+			}
+		}
+	}
+	return "", "", nil
+}
+
+// send hands the message to the channel's service, which queues it for delivery
+func (s *RecoveryService) send(ctx context.Context, channel entities.NotificationChannel, address string, recovery *entities.CheckoutRecovery, checkout *Checkout) error {
+	switch channel {
+	case entities.NotificationChannelEmail:
+		orderLines, err := s.orderLineRepo.GetByOrderID(ctx, recovery.OrderID)
+		if err != nil {
+			return fmt.Errorf("failed to get order lines: %w", err)
+		}
+		return s.emailService.SendCheckoutRecoveryEmail(ctx, recovery, checkout.event, orderLines)
+	case entities.NotificationChannelSMS:
+		_, err := s.smsService.Send(ctx, notifications.SMSRequest{
+			To:          address,
+			Template:    services.MessageCheckoutRecovery,
+			Data:        messageData(recovery, checkout),
+			UserID:      recovery.UserID,
+			OrderID:     &recovery.OrderID,
+			OrganizerID: checkout.event.OrganizerID,
+		})
+		return err
+	case entities.NotificationChannelWhatsApp:
+		_, err := s.whatsAppService.SendTemplate(ctx, notifications.WhatsAppTemplateRequest{
+			To:          address,
+			Template:    s.config.WhatsAppTemplate,
+			Parameters:  whatsAppParameters(recovery, checkout),
+			Key:         services.MessageCheckoutRecovery,
+			Data:        messageData(recovery, checkout),
+			UserID:      recovery.UserID,
+			OrganizerID: checkout.event.OrganizerID,
+		})
+		return err
+	}
+	return fmt.Errorf("unsupported channel %q", channel)
+}
+
+// messageData fills the checkout_recovery SMS and WhatsApp templates
+func messageData(recovery *entities.CheckoutRecovery, checkout *Checkout) map[string]interface{} {
+	items := make([]map[string]interface{}, 0, len(checkout.Items))
+	for _, item := range checkout.Items {
+		items = append(items, map[string]interface{}{
+			"Name":     item.Name,
+			"Quantity": item.OriginalQuantity,
+		})
+	}
+	return map[string]interface{}{
+		"FirstName":   recovery.FirstName(),
+		"EventName":   checkout.EventName,
+		"EventDate":   checkout.EventDate,
+		"VenueName":   checkout.VenueName,
+		"Items":       items,
+		"Total":       recovery.Amount,
+		"Currency":    recovery.Currency,
+		"Expired":     recovery.Reason == entities.CheckoutOrderExpired,
+		"RecoveryURL": recoveryURL(recovery),
+	}
+}
+
+// whatsAppParameters fills the approved template's body parameters, which cannot be empty
+func whatsAppParameters(recovery *entities.CheckoutRecovery, checkout *Checkout) []string {
+	name := recovery.FirstName()
+	if name == "" {
+		name = "there"
+	}
+	return []string{name, checkout.EventName, recoveryURL(recovery)}
+}
+
+func recoveryURL(recovery *entities.CheckoutRecovery) string {
+	return fmt.Sprintf("%s/checkout/recover?token=%s", os.Getenv("FRONTEND_URL"), recovery.Token)
+}
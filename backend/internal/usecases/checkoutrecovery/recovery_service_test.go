@@ -0,0 +1,514 @@
+package checkoutrecovery
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/orders"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+// fakeRecoveries stores recoveries by ID and reports the contacts in
+// purchased as having bought tickets since abandoning their order
+type fakeRecoveries struct {
+	repositories.CheckoutRecoveryRepository
+	recoveries map[uuid.UUID]*entities.CheckoutRecovery
+	purchased  map[string]bool
+	throttle   time.Duration
+}
+
+func (f *fakeRecoveries) Create(ctx context.Context, recovery *entities.CheckoutRecovery, throttle time.Duration) (bool, error) {
+	f.recoveries[recovery.ID] = recovery
+	f.throttle = throttle
+	return true, nil
+}
+
+func (f *fakeRecoveries) GetByToken(ctx context.Context, token string) (*entities.CheckoutRecovery, error) {
+	for _, recovery := range f.recoveries {
+		if recovery.Token == token {
+			copied := *recovery
+			return &copied, nil
+		}
+	}
+	return nil, entities.ErrCheckoutRecoveryNotFound
+}
+
+func (f *fakeRecoveries) GetByRecoveredOrder(ctx context.Context, orderID uuid.UUID) (*entities.CheckoutRecovery, error) {
+	for _, recovery := range f.recoveries {
+		if recovery.RecoveredOrderID != nil && *recovery.RecoveredOrderID == orderID {
+			copied := *recovery
+			return &copied, nil
+		}
+	}
+	return nil, entities.ErrCheckoutRecoveryNotFound
+}
+
+func (f *fakeRecoveries) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.CheckoutRecovery, error) {
+	var due []*entities.CheckoutRecovery
+	for _, recovery := range f.recoveries {
+		if recovery.Status == entities.CheckoutRecoveryPending && !recovery.SendAt.After(time.Now()) {
+			copied := *recovery
+			due = append(due, &copied)
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeRecoveries) Update(ctx context.Context, recovery *entities.CheckoutRecovery) error {
+	f.recoveries[recovery.ID] = recovery
+	return nil
+}
+
+func (f *fakeRecoveries) HasPurchased(ctx context.Context, recovery *entities.CheckoutRecovery) (bool, error) {
+	return f.purchased[recovery.ContactKey], nil
+}
+
+type fakeOrders struct {
+	repositories.OrderRepository
+	orders map[uuid.UUID]*entities.Order
+}
+
+func (f *fakeOrders) Create(ctx context.Context, order *entities.Order) error {
+	f.orders[order.ID] = order
+	return nil
+}
+
+func (f *fakeOrders) GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+	order, ok := f.orders[id]
+	if !ok {
+		return nil, entities.ErrOrderNotFound
+	}
+	copied := *order
+	return &copied, nil
+}
+
+func (f *fakeOrders) Update(ctx context.Context, order *entities.Order) error {
+	f.orders[order.ID] = order
+	return nil
+}
+
+type fakeOrderLines struct {
+	repositories.OrderLineRepository
+	lines []*entities.OrderLine
+}
+
+func (f *fakeOrderLines) Create(ctx context.Context, line *entities.OrderLine) error {
+	f.lines = append(f.lines, line)
+	return nil
+}
+
+func (f *fakeOrderLines) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderLine, error) {
+	var lines []*entities.OrderLine
+	for _, line := range f.lines {
+		if line.OrderID == orderID {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+type fakeHolds struct {
+	repositories.InventoryHoldRepository
+}
+
+func (f *fakeHolds) Create(ctx context.Context, hold *entities.InventoryHold) error {
+	return nil
+}
+
+type fakeEvents struct {
+	repositories.EventRepository
+	event *entities.Event
+}
+
+func (f *fakeEvents) GetByID(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	if id != f.event.ID {
+		return nil, entities.ErrEventNotFound
+	}
+	return f.event, nil
+}
+
+// fakeTiers sells each tier at the price and quantity in its availability
+type fakeTiers struct {
+	repositories.TicketTierRepository
+	tiers        map[uuid.UUID]*entities.TicketTier
+	availability map[uuid.UUID]*repositories.TicketTierAvailability
+}
+
+func (f *fakeTiers) GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
+	tier, ok := f.tiers[id]
+	if !ok {
+		return nil, entities.ErrTicketTierNotFound
+	}
+	return tier, nil
+}
+
+func (f *fakeTiers) GetAvailability(ctx context.Context, eventID uuid.UUID) ([]*repositories.TicketTierAvailability, error) {
+	var availability []*repositories.TicketTierAvailability
+	for _, tier := range f.availability {
+		copied := *tier
+		availability = append(availability, &copied)
+	}
+	return availability, nil
+}
+
+func (f *fakeTiers) LockForUpdate(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.TicketTier, error) {
+	return f.tiers, nil
+}
+
+func (f *fakeTiers) GetAvailableQuantity(ctx context.Context, id uuid.UUID) (int, error) {
+	return f.availability[id].Available, nil
+}
+
+type fakePriceChanges struct {
+	repositories.TierPriceChangeRepository
+}
+
+func (f *fakePriceChanges) ApplyDue(ctx context.Context, eventID *uuid.UUID) (int, error) {
+	return 0, nil
+}
+
+type fakeUsers struct {
+	repositories.UserRepository
+}
+
+func (f *fakeUsers) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	return &entities.User{ID: id}, nil
+}
+
+func (f *fakeUsers) GetNotificationChannel(ctx context.Context, userID uuid.UUID) (entities.NotificationChannel, error) {
+	return "", nil
+}
+
+type fakeOutbox struct {
+	repositories.OutboxRepository
+}
+
+func (f *fakeOutbox) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	return nil
+}
+
+// fakeEmails records the recoveries emailed
+type fakeEmails struct {
+	services.EmailService
+	sent []*entities.CheckoutRecovery
+}
+
+func (f *fakeEmails) SendCheckoutRecoveryEmail(ctx context.Context, recovery *entities.CheckoutRecovery, event *entities.Event, orderLines []*entities.OrderLine) error {
+	f.sent = append(f.sent, recovery)
+	return nil
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	ctx        context.Context
+	orders     *fakeOrders
+	orderLines *fakeOrderLines
+	tiers      *fakeTiers
+}
+
+func (tx *fakeTx) Commit() error                                        { return nil }
+func (tx *fakeTx) Rollback() error                                      { return nil }
+func (tx *fakeTx) Context() context.Context                             { return tx.ctx }
+func (tx *fakeTx) Orders() repositories.OrderRepository                 { return tx.orders }
+func (tx *fakeTx) OrderLines() repositories.OrderLineRepository         { return tx.orderLines }
+func (tx *fakeTx) InventoryHolds() repositories.InventoryHoldRepository { return &fakeHolds{} }
+func (tx *fakeTx) TicketTiers() repositories.TicketTierRepository       { return tx.tiers }
+func (tx *fakeTx) Outbox() repositories.OutboxRepository                { return &fakeOutbox{} }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	u.tx.ctx = ctx
+	return u.tx, nil
+}
+
+type recoveryFixture struct {
+	service    *RecoveryService
+	recoveries *fakeRecoveries
+	orders     *fakeOrders
+	tiers      *fakeTiers
+	emails     *fakeEmails
+	event      *entities.Event
+	regular    *entities.TicketTier
+	vip        *entities.TicketTier
+	userID     uuid.UUID
+	abandoned  *entities.Order
+}
+
+// newRecoveryFixture builds a recovery service over fakes and a real order
+// service, for an event on sale in 30 days. Ada abandoned an order for two
+// Regular and one VIP ticket, which has expired; both tiers are still on
+// sale with plenty left. Recoveries go out an hour after the order is
+// abandoned, at most one a week per customer and event.
+func newRecoveryFixture(t *testing.T) *recoveryFixture {
+	t.Helper()
+
+	event := entities.NewEvent(uuid.New(), "Afrobeats Live", "afrobeats-live", time.Now().AddDate(0, 0, 30), "Eko Hotel", "Victoria Island", "Lagos", "NG")
+	event.Status = entities.EventStatusPublished
+	event.Currency = entities.DefaultCurrency
+
+	f := &recoveryFixture{
+		recoveries: &fakeRecoveries{recoveries: make(map[uuid.UUID]*entities.CheckoutRecovery), purchased: make(map[string]bool)},
+		orders:     &fakeOrders{orders: make(map[uuid.UUID]*entities.Order)},
+		tiers:      &fakeTiers{tiers: make(map[uuid.UUID]*entities.TicketTier), availability: make(map[uuid.UUID]*repositories.TicketTierAvailability)},
+		emails:     &fakeEmails{},
+		event:      event,
+		regular:    entities.NewTicketTier(event.ID, "Regular", 10000),
+		vip:        entities.NewTicketTier(event.ID, "VIP", 50000),
+		userID:     uuid.New(),
+	}
+	orderLines := &fakeOrderLines{}
+	for _, tier := range []*entities.TicketTier{f.regular, f.vip} {
+		f.tiers.tiers[tier.ID] = tier
+		f.tiers.availability[tier.ID] = &repositories.TicketTierAvailability{
+			TicketTierID: tier.ID,
+			Name:         tier.Name,
+			Price:        tier.Price,
+			BasePrice:    tier.Price,
+			Currency:     entities.DefaultCurrency,
+			Available:    50,
+			MaxPurchase:  10,
+			IsOnSale:     true,
+			SaleStatus:   entities.TierSaleStateOnSale,
+		}
+	}
+
+	f.abandoned = entities.NewOrder(event.ID.String(), "ada@example.com")
+	f.abandoned.UserID = &f.userID
+	f.abandoned.CustomerFirstName = "Ada"
+	f.abandoned.Status = entities.OrderStatusExpired
+	f.abandoned.TotalAmount = 70000
+	f.orders.orders[f.abandoned.ID] = f.abandoned
+	orderLines.lines = append(orderLines.lines,
+		entities.NewOrderLine(f.abandoned.ID, f.regular.ID, 2, 10000),
+		entities.NewOrderLine(f.abandoned.ID, f.vip.ID, 1, 50000))
+
+	unitOfWork := &fakeUnitOfWork{tx: &fakeTx{orders: f.orders, orderLines: orderLines, tiers: f.tiers}}
+	rootOutbox := &fakeOutbox{}
+	orderService := orders.NewOrderService(f.orders, orderLines, &fakeHolds{}, &fakeEvents{event: event}, f.tiers, &fakePriceChanges{}, &fakeUsers{},
+		unitOfWork, eventbus.NewBus(rootOutbox, outbox.NewDispatcher(rootOutbox)))
+
+	f.service = NewRecoveryService(f.recoveries, f.orders, orderLines, &fakeEvents{event: event}, f.tiers, &fakeUsers{}, orderService, f.emails, nil, nil,
+		Config{Delay: time.Hour, Throttle: 7 * 24 * time.Hour})
+	return f
+}
+
+// recordRecovery records the recovery of Ada's abandoned order, already due to be sent
+func (f *recoveryFixture) recordRecovery(t *testing.T) *entities.CheckoutRecovery {
+	t.Helper()
+	recovery := entities.NewCheckoutRecovery(f.abandoned, entities.CheckoutOrderExpired, 0)
+	f.recoveries.recoveries[recovery.ID] = recovery
+	return recovery
+}
+
+func TestOrderExpiredRecordsRecoveryAfterDelay(t *testing.T) {
+	f := newRecoveryFixture(t)
+
+	if err := f.service.handleOrderExpired(context.Background(), entities.NewOrderExpired(f.abandoned)); err != nil {
+		t.Fatalf("handleOrderExpired() error = %v", err)
+	}
+	if len(f.recoveries.recoveries) != 1 {
+		t.Fatalf("recorded %d recoveries, want 1", len(f.recoveries.recoveries))
+	}
+	for _, recovery := range f.recoveries.recoveries {
+		if recovery.ContactKey != entities.UserContactKey(f.userID) || recovery.EventID != f.event.ID || recovery.Amount != 70000 {
+			t.Errorf("recovery = %s for %s worth %v, want Ada's account for the event worth 70000", recovery.ContactKey, recovery.EventID, recovery.Amount)
+		}
+		if wait := time.Until(recovery.SendAt); wait < 59*time.Minute || wait > time.Hour {
+			t.Errorf("recovery sends in %v, want after the hour's delay", wait)
+		}
+		if len(recovery.Token) != 48 {
+			t.Errorf("recovery token = %q, want 24 random bytes in hex", recovery.Token)
+		}
+	}
+	if f.recoveries.throttle != 7*24*time.Hour {
+		t.Errorf("recorded with a throttle of %v, want a week", f.recoveries.throttle)
+	}
+
+	// A guest order without any contact details cannot be recovered
+	guest := entities.NewOrder(f.event.ID.String(), "")
+	f.orders.orders[guest.ID] = guest
+	if err := f.service.handleOrderExpired(context.Background(), entities.NewOrderExpired(guest)); err != nil {
+		t.Fatalf("handleOrderExpired() error = %v", err)
+	}
+	if len(f.recoveries.recoveries) != 1 {
+		t.Errorf("recorded a recovery for an order without contact details")
+	}
+}
+
+func TestSendDueSkipsRecoveriesThatCannotHelp(t *testing.T) {
+	tests := []struct {
+		name       string
+		change     func(f *recoveryFixture, recovery *entities.CheckoutRecovery)
+		status     entities.CheckoutRecoveryStatus
+		skipReason string
+	}{
+		{
+			name:   "still wanted",
+			change: func(f *recoveryFixture, recovery *entities.CheckoutRecovery) {},
+			status: entities.CheckoutRecoverySent,
+		},
+		{
+			name: "bought since",
+			change: func(f *recoveryFixture, recovery *entities.CheckoutRecovery) {
+				f.recoveries.purchased[recovery.ContactKey] = true
+			},
+			status:     entities.CheckoutRecoverySkipped,
+			skipReason: entities.CheckoutSkipPurchased,
+		},
+		{
+			name: "sold out",
+			change: func(f *recoveryFixture, recovery *entities.CheckoutRecovery) {
+				f.tiers.availability[f.regular.ID].Available = 0
+				f.tiers.availability[f.vip.ID].IsOnSale = false
+			},
+			status:     entities.CheckoutRecoverySkipped,
+			skipReason: entities.CheckoutSkipUnavailable,
+		},
+		{
+			name: "no way to reach them",
+			change: func(f *recoveryFixture, recovery *entities.CheckoutRecovery) {
+				recovery.Email = nil
+			},
+			status:     entities.CheckoutRecoverySkipped,
+			skipReason: entities.CheckoutSkipNoContact,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newRecoveryFixture(t)
+			recovery := f.recordRecovery(t)
+			tt.change(f, recovery)
+
+			if err := f.service.SendDue(context.Background()); err != nil {
+				t.Fatalf("SendDue() error = %v", err)
+			}
+
+			saved := f.recoveries.recoveries[recovery.ID]
+			if saved.Status != tt.status {
+				t.Fatalf("recovery = %s, want %s", saved.Status, tt.status)
+			}
+			if tt.skipReason != "" {
+				if *saved.SkipReason != tt.skipReason || len(f.emails.sent) != 0 {
+					t.Errorf("recovery skipped as %s with %d emails, want %s without an email", *saved.SkipReason, len(f.emails.sent), tt.skipReason)
+				}
+				return
+			}
+			if len(f.emails.sent) != 1 || *saved.Channel != entities.NotificationChannelEmail || saved.SentAt == nil {
+				t.Errorf("recovery sent %d emails by %v, want one email", len(f.emails.sent), saved.Channel)
+			}
+		})
+	}
+}
+
+func TestGetCheckoutOffersWhatIsLeftAtTodaysPrices(t *testing.T) {
+	f := newRecoveryFixture(t)
+	recovery := f.recordRecovery(t)
+	f.tiers.availability[f.regular.ID].Available = 1
+	f.tiers.availability[f.regular.ID].Price = 12000
+	f.tiers.availability[f.vip.ID].IsOnSale = false
+	f.tiers.availability[f.vip.ID].SaleStatus = entities.TierSaleStateEnded
+
+	checkout, err := f.service.GetCheckout(context.Background(), recovery.Token)
+	if err != nil {
+		t.Fatalf("GetCheckout() error = %v", err)
+	}
+	if !checkout.Available || checkout.Total != 12000 || checkout.Reason != entities.CheckoutOrderExpired {
+		t.Errorf("checkout = available %v at %v, want the last Regular ticket at 12000", checkout.Available, checkout.Total)
+	}
+	for _, item := range checkout.Items {
+		switch item.TicketTierID {
+		case f.regular.ID:
+			if !item.Available || item.Quantity != 1 || item.OriginalQuantity != 2 || item.UnitPrice != 12000 {
+				t.Errorf("Regular = %d of %d at %v, want 1 of 2 at 12000", item.Quantity, item.OriginalQuantity, item.UnitPrice)
+			}
+		case f.vip.ID:
+			if item.Available || item.SaleStatus != entities.TierSaleStateEnded {
+				t.Errorf("VIP = available %v (%s), want left out once its sale ended", item.Available, item.SaleStatus)
+			}
+		}
+	}
+
+	var notFound *entities.NotFoundError
+	if _, err := f.service.GetCheckout(context.Background(), "not-a-token"); !errors.As(err, &notFound) {
+		t.Errorf("GetCheckout() with an unknown token error = %v, want not found", err)
+	}
+}
+
+func TestRecreateOrderFromRecoveryToken(t *testing.T) {
+	f := newRecoveryFixture(t)
+	ctx := context.Background()
+	recovery := f.recordRecovery(t)
+	f.tiers.availability[f.vip.ID].Available = 0
+
+	// The link of a signed-in order only works for that account
+	var notFound *entities.NotFoundError
+	if _, err := f.service.RecreateOrder(ctx, recovery.Token, uuid.New()); !errors.As(err, &notFound) {
+		t.Errorf("RecreateOrder() by another account error = %v, want not found", err)
+	}
+
+	created, err := f.service.RecreateOrder(ctx, recovery.Token, f.userID)
+	if err != nil {
+		t.Fatalf("RecreateOrder() error = %v", err)
+	}
+	if len(created.OrderLines) != 1 || created.OrderLines[0].TicketTierID != f.regular.ID || created.OrderLines[0].Quantity != 2 {
+		t.Errorf("RecreateOrder() placed %d lines, want the two Regular tickets only", len(created.OrderLines))
+	}
+	saved := f.recoveries.recoveries[recovery.ID]
+	if saved.ClickedAt == nil || saved.RecoveredOrderID == nil || *saved.RecoveredOrderID != created.Order.ID {
+		t.Errorf("recovery clicked %v for order %v, want the new order %s", saved.ClickedAt, saved.RecoveredOrderID, created.Order.ID)
+	}
+
+	// Following the link again returns the order still held
+	again, err := f.service.RecreateOrder(ctx, recovery.Token, f.userID)
+	if err != nil {
+		t.Fatalf("RecreateOrder() again error = %v", err)
+	}
+	if again.Order.ID != created.Order.ID || len(f.orders.orders) != 2 {
+		t.Errorf("RecreateOrder() again placed order %s, want the held order %s", again.Order.ID, created.Order.ID)
+	}
+
+	// Paying the new order credits the recovery, and the link is then used up
+	paid := &entities.OrderPaid{OrderID: created.Order.ID, TotalAmount: created.TotalAmount}
+	if err := f.service.handleOrderPaid(ctx, paid); err != nil {
+		t.Fatalf("handleOrderPaid() error = %v", err)
+	}
+	saved = f.recoveries.recoveries[recovery.ID]
+	if !saved.IsConverted() || saved.RecoveredAmount != 20000 {
+		t.Errorf("recovery converted %v with %v recovered, want 20000", saved.IsConverted(), saved.RecoveredAmount)
+	}
+
+	_, err = f.service.RecreateOrder(ctx, recovery.Token, f.userID)
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "checkout_already_recovered" {
+		t.Errorf("RecreateOrder() after paying error = %v, want checkout_already_recovered", err)
+	}
+}
+
+func TestRecreateOrderWhenNothingIsLeft(t *testing.T) {
+	f := newRecoveryFixture(t)
+	recovery := f.recordRecovery(t)
+	f.event.Status = entities.EventStatusCancelled
+
+	_, err := f.service.RecreateOrder(context.Background(), recovery.Token, f.userID)
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "checkout_unavailable" {
+		t.Errorf("RecreateOrder() for a cancelled event error = %v, want checkout_unavailable", err)
+	}
+	if len(f.orders.orders) != 1 {
+		t.Errorf("an order was placed for a cancelled event")
+	}
+}
@@ -0,0 +1,564 @@
+package comps
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/payments"
+)
+
+// MaxImportRows caps the number of comps a single CSV upload may issue
+const MaxImportRows = 500
+
+// CompService handles complimentary ticket allocation, issuing and guest list check-in
+type CompService struct {
+	compRepo       repositories.CompRepository
+	ticketTierRepo repositories.TicketTierRepository
+	unitOfWork     repositories.UnitOfWork
+	paymentService *payments.PaymentService
+	eventBus       *eventbus.Bus
+}
+
+// NewCompService creates a new comp service
+func NewCompService(
+	compRepo repositories.CompRepository,
+	ticketTierRepo repositories.TicketTierRepository,
+	unitOfWork repositories.UnitOfWork,
+	paymentService *payments.PaymentService,
+	eventBus *eventbus.Bus,
+) *CompService {
+	return &CompService{
+		compRepo:       compRepo,
+		ticketTierRepo: ticketTierRepo,
+		unitOfWork:     unitOfWork,
+		paymentService: paymentService,
+		eventBus:       eventBus,
+	}
+}
+
+// SetAllocationRequest represents the request to set the comp quota of a ticket tier
+type SetAllocationRequest struct {
+	TicketTierID uuid.UUID  `json:"ticket_tier_id" validate:"required"`
+	Quota        int        `json:"quota" validate:"gte=0"`
+	CreatedBy    *uuid.UUID `json:"-"`
+}
+
+// SetAllocation creates or resizes the comp quota for a ticket tier. The
+// unissued part of the quota is set aside from the tier's availability, so
+// public sales cannot take the seats promised to the guest list; it must fit
+// in what the tier has left.
+func (s *CompService) SetAllocation(ctx context.Context, req *SetAllocationRequest) (*entities.CompAllocation, error) {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the tier so a sale running alongside cannot take the seats being set aside
+	tier, err := tx.TicketTiers().GetByIDForUpdate(tx.Context(), req.TicketTierID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("ticket_tier", "ticket tier not found")
+	}
+
+	allocation, err := tx.Comps().GetAllocationByTier(tx.Context(), tier.ID)
+	isNew := false
+	reserved := 0
+	if err != nil {
+		if err != entities.ErrCompAllocationNotFound {
+			return nil, err
+		}
+		allocation = entities.NewCompAllocation(tier.EventID, tier.ID, req.Quota)
+		allocation.CreatedBy = req.CreatedBy
+		isNew = true
+	} else {
+		reserved = allocation.Quota - allocation.Issued
+	}
+
+	// Availability already leaves out what the current quota sets aside
+	available, err := tx.TicketTiers().GetAvailableQuantity(tx.Context(), tier.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+	if req.Quota-allocation.Issued > available+reserved {
+		return nil, entities.NewBusinessRuleError("comp_quota_exceeds_capacity", "comp quota exceeds the tier's remaining capacity", map[string]interface{}{
+			"available": available + reserved,
+			"issued":    allocation.Issued,
+		})
+	}
+
+	allocation.Quota = req.Quota
+	allocation.UpdatedAt = time.Now()
+	if err := allocation.Validate(); err != nil {
+		return nil, err
+	}
+
+	if isNew {
+		err = tx.Comps().CreateAllocation(tx.Context(), allocation)
+	} else {
+		err = tx.Comps().UpdateAllocation(tx.Context(), allocation)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return allocation, nil
+}
+
+// ListAllocations retrieves the comp allocations for an event
+func (s *CompService) ListAllocations(ctx context.Context, eventID uuid.UUID) ([]*entities.CompAllocation, error) {
+	return s.compRepo.ListAllocations(ctx, eventID)
+}
+
+// IssueCompRequest represents the request to issue a named comp
+type IssueCompRequest struct {
+	TicketTierID uuid.UUID                  `json:"ticket_tier_id" validate:"required"`
+	FirstName    string                     `json:"first_name" validate:"required,max=100"`
+	LastName     string                     `json:"last_name" validate:"required,max=100"`
+	Email        string                     `json:"email" validate:"omitempty,email"`
+	Phone        string                     `json:"phone" validate:"omitempty,max=20"`
+	Category     entities.GuestListCategory `json:"category"`
+	PlusOnes     int                        `json:"plus_ones" validate:"gte=0,lte=10"`
+	Notes        string                     `json:"notes"`
+	IssuedBy     *uuid.UUID                 `json:"-"`
+}
+
+// IssueCompResponse represents an issued comp
+type IssueCompResponse struct {
+	Entry   *entities.GuestListEntry `json:"entry"`
+	Tickets []*entities.Ticket       `json:"tickets"`
+	Emailed bool                     `json:"emailed"`
+}
+
+// IssueComp issues a named comp from the tier's allocation. A zero-value order is
+// created already paid, so the tickets scan like any other, and one ticket is
+// issued per admission (the guest plus their plus-ones).
+func (s *CompService) IssueComp(ctx context.Context, req *IssueCompRequest) (*IssueCompResponse, error) {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the tier first, so sales and other comps running alongside wait
+	// and the allocation read below is current
+	tier, err := tx.TicketTiers().GetByIDForUpdate(tx.Context(), req.TicketTierID)
+	if err != nil {
+		if err == entities.ErrNotFoundError {
+			return nil, entities.NewNotFoundError("ticket_tier", "ticket tier not found")
+		}
+		return nil, fmt.Errorf("failed to get ticket tier: %w", err)
+	}
+
+	allocation, err := tx.Comps().GetAllocationByTier(tx.Context(), tier.ID)
+	if err != nil {
+		if err == entities.ErrCompAllocationNotFound {
+			return nil, entities.NewBusinessRuleError("no_comp_allocation", "ticket tier has no comp allocation", nil)
+		}
+		return nil, err
+	}
+
+	entry := entities.NewGuestListEntry(allocation, req.FirstName, req.LastName)
+	if req.Category != "" {
+		entry.Category = req.Category
+	}
+	entry.PlusOnes = req.PlusOnes
+	entry.IssuedBy = req.IssuedBy
+	if req.Email != "" {
+		entry.Email = &req.Email
+	}
+	if req.Phone != "" {
+		entry.Phone = &req.Phone
+	}
+	if req.Notes != "" {
+		entry.Notes = &req.Notes
+	}
+	if err := entry.Validate(); err != nil {
+		return nil, err
+	}
+
+	// Comps draw on the seats their allocation sets aside, which public
+	// availability leaves out
+	quantity := entry.Admissions()
+	unissued := allocation.Quota - allocation.Issued
+	if quantity > unissued {
+		return nil, compQuotaExceeded(allocation, quantity)
+	}
+	available, err := tx.TicketTiers().GetAvailableQuantity(tx.Context(), tier.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check availability: %w", err)
+	}
+	if available+unissued < quantity {
+		return nil, entities.NewBusinessRuleError("insufficient_tickets", "not enough tickets available", map[string]interface{}{
+			"ticket_tier_id": tier.ID,
+			"available":      available + unissued,
+		})
+	}
+
+	if err := tx.Comps().IncrementIssued(tx.Context(), allocation.ID, quantity); err != nil {
+		if err == entities.ErrCompQuotaExceeded {
+			return nil, compQuotaExceeded(allocation, quantity)
+		}
+		return nil, err
+	}
+
+	order := entities.NewOrder(allocation.EventID.String(), req.Email)
+	order.IsComp = true
+	order.Currency = entities.NormalizeCurrency(tier.Currency)
+	order.CustomerFirstName = entry.FirstName
+	order.CustomerLastName = entry.LastName
+	order.CustomerEmail = req.Email
+	order.CustomerPhone = req.Phone
+
+	if err := tx.Orders().Create(tx.Context(), order); err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	orderLine := entities.NewOrderLine(order.ID, tier.ID, quantity, 0)
+	if err := tx.OrderLines().Create(tx.Context(), orderLine); err != nil {
+		return nil, fmt.Errorf("failed to create order line: %w", err)
+	}
+
+	now := time.Now()
+	order.PaidAt = &now
+	order.MarkPaid()
+	if err := tx.Orders().Update(tx.Context(), order); err != nil {
+		return nil, fmt.Errorf("failed to update order: %w", err)
+	}
+
+	tickets, err := s.paymentService.IssueTickets(tx.Context(), tx, order, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to issue tickets: %w", err)
+	}
+
+	entry.OrderID = &order.ID
+	if err := tx.Comps().CreateGuestListEntry(tx.Context(), entry); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &IssueCompResponse{
+		Entry:   entry,
+		Tickets: tickets,
+		Emailed: order.CustomerEmail != "",
+	}, nil
+}
+
+// compQuotaExceeded reports a comp that needs more admissions than its allocation has left
+func compQuotaExceeded(allocation *entities.CompAllocation, requested int) error {
+	return entities.NewBusinessRuleError("comp_quota_exceeded", "comp quota for this tier is used up", map[string]interface{}{
+		"quota":     allocation.Quota,
+		"issued":    allocation.Issued,
+		"requested": requested,
+	})
+}
+
+// ImportCompsRequest represents a CSV upload of named comps
+type ImportCompsRequest struct {
+	// TicketTierID is used for rows that do not carry their own ticket_tier_id column
+	TicketTierID *uuid.UUID
+	File         io.Reader
+	IssuedBy     *uuid.UUID
+}
+
+// ImportRowError describes a CSV row that could not be issued
+type ImportRowError struct {
+	Row     int    `json:"row"`
+	Name    string `json:"name,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportCompsResponse represents the outcome of a CSV upload
+type ImportCompsResponse struct {
+	Issued  []*entities.GuestListEntry `json:"issued"`
+	Errors  []ImportRowError           `json:"errors"`
+	Total   int                        `json:"total"`
+	Success int                        `json:"success"`
+	Failed  int                        `json:"failed"`
+}
+
+// ImportComps issues one comp per CSV row. The header row names the columns:
+// first_name and last_name are required; ticket_tier_id, email, phone, category,
+// plus_ones and notes are optional. A file over MaxImportRows is rejected
+// before any comp is issued; otherwise each row is issued independently so a
+// bad row does not block the rest of the list.
+func (s *CompService) ImportComps(ctx context.Context, req *ImportCompsRequest) (*ImportCompsResponse, error) {
+	reader := csv.NewReader(req.File)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, entities.NewValidationError("file", "CSV file is empty or unreadable")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, required := range []string{"first_name", "last_name"} {
+		if _, ok := columns[required]; !ok {
+			return nil, entities.NewValidationError("file", fmt.Sprintf("CSV is missing the %s column", required))
+		}
+	}
+	if _, ok := columns["ticket_tier_id"]; !ok && req.TicketTierID == nil {
+		return nil, entities.NewValidationError("ticket_tier_id", "ticket tier is required when the CSV has no ticket_tier_id column")
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+
+	// Read the whole file first, so an oversized one issues nothing
+	type csvRow struct {
+		record []string
+		err    error
+	}
+	var rows []csvRow
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if len(rows) == MaxImportRows {
+			return nil, entities.NewValidationError("file", fmt.Sprintf("CSV exceeds the limit of %d rows", MaxImportRows))
+		}
+		rows = append(rows, csvRow{record: record, err: err})
+	}
+
+	resp := &ImportCompsResponse{
+		Issued: []*entities.GuestListEntry{},
+		Errors: []ImportRowError{},
+		Total:  len(rows),
+	}
+
+	for i, csvRow := range rows {
+		row, record := i+2, csvRow.record
+		if csvRow.err != nil {
+			resp.Errors = append(resp.Errors, ImportRowError{Row: row, Message: "malformed CSV row"})
+			continue
+		}
+
+		issueReq := &IssueCompRequest{
+			FirstName: field(record, "first_name"),
+			LastName:  field(record, "last_name"),
+			Email:     field(record, "email"),
+			Phone:     field(record, "phone"),
+			Category:  entities.GuestListCategory(strings.ToLower(field(record, "category"))),
+			Notes:     field(record, "notes"),
+			IssuedBy:  req.IssuedBy,
+		}
+		name := strings.TrimSpace(issueReq.FirstName + " " + issueReq.LastName)
+
+		if tierID := field(record, "ticket_tier_id"); tierID != "" {
+			id, err := uuid.Parse(tierID)
+			if err != nil {
+				resp.Errors = append(resp.Errors, ImportRowError{Row: row, Name: name, Message: "invalid ticket_tier_id"})
+				continue
+			}
+			issueReq.TicketTierID = id
+		} else if req.TicketTierID != nil {
+			issueReq.TicketTierID = *req.TicketTierID
+		} else {
+			resp.Errors = append(resp.Errors, ImportRowError{Row: row, Name: name, Message: "ticket_tier_id is required"})
+			continue
+		}
+
+		if plusOnes := field(record, "plus_ones"); plusOnes != "" {
+			n, err := strconv.Atoi(plusOnes)
+			if err != nil || n < 0 || n > 10 {
+				resp.Errors = append(resp.Errors, ImportRowError{Row: row, Name: name, Message: "plus_ones must be a number between 0 and 10"})
+				continue
+			}
+			issueReq.PlusOnes = n
+		}
+
+		issued, err := s.IssueComp(ctx, issueReq)
+		if err != nil {
+			resp.Errors = append(resp.Errors, ImportRowError{Row: row, Name: name, Message: importErrorMessage(err)})
+			continue
+		}
+		resp.Issued = append(resp.Issued, issued.Entry)
+	}
+
+	resp.Success = len(resp.Issued)
+	resp.Failed = len(resp.Errors)
+	return resp, nil
+}
+
+// importErrorMessage turns a domain error into a message fit for a CSV report
+func importErrorMessage(err error) string {
+	var validationErr *entities.ValidationError
+	var businessErr *entities.BusinessRuleError
+	switch {
+	case errors.As(err, &validationErr):
+		return validationErr.Message
+	case errors.As(err, &businessErr):
+		return businessErr.Message
+	}
+	return "failed to issue comp"
+}
+
+// ListGuestList retrieves guest list entries with filtering and pagination
+func (s *CompService) ListGuestList(ctx context.Context, filter repositories.GuestListFilter) ([]*entities.GuestListEntry, *repositories.PaginationResult, error) {
+	return s.compRepo.ListGuestList(ctx, filter)
+}
+
+// CheckInRequest represents admitting a guest by name at the door
+type CheckInRequest struct {
+	EntryID uuid.UUID
+	// EventID, when set, scopes the check-in to one event (e.g. a scanner's assigned event)
+	EventID     *uuid.UUID
+	CheckedInBy string
+}
+
+// CheckIn admits a guest by name without scanning a QR code. The entry's tickets
+// are redeemed at the same time so they cannot also be used at a scanner.
+func (s *CompService) CheckIn(ctx context.Context, req *CheckInRequest) (*entities.GuestListEntry, error) {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	entry, err := s.getEntry(tx.Context(), tx.Comps(), req.EntryID)
+	if err != nil {
+		return nil, err
+	}
+	if req.EventID != nil && entry.EventID != *req.EventID {
+		return nil, entities.NewNotFoundError("guest_list_entry", "guest list entry not found for this event")
+	}
+
+	if err := entry.CheckIn(req.CheckedInBy); err != nil {
+		return nil, err
+	}
+
+	var events []entities.DomainEvent
+	if entry.OrderID != nil {
+		tickets, err := tx.Tickets().GetByOrder(tx.Context(), *entry.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tickets: %w", err)
+		}
+		for _, ticket := range tickets {
+			if !ticket.CanBeRedeemed() {
+				continue
+			}
+			if err := tx.Tickets().MarkRedeemed(tx.Context(), ticket.ID, req.CheckedInBy); err != nil {
+				return nil, fmt.Errorf("failed to redeem ticket %s: %w", ticket.SerialNumber, err)
+			}
+			events = append(events, entities.NewTicketRedeemed(ticket, entry.EventID, req.CheckedInBy, nil))
+		}
+	}
+
+	if err := tx.Comps().UpdateGuestListEntry(tx.Context(), entry); err != nil {
+		return nil, err
+	}
+
+	if err := s.eventBus.PublishTx(tx.Context(), tx, events...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return entry, nil
+}
+
+// CancelComp withdraws a comp that has not been used. Its tickets are voided and
+// both the comp quota and the tier capacity are released.
+func (s *CompService) CancelComp(ctx context.Context, entryID uuid.UUID) (*entities.GuestListEntry, error) {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	entry, err := s.getEntry(tx.Context(), tx.Comps(), entryID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := entry.Cancel(); err != nil {
+		return nil, err
+	}
+
+	var events []entities.DomainEvent
+	if entry.OrderID != nil {
+		tickets, err := tx.Tickets().GetByOrder(tx.Context(), *entry.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tickets: %w", err)
+		}
+		for _, ticket := range tickets {
+			if ticket.IsRedeemed() {
+				return nil, entities.NewBusinessRuleError("ticket_redeemed", "a ticket on this comp has already been scanned", map[string]interface{}{
+					"serial_number": ticket.SerialNumber,
+				})
+			}
+			if err := tx.Tickets().MarkVoided(tx.Context(), ticket.ID); err != nil {
+				return nil, fmt.Errorf("failed to void ticket %s: %w", ticket.SerialNumber, err)
+			}
+			events = append(events, entities.NewTicketVoided(ticket, entry.EventID, "comp_cancelled"))
+		}
+
+		order, err := tx.Orders().GetByID(tx.Context(), *entry.OrderID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get order: %w", err)
+		}
+		// Comp orders carry no money, so they are cancelled rather than refunded
+		order.Status = entities.OrderStatusCancelled
+		if err := tx.Orders().Update(tx.Context(), order); err != nil {
+			return nil, fmt.Errorf("failed to update order: %w", err)
+		}
+	}
+
+	if err := tx.Comps().DecrementIssued(tx.Context(), entry.AllocationID, entry.Admissions()); err != nil {
+		return nil, err
+	}
+	if err := tx.TicketTiers().DecrementSold(tx.Context(), entry.TicketTierID, entry.Admissions()); err != nil {
+		return nil, fmt.Errorf("failed to release tier capacity: %w", err)
+	}
+
+	if err := tx.Comps().UpdateGuestListEntry(tx.Context(), entry); err != nil {
+		return nil, err
+	}
+
+	if err := s.eventBus.PublishTx(tx.Context(), tx, events...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return entry, nil
+}
+
+func (s *CompService) getEntry(ctx context.Context, compRepo repositories.CompRepository, entryID uuid.UUID) (*entities.GuestListEntry, error) {
+	entry, err := compRepo.GetGuestListEntryByID(ctx, entryID)
+	if err != nil {
+		if err == entities.ErrGuestListEntryNotFound {
+			return nil, entities.NewNotFoundError("guest_list_entry", "guest list entry not found")
+		}
+		return nil, err
+	}
+	return entry, nil
+}
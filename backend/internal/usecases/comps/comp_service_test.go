@@ -0,0 +1,413 @@
+package comps
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	infrapayments "github.com/uduxpass/backend/internal/infrastructure/payments"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/payments"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+type fakeComps struct {
+	repositories.CompRepository
+	allocations map[uuid.UUID]*entities.CompAllocation
+	entries     []*entities.GuestListEntry
+}
+
+func (f *fakeComps) GetAllocationByTier(ctx context.Context, ticketTierID uuid.UUID) (*entities.CompAllocation, error) {
+	allocation, ok := f.allocations[ticketTierID]
+	if !ok {
+		return nil, entities.ErrCompAllocationNotFound
+	}
+	copied := *allocation
+	return &copied, nil
+}
+
+func (f *fakeComps) CreateAllocation(ctx context.Context, allocation *entities.CompAllocation) error {
+	copied := *allocation
+	f.allocations[allocation.TicketTierID] = &copied
+	return nil
+}
+
+func (f *fakeComps) UpdateAllocation(ctx context.Context, allocation *entities.CompAllocation) error {
+	f.allocations[allocation.TicketTierID].Quota = allocation.Quota
+	return nil
+}
+
+func (f *fakeComps) IncrementIssued(ctx context.Context, allocationID uuid.UUID, quantity int) error {
+	for _, allocation := range f.allocations {
+		if allocation.ID != allocationID {
+			continue
+		}
+		if allocation.Issued+quantity > allocation.Quota {
+			return entities.ErrCompQuotaExceeded
+		}
+		allocation.Issued += quantity
+		return nil
+	}
+	return entities.ErrCompAllocationNotFound
+}
+
+func (f *fakeComps) CreateGuestListEntry(ctx context.Context, entry *entities.GuestListEntry) error {
+	f.entries = append(f.entries, entry)
+	return nil
+}
+
+// fakeTiers works out availability the way the repository does: the tier's
+// quota less what is sold and the unissued part of its comp allocation
+type fakeTiers struct {
+	repositories.TicketTierRepository
+	comps  *fakeComps
+	tiers  map[uuid.UUID]*entities.TicketTier
+	locked map[uuid.UUID]bool
+}
+
+func (f *fakeTiers) GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
+	tier, ok := f.tiers[id]
+	if !ok {
+		return nil, entities.ErrNotFoundError
+	}
+	return tier, nil
+}
+
+func (f *fakeTiers) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
+	tier, err := f.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	f.locked[id] = true
+	return tier, nil
+}
+
+func (f *fakeTiers) GetAvailableQuantity(ctx context.Context, id uuid.UUID) (int, error) {
+	tier := f.tiers[id]
+	available := tier.Quota - tier.Sold
+	if allocation, ok := f.comps.allocations[id]; ok {
+		available -= allocation.Quota - allocation.Issued
+	}
+	return available, nil
+}
+
+func (f *fakeTiers) IncrementSold(ctx context.Context, id uuid.UUID, quantity int) error {
+	f.tiers[id].Sold += quantity
+	return nil
+}
+
+type fakeOrders struct {
+	repositories.OrderRepository
+	orders map[uuid.UUID]*entities.Order
+}
+
+func (f *fakeOrders) Create(ctx context.Context, order *entities.Order) error {
+	f.orders[order.ID] = order
+	return nil
+}
+
+func (f *fakeOrders) Update(ctx context.Context, order *entities.Order) error {
+	f.orders[order.ID] = order
+	return nil
+}
+
+type fakeOrderLines struct {
+	repositories.OrderLineRepository
+	lines []*entities.OrderLine
+}
+
+func (f *fakeOrderLines) Create(ctx context.Context, line *entities.OrderLine) error {
+	f.lines = append(f.lines, line)
+	return nil
+}
+
+func (f *fakeOrderLines) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderLine, error) {
+	var lines []*entities.OrderLine
+	for _, line := range f.lines {
+		if line.OrderID == orderID {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+type fakeTickets struct {
+	repositories.TicketRepository
+	tickets []*entities.Ticket
+}
+
+func (f *fakeTickets) CreateBatch(ctx context.Context, tickets []*entities.Ticket) error {
+	f.tickets = append(f.tickets, tickets...)
+	return nil
+}
+
+type fakeOutbox struct {
+	repositories.OutboxRepository
+	messages []*entities.OutboxMessage
+}
+
+func (f *fakeOutbox) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	ctx        context.Context
+	comps      *fakeComps
+	tiers      *fakeTiers
+	orders     *fakeOrders
+	orderLines *fakeOrderLines
+	tickets    *fakeTickets
+	outbox     *fakeOutbox
+}
+
+func (tx *fakeTx) Commit() error                                  { return nil }
+func (tx *fakeTx) Rollback() error                                { return nil }
+func (tx *fakeTx) Context() context.Context                       { return tx.ctx }
+func (tx *fakeTx) Comps() repositories.CompRepository             { return tx.comps }
+func (tx *fakeTx) TicketTiers() repositories.TicketTierRepository { return tx.tiers }
+func (tx *fakeTx) Orders() repositories.OrderRepository           { return tx.orders }
+func (tx *fakeTx) OrderLines() repositories.OrderLineRepository   { return tx.orderLines }
+func (tx *fakeTx) Tickets() repositories.TicketRepository         { return tx.tickets }
+func (tx *fakeTx) Outbox() repositories.OutboxRepository          { return tx.outbox }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	u.tx.ctx = ctx
+	return u.tx, nil
+}
+
+type compFixture struct {
+	service *CompService
+	tx      *fakeTx
+	tier    *entities.TicketTier
+}
+
+// newCompFixture builds a comp service over fakes, with a tier of 10 tickets
+// of which 4 are sold
+func newCompFixture(t *testing.T) *compFixture {
+	t.Helper()
+
+	tier := entities.NewTicketTier(uuid.New(), "Regular", 10000)
+	tier.Quota = 10
+	tier.Sold = 4
+
+	comps := &fakeComps{allocations: make(map[uuid.UUID]*entities.CompAllocation)}
+	orderLines := &fakeOrderLines{}
+	tickets := &fakeTickets{}
+	tx := &fakeTx{
+		comps: comps,
+		tiers: &fakeTiers{
+			comps:  comps,
+			tiers:  map[uuid.UUID]*entities.TicketTier{tier.ID: tier},
+			locked: make(map[uuid.UUID]bool),
+		},
+		orders:     &fakeOrders{orders: make(map[uuid.UUID]*entities.Order)},
+		orderLines: orderLines,
+		tickets:    tickets,
+		outbox:     &fakeOutbox{},
+	}
+
+	bus := eventbus.NewBus(nil, nil)
+	paymentService := payments.NewPaymentService(
+		nil, nil, orderLines, tickets, nil, nil,
+		infrapayments.MoMoProvider{}, infrapayments.PaystackProvider{},
+		nil, nil, bus, "test-secret",
+	)
+
+	return &compFixture{
+		service: NewCompService(comps, tx.tiers, &fakeUnitOfWork{tx: tx}, paymentService, bus),
+		tx:      tx,
+		tier:    tier,
+	}
+}
+
+// allocate gives the fixture's tier a comp allocation with some comps already issued
+func (f *compFixture) allocate(quota, issued int) {
+	allocation := entities.NewCompAllocation(f.tier.EventID, f.tier.ID, quota)
+	allocation.Issued = issued
+	f.tx.comps.allocations[f.tier.ID] = allocation
+}
+
+func (f *compFixture) available(t *testing.T) int {
+	t.Helper()
+	available, err := f.tx.tiers.GetAvailableQuantity(context.Background(), f.tier.ID)
+	if err != nil {
+		t.Fatalf("GetAvailableQuantity() error = %v", err)
+	}
+	return available
+}
+
+func assertRule(t *testing.T, err error, rule string) {
+	t.Helper()
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != rule {
+		t.Fatalf("error = %v, want business rule %s", err, rule)
+	}
+}
+
+func TestSetAllocationSetsSeatsAsideFromPublicSale(t *testing.T) {
+	f := newCompFixture(t)
+
+	allocation, err := f.service.SetAllocation(context.Background(), &SetAllocationRequest{TicketTierID: f.tier.ID, Quota: 4})
+	if err != nil {
+		t.Fatalf("SetAllocation() error = %v", err)
+	}
+	if !f.tx.tiers.locked[f.tier.ID] {
+		t.Error("SetAllocation() did not lock the tier")
+	}
+	if allocation.Quota != 4 {
+		t.Errorf("allocation quota = %d, want 4", allocation.Quota)
+	}
+	if got := f.available(t); got != 2 {
+		t.Errorf("public availability = %d, want 2 once 4 seats are set aside", got)
+	}
+
+	// Resizing counts the seats the allocation already holds
+	if _, err := f.service.SetAllocation(context.Background(), &SetAllocationRequest{TicketTierID: f.tier.ID, Quota: 6}); err != nil {
+		t.Fatalf("SetAllocation() resize error = %v", err)
+	}
+	if got := f.available(t); got != 0 {
+		t.Errorf("public availability = %d, want 0", got)
+	}
+}
+
+func TestSetAllocationRejectsQuotaOverCapacity(t *testing.T) {
+	f := newCompFixture(t)
+	f.allocate(4, 1)
+
+	// 4 sold, one of them the comp, leave 6 seats, which 7 more comps would not fit in
+	_, err := f.service.SetAllocation(context.Background(), &SetAllocationRequest{TicketTierID: f.tier.ID, Quota: 8})
+	assertRule(t, err, "comp_quota_exceeds_capacity")
+
+	if got := f.tx.comps.allocations[f.tier.ID].Quota; got != 4 {
+		t.Errorf("allocation quota = %d, want it left at 4", got)
+	}
+}
+
+func TestIssueCompDrawsOnReservedSeats(t *testing.T) {
+	f := newCompFixture(t)
+	f.allocate(3, 0)
+	f.tier.Sold = 7 // the public has bought every seat it can
+
+	if got := f.available(t); got != 0 {
+		t.Fatalf("public availability = %d, want 0", got)
+	}
+
+	resp, err := f.service.IssueComp(context.Background(), &IssueCompRequest{
+		TicketTierID: f.tier.ID,
+		FirstName:    "Ada",
+		LastName:     "Obi",
+		Email:        "ada@example.com",
+		PlusOnes:     1,
+	})
+	if err != nil {
+		t.Fatalf("IssueComp() error = %v", err)
+	}
+	if !f.tx.tiers.locked[f.tier.ID] {
+		t.Error("IssueComp() did not lock the tier")
+	}
+	if len(resp.Tickets) != 2 {
+		t.Errorf("IssueComp() issued %d tickets, want 2", len(resp.Tickets))
+	}
+	if got := f.tx.comps.allocations[f.tier.ID].Issued; got != 2 {
+		t.Errorf("allocation issued = %d, want 2", got)
+	}
+	if f.tier.Sold != 9 {
+		t.Errorf("tier sold = %d, want 9", f.tier.Sold)
+	}
+	if got := f.available(t); got != 0 {
+		t.Errorf("public availability = %d, want 0 after the comp", got)
+	}
+	if len(f.tx.comps.entries) != 1 || f.tx.comps.entries[0].OrderID == nil {
+		t.Error("IssueComp() did not record the guest list entry against its order")
+	}
+}
+
+func TestIssueCompRejectsMoreThanQuota(t *testing.T) {
+	f := newCompFixture(t)
+	f.allocate(3, 2)
+
+	_, err := f.service.IssueComp(context.Background(), &IssueCompRequest{
+		TicketTierID: f.tier.ID,
+		FirstName:    "Ada",
+		LastName:     "Obi",
+		PlusOnes:     1,
+	})
+	assertRule(t, err, "comp_quota_exceeded")
+
+	if len(f.tx.tickets.tickets) != 0 || f.tx.comps.allocations[f.tier.ID].Issued != 2 {
+		t.Error("IssueComp() issued a comp over quota")
+	}
+}
+
+func TestIssueCompRequiresAllocation(t *testing.T) {
+	f := newCompFixture(t)
+
+	_, err := f.service.IssueComp(context.Background(), &IssueCompRequest{TicketTierID: f.tier.ID, FirstName: "Ada", LastName: "Obi"})
+	assertRule(t, err, "no_comp_allocation")
+}
+
+func TestImportCompsRejectsFileOverRowLimitBeforeIssuing(t *testing.T) {
+	f := newCompFixture(t)
+	f.allocate(6, 0)
+
+	var csv strings.Builder
+	csv.WriteString("first_name,last_name\n")
+	for i := 0; i <= MaxImportRows; i++ {
+		fmt.Fprintf(&csv, "Guest,%d\n", i)
+	}
+
+	_, err := f.service.ImportComps(context.Background(), &ImportCompsRequest{TicketTierID: &f.tier.ID, File: strings.NewReader(csv.String())})
+	var validationErr *entities.ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("ImportComps() error = %v, want a validation error", err)
+	}
+	if len(f.tx.comps.entries) != 0 || f.tx.comps.allocations[f.tier.ID].Issued != 0 {
+		t.Errorf("ImportComps() issued %d comps from a file over the limit", len(f.tx.comps.entries))
+	}
+}
+
+func TestImportCompsReportsRowErrors(t *testing.T) {
+	f := newCompFixture(t)
+	f.allocate(3, 0)
+
+	file := strings.NewReader(strings.Join([]string{
+		"first_name,last_name,plus_ones",
+		"Ada,Obi,1",
+		"Tunde,,0",
+		"Chidi,Eze,lots",
+		"Ngozi,Bello,1",
+	}, "\n"))
+
+	resp, err := f.service.ImportComps(context.Background(), &ImportCompsRequest{TicketTierID: &f.tier.ID, File: file})
+	if err != nil {
+		t.Fatalf("ImportComps() error = %v", err)
+	}
+	if resp.Total != 4 || resp.Success != 1 || resp.Failed != 3 {
+		t.Fatalf("ImportComps() = %d total, %d issued, %d failed, want 4, 1, 3", resp.Total, resp.Success, resp.Failed)
+	}
+
+	// Ngozi and her plus-one do not fit in what Ada left of the quota
+	want := map[int]string{
+		3: "last name is required",
+		4: "plus_ones must be a number between 0 and 10",
+		5: "comp quota for this tier is used up",
+	}
+	for _, rowErr := range resp.Errors {
+		if want[rowErr.Row] != rowErr.Message {
+			t.Errorf("row %d error = %q, want %q", rowErr.Row, rowErr.Message, want[rowErr.Row])
+		}
+	}
+}
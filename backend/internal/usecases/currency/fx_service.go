@@ -0,0 +1,188 @@
+package currency
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// FXService handles exchange rate maintenance and per-currency settlement reporting
+type FXService struct {
+	fxRateRepo repositories.FXRateRepository
+	orderRepo  repositories.OrderRepository
+}
+
+// NewFXService creates a new FX service
+func NewFXService(
+	fxRateRepo repositories.FXRateRepository,
+	orderRepo repositories.OrderRepository,
+) *FXService {
+	return &FXService{
+		fxRateRepo: fxRateRepo,
+		orderRepo:  orderRepo,
+	}
+}
+
+// CreateRateRequest represents the request to publish an exchange rate
+type CreateRateRequest struct {
+	BaseCurrency  string     `json:"base_currency" validate:"required,len=3"`
+	QuoteCurrency string     `json:"quote_currency" validate:"required,len=3"`
+	Rate          float64    `json:"rate" validate:"required,gt=0"`
+	EffectiveAt   *time.Time `json:"effective_at,omitempty"`
+	Source        *string    `json:"source,omitempty"`
+	CreatedBy     *uuid.UUID `json:"-"`
+}
+
+// CreateRate publishes a new exchange rate. Earlier rates are kept as history.
+func (s *FXService) CreateRate(ctx context.Context, req *CreateRateRequest) (*entities.FXRate, error) {
+	rate := entities.NewFXRate(req.BaseCurrency, req.QuoteCurrency, req.Rate)
+	if req.EffectiveAt != nil {
+		rate.EffectiveAt = *req.EffectiveAt
+	}
+	rate.Source = req.Source
+	rate.CreatedBy = req.CreatedBy
+
+	if err := rate.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.fxRateRepo.Create(ctx, rate); err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+// ListRates retrieves exchange rates with filtering and pagination
+func (s *FXService) ListRates(ctx context.Context, filter repositories.FXRateFilter) ([]*entities.FXRate, *repositories.PaginationResult, error) {
+	filter.BaseCurrency = entities.NormalizeCurrency(filter.BaseCurrency)
+	filter.QuoteCurrency = entities.NormalizeCurrency(filter.QuoteCurrency)
+	return s.fxRateRepo.List(ctx, filter)
+}
+
+// DeleteRate removes an exchange rate entered in error
+func (s *FXService) DeleteRate(ctx context.Context, id uuid.UUID) error {
+	if err := s.fxRateRepo.Delete(ctx, id); err != nil {
+		if err == entities.ErrFXRateNotFound {
+			return entities.NewNotFoundError("fx_rate", "exchange rate not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// GetRate returns the rate converting one unit of from into to at the given instant.
+// The inverse of a stored pair is used when only the opposite direction is maintained.
+func (s *FXService) GetRate(ctx context.Context, from, to string, at time.Time) (float64, error) {
+	from = entities.NormalizeCurrency(from)
+	to = entities.NormalizeCurrency(to)
+	if from == to {
+		return 1, nil
+	}
+
+	rate, err := s.fxRateRepo.GetEffective(ctx, from, to, at)
+	if err == nil {
+		return rate.Rate, nil
+	}
+	if err != entities.ErrFXRateNotFound {
+		return 0, err
+	}
+
+	inverse, err := s.fxRateRepo.GetEffective(ctx, to, from, at)
+	if err != nil {
+		return 0, err
+	}
+	return 1 / inverse.Rate, nil
+}
+
+// SettlementReportRequest represents the request for a per-currency settlement report
+type SettlementReportRequest struct {
+	EventID           *uuid.UUID
+	OrganizerID       *uuid.UUID
+	DateFrom          *time.Time
+	DateTo            *time.Time
+	ReportingCurrency string
+}
+
+// CurrencySettlement represents settled revenue in one currency, optionally converted
+type CurrencySettlement struct {
+	repositories.CurrencyRevenue
+	FXRate           *float64 `json:"fx_rate,omitempty"`
+	ConvertedRevenue *float64 `json:"converted_revenue,omitempty"`
+}
+
+// SettlementReport represents revenue broken down by settlement currency
+type SettlementReport struct {
+	Currencies        []*CurrencySettlement `json:"currencies"`
+	ReportingCurrency string                `json:"reporting_currency,omitempty"`
+	ConvertedTotal    *float64              `json:"converted_total,omitempty"`
+	MissingRates      []string              `json:"missing_rates,omitempty"`
+	RatesAsOf         time.Time             `json:"rates_as_of"`
+}
+
+// GetSettlementReport aggregates paid revenue per currency. Amounts are never summed
+// across currencies unless a reporting currency is requested, in which case each
+// currency is converted at the admin-maintained rate in force at the end of the period.
+func (s *FXService) GetSettlementReport(ctx context.Context, req *SettlementReportRequest) (*SettlementReport, error) {
+	revenue, err := s.orderRepo.GetRevenueByCurrency(ctx, repositories.RevenueFilter{
+		EventID:     req.EventID,
+		OrganizerID: req.OrganizerID,
+		DateFrom:    req.DateFrom,
+		DateTo:      req.DateTo,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revenue by currency: %w", err)
+	}
+
+	asOf := time.Now()
+	if req.DateTo != nil && req.DateTo.Before(asOf) {
+		asOf = *req.DateTo
+	}
+
+	report := &SettlementReport{
+		Currencies: make([]*CurrencySettlement, len(revenue)),
+		RatesAsOf:  asOf,
+	}
+	for i, row := range revenue {
+		report.Currencies[i] = &CurrencySettlement{CurrencyRevenue: *row}
+	}
+
+	if req.ReportingCurrency == "" {
+		return report, nil
+	}
+
+	reportingCurrency := entities.NormalizeCurrency(req.ReportingCurrency)
+	if !entities.IsSupportedCurrency(reportingCurrency) {
+		return nil, entities.NewValidationError("reporting_currency", "unsupported reporting currency")
+	}
+	report.ReportingCurrency = reportingCurrency
+
+	var total float64
+	for _, row := range report.Currencies {
+		rate, err := s.GetRate(ctx, row.Currency, reportingCurrency, asOf)
+		if err != nil {
+			if err == entities.ErrFXRateNotFound {
+				report.MissingRates = append(report.MissingRates, fmt.Sprintf("%s/%s", row.Currency, reportingCurrency))
+				continue
+			}
+			return nil, fmt.Errorf("failed to get fx rate: %w", err)
+		}
+		converted := math.Round(row.GrossRevenue*rate*100) / 100
+		row.FXRate = &rate
+		row.ConvertedRevenue = &converted
+		total += converted
+	}
+
+	// Only report a converted total when every currency could be converted
+	if len(report.MissingRates) == 0 {
+		total = math.Round(total*100) / 100
+		report.ConvertedTotal = &total
+	}
+
+	return report, nil
+}
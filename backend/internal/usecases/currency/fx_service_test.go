@@ -0,0 +1,218 @@
+package currency
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+// fakeRates returns the latest rate for a pair effective at the given instant
+type fakeRates struct {
+	repositories.FXRateRepository
+	rates []*entities.FXRate
+}
+
+func (f *fakeRates) Create(ctx context.Context, rate *entities.FXRate) error {
+	f.rates = append(f.rates, rate)
+	return nil
+}
+
+func (f *fakeRates) GetEffective(ctx context.Context, baseCurrency, quoteCurrency string, at time.Time) (*entities.FXRate, error) {
+	var effective *entities.FXRate
+	for _, rate := range f.rates {
+		if rate.BaseCurrency != baseCurrency || rate.QuoteCurrency != quoteCurrency || rate.EffectiveAt.After(at) {
+			continue
+		}
+		if effective == nil || rate.EffectiveAt.After(effective.EffectiveAt) {
+			effective = rate
+		}
+	}
+	if effective == nil {
+		return nil, entities.ErrFXRateNotFound
+	}
+	return effective, nil
+}
+
+type fakeOrders struct {
+	repositories.OrderRepository
+	revenue []*repositories.CurrencyRevenue
+	filter  repositories.RevenueFilter
+}
+
+func (f *fakeOrders) GetRevenueByCurrency(ctx context.Context, filter repositories.RevenueFilter) ([]*repositories.CurrencyRevenue, error) {
+	f.filter = filter
+	return f.revenue, nil
+}
+
+type fxFixture struct {
+	service *FXService
+	rates   *fakeRates
+	orders  *fakeOrders
+	endOfQ1 time.Time
+}
+
+// newFXFixture builds an FX service over fakes, with paid revenue in naira and
+// cedis. The naira rate to dollars changed at the end of March and only the
+// dollar to cedi direction is maintained.
+func newFXFixture(t *testing.T) *fxFixture {
+	t.Helper()
+
+	endOfQ1 := time.Date(2026, time.April, 1, 0, 0, 0, 0, time.UTC)
+	rate := func(base, quote string, value float64, effectiveAt time.Time) *entities.FXRate {
+		r := entities.NewFXRate(base, quote, value)
+		r.EffectiveAt = effectiveAt
+		return r
+	}
+
+	rates := &fakeRates{rates: []*entities.FXRate{
+		rate("NGN", "USD", 0.0008, endOfQ1.AddDate(0, -3, 0)),
+		rate("NGN", "USD", 0.0006, endOfQ1.Add(time.Hour)),
+		rate("USD", "GHS", 12.5, endOfQ1.AddDate(0, -3, 0)),
+	}}
+	orders := &fakeOrders{revenue: []*repositories.CurrencyRevenue{
+		{Currency: "NGN", PaidOrders: 4, TicketsSold: 9, GrossRevenue: 1250000},
+		{Currency: "GHS", PaidOrders: 2, TicketsSold: 3, GrossRevenue: 1000},
+	}}
+
+	return &fxFixture{
+		service: NewFXService(rates, orders),
+		rates:   rates,
+		orders:  orders,
+		endOfQ1: endOfQ1,
+	}
+}
+
+func TestGetRateUsesStoredPairOrItsInverse(t *testing.T) {
+	f := newFXFixture(t)
+	ctx := context.Background()
+
+	tests := []struct {
+		from, to string
+		want     float64
+	}{
+		{from: "ngn", to: "NGN", want: 1},
+		{from: "NGN", to: "USD", want: 0.0008},
+		{from: "GHS", to: "USD", want: 0.08},
+	}
+	for _, tt := range tests {
+		got, err := f.service.GetRate(ctx, tt.from, tt.to, f.endOfQ1)
+		if err != nil {
+			t.Fatalf("GetRate(%s, %s) error = %v", tt.from, tt.to, err)
+		}
+		if got != tt.want {
+			t.Errorf("GetRate(%s, %s) = %v, want %v", tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	// Rates published later do not apply to earlier instants
+	if got, _ := f.service.GetRate(ctx, "NGN", "USD", f.endOfQ1.Add(2*time.Hour)); got != 0.0006 {
+		t.Errorf("GetRate() after the change = %v, want 0.0006", got)
+	}
+
+	if _, err := f.service.GetRate(ctx, "NGN", "KES", f.endOfQ1); err != entities.ErrFXRateNotFound {
+		t.Errorf("GetRate() for an unmaintained pair error = %v, want %v", err, entities.ErrFXRateNotFound)
+	}
+}
+
+func TestCreateRateRejectsInvalidPairs(t *testing.T) {
+	f := newFXFixture(t)
+
+	tests := []struct {
+		name string
+		req  CreateRateRequest
+	}{
+		{name: "unsupported currency", req: CreateRateRequest{BaseCurrency: "EUR", QuoteCurrency: "NGN", Rate: 1600}},
+		{name: "same currency", req: CreateRateRequest{BaseCurrency: "usd", QuoteCurrency: "USD", Rate: 1}},
+		{name: "non-positive rate", req: CreateRateRequest{BaseCurrency: "USD", QuoteCurrency: "NGN", Rate: 0}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var validationErr *entities.ValidationError
+			if _, err := f.service.CreateRate(context.Background(), &tt.req); !errors.As(err, &validationErr) {
+				t.Errorf("CreateRate() error = %v, want a validation error", err)
+			}
+		})
+	}
+	if len(f.rates.rates) != 3 {
+		t.Errorf("CreateRate() stored %d rates, want none added", len(f.rates.rates)-3)
+	}
+}
+
+func TestSettlementReportKeepsCurrenciesApart(t *testing.T) {
+	f := newFXFixture(t)
+	eventID := uuid.New()
+
+	report, err := f.service.GetSettlementReport(context.Background(), &SettlementReportRequest{EventID: &eventID})
+	if err != nil {
+		t.Fatalf("GetSettlementReport() error = %v", err)
+	}
+
+	if f.orders.filter.EventID == nil || *f.orders.filter.EventID != eventID {
+		t.Errorf("revenue filter = %+v, want event %s", f.orders.filter, eventID)
+	}
+	if len(report.Currencies) != 2 || report.Currencies[0].GrossRevenue != 1250000 || report.Currencies[1].GrossRevenue != 1000 {
+		t.Fatalf("GetSettlementReport() currencies = %+v, want naira and cedi revenue as settled", report.Currencies)
+	}
+	if report.ConvertedTotal != nil || report.Currencies[0].ConvertedRevenue != nil {
+		t.Errorf("GetSettlementReport() converted without a reporting currency")
+	}
+}
+
+func TestSettlementReportConvertsAtRatesInForceAtPeriodEnd(t *testing.T) {
+	f := newFXFixture(t)
+
+	report, err := f.service.GetSettlementReport(context.Background(), &SettlementReportRequest{
+		DateTo:            &f.endOfQ1,
+		ReportingCurrency: "usd",
+	})
+	if err != nil {
+		t.Fatalf("GetSettlementReport() error = %v", err)
+	}
+
+	if report.ReportingCurrency != "USD" || !report.RatesAsOf.Equal(f.endOfQ1) {
+		t.Errorf("report in %s as of %v, want USD as of %v", report.ReportingCurrency, report.RatesAsOf, f.endOfQ1)
+	}
+	// 1,250,000 NGN at 0.0008 and 1,000 GHS at 1/12.5
+	if got := *report.Currencies[0].ConvertedRevenue; got != 1000 {
+		t.Errorf("naira converted = %v, want 1000", got)
+	}
+	if got := *report.Currencies[1].ConvertedRevenue; got != 80 {
+		t.Errorf("cedis converted = %v, want 80", got)
+	}
+	if report.ConvertedTotal == nil || *report.ConvertedTotal != 1080 {
+		t.Errorf("converted total = %v, want 1080", report.ConvertedTotal)
+	}
+}
+
+func TestSettlementReportOmitsTotalWhenRateMissing(t *testing.T) {
+	f := newFXFixture(t)
+
+	report, err := f.service.GetSettlementReport(context.Background(), &SettlementReportRequest{
+		DateTo:            &f.endOfQ1,
+		ReportingCurrency: "KES",
+	})
+	if err != nil {
+		t.Fatalf("GetSettlementReport() error = %v", err)
+	}
+
+	if got := strings.Join(report.MissingRates, ","); got != "NGN/KES,GHS/KES" {
+		t.Errorf("missing rates = %s, want NGN/KES,GHS/KES", got)
+	}
+	if report.ConvertedTotal != nil {
+		t.Errorf("converted total = %v, want none when a currency cannot be converted", *report.ConvertedTotal)
+	}
+
+	var validationErr *entities.ValidationError
+	if _, err := f.service.GetSettlementReport(context.Background(), &SettlementReportRequest{ReportingCurrency: "EUR"}); !errors.As(err, &validationErr) {
+		t.Errorf("GetSettlementReport() in EUR error = %v, want a validation error", err)
+	}
+}
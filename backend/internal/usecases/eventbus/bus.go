@@ -0,0 +1,184 @@
+package eventbus
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
+)
+
+// topicPrefix marks outbox topics that carry domain events
+const topicPrefix = "event."
+
+// Handler reacts to a domain event. Handlers receive the event as published,
+// e.g. *entities.OrderPaid, and type-assert it.
+type Handler func(ctx context.Context, event entities.DomainEvent) error
+
+// subscription is an asynchronous subscriber of one event type
+type subscription struct {
+	name      string
+	eventName string
+}
+
+// Bus delivers domain events published by the use cases to subscribers.
+//
+// Synchronous subscribers run inside Publish, in the order they subscribed; the
+// first error stops publishing and is returned, so a publisher inside a
+// transaction rolls back. They must not have side effects outside the database.
+//
+// Asynchronous subscribers get the event through the outbox: Publish records one
+// outbox message per subscriber, in the publisher's transaction when there is
+// one, and the dispatcher delivers each with its own retries.
+type Bus struct {
+	outboxRepo repositories.OutboxRepository
+	dispatcher *outbox.Dispatcher
+
+	mu            sync.RWMutex
+	handlers      map[string][]Handler
+	allHandlers   []Handler
+	subscriptions map[string][]subscription
+}
+
+// NewBus creates a bus that queues asynchronous deliveries in outboxRepo and
+// registers asynchronous subscribers with dispatcher. Both may be nil for a bus
+// with synchronous subscribers only.
+func NewBus(outboxRepo repositories.OutboxRepository, dispatcher *outbox.Dispatcher) *Bus {
+	return &Bus{
+		outboxRepo:    outboxRepo,
+		dispatcher:    dispatcher,
+		handlers:      make(map[string][]Handler),
+		subscriptions: make(map[string][]subscription),
+	}
+}
+
+// Subscribe runs handler inside Publish for every event with the given name
+func (b *Bus) Subscribe(eventName string, handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventName] = append(b.handlers[eventName], handler)
+}
+
+// SubscribeAll runs handler inside Publish for every event
+func (b *Bus) SubscribeAll(handler Handler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.allHandlers = append(b.allHandlers, handler)
+}
+
+// SubscribeAsync delivers every event with the given name to handler through
+// the outbox. name identifies the subscriber and must be unique per event, as
+// it becomes part of the outbox topic; renaming a subscriber orphans messages
+// still queued under the old name.
+func (b *Bus) SubscribeAsync(name, eventName string, handler Handler) {
+	if b.dispatcher == nil || b.outboxRepo == nil {
+		panic("eventbus: asynchronous subscribers need an outbox and a dispatcher")
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for _, sub := range b.subscriptions[eventName] {
+		if sub.name == name {
+			panic(fmt.Sprintf("eventbus: %s already has a subscriber named %q", eventName, name))
+		}
+	}
+	b.subscriptions[eventName] = append(b.subscriptions[eventName], subscription{name: name, eventName: eventName})
+
+	b.dispatcher.Handle(asyncTopic(eventName, name), func(ctx context.Context, message *entities.OutboxMessage) error {
+		event, err := decodeEvent(eventName, message)
+		if err != nil {
+			return err
+		}
+		return handler(ctx, event)
+	})
+}
+
+// Publish publishes events outside a transaction
+func (b *Bus) Publish(ctx context.Context, events ...entities.DomainEvent) error {
+	return b.publish(ctx, b.outboxRepo, events)
+}
+
+// PublishTx publishes events within tx, so asynchronous subscribers only hear
+// of them if the transaction commits
+func (b *Bus) PublishTx(ctx context.Context, tx repositories.Transaction, events ...entities.DomainEvent) error {
+	return b.publish(ctx, tx.Outbox(), events)
+}
+
+func (b *Bus) publish(ctx context.Context, outboxRepo repositories.OutboxRepository, events []entities.DomainEvent) error {
+	for _, event := range events {
+		handlers, subs := b.subscribers(event.EventName())
+
+		for _, handler := range handlers {
+			if err := handler(ctx, event); err != nil {
+				return fmt.Errorf("%s subscriber failed: %w", event.EventName(), err)
+			}
+		}
+
+		if len(subs) == 0 {
+			continue
+		}
+		payload, err := encodeEvent(event)
+		if err != nil {
+			return err
+		}
+		for _, sub := range subs {
+			message := entities.NewOutboxMessage(asyncTopic(sub.eventName, sub.name), payload)
+			message.SetAggregate(event.AggregateType(), event.AggregateID())
+			if err := outboxRepo.Create(ctx, message); err != nil {
+				return fmt.Errorf("failed to queue %s for %s: %w", event.EventName(), sub.name, err)
+			}
+		}
+	}
+
+	return nil
+}
+
+// subscribers returns a snapshot of an event's subscribers, so handlers may
+// publish further events without holding the lock
+func (b *Bus) subscribers(eventName string) ([]Handler, []subscription) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	handlers := make([]Handler, 0, len(b.handlers[eventName])+len(b.allHandlers))
+	handlers = append(handlers, b.handlers[eventName]...)
+	handlers = append(handlers, b.allHandlers...)
+	subs := append([]subscription(nil), b.subscriptions[eventName]...)
+	return handlers, subs
+}
+
+// asyncTopic is the outbox topic of one asynchronous subscriber, e.g. "event.order.paid.analytics"
+func asyncTopic(eventName, subscriberName string) string {
+	return topicPrefix + eventName + "." + subscriberName
+}
+
+func encodeEvent(event entities.DomainEvent) (entities.JSONB, error) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.EventName(), err)
+	}
+
+	var payload entities.JSONB
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to encode %s event: %w", event.EventName(), err)
+	}
+	return payload, nil
+}
+
+func decodeEvent(eventName string, message *entities.OutboxMessage) (entities.DomainEvent, error) {
+	event, ok := entities.NewDomainEvent(eventName)
+	if !ok {
+		return nil, fmt.Errorf("unknown domain event %q", eventName)
+	}
+
+	data, err := json.Marshal(message.Payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", eventName, err)
+	}
+	if err := json.Unmarshal(data, event); err != nil {
+		return nil, fmt.Errorf("failed to decode %s event: %w", eventName, err)
+	}
+	return event, nil
+}
@@ -0,0 +1,118 @@
+package eventbus
+
+import (
+	"context"
+	"sync"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+)
+
+// TB is the part of testing.TB the recorder reports failures through
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Recorder captures every event published on a bus, so tests can assert what
+// a use case emitted:
+//
+//	bus := eventbus.NewBus(nil, nil)
+//	recorded := eventbus.NewRecorder(bus)
+//	// ... run the use case with bus ...
+//	recorded.AssertPublished(t, entities.DomainEventOrderPaid, 1)
+//	paid := recorded.Last(entities.DomainEventOrderPaid).(*entities.OrderPaid)
+type Recorder struct {
+	mu     sync.Mutex
+	events []entities.DomainEvent
+}
+
+// NewRecorder subscribes a recorder to every event on bus
+func NewRecorder(bus *Bus) *Recorder {
+	r := &Recorder{}
+	bus.SubscribeAll(func(ctx context.Context, event entities.DomainEvent) error {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.events = append(r.events, event)
+		return nil
+	})
+	return r
+}
+
+// Events returns every recorded event in publishing order
+func (r *Recorder) Events() []entities.DomainEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]entities.DomainEvent(nil), r.events...)
+}
+
+// Named returns the recorded events with the given name in publishing order
+func (r *Recorder) Named(eventName string) []entities.DomainEvent {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var named []entities.DomainEvent
+	for _, event := range r.events {
+		if event.EventName() == eventName {
+			named = append(named, event)
+		}
+	}
+	return named
+}
+
+// Last returns the most recent event with the given name, or nil
+func (r *Recorder) Last(eventName string) entities.DomainEvent {
+	named := r.Named(eventName)
+	if len(named) == 0 {
+		return nil
+	}
+	return named[len(named)-1]
+}
+
+// Reset forgets every recorded event
+func (r *Recorder) Reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.events = nil
+}
+
+// AssertPublished reports a failure unless exactly count events with the given name were recorded
+func (r *Recorder) AssertPublished(t TB, eventName string, count int) bool {
+	t.Helper()
+	if got := len(r.Named(eventName)); got != count {
+		t.Errorf("expected %d %s events, got %d (recorded: %v)", count, eventName, got, r.names())
+		return false
+	}
+	return true
+}
+
+// AssertNotPublished reports a failure if any event with the given name was recorded
+func (r *Recorder) AssertNotPublished(t TB, eventName string) bool {
+	t.Helper()
+	return r.AssertPublished(t, eventName, 0)
+}
+
+// AssertSequence reports a failure unless the recorded event names are exactly eventNames, in order
+func (r *Recorder) AssertSequence(t TB, eventNames ...string) bool {
+	t.Helper()
+	got := r.names()
+	if len(got) != len(eventNames) {
+		t.Errorf("expected events %v, got %v", eventNames, got)
+		return false
+	}
+	for i := range got {
+		if got[i] != eventNames[i] {
+			t.Errorf("expected events %v, got %v", eventNames, got)
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Recorder) names() []string {
+	events := r.Events()
+	names := make([]string, len(events))
+	for i, event := range events {
+		names[i] = event.EventName()
+	}
+	return names
+}
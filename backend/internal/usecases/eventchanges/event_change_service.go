@@ -0,0 +1,616 @@
+package eventchanges
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/payments"
+	"github.com/uduxpass/backend/pkg/security"
+)
+
+const (
+	// DefaultBatchSize is the number of ticket holders handled per batch
+	DefaultBatchSize = 50
+
+	// defaultBatchPause spaces batches out to stay within email and SMS provider rate limits
+	defaultBatchPause = time.Second
+)
+
+// EventChangeService cancels and postpones events. The event is updated and the
+// change recorded in one transaction; a background worker then stages one
+// recipient per paid order and works through them in batches, voiding tickets,
+// refunding and notifying each holder. Every step is recorded on the recipient,
+// so a worker that stops partway (a crash or restart) resumes where it left off
+// without voiding, refunding or notifying anyone twice.
+type EventChangeService struct {
+	changeRepo     repositories.EventChangeRepository
+	eventRepo      repositories.EventRepository
+	ticketRepo     repositories.TicketRepository
+	unitOfWork     repositories.UnitOfWork
+	paymentService *payments.PaymentService
+	emailService   services.EmailService
+	smsService     security.SMSService
+	renderer       services.MessageRenderer
+	eventBus       *eventbus.Bus
+	batchSize      int
+	batchPause     time.Duration
+
+	mu      sync.Mutex
+	running map[uuid.UUID]bool
+}
+
+// NewEventChangeService creates a new event change service
+func NewEventChangeService(
+	changeRepo repositories.EventChangeRepository,
+	eventRepo repositories.EventRepository,
+	ticketRepo repositories.TicketRepository,
+	unitOfWork repositories.UnitOfWork,
+	paymentService *payments.PaymentService,
+	emailService services.EmailService,
+	smsService security.SMSService,
+	renderer services.MessageRenderer,
+	eventBus *eventbus.Bus,
+) *EventChangeService {
+	return &EventChangeService{
+		changeRepo:     changeRepo,
+		eventRepo:      eventRepo,
+		ticketRepo:     ticketRepo,
+		unitOfWork:     unitOfWork,
+		paymentService: paymentService,
+		emailService:   emailService,
+		smsService:     smsService,
+		renderer:       renderer,
+		eventBus:       eventBus,
+		batchSize:      DefaultBatchSize,
+		batchPause:     defaultBatchPause,
+		running:        make(map[uuid.UUID]bool),
+	}
+}
+
+// CancelEventRequest represents the request to cancel an event
+type CancelEventRequest struct {
+	Reason       *string               `json:"reason,omitempty" validate:"omitempty,max=500"`
+	Message      *string               `json:"message,omitempty"`
+	VoidTickets  bool                  `json:"void_tickets"`
+	RefundPolicy entities.RefundPolicy `json:"refund_policy"`
+	NotifyEmail  *bool                 `json:"notify_email,omitempty"`
+	NotifySMS    bool                  `json:"notify_sms"`
+	CreatedBy    *uuid.UUID            `json:"-"`
+}
+
+// PostponeEventRequest represents the request to move an event to a new date
+type PostponeEventRequest struct {
+	NewDate        time.Time             `json:"new_date" validate:"required"`
+	Reason         *string               `json:"reason,omitempty" validate:"omitempty,max=500"`
+	Message        *string               `json:"message,omitempty"`
+	RefundPolicy   entities.RefundPolicy `json:"refund_policy"`
+	RefundDeadline *time.Time            `json:"refund_deadline,omitempty"`
+	NotifyEmail    *bool                 `json:"notify_email,omitempty"`
+	NotifySMS      bool                  `json:"notify_sms"`
+	CreatedBy      *uuid.UUID            `json:"-"`
+}
+
+// CancelEvent cancels an event and starts handling its ticket holders in the background
+func (s *EventChangeService) CancelEvent(ctx context.Context, eventID uuid.UUID, req *CancelEventRequest) (*entities.EventChange, error) {
+	event, err := s.getChangeableEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	change := entities.NewEventChange(event, entities.EventChangeCancellation)
+	change.Reason = req.Reason
+	change.Message = req.Message
+	change.VoidTickets = req.VoidTickets
+	if req.RefundPolicy != "" {
+		change.RefundPolicy = req.RefundPolicy
+	}
+	if req.NotifyEmail != nil {
+		change.NotifyEmail = *req.NotifyEmail
+	}
+	change.NotifySMS = req.NotifySMS
+	change.CreatedBy = req.CreatedBy
+	if err := change.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := event.Cancel(); err != nil {
+		return nil, err
+	}
+
+	return s.recordChange(ctx, event, change)
+}
+
+// PostponeEvent moves an event to a new date and starts handling its ticket
+// holders in the background. Doors and the end of the sale move with the event.
+func (s *EventChangeService) PostponeEvent(ctx context.Context, eventID uuid.UUID, req *PostponeEventRequest) (*entities.EventChange, error) {
+	event, err := s.getChangeableEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	change := entities.NewEventChange(event, entities.EventChangePostponement)
+	newDate := req.NewDate
+	change.NewDate = &newDate
+	change.Reason = req.Reason
+	change.Message = req.Message
+	if req.RefundPolicy != "" {
+		change.RefundPolicy = req.RefundPolicy
+	}
+	change.RefundDeadline = req.RefundDeadline
+	if req.NotifyEmail != nil {
+		change.NotifyEmail = *req.NotifyEmail
+	}
+	change.NotifySMS = req.NotifySMS
+	change.CreatedBy = req.CreatedBy
+	if err := change.Validate(); err != nil {
+		return nil, err
+	}
+
+	shift := newDate.Sub(event.EventDate)
+	event.EventDate = newDate
+	if event.DoorsOpen != nil {
+		doorsOpen := event.DoorsOpen.Add(shift)
+		event.DoorsOpen = &doorsOpen
+	}
+	if event.SaleEnd != nil {
+		saleEnd := event.SaleEnd.Add(shift)
+		event.SaleEnd = &saleEnd
+	}
+	event.UpdatedAt = time.Now()
+
+	return s.recordChange(ctx, event, change)
+}
+
+// getChangeableEvent loads an event that can still be cancelled or postponed
+func (s *EventChangeService) getChangeableEvent(ctx context.Context, eventID uuid.UUID) (*entities.Event, error) {
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("event", "event not found")
+	}
+
+	switch event.Status {
+	case entities.EventStatusCancelled:
+		return nil, entities.NewBusinessRuleError("event_cancelled", "event has already been cancelled", nil)
+	case entities.EventStatusCompleted:
+		return nil, entities.NewBusinessRuleError("event_completed", "event has already taken place", nil)
+	}
+
+	existing, err := s.changeRepo.GetUnfinishedByEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		return nil, entities.NewConflictError("event_change", "a previous change to this event is still being processed", nil)
+	}
+
+	return event, nil
+}
+
+// recordChange saves the event and the change together, then starts the worker
+func (s *EventChangeService) recordChange(ctx context.Context, event *entities.Event, change *entities.EventChange) (*entities.EventChange, error) {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.Events().Update(tx.Context(), event); err != nil {
+		return nil, fmt.Errorf("failed to update event: %w", err)
+	}
+	if err := tx.EventChanges().Create(tx.Context(), change); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.startProcessing(change.ID)
+	return change, nil
+}
+
+// GetChange retrieves an event change and its progress counters
+func (s *EventChangeService) GetChange(ctx context.Context, changeID uuid.UUID) (*entities.EventChange, error) {
+	change, err := s.changeRepo.GetByID(ctx, changeID)
+	if err != nil {
+		if errors.Is(err, entities.ErrEventChangeNotFound) {
+			return nil, entities.NewNotFoundError("event_change", "event change not found")
+		}
+		return nil, err
+	}
+	return change, nil
+}
+
+// ListChanges retrieves event changes with filtering and pagination
+func (s *EventChangeService) ListChanges(ctx context.Context, filter repositories.EventChangeFilter) ([]*entities.EventChange, *repositories.PaginationResult, error) {
+	return s.changeRepo.List(ctx, filter)
+}
+
+// ListRecipients retrieves the per-holder delivery and refund report of a change
+func (s *EventChangeService) ListRecipients(ctx context.Context, filter repositories.EventChangeRecipientFilter) ([]*entities.EventChangeRecipient, *repositories.PaginationResult, error) {
+	if _, err := s.GetChange(ctx, filter.ChangeID); err != nil {
+		return nil, nil, err
+	}
+	return s.changeRepo.ListRecipients(ctx, filter)
+}
+
+// ResumeChange restarts processing of the recipients still pending, e.g. after a failure
+func (s *EventChangeService) ResumeChange(ctx context.Context, changeID uuid.UUID) (*entities.EventChange, error) {
+	change, err := s.GetChange(ctx, changeID)
+	if err != nil {
+		return nil, err
+	}
+	if change.IsFinished() {
+		counts, err := s.changeRepo.CountRecipients(ctx, change.ID)
+		if err != nil {
+			return nil, err
+		}
+		if counts.Pending == 0 {
+			return nil, entities.NewBusinessRuleError("event_change_finished", "every ticket holder has already been processed", nil)
+		}
+	}
+
+	if !s.startProcessing(change.ID) {
+		return nil, entities.NewConflictError("event_change", "event change is already being processed", nil)
+	}
+
+	return change, nil
+}
+
+// ResumeUnfinished restarts the changes a previous process left pending or
+// processing. It is called once at startup.
+func (s *EventChangeService) ResumeUnfinished(ctx context.Context) error {
+	changes, err := s.changeRepo.GetUnfinished(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, change := range changes {
+		if s.startProcessing(change.ID) {
+			fmt.Printf("Resuming event change %s for event %s\n", change.ID, change.EventID)
+		}
+	}
+	return nil
+}
+
+// ChoiceInfo is what a ticket holder sees when they open their keep-or-refund link
+type ChoiceInfo struct {
+	EventID        uuid.UUID                      `json:"event_id"`
+	EventName      string                         `json:"event_name"`
+	VenueName      string                         `json:"venue_name"`
+	PreviousDate   time.Time                      `json:"previous_date"`
+	NewDate        *time.Time                     `json:"new_date,omitempty"`
+	RefundDeadline *time.Time                     `json:"refund_deadline,omitempty"`
+	CanChoose      bool                           `json:"can_choose"`
+	Choice         *entities.RefundChoice         `json:"choice,omitempty"`
+	RefundStatus   entities.RecipientRefundStatus `json:"refund_status"`
+}
+
+// RecordChoiceRequest represents a holder's keep-or-refund answer
+type RecordChoiceRequest struct {
+	Choice entities.RefundChoice `json:"choice" validate:"required,oneof=keep refund"`
+}
+
+// GetChoice returns the keep-or-refund offer behind a link token
+func (s *EventChangeService) GetChoice(ctx context.Context, token string) (*ChoiceInfo, error) {
+	recipient, change, event, err := s.getChoice(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return newChoiceInfo(recipient, change, event), nil
+}
+
+// RecordChoice records a holder's answer before the deadline. A refund is
+// handed to the worker, which processes it like an automatic one.
+func (s *EventChangeService) RecordChoice(ctx context.Context, token string, req *RecordChoiceRequest) (*ChoiceInfo, error) {
+	recipient, change, event, err := s.getChoice(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	if !change.AcceptsChoices() {
+		return nil, entities.NewBusinessRuleError("choice_closed", "the deadline for choosing a refund has passed", nil)
+	}
+
+	if err := recipient.Choose(req.Choice); err != nil {
+		return nil, err
+	}
+	if err := s.changeRepo.UpdateRecipient(ctx, recipient); err != nil {
+		return nil, err
+	}
+
+	if req.Choice == entities.RefundChoiceRefund {
+		s.startProcessing(change.ID)
+	}
+
+	return newChoiceInfo(recipient, change, event), nil
+}
+
+func (s *EventChangeService) getChoice(ctx context.Context, token string) (*entities.EventChangeRecipient, *entities.EventChange, *entities.Event, error) {
+	recipient, err := s.changeRepo.GetRecipientByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, entities.ErrEventChangeRecipientNotFound) {
+			return nil, nil, nil, entities.NewNotFoundError("choice", "this link is invalid")
+		}
+		return nil, nil, nil, err
+	}
+
+	change, err := s.GetChange(ctx, recipient.ChangeID)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if change.RefundPolicy != entities.RefundPolicyChoice {
+		return nil, nil, nil, entities.NewNotFoundError("choice", "this link is invalid")
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, change.EventID)
+	if err != nil {
+		return nil, nil, nil, entities.NewNotFoundError("event", "event not found")
+	}
+
+	return recipient, change, event, nil
+}
+
+func newChoiceInfo(recipient *entities.EventChangeRecipient, change *entities.EventChange, event *entities.Event) *ChoiceInfo {
+	return &ChoiceInfo{
+		EventID:        event.ID,
+		EventName:      event.Name,
+		VenueName:      event.VenueName,
+		PreviousDate:   change.PreviousDate,
+		NewDate:        change.NewDate,
+		RefundDeadline: change.RefundDeadline,
+		CanChoose:      change.AcceptsChoices() && recipient.RefundChoice == nil,
+		Choice:         recipient.RefundChoice,
+		RefundStatus:   recipient.RefundStatus,
+	}
+}
+
+// startProcessing launches the background worker unless one is already running for the change
+func (s *EventChangeService) startProcessing(changeID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[changeID] {
+		return false
+	}
+	s.running[changeID] = true
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, changeID)
+			s.mu.Unlock()
+		}()
+		if err := s.process(context.Background(), changeID); err != nil {
+			fmt.Printf("Warning: event change %s stopped: %v\n", changeID, err)
+		}
+	}()
+	return true
+}
+
+// process stages the recipients once, then works through the pending ones batch by batch
+func (s *EventChangeService) process(ctx context.Context, changeID uuid.UUID) error {
+	change, err := s.changeRepo.GetByID(ctx, changeID)
+	if err != nil {
+		return err
+	}
+
+	change.MarkProcessing()
+	if err := s.changeRepo.Update(ctx, change); err != nil {
+		return err
+	}
+
+	fail := func(err error) error {
+		change.MarkFailed(err.Error())
+		if updateErr := s.changeRepo.Update(ctx, change); updateErr != nil {
+			fmt.Printf("Warning: failed to record event change %s failure: %v\n", changeID, updateErr)
+		}
+		return err
+	}
+
+	event, err := s.eventRepo.GetByID(ctx, change.EventID)
+	if err != nil {
+		return fail(fmt.Errorf("failed to get event: %w", err))
+	}
+
+	// Holders are captured once; orders placed after a postponement already know the new date
+	if change.RecipientsStagedAt == nil {
+		if err := s.stageRecipients(ctx, change); err != nil {
+			return fail(err)
+		}
+	}
+
+	for {
+		recipients, err := s.changeRepo.GetPendingRecipients(ctx, changeID, s.batchSize)
+		if err != nil {
+			return fail(err)
+		}
+		if len(recipients) == 0 {
+			break
+		}
+
+		for _, recipient := range recipients {
+			if err := s.processRecipient(ctx, change, event, recipient); err != nil {
+				return fail(err)
+			}
+		}
+
+		if err := s.refreshProgress(ctx, change); err != nil {
+			return fail(err)
+		}
+		time.Sleep(s.batchPause)
+	}
+
+	if err := s.refreshProgress(ctx, change); err != nil {
+		return fail(err)
+	}
+	change.MarkFinished()
+	return s.changeRepo.Update(ctx, change)
+}
+
+// stageRecipients records one recipient per paid order in the same transaction
+// that marks the change as staged, so a restart never stages twice
+func (s *EventChangeService) stageRecipients(ctx context.Context, change *entities.EventChange) error {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	staged, err := tx.EventChanges().StageRecipients(tx.Context(), change)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	change.RecipientsStagedAt = &now
+	change.TotalRecipients = staged
+	change.UpdatedAt = now
+	if err := tx.EventChanges().Update(tx.Context(), change); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// processRecipient runs the steps still pending for one holder: void, refund,
+// email, SMS. Step failures are recorded on the recipient and retried on a later
+// batch; only a failure to save the recipient stops the worker.
+func (s *EventChangeService) processRecipient(ctx context.Context, change *entities.EventChange, event *entities.Event, recipient *entities.EventChangeRecipient) error {
+	var stepErrors []string
+
+	if change.VoidTickets && !recipient.TicketsVoided {
+		if err := s.voidTickets(ctx, change.EventID, recipient.OrderID); err != nil {
+			stepErrors = append(stepErrors, fmt.Sprintf("void tickets: %v", err))
+		} else {
+			recipient.TicketsVoided = true
+		}
+	}
+
+	if recipient.RefundStatus == entities.RecipientRefundPending {
+		_, err := s.paymentService.RefundOrder(ctx, recipient.OrderID, refundReason(change))
+		var businessErr *entities.BusinessRuleError
+		switch {
+		case err == nil:
+			recipient.RefundStatus = entities.RecipientRefundRefunded
+		case errors.As(err, &businessErr):
+			// Retrying cannot help, e.g. a mobile money payment that needs a manual refund
+			recipient.RefundStatus = entities.RecipientRefundFailed
+			message := fmt.Sprintf("refund: %s", businessErr.Message)
+			recipient.LastError = &message
+		default:
+			stepErrors = append(stepErrors, fmt.Sprintf("refund: %v", err))
+		}
+	}
+
+	if recipient.EmailStatus == entities.DeliveryPending {
+		if err := s.emailService.SendEventChangeEmail(ctx, change, event, recipient); err != nil {
+			stepErrors = append(stepErrors, fmt.Sprintf("email: %v", err))
+		} else {
+			recipient.EmailStatus = entities.DeliverySent
+		}
+	}
+
+	if recipient.SMSStatus == entities.DeliveryPending {
+		if err := s.sendSMS(ctx, change, event, recipient); err != nil {
+			stepErrors = append(stepErrors, fmt.Sprintf("sms: %v", err))
+		} else {
+			recipient.SMSStatus = entities.DeliverySent
+		}
+	}
+
+	if len(stepErrors) > 0 {
+		recipient.RecordAttemptError(strings.Join(stepErrors, "; "))
+	} else {
+		recipient.Finish()
+	}
+
+	return s.changeRepo.UpdateRecipient(ctx, recipient)
+}
+
+// voidTickets cancels the order's tickets that are still valid
+func (s *EventChangeService) voidTickets(ctx context.Context, eventID, orderID uuid.UUID) error {
+	tickets, err := s.ticketRepo.GetByOrder(ctx, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get tickets: %w", err)
+	}
+
+	var events []entities.DomainEvent
+	for _, ticket := range tickets {
+		if ticket.Status != entities.TicketStatusActive && !ticket.IsRedeemed() {
+			continue
+		}
+		if err := s.ticketRepo.MarkVoided(ctx, ticket.ID); err != nil && !errors.Is(err, entities.ErrTicketNotFound) {
+			return fmt.Errorf("failed to void ticket %s: %w", ticket.SerialNumber, err)
+		}
+		events = append(events, entities.NewTicketVoided(ticket, eventID, "event_cancelled"))
+	}
+
+	if err := s.eventBus.Publish(ctx, events...); err != nil {
+		fmt.Printf("Warning: failed to publish voided tickets of order %s: %v\n", orderID, err)
+	}
+	return nil
+}
+
+// refreshProgress recounts the recipients so progress stays correct across resumes
+func (s *EventChangeService) refreshProgress(ctx context.Context, change *entities.EventChange) error {
+	counts, err := s.changeRepo.CountRecipients(ctx, change.ID)
+	if err != nil {
+		return err
+	}
+
+	change.TotalRecipients = counts.Total
+	change.NotifiedRecipients = counts.Notified
+	change.FailedRecipients = counts.Failed
+	change.RefundsRequested = counts.RefundsRequested
+	change.RefundsCompleted = counts.RefundsCompleted
+	change.UpdatedAt = time.Now()
+	return s.changeRepo.Update(ctx, change)
+}
+
+func refundReason(change *entities.EventChange) string {
+	if change.Type == entities.EventChangePostponement {
+		return "event postponed"
+	}
+	return "event cancelled"
+}
+
+// sendSMS texts the recipient the event_change SMS template, rendered in
+// their locale; the template is kept short enough for one or two SMS parts
+func (s *EventChangeService) sendSMS(ctx context.Context, change *entities.EventChange, event *entities.Event, recipient *entities.EventChangeRecipient) error {
+	data := map[string]interface{}{
+		"EventName":    event.Name,
+		"Cancelled":    change.Type == entities.EventChangeCancellation,
+		"PreviousDate": change.PreviousDate,
+		"NewDate":      change.NewDate,
+		"Refunding":    change.RefundPolicy == entities.RefundPolicyAutomatic && recipient.RefundStatus != entities.RecipientRefundNone,
+		"CanChoose":    change.RefundPolicy == entities.RefundPolicyChoice && recipient.RefundChoice == nil,
+	}
+	if change.RefundDeadline != nil {
+		data["RefundDeadline"] = change.RefundDeadline
+		data["ChoiceURL"] = fmt.Sprintf("%s/event-changes/choice?token=%s", os.Getenv("FRONTEND_URL"), recipient.ChoiceToken)
+	}
+
+	message, err := s.renderer.Render(ctx, services.RenderRequest{
+		Channel:     entities.NotificationChannelSMS,
+		Key:         services.MessageEventChange,
+		UserID:      recipient.UserID,
+		OrganizerID: event.OrganizerID,
+		Data:        data,
+	})
+	if err != nil {
+		return err
+	}
+	return s.smsService.SendSMS(*recipient.Phone, message.Text)
+}
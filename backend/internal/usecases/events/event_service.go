@@ -3,20 +3,26 @@ package events
 import (
 	"context"
 	"fmt"
+	"math"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/uduxpass/backend/internal/domain/entities"
 	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
 )
 
 // EventService handles event management use cases
 type EventService struct {
-	eventRepo      repositories.EventRepository
-	tourRepo       repositories.TourRepository
-	organizerRepo  repositories.OrganizerRepository
-	ticketTierRepo repositories.TicketTierRepository
-	unitOfWork     repositories.UnitOfWork
+	eventRepo         repositories.EventRepository
+	tourRepo          repositories.TourRepository
+	organizerRepo     repositories.OrganizerRepository
+	ticketTierRepo    repositories.TicketTierRepository
+	venueRepo         repositories.VenueRepository
+	eventTemplateRepo repositories.EventTemplateRepository
+	unitOfWork        repositories.UnitOfWork
+	eventBus          *eventbus.Bus
 }
 
 // NewEventService creates a new event service
@@ -25,14 +31,20 @@ func NewEventService(
 	tourRepo repositories.TourRepository,
 	organizerRepo repositories.OrganizerRepository,
 	ticketTierRepo repositories.TicketTierRepository,
+	venueRepo repositories.VenueRepository,
+	eventTemplateRepo repositories.EventTemplateRepository,
 	unitOfWork repositories.UnitOfWork,
+	eventBus *eventbus.Bus,
 ) *EventService {
 	return &EventService{
-		eventRepo:      eventRepo,
-		tourRepo:       tourRepo,
-		organizerRepo:  organizerRepo,
-		ticketTierRepo: ticketTierRepo,
-		unitOfWork:     unitOfWork,
+		eventRepo:         eventRepo,
+		tourRepo:          tourRepo,
+		organizerRepo:     organizerRepo,
+		ticketTierRepo:    ticketTierRepo,
+		venueRepo:         venueRepo,
+		eventTemplateRepo: eventTemplateRepo,
+		unitOfWork:        unitOfWork,
+		eventBus:          eventBus,
 	}
 }
 
@@ -46,22 +58,30 @@ type TicketTierRequest struct {
 	MaxPurchase int        `json:"max_per_order,omitempty"`
 	SaleStart   *time.Time `json:"sale_start,omitempty"`
 	SaleEnd     *time.Time `json:"sale_end,omitempty"`
+	Currency    string     `json:"currency,omitempty"`
+	// OpensAfter names another tier in the request that must sell out or end
+	// before this one opens, e.g. "Early Bird" for the regular tier
+	OpensAfter string `json:"opens_after,omitempty"`
+	// PricingStrategy makes the tier's price follow demand, with Price as the base
+	PricingStrategy *entities.PricingStrategy `json:"pricing_strategy,omitempty"`
 }
 
 // CreateEventRequest represents the request to create an event
 type CreateEventRequest struct {
 	OrganizerID     uuid.UUID             `json:"organizer_id" validate:"required"`
 	TourID          *uuid.UUID            `json:"tour_id,omitempty"`
+	CategoryID      *uuid.UUID            `json:"category_id,omitempty"`
 	Name            string                `json:"name" validate:"required"`
 	Slug            string                `json:"slug" validate:"required"`
 	Description     *string               `json:"description,omitempty"`
 	EventDate       time.Time             `json:"event_date" validate:"required"`
 	DoorsOpen       *time.Time            `json:"doors_open,omitempty"`
-	VenueName       string                `json:"venue_name" validate:"required"`
-	VenueAddress    string                `json:"venue_address" validate:"required"`
-	VenueCity       string                `json:"venue_city" validate:"required"`
+	VenueID         *uuid.UUID            `json:"venue_id,omitempty"`
+	VenueName       string                `json:"venue_name" validate:"required_without=VenueID"`
+	VenueAddress    string                `json:"venue_address" validate:"required_without=VenueID"`
+	VenueCity       string                `json:"venue_city" validate:"required_without=VenueID"`
 	VenueState      *string               `json:"venue_state,omitempty"`
-	VenueCountry    string                `json:"venue_country" validate:"required"`
+	VenueCountry    string                `json:"venue_country" validate:"required_without=VenueID"`
 	VenueCapacity   *int                  `json:"venue_capacity,omitempty"`
 	VenueLatitude   *float64              `json:"venue_latitude,omitempty"`
 	VenueLongitude  *float64              `json:"venue_longitude,omitempty"`
@@ -74,6 +94,7 @@ type CreateEventRequest struct {
 	TicketTiers     []TicketTierRequest   `json:"ticket_tiers,omitempty"`
 	EnableMomo      *bool                 `json:"enable_momo,omitempty"`
 	EnablePaystack  *bool                 `json:"enable_paystack,omitempty"`
+	Currency        string                `json:"currency,omitempty"`
 }
 
 // CreateEventResponse represents the response from event creation
@@ -103,6 +124,21 @@ func (s *EventService) CreateEvent(ctx context.Context, req *CreateEventRequest)
 		}
 	}
 	
+	// Resolve the venue if provided; its details replace the inline venue fields
+	var venue *entities.Venue
+	if req.VenueID != nil {
+		venue, err = s.venueRepo.GetByID(ctx, *req.VenueID)
+		if err != nil {
+			if err == entities.ErrVenueNotFound {
+				return nil, entities.NewNotFoundError("venue", "venue not found")
+			}
+			return nil, fmt.Errorf("failed to get venue: %w", err)
+		}
+		if !venue.IsActive {
+			return nil, entities.NewValidationError("venue_id", "venue is no longer active")
+		}
+	}
+	
 	// Check if event slug already exists for this organizer
 	exists, err = s.eventRepo.ExistsBySlug(ctx, req.OrganizerID, req.Slug)
 	if err != nil {
@@ -125,10 +161,12 @@ func (s *EventService) CreateEvent(ctx context.Context, req *CreateEventRequest)
 	)
 	
 	// Set optional fields
-	// TourID removed from schema
-	// if req.TourID != nil {
-	// 	event.SetTour(*req.TourID)
-	// }
+	if req.TourID != nil {
+		event.SetTour(*req.TourID)
+	}
+	if req.CategoryID != nil {
+		event.SetCategory(req.CategoryID)
+	}
 	if req.Description != nil {
 		event.Description = req.Description
 	}
@@ -141,10 +179,23 @@ func (s *EventService) CreateEvent(ctx context.Context, req *CreateEventRequest)
 	if req.VenueCapacity != nil {
 		event.VenueCapacity = req.VenueCapacity
 	}
-	// Venue coordinates removed from schema
-	// if req.VenueLatitude != nil && req.VenueLongitude != nil {
-	// 	event.SetVenueLocation(*req.VenueLatitude, *req.VenueLongitude)
-	// }
+	if venue != nil {
+		event.AssignVenue(venue)
+		// An event may use less of the venue than its full capacity
+		if req.VenueCapacity != nil {
+			if venue.Capacity != nil && *req.VenueCapacity > *venue.Capacity {
+				return nil, entities.NewValidationError("venue_capacity", fmt.Sprintf("venue capacity cannot exceed the venue's capacity of %d", *venue.Capacity))
+			}
+			event.VenueCapacity = req.VenueCapacity
+		}
+	} else if req.VenueLatitude != nil || req.VenueLongitude != nil {
+		if req.VenueLatitude == nil || req.VenueLongitude == nil {
+			return nil, entities.NewValidationError("venue_latitude", "venue latitude and longitude must be set together")
+		}
+		if err := event.SetVenueLocation(*req.VenueLatitude, *req.VenueLongitude); err != nil {
+			return nil, err
+		}
+	}
 	if req.EventImageURL != nil {
 		event.SetImage(*req.EventImageURL)
 	}
@@ -169,12 +220,29 @@ func (s *EventService) CreateEvent(ctx context.Context, req *CreateEventRequest)
 	if req.EnablePaystack != nil {
 		event.EnablePaystack = *req.EnablePaystack
 	}
+	if req.Currency != "" {
+		event.Currency = entities.NormalizeCurrency(req.Currency)
+	}
 	
 	// Validate event
 	if err := event.Validate(); err != nil {
 		return nil, err
 	}
 	
+	// Tiers may not offer more tickets than the venue holds
+	if event.VenueCapacity != nil {
+		totalQuota := 0
+		for _, tierReq := range req.TicketTiers {
+			totalQuota += tierReq.Quota
+		}
+		if totalQuota > *event.VenueCapacity {
+			return nil, entities.NewBusinessRuleError("tier_quota_exceeds_capacity", "total ticket tier quota exceeds venue capacity", map[string]interface{}{
+				"capacity":    *event.VenueCapacity,
+				"total_quota": totalQuota,
+			})
+		}
+	}
+	
 	// Begin transaction for atomic event creation
 	tx, err := s.unitOfWork.Begin(ctx)
 	if err != nil {
@@ -193,10 +261,19 @@ func (s *EventService) CreateEvent(ctx context.Context, req *CreateEventRequest)
 	
 	// Create ticket tiers if provided (strategic implementation)
 	if len(req.TicketTiers) > 0 {
-		for _, tierReq := range req.TicketTiers {
+		tiers := make([]*entities.TicketTier, 0, len(req.TicketTiers))
+		tiersByName := make(map[string]*entities.TicketTier, len(req.TicketTiers))
+		for i, tierReq := range req.TicketTiers {
 			// Create ticket tier entity
 			tier := entities.NewTicketTier(event.ID, tierReq.Name, tierReq.Price)
 			
+			// Every tier is priced in the event currency so orders never mix currencies
+			if tierReq.Currency != "" && entities.NormalizeCurrency(tierReq.Currency) != event.Currency {
+				err = entities.NewValidationError("currency", fmt.Sprintf("ticket tier '%s' must be priced in the event currency %s", tierReq.Name, event.Currency))
+				return nil, err
+			}
+			tier.Currency = event.Currency
+			
 			// Set optional fields
 			if tierReq.Description != nil {
 				tier.Description = tierReq.Description
@@ -221,14 +298,40 @@ func (s *EventService) CreateEvent(ctx context.Context, req *CreateEventRequest)
 				}
 			}
 			
+			tier.PricingStrategy = tierReq.PricingStrategy
+			tier.Position = i
+			
 			// Validate tier
 			if err := tier.Validate(); err != nil {
 				return nil, fmt.Errorf("invalid ticket tier '%s': %w", tierReq.Name, err)
 			}
 			
+			tiers = append(tiers, tier)
+			tiersByName[strings.ToLower(strings.TrimSpace(tier.Name))] = tier
+		}
+		
+		// Sequence tiers by name now that every tier of the request has an ID
+		for i, tierReq := range req.TicketTiers {
+			if tierReq.OpensAfter == "" {
+				continue
+			}
+			previous, ok := tiersByName[strings.ToLower(strings.TrimSpace(tierReq.OpensAfter))]
+			if !ok {
+				err = entities.NewValidationError("opens_after", fmt.Sprintf("ticket tier '%s' opens after unknown tier '%s'", tierReq.Name, tierReq.OpensAfter))
+				return nil, err
+			}
+			if err = tiers[i].OpensAfter(previous); err != nil {
+				return nil, err
+			}
+		}
+		if err = entities.ValidateTierSequence(tiers); err != nil {
+			return nil, err
+		}
+		
+		for _, tier := range tiers {
 			// Create tier within transaction
 			if err = tx.TicketTiers().Create(tx.Context(), tier); err != nil {
-				return nil, fmt.Errorf("failed to create ticket tier '%s': %w", tierReq.Name, err)
+				return nil, fmt.Errorf("failed to create ticket tier '%s': %w", tier.Name, err)
 			}
 		}
 	}
@@ -243,18 +346,30 @@ func (s *EventService) CreateEvent(ctx context.Context, req *CreateEventRequest)
 	}, nil
 }
 
+const (
+	// DefaultNearbyRadiusKm is the search radius when a location is given without one
+	DefaultNearbyRadiusKm = 25.0
+
+	// MaxNearbyRadiusKm caps the search radius of a nearby query
+	MaxNearbyRadiusKm = 500.0
+)
+
 // GetPublicEventsRequest represents the request to get public events
 type GetPublicEventsRequest struct {
-	City          string     `json:"city,omitempty"`
-	Country       string     `json:"country,omitempty"`
-	TourID        *uuid.UUID `json:"tour_id,omitempty"`
-	Search        string     `json:"search,omitempty"`
-	EventDateFrom *time.Time `json:"event_date_from,omitempty"`
-	EventDateTo   *time.Time `json:"event_date_to,omitempty"`
-	Page          int        `json:"page"`
-	Limit         int        `json:"limit"`
-	SortBy        string     `json:"sort_by"`
-	SortOrder     string     `json:"sort_order"`
+	City          string                 `json:"city,omitempty"`
+	Country       string                 `json:"country,omitempty"`
+	TourID        *uuid.UUID             `json:"tour_id,omitempty"`
+	Search        string                 `json:"search,omitempty"`
+	CategoryID    *uuid.UUID             `json:"category_id,omitempty"`
+	EventDateFrom *time.Time             `json:"event_date_from,omitempty"`
+	EventDateTo   *time.Time             `json:"event_date_to,omitempty"`
+	Near          *repositories.GeoPoint `json:"near,omitempty"`
+	RadiusKm      float64                `json:"radius_km,omitempty"`
+	GroupSeries   bool                   `json:"group_series,omitempty"`
+	Page          int                    `json:"page"`
+	Limit         int                    `json:"limit"`
+	SortBy        string                 `json:"sort_by"`
+	SortOrder     string                 `json:"sort_order"`
 }
 
 // GetPublicEventsResponse represents the response from getting public events
@@ -276,11 +391,13 @@ func (s *EventService) GetPublicEvents(ctx context.Context, req *GetPublicEvents
 		Country:            req.Country,
 		TourID:             req.TourID,
 		Search:             req.Search,
+		CategoryID:         req.CategoryID,
 		EventDateFrom:      req.EventDateFrom,
 		EventDateTo:        req.EventDateTo,
 		IncludeTour:        true,
 		IncludeTicketTiers: true,
 		IncludeMinMaxPrice: true,
+		GroupSeries:        req.GroupSeries,
 	}
 	
 	// Validate filter
@@ -288,6 +405,10 @@ func (s *EventService) GetPublicEvents(ctx context.Context, req *GetPublicEvents
 		return nil, err
 	}
 	
+	if req.Near != nil {
+		return s.getNearbyPublicEvents(ctx, req, filter)
+	}
+	
 	events, pagination, err := s.eventRepo.ListPublic(ctx, filter)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get public events: %w", err)
@@ -298,6 +419,86 @@ func (s *EventService) GetPublicEvents(ctx context.Context, req *GetPublicEvents
 		publicEvents[i] = mapEventToPublicEventInfo(event)
 	}
 	
+	if req.GroupSeries {
+		if err := s.attachSeriesOccurrences(ctx, publicEvents); err != nil {
+			return nil, err
+		}
+	}
+	
+	return &GetPublicEventsResponse{
+		Events:     publicEvents,
+		Pagination: pagination,
+	}, nil
+}
+
+// attachSeriesOccurrences lists the upcoming occurrences of each series that
+// a grouped listing shows as a single event
+func (s *EventService) attachSeriesOccurrences(ctx context.Context, publicEvents []*PublicEventInfo) error {
+	var seriesIDs []uuid.UUID
+	bySeries := make(map[uuid.UUID]*SeriesInfo)
+	for _, info := range publicEvents {
+		if info.SeriesID == nil {
+			continue
+		}
+		info.Series = &SeriesInfo{ID: *info.SeriesID, UpcomingOccurrences: []*OccurrenceInfo{}}
+		bySeries[*info.SeriesID] = info.Series
+		seriesIDs = append(seriesIDs, *info.SeriesID)
+	}
+	if len(seriesIDs) == 0 {
+		return nil
+	}
+	
+	occurrences, err := s.eventRepo.GetPublicBySeries(ctx, seriesIDs, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to get series occurrences: %w", err)
+	}
+	
+	for _, occurrence := range occurrences {
+		series, ok := bySeries[*occurrence.SeriesID]
+		if !ok {
+			continue
+		}
+		series.UpcomingOccurrences = append(series.UpcomingOccurrences, &OccurrenceInfo{
+			EventID:   occurrence.ID,
+			Slug:      occurrence.Slug,
+			EventDate: occurrence.EventDate,
+			Status:    occurrence.Status,
+		})
+	}
+	
+	return nil
+}
+
+// getNearbyPublicEvents lists public events around a point, nearest first
+func (s *EventService) getNearbyPublicEvents(ctx context.Context, req *GetPublicEventsRequest, filter repositories.PublicEventFilter) (*GetPublicEventsResponse, error) {
+	if err := entities.ValidateCoordinates(req.Near.Latitude, req.Near.Longitude); err != nil {
+		return nil, err
+	}
+	
+	radius := req.RadiusKm
+	if radius == 0 {
+		radius = DefaultNearbyRadiusKm
+	}
+	if radius < 0 || radius > MaxNearbyRadiusKm {
+		return nil, entities.NewValidationError("radius_km", fmt.Sprintf("radius must be between 0 and %.0f km", MaxNearbyRadiusKm))
+	}
+	
+	filter.Near = req.Near
+	filter.RadiusKm = radius
+	
+	nearby, pagination, err := s.eventRepo.ListPublicNearby(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get nearby events: %w", err)
+	}
+	
+	publicEvents := make([]*PublicEventInfo, len(nearby))
+	for i, result := range nearby {
+		info := mapEventToPublicEventInfo(result.Event)
+		distance := math.Round(result.DistanceKm*100) / 100
+		info.DistanceKm = &distance
+		publicEvents[i] = info
+	}
+	
 	return &GetPublicEventsResponse{
 		Events:     publicEvents,
 		Pagination: pagination,
@@ -325,9 +526,9 @@ func (s *EventService) GetEventDetails(ctx context.Context, req *GetEventDetails
 	}
 	
 	// Get ticket tier availability
-	availability, err := s.ticketTierRepo.GetAvailability(ctx, req.EventID)
+	ticketTiers, err := s.GetTicketTierAvailability(ctx, req.EventID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get ticket tier availability: %w", err)
+		return nil, err
 	}
 	
 	// Get event stats
@@ -336,11 +537,6 @@ func (s *EventService) GetEventDetails(ctx context.Context, req *GetEventDetails
 		return nil, fmt.Errorf("failed to get event stats: %w", err)
 	}
 	
-	ticketTiers := make([]*TicketTierAvailabilityInfo, len(availability))
-	for i, tier := range availability {
-		ticketTiers[i] = mapTicketTierAvailabilityToInfo(tier)
-	}
-	
 	return &GetEventDetailsResponse{
 		Event:       mapEventToEventInfo(event),
 		TicketTiers: ticketTiers,
@@ -348,6 +544,21 @@ func (s *EventService) GetEventDetails(ctx context.Context, req *GetEventDetails
 	}, nil
 }
 
+// GetTicketTierAvailability retrieves an event's tiers with their current
+// price, stock and sale state, in display order
+func (s *EventService) GetTicketTierAvailability(ctx context.Context, eventID uuid.UUID) ([]*TicketTierAvailabilityInfo, error) {
+	availability, err := s.ticketTierRepo.GetAvailability(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket tier availability: %w", err)
+	}
+	
+	ticketTiers := make([]*TicketTierAvailabilityInfo, len(availability))
+	for i, tier := range availability {
+		ticketTiers[i] = mapTicketTierAvailabilityToInfo(tier)
+	}
+	return ticketTiers, nil
+}
+
 // PublishEventRequest represents the request to publish an event
 type PublishEventRequest struct {
 	EventID uuid.UUID `json:"event_id" validate:"required"`
@@ -377,6 +588,10 @@ func (s *EventService) PublishEvent(ctx context.Context, req *PublishEventReques
 		return nil, fmt.Errorf("failed to update event: %w", err)
 	}
 	
+	if err := s.eventBus.Publish(ctx, entities.NewEventPublished(event)); err != nil {
+		fmt.Printf("Warning: failed to publish event.published for event %s: %v\n", event.ID, err)
+	}
+	
 	return &PublishEventResponse{
 		Event:   mapEventToEventInfo(event),
 		Message: "Event published successfully",
@@ -423,17 +638,22 @@ type EventInfo struct {
 	ID              uuid.UUID                `json:"id"`
 	OrganizerID     *uuid.UUID               `json:"organizer_id,omitempty"`
 	CategoryID      *uuid.UUID               `json:"category_id,omitempty"`
+	TourID          *uuid.UUID               `json:"tour_id,omitempty"`
+	SeriesID        *uuid.UUID               `json:"series_id,omitempty"`
 	Name            string                   `json:"name"`
 	Slug            string                   `json:"slug"`
 	Description     *string                  `json:"description,omitempty"`
 	EventDate       time.Time                `json:"event_date"`
 	DoorsOpen       *time.Time               `json:"doors_open,omitempty"`
+	VenueID         *uuid.UUID               `json:"venue_id,omitempty"`
 	VenueName       string                   `json:"venue_name"`
 	VenueAddress    string                   `json:"venue_address"`
 	VenueCity       string                   `json:"venue_city"`
 	VenueState      *string                  `json:"venue_state,omitempty"`
 	VenueCountry    *string                  `json:"venue_country,omitempty"`
 	VenueCapacity   *int                     `json:"venue_capacity,omitempty"`
+	VenueLatitude   *float64                 `json:"venue_latitude,omitempty"`
+	VenueLongitude  *float64                 `json:"venue_longitude,omitempty"`
 	EventImageURL   *string                  `json:"event_image_url,omitempty"`
 	ThumbnailURL    *string                  `json:"thumbnail_url,omitempty"`
 	PromoVideoURL   *string                  `json:"promo_video_url,omitempty"`
@@ -451,12 +671,17 @@ type PublicEventInfo struct {
 	ID             uuid.UUID            `json:"id"`
 	Name           string               `json:"name"`
 	Slug           string               `json:"slug"`
+	TourID         *uuid.UUID           `json:"tour_id,omitempty"`
+	SeriesID       *uuid.UUID           `json:"series_id,omitempty"`
 	Description    *string              `json:"description,omitempty"`
 	EventDate      time.Time            `json:"event_date"`
 	DoorsOpen      *time.Time           `json:"doors_open,omitempty"`
 	VenueName      string               `json:"venue_name"`
 	VenueCity      string               `json:"venue_city"`
 	VenueAddress   string               `json:"venue_address"`
+	VenueLatitude  *float64             `json:"venue_latitude,omitempty"`
+	VenueLongitude *float64             `json:"venue_longitude,omitempty"`
+	DistanceKm     *float64             `json:"distance_km,omitempty"`
 	EventImageURL  *string              `json:"event_image_url,omitempty"`
 	ThumbnailURL   *string              `json:"thumbnail_url,omitempty"`
 	PromoVideoURL  *string              `json:"promo_video_url,omitempty"`
@@ -466,6 +691,20 @@ type PublicEventInfo struct {
 	MaxPrice       *float64             `json:"max_price,omitempty"`
 	Currency       string               `json:"currency"`
 	TourInfo       *TourInfo            `json:"tour_info,omitempty"`
+	Series         *SeriesInfo          `json:"series,omitempty"`
+}
+
+// SeriesInfo groups the upcoming occurrences of a recurring event
+type SeriesInfo struct {
+	ID                  uuid.UUID         `json:"id"`
+	UpcomingOccurrences []*OccurrenceInfo `json:"upcoming_occurrences"`
+}
+
+type OccurrenceInfo struct {
+	EventID   uuid.UUID            `json:"event_id"`
+	Slug      string               `json:"slug"`
+	EventDate time.Time            `json:"event_date"`
+	Status    entities.EventStatus `json:"status"`
 }
 
 type TourInfo struct {
@@ -474,14 +713,22 @@ type TourInfo struct {
 	ArtistName string    `json:"artist_name"`
 }
 
+// TicketTierAvailabilityInfo is a tier as buyers see it. SaleStatus is one of
+// upcoming, on_sale, sold_out or ended.
 type TicketTierAvailabilityInfo struct {
-	ID         uuid.UUID `json:"id"`
-	Name       string    `json:"name"`
-	Price      float64   `json:"price"`
-	Currency   string    `json:"currency"`
-	Available  int       `json:"available"`
-	IsOnSale   bool      `json:"is_on_sale"`
-	SaleStatus string    `json:"sale_status"`
+	ID               uuid.UUID              `json:"id"`
+	Name             string                 `json:"name"`
+	Description      *string                `json:"description,omitempty"`
+	Price            float64                `json:"price"`
+	Currency         string                 `json:"currency"`
+	Available        int                    `json:"available"`
+	MinPurchase      int                    `json:"min_per_order"`
+	MaxPurchase      int                    `json:"max_per_order"`
+	SaleStart        *time.Time             `json:"sale_start,omitempty"`
+	SaleEnd          *time.Time             `json:"sale_end,omitempty"`
+	OpensAfterTierID *uuid.UUID             `json:"opens_after_tier_id,omitempty"`
+	IsOnSale         bool                   `json:"is_on_sale"`
+	SaleStatus       entities.TierSaleState `json:"sale_status"`
 }
 
 // Helper functions
@@ -490,17 +737,22 @@ func mapEventToEventInfo(event *entities.Event) *EventInfo {
 		ID:             event.ID,
 		OrganizerID:    event.OrganizerID,
 		CategoryID:     event.CategoryID,
+		TourID:         event.TourID,
+		SeriesID:       event.SeriesID,
 		Name:           event.Name,
 		Slug:           event.Slug,
 		Description:    event.Description,
 		EventDate:      event.EventDate,
 		DoorsOpen:      event.DoorsOpen,
+		VenueID:        event.VenueID,
 		VenueName:      event.VenueName,
 		VenueAddress:   event.VenueAddress,
 		VenueCity:      event.VenueCity,
 		VenueState:     event.VenueState,
 		VenueCountry:   event.VenueCountry,
 		VenueCapacity:  event.VenueCapacity,
+		VenueLatitude:  event.VenueLatitude,
+		VenueLongitude: event.VenueLongitude,
 		EventImageURL:  event.EventImageURL,
 		ThumbnailURL:   event.ThumbnailURL,
 		PromoVideoURL:  event.PromoVideoURL,
@@ -508,7 +760,7 @@ func mapEventToEventInfo(event *entities.Event) *EventInfo {
 		Status:         event.Status,
 		SaleStart:      event.SaleStart,
 		SaleEnd:        event.SaleEnd,
-		Currency:       func() *string { s := "NGN"; return &s }(), // Hardcoded to NGN for now
+		Currency:       eventCurrency(event),
 		CreatedAt:      event.CreatedAt,
 		UpdatedAt:      event.UpdatedAt,
 		IsActive:       event.IsActive,
@@ -517,34 +769,53 @@ func mapEventToEventInfo(event *entities.Event) *EventInfo {
 
 func mapEventToPublicEventInfo(event *entities.Event) *PublicEventInfo {
 	return &PublicEventInfo{
-		ID:            event.ID,
-		Name:          event.Name,
-		Slug:          event.Slug,
-		Description:   event.Description,
-		EventDate:     event.EventDate,
-		DoorsOpen:     event.DoorsOpen,
-		VenueName:     event.VenueName,
-		VenueCity:     event.VenueCity,
-		VenueAddress:  event.VenueAddress,
-		EventImageURL: event.EventImageURL,
-		ThumbnailURL:  event.ThumbnailURL,
-		PromoVideoURL: event.PromoVideoURL,
-		GalleryImages: event.GalleryImages,
-		Status:        event.Status,
-		Currency:      "NGN",
+		ID:             event.ID,
+		Name:           event.Name,
+		Slug:           event.Slug,
+		TourID:         event.TourID,
+		SeriesID:       event.SeriesID,
+		Description:    event.Description,
+		EventDate:      event.EventDate,
+		DoorsOpen:      event.DoorsOpen,
+		VenueName:      event.VenueName,
+		VenueCity:      event.VenueCity,
+		VenueAddress:   event.VenueAddress,
+		VenueLatitude:  event.VenueLatitude,
+		VenueLongitude: event.VenueLongitude,
+		EventImageURL:  event.EventImageURL,
+		ThumbnailURL:   event.ThumbnailURL,
+		PromoVideoURL:  event.PromoVideoURL,
+		GalleryImages:  event.GalleryImages,
+		Status:         event.Status,
+		Currency:       *eventCurrency(event),
 		// TODO: Calculate min/max prices from ticket tiers
 	}
 }
 
+// eventCurrency returns the event currency, falling back to the default for legacy rows
+func eventCurrency(event *entities.Event) *string {
+	currency := event.Currency
+	if currency == "" {
+		currency = entities.DefaultCurrency
+	}
+	return &currency
+}
+
 func mapTicketTierAvailabilityToInfo(tier *repositories.TicketTierAvailability) *TicketTierAvailabilityInfo {
 	return &TicketTierAvailabilityInfo{
-		ID:         tier.TicketTierID,
-		Name:       tier.Name,
-		Price:      tier.Price,
-		Currency:   tier.Currency,
-		Available:  tier.Available,
-		IsOnSale:   tier.IsOnSale,
-		SaleStatus: tier.SaleStatus,
+		ID:               tier.TicketTierID,
+		Name:             tier.Name,
+		Description:      tier.Description,
+		Price:            tier.Price,
+		Currency:         tier.Currency,
+		Available:        tier.Available,
+		MinPurchase:      tier.MinPurchase,
+		MaxPurchase:      tier.MaxPurchase,
+		SaleStart:        tier.SaleStart,
+		SaleEnd:          tier.SaleEnd,
+		OpensAfterTierID: tier.OpensAfterTierID,
+		IsOnSale:         tier.IsOnSale,
+		SaleStatus:       tier.SaleStatus,
 	}
 }
 
@@ -0,0 +1,373 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// CloneEventRequest represents the request to copy an event to a new date.
+// Doors, sale windows and tier sale windows move with the event date.
+type CloneEventRequest struct {
+	Name               *string    `json:"name,omitempty" validate:"omitempty,max=255"`
+	Slug               string     `json:"slug" validate:"required,max=100"`
+	EventDate          time.Time  `json:"event_date" validate:"required"`
+	TourID             *uuid.UUID `json:"tour_id,omitempty"`
+	IncludeTicketTiers *bool      `json:"include_ticket_tiers,omitempty"`
+	IncludeScanners    bool       `json:"include_scanners,omitempty"`
+	AssignedBy         *uuid.UUID `json:"-"`
+}
+
+// CloneEvent copies an event, its ticket tiers and optionally its scanner
+// assignments. The copy starts as a draft with nothing sold.
+func (s *EventService) CloneEvent(ctx context.Context, sourceID uuid.UUID, req *CloneEventRequest) (*CreateEventResponse, error) {
+	source, err := s.eventRepo.GetByID(ctx, sourceID)
+	if err != nil {
+		if err == entities.ErrEventNotFound {
+			return nil, entities.NewNotFoundError("event", "event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	if source.OrganizerID == nil {
+		return nil, entities.NewValidationError("event_id", "event has no organizer")
+	}
+	if req.IncludeScanners && req.AssignedBy == nil {
+		return nil, entities.NewValidationError("include_scanners", "scanner assignments can only be copied by an admin")
+	}
+
+	clone := source.Clone(strings.TrimSpace(req.Slug), req.EventDate)
+	if req.Name != nil {
+		clone.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.TourID != nil {
+		if err := s.checkTour(ctx, *req.TourID); err != nil {
+			return nil, err
+		}
+		clone.SetTour(*req.TourID)
+	}
+	if err := clone.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkSlug(ctx, clone); err != nil {
+		return nil, err
+	}
+
+	var tiers []*entities.TicketTier
+	if req.IncludeTicketTiers == nil || *req.IncludeTicketTiers {
+		sourceTiers, err := s.ticketTierRepo.GetByEvent(ctx, source.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ticket tiers: %w", err)
+		}
+		var activeTiers []*entities.TicketTier
+		for _, tier := range sourceTiers {
+			if tier.IsActive {
+				activeTiers = append(activeTiers, tier)
+			}
+		}
+		tiers = entities.CopyTiersForEvent(activeTiers, clone.ID, clone.EventDate.Sub(source.EventDate))
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := createEventWithTiers(tx, clone, tiers); err != nil {
+		return nil, err
+	}
+
+	if req.IncludeScanners {
+		scanners, err := tx.ScannerUsers().GetEventScanners(tx.Context(), source.ID)
+		if err != nil {
+			return nil, err
+		}
+		for _, scanner := range scanners {
+			if err := tx.ScannerUsers().AssignToEvent(tx.Context(), scanner.ID, clone.ID, *req.AssignedBy); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &CreateEventResponse{
+		Event: mapEventToEventInfo(clone),
+	}, nil
+}
+
+// CreateEventTemplateRequest represents the request to save an event template,
+// either captured from an existing event or given in full
+type CreateEventTemplateRequest struct {
+	EventID     *uuid.UUID                     `json:"event_id,omitempty"`
+	OrganizerID *uuid.UUID                     `json:"organizer_id,omitempty"`
+	Name        string                         `json:"name,omitempty" validate:"max=255"`
+	Description *string                        `json:"description,omitempty"`
+	Content     *entities.EventTemplateContent `json:"content,omitempty"`
+	CreatedBy   *uuid.UUID                     `json:"-"`
+}
+
+// UpdateEventTemplateRequest represents the request to update an event
+// template; content replaces the saved setup as a whole
+type UpdateEventTemplateRequest struct {
+	Name        *string                        `json:"name,omitempty" validate:"omitempty,max=255"`
+	Description *string                        `json:"description,omitempty"`
+	Content     *entities.EventTemplateContent `json:"content,omitempty"`
+}
+
+// CreateEventFromTemplateRequest represents the request to create an event
+// from a template. Times saved in the template are placed relative to EventDate.
+type CreateEventFromTemplateRequest struct {
+	Name      *string    `json:"name,omitempty" validate:"omitempty,max=255"`
+	Slug      string     `json:"slug" validate:"required,max=100"`
+	EventDate time.Time  `json:"event_date" validate:"required"`
+	TourID    *uuid.UUID `json:"tour_id,omitempty"`
+	VenueID   *uuid.UUID `json:"venue_id,omitempty"`
+}
+
+// CreateEventTemplate saves a reusable event template
+func (s *EventService) CreateEventTemplate(ctx context.Context, req *CreateEventTemplateRequest) (*entities.EventTemplate, error) {
+	var template *entities.EventTemplate
+	switch {
+	case req.EventID != nil:
+		event, err := s.eventRepo.GetByID(ctx, *req.EventID)
+		if err != nil {
+			if err == entities.ErrEventNotFound {
+				return nil, entities.NewNotFoundError("event", "event not found")
+			}
+			return nil, fmt.Errorf("failed to get event: %w", err)
+		}
+		tiers, err := s.ticketTierRepo.GetByEvent(ctx, event.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ticket tiers: %w", err)
+		}
+		template = entities.NewEventTemplate(event, tiers, req.Name)
+	case req.Content != nil:
+		if req.OrganizerID == nil {
+			return nil, entities.NewValidationError("organizer_id", "organizer is required")
+		}
+		now := time.Now()
+		template = &entities.EventTemplate{
+			ID:          uuid.New(),
+			OrganizerID: *req.OrganizerID,
+			Name:        strings.TrimSpace(req.Name),
+			Content:     *req.Content,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+			IsActive:    true,
+		}
+		if template.Name == "" {
+			template.Name = req.Content.EventName
+		}
+	default:
+		return nil, entities.NewValidationError("event_id", "an event to save or the template content is required")
+	}
+
+	template.Description = req.Description
+	template.CreatedBy = req.CreatedBy
+	template.Content.Currency = entities.NormalizeCurrency(template.Content.Currency)
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.eventTemplateRepo.Create(ctx, template); err != nil {
+		if err == entities.ErrOrganizerNotFound {
+			return nil, entities.NewNotFoundError("organizer", "organizer not found")
+		}
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// GetEventTemplate retrieves an event template
+func (s *EventService) GetEventTemplate(ctx context.Context, id uuid.UUID) (*entities.EventTemplate, error) {
+	template, err := s.eventTemplateRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == entities.ErrEventTemplateNotFound {
+			return nil, entities.NewNotFoundError("event_template", "event template not found")
+		}
+		return nil, err
+	}
+	return template, nil
+}
+
+// ListEventTemplates retrieves event templates with pagination and filtering
+func (s *EventService) ListEventTemplates(ctx context.Context, filter repositories.EventTemplateFilter) ([]*entities.EventTemplate, *repositories.PaginationResult, error) {
+	return s.eventTemplateRepo.List(ctx, filter)
+}
+
+// UpdateEventTemplate updates an event template
+func (s *EventService) UpdateEventTemplate(ctx context.Context, id uuid.UUID, req *UpdateEventTemplateRequest) (*entities.EventTemplate, error) {
+	template, err := s.GetEventTemplate(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		template.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		template.Description = req.Description
+	}
+	if req.Content != nil {
+		template.Content = *req.Content
+		template.Content.Currency = entities.NormalizeCurrency(template.Content.Currency)
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	template.UpdatedAt = time.Now()
+	if err := s.eventTemplateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	return template, nil
+}
+
+// DeleteEventTemplate deactivates an event template
+func (s *EventService) DeleteEventTemplate(ctx context.Context, id uuid.UUID) error {
+	if err := s.eventTemplateRepo.Delete(ctx, id); err != nil {
+		if err == entities.ErrEventTemplateNotFound {
+			return entities.NewNotFoundError("event_template", "event template not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// CreateEventFromTemplate creates a draft event and its ticket tiers from a template
+func (s *EventService) CreateEventFromTemplate(ctx context.Context, templateID uuid.UUID, req *CreateEventFromTemplateRequest) (*CreateEventResponse, error) {
+	template, err := s.GetEventTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	name := ""
+	if req.Name != nil {
+		name = strings.TrimSpace(*req.Name)
+	}
+	event, tiers := template.Content.Instantiate(template.OrganizerID, name, strings.TrimSpace(req.Slug), req.EventDate)
+
+	// The venue may have changed since the template was saved
+	venueID := template.Content.VenueID
+	if req.VenueID != nil {
+		venueID = req.VenueID
+	}
+	if venueID != nil {
+		venue, err := s.venueRepo.GetByID(ctx, *venueID)
+		if err != nil {
+			if err == entities.ErrVenueNotFound {
+				return nil, entities.NewNotFoundError("venue", "venue not found")
+			}
+			return nil, fmt.Errorf("failed to get venue: %w", err)
+		}
+		if !venue.IsActive {
+			return nil, entities.NewValidationError("venue_id", "venue is no longer active")
+		}
+		event.AssignVenue(venue)
+		// Keep a saved capacity below the venue's, as events may use part of a venue
+		if req.VenueID == nil && template.Content.VenueCapacity != nil &&
+			(venue.Capacity == nil || *template.Content.VenueCapacity <= *venue.Capacity) {
+			event.VenueCapacity = template.Content.VenueCapacity
+		}
+	}
+
+	if req.TourID != nil {
+		if err := s.checkTour(ctx, *req.TourID); err != nil {
+			return nil, err
+		}
+		event.SetTour(*req.TourID)
+	}
+	if err := event.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkSlug(ctx, event); err != nil {
+		return nil, err
+	}
+
+	if event.VenueCapacity != nil {
+		totalQuota := 0
+		for _, tier := range tiers {
+			totalQuota += tier.Quota
+		}
+		if totalQuota > *event.VenueCapacity {
+			return nil, entities.NewBusinessRuleError("tier_quota_exceeds_capacity", "total ticket tier quota exceeds venue capacity", map[string]interface{}{
+				"capacity":    *event.VenueCapacity,
+				"total_quota": totalQuota,
+			})
+		}
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := createEventWithTiers(tx, event, tiers); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &CreateEventResponse{
+		Event: mapEventToEventInfo(event),
+	}, nil
+}
+
+func (s *EventService) checkTour(ctx context.Context, tourID uuid.UUID) error {
+	exists, err := s.tourRepo.Exists(ctx, tourID)
+	if err != nil {
+		return fmt.Errorf("failed to check tour existence: %w", err)
+	}
+	if !exists {
+		return entities.NewNotFoundError("tour", "tour not found")
+	}
+	return nil
+}
+
+func (s *EventService) checkSlug(ctx context.Context, event *entities.Event) error {
+	exists, err := s.eventRepo.ExistsBySlug(ctx, *event.OrganizerID, event.Slug)
+	if err != nil {
+		return fmt.Errorf("failed to check event slug existence: %w", err)
+	}
+	if exists {
+		return entities.NewConflictError("event", "event with this slug already exists for this organizer", nil)
+	}
+	return nil
+}
+
+// createEventWithTiers creates an event and its ticket tiers within a transaction
+func createEventWithTiers(tx repositories.Transaction, event *entities.Event, tiers []*entities.TicketTier) error {
+	if err := tx.Events().Create(tx.Context(), event); err != nil {
+		if err == entities.ErrConflictError {
+			return entities.NewConflictError("event", "event with this slug already exists for this organizer", nil)
+		}
+		return fmt.Errorf("failed to create event: %w", err)
+	}
+
+	if err := entities.ValidateTierSequence(tiers); err != nil {
+		return err
+	}
+	for _, tier := range tiers {
+		if err := tier.Validate(); err != nil {
+			return err
+		}
+		if err := tx.TicketTiers().Create(tx.Context(), tier); err != nil {
+			return fmt.Errorf("failed to create ticket tier '%s': %w", tier.Name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,405 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+type fakeEvents struct {
+	repositories.EventRepository
+	events map[uuid.UUID]*entities.Event
+}
+
+func (f *fakeEvents) GetByID(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	event, ok := f.events[id]
+	if !ok {
+		return nil, entities.ErrEventNotFound
+	}
+	return event, nil
+}
+
+func (f *fakeEvents) ExistsBySlug(ctx context.Context, organizerID uuid.UUID, slug string) (bool, error) {
+	for _, event := range f.events {
+		if *event.OrganizerID == organizerID && event.Slug == slug {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeEvents) Create(ctx context.Context, event *entities.Event) error {
+	f.events[event.ID] = event
+	return nil
+}
+
+type fakeTiers struct {
+	repositories.TicketTierRepository
+	byEvent map[uuid.UUID][]*entities.TicketTier
+}
+
+func (f *fakeTiers) GetByEvent(ctx context.Context, eventID uuid.UUID) ([]*entities.TicketTier, error) {
+	return f.byEvent[eventID], nil
+}
+
+func (f *fakeTiers) Create(ctx context.Context, tier *entities.TicketTier) error {
+	f.byEvent[tier.EventID] = append(f.byEvent[tier.EventID], tier)
+	return nil
+}
+
+type fakeVenues struct {
+	repositories.VenueRepository
+	venues map[uuid.UUID]*entities.Venue
+}
+
+func (f *fakeVenues) GetByID(ctx context.Context, id uuid.UUID) (*entities.Venue, error) {
+	venue, ok := f.venues[id]
+	if !ok {
+		return nil, entities.ErrVenueNotFound
+	}
+	return venue, nil
+}
+
+type fakeTemplates struct {
+	repositories.EventTemplateRepository
+	templates map[uuid.UUID]*entities.EventTemplate
+}
+
+func (f *fakeTemplates) Create(ctx context.Context, template *entities.EventTemplate) error {
+	f.templates[template.ID] = template
+	return nil
+}
+
+func (f *fakeTemplates) GetByID(ctx context.Context, id uuid.UUID) (*entities.EventTemplate, error) {
+	template, ok := f.templates[id]
+	if !ok {
+		return nil, entities.ErrEventTemplateNotFound
+	}
+	return template, nil
+}
+
+// fakeScanners records the scanners assigned to each event
+type fakeScanners struct {
+	repositories.ScannerUserRepository
+	assigned map[uuid.UUID][]uuid.UUID
+}
+
+func (f *fakeScanners) GetEventScanners(ctx context.Context, eventID uuid.UUID) ([]*entities.ScannerUser, error) {
+	var scanners []*entities.ScannerUser
+	for _, id := range f.assigned[eventID] {
+		scanners = append(scanners, &entities.ScannerUser{ID: id})
+	}
+	return scanners, nil
+}
+
+func (f *fakeScanners) AssignToEvent(ctx context.Context, scannerID, eventID, assignedBy uuid.UUID) error {
+	f.assigned[eventID] = append(f.assigned[eventID], scannerID)
+	return nil
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	ctx      context.Context
+	events   *fakeEvents
+	tiers    *fakeTiers
+	scanners *fakeScanners
+}
+
+func (tx *fakeTx) Commit() error                                    { return nil }
+func (tx *fakeTx) Rollback() error                                  { return nil }
+func (tx *fakeTx) Context() context.Context                         { return tx.ctx }
+func (tx *fakeTx) Events() repositories.EventRepository             { return tx.events }
+func (tx *fakeTx) TicketTiers() repositories.TicketTierRepository   { return tx.tiers }
+func (tx *fakeTx) ScannerUsers() repositories.ScannerUserRepository { return tx.scanners }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	u.tx.ctx = ctx
+	return u.tx, nil
+}
+
+type templateFixture struct {
+	service   *EventService
+	events    *fakeEvents
+	tiers     *fakeTiers
+	venues    *fakeVenues
+	scanners  *fakeScanners
+	templates *fakeTemplates
+	source    *entities.Event
+	venue     *entities.Venue
+}
+
+// newTemplateFixture builds an event service over fakes, with a published
+// show at a 1,000 seat venue, a week from now. The show has a scanner and
+// sells early bird tickets from two weeks before, regular tickets that open
+// after them, and a retired tier.
+func newTemplateFixture(t *testing.T) *templateFixture {
+	t.Helper()
+
+	capacity := 1000
+	venue := entities.NewVenue("Eko Convention Centre", "Adetokunbo Ademola Street", "Lagos", "NG")
+	venue.Capacity = &capacity
+
+	start := time.Now().AddDate(0, 0, 7).Truncate(time.Minute)
+	source := entities.NewEvent(uuid.New(), "Afro Nation", "afro-nation", start, "", "", "", "")
+	source.AssignVenue(venue)
+	source.Status = entities.EventStatusPublished
+	source.EnableMomo = false
+	source.Settings["age_limit"] = 18
+	source.SetGallery([]string{"https://cdn.example.com/1.jpg"})
+	doorsOpen := start.Add(-2 * time.Hour)
+	source.DoorsOpen = &doorsOpen
+
+	early := entities.NewTicketTier(source.ID, "Early Bird", 15000)
+	early.Sold = 300
+	saleStart := start.AddDate(0, 0, -14)
+	early.SaleStart = &saleStart
+	regular := entities.NewTicketTier(source.ID, "Regular", 25000)
+	regular.Sold = 120
+	regular.OpensAfterTierID = &early.ID
+	retired := entities.NewTicketTier(source.ID, "Table", 500000)
+	retired.IsActive = false
+
+	scannerID := uuid.New()
+	events := &fakeEvents{events: map[uuid.UUID]*entities.Event{source.ID: source}}
+	tiers := &fakeTiers{byEvent: map[uuid.UUID][]*entities.TicketTier{source.ID: {early, regular, retired}}}
+	venues := &fakeVenues{venues: map[uuid.UUID]*entities.Venue{venue.ID: venue}}
+	scanners := &fakeScanners{assigned: map[uuid.UUID][]uuid.UUID{source.ID: {scannerID}}}
+	templates := &fakeTemplates{templates: make(map[uuid.UUID]*entities.EventTemplate)}
+	tx := &fakeTx{events: events, tiers: tiers, scanners: scanners}
+
+	return &templateFixture{
+		service:   NewEventService(events, nil, nil, tiers, venues, templates, &fakeUnitOfWork{tx: tx}, nil),
+		events:    events,
+		tiers:     tiers,
+		venues:    venues,
+		scanners:  scanners,
+		templates: templates,
+		source:    source,
+		venue:     venue,
+	}
+}
+
+func TestCloneEventCopiesSetupAsDraft(t *testing.T) {
+	f := newTemplateFixture(t)
+	admin := uuid.New()
+	nextMonth := f.source.EventDate.AddDate(0, 1, 0)
+
+	resp, err := f.service.CloneEvent(context.Background(), f.source.ID, &CloneEventRequest{
+		Slug:            " afro-nation-2 ",
+		EventDate:       nextMonth,
+		IncludeScanners: true,
+		AssignedBy:      &admin,
+	})
+	if err != nil {
+		t.Fatalf("CloneEvent() error = %v", err)
+	}
+
+	clone := f.events.events[resp.Event.ID]
+	if clone.Status != entities.EventStatusDraft || clone.Slug != "afro-nation-2" || clone.Name != "Afro Nation" {
+		t.Errorf("clone = %s %q (%s), want a draft afro-nation-2 named after the source", clone.Slug, clone.Name, clone.Status)
+	}
+	if clone.DoorsOpen == nil || !clone.DoorsOpen.Equal(nextMonth.Add(-2*time.Hour)) {
+		t.Errorf("clone doors open = %v, want two hours before the new date", clone.DoorsOpen)
+	}
+	if clone.EnableMomo || clone.VenueID == nil || *clone.VenueID != f.venue.ID || len(clone.GalleryImages) != 1 {
+		t.Errorf("clone did not keep the payment toggles, venue and gallery of the source")
+	}
+
+	// The copy's settings are its own
+	clone.Settings["age_limit"] = 21
+	if f.source.Settings["age_limit"] != 18 {
+		t.Errorf("changing the clone's settings changed the source")
+	}
+
+	tiers := f.tiers.byEvent[clone.ID]
+	if len(tiers) != 2 {
+		t.Fatalf("clone tiers = %d, want the two active tiers", len(tiers))
+	}
+	for _, tier := range tiers {
+		if tier.Sold != 0 || tier.EventID != clone.ID {
+			t.Errorf("tier %s = %d sold for event %s, want none sold for the clone", tier.Name, tier.Sold, tier.EventID)
+		}
+	}
+	if tiers[0].SaleStart == nil || !tiers[0].SaleStart.Equal(nextMonth.AddDate(0, 0, -14)) {
+		t.Errorf("early bird sale start = %v, want two weeks before the new date", tiers[0].SaleStart)
+	}
+	if tiers[1].OpensAfterTierID == nil || *tiers[1].OpensAfterTierID != tiers[0].ID {
+		t.Errorf("regular tier opens after %v, want the copied early bird %s", tiers[1].OpensAfterTierID, tiers[0].ID)
+	}
+
+	if len(f.scanners.assigned[clone.ID]) != 1 || f.scanners.assigned[clone.ID][0] != f.scanners.assigned[f.source.ID][0] {
+		t.Errorf("clone scanners = %v, want the source's scanner", f.scanners.assigned[clone.ID])
+	}
+}
+
+func TestCloneEventOptions(t *testing.T) {
+	f := newTemplateFixture(t)
+	ctx := context.Background()
+	nextMonth := f.source.EventDate.AddDate(0, 1, 0)
+
+	// Copying scanners is for admins only
+	_, err := f.service.CloneEvent(ctx, f.source.ID, &CloneEventRequest{Slug: "afro-nation-2", EventDate: nextMonth, IncludeScanners: true})
+	var validationErr *entities.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "include_scanners" {
+		t.Errorf("CloneEvent() with scanners and no admin error = %v, want a validation error on include_scanners", err)
+	}
+
+	_, err = f.service.CloneEvent(ctx, f.source.ID, &CloneEventRequest{Slug: "afro-nation", EventDate: nextMonth})
+	var conflictErr *entities.ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Errorf("CloneEvent() onto a taken slug error = %v, want a conflict", err)
+	}
+
+	withoutTiers := false
+	resp, err := f.service.CloneEvent(ctx, f.source.ID, &CloneEventRequest{Slug: "afro-nation-2", EventDate: nextMonth, IncludeTicketTiers: &withoutTiers})
+	if err != nil {
+		t.Fatalf("CloneEvent() error = %v", err)
+	}
+	if len(f.tiers.byEvent[resp.Event.ID]) != 0 || len(f.scanners.assigned[resp.Event.ID]) != 0 {
+		t.Errorf("clone without tiers got %d tiers and %d scanners, want none", len(f.tiers.byEvent[resp.Event.ID]), len(f.scanners.assigned[resp.Event.ID]))
+	}
+}
+
+func TestEventTemplateCreatesEventsRelativeToNewDate(t *testing.T) {
+	f := newTemplateFixture(t)
+	ctx := context.Background()
+
+	template, err := f.service.CreateEventTemplate(ctx, &CreateEventTemplateRequest{EventID: &f.source.ID})
+	if err != nil {
+		t.Fatalf("CreateEventTemplate() error = %v", err)
+	}
+	if template.Name != "Afro Nation" || template.OrganizerID != *f.source.OrganizerID || len(template.Content.TicketTiers) != 2 {
+		t.Fatalf("template = %q for %s with %d tiers, want the source's name, organizer and active tiers", template.Name, template.OrganizerID, len(template.Content.TicketTiers))
+	}
+
+	nextYear := f.source.EventDate.AddDate(1, 0, 0)
+	name := "Afro Nation 2027"
+	resp, err := f.service.CreateEventFromTemplate(ctx, template.ID, &CreateEventFromTemplateRequest{Name: &name, Slug: "afro-nation-2027", EventDate: nextYear})
+	if err != nil {
+		t.Fatalf("CreateEventFromTemplate() error = %v", err)
+	}
+
+	event := f.events.events[resp.Event.ID]
+	if event.Name != name || event.Status != entities.EventStatusDraft || event.Settings["age_limit"] != 18 {
+		t.Errorf("event = %q (%s) with settings %v, want a draft carrying the template settings", event.Name, event.Status, event.Settings)
+	}
+	if event.DoorsOpen == nil || !event.DoorsOpen.Equal(nextYear.Add(-2*time.Hour)) {
+		t.Errorf("doors open = %v, want two hours before the new date", event.DoorsOpen)
+	}
+
+	tiers := f.tiers.byEvent[event.ID]
+	if len(tiers) != 2 || tiers[0].Name != "Early Bird" || tiers[0].Sold != 0 {
+		t.Fatalf("event tiers = %+v, want early bird and regular with nothing sold", tiers)
+	}
+	if tiers[0].SaleStart == nil || !tiers[0].SaleStart.Equal(nextYear.AddDate(0, 0, -14)) {
+		t.Errorf("early bird sale start = %v, want two weeks before the new date", tiers[0].SaleStart)
+	}
+	if tiers[1].OpensAfterTierID == nil || *tiers[1].OpensAfterTierID != tiers[0].ID {
+		t.Errorf("regular tier opens after %v, want the new early bird %s", tiers[1].OpensAfterTierID, tiers[0].ID)
+	}
+}
+
+func TestCreateEventFromTemplateRefreshesVenue(t *testing.T) {
+	f := newTemplateFixture(t)
+	ctx := context.Background()
+
+	// The show used 800 of the venue's seats
+	partial := 800
+	f.source.VenueCapacity = &partial
+	template, err := f.service.CreateEventTemplate(ctx, &CreateEventTemplateRequest{EventID: &f.source.ID})
+	if err != nil {
+		t.Fatalf("CreateEventTemplate() error = %v", err)
+	}
+
+	f.venue.Name = "Eko Convention Centre Hall A"
+	nextYear := f.source.EventDate.AddDate(1, 0, 0)
+	resp, err := f.service.CreateEventFromTemplate(ctx, template.ID, &CreateEventFromTemplateRequest{Slug: "afro-nation-2027", EventDate: nextYear})
+	if err != nil {
+		t.Fatalf("CreateEventFromTemplate() error = %v", err)
+	}
+	event := f.events.events[resp.Event.ID]
+	if event.VenueName != f.venue.Name || event.VenueCapacity == nil || *event.VenueCapacity != partial {
+		t.Errorf("event venue = %q for %v seats, want the venue's current name and the saved 800 seats", event.VenueName, event.VenueCapacity)
+	}
+
+	// A smaller venue cannot hold the template's tiers
+	small := 100
+	hall := entities.NewVenue("Muson Centre", "Onikan", "Lagos", "NG")
+	hall.Capacity = &small
+	f.venues.venues[hall.ID] = hall
+	_, err = f.service.CreateEventFromTemplate(ctx, template.ID, &CreateEventFromTemplateRequest{Slug: "afro-nation-muson", EventDate: nextYear, VenueID: &hall.ID})
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "tier_quota_exceeds_capacity" {
+		t.Errorf("CreateEventFromTemplate() at a small venue error = %v, want tier_quota_exceeds_capacity", err)
+	}
+
+	f.venue.IsActive = false
+	_, err = f.service.CreateEventFromTemplate(ctx, template.ID, &CreateEventFromTemplateRequest{Slug: "afro-nation-closed", EventDate: nextYear})
+	var validationErr *entities.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "venue_id" {
+		t.Errorf("CreateEventFromTemplate() at a closed venue error = %v, want a validation error on venue_id", err)
+	}
+}
+
+func TestCreateEventTemplateValidatesContent(t *testing.T) {
+	f := newTemplateFixture(t)
+	organizerID := *f.source.OrganizerID
+	content := func(tiers ...entities.TemplateTicketTier) *entities.EventTemplateContent {
+		return &entities.EventTemplateContent{
+			EventName:    "Comedy Night",
+			VenueName:    "Terra Kulture",
+			VenueAddress: "Tiamiyu Savage",
+			VenueCity:    "Lagos",
+			Currency:     "ngn",
+			TicketTiers:  tiers,
+		}
+	}
+
+	tests := []struct {
+		name  string
+		req   CreateEventTemplateRequest
+		field string
+	}{
+		{name: "nothing to save", req: CreateEventTemplateRequest{OrganizerID: &organizerID}, field: "event_id"},
+		{name: "no organizer", req: CreateEventTemplateRequest{Content: content()}, field: "organizer_id"},
+		{name: "unknown opens after", req: CreateEventTemplateRequest{OrganizerID: &organizerID, Content: content(
+			entities.TemplateTicketTier{Name: "Regular", Price: 5000, Quota: 100, OpensAfter: "Early Bird"},
+		)}, field: "content.ticket_tiers[0].opens_after"},
+		{name: "tier without quota", req: CreateEventTemplateRequest{OrganizerID: &organizerID, Content: content(
+			entities.TemplateTicketTier{Name: "Regular", Price: 5000},
+		)}, field: "content.ticket_tiers[0].quota"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.CreateEventTemplate(context.Background(), &tt.req)
+			var validationErr *entities.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Errorf("CreateEventTemplate() error = %v, want a validation error on %s", err, tt.field)
+			}
+		})
+	}
+
+	template, err := f.service.CreateEventTemplate(context.Background(), &CreateEventTemplateRequest{OrganizerID: &organizerID, Content: content()})
+	if err != nil {
+		t.Fatalf("CreateEventTemplate() error = %v", err)
+	}
+	if template.Name != "Comedy Night" || template.Content.Currency != "NGN" {
+		t.Errorf("template = %q in %s, want it named after the event in NGN", template.Name, template.Content.Currency)
+	}
+	if len(f.templates.templates) != 1 {
+		t.Errorf("saved %d templates, want only the valid one", len(f.templates.templates))
+	}
+}
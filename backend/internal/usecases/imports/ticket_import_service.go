@@ -0,0 +1,584 @@
+package imports
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/mail"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/payments"
+)
+
+const (
+	// DefaultChunkSize is the number of rows written per transaction
+	DefaultChunkSize = 100
+
+	// MaxImportRows caps the size of a single upload
+	MaxImportRows = 20000
+
+	// stagingBatchSize is the number of rows inserted per multi-row INSERT while staging
+	stagingBatchSize = 1000
+)
+
+// TicketImportService loads attendees and pre-sold tickets from CSV. Rows are
+// staged first, then issued in chunked transactions by a background worker, so
+// an interrupted import can be resumed from the rows still pending. The external
+// reference of each row is unique per event, which makes re-running an import
+// (or uploading the same file twice) safe.
+type TicketImportService struct {
+	importRepo     repositories.TicketImportRepository
+	eventRepo      repositories.EventRepository
+	ticketTierRepo repositories.TicketTierRepository
+	unitOfWork     repositories.UnitOfWork
+	paymentService *payments.PaymentService
+	chunkSize      int
+
+	mu      sync.Mutex
+	running map[uuid.UUID]bool
+}
+
+// NewTicketImportService creates a new ticket import service
+func NewTicketImportService(
+	importRepo repositories.TicketImportRepository,
+	eventRepo repositories.EventRepository,
+	ticketTierRepo repositories.TicketTierRepository,
+	unitOfWork repositories.UnitOfWork,
+	paymentService *payments.PaymentService,
+) *TicketImportService {
+	return &TicketImportService{
+		importRepo:     importRepo,
+		eventRepo:      eventRepo,
+		ticketTierRepo: ticketTierRepo,
+		unitOfWork:     unitOfWork,
+		paymentService: paymentService,
+		chunkSize:      DefaultChunkSize,
+		running:        make(map[uuid.UUID]bool),
+	}
+}
+
+// CreateImportRequest represents a CSV upload of attendees or pre-sold tickets
+type CreateImportRequest struct {
+	EventID    uuid.UUID
+	File       io.Reader
+	Filename   string
+	Source     string
+	SendEmails bool
+	CreatedBy  *uuid.UUID
+}
+
+// CreateImport stages the rows of a CSV file and starts issuing them in the background.
+//
+// Recognised columns (header names are case-insensitive):
+//   - external_reference (or reference): required, unique per event
+//   - tier: ticket tier name or ID, required
+//   - name, or first_name and last_name
+//   - email, phone
+//   - quantity: optional, defaults to 1
+func (s *TicketImportService) CreateImport(ctx context.Context, req *CreateImportRequest) (*entities.TicketImport, error) {
+	if _, err := s.eventRepo.GetByID(ctx, req.EventID); err != nil {
+		return nil, entities.NewNotFoundError("event", "event not found")
+	}
+
+	ticketImport := entities.NewTicketImport(req.EventID)
+	ticketImport.SendEmails = req.SendEmails
+	ticketImport.CreatedBy = req.CreatedBy
+	if req.Filename != "" {
+		ticketImport.Filename = &req.Filename
+	}
+	if req.Source != "" {
+		ticketImport.Source = &req.Source
+	}
+
+	rows, err := parseImportCSV(ticketImport.ID, req.File)
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, entities.NewValidationError("file", "CSV file has no data rows")
+	}
+
+	ticketImport.TotalRows = len(rows)
+	for _, row := range rows {
+		if row.Status == entities.TicketImportRowFailed {
+			ticketImport.FailedRows++
+		}
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.TicketImports().Create(tx.Context(), ticketImport); err != nil {
+		return nil, err
+	}
+	for start := 0; start < len(rows); start += stagingBatchSize {
+		end := start + stagingBatchSize
+		if end > len(rows) {
+			end = len(rows)
+		}
+		if err := tx.TicketImports().CreateRows(tx.Context(), rows[start:end]); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	s.startProcessing(ticketImport.ID)
+	return ticketImport, nil
+}
+
+// parseImportCSV reads the upload into staged rows. Rows that are malformed on
+// their own (missing reference, bad email, duplicate reference within the file)
+// are staged as failed so they still appear in the result report.
+func parseImportCSV(importID uuid.UUID, file io.Reader) ([]*entities.TicketImportRow, error) {
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+	reader.FieldsPerRecord = -1
+
+	header, err := reader.Read()
+	if err != nil {
+		return nil, entities.NewValidationError("file", "CSV file is empty or unreadable")
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	if _, ok := columns["reference"]; ok {
+		if _, exists := columns["external_reference"]; !exists {
+			columns["external_reference"] = columns["reference"]
+		}
+	}
+	for _, required := range []string{"external_reference", "tier"} {
+		if _, ok := columns[required]; !ok {
+			return nil, entities.NewValidationError("file", fmt.Sprintf("CSV is missing the %s column", required))
+		}
+	}
+	_, hasName := columns["name"]
+	_, hasFirstName := columns["first_name"]
+	if !hasName && !hasFirstName {
+		return nil, entities.NewValidationError("file", "CSV needs a name or first_name column")
+	}
+
+	field := func(record []string, name string) string {
+		if i, ok := columns[name]; ok && i < len(record) {
+			return strings.TrimSpace(record[i])
+		}
+		return ""
+	}
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+
+	var rows []*entities.TicketImportRow
+	seenReferences := make(map[string]int)
+
+	for rowNumber := 2; ; rowNumber++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if len(rows) >= MaxImportRows {
+			return nil, entities.NewValidationError("file", fmt.Sprintf("CSV exceeds the limit of %d rows", MaxImportRows))
+		}
+
+		row := entities.NewTicketImportRow(importID, rowNumber)
+		rows = append(rows, row)
+		if err != nil {
+			row.MarkFailed("malformed CSV row")
+			continue
+		}
+
+		reference := field(record, "external_reference")
+		row.ExternalReference = optional(reference)
+		row.Tier = optional(field(record, "tier"))
+		row.Email = optional(field(record, "email"))
+		row.Phone = optional(field(record, "phone"))
+		if hasFirstName {
+			row.FirstName = optional(field(record, "first_name"))
+			row.LastName = optional(field(record, "last_name"))
+		} else {
+			row.SetName(field(record, "name"))
+		}
+
+		switch {
+		case reference == "":
+			row.MarkFailed("external_reference is required")
+			continue
+		case seenReferences[reference] != 0:
+			row.MarkFailed(fmt.Sprintf("duplicate external_reference (first seen on row %d)", seenReferences[reference]))
+			continue
+		}
+		seenReferences[reference] = rowNumber
+
+		if row.Tier == nil {
+			row.MarkFailed("tier is required")
+			continue
+		}
+		if row.FirstName == nil {
+			row.MarkFailed("name is required")
+			continue
+		}
+		if row.Email != nil {
+			if _, err := mail.ParseAddress(*row.Email); err != nil {
+				row.MarkFailed("invalid email address")
+				continue
+			}
+		}
+		if quantity := field(record, "quantity"); quantity != "" {
+			n, err := strconv.Atoi(quantity)
+			if err != nil || n < 1 || n > 100 {
+				row.MarkFailed("quantity must be a number between 1 and 100")
+				continue
+			}
+			row.Quantity = n
+		}
+	}
+
+	return rows, nil
+}
+
+// ResumeImport restarts processing of the rows still pending, e.g. after a restart or failure
+func (s *TicketImportService) ResumeImport(ctx context.Context, importID uuid.UUID) (*entities.TicketImport, error) {
+	ticketImport, err := s.GetImport(ctx, importID)
+	if err != nil {
+		return nil, err
+	}
+	if ticketImport.IsFinished() {
+		return nil, entities.NewBusinessRuleError("import_finished", "import has already been completed", nil)
+	}
+
+	if !s.startProcessing(ticketImport.ID) {
+		return nil, entities.NewConflictError("ticket_import", "import is already being processed", nil)
+	}
+
+	return ticketImport, nil
+}
+
+// GetImport retrieves an import and its progress
+func (s *TicketImportService) GetImport(ctx context.Context, importID uuid.UUID) (*entities.TicketImport, error) {
+	ticketImport, err := s.importRepo.GetByID(ctx, importID)
+	if err != nil {
+		if err == entities.ErrTicketImportNotFound {
+			return nil, entities.NewNotFoundError("ticket_import", "ticket import not found")
+		}
+		return nil, err
+	}
+	return ticketImport, nil
+}
+
+// ListImports retrieves imports with filtering and pagination
+func (s *TicketImportService) ListImports(ctx context.Context, filter repositories.TicketImportFilter) ([]*entities.TicketImport, *repositories.PaginationResult, error) {
+	return s.importRepo.List(ctx, filter)
+}
+
+// ListRows retrieves the per-row result report of an import
+func (s *TicketImportService) ListRows(ctx context.Context, filter repositories.TicketImportRowFilter) ([]*entities.TicketImportRow, *repositories.PaginationResult, error) {
+	if _, err := s.GetImport(ctx, filter.ImportID); err != nil {
+		return nil, nil, err
+	}
+	return s.importRepo.ListRows(ctx, filter)
+}
+
+// startProcessing launches the background worker unless one is already running for the import
+func (s *TicketImportService) startProcessing(importID uuid.UUID) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running[importID] {
+		return false
+	}
+	s.running[importID] = true
+
+	go func() {
+		defer func() {
+			s.mu.Lock()
+			delete(s.running, importID)
+			s.mu.Unlock()
+		}()
+		if err := s.process(context.Background(), importID); err != nil {
+			fmt.Printf("Warning: ticket import %s stopped: %v\n", importID, err)
+		}
+	}()
+	return true
+}
+
+// process works through the pending rows chunk by chunk until none remain
+func (s *TicketImportService) process(ctx context.Context, importID uuid.UUID) error {
+	ticketImport, err := s.importRepo.GetByID(ctx, importID)
+	if err != nil {
+		return err
+	}
+
+	ticketImport.MarkProcessing()
+	if err := s.importRepo.Update(ctx, ticketImport); err != nil {
+		return err
+	}
+
+	fail := func(err error) error {
+		ticketImport.MarkFailed(err.Error())
+		if updateErr := s.importRepo.Update(ctx, ticketImport); updateErr != nil {
+			fmt.Printf("Warning: failed to record ticket import %s failure: %v\n", importID, updateErr)
+		}
+		return err
+	}
+
+	tiers, err := s.loadTiers(ctx, ticketImport.EventID)
+	if err != nil {
+		return fail(err)
+	}
+
+	for {
+		rows, err := s.importRepo.GetPendingRows(ctx, importID, s.chunkSize)
+		if err != nil {
+			return fail(err)
+		}
+		if len(rows) == 0 {
+			break
+		}
+
+		if err := s.processChunk(ctx, ticketImport, rows, tiers); err != nil {
+			return fail(err)
+		}
+
+		if err := s.refreshProgress(ctx, ticketImport); err != nil {
+			return fail(err)
+		}
+	}
+
+	if err := s.refreshProgress(ctx, ticketImport); err != nil {
+		return fail(err)
+	}
+	ticketImport.MarkFinished()
+	return s.importRepo.Update(ctx, ticketImport)
+}
+
+// refreshProgress recounts the rows so progress stays correct across resumes
+func (s *TicketImportService) refreshProgress(ctx context.Context, ticketImport *entities.TicketImport) error {
+	counts, err := s.importRepo.CountRows(ctx, ticketImport.ID)
+	if err != nil {
+		return err
+	}
+
+	ticketImport.TotalRows = counts.Total
+	ticketImport.ImportedRows = counts.Imported
+	ticketImport.SkippedRows = counts.Skipped
+	ticketImport.FailedRows = counts.Failed
+	ticketImport.TicketsIssued = counts.TicketsIssued
+	ticketImport.UpdatedAt = time.Now()
+	return s.importRepo.Update(ctx, ticketImport)
+}
+
+// tierLookup resolves the tier column of a row by ID or case-insensitive name
+type tierLookup struct {
+	byID   map[uuid.UUID]*entities.TicketTier
+	byName map[string]*entities.TicketTier
+}
+
+func (l *tierLookup) resolve(value string) *entities.TicketTier {
+	if id, err := uuid.Parse(value); err == nil {
+		return l.byID[id]
+	}
+	return l.byName[strings.ToLower(strings.TrimSpace(value))]
+}
+
+func (s *TicketImportService) loadTiers(ctx context.Context, eventID uuid.UUID) (*tierLookup, error) {
+	tiers, err := s.ticketTierRepo.GetByEvent(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket tiers: %w", err)
+	}
+
+	lookup := &tierLookup{
+		byID:   make(map[uuid.UUID]*entities.TicketTier, len(tiers)),
+		byName: make(map[string]*entities.TicketTier, len(tiers)),
+	}
+	for _, tier := range tiers {
+		if !tier.IsActive {
+			continue
+		}
+		lookup.byID[tier.ID] = tier
+		lookup.byName[strings.ToLower(tier.Name)] = tier
+	}
+	return lookup, nil
+}
+
+// plannedRow is a row that passed validation and is ready to be written
+type plannedRow struct {
+	row  *entities.TicketImportRow
+	tier *entities.TicketTier
+}
+
+// processChunk resolves the tier of each row in a chunk and writes the rows not
+// already imported in a single transaction. If that transaction fails, the chunk
+// is retried one row per transaction so a single bad row cannot block its neighbours.
+func (s *TicketImportService) processChunk(ctx context.Context, ticketImport *entities.TicketImport, rows []*entities.TicketImportRow, tiers *tierLookup) error {
+	var planned []plannedRow
+
+	for _, row := range rows {
+		tier := tiers.resolve(stringValue(row.Tier))
+		if tier == nil {
+			row.MarkFailed(fmt.Sprintf("unknown ticket tier %q", stringValue(row.Tier)))
+			if err := s.importRepo.UpdateRow(ctx, row); err != nil {
+				return err
+			}
+			continue
+		}
+
+		existing, err := s.importRepo.FindOrderByExternalReference(ctx, ticketImport.EventID, stringValue(row.ExternalReference))
+		if err != nil {
+			return err
+		}
+		if existing != nil {
+			row.MarkSkipped(existing, "external reference already imported")
+			if err := s.importRepo.UpdateRow(ctx, row); err != nil {
+				return err
+			}
+			continue
+		}
+
+		planned = append(planned, plannedRow{row: row, tier: tier})
+	}
+
+	if len(planned) == 0 {
+		return nil
+	}
+
+	if err := s.writeRows(ctx, ticketImport, planned); err != nil {
+		for _, p := range planned {
+			err := s.writeRows(ctx, ticketImport, []plannedRow{p})
+			if err == nil {
+				continue
+			}
+
+			if errors.Is(err, entities.ErrOrderExternalReferenceExists) {
+				existing, _ := s.importRepo.FindOrderByExternalReference(ctx, ticketImport.EventID, stringValue(p.row.ExternalReference))
+				p.row.MarkSkipped(existing, "external reference already imported")
+			} else {
+				p.row.MarkFailed(rowErrorMessage(err))
+			}
+			if err := s.importRepo.UpdateRow(ctx, p.row); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// writeRows creates a paid order with signed tickets for each planned row in one
+// transaction, queueing the ticket emails alongside when the import sends them.
+// The rows' tiers are locked before their availability is read, so sales running
+// alongside cannot take the same tickets; rows that no longer fit are failed.
+func (s *TicketImportService) writeRows(ctx context.Context, ticketImport *entities.TicketImport, planned []plannedRow) error {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	tierIDs := make([]uuid.UUID, 0, len(planned))
+	for _, p := range planned {
+		tierIDs = append(tierIDs, p.tier.ID)
+	}
+	if _, err := tx.TicketTiers().LockForUpdate(tx.Context(), tierIDs); err != nil {
+		return fmt.Errorf("failed to lock ticket tiers: %w", err)
+	}
+
+	remaining := make(map[uuid.UUID]int)
+	for _, p := range planned {
+		row := p.row
+
+		available, ok := remaining[p.tier.ID]
+		if !ok {
+			available, err = tx.TicketTiers().GetAvailableQuantity(tx.Context(), p.tier.ID)
+			if err != nil {
+				return fmt.Errorf("failed to check availability: %w", err)
+			}
+		}
+		if available < row.Quantity {
+			remaining[p.tier.ID] = available
+			row.MarkFailed(fmt.Sprintf("not enough tickets left in tier %q (%d available)", p.tier.Name, available))
+			if err := tx.TicketImports().UpdateRow(tx.Context(), row); err != nil {
+				return err
+			}
+			continue
+		}
+		remaining[p.tier.ID] = available - row.Quantity
+
+		order := entities.NewOrder(ticketImport.EventID.String(), stringValue(row.Email))
+		order.ExternalReference = row.ExternalReference
+		order.Currency = entities.NormalizeCurrency(p.tier.Currency)
+		order.CustomerFirstName = stringValue(row.FirstName)
+		order.CustomerLastName = stringValue(row.LastName)
+		order.CustomerEmail = stringValue(row.Email)
+		order.CustomerPhone = stringValue(row.Phone)
+		order.IsImported = true
+
+		if err := tx.Orders().Create(tx.Context(), order); err != nil {
+			return err
+		}
+
+		orderLine := entities.NewOrderLine(order.ID, p.tier.ID, row.Quantity, p.tier.Price)
+		if err := tx.OrderLines().Create(tx.Context(), orderLine); err != nil {
+			return fmt.Errorf("failed to create order line: %w", err)
+		}
+
+		now := time.Now()
+		order.TotalAmount = orderLine.Subtotal
+		order.PaidAt = &now
+		order.MarkPaid()
+		if err := tx.Orders().Update(tx.Context(), order); err != nil {
+			return fmt.Errorf("failed to update order: %w", err)
+		}
+
+		if _, err := s.paymentService.IssueTickets(tx.Context(), tx, order, ticketImport.SendEmails); err != nil {
+			return fmt.Errorf("failed to issue tickets: %w", err)
+		}
+
+		row.MarkImported(order.ID)
+		if err := tx.TicketImports().UpdateRow(tx.Context(), row); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// rowErrorMessage turns an error into a message fit for the per-row report
+func rowErrorMessage(err error) string {
+	var validationErr *entities.ValidationError
+	var businessErr *entities.BusinessRuleError
+	switch {
+	case errors.As(err, &validationErr):
+		return validationErr.Message
+	case errors.As(err, &businessErr):
+		return businessErr.Message
+	}
+	return err.Error()
+}
+
+func stringValue(value *string) string {
+	if value == nil {
+		return ""
+	}
+	return *value
+}
@@ -0,0 +1,394 @@
+package imports
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	infrapayments "github.com/uduxpass/backend/internal/infrastructure/payments"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/payments"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+type fakeImports struct {
+	repositories.TicketImportRepository
+	imports map[uuid.UUID]*entities.TicketImport
+	rows    []*entities.TicketImportRow
+	orders  *fakeOrders
+	chunks  []int
+}
+
+func (f *fakeImports) GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketImport, error) {
+	ticketImport, ok := f.imports[id]
+	if !ok {
+		return nil, entities.ErrTicketImportNotFound
+	}
+	return ticketImport, nil
+}
+
+func (f *fakeImports) Update(ctx context.Context, ticketImport *entities.TicketImport) error {
+	f.imports[ticketImport.ID] = ticketImport
+	return nil
+}
+
+func (f *fakeImports) GetPendingRows(ctx context.Context, importID uuid.UUID, limit int) ([]*entities.TicketImportRow, error) {
+	var rows []*entities.TicketImportRow
+	for _, row := range f.rows {
+		if row.ImportID == importID && row.Status == entities.TicketImportRowPending && len(rows) < limit {
+			rows = append(rows, row)
+		}
+	}
+	if len(rows) > 0 {
+		f.chunks = append(f.chunks, len(rows))
+	}
+	return rows, nil
+}
+
+// UpdateRow has nothing to store, since the rows are shared with the service
+func (f *fakeImports) UpdateRow(ctx context.Context, row *entities.TicketImportRow) error {
+	return nil
+}
+
+func (f *fakeImports) CountRows(ctx context.Context, importID uuid.UUID) (*repositories.TicketImportRowCounts, error) {
+	counts := &repositories.TicketImportRowCounts{}
+	for _, row := range f.rows {
+		if row.ImportID != importID {
+			continue
+		}
+		counts.Total++
+		switch row.Status {
+		case entities.TicketImportRowPending:
+			counts.Pending++
+		case entities.TicketImportRowImported:
+			counts.Imported++
+			counts.TicketsIssued += row.Quantity
+		case entities.TicketImportRowSkipped:
+			counts.Skipped++
+		case entities.TicketImportRowFailed:
+			counts.Failed++
+		}
+	}
+	return counts, nil
+}
+
+func (f *fakeImports) FindOrderByExternalReference(ctx context.Context, eventID uuid.UUID, externalReference string) (*uuid.UUID, error) {
+	for _, order := range f.orders.orders {
+		if order.ExternalReference != nil && *order.ExternalReference == externalReference {
+			return &order.ID, nil
+		}
+	}
+	return nil, nil
+}
+
+type fakeOrders struct {
+	repositories.OrderRepository
+	orders map[uuid.UUID]*entities.Order
+}
+
+func (f *fakeOrders) Create(ctx context.Context, order *entities.Order) error {
+	f.orders[order.ID] = order
+	return nil
+}
+
+func (f *fakeOrders) Update(ctx context.Context, order *entities.Order) error {
+	f.orders[order.ID] = order
+	return nil
+}
+
+type fakeOrderLines struct {
+	repositories.OrderLineRepository
+	lines []*entities.OrderLine
+}
+
+func (f *fakeOrderLines) Create(ctx context.Context, line *entities.OrderLine) error {
+	f.lines = append(f.lines, line)
+	return nil
+}
+
+func (f *fakeOrderLines) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderLine, error) {
+	var lines []*entities.OrderLine
+	for _, line := range f.lines {
+		if line.OrderID == orderID {
+			lines = append(lines, line)
+		}
+	}
+	return lines, nil
+}
+
+// fakeTiers notes availability checked on a tier that is not locked, which a
+// concurrent sale could change before the import commits
+type fakeTiers struct {
+	repositories.TicketTierRepository
+	tiers    map[uuid.UUID]*entities.TicketTier
+	locked   map[uuid.UUID]bool
+	unlocked bool
+}
+
+func (f *fakeTiers) GetByEvent(ctx context.Context, eventID uuid.UUID) ([]*entities.TicketTier, error) {
+	var tiers []*entities.TicketTier
+	for _, tier := range f.tiers {
+		if tier.EventID == eventID {
+			tiers = append(tiers, tier)
+		}
+	}
+	return tiers, nil
+}
+
+func (f *fakeTiers) GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
+	tier, ok := f.tiers[id]
+	if !ok {
+		return nil, entities.ErrNotFoundError
+	}
+	return tier, nil
+}
+
+func (f *fakeTiers) LockForUpdate(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.TicketTier, error) {
+	tiers := make(map[uuid.UUID]*entities.TicketTier, len(ids))
+	for _, id := range ids {
+		tier, err := f.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		f.locked[id] = true
+		tiers[id] = tier
+	}
+	return tiers, nil
+}
+
+func (f *fakeTiers) GetAvailableQuantity(ctx context.Context, id uuid.UUID) (int, error) {
+	if !f.locked[id] {
+		f.unlocked = true
+	}
+	return f.tiers[id].Quota - f.tiers[id].Sold, nil
+}
+
+func (f *fakeTiers) IncrementSold(ctx context.Context, id uuid.UUID, quantity int) error {
+	f.tiers[id].Sold += quantity
+	return nil
+}
+
+type fakeTickets struct {
+	repositories.TicketRepository
+	tickets []*entities.Ticket
+}
+
+func (f *fakeTickets) CreateBatch(ctx context.Context, tickets []*entities.Ticket) error {
+	f.tickets = append(f.tickets, tickets...)
+	return nil
+}
+
+type fakeOutbox struct {
+	repositories.OutboxRepository
+	messages []*entities.OutboxMessage
+}
+
+func (f *fakeOutbox) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+// fakeTx releases the tier locks when the transaction ends
+type fakeTx struct {
+	repositories.Transaction
+	ctx        context.Context
+	imports    *fakeImports
+	orders     *fakeOrders
+	orderLines *fakeOrderLines
+	tiers      *fakeTiers
+	tickets    *fakeTickets
+	outbox     *fakeOutbox
+}
+
+func (tx *fakeTx) Commit() error                                      { tx.tiers.locked = make(map[uuid.UUID]bool); return nil }
+func (tx *fakeTx) Rollback() error                                    { tx.tiers.locked = make(map[uuid.UUID]bool); return nil }
+func (tx *fakeTx) Context() context.Context                           { return tx.ctx }
+func (tx *fakeTx) TicketImports() repositories.TicketImportRepository { return tx.imports }
+func (tx *fakeTx) Orders() repositories.OrderRepository               { return tx.orders }
+func (tx *fakeTx) OrderLines() repositories.OrderLineRepository       { return tx.orderLines }
+func (tx *fakeTx) TicketTiers() repositories.TicketTierRepository     { return tx.tiers }
+func (tx *fakeTx) Tickets() repositories.TicketRepository             { return tx.tickets }
+func (tx *fakeTx) Outbox() repositories.OutboxRepository              { return tx.outbox }
+
+type fakeUnitOfWork struct {
+	tx    *fakeTx
+	begun int
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	u.begun++
+	u.tx.ctx = ctx
+	return u.tx, nil
+}
+
+type importFixture struct {
+	service      *TicketImportService
+	unitOfWork   *fakeUnitOfWork
+	tx           *fakeTx
+	ticketImport *entities.TicketImport
+	tier         *entities.TicketTier
+}
+
+// newImportFixture builds an import service over fakes, with an import staged
+// for an event whose Regular tier has 10 tickets left
+func newImportFixture(t *testing.T) *importFixture {
+	t.Helper()
+
+	ticketImport := entities.NewTicketImport(uuid.New())
+	tier := entities.NewTicketTier(ticketImport.EventID, "Regular", 10000)
+	tier.Quota = 10
+
+	orders := &fakeOrders{orders: make(map[uuid.UUID]*entities.Order)}
+	imports := &fakeImports{
+		imports: map[uuid.UUID]*entities.TicketImport{ticketImport.ID: ticketImport},
+		orders:  orders,
+	}
+	orderLines := &fakeOrderLines{}
+	tickets := &fakeTickets{}
+	tiers := &fakeTiers{
+		tiers:  map[uuid.UUID]*entities.TicketTier{tier.ID: tier},
+		locked: make(map[uuid.UUID]bool),
+	}
+	tx := &fakeTx{
+		imports:    imports,
+		orders:     orders,
+		orderLines: orderLines,
+		tiers:      tiers,
+		tickets:    tickets,
+		outbox:     &fakeOutbox{},
+	}
+	unitOfWork := &fakeUnitOfWork{tx: tx}
+
+	paymentService := payments.NewPaymentService(
+		nil, nil, orderLines, tickets, nil, nil,
+		infrapayments.MoMoProvider{}, infrapayments.PaystackProvider{},
+		nil, nil, eventbus.NewBus(nil, nil), "test-secret",
+	)
+
+	return &importFixture{
+		service:      NewTicketImportService(imports, nil, tiers, unitOfWork, paymentService),
+		unitOfWork:   unitOfWork,
+		tx:           tx,
+		ticketImport: ticketImport,
+		tier:         tier,
+	}
+}
+
+// stage adds a pending row for the fixture's tier
+func (f *importFixture) stage(reference string, quantity int) *entities.TicketImportRow {
+	row := entities.NewTicketImportRow(f.ticketImport.ID, len(f.tx.imports.rows)+2)
+	tierName := f.tier.Name
+	row.ExternalReference = &reference
+	row.Tier = &tierName
+	row.SetName("Ada Obi")
+	row.Quantity = quantity
+	f.tx.imports.rows = append(f.tx.imports.rows, row)
+	return row
+}
+
+func TestProcessWritesPendingRowsInChunks(t *testing.T) {
+	f := newImportFixture(t)
+	f.service.chunkSize = 2
+	for i := 1; i <= 5; i++ {
+		f.stage(fmt.Sprintf("REF-%d", i), 1)
+	}
+
+	if err := f.service.process(context.Background(), f.ticketImport.ID); err != nil {
+		t.Fatalf("process() error = %v", err)
+	}
+
+	if got := f.tx.imports.chunks; len(got) != 3 || got[0] != 2 || got[1] != 2 || got[2] != 1 {
+		t.Errorf("process() read chunks of %v rows, want [2 2 1]", got)
+	}
+	if f.unitOfWork.begun != 3 {
+		t.Errorf("process() used %d transactions, want one per chunk", f.unitOfWork.begun)
+	}
+	if f.ticketImport.Status != entities.TicketImportStatusCompleted || f.ticketImport.ImportedRows != 5 || f.ticketImport.TicketsIssued != 5 {
+		t.Errorf("import = %s with %d rows and %d tickets, want completed with 5 and 5", f.ticketImport.Status, f.ticketImport.ImportedRows, f.ticketImport.TicketsIssued)
+	}
+	for _, order := range f.tx.orders.orders {
+		if !order.IsImported || order.Status != entities.OrderStatusPaid {
+			t.Errorf("order %s imported = %v, status = %s, want a paid imported order", order.Code, order.IsImported, order.Status)
+		}
+	}
+}
+
+func TestProcessResumesFromPendingRows(t *testing.T) {
+	f := newImportFixture(t)
+	done := f.stage("REF-1", 1)
+	done.MarkImported(uuid.New())
+	// Uploaded again in another file, so an order already carries the reference
+	duplicate := f.stage("REF-2", 1)
+	existing := entities.NewOrder(f.ticketImport.EventID.String(), "")
+	existing.ExternalReference = duplicate.ExternalReference
+	f.tx.orders.orders[existing.ID] = existing
+	pending := f.stage("REF-3", 2)
+
+	if err := f.service.process(context.Background(), f.ticketImport.ID); err != nil {
+		t.Fatalf("process() error = %v", err)
+	}
+
+	if duplicate.Status != entities.TicketImportRowSkipped || duplicate.OrderID == nil || *duplicate.OrderID != existing.ID {
+		t.Errorf("duplicate row = %s, want skipped against the existing order", duplicate.Status)
+	}
+	if pending.Status != entities.TicketImportRowImported {
+		t.Errorf("pending row = %s, want imported", pending.Status)
+	}
+	if len(f.tx.tickets.tickets) != 2 {
+		t.Errorf("process() issued %d tickets, want only the 2 of the pending row", len(f.tx.tickets.tickets))
+	}
+	if f.ticketImport.ImportedRows != 2 || f.ticketImport.SkippedRows != 1 {
+		t.Errorf("import counted %d imported and %d skipped rows, want 2 and 1", f.ticketImport.ImportedRows, f.ticketImport.SkippedRows)
+	}
+}
+
+func TestProcessFailsRowsThatNoLongerFit(t *testing.T) {
+	f := newImportFixture(t)
+	first := f.stage("REF-1", 2)
+	tooMany := f.stage("REF-2", 2)
+	last := f.stage("REF-3", 1)
+
+	// A sale after the upload leaves 3 tickets
+	f.tier.Sold = 7
+
+	if err := f.service.process(context.Background(), f.ticketImport.ID); err != nil {
+		t.Fatalf("process() error = %v", err)
+	}
+
+	if f.tx.tiers.unlocked {
+		t.Error("process() checked availability before locking the tier")
+	}
+	if first.Status != entities.TicketImportRowImported || last.Status != entities.TicketImportRowImported {
+		t.Errorf("rows = %s and %s, want both imported", first.Status, last.Status)
+	}
+	want := `not enough tickets left in tier "Regular" (1 available)`
+	if tooMany.Status != entities.TicketImportRowFailed || tooMany.Error == nil || *tooMany.Error != want {
+		t.Errorf("row over capacity = %s (%v), want failed with %q", tooMany.Status, stringValue(tooMany.Error), want)
+	}
+	if f.tier.Sold != 10 {
+		t.Errorf("tier sold = %d, want 10", f.tier.Sold)
+	}
+	if f.ticketImport.Status != entities.TicketImportStatusCompletedWithErrors {
+		t.Errorf("import = %s, want %s", f.ticketImport.Status, entities.TicketImportStatusCompletedWithErrors)
+	}
+}
+
+func TestProcessFailsRowsForUnknownTier(t *testing.T) {
+	f := newImportFixture(t)
+	row := f.stage("REF-1", 1)
+	unknown := "Backstage"
+	row.Tier = &unknown
+
+	if err := f.service.process(context.Background(), f.ticketImport.ID); err != nil {
+		t.Fatalf("process() error = %v", err)
+	}
+
+	if row.Status != entities.TicketImportRowFailed || f.unitOfWork.begun != 0 {
+		t.Errorf("row = %s after %d transactions, want failed without writing", row.Status, f.unitOfWork.begun)
+	}
+}
@@ -0,0 +1,264 @@
+package marketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// CheckoutConsent is the marketing choice a customer makes at checkout: to
+// hear from the event's organizer, from the platform, or both. An unticked
+// box records nothing, so checkout never withdraws consent given elsewhere.
+type CheckoutConsent struct {
+	Organizer bool `json:"organizer"`
+	Platform  bool `json:"platform"`
+}
+
+// ConsentMetadata is where a consent choice was made, kept in the audit trail
+type ConsentMetadata struct {
+	IPAddress string
+	UserAgent string
+}
+
+// ConsentChoice is a customer's current choice for the platform, when
+// OrganizerID is nil, or for one organizer
+type ConsentChoice struct {
+	OrganizerID   *uuid.UUID `json:"organizer_id,omitempty"`
+	OrganizerName string     `json:"organizer_name,omitempty"`
+	Granted       bool       `json:"granted"`
+	Source        string     `json:"source,omitempty"`
+	PolicyVersion string     `json:"policy_version,omitempty"`
+	UpdatedAt     *time.Time `json:"updated_at,omitempty"`
+}
+
+// UpdateConsentRequest represents a choice made in a user's settings. Without
+// an organizer it is the choice for platform-wide marketing.
+type UpdateConsentRequest struct {
+	OrganizerID *uuid.UUID `json:"organizer_id,omitempty"`
+	Granted     *bool      `json:"granted" validate:"required"`
+}
+
+// UnsubscribeInfo describes who a marketing unsubscribe link stops emails from
+type UnsubscribeInfo struct {
+	OrganizerID   *uuid.UUID `json:"organizer_id,omitempty"`
+	OrganizerName string     `json:"organizer_name,omitempty"`
+	CampaignName  string     `json:"campaign_name"`
+	Subscribed    bool       `json:"subscribed"`
+}
+
+// RecordCheckoutConsent records the marketing boxes a customer ticked when
+// placing an order, against the order's organizer and the platform
+func (s *MarketingService) RecordCheckoutConsent(ctx context.Context, order *entities.Order, choice *CheckoutConsent, metadata ConsentMetadata) error {
+	if choice == nil || (!choice.Organizer && !choice.Platform) {
+		return nil
+	}
+
+	email := order.CustomerEmail
+	if email == "" {
+		email = order.Email
+	}
+	contactKey := entities.EmailContactKey(email)
+	if order.UserID != nil {
+		contactKey = entities.UserContactKey(*order.UserID)
+	} else if email == "" {
+		return nil
+	}
+
+	var scopes []*uuid.UUID
+	if choice.Platform {
+		scopes = append(scopes, nil)
+	}
+	if choice.Organizer {
+		organizerID, err := s.orderOrganizer(ctx, order)
+		if err != nil {
+			return err
+		}
+		if organizerID != nil {
+			scopes = append(scopes, organizerID)
+		}
+	}
+
+	for _, organizerID := range scopes {
+		record := entities.NewMarketingConsentRecord(contactKey, order.UserID, optionalText(&email), organizerID, true, entities.ConsentSourceCheckout, s.config.PolicyVersion)
+		record.OrderID = &order.ID
+		applyMetadata(record, metadata)
+		if _, err := s.consentRepo.Record(ctx, record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// orderOrganizer returns the organizer of an order's event; events without
+// an organizer are marketed by the platform alone
+func (s *MarketingService) orderOrganizer(ctx context.Context, order *entities.Order) (*uuid.UUID, error) {
+	eventID, err := uuid.Parse(order.EventID)
+	if err != nil {
+		return nil, nil
+	}
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event for order %s: %w", order.Code, err)
+	}
+	return event.OrganizerID, nil
+}
+
+// GetConsents retrieves a user's choices: platform-wide first, then every
+// organizer they bought from or made a choice for
+func (s *MarketingService) GetConsents(ctx context.Context, userID uuid.UUID) ([]*ConsentChoice, error) {
+	consents, err := s.consentRepo.ListConsents(ctx, entities.UserContactKey(userID))
+	if err != nil {
+		return nil, err
+	}
+	organizers, err := s.consentRepo.ListUserOrganizers(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	choices := []*ConsentChoice{{}}
+	byOrganizer := make(map[uuid.UUID]*ConsentChoice, len(organizers))
+	for _, organizer := range organizers {
+		organizerID := organizer.ID
+		choice := &ConsentChoice{OrganizerID: &organizerID, OrganizerName: organizer.Name}
+		byOrganizer[organizerID] = choice
+		choices = append(choices, choice)
+	}
+
+	for _, consent := range consents {
+		choice := choices[0]
+		if consent.OrganizerID != nil {
+			var ok bool
+			if choice, ok = byOrganizer[*consent.OrganizerID]; !ok {
+				choice = &ConsentChoice{OrganizerID: consent.OrganizerID}
+				if organizer, err := s.organizerRepo.GetByID(ctx, *consent.OrganizerID); err == nil {
+					choice.OrganizerName = organizer.Name
+				}
+				byOrganizer[*consent.OrganizerID] = choice
+				choices = append(choices, choice)
+			}
+		}
+		updatedAt := consent.UpdatedAt
+		choice.Granted = consent.Granted
+		choice.Source = consent.Source
+		choice.PolicyVersion = consent.PolicyVersion
+		choice.UpdatedAt = &updatedAt
+	}
+	return choices, nil
+}
+
+// UpdateConsent records a choice made in a user's settings and returns all their choices
+func (s *MarketingService) UpdateConsent(ctx context.Context, userID uuid.UUID, req *UpdateConsentRequest, metadata ConsentMetadata) ([]*ConsentChoice, error) {
+	if req.OrganizerID != nil {
+		if _, err := s.getOrganizer(ctx, *req.OrganizerID); err != nil {
+			return nil, err
+		}
+	}
+
+	user, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if errors.Is(err, entities.ErrUserNotFound) {
+			return nil, entities.NewNotFoundError("user", "user not found")
+		}
+		return nil, err
+	}
+
+	record := entities.NewMarketingConsentRecord(entities.UserContactKey(userID), &userID, optionalText(user.Email), req.OrganizerID, *req.Granted, entities.ConsentSourceSettings, s.config.PolicyVersion)
+	applyMetadata(record, metadata)
+	if _, err := s.consentRepo.Record(ctx, record); err != nil {
+		return nil, err
+	}
+	return s.GetConsents(ctx, userID)
+}
+
+// ListConsentHistory retrieves every choice a user has made, newest first
+func (s *MarketingService) ListConsentHistory(ctx context.Context, userID uuid.UUID, page, limit int) ([]*entities.MarketingConsentRecord, *repositories.PaginationResult, error) {
+	contactKey := entities.UserContactKey(userID)
+	return s.consentRepo.ListRecords(ctx, repositories.MarketingConsentRecordFilter{
+		BaseFilter: repositories.BaseFilter{Page: page, Limit: limit},
+		ContactKey: &contactKey,
+	})
+}
+
+// ListConsentRecords retrieves the consent audit trail for admins
+func (s *MarketingService) ListConsentRecords(ctx context.Context, filter repositories.MarketingConsentRecordFilter) ([]*entities.MarketingConsentRecord, *repositories.PaginationResult, error) {
+	return s.consentRepo.ListRecords(ctx, filter)
+}
+
+// GetUnsubscribe describes who the link's campaign was from and whether the member still hears from them
+func (s *MarketingService) GetUnsubscribe(ctx context.Context, token string) (*UnsubscribeInfo, error) {
+	delivery, campaign, err := s.getByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.unsubscribeInfo(ctx, delivery, campaign)
+}
+
+// Unsubscribe withdraws the member's consent for the campaign's organizer, or
+// for platform marketing when the campaign was the platform's. Links keep
+// working, so repeating one only adds to the audit trail.
+func (s *MarketingService) Unsubscribe(ctx context.Context, token string, metadata ConsentMetadata) (*UnsubscribeInfo, error) {
+	delivery, campaign, err := s.getByToken(ctx, token)
+	if err != nil {
+		return nil, err
+	}
+
+	record := entities.NewMarketingConsentRecord(delivery.ContactKey, delivery.UserID, &delivery.Email, campaign.OrganizerID, false, entities.ConsentSourceUnsubscribe, s.config.PolicyVersion)
+	applyMetadata(record, metadata)
+	if _, err := s.consentRepo.Record(ctx, record); err != nil {
+		return nil, err
+	}
+	return s.unsubscribeInfo(ctx, delivery, campaign)
+}
+
+func (s *MarketingService) getByToken(ctx context.Context, token string) (*entities.MarketingDelivery, *entities.MarketingCampaign, error) {
+	delivery, err := s.campaignRepo.GetDeliveryByToken(ctx, token)
+	if err != nil {
+		if errors.Is(err, entities.ErrMarketingDeliveryNotFound) {
+			return nil, nil, entities.NewNotFoundError("unsubscribe_link", "unsubscribe link not found")
+		}
+		return nil, nil, err
+	}
+	campaign, err := s.GetCampaign(ctx, delivery.CampaignID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return delivery, campaign, nil
+}
+
+func (s *MarketingService) unsubscribeInfo(ctx context.Context, delivery *entities.MarketingDelivery, campaign *entities.MarketingCampaign) (*UnsubscribeInfo, error) {
+	subscribed, err := s.consentRepo.HasConsent(ctx, delivery.ContactKey, &delivery.Email, campaign.OrganizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	info := &UnsubscribeInfo{
+		OrganizerID:  campaign.OrganizerID,
+		CampaignName: campaign.Name,
+		Subscribed:   subscribed,
+	}
+	if campaign.OrganizerID != nil {
+		if organizer, err := s.organizerRepo.GetByID(ctx, *campaign.OrganizerID); err == nil {
+			info.OrganizerName = organizer.Name
+		}
+	}
+	return info, nil
+}
+
+// applyMetadata copies where a choice was made onto its audit record
+func applyMetadata(record *entities.MarketingConsentRecord, metadata ConsentMetadata) {
+	if ip := strings.TrimSpace(metadata.IPAddress); ip != "" {
+		record.IPAddress = &ip
+	}
+	if userAgent := strings.TrimSpace(metadata.UserAgent); userAgent != "" {
+		if len(userAgent) > 500 {
+			userAgent = userAgent[:500]
+		}
+		record.UserAgent = &userAgent
+	}
+}
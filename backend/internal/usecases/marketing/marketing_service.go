@@ -0,0 +1,556 @@
+package marketing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+const (
+	// DefaultPolicyVersion identifies the consent wording customers are shown,
+	// overridden with MARKETING_CONSENT_POLICY_VERSION whenever the wording changes
+	DefaultPolicyVersion = "1"
+
+	// DefaultBatchSize is the number of audience members emailed per batch
+	DefaultBatchSize = 100
+
+	// DefaultSchedulerInterval is how often the scheduler looks for due campaigns
+	DefaultSchedulerInterval = time.Minute
+
+	// defaultBatchPause spaces batches out to stay within provider rate limits
+	defaultBatchPause = time.Second
+
+	// staleAfter is how long a processing campaign may go without progress
+	// before the scheduler takes it over, e.g. after a crash
+	staleAfter = 10 * time.Minute
+
+	// dueLimit caps the campaigns picked up per scheduler run
+	dueLimit = 20
+)
+
+// Config sets the version of the consent wording recorded with every choice
+type Config struct {
+	PolicyVersion string
+}
+
+// MarketingService records marketing consent and sends marketing campaigns.
+// Customers opt in at checkout or in their settings, per organizer and for
+// the platform, and every choice is kept in an audit trail. Organizers build
+// audiences from purchase history; a campaign emails only the members whose
+// latest choice for the campaign's organizer is a grant, checked again just
+// before each email is sent, and every email carries an unsubscribe link.
+type MarketingService struct {
+	consentRepo   repositories.MarketingConsentRepository
+	campaignRepo  repositories.MarketingCampaignRepository
+	organizerRepo repositories.OrganizerRepository
+	eventRepo     repositories.EventRepository
+	userRepo      repositories.UserRepository
+	unitOfWork    repositories.UnitOfWork
+	emailService  services.EmailService
+	config        Config
+	batchSize     int
+	batchPause    time.Duration
+}
+
+// NewMarketingService creates a new marketing service. An empty policy version takes the default.
+func NewMarketingService(
+	consentRepo repositories.MarketingConsentRepository,
+	campaignRepo repositories.MarketingCampaignRepository,
+	organizerRepo repositories.OrganizerRepository,
+	eventRepo repositories.EventRepository,
+	userRepo repositories.UserRepository,
+	unitOfWork repositories.UnitOfWork,
+	emailService services.EmailService,
+	config Config,
+) *MarketingService {
+	if config.PolicyVersion == "" {
+		config.PolicyVersion = DefaultPolicyVersion
+	}
+	return &MarketingService{
+		consentRepo:   consentRepo,
+		campaignRepo:  campaignRepo,
+		organizerRepo: organizerRepo,
+		eventRepo:     eventRepo,
+		userRepo:      userRepo,
+		unitOfWork:    unitOfWork,
+		emailService:  emailService,
+		config:        config,
+		batchSize:     DefaultBatchSize,
+		batchPause:    defaultBatchPause,
+	}
+}
+
+// CreateAudienceRequest represents the request to save an audience. Without
+// an organizer the audience covers buyers from every organizer, for platform marketing.
+type CreateAudienceRequest struct {
+	OrganizerID *uuid.UUID                `json:"organizer_id,omitempty"`
+	Name        string                    `json:"name" validate:"required,max=100"`
+	Description *string                   `json:"description,omitempty"`
+	Criteria    entities.AudienceCriteria `json:"criteria"`
+	CreatedBy   *uuid.UUID                `json:"-"`
+}
+
+// UpdateAudienceRequest represents changes to an audience. Omitted fields are left unchanged.
+type UpdateAudienceRequest struct {
+	Name        *string                    `json:"name,omitempty" validate:"omitempty,max=100"`
+	Description *string                    `json:"description,omitempty"`
+	Criteria    *entities.AudienceCriteria `json:"criteria,omitempty"`
+}
+
+// AudiencePreview is an audience with how many buyers it matches and how many can be emailed
+type AudiencePreview struct {
+	Audience *entities.MarketingAudience `json:"audience"`
+	Size     *repositories.AudienceSize  `json:"size"`
+}
+
+// CreateAudience saves a segment of past buyers
+func (s *MarketingService) CreateAudience(ctx context.Context, req *CreateAudienceRequest) (*entities.MarketingAudience, error) {
+	if req.OrganizerID != nil {
+		if _, err := s.getOrganizer(ctx, *req.OrganizerID); err != nil {
+			return nil, err
+		}
+	}
+
+	audience := entities.NewMarketingAudience(req.OrganizerID, req.Name, req.Criteria)
+	audience.Description = optionalText(req.Description)
+	audience.CreatedBy = req.CreatedBy
+	if err := s.validateAudience(ctx, audience); err != nil {
+		return nil, err
+	}
+
+	if err := s.campaignRepo.CreateAudience(ctx, audience); err != nil {
+		return nil, err
+	}
+	return audience, nil
+}
+
+// GetAudience retrieves an audience
+func (s *MarketingService) GetAudience(ctx context.Context, audienceID uuid.UUID) (*entities.MarketingAudience, error) {
+	audience, err := s.campaignRepo.GetAudience(ctx, audienceID)
+	if err != nil {
+		if errors.Is(err, entities.ErrMarketingAudienceNotFound) {
+			return nil, entities.NewNotFoundError("marketing_audience", "marketing audience not found")
+		}
+		return nil, err
+	}
+	return audience, nil
+}
+
+// UpdateAudience changes an audience's name or criteria. Campaigns already
+// sent keep the members they were sent to.
+func (s *MarketingService) UpdateAudience(ctx context.Context, audienceID uuid.UUID, req *UpdateAudienceRequest) (*entities.MarketingAudience, error) {
+	audience, err := s.GetAudience(ctx, audienceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		audience.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		audience.Description = optionalText(req.Description)
+	}
+	if req.Criteria != nil {
+		audience.Criteria = *req.Criteria
+		audience.Normalize()
+	}
+	audience.UpdatedAt = time.Now()
+	if err := s.validateAudience(ctx, audience); err != nil {
+		return nil, err
+	}
+
+	if err := s.campaignRepo.UpdateAudience(ctx, audience); err != nil {
+		return nil, err
+	}
+	return audience, nil
+}
+
+// DeleteAudience deletes an audience no campaign uses
+func (s *MarketingService) DeleteAudience(ctx context.Context, audienceID uuid.UUID) error {
+	if err := s.campaignRepo.DeleteAudience(ctx, audienceID); err != nil {
+		if errors.Is(err, entities.ErrMarketingAudienceNotFound) {
+			return entities.NewNotFoundError("marketing_audience", "marketing audience not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// ListAudiences retrieves audiences with pagination and filtering
+func (s *MarketingService) ListAudiences(ctx context.Context, filter repositories.MarketingAudienceFilter) ([]*entities.MarketingAudience, *repositories.PaginationResult, error) {
+	return s.campaignRepo.ListAudiences(ctx, filter)
+}
+
+// PreviewAudience counts who an audience matches right now and how many of them consented
+func (s *MarketingService) PreviewAudience(ctx context.Context, audienceID uuid.UUID) (*AudiencePreview, error) {
+	audience, err := s.GetAudience(ctx, audienceID)
+	if err != nil {
+		return nil, err
+	}
+
+	size, err := s.campaignRepo.CountAudience(ctx, audience)
+	if err != nil {
+		return nil, err
+	}
+	return &AudiencePreview{Audience: audience, Size: size}, nil
+}
+
+// validateAudience checks the audience and that an organizer's audience only
+// selects buyers of the organizer's own events
+func (s *MarketingService) validateAudience(ctx context.Context, audience *entities.MarketingAudience) error {
+	if err := audience.Validate(); err != nil {
+		return err
+	}
+
+	for _, eventID := range audience.Criteria.EventIDs {
+		event, err := s.eventRepo.GetByID(ctx, eventID)
+		if err != nil {
+			return entities.NewValidationError("criteria.event_ids", fmt.Sprintf("event %s not found", eventID))
+		}
+		if audience.OrganizerID != nil && (event.OrganizerID == nil || *event.OrganizerID != *audience.OrganizerID) {
+			return entities.NewValidationError("criteria.event_ids", fmt.Sprintf("event %s belongs to another organizer", eventID))
+		}
+	}
+	return nil
+}
+
+// CreateCampaignRequest represents the request to schedule a marketing email
+// to an audience. Without a send time it goes out on the scheduler's next run.
+type CreateCampaignRequest struct {
+	AudienceID uuid.UUID  `json:"audience_id" validate:"required"`
+	Name       string     `json:"name" validate:"required,max=100"`
+	Subject    string     `json:"subject" validate:"required,max=150"`
+	Message    string     `json:"message" validate:"required"`
+	LinkURL    *string    `json:"link_url,omitempty"`
+	LinkLabel  *string    `json:"link_label,omitempty"`
+	SendAt     *time.Time `json:"send_at,omitempty"`
+	CreatedBy  *uuid.UUID `json:"-"`
+}
+
+// UpdateCampaignRequest represents changes to a campaign that has not been sent. Omitted fields are left unchanged.
+type UpdateCampaignRequest struct {
+	Name      *string    `json:"name,omitempty" validate:"omitempty,max=100"`
+	Subject   *string    `json:"subject,omitempty" validate:"omitempty,max=150"`
+	Message   *string    `json:"message,omitempty"`
+	LinkURL   *string    `json:"link_url,omitempty"`
+	LinkLabel *string    `json:"link_label,omitempty"`
+	SendAt    *time.Time `json:"send_at,omitempty"`
+}
+
+// CreateCampaign schedules a marketing email to an audience, sent on behalf
+// of the audience's organizer
+func (s *MarketingService) CreateCampaign(ctx context.Context, req *CreateCampaignRequest) (*entities.MarketingCampaign, error) {
+	audience, err := s.GetAudience(ctx, req.AudienceID)
+	if err != nil {
+		return nil, err
+	}
+
+	sendAt := time.Now()
+	if req.SendAt != nil {
+		sendAt = *req.SendAt
+	}
+	campaign := entities.NewMarketingCampaign(audience, req.Name, req.Subject, req.Message, sendAt)
+	campaign.LinkURL = optionalText(req.LinkURL)
+	campaign.LinkLabel = optionalText(req.LinkLabel)
+	campaign.CreatedBy = req.CreatedBy
+	if err := campaign.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.campaignRepo.Create(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// GetCampaign retrieves a campaign and its progress counters
+func (s *MarketingService) GetCampaign(ctx context.Context, campaignID uuid.UUID) (*entities.MarketingCampaign, error) {
+	campaign, err := s.campaignRepo.GetByID(ctx, campaignID)
+	if err != nil {
+		if errors.Is(err, entities.ErrMarketingCampaignNotFound) {
+			return nil, entities.NewNotFoundError("marketing_campaign", "marketing campaign not found")
+		}
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// UpdateCampaign changes the content or send time of a campaign that has not been sent
+func (s *MarketingService) UpdateCampaign(ctx context.Context, campaignID uuid.UUID, req *UpdateCampaignRequest) (*entities.MarketingCampaign, error) {
+	campaign, err := s.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	if !campaign.IsEditable() {
+		return nil, entities.NewBusinessRuleError("campaign_not_editable", "only scheduled campaigns can be changed", map[string]interface{}{
+			"status": campaign.Status,
+		})
+	}
+
+	if req.Name != nil {
+		campaign.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Subject != nil {
+		campaign.Subject = strings.TrimSpace(*req.Subject)
+	}
+	if req.Message != nil {
+		campaign.Message = strings.TrimSpace(*req.Message)
+	}
+	if req.LinkURL != nil {
+		campaign.LinkURL = optionalText(req.LinkURL)
+	}
+	if req.LinkLabel != nil {
+		campaign.LinkLabel = optionalText(req.LinkLabel)
+	}
+	if req.SendAt != nil {
+		campaign.SendAt = *req.SendAt
+	}
+	campaign.UpdatedAt = time.Now()
+	if err := campaign.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.campaignRepo.Update(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// CancelCampaign stops a campaign that is not being sent right now. Members
+// a failed campaign has not reached yet are skipped.
+func (s *MarketingService) CancelCampaign(ctx context.Context, campaignID uuid.UUID) (*entities.MarketingCampaign, error) {
+	campaign, err := s.GetCampaign(ctx, campaignID)
+	if err != nil {
+		return nil, err
+	}
+	switch campaign.Status {
+	case entities.CampaignStatusScheduled, entities.CampaignStatusFailed:
+	case entities.CampaignStatusProcessing:
+		return nil, entities.NewConflictError("marketing_campaign", "campaign is being sent", nil)
+	default:
+		return nil, entities.NewBusinessRuleError("campaign_finished", "campaign has already finished", map[string]interface{}{
+			"status": campaign.Status,
+		})
+	}
+
+	if err := s.close(ctx, campaign, entities.CampaignStatusCancelled, entities.CampaignSkipCancelled, ""); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// ListCampaigns retrieves campaigns with pagination and filtering
+func (s *MarketingService) ListCampaigns(ctx context.Context, filter repositories.MarketingCampaignFilter) ([]*entities.MarketingCampaign, *repositories.PaginationResult, error) {
+	return s.campaignRepo.List(ctx, filter)
+}
+
+// ListDeliveries retrieves the per-member delivery report of a campaign
+func (s *MarketingService) ListDeliveries(ctx context.Context, filter repositories.MarketingDeliveryFilter) ([]*entities.MarketingDelivery, *repositories.PaginationResult, error) {
+	if _, err := s.GetCampaign(ctx, filter.CampaignID); err != nil {
+		return nil, nil, err
+	}
+	return s.campaignRepo.ListDeliveries(ctx, filter)
+}
+
+// RunScheduler sends due campaigns every interval until ctx is done
+func (s *MarketingService) RunScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.SendDue(ctx); err != nil {
+			fmt.Printf("Warning: failed to send due marketing campaigns: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// SendDue sends every due campaign, picking up ones a previous run left unfinished
+func (s *MarketingService) SendDue(ctx context.Context) error {
+	staleBefore := time.Now().Add(-staleAfter)
+	campaigns, err := s.campaignRepo.GetDue(ctx, staleBefore, dueLimit)
+	if err != nil {
+		return err
+	}
+
+	for _, campaign := range campaigns {
+		claimed, err := s.campaignRepo.Claim(ctx, campaign.ID, staleBefore)
+		if err != nil {
+			return err
+		}
+		if !claimed {
+			continue
+		}
+		if err := s.process(ctx, campaign); err != nil {
+			fmt.Printf("Warning: marketing campaign %s stopped: %v\n", campaign.ID, err)
+		}
+	}
+	return nil
+}
+
+// process stages the deliveries once, then sends the pending ones batch by batch
+func (s *MarketingService) process(ctx context.Context, campaign *entities.MarketingCampaign) error {
+	campaign.MarkProcessing()
+
+	fail := func(err error) error {
+		campaign.MarkFailed(err.Error())
+		if updateErr := s.campaignRepo.Update(ctx, campaign); updateErr != nil {
+			fmt.Printf("Warning: failed to record marketing campaign %s failure: %v\n", campaign.ID, updateErr)
+		}
+		return err
+	}
+
+	// Members are captured once, when the campaign comes due
+	if campaign.RecipientsStagedAt == nil {
+		audience, err := s.campaignRepo.GetAudience(ctx, campaign.AudienceID)
+		if err != nil {
+			return fail(fmt.Errorf("failed to get audience: %w", err))
+		}
+		if err := s.stageDeliveries(ctx, campaign, audience); err != nil {
+			return fail(err)
+		}
+	}
+
+	for {
+		deliveries, err := s.campaignRepo.GetPendingDeliveries(ctx, campaign.ID, s.batchSize)
+		if err != nil {
+			return fail(err)
+		}
+		if len(deliveries) == 0 {
+			break
+		}
+
+		for _, delivery := range deliveries {
+			if err := s.processDelivery(ctx, campaign, delivery); err != nil {
+				return fail(err)
+			}
+		}
+
+		if err := s.refreshProgress(ctx, campaign); err != nil {
+			return fail(err)
+		}
+		time.Sleep(s.batchPause)
+	}
+
+	if err := s.refreshProgress(ctx, campaign); err != nil {
+		return fail(err)
+	}
+	campaign.MarkFinished()
+	return s.campaignRepo.Update(ctx, campaign)
+}
+
+// stageDeliveries records one delivery per consenting member in the same
+// transaction that marks the campaign as staged, so a restart never stages twice
+func (s *MarketingService) stageDeliveries(ctx context.Context, campaign *entities.MarketingCampaign, audience *entities.MarketingAudience) error {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	staged, err := tx.MarketingCampaigns().StageDeliveries(tx.Context(), campaign, audience)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	campaign.RecipientsStagedAt = &now
+	campaign.TotalRecipients = staged
+	campaign.UpdatedAt = now
+	if err := tx.MarketingCampaigns().Update(tx.Context(), campaign); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// processDelivery emails one member, unless they withdrew consent since the
+// campaign was staged. Send failures are recorded on the delivery and retried
+// on a later batch; only a failure to load or save the delivery's state
+// stops the campaign.
+func (s *MarketingService) processDelivery(ctx context.Context, campaign *entities.MarketingCampaign, delivery *entities.MarketingDelivery) error {
+	granted, err := s.consentRepo.HasConsent(ctx, delivery.ContactKey, &delivery.Email, campaign.OrganizerID)
+	if err != nil {
+		return err
+	}
+	if !granted {
+		delivery.Skip(entities.CampaignSkipNoConsent)
+		return s.campaignRepo.UpdateDelivery(ctx, delivery)
+	}
+
+	sendErr := s.emailService.SendMarketingEmail(ctx, campaign, delivery)
+	var businessErr *entities.BusinessRuleError
+	var validationErr *entities.ValidationError
+	switch {
+	case sendErr == nil:
+		delivery.MarkSent()
+	case errors.As(sendErr, &businessErr), errors.As(sendErr, &validationErr):
+		delivery.Fail(sendErr.Error())
+	default:
+		delivery.RecordAttemptError(sendErr.Error())
+	}
+
+	return s.campaignRepo.UpdateDelivery(ctx, delivery)
+}
+
+// close ends a campaign for good, skipping the members it has not reached
+func (s *MarketingService) close(ctx context.Context, campaign *entities.MarketingCampaign, status entities.CampaignStatus, skipReason, reason string) error {
+	if _, err := s.campaignRepo.SkipPendingDeliveries(ctx, campaign.ID, skipReason); err != nil {
+		return err
+	}
+	if err := s.refreshProgress(ctx, campaign); err != nil {
+		return err
+	}
+	campaign.Close(status, reason)
+	return s.campaignRepo.Update(ctx, campaign)
+}
+
+// refreshProgress recounts the deliveries so progress stays correct across resumes
+func (s *MarketingService) refreshProgress(ctx context.Context, campaign *entities.MarketingCampaign) error {
+	counts, err := s.campaignRepo.CountDeliveries(ctx, campaign.ID)
+	if err != nil {
+		return err
+	}
+
+	campaign.TotalRecipients = counts.Total
+	campaign.SentCount = counts.Sent
+	campaign.SkippedCount = counts.Skipped
+	campaign.FailedCount = counts.Failed
+	campaign.UpdatedAt = time.Now()
+	return s.campaignRepo.Update(ctx, campaign)
+}
+
+func (s *MarketingService) getOrganizer(ctx context.Context, organizerID uuid.UUID) (*entities.Organizer, error) {
+	organizer, err := s.organizerRepo.GetByID(ctx, organizerID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("organizer", "organizer not found")
+	}
+	return organizer, nil
+}
+
+// optionalText trims a text field, treating an empty value as unset
+func optionalText(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
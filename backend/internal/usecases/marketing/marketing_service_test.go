@@ -0,0 +1,715 @@
+package marketing
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+// fakeConsents keeps every choice in order, so the latest one for a contact
+// and organizer is their current choice, as the consent table is
+type fakeConsents struct {
+	repositories.MarketingConsentRepository
+	records    []*entities.MarketingConsentRecord
+	organizers map[uuid.UUID][]*repositories.ConsentOrganizer
+}
+
+func (f *fakeConsents) Record(ctx context.Context, record *entities.MarketingConsentRecord) (*entities.MarketingConsent, error) {
+	f.records = append(f.records, record)
+	return &entities.MarketingConsent{
+		ID:            uuid.New(),
+		ContactKey:    record.ContactKey,
+		UserID:        record.UserID,
+		Email:         record.Email,
+		OrganizerID:   record.OrganizerID,
+		Granted:       record.Granted,
+		Source:        record.Source,
+		PolicyVersion: record.PolicyVersion,
+		CreatedAt:     record.CreatedAt,
+		UpdatedAt:     record.CreatedAt,
+	}, nil
+}
+
+func (f *fakeConsents) ListConsents(ctx context.Context, contactKey string) ([]*entities.MarketingConsent, error) {
+	latest := make(map[uuid.UUID]*entities.MarketingConsentRecord)
+	var platform *entities.MarketingConsentRecord
+	var order []uuid.UUID
+	for _, record := range f.records {
+		if record.ContactKey != contactKey {
+			continue
+		}
+		if record.OrganizerID == nil {
+			platform = record
+			continue
+		}
+		if _, ok := latest[*record.OrganizerID]; !ok {
+			order = append(order, *record.OrganizerID)
+		}
+		latest[*record.OrganizerID] = record
+	}
+
+	var consents []*entities.MarketingConsent
+	toConsent := func(record *entities.MarketingConsentRecord) *entities.MarketingConsent {
+		return &entities.MarketingConsent{
+			ContactKey:    record.ContactKey,
+			OrganizerID:   record.OrganizerID,
+			Granted:       record.Granted,
+			Source:        record.Source,
+			PolicyVersion: record.PolicyVersion,
+			UpdatedAt:     record.CreatedAt,
+		}
+	}
+	if platform != nil {
+		consents = append(consents, toConsent(platform))
+	}
+	for _, organizerID := range order {
+		consents = append(consents, toConsent(latest[organizerID]))
+	}
+	return consents, nil
+}
+
+func (f *fakeConsents) HasConsent(ctx context.Context, contactKey string, email *string, organizerID *uuid.UUID) (bool, error) {
+	granted := false
+	for _, record := range f.records {
+		sameContact := record.ContactKey == contactKey || (email != nil && record.Email != nil && *record.Email == strings.ToLower(*email))
+		sameOrganizer := (record.OrganizerID == nil && organizerID == nil) ||
+			(record.OrganizerID != nil && organizerID != nil && *record.OrganizerID == *organizerID)
+		if sameContact && sameOrganizer {
+			granted = record.Granted
+		}
+	}
+	return granted, nil
+}
+
+func (f *fakeConsents) ListRecords(ctx context.Context, filter repositories.MarketingConsentRecordFilter) ([]*entities.MarketingConsentRecord, *repositories.PaginationResult, error) {
+	var records []*entities.MarketingConsentRecord
+	for i := len(f.records) - 1; i >= 0; i-- {
+		if filter.ContactKey != nil && f.records[i].ContactKey != *filter.ContactKey {
+			continue
+		}
+		records = append(records, f.records[i])
+	}
+	return records, &repositories.PaginationResult{Page: 1, Limit: filter.Limit, Total: len(records)}, nil
+}
+
+func (f *fakeConsents) ListUserOrganizers(ctx context.Context, userID uuid.UUID) ([]*repositories.ConsentOrganizer, error) {
+	return f.organizers[userID], nil
+}
+
+// fakeCampaigns stages every member of an audience, standing in for the
+// purchase history query, so the consent check made before each email is
+// what decides who is reached
+type fakeCampaigns struct {
+	repositories.MarketingCampaignRepository
+	audiences  map[uuid.UUID]*entities.MarketingAudience
+	campaigns  map[uuid.UUID]*entities.MarketingCampaign
+	members    []*entities.MarketingDelivery
+	deliveries []*entities.MarketingDelivery
+}
+
+func (f *fakeCampaigns) CreateAudience(ctx context.Context, audience *entities.MarketingAudience) error {
+	f.audiences[audience.ID] = audience
+	return nil
+}
+
+func (f *fakeCampaigns) GetAudience(ctx context.Context, id uuid.UUID) (*entities.MarketingAudience, error) {
+	audience, ok := f.audiences[id]
+	if !ok {
+		return nil, entities.ErrMarketingAudienceNotFound
+	}
+	return audience, nil
+}
+
+func (f *fakeCampaigns) Create(ctx context.Context, campaign *entities.MarketingCampaign) error {
+	f.campaigns[campaign.ID] = campaign
+	return nil
+}
+
+func (f *fakeCampaigns) GetByID(ctx context.Context, id uuid.UUID) (*entities.MarketingCampaign, error) {
+	campaign, ok := f.campaigns[id]
+	if !ok {
+		return nil, entities.ErrMarketingCampaignNotFound
+	}
+	copied := *campaign
+	return &copied, nil
+}
+
+func (f *fakeCampaigns) Update(ctx context.Context, campaign *entities.MarketingCampaign) error {
+	f.campaigns[campaign.ID] = campaign
+	return nil
+}
+
+func (f *fakeCampaigns) GetDue(ctx context.Context, staleBefore time.Time, limit int) ([]*entities.MarketingCampaign, error) {
+	var due []*entities.MarketingCampaign
+	for _, campaign := range f.campaigns {
+		if !campaign.IsFinished() && !campaign.SendAt.After(time.Now()) {
+			due = append(due, campaign)
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeCampaigns) Claim(ctx context.Context, id uuid.UUID, staleBefore time.Time) (bool, error) {
+	return true, nil
+}
+
+func (f *fakeCampaigns) StageDeliveries(ctx context.Context, campaign *entities.MarketingCampaign, audience *entities.MarketingAudience) (int, error) {
+	for _, member := range f.members {
+		member.CampaignID = campaign.ID
+		f.deliveries = append(f.deliveries, member)
+	}
+	return len(f.members), nil
+}
+
+func (f *fakeCampaigns) GetPendingDeliveries(ctx context.Context, campaignID uuid.UUID, limit int) ([]*entities.MarketingDelivery, error) {
+	var pending []*entities.MarketingDelivery
+	for _, delivery := range f.deliveries {
+		if delivery.CampaignID == campaignID && delivery.Status == entities.CampaignDeliveryPending && len(pending) < limit {
+			pending = append(pending, delivery)
+		}
+	}
+	return pending, nil
+}
+
+func (f *fakeCampaigns) GetDeliveryByToken(ctx context.Context, token string) (*entities.MarketingDelivery, error) {
+	for _, delivery := range f.deliveries {
+		if delivery.UnsubscribeToken == token {
+			return delivery, nil
+		}
+	}
+	return nil, entities.ErrMarketingDeliveryNotFound
+}
+
+func (f *fakeCampaigns) UpdateDelivery(ctx context.Context, delivery *entities.MarketingDelivery) error {
+	return nil
+}
+
+func (f *fakeCampaigns) CountDeliveries(ctx context.Context, campaignID uuid.UUID) (*repositories.CampaignDeliveryCounts, error) {
+	counts := &repositories.CampaignDeliveryCounts{}
+	for _, delivery := range f.deliveries {
+		if delivery.CampaignID != campaignID {
+			continue
+		}
+		counts.Total++
+		switch delivery.Status {
+		case entities.CampaignDeliveryPending:
+			counts.Pending++
+		case entities.CampaignDeliverySent:
+			counts.Sent++
+		case entities.CampaignDeliverySkipped:
+			counts.Skipped++
+		case entities.CampaignDeliveryFailed:
+			counts.Failed++
+		}
+	}
+	return counts, nil
+}
+
+type fakeOrganizers struct {
+	repositories.OrganizerRepository
+	organizers map[uuid.UUID]*entities.Organizer
+}
+
+func (f *fakeOrganizers) GetByID(ctx context.Context, id uuid.UUID) (*entities.Organizer, error) {
+	organizer, ok := f.organizers[id]
+	if !ok {
+		return nil, entities.ErrOrganizerNotFound
+	}
+	return organizer, nil
+}
+
+type fakeEvents struct {
+	repositories.EventRepository
+	events map[uuid.UUID]*entities.Event
+}
+
+func (f *fakeEvents) GetByID(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	event, ok := f.events[id]
+	if !ok {
+		return nil, entities.ErrEventNotFound
+	}
+	return event, nil
+}
+
+type fakeUsers struct {
+	repositories.UserRepository
+	users map[uuid.UUID]*entities.User
+}
+
+func (f *fakeUsers) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	user, ok := f.users[id]
+	if !ok {
+		return nil, entities.ErrUserNotFound
+	}
+	return user, nil
+}
+
+// fakeEmails records who was emailed
+type fakeEmails struct {
+	services.EmailService
+	sent []string
+}
+
+func (f *fakeEmails) SendMarketingEmail(ctx context.Context, campaign *entities.MarketingCampaign, delivery *entities.MarketingDelivery) error {
+	f.sent = append(f.sent, delivery.Email)
+	return nil
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	ctx       context.Context
+	campaigns *fakeCampaigns
+}
+
+func (tx *fakeTx) Commit() error                                                { return nil }
+func (tx *fakeTx) Rollback() error                                              { return nil }
+func (tx *fakeTx) Context() context.Context                                     { return tx.ctx }
+func (tx *fakeTx) MarketingCampaigns() repositories.MarketingCampaignRepository { return tx.campaigns }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	u.tx.ctx = ctx
+	return u.tx, nil
+}
+
+type marketingFixture struct {
+	service   *MarketingService
+	consents  *fakeConsents
+	campaigns *fakeCampaigns
+	emails    *fakeEmails
+	organizer *entities.Organizer
+	event     *entities.Event
+	user      *entities.User
+}
+
+// newMarketingFixture builds a marketing service over fakes, with Ada signed
+// up and an organizer running one event. No policy version is configured and
+// batches are not spaced out.
+func newMarketingFixture(t *testing.T) *marketingFixture {
+	t.Helper()
+
+	organizer := entities.NewOrganizer("Livespot", "livespot", "hello@livespot.ng")
+	event := entities.NewEvent(organizer.ID, "Afrobeats Live", "afrobeats-live", time.Now().Add(30*24*time.Hour), "Eko Hotel", "Victoria Island", "Lagos", "NG")
+	user := entities.NewUser("ada@example.com", "Ada", "Obi")
+
+	f := &marketingFixture{
+		consents:  &fakeConsents{organizers: make(map[uuid.UUID][]*repositories.ConsentOrganizer)},
+		campaigns: &fakeCampaigns{audiences: make(map[uuid.UUID]*entities.MarketingAudience), campaigns: make(map[uuid.UUID]*entities.MarketingCampaign)},
+		emails:    &fakeEmails{},
+		organizer: organizer,
+		event:     event,
+		user:      user,
+	}
+	f.service = NewMarketingService(
+		f.consents,
+		f.campaigns,
+		&fakeOrganizers{organizers: map[uuid.UUID]*entities.Organizer{organizer.ID: organizer}},
+		&fakeEvents{events: map[uuid.UUID]*entities.Event{event.ID: event}},
+		&fakeUsers{users: map[uuid.UUID]*entities.User{user.ID: user}},
+		&fakeUnitOfWork{tx: &fakeTx{campaigns: f.campaigns}},
+		f.emails,
+		Config{},
+	)
+	f.service.batchPause = 0
+	return f
+}
+
+// dueCampaign saves an audience of the organizer, or of the platform when
+// organizerID is nil, and a campaign to it whose send time has passed
+func (f *marketingFixture) dueCampaign(t *testing.T, organizerID *uuid.UUID) *entities.MarketingCampaign {
+	t.Helper()
+
+	audience, err := f.service.CreateAudience(context.Background(), &CreateAudienceRequest{OrganizerID: organizerID, Name: "Past buyers"})
+	if err != nil {
+		t.Fatalf("CreateAudience() error = %v", err)
+	}
+	sendAt := time.Now().Add(-time.Minute)
+	campaign, err := f.service.CreateCampaign(context.Background(), &CreateCampaignRequest{
+		AudienceID: audience.ID,
+		Name:       "New show",
+		Subject:    "We are back in December",
+		Message:    "Tickets go on sale on Friday.",
+		SendAt:     &sendAt,
+	})
+	if err != nil {
+		t.Fatalf("CreateCampaign() error = %v", err)
+	}
+	return campaign
+}
+
+// member adds a past buyer the audiences match; a member with an account is
+// keyed by it, a guest by their email
+func (f *marketingFixture) member(userID *uuid.UUID, email string) *entities.MarketingDelivery {
+	delivery := &entities.MarketingDelivery{
+		ID:               uuid.New(),
+		UserID:           userID,
+		ContactKey:       entities.EmailContactKey(email),
+		Email:            email,
+		Status:           entities.CampaignDeliveryPending,
+		UnsubscribeToken: uuid.New().String(),
+	}
+	if userID != nil {
+		delivery.ContactKey = entities.UserContactKey(*userID)
+	}
+	f.campaigns.members = append(f.campaigns.members, delivery)
+	return delivery
+}
+
+// checkout records the boxes a buyer ticked on an order for the event
+func (f *marketingFixture) checkout(t *testing.T, userID *uuid.UUID, email string, choice *CheckoutConsent) *entities.Order {
+	t.Helper()
+
+	order := entities.NewOrder(f.event.ID.String(), email)
+	order.UserID = userID
+	if err := f.service.RecordCheckoutConsent(context.Background(), order, choice, ConsentMetadata{}); err != nil {
+		t.Fatalf("RecordCheckoutConsent() error = %v", err)
+	}
+	return order
+}
+
+func TestRecordCheckoutConsent(t *testing.T) {
+	tests := []struct {
+		name       string
+		choice     *CheckoutConsent
+		eventID    func(f *marketingFixture) string
+		guest      bool
+		organizers []bool
+	}{
+		{name: "nothing ticked", choice: &CheckoutConsent{}},
+		{name: "no choice", choice: nil},
+		{name: "organizer", choice: &CheckoutConsent{Organizer: true}, organizers: []bool{true}},
+		{name: "platform and organizer", choice: &CheckoutConsent{Organizer: true, Platform: true}, organizers: []bool{false, true}},
+		{name: "guest", choice: &CheckoutConsent{Platform: true}, guest: true, organizers: []bool{false}},
+		{
+			name:       "event without an organizer",
+			choice:     &CheckoutConsent{Organizer: true, Platform: true},
+			eventID:    func(f *marketingFixture) string { return "legacy-event" },
+			organizers: []bool{false},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newMarketingFixture(t)
+			eventID := f.event.ID.String()
+			if tt.eventID != nil {
+				eventID = tt.eventID(f)
+			}
+			order := entities.NewOrder(eventID, " Ada@Example.com ")
+			if !tt.guest {
+				order.UserID = &f.user.ID
+			}
+
+			metadata := ConsentMetadata{IPAddress: " 102.89.1.7 ", UserAgent: strings.Repeat("a", 600)}
+			if err := f.service.RecordCheckoutConsent(context.Background(), order, tt.choice, metadata); err != nil {
+				t.Fatalf("RecordCheckoutConsent() error = %v", err)
+			}
+			if len(f.consents.records) != len(tt.organizers) {
+				t.Fatalf("RecordCheckoutConsent() recorded %d choices, want %d", len(f.consents.records), len(tt.organizers))
+			}
+
+			wantKey := entities.UserContactKey(f.user.ID)
+			if tt.guest {
+				wantKey = entities.EmailContactKey("ada@example.com")
+			}
+			for i, record := range f.consents.records {
+				if (record.OrganizerID != nil) != tt.organizers[i] || (record.OrganizerID != nil && *record.OrganizerID != f.organizer.ID) {
+					t.Errorf("record %d organizer = %v, want organizer scope %v", i, record.OrganizerID, tt.organizers[i])
+				}
+				if !record.Granted || record.Source != entities.ConsentSourceCheckout || record.PolicyVersion != DefaultPolicyVersion {
+					t.Errorf("record %d = granted %v from %s under policy %s, want a checkout grant under policy %s", i, record.Granted, record.Source, record.PolicyVersion, DefaultPolicyVersion)
+				}
+				if record.ContactKey != wantKey || record.Email == nil || *record.Email != "ada@example.com" {
+					t.Errorf("record %d contact = %s, want %s with the normalized email", i, record.ContactKey, wantKey)
+				}
+				if record.OrderID == nil || *record.OrderID != order.ID {
+					t.Errorf("record %d order = %v, want %s", i, record.OrderID, order.ID)
+				}
+				if record.IPAddress == nil || *record.IPAddress != "102.89.1.7" || record.UserAgent == nil || len(*record.UserAgent) != 500 {
+					t.Errorf("record %d metadata was not trimmed to fit the audit trail", i)
+				}
+				if record.CreatedAt.IsZero() {
+					t.Errorf("record %d has no timestamp", i)
+				}
+			}
+		})
+	}
+}
+
+func TestUpdateConsentFromSettings(t *testing.T) {
+	f := newMarketingFixture(t)
+	ctx := context.Background()
+	other := &repositories.ConsentOrganizer{ID: uuid.New(), Name: "Comedy Central Lagos"}
+	f.consents.organizers[f.user.ID] = []*repositories.ConsentOrganizer{other}
+
+	granted := true
+	choices, err := f.service.UpdateConsent(ctx, f.user.ID, &UpdateConsentRequest{OrganizerID: &f.organizer.ID, Granted: &granted}, ConsentMetadata{})
+	if err != nil {
+		t.Fatalf("UpdateConsent() error = %v", err)
+	}
+
+	// Platform-wide first, then the organizers bought from, then any other choice
+	if len(choices) != 3 || choices[0].OrganizerID != nil || choices[0].Granted {
+		t.Fatalf("GetConsents() = %d choices, want the unset platform choice first of 3", len(choices))
+	}
+	if *choices[1].OrganizerID != other.ID || choices[1].OrganizerName != other.Name || choices[1].Granted || choices[1].UpdatedAt != nil {
+		t.Errorf("choice for %s = granted %v, want unset", other.Name, choices[1].Granted)
+	}
+	choice := choices[2]
+	if *choice.OrganizerID != f.organizer.ID || choice.OrganizerName != "Livespot" || !choice.Granted || choice.Source != entities.ConsentSourceSettings || choice.UpdatedAt == nil {
+		t.Errorf("choice for Livespot = granted %v from %s, want a timestamped grant from settings", choice.Granted, choice.Source)
+	}
+
+	// Withdrawing platform-wide marketing leaves the organizer's grant alone
+	withdrawn := false
+	choices, err = f.service.UpdateConsent(ctx, f.user.ID, &UpdateConsentRequest{Granted: &withdrawn}, ConsentMetadata{})
+	if err != nil {
+		t.Fatalf("UpdateConsent() error = %v", err)
+	}
+	if choices[0].Granted || choices[0].Source != entities.ConsentSourceSettings || !choices[2].Granted {
+		t.Errorf("UpdateConsent() = platform %v, Livespot %v, want only the platform withdrawn", choices[0].Granted, choices[2].Granted)
+	}
+
+	unknown := uuid.New()
+	_, err = f.service.UpdateConsent(ctx, f.user.ID, &UpdateConsentRequest{OrganizerID: &unknown, Granted: &granted}, ConsentMetadata{})
+	var notFound *entities.NotFoundError
+	if !errors.As(err, &notFound) || notFound.Resource != "organizer" {
+		t.Errorf("UpdateConsent() error = %v, want organizer not found", err)
+	}
+	if len(f.consents.records) != 2 {
+		t.Errorf("recorded %d choices, want 2", len(f.consents.records))
+	}
+}
+
+func TestSendDueEmailsOnlyConsentingMembers(t *testing.T) {
+	f := newMarketingFixture(t)
+	ctx := context.Background()
+	organizerID := f.organizer.ID
+
+	bola, chi, dayo := uuid.New(), uuid.New(), uuid.New()
+	ada := f.member(&f.user.ID, "ada@example.com")
+	bolaMember := f.member(&bola, "bola@example.com")
+	chiMember := f.member(&chi, "chi@example.com")
+	dayoMember := f.member(&dayo, "dayo@example.com")
+	eniMember := f.member(nil, "eni@example.com")
+
+	// Ada opted in to the organizer at checkout
+	f.checkout(t, &f.user.ID, "ada@example.com", &CheckoutConsent{Organizer: true})
+	// Bola only opted in to the platform, which does not cover the organizer
+	f.checkout(t, &bola, "bola@example.com", &CheckoutConsent{Platform: true})
+	// Chi opted in, then withdrew in their settings
+	f.checkout(t, &chi, "chi@example.com", &CheckoutConsent{Organizer: true})
+	f.consents.records = append(f.consents.records, entities.NewMarketingConsentRecord(entities.UserContactKey(chi), &chi, nil, &organizerID, false, entities.ConsentSourceSettings, DefaultPolicyVersion))
+	// Dayo opted in as a guest before signing up, so the grant is under their email
+	f.checkout(t, nil, "dayo@example.com", &CheckoutConsent{Organizer: true})
+	// Eni never ticked a box
+
+	campaign := f.dueCampaign(t, &organizerID)
+	if err := f.service.SendDue(ctx); err != nil {
+		t.Fatalf("SendDue() error = %v", err)
+	}
+
+	if len(f.emails.sent) != 2 || f.emails.sent[0] != "ada@example.com" || f.emails.sent[1] != "dayo@example.com" {
+		t.Errorf("emailed %v, want only Ada and Dayo", f.emails.sent)
+	}
+	for _, delivery := range []*entities.MarketingDelivery{bolaMember, chiMember, eniMember} {
+		if delivery.Status != entities.CampaignDeliverySkipped || *delivery.SkipReason != entities.CampaignSkipNoConsent {
+			t.Errorf("%s = %s, want skipped for no consent", delivery.Email, delivery.Status)
+		}
+	}
+	if ada.Status != entities.CampaignDeliverySent || dayoMember.Status != entities.CampaignDeliverySent {
+		t.Errorf("Ada = %s, Dayo = %s, want both sent", ada.Status, dayoMember.Status)
+	}
+
+	saved := f.campaigns.campaigns[campaign.ID]
+	if saved.Status != entities.CampaignStatusCompleted || saved.TotalRecipients != 5 || saved.SentCount != 2 || saved.SkippedCount != 3 {
+		t.Errorf("campaign = %s with %d of %d sent and %d skipped, want completed with 2 of 5 sent", saved.Status, saved.SentCount, saved.TotalRecipients, saved.SkippedCount)
+	}
+}
+
+func TestSendDueSkipsMembersWhoWithdrewAfterStaging(t *testing.T) {
+	f := newMarketingFixture(t)
+	ctx := context.Background()
+
+	// A platform campaign only needs platform-wide consent
+	f.checkout(t, &f.user.ID, "ada@example.com", &CheckoutConsent{Platform: true})
+	bola := uuid.New()
+	f.checkout(t, &bola, "bola@example.com", &CheckoutConsent{Platform: true})
+	f.member(&f.user.ID, "ada@example.com")
+	bolaMember := f.member(&bola, "bola@example.com")
+
+	campaign := f.dueCampaign(t, nil)
+	staged := time.Now()
+	campaign.RecipientsStagedAt = &staged
+	for _, member := range f.campaigns.members {
+		member.CampaignID = campaign.ID
+		f.campaigns.deliveries = append(f.campaigns.deliveries, member)
+	}
+
+	// Bola unsubscribes from an earlier email before this one goes out
+	if _, err := f.service.Unsubscribe(ctx, bolaMember.UnsubscribeToken, ConsentMetadata{}); err != nil {
+		t.Fatalf("Unsubscribe() error = %v", err)
+	}
+	if err := f.service.SendDue(ctx); err != nil {
+		t.Fatalf("SendDue() error = %v", err)
+	}
+
+	if len(f.emails.sent) != 1 || f.emails.sent[0] != "ada@example.com" {
+		t.Errorf("emailed %v, want only Ada", f.emails.sent)
+	}
+	if bolaMember.Status != entities.CampaignDeliverySkipped || *bolaMember.SkipReason != entities.CampaignSkipNoConsent {
+		t.Errorf("Bola = %s, want skipped for no consent", bolaMember.Status)
+	}
+}
+
+func TestUnsubscribeFromLink(t *testing.T) {
+	f := newMarketingFixture(t)
+	ctx := context.Background()
+	organizerID := f.organizer.ID
+
+	f.checkout(t, &f.user.ID, "ada@example.com", &CheckoutConsent{Organizer: true, Platform: true})
+	delivery := f.member(&f.user.ID, "ada@example.com")
+	f.dueCampaign(t, &organizerID)
+	if err := f.service.SendDue(ctx); err != nil {
+		t.Fatalf("SendDue() error = %v", err)
+	}
+
+	info, err := f.service.GetUnsubscribe(ctx, delivery.UnsubscribeToken)
+	if err != nil {
+		t.Fatalf("GetUnsubscribe() error = %v", err)
+	}
+	if !info.Subscribed || info.OrganizerName != "Livespot" || info.CampaignName != "New show" {
+		t.Errorf("GetUnsubscribe() = subscribed %v to %q, want subscribed to Livespot", info.Subscribed, info.OrganizerName)
+	}
+
+	// Links keep working, each use adding to the audit trail
+	for i := 0; i < 2; i++ {
+		info, err = f.service.Unsubscribe(ctx, delivery.UnsubscribeToken, ConsentMetadata{IPAddress: "102.89.1.7"})
+		if err != nil {
+			t.Fatalf("Unsubscribe() error = %v", err)
+		}
+		if info.Subscribed {
+			t.Errorf("Unsubscribe() left Ada subscribed")
+		}
+	}
+
+	records := f.consents.records[2:]
+	if len(records) != 2 {
+		t.Fatalf("Unsubscribe() recorded %d choices, want 2", len(records))
+	}
+	for _, record := range records {
+		if record.Granted || record.Source != entities.ConsentSourceUnsubscribe || *record.OrganizerID != organizerID || *record.IPAddress != "102.89.1.7" {
+			t.Errorf("record = granted %v from %s, want a withdrawal from the link for Livespot", record.Granted, record.Source)
+		}
+	}
+
+	// Only the organizer's emails stop; platform marketing is untouched
+	platform, _ := f.consents.HasConsent(ctx, delivery.ContactKey, &delivery.Email, nil)
+	if !platform {
+		t.Errorf("Unsubscribe() withdrew platform-wide consent")
+	}
+
+	_, err = f.service.Unsubscribe(ctx, "unknown", ConsentMetadata{})
+	var notFound *entities.NotFoundError
+	if !errors.As(err, &notFound) || notFound.Resource != "unsubscribe_link" {
+		t.Errorf("Unsubscribe() error = %v, want unsubscribe link not found", err)
+	}
+}
+
+func TestConsentHistoryKeepsEveryChoice(t *testing.T) {
+	f := newMarketingFixture(t)
+	ctx := context.Background()
+
+	f.checkout(t, &f.user.ID, "ada@example.com", &CheckoutConsent{Organizer: true})
+	for _, granted := range []bool{false, true} {
+		if _, err := f.service.UpdateConsent(ctx, f.user.ID, &UpdateConsentRequest{OrganizerID: &f.organizer.ID, Granted: &granted}, ConsentMetadata{UserAgent: "Mozilla/5.0"}); err != nil {
+			t.Fatalf("UpdateConsent() error = %v", err)
+		}
+	}
+	// Another customer's choices are not part of Ada's history
+	f.checkout(t, nil, "bola@example.com", &CheckoutConsent{Platform: true})
+
+	history, _, err := f.service.ListConsentHistory(ctx, f.user.ID, 1, 20)
+	if err != nil {
+		t.Fatalf("ListConsentHistory() error = %v", err)
+	}
+	want := []struct {
+		granted bool
+		source  string
+	}{
+		{granted: true, source: entities.ConsentSourceSettings},
+		{granted: false, source: entities.ConsentSourceSettings},
+		{granted: true, source: entities.ConsentSourceCheckout},
+	}
+	if len(history) != len(want) {
+		t.Fatalf("ListConsentHistory() = %d records, want %d", len(history), len(want))
+	}
+	for i, record := range history {
+		if record.Granted != want[i].granted || record.Source != want[i].source {
+			t.Errorf("record %d = granted %v from %s, want granted %v from %s", i, record.Granted, record.Source, want[i].granted, want[i].source)
+		}
+	}
+	if history[0].UserAgent == nil || *history[0].UserAgent != "Mozilla/5.0" || history[2].OrderID == nil {
+		t.Errorf("history lost where the choices were made")
+	}
+}
+
+func TestCreateAudienceOnlySelectsTheOrganizersEvents(t *testing.T) {
+	f := newMarketingFixture(t)
+	ctx := context.Background()
+	organizerID := f.organizer.ID
+	minSpend, maxSpend := 50000.0, 10000.0
+
+	tests := []struct {
+		name     string
+		req      *CreateAudienceRequest
+		field    string
+		notFound bool
+	}{
+		{name: "unknown event", req: &CreateAudienceRequest{OrganizerID: &organizerID, Name: "Fans", Criteria: entities.AudienceCriteria{EventIDs: []uuid.UUID{uuid.New()}}}, field: "criteria.event_ids"},
+		{name: "spend range", req: &CreateAudienceRequest{OrganizerID: &organizerID, Name: "Fans", Criteria: entities.AudienceCriteria{MinSpend: &minSpend, MaxSpend: &maxSpend}}, field: "criteria.max_spend"},
+		{name: "unknown organizer", req: &CreateAudienceRequest{OrganizerID: func() *uuid.UUID { id := uuid.New(); return &id }(), Name: "Fans"}, notFound: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.CreateAudience(ctx, tt.req)
+			var validationErr *entities.ValidationError
+			var notFound *entities.NotFoundError
+			switch {
+			case tt.notFound && !errors.As(err, &notFound):
+				t.Errorf("CreateAudience() error = %v, want organizer not found", err)
+			case !tt.notFound && (!errors.As(err, &validationErr) || validationErr.Field != tt.field):
+				t.Errorf("CreateAudience() error = %v, want a validation error on %s", err, tt.field)
+			}
+		})
+	}
+
+	// Another organizer cannot target this organizer's buyers
+	other := entities.NewOrganizer("Comedy Central Lagos", "comedy-central-lagos", "hi@ccl.ng")
+	f.service.organizerRepo.(*fakeOrganizers).organizers[other.ID] = other
+	_, err := f.service.CreateAudience(ctx, &CreateAudienceRequest{OrganizerID: &other.ID, Name: "Fans", Criteria: entities.AudienceCriteria{EventIDs: []uuid.UUID{f.event.ID}}})
+	var validationErr *entities.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "criteria.event_ids" {
+		t.Errorf("CreateAudience() error = %v, want the event refused", err)
+	}
+
+	audience, err := f.service.CreateAudience(ctx, &CreateAudienceRequest{OrganizerID: &organizerID, Name: " Lagos fans ", Criteria: entities.AudienceCriteria{EventIDs: []uuid.UUID{f.event.ID}, Cities: []string{" Lagos ", ""}}})
+	if err != nil {
+		t.Fatalf("CreateAudience() error = %v", err)
+	}
+	if audience.Name != "Lagos fans" || len(audience.Criteria.Cities) != 1 || audience.Criteria.Cities[0] != "lagos" || audience.Criteria.Currency != entities.DefaultCurrency {
+		t.Errorf("CreateAudience() = %q for %v in %s, want normalized criteria", audience.Name, audience.Criteria.Cities, audience.Criteria.Currency)
+	}
+}
@@ -0,0 +1,349 @@
+package messagetemplates
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+	"github.com/uduxpass/backend/internal/infrastructure/templates"
+)
+
+// TemplateService manages message template overrides, organizer branding and
+// the locale users receive messages in
+type TemplateService struct {
+	engine        *templates.Engine
+	unitOfWork    repositories.UnitOfWork
+	templateRepo  repositories.MessageTemplateRepository
+	userRepo      repositories.UserRepository
+	organizerRepo repositories.OrganizerRepository
+}
+
+// NewTemplateService creates a new template service
+func NewTemplateService(
+	engine *templates.Engine,
+	unitOfWork repositories.UnitOfWork,
+	templateRepo repositories.MessageTemplateRepository,
+	userRepo repositories.UserRepository,
+	organizerRepo repositories.OrganizerRepository,
+) *TemplateService {
+	return &TemplateService{
+		engine:        engine,
+		unitOfWork:    unitOfWork,
+		templateRepo:  templateRepo,
+		userRepo:      userRepo,
+		organizerRepo: organizerRepo,
+	}
+}
+
+// PublishTemplateRequest represents the request to publish a new version of a
+// template. Without an organizer the version applies to every organizer
+// without its own override.
+type PublishTemplateRequest struct {
+	Key         string                       `json:"key" validate:"required,max=100"`
+	Channel     entities.NotificationChannel `json:"channel" validate:"required"`
+	Locale      string                       `json:"locale" validate:"required"`
+	OrganizerID *uuid.UUID                   `json:"organizer_id,omitempty"`
+	Subject     string                       `json:"subject"`
+	HTMLBody    string                       `json:"html_body"`
+	TextBody    string                       `json:"text_body"`
+}
+
+// PreviewTemplateRequest represents the request to preview a template. When
+// a subject or body is given they are previewed as a draft; otherwise the
+// template the recipient would get is. Data defaults to sample data.
+type PreviewTemplateRequest struct {
+	Key         string                       `json:"key" validate:"required,max=100"`
+	Channel     entities.NotificationChannel `json:"channel" validate:"required"`
+	Locale      string                       `json:"locale"`
+	OrganizerID *uuid.UUID                   `json:"organizer_id,omitempty"`
+	Subject     string                       `json:"subject"`
+	HTMLBody    string                       `json:"html_body"`
+	TextBody    string                       `json:"text_body"`
+	Data        map[string]interface{}       `json:"data,omitempty"`
+}
+
+// UpdateBrandingRequest represents the request to update an organizer's
+// message branding. Omitted fields are left unchanged.
+type UpdateBrandingRequest struct {
+	DisplayName  *string `json:"display_name,omitempty" validate:"omitempty,max=100"`
+	LogoURL      *string `json:"logo_url,omitempty" validate:"omitempty,max=2000"`
+	PrimaryColor *string `json:"primary_color,omitempty"`
+	FooterText   *string `json:"footer_text,omitempty" validate:"omitempty,max=500"`
+	SupportEmail *string `json:"support_email,omitempty" validate:"omitempty,max=255"`
+}
+
+// UpdateLocaleRequest represents a user's choice of message language
+type UpdateLocaleRequest struct {
+	Locale string `json:"locale" validate:"required"`
+}
+
+// LocaleSettings is the locale a user receives messages in and the choices
+type LocaleSettings struct {
+	Locale    string   `json:"locale"`
+	Supported []string `json:"supported"`
+}
+
+// Catalog lists the templates that can be overridden
+func (s *TemplateService) Catalog() []templates.CatalogEntry {
+	return s.engine.Catalog()
+}
+
+// GetDefault returns the built-in version of a template
+func (s *TemplateService) GetDefault(channel entities.NotificationChannel, key, locale string) (*templates.Source, error) {
+	if locale == "" {
+		locale = entities.DefaultLocale
+	}
+	source, ok := s.engine.Default(channel, key, locale)
+	if !ok {
+		return nil, entities.NewNotFoundError("message_template", "there is no built-in template for this channel, key and locale")
+	}
+	return source, nil
+}
+
+// ListVersions lists stored template versions
+func (s *TemplateService) ListVersions(ctx context.Context, filter repositories.MessageTemplateFilter) ([]*entities.MessageTemplate, *repositories.PaginationResult, error) {
+	return s.templateRepo.List(ctx, filter)
+}
+
+// GetVersion retrieves a stored template version
+func (s *TemplateService) GetVersion(ctx context.Context, id uuid.UUID) (*entities.MessageTemplate, error) {
+	template, err := s.templateRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == entities.ErrMessageTemplateNotFound {
+			return nil, entities.NewNotFoundError("message_template", "message template not found")
+		}
+		return nil, err
+	}
+	return template, nil
+}
+
+// Publish stores a new version of a template and makes it the active one.
+// Templates that fail to render with sample data are refused.
+func (s *TemplateService) Publish(ctx context.Context, req *PublishTemplateRequest, adminID *uuid.UUID) (*entities.MessageTemplate, error) {
+	if req.OrganizerID != nil {
+		if err := s.checkOrganizer(ctx, *req.OrganizerID); err != nil {
+			return nil, err
+		}
+	}
+
+	template := entities.NewMessageTemplate(strings.TrimSpace(req.Key), req.Channel, req.Locale, req.OrganizerID)
+	template.Subject = strings.TrimSpace(req.Subject)
+	template.HTMLBody = req.HTMLBody
+	template.TextBody = req.TextBody
+	template.CreatedBy = adminID
+	if err := s.engine.Check(template); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.MessageTemplates().Create(ctx, template); err != nil {
+		return nil, err
+	}
+	if err := tx.MessageTemplates().DeactivateOthers(ctx, template); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// Activate makes a stored version the active one, e.g. to roll back to it
+func (s *TemplateService) Activate(ctx context.Context, id uuid.UUID) (*entities.MessageTemplate, error) {
+	template, err := s.GetVersion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if template.IsActive {
+		return template, nil
+	}
+	if err := s.engine.Check(template); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.MessageTemplates().DeactivateOthers(ctx, template); err != nil {
+		return nil, err
+	}
+	template.Activate(time.Now())
+	if err := tx.MessageTemplates().Update(ctx, template); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// Deactivate deactivates a stored version, so messages fall back to the next
+// template in line: the shared override, then the built-in template
+func (s *TemplateService) Deactivate(ctx context.Context, id uuid.UUID) (*entities.MessageTemplate, error) {
+	template, err := s.GetVersion(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !template.IsActive {
+		return template, nil
+	}
+
+	template.Deactivate(time.Now())
+	if err := s.templateRepo.Update(ctx, template); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
+
+// Preview renders a template, or a draft of one, with sample data
+func (s *TemplateService) Preview(ctx context.Context, req *PreviewTemplateRequest) (*services.RenderedMessage, error) {
+	if req.Locale != "" && !entities.IsSupportedLocale(req.Locale) {
+		return nil, entities.NewValidationError("locale", "locale must be one of en, yo, ha, ig, fr")
+	}
+	if req.OrganizerID != nil {
+		if err := s.checkOrganizer(ctx, *req.OrganizerID); err != nil {
+			return nil, err
+		}
+	}
+
+	preview := templates.PreviewRequest{
+		Channel:     req.Channel,
+		Key:         req.Key,
+		Locale:      req.Locale,
+		OrganizerID: req.OrganizerID,
+	}
+	if req.Data != nil {
+		preview.Data = req.Data
+	}
+	if req.Subject != "" || req.HTMLBody != "" || req.TextBody != "" {
+		locale := req.Locale
+		if locale == "" {
+			locale = entities.DefaultLocale
+		}
+		draft := entities.NewMessageTemplate(req.Key, req.Channel, locale, req.OrganizerID)
+		draft.Subject = strings.TrimSpace(req.Subject)
+		draft.HTMLBody = req.HTMLBody
+		draft.TextBody = req.TextBody
+		preview.Draft = draft
+	}
+
+	return s.engine.Preview(ctx, preview)
+}
+
+// GetBranding returns an organizer's message branding, or the branding its
+// messages get until one is saved
+func (s *TemplateService) GetBranding(ctx context.Context, organizerID uuid.UUID) (*entities.OrganizerBranding, error) {
+	branding, err := s.templateRepo.GetBranding(ctx, organizerID)
+	if err == entities.ErrOrganizerBrandingNotFound {
+		organizer, orgErr := s.organizerRepo.GetByID(ctx, organizerID)
+		if orgErr != nil {
+			if orgErr == entities.ErrNotFoundError {
+				return nil, entities.NewNotFoundError("organizer", "organizer not found")
+			}
+			return nil, orgErr
+		}
+		return entities.NewOrganizerBranding(organizer), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return branding, nil
+}
+
+// UpdateBranding updates an organizer's message branding
+func (s *TemplateService) UpdateBranding(ctx context.Context, organizerID uuid.UUID, req *UpdateBrandingRequest) (*entities.OrganizerBranding, error) {
+	branding, err := s.GetBranding(ctx, organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.DisplayName != nil {
+		branding.DisplayName = strings.TrimSpace(*req.DisplayName)
+	}
+	if req.LogoURL != nil {
+		branding.LogoURL = optionalString(*req.LogoURL)
+	}
+	if req.PrimaryColor != nil {
+		branding.PrimaryColor = strings.TrimSpace(*req.PrimaryColor)
+	}
+	if req.FooterText != nil {
+		branding.FooterText = strings.TrimSpace(*req.FooterText)
+	}
+	if req.SupportEmail != nil {
+		branding.SupportEmail = optionalString(*req.SupportEmail)
+	}
+	branding.UpdatedAt = time.Now()
+	if err := branding.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.templateRepo.SaveBranding(ctx, branding); err != nil {
+		return nil, err
+	}
+	return branding, nil
+}
+
+// GetLocale returns the locale a user receives messages in
+func (s *TemplateService) GetLocale(ctx context.Context, userID uuid.UUID) (*LocaleSettings, error) {
+	locale, err := s.userRepo.GetLocale(ctx, userID)
+	if err != nil {
+		if err == entities.ErrUserNotFound {
+			return nil, entities.NewNotFoundError("user", "user not found")
+		}
+		return nil, err
+	}
+	return &LocaleSettings{
+		Locale:    entities.NormalizeLocale(locale),
+		Supported: entities.SupportedLocales,
+	}, nil
+}
+
+// UpdateLocale sets the locale a user receives messages in
+func (s *TemplateService) UpdateLocale(ctx context.Context, userID uuid.UUID, req *UpdateLocaleRequest) (*LocaleSettings, error) {
+	if !entities.IsSupportedLocale(req.Locale) {
+		return nil, entities.NewValidationError("locale", "locale must be one of en, yo, ha, ig, fr")
+	}
+	if err := s.userRepo.UpdateLocale(ctx, userID, req.Locale); err != nil {
+		if err == entities.ErrUserNotFound {
+			return nil, entities.NewNotFoundError("user", "user not found")
+		}
+		return nil, err
+	}
+	return &LocaleSettings{
+		Locale:    req.Locale,
+		Supported: entities.SupportedLocales,
+	}, nil
+}
+
+// checkOrganizer returns a not found error unless the organizer exists
+func (s *TemplateService) checkOrganizer(ctx context.Context, organizerID uuid.UUID) error {
+	if _, err := s.organizerRepo.GetByID(ctx, organizerID); err != nil {
+		if err == entities.ErrNotFoundError {
+			return entities.NewNotFoundError("organizer", "organizer not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func optionalString(value string) *string {
+	trimmed := strings.TrimSpace(value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,428 @@
+package notifications
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
+	"github.com/uduxpass/backend/pkg/security"
+)
+
+// SMS outbox topics
+const (
+	// TopicSendSMS hands one recorded message to the provider
+	TopicSendSMS = "sms.send"
+	// TopicTicketLinkSMS texts a ticket retrieval link to a customer without an email address
+	TopicTicketLinkSMS = "sms.ticket_link"
+)
+
+// DefaultSMSSenderID is the alphanumeric sender used when SMS_SENDER_ID is not set
+const DefaultSMSSenderID = "uduXPass"
+
+// otpValidMinutes is how long the codes sent by SendOTP are valid, as stated in the message
+const otpValidMinutes = 15
+
+// SMSRateLimit allows at most Max messages to one number within Window
+type SMSRateLimit struct {
+	Window time.Duration
+	Max    int
+}
+
+// DefaultSMSRateLimits stop a number from being flooded, e.g. by repeated OTP requests
+var DefaultSMSRateLimits = []SMSRateLimit{
+	{Window: 10 * time.Minute, Max: 5},
+	{Window: 24 * time.Hour, Max: 20},
+}
+
+// SMSRequest describes one SMS. Either Template (with Data) or Body is set.
+// Templates are rendered in Locale, or else the locale of UserID, with the
+// overrides of OrganizerID when given.
+type SMSRequest struct {
+	To          string
+	Template    string
+	Data        interface{}
+	Body        string
+	Locale      string
+	UserID      *uuid.UUID
+	OrderID     *uuid.UUID
+	OrganizerID *uuid.UUID
+}
+
+type smsPayload struct {
+	NotificationID uuid.UUID `json:"notification_id"`
+}
+
+type ticketLinkPayload struct {
+	OrderID uuid.UUID `json:"order_id"`
+}
+
+// SMSService sends text messages through the configured provider. Messages
+// are recorded and queued in the outbox, sent by the dispatcher with retries,
+// and updated from the provider's delivery receipts.
+type SMSService struct {
+	unitOfWork       repositories.UnitOfWork
+	notificationRepo repositories.NotificationRepository
+	orderRepo        repositories.OrderRepository
+	ticketRepo       repositories.TicketRepository
+	eventRepo        repositories.EventRepository
+	ticketLinks      *TicketLinkService
+	renderer         services.MessageRenderer
+	provider         services.SMSProvider
+	senderID         string
+	limits           []SMSRateLimit
+}
+
+// NewSMSService creates an SMS service. An empty senderID selects DefaultSMSSenderID.
+func NewSMSService(
+	unitOfWork repositories.UnitOfWork,
+	notificationRepo repositories.NotificationRepository,
+	orderRepo repositories.OrderRepository,
+	ticketRepo repositories.TicketRepository,
+	eventRepo repositories.EventRepository,
+	ticketLinks *TicketLinkService,
+	renderer services.MessageRenderer,
+	provider services.SMSProvider,
+	senderID string,
+) *SMSService {
+	if senderID == "" {
+		senderID = DefaultSMSSenderID
+	}
+	return &SMSService{
+		unitOfWork:       unitOfWork,
+		notificationRepo: notificationRepo,
+		orderRepo:        orderRepo,
+		ticketRepo:       ticketRepo,
+		eventRepo:        eventRepo,
+		ticketLinks:      ticketLinks,
+		renderer:         renderer,
+		provider:         provider,
+		senderID:         senderID,
+		limits:           DefaultSMSRateLimits,
+	}
+}
+
+var _ security.SMSService = (*SMSService)(nil)
+
+// Register registers the handlers that send queued messages
+func (s *SMSService) Register(dispatcher *outbox.Dispatcher) {
+	dispatcher.Handle(TopicSendSMS, s.deliver)
+	dispatcher.Handle(TopicTicketLinkSMS, s.deliverTicketLink)
+}
+
+// Send records an SMS and queues it for the provider. It fails with a
+// business rule error when the number has reached its rate limit.
+func (s *SMSService) Send(ctx context.Context, req SMSRequest) (*entities.NotificationMessage, error) {
+	to, err := entities.NormalizePhoneNumber(req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	body := req.Body
+	if req.Template != "" {
+		rendered, err := s.renderer.Render(ctx, services.RenderRequest{
+			Channel:     entities.NotificationChannelSMS,
+			Key:         req.Template,
+			Locale:      req.Locale,
+			UserID:      req.UserID,
+			OrganizerID: req.OrganizerID,
+			Data:        req.Data,
+		})
+		if err != nil {
+			return nil, err
+		}
+		body = rendered.Text
+	}
+
+	message := entities.NewNotificationMessage(entities.NotificationChannelSMS, to, body)
+	message.SenderID = &s.senderID
+	if req.Template != "" {
+		message.Template = &req.Template
+	}
+	message.UserID = req.UserID
+	message.OrderID = req.OrderID
+	if err := message.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkRateLimit(ctx, to); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.Notifications().Create(ctx, message); err != nil {
+		return nil, err
+	}
+	payload, err := encodePayload(smsPayload{NotificationID: message.ID})
+	if err != nil {
+		return nil, err
+	}
+	queued := entities.NewOutboxMessage(TopicSendSMS, payload)
+	queued.SetAggregate("notification", message.ID)
+	if err := tx.Outbox().Create(ctx, queued); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// SendSMS queues a plain message; it implements security.SMSService
+func (s *SMSService) SendSMS(phone, message string) error {
+	_, err := s.Send(context.Background(), SMSRequest{To: phone, Body: message})
+	return err
+}
+
+// SendOTP queues a verification code message; it implements security.SMSService
+func (s *SMSService) SendOTP(phone, otp string, purpose security.OTPPurpose) error {
+	_, err := s.Send(context.Background(), SMSRequest{
+		To:       phone,
+		Template: SMSTemplateOTP,
+		Data:     OTPData{Code: otp, Minutes: otpValidMinutes},
+	})
+	return err
+}
+
+// checkRateLimit counts the messages recently sent to a number against each limit
+func (s *SMSService) checkRateLimit(ctx context.Context, to string) error {
+	now := time.Now()
+	for _, limit := range s.limits {
+		count, err := s.notificationRepo.CountSince(ctx, entities.NotificationChannelSMS, to, now.Add(-limit.Window))
+		if err != nil {
+			return err
+		}
+		if count >= limit.Max {
+			return entities.NewBusinessRuleError("sms_rate_limited", "too many messages to this number; try again later", map[string]interface{}{
+				"limit":          limit.Max,
+				"window_minutes": int(limit.Window / time.Minute),
+			})
+		}
+	}
+	return nil
+}
+
+// deliver hands a queued message to the provider. Rejected messages fail
+// straight away; other errors are retried until the outbox runs out of attempts.
+func (s *SMSService) deliver(ctx context.Context, queued *entities.OutboxMessage) error {
+	var payload smsPayload
+	if err := decodePayload(queued.Payload, &payload); err != nil {
+		return err
+	}
+
+	message, err := s.notificationRepo.GetByID(ctx, payload.NotificationID)
+	if err != nil {
+		if err == entities.ErrNotificationNotFound {
+			return nil
+		}
+		return err
+	}
+	if message.Status != entities.NotificationQueued {
+		return nil
+	}
+
+	senderID := s.senderID
+	if message.SenderID != nil {
+		senderID = *message.SenderID
+	}
+
+	result, sendErr := s.provider.Send(ctx, message.Recipient, senderID, message.Body)
+	now := time.Now()
+	if sendErr == nil {
+		message.MarkSent(s.provider.Name(), result.ProviderMessageID, now)
+		return s.notificationRepo.Update(ctx, message)
+	}
+
+	final := errors.Is(sendErr, services.ErrSMSRejected) || queued.Attempts+1 >= queued.MaxAttempts
+	if final {
+		message.MarkFailed(sendErr.Error(), now)
+	} else {
+		message.RecordAttemptError(sendErr.Error(), now)
+	}
+	if err := s.notificationRepo.Update(ctx, message); err != nil {
+		return err
+	}
+	if final {
+		return nil
+	}
+	return sendErr
+}
+
+// HandleDeliveryReceipts applies a provider's delivery report to the messages
+// it names and returns how many it updated. Receipts for unknown messages are ignored.
+func (s *SMSService) HandleDeliveryReceipts(ctx context.Context, providerName, contentType string, body []byte) (int, error) {
+	if providerName != s.provider.Name() {
+		return 0, entities.NewNotFoundError("sms_provider", "SMS provider is not configured")
+	}
+
+	receipts, err := s.provider.ParseDeliveryReceipts(contentType, body)
+	if err != nil {
+		return 0, entities.NewValidationError("body", err.Error())
+	}
+
+	updated := 0
+	now := time.Now()
+	for _, receipt := range receipts {
+		message, err := s.notificationRepo.GetByProviderMessageID(ctx, providerName, receipt.ProviderMessageID)
+		if err != nil {
+			if err == entities.ErrNotificationNotFound {
+				continue
+			}
+			return updated, err
+		}
+
+		message.ApplyReceipt(receipt.Status, receipt.Error, now)
+		if err := s.notificationRepo.Update(ctx, message); err != nil {
+			return updated, err
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// ListMessages lists sent and queued messages, newest first
+func (s *SMSService) ListMessages(ctx context.Context, filter repositories.NotificationFilter) ([]*entities.NotificationMessage, *repositories.PaginationResult, error) {
+	channel := entities.NotificationChannelSMS
+	filter.Channel = &channel
+	return s.notificationRepo.List(ctx, filter)
+}
+
+// GetMessage retrieves one message with its delivery state
+func (s *SMSService) GetMessage(ctx context.Context, id uuid.UUID) (*entities.NotificationMessage, error) {
+	return getChannelMessage(ctx, s.notificationRepo, entities.NotificationChannelSMS, id)
+}
+
+// getChannelMessage retrieves a message, reporting messages on other channels as not found
+func getChannelMessage(ctx context.Context, notificationRepo repositories.NotificationRepository, channel entities.NotificationChannel, id uuid.UUID) (*entities.NotificationMessage, error) {
+	message, err := notificationRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == entities.ErrNotificationNotFound {
+			return nil, entities.NewNotFoundError("notification", "message not found")
+		}
+		return nil, err
+	}
+	if message.Channel != channel {
+		return nil, entities.NewNotFoundError("notification", "message not found")
+	}
+	return message, nil
+}
+
+// QueueTicketLinkSMS queues a text with a ticket retrieval link for an order
+// without an email address. Pass a transaction's outbox repository to queue
+// it together with the tickets.
+func QueueTicketLinkSMS(ctx context.Context, outboxRepo repositories.OutboxRepository, order *entities.Order) error {
+	payload, err := encodePayload(ticketLinkPayload{OrderID: order.ID})
+	if err != nil {
+		return err
+	}
+	message := entities.NewOutboxMessage(TopicTicketLinkSMS, payload)
+	message.SetAggregate("order", order.ID)
+	return outboxRepo.Create(ctx, message)
+}
+
+// deliverTicketLink sends the ticket link text for an order, once
+func (s *SMSService) deliverTicketLink(ctx context.Context, queued *entities.OutboxMessage) error {
+	var payload ticketLinkPayload
+	if err := decodePayload(queued.Payload, &payload); err != nil {
+		return err
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, payload.OrderID)
+	if err != nil {
+		return err
+	}
+	if order.CustomerPhone == "" {
+		return nil
+	}
+
+	// The outbox delivers at least once; don't text the same link twice
+	orderID := order.ID
+	channel := entities.NotificationChannelSMS
+	_, previous, err := s.notificationRepo.List(ctx, repositories.NotificationFilter{
+		BaseFilter: repositories.BaseFilter{Page: 1, Limit: 1},
+		Channel:    &channel,
+		OrderID:    &orderID,
+		Template:   SMSTemplateTicketLink,
+	})
+	if err != nil {
+		return err
+	}
+	if previous.Total > 0 {
+		return nil
+	}
+
+	tickets, err := s.ticketRepo.GetByOrder(ctx, order.ID)
+	if err != nil {
+		return err
+	}
+	link, err := s.ticketLinks.Link(order)
+	if err != nil {
+		return err
+	}
+
+	data := TicketLinkData{OrderCode: order.Code, TicketCount: len(tickets), Link: link, EventName: "your event"}
+	var organizerID *uuid.UUID
+	if event, err := s.eventRepo.GetByID(ctx, orderEventID(order)); err == nil {
+		data.EventName = event.Name
+		organizerID = event.OrganizerID
+	}
+
+	_, err = s.Send(ctx, SMSRequest{
+		To:          order.CustomerPhone,
+		Template:    SMSTemplateTicketLink,
+		Data:        data,
+		UserID:      order.UserID,
+		OrderID:     &orderID,
+		OrganizerID: organizerID,
+	})
+	var validationErr *entities.ValidationError
+	var ruleErr *entities.BusinessRuleError
+	if errors.As(err, &validationErr) || errors.As(err, &ruleErr) {
+		// An unusable number or a rate-limited one will not improve with retries
+		fmt.Printf("Warning: ticket link SMS for order %s not sent: %v\n", order.Code, err)
+		return nil
+	}
+	return err
+}
+
+// orderEventID parses the order's event ID, which orders store as a string
+func orderEventID(order *entities.Order) uuid.UUID {
+	eventID, _ := uuid.Parse(order.EventID)
+	return eventID
+}
+
+func encodePayload(payload interface{}) (entities.JSONB, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode notification payload: %w", err)
+	}
+
+	var encoded entities.JSONB
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to encode notification payload: %w", err)
+	}
+	return encoded, nil
+}
+
+func decodePayload(payload entities.JSONB, value interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to decode notification payload: %w", err)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("failed to decode notification payload: %w", err)
+	}
+	return nil
+}
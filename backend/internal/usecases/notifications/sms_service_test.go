@@ -0,0 +1,335 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+	"github.com/uduxpass/backend/internal/infrastructure/sms"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
+)
+
+// The fakes embed the repository interfaces, so a call the service is not
+// expected to make panics instead of passing silently.
+
+type fakeNotifications struct {
+	repositories.NotificationRepository
+	messages map[uuid.UUID]*entities.NotificationMessage
+}
+
+func (f *fakeNotifications) Create(ctx context.Context, message *entities.NotificationMessage) error {
+	copied := *message
+	f.messages[message.ID] = &copied
+	return nil
+}
+
+func (f *fakeNotifications) GetByID(ctx context.Context, id uuid.UUID) (*entities.NotificationMessage, error) {
+	message, ok := f.messages[id]
+	if !ok {
+		return nil, entities.ErrNotificationNotFound
+	}
+	copied := *message
+	return &copied, nil
+}
+
+func (f *fakeNotifications) GetByProviderMessageID(ctx context.Context, provider, providerMessageID string) (*entities.NotificationMessage, error) {
+	for _, message := range f.messages {
+		if message.Provider != nil && *message.Provider == provider &&
+			message.ProviderMessageID != nil && *message.ProviderMessageID == providerMessageID {
+			copied := *message
+			return &copied, nil
+		}
+	}
+	return nil, entities.ErrNotificationNotFound
+}
+
+func (f *fakeNotifications) Update(ctx context.Context, message *entities.NotificationMessage) error {
+	copied := *message
+	f.messages[message.ID] = &copied
+	return nil
+}
+
+func (f *fakeNotifications) CountSince(ctx context.Context, channel entities.NotificationChannel, recipient string, since time.Time) (int, error) {
+	count := 0
+	for _, message := range f.messages {
+		if message.Channel == channel && message.Recipient == recipient && !message.CreatedAt.Before(since) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// seed records a message sent to a number at a time in the past
+func (f *fakeNotifications) seed(to string, at time.Time) {
+	message := entities.NewNotificationMessage(entities.NotificationChannelSMS, to, "earlier message")
+	message.CreatedAt = at
+	f.messages[message.ID] = message
+}
+
+// fakeOutbox claims pending messages that are due, in the order they were queued
+type fakeOutbox struct {
+	repositories.OutboxRepository
+	messages []*entities.OutboxMessage
+}
+
+func (f *fakeOutbox) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+func (f *fakeOutbox) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxMessage, error) {
+	now := time.Now()
+	var due []*entities.OutboxMessage
+	for _, message := range f.messages {
+		if len(due) < limit && message.Status == entities.OutboxStatusPending && !message.NextAttemptAt.After(now) {
+			due = append(due, message)
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeOutbox) Update(ctx context.Context, message *entities.OutboxMessage) error {
+	return nil
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	notifications *fakeNotifications
+	outbox        *fakeOutbox
+}
+
+func (tx *fakeTx) Commit() error                                      { return nil }
+func (tx *fakeTx) Rollback() error                                    { return nil }
+func (tx *fakeTx) Notifications() repositories.NotificationRepository { return tx.notifications }
+func (tx *fakeTx) Outbox() repositories.OutboxRepository              { return tx.outbox }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	return u.tx, nil
+}
+
+type smsFixture struct {
+	service       *SMSService
+	provider      *sms.FakeProvider
+	notifications *fakeNotifications
+	outbox        *fakeOutbox
+	dispatcher    *outbox.Dispatcher
+}
+
+// newSMSFixture builds an SMS service over the fake provider, registered with
+// the real outbox dispatcher
+func newSMSFixture(t *testing.T) *smsFixture {
+	t.Helper()
+
+	notifications := &fakeNotifications{messages: make(map[uuid.UUID]*entities.NotificationMessage)}
+	outboxRepo := &fakeOutbox{}
+	provider := sms.NewFakeProvider()
+
+	service := NewSMSService(
+		&fakeUnitOfWork{tx: &fakeTx{notifications: notifications, outbox: outboxRepo}},
+		notifications,
+		nil,
+		nil,
+		nil,
+		nil,
+		nil,
+		provider,
+		"",
+	)
+	dispatcher := outbox.NewDispatcher(outboxRepo)
+	service.Register(dispatcher)
+
+	return &smsFixture{
+		service:       service,
+		provider:      provider,
+		notifications: notifications,
+		outbox:        outboxRepo,
+		dispatcher:    dispatcher,
+	}
+}
+
+func (f *smsFixture) dispatch(t *testing.T) {
+	t.Helper()
+	if _, err := f.dispatcher.DispatchDue(context.Background()); err != nil {
+		t.Fatalf("DispatchDue() error = %v", err)
+	}
+}
+
+func (f *smsFixture) send(to, body string) (*entities.NotificationMessage, error) {
+	return f.service.Send(context.Background(), SMSRequest{To: to, Body: body})
+}
+
+func assertRateLimited(t *testing.T, err error, window time.Duration) {
+	t.Helper()
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "sms_rate_limited" {
+		t.Fatalf("error = %v, want sms_rate_limited", err)
+	}
+	if got := ruleErr.Details["window_minutes"]; got != int(window/time.Minute) {
+		t.Errorf("rate limit window = %v minutes, want %d", got, int(window/time.Minute))
+	}
+}
+
+func TestSendDeliversThroughProvider(t *testing.T) {
+	f := newSMSFixture(t)
+
+	message, err := f.send("0803 123 4567", "Your tickets are ready")
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	if message.Recipient != "+2348031234567" || *message.SenderID != DefaultSMSSenderID {
+		t.Errorf("queued message to %s from %s, want +2348031234567 from %s", message.Recipient, *message.SenderID, DefaultSMSSenderID)
+	}
+	if len(f.provider.Messages()) != 0 {
+		t.Fatalf("message sent before the dispatcher ran")
+	}
+
+	f.dispatch(t)
+
+	sent := f.provider.MessagesTo("+2348031234567")
+	if len(sent) != 1 || sent[0].Body != "Your tickets are ready" || sent[0].SenderID != DefaultSMSSenderID {
+		t.Fatalf("provider got %+v, want the queued message", sent)
+	}
+	stored := f.notifications.messages[message.ID]
+	if stored.Status != entities.NotificationSent || *stored.Provider != sms.ProviderFake || *stored.ProviderMessageID != sent[0].ID {
+		t.Errorf("message is %s via %v as %v, want sent via fake as %s", stored.Status, stored.Provider, stored.ProviderMessageID, sent[0].ID)
+	}
+}
+
+func TestSendRetriesProviderErrorsUnlessRejected(t *testing.T) {
+	f := newSMSFixture(t)
+
+	f.provider.FailWith(errors.New("gateway timeout"))
+	retried, err := f.send("+2348031234567", "First")
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	f.dispatch(t)
+
+	stored := f.notifications.messages[retried.ID]
+	if stored.Status != entities.NotificationQueued || stored.LastError == nil {
+		t.Errorf("message is %s after a transient error, want queued with the error", stored.Status)
+	}
+	if queued := f.outbox.messages[0]; queued.Status != entities.OutboxStatusPending || queued.Attempts != 1 {
+		t.Errorf("outbox message is %s after %d attempts, want a pending retry", queued.Status, queued.Attempts)
+	}
+
+	f.provider.FailWith(fmt.Errorf("%w: invalid number", services.ErrSMSRejected))
+	rejected, err := f.send("+2348039876543", "Second")
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	f.dispatch(t)
+
+	stored = f.notifications.messages[rejected.ID]
+	if stored.Status != entities.NotificationFailed || stored.FailedAt == nil {
+		t.Errorf("message is %s after the provider rejected it, want failed", stored.Status)
+	}
+	if queued := f.outbox.messages[1]; queued.Status != entities.OutboxStatusDelivered {
+		t.Errorf("outbox message is %s, want a rejected message not to be retried", queued.Status)
+	}
+}
+
+func TestSendRateLimitsPerNumber(t *testing.T) {
+	f := newSMSFixture(t)
+	short := DefaultSMSRateLimits[0]
+
+	for i := 0; i < short.Max; i++ {
+		if _, err := f.send("+2348031234567", fmt.Sprintf("Code %d", i)); err != nil {
+			t.Fatalf("Send() %d error = %v", i+1, err)
+		}
+	}
+
+	// The same number written differently is still the same number
+	_, err := f.send("0803 123 4567", "One too many")
+	assertRateLimited(t, err, short.Window)
+	if len(f.notifications.messages) != short.Max || len(f.outbox.messages) != short.Max {
+		t.Errorf("a rate limited message was recorded or queued")
+	}
+
+	// Other numbers are not affected
+	if _, err := f.send("+2348039876543", "Hello"); err != nil {
+		t.Errorf("Send() to another number error = %v", err)
+	}
+}
+
+func TestSendRateLimitsPerNumberPerDay(t *testing.T) {
+	f := newSMSFixture(t)
+	short, daily := DefaultSMSRateLimits[0], DefaultSMSRateLimits[1]
+
+	// Spread out enough to stay under the short limit
+	for i := 0; i < daily.Max; i++ {
+		f.notifications.seed("+2348031234567", time.Now().Add(-short.Window-time.Duration(i)*time.Minute))
+	}
+	_, err := f.send("+2348031234567", "Hello")
+	assertRateLimited(t, err, daily.Window)
+
+	// Messages older than a day no longer count
+	f.notifications.messages = make(map[uuid.UUID]*entities.NotificationMessage)
+	for i := 0; i < daily.Max; i++ {
+		f.notifications.seed("+2348031234567", time.Now().Add(-daily.Window-time.Minute))
+	}
+	if _, err := f.send("+2348031234567", "Hello"); err != nil {
+		t.Errorf("Send() error = %v, want old messages not to count", err)
+	}
+}
+
+func TestHandleDeliveryReceipts(t *testing.T) {
+	f := newSMSFixture(t)
+	ctx := context.Background()
+
+	message, err := f.send("+2348031234567", "Your tickets are ready")
+	if err != nil {
+		t.Fatalf("Send() error = %v", err)
+	}
+	f.dispatch(t)
+	providerID := f.provider.Messages()[0].ID
+
+	receipt := func(id, status string) []byte {
+		return []byte(fmt.Sprintf(`{"message_id":%q,"status":%q}`, id, status))
+	}
+
+	updated, err := f.service.HandleDeliveryReceipts(ctx, sms.ProviderFake, "application/json", receipt(providerID, "delivered"))
+	if err != nil || updated != 1 {
+		t.Fatalf("HandleDeliveryReceipts() = %d, %v, want 1 update", updated, err)
+	}
+	stored := f.notifications.messages[message.ID]
+	if stored.Status != entities.NotificationDelivered || stored.DeliveredAt == nil {
+		t.Errorf("message is %s after a delivered receipt", stored.Status)
+	}
+
+	// A late failure report does not undo a delivery
+	if _, err := f.service.HandleDeliveryReceipts(ctx, sms.ProviderFake, "application/json", receipt(providerID, "failed")); err != nil {
+		t.Fatalf("HandleDeliveryReceipts() error = %v", err)
+	}
+	if status := f.notifications.messages[message.ID].Status; status != entities.NotificationDelivered {
+		t.Errorf("message is %s after an out of order receipt, want delivered", status)
+	}
+
+	// Receipts for messages this service did not send are ignored
+	updated, err = f.service.HandleDeliveryReceipts(ctx, sms.ProviderFake, "application/json", receipt("fake-unknown", "delivered"))
+	if err != nil || updated != 0 {
+		t.Errorf("HandleDeliveryReceipts() of an unknown message = %d, %v, want 0 updates", updated, err)
+	}
+
+	// Reports that cannot be read are the caller's fault
+	var validationErr *entities.ValidationError
+	if _, err := f.service.HandleDeliveryReceipts(ctx, sms.ProviderFake, "application/json", []byte(`not json`)); !errors.As(err, &validationErr) {
+		t.Errorf("HandleDeliveryReceipts() of a malformed report error = %v, want a validation error", err)
+	}
+
+	// Only the configured provider's callback is accepted
+	var notFoundErr *entities.NotFoundError
+	if _, err := f.service.HandleDeliveryReceipts(ctx, sms.ProviderTermii, "application/json", receipt(providerID, "failed")); !errors.As(err, &notFoundErr) {
+		t.Errorf("HandleDeliveryReceipts() for another provider error = %v, want not found", err)
+	}
+}
@@ -0,0 +1,25 @@
+package notifications
+
+import "github.com/uduxpass/backend/internal/domain/services"
+
+// SMS template names, recorded on each message in the log. The templates
+// themselves are rendered by the shared message renderer and kept short: one
+// 160-character segment where the data allows.
+const (
+	SMSTemplateOTP        = services.MessageOTP
+	SMSTemplateTicketLink = services.MessageTicketLink
+)
+
+// OTPData fills the otp template
+type OTPData struct {
+	Code    string
+	Minutes int
+}
+
+// TicketLinkData fills the ticket_link template
+type TicketLinkData struct {
+	EventName   string
+	OrderCode   string
+	TicketCount int
+	Link        string
+}
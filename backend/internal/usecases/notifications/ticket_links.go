@@ -0,0 +1,105 @@
+package notifications
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// ticketLinkSignatureLength keeps retrieval links short enough for one SMS
+const ticketLinkSignatureLength = 16
+
+// TicketRetrieval is what a ticket retrieval link opens: the order and its tickets
+type TicketRetrieval struct {
+	OrderCode string             `json:"order_code"`
+	EventName string             `json:"event_name,omitempty"`
+	EventDate *time.Time         `json:"event_date,omitempty"`
+	Tickets   []*entities.Ticket `json:"tickets"`
+}
+
+// TicketLinkService creates and opens short links to an order's tickets, for
+// customers without an email address. Links are signed with the order's own
+// secret, so they need no storage.
+type TicketLinkService struct {
+	orderRepo  repositories.OrderRepository
+	ticketRepo repositories.TicketRepository
+	eventRepo  repositories.EventRepository
+	baseURL    string
+}
+
+// NewTicketLinkService creates a ticket link service. baseURL is the frontend
+// URL the /t/<token> page is served under.
+func NewTicketLinkService(
+	orderRepo repositories.OrderRepository,
+	ticketRepo repositories.TicketRepository,
+	eventRepo repositories.EventRepository,
+	baseURL string,
+) *TicketLinkService {
+	return &TicketLinkService{
+		orderRepo:  orderRepo,
+		ticketRepo: ticketRepo,
+		eventRepo:  eventRepo,
+		baseURL:    strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// Link returns the retrieval link for an order's tickets
+func (s *TicketLinkService) Link(order *entities.Order) (string, error) {
+	if order.Secret == "" {
+		return "", fmt.Errorf("order %s has no secret to sign a ticket link with", order.Code)
+	}
+	return fmt.Sprintf("%s/t/%s.%s", s.baseURL, order.Code, ticketLinkSignature(order)), nil
+}
+
+// Retrieve opens a retrieval link token ("<order code>.<signature>") and
+// returns the paid order's tickets
+func (s *TicketLinkService) Retrieve(ctx context.Context, token string) (*TicketRetrieval, error) {
+	notFound := entities.NewNotFoundError("tickets", "ticket link is invalid or has expired")
+
+	code, signature, ok := strings.Cut(token, ".")
+	if !ok || code == "" || signature == "" {
+		return nil, notFound
+	}
+
+	order, err := s.orderRepo.GetByCode(ctx, code)
+	if err != nil {
+		if err == entities.ErrOrderNotFound {
+			return nil, notFound
+		}
+		return nil, err
+	}
+	if order.Secret == "" || !hmac.Equal([]byte(signature), []byte(ticketLinkSignature(order))) {
+		return nil, notFound
+	}
+	if order.Status != entities.OrderStatusPaid && order.Status != entities.OrderStatusConfirmed {
+		return nil, notFound
+	}
+
+	tickets, err := s.ticketRepo.GetByOrder(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	retrieval := &TicketRetrieval{
+		OrderCode: order.Code,
+		Tickets:   tickets,
+	}
+	if event, err := s.eventRepo.GetByID(ctx, orderEventID(order)); err == nil {
+		retrieval.EventName = event.Name
+		retrieval.EventDate = &event.EventDate
+	}
+	return retrieval, nil
+}
+
+func ticketLinkSignature(order *entities.Order) string {
+	mac := hmac.New(sha256.New, []byte(order.Secret))
+	mac.Write([]byte("tickets:" + order.Code))
+	return hex.EncodeToString(mac.Sum(nil))[:ticketLinkSignatureLength]
+}
@@ -0,0 +1,678 @@
+package notifications
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+	"github.com/uduxpass/backend/internal/infrastructure/pdf"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
+)
+
+// WhatsApp outbox topics
+const (
+	// TopicTicketWhatsApp picks the channel for an order's tickets: WhatsApp
+	// when the customer opted in, otherwise email or SMS
+	TopicTicketWhatsApp = "whatsapp.tickets"
+	// TopicSendWhatsApp hands one recorded WhatsApp message to the provider
+	TopicSendWhatsApp = "whatsapp.send"
+	// TopicSendWhatsAppTemplate hands one recorded template message without attachments to the provider
+	TopicSendWhatsAppTemplate = "whatsapp.template"
+)
+
+// WhatsApp template defaults, overridden with WHATSAPP_TICKET_TEMPLATE and WHATSAPP_TEMPLATE_LANGUAGE
+const (
+	DefaultWhatsAppTicketTemplate = "ticket_delivery"
+	DefaultWhatsAppLanguage       = "en"
+)
+
+// WhatsAppOptInSourceAccount marks consent given in the user's account settings
+const WhatsAppOptInSourceAccount = "account"
+
+// ErrInvalidWhatsAppSignature is returned for status webhooks that fail signature verification
+var ErrInvalidWhatsAppSignature = errors.New("invalid whatsapp webhook signature")
+
+// WhatsAppConfig names the approved template tickets are sent with. The
+// template has a document header and five body parameters: the customer's
+// first name, the event name, the order code, the ticket count and the
+// ticket retrieval link. Languages lists the languages the template is
+// approved in; customers whose locale is not among them get Language.
+type WhatsAppConfig struct {
+	TicketTemplate string
+	Language       string
+	Languages      []string
+}
+
+// UpdateWhatsAppOptInRequest represents a user's request to opt in to or out of WhatsApp messages
+type UpdateWhatsAppOptInRequest struct {
+	OptedIn bool   `json:"opted_in"`
+	Phone   string `json:"phone"`
+	Source  string `json:"source"`
+}
+
+// WhatsAppTemplateRequest describes a template message without attachments to
+// a customer who opted in. Parameters fill the approved template's body; Key
+// and Data render the text the message log shows, in the locale of UserID.
+type WhatsAppTemplateRequest struct {
+	To          string
+	Template    string
+	Parameters  []string
+	Key         string
+	Data        interface{}
+	UserID      *uuid.UUID
+	OrganizerID *uuid.UUID
+}
+
+type whatsAppTemplatePayload struct {
+	NotificationID uuid.UUID `json:"notification_id"`
+	Language       string    `json:"language"`
+	Parameters     []string  `json:"parameters"`
+}
+
+type whatsAppTicketsPayload struct {
+	OrderID   uuid.UUID   `json:"order_id"`
+	TicketIDs []uuid.UUID `json:"ticket_ids,omitempty"`
+}
+
+type whatsAppSendPayload struct {
+	NotificationID uuid.UUID   `json:"notification_id"`
+	TicketIDs      []uuid.UUID `json:"ticket_ids,omitempty"`
+}
+
+// WhatsAppService delivers tickets on WhatsApp to customers who opted in: an
+// approved template message with the tickets as one PDF and a retrieval link.
+// When the customer has not opted in or the message cannot be delivered, the
+// tickets go out by email, or by SMS link when the order has no email.
+type WhatsAppService struct {
+	unitOfWork       repositories.UnitOfWork
+	notificationRepo repositories.NotificationRepository
+	orderRepo        repositories.OrderRepository
+	orderLineRepo    repositories.OrderLineRepository
+	ticketRepo       repositories.TicketRepository
+	eventRepo        repositories.EventRepository
+	outboxRepo       repositories.OutboxRepository
+	ticketLinks      *TicketLinkService
+	renderer         services.MessageRenderer
+	pdfGenerator     *pdf.TicketPDFGenerator
+	provider         services.WhatsAppProvider
+	config           WhatsAppConfig
+}
+
+// NewWhatsAppService creates a WhatsApp service. Empty config fields take the defaults.
+func NewWhatsAppService(
+	unitOfWork repositories.UnitOfWork,
+	notificationRepo repositories.NotificationRepository,
+	orderRepo repositories.OrderRepository,
+	orderLineRepo repositories.OrderLineRepository,
+	ticketRepo repositories.TicketRepository,
+	eventRepo repositories.EventRepository,
+	outboxRepo repositories.OutboxRepository,
+	ticketLinks *TicketLinkService,
+	renderer services.MessageRenderer,
+	provider services.WhatsAppProvider,
+	config WhatsAppConfig,
+) *WhatsAppService {
+	if config.TicketTemplate == "" {
+		config.TicketTemplate = DefaultWhatsAppTicketTemplate
+	}
+	if config.Language == "" {
+		config.Language = DefaultWhatsAppLanguage
+	}
+	return &WhatsAppService{
+		unitOfWork:       unitOfWork,
+		notificationRepo: notificationRepo,
+		orderRepo:        orderRepo,
+		orderLineRepo:    orderLineRepo,
+		ticketRepo:       ticketRepo,
+		eventRepo:        eventRepo,
+		outboxRepo:       outboxRepo,
+		ticketLinks:      ticketLinks,
+		renderer:         renderer,
+		pdfGenerator:     pdf.NewTicketPDFGenerator(),
+		provider:         provider,
+		config:           config,
+	}
+}
+
+// Register registers the handlers that route and send ticket messages
+func (s *WhatsAppService) Register(dispatcher *outbox.Dispatcher) {
+	dispatcher.Handle(TopicTicketWhatsApp, s.routeTickets)
+	dispatcher.Handle(TopicSendWhatsApp, s.deliver)
+	dispatcher.Handle(TopicSendWhatsAppTemplate, s.deliverTemplate)
+}
+
+// QueueTicketWhatsApp queues ticket delivery for an order whose customer may
+// have opted in to WhatsApp. Pass a transaction's outbox repository to queue
+// it together with the tickets.
+func QueueTicketWhatsApp(ctx context.Context, outboxRepo repositories.OutboxRepository, order *entities.Order, tickets []*entities.Ticket) error {
+	payload := whatsAppTicketsPayload{OrderID: order.ID, TicketIDs: make([]uuid.UUID, len(tickets))}
+	for i, ticket := range tickets {
+		payload.TicketIDs[i] = ticket.ID
+	}
+
+	encoded, err := encodePayload(payload)
+	if err != nil {
+		return err
+	}
+	message := entities.NewOutboxMessage(TopicTicketWhatsApp, encoded)
+	message.SetAggregate("order", order.ID)
+	return outboxRepo.Create(ctx, message)
+}
+
+// queueTicketFallback sends tickets by email, or a ticket link by SMS when the order has no email
+func queueTicketFallback(ctx context.Context, outboxRepo repositories.OutboxRepository, order *entities.Order, tickets []*entities.Ticket) error {
+	if order.CustomerEmail != "" {
+		return outbox.NewQueuedEmailService(outboxRepo).QueueTicketPDFEmail(ctx, order, tickets)
+	}
+	if order.CustomerPhone != "" {
+		return QueueTicketLinkSMS(ctx, outboxRepo, order)
+	}
+	return nil
+}
+
+// routeTickets records a WhatsApp message and queues it for sending when the
+// customer opted in, and falls back to email or SMS otherwise
+func (s *WhatsAppService) routeTickets(ctx context.Context, queued *entities.OutboxMessage) error {
+	var payload whatsAppTicketsPayload
+	if err := decodePayload(queued.Payload, &payload); err != nil {
+		return err
+	}
+
+	order, tickets, err := s.loadTickets(ctx, payload.OrderID, payload.TicketIDs)
+	if err != nil {
+		return err
+	}
+
+	optIn, err := s.activeOptIn(ctx, order)
+	if err != nil {
+		return err
+	}
+	if optIn == nil {
+		return queueTicketFallback(ctx, s.outboxRepo, order, tickets)
+	}
+
+	content, err := s.ticketContent(ctx, order, tickets)
+	if err != nil {
+		return err
+	}
+
+	message := entities.NewNotificationMessage(entities.NotificationChannelWhatsApp, optIn.Phone, content.summary)
+	template := s.config.TicketTemplate
+	message.Template = &template
+	message.UserID = order.UserID
+	message.OrderID = &order.ID
+	if err := message.Validate(); err != nil {
+		return err
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if err := tx.Notifications().Create(ctx, message); err != nil {
+		return err
+	}
+	encoded, err := encodePayload(whatsAppSendPayload{NotificationID: message.ID, TicketIDs: payload.TicketIDs})
+	if err != nil {
+		return err
+	}
+	send := entities.NewOutboxMessage(TopicSendWhatsApp, encoded)
+	send.SetAggregate("notification", message.ID)
+	if err := tx.Outbox().Create(ctx, send); err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// deliver sends a recorded ticket message. Rejected messages and messages
+// out of attempts fall back to email or SMS; other errors are retried.
+func (s *WhatsAppService) deliver(ctx context.Context, queued *entities.OutboxMessage) error {
+	var payload whatsAppSendPayload
+	if err := decodePayload(queued.Payload, &payload); err != nil {
+		return err
+	}
+
+	message, err := s.notificationRepo.GetByID(ctx, payload.NotificationID)
+	if err != nil {
+		if err == entities.ErrNotificationNotFound {
+			return nil
+		}
+		return err
+	}
+	if message.Status != entities.NotificationQueued || message.OrderID == nil {
+		return nil
+	}
+
+	order, tickets, err := s.loadTickets(ctx, *message.OrderID, payload.TicketIDs)
+	if err != nil {
+		return err
+	}
+	content, err := s.ticketContent(ctx, order, tickets)
+	if err != nil {
+		return err
+	}
+	document, err := s.ticketDocument(ctx, order, tickets, content.event)
+	if err != nil {
+		return err
+	}
+
+	template := s.config.TicketTemplate
+	if message.Template != nil {
+		template = *message.Template
+	}
+
+	result, sendErr := s.provider.SendTemplate(ctx, message.Recipient, services.WhatsAppTemplateMessage{
+		Template:       template,
+		Language:       s.templateLanguage(content.locale),
+		Document:       document,
+		BodyParameters: content.parameters,
+	})
+	now := time.Now()
+	if sendErr == nil {
+		message.MarkSent(s.provider.Name(), result.ProviderMessageID, now)
+		return s.notificationRepo.Update(ctx, message)
+	}
+
+	final := errors.Is(sendErr, services.ErrWhatsAppRejected) || queued.Attempts+1 >= queued.MaxAttempts
+	if !final {
+		message.RecordAttemptError(sendErr.Error(), now)
+		if err := s.notificationRepo.Update(ctx, message); err != nil {
+			return err
+		}
+		return sendErr
+	}
+
+	message.MarkFailed(sendErr.Error(), now)
+	if err := s.notificationRepo.Update(ctx, message); err != nil {
+		return err
+	}
+	return queueTicketFallback(ctx, s.outboxRepo, order, tickets)
+}
+
+// SendTemplate records a template message and queues it for the provider.
+// Unlike ticket messages there is no fallback: a message that cannot be
+// delivered is marked failed.
+func (s *WhatsAppService) SendTemplate(ctx context.Context, req WhatsAppTemplateRequest) (*entities.NotificationMessage, error) {
+	to, err := entities.NormalizePhoneNumber(req.To)
+	if err != nil {
+		return nil, err
+	}
+
+	summary, err := s.renderer.Render(ctx, services.RenderRequest{
+		Channel:     entities.NotificationChannelWhatsApp,
+		Key:         req.Key,
+		UserID:      req.UserID,
+		OrganizerID: req.OrganizerID,
+		Data:        req.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	message := entities.NewNotificationMessage(entities.NotificationChannelWhatsApp, to, summary.Text)
+	template := req.Template
+	message.Template = &template
+	message.UserID = req.UserID
+	if err := message.Validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.Notifications().Create(ctx, message); err != nil {
+		return nil, err
+	}
+	encoded, err := encodePayload(whatsAppTemplatePayload{
+		NotificationID: message.ID,
+		Language:       s.templateLanguage(summary.Locale),
+		Parameters:     req.Parameters,
+	})
+	if err != nil {
+		return nil, err
+	}
+	send := entities.NewOutboxMessage(TopicSendWhatsAppTemplate, encoded)
+	send.SetAggregate("notification", message.ID)
+	if err := tx.Outbox().Create(ctx, send); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return message, nil
+}
+
+// deliverTemplate sends a recorded template message. Rejected messages and
+// messages out of attempts are marked failed; other errors are retried.
+func (s *WhatsAppService) deliverTemplate(ctx context.Context, queued *entities.OutboxMessage) error {
+	var payload whatsAppTemplatePayload
+	if err := decodePayload(queued.Payload, &payload); err != nil {
+		return err
+	}
+
+	message, err := s.notificationRepo.GetByID(ctx, payload.NotificationID)
+	if err != nil {
+		if err == entities.ErrNotificationNotFound {
+			return nil
+		}
+		return err
+	}
+	if message.Status != entities.NotificationQueued || message.Template == nil {
+		return nil
+	}
+
+	result, sendErr := s.provider.SendTemplate(ctx, message.Recipient, services.WhatsAppTemplateMessage{
+		Template:       *message.Template,
+		Language:       payload.Language,
+		BodyParameters: payload.Parameters,
+	})
+	now := time.Now()
+	if sendErr == nil {
+		message.MarkSent(s.provider.Name(), result.ProviderMessageID, now)
+		return s.notificationRepo.Update(ctx, message)
+	}
+
+	if errors.Is(sendErr, services.ErrWhatsAppRejected) || queued.Attempts+1 >= queued.MaxAttempts {
+		message.MarkFailed(sendErr.Error(), now)
+		return s.notificationRepo.Update(ctx, message)
+	}
+
+	message.RecordAttemptError(sendErr.Error(), now)
+	if err := s.notificationRepo.Update(ctx, message); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// VerifyWebhook answers the provider's webhook verification handshake
+func (s *WhatsAppService) VerifyWebhook(mode, token, challenge string) (string, bool) {
+	return s.provider.VerifyWebhook(mode, token, challenge)
+}
+
+// HandleStatusWebhook applies a signed status webhook and returns how many
+// messages it updated. Ticket messages reported as failed fall back to email
+// or SMS; updates for unknown messages are ignored.
+func (s *WhatsAppService) HandleStatusWebhook(ctx context.Context, body []byte, signature string) (int, error) {
+	if !s.provider.VerifySignature(body, signature) {
+		return 0, ErrInvalidWhatsAppSignature
+	}
+
+	updates, err := s.provider.ParseStatusUpdates(body)
+	if err != nil {
+		return 0, entities.NewValidationError("body", err.Error())
+	}
+
+	updated := 0
+	now := time.Now()
+	for _, update := range updates {
+		message, err := s.notificationRepo.GetByProviderMessageID(ctx, s.provider.Name(), update.ProviderMessageID)
+		if err != nil {
+			if err == entities.ErrNotificationNotFound {
+				continue
+			}
+			return updated, err
+		}
+
+		alreadyFailed := message.Status == entities.NotificationFailed
+		message.ApplyReceipt(update.Status, update.Error, now)
+		if err := s.notificationRepo.Update(ctx, message); err != nil {
+			return updated, err
+		}
+		updated++
+
+		if !alreadyFailed && message.Status == entities.NotificationFailed && message.OrderID != nil {
+			order, tickets, err := s.loadTickets(ctx, *message.OrderID, nil)
+			if err != nil {
+				return updated, err
+			}
+			if err := queueTicketFallback(ctx, s.outboxRepo, order, tickets); err != nil {
+				return updated, err
+			}
+		}
+	}
+	return updated, nil
+}
+
+// GetOptIn returns a user's WhatsApp opt-in; users who never chose are opted out
+func (s *WhatsAppService) GetOptIn(ctx context.Context, userID uuid.UUID) (*entities.WhatsAppOptIn, error) {
+	optIn, err := s.notificationRepo.GetWhatsAppOptIn(ctx, userID)
+	if err != nil {
+		if err == entities.ErrWhatsAppOptInNotFound {
+			return entities.NewWhatsAppOptIn(userID), nil
+		}
+		return nil, err
+	}
+	return optIn, nil
+}
+
+// UpdateOptIn opts a user in to or out of WhatsApp messages. Opting in needs
+// a phone number, unless one was given before.
+func (s *WhatsAppService) UpdateOptIn(ctx context.Context, userID uuid.UUID, req *UpdateWhatsAppOptInRequest) (*entities.WhatsAppOptIn, error) {
+	optIn, err := s.GetOptIn(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if !req.OptedIn {
+		optIn.OptOut(now)
+	} else {
+		phone := optIn.Phone
+		if strings.TrimSpace(req.Phone) != "" {
+			if phone, err = entities.NormalizePhoneNumber(req.Phone); err != nil {
+				return nil, err
+			}
+		}
+		if phone == "" {
+			return nil, entities.NewValidationError("phone", "phone is required to opt in to WhatsApp")
+		}
+
+		source := strings.TrimSpace(req.Source)
+		if source == "" {
+			source = WhatsAppOptInSourceAccount
+		}
+		if len(source) > 30 {
+			return nil, entities.NewValidationError("source", "source must be at most 30 characters")
+		}
+		optIn.OptIn(phone, source, now)
+	}
+
+	if err := s.notificationRepo.SaveWhatsAppOptIn(ctx, optIn); err != nil {
+		return nil, err
+	}
+	return optIn, nil
+}
+
+// ListMessages lists WhatsApp messages, newest first
+func (s *WhatsAppService) ListMessages(ctx context.Context, filter repositories.NotificationFilter) ([]*entities.NotificationMessage, *repositories.PaginationResult, error) {
+	channel := entities.NotificationChannelWhatsApp
+	filter.Channel = &channel
+	return s.notificationRepo.List(ctx, filter)
+}
+
+// GetMessage retrieves one WhatsApp message with its delivery state
+func (s *WhatsAppService) GetMessage(ctx context.Context, id uuid.UUID) (*entities.NotificationMessage, error) {
+	return getChannelMessage(ctx, s.notificationRepo, entities.NotificationChannelWhatsApp, id)
+}
+
+// activeOptIn returns the opt-in of the order's customer, or nil when there is none
+func (s *WhatsAppService) activeOptIn(ctx context.Context, order *entities.Order) (*entities.WhatsAppOptIn, error) {
+	if order.UserID == nil {
+		return nil, nil
+	}
+	return s.ActiveOptIn(ctx, *order.UserID)
+}
+
+// ActiveOptIn returns a user's opt-in when they can be messaged on WhatsApp, or nil
+func (s *WhatsAppService) ActiveOptIn(ctx context.Context, userID uuid.UUID) (*entities.WhatsAppOptIn, error) {
+	optIn, err := s.notificationRepo.GetWhatsAppOptIn(ctx, userID)
+	if err != nil {
+		if err == entities.ErrWhatsAppOptInNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if !optIn.OptedIn || optIn.Phone == "" {
+		return nil, nil
+	}
+	return optIn, nil
+}
+
+// loadTickets loads an order and the tickets to deliver; no ticket IDs means all of them
+func (s *WhatsAppService) loadTickets(ctx context.Context, orderID uuid.UUID, ticketIDs []uuid.UUID) (*entities.Order, []*entities.Ticket, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch order %s: %w", orderID, err)
+	}
+
+	orderTickets, err := s.ticketRepo.GetByOrder(ctx, order.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch tickets for order %s: %w", order.Code, err)
+	}
+
+	tickets := orderTickets
+	if len(ticketIDs) > 0 {
+		wanted := make(map[uuid.UUID]bool, len(ticketIDs))
+		for _, id := range ticketIDs {
+			wanted[id] = true
+		}
+		tickets = make([]*entities.Ticket, 0, len(ticketIDs))
+		for _, ticket := range orderTickets {
+			if wanted[ticket.ID] {
+				tickets = append(tickets, ticket)
+			}
+		}
+	}
+	if len(tickets) == 0 {
+		return nil, nil, fmt.Errorf("no tickets found for order %s", order.Code)
+	}
+
+	return order, tickets, nil
+}
+
+type whatsAppTicketContent struct {
+	event      *entities.Event
+	parameters []string
+	summary    string
+	locale     string
+}
+
+// ticketContent fills the ticket template's body parameters and renders a
+// plain-text summary of the message for the log, in the customer's locale
+func (s *WhatsAppService) ticketContent(ctx context.Context, order *entities.Order, tickets []*entities.Ticket) (*whatsAppTicketContent, error) {
+	event, err := s.eventRepo.GetByID(ctx, orderEventID(order))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch event for order %s: %w", order.Code, err)
+	}
+	link, err := s.ticketLinks.Link(order)
+	if err != nil {
+		return nil, err
+	}
+
+	firstName := strings.TrimSpace(order.CustomerFirstName)
+	summary, err := s.renderer.Render(ctx, services.RenderRequest{
+		Channel:     entities.NotificationChannelWhatsApp,
+		Key:         services.MessageTicketDelivery,
+		UserID:      order.UserID,
+		OrganizerID: event.OrganizerID,
+		Data: map[string]interface{}{
+			"FirstName":   firstName,
+			"EventName":   event.Name,
+			"OrderCode":   order.Code,
+			"TicketCount": len(tickets),
+			"Link":        link,
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	name := firstName
+	if name == "" {
+		name = "there"
+	}
+
+	return &whatsAppTicketContent{
+		event:      event,
+		parameters: []string{name, event.Name, order.Code, strconv.Itoa(len(tickets)), link},
+		summary:    summary.Text,
+		locale:     summary.Locale,
+	}, nil
+}
+
+// templateLanguage picks the language to send the approved template in: the
+// customer's locale when the template is approved in it, else the default
+func (s *WhatsAppService) templateLanguage(locale string) string {
+	for _, language := range s.config.Languages {
+		if language == locale {
+			return language
+		}
+	}
+	return s.config.Language
+}
+
+// ticketDocument renders the tickets as one PDF with a page per ticket
+func (s *WhatsAppService) ticketDocument(ctx context.Context, order *entities.Order, tickets []*entities.Ticket, event *entities.Event) (*services.WhatsAppDocument, error) {
+	orderLines, err := s.orderLineRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch order lines for order %s: %w", order.Code, err)
+	}
+	lines := make(map[uuid.UUID]*entities.OrderLine, len(orderLines))
+	for _, line := range orderLines {
+		lines[line.ID] = line
+	}
+
+	pages := make([]pdf.TicketData, 0, len(tickets))
+	for i, ticket := range tickets {
+		var tierName string
+		var price float64
+		if line, ok := lines[ticket.OrderLineID]; ok {
+			// Order lines loaded by GetByOrderID carry the tier name but not the tier relation
+			tierName, price = line.TicketTierName, line.UnitPrice
+			if line.TicketTier != nil {
+				tierName, price = line.TicketTier.Name, line.TicketTier.Price
+			}
+		}
+
+		pages = append(pages, pdf.TicketData{
+			TicketID:      ticket.ID.String(),
+			QRCode:        ticket.SerialNumber,
+			EventName:     event.Name,
+			EventDate:     event.EventDate,
+			VenueName:     event.VenueName,
+			VenueAddress:  event.VenueAddress,
+			TierName:      tierName,
+			Price:         price,
+			CustomerName:  order.CustomerFirstName + " " + order.CustomerLastName,
+			CustomerEmail: order.CustomerEmail,
+			OrderID:       order.Code,
+			TicketNumber:  i + 1,
+			TotalTickets:  len(tickets),
+		})
+	}
+
+	data, err := s.pdfGenerator.GenerateOrderPDF(pages)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ticket PDF for order %s: %w", order.Code, err)
+	}
+
+	return &services.WhatsAppDocument{
+		Filename: fmt.Sprintf("tickets_%s.pdf", order.Code),
+		MimeType: "application/pdf",
+		Data:     data,
+	}, nil
+}
@@ -8,8 +8,12 @@ import (
 	"github.com/google/uuid"
 	"github.com/uduxpass/backend/internal/domain/entities"
 	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
 )
 
+// DefaultExpiryInterval is how often pending orders past their hold are expired
+const DefaultExpiryInterval = time.Minute
+
 // OrderService handles order operations
 type OrderService struct {
 	orderRepo         repositories.OrderRepository
@@ -17,7 +21,10 @@ type OrderService struct {
 	inventoryHoldRepo repositories.InventoryHoldRepository
 	eventRepo         repositories.EventRepository
 	ticketTierRepo    repositories.TicketTierRepository
+	priceChangeRepo   repositories.TierPriceChangeRepository
 	userRepo          repositories.UserRepository
+	unitOfWork        repositories.UnitOfWork
+	eventBus          *eventbus.Bus
 	holdDuration      time.Duration
 }
 
@@ -28,7 +35,10 @@ func NewOrderService(
 	inventoryHoldRepo repositories.InventoryHoldRepository,
 	eventRepo repositories.EventRepository,
 	ticketTierRepo repositories.TicketTierRepository,
+	priceChangeRepo repositories.TierPriceChangeRepository,
 	userRepo repositories.UserRepository,
+	unitOfWork repositories.UnitOfWork,
+	eventBus *eventbus.Bus,
 ) *OrderService {
 	return &OrderService{
 		orderRepo:         orderRepo,
@@ -36,7 +46,10 @@ func NewOrderService(
 		inventoryHoldRepo: inventoryHoldRepo,
 		eventRepo:         eventRepo,
 		ticketTierRepo:    ticketTierRepo,
+		priceChangeRepo:   priceChangeRepo,
 		userRepo:          userRepo,
+		unitOfWork:        unitOfWork,
+		eventBus:          eventBus,
 		holdDuration:      15 * time.Minute, // 15 minutes hold
 	}
 }
@@ -88,6 +101,14 @@ type CreateOrderResponse struct {
 	ExpiresAt   time.Time            `json:"expires_at"`
 }
 
+// CreateTourPassOrderRequest represents a request to buy tour passes
+type CreateTourPassOrderRequest struct {
+	UserID       uuid.UUID     `json:"user_id"`
+	TourPassID   uuid.UUID     `json:"tour_pass_id"`
+	Quantity     int           `json:"quantity" validate:"required,min=1"`
+	CustomerInfo *CustomerInfo `json:"customer_info,omitempty"`
+}
+
 // UpdateOrderRequest represents an update order request
 type UpdateOrderRequest struct {
 	Status      *entities.OrderStatus `json:"status,omitempty"`
@@ -124,7 +145,7 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *CreateOrderRequest)
 		return nil, fmt.Errorf("failed to get event: %w", err)
 	}
 
-	if event.Status != entities.EventStatusPublished {
+	if event.Status != entities.EventStatusPublished && event.Status != entities.EventStatusOnSale {
 		return nil, entities.ErrEventNotActive
 	}
 
@@ -133,46 +154,85 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *CreateOrderRequest)
 		return nil, entities.ErrEventExpired
 	}
 
-	// Create order
-	customerEmail := ""
-	if req.CustomerInfo != nil && req.CustomerInfo.Email != "" {
-		customerEmail = req.CustomerInfo.Email
-	} else if user.Email != nil {
-		customerEmail = *user.Email
+	// Switch tiers to scheduled prices that have come due so the order is charged the current price
+	if _, err := s.priceChangeRepo.ApplyDue(ctx, &event.ID); err != nil {
+		return nil, fmt.Errorf("failed to apply scheduled ticket prices: %w", err)
 	}
-	
-	order := entities.NewOrder(req.EventID.String(), customerEmail)
-	// Set the user ID on the order so ownership checks work
-	order.UserID = &req.UserID
-	
-	// Set customer info if provided
-	if req.CustomerInfo != nil {
-		order.CustomerFirstName = req.CustomerInfo.FirstName
-		order.CustomerLastName = req.CustomerInfo.LastName
-		order.CustomerEmail = req.CustomerInfo.Email
-		order.CustomerPhone = req.CustomerInfo.Phone
-	} else {
-		// Use user info as fallback
-		if user.FirstName != nil {
-			order.CustomerFirstName = *user.FirstName
+
+	// Tier sale windows and sequencing decide which tiers can be bought right
+	// now, and dynamic pricing what each ticket is quoted at
+	availability, err := s.ticketTierRepo.GetAvailability(ctx, event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket tier availability: %w", err)
+	}
+	quotes := make(map[uuid.UUID]*repositories.TicketTierAvailability, len(availability))
+	for _, tier := range availability {
+		quotes[tier.TicketTierID] = tier
+	}
+
+	// Resolve ticket tiers up front so an order can never span events or currencies
+	orderCurrency := event.Currency
+	if orderCurrency == "" {
+		orderCurrency = entities.DefaultCurrency
+	}
+	ticketTiers := make(map[uuid.UUID]*entities.TicketTier)
+	for _, lineItem := range req.GetOrderLines() {
+		if _, ok := ticketTiers[lineItem.TicketTierID]; ok {
+			continue
 		}
-		if user.LastName != nil {
-			order.CustomerLastName = *user.LastName
+		ticketTier, err := s.ticketTierRepo.GetByID(ctx, lineItem.TicketTierID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ticket tier: %w", err)
 		}
-		if user.Email != nil {
-			order.CustomerEmail = *user.Email
+		if ticketTier.EventID != event.ID {
+			return nil, entities.NewValidationError("ticket_tier_id", "ticket tier does not belong to this event")
 		}
-		if user.Phone != nil {
-			order.CustomerPhone = *user.Phone
+		if entities.NormalizeCurrency(ticketTier.Currency) != orderCurrency {
+			return nil, entities.NewBusinessRuleError("mixed_currency", "all ticket tiers in an order must be priced in the event currency", map[string]interface{}{
+				"event_currency": orderCurrency,
+				"tier_currency":  ticketTier.Currency,
+				"ticket_tier_id": ticketTier.ID,
+			})
 		}
+		if quote, ok := quotes[ticketTier.ID]; !ok || !quote.IsOnSale {
+			var state entities.TierSaleState
+			if ok {
+				state = quote.SaleStatus
+			}
+			return nil, entities.NewBusinessRuleError("ticket_tier_not_on_sale", fmt.Sprintf("ticket tier '%s' is not on sale", ticketTier.Name), map[string]interface{}{
+				"ticket_tier_id": ticketTier.ID,
+				"sale_status":    state,
+			})
+		}
+		ticketTiers[ticketTier.ID] = ticketTier
 	}
 
+	// Create order
+	order := newCustomerOrder(req.EventID, req.UserID, user, req.CustomerInfo)
+	order.Currency = orderCurrency
+
 	// Set order expiry (in UTC to match database storage)
 	expiresAt := time.Now().UTC().Add(s.holdDuration)
 	order.ExpiresAt = expiresAt
 
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the tiers before checking availability, so concurrent checkouts and
+	// box office sales of the same tier cannot both take its last tickets
+	tierIDs := make([]uuid.UUID, 0, len(ticketTiers))
+	for id := range ticketTiers {
+		tierIDs = append(tierIDs, id)
+	}
+	if _, err := tx.TicketTiers().LockForUpdate(tx.Context(), tierIDs); err != nil {
+		return nil, fmt.Errorf("failed to lock ticket tiers: %w", err)
+	}
+
 	// Save order
-	if err := s.orderRepo.Create(ctx, order); err != nil {
+	if err := tx.Orders().Create(tx.Context(), order); err != nil {
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
@@ -181,14 +241,10 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *CreateOrderRequest)
 
 	// Process each order line
 	for _, lineItem := range req.GetOrderLines() {
-		// Get ticket tier
-		ticketTier, err := s.ticketTierRepo.GetByID(ctx, lineItem.TicketTierID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to get ticket tier: %w", err)
-		}
+		ticketTier := ticketTiers[lineItem.TicketTierID]
 
 		// Check availability
-		available, err := s.ticketTierRepo.GetAvailableQuantity(ctx, lineItem.TicketTierID)
+		available, err := tx.TicketTiers().GetAvailableQuantity(tx.Context(), lineItem.TicketTierID)
 		if err != nil {
 			return nil, fmt.Errorf("failed to check availability: %w", err)
 		}
@@ -205,32 +261,42 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *CreateOrderRequest)
 			s.holdDuration,
 		)
 
-		if err := s.inventoryHoldRepo.Create(ctx, inventoryHold); err != nil {
+		if err := tx.InventoryHolds().Create(tx.Context(), inventoryHold); err != nil {
 			return nil, fmt.Errorf("failed to create inventory hold: %w", err)
 		}
 
-		// Create order line
+		// Create order line at the price quoted now; the quote holds as long as the inventory hold
+		quote := quotes[ticketTier.ID]
 		orderLine := entities.NewOrderLine(
 			order.ID,
 			lineItem.TicketTierID,
 			lineItem.Quantity,
-			ticketTier.Price,
+			quote.Price,
 		)
+		orderLine.LockQuote(quote.BasePrice, expiresAt)
 
-		if err := s.orderLineRepo.Create(ctx, orderLine); err != nil {
+		if err := tx.OrderLines().Create(tx.Context(), orderLine); err != nil {
 			return nil, fmt.Errorf("failed to create order line: %w", err)
 		}
 
 		orderLines = append(orderLines, orderLine)
-		totalAmount += float64(lineItem.Quantity) * ticketTier.Price
+		totalAmount += orderLine.Subtotal
 	}
 
 	// Update order total
 	order.TotalAmount = totalAmount
-	if err := s.orderRepo.Update(ctx, order); err != nil {
+	if err := tx.Orders().Update(tx.Context(), order); err != nil {
 		return nil, fmt.Errorf("failed to update order total: %w", err)
 	}
 
+	if err := s.eventBus.PublishTx(tx.Context(), tx, entities.NewOrderCreated(order)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return &CreateOrderResponse{
 		Order:       order,
 		OrderLines:  orderLines,
@@ -239,6 +305,199 @@ func (s *OrderService) CreateOrder(ctx context.Context, req *CreateOrderRequest)
 	}, nil
 }
 
+// CreateTourPassOrder creates an order for tour passes. The order carries one
+// line per event the pass covers, so each event's tier is held and, once paid,
+// one ticket per pass is issued for every event.
+func (s *OrderService) CreateTourPassOrder(ctx context.Context, req *CreateTourPassOrderRequest) (*CreateOrderResponse, error) {
+	user, err := s.userRepo.GetByID(ctx, req.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Lock the pass so concurrent purchases cannot oversell its quota
+	pass, err := tx.TourPasses().GetByIDForUpdate(tx.Context(), req.TourPassID)
+	if err != nil {
+		if err == entities.ErrTourPassNotFound {
+			return nil, entities.NewNotFoundError("tour_pass", "tour pass not found")
+		}
+		return nil, fmt.Errorf("failed to get tour pass: %w", err)
+	}
+
+	now := time.Now()
+	if !pass.IsOnSale(now) {
+		return nil, entities.NewBusinessRuleError("tour_pass_not_on_sale", "tour pass is not on sale", nil)
+	}
+	if req.Quantity < 1 || req.Quantity > pass.MaxPerOrder {
+		return nil, entities.NewValidationError("quantity", fmt.Sprintf("quantity must be between 1 and %d", pass.MaxPerOrder))
+	}
+	if len(pass.Events) == 0 {
+		return nil, entities.NewBusinessRuleError("tour_pass_not_on_sale", "tour pass covers no events", nil)
+	}
+
+	if pass.Quota != nil {
+		reserved, err := tx.TourPasses().CountReserved(tx.Context(), pass.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to count reserved passes: %w", err)
+		}
+		if reserved+req.Quantity > *pass.Quota {
+			return nil, entities.NewBusinessRuleError("insufficient_tickets", "not enough tour passes available", map[string]interface{}{
+				"available": *pass.Quota - reserved,
+			})
+		}
+	}
+
+	// Every covered event must still be on sale with room on its tier, which
+	// stays locked until the order is saved
+	tierIDs := make([]uuid.UUID, 0, len(pass.Events))
+	for _, passEvent := range pass.Events {
+		tierIDs = append(tierIDs, passEvent.TicketTierID)
+	}
+	if _, err := tx.TicketTiers().LockForUpdate(tx.Context(), tierIDs); err != nil {
+		return nil, fmt.Errorf("failed to lock ticket tiers: %w", err)
+	}
+	for _, passEvent := range pass.Events {
+		event, err := tx.Events().GetByID(tx.Context(), passEvent.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event: %w", err)
+		}
+		if event.Status != entities.EventStatusPublished && event.Status != entities.EventStatusOnSale {
+			return nil, entities.NewBusinessRuleError("tour_pass_not_on_sale", "an event covered by the pass is not on sale", map[string]interface{}{
+				"event_id": event.ID,
+			})
+		}
+		if !event.EventDate.After(now) {
+			return nil, entities.NewBusinessRuleError("tour_pass_not_on_sale", "an event covered by the pass has already taken place", map[string]interface{}{
+				"event_id": event.ID,
+			})
+		}
+
+		available, err := tx.TicketTiers().GetAvailableQuantity(tx.Context(), passEvent.TicketTierID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check availability: %w", err)
+		}
+		if available < req.Quantity {
+			return nil, entities.NewBusinessRuleError("insufficient_tickets", "not enough tickets available for an event covered by the pass", map[string]interface{}{
+				"event_id":  event.ID,
+				"available": available,
+			})
+		}
+	}
+
+	order := newCustomerOrder(pass.Events[0].EventID, req.UserID, user, req.CustomerInfo)
+	order.Currency = pass.Currency
+
+	expiresAt := time.Now().UTC().Add(s.holdDuration)
+	order.ExpiresAt = expiresAt
+
+	if err := tx.Orders().Create(tx.Context(), order); err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	var orderLines []*entities.OrderLine
+	var totalAmount float64
+
+	prices := pass.EventPrices()
+	for i, passEvent := range pass.Events {
+		inventoryHold := entities.NewInventoryHold(
+			order.ID,
+			passEvent.TicketTierID,
+			req.Quantity,
+			s.holdDuration,
+		)
+		if err := tx.InventoryHolds().Create(tx.Context(), inventoryHold); err != nil {
+			return nil, fmt.Errorf("failed to create inventory hold: %w", err)
+		}
+
+		orderLine := entities.NewOrderLine(
+			order.ID,
+			passEvent.TicketTierID,
+			req.Quantity,
+			prices[i],
+		)
+		if err := tx.OrderLines().Create(tx.Context(), orderLine); err != nil {
+			return nil, fmt.Errorf("failed to create order line: %w", err)
+		}
+
+		orderLines = append(orderLines, orderLine)
+		totalAmount += float64(req.Quantity) * prices[i]
+	}
+
+	order.TotalAmount = totalAmount
+	if err := tx.Orders().Update(tx.Context(), order); err != nil {
+		return nil, fmt.Errorf("failed to update order total: %w", err)
+	}
+
+	passOrder := &entities.TourPassOrder{
+		OrderID:    order.ID,
+		TourPassID: pass.ID,
+		Quantity:   req.Quantity,
+		CreatedAt:  time.Now(),
+	}
+	if err := tx.TourPasses().CreateOrder(tx.Context(), passOrder); err != nil {
+		return nil, fmt.Errorf("failed to link tour pass order: %w", err)
+	}
+
+	if err := s.eventBus.PublishTx(tx.Context(), tx, entities.NewOrderCreated(order)); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &CreateOrderResponse{
+		Order:       order,
+		OrderLines:  orderLines,
+		TotalAmount: totalAmount,
+		ExpiresAt:   expiresAt,
+	}, nil
+}
+
+// newCustomerOrder creates an order for a user, taking the customer details from
+// the request when given and from the user's profile otherwise
+func newCustomerOrder(eventID, userID uuid.UUID, user *entities.User, info *CustomerInfo) *entities.Order {
+	customerEmail := ""
+	if info != nil && info.Email != "" {
+		customerEmail = info.Email
+	} else if user.Email != nil {
+		customerEmail = *user.Email
+	}
+
+	order := entities.NewOrder(eventID.String(), customerEmail)
+	// Set the user ID on the order so ownership checks work
+	order.UserID = &userID
+
+	// Set customer info if provided
+	if info != nil {
+		order.CustomerFirstName = info.FirstName
+		order.CustomerLastName = info.LastName
+		order.CustomerEmail = info.Email
+		order.CustomerPhone = info.Phone
+	} else {
+		// Use user info as fallback
+		if user.FirstName != nil {
+			order.CustomerFirstName = *user.FirstName
+		}
+		if user.LastName != nil {
+			order.CustomerLastName = *user.LastName
+		}
+		if user.Email != nil {
+			order.CustomerEmail = *user.Email
+		}
+		if user.Phone != nil {
+			order.CustomerPhone = *user.Phone
+		}
+	}
+
+	return order
+}
+
 // GetOrder retrieves an order by ID
 func (s *OrderService) GetOrder(ctx context.Context, orderID uuid.UUID) (*entities.Order, error) {
 	return s.orderRepo.GetByID(ctx, orderID)
@@ -388,26 +647,40 @@ func (s *OrderService) ExpireOrder(ctx context.Context, orderID uuid.UUID) error
 		return fmt.Errorf("order is not in pending status")
 	}
 
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
 	// Update order status
 	order.Status = entities.OrderStatusExpired
 
-	if err := s.orderRepo.Update(ctx, order); err != nil {
+	if err := tx.Orders().Update(tx.Context(), order); err != nil {
 		return err
 	}
 
 	// Release inventory holds
-	holds, err := s.inventoryHoldRepo.GetByOrderID(ctx, orderID)
+	holds, err := tx.InventoryHolds().GetByOrderID(tx.Context(), orderID)
 	if err != nil {
 		return err
 	}
 
 	for _, hold := range holds {
 		hold.Status = entities.InventoryHoldStatusExpired
-		if err := s.inventoryHoldRepo.Update(ctx, hold); err != nil {
+		if err := tx.InventoryHolds().Update(tx.Context(), hold); err != nil {
 			return err
 		}
 	}
 
+	if err := s.eventBus.PublishTx(tx.Context(), tx, entities.NewOrderExpired(order)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
 	return nil
 }
 
@@ -428,6 +701,24 @@ func (s *OrderService) ProcessExpiredOrders(ctx context.Context) error {
 	return nil
 }
 
+// RunExpiry expires pending orders past their hold every interval until ctx is done
+func (s *OrderService) RunExpiry(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.ProcessExpiredOrders(ctx); err != nil {
+			fmt.Printf("Warning: failed to process expired orders: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
 // GetOrderStats retrieves order statistics
 func (s *OrderService) GetOrderStats(ctx context.Context, eventID *uuid.UUID) (*OrderStats, error) {
 	var eventUUID uuid.UUID
@@ -460,4 +751,3 @@ type OrderStats struct {
 	ExpiredOrders   int     `json:"expired_orders"`
 	TotalRevenue    float64 `json:"total_revenue"`
 }
-
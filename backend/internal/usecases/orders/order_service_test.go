@@ -0,0 +1,685 @@
+package orders
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+type fakeUsers struct {
+	repositories.UserRepository
+	user *entities.User
+}
+
+func (f *fakeUsers) GetByID(ctx context.Context, id uuid.UUID) (*entities.User, error) {
+	return f.user, nil
+}
+
+type fakeEvents struct {
+	repositories.EventRepository
+	event *entities.Event
+}
+
+func (f *fakeEvents) GetByID(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	if id != f.event.ID {
+		return nil, entities.ErrEventNotFound
+	}
+	return f.event, nil
+}
+
+type fakePriceChanges struct {
+	repositories.TierPriceChangeRepository
+	applied []uuid.UUID
+}
+
+func (f *fakePriceChanges) ApplyDue(ctx context.Context, eventID *uuid.UUID) (int, error) {
+	f.applied = append(f.applied, *eventID)
+	return 0, nil
+}
+
+// fakeTiers reports every tier on sale at its own price unless the test
+// gives it another sale state or quoted price
+type fakeTiers struct {
+	repositories.TicketTierRepository
+	tiers     map[uuid.UUID]*entities.TicketTier
+	available int
+	states    map[uuid.UUID]entities.TierSaleState
+	quotes    map[uuid.UUID]float64
+	locked    []uuid.UUID
+	unlocked  bool
+}
+
+func (f *fakeTiers) GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
+	tier, ok := f.tiers[id]
+	if !ok {
+		return nil, entities.ErrNotFoundError
+	}
+	return tier, nil
+}
+
+func (f *fakeTiers) LockForUpdate(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.TicketTier, error) {
+	tiers := make(map[uuid.UUID]*entities.TicketTier, len(ids))
+	for _, id := range ids {
+		tier, err := f.GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		f.locked = append(f.locked, id)
+		tiers[id] = tier
+	}
+	return tiers, nil
+}
+
+// GetAvailableQuantity notes availability checked on a tier that is not
+// locked, which a concurrent sale could change before the order commits
+func (f *fakeTiers) GetAvailableQuantity(ctx context.Context, id uuid.UUID) (int, error) {
+	if !containsID(f.locked, id) {
+		f.unlocked = true
+	}
+	return f.available, nil
+}
+
+func (f *fakeTiers) GetAvailability(ctx context.Context, eventID uuid.UUID) ([]*repositories.TicketTierAvailability, error) {
+	var availability []*repositories.TicketTierAvailability
+	for _, tier := range f.tiers {
+		state, ok := f.states[tier.ID]
+		if !ok {
+			state = entities.TierSaleStateOnSale
+		}
+		price, ok := f.quotes[tier.ID]
+		if !ok {
+			price = tier.Price
+		}
+		availability = append(availability, &repositories.TicketTierAvailability{
+			TicketTierID: tier.ID,
+			Name:         tier.Name,
+			Price:        price,
+			BasePrice:    tier.Price,
+			Currency:     tier.Currency,
+			Available:    f.available,
+			SaleStatus:   state,
+			IsOnSale:     state == entities.TierSaleStateOnSale,
+		})
+	}
+	return availability, nil
+}
+
+type fakeOrders struct {
+	repositories.OrderRepository
+	orders map[uuid.UUID]*entities.Order
+}
+
+func (f *fakeOrders) Create(ctx context.Context, order *entities.Order) error {
+	f.orders[order.ID] = order
+	return nil
+}
+
+func (f *fakeOrders) Update(ctx context.Context, order *entities.Order) error {
+	f.orders[order.ID] = order
+	return nil
+}
+
+func (f *fakeOrders) GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+	order, ok := f.orders[id]
+	if !ok {
+		return nil, entities.ErrOrderNotFound
+	}
+	copied := *order
+	return &copied, nil
+}
+
+type fakeOrderLines struct {
+	repositories.OrderLineRepository
+	lines []*entities.OrderLine
+}
+
+func (f *fakeOrderLines) Create(ctx context.Context, line *entities.OrderLine) error {
+	f.lines = append(f.lines, line)
+	return nil
+}
+
+type fakeHolds struct {
+	repositories.InventoryHoldRepository
+	holds []*entities.InventoryHold
+}
+
+func (f *fakeHolds) Create(ctx context.Context, hold *entities.InventoryHold) error {
+	f.holds = append(f.holds, hold)
+	return nil
+}
+
+func (f *fakeHolds) GetByOrderID(ctx context.Context, orderID uuid.UUID) ([]*entities.InventoryHold, error) {
+	var holds []*entities.InventoryHold
+	for _, hold := range f.holds {
+		if hold.OrderID == orderID {
+			holds = append(holds, hold)
+		}
+	}
+	return holds, nil
+}
+
+func (f *fakeHolds) Update(ctx context.Context, hold *entities.InventoryHold) error {
+	return nil
+}
+
+type fakeOutbox struct {
+	repositories.OutboxRepository
+	messages []*entities.OutboxMessage
+}
+
+func (f *fakeOutbox) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+// fakeTx hands out the same repositories as the service uses outside a
+// transaction, except for its own outbox, so tests can tell what was queued
+// inside the transaction
+type fakeTx struct {
+	repositories.Transaction
+	ctx        context.Context
+	orders     *fakeOrders
+	orderLines *fakeOrderLines
+	holds      *fakeHolds
+	tiers      *fakeTiers
+	outbox     *fakeOutbox
+	committed  bool
+}
+
+func (tx *fakeTx) Commit() error                                        { tx.committed = true; return nil }
+func (tx *fakeTx) Rollback() error                                      { return nil }
+func (tx *fakeTx) Context() context.Context                             { return tx.ctx }
+func (tx *fakeTx) Orders() repositories.OrderRepository                 { return tx.orders }
+func (tx *fakeTx) OrderLines() repositories.OrderLineRepository         { return tx.orderLines }
+func (tx *fakeTx) InventoryHolds() repositories.InventoryHoldRepository { return tx.holds }
+func (tx *fakeTx) TicketTiers() repositories.TicketTierRepository       { return tx.tiers }
+func (tx *fakeTx) Outbox() repositories.OutboxRepository                { return tx.outbox }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	u.tx.ctx = ctx
+	return u.tx, nil
+}
+
+type orderFixture struct {
+	service  *OrderService
+	recorded *eventbus.Recorder
+	outbox   *fakeOutbox
+	tx       *fakeTx
+	orders   *fakeOrders
+	event    *entities.Event
+	tiers    []*entities.TicketTier
+	prices   *fakePriceChanges
+	userID   uuid.UUID
+}
+
+// newOrderFixture builds an order service over fakes, with an event on sale
+// and two of its tiers. The bus has an asynchronous subscriber for the order
+// events, so publishing them queues outbox messages.
+func newOrderFixture(t *testing.T) *orderFixture {
+	t.Helper()
+
+	event := entities.NewEvent(uuid.New(), "Afrobeats Live", "afrobeats-live", time.Now().Add(30*24*time.Hour), "Eko Hotel", "Victoria Island", "Lagos", "NG")
+	event.Status = entities.EventStatusPublished
+	event.Currency = entities.DefaultCurrency
+
+	regular := entities.NewTicketTier(event.ID, "Regular", 10000)
+	vip := entities.NewTicketTier(event.ID, "VIP", 50000)
+	tiers := &fakeTiers{
+		tiers:     map[uuid.UUID]*entities.TicketTier{regular.ID: regular, vip.ID: vip},
+		available: 10,
+	}
+
+	email := "ada@example.com"
+	user := &entities.User{ID: uuid.New(), Email: &email}
+
+	orders := &fakeOrders{orders: make(map[uuid.UUID]*entities.Order)}
+	orderLines := &fakeOrderLines{}
+	holds := &fakeHolds{}
+	rootOutbox := &fakeOutbox{}
+	tx := &fakeTx{orders: orders, orderLines: orderLines, holds: holds, tiers: tiers, outbox: &fakeOutbox{}}
+
+	bus := eventbus.NewBus(rootOutbox, outbox.NewDispatcher(rootOutbox))
+	noop := func(ctx context.Context, event entities.DomainEvent) error { return nil }
+	bus.SubscribeAsync("test", entities.DomainEventOrderCreated, noop)
+	bus.SubscribeAsync("test", entities.DomainEventOrderExpired, noop)
+	recorded := eventbus.NewRecorder(bus)
+
+	prices := &fakePriceChanges{}
+	service := NewOrderService(
+		orders,
+		orderLines,
+		holds,
+		&fakeEvents{event: event},
+		tiers,
+		prices,
+		&fakeUsers{user: user},
+		&fakeUnitOfWork{tx: tx},
+		bus,
+	)
+
+	return &orderFixture{
+		service:  service,
+		recorded: recorded,
+		outbox:   rootOutbox,
+		tx:       tx,
+		orders:   orders,
+		event:    event,
+		tiers:    []*entities.TicketTier{regular, vip},
+		prices:   prices,
+		userID:   user.ID,
+	}
+}
+
+func TestCreateOrderPublishesOrderCreatedInTransaction(t *testing.T) {
+	f := newOrderFixture(t)
+
+	resp, err := f.service.CreateOrder(context.Background(), &CreateOrderRequest{
+		UserID:  f.userID,
+		EventID: f.event.ID,
+		OrderLines: []CreateOrderLineItem{
+			{TicketTierID: f.tiers[1].ID, Quantity: 1},
+			{TicketTierID: f.tiers[0].ID, Quantity: 2},
+		},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	f.recorded.AssertSequence(t, entities.DomainEventOrderCreated)
+	created := f.recorded.Last(entities.DomainEventOrderCreated).(*entities.OrderCreated)
+	if created.OrderID != resp.Order.ID || created.EventID != f.event.ID {
+		t.Errorf("OrderCreated = %+v, want order %s of event %s", created, resp.Order.ID, f.event.ID)
+	}
+	if created.TotalAmount != 70000 {
+		t.Errorf("OrderCreated total = %v, want 70000", created.TotalAmount)
+	}
+
+	if !f.tx.committed {
+		t.Fatalf("order was not committed")
+	}
+	if len(f.tx.outbox.messages) != 1 || f.tx.outbox.messages[0].Topic != "event.order.created.test" {
+		t.Fatalf("expected OrderCreated queued in the order's transaction, got %v", topics(f.tx.outbox.messages))
+	}
+	if len(f.outbox.messages) != 0 {
+		t.Errorf("expected nothing queued outside the transaction, got %v", topics(f.outbox.messages))
+	}
+
+	// Both tiers are locked before availability is checked
+	if len(f.tx.tiers.locked) != 2 || !containsID(f.tx.tiers.locked, f.tiers[0].ID) || !containsID(f.tx.tiers.locked, f.tiers[1].ID) {
+		t.Errorf("locked tiers %v, want both of the order's tiers", f.tx.tiers.locked)
+	}
+	if f.tx.tiers.unlocked {
+		t.Errorf("availability was checked before the tier was locked")
+	}
+}
+
+func TestCreateOrderAcceptsPublishedAndOnSaleEvents(t *testing.T) {
+	tests := []struct {
+		status  entities.EventStatus
+		wantErr error
+	}{
+		{status: entities.EventStatusPublished},
+		{status: entities.EventStatusOnSale},
+		{status: entities.EventStatusDraft, wantErr: entities.ErrEventNotActive},
+		{status: entities.EventStatusCancelled, wantErr: entities.ErrEventNotActive},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			f := newOrderFixture(t)
+			f.event.Status = tt.status
+
+			_, err := f.service.CreateOrder(context.Background(), &CreateOrderRequest{
+				UserID:     f.userID,
+				EventID:    f.event.ID,
+				OrderLines: []CreateOrderLineItem{{TicketTierID: f.tiers[0].ID, Quantity: 1}},
+			})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CreateOrder() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateOrderRejectsTierInAnotherCurrency(t *testing.T) {
+	f := newOrderFixture(t)
+	f.event.Currency = entities.CurrencyGHS
+	f.tiers[0].Currency = entities.CurrencyGHS
+
+	resp, err := f.service.CreateOrder(context.Background(), &CreateOrderRequest{
+		UserID:     f.userID,
+		EventID:    f.event.ID,
+		OrderLines: []CreateOrderLineItem{{TicketTierID: f.tiers[0].ID, Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if resp.Order.Currency != entities.CurrencyGHS {
+		t.Errorf("order currency = %s, want the event currency %s", resp.Order.Currency, entities.CurrencyGHS)
+	}
+
+	// The VIP tier is still priced in naira
+	_, err = f.service.CreateOrder(context.Background(), &CreateOrderRequest{
+		UserID:  f.userID,
+		EventID: f.event.ID,
+		OrderLines: []CreateOrderLineItem{
+			{TicketTierID: f.tiers[0].ID, Quantity: 1},
+			{TicketTierID: f.tiers[1].ID, Quantity: 1},
+		},
+	})
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "mixed_currency" {
+		t.Fatalf("CreateOrder() error = %v, want business rule mixed_currency", err)
+	}
+}
+
+func TestCreateOrderRefusesTiersNotOnSale(t *testing.T) {
+	states := []entities.TierSaleState{
+		entities.TierSaleStateUpcoming,
+		entities.TierSaleStateSoldOut,
+		entities.TierSaleStateEnded,
+	}
+
+	for _, state := range states {
+		t.Run(string(state), func(t *testing.T) {
+			f := newOrderFixture(t)
+			f.tx.tiers.states = map[uuid.UUID]entities.TierSaleState{f.tiers[1].ID: state}
+
+			_, err := f.service.CreateOrder(context.Background(), &CreateOrderRequest{
+				UserID:  f.userID,
+				EventID: f.event.ID,
+				OrderLines: []CreateOrderLineItem{
+					{TicketTierID: f.tiers[0].ID, Quantity: 1},
+					{TicketTierID: f.tiers[1].ID, Quantity: 1},
+				},
+			})
+			var ruleErr *entities.BusinessRuleError
+			if !errors.As(err, &ruleErr) || ruleErr.Rule != "ticket_tier_not_on_sale" {
+				t.Fatalf("CreateOrder() error = %v, want business rule ticket_tier_not_on_sale", err)
+			}
+			if ruleErr.Details["sale_status"] != state {
+				t.Errorf("sale status = %v, want %s", ruleErr.Details["sale_status"], state)
+			}
+			if len(f.orders.orders) != 0 {
+				t.Errorf("an order was saved for a tier not on sale")
+			}
+		})
+	}
+}
+
+func TestCreateOrderAppliesDuePriceChangesFirst(t *testing.T) {
+	f := newOrderFixture(t)
+
+	if _, err := f.service.CreateOrder(context.Background(), &CreateOrderRequest{
+		UserID:     f.userID,
+		EventID:    f.event.ID,
+		OrderLines: []CreateOrderLineItem{{TicketTierID: f.tiers[0].ID, Quantity: 1}},
+	}); err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	if len(f.prices.applied) != 1 || f.prices.applied[0] != f.event.ID {
+		t.Errorf("due price changes applied for %v, want the order's event", f.prices.applied)
+	}
+}
+
+func TestCreateOrderLocksQuotedPriceForTheHold(t *testing.T) {
+	f := newOrderFixture(t)
+	f.tx.tiers.quotes = map[uuid.UUID]float64{f.tiers[0].ID: 12500}
+
+	resp, err := f.service.CreateOrder(context.Background(), &CreateOrderRequest{
+		UserID:     f.userID,
+		EventID:    f.event.ID,
+		OrderLines: []CreateOrderLineItem{{TicketTierID: f.tiers[0].ID, Quantity: 2}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+
+	line := f.tx.orderLines.lines[0]
+	if line.UnitPrice != 12500 || line.Subtotal != 25000 || resp.Order.TotalAmount != 25000 {
+		t.Errorf("order line = %v each, %v in all, want the 12500 quote", line.UnitPrice, line.Subtotal)
+	}
+	if line.QuotedPrice == nil || *line.QuotedPrice != 12500 || line.BasePrice == nil || *line.BasePrice != 10000 {
+		t.Errorf("order line quote = %v over base %v, want 12500 over 10000", line.QuotedPrice, line.BasePrice)
+	}
+	if line.QuoteExpiresAt == nil || !line.QuoteExpiresAt.Equal(resp.Order.ExpiresAt) {
+		t.Errorf("quote expires at %v, want with the hold at %v", line.QuoteExpiresAt, resp.Order.ExpiresAt)
+	}
+}
+
+func TestCreateOrderPublishesNothingWhenSoldOut(t *testing.T) {
+	f := newOrderFixture(t)
+	f.tx.tiers.available = 1
+
+	_, err := f.service.CreateOrder(context.Background(), &CreateOrderRequest{
+		UserID:     f.userID,
+		EventID:    f.event.ID,
+		OrderLines: []CreateOrderLineItem{{TicketTierID: f.tiers[0].ID, Quantity: 2}},
+	})
+	if !errors.Is(err, entities.ErrInsufficientTickets) {
+		t.Fatalf("CreateOrder() error = %v, want %v", err, entities.ErrInsufficientTickets)
+	}
+
+	f.recorded.AssertNotPublished(t, entities.DomainEventOrderCreated)
+	if f.tx.committed {
+		t.Errorf("a failed order must not be committed")
+	}
+}
+
+func TestExpireOrderPublishesOrderExpiredInTransaction(t *testing.T) {
+	f := newOrderFixture(t)
+
+	resp, err := f.service.CreateOrder(context.Background(), &CreateOrderRequest{
+		UserID:     f.userID,
+		EventID:    f.event.ID,
+		OrderLines: []CreateOrderLineItem{{TicketTierID: f.tiers[0].ID, Quantity: 1}},
+	})
+	if err != nil {
+		t.Fatalf("CreateOrder() error = %v", err)
+	}
+	f.recorded.Reset()
+	f.tx.outbox.messages = nil
+	f.tx.committed = false
+
+	if err := f.service.ExpireOrder(context.Background(), resp.Order.ID); err != nil {
+		t.Fatalf("ExpireOrder() error = %v", err)
+	}
+
+	f.recorded.AssertSequence(t, entities.DomainEventOrderExpired)
+	expired := f.recorded.Last(entities.DomainEventOrderExpired).(*entities.OrderExpired)
+	if expired.OrderID != resp.Order.ID {
+		t.Errorf("OrderExpired for order %s, want %s", expired.OrderID, resp.Order.ID)
+	}
+	if f.orders.orders[resp.Order.ID].Status != entities.OrderStatusExpired {
+		t.Errorf("order status = %s, want expired", f.orders.orders[resp.Order.ID].Status)
+	}
+	if !f.tx.committed {
+		t.Fatalf("expiry was not committed")
+	}
+	if len(f.tx.outbox.messages) != 1 || f.tx.outbox.messages[0].Topic != "event.order.expired.test" {
+		t.Fatalf("expected OrderExpired queued in the expiry's transaction, got %v", topics(f.tx.outbox.messages))
+	}
+	if len(f.outbox.messages) != 0 {
+		t.Errorf("expected nothing queued outside the transaction, got %v", topics(f.outbox.messages))
+	}
+
+	// An order that is no longer pending is left alone
+	f.recorded.Reset()
+	if err := f.service.ExpireOrder(context.Background(), resp.Order.ID); err == nil {
+		t.Fatalf("expiring an expired order should fail")
+	}
+	f.recorded.AssertNotPublished(t, entities.DomainEventOrderExpired)
+}
+
+// stock stands in for the ticket_tiers rows: a transaction locking a tier
+// holds it until it commits or rolls back, and only committed order lines
+// count against the quota
+type stock struct {
+	quota int
+	mu    sync.Mutex
+	sold  map[uuid.UUID]int
+	locks map[uuid.UUID]*sync.Mutex
+}
+
+func newStock(quota int, tierIDs ...uuid.UUID) *stock {
+	s := &stock{quota: quota, sold: make(map[uuid.UUID]int), locks: make(map[uuid.UUID]*sync.Mutex)}
+	for _, id := range tierIDs {
+		s.locks[id] = &sync.Mutex{}
+	}
+	return s
+}
+
+func (s *stock) soldOf(id uuid.UUID) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sold[id]
+}
+
+// stockTiers reads tiers from the fixture and availability from the stock
+type stockTiers struct {
+	*fakeTiers
+	tx *stockTx
+}
+
+func (r *stockTiers) LockForUpdate(ctx context.Context, ids []uuid.UUID) (map[uuid.UUID]*entities.TicketTier, error) {
+	tiers := make(map[uuid.UUID]*entities.TicketTier, len(ids))
+	for _, id := range ids {
+		r.tx.stock.locks[id].Lock()
+		r.tx.held = append(r.tx.held, id)
+		tiers[id] = r.tiers[id]
+	}
+	return tiers, nil
+}
+
+// GetAvailableQuantity pauses after reading, so checkouts that do not hold
+// the tier's lock overlap and see the same tickets as available
+func (r *stockTiers) GetAvailableQuantity(ctx context.Context, id uuid.UUID) (int, error) {
+	available := r.tx.stock.quota - r.tx.stock.soldOf(id)
+	time.Sleep(time.Millisecond)
+	return available, nil
+}
+
+type stockTx struct {
+	repositories.Transaction
+	ctx        context.Context
+	stock      *stock
+	tiers      *fakeTiers
+	orderLines *fakeOrderLines
+	held       []uuid.UUID
+	done       bool
+}
+
+func (tx *stockTx) Context() context.Context { return tx.ctx }
+func (tx *stockTx) Orders() repositories.OrderRepository {
+	return &fakeOrders{orders: make(map[uuid.UUID]*entities.Order)}
+}
+func (tx *stockTx) OrderLines() repositories.OrderLineRepository         { return tx.orderLines }
+func (tx *stockTx) InventoryHolds() repositories.InventoryHoldRepository { return &fakeHolds{} }
+func (tx *stockTx) TicketTiers() repositories.TicketTierRepository {
+	return &stockTiers{fakeTiers: tx.tiers, tx: tx}
+}
+func (tx *stockTx) Outbox() repositories.OutboxRepository { return &fakeOutbox{} }
+
+func (tx *stockTx) Commit() error {
+	tx.stock.mu.Lock()
+	for _, line := range tx.orderLines.lines {
+		tx.stock.sold[line.TicketTierID] += line.Quantity
+	}
+	tx.stock.mu.Unlock()
+	tx.release()
+	return nil
+}
+
+func (tx *stockTx) Rollback() error {
+	tx.release()
+	return nil
+}
+
+func (tx *stockTx) release() {
+	if tx.done {
+		return
+	}
+	tx.done = true
+	for _, id := range tx.held {
+		tx.stock.locks[id].Unlock()
+	}
+}
+
+type stockUnitOfWork struct {
+	stock *stock
+	tiers *fakeTiers
+}
+
+func (u *stockUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	return &stockTx{ctx: ctx, stock: u.stock, tiers: u.tiers, orderLines: &fakeOrderLines{}}, nil
+}
+
+func TestConcurrentCheckoutsCannotOversellTier(t *testing.T) {
+	f := newOrderFixture(t)
+	regular := f.tiers[0]
+	inventory := newStock(3, regular.ID, f.tiers[1].ID)
+	f.service.unitOfWork = &stockUnitOfWork{stock: inventory, tiers: f.tx.tiers}
+
+	const buyers = 10
+	var wg sync.WaitGroup
+	errs := make(chan error, buyers)
+	for i := 0; i < buyers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := f.service.CreateOrder(context.Background(), &CreateOrderRequest{
+				UserID:     f.userID,
+				EventID:    f.event.ID,
+				OrderLines: []CreateOrderLineItem{{TicketTierID: regular.ID, Quantity: 1}},
+			})
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	succeeded := 0
+	for err := range errs {
+		switch {
+		case err == nil:
+			succeeded++
+		case !errors.Is(err, entities.ErrInsufficientTickets):
+			t.Errorf("CreateOrder() error = %v, want %v", err, entities.ErrInsufficientTickets)
+		}
+	}
+	if succeeded != 3 || inventory.soldOf(regular.ID) != 3 {
+		t.Errorf("%d checkouts succeeded selling %d tickets, want 3 of a quota of 3", succeeded, inventory.soldOf(regular.ID))
+	}
+}
+
+func topics(messages []*entities.OutboxMessage) []string {
+	names := make([]string, len(messages))
+	for i, message := range messages {
+		names[i] = message.Topic
+	}
+	return names
+}
+
+func containsID(ids []uuid.UUID, id uuid.UUID) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
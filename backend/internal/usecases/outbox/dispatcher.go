@@ -0,0 +1,143 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+const (
+	// DefaultDispatchInterval is how often the dispatcher looks for due messages
+	DefaultDispatchInterval = 5 * time.Second
+
+	// DefaultDispatchBatchSize is how many messages are claimed at a time
+	DefaultDispatchBatchSize = 50
+
+	// DefaultDispatchLease is how long a claimed message stays locked; a message
+	// whose attempt outlives it may be claimed again
+	DefaultDispatchLease = 5 * time.Minute
+)
+
+// Handler delivers one outbox message. Returning an error schedules a retry.
+// Handlers must tolerate the same message more than once.
+type Handler func(ctx context.Context, message *entities.OutboxMessage) error
+
+// Dispatcher delivers due outbox messages to the handler registered for their topic
+type Dispatcher struct {
+	outboxRepo repositories.OutboxRepository
+	handlers   map[string]Handler
+	batchSize  int
+	lease      time.Duration
+}
+
+// NewDispatcher creates a dispatcher with no handlers
+func NewDispatcher(outboxRepo repositories.OutboxRepository) *Dispatcher {
+	return &Dispatcher{
+		outboxRepo: outboxRepo,
+		handlers:   make(map[string]Handler),
+		batchSize:  DefaultDispatchBatchSize,
+		lease:      DefaultDispatchLease,
+	}
+}
+
+// Handle registers the handler for a topic. Register every handler before Run.
+func (d *Dispatcher) Handle(topic string, handler Handler) {
+	d.handlers[topic] = handler
+}
+
+// DispatchDue attempts one batch of due messages and returns how many it attempted
+func (d *Dispatcher) DispatchDue(ctx context.Context) (int, error) {
+	messages, err := d.outboxRepo.ClaimDue(ctx, d.batchSize, d.lease)
+	if err != nil {
+		return 0, err
+	}
+
+	for i, message := range messages {
+		if err := d.deliver(ctx, message); err != nil {
+			message.MarkFailed(err.Error(), time.Now())
+			if message.Status == entities.OutboxStatusDead {
+				fmt.Printf("Warning: outbox message %s (%s) dead-lettered after %d attempts: %v\n",
+					message.ID, message.Topic, message.Attempts, err)
+			}
+		} else {
+			message.MarkDelivered(time.Now())
+			dropSecrets(message)
+		}
+
+		if err := d.outboxRepo.Update(ctx, message); err != nil {
+			return i, err
+		}
+	}
+
+	return len(messages), nil
+}
+
+// deliver runs the topic's handler, turning a panic into an error so one bad
+// message cannot stop the dispatcher
+func (d *Dispatcher) deliver(ctx context.Context, message *entities.OutboxMessage) (err error) {
+	handler, ok := d.handlers[message.Topic]
+	if !ok {
+		return fmt.Errorf("no handler for topic %q", message.Topic)
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler panicked: %v", r)
+		}
+	}()
+	return handler(ctx, message)
+}
+
+// Run dispatches due messages every interval until ctx is done. A full batch
+// is followed straight away by the next one.
+func (d *Dispatcher) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		attempted, err := d.DispatchDue(ctx)
+		if err != nil {
+			fmt.Printf("Warning: failed to dispatch outbox messages: %v\n", err)
+		}
+
+		if err == nil && attempted >= d.batchSize && ctx.Err() == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// encodePayload converts a payload struct to the JSONB stored on the message
+func encodePayload(payload interface{}) (entities.JSONB, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode outbox payload: %w", err)
+	}
+
+	var encoded entities.JSONB
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to encode outbox payload: %w", err)
+	}
+	return encoded, nil
+}
+
+// decodePayload reads a message payload into a payload struct
+func decodePayload(message *entities.OutboxMessage, payload interface{}) error {
+	data, err := json.Marshal(message.Payload)
+	if err != nil {
+		return fmt.Errorf("failed to decode outbox payload: %w", err)
+	}
+	if err := json.Unmarshal(data, payload); err != nil {
+		return fmt.Errorf("failed to decode outbox payload: %w", err)
+	}
+	return nil
+}
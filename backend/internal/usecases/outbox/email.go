@@ -0,0 +1,182 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// Email topics. Payloads carry IDs rather than rendered emails, so a retried
+// message renders from the data as it is when it is finally delivered.
+const (
+	TopicTicketEmail            = "email.tickets"
+	TopicTicketPDFEmail         = "email.ticket_pdfs"
+	TopicOrderConfirmationEmail = "email.order_confirmation"
+	TopicWelcomeEmail           = "email.welcome"
+	TopicPasswordResetEmail     = "email.password_reset"
+	TopicEventChangeEmail       = "email.event_change"
+	TopicEventCampaignEmail     = "email.event_campaign"
+	TopicCheckoutRecoveryEmail  = "email.checkout_recovery"
+	TopicMarketingEmail         = "email.marketing"
+)
+
+// ticketEmailPayload identifies the order and tickets to email and where to send them
+type ticketEmailPayload struct {
+	OrderID   uuid.UUID   `json:"order_id"`
+	TicketIDs []uuid.UUID `json:"ticket_ids,omitempty"`
+	Email     string      `json:"email"`
+}
+
+type orderEmailPayload struct {
+	OrderID uuid.UUID `json:"order_id"`
+}
+
+type userEmailPayload struct {
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type passwordResetPayload struct {
+	Email      string `json:"email"`
+	ResetToken string `json:"reset_token"`
+}
+
+type eventChangeEmailPayload struct {
+	ChangeID    uuid.UUID `json:"change_id"`
+	EventID     uuid.UUID `json:"event_id"`
+	RecipientID uuid.UUID `json:"recipient_id"`
+}
+
+type eventCampaignEmailPayload struct {
+	CampaignID uuid.UUID `json:"campaign_id"`
+	EventID    uuid.UUID `json:"event_id"`
+	DeliveryID uuid.UUID `json:"delivery_id"`
+}
+
+type checkoutRecoveryEmailPayload struct {
+	RecoveryID uuid.UUID `json:"recovery_id"`
+}
+
+type marketingEmailPayload struct {
+	CampaignID uuid.UUID `json:"campaign_id"`
+	DeliveryID uuid.UUID `json:"delivery_id"`
+}
+
+// QueuedEmailService implements services.EmailService by recording each email
+// in the outbox; the dispatcher sends it through EmailDelivery. A nil error
+// means the email is queued, not that it was sent.
+type QueuedEmailService struct {
+	outboxRepo repositories.OutboxRepository
+}
+
+// NewQueuedEmailService creates an email service that queues into outboxRepo.
+// Pass a transaction's outbox repository to queue atomically with other writes.
+func NewQueuedEmailService(outboxRepo repositories.OutboxRepository) *QueuedEmailService {
+	return &QueuedEmailService{
+		outboxRepo: outboxRepo,
+	}
+}
+
+var _ services.EmailService = (*QueuedEmailService)(nil)
+
+// SendTicketEmail queues the plain ticket email for an order
+func (s *QueuedEmailService) SendTicketEmail(ctx context.Context, order *entities.Order, tickets []*entities.Ticket) error {
+	return s.queueTickets(ctx, TopicTicketEmail, order, tickets)
+}
+
+// SendTicketPDFEmail queues the ticket PDF email for an order. Order lines,
+// event and wallet links are loaded again at delivery, so only the order and
+// tickets are recorded.
+func (s *QueuedEmailService) SendTicketPDFEmail(ctx context.Context, order *entities.Order, tickets []*entities.Ticket, orderLines []*entities.OrderLine, event *entities.Event, walletLinks []services.WalletLinks) error {
+	return s.QueueTicketPDFEmail(ctx, order, tickets)
+}
+
+// QueueTicketPDFEmail queues the ticket PDF email for an order's tickets
+func (s *QueuedEmailService) QueueTicketPDFEmail(ctx context.Context, order *entities.Order, tickets []*entities.Ticket) error {
+	return s.queueTickets(ctx, TopicTicketPDFEmail, order, tickets)
+}
+
+func (s *QueuedEmailService) queueTickets(ctx context.Context, topic string, order *entities.Order, tickets []*entities.Ticket) error {
+	payload := ticketEmailPayload{
+		OrderID:   order.ID,
+		TicketIDs: make([]uuid.UUID, len(tickets)),
+		Email:     order.CustomerEmail,
+	}
+	for i, ticket := range tickets {
+		payload.TicketIDs[i] = ticket.ID
+	}
+	return s.queue(ctx, topic, "order", order.ID, payload)
+}
+
+// SendOrderConfirmation queues the order confirmation email
+func (s *QueuedEmailService) SendOrderConfirmation(ctx context.Context, order *entities.Order) error {
+	return s.queue(ctx, TopicOrderConfirmationEmail, "order", order.ID, orderEmailPayload{OrderID: order.ID})
+}
+
+// SendWelcomeEmail queues the welcome email for a new user
+func (s *QueuedEmailService) SendWelcomeEmail(ctx context.Context, user *entities.User) error {
+	return s.queue(ctx, TopicWelcomeEmail, "user", user.ID, userEmailPayload{UserID: user.ID})
+}
+
+// SendPasswordResetEmail queues a password reset email. The token is hidden
+// from the admin outbox views and dropped from the payload once delivered.
+func (s *QueuedEmailService) SendPasswordResetEmail(ctx context.Context, email, resetToken string) error {
+	message, err := newMessage(TopicPasswordResetEmail, passwordResetPayload{Email: email, ResetToken: resetToken})
+	if err != nil {
+		return err
+	}
+	return s.outboxRepo.Create(ctx, message)
+}
+
+// SendEventChangeEmail queues the cancellation or postponement email for one holder
+func (s *QueuedEmailService) SendEventChangeEmail(ctx context.Context, change *entities.EventChange, event *entities.Event, recipient *entities.EventChangeRecipient) error {
+	return s.queue(ctx, TopicEventChangeEmail, "event_change", change.ID, eventChangeEmailPayload{
+		ChangeID:    change.ID,
+		EventID:     event.ID,
+		RecipientID: recipient.ID,
+	})
+}
+
+// SendEventCampaignEmail queues a reminder or follow-up email for one holder
+func (s *QueuedEmailService) SendEventCampaignEmail(ctx context.Context, campaign *entities.EventCampaign, event *entities.Event, delivery *entities.CampaignDelivery) error {
+	return s.queue(ctx, TopicEventCampaignEmail, "event_campaign", campaign.ID, eventCampaignEmailPayload{
+		CampaignID: campaign.ID,
+		EventID:    event.ID,
+		DeliveryID: delivery.ID,
+	})
+}
+
+// SendCheckoutRecoveryEmail queues the message inviting a customer back to an
+// abandoned order. The order lines are loaded again at delivery.
+func (s *QueuedEmailService) SendCheckoutRecoveryEmail(ctx context.Context, recovery *entities.CheckoutRecovery, event *entities.Event, orderLines []*entities.OrderLine) error {
+	return s.queue(ctx, TopicCheckoutRecoveryEmail, "checkout_recovery", recovery.ID, checkoutRecoveryEmailPayload{
+		RecoveryID: recovery.ID,
+	})
+}
+
+// SendMarketingEmail queues a marketing campaign email for one audience member
+func (s *QueuedEmailService) SendMarketingEmail(ctx context.Context, campaign *entities.MarketingCampaign, delivery *entities.MarketingDelivery) error {
+	return s.queue(ctx, TopicMarketingEmail, "marketing_campaign", campaign.ID, marketingEmailPayload{
+		CampaignID: campaign.ID,
+		DeliveryID: delivery.ID,
+	})
+}
+
+func (s *QueuedEmailService) queue(ctx context.Context, topic, aggregateType string, aggregateID uuid.UUID, payload interface{}) error {
+	message, err := newMessage(topic, payload)
+	if err != nil {
+		return err
+	}
+	message.SetAggregate(aggregateType, aggregateID)
+	return s.outboxRepo.Create(ctx, message)
+}
+
+func newMessage(topic string, payload interface{}) (*entities.OutboxMessage, error) {
+	encoded, err := encodePayload(payload)
+	if err != nil {
+		return nil, err
+	}
+	return entities.NewOutboxMessage(topic, encoded), nil
+}
@@ -0,0 +1,297 @@
+package outbox
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+)
+
+// EmailDelivery sends queued emails through the real email service, loading
+// what each email needs from the repositories at delivery time
+type EmailDelivery struct {
+	emailService    services.EmailService
+	orderRepo       repositories.OrderRepository
+	orderLineRepo   repositories.OrderLineRepository
+	ticketRepo      repositories.TicketRepository
+	eventRepo       repositories.EventRepository
+	userRepo        repositories.UserRepository
+	eventChangeRepo repositories.EventChangeRepository
+	campaignRepo    repositories.EventCampaignRepository
+	recoveryRepo    repositories.CheckoutRecoveryRepository
+	marketingRepo   repositories.MarketingCampaignRepository
+	walletPasses    services.WalletPassService
+}
+
+// NewEmailDelivery creates the email delivery handlers. walletPasses may be nil.
+func NewEmailDelivery(
+	emailService services.EmailService,
+	orderRepo repositories.OrderRepository,
+	orderLineRepo repositories.OrderLineRepository,
+	ticketRepo repositories.TicketRepository,
+	eventRepo repositories.EventRepository,
+	userRepo repositories.UserRepository,
+	eventChangeRepo repositories.EventChangeRepository,
+	campaignRepo repositories.EventCampaignRepository,
+	recoveryRepo repositories.CheckoutRecoveryRepository,
+	marketingRepo repositories.MarketingCampaignRepository,
+	walletPasses services.WalletPassService,
+) *EmailDelivery {
+	return &EmailDelivery{
+		emailService:    emailService,
+		orderRepo:       orderRepo,
+		orderLineRepo:   orderLineRepo,
+		ticketRepo:      ticketRepo,
+		eventRepo:       eventRepo,
+		userRepo:        userRepo,
+		eventChangeRepo: eventChangeRepo,
+		campaignRepo:    campaignRepo,
+		recoveryRepo:    recoveryRepo,
+		marketingRepo:   marketingRepo,
+		walletPasses:    walletPasses,
+	}
+}
+
+// Register registers a handler for every email topic
+func (d *EmailDelivery) Register(dispatcher *Dispatcher) {
+	dispatcher.Handle(TopicTicketEmail, d.deliverTicketEmail)
+	dispatcher.Handle(TopicTicketPDFEmail, d.deliverTicketPDFEmail)
+	dispatcher.Handle(TopicOrderConfirmationEmail, d.deliverOrderConfirmation)
+	dispatcher.Handle(TopicWelcomeEmail, d.deliverWelcomeEmail)
+	dispatcher.Handle(TopicPasswordResetEmail, d.deliverPasswordReset)
+	dispatcher.Handle(TopicEventChangeEmail, d.deliverEventChangeEmail)
+	dispatcher.Handle(TopicEventCampaignEmail, d.deliverEventCampaignEmail)
+	dispatcher.Handle(TopicCheckoutRecoveryEmail, d.deliverCheckoutRecoveryEmail)
+	dispatcher.Handle(TopicMarketingEmail, d.deliverMarketingEmail)
+}
+
+func (d *EmailDelivery) deliverTicketEmail(ctx context.Context, message *entities.OutboxMessage) error {
+	order, tickets, err := d.loadTicketEmail(ctx, message)
+	if err != nil || order == nil {
+		return err
+	}
+	return d.emailService.SendTicketEmail(d.orderMessageContext(ctx, order, nil), order, tickets)
+}
+
+func (d *EmailDelivery) deliverTicketPDFEmail(ctx context.Context, message *entities.OutboxMessage) error {
+	order, tickets, err := d.loadTicketEmail(ctx, message)
+	if err != nil || order == nil {
+		return err
+	}
+
+	eventID, err := uuid.Parse(order.EventID)
+	if err != nil {
+		return fmt.Errorf("invalid event ID for order %s: %w", order.Code, err)
+	}
+	event, err := d.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch event for order %s: %w", order.Code, err)
+	}
+
+	orderLines, err := d.orderLineRepo.GetByOrderID(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch order lines for order %s: %w", order.Code, err)
+	}
+
+	var walletLinks []services.WalletLinks
+	if d.walletPasses != nil {
+		walletLinks = d.walletPasses.GetWalletLinks(ctx, tickets)
+	}
+
+	return d.emailService.SendTicketPDFEmail(d.orderMessageContext(ctx, order, event), order, tickets, orderLines, event, walletLinks)
+}
+
+// loadTicketEmail loads the order and the queued tickets, addressed to the
+// email recorded when the message was queued. A nil order means there is
+// nobody to send to.
+func (d *EmailDelivery) loadTicketEmail(ctx context.Context, message *entities.OutboxMessage) (*entities.Order, []*entities.Ticket, error) {
+	var payload ticketEmailPayload
+	if err := decodePayload(message, &payload); err != nil {
+		return nil, nil, err
+	}
+	if payload.Email == "" {
+		return nil, nil, nil
+	}
+
+	order, err := d.orderRepo.GetByID(ctx, payload.OrderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch order %s: %w", payload.OrderID, err)
+	}
+	order.CustomerEmail = payload.Email
+
+	orderTickets, err := d.ticketRepo.GetByOrder(ctx, order.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch tickets for order %s: %w", order.Code, err)
+	}
+
+	tickets := orderTickets
+	if len(payload.TicketIDs) > 0 {
+		wanted := make(map[uuid.UUID]bool, len(payload.TicketIDs))
+		for _, id := range payload.TicketIDs {
+			wanted[id] = true
+		}
+		tickets = make([]*entities.Ticket, 0, len(payload.TicketIDs))
+		for _, ticket := range orderTickets {
+			if wanted[ticket.ID] {
+				tickets = append(tickets, ticket)
+			}
+		}
+	}
+	if len(tickets) == 0 {
+		return nil, nil, fmt.Errorf("no tickets found for order %s", order.Code)
+	}
+
+	return order, tickets, nil
+}
+
+func (d *EmailDelivery) deliverOrderConfirmation(ctx context.Context, message *entities.OutboxMessage) error {
+	var payload orderEmailPayload
+	if err := decodePayload(message, &payload); err != nil {
+		return err
+	}
+
+	order, err := d.orderRepo.GetByID(ctx, payload.OrderID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch order %s: %w", payload.OrderID, err)
+	}
+	if order.CustomerEmail == "" {
+		return nil
+	}
+
+	return d.emailService.SendOrderConfirmation(d.orderMessageContext(ctx, order, nil), order)
+}
+
+// orderMessageContext tells the email service who an order email is for: the
+// buyer, for their locale, and the event's organizer, for their templates and
+// branding. event is loaded when not given; failing to load it only loses the
+// organizer's branding.
+func (d *EmailDelivery) orderMessageContext(ctx context.Context, order *entities.Order, event *entities.Event) context.Context {
+	messageContext := services.MessageContext{UserID: order.UserID}
+	if event == nil {
+		if eventID, err := uuid.Parse(order.EventID); err == nil {
+			event, _ = d.eventRepo.GetByID(ctx, eventID)
+		}
+	}
+	if event != nil {
+		messageContext.OrganizerID = event.OrganizerID
+	}
+	return services.WithMessageContext(ctx, messageContext)
+}
+
+func (d *EmailDelivery) deliverWelcomeEmail(ctx context.Context, message *entities.OutboxMessage) error {
+	var payload userEmailPayload
+	if err := decodePayload(message, &payload); err != nil {
+		return err
+	}
+
+	user, err := d.userRepo.GetByID(ctx, payload.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch user %s: %w", payload.UserID, err)
+	}
+	if user.Email == nil || *user.Email == "" {
+		return nil
+	}
+
+	ctx = services.WithMessageContext(ctx, services.MessageContext{UserID: &user.ID})
+	return d.emailService.SendWelcomeEmail(ctx, user)
+}
+
+func (d *EmailDelivery) deliverPasswordReset(ctx context.Context, message *entities.OutboxMessage) error {
+	var payload passwordResetPayload
+	if err := decodePayload(message, &payload); err != nil {
+		return err
+	}
+
+	// Reset emails are only addressed by email; find the account for its locale
+	if user, err := d.userRepo.GetByEmail(ctx, payload.Email); err == nil {
+		ctx = services.WithMessageContext(ctx, services.MessageContext{UserID: &user.ID})
+	}
+	return d.emailService.SendPasswordResetEmail(ctx, payload.Email, payload.ResetToken)
+}
+
+func (d *EmailDelivery) deliverEventChangeEmail(ctx context.Context, message *entities.OutboxMessage) error {
+	var payload eventChangeEmailPayload
+	if err := decodePayload(message, &payload); err != nil {
+		return err
+	}
+
+	change, err := d.eventChangeRepo.GetByID(ctx, payload.ChangeID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch event change %s: %w", payload.ChangeID, err)
+	}
+	event, err := d.eventRepo.GetByID(ctx, payload.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch event %s: %w", payload.EventID, err)
+	}
+	recipient, err := d.eventChangeRepo.GetRecipient(ctx, payload.RecipientID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch event change recipient %s: %w", payload.RecipientID, err)
+	}
+
+	return d.emailService.SendEventChangeEmail(ctx, change, event, recipient)
+}
+
+func (d *EmailDelivery) deliverEventCampaignEmail(ctx context.Context, message *entities.OutboxMessage) error {
+	var payload eventCampaignEmailPayload
+	if err := decodePayload(message, &payload); err != nil {
+		return err
+	}
+
+	campaign, err := d.campaignRepo.GetByID(ctx, payload.CampaignID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch event campaign %s: %w", payload.CampaignID, err)
+	}
+	event, err := d.eventRepo.GetByID(ctx, payload.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch event %s: %w", payload.EventID, err)
+	}
+	delivery, err := d.campaignRepo.GetDelivery(ctx, payload.DeliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch campaign delivery %s: %w", payload.DeliveryID, err)
+	}
+
+	return d.emailService.SendEventCampaignEmail(ctx, campaign, event, delivery)
+}
+
+func (d *EmailDelivery) deliverCheckoutRecoveryEmail(ctx context.Context, message *entities.OutboxMessage) error {
+	var payload checkoutRecoveryEmailPayload
+	if err := decodePayload(message, &payload); err != nil {
+		return err
+	}
+
+	recovery, err := d.recoveryRepo.GetByID(ctx, payload.RecoveryID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch checkout recovery %s: %w", payload.RecoveryID, err)
+	}
+	event, err := d.eventRepo.GetByID(ctx, recovery.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch event %s: %w", recovery.EventID, err)
+	}
+	orderLines, err := d.orderLineRepo.GetByOrderID(ctx, recovery.OrderID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch order lines for order %s: %w", recovery.OrderID, err)
+	}
+
+	return d.emailService.SendCheckoutRecoveryEmail(ctx, recovery, event, orderLines)
+}
+
+func (d *EmailDelivery) deliverMarketingEmail(ctx context.Context, message *entities.OutboxMessage) error {
+	var payload marketingEmailPayload
+	if err := decodePayload(message, &payload); err != nil {
+		return err
+	}
+
+	campaign, err := d.marketingRepo.GetByID(ctx, payload.CampaignID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch marketing campaign %s: %w", payload.CampaignID, err)
+	}
+	delivery, err := d.marketingRepo.GetDelivery(ctx, payload.DeliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch marketing delivery %s: %w", payload.DeliveryID, err)
+	}
+
+	return d.emailService.SendMarketingEmail(ctx, campaign, delivery)
+}
@@ -0,0 +1,146 @@
+package outbox
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// secretPayloadKeys are payload fields never shown to admins. They are only
+// needed to send the message, so the dispatcher drops them once it is delivered.
+var secretPayloadKeys = []string{"reset_token"}
+
+// OutboxService handles inspecting and retrying outbox messages
+type OutboxService struct {
+	outboxRepo repositories.OutboxRepository
+}
+
+// NewOutboxService creates a new outbox service
+func NewOutboxService(outboxRepo repositories.OutboxRepository) *OutboxService {
+	return &OutboxService{
+		outboxRepo: outboxRepo,
+	}
+}
+
+// OutboxStats counts messages per status
+type OutboxStats struct {
+	Pending   int `json:"pending"`
+	Delivered int `json:"delivered"`
+	Dead      int `json:"dead"`
+}
+
+// ListMessages lists outbox messages, newest first
+func (s *OutboxService) ListMessages(ctx context.Context, filter repositories.OutboxFilter) ([]*entities.OutboxMessage, *repositories.PaginationResult, error) {
+	messages, pagination, err := s.outboxRepo.List(ctx, filter)
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, message := range messages {
+		redact(message)
+	}
+	return messages, pagination, nil
+}
+
+// GetMessage retrieves an outbox message
+func (s *OutboxService) GetMessage(ctx context.Context, id uuid.UUID) (*entities.OutboxMessage, error) {
+	message, err := s.getMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	redact(message)
+	return message, nil
+}
+
+// GetStats counts messages per status
+func (s *OutboxService) GetStats(ctx context.Context) (*OutboxStats, error) {
+	counts, err := s.outboxRepo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &OutboxStats{
+		Pending:   counts[entities.OutboxStatusPending],
+		Delivered: counts[entities.OutboxStatusDelivered],
+		Dead:      counts[entities.OutboxStatusDead],
+	}, nil
+}
+
+// RetryMessage queues a dead or waiting message for an immediate attempt
+func (s *OutboxService) RetryMessage(ctx context.Context, id uuid.UUID) (*entities.OutboxMessage, error) {
+	message, err := s.getMessage(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := message.Retry(); err != nil {
+		return nil, err
+	}
+	if err := s.outboxRepo.Update(ctx, message); err != nil {
+		return nil, err
+	}
+
+	redact(message)
+	return message, nil
+}
+
+// RetryDead queues every dead message on a topic, or on all topics when topic
+// is empty, and returns how many were queued
+func (s *OutboxService) RetryDead(ctx context.Context, topic string) (int, error) {
+	dead := entities.OutboxStatusDead
+	filter := repositories.OutboxFilter{
+		BaseFilter: repositories.BaseFilter{Page: 1, Limit: 100},
+		Status:     &dead,
+		Topic:      topic,
+	}
+
+	retried := 0
+	for {
+		// Retried messages leave the dead list, so the first page is always the next batch
+		messages, _, err := s.outboxRepo.List(ctx, filter)
+		if err != nil {
+			return retried, err
+		}
+		if len(messages) == 0 {
+			return retried, nil
+		}
+
+		for _, message := range messages {
+			if err := message.Retry(); err != nil {
+				return retried, err
+			}
+			if err := s.outboxRepo.Update(ctx, message); err != nil {
+				return retried, err
+			}
+			retried++
+		}
+	}
+}
+
+func (s *OutboxService) getMessage(ctx context.Context, id uuid.UUID) (*entities.OutboxMessage, error) {
+	message, err := s.outboxRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == entities.ErrOutboxMessageNotFound {
+			return nil, entities.NewNotFoundError("outbox_message", "outbox message not found")
+		}
+		return nil, err
+	}
+	return message, nil
+}
+
+// redact masks secrets in a message shown to admins
+func redact(message *entities.OutboxMessage) {
+	for _, key := range secretPayloadKeys {
+		if _, ok := message.Payload[key]; ok {
+			message.Payload[key] = "[redacted]"
+		}
+	}
+}
+
+// dropSecrets removes secrets from a delivered message before it is stored,
+// so they do not outlive the delivery
+func dropSecrets(message *entities.OutboxMessage) {
+	for _, key := range secretPayloadKeys {
+		delete(message.Payload, key)
+	}
+}
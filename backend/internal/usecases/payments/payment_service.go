@@ -10,8 +10,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/uduxpass/backend/internal/domain/entities"
 	"github.com/uduxpass/backend/internal/domain/repositories"
-	"github.com/uduxpass/backend/internal/domain/services"
 	"github.com/uduxpass/backend/internal/infrastructure/payments"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/notifications"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
 	"github.com/uduxpass/backend/pkg/qrcode"
 )
 
@@ -27,7 +29,8 @@ type PaymentService struct {
 	paystackProvider  payments.PaystackProvider
 	unitOfWork        repositories.UnitOfWork
 	qrGenerator       *qrcode.Generator
-	emailService      services.EmailService
+	outboxRepo        repositories.OutboxRepository
+	eventBus          *eventbus.Bus
 	jwtSecret         []byte
 }
 
@@ -42,7 +45,8 @@ func NewPaymentService(
 	momoProvider payments.MoMoProvider,
 	paystackProvider payments.PaystackProvider,
 	unitOfWork repositories.UnitOfWork,
-	emailService services.EmailService,
+	outboxRepo repositories.OutboxRepository,
+	eventBus *eventbus.Bus,
 	jwtSecret string,
 ) *PaymentService {
 	return &PaymentService{
@@ -56,7 +60,8 @@ func NewPaymentService(
 		paystackProvider:  paystackProvider,
 		unitOfWork:        unitOfWork,
 		qrGenerator:       qrcode.NewGenerator(),
-		emailService:      emailService,
+		outboxRepo:        outboxRepo,
+		eventBus:          eventBus,
 		jwtSecret:         []byte(jwtSecret),
 	}
 }
@@ -100,6 +105,11 @@ func (s *PaymentService) InitiatePayment(ctx context.Context, req *InitiatePayme
 		return nil, entities.NewBusinessRuleError("payment_not_allowed", "order cannot be paid (expired or already paid)", nil)
 	}
 
+	// Verify the chosen provider can settle in the order currency
+	if err := s.checkProviderCurrency(req.PaymentMethod, order.Currency); err != nil {
+		return nil, err
+	}
+
 	// Start transaction
 	tx, err := s.unitOfWork.Begin(ctx)
 	if err != nil {
@@ -146,12 +156,35 @@ func (s *PaymentService) InitiatePayment(ctx context.Context, req *InitiatePayme
 		// Update payment status to failed
 		payment.MarkFailed()
 		s.paymentRepo.Update(ctx, payment)
+		if publishErr := s.eventBus.Publish(ctx, entities.NewPaymentFailed(payment, order, err)); publishErr != nil {
+			fmt.Printf("Warning: failed to publish payment failure of order %s: %v\n", order.Code, publishErr)
+		}
 		return nil, fmt.Errorf("failed to initiate payment: %w", err)
 	}
 
 	return response, nil
 }
 
+// checkProviderCurrency rejects payment methods whose provider cannot charge in the currency
+func (s *PaymentService) checkProviderCurrency(method entities.PaymentMethod, currency string) error {
+	var supported bool
+	switch method {
+	case entities.PaymentMethodMoMo:
+		supported = s.momoProvider.SupportsCurrency(currency)
+	case entities.PaymentMethodPaystack:
+		supported = s.paystackProvider.SupportsCurrency(currency)
+	default:
+		return nil
+	}
+	if !supported {
+		return entities.NewBusinessRuleError("unsupported_currency", fmt.Sprintf("%s payments are not available for %s orders", method, currency), map[string]interface{}{
+			"payment_method": method,
+			"currency":       currency,
+		})
+	}
+	return nil
+}
+
 // initiateMoMoPayment initiates MoMo payment
 func (s *PaymentService) initiateMoMoPayment(ctx context.Context, payment *entities.Payment, order *entities.Order, customerInfo PaymentCustomerInfo) (*InitiatePaymentResponse, error) {
 	// Convert to infrastructure type
@@ -200,7 +233,7 @@ func (s *PaymentService) initiateMoMoPayment(ctx context.Context, payment *entit
 func (s *PaymentService) initiatePaystackPayment(ctx context.Context, payment *entities.Payment, order *entities.Order, customerInfo PaymentCustomerInfo, callbackURL string) (*InitiatePaymentResponse, error) {
 	// Convert to infrastructure type
 	paystackReq := payments.PaystackPaymentRequest{
-		Amount:      payment.Amount * 100, // Paystack expects amount in kobo
+		Amount:      payment.Amount, // provider converts to the currency subunit
 		Currency:    payment.Currency,
 		Email:       customerInfo.Email,
 		Reference:   payment.ID.String(),
@@ -318,8 +351,8 @@ func (s *PaymentService) VerifyPayment(ctx context.Context, req *VerifyPaymentRe
 			return nil, fmt.Errorf("failed to update order: %w", err)
 		}
 
-		// Generate tickets
-		if err := s.generateTickets(tx.Context(), tx, order); err != nil {
+		// Generate tickets; the ticket delivery subscriber sends them once committed
+		if _, err := s.IssueTickets(tx.Context(), tx, order, true); err != nil {
 			return nil, fmt.Errorf("failed to generate tickets: %w", err)
 		}
 		ticketsGenerated = true
@@ -400,26 +433,32 @@ type ticketJWTClaims struct {
 	jwt.RegisteredClaims
 }
 
-// generateTickets generates tickets for a paid order.
+// IssueTickets creates the tickets for a paid order within the given transaction.
 // For each order line it:
 //  1. Looks up the ticket tier to get the event ID
 //  2. Creates N tickets (N = line.Quantity) with human-readable serial numbers
 //  3. Signs each ticket's QR code data as a JWT (HS256, no expiry — tickets are permanent)
 //  4. Atomically increments the tier's sold count
-func (s *PaymentService) generateTickets(ctx context.Context, tx repositories.Transaction, order *entities.Order) error {
+//
+// Every caller issues tickets right after marking the order paid, so this is
+// also where OrderPaid is published, followed by one TicketIssued per ticket.
+// With deliverTickets, the ticket delivery subscriber sends the tickets to the
+// customer once the transaction commits.
+func (s *PaymentService) IssueTickets(ctx context.Context, tx repositories.Transaction, order *entities.Order, deliverTickets bool) ([]*entities.Ticket, error) {
 	// Get order lines
 	orderLines, err := tx.OrderLines().GetByOrder(ctx, order.ID)
 	if err != nil {
-		return fmt.Errorf("failed to get order lines: %w", err)
+		return nil, fmt.Errorf("failed to get order lines: %w", err)
 	}
 
 	var allTickets []*entities.Ticket
+	events := []entities.DomainEvent{entities.NewOrderPaid(order, deliverTickets)}
 
 	for _, line := range orderLines {
 		// Fetch the ticket tier to get the event ID (needed for JWT claims)
 		tier, err := tx.TicketTiers().GetByID(ctx, line.TicketTierID)
 		if err != nil {
-			return fmt.Errorf("failed to get ticket tier %s: %w", line.TicketTierID, err)
+			return nil, fmt.Errorf("failed to get ticket tier %s: %w", line.TicketTierID, err)
 		}
 
 		// Derive a short event code from the event slug or ID for the serial number
@@ -435,7 +474,7 @@ func (s *PaymentService) generateTickets(ctx context.Context, tx repositories.Tr
 			// QR code data is a signed JWT — scanner verifies signature before any DB lookup
 			qrCodeData, err := s.signTicketJWT(ticketID, tier.EventID, serialNumber, line.ID)
 			if err != nil {
-				return fmt.Errorf("failed to sign ticket JWT for line %s ticket %d: %w", line.ID, i+1, err)
+				return nil, fmt.Errorf("failed to sign ticket JWT for line %s ticket %d: %w", line.ID, i+1, err)
 			}
 
 			ticket := entities.NewTicket(line.ID, serialNumber, qrCodeData)
@@ -451,56 +490,94 @@ func (s *PaymentService) generateTickets(ctx context.Context, tx repositories.Tr
 			}
 
 			if err := ticket.Validate(); err != nil {
-				return fmt.Errorf("invalid ticket %s: %w", serialNumber, err)
+				return nil, fmt.Errorf("invalid ticket %s: %w", serialNumber, err)
 			}
 
 			lineTickets = append(lineTickets, ticket)
+			events = append(events, entities.NewTicketIssued(ticket, order.ID, tier.ID, tier.EventID))
 		}
 
 		allTickets = append(allTickets, lineTickets...)
 
 		// Atomically increment the sold count on the tier — prevents race conditions
 		if err := tx.TicketTiers().IncrementSold(ctx, line.TicketTierID, line.Quantity); err != nil {
-			return fmt.Errorf("failed to increment sold count for tier %s: %w", line.TicketTierID, err)
+			return nil, fmt.Errorf("failed to increment sold count for tier %s: %w", line.TicketTierID, err)
 		}
 	}
 
 	// Batch insert all tickets in a single query
 	if len(allTickets) > 0 {
 		if err := tx.Tickets().CreateBatch(ctx, allTickets); err != nil {
-			return fmt.Errorf("failed to create tickets: %w", err)
+			return nil, fmt.Errorf("failed to create tickets: %w", err)
 		}
 	}
 
-	// Send ticket PDF email to customer (async — don't fail if email fails)
-	// IMPORTANT: Use s.eventRepo and s.orderLineRepo (not the transaction) to avoid
-	// using a closed/committed transaction connection in the goroutine.
-	ticketsCopy := make([]*entities.Ticket, len(allTickets))
-	copy(ticketsCopy, allTickets)
-	go func() {
-		eventUUID, err := uuid.Parse(order.EventID)
-		if err != nil {
-			fmt.Printf("Warning: invalid event ID for order %s: %v\n", order.Code, err)
-			return
-		}
+	if err := s.eventBus.PublishTx(ctx, tx, events...); err != nil {
+		return nil, err
+	}
 
-		event, err := s.eventRepo.GetByID(context.Background(), eventUUID)
-		if err != nil {
-			fmt.Printf("Warning: failed to fetch event for order %s: %v\n", order.Code, err)
-			return
-		}
+	return allTickets, nil
+}
 
-		orderLinesForEmail, err := s.orderLineRepo.GetByOrderID(context.Background(), order.ID)
-		if err != nil {
-			fmt.Printf("Warning: failed to fetch order lines for order %s: %v\n", order.Code, err)
-			return
-		}
+// Subscribe sends the tickets of every paid order that asks for delivery.
+// Deliveries go through the outbox, so a delivery that fails is retried.
+func (s *PaymentService) Subscribe(bus *eventbus.Bus) {
+	bus.SubscribeAsync("ticket_delivery", entities.DomainEventOrderPaid, s.handleOrderPaid)
+}
+
+func (s *PaymentService) handleOrderPaid(ctx context.Context, event entities.DomainEvent) error {
+	paid, ok := event.(*entities.OrderPaid)
+	if !ok {
+		return fmt.Errorf("unexpected %T for %s", event, entities.DomainEventOrderPaid)
+	}
+	if !paid.DeliverTickets {
+		return nil
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, paid.OrderID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch order %s: %w", paid.OrderID, err)
+	}
+	tickets, err := s.ticketRepo.GetByOrder(ctx, order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to fetch tickets for order %s: %w", order.Code, err)
+	}
+	return QueueTicketEmail(ctx, s.outboxRepo, order, tickets)
+}
+
+// SendTicketEmail queues the ticket PDF email for an order outside any
+// transaction, e.g. when resending tickets that already exist.
+func (s *PaymentService) SendTicketEmail(ctx context.Context, order *entities.Order, tickets []*entities.Ticket) error {
+	return QueueTicketEmail(ctx, s.outboxRepo, order, tickets)
+}
 
-		if err := s.emailService.SendTicketPDFEmail(context.Background(), order, ticketsCopy, orderLinesForEmail, event); err != nil {
-			fmt.Printf("Warning: failed to send ticket PDF email for order %s: %v\n", order.Code, err)
+// QueueTicketEmail queues the ticket PDF email for an order in outboxRepo.
+// Customers with an account and a phone number get their tickets on WhatsApp
+// when they opted in. Orders without a customer email get a text with a
+// short ticket retrieval link instead, when they have a phone number.
+func QueueTicketEmail(ctx context.Context, outboxRepo repositories.OutboxRepository, order *entities.Order, tickets []*entities.Ticket) error {
+	if len(tickets) == 0 {
+		return nil
+	}
+	if order.UserID != nil && order.CustomerPhone != "" {
+		if err := notifications.QueueTicketWhatsApp(ctx, outboxRepo, order, tickets); err != nil {
+			return fmt.Errorf("failed to queue ticket delivery for order %s: %w", order.Code, err)
+		}
+		return nil
+	}
+	if order.CustomerEmail == "" {
+		if order.CustomerPhone == "" {
+			return nil
 		}
-	}()
+		if err := notifications.QueueTicketLinkSMS(ctx, outboxRepo, order); err != nil {
+			return fmt.Errorf("failed to queue ticket link SMS for order %s: %w", order.Code, err)
+		}
+		return nil
+	}
 
+	if err := outbox.NewQueuedEmailService(outboxRepo).QueueTicketPDFEmail(ctx, order, tickets); err != nil {
+		return fmt.Errorf("failed to queue ticket email for order %s: %w", order.Code, err)
+	}
 	return nil
 }
 
@@ -681,13 +758,11 @@ func (s *PaymentService) ConfirmPaymentManually(ctx context.Context, req *Confir
 		return nil, fmt.Errorf("failed to update order: %w", err)
 	}
 
-	// Generate tickets
-	if err := s.generateTickets(tx.Context(), tx, order); err != nil {
+	// Generate tickets; the ticket delivery subscriber sends them once committed
+	tickets, err := s.IssueTickets(tx.Context(), tx, order, true)
+	if err != nil {
 		return nil, fmt.Errorf("failed to generate tickets: %w", err)
 	}
-
-	// Count generated tickets
-	tickets, _ := tx.Tickets().GetByOrder(tx.Context(), order.ID)
 	ticketCount := len(tickets)
 
 	// Commit
@@ -0,0 +1,250 @@
+package payments
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/infrastructure/payments"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+type fakeOrders struct {
+	repositories.OrderRepository
+	order *entities.Order
+}
+
+func (f *fakeOrders) GetByID(ctx context.Context, id uuid.UUID) (*entities.Order, error) {
+	if id != f.order.ID {
+		return nil, entities.ErrOrderNotFound
+	}
+	return f.order, nil
+}
+
+type fakeOrderLines struct {
+	repositories.OrderLineRepository
+	lines []*entities.OrderLine
+}
+
+func (f *fakeOrderLines) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*entities.OrderLine, error) {
+	return f.lines, nil
+}
+
+type fakeTiers struct {
+	repositories.TicketTierRepository
+	tiers map[uuid.UUID]*entities.TicketTier
+	sold  map[uuid.UUID]int
+}
+
+func (f *fakeTiers) GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
+	return f.tiers[id], nil
+}
+
+func (f *fakeTiers) IncrementSold(ctx context.Context, tierID uuid.UUID, quantity int) error {
+	f.sold[tierID] += quantity
+	return nil
+}
+
+type fakeTickets struct {
+	repositories.TicketRepository
+	tickets []*entities.Ticket
+}
+
+func (f *fakeTickets) CreateBatch(ctx context.Context, tickets []*entities.Ticket) error {
+	f.tickets = append(f.tickets, tickets...)
+	return nil
+}
+
+func (f *fakeTickets) GetByOrder(ctx context.Context, orderID uuid.UUID) ([]*entities.Ticket, error) {
+	return f.tickets, nil
+}
+
+type fakeOutbox struct {
+	repositories.OutboxRepository
+	messages []*entities.OutboxMessage
+}
+
+func (f *fakeOutbox) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	orderLines *fakeOrderLines
+	tiers      *fakeTiers
+	tickets    *fakeTickets
+	outbox     *fakeOutbox
+}
+
+func (tx *fakeTx) OrderLines() repositories.OrderLineRepository   { return tx.orderLines }
+func (tx *fakeTx) TicketTiers() repositories.TicketTierRepository { return tx.tiers }
+func (tx *fakeTx) Tickets() repositories.TicketRepository         { return tx.tickets }
+func (tx *fakeTx) Outbox() repositories.OutboxRepository          { return tx.outbox }
+
+type paymentFixture struct {
+	service  *PaymentService
+	recorded *eventbus.Recorder
+	outbox   *fakeOutbox
+	tx       *fakeTx
+	order    *entities.Order
+	lines    []*entities.OrderLine
+	tickets  *fakeTickets
+}
+
+// newPaymentFixture builds a payment service over fakes, with a paid order
+// for two VIP and one regular ticket. The service subscribes to the bus the
+// way the server wires it, so OrderPaid queues the ticket delivery.
+func newPaymentFixture(t *testing.T) *paymentFixture {
+	t.Helper()
+
+	eventID := uuid.New()
+	regular := entities.NewTicketTier(eventID, "Regular", 10000)
+	vip := entities.NewTicketTier(eventID, "VIP", 50000)
+
+	order := entities.NewOrder(eventID.String(), "ada@example.com")
+	order.CustomerEmail = "ada@example.com"
+	order.TotalAmount = 110000
+	order.Status = entities.OrderStatusPaid
+
+	lines := []*entities.OrderLine{
+		entities.NewOrderLine(order.ID, vip.ID, 2, vip.Price),
+		entities.NewOrderLine(order.ID, regular.ID, 1, regular.Price),
+	}
+
+	tickets := &fakeTickets{}
+	rootOutbox := &fakeOutbox{}
+	tx := &fakeTx{
+		orderLines: &fakeOrderLines{lines: lines},
+		tiers: &fakeTiers{
+			tiers: map[uuid.UUID]*entities.TicketTier{regular.ID: regular, vip.ID: vip},
+			sold:  make(map[uuid.UUID]int),
+		},
+		tickets: tickets,
+		outbox:  &fakeOutbox{},
+	}
+
+	bus := eventbus.NewBus(rootOutbox, outbox.NewDispatcher(rootOutbox))
+	recorded := eventbus.NewRecorder(bus)
+
+	service := NewPaymentService(
+		nil,
+		&fakeOrders{order: order},
+		nil,
+		tickets,
+		nil,
+		nil,
+		payments.MoMoProvider{},
+		payments.PaystackProvider{},
+		nil,
+		rootOutbox,
+		bus,
+		"test-secret",
+	)
+	service.Subscribe(bus)
+
+	return &paymentFixture{
+		service:  service,
+		recorded: recorded,
+		outbox:   rootOutbox,
+		tx:       tx,
+		order:    order,
+		lines:    lines,
+		tickets:  tickets,
+	}
+}
+
+func TestIssueTicketsPublishesOrderPaidThenTicketIssued(t *testing.T) {
+	f := newPaymentFixture(t)
+
+	tickets, err := f.service.IssueTickets(context.Background(), f.tx, f.order, true)
+	if err != nil {
+		t.Fatalf("IssueTickets() error = %v", err)
+	}
+	if len(tickets) != 3 {
+		t.Fatalf("issued %d tickets, want 3", len(tickets))
+	}
+
+	f.recorded.AssertSequence(t,
+		entities.DomainEventOrderPaid,
+		entities.DomainEventTicketIssued,
+		entities.DomainEventTicketIssued,
+		entities.DomainEventTicketIssued,
+	)
+
+	paid := f.recorded.Last(entities.DomainEventOrderPaid).(*entities.OrderPaid)
+	if paid.OrderID != f.order.ID || !paid.DeliverTickets {
+		t.Errorf("OrderPaid = %+v, want order %s with delivery", paid, f.order.ID)
+	}
+	issued := make(map[uuid.UUID]bool)
+	for _, event := range f.recorded.Named(entities.DomainEventTicketIssued) {
+		ticketIssued := event.(*entities.TicketIssued)
+		if ticketIssued.OrderID != f.order.ID {
+			t.Errorf("TicketIssued for order %s, want %s", ticketIssued.OrderID, f.order.ID)
+		}
+		issued[ticketIssued.TicketID] = true
+	}
+	for _, ticket := range tickets {
+		if !issued[ticket.ID] {
+			t.Errorf("no TicketIssued for ticket %s", ticket.SerialNumber)
+		}
+	}
+
+	// Delivery is queued with the tickets, never ahead of the commit
+	if len(f.tx.outbox.messages) != 1 || f.tx.outbox.messages[0].Topic != "event.order.paid.ticket_delivery" {
+		t.Fatalf("expected the ticket delivery queued in the transaction, got %v", topics(f.tx.outbox.messages))
+	}
+	if len(f.outbox.messages) != 0 {
+		t.Errorf("expected nothing queued outside the transaction, got %v", topics(f.outbox.messages))
+	}
+}
+
+func TestOrderPaidSubscriberQueuesTicketEmail(t *testing.T) {
+	f := newPaymentFixture(t)
+
+	if _, err := f.service.IssueTickets(context.Background(), f.tx, f.order, true); err != nil {
+		t.Fatalf("IssueTickets() error = %v", err)
+	}
+	paid := f.recorded.Last(entities.DomainEventOrderPaid)
+
+	if err := f.service.handleOrderPaid(context.Background(), paid); err != nil {
+		t.Fatalf("handleOrderPaid() error = %v", err)
+	}
+	if len(f.outbox.messages) != 1 || f.outbox.messages[0].Topic != outbox.TopicTicketPDFEmail {
+		t.Fatalf("expected the ticket email queued, got %v", topics(f.outbox.messages))
+	}
+}
+
+func TestOrderPaidSubscriberSkipsSilentIssues(t *testing.T) {
+	f := newPaymentFixture(t)
+
+	if _, err := f.service.IssueTickets(context.Background(), f.tx, f.order, false); err != nil {
+		t.Fatalf("IssueTickets() error = %v", err)
+	}
+	f.recorded.AssertPublished(t, entities.DomainEventOrderPaid, 1)
+	paid := f.recorded.Last(entities.DomainEventOrderPaid).(*entities.OrderPaid)
+	if paid.DeliverTickets {
+		t.Fatalf("OrderPaid asks for delivery of silently issued tickets")
+	}
+
+	if err := f.service.handleOrderPaid(context.Background(), paid); err != nil {
+		t.Fatalf("handleOrderPaid() error = %v", err)
+	}
+	if len(f.outbox.messages) != 0 {
+		t.Errorf("expected no delivery, got %v", topics(f.outbox.messages))
+	}
+}
+
+func topics(messages []*entities.OutboxMessage) []string {
+	names := make([]string, len(messages))
+	for i, message := range messages {
+		names[i] = message.Topic
+	}
+	return names
+}
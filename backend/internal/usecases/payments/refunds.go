@@ -0,0 +1,146 @@
+package payments
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/infrastructure/payments"
+)
+
+// RefundOrder refunds a paid order in full through its payment provider, voids
+// its tickets and releases their tier capacity. An order that is already refunded
+// is returned as is, so callers can retry safely. Payments the platform cannot
+// refund itself (mobile money, cash, manual confirmations) are reported as
+// requiring a manual refund.
+func (s *PaymentService) RefundOrder(ctx context.Context, orderID uuid.UUID, reason string) (*entities.Order, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("order", "order not found")
+	}
+	if order.Status == entities.OrderStatusRefunded {
+		return order, nil
+	}
+	if order.Status != entities.OrderStatusPaid && order.Status != entities.OrderStatusConfirmed {
+		return nil, entities.NewBusinessRuleError("refund", "only paid orders can be refunded", map[string]interface{}{
+			"status": order.Status,
+		})
+	}
+	if order.IsComp || order.IsImported || order.TotalAmount <= 0 {
+		return nil, entities.NewBusinessRuleError("refund", "order has no payment to refund", nil)
+	}
+
+	payment, err := s.refundablePayment(ctx, order.ID)
+	if err != nil {
+		return nil, err
+	}
+	if payment == nil {
+		return nil, entities.NewBusinessRuleError("manual_refund_required", "order was not paid through a payment provider and must be refunded manually", map[string]interface{}{
+			"order_code": order.Code,
+		})
+	}
+
+	// A payment already marked refunded means an earlier attempt reached the
+	// provider but stopped before the order was updated
+	if payment.Status == entities.PaymentStatusCompleted {
+		if payment.Provider != entities.PaymentMethodPaystack || payment.ProviderTransactionID == nil {
+			return nil, entities.NewBusinessRuleError("manual_refund_required", "payment provider does not support automatic refunds", map[string]interface{}{
+				"order_code": order.Code,
+				"provider":   payment.Provider,
+			})
+		}
+
+		refund, err := s.paystackProvider.RefundTransaction(ctx, *payment.ProviderTransactionID, 0, payment.Currency)
+		if err != nil {
+			return nil, fmt.Errorf("paystack refund failed for order %s: %w", order.Code, err)
+		}
+		if refund.Status == payments.RefundStatusFailed {
+			return nil, entities.NewBusinessRuleError("refund_failed", "payment provider declined the refund", map[string]interface{}{
+				"order_code": order.Code,
+			})
+		}
+
+		payment.UpdateProviderResponse(map[string]interface{}{
+			"refund_id":     refund.RefundID,
+			"refund_status": refund.Status,
+			"refund_reason": reason,
+			"refunded_at":   time.Now(),
+		})
+		if err := payment.Refund(); err != nil {
+			return nil, err
+		}
+		if err := s.paymentRepo.Update(ctx, payment); err != nil {
+			return nil, fmt.Errorf("failed to update payment: %w", err)
+		}
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := order.Refund(); err != nil {
+		return nil, err
+	}
+	if err := tx.Orders().Update(tx.Context(), order); err != nil {
+		return nil, fmt.Errorf("failed to update order: %w", err)
+	}
+
+	tickets, err := tx.Tickets().GetByOrder(tx.Context(), order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tickets: %w", err)
+	}
+	eventID, _ := uuid.Parse(order.EventID)
+	var events []entities.DomainEvent
+	for _, ticket := range tickets {
+		if ticket.Status != entities.TicketStatusActive && !ticket.IsRedeemed() {
+			continue
+		}
+		if err := tx.Tickets().MarkVoided(tx.Context(), ticket.ID); err != nil {
+			return nil, fmt.Errorf("failed to void ticket %s: %w", ticket.SerialNumber, err)
+		}
+		events = append(events, entities.NewTicketVoided(ticket, eventID, "refund"))
+	}
+
+	orderLines, err := tx.OrderLines().GetByOrder(tx.Context(), order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order lines: %w", err)
+	}
+	for _, line := range orderLines {
+		if err := tx.TicketTiers().DecrementSold(tx.Context(), line.TicketTierID, line.Quantity); err != nil {
+			return nil, fmt.Errorf("failed to release tier capacity: %w", err)
+		}
+	}
+
+	if err := s.eventBus.PublishTx(tx.Context(), tx, events...); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return order, nil
+}
+
+// refundablePayment returns the order's completed or already refunded payment, or nil
+func (s *PaymentService) refundablePayment(ctx context.Context, orderID uuid.UUID) (*entities.Payment, error) {
+	orderPayments, err := s.paymentRepo.GetByOrder(ctx, orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get payments: %w", err)
+	}
+
+	var refunded *entities.Payment
+	for _, payment := range orderPayments {
+		switch payment.Status {
+		case entities.PaymentStatusCompleted:
+			return payment, nil
+		case entities.PaymentStatusRefunded:
+			refunded = payment
+		}
+	}
+	return refunded, nil
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/uduxpass/backend/internal/domain/entities"
 	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
 	pkgjwt "github.com/uduxpass/backend/pkg/jwt"
 )
 
@@ -30,16 +31,18 @@ type ScannerAuthService struct {
 	repoManager     repositories.RepositoryManager
 	jwtService      pkgjwt.Service
 	ticketJWTSecret []byte // same secret used by PaymentService to sign ticket JWTs
+	eventBus        *eventbus.Bus
 }
 
 // NewScannerAuthService creates a new scanner authentication service.
 // jwtSecret is used both for scanner session JWTs and for verifying ticket QR code JWTs.
-func NewScannerAuthService(repoManager repositories.RepositoryManager, jwtSecret string) *ScannerAuthService {
+func NewScannerAuthService(repoManager repositories.RepositoryManager, jwtSecret string, eventBus *eventbus.Bus) *ScannerAuthService {
 	jwtService := pkgjwt.NewJWTService(jwtSecret, 15*time.Minute, 7*24*time.Hour, "uduxpass-scanner")
 	return &ScannerAuthService{
 		repoManager:     repoManager,
 		jwtService:      jwtService,
 		ticketJWTSecret: []byte(jwtSecret),
+		eventBus:        eventBus,
 	}
 }
 
@@ -232,9 +235,9 @@ func (s *ScannerAuthService) EndSession(ctx context.Context, sessionID uuid.UUID
 //  3. Verify the event_id in the JWT matches the scanner's active session event
 //  4. Look up the ticket in the DB by ID
 //  5. Check ticket status: active → valid; redeemed → duplicate; voided/other → invalid
-//  6. On valid scan: atomically mark the ticket as redeemed in the DB
-//  7. Record the validation event in ticket_validations
-//  8. Update session statistics
+//  6. On valid scan: mark the ticket as redeemed and publish TicketRedeemed in
+//     one transaction; the session subscriber then records the validation,
+//     updates the session statistics and writes the audit trail
 func (s *ScannerAuthService) ValidateTicket(ctx context.Context, scannerID, sessionID, eventID uuid.UUID, ticketCode string, notes *string) (*entities.TicketValidationResponse, error) {
 	response := &entities.TicketValidationResponse{
 		ValidationTime: time.Now(),
@@ -335,23 +338,25 @@ func (s *ScannerAuthService) ValidateTicket(ctx context.Context, scannerID, sess
 		return nil, fmt.Errorf("failed to redeem ticket %s: %w", ticketID, err)
 	}
 
-	if err := s.repoManager.Tickets().MarkRedeemed(ctx, ticketID, redeemedBy); err != nil {
-		return nil, fmt.Errorf("failed to persist ticket redemption for %s: %w", ticketID, err)
+	tx, err := s.repoManager.UnitOfWork().Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	// --- Step 7: Record the validation event ---
-	s.recordValidationEvent(ctx, ticketID, scannerID, sessionID, "valid", notes)
+	if err := tx.Tickets().MarkRedeemed(tx.Context(), ticketID, redeemedBy); err != nil {
+		return nil, fmt.Errorf("failed to persist ticket redemption for %s: %w", ticketID, err)
+	}
 
-	// --- Step 8: Update session statistics ---
-	s.repoManager.ScannerUsers().UpdateSessionStats(ctx, sessionID, 1, 1, 0, 0)
+	redeemed := entities.NewTicketRedeemed(ticket, eventID, redeemedBy, &sessionID)
+	redeemed.Notes = notes
+	if err := s.eventBus.PublishTx(tx.Context(), tx, redeemed); err != nil {
+		return nil, err
+	}
 
-	// Log audit trail
-	ticketResourceType := "ticket"
-	s.logActivity(ctx, scannerID, "ticket_validation", &sessionID, &ticketResourceType, &ticketID, map[string]interface{}{
-		"validation_result": "valid",
-		"serial_number":     claims.SerialNumber,
-		"event_id":          eventID,
-	})
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
 
 	// Build success response
 	response.Success = true
@@ -364,6 +369,60 @@ func (s *ScannerAuthService) ValidateTicket(ctx context.Context, scannerID, sess
 	return response, nil
 }
 
+// Subscribe records every scanned redemption against its scanner session.
+// Deliveries go through the outbox, so a record that fails is retried.
+func (s *ScannerAuthService) Subscribe(bus *eventbus.Bus) {
+	bus.SubscribeAsync("scanner_sessions", entities.DomainEventTicketRedeemed, s.handleTicketRedeemed)
+}
+
+// handleTicketRedeemed records the validation, counts it in the session
+// statistics and writes the audit trail. Check-ins from the guest list have no
+// scanner session and are skipped. A redelivered redemption is already recorded,
+// so it is not counted again.
+func (s *ScannerAuthService) handleTicketRedeemed(ctx context.Context, event entities.DomainEvent) error {
+	redeemed, ok := event.(*entities.TicketRedeemed)
+	if !ok {
+		return fmt.Errorf("unexpected %T for %s", event, entities.DomainEventTicketRedeemed)
+	}
+	if redeemed.SessionID == nil {
+		return nil
+	}
+	scannerID, err := uuid.Parse(redeemed.RedeemedBy)
+	if err != nil {
+		return fmt.Errorf("invalid scanner ID %q for ticket %s: %w", redeemed.RedeemedBy, redeemed.SerialNumber, err)
+	}
+	sessionID := *redeemed.SessionID
+
+	validation := &entities.TicketValidation{
+		ID:                  uuid.New(),
+		TicketID:            redeemed.TicketID,
+		ScannerID:           scannerID,
+		SessionID:           sessionID,
+		ValidationResult:    "valid",
+		ValidationTimestamp: redeemed.OccurredAt,
+		Notes:               redeemed.Notes,
+	}
+	recorded, err := s.repoManager.ScannerUsers().RecordRedemption(ctx, validation)
+	if err != nil {
+		return fmt.Errorf("failed to record validation of ticket %s: %w", redeemed.SerialNumber, err)
+	}
+	if !recorded {
+		return nil
+	}
+
+	if err := s.repoManager.ScannerUsers().UpdateSessionStats(ctx, sessionID, 1, 1, 0, 0); err != nil {
+		return fmt.Errorf("failed to update stats of session %s: %w", sessionID, err)
+	}
+
+	ticketResourceType := "ticket"
+	s.logActivity(ctx, scannerID, "ticket_validation", &sessionID, &ticketResourceType, &redeemed.TicketID, map[string]interface{}{
+		"validation_result": "valid",
+		"serial_number":     redeemed.SerialNumber,
+		"event_id":          redeemed.EventID,
+	})
+	return nil
+}
+
 // verifyTicketJWT parses and verifies the HMAC-SHA256 signature of a ticket QR code JWT.
 // Returns the claims on success, or an error if the token is invalid or tampered.
 func (s *ScannerAuthService) verifyTicketJWT(tokenString string) (*ticketJWTClaims, error) {
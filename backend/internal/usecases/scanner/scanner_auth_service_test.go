@@ -0,0 +1,328 @@
+package scanner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
+)
+
+const testTicketSecret = "test-secret"
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+type fakeTickets struct {
+	repositories.TicketRepository
+	tickets  map[uuid.UUID]*entities.Ticket
+	redeemed []uuid.UUID
+}
+
+func (f *fakeTickets) GetByID(ctx context.Context, id uuid.UUID) (*entities.Ticket, error) {
+	ticket, ok := f.tickets[id]
+	if !ok {
+		return nil, entities.ErrNotFoundError
+	}
+	copied := *ticket
+	return &copied, nil
+}
+
+func (f *fakeTickets) MarkRedeemed(ctx context.Context, ticketID uuid.UUID, redeemedBy string) error {
+	f.redeemed = append(f.redeemed, ticketID)
+	return nil
+}
+
+type sessionStats struct {
+	scans, valid, invalid int
+}
+
+type fakeScannerUsers struct {
+	repositories.ScannerUserRepository
+	validations []*entities.TicketValidation
+	stats       map[uuid.UUID]sessionStats
+	audit       []*entities.ScannerAuditLog
+}
+
+func (f *fakeScannerUsers) ValidateTicket(ctx context.Context, validation *entities.TicketValidation) error {
+	f.validations = append(f.validations, validation)
+	return nil
+}
+
+func (f *fakeScannerUsers) RecordRedemption(ctx context.Context, validation *entities.TicketValidation) (bool, error) {
+	for _, recorded := range f.validations {
+		if recorded.TicketID == validation.TicketID && recorded.SessionID == validation.SessionID && recorded.ValidationResult == "valid" {
+			return false, nil
+		}
+	}
+	f.validations = append(f.validations, validation)
+	return true, nil
+}
+
+func (f *fakeScannerUsers) UpdateSessionStats(ctx context.Context, sessionID uuid.UUID, scansCount, validScans, invalidScans int, totalRevenue float64) error {
+	stats := f.stats[sessionID]
+	stats.scans += scansCount
+	stats.valid += validScans
+	stats.invalid += invalidScans
+	f.stats[sessionID] = stats
+	return nil
+}
+
+func (f *fakeScannerUsers) LogActivity(ctx context.Context, log *entities.ScannerAuditLog) error {
+	f.audit = append(f.audit, log)
+	return nil
+}
+
+type fakeOutbox struct {
+	repositories.OutboxRepository
+	messages []*entities.OutboxMessage
+}
+
+func (f *fakeOutbox) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	ctx       context.Context
+	tickets   *fakeTickets
+	outbox    *fakeOutbox
+	committed bool
+}
+
+func (tx *fakeTx) Commit() error                          { tx.committed = true; return nil }
+func (tx *fakeTx) Rollback() error                        { return nil }
+func (tx *fakeTx) Context() context.Context               { return tx.ctx }
+func (tx *fakeTx) Tickets() repositories.TicketRepository { return tx.tickets }
+func (tx *fakeTx) Outbox() repositories.OutboxRepository  { return tx.outbox }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	u.tx.ctx = ctx
+	return u.tx, nil
+}
+
+type fakeRepoManager struct {
+	repositories.RepositoryManager
+	unitOfWork   *fakeUnitOfWork
+	tickets      *fakeTickets
+	scannerUsers *fakeScannerUsers
+}
+
+func (m *fakeRepoManager) UnitOfWork() repositories.UnitOfWork              { return m.unitOfWork }
+func (m *fakeRepoManager) Tickets() repositories.TicketRepository           { return m.tickets }
+func (m *fakeRepoManager) ScannerUsers() repositories.ScannerUserRepository { return m.scannerUsers }
+
+type scannerFixture struct {
+	service      *ScannerAuthService
+	recorded     *eventbus.Recorder
+	outbox       *fakeOutbox
+	tx           *fakeTx
+	tickets      *fakeTickets
+	scannerUsers *fakeScannerUsers
+	ticket       *entities.Ticket
+	eventID      uuid.UUID
+	scannerID    uuid.UUID
+	sessionID    uuid.UUID
+}
+
+// newScannerFixture builds a scanner service over fakes, with one active
+// ticket. The service subscribes to the bus the way the server wires it, so
+// TicketRedeemed queues the session record.
+func newScannerFixture(t *testing.T) *scannerFixture {
+	t.Helper()
+
+	ticket := entities.NewTicket(uuid.New(), "UDUX-TEST-000001", "")
+	tickets := &fakeTickets{tickets: map[uuid.UUID]*entities.Ticket{ticket.ID: ticket}}
+	scannerUsers := &fakeScannerUsers{stats: make(map[uuid.UUID]sessionStats)}
+	rootOutbox := &fakeOutbox{}
+	tx := &fakeTx{tickets: tickets, outbox: &fakeOutbox{}}
+
+	bus := eventbus.NewBus(rootOutbox, outbox.NewDispatcher(rootOutbox))
+	recorded := eventbus.NewRecorder(bus)
+
+	service := NewScannerAuthService(&fakeRepoManager{
+		unitOfWork:   &fakeUnitOfWork{tx: tx},
+		tickets:      tickets,
+		scannerUsers: scannerUsers,
+	}, testTicketSecret, bus)
+	service.Subscribe(bus)
+
+	return &scannerFixture{
+		service:      service,
+		recorded:     recorded,
+		outbox:       rootOutbox,
+		tx:           tx,
+		tickets:      tickets,
+		scannerUsers: scannerUsers,
+		ticket:       ticket,
+		eventID:      uuid.New(),
+		scannerID:    uuid.New(),
+		sessionID:    uuid.New(),
+	}
+}
+
+// ticketCode signs QR code claims for the fixture's ticket the way the
+// payment service does
+func (f *scannerFixture) ticketCode(t *testing.T, secret string) string {
+	t.Helper()
+	claims := ticketJWTClaims{
+		TicketID:     f.ticket.ID.String(),
+		EventID:      f.eventID.String(),
+		SerialNumber: f.ticket.SerialNumber,
+		OrderLineID:  f.ticket.OrderLineID.String(),
+	}
+	code, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(secret))
+	if err != nil {
+		t.Fatalf("failed to sign ticket code: %v", err)
+	}
+	return code
+}
+
+func TestValidateTicketPublishesTicketRedeemedInTransaction(t *testing.T) {
+	f := newScannerFixture(t)
+	notes := "north gate"
+
+	resp, err := f.service.ValidateTicket(context.Background(), f.scannerID, f.sessionID, f.eventID, f.ticketCode(t, testTicketSecret), &notes)
+	if err != nil {
+		t.Fatalf("ValidateTicket() error = %v", err)
+	}
+	if !resp.Valid {
+		t.Fatalf("ValidateTicket() = %q, want a valid scan", resp.Message)
+	}
+
+	f.recorded.AssertSequence(t, entities.DomainEventTicketRedeemed)
+	redeemed := f.recorded.Last(entities.DomainEventTicketRedeemed).(*entities.TicketRedeemed)
+	if redeemed.TicketID != f.ticket.ID || redeemed.EventID != f.eventID || redeemed.RedeemedBy != f.scannerID.String() {
+		t.Errorf("TicketRedeemed = %+v, want ticket %s redeemed by %s", redeemed, f.ticket.ID, f.scannerID)
+	}
+	if redeemed.SessionID == nil || *redeemed.SessionID != f.sessionID {
+		t.Errorf("TicketRedeemed session = %v, want %s", redeemed.SessionID, f.sessionID)
+	}
+	if redeemed.Notes == nil || *redeemed.Notes != notes {
+		t.Errorf("TicketRedeemed notes = %v, want %q", redeemed.Notes, notes)
+	}
+
+	if !f.tx.committed || len(f.tickets.redeemed) != 1 {
+		t.Fatalf("redemption was not committed")
+	}
+	want := "event." + entities.DomainEventTicketRedeemed + ".scanner_sessions"
+	if len(f.tx.outbox.messages) != 1 || f.tx.outbox.messages[0].Topic != want {
+		t.Fatalf("expected the session record queued in the transaction, got %v", topics(f.tx.outbox.messages))
+	}
+
+	// The session is only touched once the subscriber runs
+	if len(f.scannerUsers.validations) != 0 || len(f.scannerUsers.stats) != 0 {
+		t.Errorf("session recorded before the redemption was delivered")
+	}
+}
+
+func TestValidateTicketPublishesNothingForInvalidScans(t *testing.T) {
+	f := newScannerFixture(t)
+
+	resp, err := f.service.ValidateTicket(context.Background(), f.scannerID, f.sessionID, f.eventID, f.ticketCode(t, "forged"), nil)
+	if err != nil {
+		t.Fatalf("ValidateTicket() error = %v", err)
+	}
+	if resp.Valid {
+		t.Fatalf("ValidateTicket() accepted a forged ticket")
+	}
+
+	if err := f.ticket.Redeem(uuid.New().String()); err != nil {
+		t.Fatalf("Redeem() error = %v", err)
+	}
+	if _, err := f.service.ValidateTicket(context.Background(), f.scannerID, f.sessionID, f.eventID, f.ticketCode(t, testTicketSecret), nil); err != nil {
+		t.Fatalf("ValidateTicket() error = %v", err)
+	}
+
+	f.recorded.AssertNotPublished(t, entities.DomainEventTicketRedeemed)
+	if got := f.scannerUsers.stats[f.sessionID]; got.scans != 2 || got.invalid != 2 {
+		t.Errorf("session stats = %+v, want 2 invalid scans", got)
+	}
+}
+
+func TestTicketRedeemedSubscriberRecordsSession(t *testing.T) {
+	f := newScannerFixture(t)
+	notes := "north gate"
+
+	if _, err := f.service.ValidateTicket(context.Background(), f.scannerID, f.sessionID, f.eventID, f.ticketCode(t, testTicketSecret), &notes); err != nil {
+		t.Fatalf("ValidateTicket() error = %v", err)
+	}
+	redeemed := f.recorded.Last(entities.DomainEventTicketRedeemed)
+
+	if err := f.service.handleTicketRedeemed(context.Background(), redeemed); err != nil {
+		t.Fatalf("handleTicketRedeemed() error = %v", err)
+	}
+
+	if len(f.scannerUsers.validations) != 1 {
+		t.Fatalf("recorded %d validations, want 1", len(f.scannerUsers.validations))
+	}
+	validation := f.scannerUsers.validations[0]
+	if validation.TicketID != f.ticket.ID || validation.ScannerID != f.scannerID || validation.SessionID != f.sessionID || validation.ValidationResult != "valid" {
+		t.Errorf("validation = %+v, want a valid scan of %s", validation, f.ticket.ID)
+	}
+	if validation.Notes == nil || *validation.Notes != notes {
+		t.Errorf("validation notes = %v, want %q", validation.Notes, notes)
+	}
+	if got := f.scannerUsers.stats[f.sessionID]; got.scans != 1 || got.valid != 1 || got.invalid != 0 {
+		t.Errorf("session stats = %+v, want 1 valid scan", got)
+	}
+	if len(f.scannerUsers.audit) != 1 || f.scannerUsers.audit[0].Action != "ticket_validation" {
+		t.Errorf("expected one ticket_validation audit entry, got %d", len(f.scannerUsers.audit))
+	}
+}
+
+func TestTicketRedeemedSubscriberCountsRedeliveryOnce(t *testing.T) {
+	f := newScannerFixture(t)
+
+	if _, err := f.service.ValidateTicket(context.Background(), f.scannerID, f.sessionID, f.eventID, f.ticketCode(t, testTicketSecret), nil); err != nil {
+		t.Fatalf("ValidateTicket() error = %v", err)
+	}
+	redeemed := f.recorded.Last(entities.DomainEventTicketRedeemed)
+
+	// The outbox delivers again when the first delivery is not acknowledged
+	for i := 0; i < 2; i++ {
+		if err := f.service.handleTicketRedeemed(context.Background(), redeemed); err != nil {
+			t.Fatalf("handleTicketRedeemed() delivery %d error = %v", i+1, err)
+		}
+	}
+
+	if len(f.scannerUsers.validations) != 1 {
+		t.Errorf("recorded %d validations, want 1", len(f.scannerUsers.validations))
+	}
+	if got := f.scannerUsers.stats[f.sessionID]; got.scans != 1 || got.valid != 1 {
+		t.Errorf("session stats = %+v, want 1 valid scan", got)
+	}
+	if len(f.scannerUsers.audit) != 1 {
+		t.Errorf("wrote %d audit entries, want 1", len(f.scannerUsers.audit))
+	}
+}
+
+func TestTicketRedeemedSubscriberSkipsGuestListCheckIns(t *testing.T) {
+	f := newScannerFixture(t)
+
+	checkIn := entities.NewTicketRedeemed(f.ticket, f.eventID, uuid.New().String(), nil)
+	if err := f.service.handleTicketRedeemed(context.Background(), checkIn); err != nil {
+		t.Fatalf("handleTicketRedeemed() error = %v", err)
+	}
+	if len(f.scannerUsers.validations) != 0 || len(f.scannerUsers.stats) != 0 || len(f.scannerUsers.audit) != 0 {
+		t.Errorf("a check-in without a scanner session was recorded against one")
+	}
+}
+
+func topics(messages []*entities.OutboxMessage) []string {
+	names := make([]string, len(messages))
+	for i, message := range messages {
+		names[i] = message.Topic
+	}
+	return names
+}
@@ -0,0 +1,255 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// MaxQueryLength caps the length of a free-text search query
+const MaxQueryLength = 200
+
+// Date presets accepted by the date filter, in facet order
+const (
+	DateToday       = "today"
+	DateTomorrow    = "tomorrow"
+	DateThisWeekend = "this_weekend"
+	DateThisWeek    = "this_week"
+	DateNextWeek    = "next_week"
+	DateThisMonth   = "this_month"
+	DateNextMonth   = "next_month"
+)
+
+// Sort options
+const (
+	SortRelevance = "relevance"
+	SortDate      = "date"
+	SortPriceAsc  = "price_asc"
+	SortPriceDesc = "price_desc"
+)
+
+// Date buckets are computed on the West Africa Time calendar, where events take place
+var searchLocation = time.FixedZone("WAT", 60*60)
+
+// priceBands are the ticket price ranges offered as facets. Ranges are in the
+// event's own currency, which for almost every event is naira.
+var priceBands = []repositories.PriceBand{
+	{Key: "free", Min: floatPtr(0), Max: floatPtr(0.01)},
+	{Key: "under_10k", Min: floatPtr(0.01), Max: floatPtr(10000)},
+	{Key: "10k_25k", Min: floatPtr(10000), Max: floatPtr(25000)},
+	{Key: "25k_50k", Min: floatPtr(25000), Max: floatPtr(50000)},
+	{Key: "50k_100k", Min: floatPtr(50000), Max: floatPtr(100000)},
+	{Key: "100k_plus", Min: floatPtr(100000)},
+}
+
+// SearchService provides public event search and autocomplete
+type SearchService struct {
+	searchRepo repositories.EventSearchRepository
+	now        func() time.Time
+}
+
+// NewSearchService creates a new search service
+func NewSearchService(searchRepo repositories.EventSearchRepository) *SearchService {
+	return &SearchService{
+		searchRepo: searchRepo,
+		now:        time.Now,
+	}
+}
+
+// SearchEventsRequest represents a public event search
+type SearchEventsRequest struct {
+	Query      string
+	CategoryID *uuid.UUID
+	City       string
+	Date       string
+	DateFrom   *time.Time
+	DateTo     *time.Time
+	PriceBand  string
+	PriceMin   *float64
+	PriceMax   *float64
+	Sort       string
+	Page       int
+	Limit      int
+}
+
+// SearchEventsResponse is a page of search results with facets. Fuzzy is set
+// when no event matched every word of the query and results come from the
+// typo-tolerant fallback instead.
+type SearchEventsResponse struct {
+	Query      string                         `json:"query"`
+	Fuzzy      bool                           `json:"fuzzy"`
+	Hits       []*repositories.EventSearchHit `json:"hits"`
+	Facets     *repositories.SearchFacets     `json:"facets"`
+	Pagination *repositories.PaginationResult `json:"pagination"`
+}
+
+// SearchEvents runs a ranked full-text search, falling back to trigram
+// similarity when the query matches nothing
+func (s *SearchService) SearchEvents(ctx context.Context, req *SearchEventsRequest) (*SearchEventsResponse, error) {
+	filter, err := s.buildFilter(req)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := s.searchRepo.Search(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	fuzzy := false
+	if result.Pagination.Total == 0 && filter.Query != "" {
+		filter.Fuzzy = true
+		fuzzyResult, err := s.searchRepo.Search(ctx, filter)
+		if err != nil {
+			return nil, err
+		}
+		if fuzzyResult.Pagination.Total > 0 {
+			result = fuzzyResult
+			fuzzy = true
+		}
+	}
+
+	return &SearchEventsResponse{
+		Query:      filter.Query,
+		Fuzzy:      fuzzy,
+		Hits:       result.Hits,
+		Facets:     result.Facets,
+		Pagination: result.Pagination,
+	}, nil
+}
+
+// Suggest returns autocomplete suggestions for a partial query
+func (s *SearchService) Suggest(ctx context.Context, prefix string, limit int) ([]*repositories.SearchSuggestion, error) {
+	prefix = strings.TrimSpace(prefix)
+	if len(prefix) > MaxQueryLength {
+		prefix = prefix[:MaxQueryLength]
+	}
+	if len([]rune(prefix)) < 2 {
+		return []*repositories.SearchSuggestion{}, nil
+	}
+
+	return s.searchRepo.Suggest(ctx, prefix, limit)
+}
+
+func (s *SearchService) buildFilter(req *SearchEventsRequest) (repositories.EventSearchFilter, error) {
+	query := strings.TrimSpace(req.Query)
+	if len(query) > MaxQueryLength {
+		return repositories.EventSearchFilter{}, entities.NewValidationError("q", "search query is too long")
+	}
+
+	filter := repositories.EventSearchFilter{
+		BaseFilter: repositories.BaseFilter{
+			Page:  req.Page,
+			Limit: req.Limit,
+		},
+		Query:      query,
+		CategoryID: req.CategoryID,
+		City:       strings.TrimSpace(req.City),
+		DateFrom:   req.DateFrom,
+		DateTo:     req.DateTo,
+		PriceMin:   req.PriceMin,
+		PriceMax:   req.PriceMax,
+		PriceBands: priceBands,
+	}
+
+	switch req.Sort {
+	case "", SortRelevance:
+		if query == "" {
+			filter.SortBy = SortDate
+		}
+	case SortDate, SortPriceAsc, SortPriceDesc:
+		filter.SortBy = req.Sort
+	default:
+		return filter, entities.NewValidationError("sort", "sort must be one of relevance, date, price_asc, price_desc")
+	}
+
+	buckets := s.dateBuckets()
+	filter.DateBuckets = buckets
+	if req.Date != "" {
+		bucket, ok := findDateBucket(buckets, req.Date)
+		if !ok {
+			return filter, entities.NewValidationError("date", "unknown date filter")
+		}
+		filter.DateFrom = &bucket.From
+		filter.DateTo = &bucket.To
+	}
+
+	if req.PriceBand != "" {
+		band, ok := findPriceBand(req.PriceBand)
+		if !ok {
+			return filter, entities.NewValidationError("price", "unknown price band")
+		}
+		filter.PriceMin = band.Min
+		filter.PriceMax = nil
+		if band.Max != nil {
+			// Bands exclude their upper bound; the price filter includes it
+			max := *band.Max - 0.01
+			filter.PriceMax = &max
+		}
+	}
+
+	if filter.PriceMin != nil && filter.PriceMax != nil && *filter.PriceMin > *filter.PriceMax {
+		return filter, entities.NewValidationError("price_min", "price_min cannot be greater than price_max")
+	}
+	if filter.DateFrom != nil && filter.DateTo != nil && !filter.DateFrom.Before(*filter.DateTo) {
+		return filter, entities.NewValidationError("date_from", "date_from must be before date_to")
+	}
+
+	return filter, nil
+}
+
+// dateBuckets returns the date facet ranges relative to today
+func (s *SearchService) dateBuckets() []repositories.DateBucket {
+	now := s.now().In(searchLocation)
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, searchLocation)
+
+	// Weeks run Monday to Sunday
+	daysSinceMonday := (int(today.Weekday()) + 6) % 7
+	weekStart := today.AddDate(0, 0, -daysSinceMonday)
+	nextWeekStart := weekStart.AddDate(0, 0, 7)
+
+	// The weekend is Friday evening to the end of Sunday; once it has started it runs from now
+	weekendStart := weekStart.AddDate(0, 0, 4).Add(18 * time.Hour)
+	if weekendStart.Before(now) {
+		weekendStart = now
+	}
+
+	monthStart := time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, searchLocation)
+	nextMonthStart := monthStart.AddDate(0, 1, 0)
+
+	return []repositories.DateBucket{
+		{Key: DateToday, From: now, To: today.AddDate(0, 0, 1)},
+		{Key: DateTomorrow, From: today.AddDate(0, 0, 1), To: today.AddDate(0, 0, 2)},
+		{Key: DateThisWeekend, From: weekendStart, To: nextWeekStart},
+		{Key: DateThisWeek, From: now, To: nextWeekStart},
+		{Key: DateNextWeek, From: nextWeekStart, To: nextWeekStart.AddDate(0, 0, 7)},
+		{Key: DateThisMonth, From: now, To: nextMonthStart},
+		{Key: DateNextMonth, From: nextMonthStart, To: nextMonthStart.AddDate(0, 1, 0)},
+	}
+}
+
+func findDateBucket(buckets []repositories.DateBucket, key string) (repositories.DateBucket, bool) {
+	for _, bucket := range buckets {
+		if bucket.Key == key {
+			return bucket, true
+		}
+	}
+	return repositories.DateBucket{}, false
+}
+
+func findPriceBand(key string) (repositories.PriceBand, bool) {
+	for _, band := range priceBands {
+		if band.Key == key {
+			return band, true
+		}
+	}
+	return repositories.PriceBand{}, false
+}
+
+func floatPtr(value float64) *float64 {
+	return &value
+}
@@ -0,0 +1,230 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+// fakeSearch records each filter it is asked for and answers strict and fuzzy
+// searches with the configured number of hits
+type fakeSearch struct {
+	repositories.EventSearchRepository
+	strictHits int
+	fuzzyHits  int
+	filters    []repositories.EventSearchFilter
+	prefixes   []string
+}
+
+func (f *fakeSearch) Search(ctx context.Context, filter repositories.EventSearchFilter) (*repositories.EventSearchResult, error) {
+	f.filters = append(f.filters, filter)
+	total := f.strictHits
+	if filter.Fuzzy {
+		total = f.fuzzyHits
+	}
+	hits := make([]*repositories.EventSearchHit, total)
+	for i := range hits {
+		hits[i] = &repositories.EventSearchHit{Event: &entities.Event{}}
+	}
+	return &repositories.EventSearchResult{
+		Hits:       hits,
+		Facets:     &repositories.SearchFacets{},
+		Pagination: repositories.NewPaginationResult(1, 20, total),
+	}, nil
+}
+
+func (f *fakeSearch) Suggest(ctx context.Context, prefix string, limit int) ([]*repositories.SearchSuggestion, error) {
+	f.prefixes = append(f.prefixes, prefix)
+	return []*repositories.SearchSuggestion{{Text: prefix + "...", Type: "event"}}, nil
+}
+
+// wat returns a time on the West Africa Time calendar
+func wat(month time.Month, day, hour, minute int) time.Time {
+	return time.Date(2026, month, day, hour, minute, 0, 0, searchLocation)
+}
+
+// newSearchService builds a search service whose clock reads now
+func newSearchService(repo *fakeSearch, now time.Time) *SearchService {
+	service := NewSearchService(repo)
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestSearchEventsFallsBackToFuzzyWhenNothingMatches(t *testing.T) {
+	tests := []struct {
+		name       string
+		query      string
+		strictHits int
+		fuzzyHits  int
+		wantFuzzy  bool
+		wantHits   int
+		searches   int
+	}{
+		{name: "strict matches", query: "burna boy", strictHits: 2, fuzzyHits: 5, wantHits: 2, searches: 1},
+		{name: "typo", query: "burma boy", fuzzyHits: 3, wantFuzzy: true, wantHits: 3, searches: 2},
+		{name: "nothing close", query: "zzzz", searches: 2},
+		{name: "no query", strictHits: 0, fuzzyHits: 4, searches: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSearch{strictHits: tt.strictHits, fuzzyHits: tt.fuzzyHits}
+			service := newSearchService(repo, wat(time.October, 14, 10, 0))
+
+			resp, err := service.SearchEvents(context.Background(), &SearchEventsRequest{Query: "  " + tt.query + " "})
+			if err != nil {
+				t.Fatalf("SearchEvents() error = %v", err)
+			}
+			if resp.Fuzzy != tt.wantFuzzy || len(resp.Hits) != tt.wantHits {
+				t.Errorf("SearchEvents() = fuzzy %v with %d hits, want fuzzy %v with %d", resp.Fuzzy, len(resp.Hits), tt.wantFuzzy, tt.wantHits)
+			}
+			if len(repo.filters) != tt.searches {
+				t.Errorf("SearchEvents() ran %d searches, want %d", len(repo.filters), tt.searches)
+			}
+			if resp.Query != tt.query {
+				t.Errorf("SearchEvents() query = %q, want it trimmed to %q", resp.Query, tt.query)
+			}
+		})
+	}
+}
+
+func TestSearchEventsSortsByDateWithoutQuery(t *testing.T) {
+	tests := []struct {
+		query, sort string
+		want        string
+	}{
+		{query: "afrobeats", want: ""},
+		{query: "", want: SortDate},
+		{query: "afrobeats", sort: SortPriceAsc, want: SortPriceAsc},
+	}
+	for _, tt := range tests {
+		repo := &fakeSearch{strictHits: 1}
+		service := newSearchService(repo, wat(time.October, 14, 10, 0))
+		if _, err := service.SearchEvents(context.Background(), &SearchEventsRequest{Query: tt.query, Sort: tt.sort}); err != nil {
+			t.Fatalf("SearchEvents() error = %v", err)
+		}
+		if got := repo.filters[0].SortBy; got != tt.want {
+			t.Errorf("SearchEvents(%q, sort %q) sorted by %q, want %q", tt.query, tt.sort, got, tt.want)
+		}
+	}
+}
+
+func TestSearchEventsDatePresets(t *testing.T) {
+	// A Wednesday morning, and the Saturday afternoon of the same week
+	wednesday := wat(time.October, 14, 10, 0)
+	saturday := wat(time.October, 17, 15, 30)
+
+	tests := []struct {
+		now      time.Time
+		date     string
+		from, to time.Time
+	}{
+		{now: wednesday, date: DateToday, from: wednesday, to: wat(time.October, 15, 0, 0)},
+		{now: wednesday, date: DateTomorrow, from: wat(time.October, 15, 0, 0), to: wat(time.October, 16, 0, 0)},
+		{now: wednesday, date: DateThisWeekend, from: wat(time.October, 16, 18, 0), to: wat(time.October, 19, 0, 0)},
+		{now: saturday, date: DateThisWeekend, from: saturday, to: wat(time.October, 19, 0, 0)},
+		{now: wednesday, date: DateNextWeek, from: wat(time.October, 19, 0, 0), to: wat(time.October, 26, 0, 0)},
+		{now: wednesday, date: DateThisMonth, from: wednesday, to: wat(time.November, 1, 0, 0)},
+		{now: wednesday, date: DateNextMonth, from: wat(time.November, 1, 0, 0), to: wat(time.December, 1, 0, 0)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.now.Weekday().String()+" "+tt.date, func(t *testing.T) {
+			repo := &fakeSearch{strictHits: 1}
+			service := newSearchService(repo, tt.now)
+
+			if _, err := service.SearchEvents(context.Background(), &SearchEventsRequest{Date: tt.date}); err != nil {
+				t.Fatalf("SearchEvents() error = %v", err)
+			}
+			filter := repo.filters[0]
+			if !filter.DateFrom.Equal(tt.from) || !filter.DateTo.Equal(tt.to) {
+				t.Errorf("date range = %v to %v, want %v to %v", filter.DateFrom, filter.DateTo, tt.from, tt.to)
+			}
+			if len(filter.DateBuckets) != 7 {
+				t.Errorf("date facets = %d, want every preset", len(filter.DateBuckets))
+			}
+		})
+	}
+}
+
+func TestSearchEventsPriceBandIncludesItsRange(t *testing.T) {
+	repo := &fakeSearch{strictHits: 1}
+	service := newSearchService(repo, wat(time.October, 14, 10, 0))
+	max := 5000.0
+
+	// The band replaces any explicit price range
+	if _, err := service.SearchEvents(context.Background(), &SearchEventsRequest{PriceBand: "10k_25k", PriceMax: &max}); err != nil {
+		t.Fatalf("SearchEvents() error = %v", err)
+	}
+	filter := repo.filters[0]
+	if filter.PriceMin == nil || *filter.PriceMin != 10000 || filter.PriceMax == nil || *filter.PriceMax != 24999.99 {
+		t.Errorf("price range = %v to %v, want 10000 to 24999.99", filter.PriceMin, filter.PriceMax)
+	}
+
+	if _, err := service.SearchEvents(context.Background(), &SearchEventsRequest{PriceBand: "100k_plus"}); err != nil {
+		t.Fatalf("SearchEvents() error = %v", err)
+	}
+	if filter := repo.filters[1]; filter.PriceMin == nil || *filter.PriceMin != 100000 || filter.PriceMax != nil {
+		t.Errorf("open band price range = %v to %v, want 100000 and up", filter.PriceMin, filter.PriceMax)
+	}
+}
+
+func TestSearchEventsRejectsInvalidFilters(t *testing.T) {
+	min, max := 20000.0, 10000.0
+	from := wat(time.October, 20, 0, 0)
+	to := wat(time.October, 19, 0, 0)
+
+	tests := []struct {
+		name  string
+		req   SearchEventsRequest
+		field string
+	}{
+		{name: "long query", req: SearchEventsRequest{Query: strings.Repeat("a", MaxQueryLength+1)}, field: "q"},
+		{name: "unknown sort", req: SearchEventsRequest{Sort: "popularity"}, field: "sort"},
+		{name: "unknown date", req: SearchEventsRequest{Date: "someday"}, field: "date"},
+		{name: "unknown price band", req: SearchEventsRequest{PriceBand: "cheap"}, field: "price"},
+		{name: "inverted prices", req: SearchEventsRequest{PriceMin: &min, PriceMax: &max}, field: "price_min"},
+		{name: "inverted dates", req: SearchEventsRequest{DateFrom: &from, DateTo: &to}, field: "date_from"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &fakeSearch{}
+			service := newSearchService(repo, wat(time.October, 14, 10, 0))
+
+			_, err := service.SearchEvents(context.Background(), &tt.req)
+			var validationErr *entities.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != tt.field {
+				t.Fatalf("SearchEvents() error = %v, want a validation error on %s", err, tt.field)
+			}
+			if len(repo.filters) != 0 {
+				t.Errorf("SearchEvents() searched with an invalid filter")
+			}
+		})
+	}
+}
+
+func TestSuggestNeedsTwoCharacters(t *testing.T) {
+	repo := &fakeSearch{}
+	service := newSearchService(repo, wat(time.October, 14, 10, 0))
+
+	suggestions, err := service.Suggest(context.Background(), " w ", 5)
+	if err != nil || len(suggestions) != 0 || len(repo.prefixes) != 0 {
+		t.Errorf("Suggest(\" w \") = %d suggestions, %v, want none without a lookup", len(suggestions), err)
+	}
+
+	if _, err := service.Suggest(context.Background(), " wi ", 5); err != nil {
+		t.Fatalf("Suggest() error = %v", err)
+	}
+	if len(repo.prefixes) != 1 || repo.prefixes[0] != "wi" {
+		t.Errorf("Suggest() looked up %q, want the trimmed prefix", repo.prefixes)
+	}
+}
@@ -0,0 +1,668 @@
+package series
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// OccurrenceScope selects which occurrences of a series an edit applies to
+type OccurrenceScope string
+
+const (
+	// ScopeThis edits only the chosen occurrence
+	ScopeThis OccurrenceScope = "this"
+
+	// ScopeFollowing edits the chosen occurrence and every later one, and
+	// occurrences generated from then on
+	ScopeFollowing OccurrenceScope = "following"
+)
+
+// maxSlugLength is the length of the events.slug column
+const maxSlugLength = 100
+
+// SeriesService handles recurring event use cases
+type SeriesService struct {
+	seriesRepo     repositories.EventSeriesRepository
+	eventRepo      repositories.EventRepository
+	ticketTierRepo repositories.TicketTierRepository
+	venueRepo      repositories.VenueRepository
+	unitOfWork     repositories.UnitOfWork
+}
+
+// NewSeriesService creates a new series service
+func NewSeriesService(
+	seriesRepo repositories.EventSeriesRepository,
+	eventRepo repositories.EventRepository,
+	ticketTierRepo repositories.TicketTierRepository,
+	venueRepo repositories.VenueRepository,
+	unitOfWork repositories.UnitOfWork,
+) *SeriesService {
+	return &SeriesService{
+		seriesRepo:     seriesRepo,
+		eventRepo:      eventRepo,
+		ticketTierRepo: ticketTierRepo,
+		venueRepo:      venueRepo,
+		unitOfWork:     unitOfWork,
+	}
+}
+
+// CreateSeriesRequest represents the request to turn an event into a recurring series
+type CreateSeriesRequest struct {
+	TemplateEventID uuid.UUID `json:"template_event_id" validate:"required"`
+	Name            string    `json:"name,omitempty" validate:"max=255"`
+	RRule           string    `json:"rrule" validate:"required"`
+	Timezone        string    `json:"timezone,omitempty"`
+	ExceptionDates  []string  `json:"exception_dates,omitempty"`
+	HorizonDays     *int      `json:"horizon_days,omitempty"`
+}
+
+// UpdateSeriesRequest represents the request to update a series. Changing the
+// rule or exception dates removes future occurrences that no longer match,
+// provided no tickets have been sold for them.
+type UpdateSeriesRequest struct {
+	Name           *string   `json:"name,omitempty" validate:"omitempty,max=255"`
+	RRule          *string   `json:"rrule,omitempty"`
+	ExceptionDates *[]string `json:"exception_dates,omitempty"`
+	HorizonDays    *int      `json:"horizon_days,omitempty"`
+	IsActive       *bool     `json:"is_active,omitempty"`
+}
+
+// OccurrenceTierUpdate changes a ticket tier of the edited occurrences, matched by name
+type OccurrenceTierUpdate struct {
+	Name  string   `json:"name" validate:"required"`
+	Price *float64 `json:"price,omitempty" validate:"omitempty,min=0"`
+	Quota *int     `json:"quota,omitempty" validate:"omitempty,gt=0"`
+}
+
+// UpdateOccurrenceRequest represents an edit to one occurrence or to it and
+// all following occurrences. StartTime is the local wall clock time (HH:MM).
+type UpdateOccurrenceRequest struct {
+	Scope         OccurrenceScope        `json:"scope" validate:"required,oneof=this following"`
+	Name          *string                `json:"name,omitempty" validate:"omitempty,max=255"`
+	Description   *string                `json:"description,omitempty"`
+	StartTime     *string                `json:"start_time,omitempty"`
+	VenueID       *uuid.UUID             `json:"venue_id,omitempty"`
+	EventImageURL *string                `json:"event_image_url,omitempty"`
+	ThumbnailURL  *string                `json:"thumbnail_url,omitempty"`
+	TicketTiers   []OccurrenceTierUpdate `json:"ticket_tiers,omitempty" validate:"dive"`
+}
+
+// SeriesResponse represents a series with its occurrences in date order
+type SeriesResponse struct {
+	Series      *entities.EventSeries `json:"series"`
+	Occurrences []*entities.Event     `json:"occurrences"`
+}
+
+// CreateSeries turns an event into the first occurrence of a recurring series
+// and generates the occurrences that fall within the series horizon
+func (s *SeriesService) CreateSeries(ctx context.Context, req *CreateSeriesRequest) (*SeriesResponse, error) {
+	template, err := s.getEvent(ctx, req.TemplateEventID)
+	if err != nil {
+		return nil, err
+	}
+	if template.SeriesID != nil {
+		return nil, entities.NewConflictError("event_series", "event already belongs to a series", nil)
+	}
+	if template.Status == entities.EventStatusCancelled || template.Status == entities.EventStatusCompleted {
+		return nil, entities.NewValidationError("template_event_id", "a cancelled or completed event cannot start a series")
+	}
+
+	// Occurrences follow the venue's local time unless told otherwise
+	timezone := strings.TrimSpace(req.Timezone)
+	if timezone == "" && template.VenueID != nil {
+		venue, err := s.venueRepo.GetByID(ctx, *template.VenueID)
+		if err != nil && err != entities.ErrVenueNotFound {
+			return nil, fmt.Errorf("failed to get venue: %w", err)
+		}
+		if venue != nil {
+			timezone = venue.Timezone
+		}
+	}
+
+	series := entities.NewEventSeries(template, req.Name, req.RRule, timezone)
+	if err := series.SetExceptionDates(req.ExceptionDates); err != nil {
+		return nil, err
+	}
+	if req.HorizonDays != nil {
+		series.HorizonDays = *req.HorizonDays
+	}
+	if err := series.Validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if err := tx.EventSeries().Create(tx.Context(), series); err != nil {
+		return nil, err
+	}
+
+	template.SeriesID = &series.ID
+	template.UpdatedAt = time.Now()
+	if err := tx.Events().Update(tx.Context(), template); err != nil {
+		return nil, fmt.Errorf("failed to link template event: %w", err)
+	}
+
+	if _, err := s.generate(tx, series, template); err != nil {
+		return nil, err
+	}
+
+	if err := tx.EventSeries().Update(tx.Context(), series); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetSeries(ctx, series.ID)
+}
+
+// GetSeries retrieves a series with its occurrences
+func (s *SeriesService) GetSeries(ctx context.Context, id uuid.UUID) (*SeriesResponse, error) {
+	series, err := s.getSeries(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	occurrences, err := s.eventRepo.GetBySeries(ctx, series.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SeriesResponse{
+		Series:      series,
+		Occurrences: occurrences,
+	}, nil
+}
+
+// ListSeries retrieves series with pagination and filtering
+func (s *SeriesService) ListSeries(ctx context.Context, filter repositories.EventSeriesFilter) ([]*entities.EventSeries, *repositories.PaginationResult, error) {
+	return s.seriesRepo.List(ctx, filter)
+}
+
+// UpdateSeries updates a series and brings its future occurrences in line
+// with the rule and exception dates
+func (s *SeriesService) UpdateSeries(ctx context.Context, id uuid.UUID, req *UpdateSeriesRequest) (*SeriesResponse, error) {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	series, err := lockSeries(tx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	template, err := tx.Events().GetByID(tx.Context(), series.TemplateEventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template event: %w", err)
+	}
+
+	reschedule := false
+	if req.Name != nil {
+		series.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.RRule != nil {
+		series.RRule = strings.TrimPrefix(strings.TrimSpace(*req.RRule), "RRULE:")
+		// The new rule is anchored at the current template
+		series.StartsAt = template.EventDate
+		reschedule = true
+	}
+	if req.ExceptionDates != nil {
+		if err := series.SetExceptionDates(*req.ExceptionDates); err != nil {
+			return nil, err
+		}
+		reschedule = true
+	}
+	if req.HorizonDays != nil {
+		series.HorizonDays = *req.HorizonDays
+	}
+	if req.IsActive != nil {
+		series.IsActive = *req.IsActive
+	}
+	if err := series.Validate(); err != nil {
+		return nil, err
+	}
+
+	if reschedule {
+		if err := s.removeUnscheduled(tx, series); err != nil {
+			return nil, err
+		}
+		// Regenerate from now so dates the new rule adds are filled in
+		series.GeneratedUntil = nil
+	}
+
+	if _, err := s.generate(tx, series, template); err != nil {
+		return nil, err
+	}
+
+	series.UpdatedAt = time.Now()
+	if err := tx.EventSeries().Update(tx.Context(), series); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return s.GetSeries(ctx, series.ID)
+}
+
+// GenerateOccurrences creates the occurrences that have come within the series
+// horizon since the last run. It is safe to call repeatedly, e.g. from a cron.
+func (s *SeriesService) GenerateOccurrences(ctx context.Context, id uuid.UUID) ([]*entities.Event, error) {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	series, err := lockSeries(tx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !series.IsActive {
+		return nil, entities.NewBusinessRuleError("series_inactive", "series is not active", nil)
+	}
+
+	template, err := tx.Events().GetByID(tx.Context(), series.TemplateEventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template event: %w", err)
+	}
+
+	created, err := s.generate(tx, series, template)
+	if err != nil {
+		return nil, err
+	}
+
+	series.UpdatedAt = time.Now()
+	if err := tx.EventSeries().Update(tx.Context(), series); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return created, nil
+}
+
+// UpdateOccurrence edits one occurrence of a series, or it and every
+// following occurrence. Editing the following occurrences also makes the
+// edited occurrence the template of occurrences generated later.
+func (s *SeriesService) UpdateOccurrence(ctx context.Context, eventID uuid.UUID, req *UpdateOccurrenceRequest) ([]*entities.Event, error) {
+	event, err := s.getEvent(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+	if event.SeriesID == nil {
+		return nil, entities.NewValidationError("event_id", "event is not part of a series")
+	}
+
+	var venue *entities.Venue
+	if req.VenueID != nil {
+		venue, err = s.venueRepo.GetByID(ctx, *req.VenueID)
+		if err != nil {
+			if err == entities.ErrVenueNotFound {
+				return nil, entities.NewNotFoundError("venue", "venue not found")
+			}
+			return nil, fmt.Errorf("failed to get venue: %w", err)
+		}
+		if !venue.IsActive {
+			return nil, entities.NewValidationError("venue_id", "venue is no longer active")
+		}
+	}
+
+	var startClock *time.Time
+	if req.StartTime != nil {
+		clock, err := time.Parse("15:04", strings.TrimSpace(*req.StartTime))
+		if err != nil {
+			return nil, entities.NewValidationError("start_time", "start time must be HH:MM")
+		}
+		startClock = &clock
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	series, err := lockSeries(tx, *event.SeriesID)
+	if err != nil {
+		return nil, err
+	}
+
+	targets := []*entities.Event{event}
+	var next *entities.Event
+	occurrences, err := tx.Events().GetBySeries(tx.Context(), series.ID)
+	if err != nil {
+		return nil, err
+	}
+	for _, occurrence := range occurrences {
+		if !occurrence.EventDate.After(event.EventDate) {
+			continue
+		}
+		if next == nil {
+			next = occurrence
+		}
+		if req.Scope == ScopeFollowing && isEditable(occurrence) {
+			targets = append(targets, occurrence)
+		}
+	}
+
+	now := time.Now()
+	for _, target := range targets {
+		if req.Name != nil {
+			target.Name = strings.TrimSpace(*req.Name)
+		}
+		if req.Description != nil {
+			target.Description = req.Description
+		}
+		if req.EventImageURL != nil {
+			target.SetImage(*req.EventImageURL)
+		}
+		if req.ThumbnailURL != nil {
+			target.SetThumbnail(*req.ThumbnailURL)
+		}
+		if venue != nil {
+			target.AssignVenue(venue)
+		}
+		if startClock != nil {
+			local := target.EventDate.In(series.Location())
+			start := time.Date(local.Year(), local.Month(), local.Day(), startClock.Hour(), startClock.Minute(), 0, 0, local.Location())
+			shift := start.Sub(target.EventDate)
+			target.EventDate = start
+			if target.DoorsOpen != nil {
+				doorsOpen := target.DoorsOpen.Add(shift)
+				target.DoorsOpen = &doorsOpen
+			}
+		}
+
+		if target.Name == "" {
+			return nil, entities.NewValidationError("name", "event name is required")
+		}
+		if target.EventDate.After(now) {
+			if err := target.Validate(); err != nil {
+				return nil, err
+			}
+		}
+
+		target.UpdatedAt = now
+		if err := tx.Events().Update(tx.Context(), target); err != nil {
+			return nil, fmt.Errorf("failed to update occurrence: %w", err)
+		}
+
+		if err := s.updateTiers(tx, target, req.TicketTiers, target.ID == event.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Later occurrences are copied from the template, so it must carry the
+	// series-wide values: it moves to the edited occurrence when the following
+	// occurrences change, and away from it when only that one does
+	switch {
+	case req.Scope == ScopeFollowing:
+		series.TemplateEventID = event.ID
+	case event.ID == series.TemplateEventID && next != nil:
+		series.TemplateEventID = next.ID
+	}
+	series.UpdatedAt = now
+	if err := tx.EventSeries().Update(tx.Context(), series); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return targets, nil
+}
+
+// generate creates the occurrences between the later of now and the last
+// generation run and the end of the series horizon, copying the template
+func (s *SeriesService) generate(tx repositories.Transaction, series *entities.EventSeries, template *entities.Event) ([]*entities.Event, error) {
+	var created []*entities.Event
+	if !series.IsActive {
+		return created, nil
+	}
+
+	rule, err := series.Rule()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	after := now
+	if series.GeneratedUntil != nil && series.GeneratedUntil.After(after) {
+		after = *series.GeneratedUntil
+	}
+	end := now.AddDate(0, 0, series.HorizonDays)
+
+	existing, err := tx.Events().GetBySeries(tx.Context(), series.ID)
+	if err != nil {
+		return nil, err
+	}
+	scheduled := make(map[string]bool, len(existing))
+	for _, occurrence := range existing {
+		scheduled[series.LocalDate(occurrence.EventDate)] = true
+	}
+
+	tiers, err := tx.TicketTiers().GetByEvent(tx.Context(), template.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get template ticket tiers: %w", err)
+	}
+
+	for _, day := range rule.Occurrences(series.LocalStart(), end, 0) {
+		if len(created) >= entities.MaxSeriesOccurrences {
+			// The rest are picked up by the next run
+			end = created[len(created)-1].EventDate
+			break
+		}
+
+		start := series.OccurrenceStart(day, template.EventDate)
+		if !start.After(after) || series.IsException(start) || scheduled[series.LocalDate(start)] {
+			continue
+		}
+
+		occurrence := template.CopyAsOccurrence(series.ID, start, series.OccurrenceSlug(template.Slug, start))
+		if err := tx.Events().Create(tx.Context(), occurrence); err != nil {
+			if err == entities.ErrConflictError {
+				return nil, entities.NewConflictError("event", fmt.Sprintf("an event with slug %s already exists", occurrence.Slug), nil)
+			}
+			return nil, fmt.Errorf("failed to create occurrence: %w", err)
+		}
+
+		shift := start.Sub(template.EventDate)
+		for _, tier := range entities.CopyTiersForEvent(tiers, occurrence.ID, shift) {
+			if err := tx.TicketTiers().Create(tx.Context(), tier); err != nil {
+				return nil, fmt.Errorf("failed to copy ticket tier: %w", err)
+			}
+		}
+
+		scheduled[series.LocalDate(start)] = true
+		created = append(created, occurrence)
+	}
+
+	series.GeneratedUntil = &end
+	return created, nil
+}
+
+// removeUnscheduled removes future occurrences from the rule's start onwards
+// whose date the rule no longer produces or that fall on an exception date.
+// Occurrences with tickets sold must be cancelled by hand first. The template
+// is always kept.
+func (s *SeriesService) removeUnscheduled(tx repositories.Transaction, series *entities.EventSeries) error {
+	rule, err := series.Rule()
+	if err != nil {
+		return err
+	}
+
+	occurrences, err := tx.Events().GetBySeries(tx.Context(), series.ID)
+	if err != nil {
+		return err
+	}
+	if len(occurrences) == 0 {
+		return nil
+	}
+
+	last := occurrences[len(occurrences)-1].EventDate
+	valid := make(map[string]bool)
+	for _, day := range rule.Occurrences(series.LocalStart(), last.AddDate(0, 0, 1), 0) {
+		valid[series.LocalDate(day)] = true
+	}
+
+	now := time.Now()
+	for _, occurrence := range occurrences {
+		if occurrence.ID == series.TemplateEventID || !occurrence.EventDate.After(now) || occurrence.EventDate.Before(series.StartsAt) {
+			continue
+		}
+		if valid[series.LocalDate(occurrence.EventDate)] && !series.IsException(occurrence.EventDate) {
+			continue
+		}
+
+		sold, err := soldTickets(tx, occurrence.ID)
+		if err != nil {
+			return err
+		}
+		if sold > 0 {
+			return entities.NewBusinessRuleError("occurrence_has_sales", "an occurrence the change would remove already has tickets sold; cancel it first", map[string]interface{}{
+				"event_id":   occurrence.ID,
+				"event_date": occurrence.EventDate,
+				"sold":       sold,
+			})
+		}
+
+		// Deleted events keep their slug, so free it for the date to be
+		// generated again should the rule later bring it back
+		suffix := "-removed-" + occurrence.ID.String()[:8]
+		if len(occurrence.Slug)+len(suffix) > maxSlugLength {
+			occurrence.Slug = occurrence.Slug[:maxSlugLength-len(suffix)]
+		}
+		occurrence.Slug += suffix
+		occurrence.UpdatedAt = now
+		if err := tx.Events().Update(tx.Context(), occurrence); err != nil {
+			return fmt.Errorf("failed to release occurrence slug: %w", err)
+		}
+		if err := tx.Events().Delete(tx.Context(), occurrence.ID); err != nil {
+			return fmt.Errorf("failed to remove occurrence: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// updateTiers applies tier changes to an occurrence. A tier missing from the
+// chosen occurrence is an error; other occurrences without it are skipped.
+func (s *SeriesService) updateTiers(tx repositories.Transaction, event *entities.Event, updates []OccurrenceTierUpdate, required bool) error {
+	if len(updates) == 0 {
+		return nil
+	}
+
+	tiers, err := tx.TicketTiers().GetByEvent(tx.Context(), event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket tiers: %w", err)
+	}
+
+	for _, update := range updates {
+		var tier *entities.TicketTier
+		for _, candidate := range tiers {
+			if strings.EqualFold(candidate.Name, strings.TrimSpace(update.Name)) {
+				tier = candidate
+				break
+			}
+		}
+		if tier == nil {
+			if required {
+				return entities.NewValidationError("ticket_tiers", fmt.Sprintf("ticket tier %q not found", update.Name))
+			}
+			continue
+		}
+
+		if update.Price != nil {
+			if err := tier.UpdatePrice(*update.Price); err != nil {
+				return err
+			}
+		}
+		if update.Quota != nil {
+			if *update.Quota < tier.Sold {
+				return entities.NewBusinessRuleError("quota_below_sold", "quota cannot be lower than tickets already sold", map[string]interface{}{
+					"event_id": event.ID,
+					"tier":     tier.Name,
+					"sold":     tier.Sold,
+				})
+			}
+			if err := tier.SetQuota(*update.Quota); err != nil {
+				return err
+			}
+		}
+
+		if err := tx.TicketTiers().Update(tx.Context(), tier); err != nil {
+			return fmt.Errorf("failed to update ticket tier: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (s *SeriesService) getSeries(ctx context.Context, id uuid.UUID) (*entities.EventSeries, error) {
+	series, err := s.seriesRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == entities.ErrEventSeriesNotFound {
+			return nil, entities.NewNotFoundError("event_series", "event series not found")
+		}
+		return nil, err
+	}
+	return series, nil
+}
+
+func (s *SeriesService) getEvent(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	event, err := s.eventRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == entities.ErrEventNotFound {
+			return nil, entities.NewNotFoundError("event", "event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+	return event, nil
+}
+
+func lockSeries(tx repositories.Transaction, id uuid.UUID) (*entities.EventSeries, error) {
+	series, err := tx.EventSeries().GetByIDForUpdate(tx.Context(), id)
+	if err != nil {
+		if err == entities.ErrEventSeriesNotFound {
+			return nil, entities.NewNotFoundError("event_series", "event series not found")
+		}
+		return nil, err
+	}
+	return series, nil
+}
+
+// soldTickets counts the tickets sold across an event's tiers
+func soldTickets(tx repositories.Transaction, eventID uuid.UUID) (int, error) {
+	tiers, err := tx.TicketTiers().GetByEvent(tx.Context(), eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get ticket tiers: %w", err)
+	}
+	sold := 0
+	for _, tier := range tiers {
+		sold += tier.Sold
+	}
+	return sold, nil
+}
+
+// isEditable reports whether a series-wide edit may still change an occurrence
+func isEditable(event *entities.Event) bool {
+	return event.Status != entities.EventStatusCancelled && event.Status != entities.EventStatusCompleted
+}
@@ -0,0 +1,429 @@
+package series
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+// fakeEvents stores events in memory and lists a series' occurrences in date order
+type fakeEvents struct {
+	repositories.EventRepository
+	events  map[uuid.UUID]*entities.Event
+	deleted []*entities.Event
+}
+
+func (f *fakeEvents) GetByID(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	event, ok := f.events[id]
+	if !ok {
+		return nil, entities.ErrEventNotFound
+	}
+	return event, nil
+}
+
+func (f *fakeEvents) Create(ctx context.Context, event *entities.Event) error {
+	f.events[event.ID] = event
+	return nil
+}
+
+func (f *fakeEvents) Update(ctx context.Context, event *entities.Event) error {
+	f.events[event.ID] = event
+	return nil
+}
+
+func (f *fakeEvents) Delete(ctx context.Context, id uuid.UUID) error {
+	f.deleted = append(f.deleted, f.events[id])
+	delete(f.events, id)
+	return nil
+}
+
+func (f *fakeEvents) GetBySeries(ctx context.Context, seriesID uuid.UUID) ([]*entities.Event, error) {
+	var occurrences []*entities.Event
+	for _, event := range f.events {
+		if event.SeriesID != nil && *event.SeriesID == seriesID {
+			occurrences = append(occurrences, event)
+		}
+	}
+	sort.Slice(occurrences, func(i, j int) bool { return occurrences[i].EventDate.Before(occurrences[j].EventDate) })
+	return occurrences, nil
+}
+
+type fakeTiers struct {
+	repositories.TicketTierRepository
+	byEvent map[uuid.UUID][]*entities.TicketTier
+}
+
+func (f *fakeTiers) GetByEvent(ctx context.Context, eventID uuid.UUID) ([]*entities.TicketTier, error) {
+	return f.byEvent[eventID], nil
+}
+
+func (f *fakeTiers) Create(ctx context.Context, tier *entities.TicketTier) error {
+	f.byEvent[tier.EventID] = append(f.byEvent[tier.EventID], tier)
+	return nil
+}
+
+func (f *fakeTiers) Update(ctx context.Context, tier *entities.TicketTier) error {
+	return nil
+}
+
+type fakeSeries struct {
+	repositories.EventSeriesRepository
+	series map[uuid.UUID]*entities.EventSeries
+}
+
+func (f *fakeSeries) Create(ctx context.Context, series *entities.EventSeries) error {
+	f.series[series.ID] = series
+	return nil
+}
+
+func (f *fakeSeries) GetByID(ctx context.Context, id uuid.UUID) (*entities.EventSeries, error) {
+	series, ok := f.series[id]
+	if !ok {
+		return nil, entities.ErrEventSeriesNotFound
+	}
+	return series, nil
+}
+
+func (f *fakeSeries) GetByIDForUpdate(ctx context.Context, id uuid.UUID) (*entities.EventSeries, error) {
+	return f.GetByID(ctx, id)
+}
+
+func (f *fakeSeries) Update(ctx context.Context, series *entities.EventSeries) error {
+	f.series[series.ID] = series
+	return nil
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	ctx    context.Context
+	events *fakeEvents
+	tiers  *fakeTiers
+	series *fakeSeries
+}
+
+func (tx *fakeTx) Commit() error                                   { return nil }
+func (tx *fakeTx) Rollback() error                                 { return nil }
+func (tx *fakeTx) Context() context.Context                        { return tx.ctx }
+func (tx *fakeTx) Events() repositories.EventRepository            { return tx.events }
+func (tx *fakeTx) TicketTiers() repositories.TicketTierRepository  { return tx.tiers }
+func (tx *fakeTx) EventSeries() repositories.EventSeriesRepository { return tx.series }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	u.tx.ctx = ctx
+	return u.tx, nil
+}
+
+type seriesFixture struct {
+	service  *SeriesService
+	events   *fakeEvents
+	tiers    *fakeTiers
+	template *entities.Event
+	lagos    *time.Location
+}
+
+// newSeriesFixture builds a series service over fakes, with a published
+// comedy night at 8pm Lagos time on a Friday at least two days away. The
+// night sells early bird tickets and regular tickets that open after them.
+func newSeriesFixture(t *testing.T) *seriesFixture {
+	t.Helper()
+
+	lagos, err := time.LoadLocation(entities.DefaultVenueTimezone)
+	if err != nil {
+		t.Fatalf("LoadLocation() error = %v", err)
+	}
+	start := time.Now().In(lagos).AddDate(0, 0, 2)
+	for start.Weekday() != time.Friday {
+		start = start.AddDate(0, 0, 1)
+	}
+	start = time.Date(start.Year(), start.Month(), start.Day(), 20, 0, 0, 0, lagos)
+
+	template := entities.NewEvent(uuid.New(), "Comedy Night", "comedy-night", start, "Terra Kulture", "Tiamiyu Savage", "Lagos", "NG")
+	template.Status = entities.EventStatusPublished
+
+	early := entities.NewTicketTier(template.ID, "Early Bird", 5000)
+	regular := entities.NewTicketTier(template.ID, "Regular", 8000)
+	regular.OpensAfterTierID = &early.ID
+
+	events := &fakeEvents{events: map[uuid.UUID]*entities.Event{template.ID: template}}
+	tiers := &fakeTiers{byEvent: map[uuid.UUID][]*entities.TicketTier{template.ID: {early, regular}}}
+	series := &fakeSeries{series: make(map[uuid.UUID]*entities.EventSeries)}
+	tx := &fakeTx{events: events, tiers: tiers, series: series}
+
+	return &seriesFixture{
+		service:  NewSeriesService(series, events, tiers, nil, &fakeUnitOfWork{tx: tx}),
+		events:   events,
+		tiers:    tiers,
+		template: template,
+		lagos:    lagos,
+	}
+}
+
+// createWeekly turns the template into a weekly series with a five week horizon
+func (f *seriesFixture) createWeekly(t *testing.T, exceptions ...string) *SeriesResponse {
+	t.Helper()
+
+	horizon := 35
+	resp, err := f.service.CreateSeries(context.Background(), &CreateSeriesRequest{
+		TemplateEventID: f.template.ID,
+		RRule:           "RRULE:FREQ=WEEKLY;BYDAY=FR",
+		ExceptionDates:  exceptions,
+		HorizonDays:     &horizon,
+	})
+	if err != nil {
+		t.Fatalf("CreateSeries() error = %v", err)
+	}
+	return resp
+}
+
+// week returns the date of the template's weekday n weeks after it
+func (f *seriesFixture) week(n int) time.Time {
+	return f.template.EventDate.AddDate(0, 0, 7*n)
+}
+
+func TestCreateSeriesGeneratesOccurrencesWithinHorizon(t *testing.T) {
+	f := newSeriesFixture(t)
+	skipped := f.week(2).Format("2006-01-02")
+
+	resp := f.createWeekly(t, skipped)
+
+	if f.template.SeriesID == nil || *f.template.SeriesID != resp.Series.ID {
+		t.Fatalf("template series = %v, want %s", f.template.SeriesID, resp.Series.ID)
+	}
+	if resp.Series.Name != "Comedy Night" || resp.Series.Timezone != entities.DefaultVenueTimezone {
+		t.Errorf("series = %q in %s, want the template name in %s", resp.Series.Name, resp.Series.Timezone, entities.DefaultVenueTimezone)
+	}
+
+	// Every Friday up to five weeks from now, except the skipped one
+	horizon := time.Now().AddDate(0, 0, 35)
+	var want []time.Time
+	for n := 0; !f.week(n).After(horizon); n++ {
+		if n != 2 {
+			want = append(want, f.week(n))
+		}
+	}
+	if len(resp.Occurrences) != len(want) {
+		t.Fatalf("CreateSeries() occurrences = %d, want %d", len(resp.Occurrences), len(want))
+	}
+
+	for i, occurrence := range resp.Occurrences {
+		if !occurrence.EventDate.Equal(want[i]) {
+			t.Errorf("occurrence %d on %v, want %v", i, occurrence.EventDate, want[i])
+		}
+		if i == 0 {
+			continue
+		}
+
+		wantSlug := "comedy-night-" + want[i].Format("20060102")
+		if occurrence.Slug != wantSlug || occurrence.Status != entities.EventStatusPublished {
+			t.Errorf("occurrence %d = %s (%s), want %s published", i, occurrence.Slug, occurrence.Status, wantSlug)
+		}
+
+		tiers := f.tiers.byEvent[occurrence.ID]
+		if len(tiers) != 2 || tiers[0].Sold != 0 || tiers[1].OpensAfterTierID == nil || *tiers[1].OpensAfterTierID != tiers[0].ID {
+			t.Errorf("occurrence %d tiers = %+v, want both tiers copied with regular opening after the copied early bird", i, tiers)
+		}
+	}
+}
+
+func TestGenerateOccurrencesDoesNotRepeatDates(t *testing.T) {
+	f := newSeriesFixture(t)
+	resp := f.createWeekly(t)
+	before := len(resp.Occurrences)
+
+	// Widening the horizon adds the dates now in range, and only those
+	horizon := 63
+	if _, err := f.service.UpdateSeries(context.Background(), resp.Series.ID, &UpdateSeriesRequest{HorizonDays: &horizon}); err != nil {
+		t.Fatalf("UpdateSeries() error = %v", err)
+	}
+	created, err := f.service.GenerateOccurrences(context.Background(), resp.Series.ID)
+	if err != nil {
+		t.Fatalf("GenerateOccurrences() error = %v", err)
+	}
+	if len(created) != 0 {
+		t.Errorf("GenerateOccurrences() created %d occurrences, want none after the update caught up", len(created))
+	}
+
+	after, _ := f.service.GetSeries(context.Background(), resp.Series.ID)
+	if len(after.Occurrences) != before+4 {
+		t.Errorf("occurrences after widening = %d, want %d", len(after.Occurrences), before+4)
+	}
+	seen := make(map[string]bool)
+	for _, occurrence := range after.Occurrences {
+		if seen[occurrence.Slug] {
+			t.Errorf("occurrence %s generated twice", occurrence.Slug)
+		}
+		seen[occurrence.Slug] = true
+	}
+}
+
+func TestCreateSeriesRejectsInvalidTemplates(t *testing.T) {
+	f := newSeriesFixture(t)
+
+	_, err := f.service.CreateSeries(context.Background(), &CreateSeriesRequest{
+		TemplateEventID: f.template.ID,
+		RRule:           "FREQ=WEEKLY;BYDAY=SA",
+	})
+	var validationErr *entities.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "rrule" {
+		t.Errorf("CreateSeries() on a Saturday rule error = %v, want a validation error on rrule", err)
+	}
+
+	f.createWeekly(t)
+	_, err = f.service.CreateSeries(context.Background(), &CreateSeriesRequest{
+		TemplateEventID: f.template.ID,
+		RRule:           "FREQ=WEEKLY;BYDAY=FR",
+	})
+	var conflictErr *entities.ConflictError
+	if !errors.As(err, &conflictErr) {
+		t.Errorf("CreateSeries() for an event already in a series error = %v, want a conflict", err)
+	}
+}
+
+func TestUpdateSeriesRemovesOccurrencesOffTheSchedule(t *testing.T) {
+	f := newSeriesFixture(t)
+	resp := f.createWeekly(t)
+	first := resp.Occurrences[1]
+
+	cancelled := f.week(1).Format("2006-01-02")
+	updated, err := f.service.UpdateSeries(context.Background(), resp.Series.ID, &UpdateSeriesRequest{ExceptionDates: &[]string{cancelled}})
+	if err != nil {
+		t.Fatalf("UpdateSeries() error = %v", err)
+	}
+
+	if len(f.events.deleted) != 1 || f.events.deleted[0].ID != first.ID {
+		t.Fatalf("UpdateSeries() deleted %d occurrences, want only the one on %s", len(f.events.deleted), cancelled)
+	}
+	if !strings.HasPrefix(first.Slug, "comedy-night-") || !strings.Contains(first.Slug, "-removed-") {
+		t.Errorf("removed occurrence slug = %s, want it released", first.Slug)
+	}
+	if len(updated.Occurrences) != len(resp.Occurrences)-1 {
+		t.Errorf("occurrences after update = %d, want %d", len(updated.Occurrences), len(resp.Occurrences)-1)
+	}
+}
+
+func TestUpdateSeriesKeepsOccurrencesWithSales(t *testing.T) {
+	f := newSeriesFixture(t)
+	resp := f.createWeekly(t)
+	sold := resp.Occurrences[2]
+	f.tiers.byEvent[sold.ID][0].Sold = 3
+
+	_, err := f.service.UpdateSeries(context.Background(), resp.Series.ID, &UpdateSeriesRequest{ExceptionDates: &[]string{f.week(2).Format("2006-01-02")}})
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "occurrence_has_sales" {
+		t.Fatalf("UpdateSeries() error = %v, want occurrence_has_sales", err)
+	}
+	if _, ok := f.events.events[sold.ID]; !ok {
+		t.Errorf("occurrence with tickets sold was removed")
+	}
+}
+
+func TestUpdateOccurrenceScopes(t *testing.T) {
+	f := newSeriesFixture(t)
+	resp := f.createWeekly(t)
+	second := resp.Occurrences[2]
+
+	// Moving this and the following nights to 9pm
+	name := "Comedy Night Late"
+	startTime := "21:00"
+	targets, err := f.service.UpdateOccurrence(context.Background(), second.ID, &UpdateOccurrenceRequest{
+		Scope:     ScopeFollowing,
+		Name:      &name,
+		StartTime: &startTime,
+	})
+	if err != nil {
+		t.Fatalf("UpdateOccurrence() error = %v", err)
+	}
+	if len(targets) != len(resp.Occurrences)-2 {
+		t.Errorf("UpdateOccurrence() edited %d occurrences, want %d", len(targets), len(resp.Occurrences)-2)
+	}
+
+	series, _ := f.service.GetSeries(context.Background(), resp.Series.ID)
+	for i, occurrence := range series.Occurrences {
+		wantName, wantHour := "Comedy Night", 20
+		if i >= 2 {
+			wantName, wantHour = name, 21
+		}
+		if occurrence.Name != wantName || occurrence.EventDate.In(f.lagos).Hour() != wantHour {
+			t.Errorf("occurrence %d = %q at %v, want %q at %d:00", i, occurrence.Name, occurrence.EventDate.In(f.lagos), wantName, wantHour)
+		}
+	}
+	if series.Series.TemplateEventID != second.ID {
+		t.Errorf("template = %s, want the edited occurrence %s", series.Series.TemplateEventID, second.ID)
+	}
+
+	// Editing only the template hands the template role to the next occurrence
+	special := "Comedy Night Special"
+	if _, err := f.service.UpdateOccurrence(context.Background(), second.ID, &UpdateOccurrenceRequest{Scope: ScopeThis, Name: &special}); err != nil {
+		t.Fatalf("UpdateOccurrence() error = %v", err)
+	}
+	series, _ = f.service.GetSeries(context.Background(), resp.Series.ID)
+	if series.Series.TemplateEventID != series.Occurrences[3].ID || series.Occurrences[3].Name != name {
+		t.Errorf("template after a single edit = %s, want the next occurrence", series.Series.TemplateEventID)
+	}
+}
+
+func TestRecurrenceRuleOccurrences(t *testing.T) {
+	date := func(month time.Month, day int) time.Time {
+		return time.Date(2026, month, day, 20, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		rule    string
+		dtstart time.Time
+		want    []time.Time
+	}{
+		{rule: "FREQ=WEEKLY;INTERVAL=2;BYDAY=MO,TH;COUNT=4", dtstart: date(time.January, 5), want: []time.Time{date(time.January, 5), date(time.January, 8), date(time.January, 19), date(time.January, 22)}},
+		{rule: "FREQ=WEEKLY;BYDAY=SU;UNTIL=20260315", dtstart: date(time.March, 1), want: []time.Time{date(time.March, 1), date(time.March, 8), date(time.March, 15)}},
+		{rule: "FREQ=MONTHLY;BYDAY=-1FR;COUNT=3", dtstart: date(time.January, 30), want: []time.Time{date(time.January, 30), date(time.February, 27), date(time.March, 27)}},
+		{rule: "FREQ=MONTHLY;BYMONTHDAY=31;COUNT=3", dtstart: date(time.January, 31), want: []time.Time{date(time.January, 31), date(time.March, 31), date(time.May, 31)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.rule, func(t *testing.T) {
+			rule, err := entities.ParseRecurrenceRule(tt.rule)
+			if err != nil {
+				t.Fatalf("ParseRecurrenceRule() error = %v", err)
+			}
+			got := rule.Occurrences(tt.dtstart, tt.dtstart.AddDate(1, 0, 0), 0)
+			if len(got) != len(tt.want) {
+				t.Fatalf("Occurrences() = %v, want %v", got, tt.want)
+			}
+			for i := range got {
+				if !got[i].Equal(tt.want[i]) {
+					t.Errorf("Occurrences()[%d] = %v, want %v", i, got[i], tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestParseRecurrenceRuleRejectsUnsupportedRules(t *testing.T) {
+	for _, value := range []string{
+		"",
+		"FREQ=DAILY",
+		"FREQ=WEEKLY;BYDAY=1FR",
+		"FREQ=WEEKLY;BYMONTHDAY=1",
+		"FREQ=MONTHLY;BYDAY=FR;BYMONTHDAY=13",
+		"FREQ=MONTHLY;COUNT=2;UNTIL=20260101",
+		"FREQ=WEEKLY;BYHOUR=20",
+	} {
+		var validationErr *entities.ValidationError
+		if _, err := entities.ParseRecurrenceRule(value); !errors.As(err, &validationErr) || validationErr.Field != "rrule" {
+			t.Errorf("ParseRecurrenceRule(%q) error = %v, want a validation error on rrule", value, err)
+		}
+	}
+}
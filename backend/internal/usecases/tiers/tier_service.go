@@ -0,0 +1,322 @@
+package tiers
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// DefaultPriceSchedulerInterval is how often due price changes are applied
+const DefaultPriceSchedulerInterval = time.Minute
+
+// DefaultSimulationPoints is the number of points on a simulated revenue curve
+const DefaultSimulationPoints = 20
+
+// TierService manages when ticket tiers are on sale and at what price. Tiers
+// can be sequenced so one opens when another sells out or ends, and can be
+// given price changes that take effect at a set time. Due changes are applied
+// by a background scheduler and, for the event being bought, at checkout, so
+// an order is never charged a price that has already been replaced. A tier can
+// also follow a dynamic pricing strategy, whose revenue can be simulated first.
+type TierService struct {
+	ticketTierRepo  repositories.TicketTierRepository
+	priceChangeRepo repositories.TierPriceChangeRepository
+	eventRepo       repositories.EventRepository
+}
+
+// NewTierService creates a new tier service
+func NewTierService(
+	ticketTierRepo repositories.TicketTierRepository,
+	priceChangeRepo repositories.TierPriceChangeRepository,
+	eventRepo repositories.EventRepository,
+) *TierService {
+	return &TierService{
+		ticketTierRepo:  ticketTierRepo,
+		priceChangeRepo: priceChangeRepo,
+		eventRepo:       eventRepo,
+	}
+}
+
+// SetSequenceRequest represents the request to sequence a tier after another
+type SetSequenceRequest struct {
+	// OpensAfterTierID is the tier that must sell out or end first; null removes the sequencing
+	OpensAfterTierID *uuid.UUID `json:"opens_after_tier_id"`
+}
+
+// SchedulePriceChangeRequest represents the request to schedule a tier price change
+type SchedulePriceChangeRequest struct {
+	Price       float64    `json:"price" validate:"gte=0"`
+	EffectiveAt time.Time  `json:"effective_at" validate:"required"`
+	Reason      *string    `json:"reason,omitempty" validate:"omitempty,max=500"`
+	CreatedBy   *uuid.UUID `json:"-"`
+}
+
+// SetPricingStrategyRequest represents the request to set a tier's dynamic pricing
+type SetPricingStrategyRequest struct {
+	// Strategy is the pricing strategy to follow; null returns the tier to its fixed price
+	Strategy *entities.PricingStrategy `json:"strategy"`
+}
+
+// SimulatePricingRequest represents the request to preview a tier's revenue under dynamic pricing
+type SimulatePricingRequest struct {
+	// Strategy previews an unsaved strategy; the tier's own strategy is used when omitted
+	Strategy           *entities.PricingStrategy `json:"strategy,omitempty"`
+	Points             int                       `json:"points,omitempty" validate:"omitempty,min=2,max=200"`
+	SellThroughPercent float64                   `json:"sell_through_percent,omitempty" validate:"omitempty,gt=0,lte=100"`
+}
+
+// PricingSimulationPoint is one point on a simulated revenue curve
+type PricingSimulationPoint struct {
+	At          time.Time `json:"at"`
+	TicketsSold int       `json:"tickets_sold"`
+	SoldPercent float64   `json:"sold_percent"`
+	Price       float64   `json:"price"`
+	Revenue     float64   `json:"revenue"`
+}
+
+// PricingSimulation is the revenue a tier would make selling at an even pace
+// over its sale window, against selling every ticket at the base price
+type PricingSimulation struct {
+	TicketTierID  uuid.UUID                 `json:"ticket_tier_id"`
+	BasePrice     float64                   `json:"base_price"`
+	Currency      string                    `json:"currency"`
+	Quota         int                       `json:"quota"`
+	SaleStart     time.Time                 `json:"sale_start"`
+	SaleEnd       time.Time                 `json:"sale_end"`
+	Strategy      *entities.PricingStrategy `json:"strategy,omitempty"`
+	Points        []PricingSimulationPoint  `json:"points"`
+	TotalRevenue  float64                   `json:"total_revenue"`
+	FixedRevenue  float64                   `json:"fixed_revenue"`
+	UpliftPercent float64                   `json:"uplift_percent"`
+}
+
+// SetPricingStrategy sets or removes a tier's dynamic pricing. Orders already
+// holding tickets keep the price they were quoted.
+func (s *TierService) SetPricingStrategy(ctx context.Context, tierID uuid.UUID, req *SetPricingStrategyRequest) (*entities.TicketTier, error) {
+	tier, err := s.getTier(ctx, tierID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := tier.SetPricingStrategy(req.Strategy); err != nil {
+		return nil, err
+	}
+
+	if err := s.ticketTierRepo.Update(ctx, tier); err != nil {
+		return nil, fmt.Errorf("failed to update ticket tier: %w", err)
+	}
+	return tier, nil
+}
+
+// SimulatePricing previews the revenue curve of a tier's pricing strategy.
+// Sales are spread evenly from the start of the tier's sale window, or now, to
+// its end, or the event; each point is priced as the tickets before it were
+// sold, the way buyers are quoted at checkout.
+func (s *TierService) SimulatePricing(ctx context.Context, tierID uuid.UUID, req *SimulatePricingRequest) (*PricingSimulation, error) {
+	tier, err := s.getTier(ctx, tierID)
+	if err != nil {
+		return nil, err
+	}
+	event, err := s.eventRepo.GetByID(ctx, tier.EventID)
+	if err != nil {
+		if errors.Is(err, entities.ErrEventNotFound) {
+			return nil, entities.NewNotFoundError("event", "event not found")
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	strategy := tier.PricingStrategy
+	if req.Strategy != nil {
+		if err := req.Strategy.Validate(); err != nil {
+			return nil, err
+		}
+		strategy = req.Strategy
+	}
+
+	points := req.Points
+	if points == 0 {
+		points = DefaultSimulationPoints
+	}
+	sellThrough := req.SellThroughPercent
+	if sellThrough == 0 {
+		sellThrough = 100
+	}
+
+	start := time.Now()
+	if tier.SaleStart != nil && tier.SaleStart.After(start) {
+		start = *tier.SaleStart
+	}
+	end := event.EventDate
+	if tier.SaleEnd != nil && tier.SaleEnd.Before(end) {
+		end = *tier.SaleEnd
+	}
+	if !end.After(start) {
+		return nil, entities.NewBusinessRuleError("sale_window_closed", "the tier has no sale time left to simulate", map[string]interface{}{
+			"ticket_tier_id": tier.ID,
+		})
+	}
+
+	toSell := int(math.Round(float64(tier.Quota) * sellThrough / 100))
+	simulation := &PricingSimulation{
+		TicketTierID: tier.ID,
+		BasePrice:    tier.Price,
+		Currency:     tier.Currency,
+		Quota:        tier.Quota,
+		SaleStart:    start,
+		SaleEnd:      end,
+		Strategy:     strategy,
+		Points:       make([]PricingSimulationPoint, 0, points),
+		FixedRevenue: tier.Price * float64(toSell),
+	}
+
+	sold := 0
+	for i := 1; i <= points; i++ {
+		at := start.Add(time.Duration(float64(end.Sub(start)) * float64(i-1) / float64(points)))
+		price := entities.QuoteTierPrice(tier.Price, strategy, tier.Quota, sold, event.EventDate, at)
+
+		soldAfter := toSell * i / points
+		simulation.TotalRevenue += price * float64(soldAfter-sold)
+		sold = soldAfter
+
+		simulation.Points = append(simulation.Points, PricingSimulationPoint{
+			At:          at,
+			TicketsSold: sold,
+			SoldPercent: math.Round(float64(sold)/float64(tier.Quota)*10000) / 100,
+			Price:       price,
+			Revenue:     math.Round(simulation.TotalRevenue*100) / 100,
+		})
+	}
+
+	simulation.TotalRevenue = math.Round(simulation.TotalRevenue*100) / 100
+	if simulation.FixedRevenue > 0 {
+		simulation.UpliftPercent = math.Round((simulation.TotalRevenue/simulation.FixedRevenue-1)*10000) / 100
+	}
+	return simulation, nil
+}
+
+// SetSequence makes a tier open only once another tier of its event has sold out or ended
+func (s *TierService) SetSequence(ctx context.Context, tierID uuid.UUID, req *SetSequenceRequest) (*entities.TicketTier, error) {
+	tier, err := s.getTier(ctx, tierID)
+	if err != nil {
+		return nil, err
+	}
+
+	var previous *entities.TicketTier
+	if req.OpensAfterTierID != nil {
+		previous, err = s.getTier(ctx, *req.OpensAfterTierID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := tier.OpensAfter(previous); err != nil {
+		return nil, err
+	}
+
+	eventTiers, err := s.ticketTierRepo.GetByEvent(ctx, tier.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket tiers: %w", err)
+	}
+	for i, eventTier := range eventTiers {
+		if eventTier.ID == tier.ID {
+			eventTiers[i] = tier
+		}
+	}
+	if err := entities.ValidateTierSequence(eventTiers); err != nil {
+		return nil, err
+	}
+
+	if err := s.ticketTierRepo.Update(ctx, tier); err != nil {
+		return nil, fmt.Errorf("failed to update ticket tier: %w", err)
+	}
+	return tier, nil
+}
+
+// SchedulePriceChange schedules a tier to switch to a new price at a set time
+func (s *TierService) SchedulePriceChange(ctx context.Context, tierID uuid.UUID, req *SchedulePriceChangeRequest) (*entities.TierPriceChange, error) {
+	tier, err := s.getTier(ctx, tierID)
+	if err != nil {
+		return nil, err
+	}
+
+	change := entities.NewTierPriceChange(tier.ID, req.Price, req.EffectiveAt)
+	change.Reason = req.Reason
+	change.CreatedBy = req.CreatedBy
+	if err := change.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.priceChangeRepo.Create(ctx, change); err != nil {
+		return nil, fmt.Errorf("failed to schedule price change: %w", err)
+	}
+	return change, nil
+}
+
+// ListPriceChanges lists a tier's scheduled, applied and cancelled price changes
+func (s *TierService) ListPriceChanges(ctx context.Context, filter repositories.TierPriceChangeFilter) ([]*entities.TierPriceChange, *repositories.PaginationResult, error) {
+	if _, err := s.getTier(ctx, filter.TicketTierID); err != nil {
+		return nil, nil, err
+	}
+	return s.priceChangeRepo.List(ctx, filter)
+}
+
+// CancelPriceChange withdraws a scheduled price change before it takes effect
+func (s *TierService) CancelPriceChange(ctx context.Context, tierID, changeID uuid.UUID) (*entities.TierPriceChange, error) {
+	change, err := s.priceChangeRepo.GetByID(ctx, changeID)
+	if err != nil {
+		if errors.Is(err, entities.ErrTierPriceChangeNotFound) {
+			return nil, entities.NewNotFoundError("price_change", "price change not found")
+		}
+		return nil, err
+	}
+	if change.TicketTierID != tierID {
+		return nil, entities.NewNotFoundError("price_change", "price change not found")
+	}
+
+	if err := change.Cancel(); err != nil {
+		return nil, err
+	}
+	if err := s.priceChangeRepo.Update(ctx, change); err != nil {
+		return nil, fmt.Errorf("failed to cancel price change: %w", err)
+	}
+	return change, nil
+}
+
+// ApplyDuePriceChanges switches every tier with a due price change to its new price
+func (s *TierService) ApplyDuePriceChanges(ctx context.Context) (int, error) {
+	return s.priceChangeRepo.ApplyDue(ctx, nil)
+}
+
+// RunPriceScheduler applies due price changes every interval until ctx is done
+func (s *TierService) RunPriceScheduler(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.ApplyDuePriceChanges(ctx); err != nil {
+			fmt.Printf("Warning: failed to apply scheduled ticket prices: %v\n", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (s *TierService) getTier(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
+	tier, err := s.ticketTierRepo.GetByID(ctx, id)
+	if err != nil {
+		if errors.Is(err, entities.ErrNotFoundError) || errors.Is(err, entities.ErrTicketTierNotFound) {
+			return nil, entities.NewNotFoundError("ticket_tier", "ticket tier not found")
+		}
+		return nil, fmt.Errorf("failed to get ticket tier: %w", err)
+	}
+	return tier, nil
+}
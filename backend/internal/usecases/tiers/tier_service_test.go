@@ -0,0 +1,460 @@
+package tiers
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+type fakeTiers struct {
+	repositories.TicketTierRepository
+	tiers   map[uuid.UUID]*entities.TicketTier
+	updated []*entities.TicketTier
+}
+
+func (f *fakeTiers) GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
+	tier, ok := f.tiers[id]
+	if !ok {
+		return nil, entities.ErrTicketTierNotFound
+	}
+	copied := *tier
+	return &copied, nil
+}
+
+func (f *fakeTiers) GetByEvent(ctx context.Context, eventID uuid.UUID) ([]*entities.TicketTier, error) {
+	var tiers []*entities.TicketTier
+	for _, tier := range f.tiers {
+		if tier.EventID == eventID {
+			copied := *tier
+			tiers = append(tiers, &copied)
+		}
+	}
+	return tiers, nil
+}
+
+func (f *fakeTiers) Update(ctx context.Context, tier *entities.TicketTier) error {
+	f.tiers[tier.ID] = tier
+	f.updated = append(f.updated, tier)
+	return nil
+}
+
+type fakePriceChanges struct {
+	repositories.TierPriceChangeRepository
+	changes map[uuid.UUID]*entities.TierPriceChange
+}
+
+func (f *fakePriceChanges) Create(ctx context.Context, change *entities.TierPriceChange) error {
+	f.changes[change.ID] = change
+	return nil
+}
+
+func (f *fakePriceChanges) GetByID(ctx context.Context, id uuid.UUID) (*entities.TierPriceChange, error) {
+	change, ok := f.changes[id]
+	if !ok {
+		return nil, entities.ErrTierPriceChangeNotFound
+	}
+	return change, nil
+}
+
+func (f *fakePriceChanges) Update(ctx context.Context, change *entities.TierPriceChange) error {
+	f.changes[change.ID] = change
+	return nil
+}
+
+type fakeEvents struct {
+	repositories.EventRepository
+	event *entities.Event
+}
+
+func (f *fakeEvents) GetByID(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	if id != f.event.ID {
+		return nil, entities.ErrEventNotFound
+	}
+	return f.event, nil
+}
+
+type tierFixture struct {
+	service *TierService
+	tiers   *fakeTiers
+	changes *fakePriceChanges
+	event   *entities.Event
+	early   *entities.TicketTier
+	regular *entities.TicketTier
+	vip     *entities.TicketTier
+	other   *entities.TicketTier
+}
+
+// newTierFixture builds a tier service over fakes, with an event in 30 days
+// selling early bird, regular and VIP tickets, and a tier of another event
+func newTierFixture(t *testing.T) *tierFixture {
+	t.Helper()
+
+	event := entities.NewEvent(uuid.New(), "Detty December", "detty-december", time.Now().AddDate(0, 0, 30), "Eko Atlantic", "Eko Atlantic", "Lagos", "NG")
+	early := entities.NewTicketTier(event.ID, "Early Bird", 10000)
+	regular := entities.NewTicketTier(event.ID, "Regular", 15000)
+	vip := entities.NewTicketTier(event.ID, "VIP", 50000)
+	other := entities.NewTicketTier(uuid.New(), "Regular", 15000)
+
+	tiers := &fakeTiers{tiers: make(map[uuid.UUID]*entities.TicketTier)}
+	for _, tier := range []*entities.TicketTier{early, regular, vip, other} {
+		tier.Quota = 100
+		tiers.tiers[tier.ID] = tier
+	}
+	changes := &fakePriceChanges{changes: make(map[uuid.UUID]*entities.TierPriceChange)}
+
+	return &tierFixture{
+		service: NewTierService(tiers, changes, &fakeEvents{event: event}),
+		tiers:   tiers,
+		changes: changes,
+		event:   event,
+		early:   early,
+		regular: regular,
+		vip:     vip,
+		other:   other,
+	}
+}
+
+func TestSetSequenceOpensTierAfterAnother(t *testing.T) {
+	f := newTierFixture(t)
+	ctx := context.Background()
+
+	tier, err := f.service.SetSequence(ctx, f.regular.ID, &SetSequenceRequest{OpensAfterTierID: &f.early.ID})
+	if err != nil {
+		t.Fatalf("SetSequence() error = %v", err)
+	}
+	if tier.OpensAfterTierID == nil || *tier.OpensAfterTierID != f.early.ID {
+		t.Errorf("regular opens after %v, want early bird", tier.OpensAfterTierID)
+	}
+
+	tier, err = f.service.SetSequence(ctx, f.regular.ID, &SetSequenceRequest{})
+	if err != nil {
+		t.Fatalf("SetSequence() clearing error = %v", err)
+	}
+	if tier.OpensAfterTierID != nil {
+		t.Errorf("regular opens after %v, want the sequencing removed", tier.OpensAfterTierID)
+	}
+}
+
+func TestSetSequenceRejectsInvalidSequences(t *testing.T) {
+	f := newTierFixture(t)
+	ctx := context.Background()
+
+	// Regular after early bird, and VIP after regular
+	if _, err := f.service.SetSequence(ctx, f.regular.ID, &SetSequenceRequest{OpensAfterTierID: &f.early.ID}); err != nil {
+		t.Fatalf("SetSequence() error = %v", err)
+	}
+	if _, err := f.service.SetSequence(ctx, f.vip.ID, &SetSequenceRequest{OpensAfterTierID: &f.regular.ID}); err != nil {
+		t.Fatalf("SetSequence() error = %v", err)
+	}
+	updates := len(f.tiers.updated)
+
+	tests := []struct {
+		name          string
+		tier, opensOn uuid.UUID
+	}{
+		{name: "itself", tier: f.early.ID, opensOn: f.early.ID},
+		{name: "another event", tier: f.early.ID, opensOn: f.other.ID},
+		{name: "loop", tier: f.early.ID, opensOn: f.vip.ID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.SetSequence(ctx, tt.tier, &SetSequenceRequest{OpensAfterTierID: &tt.opensOn})
+			var validationErr *entities.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != "opens_after_tier_id" {
+				t.Errorf("SetSequence() error = %v, want a validation error on opens_after_tier_id", err)
+			}
+		})
+	}
+	if len(f.tiers.updated) != updates {
+		t.Errorf("SetSequence() saved %d invalid sequences", len(f.tiers.updated)-updates)
+	}
+}
+
+func TestResolveTierSaleStates(t *testing.T) {
+	now := time.Now()
+	earlier := now.Add(-time.Hour)
+	later := now.Add(time.Hour)
+	early, regular, vip := uuid.New(), uuid.New(), uuid.New()
+
+	tests := []struct {
+		name      string
+		schedules []entities.TierSchedule
+		want      map[uuid.UUID]entities.TierSaleState
+	}{
+		{
+			name: "early bird selling holds back the next tiers",
+			schedules: []entities.TierSchedule{
+				{TierID: early, Available: 10},
+				{TierID: regular, OpensAfterTierID: &early, Available: 100},
+				{TierID: vip, OpensAfterTierID: &regular, Available: 20},
+			},
+			want: map[uuid.UUID]entities.TierSaleState{early: entities.TierSaleStateOnSale, regular: entities.TierSaleStateUpcoming, vip: entities.TierSaleStateUpcoming},
+		},
+		{
+			name: "early bird sold out opens regular",
+			schedules: []entities.TierSchedule{
+				{TierID: early, Available: 0},
+				{TierID: regular, OpensAfterTierID: &early, Available: 100},
+			},
+			want: map[uuid.UUID]entities.TierSaleState{early: entities.TierSaleStateSoldOut, regular: entities.TierSaleStateOnSale},
+		},
+		{
+			name: "early bird ended opens regular",
+			schedules: []entities.TierSchedule{
+				{TierID: early, SaleEnd: &earlier, Available: 10},
+				{TierID: regular, OpensAfterTierID: &early, Available: 100},
+			},
+			want: map[uuid.UUID]entities.TierSaleState{early: entities.TierSaleStateEnded, regular: entities.TierSaleStateOnSale},
+		},
+		{
+			name: "own window not open yet",
+			schedules: []entities.TierSchedule{
+				{TierID: early, SaleEnd: &earlier, Available: 10},
+				{TierID: regular, SaleStart: &later, OpensAfterTierID: &early, Available: 100},
+			},
+			want: map[uuid.UUID]entities.TierSaleState{early: entities.TierSaleStateEnded, regular: entities.TierSaleStateUpcoming},
+		},
+		{
+			name: "deleted predecessor",
+			schedules: []entities.TierSchedule{
+				{TierID: regular, OpensAfterTierID: &early, Available: 100},
+			},
+			want: map[uuid.UUID]entities.TierSaleState{regular: entities.TierSaleStateOnSale},
+		},
+		{
+			name: "cycle",
+			schedules: []entities.TierSchedule{
+				{TierID: early, OpensAfterTierID: &regular, Available: 10},
+				{TierID: regular, OpensAfterTierID: &early, Available: 10},
+			},
+			want: map[uuid.UUID]entities.TierSaleState{early: entities.TierSaleStateUpcoming, regular: entities.TierSaleStateUpcoming},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			states := entities.ResolveTierSaleStates(tt.schedules, now)
+			for id, want := range tt.want {
+				if states[id] != want {
+					t.Errorf("state = %s, want %s", states[id], want)
+				}
+			}
+		})
+	}
+}
+
+func TestSchedulePriceChange(t *testing.T) {
+	f := newTierFixture(t)
+	ctx := context.Background()
+	reason := "early bird ends"
+	createdBy := uuid.New()
+	effectiveAt := time.Now().AddDate(0, 0, 7)
+
+	change, err := f.service.SchedulePriceChange(ctx, f.regular.ID, &SchedulePriceChangeRequest{
+		Price:       18000,
+		EffectiveAt: effectiveAt,
+		Reason:      &reason,
+		CreatedBy:   &createdBy,
+	})
+	if err != nil {
+		t.Fatalf("SchedulePriceChange() error = %v", err)
+	}
+	if change.Status != entities.TierPriceChangeScheduled || change.TicketTierID != f.regular.ID || change.Price != 18000 {
+		t.Errorf("SchedulePriceChange() = %+v, want 18000 scheduled for the regular tier", change)
+	}
+	if f.changes.changes[change.ID] == nil || *f.changes.changes[change.ID].CreatedBy != createdBy {
+		t.Errorf("price change was not saved with who scheduled it")
+	}
+
+	_, err = f.service.SchedulePriceChange(ctx, f.regular.ID, &SchedulePriceChangeRequest{Price: 18000, EffectiveAt: time.Now().Add(-time.Minute)})
+	var validationErr *entities.ValidationError
+	if !errors.As(err, &validationErr) || validationErr.Field != "effective_at" {
+		t.Errorf("SchedulePriceChange() in the past error = %v, want a validation error on effective_at", err)
+	}
+
+	_, err = f.service.SchedulePriceChange(ctx, uuid.New(), &SchedulePriceChangeRequest{Price: 18000, EffectiveAt: effectiveAt})
+	var notFoundErr *entities.NotFoundError
+	if !errors.As(err, &notFoundErr) {
+		t.Errorf("SchedulePriceChange() for an unknown tier error = %v, want not found", err)
+	}
+}
+
+func TestCancelPriceChange(t *testing.T) {
+	f := newTierFixture(t)
+	ctx := context.Background()
+
+	change, err := f.service.SchedulePriceChange(ctx, f.regular.ID, &SchedulePriceChangeRequest{Price: 18000, EffectiveAt: time.Now().AddDate(0, 0, 7)})
+	if err != nil {
+		t.Fatalf("SchedulePriceChange() error = %v", err)
+	}
+
+	var notFoundErr *entities.NotFoundError
+	if _, err := f.service.CancelPriceChange(ctx, f.vip.ID, change.ID); !errors.As(err, &notFoundErr) {
+		t.Errorf("CancelPriceChange() through another tier error = %v, want not found", err)
+	}
+
+	cancelled, err := f.service.CancelPriceChange(ctx, f.regular.ID, change.ID)
+	if err != nil {
+		t.Fatalf("CancelPriceChange() error = %v", err)
+	}
+	if cancelled.Status != entities.TierPriceChangeCancelled || cancelled.CancelledAt == nil {
+		t.Errorf("CancelPriceChange() = %s, want cancelled with the time", cancelled.Status)
+	}
+
+	// Applied changes are price history and stay as they are
+	applied := entities.NewTierPriceChange(f.regular.ID, 12000, time.Now().Add(-time.Hour))
+	applied.Status = entities.TierPriceChangeApplied
+	f.changes.changes[applied.ID] = applied
+	_, err = f.service.CancelPriceChange(ctx, f.regular.ID, applied.ID)
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "price_change_not_scheduled" {
+		t.Errorf("CancelPriceChange() of an applied change error = %v, want price_change_not_scheduled", err)
+	}
+}
+
+func TestPricingStrategyQuote(t *testing.T) {
+	floor, ceiling := 12000.0, 18000.0
+	steps := &entities.PricingStrategy{
+		Type:  entities.PricingStrategyInventory,
+		Steps: []entities.PricingStep{{SoldPercent: 80, Multiplier: 2}, {SoldPercent: 50, Multiplier: 1.5}},
+	}
+	curve := &entities.PricingStrategy{
+		Type:  entities.PricingStrategyTime,
+		Curve: []entities.PricingCurvePoint{{HoursBefore: 24, Multiplier: 2}, {HoursBefore: 720, Multiplier: 1}},
+	}
+	clamped := &entities.PricingStrategy{
+		Type:    entities.PricingStrategyInventory,
+		Steps:   []entities.PricingStep{{SoldPercent: 0, Multiplier: 0.5}, {SoldPercent: 90, Multiplier: 3}},
+		Floor:   &floor,
+		Ceiling: &ceiling,
+	}
+
+	tests := []struct {
+		name       string
+		strategy   *entities.PricingStrategy
+		soldRatio  float64
+		untilEvent time.Duration
+		want       float64
+	}{
+		{name: "before the first step", strategy: steps, soldRatio: 0.49, want: 10000},
+		{name: "on a step", strategy: steps, soldRatio: 0.5, want: 15000},
+		{name: "highest step reached", strategy: steps, soldRatio: 0.95, want: 20000},
+		{name: "before the curve", strategy: curve, untilEvent: 1000 * time.Hour, want: 10000},
+		{name: "along the curve", strategy: curve, untilEvent: 372 * time.Hour, want: 15000},
+		{name: "after the curve", strategy: curve, untilEvent: 2 * time.Hour, want: 20000},
+		{name: "floor", strategy: clamped, soldRatio: 0.1, want: 12000},
+		{name: "ceiling", strategy: clamped, soldRatio: 0.95, want: 18000},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.strategy.Quote(10000, tt.soldRatio, tt.untilEvent); got != tt.want {
+				t.Errorf("Quote() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetPricingStrategy(t *testing.T) {
+	f := newTierFixture(t)
+	ctx := context.Background()
+	floor, ceiling := 20000.0, 10000.0
+
+	invalid := []*entities.PricingStrategy{
+		{Type: "surge"},
+		{Type: entities.PricingStrategyInventory},
+		{Type: entities.PricingStrategyInventory, Steps: []entities.PricingStep{{SoldPercent: 100, Multiplier: 2}}},
+		{Type: entities.PricingStrategyTime, Curve: []entities.PricingCurvePoint{{HoursBefore: 24, Multiplier: 0}}},
+		{Type: entities.PricingStrategyInventory, Steps: []entities.PricingStep{{SoldPercent: 50, Multiplier: 2}}, Floor: &floor, Ceiling: &ceiling},
+	}
+	for _, strategy := range invalid {
+		var validationErr *entities.ValidationError
+		if _, err := f.service.SetPricingStrategy(ctx, f.regular.ID, &SetPricingStrategyRequest{Strategy: strategy}); !errors.As(err, &validationErr) {
+			t.Errorf("SetPricingStrategy(%+v) error = %v, want a validation error", strategy, err)
+		}
+	}
+	if len(f.tiers.updated) != 0 {
+		t.Fatalf("SetPricingStrategy() saved %d invalid strategies", len(f.tiers.updated))
+	}
+
+	strategy := &entities.PricingStrategy{Type: entities.PricingStrategyInventory, Steps: []entities.PricingStep{{SoldPercent: 50, Multiplier: 1.5}}}
+	tier, err := f.service.SetPricingStrategy(ctx, f.regular.ID, &SetPricingStrategyRequest{Strategy: strategy})
+	if err != nil {
+		t.Fatalf("SetPricingStrategy() error = %v", err)
+	}
+	if tier.PricingStrategy != strategy || tier.Price != 15000 {
+		t.Errorf("tier strategy = %+v at %v, want the strategy over the 15000 base price", tier.PricingStrategy, tier.Price)
+	}
+
+	tier, err = f.service.SetPricingStrategy(ctx, f.regular.ID, &SetPricingStrategyRequest{})
+	if err != nil {
+		t.Fatalf("SetPricingStrategy() removing error = %v", err)
+	}
+	if tier.PricingStrategy != nil {
+		t.Errorf("tier strategy = %+v, want it back on its fixed price", tier.PricingStrategy)
+	}
+}
+
+func TestSimulatePricingRevenueCurve(t *testing.T) {
+	f := newTierFixture(t)
+	ctx := context.Background()
+	ceiling := 14000.0
+	f.early.PricingStrategy = &entities.PricingStrategy{
+		Type:  entities.PricingStrategyInventory,
+		Steps: []entities.PricingStep{{SoldPercent: 50, Multiplier: 1.5}},
+	}
+
+	// Half the quota sells at the base price and half at one and a half times it
+	simulation, err := f.service.SimulatePricing(ctx, f.early.ID, &SimulatePricingRequest{Points: 4})
+	if err != nil {
+		t.Fatalf("SimulatePricing() error = %v", err)
+	}
+	if len(simulation.Points) != 4 || !simulation.SaleEnd.Equal(f.event.EventDate) {
+		t.Fatalf("SimulatePricing() = %d points up to %v, want 4 up to the event", len(simulation.Points), simulation.SaleEnd)
+	}
+	wantPrices := []float64{10000, 10000, 15000, 15000}
+	for i, point := range simulation.Points {
+		if point.Price != wantPrices[i] || point.TicketsSold != 25*(i+1) {
+			t.Errorf("point %d = %d sold at %v, want %d at %v", i, point.TicketsSold, point.Price, 25*(i+1), wantPrices[i])
+		}
+	}
+	if simulation.TotalRevenue != 1250000 || simulation.FixedRevenue != 1000000 || simulation.UpliftPercent != 25 {
+		t.Errorf("revenue = %v against %v fixed (%v%%), want 1250000 against 1000000 (25%%)", simulation.TotalRevenue, simulation.FixedRevenue, simulation.UpliftPercent)
+	}
+
+	// An unsaved strategy can be previewed without changing the tier
+	preview := &entities.PricingStrategy{
+		Type:    entities.PricingStrategyInventory,
+		Steps:   []entities.PricingStep{{SoldPercent: 50, Multiplier: 1.5}},
+		Ceiling: &ceiling,
+	}
+	simulation, err = f.service.SimulatePricing(ctx, f.early.ID, &SimulatePricingRequest{Strategy: preview, Points: 4, SellThroughPercent: 100})
+	if err != nil {
+		t.Fatalf("SimulatePricing() error = %v", err)
+	}
+	if simulation.TotalRevenue != 1200000 || simulation.UpliftPercent != 20 {
+		t.Errorf("capped revenue = %v (%v%%), want 1200000 (20%%)", simulation.TotalRevenue, simulation.UpliftPercent)
+	}
+	if f.tiers.tiers[f.early.ID].PricingStrategy.Ceiling != nil || len(f.tiers.updated) != 0 {
+		t.Errorf("previewing a strategy changed the tier")
+	}
+}
+
+func TestSimulatePricingNeedsSaleTimeLeft(t *testing.T) {
+	f := newTierFixture(t)
+	ended := time.Now().Add(-time.Hour)
+	f.regular.SaleEnd = &ended
+
+	_, err := f.service.SimulatePricing(context.Background(), f.regular.ID, &SimulatePricingRequest{})
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "sale_window_closed" {
+		t.Errorf("SimulatePricing() after the sale ended error = %v, want sale_window_closed", err)
+	}
+}
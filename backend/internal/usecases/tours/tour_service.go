@@ -0,0 +1,592 @@
+package tours
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// maxTourDates bounds the dates listed on a tour page
+const maxTourDates = 100
+
+// TourService handles tour and tour pass management use cases
+type TourService struct {
+	tourRepo       repositories.TourRepository
+	tourPassRepo   repositories.TourPassRepository
+	eventRepo      repositories.EventRepository
+	ticketTierRepo repositories.TicketTierRepository
+	organizerRepo  repositories.OrganizerRepository
+	unitOfWork     repositories.UnitOfWork
+}
+
+// NewTourService creates a new tour service
+func NewTourService(
+	tourRepo repositories.TourRepository,
+	tourPassRepo repositories.TourPassRepository,
+	eventRepo repositories.EventRepository,
+	ticketTierRepo repositories.TicketTierRepository,
+	organizerRepo repositories.OrganizerRepository,
+	unitOfWork repositories.UnitOfWork,
+) *TourService {
+	return &TourService{
+		tourRepo:       tourRepo,
+		tourPassRepo:   tourPassRepo,
+		eventRepo:      eventRepo,
+		ticketTierRepo: ticketTierRepo,
+		organizerRepo:  organizerRepo,
+		unitOfWork:     unitOfWork,
+	}
+}
+
+// CreateTourRequest represents the request to create a tour
+type CreateTourRequest struct {
+	OrganizerID  uuid.UUID              `json:"organizer_id" validate:"required"`
+	Name         string                 `json:"name" validate:"required,max=255"`
+	Slug         string                 `json:"slug" validate:"required,max=100"`
+	ArtistName   string                 `json:"artist_name" validate:"required,max=255"`
+	Description  *string                `json:"description,omitempty"`
+	TourImageURL *string                `json:"tour_image_url,omitempty"`
+	StartDate    *time.Time             `json:"start_date,omitempty"`
+	EndDate      *time.Time             `json:"end_date,omitempty"`
+	Settings     map[string]interface{} `json:"settings,omitempty"`
+}
+
+// UpdateTourRequest represents the request to update a tour. Omitted fields
+// are left unchanged; settings are merged into the existing settings.
+type UpdateTourRequest struct {
+	Name         *string                `json:"name,omitempty" validate:"omitempty,max=255"`
+	Slug         *string                `json:"slug,omitempty" validate:"omitempty,max=100"`
+	ArtistName   *string                `json:"artist_name,omitempty" validate:"omitempty,max=255"`
+	Description  *string                `json:"description,omitempty"`
+	TourImageURL *string                `json:"tour_image_url,omitempty"`
+	StartDate    *time.Time             `json:"start_date,omitempty"`
+	EndDate      *time.Time             `json:"end_date,omitempty"`
+	Settings     map[string]interface{} `json:"settings,omitempty"`
+	IsActive     *bool                  `json:"is_active,omitempty"`
+}
+
+// TourPassEventRequest names an event covered by a pass and the tier it admits to
+type TourPassEventRequest struct {
+	EventID      uuid.UUID `json:"event_id" validate:"required"`
+	TicketTierID uuid.UUID `json:"ticket_tier_id" validate:"required"`
+}
+
+// CreateTourPassRequest represents the request to create a tour pass
+type CreateTourPassRequest struct {
+	Name        string                 `json:"name" validate:"required,max=255"`
+	Description *string                `json:"description,omitempty"`
+	Price       float64                `json:"price" validate:"min=0"`
+	Quota       *int                   `json:"quota,omitempty" validate:"omitempty,gt=0"`
+	MaxPerOrder *int                   `json:"max_per_order,omitempty" validate:"omitempty,gt=0"`
+	SaleStart   *time.Time             `json:"sale_start,omitempty"`
+	SaleEnd     *time.Time             `json:"sale_end,omitempty"`
+	Events      []TourPassEventRequest `json:"events" validate:"required,min=2,dive"`
+}
+
+// UpdateTourPassRequest represents the request to update a tour pass. When
+// events is present it replaces the events the pass covers.
+type UpdateTourPassRequest struct {
+	Name        *string                 `json:"name,omitempty" validate:"omitempty,max=255"`
+	Description *string                 `json:"description,omitempty"`
+	Price       *float64                `json:"price,omitempty" validate:"omitempty,min=0"`
+	Quota       *int                    `json:"quota,omitempty" validate:"omitempty,gt=0"`
+	MaxPerOrder *int                    `json:"max_per_order,omitempty" validate:"omitempty,gt=0"`
+	SaleStart   *time.Time              `json:"sale_start,omitempty"`
+	SaleEnd     *time.Time              `json:"sale_end,omitempty"`
+	Events      *[]TourPassEventRequest `json:"events,omitempty"`
+	IsActive    *bool                   `json:"is_active,omitempty"`
+}
+
+// TourDetailsResponse represents a tour with its events, passes and statistics
+type TourDetailsResponse struct {
+	Tour   *entities.Tour          `json:"tour"`
+	Events []*entities.Event       `json:"events"`
+	Passes []*entities.TourPass    `json:"passes"`
+	Stats  *repositories.TourStats `json:"stats"`
+}
+
+// PublicTourResponse represents the public page of a tour
+type PublicTourResponse struct {
+	Tour   *entities.Tour  `json:"tour"`
+	Dates  []*TourDate     `json:"dates"`
+	Passes []*TourPassInfo `json:"passes"`
+}
+
+// TourDate is a public event of a tour
+type TourDate struct {
+	EventID       uuid.UUID            `json:"event_id"`
+	Name          string               `json:"name"`
+	Slug          string               `json:"slug"`
+	EventDate     time.Time            `json:"event_date"`
+	DoorsOpen     *time.Time           `json:"doors_open,omitempty"`
+	VenueName     string               `json:"venue_name"`
+	VenueCity     string               `json:"venue_city"`
+	EventImageURL *string              `json:"event_image_url,omitempty"`
+	Status        entities.EventStatus `json:"status"`
+}
+
+// TourPassInfo is a tour pass on sale together with how many can still be bought
+type TourPassInfo struct {
+	*entities.TourPass
+	Available int `json:"available"`
+}
+
+// CreateTour creates a new tour for an organizer
+func (s *TourService) CreateTour(ctx context.Context, req *CreateTourRequest) (*entities.Tour, error) {
+	exists, err := s.organizerRepo.Exists(ctx, req.OrganizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check organizer existence: %w", err)
+	}
+	if !exists {
+		return nil, entities.NewNotFoundError("organizer", "organizer not found")
+	}
+
+	slug := strings.TrimSpace(req.Slug)
+	exists, err = s.tourRepo.ExistsBySlug(ctx, req.OrganizerID, slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check tour slug existence: %w", err)
+	}
+	if exists {
+		return nil, entities.NewConflictError("tour", "tour with this slug already exists for this organizer", nil)
+	}
+
+	tour := entities.NewTour(req.OrganizerID, strings.TrimSpace(req.Name), slug, strings.TrimSpace(req.ArtistName))
+	tour.Description = req.Description
+	tour.StartDate = req.StartDate
+	tour.EndDate = req.EndDate
+	if req.TourImageURL != nil {
+		tour.SetImage(*req.TourImageURL)
+	}
+	if req.Settings != nil {
+		tour.UpdateSettings(req.Settings)
+	}
+
+	if err := tour.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.tourRepo.Create(ctx, tour); err != nil {
+		return nil, err
+	}
+
+	return tour, nil
+}
+
+// UpdateTour updates a tour
+func (s *TourService) UpdateTour(ctx context.Context, id uuid.UUID, req *UpdateTourRequest) (*entities.Tour, error) {
+	tour, err := s.getTour(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Slug != nil {
+		slug := strings.TrimSpace(*req.Slug)
+		if slug != tour.Slug {
+			exists, err := s.tourRepo.ExistsBySlug(ctx, tour.OrganizerID, slug)
+			if err != nil {
+				return nil, fmt.Errorf("failed to check tour slug existence: %w", err)
+			}
+			if exists {
+				return nil, entities.NewConflictError("tour", "tour with this slug already exists for this organizer", nil)
+			}
+			tour.Slug = slug
+		}
+	}
+	if req.Name != nil {
+		tour.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.ArtistName != nil {
+		tour.ArtistName = strings.TrimSpace(*req.ArtistName)
+	}
+	if req.Description != nil {
+		tour.Description = req.Description
+	}
+	if req.TourImageURL != nil {
+		tour.SetImage(*req.TourImageURL)
+	}
+	if req.StartDate != nil {
+		tour.StartDate = req.StartDate
+	}
+	if req.EndDate != nil {
+		tour.EndDate = req.EndDate
+	}
+	if req.Settings != nil {
+		tour.UpdateSettings(req.Settings)
+	}
+	if req.IsActive != nil {
+		if *req.IsActive {
+			tour.Activate()
+		} else {
+			tour.Deactivate()
+		}
+	}
+
+	if err := tour.Validate(); err != nil {
+		return nil, err
+	}
+
+	tour.UpdatedAt = time.Now()
+	if err := s.tourRepo.Update(ctx, tour); err != nil {
+		return nil, err
+	}
+
+	return tour, nil
+}
+
+// GetTour retrieves a tour with its events, passes and sales statistics
+func (s *TourService) GetTour(ctx context.Context, id uuid.UUID) (*TourDetailsResponse, error) {
+	tour, err := s.getTour(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := repositories.EventFilter{
+		BaseFilter: repositories.BaseFilter{
+			Page:      1,
+			Limit:     maxTourDates,
+			SortBy:    "event_date",
+			SortOrder: "asc",
+		},
+	}
+	events, _, err := s.eventRepo.GetByTour(ctx, tour.ID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tour events: %w", err)
+	}
+
+	passes, err := s.tourPassRepo.ListByTour(ctx, tour.ID, false)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tour passes: %w", err)
+	}
+
+	stats, err := s.tourRepo.GetTourStats(ctx, tour.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tour stats: %w", err)
+	}
+
+	return &TourDetailsResponse{
+		Tour:   tour,
+		Events: events,
+		Passes: passes,
+		Stats:  stats,
+	}, nil
+}
+
+// ListTours retrieves tours with pagination and filtering
+func (s *TourService) ListTours(ctx context.Context, filter repositories.TourFilter) ([]*entities.Tour, *repositories.PaginationResult, error) {
+	return s.tourRepo.List(ctx, filter)
+}
+
+// DeleteTour deactivates a tour. Its events stay on sale individually and
+// keep their link to the tour.
+func (s *TourService) DeleteTour(ctx context.Context, id uuid.UUID) error {
+	if _, err := s.getTour(ctx, id); err != nil {
+		return err
+	}
+	return s.tourRepo.Delete(ctx, id)
+}
+
+// CreateTourPass creates a pass admitting its holder to several events of a tour
+func (s *TourService) CreateTourPass(ctx context.Context, tourID uuid.UUID, req *CreateTourPassRequest) (*entities.TourPass, error) {
+	tour, err := s.getTour(ctx, tourID)
+	if err != nil {
+		return nil, err
+	}
+
+	events, currency, err := s.buildPassEvents(ctx, tour, req.Events)
+	if err != nil {
+		return nil, err
+	}
+
+	pass := entities.NewTourPass(tour.ID, req.Name, req.Price, currency)
+	pass.Description = req.Description
+	pass.Quota = req.Quota
+	pass.SaleStart = req.SaleStart
+	pass.SaleEnd = req.SaleEnd
+	if req.MaxPerOrder != nil {
+		pass.MaxPerOrder = *req.MaxPerOrder
+	}
+	pass.SetEvents(events)
+
+	if err := pass.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.savePass(ctx, pass, true, true); err != nil {
+		return nil, err
+	}
+
+	return s.tourPassRepo.GetByID(ctx, pass.ID)
+}
+
+// UpdateTourPass updates a tour pass. Changing the events of a pass does not
+// affect tickets already issued for it.
+func (s *TourService) UpdateTourPass(ctx context.Context, id uuid.UUID, req *UpdateTourPassRequest) (*entities.TourPass, error) {
+	pass, err := s.getTourPass(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Events != nil {
+		tour, err := s.getTour(ctx, pass.TourID)
+		if err != nil {
+			return nil, err
+		}
+		events, currency, err := s.buildPassEvents(ctx, tour, *req.Events)
+		if err != nil {
+			return nil, err
+		}
+		pass.Currency = currency
+		pass.SetEvents(events)
+	}
+	if req.Name != nil {
+		pass.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Description != nil {
+		pass.Description = req.Description
+	}
+	if req.Price != nil {
+		pass.Price = *req.Price
+	}
+	if req.Quota != nil {
+		pass.Quota = req.Quota
+	}
+	if req.MaxPerOrder != nil {
+		pass.MaxPerOrder = *req.MaxPerOrder
+	}
+	if req.SaleStart != nil {
+		pass.SaleStart = req.SaleStart
+	}
+	if req.SaleEnd != nil {
+		pass.SaleEnd = req.SaleEnd
+	}
+	if req.IsActive != nil {
+		pass.IsActive = *req.IsActive
+	}
+
+	if err := pass.Validate(); err != nil {
+		return nil, err
+	}
+
+	pass.UpdatedAt = time.Now()
+	if err := s.savePass(ctx, pass, false, req.Events != nil); err != nil {
+		return nil, err
+	}
+
+	return s.tourPassRepo.GetByID(ctx, pass.ID)
+}
+
+// ListTourPasses retrieves all passes of a tour, including inactive ones
+func (s *TourService) ListTourPasses(ctx context.Context, tourID uuid.UUID) ([]*entities.TourPass, error) {
+	if _, err := s.getTour(ctx, tourID); err != nil {
+		return nil, err
+	}
+	return s.tourPassRepo.ListByTour(ctx, tourID, false)
+}
+
+// ListPublicTours retrieves active tours that have not yet ended
+func (s *TourService) ListPublicTours(ctx context.Context, filter repositories.TourFilter) ([]*entities.Tour, *repositories.PaginationResult, error) {
+	now := time.Now()
+	filter.EndDateFrom = &now
+	return s.tourRepo.GetActive(ctx, filter)
+}
+
+// GetPublicTour retrieves the public page of a tour: its published dates and
+// the passes currently on sale
+func (s *TourService) GetPublicTour(ctx context.Context, id uuid.UUID) (*PublicTourResponse, error) {
+	tour, err := s.getTour(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !tour.IsActive {
+		return nil, entities.NewNotFoundError("tour", "tour not found")
+	}
+
+	filter := repositories.PublicEventFilter{
+		BaseFilter: repositories.BaseFilter{
+			Page:      1,
+			Limit:     maxTourDates,
+			SortBy:    "event_date",
+			SortOrder: "asc",
+		},
+		TourID: &tour.ID,
+	}
+	events, _, err := s.eventRepo.ListPublic(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tour dates: %w", err)
+	}
+
+	dates := make([]*TourDate, 0, len(events))
+	for _, event := range events {
+		dates = append(dates, &TourDate{
+			EventID:       event.ID,
+			Name:          event.Name,
+			Slug:          event.Slug,
+			EventDate:     event.EventDate,
+			DoorsOpen:     event.DoorsOpen,
+			VenueName:     event.VenueName,
+			VenueCity:     event.VenueCity,
+			EventImageURL: event.EventImageURL,
+			Status:        event.Status,
+		})
+	}
+
+	passes, err := s.tourPassRepo.ListByTour(ctx, tour.ID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tour passes: %w", err)
+	}
+
+	now := time.Now()
+	infos := make([]*TourPassInfo, 0, len(passes))
+	for _, pass := range passes {
+		if !pass.IsOnSale(now) {
+			continue
+		}
+		available, err := s.passAvailability(ctx, pass)
+		if err != nil {
+			return nil, err
+		}
+		infos = append(infos, &TourPassInfo{
+			TourPass:  pass,
+			Available: available,
+		})
+	}
+
+	return &PublicTourResponse{
+		Tour:   tour,
+		Dates:  dates,
+		Passes: infos,
+	}, nil
+}
+
+// passAvailability is the number of passes that can still be bought: the
+// remaining pass quota, capped by the tightest of the covered tiers
+func (s *TourService) passAvailability(ctx context.Context, pass *entities.TourPass) (int, error) {
+	available := 0
+	capped := false
+	if pass.Quota != nil {
+		reserved, err := s.tourPassRepo.CountReserved(ctx, pass.ID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to count reserved passes: %w", err)
+		}
+		available = *pass.Quota - reserved
+		capped = true
+	}
+
+	for _, event := range pass.Events {
+		tierAvailable, err := s.ticketTierRepo.GetAvailableQuantity(ctx, event.TicketTierID)
+		if err != nil {
+			return 0, fmt.Errorf("failed to get tier availability: %w", err)
+		}
+		if !capped || tierAvailable < available {
+			available = tierAvailable
+			capped = true
+		}
+	}
+
+	if available < 0 {
+		available = 0
+	}
+	return available, nil
+}
+
+// buildPassEvents checks that every event belongs to the tour and every tier to
+// its event, and that all tiers share one currency, which becomes the pass currency
+func (s *TourService) buildPassEvents(ctx context.Context, tour *entities.Tour, requests []TourPassEventRequest) ([]*entities.TourPassEvent, string, error) {
+	events := make([]*entities.TourPassEvent, 0, len(requests))
+	currency := ""
+
+	for _, req := range requests {
+		event, err := s.eventRepo.GetByID(ctx, req.EventID)
+		if err != nil {
+			if err == entities.ErrEventNotFound {
+				return nil, "", entities.NewNotFoundError("event", "event not found")
+			}
+			return nil, "", fmt.Errorf("failed to get event: %w", err)
+		}
+		if event.TourID == nil || *event.TourID != tour.ID {
+			return nil, "", entities.NewValidationError("events", fmt.Sprintf("event %s is not part of this tour", event.ID))
+		}
+
+		tier, err := s.ticketTierRepo.GetByID(ctx, req.TicketTierID)
+		if err != nil {
+			if err == entities.ErrTicketTierNotFound {
+				return nil, "", entities.NewNotFoundError("ticket_tier", "ticket tier not found")
+			}
+			return nil, "", fmt.Errorf("failed to get ticket tier: %w", err)
+		}
+		if tier.EventID != event.ID {
+			return nil, "", entities.NewValidationError("events", fmt.Sprintf("ticket tier %s does not belong to event %s", tier.ID, event.ID))
+		}
+
+		tierCurrency := entities.NormalizeCurrency(tier.Currency)
+		if currency == "" {
+			currency = tierCurrency
+		} else if tierCurrency != currency {
+			return nil, "", entities.NewValidationError("events", "all ticket tiers of a tour pass must use the same currency")
+		}
+
+		events = append(events, &entities.TourPassEvent{
+			EventID:      event.ID,
+			TicketTierID: tier.ID,
+		})
+	}
+
+	return events, currency, nil
+}
+
+func (s *TourService) getTour(ctx context.Context, id uuid.UUID) (*entities.Tour, error) {
+	tour, err := s.tourRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == entities.ErrTourNotFound {
+			return nil, entities.NewNotFoundError("tour", "tour not found")
+		}
+		return nil, err
+	}
+	return tour, nil
+}
+
+func (s *TourService) getTourPass(ctx context.Context, id uuid.UUID) (*entities.TourPass, error) {
+	pass, err := s.tourPassRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == entities.ErrTourPassNotFound {
+			return nil, entities.NewNotFoundError("tour_pass", "tour pass not found")
+		}
+		return nil, err
+	}
+	return pass, nil
+}
+
+// savePass writes the pass and, when requested, its events in one transaction
+func (s *TourService) savePass(ctx context.Context, pass *entities.TourPass, isNew, replaceEvents bool) error {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if isNew {
+		err = tx.TourPasses().Create(tx.Context(), pass)
+	} else {
+		err = tx.TourPasses().Update(tx.Context(), pass)
+	}
+	if err != nil {
+		return err
+	}
+
+	if replaceEvents {
+		if err := tx.TourPasses().ReplaceEvents(tx.Context(), pass.ID, pass.Events); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,304 @@
+package tours
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// The fakes embed the repository interfaces, so a call the use case is not
+// expected to make panics instead of passing silently.
+
+type fakeTours struct {
+	repositories.TourRepository
+	tour *entities.Tour
+}
+
+func (f *fakeTours) GetByID(ctx context.Context, id uuid.UUID) (*entities.Tour, error) {
+	if id != f.tour.ID {
+		return nil, entities.ErrTourNotFound
+	}
+	return f.tour, nil
+}
+
+type fakeEvents struct {
+	repositories.EventRepository
+	events map[uuid.UUID]*entities.Event
+}
+
+func (f *fakeEvents) GetByID(ctx context.Context, id uuid.UUID) (*entities.Event, error) {
+	event, ok := f.events[id]
+	if !ok {
+		return nil, entities.ErrEventNotFound
+	}
+	return event, nil
+}
+
+func (f *fakeEvents) ListPublic(ctx context.Context, filter repositories.PublicEventFilter) ([]*entities.Event, *repositories.PaginationResult, error) {
+	return nil, repositories.NewPaginationResult(1, filter.Limit, 0), nil
+}
+
+type fakeTiers struct {
+	repositories.TicketTierRepository
+	tiers     map[uuid.UUID]*entities.TicketTier
+	available map[uuid.UUID]int
+}
+
+func (f *fakeTiers) GetByID(ctx context.Context, id uuid.UUID) (*entities.TicketTier, error) {
+	tier, ok := f.tiers[id]
+	if !ok {
+		return nil, entities.ErrTicketTierNotFound
+	}
+	return tier, nil
+}
+
+func (f *fakeTiers) GetAvailableQuantity(ctx context.Context, id uuid.UUID) (int, error) {
+	return f.available[id], nil
+}
+
+// fakePasses stores the passes saved in a transaction and counts reserved
+// passes from a fixed table
+type fakePasses struct {
+	repositories.TourPassRepository
+	passes   map[uuid.UUID]*entities.TourPass
+	reserved map[uuid.UUID]int
+}
+
+func (f *fakePasses) Create(ctx context.Context, pass *entities.TourPass) error {
+	f.passes[pass.ID] = pass
+	return nil
+}
+
+func (f *fakePasses) ReplaceEvents(ctx context.Context, passID uuid.UUID, events []*entities.TourPassEvent) error {
+	f.passes[passID].Events = events
+	return nil
+}
+
+func (f *fakePasses) GetByID(ctx context.Context, id uuid.UUID) (*entities.TourPass, error) {
+	pass, ok := f.passes[id]
+	if !ok {
+		return nil, entities.ErrTourPassNotFound
+	}
+	return pass, nil
+}
+
+func (f *fakePasses) ListByTour(ctx context.Context, tourID uuid.UUID, activeOnly bool) ([]*entities.TourPass, error) {
+	var passes []*entities.TourPass
+	for _, pass := range f.passes {
+		if pass.TourID == tourID && (!activeOnly || pass.IsActive) {
+			passes = append(passes, pass)
+		}
+	}
+	return passes, nil
+}
+
+func (f *fakePasses) CountReserved(ctx context.Context, passID uuid.UUID) (int, error) {
+	return f.reserved[passID], nil
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	ctx    context.Context
+	passes *fakePasses
+}
+
+func (tx *fakeTx) Commit() error                               { return nil }
+func (tx *fakeTx) Rollback() error                             { return nil }
+func (tx *fakeTx) Context() context.Context                    { return tx.ctx }
+func (tx *fakeTx) TourPasses() repositories.TourPassRepository { return tx.passes }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	u.tx.ctx = ctx
+	return u.tx, nil
+}
+
+type tourFixture struct {
+	service *TourService
+	tiers   *fakeTiers
+	passes  *fakePasses
+	tour    *entities.Tour
+	events  []*entities.Event
+	naira   []*entities.TicketTier
+	cedis   *entities.TicketTier
+	other   *entities.Event
+}
+
+// newTourFixture builds a tour service over fakes, with a tour of three dates.
+// Each date has a naira tier; the last also sells a cedi tier. An event of
+// another tour is known too.
+func newTourFixture(t *testing.T) *tourFixture {
+	t.Helper()
+
+	tour := entities.NewTour(uuid.New(), "Love, Damini Tour", "love-damini", "Burna Boy")
+	events := &fakeEvents{events: make(map[uuid.UUID]*entities.Event)}
+	tiers := &fakeTiers{tiers: make(map[uuid.UUID]*entities.TicketTier), available: make(map[uuid.UUID]int)}
+
+	f := &tourFixture{tour: tour, tiers: tiers}
+	for i, city := range []string{"Lagos", "Abuja", "Accra"} {
+		event := entities.NewEvent(uuid.New(), "Love, Damini "+city, "love-damini-"+city, time.Now().AddDate(0, 1, i), "Arena", "Main Road", city, "NG")
+		event.TourID = &tour.ID
+		events.events[event.ID] = event
+		f.events = append(f.events, event)
+
+		tier := entities.NewTicketTier(event.ID, "Regular", 20000)
+		tier.Currency = "NGN"
+		tiers.tiers[tier.ID] = tier
+		tiers.available[tier.ID] = 100
+		f.naira = append(f.naira, tier)
+	}
+
+	f.cedis = entities.NewTicketTier(f.events[2].ID, "Regular (GHS)", 300)
+	f.cedis.Currency = "GHS"
+	tiers.tiers[f.cedis.ID] = f.cedis
+
+	otherTour := uuid.New()
+	f.other = entities.NewEvent(uuid.New(), "Twice as Tall", "twice-as-tall", time.Now().AddDate(0, 2, 0), "Arena", "Main Road", "Lagos", "NG")
+	f.other.TourID = &otherTour
+	events.events[f.other.ID] = f.other
+
+	f.passes = &fakePasses{passes: make(map[uuid.UUID]*entities.TourPass), reserved: make(map[uuid.UUID]int)}
+	f.service = NewTourService(&fakeTours{tour: tour}, f.passes, events, tiers, nil, &fakeUnitOfWork{tx: &fakeTx{passes: f.passes}})
+	return f
+}
+
+// passEvents lists the given dates of the tour with their naira tiers
+func (f *tourFixture) passEvents(dates ...int) []TourPassEventRequest {
+	requests := make([]TourPassEventRequest, 0, len(dates))
+	for _, i := range dates {
+		requests = append(requests, TourPassEventRequest{EventID: f.events[i].ID, TicketTierID: f.naira[i].ID})
+	}
+	return requests
+}
+
+func TestCreateTourPassTakesCurrencyFromItsTiers(t *testing.T) {
+	f := newTourFixture(t)
+
+	pass, err := f.service.CreateTourPass(context.Background(), f.tour.ID, &CreateTourPassRequest{
+		Name:   " All Nigeria ",
+		Price:  35000,
+		Events: f.passEvents(0, 1),
+	})
+	if err != nil {
+		t.Fatalf("CreateTourPass() error = %v", err)
+	}
+
+	if pass.Name != "All Nigeria" || pass.Currency != "NGN" || pass.TourID != f.tour.ID {
+		t.Errorf("CreateTourPass() = %q in %s for tour %s, want All Nigeria in NGN for tour %s", pass.Name, pass.Currency, pass.TourID, f.tour.ID)
+	}
+	if len(pass.Events) != 2 || pass.Events[0].TourPassID != pass.ID || pass.Events[1].TicketTierID != f.naira[1].ID {
+		t.Errorf("CreateTourPass() events = %+v, want Lagos and Abuja linked to the pass", pass.Events)
+	}
+}
+
+func TestCreateTourPassRejectsInvalidEvents(t *testing.T) {
+	f := newTourFixture(t)
+
+	tests := []struct {
+		name   string
+		events []TourPassEventRequest
+	}{
+		{name: "single event", events: f.passEvents(0)},
+		{name: "repeated event", events: f.passEvents(0, 0)},
+		{name: "event of another tour", events: append(f.passEvents(0), TourPassEventRequest{EventID: f.other.ID, TicketTierID: f.naira[1].ID})},
+		{name: "tier of another event", events: append(f.passEvents(0), TourPassEventRequest{EventID: f.events[1].ID, TicketTierID: f.naira[2].ID})},
+		{name: "mixed currencies", events: append(f.passEvents(0), TourPassEventRequest{EventID: f.events[2].ID, TicketTierID: f.cedis.ID})},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := f.service.CreateTourPass(context.Background(), f.tour.ID, &CreateTourPassRequest{
+				Name:   "Pass",
+				Price:  35000,
+				Events: tt.events,
+			})
+			var validationErr *entities.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Field != "events" {
+				t.Errorf("CreateTourPass() error = %v, want a validation error on events", err)
+			}
+		})
+	}
+	if len(f.passes.passes) != 0 {
+		t.Errorf("CreateTourPass() saved %d invalid passes", len(f.passes.passes))
+	}
+}
+
+func TestPublicTourCapsPassesByQuotaAndTiers(t *testing.T) {
+	f := newTourFixture(t)
+	ctx := context.Background()
+	quota := 50
+
+	capped, err := f.service.CreateTourPass(ctx, f.tour.ID, &CreateTourPassRequest{Name: "Capped", Price: 35000, Quota: &quota, Events: f.passEvents(0, 1)})
+	if err != nil {
+		t.Fatalf("CreateTourPass() error = %v", err)
+	}
+	open, err := f.service.CreateTourPass(ctx, f.tour.ID, &CreateTourPassRequest{Name: "Open", Price: 50000, Events: f.passEvents(0, 1, 2)})
+	if err != nil {
+		t.Fatalf("CreateTourPass() error = %v", err)
+	}
+	later := time.Now().Add(time.Hour)
+	if _, err := f.service.CreateTourPass(ctx, f.tour.ID, &CreateTourPassRequest{Name: "Later", Price: 35000, SaleStart: &later, Events: f.passEvents(1, 2)}); err != nil {
+		t.Fatalf("CreateTourPass() error = %v", err)
+	}
+
+	f.passes.reserved[capped.ID] = 45
+	f.tiers.available[f.naira[2].ID] = 3
+
+	page, err := f.service.GetPublicTour(ctx, f.tour.ID)
+	if err != nil {
+		t.Fatalf("GetPublicTour() error = %v", err)
+	}
+
+	available := make(map[uuid.UUID]int)
+	for _, info := range page.Passes {
+		available[info.ID] = info.Available
+	}
+	if len(available) != 2 {
+		t.Fatalf("GetPublicTour() listed %d passes, want only the two on sale", len(page.Passes))
+	}
+	if available[capped.ID] != 5 {
+		t.Errorf("capped pass available = %d, want the 5 left of its quota", available[capped.ID])
+	}
+	if available[open.ID] != 3 {
+		t.Errorf("open pass available = %d, want the 3 left on the Accra tier", available[open.ID])
+	}
+
+	// Oversold quotas never show as negative
+	f.passes.reserved[capped.ID] = 60
+	page, _ = f.service.GetPublicTour(ctx, f.tour.ID)
+	for _, info := range page.Passes {
+		if info.ID == capped.ID && info.Available != 0 {
+			t.Errorf("oversold pass available = %d, want 0", info.Available)
+		}
+	}
+}
+
+func TestTourPassEventPricesAddUpToPassPrice(t *testing.T) {
+	f := newTourFixture(t)
+
+	pass, err := f.service.CreateTourPass(context.Background(), f.tour.ID, &CreateTourPassRequest{
+		Name:   "All dates",
+		Price:  50000,
+		Events: f.passEvents(0, 1, 2),
+	})
+	if err != nil {
+		t.Fatalf("CreateTourPass() error = %v", err)
+	}
+
+	prices := pass.EventPrices()
+	want := []float64{16666.68, 16666.66, 16666.66}
+	for i := range want {
+		if prices[i] != want[i] {
+			t.Errorf("EventPrices() = %v, want %v", prices, want)
+			break
+		}
+	}
+}
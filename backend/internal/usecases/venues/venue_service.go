@@ -0,0 +1,279 @@
+package venues
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// VenueService handles venue management use cases
+type VenueService struct {
+	venueRepo  repositories.VenueRepository
+	unitOfWork repositories.UnitOfWork
+}
+
+// NewVenueService creates a new venue service
+func NewVenueService(
+	venueRepo repositories.VenueRepository,
+	unitOfWork repositories.UnitOfWork,
+) *VenueService {
+	return &VenueService{
+		venueRepo:  venueRepo,
+		unitOfWork: unitOfWork,
+	}
+}
+
+// VenueGateRequest represents a gate or entrance of a venue
+type VenueGateRequest struct {
+	Name         string                 `json:"name" validate:"required,max=100"`
+	Kind         entities.VenueGateKind `json:"kind,omitempty"`
+	Description  *string                `json:"description,omitempty"`
+	IsAccessible bool                   `json:"is_accessible"`
+}
+
+// CreateVenueRequest represents the request to create a venue
+type CreateVenueRequest struct {
+	Name          string                       `json:"name" validate:"required,max=255"`
+	Address       string                       `json:"address"`
+	City          string                       `json:"city" validate:"required,max=100"`
+	State         *string                      `json:"state,omitempty"`
+	Country       string                       `json:"country" validate:"required,max=100"`
+	Latitude      *float64                     `json:"latitude,omitempty"`
+	Longitude     *float64                     `json:"longitude,omitempty"`
+	Capacity      *int                         `json:"capacity,omitempty" validate:"omitempty,gt=0"`
+	Timezone      string                       `json:"timezone,omitempty"`
+	Description   *string                      `json:"description,omitempty"`
+	Accessibility *entities.VenueAccessibility `json:"accessibility,omitempty"`
+	ImageURL      *string                      `json:"image_url,omitempty"`
+	GalleryImages []string                     `json:"gallery_images,omitempty"`
+	Gates         []VenueGateRequest           `json:"gates,omitempty" validate:"dive"`
+}
+
+// UpdateVenueRequest represents the request to update a venue. Omitted fields
+// are left unchanged; when gates is present it replaces the venue's gates.
+type UpdateVenueRequest struct {
+	Name          *string                      `json:"name,omitempty" validate:"omitempty,max=255"`
+	Address       *string                      `json:"address,omitempty"`
+	City          *string                      `json:"city,omitempty" validate:"omitempty,max=100"`
+	State         *string                      `json:"state,omitempty"`
+	Country       *string                      `json:"country,omitempty" validate:"omitempty,max=100"`
+	Latitude      *float64                     `json:"latitude,omitempty"`
+	Longitude     *float64                     `json:"longitude,omitempty"`
+	Capacity      *int                         `json:"capacity,omitempty" validate:"omitempty,gt=0"`
+	Timezone      *string                      `json:"timezone,omitempty"`
+	Description   *string                      `json:"description,omitempty"`
+	Accessibility *entities.VenueAccessibility `json:"accessibility,omitempty"`
+	ImageURL      *string                      `json:"image_url,omitempty"`
+	GalleryImages *[]string                    `json:"gallery_images,omitempty"`
+	Gates         *[]VenueGateRequest          `json:"gates,omitempty"`
+	IsActive      *bool                        `json:"is_active,omitempty"`
+}
+
+// VenueResponse represents a venue with the number of events held there
+type VenueResponse struct {
+	Venue      *entities.Venue `json:"venue"`
+	EventCount int             `json:"event_count"`
+}
+
+// CreateVenue creates a venue with its gates and entrances
+func (s *VenueService) CreateVenue(ctx context.Context, req *CreateVenueRequest) (*entities.Venue, error) {
+	venue := entities.NewVenue(req.Name, req.Address, req.City, req.Country)
+	venue.State = req.State
+	venue.Capacity = req.Capacity
+	venue.Description = req.Description
+	venue.ImageURL = req.ImageURL
+	if req.Timezone != "" {
+		venue.Timezone = strings.TrimSpace(req.Timezone)
+	}
+	if req.Accessibility != nil {
+		venue.Accessibility = *req.Accessibility
+	}
+	if len(req.GalleryImages) > 0 {
+		venue.SetGallery(req.GalleryImages)
+	}
+	if req.Latitude != nil || req.Longitude != nil {
+		if req.Latitude == nil || req.Longitude == nil {
+			return nil, entities.NewValidationError("latitude", "latitude and longitude must be set together")
+		}
+		if err := venue.SetLocation(*req.Latitude, *req.Longitude); err != nil {
+			return nil, err
+		}
+	}
+	venue.SetGates(buildGates(req.Gates))
+
+	if err := venue.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, venue, true, true); err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+// UpdateVenue updates a venue. Events already linked to the venue keep their
+// venue details as they were when the event was set up, except coordinates,
+// which follow the venue.
+func (s *VenueService) UpdateVenue(ctx context.Context, id uuid.UUID, req *UpdateVenueRequest) (*entities.Venue, error) {
+	venue, err := s.getVenue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != nil {
+		venue.Name = strings.TrimSpace(*req.Name)
+	}
+	if req.Address != nil {
+		venue.Address = strings.TrimSpace(*req.Address)
+	}
+	if req.City != nil {
+		venue.City = strings.TrimSpace(*req.City)
+	}
+	if req.State != nil {
+		venue.State = req.State
+	}
+	if req.Country != nil {
+		venue.Country = strings.TrimSpace(*req.Country)
+	}
+	if req.Capacity != nil {
+		venue.Capacity = req.Capacity
+	}
+	if req.Timezone != nil {
+		venue.Timezone = strings.TrimSpace(*req.Timezone)
+	}
+	if req.Description != nil {
+		venue.Description = req.Description
+	}
+	if req.Accessibility != nil {
+		venue.Accessibility = *req.Accessibility
+	}
+	if req.ImageURL != nil {
+		venue.ImageURL = req.ImageURL
+	}
+	if req.GalleryImages != nil {
+		venue.SetGallery(*req.GalleryImages)
+	}
+	if req.Latitude != nil || req.Longitude != nil {
+		if req.Latitude == nil || req.Longitude == nil {
+			return nil, entities.NewValidationError("latitude", "latitude and longitude must be set together")
+		}
+		if err := venue.SetLocation(*req.Latitude, *req.Longitude); err != nil {
+			return nil, err
+		}
+	}
+	if req.IsActive != nil {
+		venue.IsActive = *req.IsActive
+	}
+	if req.Gates != nil {
+		venue.SetGates(buildGates(*req.Gates))
+	}
+
+	if err := venue.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := s.save(ctx, venue, false, req.Gates != nil); err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+// GetVenue retrieves a venue with its gates and event count
+func (s *VenueService) GetVenue(ctx context.Context, id uuid.UUID) (*VenueResponse, error) {
+	venue, err := s.getVenue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	count, err := s.venueRepo.CountEvents(ctx, venue.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VenueResponse{
+		Venue:      venue,
+		EventCount: count,
+	}, nil
+}
+
+// ListVenues retrieves venues with pagination and filtering
+func (s *VenueService) ListVenues(ctx context.Context, filter repositories.VenueFilter) ([]*entities.Venue, *repositories.PaginationResult, error) {
+	return s.venueRepo.List(ctx, filter)
+}
+
+// DeactivateVenue hides a venue from new events. Venues are never deleted
+// because past events and their tickets still refer to them.
+func (s *VenueService) DeactivateVenue(ctx context.Context, id uuid.UUID) (*entities.Venue, error) {
+	venue, err := s.getVenue(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !venue.IsActive {
+		return venue, nil
+	}
+
+	venue.Deactivate()
+	if err := s.venueRepo.Update(ctx, venue); err != nil {
+		return nil, err
+	}
+
+	return venue, nil
+}
+
+func (s *VenueService) getVenue(ctx context.Context, id uuid.UUID) (*entities.Venue, error) {
+	venue, err := s.venueRepo.GetByID(ctx, id)
+	if err != nil {
+		if err == entities.ErrVenueNotFound {
+			return nil, entities.NewNotFoundError("venue", "venue not found")
+		}
+		return nil, err
+	}
+	return venue, nil
+}
+
+// save writes the venue and, when requested, its gates in one transaction
+func (s *VenueService) save(ctx context.Context, venue *entities.Venue, isNew, replaceGates bool) error {
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if isNew {
+		err = tx.Venues().Create(tx.Context(), venue)
+	} else {
+		err = tx.Venues().Update(tx.Context(), venue)
+	}
+	if err != nil {
+		return err
+	}
+
+	if replaceGates {
+		if err := tx.Venues().ReplaceGates(tx.Context(), venue.ID, venue.Gates); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+func buildGates(requests []VenueGateRequest) []*entities.VenueGate {
+	gates := make([]*entities.VenueGate, 0, len(requests))
+	for _, req := range requests {
+		gate := entities.NewVenueGate(req.Name, req.Kind)
+		gate.Description = req.Description
+		gate.IsAccessible = req.IsAccessible
+		gates = append(gates, gate)
+	}
+	return gates
+}
@@ -0,0 +1,388 @@
+package wallet
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/domain/services"
+	walletpass "github.com/uduxpass/backend/internal/infrastructure/wallet"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+)
+
+// ErrPassUnauthorized is returned when a wallet request carries a wrong pass or link token
+var ErrPassUnauthorized = errors.New("invalid wallet pass token")
+
+// WalletService issues Apple Wallet and Google Wallet passes for tickets and
+// keeps them current when tickets change. Either provider may be left
+// unconfigured, in which case its endpoints report it as unavailable.
+type WalletService struct {
+	ticketRepo    repositories.TicketRepository
+	orderRepo     repositories.OrderRepository
+	orderLineRepo repositories.OrderLineRepository
+	eventRepo     repositories.EventRepository
+	walletRepo    repositories.WalletPassRepository
+	apple         *walletpass.ApplePassGenerator
+	applePusher   *walletpass.ApplePassPusher
+	google        *walletpass.GoogleWalletIssuer
+	linkSecret    []byte
+	baseURL       string
+}
+
+// NewWalletService creates a new wallet service. apple and google may be nil.
+func NewWalletService(
+	ticketRepo repositories.TicketRepository,
+	orderRepo repositories.OrderRepository,
+	orderLineRepo repositories.OrderLineRepository,
+	eventRepo repositories.EventRepository,
+	walletRepo repositories.WalletPassRepository,
+	apple *walletpass.ApplePassGenerator,
+	google *walletpass.GoogleWalletIssuer,
+	linkSecret string,
+	baseURL string,
+) *WalletService {
+	s := &WalletService{
+		ticketRepo:    ticketRepo,
+		orderRepo:     orderRepo,
+		orderLineRepo: orderLineRepo,
+		eventRepo:     eventRepo,
+		walletRepo:    walletRepo,
+		apple:         apple,
+		google:        google,
+		linkSecret:    []byte(linkSecret),
+		baseURL:       strings.TrimRight(baseURL, "/"),
+	}
+	if apple != nil {
+		s.applePusher = walletpass.NewApplePassPusher(apple)
+	}
+	return s
+}
+
+// ApplePass is a generated .pkpass bundle
+type ApplePass struct {
+	Filename     string
+	Data         []byte
+	LastModified time.Time
+}
+
+// GetApplePass builds the Apple Wallet pass for a ticket on one of the user's orders
+func (s *WalletService) GetApplePass(ctx context.Context, orderID, ticketID, userID uuid.UUID) (*ApplePass, error) {
+	ticket, err := s.getOrderTicket(ctx, orderID, ticketID, userID)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildApplePass(ctx, ticket)
+}
+
+// GetGoogleSaveURL returns the "Add to Google Wallet" link for a ticket on one of the user's orders
+func (s *WalletService) GetGoogleSaveURL(ctx context.Context, orderID, ticketID, userID uuid.UUID) (string, error) {
+	ticket, err := s.getOrderTicket(ctx, orderID, ticketID, userID)
+	if err != nil {
+		return "", err
+	}
+	return s.buildGoogleSaveURL(ctx, ticket)
+}
+
+// GetApplePassByLink builds the Apple Wallet pass for a signed email link
+func (s *WalletService) GetApplePassByLink(ctx context.Context, ticketID uuid.UUID, token string) (*ApplePass, error) {
+	ticket, err := s.getLinkedTicket(ctx, ticketID, token)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildApplePass(ctx, ticket)
+}
+
+// GetGoogleSaveURLByLink returns the "Add to Google Wallet" link for a signed email link
+func (s *WalletService) GetGoogleSaveURLByLink(ctx context.Context, ticketID uuid.UUID, token string) (string, error) {
+	ticket, err := s.getLinkedTicket(ctx, ticketID, token)
+	if err != nil {
+		return "", err
+	}
+	return s.buildGoogleSaveURL(ctx, ticket)
+}
+
+// GetWalletLinks returns signed download links for the tickets, suitable for emails
+func (s *WalletService) GetWalletLinks(ctx context.Context, tickets []*entities.Ticket) []services.WalletLinks {
+	if s.apple == nil && s.google == nil {
+		return nil
+	}
+
+	links := make([]services.WalletLinks, 0, len(tickets))
+	for _, ticket := range tickets {
+		token := s.linkToken(ticket.ID)
+		ticketLinks := services.WalletLinks{TicketID: ticket.ID}
+		if s.apple != nil {
+			ticketLinks.AppleURL = fmt.Sprintf("%s/v1/wallet/tickets/%s/apple?token=%s", s.baseURL, ticket.ID, token)
+		}
+		if s.google != nil {
+			ticketLinks.GoogleURL = fmt.Sprintf("%s/v1/wallet/tickets/%s/google?token=%s", s.baseURL, ticket.ID, token)
+		}
+		links = append(links, ticketLinks)
+	}
+	return links
+}
+
+// NotifyTicketsChanged refreshes the wallet passes of changed tickets in the
+// background: Apple devices get a push and re-download the pass, saved Google
+// objects are patched in place.
+func (s *WalletService) NotifyTicketsChanged(ctx context.Context, ticketIDs []uuid.UUID) {
+	if (s.apple == nil && s.google == nil) || len(ticketIDs) == 0 {
+		return
+	}
+
+	ids := make([]uuid.UUID, len(ticketIDs))
+	copy(ids, ticketIDs)
+	go func() {
+		ctx := context.Background()
+		for _, ticketID := range ids {
+			if err := s.refreshTicket(ctx, ticketID); err != nil {
+				fmt.Printf("Warning: failed to update wallet pass for ticket %s: %v\n", ticketID, err)
+			}
+		}
+	}()
+}
+
+// Subscribe refreshes the wallet pass of every voided ticket. Deliveries go
+// through the outbox, so a refresh that fails is retried.
+func (s *WalletService) Subscribe(bus *eventbus.Bus) {
+	bus.SubscribeAsync("wallet_passes", entities.DomainEventTicketVoided, s.handleTicketVoided)
+}
+
+func (s *WalletService) handleTicketVoided(ctx context.Context, event entities.DomainEvent) error {
+	voided, ok := event.(*entities.TicketVoided)
+	if !ok {
+		return fmt.Errorf("unexpected %T for %s", event, entities.DomainEventTicketVoided)
+	}
+	if s.apple == nil && s.google == nil {
+		return nil
+	}
+	return s.refreshTicket(ctx, voided.TicketID)
+}
+
+func (s *WalletService) refreshTicket(ctx context.Context, ticketID uuid.UUID) error {
+	ticket, err := s.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return err
+	}
+
+	if s.apple != nil {
+		registrations, err := s.walletRepo.GetBySerialNumber(ctx, s.apple.PassTypeID(), ticket.SerialNumber)
+		if err != nil {
+			return err
+		}
+		for _, registration := range registrations {
+			err := s.applePusher.Push(ctx, registration.PushToken)
+			if errors.Is(err, walletpass.ErrPushTokenInvalid) {
+				err = s.walletRepo.DeleteByPushToken(ctx, registration.PushToken)
+			}
+			if err != nil {
+				fmt.Printf("Warning: wallet push for ticket %s: %v\n", ticket.SerialNumber, err)
+			}
+		}
+	}
+
+	if s.google != nil {
+		pass, err := s.buildPass(ctx, ticket)
+		if err != nil {
+			return err
+		}
+		if err := s.google.UpdateObject(ctx, *pass); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Apple Wallet web service (PassKit device registration protocol)
+
+// RegisterDevice records a device that installed a pass; it returns true for a new registration
+func (s *WalletService) RegisterDevice(ctx context.Context, deviceLibraryID, passTypeID, serialNumber, authToken, pushToken string) (bool, error) {
+	if _, err := s.authorizePass(ctx, passTypeID, serialNumber, authToken); err != nil {
+		return false, err
+	}
+
+	registration := entities.NewWalletPassRegistration(deviceLibraryID, pushToken, passTypeID, serialNumber)
+	return s.walletRepo.Register(ctx, registration)
+}
+
+// UnregisterDevice removes a device registration when the pass is deleted from the device
+func (s *WalletService) UnregisterDevice(ctx context.Context, deviceLibraryID, passTypeID, serialNumber, authToken string) error {
+	if _, err := s.authorizePass(ctx, passTypeID, serialNumber, authToken); err != nil {
+		return err
+	}
+	return s.walletRepo.Unregister(ctx, deviceLibraryID, passTypeID, serialNumber)
+}
+
+// GetUpdatedSerialNumbers lists the passes on a device that changed after the given update tag
+func (s *WalletService) GetUpdatedSerialNumbers(ctx context.Context, deviceLibraryID, passTypeID, updatedSince string) (*repositories.WalletPassUpdates, error) {
+	var since *time.Time
+	if updatedSince != "" {
+		var seconds int64
+		if _, err := fmt.Sscanf(updatedSince, "%d", &seconds); err != nil {
+			return nil, entities.NewValidationError("passesUpdatedSince", "invalid update tag")
+		}
+		t := time.Unix(seconds, 0)
+		since = &t
+	}
+
+	return s.walletRepo.GetUpdatedSerialNumbers(ctx, deviceLibraryID, passTypeID, since)
+}
+
+// GetLatestPass returns the current version of a pass for a device that was told it changed
+func (s *WalletService) GetLatestPass(ctx context.Context, passTypeID, serialNumber, authToken string) (*ApplePass, error) {
+	ticket, err := s.authorizePass(ctx, passTypeID, serialNumber, authToken)
+	if err != nil {
+		return nil, err
+	}
+	return s.buildApplePass(ctx, ticket)
+}
+
+// authorizePass checks the pass type and the authentication token Wallet echoes back from pass.json
+func (s *WalletService) authorizePass(ctx context.Context, passTypeID, serialNumber, authToken string) (*entities.Ticket, error) {
+	if s.apple == nil || passTypeID != s.apple.PassTypeID() {
+		return nil, ErrPassUnauthorized
+	}
+	if !hmac.Equal([]byte(authToken), []byte(s.passToken(serialNumber))) {
+		return nil, ErrPassUnauthorized
+	}
+
+	ticket, err := s.ticketRepo.GetBySerialNumber(ctx, serialNumber)
+	if err != nil {
+		return nil, entities.NewNotFoundError("ticket", "pass not found")
+	}
+	return ticket, nil
+}
+
+func (s *WalletService) buildApplePass(ctx context.Context, ticket *entities.Ticket) (*ApplePass, error) {
+	if s.apple == nil {
+		return nil, entities.NewBusinessRuleError("wallet_not_configured", "Apple Wallet passes are not available", nil)
+	}
+
+	pass, err := s.buildPass(ctx, ticket)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := s.apple.Generate(*pass)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate Apple Wallet pass: %w", err)
+	}
+
+	return &ApplePass{
+		Filename:     fmt.Sprintf("ticket-%s.pkpass", ticket.SerialNumber),
+		Data:         data,
+		LastModified: ticket.UpdatedAt,
+	}, nil
+}
+
+func (s *WalletService) buildGoogleSaveURL(ctx context.Context, ticket *entities.Ticket) (string, error) {
+	if s.google == nil {
+		return "", entities.NewBusinessRuleError("wallet_not_configured", "Google Wallet passes are not available", nil)
+	}
+
+	pass, err := s.buildPass(ctx, ticket)
+	if err != nil {
+		return "", err
+	}
+
+	return s.google.SaveURL(*pass)
+}
+
+// buildPass collects the event, tier and holder details shown on a pass
+func (s *WalletService) buildPass(ctx context.Context, ticket *entities.Ticket) (*walletpass.TicketPass, error) {
+	orderLine, err := s.orderLineRepo.GetByID(ctx, ticket.OrderLineID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order line: %w", err)
+	}
+
+	order, err := s.orderRepo.GetByID(ctx, orderLine.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	eventID, err := uuid.Parse(order.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid event ID on order %s: %w", order.Code, err)
+	}
+	event, err := s.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	return &walletpass.TicketPass{
+		TicketID:            ticket.ID.String(),
+		SerialNumber:        ticket.SerialNumber,
+		Barcode:             ticket.QRCodeData,
+		EventID:             event.ID.String(),
+		EventName:           event.Name,
+		EventDate:           event.EventDate,
+		VenueName:           event.VenueName,
+		VenueAddress:        event.VenueAddress,
+		TierName:            orderLine.TicketTierName,
+		HolderName:          strings.TrimSpace(order.CustomerFirstName + " " + order.CustomerLastName),
+		OrderCode:           order.Code,
+		Voided:              !ticket.IsActive() && !ticket.IsRedeemed(),
+		Redeemed:            ticket.IsRedeemed(),
+		AuthenticationToken: s.passToken(ticket.SerialNumber),
+	}, nil
+}
+
+// getOrderTicket loads a ticket after checking it belongs to the user's order
+func (s *WalletService) getOrderTicket(ctx context.Context, orderID, ticketID, userID uuid.UUID) (*entities.Ticket, error) {
+	order, err := s.orderRepo.GetByID(ctx, orderID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("order", "order not found")
+	}
+	if order.UserID == nil || *order.UserID != userID {
+		return nil, entities.NewNotFoundError("order", "order not found")
+	}
+
+	ticket, err := s.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("ticket", "ticket not found")
+	}
+	orderLine, err := s.orderLineRepo.GetByID(ctx, ticket.OrderLineID)
+	if err != nil || orderLine.OrderID != order.ID {
+		return nil, entities.NewNotFoundError("ticket", "ticket not found")
+	}
+
+	return ticket, nil
+}
+
+// getLinkedTicket loads a ticket named by a signed email link
+func (s *WalletService) getLinkedTicket(ctx context.Context, ticketID uuid.UUID, token string) (*entities.Ticket, error) {
+	if !hmac.Equal([]byte(token), []byte(s.linkToken(ticketID))) {
+		return nil, ErrPassUnauthorized
+	}
+
+	ticket, err := s.ticketRepo.GetByID(ctx, ticketID)
+	if err != nil {
+		return nil, entities.NewNotFoundError("ticket", "ticket not found")
+	}
+	return ticket, nil
+}
+
+// passToken is the per-pass authentication token Apple Wallet presents to the web service
+func (s *WalletService) passToken(serialNumber string) string {
+	return s.sign("pass:" + serialNumber)
+}
+
+// linkToken authorises the wallet download links sent by email
+func (s *WalletService) linkToken(ticketID uuid.UUID) string {
+	return s.sign("link:" + ticketID.String())
+}
+
+func (s *WalletService) sign(value string) string {
+	mac := hmac.New(sha256.New, s.linkSecret)
+	mac.Write([]byte(value))
+	return hex.EncodeToString(mac.Sum(nil))[:32]
+}
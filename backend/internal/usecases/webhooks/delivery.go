@@ -0,0 +1,325 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/eventbus"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
+)
+
+// TopicWebhookDelivery is the outbox topic of one attempt to send a webhook delivery
+const TopicWebhookDelivery = "webhook.deliver"
+
+// Headers sent with every webhook request
+const (
+	HeaderEvent     = "X-Uduxpass-Event"
+	HeaderDelivery  = "X-Uduxpass-Delivery"
+	HeaderTimestamp = "X-Uduxpass-Timestamp"
+	HeaderSignature = "X-Uduxpass-Signature"
+)
+
+const (
+	// subscriberName identifies the webhook fan-out on the event bus
+	subscriberName = "webhooks"
+
+	// requestTimeout bounds one attempt, so a slow receiver cannot hold up the dispatcher
+	requestTimeout = 10 * time.Second
+
+	// maxResponseRead is how much of a receiver's response is read for the delivery log
+	maxResponseRead = 4096
+)
+
+// deliveryPayload identifies the delivery an outbox message sends
+type deliveryPayload struct {
+	DeliveryID uuid.UUID `json:"delivery_id"`
+}
+
+// Envelope is the JSON body of a webhook request. ID is the delivery ID,
+// which stays the same across retries, so receivers can ignore duplicates.
+type Envelope struct {
+	ID        uuid.UUID      `json:"id"`
+	Type      string         `json:"type"`
+	CreatedAt time.Time      `json:"created_at"`
+	Data      entities.JSONB `json:"data"`
+}
+
+// Deliverer turns domain events into webhook deliveries for the organizer's
+// subscriptions and sends them, retrying through the outbox with backoff
+type Deliverer struct {
+	unitOfWork  repositories.UnitOfWork
+	webhookRepo repositories.WebhookRepository
+	eventRepo   repositories.EventRepository
+	client      *http.Client
+}
+
+// NewDeliverer creates a webhook deliverer
+func NewDeliverer(
+	unitOfWork repositories.UnitOfWork,
+	webhookRepo repositories.WebhookRepository,
+	eventRepo repositories.EventRepository,
+) *Deliverer {
+	return &Deliverer{
+		unitOfWork:  unitOfWork,
+		webhookRepo: webhookRepo,
+		eventRepo:   eventRepo,
+		client:      &http.Client{Timeout: requestTimeout},
+	}
+}
+
+// Subscribe fans every event organizers can subscribe to out to their webhooks
+func (d *Deliverer) Subscribe(bus *eventbus.Bus) {
+	for _, eventType := range entities.WebhookEventTypes {
+		bus.SubscribeAsync(subscriberName, eventType, d.fanOut)
+	}
+}
+
+// Register registers the handler that sends queued deliveries
+func (d *Deliverer) Register(dispatcher *outbox.Dispatcher) {
+	dispatcher.Handle(TopicWebhookDelivery, d.deliver)
+}
+
+// fanOut records a delivery for each active subscription to the event and
+// queues it. A repeated fan-out of the same event records nothing new.
+func (d *Deliverer) fanOut(ctx context.Context, event entities.DomainEvent) error {
+	organizerID, err := d.organizerOf(ctx, event)
+	if err != nil || organizerID == nil {
+		return err
+	}
+
+	subscriptions, err := d.webhookRepo.ListActiveSubscriptions(ctx, *organizerID, event.EventName())
+	if err != nil {
+		return err
+	}
+	if len(subscriptions) == 0 {
+		return nil
+	}
+
+	payload, err := encodeJSONB(event)
+	if err != nil {
+		return err
+	}
+
+	tx, err := d.unitOfWork.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	for _, subscription := range subscriptions {
+		delivery := entities.NewWebhookDelivery(subscription.ID, event.EventName(), event.AggregateID(), payload)
+		created, err := tx.Webhooks().CreateDelivery(ctx, delivery)
+		if err != nil {
+			return err
+		}
+		if !created {
+			continue
+		}
+		if err := queueDelivery(ctx, tx.Outbox(), delivery); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// organizerOf finds the organizer of the event an order, ticket or listing
+// belongs to. Events without an organizer have no webhooks.
+func (d *Deliverer) organizerOf(ctx context.Context, event entities.DomainEvent) (*uuid.UUID, error) {
+	var eventID uuid.UUID
+	switch e := event.(type) {
+	case *entities.OrderCreated:
+		eventID = e.EventID
+	case *entities.OrderPaid:
+		eventID = e.EventID
+	case *entities.OrderExpired:
+		eventID = e.EventID
+	case *entities.TicketIssued:
+		eventID = e.EventID
+	case *entities.TicketRedeemed:
+		eventID = e.EventID
+	case *entities.TicketVoided:
+		eventID = e.EventID
+	case *entities.EventPublished:
+		if e.OrganizerID != nil {
+			return e.OrganizerID, nil
+		}
+		eventID = e.EventID
+	default:
+		return nil, fmt.Errorf("unexpected %T for webhooks", event)
+	}
+
+	if eventID == uuid.Nil {
+		return nil, nil
+	}
+	listing, err := d.eventRepo.GetByID(ctx, eventID)
+	if err != nil {
+		if errors.Is(err, entities.ErrEventNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return listing.OrganizerID, nil
+}
+
+// deliver makes one attempt at a queued delivery. A failed attempt is retried
+// by the outbox until it runs out of attempts or the subscription is disabled.
+func (d *Deliverer) deliver(ctx context.Context, message *entities.OutboxMessage) error {
+	var payload deliveryPayload
+	if err := decodeJSONB(message.Payload, &payload); err != nil {
+		return err
+	}
+
+	delivery, err := d.webhookRepo.GetDelivery(ctx, payload.DeliveryID)
+	if err != nil {
+		if err == entities.ErrWebhookDeliveryNotFound {
+			// Deleted together with its subscription
+			return nil
+		}
+		return err
+	}
+	if delivery.Status != entities.WebhookDeliveryPending {
+		return nil
+	}
+
+	subscription, err := d.webhookRepo.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		if err == entities.ErrWebhookSubscriptionNotFound {
+			return nil
+		}
+		return err
+	}
+	if !subscription.IsActive {
+		delivery.Abandon("webhook is disabled")
+		return d.webhookRepo.UpdateDelivery(ctx, delivery)
+	}
+
+	now := time.Now()
+	statusCode, response, sendErr := d.send(ctx, subscription, delivery, now)
+	if sendErr == nil && statusCode >= 200 && statusCode < 300 {
+		delivery.RecordSuccess(statusCode, response, now)
+		if err := d.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+			return err
+		}
+		return d.webhookRepo.RecordSuccess(ctx, subscription.ID)
+	}
+
+	var code *int
+	failure := ""
+	if sendErr != nil {
+		failure = sendErr.Error()
+	} else {
+		code = &statusCode
+		failure = fmt.Sprintf("receiver responded with status %d", statusCode)
+	}
+
+	disabled, err := d.webhookRepo.RecordFailure(ctx, subscription.ID, entities.WebhookMaxConsecutiveFailures)
+	if err != nil {
+		return err
+	}
+	final := disabled || message.Attempts+1 >= message.MaxAttempts
+
+	delivery.RecordFailure(code, failure, response, now, final)
+	if err := d.webhookRepo.UpdateDelivery(ctx, delivery); err != nil {
+		return err
+	}
+	if final {
+		return nil
+	}
+	return errors.New(failure)
+}
+
+// send posts the signed delivery and returns the receiver's status and the start of its response
+func (d *Deliverer) send(ctx context.Context, subscription *entities.WebhookSubscription, delivery *entities.WebhookDelivery, now time.Time) (int, string, error) {
+	body, err := json.Marshal(Envelope{
+		ID:        delivery.ID,
+		Type:      delivery.EventType,
+		CreatedAt: delivery.CreatedAt,
+		Data:      delivery.Payload,
+	})
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to encode webhook body: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, subscription.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to build webhook request: %w", err)
+	}
+
+	timestamp := now.Unix()
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "uduxpass-webhooks/1.0")
+	req.Header.Set(HeaderEvent, delivery.EventType)
+	req.Header.Set(HeaderDelivery, delivery.ID.String())
+	req.Header.Set(HeaderTimestamp, strconv.FormatInt(timestamp, 10))
+	req.Header.Set(HeaderSignature, Sign(subscription.Secret, timestamp, body))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, "", fmt.Errorf("failed to reach receiver: %w", err)
+	}
+	defer resp.Body.Close()
+
+	response, _ := io.ReadAll(io.LimitReader(resp.Body, maxResponseRead))
+	return resp.StatusCode, string(response), nil
+}
+
+// Sign returns the signature header for a webhook body: "t=<timestamp>,v1=<hex>",
+// where the hex is the HMAC-SHA256 of "<timestamp>.<body>" keyed with the
+// subscription's secret. Receivers recompute it and compare in constant time,
+// and reject old timestamps to stop replays.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10)))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// queueDelivery queues an attempt at a delivery in the outbox
+func queueDelivery(ctx context.Context, outboxRepo repositories.OutboxRepository, delivery *entities.WebhookDelivery) error {
+	payload, err := encodeJSONB(deliveryPayload{DeliveryID: delivery.ID})
+	if err != nil {
+		return err
+	}
+	message := entities.NewOutboxMessage(TopicWebhookDelivery, payload)
+	message.SetAggregate("webhook_delivery", delivery.ID)
+	return outboxRepo.Create(ctx, message)
+}
+
+func encodeJSONB(value interface{}) (entities.JSONB, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	var encoded entities.JSONB
+	if err := json.Unmarshal(data, &encoded); err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+	return encoded, nil
+}
+
+func decodeJSONB(payload entities.JSONB, value interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
+	if err := json.Unmarshal(data, value); err != nil {
+		return fmt.Errorf("failed to decode webhook payload: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,445 @@
+package webhooks
+
+import (
+	"context"
+	"crypto/hmac"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+	"github.com/uduxpass/backend/internal/usecases/outbox"
+)
+
+// The fakes embed the repository interfaces, so a call the deliverer is not
+// expected to make panics instead of passing silently.
+
+type fakeWebhooks struct {
+	repositories.WebhookRepository
+	subscriptions map[uuid.UUID]*entities.WebhookSubscription
+	deliveries    map[uuid.UUID]*entities.WebhookDelivery
+}
+
+func (f *fakeWebhooks) GetSubscription(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	subscription, ok := f.subscriptions[id]
+	if !ok {
+		return nil, entities.ErrWebhookSubscriptionNotFound
+	}
+	copied := *subscription
+	return &copied, nil
+}
+
+func (f *fakeWebhooks) RecordSuccess(ctx context.Context, id uuid.UUID) error {
+	f.subscriptions[id].ConsecutiveFailures = 0
+	return nil
+}
+
+// RecordFailure follows the postgres repository: the streak grows with every
+// failure and disables the subscription once it reaches maxFailures
+func (f *fakeWebhooks) RecordFailure(ctx context.Context, id uuid.UUID, maxFailures int) (bool, error) {
+	subscription := f.subscriptions[id]
+	subscription.ConsecutiveFailures++
+	if subscription.IsActive && subscription.ConsecutiveFailures >= maxFailures {
+		subscription.Disable("disabled after " + strconv.Itoa(maxFailures) + " failed deliveries in a row")
+	}
+	return !subscription.IsActive, nil
+}
+
+func (f *fakeWebhooks) GetDelivery(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	delivery, ok := f.deliveries[id]
+	if !ok {
+		return nil, entities.ErrWebhookDeliveryNotFound
+	}
+	copied := *delivery
+	return &copied, nil
+}
+
+func (f *fakeWebhooks) UpdateDelivery(ctx context.Context, delivery *entities.WebhookDelivery) error {
+	copied := *delivery
+	f.deliveries[delivery.ID] = &copied
+	return nil
+}
+
+// fakeOutbox claims pending messages that are due, in the order they were queued
+type fakeOutbox struct {
+	repositories.OutboxRepository
+	messages []*entities.OutboxMessage
+}
+
+func (f *fakeOutbox) Create(ctx context.Context, message *entities.OutboxMessage) error {
+	f.messages = append(f.messages, message)
+	return nil
+}
+
+func (f *fakeOutbox) ClaimDue(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxMessage, error) {
+	now := time.Now()
+	var due []*entities.OutboxMessage
+	for _, message := range f.messages {
+		if len(due) == limit {
+			break
+		}
+		if message.Status == entities.OutboxStatusPending && !message.NextAttemptAt.After(now) {
+			due = append(due, message)
+		}
+	}
+	return due, nil
+}
+
+func (f *fakeOutbox) Update(ctx context.Context, message *entities.OutboxMessage) error {
+	return nil
+}
+
+// makeDue moves every waiting message's next attempt to now, standing in for
+// the backoff running out
+func (f *fakeOutbox) makeDue() {
+	for _, message := range f.messages {
+		if message.Status == entities.OutboxStatusPending {
+			message.NextAttemptAt = time.Now()
+		}
+	}
+}
+
+type fakeTx struct {
+	repositories.Transaction
+	webhooks *fakeWebhooks
+	outbox   *fakeOutbox
+}
+
+func (tx *fakeTx) Commit() error                            { return nil }
+func (tx *fakeTx) Rollback() error                          { return nil }
+func (tx *fakeTx) Webhooks() repositories.WebhookRepository { return tx.webhooks }
+func (tx *fakeTx) Outbox() repositories.OutboxRepository    { return tx.outbox }
+
+type fakeUnitOfWork struct {
+	tx *fakeTx
+}
+
+func (u *fakeUnitOfWork) Begin(ctx context.Context) (repositories.Transaction, error) {
+	return u.tx, nil
+}
+
+// receivedRequest is one request the test receiver saw
+type receivedRequest struct {
+	header http.Header
+	body   []byte
+}
+
+// receiver is an httptest webhook endpoint that answers with the queued
+// status codes in turn, then with 200
+type receiver struct {
+	*httptest.Server
+	mu       sync.Mutex
+	statuses []int
+	requests []receivedRequest
+}
+
+func newReceiver(t *testing.T, statuses ...int) *receiver {
+	t.Helper()
+	r := &receiver{statuses: statuses}
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+
+		r.mu.Lock()
+		r.requests = append(r.requests, receivedRequest{header: req.Header.Clone(), body: body})
+		status := http.StatusOK
+		if len(r.statuses) > 0 {
+			status, r.statuses = r.statuses[0], r.statuses[1:]
+		}
+		r.mu.Unlock()
+
+		w.WriteHeader(status)
+		w.Write([]byte(http.StatusText(status)))
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *receiver) received() []receivedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedRequest(nil), r.requests...)
+}
+
+type deliveryFixture struct {
+	webhooks     *fakeWebhooks
+	outbox       *fakeOutbox
+	dispatcher   *outbox.Dispatcher
+	service      *WebhookService
+	subscription *entities.WebhookSubscription
+}
+
+// newDeliveryFixture wires a deliverer and a webhook service to the real
+// outbox dispatcher, with one subscription pointing at the receiver
+func newDeliveryFixture(t *testing.T, r *receiver) *deliveryFixture {
+	t.Helper()
+
+	subscription, err := entities.NewWebhookSubscription(uuid.New(), r.URL, []string{entities.DomainEventOrderPaid})
+	if err != nil {
+		t.Fatalf("NewWebhookSubscription() error = %v", err)
+	}
+
+	webhooks := &fakeWebhooks{
+		subscriptions: map[uuid.UUID]*entities.WebhookSubscription{subscription.ID: subscription},
+		deliveries:    make(map[uuid.UUID]*entities.WebhookDelivery),
+	}
+	outboxRepo := &fakeOutbox{}
+	unitOfWork := &fakeUnitOfWork{tx: &fakeTx{webhooks: webhooks, outbox: outboxRepo}}
+
+	dispatcher := outbox.NewDispatcher(outboxRepo)
+	NewDeliverer(unitOfWork, webhooks, nil).Register(dispatcher)
+
+	return &deliveryFixture{
+		webhooks:     webhooks,
+		outbox:       outboxRepo,
+		dispatcher:   dispatcher,
+		service:      NewWebhookService(unitOfWork, webhooks, nil),
+		subscription: subscription,
+	}
+}
+
+// queue records a pending delivery of an OrderPaid event and queues it, the
+// way the fan-out does
+func (f *deliveryFixture) queue(t *testing.T) *entities.WebhookDelivery {
+	t.Helper()
+	payload, err := encodeJSONB(map[string]interface{}{"order_id": uuid.New(), "total_amount": 50000})
+	if err != nil {
+		t.Fatalf("encodeJSONB() error = %v", err)
+	}
+	delivery := entities.NewWebhookDelivery(f.subscription.ID, entities.DomainEventOrderPaid, uuid.New(), payload)
+	f.webhooks.deliveries[delivery.ID] = delivery
+	if err := queueDelivery(context.Background(), f.outbox, delivery); err != nil {
+		t.Fatalf("queueDelivery() error = %v", err)
+	}
+	return delivery
+}
+
+func (f *deliveryFixture) dispatch(t *testing.T) int {
+	t.Helper()
+	attempted, err := f.dispatcher.DispatchDue(context.Background())
+	if err != nil {
+		t.Fatalf("DispatchDue() error = %v", err)
+	}
+	return attempted
+}
+
+func TestDeliverySignsBodyWithTimestamp(t *testing.T) {
+	r := newReceiver(t)
+	f := newDeliveryFixture(t, r)
+	delivery := f.queue(t)
+
+	before := time.Now().Unix()
+	f.dispatch(t)
+	after := time.Now().Unix()
+
+	requests := r.received()
+	if len(requests) != 1 {
+		t.Fatalf("receiver got %d requests, want 1", len(requests))
+	}
+	req := requests[0]
+
+	if got := req.header.Get(HeaderEvent); got != entities.DomainEventOrderPaid {
+		t.Errorf("%s = %q, want %q", HeaderEvent, got, entities.DomainEventOrderPaid)
+	}
+	if got := req.header.Get(HeaderDelivery); got != delivery.ID.String() {
+		t.Errorf("%s = %q, want %q", HeaderDelivery, got, delivery.ID)
+	}
+
+	timestamp, err := strconv.ParseInt(req.header.Get(HeaderTimestamp), 10, 64)
+	if err != nil {
+		t.Fatalf("%s is not a unix timestamp: %v", HeaderTimestamp, err)
+	}
+	if timestamp < before || timestamp > after {
+		t.Errorf("%s = %d, want between %d and %d", HeaderTimestamp, timestamp, before, after)
+	}
+
+	// A receiver recomputes the signature from the raw body and its secret
+	want := Sign(f.subscription.Secret, timestamp, req.body)
+	if got := req.header.Get(HeaderSignature); !hmac.Equal([]byte(got), []byte(want)) {
+		t.Errorf("%s = %q, want %q", HeaderSignature, got, want)
+	}
+	if got := req.header.Get(HeaderSignature); got == Sign("whsec_other", timestamp, req.body) {
+		t.Errorf("signature does not depend on the secret")
+	}
+
+	var envelope Envelope
+	if err := json.Unmarshal(req.body, &envelope); err != nil {
+		t.Fatalf("body is not an envelope: %v", err)
+	}
+	if envelope.ID != delivery.ID || envelope.Type != entities.DomainEventOrderPaid || envelope.Data["total_amount"] != float64(50000) {
+		t.Errorf("envelope = %+v, want delivery %s of the order", envelope, delivery.ID)
+	}
+
+	stored := f.webhooks.deliveries[delivery.ID]
+	if stored.Status != entities.WebhookDeliverySucceeded || stored.Attempts != 1 {
+		t.Errorf("delivery status %s after %d attempts, want succeeded after 1", stored.Status, stored.Attempts)
+	}
+}
+
+func TestDeliveryRetriesWithBackoffOnNon2xx(t *testing.T) {
+	r := newReceiver(t, http.StatusInternalServerError, http.StatusBadGateway)
+	f := newDeliveryFixture(t, r)
+	delivery := f.queue(t)
+	message := f.outbox.messages[0]
+
+	var waits []time.Duration
+	for attempt := 1; attempt <= 2; attempt++ {
+		failedAt := time.Now()
+		f.dispatch(t)
+
+		if message.Status != entities.OutboxStatusPending || message.Attempts != attempt {
+			t.Fatalf("after failure %d the message is %s with %d attempts", attempt, message.Status, message.Attempts)
+		}
+		waits = append(waits, message.NextAttemptAt.Sub(failedAt))
+
+		stored := f.webhooks.deliveries[delivery.ID]
+		if stored.Status != entities.WebhookDeliveryPending || stored.LastStatusCode == nil {
+			t.Fatalf("after failure %d the delivery is %s, want pending with a status code", attempt, stored.Status)
+		}
+
+		// Nothing is sent again before the backoff runs out
+		if attempted := f.dispatch(t); attempted != 0 {
+			t.Fatalf("retried %d messages before the backoff ran out", attempted)
+		}
+		f.outbox.makeDue()
+	}
+
+	if *f.webhooks.deliveries[delivery.ID].LastStatusCode != http.StatusBadGateway {
+		t.Errorf("last status code = %d, want %d", *f.webhooks.deliveries[delivery.ID].LastStatusCode, http.StatusBadGateway)
+	}
+	for i, wait := range waits {
+		want := entities.OutboxBackoff(i + 1)
+		if wait < want-time.Second || wait > want+time.Second {
+			t.Errorf("wait after failure %d = %s, want about %s", i+1, wait, want)
+		}
+	}
+	if waits[1] <= waits[0] {
+		t.Errorf("backoff did not grow: %s then %s", waits[0], waits[1])
+	}
+	if f.webhooks.subscriptions[f.subscription.ID].ConsecutiveFailures != 2 {
+		t.Errorf("failure streak = %d, want 2", f.webhooks.subscriptions[f.subscription.ID].ConsecutiveFailures)
+	}
+
+	f.dispatch(t)
+
+	if message.Status != entities.OutboxStatusDelivered {
+		t.Errorf("message is %s after the receiver accepted it", message.Status)
+	}
+	stored := f.webhooks.deliveries[delivery.ID]
+	if stored.Status != entities.WebhookDeliverySucceeded || stored.Attempts != 3 {
+		t.Errorf("delivery status %s after %d attempts, want succeeded after 3", stored.Status, stored.Attempts)
+	}
+	if f.webhooks.subscriptions[f.subscription.ID].ConsecutiveFailures != 0 {
+		t.Errorf("a success did not reset the failure streak")
+	}
+
+	// Every attempt carries the same delivery ID, so the receiver can drop duplicates
+	for i, req := range r.received() {
+		if got := req.header.Get(HeaderDelivery); got != delivery.ID.String() {
+			t.Errorf("attempt %d sent delivery %s, want %s", i+1, got, delivery.ID)
+		}
+	}
+}
+
+func TestDeliveryDisablesSubscriptionAfterRepeatedFailures(t *testing.T) {
+	statuses := make([]int, 100)
+	for i := range statuses {
+		statuses[i] = http.StatusServiceUnavailable
+	}
+	r := newReceiver(t, statuses...)
+	f := newDeliveryFixture(t, r)
+
+	// One delivery dead-letters long before the streak is reached, so keep
+	// several failing until the subscription gives up
+	var deliveries []*entities.WebhookDelivery
+	for i := 0; i < 3; i++ {
+		deliveries = append(deliveries, f.queue(t))
+	}
+	for f.dispatch(t) > 0 {
+		f.outbox.makeDue()
+	}
+
+	subscription := f.webhooks.subscriptions[f.subscription.ID]
+	if subscription.IsActive || subscription.DisabledAt == nil || subscription.DisabledReason == nil {
+		t.Fatalf("subscription still active after %d failures", subscription.ConsecutiveFailures)
+	}
+	if got := len(r.received()); got != entities.WebhookMaxConsecutiveFailures {
+		t.Errorf("receiver got %d requests, want %d before the subscription was disabled", got, entities.WebhookMaxConsecutiveFailures)
+	}
+
+	// The delivery that hit the limit and the ones still waiting all give up
+	for _, delivery := range deliveries {
+		if status := f.webhooks.deliveries[delivery.ID].Status; status != entities.WebhookDeliveryFailed {
+			t.Errorf("delivery %s is %s, want failed", delivery.ID, status)
+		}
+	}
+	for _, message := range f.outbox.messages {
+		if message.Status != entities.OutboxStatusDelivered {
+			t.Errorf("outbox message %s is %s, want it finished without a retry", message.ID, message.Status)
+		}
+	}
+
+	// New deliveries are not sent to a disabled subscription
+	late := f.queue(t)
+	f.dispatch(t)
+	if got := len(r.received()); got != entities.WebhookMaxConsecutiveFailures {
+		t.Errorf("a disabled subscription was sent another request")
+	}
+	if status := f.webhooks.deliveries[late.ID].Status; status != entities.WebhookDeliveryFailed {
+		t.Errorf("delivery to a disabled subscription is %s, want failed", status)
+	}
+
+	// Nor can finished ones be redelivered until it is enabled again
+	_, err := f.service.Redeliver(context.Background(), late.ID)
+	var ruleErr *entities.BusinessRuleError
+	if !errors.As(err, &ruleErr) || ruleErr.Rule != "webhook_disabled" {
+		t.Errorf("Redeliver() error = %v, want webhook_disabled", err)
+	}
+}
+
+func TestManualRedeliverySendsSameDeliveryAgain(t *testing.T) {
+	r := newReceiver(t)
+	f := newDeliveryFixture(t, r)
+	delivery := f.queue(t)
+	f.dispatch(t)
+
+	redelivered, err := f.service.Redeliver(context.Background(), delivery.ID)
+	if err != nil {
+		t.Fatalf("Redeliver() error = %v", err)
+	}
+	if redelivered.Status != entities.WebhookDeliveryPending {
+		t.Errorf("redelivered status = %s, want pending", redelivered.Status)
+	}
+
+	// A delivery cannot be redelivered while it is still on its way
+	var ruleErr *entities.BusinessRuleError
+	if _, err := f.service.Redeliver(context.Background(), delivery.ID); !errors.As(err, &ruleErr) || ruleErr.Rule != "webhook_delivery_pending" {
+		t.Errorf("Redeliver() of a pending delivery error = %v, want webhook_delivery_pending", err)
+	}
+
+	f.dispatch(t)
+
+	requests := r.received()
+	if len(requests) != 2 {
+		t.Fatalf("receiver got %d requests, want the original and the redelivery", len(requests))
+	}
+	for i, req := range requests {
+		if got := req.header.Get(HeaderDelivery); got != delivery.ID.String() {
+			t.Errorf("request %d sent delivery %s, want %s", i+1, got, delivery.ID)
+		}
+	}
+	if string(requests[0].body) != string(requests[1].body) {
+		t.Errorf("redelivery body differs from the original")
+	}
+	stored := f.webhooks.deliveries[delivery.ID]
+	if stored.Status != entities.WebhookDeliverySucceeded || stored.Attempts != 2 {
+		t.Errorf("delivery status %s after %d attempts, want succeeded after 2", stored.Status, stored.Attempts)
+	}
+}
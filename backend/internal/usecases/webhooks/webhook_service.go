@@ -0,0 +1,248 @@
+package webhooks
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uduxpass/backend/internal/domain/entities"
+	"github.com/uduxpass/backend/internal/domain/repositories"
+)
+
+// WebhookService handles managing organizer webhook subscriptions and their delivery log
+type WebhookService struct {
+	unitOfWork    repositories.UnitOfWork
+	webhookRepo   repositories.WebhookRepository
+	organizerRepo repositories.OrganizerRepository
+}
+
+// NewWebhookService creates a new webhook service
+func NewWebhookService(
+	unitOfWork repositories.UnitOfWork,
+	webhookRepo repositories.WebhookRepository,
+	organizerRepo repositories.OrganizerRepository,
+) *WebhookService {
+	return &WebhookService{
+		unitOfWork:    unitOfWork,
+		webhookRepo:   webhookRepo,
+		organizerRepo: organizerRepo,
+	}
+}
+
+// CreateWebhookRequest represents the request to subscribe a URL to an organizer's events
+type CreateWebhookRequest struct {
+	URL         string   `json:"url" validate:"required,url,max=2000"`
+	Description *string  `json:"description,omitempty" validate:"omitempty,max=255"`
+	EventTypes  []string `json:"event_types" validate:"required,min=1"`
+}
+
+// UpdateWebhookRequest represents the request to update a subscription. Omitted
+// fields are left unchanged; is_active re-enables a disabled subscription.
+type UpdateWebhookRequest struct {
+	URL         *string  `json:"url,omitempty" validate:"omitempty,url,max=2000"`
+	Description *string  `json:"description,omitempty" validate:"omitempty,max=255"`
+	EventTypes  []string `json:"event_types,omitempty"`
+	IsActive    *bool    `json:"is_active,omitempty"`
+}
+
+// WebhookWithSecret is a subscription together with its signing secret, which
+// is only shown when the subscription is created or its secret rotated
+type WebhookWithSecret struct {
+	*entities.WebhookSubscription
+	Secret string `json:"secret"`
+}
+
+// ListSubscriptions lists an organizer's webhook subscriptions
+func (s *WebhookService) ListSubscriptions(ctx context.Context, organizerID uuid.UUID) ([]*entities.WebhookSubscription, error) {
+	if err := s.checkOrganizer(ctx, organizerID); err != nil {
+		return nil, err
+	}
+	return s.webhookRepo.ListSubscriptions(ctx, organizerID)
+}
+
+// GetSubscription retrieves a webhook subscription
+func (s *WebhookService) GetSubscription(ctx context.Context, id uuid.UUID) (*entities.WebhookSubscription, error) {
+	subscription, err := s.webhookRepo.GetSubscription(ctx, id)
+	if err != nil {
+		if err == entities.ErrWebhookSubscriptionNotFound {
+			return nil, entities.NewNotFoundError("webhook", "webhook not found")
+		}
+		return nil, err
+	}
+	return subscription, nil
+}
+
+// CreateSubscription subscribes a URL to an organizer's events
+func (s *WebhookService) CreateSubscription(ctx context.Context, organizerID uuid.UUID, req *CreateWebhookRequest) (*WebhookWithSecret, error) {
+	if err := s.checkOrganizer(ctx, organizerID); err != nil {
+		return nil, err
+	}
+
+	subscription, err := entities.NewWebhookSubscription(organizerID, strings.TrimSpace(req.URL), req.EventTypes)
+	if err != nil {
+		return nil, err
+	}
+	subscription.Description = trimOptional(req.Description)
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.webhookRepo.CreateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return &WebhookWithSecret{WebhookSubscription: subscription, Secret: subscription.Secret}, nil
+}
+
+// UpdateSubscription updates a subscription's URL, description, event types or state
+func (s *WebhookService) UpdateSubscription(ctx context.Context, id uuid.UUID, req *UpdateWebhookRequest) (*entities.WebhookSubscription, error) {
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.URL != nil {
+		subscription.URL = strings.TrimSpace(*req.URL)
+	}
+	if req.Description != nil {
+		subscription.Description = trimOptional(req.Description)
+	}
+	if req.EventTypes != nil {
+		subscription.EventTypes = req.EventTypes
+	}
+	if req.IsActive != nil && *req.IsActive != subscription.IsActive {
+		if *req.IsActive {
+			subscription.Enable()
+		} else {
+			subscription.Disable("disabled by an administrator")
+		}
+	}
+	subscription.UpdatedAt = time.Now()
+
+	if err := subscription.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.webhookRepo.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return subscription, nil
+}
+
+// DeleteSubscription deletes a subscription together with its delivery log
+func (s *WebhookService) DeleteSubscription(ctx context.Context, id uuid.UUID) error {
+	if err := s.webhookRepo.DeleteSubscription(ctx, id); err != nil {
+		if err == entities.ErrWebhookSubscriptionNotFound {
+			return entities.NewNotFoundError("webhook", "webhook not found")
+		}
+		return err
+	}
+	return nil
+}
+
+// RotateSecret replaces a subscription's signing secret. Deliveries signed
+// with the old secret stop verifying straight away.
+func (s *WebhookService) RotateSecret(ctx context.Context, id uuid.UUID) (*WebhookWithSecret, error) {
+	subscription, err := s.GetSubscription(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := entities.GenerateWebhookSecret()
+	if err != nil {
+		return nil, err
+	}
+	subscription.Secret = secret
+	subscription.UpdatedAt = time.Now()
+
+	if err := s.webhookRepo.UpdateSubscription(ctx, subscription); err != nil {
+		return nil, err
+	}
+
+	return &WebhookWithSecret{WebhookSubscription: subscription, Secret: secret}, nil
+}
+
+// ListDeliveries lists a subscription's delivery log, newest first
+func (s *WebhookService) ListDeliveries(ctx context.Context, filter repositories.WebhookDeliveryFilter) ([]*entities.WebhookDelivery, *repositories.PaginationResult, error) {
+	if _, err := s.GetSubscription(ctx, filter.SubscriptionID); err != nil {
+		return nil, nil, err
+	}
+	return s.webhookRepo.ListDeliveries(ctx, filter)
+}
+
+// GetDelivery retrieves a webhook delivery
+func (s *WebhookService) GetDelivery(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	delivery, err := s.webhookRepo.GetDelivery(ctx, id)
+	if err != nil {
+		if err == entities.ErrWebhookDeliveryNotFound {
+			return nil, entities.NewNotFoundError("webhook_delivery", "webhook delivery not found")
+		}
+		return nil, err
+	}
+	return delivery, nil
+}
+
+// Redeliver sends a finished delivery again with the same idempotency ID,
+// e.g. after the receiver fixed a bug. The subscription must be active.
+func (s *WebhookService) Redeliver(ctx context.Context, id uuid.UUID) (*entities.WebhookDelivery, error) {
+	delivery, err := s.GetDelivery(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	subscription, err := s.GetSubscription(ctx, delivery.SubscriptionID)
+	if err != nil {
+		return nil, err
+	}
+	if !subscription.IsActive {
+		return nil, entities.NewBusinessRuleError("webhook_disabled", "webhook is disabled; enable it before redelivering", map[string]interface{}{
+			"webhook_id": subscription.ID,
+		})
+	}
+
+	if err := delivery.Redeliver(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.unitOfWork.Begin(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer tx.Rollback()
+
+	if err := tx.Webhooks().UpdateDelivery(ctx, delivery); err != nil {
+		return nil, err
+	}
+	if err := queueDelivery(ctx, tx.Outbox(), delivery); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	return delivery, nil
+}
+
+// checkOrganizer returns a not found error unless the organizer exists and is active
+func (s *WebhookService) checkOrganizer(ctx context.Context, organizerID uuid.UUID) error {
+	if _, err := s.organizerRepo.GetByID(ctx, organizerID); err != nil {
+		if err == entities.ErrNotFoundError {
+			return entities.NewNotFoundError("organizer", "organizer not found")
+		}
+		return err
+	}
+	return nil
+}
+
+func trimOptional(value *string) *string {
+	if value == nil {
+		return nil
+	}
+	trimmed := strings.TrimSpace(*value)
+	if trimmed == "" {
+		return nil
+	}
+	return &trimmed
+}
@@ -0,0 +1,40 @@
+-- Migration 022: Multi-currency events and admin-maintained FX rates
+-- Adds: currency to events (every tier and order of an event is priced in it)
+-- Adds: fx_rates table used only for reporting conversion, never for charging.
+-- Settlement stays in the event currency; existing rows default to NGN.
+
+-- ─── events table ─────────────────────────────────────────────────────────────
+
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS currency VARCHAR(3) NOT NULL DEFAULT 'NGN';
+
+COMMENT ON COLUMN events.currency IS 'ISO 4217 code all ticket tiers and orders of this event are priced and settled in (NGN, GHS, KES, USD).';
+
+-- Bring existing tiers in line with their event so no order can mix currencies.
+UPDATE ticket_tiers tt
+SET currency = e.currency
+FROM events e
+WHERE tt.event_id = e.id AND tt.currency <> e.currency;
+
+CREATE INDEX IF NOT EXISTS idx_orders_currency ON orders(currency);
+
+-- ─── fx_rates table ───────────────────────────────────────────────────────────
+
+-- One row per published rate. The latest row with effective_at <= a given
+-- instant is the rate in force at that instant, so history is never rewritten.
+CREATE TABLE IF NOT EXISTS fx_rates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    base_currency VARCHAR(3) NOT NULL,
+    quote_currency VARCHAR(3) NOT NULL,
+    rate DECIMAL(20, 8) NOT NULL CHECK (rate > 0),
+    effective_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    source VARCHAR(100),
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (base_currency <> quote_currency),
+    UNIQUE(base_currency, quote_currency, effective_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_fx_rates_pair ON fx_rates(base_currency, quote_currency, effective_at DESC);
+
+COMMENT ON TABLE fx_rates IS 'Admin-maintained exchange rates. 1 base_currency = rate quote_currency. Used for reporting conversion only.';
@@ -0,0 +1,59 @@
+-- Migration 023: Box office / point-of-sale sales
+-- Adds: cash and pos_terminal payment methods
+-- Adds: box_office_shifts (one cash drawer session per operator per event)
+-- Adds: box_office_sales (links each walk-in order to the shift that took the money)
+-- Walk-in orders have no user account; orders.user_id is already nullable.
+
+-- ─── payment methods ──────────────────────────────────────────────────────────
+
+ALTER TYPE payment_method ADD VALUE IF NOT EXISTS 'cash';
+ALTER TYPE payment_method ADD VALUE IF NOT EXISTS 'pos_terminal';
+
+-- ─── box_office_shifts table ──────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS box_office_shifts (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    operator_id UUID NOT NULL,
+    currency VARCHAR(3) NOT NULL DEFAULT 'NGN',
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'closed')),
+    opening_float DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    counted_cash DECIMAL(12, 2),
+    expected_cash DECIMAL(12, 2),
+    cash_variance DECIMAL(12, 2),
+    notes TEXT,
+    opened_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    closed_at TIMESTAMP WITH TIME ZONE,
+    closed_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- An operator can only have one open drawer at a time.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_box_office_shifts_open_operator
+    ON box_office_shifts(operator_id) WHERE status = 'open';
+CREATE INDEX IF NOT EXISTS idx_box_office_shifts_event ON box_office_shifts(event_id);
+
+COMMENT ON COLUMN box_office_shifts.opening_float IS 'Cash placed in the drawer when the shift was opened.';
+COMMENT ON COLUMN box_office_shifts.expected_cash IS 'opening_float + cash sales, computed when the shift is closed.';
+COMMENT ON COLUMN box_office_shifts.cash_variance IS 'counted_cash - expected_cash. Negative means the drawer is short.';
+
+-- ─── box_office_sales table ───────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS box_office_sales (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    shift_id UUID NOT NULL REFERENCES box_office_shifts(id) ON DELETE RESTRICT,
+    order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+    operator_id UUID NOT NULL,
+    payment_method payment_method NOT NULL,
+    amount DECIMAL(12, 2) NOT NULL,
+    amount_tendered DECIMAL(12, 2),
+    change_given DECIMAL(12, 2),
+    terminal_reference VARCHAR(255),
+    ticket_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_box_office_sales_shift ON box_office_sales(shift_id);
+
+COMMENT ON COLUMN box_office_sales.terminal_reference IS 'Card terminal approval / RRN for pos_terminal sales.';
@@ -0,0 +1,58 @@
+-- Migration 024: Complimentary tickets and guest lists
+-- Adds: orders.is_comp (comp orders are 'paid' so tickets scan, but carry no revenue)
+-- Adds: comp_allocations (comp quota per ticket tier)
+-- Adds: guest_list_entries (one named comp per entry, checkable by name at the door)
+
+-- ─── orders ───────────────────────────────────────────────────────────────────
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS is_comp BOOLEAN NOT NULL DEFAULT false;
+
+CREATE INDEX IF NOT EXISTS idx_orders_is_comp ON orders(event_id) WHERE is_comp = true;
+
+COMMENT ON COLUMN orders.is_comp IS 'Complimentary order issued from a comp allocation. Excluded from revenue, counted against capacity.';
+
+-- ─── comp_allocations table ───────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS comp_allocations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    ticket_tier_id UUID NOT NULL UNIQUE REFERENCES ticket_tiers(id) ON DELETE CASCADE,
+    quota INTEGER NOT NULL CHECK (quota >= 0),
+    issued INTEGER NOT NULL DEFAULT 0 CHECK (issued >= 0),
+    created_by UUID,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CONSTRAINT comp_allocations_within_quota CHECK (issued <= quota)
+);
+
+CREATE INDEX IF NOT EXISTS idx_comp_allocations_event ON comp_allocations(event_id);
+
+-- ─── guest_list_entries table ─────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS guest_list_entries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    ticket_tier_id UUID NOT NULL REFERENCES ticket_tiers(id) ON DELETE CASCADE,
+    allocation_id UUID NOT NULL REFERENCES comp_allocations(id) ON DELETE RESTRICT,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    first_name VARCHAR(100) NOT NULL,
+    last_name VARCHAR(100) NOT NULL,
+    email VARCHAR(255),
+    phone VARCHAR(20),
+    category VARCHAR(20) NOT NULL DEFAULT 'guest' CHECK (category IN ('artist', 'sponsor', 'press', 'staff', 'guest')),
+    plus_ones INTEGER NOT NULL DEFAULT 0 CHECK (plus_ones >= 0),
+    notes TEXT,
+    status VARCHAR(20) NOT NULL DEFAULT 'issued' CHECK (status IN ('issued', 'checked_in', 'cancelled')),
+    issued_by UUID,
+    checked_in_at TIMESTAMP WITH TIME ZONE,
+    checked_in_by VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_guest_list_entries_event ON guest_list_entries(event_id);
+CREATE INDEX IF NOT EXISTS idx_guest_list_entries_name ON guest_list_entries(event_id, LOWER(last_name), LOWER(first_name));
+
+COMMENT ON COLUMN guest_list_entries.plus_ones IS 'Additional admissions on the same entry. One ticket is issued per admission.';
+COMMENT ON COLUMN guest_list_entries.checked_in_by IS 'admin:<id> or scanner:<id> of the staff member who admitted the guest by name.';
@@ -0,0 +1,67 @@
+-- Migration 025: Bulk ticket imports
+-- Adds: orders.external_reference (idempotency key for imported / partner-sold orders)
+-- Adds: ticket_imports (one uploaded CSV and its progress)
+-- Adds: ticket_import_rows (staged rows; pending rows are picked up again on resume)
+
+-- ─── orders ───────────────────────────────────────────────────────────────────
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS external_reference VARCHAR(255);
+
+-- An external reference can only ever produce one order per event, across all imports.
+CREATE UNIQUE INDEX IF NOT EXISTS idx_orders_event_external_reference
+    ON orders(event_id, external_reference) WHERE external_reference IS NOT NULL;
+
+COMMENT ON COLUMN orders.external_reference IS 'Reference from the source platform or partner. Unique per event; makes imports idempotent.';
+
+-- ─── ticket_imports table ─────────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS ticket_imports (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    source VARCHAR(100),
+    filename VARCHAR(255),
+    status VARCHAR(30) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'completed', 'completed_with_errors', 'failed')),
+    send_emails BOOLEAN NOT NULL DEFAULT false,
+    total_rows INTEGER NOT NULL DEFAULT 0,
+    imported_rows INTEGER NOT NULL DEFAULT 0,
+    skipped_rows INTEGER NOT NULL DEFAULT 0,
+    failed_rows INTEGER NOT NULL DEFAULT 0,
+    tickets_issued INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_by UUID,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_imports_event ON ticket_imports(event_id);
+
+-- ─── ticket_import_rows table ─────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS ticket_import_rows (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    import_id UUID NOT NULL REFERENCES ticket_imports(id) ON DELETE CASCADE,
+    row_number INTEGER NOT NULL,
+    external_reference VARCHAR(255),
+    first_name VARCHAR(100),
+    last_name VARCHAR(100),
+    email VARCHAR(255),
+    phone VARCHAR(20),
+    tier VARCHAR(255),
+    quantity INTEGER NOT NULL DEFAULT 1,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'imported', 'skipped', 'failed')),
+    error TEXT,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (import_id, row_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_import_rows_pending
+    ON ticket_import_rows(import_id, row_number) WHERE status = 'pending';
+
+COMMENT ON COLUMN ticket_import_rows.status IS 'skipped = external reference already imported (idempotent re-run); failed = see error.';
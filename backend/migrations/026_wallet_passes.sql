@@ -0,0 +1,28 @@
+-- Migration 026: Apple Wallet and Google Wallet passes
+-- Adds: wallet_pass_registrations (devices that installed an Apple Wallet pass and
+--       asked to be told about updates, per the PassKit web service protocol)
+-- Google Wallet objects need no table: their IDs are derived from the ticket ID.
+
+-- ─── wallet_pass_registrations table ──────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS wallet_pass_registrations (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    device_library_id VARCHAR(255) NOT NULL,
+    push_token VARCHAR(255) NOT NULL,
+    pass_type_id VARCHAR(255) NOT NULL,
+    serial_number VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (device_library_id, pass_type_id, serial_number)
+);
+
+CREATE INDEX IF NOT EXISTS idx_wallet_pass_registrations_serial ON wallet_pass_registrations(pass_type_id, serial_number);
+CREATE INDEX IF NOT EXISTS idx_wallet_pass_registrations_device ON wallet_pass_registrations(device_library_id);
+
+COMMENT ON TABLE wallet_pass_registrations IS 'Apple Wallet devices registered for update pushes on a ticket pass';
+COMMENT ON COLUMN wallet_pass_registrations.serial_number IS 'Pass serial number; equal to tickets.serial_number';
+
+-- ─── tickets ──────────────────────────────────────────────────────────────────
+
+-- Wallet web services ask for passes changed since a timestamp
+CREATE INDEX IF NOT EXISTS idx_tickets_serial_updated ON tickets(serial_number, updated_at);
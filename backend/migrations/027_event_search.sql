@@ -0,0 +1,96 @@
+-- Migration 027: Full-text and faceted event search
+-- Adds: pg_trgm extension (typo-tolerant fallback and autocomplete)
+-- Adds: events.search_vector (weighted tsvector over name, artist, organizer, venue, description)
+-- Adds: events.search_text (plain text of the same fields for trigram matching)
+-- Adds: triggers keeping both columns current when an event, its tour or its organizer changes
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- ─── events ───────────────────────────────────────────────────────────────────
+
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS search_vector TSVECTOR,
+    ADD COLUMN IF NOT EXISTS search_text TEXT;
+
+COMMENT ON COLUMN events.search_vector IS 'Maintained by trigger. Weights: A name/artist, B organizer/venue/city, C description';
+COMMENT ON COLUMN events.search_text IS 'Maintained by trigger. Lower-cased searchable text for trigram similarity';
+
+-- ─── search document ──────────────────────────────────────────────────────────
+
+-- The 'simple' configuration is used throughout: names of artists, venues and
+-- Nigerian cities should not be stemmed as English words.
+CREATE OR REPLACE FUNCTION events_search_document() RETURNS TRIGGER AS $$
+DECLARE
+    v_artist TEXT;
+    v_tour TEXT;
+    v_organizer TEXT;
+BEGIN
+    SELECT t.artist_name, t.name INTO v_artist, v_tour FROM tours t WHERE t.id = NEW.tour_id;
+    SELECT o.name INTO v_organizer FROM organizers o WHERE o.id = NEW.organizer_id;
+
+    NEW.search_vector :=
+        setweight(to_tsvector('simple', coalesce(NEW.name, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(v_artist, '')), 'A') ||
+        setweight(to_tsvector('simple', coalesce(v_tour, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(v_organizer, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(NEW.venue_name, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(NEW.venue_city, '')), 'B') ||
+        setweight(to_tsvector('simple', coalesce(NEW.description, '')), 'C');
+
+    NEW.search_text := lower(concat_ws(' ', NEW.name, v_artist, v_tour, v_organizer, NEW.venue_name, NEW.venue_city));
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_events_search_document ON events;
+CREATE TRIGGER trg_events_search_document
+    BEFORE INSERT OR UPDATE OF name, description, venue_name, venue_city, tour_id, organizer_id
+    ON events
+    FOR EACH ROW EXECUTE FUNCTION events_search_document();
+
+-- Renaming an artist, tour or organizer re-indexes their events. The no-op
+-- update fires the events trigger above, which rebuilds the document.
+CREATE OR REPLACE FUNCTION events_search_refresh_from_tour() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE events SET tour_id = tour_id WHERE tour_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_tours_search_refresh ON tours;
+CREATE TRIGGER trg_tours_search_refresh
+    AFTER UPDATE OF name, artist_name ON tours
+    FOR EACH ROW
+    WHEN (OLD.name IS DISTINCT FROM NEW.name OR OLD.artist_name IS DISTINCT FROM NEW.artist_name)
+    EXECUTE FUNCTION events_search_refresh_from_tour();
+
+CREATE OR REPLACE FUNCTION events_search_refresh_from_organizer() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE events SET organizer_id = organizer_id WHERE organizer_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_organizers_search_refresh ON organizers;
+CREATE TRIGGER trg_organizers_search_refresh
+    AFTER UPDATE OF name ON organizers
+    FOR EACH ROW
+    WHEN (OLD.name IS DISTINCT FROM NEW.name)
+    EXECUTE FUNCTION events_search_refresh_from_organizer();
+
+-- Backfill existing events
+UPDATE events SET name = name;
+
+-- ─── indexes ──────────────────────────────────────────────────────────────────
+
+CREATE INDEX IF NOT EXISTS idx_events_search_vector ON events USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_events_search_text_trgm ON events USING GIN (search_text gin_trgm_ops);
+
+-- Autocomplete over event names, artists and venues
+CREATE INDEX IF NOT EXISTS idx_events_name_trgm ON events USING GIN (lower(name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_events_venue_name_trgm ON events USING GIN (lower(venue_name) gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_tours_artist_name_trgm ON tours USING GIN (lower(artist_name) gin_trgm_ops);
+
+-- Price ranges for price-band facets and filters
+CREATE INDEX IF NOT EXISTS idx_ticket_tiers_event_price ON ticket_tiers(event_id, price) WHERE is_active = true;
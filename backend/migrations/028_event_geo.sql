@@ -0,0 +1,90 @@
+-- Migration 028: Geo search for events
+-- Adds: venues (a place reused across events, with its coordinates)
+-- Adds: events.venue_id, linking an event to its venue
+-- Ensures: events.venue_latitude / venue_longitude exist (present in 001, unused until now)
+-- Adds: trigger copying a venue's coordinates onto its events, so nearby queries
+--       stay a single indexed scan of events
+
+-- ─── venues table ─────────────────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS venues (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name VARCHAR(255) NOT NULL,
+    address TEXT NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL,
+    state VARCHAR(100),
+    country VARCHAR(100) NOT NULL DEFAULT 'Nigeria',
+    latitude DECIMAL(10, 8),
+    longitude DECIMAL(11, 8),
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (latitude IS NULL OR latitude BETWEEN -90 AND 90),
+    CHECK (longitude IS NULL OR longitude BETWEEN -180 AND 180),
+    CHECK ((latitude IS NULL) = (longitude IS NULL))
+);
+
+-- One venue per name and city
+CREATE UNIQUE INDEX IF NOT EXISTS idx_venues_name_city ON venues(lower(name), lower(city));
+CREATE INDEX IF NOT EXISTS idx_venues_location ON venues(latitude, longitude) WHERE latitude IS NOT NULL;
+
+DROP TRIGGER IF EXISTS update_venues_updated_at ON venues;
+CREATE TRIGGER update_venues_updated_at BEFORE UPDATE ON venues FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON TABLE venues IS 'Places events are held; reused across events';
+
+-- ─── events ───────────────────────────────────────────────────────────────────
+
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS venue_id UUID REFERENCES venues(id) ON DELETE SET NULL,
+    ADD COLUMN IF NOT EXISTS venue_latitude DECIMAL(10, 8),
+    ADD COLUMN IF NOT EXISTS venue_longitude DECIMAL(11, 8);
+
+CREATE INDEX IF NOT EXISTS idx_events_venue_id ON events(venue_id);
+
+-- Bounding-box prefilter for nearby queries over public events
+CREATE INDEX IF NOT EXISTS idx_events_public_location ON events(venue_latitude, venue_longitude)
+    WHERE venue_latitude IS NOT NULL AND is_active = true AND status IN ('published', 'on_sale');
+
+COMMENT ON COLUMN events.venue_id IS 'Venue the event is held at; its coordinates are copied onto the event by trigger';
+
+-- ─── coordinate sync ──────────────────────────────────────────────────────────
+
+-- An event linked to a venue that has coordinates takes the venue's coordinates
+CREATE OR REPLACE FUNCTION events_copy_venue_location() RETURNS TRIGGER AS $$
+DECLARE
+    v_latitude DECIMAL(10, 8);
+    v_longitude DECIMAL(11, 8);
+BEGIN
+    IF NEW.venue_id IS NOT NULL THEN
+        SELECT v.latitude, v.longitude INTO v_latitude, v_longitude FROM venues v WHERE v.id = NEW.venue_id;
+        IF v_latitude IS NOT NULL THEN
+            NEW.venue_latitude := v_latitude;
+            NEW.venue_longitude := v_longitude;
+        END IF;
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_events_copy_venue_location ON events;
+CREATE TRIGGER trg_events_copy_venue_location
+    BEFORE INSERT OR UPDATE OF venue_id, venue_latitude, venue_longitude ON events
+    FOR EACH ROW EXECUTE FUNCTION events_copy_venue_location();
+
+-- Moving a venue moves its events
+CREATE OR REPLACE FUNCTION venues_push_location() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE events
+    SET venue_latitude = NEW.latitude, venue_longitude = NEW.longitude
+    WHERE venue_id = NEW.id;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TRIGGER IF EXISTS trg_venues_push_location ON venues;
+CREATE TRIGGER trg_venues_push_location
+    AFTER UPDATE OF latitude, longitude ON venues
+    FOR EACH ROW
+    WHEN (NEW.latitude IS NOT NULL AND (OLD.latitude IS DISTINCT FROM NEW.latitude OR OLD.longitude IS DISTINCT FROM NEW.longitude))
+    EXECUTE FUNCTION venues_push_location();
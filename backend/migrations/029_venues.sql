@@ -0,0 +1,70 @@
+-- Migration 029: Venue aggregate
+-- Adds: venues.capacity, timezone, description, accessibility, image_url, gallery_images
+-- Adds: venue_gates (named gates and entrances of a venue)
+-- Backfills: one venue per distinct event venue name + city, linked to its events
+-- The venue_* columns on events stay as a snapshot of the venue at the time the
+-- event was set up; tickets, emails and wallet passes read them.
+
+-- ─── venues ───────────────────────────────────────────────────────────────────
+
+ALTER TABLE venues
+    ADD COLUMN IF NOT EXISTS capacity INTEGER CHECK (capacity IS NULL OR capacity > 0),
+    ADD COLUMN IF NOT EXISTS timezone VARCHAR(64) NOT NULL DEFAULT 'Africa/Lagos',
+    ADD COLUMN IF NOT EXISTS description TEXT,
+    ADD COLUMN IF NOT EXISTS accessibility JSONB NOT NULL DEFAULT '{}',
+    ADD COLUMN IF NOT EXISTS image_url VARCHAR(500),
+    ADD COLUMN IF NOT EXISTS gallery_images JSONB NOT NULL DEFAULT '[]';
+
+CREATE INDEX IF NOT EXISTS idx_venues_city ON venues(lower(city)) WHERE is_active = true;
+
+COMMENT ON COLUMN venues.capacity IS 'Default capacity; total ticket tier quota of an event at the venue may not exceed it';
+COMMENT ON COLUMN venues.timezone IS 'IANA timezone of the venue, e.g. Africa/Lagos';
+COMMENT ON COLUMN venues.accessibility IS 'Accessibility facilities: wheelchair_accessible, step_free_access, accessible_toilets, hearing_loop, accessible_parking, notes';
+
+-- ─── venue_gates table ────────────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS venue_gates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    venue_id UUID NOT NULL REFERENCES venues(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    kind VARCHAR(20) NOT NULL DEFAULT 'gate' CHECK (kind IN ('gate', 'entrance')),
+    description TEXT,
+    is_accessible BOOLEAN NOT NULL DEFAULT false,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_venue_gates_name ON venue_gates(venue_id, lower(name));
+
+COMMENT ON TABLE venue_gates IS 'Gates and entrances of a venue, reused by every event held there';
+
+-- ─── backfill ─────────────────────────────────────────────────────────────────
+
+-- One venue per distinct name + city. Coordinates come from the most recently
+-- updated event that has them; capacity is the largest any event declared.
+INSERT INTO venues (name, address, city, state, country, latitude, longitude, capacity)
+SELECT name, address, city, state, country, latitude, longitude, capacity
+FROM (
+    SELECT DISTINCT ON (lower(trim(e.venue_name)), lower(trim(e.venue_city)))
+        trim(e.venue_name) AS name,
+        e.venue_address AS address,
+        trim(e.venue_city) AS city,
+        e.venue_state AS state,
+        COALESCE(NULLIF(e.venue_country, ''), 'Nigeria') AS country,
+        CASE WHEN e.venue_longitude IS NOT NULL THEN e.venue_latitude END AS latitude,
+        CASE WHEN e.venue_latitude IS NOT NULL THEN e.venue_longitude END AS longitude,
+        NULLIF(MAX(e.venue_capacity) OVER (PARTITION BY lower(trim(e.venue_name)), lower(trim(e.venue_city))), 0) AS capacity
+    FROM events e
+    WHERE trim(e.venue_name) <> '' AND trim(e.venue_city) <> ''
+    ORDER BY lower(trim(e.venue_name)), lower(trim(e.venue_city)),
+             (e.venue_latitude IS NULL OR e.venue_longitude IS NULL), e.updated_at DESC
+) AS distinct_venues
+ON CONFLICT (lower(name), lower(city)) DO NOTHING;
+
+UPDATE events e
+SET venue_id = v.id
+FROM venues v
+WHERE e.venue_id IS NULL
+  AND lower(trim(e.venue_name)) = lower(v.name)
+  AND lower(trim(e.venue_city)) = lower(v.city);
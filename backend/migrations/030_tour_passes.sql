@@ -0,0 +1,58 @@
+-- Migration 030: Tour passes
+-- Adds: tour_passes (one purchase admitting the holder to several events of a tour)
+-- Adds: tour_pass_events (the events a pass covers, and the ticket tier each admits to)
+-- Adds: tour_pass_orders (links a tour pass purchase to its order)
+-- A pass order carries one order line per covered event, so payment issues one
+-- ticket per event per pass and capacity is held on each event's tier.
+
+-- ─── tour_passes table ────────────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS tour_passes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    tour_id UUID NOT NULL REFERENCES tours(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    price DECIMAL(12, 2) NOT NULL CHECK (price >= 0),
+    currency VARCHAR(3) NOT NULL DEFAULT 'NGN',
+    quota INTEGER CHECK (quota IS NULL OR quota > 0),
+    max_per_order INTEGER NOT NULL DEFAULT 10 CHECK (max_per_order > 0),
+    sale_start TIMESTAMP WITH TIME ZONE,
+    sale_end TIMESTAMP WITH TIME ZONE,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (sale_start IS NULL OR sale_end IS NULL OR sale_start < sale_end)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tour_passes_tour ON tour_passes(tour_id);
+
+DROP TRIGGER IF EXISTS update_tour_passes_updated_at ON tour_passes;
+CREATE TRIGGER update_tour_passes_updated_at BEFORE UPDATE ON tour_passes FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN tour_passes.quota IS 'Passes for sale; NULL means limited only by the covered tiers';
+
+-- ─── tour_pass_events table ───────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS tour_pass_events (
+    tour_pass_id UUID NOT NULL REFERENCES tour_passes(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    ticket_tier_id UUID NOT NULL REFERENCES ticket_tiers(id) ON DELETE RESTRICT,
+    PRIMARY KEY (tour_pass_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_tour_pass_events_tier ON tour_pass_events(ticket_tier_id);
+
+COMMENT ON COLUMN tour_pass_events.ticket_tier_id IS 'Tier of the event pass holders are admitted under; its quota is shared with single-event sales';
+
+-- ─── tour_pass_orders table ───────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS tour_pass_orders (
+    order_id UUID PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    tour_pass_id UUID NOT NULL REFERENCES tour_passes(id) ON DELETE RESTRICT,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_tour_pass_orders_pass ON tour_pass_orders(tour_pass_id);
+
+COMMENT ON TABLE tour_pass_orders IS 'Orders that bought tour passes; the tickets of the order are the linked per-event admissions';
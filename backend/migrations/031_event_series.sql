@@ -0,0 +1,40 @@
+-- Migration 031: Recurring events
+-- Adds: event_series (a recurrence rule whose occurrences are real events)
+-- Adds: events.series_id (links an occurrence to its series)
+-- Occurrences are copied, with their ticket tiers, from the series template
+-- event, which is always one of the occurrences.
+
+-- ─── event_series table ───────────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS event_series (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organizer_id UUID NOT NULL REFERENCES organizers(id) ON DELETE CASCADE,
+    template_event_id UUID NOT NULL REFERENCES events(id) ON DELETE RESTRICT,
+    name VARCHAR(255) NOT NULL,
+    rrule TEXT NOT NULL,
+    timezone VARCHAR(64) NOT NULL DEFAULT 'Africa/Lagos',
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    exception_dates JSONB NOT NULL DEFAULT '[]',
+    horizon_days INTEGER NOT NULL DEFAULT 90 CHECK (horizon_days BETWEEN 1 AND 365),
+    generated_until TIMESTAMP WITH TIME ZONE,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_series_organizer ON event_series(organizer_id);
+
+DROP TRIGGER IF EXISTS update_event_series_updated_at ON event_series;
+CREATE TRIGGER update_event_series_updated_at BEFORE UPDATE ON event_series FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN event_series.rrule IS 'RFC 5545 RRULE subset: FREQ=WEEKLY|MONTHLY with INTERVAL, BYDAY, BYMONTHDAY, COUNT, UNTIL';
+COMMENT ON COLUMN event_series.template_event_id IS 'Occurrence new occurrences are copied from; moves forward when future occurrences are edited';
+COMMENT ON COLUMN event_series.exception_dates IS 'Local dates (YYYY-MM-DD) on which no occurrence is held';
+COMMENT ON COLUMN event_series.generated_until IS 'Occurrences have been generated up to this time';
+
+-- ─── events ───────────────────────────────────────────────────────────────────
+
+ALTER TABLE events
+    ADD COLUMN IF NOT EXISTS series_id UUID REFERENCES event_series(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_series ON events(series_id, event_date) WHERE series_id IS NOT NULL;
@@ -0,0 +1,25 @@
+-- Migration 032: Event templates
+-- Adds: event_templates (a saved event setup organizers create new events from)
+-- The template content holds the event details and ticket tiers, with times
+-- stored as minutes relative to the event start.
+
+-- ─── event_templates table ────────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS event_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organizer_id UUID NOT NULL REFERENCES organizers(id) ON DELETE CASCADE,
+    name VARCHAR(255) NOT NULL,
+    description TEXT,
+    content JSONB NOT NULL,
+    created_by UUID REFERENCES admin_users(id) ON DELETE SET NULL,
+    is_active BOOLEAN NOT NULL DEFAULT true,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_templates_organizer ON event_templates(organizer_id) WHERE is_active = true;
+
+DROP TRIGGER IF EXISTS update_event_templates_updated_at ON event_templates;
+CREATE TRIGGER update_event_templates_updated_at BEFORE UPDATE ON event_templates FOR EACH ROW EXECUTE FUNCTION update_updated_at_column();
+
+COMMENT ON COLUMN event_templates.content IS 'Event details and ticket tiers; times are minutes relative to the event start';
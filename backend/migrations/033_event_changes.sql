@@ -0,0 +1,78 @@
+-- Migration 033: Event cancellation and postponement
+-- Adds: event_changes (a cancellation or postponement and the progress of handling its holders)
+-- Adds: event_change_recipients (one row per paid order; each step records its own
+-- outcome, so a worker that stops partway resumes from the rows still pending)
+
+-- ─── event_changes table ──────────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS event_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('cancellation', 'postponement')),
+    reason TEXT,
+    message TEXT,
+    previous_date TIMESTAMP WITH TIME ZONE NOT NULL,
+    new_date TIMESTAMP WITH TIME ZONE,
+    void_tickets BOOLEAN NOT NULL DEFAULT false,
+    refund_policy VARCHAR(20) NOT NULL DEFAULT 'none'
+        CHECK (refund_policy IN ('none', 'automatic', 'choice')),
+    refund_deadline TIMESTAMP WITH TIME ZONE,
+    notify_email BOOLEAN NOT NULL DEFAULT true,
+    notify_sms BOOLEAN NOT NULL DEFAULT false,
+    status VARCHAR(30) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'processing', 'completed', 'completed_with_errors', 'failed')),
+    total_recipients INTEGER NOT NULL DEFAULT 0,
+    notified_recipients INTEGER NOT NULL DEFAULT 0,
+    failed_recipients INTEGER NOT NULL DEFAULT 0,
+    refunds_requested INTEGER NOT NULL DEFAULT 0,
+    refunds_completed INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_by UUID REFERENCES admin_users(id) ON DELETE SET NULL,
+    recipients_staged_at TIMESTAMP WITH TIME ZONE,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (type = 'postponement' OR new_date IS NULL),
+    CHECK (refund_policy = 'choice' OR refund_deadline IS NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_changes_event ON event_changes(event_id);
+CREATE INDEX IF NOT EXISTS idx_event_changes_unfinished
+    ON event_changes(created_at) WHERE status IN ('pending', 'processing');
+
+-- ─── event_change_recipients table ────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS event_change_recipients (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    change_id UUID NOT NULL REFERENCES event_changes(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id UUID,
+    name VARCHAR(255),
+    email VARCHAR(255),
+    phone VARCHAR(20),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'done', 'failed')),
+    tickets_voided BOOLEAN NOT NULL DEFAULT false,
+    email_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (email_status IN ('pending', 'sent', 'failed', 'skipped')),
+    sms_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (sms_status IN ('pending', 'sent', 'failed', 'skipped')),
+    refund_status VARCHAR(20) NOT NULL DEFAULT 'none'
+        CHECK (refund_status IN ('none', 'pending', 'refunded', 'failed')),
+    refund_choice VARCHAR(10) CHECK (refund_choice IN ('keep', 'refund')),
+    choice_token VARCHAR(64) NOT NULL UNIQUE,
+    chosen_at TIMESTAMP WITH TIME ZONE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    notified_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (change_id, order_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_change_recipients_pending
+    ON event_change_recipients(change_id, created_at) WHERE status = 'pending';
+
+COMMENT ON COLUMN event_change_recipients.choice_token IS 'Secret from the keep-or-refund link sent to the holder.';
+COMMENT ON COLUMN event_change_recipients.status IS 'failed = a step still failed after the retry limit; see last_error and the per-step statuses.';
@@ -0,0 +1,42 @@
+-- Migration 034: Per-tier sale sequencing and scheduled price changes
+-- Adds: ticket_tiers.opens_after_tier_id (a tier stays upcoming until the tier it
+-- opens after sells out or its sale ends, e.g. regular after early bird; the
+-- reference is deferred so an event and its tiers can be created in any order)
+-- Adds: ticket_tier_price_changes (scheduled prices; applied rows are the tier's price history)
+
+-- ─── ticket_tiers sequencing ──────────────────────────────────────────────────
+
+ALTER TABLE ticket_tiers
+    ADD COLUMN IF NOT EXISTS opens_after_tier_id UUID REFERENCES ticket_tiers(id) ON DELETE SET NULL DEFERRABLE INITIALLY DEFERRED;
+
+ALTER TABLE ticket_tiers DROP CONSTRAINT IF EXISTS ticket_tiers_opens_after_self_check;
+ALTER TABLE ticket_tiers ADD CONSTRAINT ticket_tiers_opens_after_self_check
+    CHECK (opens_after_tier_id IS NULL OR opens_after_tier_id <> id);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_tiers_opens_after
+    ON ticket_tiers(opens_after_tier_id) WHERE opens_after_tier_id IS NOT NULL;
+
+-- ─── ticket_tier_price_changes table ──────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS ticket_tier_price_changes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    ticket_tier_id UUID NOT NULL REFERENCES ticket_tiers(id) ON DELETE CASCADE,
+    price DECIMAL(10, 2) NOT NULL CHECK (price >= 0),
+    previous_price DECIMAL(10, 2),
+    effective_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled'
+        CHECK (status IN ('scheduled', 'applied', 'cancelled')),
+    reason TEXT,
+    created_by UUID REFERENCES admin_users(id) ON DELETE SET NULL,
+    applied_at TIMESTAMP WITH TIME ZONE,
+    cancelled_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_tier_price_changes_tier
+    ON ticket_tier_price_changes(ticket_tier_id, effective_at DESC);
+CREATE INDEX IF NOT EXISTS idx_ticket_tier_price_changes_due
+    ON ticket_tier_price_changes(effective_at) WHERE status = 'scheduled';
+
+COMMENT ON COLUMN ticket_tier_price_changes.previous_price IS 'Tier price just before the change was applied.';
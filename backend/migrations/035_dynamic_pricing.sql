@@ -0,0 +1,16 @@
+-- Migration 035: Dynamic demand-based pricing for ticket tiers
+-- Adds: ticket_tiers.pricing_strategy (inventory steps or a time-to-event curve,
+-- with an optional floor and ceiling; the tier price is the base price)
+-- Adds: order_lines.base_price / quoted_price / quote_expires_at (the price quoted
+-- at hold time, locked until the inventory hold expires)
+
+ALTER TABLE ticket_tiers
+    ADD COLUMN IF NOT EXISTS pricing_strategy JSONB;
+
+ALTER TABLE order_lines
+    ADD COLUMN IF NOT EXISTS base_price DECIMAL(10, 2),
+    ADD COLUMN IF NOT EXISTS quoted_price DECIMAL(10, 2),
+    ADD COLUMN IF NOT EXISTS quote_expires_at TIMESTAMP WITH TIME ZONE;
+
+COMMENT ON COLUMN ticket_tiers.pricing_strategy IS 'Dynamic pricing: {"type": "inventory"|"time", "steps"|"curve": [...], "floor", "ceiling"}; NULL is a fixed price.';
+COMMENT ON COLUMN order_lines.base_price IS 'Tier price when the line was quoted; unit_price is the quoted price charged.';
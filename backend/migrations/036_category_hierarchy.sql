@@ -0,0 +1,23 @@
+-- Migration 036: Nested event categories
+-- Adds: categories.parent_id (subcategories; deleting a parent is refused by the
+-- application while it has subcategories, the SET NULL only guards direct SQL)
+-- Changes: category names are unique among siblings instead of globally, so
+-- "Other" can appear under several parents; slugs stay globally unique
+
+ALTER TABLE categories
+    ADD COLUMN IF NOT EXISTS parent_id UUID REFERENCES categories(id) ON DELETE SET NULL;
+
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_parent_self_check;
+ALTER TABLE categories ADD CONSTRAINT categories_parent_self_check
+    CHECK (parent_id IS NULL OR parent_id <> id);
+
+ALTER TABLE categories DROP CONSTRAINT IF EXISTS categories_name_key;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_categories_sibling_name
+    ON categories(COALESCE(parent_id, '00000000-0000-0000-0000-000000000000'::uuid), lower(name));
+
+CREATE INDEX IF NOT EXISTS idx_categories_parent ON categories(parent_id, display_order);
+
+UPDATE categories SET display_order = 0 WHERE display_order IS NULL;
+ALTER TABLE categories ALTER COLUMN display_order SET NOT NULL;
+UPDATE categories SET is_active = true WHERE is_active IS NULL;
+ALTER TABLE categories ALTER COLUMN is_active SET NOT NULL;
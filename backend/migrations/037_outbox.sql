@@ -0,0 +1,34 @@
+-- Migration 037: Transactional outbox
+-- Adds: outbox_messages (side effects such as emails, written in the same
+-- transaction as the change that causes them and delivered by the dispatcher
+-- with exponential backoff; messages out of attempts are dead-lettered until
+-- an admin retries them)
+
+-- ─── outbox_messages table ────────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS outbox_messages (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    topic VARCHAR(100) NOT NULL,
+    aggregate_type VARCHAR(50),
+    aggregate_id UUID,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'delivered', 'dead')),
+    attempts INTEGER NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    max_attempts INTEGER NOT NULL DEFAULT 10 CHECK (max_attempts > 0),
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    locked_until TIMESTAMP WITH TIME ZONE,
+    last_error TEXT,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    dead_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The dispatcher only ever scans pending messages
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_due
+    ON outbox_messages(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_status ON outbox_messages(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_aggregate
+    ON outbox_messages(aggregate_id) WHERE aggregate_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_outbox_messages_topic ON outbox_messages(topic, created_at DESC);
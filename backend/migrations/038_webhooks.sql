@@ -0,0 +1,58 @@
+-- Migration 038: Organizer webhooks
+-- Adds: webhook_subscriptions (an organizer's URL, signing secret and event
+-- types; disabled automatically after too many failures in a row) and
+-- webhook_deliveries (one row per event sent to a subscription, kept as the
+-- delivery log and used for manual redelivery)
+
+-- ─── webhook_subscriptions table ──────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS webhook_subscriptions (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organizer_id UUID NOT NULL REFERENCES organizers(id) ON DELETE CASCADE,
+    url TEXT NOT NULL,
+    description TEXT,
+    secret VARCHAR(100) NOT NULL,
+    event_types JSONB NOT NULL DEFAULT '[]',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    consecutive_failures INTEGER NOT NULL DEFAULT 0 CHECK (consecutive_failures >= 0),
+    disabled_at TIMESTAMP WITH TIME ZONE,
+    disabled_reason TEXT,
+    last_success_at TIMESTAMP WITH TIME ZONE,
+    last_failure_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_organizer
+    ON webhook_subscriptions(organizer_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_webhook_subscriptions_active
+    ON webhook_subscriptions(organizer_id) WHERE is_active;
+
+-- ─── webhook_deliveries table ─────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    subscription_id UUID NOT NULL REFERENCES webhook_subscriptions(id) ON DELETE CASCADE,
+    event_type VARCHAR(50) NOT NULL,
+    aggregate_id UUID NOT NULL,
+    payload JSONB NOT NULL DEFAULT '{}',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'succeeded', 'failed')),
+    attempts INTEGER NOT NULL DEFAULT 0 CHECK (attempts >= 0),
+    last_status_code INTEGER,
+    last_error TEXT,
+    last_response TEXT,
+    last_attempt_at TIMESTAMP WITH TIME ZONE,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    -- Each event happens once per aggregate (an order is paid once, a ticket
+    -- voided once), so a retried fan-out never delivers the same event twice
+    CONSTRAINT webhook_deliveries_event_unique UNIQUE (subscription_id, event_type, aggregate_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_subscription
+    ON webhook_deliveries(subscription_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_status
+    ON webhook_deliveries(subscription_id, status);
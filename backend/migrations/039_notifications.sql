@@ -0,0 +1,38 @@
+-- Migration 039: Notification messages
+-- Adds: notification_messages (every SMS handed to a provider, with its
+-- delivery state as reported by the provider's delivery receipts; also used
+-- to rate limit messages per phone number)
+
+-- ─── notification_messages table ──────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS notification_messages (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    channel VARCHAR(20) NOT NULL,
+    recipient VARCHAR(50) NOT NULL,
+    sender_id VARCHAR(20),
+    template VARCHAR(50),
+    body TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued'
+        CHECK (status IN ('queued', 'sent', 'delivered', 'failed')),
+    provider VARCHAR(30),
+    provider_message_id VARCHAR(100),
+    last_error TEXT,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    delivered_at TIMESTAMP WITH TIME ZONE,
+    failed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Per-number rate limiting counts recent messages to one recipient
+CREATE INDEX IF NOT EXISTS idx_notification_messages_recipient
+    ON notification_messages(channel, recipient, created_at DESC);
+-- Delivery receipts identify messages by the provider's ID
+CREATE UNIQUE INDEX IF NOT EXISTS idx_notification_messages_provider_id
+    ON notification_messages(provider, provider_message_id) WHERE provider_message_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_notification_messages_status
+    ON notification_messages(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notification_messages_order
+    ON notification_messages(order_id) WHERE order_id IS NOT NULL;
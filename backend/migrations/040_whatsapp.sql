@@ -0,0 +1,23 @@
+-- Migration 040: WhatsApp ticket delivery
+-- Adds: whatsapp_opt_ins (per-user consent to WhatsApp messages; tickets are
+-- only sent on WhatsApp to users who opted in). WhatsApp messages share the
+-- notification_messages log with SMS.
+
+-- ─── whatsapp_opt_ins table ───────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS whatsapp_opt_ins (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    phone VARCHAR(50) NOT NULL,
+    opted_in BOOLEAN NOT NULL DEFAULT FALSE,
+    source VARCHAR(30) NOT NULL DEFAULT '',
+    opted_in_at TIMESTAMP WITH TIME ZONE,
+    opted_out_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_whatsapp_opt_ins_phone ON whatsapp_opt_ins(phone);
+
+-- Ticket deliveries look up earlier messages for an order by template
+CREATE INDEX IF NOT EXISTS idx_notification_messages_order_template
+    ON notification_messages(order_id, channel, template) WHERE order_id IS NOT NULL;
@@ -0,0 +1,48 @@
+-- Migration 041: Message templates and organizer branding
+-- Adds: message_templates (versioned overrides of the built-in email, SMS and
+-- WhatsApp templates, for every organizer or for one; only the active version
+-- of a key, channel, locale and organizer is used) and organizer_brandings
+-- (name, logo, colour and footer of an organizer's messages).
+-- The preferred locale of a user is kept in users.settings->>'locale'.
+
+-- ─── message_templates table ──────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS message_templates (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    key VARCHAR(100) NOT NULL,
+    channel VARCHAR(20) NOT NULL CHECK (channel IN ('email', 'sms', 'whatsapp')),
+    locale VARCHAR(10) NOT NULL CHECK (locale IN ('en', 'yo', 'ha', 'ig', 'fr')),
+    organizer_id UUID REFERENCES organizers(id) ON DELETE CASCADE,
+    version INTEGER NOT NULL CHECK (version > 0),
+    subject VARCHAR(255) NOT NULL DEFAULT '',
+    html_body TEXT NOT NULL DEFAULT '',
+    text_body TEXT NOT NULL DEFAULT '',
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by UUID REFERENCES admin_users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Version numbers are per scope; organizer_id is NULL for templates shared by every organizer
+CREATE UNIQUE INDEX IF NOT EXISTS idx_message_templates_version
+    ON message_templates(key, channel, locale, COALESCE(organizer_id, '00000000-0000-0000-0000-000000000000'::uuid), version);
+
+-- At most one active version per scope
+CREATE UNIQUE INDEX IF NOT EXISTS idx_message_templates_active
+    ON message_templates(key, channel, locale, COALESCE(organizer_id, '00000000-0000-0000-0000-000000000000'::uuid))
+    WHERE is_active;
+
+CREATE INDEX IF NOT EXISTS idx_message_templates_organizer ON message_templates(organizer_id) WHERE organizer_id IS NOT NULL;
+
+-- ─── organizer_brandings table ────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS organizer_brandings (
+    organizer_id UUID PRIMARY KEY REFERENCES organizers(id) ON DELETE CASCADE,
+    display_name VARCHAR(100) NOT NULL,
+    logo_url TEXT,
+    primary_color VARCHAR(7) NOT NULL DEFAULT '#667eea',
+    footer_text VARCHAR(500) NOT NULL DEFAULT '',
+    support_email VARCHAR(255),
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
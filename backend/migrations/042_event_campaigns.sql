@@ -0,0 +1,83 @@
+-- Migration 042: Event reminder and follow-up campaigns
+-- Adds: event_campaigns (a reminder or follow-up sent at an offset from the
+-- event's start; the send time is computed from events.event_date, so a
+-- postponed event moves its campaigns along)
+-- Adds: campaign_deliveries (one row per ticket holder, de-duplicated by
+-- account, email or phone; a worker that stops partway resumes from the rows
+-- still pending)
+-- Adds: campaign_unsubscribes (contacts who opted out of one campaign type or all)
+-- The preferred channel of a user is kept in users.settings->>'notification_channel'.
+
+-- ─── event_campaigns table ────────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS event_campaigns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('reminder', 'follow_up')),
+    offset_minutes INTEGER NOT NULL CHECK (offset_minutes BETWEEN 0 AND 43200),
+    message TEXT,
+    survey_url TEXT,
+    status VARCHAR(30) NOT NULL DEFAULT 'scheduled'
+        CHECK (status IN ('scheduled', 'processing', 'completed', 'completed_with_errors',
+                          'failed', 'cancelled', 'expired')),
+    total_recipients INTEGER NOT NULL DEFAULT 0,
+    sent_count INTEGER NOT NULL DEFAULT 0,
+    skipped_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_by UUID REFERENCES admin_users(id) ON DELETE SET NULL,
+    recipients_staged_at TIMESTAMP WITH TIME ZONE,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    CHECK (type = 'follow_up' OR survey_url IS NULL)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_campaigns_event ON event_campaigns(event_id);
+CREATE INDEX IF NOT EXISTS idx_event_campaigns_open
+    ON event_campaigns(status, updated_at) WHERE status IN ('scheduled', 'processing', 'failed');
+CREATE UNIQUE INDEX IF NOT EXISTS idx_event_campaigns_offset
+    ON event_campaigns(event_id, type, offset_minutes) WHERE status <> 'cancelled';
+
+-- ─── campaign_deliveries table ────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS campaign_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    campaign_id UUID NOT NULL REFERENCES event_campaigns(id) ON DELETE CASCADE,
+    order_id UUID NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id UUID,
+    contact_key VARCHAR(300) NOT NULL,
+    name VARCHAR(255),
+    email VARCHAR(255),
+    phone VARCHAR(20),
+    channel VARCHAR(20) CHECK (channel IN ('email', 'sms', 'whatsapp')),
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'sent', 'skipped', 'failed')),
+    skip_reason VARCHAR(30),
+    unsubscribe_token VARCHAR(64) NOT NULL UNIQUE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (campaign_id, contact_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_campaign_deliveries_pending
+    ON campaign_deliveries(campaign_id, created_at) WHERE status = 'pending';
+
+COMMENT ON COLUMN campaign_deliveries.contact_key IS 'user:<id> for customers with an account, else email:<address> or phone:<number>; one delivery per holder.';
+COMMENT ON COLUMN campaign_deliveries.unsubscribe_token IS 'Secret from the unsubscribe link sent with the message.';
+
+-- ─── campaign_unsubscribes table ──────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS campaign_unsubscribes (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    contact_key VARCHAR(300) NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    campaign_type VARCHAR(20) NOT NULL CHECK (campaign_type IN ('all', 'reminder', 'follow_up')),
+    source VARCHAR(20) NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (contact_key, campaign_type)
+);
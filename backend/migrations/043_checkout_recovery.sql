@@ -0,0 +1,49 @@
+-- Migration 043: Abandoned checkout recovery
+-- Adds: checkout_recoveries (one row per unpaid order that expired or whose
+-- payment could not be started; the message links back to a page that places
+-- the same order again, and the row records whether that order was paid)
+-- A customer gets at most one recovery message per event within the throttle
+-- window; later abandoned orders in the window are not recorded.
+
+-- ─── checkout_recoveries table ────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS checkout_recoveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    order_id UUID NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+    event_id UUID NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    contact_key VARCHAR(300) NOT NULL,
+    name VARCHAR(255),
+    email VARCHAR(255),
+    phone VARCHAR(20),
+    reason VARCHAR(20) NOT NULL CHECK (reason IN ('order_expired', 'payment_failed')),
+    amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT 'NGN',
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'sent', 'skipped', 'failed')),
+    skip_reason VARCHAR(30),
+    channel VARCHAR(20) CHECK (channel IN ('email', 'sms', 'whatsapp')),
+    token VARCHAR(64) NOT NULL UNIQUE,
+    send_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    clicked_at TIMESTAMP WITH TIME ZONE,
+    recovered_order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    converted_at TIMESTAMP WITH TIME ZONE,
+    recovered_amount DECIMAL(12, 2) NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_checkout_recoveries_due
+    ON checkout_recoveries(send_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_checkout_recoveries_contact
+    ON checkout_recoveries(event_id, contact_key, created_at);
+CREATE INDEX IF NOT EXISTS idx_checkout_recoveries_created ON checkout_recoveries(created_at);
+CREATE UNIQUE INDEX IF NOT EXISTS idx_checkout_recoveries_recovered_order
+    ON checkout_recoveries(recovered_order_id) WHERE recovered_order_id IS NOT NULL;
+
+COMMENT ON COLUMN checkout_recoveries.contact_key IS 'user:<id> for customers with an account, else email:<address> or phone:<number>; used for throttling.';
+COMMENT ON COLUMN checkout_recoveries.token IS 'Secret from the link sent with the recovery message.';
+COMMENT ON COLUMN checkout_recoveries.recovered_order_id IS 'Latest order placed from the link; a conversion is credited when it is paid.';
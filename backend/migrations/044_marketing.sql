@@ -0,0 +1,135 @@
+-- Migration 044: Marketing consent, audiences and campaigns
+-- Adds: marketing_consents (a contact's current choice to receive marketing
+-- from one organizer, or from the platform when organizer_id is NULL)
+-- Adds: marketing_consent_records (every consent choice as it was made, with
+-- its source, wording version, IP address and user agent; the audit trail
+-- kept for NDPR and never updated)
+-- Adds: marketing_audiences (saved segments of past buyers, by event, tier,
+-- city, purchase date and total spend)
+-- Adds: marketing_campaigns and marketing_deliveries (emails to an audience's
+-- consenting members, one delivery per member, each with an unsubscribe link)
+
+-- ─── marketing_consent_records table ──────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS marketing_consent_records (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    contact_key VARCHAR(300) NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE SET NULL,
+    email VARCHAR(255),
+    organizer_id UUID REFERENCES organizers(id) ON DELETE CASCADE,
+    granted BOOLEAN NOT NULL,
+    source VARCHAR(30) NOT NULL CHECK (source IN ('checkout', 'settings', 'unsubscribe_link')),
+    policy_version VARCHAR(50) NOT NULL,
+    ip_address VARCHAR(45),
+    user_agent TEXT,
+    order_id UUID REFERENCES orders(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_marketing_consent_records_contact
+    ON marketing_consent_records(contact_key, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_marketing_consent_records_user
+    ON marketing_consent_records(user_id, created_at DESC) WHERE user_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_marketing_consent_records_email
+    ON marketing_consent_records(email, created_at DESC) WHERE email IS NOT NULL;
+
+COMMENT ON TABLE marketing_consent_records IS 'Append-only audit trail of marketing consent choices.';
+COMMENT ON COLUMN marketing_consent_records.policy_version IS 'Version of the consent wording the contact was shown.';
+
+-- ─── marketing_consents table ─────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS marketing_consents (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    contact_key VARCHAR(300) NOT NULL,
+    user_id UUID REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255),
+    organizer_id UUID REFERENCES organizers(id) ON DELETE CASCADE,
+    granted BOOLEAN NOT NULL,
+    source VARCHAR(30) NOT NULL,
+    policy_version VARCHAR(50) NOT NULL,
+    granted_at TIMESTAMP WITH TIME ZONE,
+    withdrawn_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- One current choice per contact and organizer; platform-wide choices have no organizer
+CREATE UNIQUE INDEX IF NOT EXISTS idx_marketing_consents_scope
+    ON marketing_consents(contact_key, (COALESCE(organizer_id, '00000000-0000-0000-0000-000000000000'::uuid)));
+CREATE INDEX IF NOT EXISTS idx_marketing_consents_email
+    ON marketing_consents(email) WHERE email IS NOT NULL;
+
+COMMENT ON COLUMN marketing_consents.contact_key IS 'user:<id> for customers with an account, else email:<address>.';
+
+-- ─── marketing_audiences table ────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS marketing_audiences (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organizer_id UUID REFERENCES organizers(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    criteria JSONB NOT NULL DEFAULT '{}',
+    created_by UUID REFERENCES admin_users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_marketing_audiences_organizer ON marketing_audiences(organizer_id);
+
+-- ─── marketing_campaigns table ────────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS marketing_campaigns (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    organizer_id UUID REFERENCES organizers(id) ON DELETE CASCADE,
+    audience_id UUID NOT NULL REFERENCES marketing_audiences(id) ON DELETE RESTRICT,
+    name VARCHAR(100) NOT NULL,
+    subject VARCHAR(150) NOT NULL,
+    message TEXT NOT NULL,
+    link_url TEXT,
+    link_label VARCHAR(50),
+    status VARCHAR(30) NOT NULL DEFAULT 'scheduled'
+        CHECK (status IN ('scheduled', 'processing', 'completed', 'completed_with_errors',
+                          'failed', 'cancelled')),
+    send_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    total_recipients INTEGER NOT NULL DEFAULT 0,
+    sent_count INTEGER NOT NULL DEFAULT 0,
+    skipped_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    created_by UUID REFERENCES admin_users(id) ON DELETE SET NULL,
+    recipients_staged_at TIMESTAMP WITH TIME ZONE,
+    started_at TIMESTAMP WITH TIME ZONE,
+    completed_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_marketing_campaigns_organizer ON marketing_campaigns(organizer_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_marketing_campaigns_open
+    ON marketing_campaigns(status, send_at) WHERE status IN ('scheduled', 'processing', 'failed');
+
+-- ─── marketing_deliveries table ───────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS marketing_deliveries (
+    id UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    campaign_id UUID NOT NULL REFERENCES marketing_campaigns(id) ON DELETE CASCADE,
+    user_id UUID,
+    contact_key VARCHAR(300) NOT NULL,
+    name VARCHAR(255),
+    email VARCHAR(255) NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending'
+        CHECK (status IN ('pending', 'sent', 'skipped', 'failed')),
+    skip_reason VARCHAR(30),
+    unsubscribe_token VARCHAR(64) NOT NULL UNIQUE,
+    attempts INTEGER NOT NULL DEFAULT 0,
+    last_error TEXT,
+    sent_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    UNIQUE (campaign_id, contact_key)
+);
+
+CREATE INDEX IF NOT EXISTS idx_marketing_deliveries_pending
+    ON marketing_deliveries(campaign_id, created_at) WHERE status = 'pending';
+
+COMMENT ON COLUMN marketing_deliveries.unsubscribe_token IS 'Secret from the unsubscribe link sent with the email.';
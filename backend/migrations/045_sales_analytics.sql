@@ -0,0 +1,87 @@
+-- Migration 045: Sales analytics
+-- Adds: analytics_daily_sales and analytics_daily_tier_sales (materialized
+-- views rolling orders up per UTC day, so dashboards never scan every order)
+-- and analytics_refreshes (when the views were last refreshed).
+-- Refresh strategy: the API refreshes both views CONCURRENTLY every
+-- ANALYTICS_REFRESH_MINUTES (default 15) and on demand from
+-- POST /v1/admin/analytics/refresh, so reads are never blocked and sales
+-- figures lag by at most one interval. Distinct buyer counts cannot be rolled
+-- up from daily rows and are queried live from orders instead.
+-- Comp orders are left out: they are neither checkouts nor revenue.
+
+-- ─── analytics_daily_sales ───────────────────────────────────────────────────
+-- One row per day, event, payment method and currency. Orders are counted on
+-- the day they were placed (orders_created) and again, if paid, on the day
+-- they were paid (paid_orders, tickets_sold, revenue).
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS analytics_daily_sales AS
+SELECT facts.day,
+       facts.event_id,
+       facts.payment_method,
+       facts.currency,
+       SUM(facts.orders_created)::INTEGER AS orders_created,
+       SUM(facts.paid_orders)::INTEGER AS paid_orders,
+       SUM(facts.tickets_sold)::INTEGER AS tickets_sold,
+       SUM(facts.revenue)::DECIMAL(14, 2) AS revenue
+FROM (
+    SELECT (o.created_at AT TIME ZONE 'UTC')::DATE AS day,
+           o.event_id,
+           COALESCE(o.payment_method::TEXT, '') AS payment_method,
+           o.currency,
+           1 AS orders_created, 0 AS paid_orders, 0 AS tickets_sold, 0 AS revenue
+    FROM orders o
+    WHERE o.is_active = true AND o.is_comp = false
+    UNION ALL
+    SELECT (COALESCE(o.paid_at, o.created_at) AT TIME ZONE 'UTC')::DATE AS day,
+           o.event_id,
+           COALESCE(o.payment_method::TEXT, '') AS payment_method,
+           o.currency,
+           0, 1, COALESCE(lines.quantity, 0), o.total_amount
+    FROM orders o
+    LEFT JOIN (
+        SELECT order_id, SUM(quantity) AS quantity FROM order_lines GROUP BY order_id
+    ) lines ON lines.order_id = o.id
+    WHERE o.is_active = true AND o.is_comp = false AND o.status::TEXT IN ('paid', 'confirmed')
+) facts
+GROUP BY facts.day, facts.event_id, facts.payment_method, facts.currency;
+
+-- REFRESH ... CONCURRENTLY needs a unique index covering every row
+CREATE UNIQUE INDEX IF NOT EXISTS idx_analytics_daily_sales_key
+    ON analytics_daily_sales(day, event_id, payment_method, currency);
+CREATE INDEX IF NOT EXISTS idx_analytics_daily_sales_event ON analytics_daily_sales(event_id, day);
+
+-- ─── analytics_daily_tier_sales ──────────────────────────────────────────────
+-- One row per day, ticket tier and currency for paid orders. Revenue is the
+-- line total after fees, taxes and discounts.
+
+CREATE MATERIALIZED VIEW IF NOT EXISTS analytics_daily_tier_sales AS
+SELECT (COALESCE(o.paid_at, o.created_at) AT TIME ZONE 'UTC')::DATE AS day,
+       o.event_id,
+       ol.ticket_tier_id,
+       o.currency,
+       COUNT(DISTINCT o.id)::INTEGER AS paid_orders,
+       SUM(ol.quantity)::INTEGER AS tickets_sold,
+       SUM(ol.subtotal + ol.fees + ol.taxes - ol.discount_amount)::DECIMAL(14, 2) AS revenue
+FROM orders o
+JOIN order_lines ol ON ol.order_id = o.id
+WHERE o.is_active = true AND o.is_comp = false AND o.status::TEXT IN ('paid', 'confirmed')
+GROUP BY 1, o.event_id, ol.ticket_tier_id, o.currency;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_analytics_daily_tier_sales_key
+    ON analytics_daily_tier_sales(day, ticket_tier_id, currency);
+CREATE INDEX IF NOT EXISTS idx_analytics_daily_tier_sales_event ON analytics_daily_tier_sales(event_id, day);
+
+-- ─── analytics_refreshes table ───────────────────────────────────────────────
+
+CREATE TABLE IF NOT EXISTS analytics_refreshes (
+    name VARCHAR(50) PRIMARY KEY,
+    refreshed_at TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- The views were populated when they were created
+INSERT INTO analytics_refreshes (name, refreshed_at) VALUES ('sales', NOW())
+ON CONFLICT (name) DO NOTHING;
+
+COMMENT ON MATERIALIZED VIEW analytics_daily_sales IS 'Orders placed and paid per UTC day, event, payment method and currency; refreshed by the API.';
+COMMENT ON MATERIALIZED VIEW analytics_daily_tier_sales IS 'Paid tickets and revenue per UTC day, ticket tier and currency; refreshed by the API.';
+COMMENT ON COLUMN analytics_refreshes.refreshed_at IS 'When the last refresh started; the views hold every order committed before it.';
@@ -0,0 +1,84 @@
+-- Migration 046: Imported orders
+-- Adds: orders.is_imported (orders created by a ticket import are 'paid' so
+-- their tickets scan, but were paid for on another platform)
+-- Rebuilds: analytics_daily_sales and analytics_daily_tier_sales to leave
+-- imported orders out, like comps.
+
+-- ─── orders ───────────────────────────────────────────────────────────────────
+
+ALTER TABLE orders
+    ADD COLUMN IF NOT EXISTS is_imported BOOLEAN NOT NULL DEFAULT false;
+
+UPDATE orders SET is_imported = true
+WHERE id IN (SELECT order_id FROM ticket_import_rows WHERE order_id IS NOT NULL);
+
+CREATE INDEX IF NOT EXISTS idx_orders_is_imported ON orders(event_id) WHERE is_imported = true;
+
+COMMENT ON COLUMN orders.is_imported IS 'Order created by a ticket import; paid on another platform. Excluded from revenue, counted against capacity.';
+
+-- ─── analytics_daily_sales ───────────────────────────────────────────────────
+
+DROP MATERIALIZED VIEW IF EXISTS analytics_daily_sales;
+
+CREATE MATERIALIZED VIEW analytics_daily_sales AS
+SELECT facts.day,
+       facts.event_id,
+       facts.payment_method,
+       facts.currency,
+       SUM(facts.orders_created)::INTEGER AS orders_created,
+       SUM(facts.paid_orders)::INTEGER AS paid_orders,
+       SUM(facts.tickets_sold)::INTEGER AS tickets_sold,
+       SUM(facts.revenue)::DECIMAL(14, 2) AS revenue
+FROM (
+    SELECT (o.created_at AT TIME ZONE 'UTC')::DATE AS day,
+           o.event_id,
+           COALESCE(o.payment_method::TEXT, '') AS payment_method,
+           o.currency,
+           1 AS orders_created, 0 AS paid_orders, 0 AS tickets_sold, 0 AS revenue
+    FROM orders o
+    WHERE o.is_active = true AND o.is_comp = false AND o.is_imported = false
+    UNION ALL
+    SELECT (COALESCE(o.paid_at, o.created_at) AT TIME ZONE 'UTC')::DATE AS day,
+           o.event_id,
+           COALESCE(o.payment_method::TEXT, '') AS payment_method,
+           o.currency,
+           0, 1, COALESCE(lines.quantity, 0), o.total_amount
+    FROM orders o
+    LEFT JOIN (
+        SELECT order_id, SUM(quantity) AS quantity FROM order_lines GROUP BY order_id
+    ) lines ON lines.order_id = o.id
+    WHERE o.is_active = true AND o.is_comp = false AND o.is_imported = false
+      AND o.status::TEXT IN ('paid', 'confirmed')
+) facts
+GROUP BY facts.day, facts.event_id, facts.payment_method, facts.currency;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_analytics_daily_sales_key
+    ON analytics_daily_sales(day, event_id, payment_method, currency);
+CREATE INDEX IF NOT EXISTS idx_analytics_daily_sales_event ON analytics_daily_sales(event_id, day);
+
+-- ─── analytics_daily_tier_sales ──────────────────────────────────────────────
+
+DROP MATERIALIZED VIEW IF EXISTS analytics_daily_tier_sales;
+
+CREATE MATERIALIZED VIEW analytics_daily_tier_sales AS
+SELECT (COALESCE(o.paid_at, o.created_at) AT TIME ZONE 'UTC')::DATE AS day,
+       o.event_id,
+       ol.ticket_tier_id,
+       o.currency,
+       COUNT(DISTINCT o.id)::INTEGER AS paid_orders,
+       SUM(ol.quantity)::INTEGER AS tickets_sold,
+       SUM(ol.subtotal + ol.fees + ol.taxes - ol.discount_amount)::DECIMAL(14, 2) AS revenue
+FROM orders o
+JOIN order_lines ol ON ol.order_id = o.id
+WHERE o.is_active = true AND o.is_comp = false AND o.is_imported = false
+  AND o.status::TEXT IN ('paid', 'confirmed')
+GROUP BY 1, o.event_id, ol.ticket_tier_id, o.currency;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_analytics_daily_tier_sales_key
+    ON analytics_daily_tier_sales(day, ticket_tier_id, currency);
+CREATE INDEX IF NOT EXISTS idx_analytics_daily_tier_sales_event ON analytics_daily_tier_sales(event_id, day);
+
+UPDATE analytics_refreshes SET refreshed_at = NOW() WHERE name = 'sales';
+
+COMMENT ON MATERIALIZED VIEW analytics_daily_sales IS 'Orders placed and paid per UTC day, event, payment method and currency; refreshed by the API.';
+COMMENT ON MATERIALIZED VIEW analytics_daily_tier_sales IS 'Paid tickets and revenue per UTC day, ticket tier and currency; refreshed by the API.';
@@ -0,0 +1,7 @@
+-- Migration 047: Drop secrets from delivered outbox messages
+-- The dispatcher now removes password reset tokens from a message's payload
+-- once it is delivered; this clears the ones delivered before it did.
+
+UPDATE outbox_messages
+SET payload = payload - 'reset_token', updated_at = NOW()
+WHERE status = 'delivered' AND payload ? 'reset_token';
@@ -0,0 +1,8 @@
+-- Migration 048: Record each scanned redemption once
+-- The scanner session subscriber may be delivered the same redemption more
+-- than once; the valid scan of a ticket in a session is unique, so a retried
+-- delivery inserts nothing and the session statistics are not counted twice.
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_validations_redemption
+ON ticket_validations(ticket_id, session_id)
+WHERE validation_result = 'valid';